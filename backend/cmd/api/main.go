@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto"
 	"log"
 	"net/http"
 	"os"
@@ -10,13 +11,41 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-contrib/cors"
 	"github.com/redis/go-redis/v9"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/analytics"
+	"github.com/nfl-analytics/backend/internal/archive"
+	"github.com/nfl-analytics/backend/internal/assistant"
+	"github.com/nfl-analytics/backend/internal/audit"
 	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/cache"
 	"github.com/nfl-analytics/backend/internal/config"
 	"github.com/nfl-analytics/backend/internal/database"
 	"github.com/nfl-analytics/backend/internal/draft"
+	"github.com/nfl-analytics/backend/internal/espnbudget"
+	"github.com/nfl-analytics/backend/internal/eventbus"
+	"github.com/nfl-analytics/backend/internal/events"
 	"github.com/nfl-analytics/backend/internal/handlers"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/integrations/llm"
+	"github.com/nfl-analytics/backend/internal/integrations/platform"
+	"github.com/nfl-analytics/backend/internal/inactivity"
+	"github.com/nfl-analytics/backend/internal/loginguard"
+	"github.com/nfl-analytics/backend/internal/mail"
+	"github.com/nfl-analytics/backend/internal/maintenance"
+	"github.com/nfl-analytics/backend/internal/middleware"
+	"github.com/nfl-analytics/backend/internal/nflverse"
+	"github.com/nfl-analytics/backend/internal/objectstore"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/nfl-analytics/backend/internal/quota"
+	"github.com/nfl-analytics/backend/internal/redisaudit"
 	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/retention"
 	"github.com/nfl-analytics/backend/internal/services"
+	"github.com/nfl-analytics/backend/internal/stream"
+	"github.com/nfl-analytics/backend/internal/surge"
+	"github.com/nfl-analytics/backend/internal/voice"
+	"github.com/nfl-analytics/backend/internal/worker"
 )
 
 func main() {
@@ -69,6 +98,36 @@ func main() {
 		}
 	}
 
+	// Maintenance switch shared by every instance through Redis
+	maintenanceSwitch := maintenance.NewSwitch(
+		redisClient,
+		cfg.Maintenance.Enabled,
+		cfg.Maintenance.Message,
+		cfg.Maintenance.DraftGracePeriod,
+		cfg.Maintenance.RefreshInterval,
+	)
+	if cfg.Maintenance.Enabled {
+		log.Printf("Starting in maintenance mode")
+	}
+
+	// Draft-day surge mode turns on with enough drafts in progress
+	draftRepo := draft.NewPostgresRepository(db.DB)
+	surgeMode := surge.NewMode(redisClient,
+		func(ctx context.Context) (int, error) {
+			sessions, err := draftRepo.GetActiveSessions(ctx)
+			return len(sessions), err
+		},
+		surge.Options{
+			AutoThreshold:          cfg.Surge.AutoActiveDrafts,
+			RefreshInterval:        cfg.Surge.RefreshInterval,
+			CacheTTLMultiplier:     cfg.Surge.CacheTTLMultiplier,
+			JobIntervalMultiplier:  cfg.Surge.JobIntervalMultiplier,
+			DraftRateMultiplier:    cfg.Surge.DraftRateMultiplier,
+			MetricsSampleRate:      cfg.Surge.MetricsSampleRate,
+			SurgeMetricsSampleRate: cfg.Surge.SurgeMetricsSampleRate,
+		},
+	)
+
 	// DuckDB analytics temporarily disabled
 	// TODO: Re-enable when DuckDB Go driver supports Go 1.23
 
@@ -78,59 +137,594 @@ func main() {
 	leagueAuthRepo := repositories.NewPostgresLeagueAuthRepository(db)
 
 	// Initialize services
-	jwtManager := auth.NewJWTManager(
-		cfg.JWT.Secret,
-		cfg.JWT.AccessTokenExpiry,
-		cfg.JWT.RefreshTokenExpiry,
-	)
-	authService := services.NewAuthService(authRepo, userRepo, jwtManager)
-	userService := services.NewUserService(userRepo)
+	// Tokens are signed with the shared secret, or with a private key whose
+	// public half other services validate them with
+	var jwtManager *auth.JWTManager
+	if cfg.JWT.Algorithm == auth.AlgorithmHS256 {
+		jwtManager = auth.NewJWTManager(cfg.JWT.Secret, cfg.JWT.AccessTokenExpiry, cfg.JWT.RefreshTokenExpiry)
+	} else {
+		jwtManager, err = auth.NewAsymmetricJWTManager(cfg.JWT.Algorithm, []byte(cfg.JWT.PrivateKey),
+			cfg.JWT.AccessTokenExpiry, cfg.JWT.RefreshTokenExpiry)
+		if err != nil {
+			log.Fatalf("Invalid JWT signing key: %v", err)
+		}
+	}
+	previousPublicKeys := make(map[string]crypto.PublicKey, len(cfg.JWT.PreviousPublicKeys))
+	for kid, pem := range cfg.JWT.PreviousPublicKeys {
+		if previousPublicKeys[kid], err = auth.ParsePublicKeyPEM([]byte(pem)); err != nil {
+			log.Fatalf("Invalid previous JWT public key %q: %v", kid, err)
+		}
+	}
+	jwtManager.WithKeyID(cfg.JWT.KeyID).
+		WithPreviousKeys(cfg.JWT.PreviousKeys).
+		WithPreviousPublicKeys(previousPublicKeys).
+		WithRememberMe(cfg.JWT.RememberMeRefreshExpiry).
+		WithLeeway(cfg.JWT.Leeway).
+		WithIssuer(cfg.JWT.Issuer).
+		WithAudience(cfg.JWT.Audience).
+		WithValidationCache(cfg.JWT.ValidationCacheSize)
+	// Initialize credentials service with encryption key; production
+	// refuses to load the development default
+	if cfg.Encryption.Key == config.DevEncryptionKey {
+		log.Printf("WARNING: ENCRYPTION_KEY not set, using development default (%s profile)", cfg.App.Profile)
+	}
+	// The keys are kept in the environment or resolved from a file, AWS KMS
+	// or Vault, per SECRETS_PROVIDER
+	secretsCtx, cancelSecrets := context.WithTimeout(context.Background(), 30*time.Second)
+	encryptionKey, previousEncryptionKeys, err := config.ResolveEncryptionKeys(secretsCtx, cfg)
+	cancelSecrets()
+	if err != nil {
+		log.Fatalf("Failed to load encryption keys: %v", err)
+	}
+	// Values encrypted with a retired key in ENCRYPTION_PREVIOUS_KEYS are
+	// still read; cmd/reencrypt moves them to the current key
+	secretBox, err := auth.NewVersionedSecretBox(cfg.Encryption.KeyID, encryptionKey, previousEncryptionKeys)
+	if err != nil {
+		log.Fatalf("Failed to initialize encryption: %v", err)
+	}
+	credentialsService := services.NewCredentialsService(leagueAuthRepo, secretBox)
+
+	// TOTP two-factor; secrets are encrypted with the same key as credentials
+	twoFactorService := services.NewTwoFactorService(repositories.NewPostgresTwoFactorRepository(db), userRepo, secretBox, cfg.TwoFactor.Issuer)
+
+	// Passwords hashed with another algorithm or cost are rehashed when
+	// their users next sign in; new passwords found in data breaches are
+	// rejected
+	passwordManager := auth.NewPasswordManager(cfg.Password.BcryptCost)
+	if cfg.Password.Algorithm == auth.AlgorithmArgon2id {
+		passwordManager.WithArgon2id(auth.Argon2Params{
+			Memory:      uint32(cfg.Password.Argon2Memory),
+			Iterations:  uint32(cfg.Password.Argon2Iterations),
+			Parallelism: uint8(cfg.Password.Argon2Parallelism),
+		})
+	}
+	if cfg.Password.BreachCheck {
+		passwordManager.WithBreachCheck(auth.NewPwnedPasswords(cfg.Password.BreachCheckURL))
+	}
+	// Security audit log of sign-ins, password changes and credentials
+	securityEvents := audit.NewPostgresRepository(db.DB)
+	auditLogger := audit.NewLogger(securityEvents)
+	// Sign-ins from new devices and networks are logged and emailed about,
+	// and may need an emailed code first
+	var mailSender mail.Sender
+	if cfg.Mail.SMTPHost != "" {
+		mailSender = mail.NewSMTPSender(mail.SMTPConfig{
+			Host:     cfg.Mail.SMTPHost,
+			Port:     cfg.Mail.SMTPPort,
+			Username: cfg.Mail.SMTPUsername,
+			Password: cfg.Mail.SMTPPassword,
+			From:     cfg.Mail.From,
+		})
+	} else if !cfg.App.Profile.IsProduction() {
+		mailSender = mail.LogSender{}
+	}
+	var loginGuard *loginguard.Guard
+	if cfg.LoginAlerts.Enabled {
+		loginGuard = loginguard.NewGuard(loginguard.NewPostgresStore(db.DB), mailSender, auditLogger).
+			WithVerification(cfg.LoginAlerts.RequireVerification)
+	}
+	authService := services.NewAuthService(authRepo, userRepo, jwtManager, twoFactorService, passwordManager, auditLogger, loginGuard)
+	userService := services.NewUserService(userRepo, passwordManager)
+
+	// Account activity users can review from their security settings
+	activityRepo := activity.NewPostgresRepository(db.DB)
+	activityRecorder := activity.NewRecorder(activityRepo)
+
+	// Browser clients may ask for tokens as httpOnly cookies instead
+	var authCookies *auth.CookieConfig
+	if cfg.AuthCookies.Enabled {
+		sameSite, err := auth.ParseSameSite(cfg.AuthCookies.SameSite)
+		if err != nil {
+			log.Fatalf("Invalid auth cookie config: %v", err)
+		}
+		authCookies = &auth.CookieConfig{
+			Domain:     cfg.AuthCookies.Domain,
+			Secure:     cfg.AuthCookies.Secure,
+			SameSite:   sameSite,
+			AccessTTL:  cfg.JWT.AccessTokenExpiry,
+			RefreshTTL: cfg.JWT.RefreshTokenExpiry,
+		}
+	}
+
+	// Google sign-in, linked to existing accounts by verified email
+	var googleHandler *handlers.OAuthHandler
+	if cfg.OAuth.GoogleClientID != "" {
+		oauthService := services.NewOAuthService(authRepo, userRepo, repositories.NewPostgresIdentityRepository(db), jwtManager, twoFactorService, loginGuard)
+		googleProvider := auth.NewGoogleProvider(cfg.OAuth.GoogleClientID, cfg.OAuth.GoogleClientSecret, cfg.OAuth.GoogleRedirectURL)
+		googleHandler = handlers.NewOAuthHandler(oauthService, googleProvider).WithRedirect(cfg.OAuth.SuccessRedirectURL).
+			WithActivity(activityRecorder).WithAudit(auditLogger)
+		if authCookies != nil {
+			googleHandler.WithCookies(*authCookies)
+		}
+	}
 	
-	// Initialize credentials service with encryption key
-	encryptionKey := os.Getenv("ENCRYPTION_KEY")
-	if encryptionKey == "" {
-		// Use a default for development only - MUST be set in production
-		log.Println("WARNING: ENCRYPTION_KEY not set, using development default")
-		encryptionKey = "dev-key-change-in-production-32b" // Exactly 32 bytes
-	}
-	if len(encryptionKey) != 32 {
-		log.Fatalf("ENCRYPTION_KEY must be exactly 32 bytes, got %d", len(encryptionKey))
-	}
-	credentialsService, err := services.NewCredentialsService(
-		leagueAuthRepo,
-		encryptionKey,
+	// Shared ESPN client; per-user cookies are applied per request
+	espnClient := espn.NewESPNClient()
+
+	// Count ESPN requests per league so one league cannot use up the
+	// shared rate limit
+	var espnBudget *espnbudget.Budget
+	if redisClient != nil {
+		espnBudget = espnbudget.NewBudget(redisClient, cfg.Worker.ESPNLeagueHourlyBudget)
+		espnClient.SetRequestObserver(espnBudget.Record)
+	}
+	
+	// Event bus; high-volume topics can be forwarded to Kafka or NATS
+	var busTransports []eventbus.Transport
+	switch cfg.EventBus.Transport {
+	case "kafka":
+		busTransports = append(busTransports, eventbus.NewKafkaTransport(cfg.EventBus.KafkaRESTURL, cfg.EventBus.KafkaTopicPrefix))
+	case "nats":
+		natsTransport, err := eventbus.NewNATSTransport(cfg.EventBus.NATSURL, cfg.EventBus.NATSSubjectPrefix)
+		if err != nil {
+			log.Fatalf("Failed to configure NATS transport: %v", err)
+		}
+		busTransports = append(busTransports, natsTransport)
+	case "none", "":
+	default:
+		log.Printf("Unknown EVENT_BUS_TRANSPORT %q, events stay in process", cfg.EventBus.Transport)
+	}
+	bus := eventbus.New(busTransports, cfg.EventBus.Topics, cfg.EventBus.QueueSize)
+	go bus.Run(context.Background())
+	if len(busTransports) > 0 {
+		log.Printf("Event bus forwarding to %s", cfg.EventBus.Transport)
+	}
+
+	// Transactions and eligibility changes picked up by league syncs become
+	// notifications
+	notificationService := services.NewNotificationService(
+		repositories.NewPostgresNotificationRepository(db.DB),
+		repositories.NewPostgresWatchlistRepository(db.DB),
+		repositories.NewPostgresLeagueSyncRepository(db.DB),
 	)
-	if err != nil {
-		log.Fatalf("Failed to initialize credentials service: %v", err)
+	bus.Subscribe(eventbus.TopicTransactions, services.TransactionEventHandler(notificationService))
+	bus.Subscribe(eventbus.TopicRosters, services.EligibilityEventHandler(notificationService))
+
+	// Every scheduled worker reports its ticks here; one that stops ticking
+	// is alerted on and shows as stale in /readyz
+	heartbeats := worker.NewHeartbeats(cfg.Worker.HeartbeatGrace)
+	go heartbeats.Run(context.Background(), cfg.Worker.HeartbeatReportInterval)
+
+	// Start background league sync. Syncs users ask for go ahead of the
+	// scheduled refresh in the worker's queue.
+	var syncQueue *worker.SyncQueue
+	if cfg.Worker.LeagueSyncEnabled {
+		syncQueue = worker.NewSyncQueue(cfg.Worker.LeagueSyncCooldown)
+		leagueSyncWorker := worker.NewLeagueSyncWorker(
+			repositories.NewPostgresLeagueRepository(db.DB),
+			repositories.NewPostgresLeagueSyncRepository(db.DB),
+			credentialsService,
+			espnClient,
+			cfg.Worker.LeagueSyncInterval,
+			cfg.Worker.TransactionLimit,
+		).WithPauser(maintenanceSwitch).WithThrottler(surgeMode).WithPublisher(bus).
+			WithRosterStore(repositories.NewPostgresLeagueRosterRepository(db.DB)).
+			WithConcurrency(cfg.Worker.LeagueSyncConcurrency).
+			WithQueue(syncQueue).
+			WithHeartbeats(heartbeats)
+		if espnBudget != nil {
+			leagueSyncWorker.WithBudget(espnBudget)
+		}
+		go leagueSyncWorker.Run(context.Background())
+		log.Printf("League sync worker started (interval %s)", cfg.Worker.LeagueSyncInterval)
+	}
+
+	analyticsRepo := analytics.NewPostgresRepository(db.DB)
+
+	// Who may read and change leagues, drafts and the operator routes, by
+	// ownership and league and org roles
+	authz := policy.NewAuthorizer(policy.NewPostgresRepository(db.DB))
+
+	// How each team's roster was built, from synced rosters, transactions
+	// and draft results
+	rosterHistorian := services.NewRosterHistorian(
+		repositories.NewPostgresLeagueRepository(db.DB),
+		repositories.NewPostgresLeagueRosterRepository(db.DB),
+		repositories.NewPostgresLeagueSyncRepository(db.DB),
+		platform.NewFactory(espnClient, credentialsService),
+		authz,
+	)
+
+	// Start weekly league analytics precompute
+	if cfg.Worker.AnalyticsEnabled {
+		analyticsWorker := worker.NewLeagueAnalyticsWorker(
+			repositories.NewPostgresLeagueRepository(db.DB),
+			analyticsRepo,
+			credentialsService,
+			espnClient,
+			cfg.Worker.AnalyticsDay,
+			cfg.Worker.AnalyticsHour,
+			cfg.Worker.AnalyticsLocation,
+			cfg.Worker.PlayoffSimulations,
+		).WithPauser(maintenanceSwitch).
+			WithRosterHistorian(rosterHistorian).
+			WithHeartbeats(heartbeats)
+		go analyticsWorker.Run(context.Background())
+		log.Printf("League analytics worker started (%s %02d:00 %s)",
+			cfg.Worker.AnalyticsDay, cfg.Worker.AnalyticsHour, cfg.Worker.AnalyticsLocation)
+	}
+
+	playerNewsService := services.NewPlayerNewsService(
+		repositories.NewPostgresPlayerNewsRepository(db.DB),
+		espnClient,
+		cfg.Worker.PlayerNewsLimit,
+	)
+
+	// Start background injury and news sync
+	if cfg.Worker.PlayerNewsEnabled {
+		playerNewsWorker := worker.NewPlayerNewsWorker(playerNewsService, cfg.Worker.PlayerNewsInterval).
+			WithPauser(maintenanceSwitch).
+			WithThrottler(surgeMode).
+			WithHeartbeats(heartbeats)
+		go playerNewsWorker.Run(context.Background())
+		log.Printf("Player news worker started (interval %s)", cfg.Worker.PlayerNewsInterval)
 	}
+
+	// NFL team names and logos for decorating responses, refreshed weekly
+	teamMetadataService := services.NewTeamMetadataService(espnClient, cache.New(redisClient))
+	go worker.NewTeamMetadataWorker(teamMetadataService, cfg.Worker.TeamMetadataInterval).
+		WithPauser(maintenanceSwitch).
+		WithHeartbeats(heartbeats).
+		Run(context.Background())
 	
 	// Initialize draft service
-	draftRepo := draft.NewPostgresRepository(db.DB)
-	draftService := draft.NewService(draftRepo, redisClient)
+	draftService := draft.NewService(draftRepo, redisClient).WithAuthorizer(authz)
+
+	// Bot protection for registration; CAPTCHA stays off until a secret is set
+	var captcha auth.CaptchaVerifier
+	if cfg.BotProtection.CaptchaSecret != "" {
+		captcha, err = auth.NewCaptchaVerifier(cfg.BotProtection.CaptchaProvider, cfg.BotProtection.CaptchaSecret)
+		if err != nil {
+			log.Fatalf("Invalid bot protection config: %v", err)
+		}
+	}
+	botProtection := auth.NewBotProtection(captcha, cfg.BotProtection.BlockDisposableEmails, cfg.BotProtection.DisposableDomains)
+
+	// Response caches hold data longer during a surge; draft state is
+	// stored separately and never stretched
+	responseCache := cache.New(redisClient).WithTTLScaler(surgeMode)
+
+	// User requests read ESPN through a Redis cache; workers keep the direct
+	// client so syncs always see fresh data
+	userESPNClient := espn.Client(espnClient)
+	if cfg.Cache.ESPNEnabled && redisClient != nil {
+		ttls := espn.DefaultCacheTTLs()
+		ttls.LeagueInfo = cfg.Cache.ESPNLeagueTTL
+		ttls.Rosters = cfg.Cache.ESPNRostersTTL
+		ttls.FreeAgents = cfg.Cache.ESPNFreeAgentsTTL
+		userESPNClient = espn.NewCachedClient(espnClient, responseCache, ttls)
+	}
+
+	// Audit Redis memory per key namespace and expire app keys missing a TTL
+	var redisAuditor *redisaudit.Auditor
+	if redisClient != nil {
+		const mb = 1 << 20
+		redisAuditor = redisaudit.NewAuditor(redisClient, []redisaudit.Namespace{
+			{Name: "draft state", Prefix: draft.StateKeyPrefix, TTL: draft.StateTTL, Budget: int64(cfg.RedisAudit.DraftStateBudgetMB) * mb},
+			{Name: "espn cache", Prefix: "espn:", TTL: espn.DefaultCacheTTLs().History, Budget: int64(cfg.RedisAudit.CacheBudgetMB) * mb},
+			{Name: "projections cache", Prefix: "projections:", TTL: cfg.Cache.ProjectionsTTL, Budget: int64(cfg.RedisAudit.CacheBudgetMB) * mb},
+			{Name: "rate limits", Prefix: middleware.RateLimitKeyPrefix, TTL: time.Hour, Budget: int64(cfg.RedisAudit.RateLimitBudgetMB) * mb},
+			{Name: "espn budget", Prefix: espnbudget.KeyPrefix, TTL: 2 * time.Hour},
+			{Name: "token denylist", Prefix: auth.DenylistKeyPrefix, TTL: cfg.JWT.AccessTokenExpiry},
+			// Operator switches persist until changed
+			{Name: "maintenance", Prefix: "maintenance:"},
+			{Name: "surge", Prefix: "surge:"},
+			{Name: "redis audit", Prefix: "redisaudit:"},
+		})
+		if cfg.RedisAudit.Enabled {
+			go worker.NewRedisAuditWorker(redisAuditor, cfg.RedisAudit.Interval).
+				WithThrottler(surgeMode).
+				WithHeartbeats(heartbeats).
+				Run(context.Background())
+			log.Printf("Redis audit worker started (interval %s)", cfg.RedisAudit.Interval)
+		}
+	}
+
+	// Product analytics events, sent only for users who consented
+	consentRepo := repositories.NewPostgresConsentRepository(db.DB)
+	var tracker *events.Tracker
+	var analyticsSink events.Sink
+	switch cfg.Analytics.Sink {
+	case "segment":
+		analyticsSink = events.NewSegmentSink(cfg.Analytics.SegmentEndpoint, cfg.Analytics.SegmentWriteKey)
+	case "kafka":
+		analyticsSink = events.NewKafkaSink(cfg.Analytics.KafkaRESTURL, cfg.Analytics.KafkaTopic)
+	case "log":
+		analyticsSink = events.LogSink{}
+	case "none", "":
+	default:
+		log.Printf("Unknown ANALYTICS_SINK %q, product analytics disabled", cfg.Analytics.Sink)
+	}
+	if analyticsSink != nil {
+		tracker = events.NewTracker(analyticsSink, consentRepo, events.Options{
+			DefaultConsent: cfg.Analytics.DefaultConsent,
+			QueueSize:      cfg.Analytics.QueueSize,
+			BatchSize:      cfg.Analytics.BatchSize,
+			FlushInterval:  cfg.Analytics.FlushInterval,
+		})
+		go tracker.Run(context.Background())
+		log.Printf("Product analytics enabled (sink %s)", cfg.Analytics.Sink)
+	}
 
 	// Initialize handlers
-	healthHandler := handlers.NewHealthHandler(db, redisClient)
-	authHandler := handlers.NewAuthHandler(authService)
-	userHandler := handlers.NewUserHandler(userService)
-	leagueHandler := handlers.NewLeagueHandler(credentialsService)
-	draftHandler := handlers.NewDraftHandler(draftService)
-	projectionsHandler := handlers.NewProjectionsHandler(db.DB)
-
-	// Create Gin router
+	healthHandler := handlers.NewHealthHandler(db, redisClient).WithHeartbeats(heartbeats)
+	// Revoked access tokens are rejected before they expire. Entries
+	// outlive draft resume tokens too, which are revoked with their session.
+	denylistTTL := cfg.JWT.AccessTokenExpiry
+	if cfg.Draft.ResumeTokenTTL > denylistTTL {
+		denylistTTL = cfg.Draft.ResumeTokenTTL
+	}
+	denylist := auth.NewDenylist(redisClient, denylistTTL)
+	authHandler := handlers.NewAuthHandler(authService).WithBotProtection(botProtection).WithActivity(activityRecorder).
+		WithDenylist(denylist)
+	if authCookies != nil {
+		authHandler.WithCookies(*authCookies)
+	}
+	userHandler := handlers.NewUserHandler(userService).WithActivity(activityRecorder).WithDenylist(denylist).
+		WithAudit(auditLogger)
+	twoFactorHandler := handlers.NewTwoFactorHandler(twoFactorService).WithActivity(activityRecorder)
+	activityHandler := handlers.NewActivityHandler(activityRepo)
+	securityEventsHandler := handlers.NewSecurityEventsHandler(securityEvents)
+	leagueService := services.NewLeagueService(
+		repositories.NewPostgresLeagueRepository(db.DB),
+		repositories.NewPostgresLeagueHistoryRepository(db.DB),
+		espnClient,
+		authz,
+	)
+	leagueHandler := handlers.NewLeagueHandler(credentialsService, leagueService, userESPNClient).
+		WithTracker(tracker).
+		WithActivity(activityRecorder).
+		WithAudit(auditLogger).
+		WithRuleSimulator(services.NewRuleSimulator(
+			repositories.NewPostgresLeagueRepository(db.DB),
+			repositories.NewPostgresLeagueHistoryRepository(db.DB),
+			repositories.NewPostgresLeagueSyncRepository(db.DB),
+			authz,
+		)).
+		WithLineupAdvisor(services.NewLineupAdvisor(
+			repositories.NewPostgresLeagueRepository(db.DB),
+			repositories.NewPostgresLeagueRosterRepository(db.DB),
+			repositories.NewPostgresLeagueSyncRepository(db.DB),
+			authz,
+		)).
+		WithRosterHistorian(rosterHistorian)
+	if syncQueue != nil {
+		leagueHandler.WithSyncQueue(syncQueue)
+	}
+	leagueDataService := services.NewLeagueDataService(
+		repositories.NewPostgresLeagueRepository(db.DB),
+		platform.NewFactory(userESPNClient, credentialsService),
+		authz,
+	)
+	leagueDataHandler := handlers.NewLeagueDataHandler(leagueDataService)
+	draftHandler := handlers.NewDraftHandler(draftService).
+		WithTracker(tracker).
+		WithPublisher(bus).
+		WithESPN(credentialsService, leagueService, userESPNClient).
+		WithShareTokens(jwtManager).
+		WithResumeTokens(jwtManager, cfg.Draft.ResumeTokenTTL).
+		WithActivity(activityRecorder)
+	streamHub := stream.NewHub(bus, cfg.Stream.BufferSize, eventbus.TopicDraft, eventbus.TopicLiveScoring)
+	streamHandler := handlers.NewStreamHandler(
+		streamHub,
+		draftService,
+		repositories.NewPostgresLeagueRepository(db.DB),
+		cfg.Stream.PollTimeout,
+		cfg.Stream.HeartbeatInterval,
+	).WithAuthorizer(authz)
+	eventsHandler := handlers.NewEventsHandler(tracker, consentRepo, cfg.Analytics.DefaultConsent)
+	projectionsHandler := handlers.NewProjectionsHandler(db.DB, projections.NewPostgresRepository(db.DB)).
+		WithCache(responseCache, cfg.Cache.ProjectionsTTL).
+		WithECR(projections.NewPostgresECRRepository(db.DB)).
+		WithRestOfSeason(
+			projections.NewPostgresScheduleRepository(db.DB),
+			projections.NewPostgresAvailabilityRepository(db.DB),
+		)
+	availabilityHandler := handlers.NewAvailabilityHandler(projections.NewPostgresAvailabilityRepository(db.DB))
+	playersHandler := handlers.NewPlayersHandler(playerNewsService, userESPNClient).
+		WithTeams(teamMetadataService).
+		WithRosters(nflverse.NewPostgresRepository(db.DB))
+	scheduleHandler := handlers.NewScheduleHandler(
+		projections.NewPostgresScheduleRepository(db.DB),
+		repositories.NewPostgresLeagueRepository(db.DB),
+		repositories.NewPostgresLeagueSyncRepository(db.DB),
+	).WithAuthorizer(authz)
+	analyticsHandler := handlers.NewAnalyticsHandler(repositories.NewPostgresLeagueRepository(db.DB), analyticsRepo).
+		WithAuthorizer(authz)
+	pickRepo := analytics.NewPostgresPickRepository(db.DB)
+	rookiePickHandler := handlers.NewRookiePickHandler(
+		repositories.NewPostgresLeagueRepository(db.DB),
+		analyticsRepo,
+		pickRepo,
+	).WithLedgerSync(services.NewPickLedgerService(leagueDataService, pickRepo)).
+		WithAuthorizer(authz)
+	notificationsHandler := handlers.NewNotificationsHandler(notificationService)
+
+	// Voice assistants run draft commands for the accounts they are linked to
+	var voiceHandler *handlers.VoiceHandler
+	if cfg.Voice.Enabled {
+		voiceHandler = handlers.NewVoiceHandler(draftHandler, voice.NewPostgresRepository(db.DB), userRepo, jwtManager, cfg.Voice.DeviceTTL).
+			WithAlexa(voice.NewAlexaVerifier(cfg.Voice.AlexaSkillID, cfg.Voice.AlexaVerifySignatures)).
+			WithGoogleSecret(cfg.Voice.GoogleWebhookSecret).
+			WithRedirectURIs(cfg.Voice.RedirectURIs).
+			WithActivity(activityRecorder)
+		if !cfg.Voice.AlexaVerifySignatures {
+			log.Println("WARNING: Alexa request signatures are not verified")
+		}
+	}
+
+	// The assistant stays off until an LLM provider is configured
+	var assistantService *assistant.Assistant
+	if cfg.Assistant.Provider != "" {
+		llmClient, err := llm.NewClient(cfg.Assistant.Provider, cfg.Assistant.APIKey, cfg.Assistant.Model, cfg.Assistant.BaseURL, cfg.Assistant.Timeout)
+		if err != nil {
+			log.Fatalf("Failed to configure assistant: %v", err)
+		}
+		assistantService = assistant.NewAssistant(
+			llmClient,
+			projections.NewPostgresRepository(db.DB),
+			repositories.NewPostgresLeagueRepository(db.DB),
+			repositories.NewPostgresLeagueRosterRepository(db.DB),
+		).WithAuthorizer(authz)
+		// Draft commands the grammar does not recognise go to the same model
+		draftService.WithCommandParser(draft.NewLLMCommandParser(llmClient))
+		log.Printf("Assistant enabled (%s %s)", cfg.Assistant.Provider, cfg.Assistant.Model)
+	}
+	assistantHandler := handlers.NewAssistantHandler(assistantService)
+	maintenanceHandler := handlers.NewMaintenanceHandler(maintenanceSwitch, draftService, notificationService)
+	surgeHandler := handlers.NewSurgeHandler(surgeMode)
+
+	// Player teams, statuses and positions are refreshed nightly; changes
+	// drop the cached projections built on them
+	nflverseRepo := nflverse.NewPostgresRepository(db.DB)
+	metadataRefresher := nflverse.NewRefresher(nflverseRepo, &http.Client{Timeout: 5 * time.Minute}, nflverse.ReleaseURL).
+		WithInvalidator(projectionsHandler.InvalidateCache)
+	if cfg.Worker.PlayerMetadataEnabled {
+		go worker.NewPlayerMetadataWorker(metadataRefresher, cfg.Worker.PlayerMetadataHour, cfg.Worker.PlayerMetadataLocation).
+			WithPauser(maintenanceSwitch).
+			WithHeartbeats(heartbeats).
+			Run(context.Background())
+		log.Printf("Player metadata worker started (daily %02d:00 %s)",
+			cfg.Worker.PlayerMetadataHour, cfg.Worker.PlayerMetadataLocation)
+	}
+	playerMetadataHandler := handlers.NewPlayerMetadataHandler(metadataRefresher, nflverseRepo)
+
+	// Long-inactive accounts are warned, then anonymized if the user does
+	// not come back; admins can review each run
+	inactivityStore := inactivity.NewPostgresStore(db.DB)
+	inactivityJob := inactivity.NewJob(inactivityStore, notificationService, inactivity.Policy{
+		InactiveAfter: cfg.Worker.InactiveAfter,
+		WarningPeriod: cfg.Worker.InactivityWarningPeriod,
+		BatchSize:     cfg.Worker.InactivityBatchSize,
+	})
+	if cfg.Worker.InactivityEnabled {
+		go worker.NewInactivityWorker(inactivityJob, cfg.Worker.InactivityHour).
+			WithPauser(maintenanceSwitch).
+			WithHeartbeats(heartbeats).
+			Run(context.Background())
+		log.Printf("Inactive account worker started (daily %02d:00 UTC, inactive after %s)",
+			cfg.Worker.InactivityHour, cfg.Worker.InactiveAfter)
+	}
+	inactivityHandler := handlers.NewInactivityHandler(inactivityJob, inactivityStore)
+
+	// Raw projections, sync diffs and audit logs are pruned once past their
+	// retention so the database does not grow season after season
+	if cfg.Worker.RetentionEnabled {
+		retentionPolicies := retention.Policies(retention.Settings{
+			RawProjectionSeasons: cfg.Worker.RetentionRawProjectionSeasons,
+			SyncDiffs:            cfg.Worker.RetentionSyncDiffs,
+			AuditLog:             cfg.Worker.RetentionAuditLog,
+		})
+		pruner := retention.NewPruner(db.DB, retentionPolicies, cfg.Worker.RetentionBatchSize)
+		go worker.NewRetentionWorker(pruner, cfg.Worker.RetentionHour).
+			WithPauser(maintenanceSwitch).
+			WithHeartbeats(heartbeats).
+			Run(context.Background())
+		log.Printf("Retention worker started (daily %02d:00 UTC, %d tables)",
+			cfg.Worker.RetentionHour, len(retentionPolicies))
+	}
+
+	// Completed seasons' weekly detail moves to object storage as Parquet;
+	// users can load a season back when they want its full history
+	var archiveStore objectstore.Store
+	if cfg.Archive.Store == "s3" {
+		archiveStore = objectstore.NewS3Store(objectstore.S3Config{
+			Endpoint:        cfg.Archive.S3Endpoint,
+			Bucket:          cfg.Archive.S3Bucket,
+			Region:          cfg.Archive.S3Region,
+			AccessKeyID:     cfg.Archive.S3AccessKeyID,
+			SecretAccessKey: cfg.Archive.S3SecretAccessKey,
+		})
+	} else {
+		archiveStore = objectstore.NewFileStore(cfg.Archive.Dir)
+	}
+	archiver := archive.NewArchiver(archive.NewPostgresRepository(db.DB), archiveStore, archive.Policy{
+		HotSeasons:    cfg.Archive.HotSeasons,
+		RehydratedFor: cfg.Archive.RehydratedFor,
+		BatchSize:     cfg.Archive.BatchSize,
+	})
+	if cfg.Archive.Enabled {
+		go worker.NewArchiveWorker(archiver, cfg.Archive.Hour).
+			WithPauser(maintenanceSwitch).
+			WithHeartbeats(heartbeats).
+			Run(context.Background())
+		log.Printf("Season archival worker started (daily %02d:00 UTC, %d hot seasons, %s store)",
+			cfg.Archive.Hour, cfg.Archive.HotSeasons, cfg.Archive.Store)
+	}
+	archiveHandler := handlers.NewArchiveHandler(archiver, leagueService)
+
+	// Preload hot data so a deploy during games does not start cold
+	if cfg.Cache.WarmOnStartup && redisClient != nil {
+		worker.NewCacheWarmer(cfg.Cache.WarmTimeout).
+			Add("projections", func(ctx context.Context) (int, error) {
+				status, err := espnClient.GetSeasonStatus(ctx)
+				if err != nil {
+					return 0, err
+				}
+				return projectionsHandler.WarmCache(ctx, status.Season, status.Week)
+			}).
+			Add("draft sessions", draftService.WarmActiveSessions).
+			Run(context.Background())
+	}
+
+	// Create Gin router. Only development runs gin in debug mode, whatever
+	// GIN_MODE says.
+	if !cfg.App.Profile.AllowsDebug() {
+		gin.SetMode(gin.ReleaseMode)
+	}
 	r := gin.Default()
+	// Client IPs key rate limits, so forwarded headers are only believed
+	// from our own proxies
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 	
 	// Configure CORS
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:3001"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-Captcha-Token", auth.CSRFHeader, auth.TokenDeliveryHeader},
+		ExposeHeaders:    append([]string{"Content-Length", "X-Maintenance-Warning", "X-Maintenance-Deadline"}, quota.ExposedHeaders...),
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
+	// Requests authenticated by cookie must carry the CSRF token
+	r.Use(auth.CSRFMiddleware())
+
+	// Maintenance mode answers 503 everywhere except health, readiness,
+	// status and admin routes; drafts in progress get a grace period
+	r.Use(maintenance.Middleware(maintenanceSwitch,
+		[]string{"/health", "/readyz", "/.well-known", "/api/maintenance", "/api/admin"},
+		[]string{"/api/draft"},
+	))
+	r.Use(middleware.SampledMetrics(surgeMode))
+
 	// Public endpoints
 	r.GET("/health", healthHandler.Health)
+	r.GET("/readyz", healthHandler.Ready)
+	r.GET("/.well-known/jwks.json", handlers.NewJWKSHandler(jwtManager).Get)
+	r.GET("/api/maintenance", maintenanceHandler.GetStatus)
 	r.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "NFL Fantasy Analytics API",
@@ -138,21 +732,125 @@ func main() {
 		})
 	})
 	
+	// Public read-only endpoints, limited per IP
+	publicRoutes := r.Group("/api")
+	publicRoutes.Use(middleware.RedisRateLimit(redisClient, "public", cfg.RateLimit.APILimit, cfg.RateLimit.APIWindow, nil))
+
 	// Public projections endpoints (read-only, no auth required)
-	r.GET("/api/projections", projectionsHandler.GetProjections)
-	r.GET("/api/projections/player/:player", projectionsHandler.GetPlayerProjection)
+	publicRoutes.GET("/projections", projectionsHandler.GetProjections)
+	publicRoutes.GET("/projections/player/:player", projectionsHandler.GetPlayerProjection)
+	publicRoutes.POST("/projections/batch", projectionsHandler.GetProjectionsBatch)
+	publicRoutes.GET("/projections/player/:player/explain", projectionsHandler.ExplainPlayerProjection)
+	publicRoutes.GET("/projections/player/:player/history", projectionsHandler.GetPlayerHistory)
+	publicRoutes.GET("/projections/accuracy", projectionsHandler.GetRankAccuracy)
+	publicRoutes.POST("/projections/custom-score", projectionsHandler.CustomScore)
+	publicRoutes.GET("/projections/backtests", projectionsHandler.GetBacktests)
+	publicRoutes.GET("/projections/diff", projectionsHandler.GetProjectionDiff)
+	publicRoutes.GET("/projections/rest-of-season", projectionsHandler.GetRestOfSeason)
+	publicRoutes.GET("/projections/game-context", projectionsHandler.GetGameContext)
+
+	// Player news routes (public for now)
+	publicRoutes.GET("/players/trending", playersHandler.GetTrendingPlayers)
+	publicRoutes.POST("/players/batch", playersHandler.GetPlayersBatch)
+	publicRoutes.GET("/players/availability", availabilityHandler.List)
+	publicRoutes.GET("/players/:id/news", playersHandler.GetPlayerNews)
+	publicRoutes.GET("/nfl/teams", playersHandler.GetNFLTeams)
+	publicRoutes.GET("/nfl/teams/:abbr", playersHandler.GetNFLTeam)
 
 	// Auth endpoints (public)
 	authRoutes := r.Group("/api/auth")
+	authRoutes.Use(middleware.RedisRateLimit(redisClient, "auth", cfg.RateLimit.AuthLimit, cfg.RateLimit.AuthWindow, nil))
 	{
 		authRoutes.POST("/register", authHandler.Register)
 		authRoutes.POST("/login", authHandler.Login)
 		authRoutes.POST("/refresh", authHandler.RefreshToken)
+		authRoutes.POST("/2fa/verify", authHandler.VerifyTwoFactor)
+		authRoutes.POST("/login/verify", authHandler.VerifyLogin)
+		if googleHandler != nil {
+			authRoutes.GET("/google/login", googleHandler.Start)
+			authRoutes.GET("/google/callback", googleHandler.Callback)
+		}
+	}
+
+	// Read-only draft views for co-managers, authorized with a share token
+	// from POST /api/draft/sessions/:id/share instead of a login
+	sharedDraftRoutes := r.Group("/api/shared/draft")
+	sharedDraftRoutes.Use(auth.ScopedTokenMiddleware(jwtManager, auth.ScopeDraftRead, "id"))
+	sharedDraftRoutes.Use(middleware.RedisRateLimit(redisClient, "shared-draft", cfg.Draft.RateLimit, time.Minute, surgeMode))
+	{
+		sharedDraftRoutes.GET("/sessions/:id", draftHandler.GetSharedBoard)
+		sharedDraftRoutes.GET("/sessions/:id/recommendations", draftHandler.GetSharedRecommendations)
+		sharedDraftRoutes.GET("/sessions/:id/events", streamHandler.DraftEvents)
+	}
+
+	// Voice assistant webhooks, authorized with the device token each
+	// request carries. They are not limited per IP, since every user's
+	// requests come from Amazon's and Google's servers.
+	if voiceHandler != nil {
+		r.POST("/api/voice/alexa", voiceHandler.AlexaWebhook)
+		r.POST("/api/voice/google", voiceHandler.GoogleWebhook)
+	}
+
+	// Operator routes, authorized with ADMIN_API_KEY or for staff with
+	// support (read) or admin org roles
+	adminRoutes := r.Group("/api/admin")
+	adminRoutes.Use(auth.OperatorMiddleware(cfg.Admin.APIKey, jwtManager, denylist), policy.RequireAdmin(authz))
+	{
+		adminRoutes.GET("/maintenance", maintenanceHandler.GetStatus)
+		adminRoutes.PUT("/maintenance", maintenanceHandler.SetMaintenance)
+		adminRoutes.GET("/surge", surgeHandler.GetStatus)
+		adminRoutes.PUT("/surge", surgeHandler.SetOverride)
+		adminRoutes.POST("/players/metadata/refresh", playerMetadataHandler.Refresh)
+		adminRoutes.GET("/data-quality/player-metadata", playerMetadataHandler.GetReports)
+		adminRoutes.PUT("/players/availability", availabilityHandler.Upsert)
+		adminRoutes.DELETE("/players/availability", availabilityHandler.Delete)
+		adminRoutes.GET("/inactive-accounts/runs", inactivityHandler.GetRuns)
+		adminRoutes.POST("/inactive-accounts/run", inactivityHandler.Run)
+		adminRoutes.POST("/archives/run", archiveHandler.Run)
+		if redisAuditor != nil {
+			redisAuditHandler := handlers.NewRedisAuditHandler(redisAuditor)
+			adminRoutes.GET("/redis", redisAuditHandler.GetReport)
+			adminRoutes.POST("/redis/audit", redisAuditHandler.RunAudit)
+		}
+		if espnBudget != nil {
+			adminRoutes.GET("/espn-budget", handlers.NewESPNBudgetHandler(espnBudget).GetUsage)
+		}
 	}
 
 	// Protected routes
+	quotas := quota.Middleware(quota.NewStaticPlanner(quota.Plan{
+		Name: cfg.Quota.Plan,
+		Limits: map[string]int{
+			quota.ResourceLeagues:       cfg.Quota.MaxLeagues,
+			quota.ResourceDraftSessions: cfg.Quota.MaxDraftSessions,
+			quota.ResourceWatchlist:     cfg.Quota.MaxWatchlist,
+		},
+	}))
+	// The draft room, which also takes a resume token for the session from
+	// POST /api/draft/sessions, so a draft survives a failed refresh while
+	// the user signs back in
+	draftRoomRoutes := r.Group("/api/draft/sessions/:id")
+	draftRoomRoutes.Use(auth.ResumableAuthMiddleware(jwtManager, denylist, auth.ScopeDraftResume, "id"))
+	draftRoomRoutes.Use(middleware.RedisRateLimit(redisClient, "api", cfg.RateLimit.APILimit, cfg.RateLimit.APIWindow, nil))
+	draftRoomRoutes.Use(quotas)
+	draftRoomRoutes.Use(middleware.RedisRateLimit(redisClient, "draft", cfg.Draft.RateLimit, time.Minute, surgeMode))
+	{
+		draftRoomRoutes.GET("", draftHandler.GetSession)
+		draftRoomRoutes.GET("/decision-speed", draftHandler.GetDecisionSpeed)
+		draftRoomRoutes.POST("/pick", draftHandler.RecordPick)
+		draftRoomRoutes.POST("/turn", draftHandler.TakeTurn)
+		draftRoomRoutes.POST("/command", draftHandler.RunCommand)
+		draftRoomRoutes.POST("/undo", draftHandler.UndoPick)
+		draftRoomRoutes.POST("/redo", draftHandler.RedoPick)
+		draftRoomRoutes.POST("/pause", draftHandler.PauseSession)
+		draftRoomRoutes.POST("/resume", draftHandler.ResumeSession)
+		draftRoomRoutes.GET("/events", streamHandler.DraftEvents)
+	}
+
 	api := r.Group("/api")
-	api.Use(auth.AuthMiddleware(jwtManager))
+	api.Use(auth.AuthMiddleware(jwtManager, denylist))
+	api.Use(middleware.RedisRateLimit(redisClient, "api", cfg.RateLimit.APILimit, cfg.RateLimit.APIWindow, nil))
+	api.Use(quotas)
 	{
 		// User endpoints
 		userRoutes := api.Group("/users")
@@ -161,32 +859,107 @@ func main() {
 			userRoutes.PUT("/profile", userHandler.UpdateProfile)
 			userRoutes.DELETE("/account", userHandler.DeleteAccount)
 			userRoutes.POST("/password", userHandler.ChangePassword)
+			userRoutes.GET("/activity", activityHandler.GetActivity)
+			userRoutes.GET("/security-events", securityEventsHandler.GetSecurityEvents)
+			userRoutes.GET("/analytics-consent", eventsHandler.GetAnalyticsConsent)
+			userRoutes.PUT("/analytics-consent", eventsHandler.SetAnalyticsConsent)
 		}
 
 		// Logout endpoint
 		api.POST("/auth/logout", authHandler.Logout)
+		api.GET("/auth/sessions", authHandler.ListSessions)
+		api.DELETE("/auth/sessions/:id", authHandler.RevokeSession)
+
+		// Two-factor settings
+		twoFactor := api.Group("/auth/2fa")
+		{
+			twoFactor.GET("", twoFactorHandler.GetStatus)
+			twoFactor.POST("/enroll", twoFactorHandler.Enroll)
+			twoFactor.POST("/confirm", twoFactorHandler.Confirm)
+			twoFactor.POST("/disable", twoFactorHandler.Disable)
+			twoFactor.POST("/backup-codes", twoFactorHandler.RegenerateBackupCodes)
+		}
 		
 		// League endpoints
 		leagueRoutes := api.Group("/leagues")
 		{
+			leagueRoutes.GET("", leagueHandler.ListLeagues)
+			leagueRoutes.GET("/selected", leagueHandler.GetSelectedLeague)
+			leagueRoutes.PUT("/:id/select", leagueHandler.SelectLeague)
+			leagueRoutes.DELETE("/:id", leagueHandler.DisconnectLeague)
 			leagueRoutes.POST("/espn/connect", leagueHandler.ConnectESPN)
+			leagueRoutes.POST("/custom", leagueHandler.CreateCustomLeague)
+			leagueRoutes.PUT("/custom/:id", leagueHandler.UpdateCustomLeague)
 			leagueRoutes.GET("/espn/status", leagueHandler.GetESPNStatus)
 			leagueRoutes.DELETE("/espn/disconnect", leagueHandler.DisconnectESPN)
 			leagueRoutes.PUT("/espn/update", leagueHandler.UpdateESPNCredentials)
+			leagueRoutes.GET("/espn/:leagueId/waivers", leagueHandler.GetWaiverClaims)
+			leagueRoutes.GET("/espn/:leagueId/auction-values", leagueHandler.GetAuctionValues)
+			leagueRoutes.GET("/espn/:leagueId/keepers", leagueHandler.GetKeeperCosts)
+			leagueRoutes.GET("/:id/analytics", analyticsHandler.GetLeagueAnalytics)
+			leagueRoutes.GET("/:id/recap", analyticsHandler.GetRecap)
+			leagueRoutes.GET("/:id/picks", rookiePickHandler.GetPicks)
+			leagueRoutes.PUT("/:id/picks", rookiePickHandler.RecordPick)
+			leagueRoutes.GET("/:id/picks/ledger", rookiePickHandler.GetLedger)
+			leagueRoutes.POST("/:id/picks/sync", rookiePickHandler.SyncLedger)
+			leagueRoutes.GET("/:id/history", leagueHandler.GetLeagueHistory)
+			leagueRoutes.POST("/:id/history", leagueHandler.ImportLeagueHistory)
+			leagueRoutes.GET("/:id/archives", archiveHandler.ListArchives)
+			leagueRoutes.GET("/:id/archives/:season", archiveHandler.GetArchivedSeason)
+			leagueRoutes.POST("/:id/archives/:season/rehydrate", archiveHandler.Rehydrate)
+			leagueRoutes.POST("/:id/simulate-rules", leagueHandler.SimulateRuleChange)
+			leagueRoutes.GET("/:id/live", leagueHandler.GetLiveScoreboard)
+			leagueRoutes.POST("/:id/sync", leagueHandler.RequestSync)
+			leagueRoutes.GET("/:id/teams", leagueDataHandler.GetTeams)
+			leagueRoutes.GET("/:id/rosters", leagueDataHandler.GetRosters)
+			leagueRoutes.GET("/:id/players", leagueDataHandler.GetPlayers)
+			leagueRoutes.GET("/:id/transactions", leagueDataHandler.GetTransactions)
+			leagueRoutes.GET("/:id/draft", leagueDataHandler.GetDraft)
+			leagueRoutes.GET("/:id/teams/:teamId/lineup", leagueHandler.GetLineup)
+			leagueRoutes.GET("/:id/teams/:teamId/roster/history", leagueHandler.GetRosterHistory)
+			leagueRoutes.GET("/:id/teams/:teamId/acquisitions", leagueHandler.GetAcquisitions)
+			leagueRoutes.GET("/:id/live/events", streamHandler.LiveScoringEvents)
+			leagueRoutes.GET("/:id/byes", scheduleHandler.GetLeagueByePlan)
 		}
 		
-		// Draft endpoints
+		// Draft endpoints that need a login
 		draftRoutes := api.Group("/draft")
+		draftRoutes.Use(middleware.RedisRateLimit(redisClient, "draft", cfg.Draft.RateLimit, time.Minute, surgeMode))
 		{
 			draftRoutes.POST("/sessions", draftHandler.CreateSession)
+			draftRoutes.POST("/sessions/import", draftHandler.ImportSession)
 			draftRoutes.GET("/sessions", draftHandler.GetUserSessions)
-			draftRoutes.GET("/sessions/:id", draftHandler.GetSession)
-			draftRoutes.POST("/sessions/:id/pick", draftHandler.RecordPick)
-			draftRoutes.POST("/sessions/:id/undo", draftHandler.UndoPick)
-			draftRoutes.POST("/sessions/:id/redo", draftHandler.RedoPick)
-			draftRoutes.POST("/sessions/:id/pause", draftHandler.PauseSession)
-			draftRoutes.POST("/sessions/:id/resume", draftHandler.ResumeSession)
+			draftRoutes.POST("/sessions/:id/share", draftHandler.ShareSession)
+			draftRoutes.POST("/sessions/:id/resume-token", draftHandler.RenewResumeToken)
 		}
+
+		// NFL schedule and bye weeks
+		api.GET("/schedule", scheduleHandler.GetSchedule)
+		api.GET("/schedule/byes", scheduleHandler.GetByeWeeks)
+
+		// Draft and live scoring streams fall back from SSE to long polling
+		api.GET("/stream/transports", streamHandler.NegotiateTransport)
+
+		// Client-side product events
+		api.POST("/events", eventsHandler.TrackEvent)
+
+		// Natural language questions, answered from our data by an LLM
+		api.POST("/assistant/ask", middleware.RedisRateLimit(redisClient, "assistant", cfg.Assistant.RateLimit, time.Hour, nil), assistantHandler.Ask)
+
+		// Linked voice assistants
+		if voiceHandler != nil {
+			api.GET("/voice/devices", voiceHandler.ListDevices)
+			api.POST("/voice/devices", voiceHandler.LinkDevice)
+			api.DELETE("/voice/devices/:id", voiceHandler.UnlinkDevice)
+			api.POST("/voice/authorize", voiceHandler.Authorize)
+		}
+
+		// Notification and watchlist routes
+		api.GET("/notifications", notificationsHandler.GetNotifications)
+		api.POST("/notifications/:id/read", notificationsHandler.MarkNotificationRead)
+		api.GET("/watchlist", notificationsHandler.GetWatchlist)
+		api.POST("/watchlist", notificationsHandler.AddToWatchlist)
+		api.DELETE("/watchlist/:player", notificationsHandler.RemoveFromWatchlist)
 	}
 
 	// Get port from config or environment
@@ -199,7 +972,7 @@ func main() {
 	}
 
 	// Start server
-	log.Printf("Starting server on port %s", port)
+	log.Printf("Starting server on port %s (%s profile)", port, cfg.App.Profile)
 	if err := r.Run(":" + port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/nflverse"
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+func main() {
+	var (
+		season      int
+		throughYear int
+		datasets    string
+		source      string
+		actuals     bool
+		databaseURL string
+	)
+
+	// Define flags
+	flag.IntVar(&season, "season", 0, "NFL season to load (e.g. 2024)")
+	flag.IntVar(&throughYear, "through", 0, "Load every season from -season through this one")
+	flag.StringVar(&datasets, "datasets", strings.Join(nflverse.Datasets, ","), "Comma-separated datasets: player_stats, snap_counts, rosters")
+	flag.StringVar(&source, "source", nflverse.ReleaseURL, "nflverse release URL, or a directory of downloaded CSV files")
+	flag.BoolVar(&actuals, "actuals", true, "Also load regular season fantasy points from player_stats into the weekly actuals")
+	flag.StringVar(&databaseURL, "database", "", "Database connection URL")
+	flag.Parse()
+
+	if season == 0 {
+		log.Fatal("Please specify a season with -season flag")
+	}
+	if throughYear == 0 {
+		throughYear = season
+	}
+	if throughYear < season {
+		log.Fatal("-through must not be before -season")
+	}
+
+	var selected []string
+	for _, dataset := range strings.Split(datasets, ",") {
+		dataset = strings.TrimSpace(dataset)
+		if _, err := nflverse.FileName(dataset, season); err != nil {
+			log.Fatal(err)
+		}
+		selected = append(selected, dataset)
+	}
+
+	// Get database URL from environment if not provided
+	if databaseURL == "" {
+		host := getEnv("POSTGRES_HOST", "localhost")
+		port := getEnv("POSTGRES_PORT", "5432")
+		user := getEnv("POSTGRES_USER", "app_user")
+		password := getEnv("POSTGRES_PASSWORD", "secure_password")
+		dbname := getEnv("POSTGRES_DB", "fantasy_football")
+		sslmode := getEnv("POSTGRES_SSLMODE", "disable")
+
+		databaseURL = fmt.Sprintf("postgresql://%s:%s@%s:%s/%s?sslmode=%s",
+			user, password, host, port, dbname, sslmode)
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	client := &http.Client{Timeout: 5 * time.Minute}
+	repo := nflverse.NewPostgresRepository(db)
+	projectionRepo := projections.NewPostgresRepository(db)
+
+	for year := season; year <= throughYear; year++ {
+		for _, dataset := range selected {
+			f, err := nflverse.Open(ctx, client, source, dataset, year)
+			if err != nil {
+				log.Fatalf("Failed to open %s for %d: %v", dataset, year, err)
+			}
+			count, err := load(ctx, repo, projectionRepo, dataset, f, actuals)
+			f.Close()
+			if err != nil {
+				log.Fatalf("Failed to load %s for %d: %v", dataset, year, err)
+			}
+			fmt.Printf("Loaded %d %s rows for %d\n", count, dataset, year)
+		}
+	}
+}
+
+// load parses one dataset file and stores it, returning the rows stored
+func load(ctx context.Context, repo nflverse.Repository, projectionRepo projections.Repository, dataset string, r io.Reader, actuals bool) (int, error) {
+	switch dataset {
+	case nflverse.DatasetPlayerStats:
+		stats, err := nflverse.ReadPlayerStats(r)
+		if err != nil {
+			return 0, err
+		}
+		if err := repo.UpsertPlayerStats(ctx, stats); err != nil {
+			return 0, err
+		}
+		if actuals {
+			if err := projectionRepo.UpsertWeeklyActuals(ctx, weeklyActuals(stats)); err != nil {
+				return 0, err
+			}
+		}
+		return len(stats), nil
+
+	case nflverse.DatasetSnapCounts:
+		snaps, err := nflverse.ReadSnapCounts(r)
+		if err != nil {
+			return 0, err
+		}
+		return len(snaps), repo.UpsertSnapCounts(ctx, snaps)
+
+	case nflverse.DatasetRosters:
+		roster, err := nflverse.ReadRosters(r)
+		if err != nil {
+			return 0, err
+		}
+		return len(roster), repo.UpsertRosters(ctx, roster)
+	}
+	return 0, fmt.Errorf("unknown dataset %q", dataset)
+}
+
+// weeklyActuals turns regular season stat lines into the actual fantasy
+// points projections are backtested against
+func weeklyActuals(stats []nflverse.PlayerWeekStats) []projections.WeeklyActual {
+	var out []projections.WeeklyActual
+	for _, s := range stats {
+		if s.SeasonType != "REG" {
+			continue
+		}
+		out = append(out, projections.WeeklyActual{
+			PlayerName:     s.PlayerName,
+			Position:       s.Position,
+			Team:           s.Team,
+			Season:         s.Season,
+			Week:           s.Week,
+			PointsPPR:      s.FantasyPointsPPR,
+			PointsStandard: s.FantasyPoints,
+		})
+	}
+	return out
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/config"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/integrations/fantasypros"
+	"github.com/nfl-analytics/backend/internal/integrations/odds"
+	"github.com/nfl-analytics/backend/internal/integrations/weather"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+func main() {
+	var (
+		season       int
+		week         int
+		source       string
+		dstPath      string
+		kickerPath   string
+		weatherPath  string
+		forecast     bool
+		schedulePath string
+		nflverse     bool
+		actualsPath  string
+		ecrPath      string
+		draftRanks   string
+		backtest     string
+		runPipeline  bool
+		espnSource   bool
+		oddsSource   bool
+		fromWeek     int
+		toWeek       int
+		databaseURL  string
+	)
+
+	// Define flags
+	flag.IntVar(&season, "season", 0, "NFL season (e.g. 2024)")
+	flag.IntVar(&week, "week", 0, "NFL week (0 for season-long)")
+	flag.StringVar(&source, "source", "fantasypros", "Projection source name")
+	flag.StringVar(&dstPath, "dst", "", "Path to DST projections CSV")
+	flag.StringVar(&kickerPath, "kickers", "", "Path to kicker projections CSV")
+	flag.StringVar(&weatherPath, "weather", "", "Path to game weather CSV")
+	flag.BoolVar(&forecast, "forecast", false, "Fetch kickoff forecasts for -week's outdoor games from Open-Meteo")
+	flag.StringVar(&schedulePath, "schedule", "", "Path to season schedule CSV")
+	flag.BoolVar(&nflverse, "nflverse-schedule", false, "Download the season's schedule from nflverse")
+	flag.StringVar(&actualsPath, "actuals", "", "Path to weekly actual fantasy points CSV")
+	flag.StringVar(&ecrPath, "ecr", "", "Path to an expert consensus rankings CSV for -week, stored as a new snapshot")
+	flag.StringVar(&draftRanks, "draft-rankings", "", "Comma-separated draft rankings sources to snapshot for -season in every scoring format: espn, fantasypros")
+	flag.StringVar(&backtest, "backtest", "", "Backtest a modifier (matchup, weather, rest, injury) over -from-week to -to-week")
+	flag.BoolVar(&espnSource, "espn", false, "Ingest ESPN's player projections for -week")
+	flag.BoolVar(&oddsSource, "odds", false, "Ingest sportsbook player props for -week from The Odds API")
+	flag.BoolVar(&runPipeline, "pipeline", false, "Run the projection pipeline for -week after ingesting")
+	flag.IntVar(&fromWeek, "from-week", 1, "First week to backtest")
+	flag.IntVar(&toWeek, "to-week", 17, "Last week to backtest")
+	flag.StringVar(&databaseURL, "database", "", "Database connection URL")
+	flag.Parse()
+
+	if season == 0 {
+		log.Fatal("Please specify a season with -season flag")
+	}
+	if dstPath == "" && kickerPath == "" && weatherPath == "" && !forecast && schedulePath == "" && !nflverse && actualsPath == "" && ecrPath == "" && draftRanks == "" && backtest == "" && !runPipeline && !espnSource && !oddsSource {
+		log.Fatal("Please specify at least one of -dst, -kickers, -weather, -forecast, -schedule, -nflverse-schedule, -actuals, -ecr, -draft-rankings, -espn, -odds, -pipeline or -backtest")
+	}
+
+	// Get database URL from environment if not provided
+	if databaseURL == "" {
+		host := getEnv("POSTGRES_HOST", "localhost")
+		port := getEnv("POSTGRES_PORT", "5432")
+		user := getEnv("POSTGRES_USER", "app_user")
+		password := getEnv("POSTGRES_PASSWORD", "secure_password")
+		dbname := getEnv("POSTGRES_DB", "fantasy_football")
+		sslmode := getEnv("POSTGRES_SSLMODE", "disable")
+
+		databaseURL = fmt.Sprintf("postgresql://%s:%s@%s:%s/%s?sslmode=%s",
+			user, password, host, port, dbname, sslmode)
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := projections.NewPostgresRepository(db)
+
+	if schedulePath != "" {
+		games, err := projections.ReadScheduleCSV(schedulePath, season)
+		if err != nil {
+			log.Fatalf("Failed to read schedule: %v", err)
+		}
+		if err := repo.UpsertSchedule(ctx, games); err != nil {
+			log.Fatalf("Failed to store schedule: %v", err)
+		}
+		fmt.Printf("Ingested %d scheduled games\n", len(games))
+	}
+
+	if nflverse {
+		client := &http.Client{Timeout: time.Minute}
+		games, err := projections.FetchNFLVerseSchedule(ctx, client, projections.NFLVerseScheduleURL, season)
+		if err != nil {
+			log.Fatalf("Failed to fetch nflverse schedule: %v", err)
+		}
+		if len(games) == 0 {
+			log.Fatalf("nflverse has no regular season games for %d", season)
+		}
+		if err := repo.UpsertSchedule(ctx, games); err != nil {
+			log.Fatalf("Failed to store schedule: %v", err)
+		}
+		fmt.Printf("Ingested %d scheduled games from nflverse\n", len(games))
+	}
+
+	// Game-context features follow the schedule they are computed from
+	if schedulePath != "" || nflverse {
+		count, err := projections.RefreshGameContext(ctx, repo, season)
+		if err != nil {
+			log.Fatalf("Failed to compute game context: %v", err)
+		}
+		fmt.Printf("Computed game context for %d team games\n", count)
+	}
+
+	if weatherPath != "" {
+		games, err := projections.ReadWeatherCSV(weatherPath, source, season, week)
+		if err != nil {
+			log.Fatalf("Failed to read weather: %v", err)
+		}
+		if err := repo.UpsertGameWeather(ctx, games); err != nil {
+			log.Fatalf("Failed to store weather: %v", err)
+		}
+		fmt.Printf("Ingested weather for %d games\n", len(games))
+	}
+
+	if forecast {
+		if week == 0 {
+			log.Fatal("-forecast needs -week")
+		}
+		games, err := repo.GetSchedule(ctx, season, week, week)
+		if err != nil {
+			log.Fatalf("Failed to get schedule: %v", err)
+		}
+		if len(games) == 0 {
+			log.Fatalf("No schedule stored for %d week %d; load it with -nflverse-schedule first", season, week)
+		}
+		forecasts, err := projections.ForecastGameWeather(ctx, weather.NewOpenMeteoClient(), games, time.Now())
+		if err != nil {
+			log.Fatalf("Failed to fetch forecasts: %v", err)
+		}
+		if err := repo.UpsertGameWeather(ctx, forecasts); err != nil {
+			log.Fatalf("Failed to store weather: %v", err)
+		}
+		fmt.Printf("Fetched forecasts for %d of %d games\n", len(forecasts), len(games))
+	}
+
+	if actualsPath != "" {
+		actuals, err := projections.ReadActualsCSV(actualsPath, season, week)
+		if err != nil {
+			log.Fatalf("Failed to read actuals: %v", err)
+		}
+		if err := repo.UpsertWeeklyActuals(ctx, actuals); err != nil {
+			log.Fatalf("Failed to store actuals: %v", err)
+		}
+		fmt.Printf("Ingested actuals for %d players\n", len(actuals))
+	}
+
+	if ecrPath != "" {
+		if week == 0 {
+			log.Fatal("-ecr needs -week")
+		}
+		rankings, err := projections.ReadECRCSV(ecrPath, source, season, week, time.Now())
+		if err != nil {
+			log.Fatalf("Failed to read expert rankings: %v", err)
+		}
+		if err := projections.NewPostgresECRRepository(db).UpsertExpertRankings(ctx, rankings); err != nil {
+			log.Fatalf("Failed to store expert rankings: %v", err)
+		}
+		fmt.Printf("Ingested expert ranks for %d players\n", len(rankings))
+	}
+
+	if draftRanks != "" {
+		rankingsRepo := projections.NewPostgresDraftRankingRepository(db)
+		snapshotAt := time.Now()
+		for _, rankingsSource := range strings.Split(draftRanks, ",") {
+			rankingsSource = strings.TrimSpace(rankingsSource)
+			for _, format := range fantasypros.Formats {
+				var rankings []projections.DraftRanking
+				var err error
+				switch rankingsSource {
+				case projections.RankingsSourceESPN:
+					rankings, err = projections.ESPNDraftRankings(ctx, espn.NewESPNClient(), season, format, snapshotAt)
+				case projections.RankingsSourceFantasyPros:
+					rankings, err = projections.FantasyProsDraftRankings(ctx, fantasypros.NewFantasyProsClient(), season, format, snapshotAt)
+				default:
+					log.Fatalf("Unknown draft rankings source %q", rankingsSource)
+				}
+				if err != nil {
+					log.Fatalf("Failed to fetch %s %s draft rankings: %v", rankingsSource, format, err)
+				}
+				if err := rankingsRepo.UpsertDraftRankings(ctx, rankings); err != nil {
+					log.Fatalf("Failed to store draft rankings: %v", err)
+				}
+				fmt.Printf("Ingested %s %s draft rankings for %d players\n", rankingsSource, format, len(rankings))
+			}
+		}
+	}
+
+	if dstPath != "" || kickerPath != "" || espnSource || oddsSource {
+		var ingester *projections.Ingester
+		if dstPath != "" || kickerPath != "" {
+			ingester = projections.NewIngester(repo, projections.NewCSVSource(source, dstPath, kickerPath))
+		} else {
+			ingester = projections.NewIngester(repo)
+		}
+		if espnSource {
+			ingester.WithPlayerSources(projections.NewESPNSource(espn.NewESPNClient()))
+		}
+		if oddsSource {
+			cfg := config.LoadProjections()
+			if cfg.OddsAPIKey == "" {
+				log.Fatal("-odds needs ODDS_API_KEY")
+			}
+			ingester.WithPlayerSources(projections.NewOddsSource(
+				odds.NewOddsAPIClient(cfg.OddsAPIKey),
+				projections.NewPostgresScheduleRepository(db),
+				projections.NewPostgresPropStore(db),
+				cfg.OddsBookmakers,
+			))
+		}
+
+		result, err := ingester.Ingest(ctx, season, week)
+		if err != nil {
+			log.Fatalf("Failed to ingest projections: %v", err)
+		}
+
+		fmt.Printf("Ingested %d DST, %d kicker and %d player projections for %d week %d\n",
+			result.DSTCount, result.KickerCount, result.PlayerCount, result.Season, result.Week)
+	}
+
+	if runPipeline {
+		cfg := config.LoadProjections()
+		pipeline, err := projections.BuildPipeline(repo, projections.PipelineConfig{
+			Stages:        cfg.PipelineStages,
+			SourceWeights: cfg.SourceWeights,
+			RestEnabled:   cfg.RestModifier,
+		})
+		if err != nil {
+			log.Fatalf("Invalid projection pipeline: %v", err)
+		}
+
+		startedAt := time.Now()
+		result, err := pipeline.Run(ctx, season, week)
+		if err != nil {
+			log.Fatalf("Failed to run projection pipeline: %v", err)
+		}
+
+		fmt.Printf("Computed projections for %d players in %d week %d (stages: %s)\n",
+			result.Players, result.Season, result.Week, strings.Join(result.Stages, ", "))
+
+		// Alert users to projections that moved since the previous run
+		changes, err := projections.DiffSince(ctx, repo, season, week, startedAt, cfg.AlertThreshold)
+		if err != nil {
+			log.Fatalf("Failed to diff projections: %v", err)
+		}
+		notificationService := services.NewNotificationService(
+			repositories.NewPostgresNotificationRepository(db),
+			repositories.NewPostgresWatchlistRepository(db),
+			repositories.NewPostgresLeagueSyncRepository(db),
+		)
+		sent, err := notificationService.NotifyProjectionChanges(ctx, changes)
+		if err != nil {
+			log.Fatalf("Failed to send projection alerts: %v", err)
+		}
+		fmt.Printf("%d projections moved at least %.1f points; sent %d notifications\n",
+			len(changes), cfg.AlertThreshold, sent)
+	}
+
+	if backtest != "" {
+		result, err := projections.NewBacktester(repo).Run(ctx, backtest, season, fromWeek, toWeek)
+		if err != nil {
+			log.Fatalf("Failed to backtest %s: %v", backtest, err)
+		}
+
+		fmt.Printf("Backtest %s, %d weeks %d-%d: %d adjusted projections, MAE %.3f -> %.3f (improved: %v)\n",
+			result.Modifier, result.Season, result.WeekStart, result.WeekEnd,
+			result.AdjustedCount, result.BaseMAE, result.AdjustedMAE, result.Improved())
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
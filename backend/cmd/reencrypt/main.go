@@ -0,0 +1,84 @@
+// Command reencrypt moves stored ESPN credentials and two-factor secrets to
+// the current ENCRYPTION_KEY after a key rotation. Run it with the API's
+// environment, the new key in ENCRYPTION_KEY and ENCRYPTION_KEY_ID and the
+// retired ones in ENCRYPTION_PREVIOUS_KEYS; once it reports no failures the
+// retired keys can be removed.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/config"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+func main() {
+	var (
+		dryRun      bool
+		databaseURL string
+	)
+
+	// Define flags
+	flag.BoolVar(&dryRun, "dry-run", false, "Count values to re-encrypt without changing them")
+	flag.StringVar(&databaseURL, "database", "", "Database connection URL")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Keys are resolved the same way as the API's, per SECRETS_PROVIDER
+	secretsCtx, cancelSecrets := context.WithTimeout(context.Background(), 30*time.Second)
+	encryptionKey, previousEncryptionKeys, err := config.ResolveEncryptionKeys(secretsCtx, cfg)
+	cancelSecrets()
+	if err != nil {
+		log.Fatalf("Failed to load encryption keys: %v", err)
+	}
+
+	box, err := auth.NewVersionedSecretBox(cfg.Encryption.KeyID, encryptionKey, previousEncryptionKeys)
+	if err != nil {
+		log.Fatalf("Failed to initialize encryption: %v", err)
+	}
+
+	// Get database URL from configuration if not provided
+	if databaseURL == "" {
+		databaseURL = fmt.Sprintf("postgresql://%s:%s@%s:%s/%s?sslmode=%s",
+			cfg.Database.User, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port,
+			cfg.Database.Name, cfg.Database.SSLMode)
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	reencryptor := services.NewReencryptor(repositories.NewPostgresEncryptedValueRepository(db), box)
+	results, err := reencryptor.Run(context.Background(), dryRun)
+
+	verb := "Re-encrypted"
+	if dryRun {
+		verb = "Would re-encrypt"
+	}
+	failed := 0
+	for _, r := range results {
+		fmt.Printf("%s: %s %d of %d (%d already on key %s, %d changed during the run, %d failed)\n",
+			r.Kind, verb, r.Reencrypted, r.Scanned, r.Current, box.KeyID(), r.Changed, r.Failed)
+		failed += r.Failed
+	}
+	if err != nil {
+		log.Fatalf("Failed to re-encrypt: %v", err)
+	}
+	if failed > 0 {
+		log.Fatalf("%d values could not be decrypted with any configured key; keep the retired keys until they are fixed", failed)
+	}
+}
@@ -0,0 +1,157 @@
+// Package activity records the significant things users do to their own
+// accounts, such as signing in or connecting a league, so they can review
+// them from their security settings
+package activity
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Actions users can see in their activity
+const (
+	ActionAccountCreated      = "account_created"
+	ActionLogin               = "login"
+	ActionLogout              = "logout"
+	ActionPasswordChanged     = "password_changed"
+	ActionCredentialsAdded    = "credentials_added"
+	ActionCredentialsUpdated  = "credentials_updated"
+	ActionCredentialsRemoved  = "credentials_removed"
+	ActionLeagueConnected     = "league_connected"
+	ActionLeagueDisconnected  = "league_disconnected"
+	ActionDraftShared         = "draft_shared"
+	ActionTwoFactorEnabled    = "two_factor_enabled"
+	ActionTwoFactorDisabled   = "two_factor_disabled"
+	ActionBackupCodesReset    = "backup_codes_regenerated"
+	ActionSessionRevoked      = "session_revoked"
+	ActionVoiceDeviceLinked   = "voice_device_linked"
+	ActionVoiceDeviceUnlinked = "voice_device_unlinked"
+)
+
+// Entry is one action in a user's activity
+type Entry struct {
+	ID         uuid.UUID              `json:"id"`
+	UserID     uuid.UUID              `json:"-"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type,omitempty"`
+	EntityID   string                 `json:"entity_id,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	IPAddress  string                 `json:"ip_address,omitempty"`
+	UserAgent  string                 `json:"user_agent,omitempty"`
+	Device     Device                 `json:"device"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// Filter narrows a user's activity. Zero values match everything.
+type Filter struct {
+	Actions []string
+	Since   time.Time
+	Until   time.Time
+	// Query matches the action, device, IP address or details
+	Query string
+	// Before pages back from the oldest entry already seen
+	Before time.Time
+	Limit  int
+}
+
+// Repository stores activity entries
+type Repository interface {
+	Record(ctx context.Context, entry *Entry) error
+	// List returns a user's entries, newest first
+	List(ctx context.Context, userID uuid.UUID, filter Filter) ([]Entry, error)
+}
+
+// Recorder records actions taken in requests. Recording never fails the
+// request. A nil Recorder discards every action.
+type Recorder struct {
+	repo Repository
+}
+
+// NewRecorder creates a recorder that stores actions in repo
+func NewRecorder(repo Repository) *Recorder {
+	return &Recorder{repo: repo}
+}
+
+// Record stores an action by the user making the request, with the client's
+// IP address and user agent. entityType and entityID name what was acted
+// on, if anything.
+func (r *Recorder) Record(c *gin.Context, userID uuid.UUID, action, entityType, entityID string, details map[string]interface{}) {
+	if r == nil {
+		return
+	}
+	entry := &Entry{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Details:    details,
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		CreatedAt:  time.Now(),
+	}
+	// The action already happened, so record it even if the client has gone
+	ctx := context.WithoutCancel(c.Request.Context())
+	if err := r.repo.Record(ctx, entry); err != nil {
+		log.Printf("Failed to record %s activity for user %s: %v", action, userID, err)
+	}
+}
+
+// Device describes the client behind a user agent
+type Device struct {
+	Browser string `json:"browser"`
+	OS      string `json:"os"`
+	Mobile  bool   `json:"mobile"`
+}
+
+// browserMarkers and osMarkers are checked in order, since most user agents
+// also name the browsers and systems theirs descend from
+var (
+	browserMarkers = []struct{ marker, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"FxiOS/", "Firefox"},
+		{"CriOS/", "Chrome"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"okhttp", "Android app"},
+		{"CFNetwork", "iOS app"},
+		{"curl/", "curl"},
+	}
+	osMarkers = []struct{ marker, name string }{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Macintosh", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// DescribeDevice reads the browser and operating system from a user agent.
+// Parts it does not recognize are "Unknown".
+func DescribeDevice(userAgent string) Device {
+	device := Device{Browser: "Unknown", OS: "Unknown"}
+	for _, m := range browserMarkers {
+		if strings.Contains(userAgent, m.marker) {
+			device.Browser = m.name
+			break
+		}
+	}
+	for _, m := range osMarkers {
+		if strings.Contains(userAgent, m.marker) {
+			device.OS = m.name
+			break
+		}
+	}
+	device.Mobile = strings.Contains(userAgent, "Mobile") || device.OS == "iOS" || device.OS == "Android"
+	return device
+}
@@ -0,0 +1,56 @@
+package activity
+
+import "testing"
+
+func TestDescribeDevice(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      Device
+	}{
+		{
+			name:      "chrome on macOS",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+			want:      Device{Browser: "Chrome", OS: "macOS"},
+		},
+		{
+			name:      "safari on iPhone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+			want:      Device{Browser: "Safari", OS: "iOS", Mobile: true},
+		},
+		{
+			name:      "edge on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36 Edg/126.0.0.0",
+			want:      Device{Browser: "Edge", OS: "Windows"},
+		},
+		{
+			name:      "firefox on Android",
+			userAgent: "Mozilla/5.0 (Android 14; Mobile; rv:127.0) Gecko/127.0 Firefox/127.0",
+			want:      Device{Browser: "Firefox", OS: "Android", Mobile: true},
+		},
+		{
+			name:      "chrome on Linux",
+			userAgent: "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+			want:      Device{Browser: "Chrome", OS: "Linux"},
+		},
+		{
+			name:      "empty",
+			userAgent: "",
+			want:      Device{Browser: "Unknown", OS: "Unknown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DescribeDevice(tt.userAgent); got != tt.want {
+				t.Errorf("DescribeDevice() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecorder_NilDiscards(t *testing.T) {
+	var r *Recorder
+	// Must not panic without a request
+	r.Record(nil, [16]byte{}, ActionLogin, "", "", nil)
+}
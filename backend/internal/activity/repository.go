@@ -0,0 +1,124 @@
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// maxListLimit bounds how many entries one List call returns
+const maxListLimit = 100
+
+// PostgresRepository stores activity in the audit_logs table
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new activity repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// Record stores an entry. Entity IDs that are not UUIDs are kept in the
+// details.
+func (r *PostgresRepository) Record(ctx context.Context, entry *Entry) error {
+	entityID := entry.EntityID
+	if _, err := uuid.Parse(entityID); entityID != "" && err != nil {
+		if entry.Details == nil {
+			entry.Details = map[string]interface{}{}
+		}
+		entry.Details["entity_id"] = entityID
+		entityID = ""
+	}
+
+	var details []byte
+	if len(entry.Details) > 0 {
+		var err error
+		details, err = json.Marshal(entry.Details)
+		if err != nil {
+			return fmt.Errorf("failed to marshal activity details: %w", err)
+		}
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_logs (id, user_id, action, entity_type, entity_id, new_values, ip_address, user_agent, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, '')::uuid, $6, NULLIF($7, '')::inet, NULLIF($8, ''), $9)`,
+		entry.ID, entry.UserID, entry.Action, entry.EntityType, entityID, details, entry.IPAddress, entry.UserAgent, entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record activity: %w", err)
+	}
+	return nil
+}
+
+// List returns a user's entries matching the filter, newest first
+func (r *PostgresRepository) List(ctx context.Context, userID uuid.UUID, filter Filter) ([]Entry, error) {
+	conditions := []string{"user_id = $1"}
+	args := []interface{}{userID}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if len(filter.Actions) > 0 {
+		add("action = ANY($%d)", pq.Array(filter.Actions))
+	}
+	if !filter.Since.IsZero() {
+		add("created_at >= $%d", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		add("created_at < $%d", filter.Until)
+	}
+	if !filter.Before.IsZero() {
+		add("created_at < $%d", filter.Before)
+	}
+	if filter.Query != "" {
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter.Query) + "%"
+		add(`(action ILIKE $%[1]d OR entity_type ILIKE $%[1]d OR user_agent ILIKE $%[1]d
+			OR host(ip_address) ILIKE $%[1]d OR new_values::text ILIKE $%[1]d)`, pattern)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > maxListLimit {
+		limit = maxListLimit
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT id, action, COALESCE(entity_type, ''), COALESCE(entity_id::text, ''), new_values,
+			COALESCE(host(ip_address), ''), COALESCE(user_agent, ''), created_at
+		FROM audit_logs
+		WHERE %s
+		ORDER BY created_at DESC, id
+		LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query activity: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []Entry
+	for rows.Next() {
+		var e Entry
+		var details []byte
+		if err := rows.Scan(&e.ID, &e.Action, &e.EntityType, &e.EntityID, &details, &e.IPAddress, &e.UserAgent, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan activity: %w", err)
+		}
+		if len(details) > 0 {
+			if err := json.Unmarshal(details, &e.Details); err != nil {
+				return nil, fmt.Errorf("failed to decode activity details: %w", err)
+			}
+		}
+		e.UserID = userID
+		e.Device = DescribeDevice(e.UserAgent)
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,148 @@
+package analytics
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/integrations/platform"
+)
+
+// OriginKeeper is a player kept from last season, taken with a draft pick
+const OriginKeeper = "keeper"
+
+// PlayerAcquisition is how a rostered player came to be on their team
+type PlayerAcquisition struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Position   string `json:"position"`
+	TeamID     int    `json:"team_id"`
+	// Source is an origin: draft, keeper, waiver, free_agent, trade or unknown
+	Source string `json:"source"`
+	// DraftRound and DraftPick (overall) are set for drafted players
+	DraftRound int `json:"draft_round,omitempty"`
+	DraftPick  int `json:"draft_pick,omitempty"`
+	// Week is the first synced week the player was on the team
+	Week int `json:"week"`
+	// Bid is the FAAB spent on a waiver claim
+	Bid           int    `json:"bid,omitempty"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	// Points are what the player has scored for this team this season
+	Points float64 `json:"points"`
+}
+
+// SourceBreakdown is the part of a roster from one acquisition source
+type SourceBreakdown struct {
+	Players int     `json:"players"`
+	Points  float64 `json:"points"`
+	// PointsShare is the share of the roster's points, 0-1
+	PointsShare float64 `json:"points_share"`
+}
+
+// RosterConstruction summarizes how a team's current roster was built
+type RosterConstruction struct {
+	TeamID  int `json:"team_id"`
+	Players int `json:"players"`
+	// Sources breaks the roster down by acquisition source
+	Sources map[string]SourceBreakdown `json:"sources"`
+	// DraftedShare is the share of the roster the team drafted or kept
+	DraftedShare  float64 `json:"drafted_share"`
+	AvgDraftRound float64 `json:"avg_draft_round,omitempty"`
+	// WaiverSpend is the FAAB spent on players still on the roster
+	WaiverSpend int `json:"waiver_spend"`
+}
+
+// AttributeAcquisitions tags each player on the team at the end of a roster
+// history with their source, adding the draft round for drafted players and
+// the bid for waiver claims. A player the draft results give to the team
+// counts as drafted even if the history could not tell. points are the
+// team's season points per player ID.
+func AttributeAcquisitions(history *RosterHistory, draft []platform.DraftPick, transactions []espn.Transaction, points map[string]float64) []PlayerAcquisition {
+	teamID := strconv.Itoa(history.TeamID)
+	picks := make(map[string]platform.DraftPick)
+	for _, p := range draft {
+		if p.TeamID == teamID {
+			picks[p.PlayerID] = p
+		}
+	}
+	bids := make(map[string]int)
+	for _, t := range transactions {
+		bids[t.ID] = t.BidAmount
+	}
+
+	acquisitions := []PlayerAcquisition{}
+	for _, s := range history.Players {
+		if s.ReleasedWeek != 0 {
+			continue
+		}
+		a := PlayerAcquisition{
+			PlayerID:      s.PlayerID,
+			PlayerName:    s.PlayerName,
+			Position:      s.Position,
+			TeamID:        history.TeamID,
+			Source:        s.Origin,
+			Week:          s.AcquiredWeek,
+			TransactionID: s.TransactionID,
+			Points:        points[s.PlayerID],
+		}
+		if pick, ok := picks[s.PlayerID]; ok && (s.Origin == OriginDraft || s.Origin == OriginUnknown) {
+			a.Source = OriginDraft
+			if pick.Keeper {
+				a.Source = OriginKeeper
+			}
+			a.DraftRound, a.DraftPick = pick.Round, pick.Overall
+		}
+		if a.Source == OriginWaiver {
+			a.Bid = bids[s.TransactionID]
+		}
+		acquisitions = append(acquisitions, a)
+	}
+
+	sort.Slice(acquisitions, func(i, j int) bool {
+		return acquisitions[i].Points > acquisitions[j].Points
+	})
+	return acquisitions
+}
+
+// SummarizeRosterConstruction totals a team's acquisitions by source
+func SummarizeRosterConstruction(teamID int, acquisitions []PlayerAcquisition) RosterConstruction {
+	construction := RosterConstruction{
+		TeamID:  teamID,
+		Players: len(acquisitions),
+		Sources: make(map[string]SourceBreakdown),
+	}
+
+	var total float64
+	var drafted, withRound, rounds int
+	for _, a := range acquisitions {
+		b := construction.Sources[a.Source]
+		b.Players++
+		b.Points += a.Points
+		construction.Sources[a.Source] = b
+		total += a.Points
+
+		if a.Source == OriginDraft || a.Source == OriginKeeper {
+			drafted++
+		}
+		if a.DraftRound > 0 {
+			withRound++
+			rounds += a.DraftRound
+		}
+		construction.WaiverSpend += a.Bid
+	}
+
+	for source, b := range construction.Sources {
+		b.Points = round2(b.Points)
+		if total > 0 {
+			b.PointsShare = round2(b.Points / total)
+		}
+		construction.Sources[source] = b
+	}
+	if len(acquisitions) > 0 {
+		construction.DraftedShare = round2(float64(drafted) / float64(len(acquisitions)))
+	}
+	if withRound > 0 {
+		construction.AvgDraftRound = round2(float64(rounds) / float64(withRound))
+	}
+	return construction
+}
@@ -0,0 +1,456 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+)
+
+const (
+	// recentWeeks is how many weeks count as recent form in power rankings
+	recentWeeks = 3
+	// defaultPlayoffTeams is used when the league settings omit playoff size
+	defaultPlayoffTeams = 4
+)
+
+// Award names
+const (
+	AwardTopScorer  = "top_scorer"
+	AwardLowScorer  = "low_scorer"
+	AwardBlowout    = "biggest_blowout"
+	AwardClosestWin = "closest_win"
+	AwardUnluckiest = "unluckiest"
+)
+
+// game is one team's side of a completed matchup
+type game struct {
+	week           int
+	teamID         int
+	points         float64
+	opponentID     int
+	opponentPoints float64
+}
+
+// Compute builds standings, power rankings, records, the latest week's awards
+// and playoff odds from a league's season schedule. Only regular season
+// matchups count. Playoff odds come from simulations runs of the remaining
+// schedule, drawing scores from each team's season distribution; zero
+// simulations skips them.
+func Compute(info *espn.LeagueInfo, schedule []espn.Matchup, simulations int, rng *rand.Rand) *LeagueAnalytics {
+	names := teamNames(info.Teams)
+	games, remaining, week := regularSeason(schedule)
+
+	standings := computeStandings(names, games)
+
+	result := &LeagueAnalytics{
+		Season:        info.Season,
+		Week:          week,
+		Standings:     standings,
+		PowerRankings: computePowerRankings(names, games, standings, week),
+		Records:       computeRecords(names, games),
+		Awards:        computeAwards(names, games, week),
+		PlayoffOdds:   []PlayoffOdds{},
+		ComputedAt:    time.Now(),
+	}
+
+	if simulations > 0 {
+		playoffTeams := info.Settings.PlayoffSettings.PlayoffTeams
+		if playoffTeams <= 0 {
+			playoffTeams = defaultPlayoffTeams
+		}
+		result.PlayoffOdds = simulatePlayoffOdds(names, games, standings, remaining, playoffTeams, simulations, rng)
+	}
+
+	return result
+}
+
+// regularSeason splits a schedule's regular season into both sides of each
+// completed matchup and the matchups still to play, returning the latest
+// completed week
+func regularSeason(schedule []espn.Matchup) ([]game, []espn.Matchup, int) {
+	var games []game
+	var remaining []espn.Matchup
+	week := 0
+	for _, m := range schedule {
+		if m.IsPlayoffs {
+			continue
+		}
+		if !m.IsComplete {
+			remaining = append(remaining, m)
+			continue
+		}
+		games = append(games,
+			game{week: m.Week, teamID: m.HomeTeamID, points: m.HomeScore, opponentID: m.AwayTeamID, opponentPoints: m.AwayScore},
+			game{week: m.Week, teamID: m.AwayTeamID, points: m.AwayScore, opponentID: m.HomeTeamID, opponentPoints: m.HomeScore},
+		)
+		if m.Week > week {
+			week = m.Week
+		}
+	}
+	return games, remaining, week
+}
+
+// teamNames maps team IDs to display names
+func teamNames(teams []espn.Team) map[int]string {
+	names := make(map[int]string, len(teams))
+	for _, t := range teams {
+		name := strings.TrimSpace(t.FullName + " " + t.Nickname)
+		if name == "" {
+			name = t.Name
+		}
+		names[t.ID] = name
+	}
+	return names
+}
+
+// computeStandings ranks teams by win percentage, then points scored
+func computeStandings(names map[int]string, games []game) []TeamStanding {
+	byTeam := make(map[int]*TeamStanding, len(names))
+	for id, name := range names {
+		byTeam[id] = &TeamStanding{TeamID: id, TeamName: name}
+	}
+
+	for _, g := range games {
+		s, ok := byTeam[g.teamID]
+		if !ok {
+			s = &TeamStanding{TeamID: g.teamID, TeamName: names[g.teamID]}
+			byTeam[g.teamID] = s
+		}
+		switch {
+		case g.points > g.opponentPoints:
+			s.Wins++
+		case g.points < g.opponentPoints:
+			s.Losses++
+		default:
+			s.Ties++
+		}
+		s.PointsFor += g.points
+		s.PointsAgainst += g.opponentPoints
+	}
+
+	standings := make([]TeamStanding, 0, len(byTeam))
+	for _, s := range byTeam {
+		if played := s.Wins + s.Losses + s.Ties; played > 0 {
+			s.WinPct = round3((float64(s.Wins) + 0.5*float64(s.Ties)) / float64(played))
+		}
+		s.PointsFor = round2(s.PointsFor)
+		s.PointsAgainst = round2(s.PointsAgainst)
+		standings = append(standings, *s)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].WinPct != standings[j].WinPct {
+			return standings[i].WinPct > standings[j].WinPct
+		}
+		if standings[i].PointsFor != standings[j].PointsFor {
+			return standings[i].PointsFor > standings[j].PointsFor
+		}
+		return standings[i].TeamID < standings[j].TeamID
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+
+	return standings
+}
+
+// computePowerRankings scores teams on their all-play record for the season
+// (60%) and the last few weeks (20%), plus their actual win percentage (20%)
+func computePowerRankings(names map[int]string, games []game, standings []TeamStanding, week int) []PowerRanking {
+	byWeek := make(map[int][]game)
+	for _, g := range games {
+		byWeek[g.week] = append(byWeek[g.week], g)
+	}
+
+	type allPlay struct {
+		wins, losses             int
+		recentWins, recentLosses int
+		recentPoints             float64
+		recentGames              int
+	}
+	records := make(map[int]*allPlay)
+	for _, s := range standings {
+		records[s.TeamID] = &allPlay{}
+	}
+
+	for w, weekGames := range byWeek {
+		recent := w > week-recentWeeks
+		for _, g := range weekGames {
+			r := records[g.teamID]
+			for _, other := range weekGames {
+				if other.teamID == g.teamID {
+					continue
+				}
+				switch {
+				case g.points > other.points:
+					r.wins++
+					if recent {
+						r.recentWins++
+					}
+				case g.points < other.points:
+					r.losses++
+					if recent {
+						r.recentLosses++
+					}
+				}
+			}
+			if recent {
+				r.recentPoints += g.points
+				r.recentGames++
+			}
+		}
+	}
+
+	rankings := make([]PowerRanking, 0, len(standings))
+	for _, s := range standings {
+		r := records[s.TeamID]
+		score := 0.6*pct(r.wins, r.losses) + 0.2*pct(r.recentWins, r.recentLosses) + 0.2*s.WinPct
+
+		ranking := PowerRanking{
+			TeamID:        s.TeamID,
+			TeamName:      s.TeamName,
+			Score:         round2(100 * score),
+			AllPlayWins:   r.wins,
+			AllPlayLosses: r.losses,
+		}
+		if r.recentGames > 0 {
+			ranking.RecentAvg = round2(r.recentPoints / float64(r.recentGames))
+		}
+		rankings = append(rankings, ranking)
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		return rankings[i].Score > rankings[j].Score
+	})
+	for i := range rankings {
+		rankings[i].Rank = i + 1
+	}
+
+	return rankings
+}
+
+// computeRecords finds the season's single-game extremes
+func computeRecords(names map[int]string, games []game) LeagueRecords {
+	var records LeagueRecords
+	for _, g := range games {
+		rec := gameRecord(names, g)
+		if records.HighScore == nil || g.points > records.HighScore.Points {
+			records.HighScore = rec
+		}
+		if records.LowScore == nil || g.points < records.LowScore.Points {
+			records.LowScore = rec
+		}
+		if rec.Margin <= 0 {
+			continue
+		}
+		if records.BiggestBlowout == nil || rec.Margin > records.BiggestBlowout.Margin {
+			records.BiggestBlowout = rec
+		}
+		if records.ClosestGame == nil || rec.Margin < records.ClosestGame.Margin {
+			records.ClosestGame = rec
+		}
+	}
+	return records
+}
+
+// computeAwards hands out superlatives for a week's matchups
+func computeAwards(names map[int]string, games []game, week int) []Award {
+	var weekGames []game
+	for _, g := range games {
+		if g.week == week {
+			weekGames = append(weekGames, g)
+		}
+	}
+	if len(weekGames) == 0 {
+		return []Award{}
+	}
+
+	var top, low, blowout, closest, unlucky *game
+	for i := range weekGames {
+		g := &weekGames[i]
+		margin := g.points - g.opponentPoints
+		if top == nil || g.points > top.points {
+			top = g
+		}
+		if low == nil || g.points < low.points {
+			low = g
+		}
+		if margin > 0 {
+			if blowout == nil || margin > blowout.points-blowout.opponentPoints {
+				blowout = g
+			}
+			if closest == nil || margin < closest.points-closest.opponentPoints {
+				closest = g
+			}
+		}
+		if margin < 0 && (unlucky == nil || g.points > unlucky.points) {
+			unlucky = g
+		}
+	}
+
+	awards := []Award{
+		{Award: AwardTopScorer, TeamID: top.teamID, TeamName: names[top.teamID], Value: round2(top.points),
+			Description: fmt.Sprintf("Led the league with %.2f points", top.points)},
+		{Award: AwardLowScorer, TeamID: low.teamID, TeamName: names[low.teamID], Value: round2(low.points),
+			Description: fmt.Sprintf("Managed only %.2f points", low.points)},
+	}
+	if blowout != nil {
+		margin := blowout.points - blowout.opponentPoints
+		awards = append(awards, Award{Award: AwardBlowout, TeamID: blowout.teamID, TeamName: names[blowout.teamID], Value: round2(margin),
+			Description: fmt.Sprintf("Beat %s by %.2f", names[blowout.opponentID], margin)})
+	}
+	if closest != nil {
+		margin := closest.points - closest.opponentPoints
+		awards = append(awards, Award{Award: AwardClosestWin, TeamID: closest.teamID, TeamName: names[closest.teamID], Value: round2(margin),
+			Description: fmt.Sprintf("Edged %s by %.2f", names[closest.opponentID], margin)})
+	}
+	if unlucky != nil {
+		awards = append(awards, Award{Award: AwardUnluckiest, TeamID: unlucky.teamID, TeamName: names[unlucky.teamID], Value: round2(unlucky.points),
+			Description: fmt.Sprintf("Scored %.2f and still lost", unlucky.points)})
+	}
+
+	return awards
+}
+
+// simulatePlayoffOdds plays out the remaining regular season. Teams with
+// fewer than two games use the league-wide scoring distribution.
+func simulatePlayoffOdds(names map[int]string, games []game, standings []TeamStanding, remaining []espn.Matchup, playoffTeams, simulations int, rng *rand.Rand) []PlayoffOdds {
+	scores := make(map[int][]float64)
+	var all []float64
+	for _, g := range games {
+		scores[g.teamID] = append(scores[g.teamID], g.points)
+		all = append(all, g.points)
+	}
+	leagueMean, leagueStd := meanStd(all)
+
+	type dist struct{ mean, std float64 }
+	dists := make(map[int]dist, len(standings))
+	for _, s := range standings {
+		if len(scores[s.TeamID]) < 2 {
+			dists[s.TeamID] = dist{leagueMean, leagueStd}
+			continue
+		}
+		mean, std := meanStd(scores[s.TeamID])
+		dists[s.TeamID] = dist{mean, std}
+	}
+
+	type simTeam struct {
+		id     int
+		wins   float64
+		points float64
+	}
+	made := make(map[int]int, len(standings))
+	totalWins := make(map[int]float64, len(standings))
+	teams := make([]simTeam, len(standings))
+
+	for sim := 0; sim < simulations; sim++ {
+		index := make(map[int]int, len(standings))
+		for i, s := range standings {
+			teams[i] = simTeam{id: s.TeamID, wins: float64(s.Wins) + 0.5*float64(s.Ties), points: s.PointsFor}
+			index[s.TeamID] = i
+		}
+
+		for _, m := range remaining {
+			home, away := dists[m.HomeTeamID], dists[m.AwayTeamID]
+			homeScore := home.mean + home.std*rng.NormFloat64()
+			awayScore := away.mean + away.std*rng.NormFloat64()
+
+			hi, hok := index[m.HomeTeamID]
+			ai, aok := index[m.AwayTeamID]
+			if !hok || !aok {
+				continue
+			}
+			teams[hi].points += homeScore
+			teams[ai].points += awayScore
+			switch {
+			case homeScore > awayScore:
+				teams[hi].wins++
+			case awayScore > homeScore:
+				teams[ai].wins++
+			default:
+				teams[hi].wins += 0.5
+				teams[ai].wins += 0.5
+			}
+		}
+
+		sort.Slice(teams, func(i, j int) bool {
+			if teams[i].wins != teams[j].wins {
+				return teams[i].wins > teams[j].wins
+			}
+			return teams[i].points > teams[j].points
+		})
+		for i, t := range teams {
+			if i < playoffTeams {
+				made[t.id]++
+			}
+			totalWins[t.id] += t.wins
+		}
+	}
+
+	odds := make([]PlayoffOdds, 0, len(standings))
+	for _, s := range standings {
+		odds = append(odds, PlayoffOdds{
+			TeamID:        s.TeamID,
+			TeamName:      names[s.TeamID],
+			Probability:   round3(float64(made[s.TeamID]) / float64(simulations)),
+			ProjectedWins: round2(totalWins[s.TeamID] / float64(simulations)),
+		})
+	}
+	sort.SliceStable(odds, func(i, j int) bool {
+		return odds[i].Probability > odds[j].Probability
+	})
+
+	return odds
+}
+
+func gameRecord(names map[int]string, g game) *GameRecord {
+	return &GameRecord{
+		Week:           g.week,
+		TeamID:         g.teamID,
+		TeamName:       names[g.teamID],
+		Points:         round2(g.points),
+		OpponentID:     g.opponentID,
+		OpponentName:   names[g.opponentID],
+		OpponentPoints: round2(g.opponentPoints),
+		Margin:         round2(g.points - g.opponentPoints),
+	}
+}
+
+func meanStd(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(values)-1))
+}
+
+func pct(wins, losses int) float64 {
+	if wins+losses == 0 {
+		return 0
+	}
+	return float64(wins) / float64(wins+losses)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
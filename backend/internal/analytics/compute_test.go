@@ -0,0 +1,98 @@
+package analytics
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLeague() (*espn.LeagueInfo, []espn.Matchup) {
+	info := &espn.LeagueInfo{
+		ID:     "12345",
+		Season: 2023,
+		Settings: espn.LeagueSettings{
+			PlayoffSettings: espn.PlayoffSettings{PlayoffTeams: 2},
+		},
+		Teams: []espn.Team{
+			{ID: 1, FullName: "Team", Nickname: "Alpha"},
+			{ID: 2, FullName: "Team", Nickname: "Beta"},
+			{ID: 3, FullName: "Team", Nickname: "Gamma"},
+			{ID: 4, Name: "Delta"},
+		},
+	}
+	schedule := []espn.Matchup{
+		{Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeScore: 120, AwayScore: 100, IsComplete: true},
+		{Week: 1, HomeTeamID: 3, AwayTeamID: 4, HomeScore: 90, AwayScore: 95, IsComplete: true},
+		{Week: 2, HomeTeamID: 1, AwayTeamID: 3, HomeScore: 140, AwayScore: 130, IsComplete: true},
+		{Week: 2, HomeTeamID: 2, AwayTeamID: 4, HomeScore: 105, AwayScore: 80, IsComplete: true},
+		{Week: 3, HomeTeamID: 1, AwayTeamID: 4},
+		{Week: 3, HomeTeamID: 2, AwayTeamID: 3},
+		{Week: 4, HomeTeamID: 1, AwayTeamID: 2, IsPlayoffs: true},
+	}
+	return info, schedule
+}
+
+func TestCompute(t *testing.T) {
+	info, schedule := testLeague()
+
+	result := Compute(info, schedule, 2000, rand.New(rand.NewSource(1)))
+	assert.Equal(t, 2023, result.Season)
+	assert.Equal(t, 2, result.Week)
+
+	require.Len(t, result.Standings, 4)
+	var order []int
+	for _, s := range result.Standings {
+		order = append(order, s.TeamID)
+	}
+	// Teams 2 and 4 are both 1-1, so points for breaks the tie
+	assert.Equal(t, []int{1, 2, 4, 3}, order)
+	assert.Equal(t, "Team Alpha", result.Standings[0].TeamName)
+	assert.Equal(t, "Delta", result.Standings[2].TeamName)
+	assert.Equal(t, 260.0, result.Standings[0].PointsFor)
+
+	require.Len(t, result.PowerRankings, 4)
+	assert.Equal(t, 1, result.PowerRankings[0].TeamID)
+	assert.Equal(t, 100.0, result.PowerRankings[0].Score)
+	assert.Equal(t, 6, result.PowerRankings[0].AllPlayWins)
+	// Gamma is winless but outscored Delta across the league
+	assert.Equal(t, 3, result.PowerRankings[2].TeamID)
+	assert.Equal(t, 26.67, result.PowerRankings[2].Score)
+
+	require.NotNil(t, result.Records.HighScore)
+	assert.Equal(t, 140.0, result.Records.HighScore.Points)
+	assert.Equal(t, 80.0, result.Records.LowScore.Points)
+	assert.Equal(t, 2, result.Records.BiggestBlowout.TeamID)
+	assert.Equal(t, 25.0, result.Records.BiggestBlowout.Margin)
+	assert.Equal(t, 4, result.Records.ClosestGame.TeamID)
+	assert.Equal(t, 5.0, result.Records.ClosestGame.Margin)
+
+	awards := make(map[string]Award)
+	for _, a := range result.Awards {
+		awards[a.Award] = a
+	}
+	assert.Equal(t, 1, awards[AwardTopScorer].TeamID)
+	assert.Equal(t, 4, awards[AwardLowScorer].TeamID)
+	assert.Equal(t, 2, awards[AwardBlowout].TeamID)
+	assert.Equal(t, 1, awards[AwardClosestWin].TeamID)
+	assert.Equal(t, 3, awards[AwardUnluckiest].TeamID)
+
+	require.Len(t, result.PlayoffOdds, 4)
+	assert.Equal(t, 1, result.PlayoffOdds[0].TeamID)
+	var total float64
+	for _, o := range result.PlayoffOdds {
+		total += o.Probability
+	}
+	// Exactly two teams make it in every simulation
+	assert.InDelta(t, 2.0, total, 0.01)
+}
+
+func TestComputeWithoutSimulations(t *testing.T) {
+	info, schedule := testLeague()
+
+	result := Compute(info, schedule, 0, nil)
+	assert.Empty(t, result.PlayoffOdds)
+	assert.Len(t, result.Standings, 4)
+}
@@ -0,0 +1,207 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// flexPositions are the positions a FLEX slot accepts
+var flexPositions = map[string]bool{"RB": true, "WR": true, "TE": true}
+
+// LineupPlayer is a rostered player who can be put in a lineup
+type LineupPlayer struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	// Positions are the positions the player is eligible to start at
+	Positions       []string `json:"positions"`
+	ProjectedPoints float64  `json:"projected_points"`
+	// LineupSlot is where the player is now, BE and IR for the bench
+	LineupSlot string `json:"lineup_slot"`
+}
+
+// LineupAssignment is a player put in a starting slot
+type LineupAssignment struct {
+	Slot            string  `json:"slot"`
+	PlayerID        string  `json:"player_id"`
+	PlayerName      string  `json:"player_name"`
+	ProjectedPoints float64 `json:"projected_points"`
+}
+
+// LineupIssue is something wrong with a lineup as set
+type LineupIssue struct {
+	Slot       string `json:"slot"`
+	PlayerID   string `json:"player_id,omitempty"`
+	PlayerName string `json:"player_name,omitempty"`
+	Problem    string `json:"problem"`
+}
+
+// LineupReport compares a team's lineup with the best one it could start
+type LineupReport struct {
+	Issues        []LineupIssue      `json:"issues"`
+	Optimal       []LineupAssignment `json:"optimal"`
+	CurrentPoints float64            `json:"current_points"`
+	OptimalPoints float64            `json:"optimal_points"`
+	Gain          float64            `json:"gain"`
+}
+
+// LineupSlots lists a league's starting slots in lineup order
+func LineupSlots(roster models.RosterRequirements) []string {
+	var slots []string
+	for _, s := range []struct {
+		slot  string
+		count int
+	}{
+		{"QB", roster.QB}, {"RB", roster.RB}, {"WR", roster.WR}, {"TE", roster.TE},
+		{"FLEX", roster.FLEX}, {"DST", roster.DST}, {"K", roster.K},
+	} {
+		for i := 0; i < s.count; i++ {
+			slots = append(slots, s.slot)
+		}
+	}
+	return slots
+}
+
+// SlotAccepts reports whether a player eligible at positions can start in slot
+func SlotAccepts(slot string, positions []string) bool {
+	for _, p := range positions {
+		if p == slot || (slot == "FLEX" && flexPositions[p]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateLineup checks a lineup as set: every starter must be eligible for
+// their slot, no slot may be over-filled and every slot should be filled
+func ValidateLineup(roster models.RosterRequirements, players []LineupPlayer) []LineupIssue {
+	issues := []LineupIssue{}
+
+	open := make(map[string]int)
+	for _, slot := range LineupSlots(roster) {
+		open[slot]++
+	}
+
+	for _, p := range players {
+		if benchSlot(p.LineupSlot) {
+			continue
+		}
+		switch {
+		case open[p.LineupSlot] == 0 && !inLineup(roster, p.LineupSlot):
+			issues = append(issues, LineupIssue{Slot: p.LineupSlot, PlayerID: p.PlayerID, PlayerName: p.PlayerName,
+				Problem: fmt.Sprintf("league has no %s slot", p.LineupSlot)})
+			continue
+		case open[p.LineupSlot] == 0:
+			issues = append(issues, LineupIssue{Slot: p.LineupSlot, PlayerID: p.PlayerID, PlayerName: p.PlayerName,
+				Problem: fmt.Sprintf("too many players in %s", p.LineupSlot)})
+			continue
+		}
+		open[p.LineupSlot]--
+		if !SlotAccepts(p.LineupSlot, p.Positions) {
+			issues = append(issues, LineupIssue{Slot: p.LineupSlot, PlayerID: p.PlayerID, PlayerName: p.PlayerName,
+				Problem: fmt.Sprintf("not eligible at %s", p.LineupSlot)})
+		}
+	}
+
+	for _, slot := range LineupSlots(roster) {
+		if open[slot] > 0 {
+			issues = append(issues, LineupIssue{Slot: slot, Problem: "slot is empty"})
+			open[slot]--
+		}
+	}
+	return issues
+}
+
+// OptimizeLineup picks the starters with the most projected points. Players
+// are taken in order of projection, each placed if the slots can be
+// rearranged to fit them, which gives the best lineup even when players are
+// eligible at several positions. Players on IR are left out.
+func OptimizeLineup(roster models.RosterRequirements, players []LineupPlayer) []LineupAssignment {
+	slots := LineupSlots(roster)
+
+	candidates := make([]LineupPlayer, 0, len(players))
+	for _, p := range players {
+		if p.LineupSlot != "IR" {
+			candidates = append(candidates, p)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ProjectedPoints > candidates[j].ProjectedPoints
+	})
+
+	// filled[slot] is the index of the candidate in the slot, or -1
+	filled := make([]int, len(slots))
+	for i := range filled {
+		filled[i] = -1
+	}
+	var place func(player int, seen []bool) bool
+	place = func(player int, seen []bool) bool {
+		for slot := range slots {
+			if seen[slot] || !SlotAccepts(slots[slot], candidates[player].Positions) {
+				continue
+			}
+			seen[slot] = true
+			if filled[slot] == -1 || place(filled[slot], seen) {
+				filled[slot] = player
+				return true
+			}
+		}
+		return false
+	}
+	for player := range candidates {
+		place(player, make([]bool, len(slots)))
+	}
+
+	lineup := make([]LineupAssignment, 0, len(slots))
+	for slot, player := range filled {
+		if player == -1 {
+			lineup = append(lineup, LineupAssignment{Slot: slots[slot]})
+			continue
+		}
+		p := candidates[player]
+		lineup = append(lineup, LineupAssignment{
+			Slot:            slots[slot],
+			PlayerID:        p.PlayerID,
+			PlayerName:      p.PlayerName,
+			ProjectedPoints: p.ProjectedPoints,
+		})
+	}
+	return lineup
+}
+
+// AnalyzeLineup validates a team's lineup and compares its projection with
+// the optimal lineup's
+func AnalyzeLineup(roster models.RosterRequirements, players []LineupPlayer) *LineupReport {
+	report := &LineupReport{
+		Issues:  ValidateLineup(roster, players),
+		Optimal: OptimizeLineup(roster, players),
+	}
+	for _, p := range players {
+		if !benchSlot(p.LineupSlot) {
+			report.CurrentPoints += p.ProjectedPoints
+		}
+	}
+	for _, a := range report.Optimal {
+		report.OptimalPoints += a.ProjectedPoints
+	}
+	report.CurrentPoints = round2(report.CurrentPoints)
+	report.OptimalPoints = round2(report.OptimalPoints)
+	report.Gain = round2(report.OptimalPoints - report.CurrentPoints)
+	return report
+}
+
+// benchSlot reports whether a lineup slot does not start
+func benchSlot(slot string) bool {
+	return slot == "" || slot == "BE" || slot == "IR"
+}
+
+// inLineup reports whether the league has a slot of this kind
+func inLineup(roster models.RosterRequirements, slot string) bool {
+	for _, s := range LineupSlots(roster) {
+		if s == slot {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeLineupUsesAddedEligibility(t *testing.T) {
+	roster := models.RosterRequirements{QB: 1, RB: 1, WR: 1, TE: 1, FLEX: 1}
+	players := []LineupPlayer{
+		{PlayerID: "1", PlayerName: "Quarterback", Positions: []string{"QB"}, ProjectedPoints: 20, LineupSlot: "QB"},
+		{PlayerID: "2", PlayerName: "Runner", Positions: []string{"RB"}, ProjectedPoints: 15, LineupSlot: "RB"},
+		{PlayerID: "3", PlayerName: "Receiver", Positions: []string{"WR"}, ProjectedPoints: 14, LineupSlot: "WR"},
+		// Newly TE eligible, so the bench RB can take the flex
+		{PlayerID: "4", PlayerName: "Hybrid", Positions: []string{"WR", "TE"}, ProjectedPoints: 13, LineupSlot: "FLEX"},
+		{PlayerID: "5", PlayerName: "Blocker", Positions: []string{"TE"}, ProjectedPoints: 5, LineupSlot: "TE"},
+		{PlayerID: "6", PlayerName: "Backup", Positions: []string{"RB"}, ProjectedPoints: 12, LineupSlot: "BE"},
+		{PlayerID: "7", PlayerName: "Hurt", Positions: []string{"RB"}, ProjectedPoints: 30, LineupSlot: "IR"},
+	}
+
+	report := AnalyzeLineup(roster, players)
+	assert.Empty(t, report.Issues)
+	starters := make(map[string]string)
+	for _, a := range report.Optimal {
+		starters[a.PlayerID] = a.Slot
+	}
+	assert.Len(t, starters, 5)
+	assert.Equal(t, "TE", starters["4"])
+	assert.Contains(t, starters, "6")
+	assert.NotContains(t, starters, "5")
+	assert.NotContains(t, starters, "7")
+	assert.Equal(t, 67.0, report.CurrentPoints)
+	assert.Equal(t, 74.0, report.OptimalPoints)
+	assert.Equal(t, 7.0, report.Gain)
+}
+
+func TestValidateLineup(t *testing.T) {
+	roster := models.RosterRequirements{QB: 1, TE: 1, K: 1}
+	players := []LineupPlayer{
+		{PlayerID: "1", PlayerName: "Quarterback", Positions: []string{"QB"}, LineupSlot: "QB"},
+		{PlayerID: "2", PlayerName: "Second QB", Positions: []string{"QB"}, LineupSlot: "QB"},
+		{PlayerID: "3", PlayerName: "Receiver", Positions: []string{"WR"}, LineupSlot: "TE"},
+		{PlayerID: "4", PlayerName: "Runner", Positions: []string{"RB"}, LineupSlot: "RB"},
+	}
+
+	assert.Equal(t, []LineupIssue{
+		{Slot: "QB", PlayerID: "2", PlayerName: "Second QB", Problem: "too many players in QB"},
+		{Slot: "TE", PlayerID: "3", PlayerName: "Receiver", Problem: "not eligible at TE"},
+		{Slot: "RB", PlayerID: "4", PlayerName: "Runner", Problem: "league has no RB slot"},
+		{Slot: "K", Problem: "slot is empty"},
+	}, ValidateLineup(roster, players))
+}
@@ -0,0 +1,81 @@
+package analytics
+
+import "time"
+
+// TeamStanding is a team's regular season record
+type TeamStanding struct {
+	TeamID        int     `json:"team_id"`
+	TeamName      string  `json:"team_name"`
+	Rank          int     `json:"rank"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Ties          int     `json:"ties"`
+	WinPct        float64 `json:"win_pct"`
+	PointsFor     float64 `json:"points_for"`
+	PointsAgainst float64 `json:"points_against"`
+}
+
+// PowerRanking ranks teams by how they would fare against the whole league
+// each week, weighted toward recent weeks
+type PowerRanking struct {
+	TeamID        int     `json:"team_id"`
+	TeamName      string  `json:"team_name"`
+	Rank          int     `json:"rank"`
+	Score         float64 `json:"score"`
+	AllPlayWins   int     `json:"all_play_wins"`
+	AllPlayLosses int     `json:"all_play_losses"`
+	RecentAvg     float64 `json:"recent_avg"`
+	// RosterConstruction is how the team built its roster, when its rosters
+	// have been synced
+	RosterConstruction *RosterConstruction `json:"roster_construction,omitempty"`
+}
+
+// GameRecord is a single team score in a completed matchup
+type GameRecord struct {
+	Week           int     `json:"week"`
+	TeamID         int     `json:"team_id"`
+	TeamName       string  `json:"team_name"`
+	Points         float64 `json:"points"`
+	OpponentID     int     `json:"opponent_id"`
+	OpponentName   string  `json:"opponent_name"`
+	OpponentPoints float64 `json:"opponent_points"`
+	Margin         float64 `json:"margin"`
+}
+
+// LeagueRecords are the season's single-game records
+type LeagueRecords struct {
+	HighScore      *GameRecord `json:"high_score"`
+	LowScore       *GameRecord `json:"low_score"`
+	BiggestBlowout *GameRecord `json:"biggest_blowout"`
+	ClosestGame    *GameRecord `json:"closest_game"`
+}
+
+// Award is a weekly superlative
+type Award struct {
+	Award       string  `json:"award"`
+	TeamID      int     `json:"team_id"`
+	TeamName    string  `json:"team_name"`
+	Value       float64 `json:"value"`
+	Description string  `json:"description"`
+}
+
+// PlayoffOdds is a team's simulated chance of making the playoffs
+type PlayoffOdds struct {
+	TeamID        int     `json:"team_id"`
+	TeamName      string  `json:"team_name"`
+	Probability   float64 `json:"probability"`
+	ProjectedWins float64 `json:"projected_wins"`
+}
+
+// LeagueAnalytics is the precomputed analytics for a league as of a week
+type LeagueAnalytics struct {
+	LeagueID      string         `json:"league_id"`
+	Season        int            `json:"season"`
+	Week          int            `json:"week"`
+	Standings     []TeamStanding `json:"standings"`
+	PowerRankings []PowerRanking `json:"power_rankings"`
+	Records       LeagueRecords  `json:"records"`
+	Awards        []Award        `json:"awards"`
+	PlayoffOdds   []PlayoffOdds  `json:"playoff_odds"`
+	ComputedAt    time.Time      `json:"computed_at"`
+}
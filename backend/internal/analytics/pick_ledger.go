@@ -0,0 +1,172 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/platform"
+)
+
+// Pick trade sources
+const (
+	PickTradeTransaction  = "transaction"
+	PickTradeCommissioner = "commissioner"
+)
+
+// PickTrade is a ledger entry: a rookie pick moving from one team to
+// another, synced from a platform trade or entered by hand
+type PickTrade struct {
+	ID             int64  `json:"id"`
+	LeagueID       string `json:"league_id"`
+	Season         int    `json:"season"`
+	Round          int    `json:"round"`
+	OriginalTeamID int    `json:"original_team_id"`
+	FromTeamID     int    `json:"from_team_id"`
+	ToTeamID       int    `json:"to_team_id"`
+	Source         string `json:"source"`
+	// TransactionID is the platform's trade, for synced entries
+	TransactionID string `json:"transaction_id,omitempty"`
+	// RecordedBy is the user who entered the trade by hand
+	RecordedBy string    `json:"recorded_by,omitempty"`
+	Notes      string    `json:"notes,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// PickOwner returns the team that owns a pick, given a league's traded picks
+func PickOwner(traded []RookiePick, season, round, originalTeamID int) int {
+	for _, p := range traded {
+		if p.Season == season && p.Round == round && p.OriginalTeamID == originalTeamID {
+			return p.OwnerTeamID
+		}
+	}
+	return originalTeamID
+}
+
+// PickTradesFromTransactions lists the picks moved by a league's completed
+// trades, oldest first so that replaying them leaves each pick with its
+// latest owner. Picks with team IDs that are not numeric are skipped.
+func PickTradesFromTransactions(leagueID string, transactions []platform.Transaction) []PickTrade {
+	var trades []PickTrade
+	for _, t := range transactions {
+		if t.Type != platform.TransactionTrade || (t.Status != "" && t.Status != "COMPLETE") {
+			continue
+		}
+		for _, p := range t.Picks {
+			original, err1 := strconv.Atoi(p.OriginalTeamID)
+			from, err2 := strconv.Atoi(p.FromTeamID)
+			to, err3 := strconv.Atoi(p.ToTeamID)
+			if err1 != nil || err2 != nil || err3 != nil {
+				continue
+			}
+			trades = append(trades, PickTrade{
+				LeagueID:       leagueID,
+				Season:         p.Season,
+				Round:          p.Round,
+				OriginalTeamID: original,
+				FromTeamID:     from,
+				ToTeamID:       to,
+				Source:         PickTradeTransaction,
+				TransactionID:  t.ID,
+				RecordedAt:     t.ProcessedAt,
+			})
+		}
+	}
+
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].RecordedAt.Before(trades[j].RecordedAt)
+	})
+	return trades
+}
+
+// RecordPickTrade adds a trade to the ledger and moves the pick, in one
+// transaction. A pick traded back to its original team is no longer stored
+// as traded.
+func (r *PostgresRepository) RecordPickTrade(ctx context.Context, trade *PickTrade) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if trade.RecordedAt.IsZero() {
+		trade.RecordedAt = time.Now()
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO league_pick_trades (
+			league_id, season, round, original_team_id, from_team_id, to_team_id,
+			source, transaction_id, recorded_by, notes, recorded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, '')::uuid, NULLIF($10, ''), $11)
+		ON CONFLICT (league_id, transaction_id, season, round, original_team_id) WHERE transaction_id IS NOT NULL
+		DO NOTHING
+		RETURNING id`,
+		trade.LeagueID, trade.Season, trade.Round, trade.OriginalTeamID, trade.FromTeamID, trade.ToTeamID,
+		trade.Source, trade.TransactionID, trade.RecordedBy, trade.Notes, trade.RecordedAt,
+	).Scan(&trade.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record pick trade: %w", err)
+	}
+
+	if trade.ToTeamID == trade.OriginalTeamID {
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM league_rookie_picks WHERE league_id = $1 AND season = $2 AND round = $3 AND original_team_id = $4`,
+			trade.LeagueID, trade.Season, trade.Round, trade.OriginalTeamID,
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO league_rookie_picks (league_id, season, round, original_team_id, owner_team_id, notes, updated_at)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), CURRENT_TIMESTAMP)
+			ON CONFLICT (league_id, season, round, original_team_id) DO UPDATE SET
+				owner_team_id = EXCLUDED.owner_team_id,
+				notes = EXCLUDED.notes,
+				updated_at = CURRENT_TIMESTAMP`,
+			trade.LeagueID, trade.Season, trade.Round, trade.OriginalTeamID, trade.ToTeamID, trade.Notes,
+		)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update pick owner: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit pick trade: %w", err)
+	}
+	return true, nil
+}
+
+// GetPickTrades returns a league's ledger, oldest first. A nonzero season
+// returns only that draft's picks.
+func (r *PostgresRepository) GetPickTrades(ctx context.Context, leagueID string, season int) ([]PickTrade, error) {
+	query := `
+		SELECT id, league_id, season, round, original_team_id, from_team_id, to_team_id, source,
+			COALESCE(transaction_id, ''), COALESCE(recorded_by::text, ''), COALESCE(notes, ''), recorded_at
+		FROM league_pick_trades
+		WHERE league_id = $1 AND ($2 = 0 OR season = $2)
+		ORDER BY recorded_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pick trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []PickTrade
+	for rows.Next() {
+		var t PickTrade
+		if err := rows.Scan(
+			&t.ID, &t.LeagueID, &t.Season, &t.Round, &t.OriginalTeamID, &t.FromTeamID, &t.ToTeamID, &t.Source,
+			&t.TransactionID, &t.RecordedBy, &t.Notes, &t.RecordedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan pick trade: %w", err)
+		}
+		trades = append(trades, t)
+	}
+
+	return trades, rows.Err()
+}
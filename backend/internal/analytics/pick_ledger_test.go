@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickTradesFromTransactions(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	transactions := []platform.Transaction{
+		{
+			ID: "t2", Type: platform.TransactionTrade, Status: "COMPLETE", ProcessedAt: now,
+			Picks: []platform.TransactionPick{
+				// Team 1's 2026 second goes back to team 1
+				{Season: 2026, Round: 2, OriginalTeamID: "1", FromTeamID: "3", ToTeamID: "1"},
+			},
+		},
+		{
+			ID: "t1", Type: platform.TransactionTrade, Status: "COMPLETE", ProcessedAt: now.Add(-24 * time.Hour),
+			Picks: []platform.TransactionPick{
+				{Season: 2026, Round: 2, OriginalTeamID: "1", FromTeamID: "1", ToTeamID: "3"},
+				{Season: 2027, Round: 1, OriginalTeamID: "x", FromTeamID: "3", ToTeamID: "1"},
+			},
+		},
+		// Failed trades and other transactions move nothing
+		{
+			ID: "t3", Type: platform.TransactionTrade, Status: "FAILED", ProcessedAt: now,
+			Picks: []platform.TransactionPick{{Season: 2026, Round: 1, OriginalTeamID: "2", FromTeamID: "2", ToTeamID: "4"}},
+		},
+		{ID: "t4", Type: platform.TransactionWaiver, Status: "COMPLETE", ProcessedAt: now},
+	}
+
+	trades := PickTradesFromTransactions("league-1", transactions)
+	require.Len(t, trades, 2)
+
+	assert.Equal(t, "t1", trades[0].TransactionID)
+	assert.Equal(t, 3, trades[0].ToTeamID)
+	assert.Equal(t, PickTradeTransaction, trades[0].Source)
+	assert.Equal(t, "t2", trades[1].TransactionID)
+	assert.Equal(t, 1, trades[1].ToTeamID)
+	assert.Equal(t, "league-1", trades[1].LeagueID)
+}
+
+func TestPickOwner(t *testing.T) {
+	traded := []RookiePick{{Season: 2026, Round: 1, OriginalTeamID: 3, OwnerTeamID: 2}}
+	assert.Equal(t, 2, PickOwner(traded, 2026, 1, 3))
+	assert.Equal(t, 3, PickOwner(traded, 2026, 2, 3))
+	assert.Equal(t, 4, PickOwner(traded, 2026, 1, 4))
+}
@@ -0,0 +1,275 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+)
+
+// Recap section kinds
+const (
+	RecapScores    = "scores"
+	RecapUpsets    = "upsets"
+	RecapAwards    = "awards"
+	RecapStandings = "standings"
+)
+
+// RecapGame is a completed matchup from the recapped week
+type RecapGame struct {
+	WinnerID     int     `json:"winner_id"`
+	WinnerName   string  `json:"winner_name"`
+	WinnerPoints float64 `json:"winner_points"`
+	LoserID      int     `json:"loser_id"`
+	LoserName    string  `json:"loser_name"`
+	LoserPoints  float64 `json:"loser_points"`
+	Margin       float64 `json:"margin"`
+	// Tie is set when neither team won; the home team is listed as winner
+	Tie bool `json:"tie"`
+}
+
+// Upset is a win over a team ranked higher in the power rankings going into
+// the week
+type Upset struct {
+	Game       RecapGame `json:"game"`
+	WinnerRank int       `json:"winner_rank"`
+	LoserRank  int       `json:"loser_rank"`
+}
+
+// StandingsMove is a team's change in the standings over the week
+type StandingsMove struct {
+	TeamID       int    `json:"team_id"`
+	TeamName     string `json:"team_name"`
+	Rank         int    `json:"rank"`
+	PreviousRank int    `json:"previous_rank"`
+	// Change is positive for teams that moved up; zero in week one
+	Change int    `json:"change"`
+	Record string `json:"record"`
+}
+
+// RecapSection is one part of a recap rendered as text, for creators to read
+// out or an email to print
+type RecapSection struct {
+	Kind  string   `json:"kind"`
+	Title string   `json:"title"`
+	Lines []string `json:"lines"`
+}
+
+// WeeklyRecap is the story of a league's week, as data and as text sections
+type WeeklyRecap struct {
+	LeagueID   string          `json:"league_id"`
+	Season     int             `json:"season"`
+	Week       int             `json:"week"`
+	Headline   string          `json:"headline"`
+	Scores     []RecapGame     `json:"scores"`
+	Upsets     []Upset         `json:"upsets"`
+	Awards     []Award         `json:"awards"`
+	Standings  []StandingsMove `json:"standings"`
+	Sections   []RecapSection  `json:"sections"`
+	ComputedAt time.Time       `json:"computed_at"`
+}
+
+// Script renders the recap as plain text, headline first, then each section
+// under its title
+func (r *WeeklyRecap) Script() string {
+	var b strings.Builder
+	b.WriteString(r.Headline)
+	b.WriteString("\n")
+	for _, section := range r.Sections {
+		b.WriteString("\n")
+		b.WriteString(section.Title)
+		b.WriteString("\n")
+		for _, line := range section.Lines {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// BuildRecap recaps the latest week in a league's computed analytics.
+// Upsets and standings movement compare against the league as it stood
+// before the week. Returns nil before any week is complete.
+func BuildRecap(info *espn.LeagueInfo, schedule []espn.Matchup, result *LeagueAnalytics) *WeeklyRecap {
+	if result.Week == 0 {
+		return nil
+	}
+
+	names := teamNames(info.Teams)
+	games, _, _ := regularSeason(schedule)
+
+	var before []game
+	for _, g := range games {
+		if g.week < result.Week {
+			before = append(before, g)
+		}
+	}
+	previousStandings := computeStandings(names, before)
+	previousRanks := make(map[int]int)
+	for _, r := range computePowerRankings(names, before, previousStandings, result.Week-1) {
+		previousRanks[r.TeamID] = r.Rank
+	}
+
+	recap := &WeeklyRecap{
+		LeagueID:   result.LeagueID,
+		Season:     result.Season,
+		Week:       result.Week,
+		Scores:     recapScores(names, schedule, result.Week),
+		Upsets:     []Upset{},
+		Awards:     result.Awards,
+		Standings:  recapStandings(previousStandings, result.Standings),
+		ComputedAt: result.ComputedAt,
+	}
+
+	for _, g := range recap.Scores {
+		winnerRank, loserRank := previousRanks[g.WinnerID], previousRanks[g.LoserID]
+		if g.Tie || winnerRank == 0 || loserRank == 0 || winnerRank <= loserRank {
+			continue
+		}
+		recap.Upsets = append(recap.Upsets, Upset{Game: g, WinnerRank: winnerRank, LoserRank: loserRank})
+	}
+	// Biggest gap in the rankings first
+	sort.SliceStable(recap.Upsets, func(i, j int) bool {
+		return recap.Upsets[i].WinnerRank-recap.Upsets[i].LoserRank > recap.Upsets[j].WinnerRank-recap.Upsets[j].LoserRank
+	})
+
+	recap.Headline = recapHeadline(recap)
+	recap.Sections = recapSections(recap)
+	return recap
+}
+
+// recapScores lists the week's completed regular season matchups, highest
+// winning score first
+func recapScores(names map[int]string, schedule []espn.Matchup, week int) []RecapGame {
+	scores := []RecapGame{}
+	for _, m := range schedule {
+		if m.Week != week || m.IsPlayoffs || !m.IsComplete {
+			continue
+		}
+		winner, loser := m.HomeTeamID, m.AwayTeamID
+		winnerPoints, loserPoints := m.HomeScore, m.AwayScore
+		if m.AwayScore > m.HomeScore {
+			winner, loser = loser, winner
+			winnerPoints, loserPoints = loserPoints, winnerPoints
+		}
+		scores = append(scores, RecapGame{
+			WinnerID:     winner,
+			WinnerName:   names[winner],
+			WinnerPoints: round2(winnerPoints),
+			LoserID:      loser,
+			LoserName:    names[loser],
+			LoserPoints:  round2(loserPoints),
+			Margin:       round2(winnerPoints - loserPoints),
+			Tie:          winnerPoints == loserPoints,
+		})
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].WinnerPoints > scores[j].WinnerPoints
+	})
+	return scores
+}
+
+// recapStandings compares each team's rank with its rank before the week
+func recapStandings(previous, current []TeamStanding) []StandingsMove {
+	previousRanks := make(map[int]int, len(previous))
+	for _, s := range previous {
+		previousRanks[s.TeamID] = s.Rank
+	}
+
+	moves := make([]StandingsMove, 0, len(current))
+	for _, s := range current {
+		move := StandingsMove{
+			TeamID:       s.TeamID,
+			TeamName:     s.TeamName,
+			Rank:         s.Rank,
+			PreviousRank: previousRanks[s.TeamID],
+			Record:       record(s),
+		}
+		if move.PreviousRank > 0 {
+			move.Change = move.PreviousRank - s.Rank
+		}
+		moves = append(moves, move)
+	}
+	return moves
+}
+
+// recapHeadline leads with the biggest upset, or else the top score
+func recapHeadline(recap *WeeklyRecap) string {
+	if len(recap.Upsets) > 0 {
+		u := recap.Upsets[0]
+		return fmt.Sprintf("Week %d: No. %d %s stuns No. %d %s", recap.Week, u.WinnerRank, u.Game.WinnerName, u.LoserRank, u.Game.LoserName)
+	}
+	for _, a := range recap.Awards {
+		if a.Award == AwardTopScorer {
+			return fmt.Sprintf("Week %d: %s leads the league with %.2f", recap.Week, a.TeamName, a.Value)
+		}
+	}
+	return fmt.Sprintf("Week %d recap", recap.Week)
+}
+
+// recapSections renders the recap's data as text
+func recapSections(recap *WeeklyRecap) []RecapSection {
+	scores := RecapSection{Kind: RecapScores, Title: "Scores", Lines: []string{}}
+	for _, g := range recap.Scores {
+		verb := "beat"
+		if g.Tie {
+			verb = "tied"
+		}
+		scores.Lines = append(scores.Lines, fmt.Sprintf("%s %s %s %.2f-%.2f", g.WinnerName, verb, g.LoserName, g.WinnerPoints, g.LoserPoints))
+	}
+
+	upsets := RecapSection{Kind: RecapUpsets, Title: "Upsets", Lines: []string{}}
+	for _, u := range recap.Upsets {
+		upsets.Lines = append(upsets.Lines, fmt.Sprintf("No. %d %s upset No. %d %s %.2f-%.2f",
+			u.WinnerRank, u.Game.WinnerName, u.LoserRank, u.Game.LoserName, u.Game.WinnerPoints, u.Game.LoserPoints))
+	}
+	if len(upsets.Lines) == 0 {
+		upsets.Lines = append(upsets.Lines, "The favorites held serve")
+	}
+
+	awards := RecapSection{Kind: RecapAwards, Title: "Awards", Lines: []string{}}
+	for _, a := range recap.Awards {
+		awards.Lines = append(awards.Lines, fmt.Sprintf("%s: %s", a.TeamName, a.Description))
+	}
+
+	standings := RecapSection{Kind: RecapStandings, Title: "Standings", Lines: []string{}}
+	for _, m := range recap.Standings {
+		line := fmt.Sprintf("%d. %s (%s)", m.Rank, m.TeamName, m.Record)
+		switch {
+		case m.Change > 0:
+			line += fmt.Sprintf(", up %d from %s", m.Change, ordinal(m.PreviousRank))
+		case m.Change < 0:
+			line += fmt.Sprintf(", down %d from %s", -m.Change, ordinal(m.PreviousRank))
+		}
+		standings.Lines = append(standings.Lines, line)
+	}
+
+	return []RecapSection{scores, upsets, awards, standings}
+}
+
+// record formats a team's win-loss record, with ties only when there are any
+func record(s TeamStanding) string {
+	if s.Ties > 0 {
+		return fmt.Sprintf("%d-%d-%d", s.Wins, s.Losses, s.Ties)
+	}
+	return fmt.Sprintf("%d-%d", s.Wins, s.Losses)
+}
+
+// ordinal formats a rank as 1st, 2nd, 3rd and so on
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
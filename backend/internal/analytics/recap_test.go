@@ -0,0 +1,82 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRecap(t *testing.T) {
+	info, schedule := testLeague()
+	// Delta, third in the power rankings after week one, beats second place
+	// Beta
+	schedule[3].AwayScore = 110
+
+	result := Compute(info, schedule, 0, nil)
+	result.LeagueID = "league-1"
+	recap := BuildRecap(info, schedule, result)
+	require.NotNil(t, recap)
+
+	assert.Equal(t, "league-1", recap.LeagueID)
+	assert.Equal(t, 2, recap.Week)
+
+	require.Len(t, recap.Scores, 2)
+	assert.Equal(t, 1, recap.Scores[0].WinnerID)
+	assert.Equal(t, 10.0, recap.Scores[0].Margin)
+	assert.Equal(t, 4, recap.Scores[1].WinnerID)
+	assert.Equal(t, 2, recap.Scores[1].LoserID)
+
+	require.Len(t, recap.Upsets, 1)
+	assert.Equal(t, 4, recap.Upsets[0].Game.WinnerID)
+	assert.Equal(t, 3, recap.Upsets[0].WinnerRank)
+	assert.Equal(t, 2, recap.Upsets[0].LoserRank)
+	assert.Equal(t, "Week 2: No. 3 Delta stuns No. 2 Team Beta", recap.Headline)
+
+	// Gamma outscored Beta to climb past them
+	require.Len(t, recap.Standings, 4)
+	moves := make(map[int]StandingsMove)
+	for _, m := range recap.Standings {
+		moves[m.TeamID] = m
+	}
+	assert.Equal(t, 0, moves[1].Change)
+	assert.Equal(t, 1, moves[3].Change)
+	assert.Equal(t, -1, moves[2].Change)
+	assert.Equal(t, "0-2", moves[2].Record)
+
+	require.Len(t, recap.Sections, 4)
+	assert.Equal(t, RecapScores, recap.Sections[0].Kind)
+	assert.Equal(t, "Team Alpha beat Team Gamma 140.00-130.00", recap.Sections[0].Lines[0])
+	assert.Equal(t, "3. Team Gamma (0-2), up 1 from 4th", recap.Sections[3].Lines[2])
+
+	script := recap.Script()
+	assert.Contains(t, script, "Week 2: No. 3 Delta stuns No. 2 Team Beta\n\nScores\n")
+	assert.Contains(t, script, "No. 3 Delta upset No. 2 Team Beta 110.00-105.00")
+}
+
+func TestBuildRecap_NoUpsets(t *testing.T) {
+	info, schedule := testLeague()
+
+	result := Compute(info, schedule, 0, nil)
+	recap := BuildRecap(info, schedule, result)
+	require.NotNil(t, recap)
+
+	assert.Empty(t, recap.Upsets)
+	assert.Equal(t, "Week 2: Team Alpha leads the league with 140.00", recap.Headline)
+	assert.Equal(t, []string{"The favorites held serve"}, recap.Sections[1].Lines)
+}
+
+func TestBuildRecap_BeforeAnyGames(t *testing.T) {
+	info, schedule := testLeague()
+	for i := range schedule {
+		schedule[i].IsComplete = false
+	}
+
+	assert.Nil(t, BuildRecap(info, schedule, Compute(info, schedule, 0, nil)))
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 21: "21st", 112: "112th"} {
+		assert.Equal(t, want, ordinal(n))
+	}
+}
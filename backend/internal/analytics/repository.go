@@ -0,0 +1,134 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Repository defines the interface for precomputed league analytics storage
+type Repository interface {
+	SaveLeagueAnalytics(ctx context.Context, analytics *LeagueAnalytics) error
+	GetLeagueAnalytics(ctx context.Context, leagueID string) (*LeagueAnalytics, error)
+	SaveRecap(ctx context.Context, recap *WeeklyRecap) error
+	// GetRecap returns the recap for a week of the latest season recapped, or
+	// the latest recap when week is zero
+	GetRecap(ctx context.Context, leagueID string, week int) (*WeeklyRecap, error)
+}
+
+// PostgresRepository implements Repository for PostgreSQL
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL league analytics repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// SaveLeagueAnalytics stores a league's analytics, replacing any earlier
+// computation for the same week
+func (r *PostgresRepository) SaveLeagueAnalytics(ctx context.Context, analytics *LeagueAnalytics) error {
+	payload, err := json.Marshal(analytics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal league analytics: %w", err)
+	}
+
+	query := `
+		INSERT INTO league_analytics (league_id, season, week, payload, computed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (league_id, season, week) DO UPDATE SET
+			payload = EXCLUDED.payload,
+			computed_at = EXCLUDED.computed_at`
+
+	_, err = r.db.ExecContext(ctx, query,
+		analytics.LeagueID, analytics.Season, analytics.Week, payload, analytics.ComputedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save league analytics: %w", err)
+	}
+
+	return nil
+}
+
+// GetLeagueAnalytics returns the most recently computed analytics for a
+// league, or nil if none have been computed yet
+func (r *PostgresRepository) GetLeagueAnalytics(ctx context.Context, leagueID string) (*LeagueAnalytics, error) {
+	query := `
+		SELECT payload
+		FROM league_analytics
+		WHERE league_id = $1
+		ORDER BY computed_at DESC
+		LIMIT 1`
+
+	var payload []byte
+	err := r.db.QueryRowContext(ctx, query, leagueID).Scan(&payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league analytics: %w", err)
+	}
+
+	var analytics LeagueAnalytics
+	if err := json.Unmarshal(payload, &analytics); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal league analytics: %w", err)
+	}
+
+	return &analytics, nil
+}
+
+// SaveRecap stores a league's weekly recap, replacing any earlier one for the
+// same week
+func (r *PostgresRepository) SaveRecap(ctx context.Context, recap *WeeklyRecap) error {
+	payload, err := json.Marshal(recap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal league recap: %w", err)
+	}
+
+	query := `
+		INSERT INTO league_recaps (league_id, season, week, payload, computed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (league_id, season, week) DO UPDATE SET
+			payload = EXCLUDED.payload,
+			computed_at = EXCLUDED.computed_at`
+
+	_, err = r.db.ExecContext(ctx, query,
+		recap.LeagueID, recap.Season, recap.Week, payload, recap.ComputedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save league recap: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecap returns a league's recap for a week, or nil if it has not been
+// built
+func (r *PostgresRepository) GetRecap(ctx context.Context, leagueID string, week int) (*WeeklyRecap, error) {
+	query := `
+		SELECT payload
+		FROM league_recaps
+		WHERE league_id = $1 AND ($2 = 0 OR week = $2)
+			AND season = (SELECT MAX(season) FROM league_recaps WHERE league_id = $1)
+		ORDER BY computed_at DESC
+		LIMIT 1`
+
+	var payload []byte
+	err := r.db.QueryRowContext(ctx, query, leagueID, week).Scan(&payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league recap: %w", err)
+	}
+
+	var recap WeeklyRecap
+	if err := json.Unmarshal(payload, &recap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal league recap: %w", err)
+	}
+
+	return &recap, nil
+}
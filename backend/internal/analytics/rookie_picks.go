@@ -0,0 +1,215 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+)
+
+const (
+	// DefaultRookieRounds is how many rounds a rookie draft has unless more
+	// are asked for
+	DefaultRookieRounds = 4
+	// rookiePickDecay is how fast pick value falls off with each overall
+	// pick: in a 12 team league the 1.12 is worth about 60% of the 1.01 and
+	// the 2.12 about 35%
+	rookiePickDecay = 0.045
+	// futurePickDiscount is taken off a pick's value for each draft past the
+	// next one, for the uncertainty in where it lands
+	futurePickDiscount = 0.9
+)
+
+// RookiePick is a future rookie draft pick in a dynasty league, a tradable
+// asset owned by a team that may not be the one it originally belonged to
+type RookiePick struct {
+	LeagueID         string `json:"league_id"`
+	Season           int    `json:"season"`
+	Round            int    `json:"round"`
+	OriginalTeamID   int    `json:"original_team_id"`
+	OriginalTeamName string `json:"original_team_name,omitempty"`
+	OwnerTeamID      int    `json:"owner_team_id"`
+	OwnerTeamName    string `json:"owner_team_name,omitempty"`
+	Traded           bool   `json:"traded"`
+	// ProjectedSlot is where in its round the pick is expected to fall. For
+	// the next draft it comes from the original team's place in the current
+	// standings, worst team first; later drafts assume the middle of the
+	// round.
+	ProjectedSlot     int        `json:"projected_slot"`
+	SlotFromStandings bool       `json:"slot_from_standings"`
+	Value             float64    `json:"value"`
+	Notes             string     `json:"notes,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// PickValue is a row of the rookie pick value chart
+type PickValue struct {
+	Pick    string  `json:"pick"` // round.slot, as in 1.01
+	Round   int     `json:"round"`
+	Slot    int     `json:"slot"`
+	Overall int     `json:"overall"`
+	Value   float64 `json:"value"`
+}
+
+// PickRepository stores rookie pick ownership and the ledger of trades
+// behind it. Only traded picks have an owner stored; the rest belong to
+// their original team.
+type PickRepository interface {
+	GetRookiePicks(ctx context.Context, leagueID string) ([]RookiePick, error)
+	// RecordPickTrade adds a trade to the ledger and moves the pick to its
+	// new owner. It reports false, changing nothing, for a synced trade that
+	// is already in the ledger.
+	RecordPickTrade(ctx context.Context, trade *PickTrade) (bool, error)
+	GetPickTrades(ctx context.Context, leagueID string, season int) ([]PickTrade, error)
+}
+
+// NewPostgresPickRepository creates a rookie pick repository backed by
+// PostgreSQL
+func NewPostgresPickRepository(db *sql.DB) PickRepository {
+	return &PostgresRepository{db: db}
+}
+
+// RookiePickValue is a pick's value on the dynasty chart's 0-100 scale, the
+// 1.01 of the next draft being 100. yearsOut is 1 for the next draft.
+func RookiePickValue(round, slot, teams, yearsOut int) float64 {
+	overall := (round-1)*teams + slot
+	value := 100 * math.Exp(-rookiePickDecay*float64(overall-1))
+	for year := 1; year < yearsOut; year++ {
+		value *= futurePickDiscount
+	}
+	return round2(value)
+}
+
+// RookiePickValueChart lists the value of every pick in the next draft
+func RookiePickValueChart(teams, rounds int) []PickValue {
+	chart := make([]PickValue, 0, teams*rounds)
+	for round := 1; round <= rounds; round++ {
+		for slot := 1; slot <= teams; slot++ {
+			chart = append(chart, PickValue{
+				Pick:    fmt.Sprintf("%d.%02d", round, slot),
+				Round:   round,
+				Slot:    slot,
+				Overall: (round-1)*teams + slot,
+				Value:   RookiePickValue(round, slot, teams, 1),
+			})
+		}
+	}
+	return chart
+}
+
+// RookiePickBoard lists every team's picks in the drafts from firstSeason on,
+// with each pick's owner, projected slot and value. Picks default to their
+// original team; traded holds the recorded exceptions. The board grows to
+// cover traded picks beyond the years and rounds asked for.
+func RookiePickBoard(leagueID string, teams []espn.Team, standings []TeamStanding, traded []RookiePick, firstSeason, years, rounds int) []RookiePick {
+	names := teamNames(teams)
+	for _, s := range standings {
+		if _, ok := names[s.TeamID]; !ok {
+			names[s.TeamID] = s.TeamName
+		}
+	}
+	teamCount := len(names)
+	if teamCount == 0 {
+		return []RookiePick{}
+	}
+
+	type pickKey struct{ season, round, team int }
+	owners := make(map[pickKey]RookiePick, len(traded))
+	lastSeason := firstSeason + years - 1
+	for _, p := range traded {
+		if p.Season < firstSeason {
+			continue
+		}
+		owners[pickKey{p.Season, p.Round, p.OriginalTeamID}] = p
+		lastSeason = max(lastSeason, p.Season)
+		rounds = max(rounds, p.Round)
+	}
+
+	// Worst record picks first; teams without a standing go mid-round
+	midSlot := (teamCount + 1) / 2
+	slots := make(map[int]int, len(standings))
+	for _, s := range standings {
+		slots[s.TeamID] = teamCount - s.Rank + 1
+	}
+
+	teamIDs := make([]int, 0, teamCount)
+	for id := range names {
+		teamIDs = append(teamIDs, id)
+	}
+	sort.Ints(teamIDs)
+
+	board := make([]RookiePick, 0, (lastSeason-firstSeason+1)*rounds*teamCount)
+	for season := firstSeason; season <= lastSeason; season++ {
+		for round := 1; round <= rounds; round++ {
+			for _, team := range teamIDs {
+				pick := RookiePick{
+					LeagueID:       leagueID,
+					Season:         season,
+					Round:          round,
+					OriginalTeamID: team,
+					OwnerTeamID:    team,
+					ProjectedSlot:  midSlot,
+				}
+				if t, ok := owners[pickKey{season, round, team}]; ok {
+					pick.OwnerTeamID = t.OwnerTeamID
+					pick.Traded = t.OwnerTeamID != team
+					pick.Notes = t.Notes
+					pick.UpdatedAt = t.UpdatedAt
+				}
+				if slot, ok := slots[team]; ok && season == firstSeason && slot >= 1 && slot <= teamCount {
+					pick.ProjectedSlot = slot
+					pick.SlotFromStandings = true
+				}
+				pick.OriginalTeamName = names[pick.OriginalTeamID]
+				pick.OwnerTeamName = names[pick.OwnerTeamID]
+				pick.Value = RookiePickValue(round, pick.ProjectedSlot, teamCount, season-firstSeason+1)
+				board = append(board, pick)
+			}
+		}
+	}
+
+	sort.SliceStable(board, func(i, j int) bool {
+		a, b := board[i], board[j]
+		if a.Season != b.Season {
+			return a.Season < b.Season
+		}
+		if a.Round != b.Round {
+			return a.Round < b.Round
+		}
+		return a.ProjectedSlot < b.ProjectedSlot
+	})
+	return board
+}
+
+// GetRookiePicks returns a league's traded picks
+func (r *PostgresRepository) GetRookiePicks(ctx context.Context, leagueID string) ([]RookiePick, error) {
+	query := `
+		SELECT league_id, season, round, original_team_id, owner_team_id, COALESCE(notes, ''), updated_at
+		FROM league_rookie_picks
+		WHERE league_id = $1
+		ORDER BY season, round, original_team_id`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rookie picks: %w", err)
+	}
+	defer rows.Close()
+
+	var picks []RookiePick
+	for rows.Next() {
+		var p RookiePick
+		var updatedAt time.Time
+		if err := rows.Scan(&p.LeagueID, &p.Season, &p.Round, &p.OriginalTeamID, &p.OwnerTeamID, &p.Notes, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rookie pick: %w", err)
+		}
+		p.UpdatedAt = &updatedAt
+		p.Traded = p.OwnerTeamID != p.OriginalTeamID
+		picks = append(picks, p)
+	}
+
+	return picks, rows.Err()
+}
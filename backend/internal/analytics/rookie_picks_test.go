@@ -0,0 +1,77 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRookiePickValue(t *testing.T) {
+	assert.Equal(t, 100.0, RookiePickValue(1, 1, 12, 1))
+	assert.InDelta(t, 61, RookiePickValue(1, 12, 12, 1), 1)
+	assert.InDelta(t, 35, RookiePickValue(2, 12, 12, 1), 1)
+
+	// Picks further out are discounted
+	assert.Equal(t, 90.0, RookiePickValue(1, 1, 12, 2))
+	assert.Equal(t, 81.0, RookiePickValue(1, 1, 12, 3))
+
+	chart := RookiePickValueChart(12, 2)
+	require.Len(t, chart, 24)
+	assert.Equal(t, "1.01", chart[0].Pick)
+	assert.Equal(t, "2.12", chart[23].Pick)
+	assert.Equal(t, 24, chart[23].Overall)
+}
+
+func TestRookiePickBoard(t *testing.T) {
+	teams := []espn.Team{
+		{ID: 1, FullName: "Team", Nickname: "One"},
+		{ID: 2, FullName: "Team", Nickname: "Two"},
+		{ID: 3, FullName: "Team", Nickname: "Three"},
+		{ID: 4, FullName: "Team", Nickname: "Four"},
+	}
+	standings := []TeamStanding{
+		{TeamID: 2, Rank: 1}, {TeamID: 4, Rank: 2}, {TeamID: 1, Rank: 3}, {TeamID: 3, Rank: 4},
+	}
+	traded := []RookiePick{
+		// Team 3's first next year went to the champion
+		{Season: 2026, Round: 1, OriginalTeamID: 3, OwnerTeamID: 2},
+		// A 2028 third rounder widens the board
+		{Season: 2028, Round: 3, OriginalTeamID: 1, OwnerTeamID: 4},
+		// Past drafts are ignored
+		{Season: 2025, Round: 1, OriginalTeamID: 1, OwnerTeamID: 2},
+	}
+
+	board := RookiePickBoard("league-1", teams, standings, traded, 2026, 1, 2)
+	// 2026 through 2028, three rounds, four teams
+	require.Len(t, board, 3*3*4)
+
+	// The worst team picks first, and its pick now belongs to team 2
+	first := board[0]
+	assert.Equal(t, 2026, first.Season)
+	assert.Equal(t, 3, first.OriginalTeamID)
+	assert.Equal(t, 2, first.OwnerTeamID)
+	assert.Equal(t, "Team Two", first.OwnerTeamName)
+	assert.True(t, first.Traded)
+	assert.True(t, first.SlotFromStandings)
+	assert.Equal(t, 1, first.ProjectedSlot)
+	assert.Equal(t, 100.0, first.Value)
+
+	assert.Equal(t, 2, board[3].OriginalTeamID)
+	assert.Equal(t, 4, board[3].ProjectedSlot)
+	assert.False(t, board[3].Traded)
+
+	// Later drafts have no standings to go on
+	for _, p := range board {
+		if p.Season > 2026 {
+			assert.False(t, p.SlotFromStandings)
+			assert.Equal(t, 2, p.ProjectedSlot)
+		}
+		if p.Season == 2028 && p.Round == 3 && p.OriginalTeamID == 1 {
+			assert.Equal(t, 4, p.OwnerTeamID)
+		}
+	}
+
+	assert.Empty(t, RookiePickBoard("league-1", nil, nil, nil, 2026, 3, 4))
+}
@@ -0,0 +1,209 @@
+package analytics
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// How a player came to be on a team
+const (
+	OriginDraft     = "draft"
+	OriginWaiver    = "waiver"
+	OriginFreeAgent = "free_agent"
+	OriginTrade     = "trade"
+	// OriginUnknown is a player who joined between syncs without a
+	// transaction we kept
+	OriginUnknown = "unknown"
+)
+
+// RosterStint is one stretch of weeks a player spent on a team
+type RosterStint struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Position   string `json:"position"`
+	Origin     string `json:"origin"`
+	// TransactionID is the trade or pickup that brought the player in
+	TransactionID string     `json:"transaction_id,omitempty"`
+	AcquiredWeek  int        `json:"acquired_week"`
+	AcquiredAt    *time.Time `json:"acquired_at,omitempty"`
+	// ReleasedWeek is the first synced week the player was gone, or 0 if
+	// they were still on the team at the end of the range
+	ReleasedWeek  int `json:"released_week,omitempty"`
+	WeeksRostered int `json:"weeks_rostered"`
+}
+
+// RosterWeek is a team's roster in one synced week and how it changed from
+// the week before
+type RosterWeek struct {
+	Week      int       `json:"week"`
+	SyncedAt  time.Time `json:"synced_at"`
+	PlayerIDs []string  `json:"player_ids"`
+	Added     []string  `json:"added"`
+	Removed   []string  `json:"removed"`
+	// Origins counts the week's players by how they were acquired
+	Origins map[string]int `json:"origins"`
+}
+
+// RosterHistory is how a team's roster was built over a range of weeks
+type RosterHistory struct {
+	TeamID   int           `json:"team_id"`
+	Season   int           `json:"season"`
+	FromWeek int           `json:"from_week"`
+	ToWeek   int           `json:"to_week"`
+	Weeks    []RosterWeek  `json:"weeks"`
+	Players  []RosterStint `json:"players"`
+}
+
+// BuildRosterHistory reconstructs a team's roster over the season from its
+// weekly synced rosters, diffing each week against the one before. Players
+// on the team's first synced roster were drafted unless a trade or pickup
+// says otherwise; later arrivals are matched to the latest trade, waiver
+// claim or free agent add naming both the team and the player. Every week
+// of the season is replayed so origins are right, but only weeks from..to
+// are returned. entries must all belong to the team.
+func BuildRosterHistory(teamID, season int, entries []models.LeagueRosterEntry, transactions []espn.Transaction, from, to int) *RosterHistory {
+	byWeek := make(map[int][]models.LeagueRosterEntry)
+	for _, e := range entries {
+		byWeek[e.Week] = append(byWeek[e.Week], e)
+	}
+	weeks := make([]int, 0, len(byWeek))
+	for w := range byWeek {
+		weeks = append(weeks, w)
+	}
+	sort.Ints(weeks)
+
+	history := &RosterHistory{TeamID: teamID, Season: season, FromWeek: from, ToWeek: to, Weeks: []RosterWeek{}, Players: []RosterStint{}}
+
+	// Stints still open, by player
+	open := make(map[string]*RosterStint)
+	// When each player's last stint ended, so a returning player is matched
+	// to a transaction after they left
+	left := make(map[string]time.Time)
+	var stints []*RosterStint
+	var previous []string
+	var previousSync time.Time
+
+	for i, week := range weeks {
+		rows := byWeek[week]
+		syncedAt := rows[0].SyncedAt
+		current := make(map[string]bool, len(rows))
+		for _, e := range rows {
+			current[e.PlayerID] = true
+		}
+
+		var added, removed []string
+		for _, e := range rows {
+			stint, ok := open[e.PlayerID]
+			if !ok {
+				added = append(added, e.PlayerID)
+				origin, txID := OriginUnknown, ""
+				if t := acquiringTransaction(transactions, teamID, e.PlayerID, left[e.PlayerID], syncedAt); t != nil {
+					origin, txID = transactionOrigin(t.Type), t.ID
+				} else if i == 0 {
+					origin = OriginDraft
+				}
+				stint = &RosterStint{
+					PlayerID:      e.PlayerID,
+					Origin:        origin,
+					TransactionID: txID,
+					AcquiredWeek:  week,
+					AcquiredAt:    e.AcquiredAt,
+				}
+				open[e.PlayerID] = stint
+				stints = append(stints, stint)
+			}
+			stint.PlayerName, stint.Position = e.PlayerName, e.Position
+			if week >= from && week <= to {
+				stint.WeeksRostered++
+			}
+		}
+		for _, id := range previous {
+			if !current[id] {
+				removed = append(removed, id)
+				open[id].ReleasedWeek = week
+				left[id] = previousSync
+				delete(open, id)
+			}
+		}
+
+		previous = previous[:0]
+		for _, e := range rows {
+			previous = append(previous, e.PlayerID)
+		}
+		previousSync = syncedAt
+
+		if week < from || week > to {
+			continue
+		}
+		rosterWeek := RosterWeek{
+			Week:      week,
+			SyncedAt:  syncedAt,
+			PlayerIDs: append([]string{}, previous...),
+			Added:     nonNil(added),
+			Removed:   nonNil(removed),
+			Origins:   make(map[string]int),
+		}
+		for _, id := range previous {
+			rosterWeek.Origins[open[id].Origin]++
+		}
+		history.Weeks = append(history.Weeks, rosterWeek)
+	}
+
+	for _, s := range stints {
+		// Keep stints that overlap the range
+		if s.AcquiredWeek > to || (s.ReleasedWeek != 0 && s.ReleasedWeek <= from) {
+			continue
+		}
+		if s.ReleasedWeek > to {
+			s.ReleasedWeek = 0
+		}
+		history.Players = append(history.Players, *s)
+	}
+	return history
+}
+
+// acquiringTransaction returns the latest trade or add naming the team and
+// player that was processed after since and by syncedAt
+func acquiringTransaction(transactions []espn.Transaction, teamID int, playerID string, since, syncedAt time.Time) *espn.Transaction {
+	var latest *espn.Transaction
+	for i := range transactions {
+		t := &transactions[i]
+		if transactionOrigin(t.Type) == "" || t.ProcessDate.After(syncedAt) || !t.ProcessDate.After(since) {
+			continue
+		}
+		if t.ProposingTeamID != teamID && t.AcceptingTeamID != teamID {
+			continue
+		}
+		for _, id := range t.Players {
+			if id == playerID && (latest == nil || t.ProcessDate.After(latest.ProcessDate)) {
+				latest = t
+			}
+		}
+	}
+	return latest
+}
+
+// transactionOrigin maps a transaction type to an origin, or "" for types
+// that do not bring a player in
+func transactionOrigin(transactionType string) string {
+	switch strings.ToUpper(transactionType) {
+	case "TRADE":
+		return OriginTrade
+	case "WAIVER":
+		return OriginWaiver
+	case "ADD", "FREEAGENT":
+		return OriginFreeAgent
+	}
+	return ""
+}
+
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
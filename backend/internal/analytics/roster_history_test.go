@@ -0,0 +1,115 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/integrations/platform"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRosterHistory(t *testing.T) {
+	start := time.Date(2024, 9, 3, 12, 0, 0, 0, time.UTC)
+	synced := func(week int) time.Time { return start.AddDate(0, 0, 7*(week-1)) }
+	roster := func(week int, ids ...string) []models.LeagueRosterEntry {
+		var entries []models.LeagueRosterEntry
+		for _, id := range ids {
+			entries = append(entries, models.LeagueRosterEntry{Week: week, TeamID: 1, PlayerID: id, PlayerName: "Player " + id, SyncedAt: synced(week)})
+		}
+		return entries
+	}
+
+	var entries []models.LeagueRosterEntry
+	entries = append(entries, roster(1, "a", "b", "c")...)
+	entries = append(entries, roster(2, "a", "b", "d")...)      // c dropped, d off waivers
+	entries = append(entries, roster(3, "a", "e", "d")...)      // b traded for e
+	entries = append(entries, roster(4, "a", "e", "d", "f")...) // f appears with no transaction
+
+	transactions := []espn.Transaction{
+		{ID: "w1", Type: "WAIVER", ProposingTeamID: 1, Players: []string{"d"}, ProcessDate: synced(2).Add(-24 * time.Hour)},
+		{ID: "t1", Type: "TRADE", Status: "EXECUTED", ProposingTeamID: 2, AcceptingTeamID: 1, Players: []string{"b", "e"}, ProcessDate: synced(3).Add(-24 * time.Hour)},
+		// Another team's pickup of the same player is not ours
+		{ID: "x1", Type: "ADD", ProposingTeamID: 3, Players: []string{"f"}, ProcessDate: synced(4).Add(-24 * time.Hour)},
+	}
+
+	history := BuildRosterHistory(1, 2024, entries, transactions, 2, 4)
+
+	require.Len(t, history.Weeks, 3)
+	assert.Equal(t, 2, history.Weeks[0].Week)
+	assert.Equal(t, []string{"d"}, history.Weeks[0].Added)
+	assert.Equal(t, []string{"c"}, history.Weeks[0].Removed)
+	assert.Equal(t, map[string]int{OriginDraft: 2, OriginWaiver: 1}, history.Weeks[0].Origins)
+	assert.Equal(t, []string{"e"}, history.Weeks[1].Added)
+	assert.Equal(t, []string{"b"}, history.Weeks[1].Removed)
+	assert.Equal(t, map[string]int{OriginDraft: 1, OriginWaiver: 1, OriginTrade: 1, OriginUnknown: 1}, history.Weeks[2].Origins)
+
+	stints := make(map[string]RosterStint)
+	for _, s := range history.Players {
+		stints[s.PlayerID] = s
+	}
+	// c left before the range
+	assert.NotContains(t, stints, "c")
+	assert.Equal(t, OriginDraft, stints["b"].Origin)
+	assert.Equal(t, 3, stints["b"].ReleasedWeek)
+	assert.Equal(t, 1, stints["b"].WeeksRostered)
+	assert.Equal(t, OriginWaiver, stints["d"].Origin)
+	assert.Equal(t, "w1", stints["d"].TransactionID)
+	assert.Equal(t, OriginTrade, stints["e"].Origin)
+	assert.Equal(t, 3, stints["e"].AcquiredWeek)
+	assert.Equal(t, OriginUnknown, stints["f"].Origin)
+	assert.Equal(t, 3, stints["a"].WeeksRostered)
+	assert.Zero(t, stints["a"].ReleasedWeek)
+}
+
+func TestAttributeAcquisitions(t *testing.T) {
+	history := &RosterHistory{
+		TeamID: 1,
+		Players: []RosterStint{
+			{PlayerID: "a", Origin: OriginDraft, AcquiredWeek: 1},
+			{PlayerID: "k", Origin: OriginDraft, AcquiredWeek: 1},
+			{PlayerID: "b", Origin: OriginDraft, AcquiredWeek: 1, ReleasedWeek: 3},
+			{PlayerID: "d", Origin: OriginWaiver, AcquiredWeek: 2, TransactionID: "w1"},
+			{PlayerID: "e", Origin: OriginTrade, AcquiredWeek: 3, TransactionID: "t1"},
+			// Drafted, but the first synced roster came after a pickup
+			{PlayerID: "g", Origin: OriginUnknown, AcquiredWeek: 4},
+		},
+	}
+	draft := []platform.DraftPick{
+		{PlayerID: "a", TeamID: "1", Round: 1, Overall: 3},
+		{PlayerID: "k", TeamID: "1", Round: 3, Overall: 27, Keeper: true},
+		{PlayerID: "g", TeamID: "1", Round: 10, Overall: 111},
+		// Drafted by another team, then traded to us
+		{PlayerID: "e", TeamID: "2", Round: 2, Overall: 14},
+	}
+	transactions := []espn.Transaction{{ID: "w1", Type: "WAIVER", BidAmount: 17}}
+	points := map[string]float64{"a": 120, "k": 40, "d": 60, "e": 80, "g": 0}
+
+	acquisitions := AttributeAcquisitions(history, draft, transactions, points)
+
+	require.Len(t, acquisitions, 5)
+	assert.Equal(t, []string{"a", "e", "d", "k", "g"}, []string{
+		acquisitions[0].PlayerID, acquisitions[1].PlayerID, acquisitions[2].PlayerID, acquisitions[3].PlayerID, acquisitions[4].PlayerID,
+	})
+	byID := make(map[string]PlayerAcquisition)
+	for _, a := range acquisitions {
+		byID[a.PlayerID] = a
+	}
+	assert.Equal(t, 1, byID["a"].DraftRound)
+	assert.Equal(t, OriginKeeper, byID["k"].Source)
+	assert.Equal(t, 17, byID["d"].Bid)
+	assert.Equal(t, OriginTrade, byID["e"].Source)
+	assert.Zero(t, byID["e"].DraftRound)
+	assert.Equal(t, OriginDraft, byID["g"].Source)
+	assert.Equal(t, 10, byID["g"].DraftRound)
+
+	construction := SummarizeRosterConstruction(1, acquisitions)
+	assert.Equal(t, 5, construction.Players)
+	assert.Equal(t, SourceBreakdown{Players: 2, Points: 120, PointsShare: 0.4}, construction.Sources[OriginDraft])
+	assert.Equal(t, SourceBreakdown{Players: 1, Points: 80, PointsShare: 0.27}, construction.Sources[OriginTrade])
+	assert.Equal(t, 0.6, construction.DraftedShare)
+	assert.Equal(t, 4.67, construction.AvgDraftRound)
+	assert.Equal(t, 17, construction.WaiverSpend)
+}
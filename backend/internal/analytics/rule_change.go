@@ -0,0 +1,333 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// superflexPositions can fill a superflex slot
+var superflexPositions = map[string]bool{"QB": true, "RB": true, "WR": true, "TE": true}
+
+// defaultPlayerShifts is how many players' value shifts a simulation returns
+// when the caller does not ask for a number
+const defaultPlayerShifts = 25
+
+// RuleChange is a proposed change to a league's rules
+type RuleChange struct {
+	// Scoring replaces the league's scoring rules when set
+	Scoring *espn.ScoringSettings `json:"scoring,omitempty"`
+	// ReceptionBonus adds points per reception by position, e.g. {"TE": 0.5}
+	ReceptionBonus map[string]float64 `json:"reception_bonus,omitempty"`
+	// Superflex adds a starting slot any QB, RB, WR or TE can fill
+	Superflex bool `json:"superflex"`
+}
+
+// StandingShift is how a team's regular season record moves under the
+// proposed rules
+type StandingShift struct {
+	TeamID          int     `json:"team_id"`
+	TeamName        string  `json:"team_name"`
+	RankBefore      int     `json:"rank_before"`
+	RankAfter       int     `json:"rank_after"`
+	RankChange      int     `json:"rank_change"` // Positive moved up
+	WinsBefore      int     `json:"wins_before"`
+	WinsAfter       int     `json:"wins_after"`
+	LossesBefore    int     `json:"losses_before"`
+	LossesAfter     int     `json:"losses_after"`
+	PointsForBefore float64 `json:"points_for_before"`
+	PointsForAfter  float64 `json:"points_for_after"`
+}
+
+// PlayerValueShift is how a player's season value moves under the proposed
+// rules. Value is points over the replacement player at the position, the
+// last player a league of this size starts.
+type PlayerValueShift struct {
+	PlayerID           string  `json:"player_id"`
+	PlayerName         string  `json:"player_name"`
+	Position           string  `json:"position"`
+	PointsBefore       float64 `json:"points_before"`
+	PointsAfter        float64 `json:"points_after"`
+	ValueBefore        float64 `json:"value_before"`
+	ValueAfter         float64 `json:"value_after"`
+	PositionRankBefore int     `json:"position_rank_before"`
+	PositionRankAfter  int     `json:"position_rank_after"`
+	OverallRankBefore  int     `json:"overall_rank_before"` // By value
+	OverallRankAfter   int     `json:"overall_rank_after"`
+}
+
+// RuleChangeImpact is what a rule change would have done to a past season
+type RuleChangeImpact struct {
+	Season int        `json:"season"`
+	Change RuleChange `json:"change"`
+	// SimulatedWeeks had box scores to rescore; matchups in MissingWeeks keep
+	// their actual scores
+	SimulatedWeeks  []int              `json:"simulated_weeks"`
+	MissingWeeks    []int              `json:"missing_weeks"`
+	FlippedMatchups int                `json:"flipped_matchups"`
+	Standings       []StandingShift    `json:"standings"`
+	Players         []PlayerValueShift `json:"players"`
+}
+
+// SeasonData is a past season's results and the box scores behind them
+type SeasonData struct {
+	Season    int
+	Teams     map[int]string // Team ID to name
+	Matchups  []espn.Matchup
+	BoxScores map[int][]models.PlayerBoxScore // By week
+	Scoring   espn.ScoringSettings
+	Roster    models.RosterRequirements
+}
+
+// SimulateRuleChange replays a season under proposed rules. Each player's
+// actual points move by the difference between their stats scored under the
+// proposed and current rules, so scoring the platform applies beyond the
+// rules we model carries over unchanged. Lineups are kept as managers set
+// them; a superflex slot is filled with the team's best benched QB, RB, WR
+// or TE that week.
+func SimulateRuleChange(data SeasonData, change RuleChange, playerShifts int) *RuleChangeImpact {
+	if playerShifts <= 0 {
+		playerShifts = defaultPlayerShifts
+	}
+
+	current := projections.CustomScoringRules{ScoringSettings: data.Scoring}
+	proposed := projections.CustomScoringRules{ScoringSettings: data.Scoring, ReceptionBonus: change.ReceptionBonus}
+	if change.Scoring != nil {
+		proposed.ScoringSettings = *change.Scoring
+	}
+
+	impact := &RuleChangeImpact{
+		Season:         data.Season,
+		Change:         change,
+		SimulatedWeeks: []int{},
+		MissingWeeks:   []int{},
+		Standings:      []StandingShift{},
+		Players:        []PlayerValueShift{},
+	}
+
+	// Rescore every rostered player and each team's week
+	type teamWeek struct{ week, teamID int }
+	adjustment := make(map[teamWeek]float64)
+	players := make(map[string]*seasonPlayer)
+	weeks := make(map[int]bool)
+	for _, m := range data.Matchups {
+		if !m.IsPlayoffs && m.IsComplete {
+			weeks[m.Week] = true
+		}
+	}
+	for week := range weeks {
+		scores := data.BoxScores[week]
+		if len(scores) == 0 {
+			impact.MissingWeeks = append(impact.MissingWeeks, week)
+			continue
+		}
+		impact.SimulatedWeeks = append(impact.SimulatedWeeks, week)
+
+		bestBench := make(map[int]float64)
+		for _, s := range scores {
+			points := s.Points + proposed.ScoreStats(s.Position, s.Stats) - current.ScoreStats(s.Position, s.Stats)
+			if s.Starter {
+				adjustment[teamWeek{week, s.TeamID}] += points - s.Points
+			} else if change.Superflex && s.LineupSlot != "IR" && superflexPositions[s.Position] {
+				bestBench[s.TeamID] = math.Max(bestBench[s.TeamID], points)
+			}
+
+			p, ok := players[s.PlayerID]
+			if !ok {
+				p = &seasonPlayer{id: s.PlayerID, name: s.PlayerName, position: s.Position}
+				players[s.PlayerID] = p
+			}
+			p.before += s.Points
+			p.after += points
+		}
+		for teamID, points := range bestBench {
+			adjustment[teamWeek{week, teamID}] += points
+		}
+	}
+	sort.Ints(impact.SimulatedWeeks)
+	sort.Ints(impact.MissingWeeks)
+
+	// Replay the schedule with the adjusted scores
+	rescored := make([]espn.Matchup, 0, len(data.Matchups))
+	for _, m := range data.Matchups {
+		if m.IsPlayoffs || !m.IsComplete {
+			continue
+		}
+		after := m
+		after.HomeScore += adjustment[teamWeek{m.Week, m.HomeTeamID}]
+		after.AwayScore += adjustment[teamWeek{m.Week, m.AwayTeamID}]
+		if winner(m) != winner(after) {
+			impact.FlippedMatchups++
+		}
+		rescored = append(rescored, after)
+	}
+
+	impact.Standings = standingShifts(
+		computeStandings(data.Teams, regularSeasonGames(data.Matchups)),
+		computeStandings(data.Teams, regularSeasonGames(rescored)),
+	)
+	impact.Players = playerValueShifts(players, len(data.Teams), data.Roster, change.Superflex, playerShifts)
+	return impact
+}
+
+// seasonPlayer totals a player's points over the simulated weeks
+type seasonPlayer struct {
+	id, name, position string
+	before, after      float64
+}
+
+// winner is "HOME", "AWAY" or "TIE" by score
+func winner(m espn.Matchup) string {
+	switch {
+	case m.HomeScore > m.AwayScore:
+		return "HOME"
+	case m.AwayScore > m.HomeScore:
+		return "AWAY"
+	default:
+		return "TIE"
+	}
+}
+
+// regularSeasonGames splits completed regular season matchups into each
+// team's side
+func regularSeasonGames(matchups []espn.Matchup) []game {
+	var games []game
+	for _, m := range matchups {
+		if m.IsPlayoffs || !m.IsComplete {
+			continue
+		}
+		games = append(games,
+			game{week: m.Week, teamID: m.HomeTeamID, points: m.HomeScore, opponentID: m.AwayTeamID, opponentPoints: m.AwayScore},
+			game{week: m.Week, teamID: m.AwayTeamID, points: m.AwayScore, opponentID: m.HomeTeamID, opponentPoints: m.HomeScore},
+		)
+	}
+	return games
+}
+
+// standingShifts pairs each team's standing before and after, in the new
+// order
+func standingShifts(before, after []TeamStanding) []StandingShift {
+	byTeam := make(map[int]TeamStanding, len(before))
+	for _, s := range before {
+		byTeam[s.TeamID] = s
+	}
+
+	shifts := make([]StandingShift, 0, len(after))
+	for _, a := range after {
+		b := byTeam[a.TeamID]
+		shifts = append(shifts, StandingShift{
+			TeamID:          a.TeamID,
+			TeamName:        a.TeamName,
+			RankBefore:      b.Rank,
+			RankAfter:       a.Rank,
+			RankChange:      b.Rank - a.Rank,
+			WinsBefore:      b.Wins,
+			WinsAfter:       a.Wins,
+			LossesBefore:    b.Losses,
+			LossesAfter:     a.Losses,
+			PointsForBefore: b.PointsFor,
+			PointsForAfter:  a.PointsFor,
+		})
+	}
+	return shifts
+}
+
+// playerValueShifts values every player before and after and returns the
+// biggest movers among players who were starters either way
+func playerValueShifts(players map[string]*seasonPlayer, teams int, roster models.RosterRequirements, superflex bool, limit int) []PlayerValueShift {
+	if teams <= 0 {
+		teams = 1
+	}
+
+	shifts := make([]PlayerValueShift, 0, len(players))
+	for _, p := range players {
+		shifts = append(shifts, PlayerValueShift{
+			PlayerID:     p.id,
+			PlayerName:   p.name,
+			Position:     p.position,
+			PointsBefore: round2(p.before),
+			PointsAfter:  round2(p.after),
+		})
+	}
+
+	beforeStarters := startersByPosition(roster, teams, false)
+	afterStarters := startersByPosition(roster, teams, superflex)
+	valuePlayers(shifts,
+		func(s *PlayerValueShift) float64 { return s.PointsBefore },
+		func(s *PlayerValueShift, rank int, value float64) { s.PositionRankBefore, s.ValueBefore = rank, value },
+		beforeStarters)
+	valuePlayers(shifts,
+		func(s *PlayerValueShift) float64 { return s.PointsAfter },
+		func(s *PlayerValueShift, rank int, value float64) { s.PositionRankAfter, s.ValueAfter = rank, value },
+		afterStarters)
+
+	sort.SliceStable(shifts, func(i, j int) bool { return shifts[i].ValueBefore > shifts[j].ValueBefore })
+	for i := range shifts {
+		shifts[i].OverallRankBefore = i + 1
+	}
+	sort.SliceStable(shifts, func(i, j int) bool { return shifts[i].ValueAfter > shifts[j].ValueAfter })
+	for i := range shifts {
+		shifts[i].OverallRankAfter = i + 1
+	}
+
+	// Only players a league would start in either version are worth showing
+	relevant := shifts[:0]
+	for _, s := range shifts {
+		if s.PositionRankBefore <= beforeStarters[s.Position] || s.PositionRankAfter <= afterStarters[s.Position] {
+			relevant = append(relevant, s)
+		}
+	}
+	sort.SliceStable(relevant, func(i, j int) bool {
+		return math.Abs(relevant[i].ValueAfter-relevant[i].ValueBefore) > math.Abs(relevant[j].ValueAfter-relevant[j].ValueBefore)
+	})
+	if len(relevant) > limit {
+		relevant = relevant[:limit]
+	}
+	return relevant
+}
+
+// valuePlayers ranks players within their position by points and sets each
+// one's points over the position's replacement level
+func valuePlayers(shifts []PlayerValueShift, points func(*PlayerValueShift) float64, set func(*PlayerValueShift, int, float64), starters map[string]int) {
+	byPosition := make(map[string][]*PlayerValueShift)
+	for i := range shifts {
+		byPosition[shifts[i].Position] = append(byPosition[shifts[i].Position], &shifts[i])
+	}
+
+	for position, group := range byPosition {
+		sort.SliceStable(group, func(i, j int) bool { return points(group[i]) > points(group[j]) })
+
+		replacement := 0.0
+		if n := starters[position]; n > 0 {
+			if n > len(group) {
+				n = len(group)
+			}
+			replacement = points(group[n-1])
+		}
+		for rank, s := range group {
+			set(s, rank+1, round2(points(s)-replacement))
+		}
+	}
+}
+
+// startersByPosition is how many players at each position a league starts
+// across all teams. Flex slots are split between RBs and WRs, and a
+// superflex slot almost always goes to a quarterback.
+func startersByPosition(roster models.RosterRequirements, teams int, superflex bool) map[string]int {
+	qb := roster.QB
+	if superflex {
+		qb++
+	}
+	flexRB := roster.FLEX / 2
+	return map[string]int{
+		"QB":  qb * teams,
+		"RB":  (roster.RB + flexRB) * teams,
+		"WR":  (roster.WR + roster.FLEX - flexRB) * teams,
+		"TE":  roster.TE * teams,
+		"K":   roster.K * teams,
+		"DST": roster.DST * teams,
+	}
+}
@@ -0,0 +1,101 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSeason() SeasonData {
+	// Team 1 wins week 1 by a point with a WR; team 2 starts a pass-catching TE
+	scoring := espn.ScoringSettings{ReceptionPoints: 1, ReceivingYards: 0.1, PassingYards: 0.04, PassingTouchdowns: 4}
+	box := func(week, team int, id, name, position string, starter bool, points float64, stats map[string]float64) models.PlayerBoxScore {
+		return models.PlayerBoxScore{Week: week, TeamID: team, PlayerID: id, PlayerName: name, Position: position, Starter: starter, Points: points, Stats: stats}
+	}
+	return SeasonData{
+		Season: 2024,
+		Teams:  map[int]string{1: "Alpha", 2: "Beta"},
+		Matchups: []espn.Matchup{
+			{Week: 1, HomeTeamID: 1, AwayTeamID: 2, HomeScore: 100, AwayScore: 99, IsComplete: true},
+			{Week: 2, HomeTeamID: 2, AwayTeamID: 1, HomeScore: 140, AwayScore: 141, IsComplete: true},
+			{Week: 3, HomeTeamID: 1, AwayTeamID: 2, HomeScore: 80, AwayScore: 70, IsComplete: true, IsPlayoffs: true},
+		},
+		BoxScores: map[int][]models.PlayerBoxScore{
+			1: {
+				box(1, 1, "10", "Wide Out", "WR", true, 16, map[string]float64{espn.StatReceptions: 6, espn.StatReceivingYards: 100}),
+				box(1, 2, "20", "Tight End", "TE", true, 15, map[string]float64{espn.StatReceptions: 8, espn.StatReceivingYards: 70}),
+				box(1, 2, "21", "Backup QB", "QB", false, 18, map[string]float64{espn.StatPassingYards: 300, espn.StatPassingTDs: 1.5}),
+			},
+		},
+		Scoring: scoring,
+		Roster:  models.RosterRequirements{QB: 1, RB: 2, WR: 2, TE: 1, FLEX: 1},
+	}
+}
+
+func TestSimulateRuleChangeTEPremium(t *testing.T) {
+	impact := SimulateRuleChange(testSeason(), RuleChange{ReceptionBonus: map[string]float64{"TE": 0.5}}, 0)
+
+	assert.Equal(t, []int{1}, impact.SimulatedWeeks)
+	assert.Equal(t, []int{2}, impact.MissingWeeks)
+	// Four extra points for the TE turn Beta's week 1 loss into a win
+	assert.Equal(t, 1, impact.FlippedMatchups)
+
+	require.Len(t, impact.Standings, 2)
+	beta := impact.Standings[0]
+	assert.Equal(t, 2, beta.TeamID)
+	assert.Equal(t, 0, beta.WinsBefore)
+	assert.Equal(t, 1, beta.WinsAfter)
+	// Both teams finish 1-1 and Beta's points for break the tie
+	assert.Equal(t, 1, beta.RankChange)
+	assert.Equal(t, 239.0, beta.PointsForBefore)
+	assert.Equal(t, 243.0, beta.PointsForAfter)
+
+	var te *PlayerValueShift
+	for i := range impact.Players {
+		if impact.Players[i].PlayerID == "20" {
+			te = &impact.Players[i]
+		}
+	}
+	require.NotNil(t, te)
+	assert.Equal(t, 15.0, te.PointsBefore)
+	assert.Equal(t, 19.0, te.PointsAfter)
+}
+
+func TestSimulateRuleChangeSuperflex(t *testing.T) {
+	impact := SimulateRuleChange(testSeason(), RuleChange{Superflex: true}, 0)
+
+	// Beta's benched QB fills the superflex slot
+	assert.Equal(t, 1, impact.FlippedMatchups)
+	for _, s := range impact.Standings {
+		if s.TeamID == 2 {
+			assert.Equal(t, 257.0, s.PointsForAfter)
+		}
+	}
+}
+
+func TestSimulateRuleChangeScoringReplacement(t *testing.T) {
+	data := testSeason()
+	proposed := data.Scoring
+	proposed.ReceptionPoints = 0
+
+	impact := SimulateRuleChange(data, RuleChange{Scoring: &proposed}, 0)
+
+	// Standard scoring costs the TE 8 points and the WR 6, so Alpha keeps the win
+	assert.Zero(t, impact.FlippedMatchups)
+	assert.Equal(t, 1, impact.Standings[0].TeamID)
+	assert.Equal(t, 235.0, impact.Standings[0].PointsForAfter)
+}
+
+func TestStartersByPosition(t *testing.T) {
+	roster := models.RosterRequirements{QB: 1, RB: 2, WR: 2, TE: 1, FLEX: 2, K: 1, DST: 1}
+
+	starters := startersByPosition(roster, 10, false)
+	assert.Equal(t, 10, starters["QB"])
+	assert.Equal(t, 30, starters["RB"])
+	assert.Equal(t, 30, starters["WR"])
+
+	assert.Equal(t, 20, startersByPosition(roster, 10, true)["QB"])
+}
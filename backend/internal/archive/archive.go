@@ -0,0 +1,326 @@
+// Package archive moves the weekly detail of completed seasons out of
+// Postgres into Parquet files in object storage, keeping a summary row per
+// team and week. A user asking for an archived season's detail has it
+// loaded back for a while.
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/objectstore"
+	"github.com/nfl-analytics/backend/internal/parquet"
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// ErrNotArchived is returned when rehydrating a season with nothing archived
+var ErrNotArchived = errors.New("season is not archived")
+
+// Table is a detail table archived per league and season
+type Table struct {
+	Name    string
+	Columns []parquet.Column
+	// Key is the table's unique key, so loading a season back twice cannot
+	// duplicate rows
+	Key string
+}
+
+// Tables are the detail tables archived, in order. Weekly matchups are the
+// box scores' teams and opponents, and survive archiving as summary rows.
+// Transaction and matchup snapshots hold one row per week that the next
+// season's sync replaces, so they never pile up and are not archived.
+var Tables = []Table{
+	{
+		Name: "league_rosters",
+		Columns: []parquet.Column{
+			{Name: "league_id", Type: parquet.String},
+			{Name: "season", Type: parquet.Int32},
+			{Name: "week", Type: parquet.Int32},
+			{Name: "team_id", Type: parquet.Int32},
+			{Name: "player_id", Type: parquet.String},
+			{Name: "lineup_slot", Type: parquet.String},
+			{Name: "acquired_at", Type: parquet.Timestamp},
+			{Name: "synced_at", Type: parquet.Timestamp},
+		},
+		Key: "league_id, season, week, team_id, player_id",
+	},
+	{
+		Name: "league_box_scores",
+		Columns: []parquet.Column{
+			{Name: "league_id", Type: parquet.String},
+			{Name: "season", Type: parquet.Int32},
+			{Name: "week", Type: parquet.Int32},
+			{Name: "team_id", Type: parquet.Int32},
+			{Name: "opponent_id", Type: parquet.Int32},
+			{Name: "player_id", Type: parquet.String},
+			{Name: "player_name", Type: parquet.String},
+			{Name: "position", Type: parquet.String},
+			{Name: "nfl_team", Type: parquet.String},
+			{Name: "lineup_slot", Type: parquet.String},
+			{Name: "starter", Type: parquet.Boolean},
+			{Name: "points", Type: parquet.Double},
+			{Name: "projected_points", Type: parquet.Double},
+			{Name: "stats", Type: parquet.JSON},
+			{Name: "updated_at", Type: parquet.Timestamp},
+		},
+		Key: "league_id, season, week, team_id, player_id",
+	},
+}
+
+// Archive is one table's detail for a league season, held in object storage
+type Archive struct {
+	LeagueID     string     `json:"league_id"`
+	Season       int        `json:"season"`
+	Table        string     `json:"table"`
+	ObjectKey    string     `json:"object_key"`
+	Rows         int64      `json:"rows"`
+	Bytes        int64      `json:"bytes"`
+	ArchivedAt   time.Time  `json:"archived_at"`
+	RehydratedAt *time.Time `json:"rehydrated_at,omitempty"`
+}
+
+// WeeklySummary is what is kept in Postgres of a team's week once its
+// season is archived
+type WeeklySummary struct {
+	Week            int      `json:"week"`
+	TeamID          int      `json:"team_id"`
+	OpponentID      *int     `json:"opponent_id,omitempty"`
+	Points          *float64 `json:"points,omitempty"`
+	ProjectedPoints *float64 `json:"projected_points,omitempty"`
+	Players         int      `json:"players"`
+}
+
+// LeagueSeason is a league's season with detail to archive
+type LeagueSeason struct {
+	LeagueID string
+	Season   int
+}
+
+// Repository reads, replaces and restores detail rows
+type Repository interface {
+	// Candidates are league seasons before season with detail rows, except
+	// those rehydrated after rehydratedAfter
+	Candidates(ctx context.Context, season int, rehydratedAfter time.Time, limit int) ([]LeagueSeason, error)
+	// Export returns a table's rows for a league season, in column order
+	Export(ctx context.Context, table Table, leagueID string, season int) ([][]interface{}, error)
+	// Complete records the new archives, summarizes the season's weeks and
+	// deletes the detail of every archived table in one transaction
+	Complete(ctx context.Context, leagueID string, season int, archives []Archive) error
+	// Restore loads rows back and marks the table's archive rehydrated
+	Restore(ctx context.Context, table Table, leagueID string, season int, rows [][]interface{}) error
+	List(ctx context.Context, leagueID string) ([]Archive, error)
+	Summaries(ctx context.Context, leagueID string, season int) ([]WeeklySummary, error)
+}
+
+// Policy is which seasons are archived and for how long they come back
+type Policy struct {
+	// HotSeasons are kept in Postgres, counting the current one
+	HotSeasons int
+	// RehydratedFor is how long a rehydrated season stays before it is
+	// archived again
+	RehydratedFor time.Duration
+	// BatchSize bounds the league seasons archived per run
+	BatchSize int
+}
+
+// Report is the result of one archival run
+type Report struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Seasons    int       `json:"seasons"`
+	Rows       int64     `json:"rows"`
+	Bytes      int64     `json:"bytes"`
+	Failed     int       `json:"failed"`
+}
+
+// Archiver archives completed seasons and rehydrates them on request
+type Archiver struct {
+	repo   Repository
+	store  objectstore.Store
+	policy Policy
+	now    func() time.Time
+}
+
+// NewArchiver creates an archiver writing to store
+func NewArchiver(repo Repository, store objectstore.Store, policy Policy) *Archiver {
+	if policy.HotSeasons < 1 {
+		policy.HotSeasons = 1
+	}
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = 100
+	}
+	return &Archiver{repo: repo, store: store, policy: policy, now: time.Now}
+}
+
+// Run archives every league season past the hot seasons. A league season
+// that fails is left in place for the next run.
+func (a *Archiver) Run(ctx context.Context) (*Report, error) {
+	report := &Report{StartedAt: a.now()}
+	before := projections.CurrentSeason(report.StartedAt) - a.policy.HotSeasons + 1
+
+	candidates, err := a.repo.Candidates(ctx, before, report.StartedAt.Add(-a.policy.RehydratedFor), a.policy.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range candidates {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		rows, size, err := a.archive(ctx, c)
+		if err != nil {
+			log.Printf("Failed to archive league %s season %d: %v", c.LeagueID, c.Season, err)
+			report.Failed++
+			continue
+		}
+		report.Seasons++
+		report.Rows += rows
+		report.Bytes += size
+	}
+
+	report.FinishedAt = a.now()
+	log.Printf("metrics season_archive seasons=%d rows=%d bytes=%d failed=%d duration_ms=%d",
+		report.Seasons, report.Rows, report.Bytes, report.Failed, report.FinishedAt.Sub(report.StartedAt).Milliseconds())
+	return report, nil
+}
+
+// archive exports a league season's tables not yet in object storage, then
+// replaces the detail with summaries. Tables archived before and since
+// rehydrated are not exported again.
+func (a *Archiver) archive(ctx context.Context, c LeagueSeason) (int64, int64, error) {
+	existing, err := a.archives(ctx, c.LeagueID, c.Season)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var archives []Archive
+	var rows, size int64
+	for _, table := range Tables {
+		if _, ok := existing[table.Name]; ok {
+			continue
+		}
+		data, err := a.repo.Export(ctx, table, c.LeagueID, c.Season)
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := parquet.Write(&buf, table.Columns, data); err != nil {
+			return 0, 0, fmt.Errorf("failed to write %s parquet: %w", table.Name, err)
+		}
+		key := ObjectKey(c.LeagueID, c.Season, table.Name)
+		if err := a.store.Put(ctx, key, buf.Bytes()); err != nil {
+			return 0, 0, err
+		}
+
+		archives = append(archives, Archive{
+			LeagueID:  c.LeagueID,
+			Season:    c.Season,
+			Table:     table.Name,
+			ObjectKey: key,
+			Rows:      int64(len(data)),
+			Bytes:     int64(buf.Len()),
+		})
+		rows += int64(len(data))
+		size += int64(buf.Len())
+	}
+
+	if err := a.repo.Complete(ctx, c.LeagueID, c.Season, archives); err != nil {
+		return 0, 0, err
+	}
+	return rows, size, nil
+}
+
+// Rehydrate loads an archived league season's detail back into Postgres,
+// where it stays until RehydratedFor has passed
+func (a *Archiver) Rehydrate(ctx context.Context, leagueID string, season int) ([]Archive, error) {
+	existing, err := a.archives(ctx, leagueID, season)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		return nil, ErrNotArchived
+	}
+
+	started := a.now()
+	var restored []Archive
+	var rows int64
+	for _, table := range Tables {
+		archive, ok := existing[table.Name]
+		if !ok {
+			continue
+		}
+		data, err := a.store.Get(ctx, archive.ObjectKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s archive: %w", table.Name, err)
+		}
+		columns, values, err := parquet.Read(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s archive: %w", table.Name, err)
+		}
+		if !sameColumns(columns, table.Columns) {
+			return nil, fmt.Errorf("%s archive does not match the table's columns", table.Name)
+		}
+		if err := a.repo.Restore(ctx, table, leagueID, season, values); err != nil {
+			return nil, err
+		}
+
+		now := a.now()
+		archive.RehydratedAt = &now
+		restored = append(restored, archive)
+		rows += int64(len(values))
+	}
+
+	log.Printf("metrics season_rehydrate league=%s season=%d rows=%d duration_ms=%d",
+		leagueID, season, rows, a.now().Sub(started).Milliseconds())
+	return restored, nil
+}
+
+// List returns a league's archived seasons
+func (a *Archiver) List(ctx context.Context, leagueID string) ([]Archive, error) {
+	return a.repo.List(ctx, leagueID)
+}
+
+// Summaries returns the weekly summaries kept for an archived league season
+func (a *Archiver) Summaries(ctx context.Context, leagueID string, season int) ([]WeeklySummary, error) {
+	return a.repo.Summaries(ctx, leagueID, season)
+}
+
+// archives returns a league season's archives by table
+func (a *Archiver) archives(ctx context.Context, leagueID string, season int) (map[string]Archive, error) {
+	all, err := a.repo.List(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	bySeason := make(map[string]Archive)
+	for _, archive := range all {
+		if archive.Season == season {
+			bySeason[archive.Table] = archive
+		}
+	}
+	return bySeason, nil
+}
+
+// ObjectKey is where a table's archive of a league season is stored
+func ObjectKey(leagueID string, season int, table string) string {
+	return fmt.Sprintf("seasons/%d/%s/%s.parquet", season, leagueID, table)
+}
+
+func sameColumns(a, b []parquet.Column) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/objectstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore map[string][]byte
+
+func (s memoryStore) Put(ctx context.Context, key string, data []byte) error {
+	s[key] = data
+	return nil
+}
+
+func (s memoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, ok := s[key]
+	if !ok {
+		return nil, objectstore.ErrNotFound
+	}
+	return data, nil
+}
+
+// fakeRepo keeps detail rows by table, and archives, in memory
+type fakeRepo struct {
+	detail   map[string][][]interface{}
+	archives []Archive
+	before   int
+	restored map[string][][]interface{}
+}
+
+func (r *fakeRepo) Candidates(ctx context.Context, season int, rehydratedAfter time.Time, limit int) ([]LeagueSeason, error) {
+	r.before = season
+	return []LeagueSeason{{LeagueID: "league-1", Season: 2023}}, nil
+}
+
+func (r *fakeRepo) Export(ctx context.Context, table Table, leagueID string, season int) ([][]interface{}, error) {
+	return r.detail[table.Name], nil
+}
+
+func (r *fakeRepo) Complete(ctx context.Context, leagueID string, season int, archives []Archive) error {
+	r.archives = append(r.archives, archives...)
+	r.detail = nil
+	return nil
+}
+
+func (r *fakeRepo) Restore(ctx context.Context, table Table, leagueID string, season int, rows [][]interface{}) error {
+	r.restored[table.Name] = rows
+	return nil
+}
+
+func (r *fakeRepo) List(ctx context.Context, leagueID string) ([]Archive, error) {
+	return r.archives, nil
+}
+
+func (r *fakeRepo) Summaries(ctx context.Context, leagueID string, season int) ([]WeeklySummary, error) {
+	return nil, nil
+}
+
+func TestArchiver_RunAndRehydrate(t *testing.T) {
+	synced := time.Date(2023, 12, 5, 9, 30, 0, 0, time.UTC)
+	rosters := [][]interface{}{
+		{"league-1", int32(2023), int32(1), int32(3), "4262921", "QB", nil, synced},
+		{"league-1", int32(2023), int32(1), int32(3), "4430737", "BE", synced.AddDate(0, -1, 0), synced},
+	}
+	repo := &fakeRepo{
+		detail:   map[string][][]interface{}{"league_rosters": rosters},
+		restored: map[string][][]interface{}{},
+	}
+	store := memoryStore{}
+	archiver := NewArchiver(repo, store, Policy{HotSeasons: 2, RehydratedFor: 7 * 24 * time.Hour})
+	archiver.now = func() time.Time { return time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC) }
+
+	report, err := archiver.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2024, repo.before, "2025 and 2024 stay in Postgres")
+	assert.Equal(t, 1, report.Seasons)
+	assert.Equal(t, int64(2), report.Rows)
+
+	// Tables without rows in the season are not archived
+	require.Len(t, repo.archives, 1)
+	archive := repo.archives[0]
+	assert.Equal(t, "league_rosters", archive.Table)
+	assert.Equal(t, "seasons/2023/league-1/league_rosters.parquet", archive.ObjectKey)
+	assert.Equal(t, int64(len(store[archive.ObjectKey])), archive.Bytes)
+
+	restored, err := archiver.Rehydrate(context.Background(), "league-1", 2023)
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	assert.NotNil(t, restored[0].RehydratedAt)
+	assert.Equal(t, rosters, repo.restored["league_rosters"])
+
+	_, err = archiver.Rehydrate(context.Background(), "league-1", 2022)
+	assert.ErrorIs(t, err, ErrNotArchived)
+}
+
+func TestArchiver_RunSkipsArchivedTables(t *testing.T) {
+	repo := &fakeRepo{
+		detail: map[string][][]interface{}{"league_rosters": {
+			{"league-1", int32(2023), int32(1), int32(3), "4262921", "QB", nil, time.Now().UTC()},
+		}},
+		archives: []Archive{{LeagueID: "league-1", Season: 2023, Table: "league_rosters", ObjectKey: "seasons/2023/league-1/league_rosters.parquet"}},
+	}
+	store := memoryStore{}
+	archiver := NewArchiver(repo, store, Policy{HotSeasons: 2})
+
+	// A rehydrated season is archived again without another upload
+	report, err := archiver.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Seasons)
+	assert.Empty(t, store)
+	assert.Len(t, repo.archives, 1)
+}
@@ -0,0 +1,322 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/parquet"
+)
+
+// restoreBatch is how many rows one insert loads back
+const restoreBatch = 500
+
+// PostgresRepository implements Repository for PostgreSQL
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL archive repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// Candidates finds league seasons with detail rows before season
+func (r *PostgresRepository) Candidates(ctx context.Context, season int, rehydratedAfter time.Time, limit int) ([]LeagueSeason, error) {
+	var detail []string
+	for _, t := range Tables {
+		detail = append(detail, fmt.Sprintf(`SELECT DISTINCT league_id, season FROM %s WHERE season < $1`, t.Name))
+	}
+
+	query := `
+		SELECT s.league_id, s.season
+		FROM (` + strings.Join(detail, " UNION ") + `) s
+		WHERE NOT EXISTS (
+			SELECT 1 FROM league_season_archives a
+			WHERE a.league_id = s.league_id AND a.season = s.season
+				AND a.rehydrated_at > $2
+		)
+		ORDER BY s.season, s.league_id
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, rehydratedAfter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find seasons to archive: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []LeagueSeason
+	for rows.Next() {
+		var c LeagueSeason
+		if err := rows.Scan(&c.LeagueID, &c.Season); err != nil {
+			return nil, fmt.Errorf("failed to scan season to archive: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// Export reads a table's rows for a league season
+func (r *PostgresRepository) Export(ctx context.Context, table Table, leagueID string, season int) ([][]interface{}, error) {
+	names := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		names[i] = col.Name
+	}
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE league_id = $1 AND season = $2 ORDER BY %s`,
+		strings.Join(names, ", "), table.Name, table.Key)
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export %s: %w", table.Name, err)
+	}
+	defer rows.Close()
+
+	var out [][]interface{}
+	for rows.Next() {
+		dest := make([]interface{}, len(table.Columns))
+		for i, col := range table.Columns {
+			dest[i] = scanTarget(col.Type)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan %s row: %w", table.Name, err)
+		}
+		row := make([]interface{}, len(dest))
+		for i, d := range dest {
+			row[i] = scannedValue(d)
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// Complete replaces a league season's detail with summaries
+func (r *PostgresRepository) Complete(ctx context.Context, leagueID string, season int, archives []Archive) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, a := range archives {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO league_season_archives (league_id, season, table_name, object_key, row_count, byte_size)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (league_id, season, table_name) DO UPDATE SET
+				object_key = EXCLUDED.object_key,
+				row_count = EXCLUDED.row_count,
+				byte_size = EXCLUDED.byte_size,
+				archived_at = NOW()
+		`, leagueID, season, a.Table, a.ObjectKey, a.Rows, a.Bytes); err != nil {
+			return fmt.Errorf("failed to record %s archive: %w", a.Table, err)
+		}
+	}
+
+	// Each team's week, with its opponent and starters' points, outlives
+	// the per-player detail
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO league_weekly_summaries (league_id, season, week, team_id, opponent_id, points, projected_points, players)
+		SELECT league_id, season, week, team_id, MAX(opponent_id),
+			SUM(points) FILTER (WHERE starter), SUM(projected_points) FILTER (WHERE starter), COUNT(*)
+		FROM league_box_scores
+		WHERE league_id = $1 AND season = $2
+		GROUP BY league_id, season, week, team_id
+		ON CONFLICT (league_id, season, week, team_id) DO NOTHING
+	`, leagueID, season); err != nil {
+		return fmt.Errorf("failed to summarize season: %w", err)
+	}
+
+	// Only tables with an archive lose their detail
+	for _, t := range Tables {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			DELETE FROM %s
+			WHERE league_id = $1 AND season = $2
+				AND EXISTS (
+					SELECT 1 FROM league_season_archives
+					WHERE league_id = $1 AND season = $2 AND table_name = $3
+				)
+		`, t.Name), leagueID, season, t.Name); err != nil {
+			return fmt.Errorf("failed to delete archived %s: %w", t.Name, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE league_season_archives SET rehydrated_at = NULL
+		WHERE league_id = $1 AND season = $2
+	`, leagueID, season); err != nil {
+		return fmt.Errorf("failed to update archives: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit archive: %w", err)
+	}
+	return nil
+}
+
+// Restore loads a table's archived rows back in batches
+func (r *PostgresRepository) Restore(ctx context.Context, table Table, leagueID string, season int, rows [][]interface{}) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	names := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		names[i] = col.Name
+	}
+
+	for start := 0; start < len(rows); start += restoreBatch {
+		end := start + restoreBatch
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		var placeholders []string
+		var args []interface{}
+		for _, row := range rows[start:end] {
+			values := make([]string, len(row))
+			for i, v := range row {
+				args = append(args, v)
+				values[i] = fmt.Sprintf("$%d", len(args))
+			}
+			placeholders = append(placeholders, "("+strings.Join(values, ", ")+")")
+		}
+
+		query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES %s ON CONFLICT (%s) DO NOTHING`,
+			table.Name, strings.Join(names, ", "), strings.Join(placeholders, ", "), table.Key)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", table.Name, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE league_season_archives SET rehydrated_at = NOW()
+		WHERE league_id = $1 AND season = $2 AND table_name = $3
+	`, leagueID, season, table.Name); err != nil {
+		return fmt.Errorf("failed to mark %s rehydrated: %w", table.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return nil
+}
+
+// List returns a league's archives, newest season first
+func (r *PostgresRepository) List(ctx context.Context, leagueID string) ([]Archive, error) {
+	query := `
+		SELECT league_id, season, table_name, object_key, row_count, byte_size, archived_at, rehydrated_at
+		FROM league_season_archives
+		WHERE league_id = $1
+		ORDER BY season DESC, table_name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archives: %w", err)
+	}
+	defer rows.Close()
+
+	var archives []Archive
+	for rows.Next() {
+		var a Archive
+		var rehydratedAt sql.NullTime
+		if err := rows.Scan(&a.LeagueID, &a.Season, &a.Table, &a.ObjectKey, &a.Rows, &a.Bytes, &a.ArchivedAt, &rehydratedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan archive: %w", err)
+		}
+		if rehydratedAt.Valid {
+			a.RehydratedAt = &rehydratedAt.Time
+		}
+		archives = append(archives, a)
+	}
+	return archives, rows.Err()
+}
+
+// Summaries returns a league season's weekly team summaries
+func (r *PostgresRepository) Summaries(ctx context.Context, leagueID string, season int) ([]WeeklySummary, error) {
+	query := `
+		SELECT week, team_id, opponent_id, points, projected_points, players
+		FROM league_weekly_summaries
+		WHERE league_id = $1 AND season = $2
+		ORDER BY week, team_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly summaries: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []WeeklySummary
+	for rows.Next() {
+		var s WeeklySummary
+		var opponentID sql.NullInt64
+		var points, projected sql.NullFloat64
+		if err := rows.Scan(&s.Week, &s.TeamID, &opponentID, &points, &projected, &s.Players); err != nil {
+			return nil, fmt.Errorf("failed to scan weekly summary: %w", err)
+		}
+		if opponentID.Valid {
+			id := int(opponentID.Int64)
+			s.OpponentID = &id
+		}
+		if points.Valid {
+			s.Points = &points.Float64
+		}
+		if projected.Valid {
+			s.ProjectedPoints = &projected.Float64
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries, rows.Err()
+}
+
+// scanTarget returns a nullable destination for a column of typ
+func scanTarget(typ parquet.Type) interface{} {
+	switch typ {
+	case parquet.Boolean:
+		return &sql.NullBool{}
+	case parquet.Int32:
+		return &sql.NullInt32{}
+	case parquet.Int64:
+		return &sql.NullInt64{}
+	case parquet.Double:
+		return &sql.NullFloat64{}
+	case parquet.Timestamp:
+		return &sql.NullTime{}
+	}
+	return &sql.NullString{}
+}
+
+// scannedValue unwraps a scanned value, nil when null
+func scannedValue(dest interface{}) interface{} {
+	switch d := dest.(type) {
+	case *sql.NullBool:
+		if d.Valid {
+			return d.Bool
+		}
+	case *sql.NullInt32:
+		if d.Valid {
+			return d.Int32
+		}
+	case *sql.NullInt64:
+		if d.Valid {
+			return d.Int64
+		}
+	case *sql.NullFloat64:
+		if d.Valid {
+			return d.Float64
+		}
+	case *sql.NullTime:
+		if d.Valid {
+			return d.Time.UTC()
+		}
+	case *sql.NullString:
+		if d.Valid {
+			return d.String
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/llm"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// answerMaxTokens caps the model's answer
+const answerMaxTokens = 600
+
+var (
+	// ErrWeekRequired is returned when a question has no league to take the
+	// season and week from and names neither
+	ErrWeekRequired = errors.New("season and week are required without a league")
+	// ErrNoFacts is returned when none of our data bears on the question, so
+	// the model is not asked
+	ErrNoFacts = errors.New("no projections or roster data matched the question")
+)
+
+// Question is a user's question and the league it is about
+type Question struct {
+	Text string
+	// LeagueID defaults to the user's selected league
+	LeagueID uuid.UUID
+	// TeamID is the user's team in the league, for roster context
+	TeamID int
+	// Season and Week default to the league's latest synced week
+	Season int
+	Week   int
+}
+
+// Answer is the model's answer with the facts it cited
+type Answer struct {
+	Answer    string `json:"answer"`
+	Citations []Fact `json:"citations"`
+	Season    int    `json:"season"`
+	Week      int    `json:"week"`
+	Model     string `json:"model"`
+}
+
+// Assistant answers roster questions with a language model, grounded in our
+// projections, schedules and synced league rosters
+type Assistant struct {
+	client         llm.Client
+	projectionRepo projections.Repository
+	leagueRepo     repositories.LeagueRepository
+	rosterRepo     repositories.LeagueRosterRepository
+	authz          *policy.Authorizer
+}
+
+// NewAssistant creates an assistant that asks client
+func NewAssistant(
+	client llm.Client,
+	projectionRepo projections.Repository,
+	leagueRepo repositories.LeagueRepository,
+	rosterRepo repositories.LeagueRosterRepository,
+) *Assistant {
+	return &Assistant{
+		client:         client,
+		projectionRepo: projectionRepo,
+		leagueRepo:     leagueRepo,
+		rosterRepo:     rosterRepo,
+	}
+}
+
+// WithAuthorizer sets who besides a league's owner may ask about it
+func (a *Assistant) WithAuthorizer(authz *policy.Authorizer) *Assistant {
+	a.authz = authz
+	return a
+}
+
+// Ask retrieves the data a question needs and has the model answer from it
+func (a *Assistant) Ask(ctx context.Context, userID uuid.UUID, q Question) (*Answer, error) {
+	e := evidence{question: q.Text, season: q.Season, week: q.Week, teamID: q.TeamID}
+
+	league, err := a.league(ctx, userID, q.LeagueID)
+	if err != nil {
+		return nil, err
+	}
+	if league != nil {
+		e.scoringType = league.ScoringType
+		if e.season == 0 {
+			e.season = league.Season
+		}
+		rosterWeek, err := a.rosterRepo.GetLatestWeek(ctx, league.ID.String(), e.season)
+		if err != nil {
+			return nil, err
+		}
+		if e.week == 0 {
+			e.week = rosterWeek
+		}
+		if rosterWeek > 0 {
+			e.rosters, err = a.rosterRepo.GetRosters(ctx, league.ID.String(), e.season, rosterWeek)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if e.season == 0 || e.week == 0 {
+		return nil, ErrWeekRequired
+	}
+
+	e.projections, err = a.projectionRepo.GetConsensusProjections(ctx, e.season, e.week)
+	if err != nil {
+		return nil, err
+	}
+	e.games, err = a.projectionRepo.GetSchedule(ctx, e.season, e.week, e.week)
+	if err != nil {
+		return nil, err
+	}
+	e.pointsAllowed, err = a.projectionRepo.GetPointsAllowed(ctx, e.season, e.week)
+	if err != nil {
+		return nil, err
+	}
+
+	facts := gather(e)
+	if len(facts) == 0 {
+		return nil, ErrNoFacts
+	}
+
+	resp, err := a.client.Complete(ctx, llm.Request{
+		System:    systemPrompt,
+		Prompt:    prompt(q.Text, facts),
+		MaxTokens: answerMaxTokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+
+	return &Answer{
+		Answer:    resp.Text,
+		Citations: citations(resp.Text, facts),
+		Season:    e.season,
+		Week:      e.week,
+		Model:     resp.Model,
+	}, nil
+}
+
+// league returns the league the question is about: the one asked for, which
+// the user must be allowed to read, or else their selected league. Returns nil when the
+// user has not selected one.
+func (a *Assistant) league(ctx context.Context, userID, leagueID uuid.UUID) (*models.League, error) {
+	id := leagueID.String()
+	if leagueID == uuid.Nil {
+		selected, err := a.leagueRepo.GetSelectedLeagueID(ctx, userID.String())
+		if err != nil || selected == "" {
+			return nil, err
+		}
+		id = selected
+	}
+
+	league, err := a.leagueRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrLeagueNotFound) && leagueID == uuid.Nil {
+		// The selected league has since been deleted
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = a.authz.Authorize(ctx, policy.User(userID.String()), policy.ActionRead, policy.League(league))
+	if errors.Is(err, policy.ErrForbidden) {
+		return nil, repositories.ErrLeagueNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return league, nil
+}
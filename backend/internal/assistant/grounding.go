@@ -0,0 +1,382 @@
+package assistant
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// Fact kinds
+const (
+	FactProjection = "projection"
+	FactMatchup    = "matchup"
+	FactRoster     = "roster"
+	FactWaiver     = "waiver"
+)
+
+const (
+	// maxMentioned caps the players matched from a question
+	maxMentioned = 8
+	// maxPerToken is how many players a single ambiguous name such as
+	// "Moore" may match, best projected first
+	maxPerToken = 2
+	// maxWaiverTargets is how many unrostered players are offered for
+	// waiver questions
+	maxWaiverTargets = 8
+)
+
+// Fact is one piece of our data given to the model, cited by ID
+type Fact struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind"`
+	Subject string `json:"subject"`
+	Detail  string `json:"detail"`
+}
+
+// evidence is everything retrieved for a question
+type evidence struct {
+	question    string
+	season      int
+	week        int
+	scoringType string
+	projections []projections.ConsensusProjection
+	// rosters are every team's rosters in the user's league, if any
+	rosters       []models.LeagueRosterEntry
+	teamID        int
+	games         []projections.ScheduledGame
+	pointsAllowed []projections.PointsAllowed
+}
+
+// stopwords are question words that are also player names
+var stopwords = map[string]bool{
+	"who": true, "what": true, "should": true, "start": true, "sit": true, "bench": true,
+	"will": true, "the": true, "and": true, "for": true, "this": true, "week": true,
+	"play": true, "target": true, "waiver": true, "waivers": true, "pick": true,
+	"add": true, "drop": true, "trade": true, "team": true, "over": true, "than": true,
+	"best": true, "better": true, "good": true, "my": true, "or": true,
+}
+
+// waiverWords mark a question about players to pick up
+var waiverWords = map[string]bool{
+	"waiver": true, "waivers": true, "pickup": true, "pickups": true, "add": true,
+	"stream": true, "streaming": true, "agent": true, "agents": true, "target": true,
+}
+
+// positionWords map question words to the position they ask about
+var positionWords = map[string]string{
+	"qb": "QB", "qbs": "QB", "quarterback": "QB",
+	"rb": "RB", "rbs": "RB", "running": "RB",
+	"wr": "WR", "wrs": "WR", "receiver": "WR", "receivers": "WR",
+	"te": "TE", "tes": "TE", "tight": "TE",
+	"k": "K", "kicker": "K",
+	"dst": "DST", "defense": "DST",
+}
+
+// nameSuffixes are dropped when matching names
+var nameSuffixes = map[string]bool{"jr": true, "sr": true, "ii": true, "iii": true, "iv": true}
+
+// gather turns retrieved data into numbered facts: the players the question
+// names, with their projections, matchups and whether they are rostered; the
+// user's roster; and for waiver questions, the best unrostered players
+func gather(e evidence) []Fact {
+	var facts []Fact
+	add := func(kind, subject, detail string) {
+		facts = append(facts, Fact{ID: fmt.Sprintf("F%d", len(facts)+1), Kind: kind, Subject: subject, Detail: detail})
+	}
+
+	byName := make(map[string]projections.ConsensusProjection, len(e.projections))
+	for _, p := range e.projections {
+		byName[normalizeName(p.PlayerName)] = p
+	}
+	owner := make(map[string]models.LeagueRosterEntry, len(e.rosters))
+	for _, r := range e.rosters {
+		owner[normalizeName(r.PlayerName)] = r
+	}
+	opponents := opponentsByTeam(e.games)
+	allowed := make(map[string]projections.PointsAllowed, len(e.pointsAllowed))
+	for _, a := range e.pointsAllowed {
+		allowed[a.Defense+"|"+a.Position] = a
+	}
+
+	mentioned := make(map[string]bool)
+	for _, p := range matchPlayers(e.question, e.projections) {
+		mentioned[normalizeName(p.PlayerName)] = true
+		add(FactProjection, p.PlayerName, fmt.Sprintf("%s (%s, %s) projects %.1f %s points in week %d, with a floor of %.1f and a ceiling of %.1f PPR",
+			p.PlayerName, p.Position, p.Team, points(p, e.scoringType), scoringLabel(e.scoringType), e.week, p.FloorPPR, p.CeilingPPR))
+
+		if game, ok := opponents[p.Team]; ok {
+			detail := fmt.Sprintf("%s %s %s in week %d", p.Team, game.where, game.opponent, e.week)
+			if a, ok := allowed[game.opponent+"|"+p.Position]; ok {
+				detail += fmt.Sprintf("; %s allows %.1f PPR points per game to %ss over %d games", game.opponent, a.AvgPoints, p.Position, a.Games)
+			}
+			add(FactMatchup, p.PlayerName, detail)
+		}
+
+		if len(e.rosters) > 0 {
+			switch r, ok := owner[normalizeName(p.PlayerName)]; {
+			case !ok:
+				add(FactRoster, p.PlayerName, fmt.Sprintf("%s is not rostered in your league", p.PlayerName))
+			case e.teamID > 0 && r.TeamID == e.teamID:
+				add(FactRoster, p.PlayerName, fmt.Sprintf("%s is on your roster in the %s slot%s", p.PlayerName, r.LineupSlot, injuryNote(r)))
+			default:
+				add(FactRoster, p.PlayerName, fmt.Sprintf("%s is rostered by team %d%s", p.PlayerName, r.TeamID, injuryNote(r)))
+			}
+		}
+	}
+
+	if e.teamID > 0 {
+		for _, r := range e.rosters {
+			if r.TeamID != e.teamID || mentioned[normalizeName(r.PlayerName)] {
+				continue
+			}
+			detail := fmt.Sprintf("Your roster has %s (%s, %s) in the %s slot", r.PlayerName, r.Position, r.NFLTeam, r.LineupSlot)
+			if p, ok := byName[normalizeName(r.PlayerName)]; ok {
+				detail += fmt.Sprintf(", projecting %.1f %s points", points(p, e.scoringType), scoringLabel(e.scoringType))
+			}
+			add(FactRoster, r.PlayerName, detail+injuryNote(r))
+		}
+	}
+
+	if len(e.rosters) > 0 && asksAboutWaivers(e.question) {
+		position := askedPosition(e.question)
+		available := make([]projections.ConsensusProjection, 0, len(e.projections))
+		for _, p := range e.projections {
+			if _, rostered := owner[normalizeName(p.PlayerName)]; rostered {
+				continue
+			}
+			if position != "" && p.Position != position {
+				continue
+			}
+			available = append(available, p)
+		}
+		sort.SliceStable(available, func(i, j int) bool {
+			return points(available[i], e.scoringType) > points(available[j], e.scoringType)
+		})
+		if len(available) > maxWaiverTargets {
+			available = available[:maxWaiverTargets]
+		}
+		for _, p := range available {
+			add(FactWaiver, p.PlayerName, fmt.Sprintf("%s (%s, %s) is not rostered in your league and projects %.1f %s points in week %d",
+				p.PlayerName, p.Position, p.Team, points(p, e.scoringType), scoringLabel(e.scoringType), e.week))
+		}
+	}
+
+	return facts
+}
+
+// matchPlayers finds the players a question names. A full name always
+// matches; a lone first or last name matches the best projected players
+// with it.
+func matchPlayers(question string, players []projections.ConsensusProjection) []projections.ConsensusProjection {
+	tokens := nameTokens(question)
+	padded := " " + strings.Join(tokens, " ") + " "
+
+	type match struct {
+		player projections.ConsensusProjection
+		at     int // Where the question names the player
+	}
+	var matches []match
+	seen := make(map[string]bool)
+	used := make(map[string]bool)
+	for _, p := range players {
+		name := normalizeName(p.PlayerName)
+		at := strings.Index(padded, " "+name+" ")
+		if name == "" || seen[name] || at < 0 {
+			continue
+		}
+		matches = append(matches, match{player: p, at: at})
+		seen[name] = true
+		for _, t := range strings.Fields(name) {
+			used[t] = true
+		}
+	}
+
+	for _, token := range tokens {
+		if used[token] || stopwords[token] || len(token) < 3 {
+			continue
+		}
+		var candidates []projections.ConsensusProjection
+		for _, p := range players {
+			name := normalizeName(p.PlayerName)
+			parts := strings.Fields(name)
+			if seen[name] || len(parts) == 0 {
+				continue
+			}
+			if parts[0] == token || parts[len(parts)-1] == token {
+				candidates = append(candidates, p)
+			}
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].PointsPPR > candidates[j].PointsPPR
+		})
+		if len(candidates) > maxPerToken {
+			candidates = candidates[:maxPerToken]
+		}
+		at := strings.Index(padded, " "+token+" ")
+		for _, p := range candidates {
+			matches = append(matches, match{player: p, at: at})
+			seen[normalizeName(p.PlayerName)] = true
+		}
+		used[token] = true
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].at < matches[j].at
+	})
+	if len(matches) > maxMentioned {
+		matches = matches[:maxMentioned]
+	}
+	matched := make([]projections.ConsensusProjection, len(matches))
+	for i, m := range matches {
+		matched[i] = m.player
+	}
+	return matched
+}
+
+// citationPattern finds fact IDs cited as [F1]
+var citationPattern = regexp.MustCompile(`\[(F\d+)\]`)
+
+// citations returns the facts an answer cites, in the order first cited
+func citations(answer string, facts []Fact) []Fact {
+	byID := make(map[string]Fact, len(facts))
+	for _, f := range facts {
+		byID[f.ID] = f
+	}
+
+	cited := []Fact{}
+	seen := make(map[string]bool)
+	for _, m := range citationPattern.FindAllStringSubmatch(answer, -1) {
+		f, ok := byID[m[1]]
+		if !ok || seen[f.ID] {
+			continue
+		}
+		cited = append(cited, f)
+		seen[f.ID] = true
+	}
+	return cited
+}
+
+// systemPrompt keeps the model to our data
+const systemPrompt = `You are the fantasy football assistant for NFL Analytics. Answer the user's question using only the numbered facts provided, which come from our projections, schedules and league rosters. Cite every fact you rely on by its ID in square brackets, like [F2]. If the facts do not answer the question, say what is missing rather than guessing. Keep answers under 150 words.`
+
+// prompt lists the facts ahead of the question
+func prompt(question string, facts []Fact) string {
+	var b strings.Builder
+	b.WriteString("Facts:\n")
+	for _, f := range facts {
+		fmt.Fprintf(&b, "[%s] %s\n", f.ID, f.Detail)
+	}
+	fmt.Fprintf(&b, "\nQuestion: %s", strings.TrimSpace(question))
+	return b.String()
+}
+
+// gameSide is a team's opponent and whether it is home or away
+type gameSide struct {
+	opponent string
+	where    string
+}
+
+// injuryNote describes a rostered player's injury status, if any
+func injuryNote(r models.LeagueRosterEntry) string {
+	if r.InjuryStatus == "" || r.InjuryStatus == "ACTIVE" {
+		return ""
+	}
+	return fmt.Sprintf(", listed %s", r.InjuryStatus)
+}
+
+func opponentsByTeam(games []projections.ScheduledGame) map[string]gameSide {
+	sides := make(map[string]gameSide, 2*len(games))
+	for _, g := range games {
+		sides[g.HomeTeam] = gameSide{opponent: g.AwayTeam, where: "hosts"}
+		sides[g.AwayTeam] = gameSide{opponent: g.HomeTeam, where: "visits"}
+	}
+	return sides
+}
+
+func asksAboutWaivers(question string) bool {
+	for _, t := range nameTokens(question) {
+		if waiverWords[t] {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(question), "pick up")
+}
+
+// askedPosition returns the position a question asks about, or empty
+func askedPosition(question string) string {
+	for _, t := range nameTokens(question) {
+		if position, ok := positionWords[t]; ok {
+			return position
+		}
+	}
+	return ""
+}
+
+// points is a projection in the league's scoring
+func points(p projections.ConsensusProjection, scoringType string) float64 {
+	switch strings.ToUpper(scoringType) {
+	case "STANDARD":
+		return p.PointsStandard
+	case "HALF_PPR":
+		return (p.PointsPPR + p.PointsStandard) / 2
+	default:
+		return p.PointsPPR
+	}
+}
+
+func scoringLabel(scoringType string) string {
+	switch strings.ToUpper(scoringType) {
+	case "STANDARD":
+		return "standard"
+	case "HALF_PPR":
+		return "half PPR"
+	default:
+		return "PPR"
+	}
+}
+
+// normalizeName lowercases a name and drops punctuation and suffixes, so
+// "D.J. Moore" and "dj moore" match
+func normalizeName(name string) string {
+	return strings.Join(nameTokens(name), " ")
+}
+
+// nameTokens splits text into lowercase words without punctuation,
+// possessives or name suffixes
+func nameTokens(text string) []string {
+	runes := []rune(strings.ToLower(text))
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			b.WriteRune(r)
+		case r == '\'' || r == '’':
+			// Ja'Marr keeps his name, Puka's loses the possessive
+			if i+1 < len(runes) && runes[i+1] == 's' && (i+2 == len(runes) || !isLetter(runes[i+2])) {
+				i++
+			}
+		case r == '.':
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	var tokens []string
+	for _, t := range strings.Fields(b.String()) {
+		t = strings.Trim(t, "-")
+		if t == "" || nameSuffixes[t] {
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+func isLetter(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
@@ -0,0 +1,120 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProjections() []projections.ConsensusProjection {
+	return []projections.ConsensusProjection{
+		{PlayerName: "Puka Nacua", Position: "WR", Team: "LAR", PointsPPR: 17.2, PointsStandard: 11.0, FloorPPR: 9.5, CeilingPPR: 26.1},
+		{PlayerName: "D.J. Moore", Position: "WR", Team: "CHI", PointsPPR: 14.8, PointsStandard: 9.9, FloorPPR: 7.2, CeilingPPR: 22.4},
+		{PlayerName: "Elijah Moore", Position: "WR", Team: "CLE", PointsPPR: 8.1, PointsStandard: 5.0},
+		{PlayerName: "Rondale Moore", Position: "WR", Team: "ATL", PointsPPR: 6.0, PointsStandard: 4.1},
+		{PlayerName: "Ja'Marr Chase", Position: "WR", Team: "CIN", PointsPPR: 20.3, PointsStandard: 14.2},
+		{PlayerName: "Jaylen Warren", Position: "RB", Team: "PIT", PointsPPR: 10.4, PointsStandard: 8.0},
+		{PlayerName: "Tyler Allgeier", Position: "RB", Team: "ATL", PointsPPR: 9.7, PointsStandard: 8.3},
+	}
+}
+
+func names(players []projections.ConsensusProjection) []string {
+	var result []string
+	for _, p := range players {
+		result = append(result, p.PlayerName)
+	}
+	return result
+}
+
+func TestMatchPlayers(t *testing.T) {
+	players := testProjections()
+
+	assert.Equal(t, []string{"Puka Nacua", "D.J. Moore"},
+		names(matchPlayers("Should I start Puka or DJ Moore?", players)))
+	assert.Equal(t, []string{"Ja'Marr Chase"},
+		names(matchPlayers("Is Ja'Marr's matchup good?", players)))
+	// A lone last name matches the best projected players with it
+	assert.Equal(t, []string{"D.J. Moore", "Elijah Moore"},
+		names(matchPlayers("What about Moore?", players)))
+	assert.Empty(t, matchPlayers("Who should I start this week?", players))
+}
+
+func TestGather(t *testing.T) {
+	e := evidence{
+		question:    "Should I start Puka or DJ Moore?",
+		season:      2024,
+		week:        5,
+		scoringType: "PPR",
+		projections: testProjections(),
+		rosters: []models.LeagueRosterEntry{
+			{TeamID: 1, PlayerName: "Puka Nacua", Position: "WR", NFLTeam: "LAR", LineupSlot: "WR"},
+			{TeamID: 1, PlayerName: "DJ Moore", Position: "WR", NFLTeam: "CHI", LineupSlot: "BE", InjuryStatus: "QUESTIONABLE"},
+			{TeamID: 1, PlayerName: "Jaylen Warren", Position: "RB", NFLTeam: "PIT", LineupSlot: "RB"},
+			{TeamID: 2, PlayerName: "Ja'Marr Chase", Position: "WR", NFLTeam: "CIN", LineupSlot: "WR"},
+		},
+		teamID: 1,
+		games:  []projections.ScheduledGame{{Season: 2024, Week: 5, HomeTeam: "LAR", AwayTeam: "GB"}},
+		pointsAllowed: []projections.PointsAllowed{
+			{Defense: "GB", Position: "WR", AvgPoints: 38.4, Games: 4},
+		},
+	}
+
+	facts := gather(e)
+	require.Len(t, facts, 6)
+
+	assert.Equal(t, Fact{ID: "F1", Kind: FactProjection, Subject: "Puka Nacua",
+		Detail: "Puka Nacua (WR, LAR) projects 17.2 PPR points in week 5, with a floor of 9.5 and a ceiling of 26.1 PPR"}, facts[0])
+	assert.Equal(t, "LAR hosts GB in week 5; GB allows 38.4 PPR points per game to WRs over 4 games", facts[1].Detail)
+	assert.Equal(t, "Puka Nacua is on your roster in the WR slot", facts[2].Detail)
+	// No game on the schedule for Chicago, so no matchup fact
+	assert.Equal(t, FactProjection, facts[3].Kind)
+	assert.Equal(t, "D.J. Moore is on your roster in the BE slot, listed QUESTIONABLE", facts[4].Detail)
+	// The rest of the roster follows the players asked about
+	assert.Equal(t, "Your roster has Jaylen Warren (RB, PIT) in the RB slot, projecting 10.4 PPR points", facts[5].Detail)
+
+	// Without a team, the rest of the roster is not listed and ownership is
+	// by team
+	e.teamID = 0
+	e.question = "Is Ja'Marr Chase worth it?"
+	facts = gather(e)
+	require.Len(t, facts, 2)
+	assert.Equal(t, "Ja'Marr Chase is rostered by team 2", facts[1].Detail)
+}
+
+func TestGather_WaiverTargets(t *testing.T) {
+	e := evidence{
+		question:    "Which RB should I target on waivers?",
+		week:        5,
+		scoringType: "STANDARD",
+		projections: testProjections(),
+		rosters: []models.LeagueRosterEntry{
+			{TeamID: 2, PlayerName: "Jaylen Warren", Position: "RB"},
+		},
+	}
+
+	facts := gather(e)
+	require.Len(t, facts, 1)
+	assert.Equal(t, FactWaiver, facts[0].Kind)
+	assert.Equal(t, "Tyler Allgeier (RB, ATL) is not rostered in your league and projects 8.3 standard points in week 5", facts[0].Detail)
+}
+
+func TestCitations(t *testing.T) {
+	facts := []Fact{{ID: "F1"}, {ID: "F2"}, {ID: "F3"}}
+
+	cited := citations("Start Puka [F2] over Moore [F1][F2]; ignore [F9].", facts)
+	require.Len(t, cited, 2)
+	assert.Equal(t, "F2", cited[0].ID)
+	assert.Equal(t, "F1", cited[1].ID)
+
+	assert.Empty(t, citations("No idea.", facts))
+}
+
+func TestPrompt(t *testing.T) {
+	p := prompt(" Who? ", []Fact{{ID: "F1", Detail: "Puka projects 17.2"}})
+	assert.True(t, strings.HasPrefix(p, "Facts:\n[F1] Puka projects 17.2\n"))
+	assert.True(t, strings.HasSuffix(p, "Question: Who?"))
+}
@@ -0,0 +1,108 @@
+// Package audit keeps a security log of authentication events: sign-ins
+// that worked and those that failed, password changes, token refreshes,
+// platform credentials and account deletion. Users can review their own
+// events; the log outlives the account so deletions stay on record.
+package audit
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/auth"
+)
+
+// Security events
+const (
+	EventLoginSucceeded          = "login_succeeded"
+	EventLoginFailed             = "login_failed"
+	EventPasswordChanged         = "password_changed"
+	EventTokenRefreshed          = "token_refreshed"
+	EventCredentialsConnected    = "credentials_connected"
+	EventCredentialsDisconnected = "credentials_disconnected"
+	EventAccountDeleted          = "account_deleted"
+	// EventNewDeviceLogin is a sign-in from a device or network the user had
+	// not signed in from before
+	EventNewDeviceLogin = "new_device_login"
+)
+
+// Reasons a login failed, in a failed login's details
+const (
+	ReasonUnknownEmail     = "unknown_email"
+	ReasonWrongPassword    = "wrong_password"
+	ReasonInactive         = "inactive_account"
+	ReasonInvalidTwoFactor = "invalid_two_factor_code"
+	ReasonTwoFactorLocked  = "two_factor_locked"
+	// ReasonInvalidLoginVerification is a wrong code emailed to confirm a
+	// sign-in from a new device
+	ReasonInvalidLoginVerification = "invalid_login_verification_code"
+)
+
+// Event is one security event. UserID is nil for failed logins to emails
+// with no account.
+type Event struct {
+	ID        uuid.UUID              `json:"id"`
+	UserID    *uuid.UUID             `json:"-"`
+	Event     string                 `json:"event"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	IPAddress string                 `json:"ip_address,omitempty"`
+	UserAgent string                 `json:"user_agent,omitempty"`
+	Device    activity.Device        `json:"device"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// Filter narrows a user's events. Zero values match everything.
+type Filter struct {
+	Events []string
+	// Before pages back from the oldest event already seen
+	Before time.Time
+	Limit  int
+}
+
+// Repository stores security events
+type Repository interface {
+	Record(ctx context.Context, event *Event) error
+	// List returns a user's events, newest first
+	List(ctx context.Context, userID uuid.UUID, filter Filter) ([]Event, error)
+}
+
+// Logger records security events. Recording never fails the action being
+// recorded. A nil Logger discards every event.
+type Logger struct {
+	repo Repository
+}
+
+// NewLogger creates a logger that stores events in repo
+func NewLogger(repo Repository) *Logger {
+	return &Logger{repo: repo}
+}
+
+// Record stores an event for userID, nil if the user is unknown, with the
+// client set on ctx by auth.WithClient
+func (l *Logger) Record(ctx context.Context, userID *uuid.UUID, event string, details map[string]interface{}) {
+	if l == nil {
+		return
+	}
+	client := auth.ClientFromContext(ctx)
+	e := &Event{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Event:     event,
+		Details:   details,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		CreatedAt: time.Now(),
+	}
+	// The event already happened, so record it even if the client has gone
+	if err := l.repo.Record(context.WithoutCancel(ctx), e); err != nil {
+		log.Printf("Failed to record %s security event: %v", event, err)
+	}
+}
+
+// RecordRequest stores an event for the user making the request
+func (l *Logger) RecordRequest(c *gin.Context, userID uuid.UUID, event string, details map[string]interface{}) {
+	l.Record(auth.WithClient(c.Request.Context(), c), &userID, event, details)
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRepository struct {
+	events []*Event
+	err    error
+}
+
+func (r *fakeRepository) Record(ctx context.Context, event *Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.events = append(r.events, event)
+	return r.err
+}
+
+func (r *fakeRepository) List(ctx context.Context, userID uuid.UUID, filter Filter) ([]Event, error) {
+	return nil, nil
+}
+
+func TestLogger_RecordRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &fakeRepository{}
+	logger := NewLogger(repo)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/users/password", nil)
+	c.Request.Header.Set("User-Agent", "test-agent")
+	c.Request.RemoteAddr = "203.0.113.7:4321"
+
+	userID := uuid.New()
+	logger.RecordRequest(c, userID, EventPasswordChanged, nil)
+
+	require.Len(t, repo.events, 1)
+	event := repo.events[0]
+	assert.Equal(t, EventPasswordChanged, event.Event)
+	require.NotNil(t, event.UserID)
+	assert.Equal(t, userID, *event.UserID)
+	assert.Equal(t, "203.0.113.7", event.IPAddress)
+	assert.Equal(t, "test-agent", event.UserAgent)
+	assert.NotEqual(t, uuid.Nil, event.ID)
+}
+
+func TestLogger_RecordAfterCancel(t *testing.T) {
+	repo := &fakeRepository{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	NewLogger(repo).Record(ctx, nil, EventLoginFailed, map[string]interface{}{"reason": ReasonUnknownEmail})
+
+	require.Len(t, repo.events, 1)
+	assert.Nil(t, repo.events[0].UserID)
+	assert.Equal(t, ReasonUnknownEmail, repo.events[0].Details["reason"])
+}
+
+func TestLogger_NeverFails(t *testing.T) {
+	var logger *Logger
+	logger.Record(context.Background(), nil, EventLoginFailed, nil)
+
+	repo := &fakeRepository{err: errors.New("database down")}
+	NewLogger(repo).Record(context.Background(), nil, EventLoginFailed, nil)
+	assert.Len(t, repo.events, 1)
+}
@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/activity"
+)
+
+// maxListLimit bounds how many events one List call returns
+const maxListLimit = 100
+
+// PostgresRepository stores security events in the audit_events table
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new security event repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// Record stores an event
+func (r *PostgresRepository) Record(ctx context.Context, event *Event) error {
+	var details []byte
+	if len(event.Details) > 0 {
+		var err error
+		details, err = json.Marshal(event.Details)
+		if err != nil {
+			return fmt.Errorf("failed to marshal security event details: %w", err)
+		}
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_events (id, user_id, event, details, ip_address, user_agent, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::inet, NULLIF($6, ''), $7)`,
+		event.ID, event.UserID, event.Event, details, event.IPAddress, event.UserAgent, event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record security event: %w", err)
+	}
+	return nil
+}
+
+// List returns a user's events matching the filter, newest first
+func (r *PostgresRepository) List(ctx context.Context, userID uuid.UUID, filter Filter) ([]Event, error) {
+	conditions := []string{"user_id = $1"}
+	args := []interface{}{userID}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if len(filter.Events) > 0 {
+		add("event = ANY($%d)", pq.Array(filter.Events))
+	}
+	if !filter.Before.IsZero() {
+		add("created_at < $%d", filter.Before)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > maxListLimit {
+		limit = maxListLimit
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT id, event, details, COALESCE(host(ip_address), ''), COALESCE(user_agent, ''), created_at
+		FROM audit_events
+		WHERE %s
+		ORDER BY created_at DESC, id
+		LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query security events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		var details []byte
+		if err := rows.Scan(&e.ID, &e.Event, &details, &e.IPAddress, &e.UserAgent, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan security event: %w", err)
+		}
+		if len(details) > 0 {
+			if err := json.Unmarshal(details, &e.Details); err != nil {
+				return nil, fmt.Errorf("failed to decode security event details: %w", err)
+			}
+		}
+		id := userID
+		e.UserID = &id
+		e.Device = activity.DescribeDevice(e.UserAgent)
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBotProtection_IsDisposableEmail(t *testing.T) {
+	bp := NewBotProtection(nil, true, []string{"Burner.example"})
+
+	tests := []struct {
+		email string
+		want  bool
+	}{
+		{"user@gmail.com", false},
+		{"user@mailinator.com", true},
+		{"user@MAILINATOR.com", true},
+		{"user@eu.mailinator.com", true},
+		{"user@burner.example", true},
+		{"user@notmailinator.com", false},
+		{"not-an-email", false},
+	}
+
+	for _, tt := range tests {
+		if got := bp.IsDisposableEmail(tt.email); got != tt.want {
+			t.Errorf("IsDisposableEmail(%q) = %v, want %v", tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestBotProtection_Check(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nil allows everything", func(t *testing.T) {
+		var bp *BotProtection
+		if err := bp.Check(ctx, "user@mailinator.com", "", ""); err != nil {
+			t.Errorf("Check() error = %v", err)
+		}
+	})
+
+	t.Run("disposable email blocked only when enabled", func(t *testing.T) {
+		if err := NewBotProtection(nil, false, nil).Check(ctx, "user@yopmail.com", "", ""); err != nil {
+			t.Errorf("Check() error = %v, want nil", err)
+		}
+		err := NewBotProtection(nil, true, nil).Check(ctx, "user@yopmail.com", "", "")
+		if !errors.Is(err, ErrDisposableEmail) {
+			t.Errorf("Check() error = %v, want ErrDisposableEmail", err)
+		}
+	})
+
+	t.Run("captcha required when configured", func(t *testing.T) {
+		bp := NewBotProtection(newSiteVerifier("http://unused.invalid", "secret"), false, nil)
+		if err := bp.Check(ctx, "user@gmail.com", "", ""); !errors.Is(err, ErrCaptchaRequired) {
+			t.Errorf("Check() error = %v, want ErrCaptchaRequired", err)
+		}
+	})
+}
+
+func TestSiteVerifier_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm() error = %v", err)
+		}
+		if r.Form.Get("secret") != "secret" {
+			t.Errorf("secret = %q, want secret", r.Form.Get("secret"))
+		}
+		switch r.Form.Get("response") {
+		case "good":
+			w.Write([]byte(`{"success": true}`))
+		case "down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+		}
+	}))
+	defer server.Close()
+
+	verifier := newSiteVerifier(server.URL, "secret")
+	ctx := context.Background()
+
+	if err := verifier.Verify(ctx, "good", "203.0.113.1"); err != nil {
+		t.Errorf("Verify(good) error = %v", err)
+	}
+	if err := verifier.Verify(ctx, "bad", ""); !errors.Is(err, ErrCaptchaFailed) {
+		t.Errorf("Verify(bad) error = %v, want ErrCaptchaFailed", err)
+	}
+	if err := verifier.Verify(ctx, "down", ""); !errors.Is(err, ErrCaptchaUnavailable) {
+		t.Errorf("Verify(down) error = %v, want ErrCaptchaUnavailable", err)
+	}
+}
+
+func TestNewCaptchaVerifier(t *testing.T) {
+	for _, provider := range []string{"turnstile", "hcaptcha", "ReCAPTCHA"} {
+		if _, err := NewCaptchaVerifier(provider, "secret"); err != nil {
+			t.Errorf("NewCaptchaVerifier(%q) error = %v", provider, err)
+		}
+	}
+	if _, err := NewCaptchaVerifier("unknown", "secret"); err == nil {
+		t.Error("NewCaptchaVerifier(unknown) expected error")
+	}
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrPasswordBreached is returned for passwords found in known data breaches
+var ErrPasswordBreached = errors.New("password has appeared in a data breach, please choose a different password")
+
+// PwnedPasswordsURL is the Have I Been Pwned passwords API
+const PwnedPasswordsURL = "https://api.pwnedpasswords.com"
+
+// breachCheckTimeout bounds one breach lookup, so a slow API cannot hold up
+// registration
+const breachCheckTimeout = 3 * time.Second
+
+// BreachChecker tells whether a password is known from data breaches
+type BreachChecker interface {
+	Breached(ctx context.Context, password string) (bool, error)
+}
+
+// PwnedPasswords checks passwords against Have I Been Pwned with its
+// k-anonymity range API: only the first five hex characters of the
+// password's SHA-1 hash leave the server.
+type PwnedPasswords struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewPwnedPasswords creates a checker for the API at baseURL, or the public
+// API when it is empty
+func NewPwnedPasswords(baseURL string) *PwnedPasswords {
+	if baseURL == "" {
+		baseURL = PwnedPasswordsURL
+	}
+	return &PwnedPasswords{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: breachCheckTimeout},
+	}
+}
+
+// Breached looks the password's hash up among the breached hashes sharing
+// its prefix
+func (p *PwnedPasswords) Breached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create breach check request: %w", err)
+	}
+	// Padding hides from anyone watching how many hashes share the prefix
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("breach check request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("breach check returned status %d", resp.StatusCode)
+	}
+
+	// Each line is a hash suffix and how often it was seen; padding lines
+	// have a count of zero
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if ok && strings.EqualFold(line, suffix) && count != "0" {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read breach check response: %w", err)
+	}
+	return false, nil
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// "password" hashes to 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+func TestPwnedPasswords_Breached(t *testing.T) {
+	var gotPath, gotPadding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotPadding = r.Header.Get("Add-Padding")
+		fmt.Fprint(w, "003D68EB55068C33ACE09247EE4C639306B:3\r\n")
+		fmt.Fprint(w, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365\r\n")
+		fmt.Fprint(w, "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:0\r\n")
+	}))
+	defer server.Close()
+
+	checker := NewPwnedPasswords(server.URL)
+
+	breached, err := checker.Breached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.True(t, breached)
+	assert.Equal(t, "/range/5BAA6", gotPath, "only the hash prefix is sent")
+	assert.Equal(t, "true", gotPadding)
+
+	// Passwords whose hash is not listed are not breached
+	breached, err = checker.Breached(context.Background(), "Tr0ub4dor&Zebra!")
+	require.NoError(t, err)
+	assert.False(t, breached)
+}
+
+func TestPwnedPasswords_IgnoresPadding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:0\r\n")
+	}))
+	defer server.Close()
+
+	breached, err := NewPwnedPasswords(server.URL).Breached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.False(t, breached)
+}
+
+func TestPwnedPasswords_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewPwnedPasswords(server.URL).Breached(context.Background(), "password")
+	assert.Error(t, err)
+}
+
+type fakeBreachChecker struct {
+	breached bool
+	err      error
+	calls    int
+}
+
+func (f *fakeBreachChecker) Breached(ctx context.Context, password string) (bool, error) {
+	f.calls++
+	return f.breached, f.err
+}
+
+func TestValidatePasswordStrength_BreachCheck(t *testing.T) {
+	const strong = "Tr0ub4dor&Zebra!"
+
+	checker := &fakeBreachChecker{breached: true}
+	pm := NewPasswordManager(4).WithBreachCheck(checker)
+	assert.ErrorIs(t, pm.ValidatePasswordStrength(strong), ErrPasswordBreached)
+
+	// Passwords failing the local rules are never looked up
+	checker.calls = 0
+	assert.Error(t, pm.ValidatePasswordStrength("short"))
+	assert.Zero(t, checker.calls)
+
+	// An unreachable API lets the password through
+	pm = NewPasswordManager(4).WithBreachCheck(&fakeBreachChecker{err: errors.New("offline")})
+	assert.NoError(t, pm.ValidatePasswordStrength(strong))
+
+	pm = NewPasswordManager(4).WithBreachCheck(&fakeBreachChecker{})
+	assert.NoError(t, pm.ValidatePasswordStrength(strong))
+}
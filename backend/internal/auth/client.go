@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Client is the device a request came from, stored with the sessions it
+// signs in so users can tell them apart
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
+
+type clientKey struct{}
+
+// WithClient returns a copy of ctx carrying the request's client
+func WithClient(ctx context.Context, c *gin.Context) context.Context {
+	return context.WithValue(ctx, clientKey{}, Client{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	})
+}
+
+// ClientFromContext returns the client set by WithClient, or an empty one
+func ClientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientKey{}).(Client)
+	return client
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestCSRFMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CSRFMiddleware())
+	r.Any("/api/thing", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name       string
+		method     string
+		bearer     bool
+		authCookie bool
+		csrfCookie string
+		csrfHeader string
+		wantStatus int
+	}{
+		{"safe method", http.MethodGet, false, true, "", "", http.StatusOK},
+		{"no cookies", http.MethodPost, false, false, "", "", http.StatusOK},
+		{"bearer token", http.MethodPost, true, true, "", "", http.StatusOK},
+		{"matching token", http.MethodPost, false, true, "csrf-1", "csrf-1", http.StatusOK},
+		{"missing header", http.MethodPost, false, true, "csrf-1", "", http.StatusForbidden},
+		{"wrong header", http.MethodDelete, false, true, "csrf-1", "csrf-2", http.StatusForbidden},
+		{"missing cookie", http.MethodPut, false, true, "", "csrf-1", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/thing", nil)
+			if tt.bearer {
+				req.Header.Set(AuthorizationHeader, BearerPrefix+"token")
+			}
+			if tt.authCookie {
+				req.AddCookie(&http.Cookie{Name: AccessTokenCookie, Value: "token"})
+			}
+			if tt.csrfCookie != "" {
+				req.AddCookie(&http.Cookie{Name: CSRFCookie, Value: tt.csrfCookie})
+			}
+			if tt.csrfHeader != "" {
+				req.Header.Set(CSRFHeader, tt.csrfHeader)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestSetAuthCookies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := CookieConfig{Secure: true, SameSite: http.SameSiteStrictMode, AccessTTL: 15 * time.Minute, RefreshTTL: 24 * time.Hour}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	csrfToken, err := SetAuthCookies(c, cfg, "access", "refresh")
+	if err != nil {
+		t.Fatalf("SetAuthCookies() error = %v", err)
+	}
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range w.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	access, refresh, csrf := cookies[AccessTokenCookie], cookies[RefreshTokenCookie], cookies[CSRFCookie]
+	if access == nil || refresh == nil || csrf == nil {
+		t.Fatalf("cookies = %v, want access, refresh and csrf", cookies)
+	}
+	if access.Value != "access" || !access.HttpOnly || !access.Secure || access.SameSite != http.SameSiteStrictMode || access.MaxAge != 900 {
+		t.Errorf("access cookie = %+v", access)
+	}
+	if refresh.Value != "refresh" || !refresh.HttpOnly || refresh.Path != refreshCookiePath {
+		t.Errorf("refresh cookie = %+v", refresh)
+	}
+	if csrf.Value != csrfToken || csrf.HttpOnly {
+		t.Errorf("csrf cookie = %+v, want readable %q", csrf, csrfToken)
+	}
+}
+
+func TestAuthMiddleware_AccessTokenCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtManager := NewJWTManager("test_secret_key", 15*time.Minute, 7*24*time.Hour)
+	userID := uuid.New()
+	accessToken, _ := jwtManager.GenerateAccessToken(userID, "user@example.com")
+
+	r := gin.New()
+	r.GET("/api/me", AuthMiddleware(jwtManager, nil), func(c *gin.Context) {
+		id, _ := GetUserID(c)
+		c.String(http.StatusOK, id.String())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	req.AddCookie(&http.Cookie{Name: AccessTokenCookie, Value: accessToken})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || w.Body.String() != userID.String() {
+		t.Errorf("status = %d, body = %q, want 200 with user ID", w.Code, w.Body.String())
+	}
+}
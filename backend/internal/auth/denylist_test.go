@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestDenylist_Revoked(t *testing.T) {
+	issued := time.Now().Add(-5 * time.Minute)
+	claims := &Claims{
+		UserID:           uuid.New(),
+		SessionID:        uuid.New().String(),
+		RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1", IssuedAt: jwt.NewNumericDate(issued)},
+	}
+	unix := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }
+
+	tests := []struct {
+		name   string
+		values []interface{}
+		want   bool
+	}{
+		{"nothing revoked", []interface{}{nil, nil, nil}, false},
+		{"token revoked", []interface{}{"1", nil, nil}, true},
+		{"session revoked", []interface{}{nil, nil, "1"}, true},
+		{"user revoked after issue", []interface{}{nil, unix(time.Now()), nil}, true},
+		{"user revoked before issue", []interface{}{nil, unix(issued.Add(-time.Minute)), nil}, false},
+		{"user revoked same second", []interface{}{nil, unix(issued), nil}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := revoked(tt.values, claims); got != tt.want {
+				t.Errorf("revoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDenylist_Nil(t *testing.T) {
+	var d *Denylist
+	if NewDenylist(nil, time.Minute) != nil {
+		t.Error("NewDenylist(nil) should be nil")
+	}
+	if err := d.RevokeUser(context.Background(), uuid.New()); err != nil {
+		t.Errorf("RevokeUser() on nil denylist error = %v", err)
+	}
+	revoked, err := d.IsRevoked(context.Background(), &Claims{})
+	if revoked || err != nil {
+		t.Errorf("IsRevoked() on nil denylist = %v, %v", revoked, err)
+	}
+}
@@ -19,69 +19,231 @@ type TokenType string
 const (
 	AccessToken  TokenType = "access"
 	RefreshToken TokenType = "refresh"
+	// ScopedToken grants one kind of access to one resource of the user's,
+	// for sharing with someone else
+	ScopedToken TokenType = "scoped"
 )
 
 type Claims struct {
 	UserID    uuid.UUID `json:"user_id"`
 	Email     string    `json:"email"`
 	TokenType TokenType `json:"token_type"`
+	// Scope and Resource limit a scoped token, as in draft:read on a draft
+	// session ID
+	Scope    string `json:"scope,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	// SessionID is the signed-in session an access or refresh token belongs
+	// to, kept across refreshes
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// DefaultKeyID is the kid of the signing key when none is configured
+const DefaultKeyID = "primary"
+
+// JWTManager signs tokens with one key and validates them against any active
+// key, chosen by the token's kid header. Rotating keeps the old key active
+// until tokens signed with it have expired, so sessions are not all
+// invalidated at once. Keys are HMAC secrets, or RSA and Ed25519 key pairs
+// whose public halves let other services validate tokens without being able
+// to sign them.
 type JWTManager struct {
-	secret               string
+	keyID  string
+	method jwt.SigningMethod
+	// signingKey is the HMAC secret or private key; verifiers have none
+	signingKey interface{}
+	// keys are HMAC secrets ([]byte) or public keys, by kid
+	keys                 map[string]interface{}
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
+	rememberMeDuration   time.Duration
+	// leeway tolerates clock skew between the servers signing and
+	// validating tokens when checking exp, nbf and iat
+	leeway time.Duration
+	// issuer and audience are set on new tokens and, when not empty,
+	// required of the tokens validated
+	issuer   string
+	audience string
+	// cache holds recently validated tokens; nil disables it
+	cache *TokenCache
 }
 
-// NewJWTManager creates a new JWT manager
+// NewJWTManager creates a new JWT manager signing with secret under
+// DefaultKeyID
 func NewJWTManager(secret string, accessDuration, refreshDuration time.Duration) *JWTManager {
 	return &JWTManager{
-		secret:               secret,
+		keyID:                DefaultKeyID,
+		method:               jwt.SigningMethodHS256,
+		signingKey:           []byte(secret),
+		keys:                 map[string]interface{}{DefaultKeyID: []byte(secret)},
 		accessTokenDuration:  accessDuration,
 		refreshTokenDuration: refreshDuration,
 	}
 }
 
+// WithKeyID names the signing key in the kid header of new tokens
+func (j *JWTManager) WithKeyID(keyID string) *JWTManager {
+	key := j.keys[j.keyID]
+	delete(j.keys, j.keyID)
+	j.keyID = keyID
+	j.keys[keyID] = key
+	return j
+}
+
+// WithPreviousKeys keeps retired HMAC secrets, by kid, valid for tokens
+// already signed with them. New tokens are never signed with these.
+func (j *JWTManager) WithPreviousKeys(keys map[string]string) *JWTManager {
+	for kid, secret := range keys {
+		if kid != j.keyID {
+			j.keys[kid] = []byte(secret)
+		}
+	}
+	return j
+}
+
+// WithRememberMe sets the refresh token lifetime of remember-me sessions.
+// Without it they get the standard refresh lifetime.
+func (j *JWTManager) WithRememberMe(duration time.Duration) *JWTManager {
+	j.rememberMeDuration = duration
+	return j
+}
+
+// WithLeeway tolerates clock skew of up to leeway when checking a token's
+// expiry and not-before times
+func (j *JWTManager) WithLeeway(leeway time.Duration) *JWTManager {
+	j.leeway = leeway
+	return j
+}
+
+// WithIssuer sets the iss claim of new tokens and rejects tokens from any
+// other issuer. Tokens signed before it was set carry none and are rejected
+// too, so their users sign in again.
+func (j *JWTManager) WithIssuer(issuer string) *JWTManager {
+	j.issuer = issuer
+	return j
+}
+
+// WithAudience sets the aud claim of new tokens and rejects tokens not
+// meant for audience. Like WithIssuer, it rejects tokens signed before it
+// was set.
+func (j *JWTManager) WithAudience(audience string) *JWTManager {
+	j.audience = audience
+	return j
+}
+
+// WithValidationCache keeps up to size recently validated tokens, so
+// AuthMiddleware does not verify the same signature on every request. A
+// size of zero disables the cache.
+func (j *JWTManager) WithValidationCache(size int) *JWTManager {
+	j.cache = NewTokenCache(size)
+	return j
+}
+
+// RefreshDuration is how long a session's refresh tokens last
+func (j *JWTManager) RefreshDuration(rememberMe bool) time.Duration {
+	if rememberMe && j.rememberMeDuration > 0 {
+		return j.rememberMeDuration
+	}
+	return j.refreshTokenDuration
+}
+
+// KeyID returns the kid new tokens are signed under
+func (j *JWTManager) KeyID() string {
+	return j.keyID
+}
+
 // GenerateAccessToken generates a new access token
 func (j *JWTManager) GenerateAccessToken(userID uuid.UUID, email string) (string, error) {
-	return j.generateToken(userID, email, AccessToken, j.accessTokenDuration)
+	return j.generateToken(userID, email, "", AccessToken, j.accessTokenDuration)
 }
 
 // GenerateRefreshToken generates a new refresh token
 func (j *JWTManager) GenerateRefreshToken(userID uuid.UUID, email string) (string, error) {
-	return j.generateToken(userID, email, RefreshToken, j.refreshTokenDuration)
+	return j.generateToken(userID, email, "", RefreshToken, j.refreshTokenDuration)
 }
 
 // generateToken creates a JWT token with the given parameters
-func (j *JWTManager) generateToken(userID uuid.UUID, email string, tokenType TokenType, duration time.Duration) (string, error) {
+func (j *JWTManager) generateToken(userID uuid.UUID, email, sessionID string, tokenType TokenType, duration time.Duration) (string, error) {
+	claims := Claims{
+		UserID:           userID,
+		Email:            email,
+		TokenType:        tokenType,
+		SessionID:        sessionID,
+		RegisteredClaims: j.registeredClaims(time.Now(), duration),
+	}
+
+	return j.sign(claims)
+}
+
+// registeredClaims are the standard claims of a token issued at now
+func (j *JWTManager) registeredClaims(now time.Time, duration time.Duration) jwt.RegisteredClaims {
+	claims := jwt.RegisteredClaims{
+		Issuer:    j.issuer,
+		ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ID:        uuid.New().String(),
+	}
+	if j.audience != "" {
+		claims.Audience = jwt.ClaimStrings{j.audience}
+	}
+	return claims
+}
+
+// sign signs claims with the current key, naming it in the kid header
+func (j *JWTManager) sign(claims Claims) (string, error) {
+	if j.signingKey == nil {
+		return "", ErrNoSigningKey
+	}
+	token := jwt.NewWithClaims(j.method, claims)
+	token.Header["kid"] = j.keyID
+	return token.SignedString(j.signingKey)
+}
+
+// GenerateScopedToken generates a token granting scope on one resource of the
+// user's until it expires. It cannot be used as an access token.
+func (j *JWTManager) GenerateScopedToken(userID uuid.UUID, scope, resource string, duration time.Duration) (string, time.Time, error) {
+	return j.GenerateSessionScopedToken(userID, "", "", scope, resource, duration)
+}
+
+// GenerateSessionScopedToken generates a scoped token for the user's own use
+// within a signed-in session, carrying their email and the session's ID so
+// that signing the session out revokes it too
+func (j *JWTManager) GenerateSessionScopedToken(userID uuid.UUID, email, sessionID, scope, resource string, duration time.Duration) (string, time.Time, error) {
 	now := time.Now()
+	expiresAt := now.Add(duration)
 	claims := Claims{
-		UserID:    userID,
-		Email:     email,
-		TokenType: tokenType,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-			ID:        uuid.New().String(),
-		},
+		UserID:           userID,
+		Email:            email,
+		TokenType:        ScopedToken,
+		Scope:            scope,
+		Resource:         resource,
+		SessionID:        sessionID,
+		RegisteredClaims: j.registeredClaims(now, duration),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.secret))
+	signed, err := j.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
 }
 
 // ValidateToken validates and parses a JWT token
 func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(j.secret), nil
-	})
+	options := []jwt.ParserOption{jwt.WithValidMethods(validAlgorithms), jwt.WithLeeway(j.leeway)}
+	if j.issuer != "" {
+		options = append(options, jwt.WithIssuer(j.issuer))
+	}
+	if j.audience != "" {
+		options = append(options, jwt.WithAudience(j.audience))
+	}
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, j.keyFunc, options...)
 
 	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, fmt.Errorf("%w: %w", ErrExpiredToken, err)
+		}
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
@@ -94,14 +256,77 @@ func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, ErrInvalidToken
 	}
 
-	// Check if token is expired
-	if claims.ExpiresAt != nil && claims.ExpiresAt.Before(time.Now()) {
-		return nil, ErrExpiredToken
-	}
+	return claims, nil
+}
 
+// validateCached validates a token, reusing the claims of a recent
+// validation of the same token while it is unexpired
+func (j *JWTManager) validateCached(tokenString string) (*Claims, error) {
+	if claims, ok := j.cache.Get(tokenString); ok {
+		return claims, nil
+	}
+	claims, err := j.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	j.cache.Add(tokenString, claims)
 	return claims, nil
 }
 
+// ValidationFailureReason names why ValidateToken rejected a token, for
+// metrics
+func ValidationFailureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrExpiredToken), errors.Is(err, jwt.ErrTokenExpired):
+		return "expired"
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return "not_yet_valid"
+	case errors.Is(err, jwt.ErrTokenRequiredClaimMissing):
+		return "missing_claim"
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return "issuer"
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return "audience"
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return "signature"
+	case errors.Is(err, jwt.ErrTokenUnverifiable):
+		return "unknown_key"
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return "malformed"
+	default:
+		return "invalid"
+	}
+}
+
+// keyFunc picks the active key named by the token's kid. Tokens from before
+// kids were added have none and are checked against every active key. A key
+// only verifies tokens of its own kind, so a public key can never be used as
+// an HMAC secret.
+func (j *JWTManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		set := jwt.VerificationKeySet{}
+		for _, key := range j.keys {
+			if keyMatches(token.Method, key) {
+				set.Keys = append(set.Keys, key)
+			}
+		}
+		if len(set.Keys) == 0 {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return set, nil
+	}
+
+	key, ok := j.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if !keyMatches(token.Method, key) {
+		return nil, fmt.Errorf("unexpected signing method %v for key %q", token.Header["alg"], kid)
+	}
+	return key, nil
+}
+
 // GenerateTokenPair generates both access and refresh tokens
 func (j *JWTManager) GenerateTokenPair(userID uuid.UUID, email string) (accessToken, refreshToken string, err error) {
 	accessToken, err = j.GenerateAccessToken(userID, email)
@@ -115,4 +340,21 @@ func (j *JWTManager) GenerateTokenPair(userID uuid.UUID, email string) (accessTo
 	}
 
 	return accessToken, refreshToken, nil
-}
\ No newline at end of file
+}
+
+// GenerateSessionTokenPair generates access and refresh tokens carrying the
+// session they belong to. Remember-me sessions get the longer refresh
+// lifetime.
+func (j *JWTManager) GenerateSessionTokenPair(userID uuid.UUID, email string, sessionID uuid.UUID, rememberMe bool) (accessToken, refreshToken string, err error) {
+	accessToken, err = j.generateToken(userID, email, sessionID.String(), AccessToken, j.accessTokenDuration)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err = j.generateToken(userID, email, sessionID.String(), RefreshToken, j.RefreshDuration(rememberMe))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return accessToken, refreshToken, nil
+}
@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Token signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// validAlgorithms are the only algorithms tokens are accepted with
+var validAlgorithms = []string{AlgorithmHS256, AlgorithmRS256, AlgorithmEdDSA}
+
+// minRSAKeyBits is the smallest RSA key accepted for signing or validation
+const minRSAKeyBits = 2048
+
+// ErrNoSigningKey is returned when a verifier is asked to sign a token
+var ErrNoSigningKey = errors.New("no signing key configured")
+
+// NewAsymmetricJWTManager creates a JWT manager signing with a PEM-encoded
+// RSA (RS256) or Ed25519 (EdDSA) private key under DefaultKeyID. algorithm
+// must match the key.
+func NewAsymmetricJWTManager(algorithm string, privateKeyPEM []byte, accessDuration, refreshDuration time.Duration) (*JWTManager, error) {
+	signer, keyAlgorithm, err := ParsePrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if keyAlgorithm != algorithm {
+		return nil, fmt.Errorf("signing key is for %s, not %s", keyAlgorithm, algorithm)
+	}
+
+	return &JWTManager{
+		keyID:                DefaultKeyID,
+		method:               jwt.GetSigningMethod(algorithm),
+		signingKey:           signer,
+		keys:                 map[string]interface{}{DefaultKeyID: signer.Public()},
+		accessTokenDuration:  accessDuration,
+		refreshTokenDuration: refreshDuration,
+	}, nil
+}
+
+// NewJWTVerifier creates a JWT manager that validates tokens against
+// PEM-encoded public keys by kid and cannot sign any. Services that only
+// check tokens use it, so they never hold a key that could forge one.
+func NewJWTVerifier(publicKeysPEM map[string][]byte) (*JWTManager, error) {
+	if len(publicKeysPEM) == 0 {
+		return nil, fmt.Errorf("at least one public key is required")
+	}
+	keys := make(map[string]interface{}, len(publicKeysPEM))
+	for kid, pem := range publicKeysPEM {
+		key, err := ParsePublicKeyPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("public key %q: %w", kid, err)
+		}
+		keys[kid] = key
+	}
+	return &JWTManager{keys: keys}, nil
+}
+
+// WithPreviousPublicKeys keeps retired public keys, by kid, valid for tokens
+// already signed with their private keys
+func (j *JWTManager) WithPreviousPublicKeys(keys map[string]crypto.PublicKey) *JWTManager {
+	for kid, key := range keys {
+		if kid != j.keyID {
+			j.keys[kid] = key
+		}
+	}
+	return j
+}
+
+// Algorithm returns the algorithm new tokens are signed with, or "" for a
+// verifier
+func (j *JWTManager) Algorithm() string {
+	if j.method == nil {
+		return ""
+	}
+	return j.method.Alg()
+}
+
+// ParsePrivateKeyPEM reads an RSA or Ed25519 private key and returns the
+// algorithm it signs with
+func ParsePrivateKeyPEM(pem []byte) (crypto.Signer, string, error) {
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(pem); err == nil {
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, "", fmt.Errorf("RSA signing key must be at least %d bits", minRSAKeyBits)
+		}
+		return key, AlgorithmRS256, nil
+	}
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(pem); err == nil {
+		if signer, ok := key.(ed25519.PrivateKey); ok {
+			return signer, AlgorithmEdDSA, nil
+		}
+	}
+	return nil, "", fmt.Errorf("signing key is not a PEM-encoded RSA or Ed25519 private key")
+}
+
+// ParsePublicKeyPEM reads an RSA or Ed25519 public key
+func ParsePublicKeyPEM(pem []byte) (crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA public key must be at least %d bits", minRSAKeyBits)
+		}
+		return key, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(pem); err == nil {
+		if public, ok := key.(ed25519.PublicKey); ok {
+			return public, nil
+		}
+	}
+	return nil, fmt.Errorf("not a PEM-encoded RSA or Ed25519 public key")
+}
+
+// keyMatches reports whether key verifies tokens signed with method
+func keyMatches(method jwt.SigningMethod, key interface{}) bool {
+	switch key.(type) {
+	case []byte:
+		return method.Alg() == AlgorithmHS256
+	case *rsa.PublicKey:
+		return method.Alg() == AlgorithmRS256
+	case ed25519.PublicKey:
+		return method.Alg() == AlgorithmEdDSA
+	}
+	return false
+}
+
+// JWK is one public key in a JSON Web Key Set
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// N and E are an RSA key's modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Curve and X are an Ed25519 key
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the active public keys, by kid, for services validating
+// tokens. HMAC secrets are never included.
+func (j *JWTManager) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for kid, key := range j.keys {
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				KeyType:   "RSA",
+				KeyID:     kid,
+				Use:       "sig",
+				Algorithm: AlgorithmRS256,
+				N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				KeyType:   "OKP",
+				KeyID:     kid,
+				Use:       "sig",
+				Algorithm: AlgorithmEdDSA,
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(key),
+			})
+		}
+	}
+	sort.Slice(set.Keys, func(a, b int) bool { return set.Keys[a].KeyID < set.Keys[b].KeyID })
+	return set
+}
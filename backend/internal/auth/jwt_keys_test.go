@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKeyPair returns a PEM-encoded private key and its public key
+func testKeyPair(t *testing.T, algorithm string) (privatePEM, publicPEM []byte) {
+	t.Helper()
+
+	var private, public interface{}
+	switch algorithm {
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		private, public = key, &key.PublicKey
+	case AlgorithmEdDSA:
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		private, public = key, pub
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+}
+
+func TestAsymmetricJWTManager_VerifierValidates(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			privatePEM, publicPEM := testKeyPair(t, algorithm)
+
+			signer, err := NewAsymmetricJWTManager(algorithm, privatePEM, 15*time.Minute, time.Hour)
+			require.NoError(t, err)
+			signer.WithKeyID("2025-01")
+			assert.Equal(t, algorithm, signer.Algorithm())
+
+			userID := uuid.New()
+			token, err := signer.GenerateAccessToken(userID, "fan@example.com")
+			require.NoError(t, err)
+
+			// Another service holding only the public key
+			verifier, err := NewJWTVerifier(map[string][]byte{"2025-01": publicPEM})
+			require.NoError(t, err)
+			claims, err := verifier.ValidateToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, userID, claims.UserID)
+
+			_, err = verifier.GenerateAccessToken(userID, "fan@example.com")
+			assert.ErrorIs(t, err, ErrNoSigningKey)
+
+			jwks := signer.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, "2025-01", jwks.Keys[0].KeyID)
+			assert.Equal(t, algorithm, jwks.Keys[0].Algorithm)
+		})
+	}
+}
+
+func TestAsymmetricJWTManager_AlgorithmMustMatchKey(t *testing.T) {
+	privatePEM, _ := testKeyPair(t, AlgorithmEdDSA)
+	_, err := NewAsymmetricJWTManager(AlgorithmRS256, privatePEM, time.Minute, time.Hour)
+	assert.Error(t, err)
+
+	_, err = NewAsymmetricJWTManager(AlgorithmEdDSA, []byte("not a key"), time.Minute, time.Hour)
+	assert.Error(t, err)
+}
+
+// A token signed with HS256 using the public key as the secret must not pass
+// as signed by the private key
+func TestAsymmetricJWTManager_RejectsAlgorithmConfusion(t *testing.T) {
+	privatePEM, publicPEM := testKeyPair(t, AlgorithmRS256)
+	signer, err := NewAsymmetricJWTManager(AlgorithmRS256, privatePEM, time.Minute, time.Hour)
+	require.NoError(t, err)
+
+	claims := Claims{
+		UserID:    uuid.New(),
+		TokenType: AccessToken,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	for _, kid := range []string{DefaultKeyID, ""} {
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		if kid != "" {
+			forged.Header["kid"] = kid
+		}
+		token, err := forged.SignedString(publicPEM)
+		require.NoError(t, err)
+
+		_, err = signer.ValidateToken(token)
+		assert.Error(t, err, "kid %q", kid)
+	}
+}
+
+func TestJWTManager_MigrateFromHMAC(t *testing.T) {
+	// Tokens signed with the old shared secret stay valid after switching
+	// to a key pair, until they expire
+	old := NewJWTManager("old_secret", 15*time.Minute, time.Hour).WithKeyID("2024-06")
+	oldToken, err := old.GenerateAccessToken(uuid.New(), "fan@example.com")
+	require.NoError(t, err)
+
+	privatePEM, _ := testKeyPair(t, AlgorithmEdDSA)
+	current, err := NewAsymmetricJWTManager(AlgorithmEdDSA, privatePEM, 15*time.Minute, time.Hour)
+	require.NoError(t, err)
+	current.WithKeyID("2025-01").WithPreviousKeys(map[string]string{"2024-06": "old_secret"})
+
+	_, err = current.ValidateToken(oldToken)
+	assert.NoError(t, err)
+
+	// The shared secret is never published
+	jwks := current.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "2025-01", jwks.Keys[0].KeyID)
+}
@@ -1,9 +1,11 @@
 package auth
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
@@ -163,6 +165,37 @@ func TestJWTManager_GenerateTokenPair(t *testing.T) {
 	}
 }
 
+func TestJWTManager_RememberMe(t *testing.T) {
+	jwtManager := NewJWTManager("test_secret_key", 15*time.Minute, 7*24*time.Hour).
+		WithRememberMe(30 * 24 * time.Hour)
+	userID := uuid.New()
+
+	lifetime := func(rememberMe bool) time.Duration {
+		_, refreshToken, err := jwtManager.GenerateSessionTokenPair(userID, "test@example.com", uuid.New(), rememberMe)
+		if err != nil {
+			t.Fatalf("GenerateSessionTokenPair() error = %v", err)
+		}
+		claims, err := jwtManager.ValidateToken(refreshToken)
+		if err != nil {
+			t.Fatalf("Failed to validate refresh token: %v", err)
+		}
+		return claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+	}
+
+	if got := lifetime(false); got != 7*24*time.Hour {
+		t.Errorf("standard refresh token lasts %s, want 7 days", got)
+	}
+	if got := lifetime(true); got != 30*24*time.Hour {
+		t.Errorf("remember-me refresh token lasts %s, want 30 days", got)
+	}
+
+	// Without a remember-me lifetime every session gets the standard one
+	plain := NewJWTManager("test_secret_key", 15*time.Minute, 7*24*time.Hour)
+	if got := plain.RefreshDuration(true); got != 7*24*time.Hour {
+		t.Errorf("RefreshDuration(true) = %s without remember me, want 7 days", got)
+	}
+}
+
 func TestJWTManager_DifferentSecrets(t *testing.T) {
 	jwtManager1 := NewJWTManager("secret1", 15*time.Minute, 7*24*time.Hour)
 	jwtManager2 := NewJWTManager("secret2", 15*time.Minute, 7*24*time.Hour)
@@ -181,4 +214,136 @@ func TestJWTManager_DifferentSecrets(t *testing.T) {
 	if err == nil {
 		t.Error("ValidateToken() should fail with different secret")
 	}
-}
\ No newline at end of file
+}
+func TestJWTManager_KeyRotation(t *testing.T) {
+	userID := uuid.New()
+	old := NewJWTManager("old_secret", 15*time.Minute, time.Hour).WithKeyID("2024-06")
+	oldToken, err := old.GenerateAccessToken(userID, "test@example.com")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+	// Signed before tokens carried a kid
+	legacy, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{UserID: userID, TokenType: AccessToken}).SignedString([]byte("old_secret"))
+
+	rotated := NewJWTManager("new_secret", 15*time.Minute, time.Hour).
+		WithKeyID("2024-09").
+		WithPreviousKeys(map[string]string{"2024-06": "old_secret"})
+
+	newToken, err := rotated.GenerateAccessToken(userID, "test@example.com")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+	parsed, _, _ := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	if parsed.Header["kid"] != "2024-09" {
+		t.Errorf("kid = %v, want 2024-09", parsed.Header["kid"])
+	}
+
+	for name, token := range map[string]string{"current key": newToken, "previous key": oldToken, "no kid": legacy} {
+		if claims, err := rotated.ValidateToken(token); err != nil || claims.UserID != userID {
+			t.Errorf("ValidateToken(%s) = %v, %v", name, claims, err)
+		}
+	}
+
+	// Once the old key is dropped its tokens stop working
+	dropped := NewJWTManager("new_secret", 15*time.Minute, time.Hour).WithKeyID("2024-09")
+	if _, err := dropped.ValidateToken(oldToken); err == nil {
+		t.Error("ValidateToken accepted a token signed with a dropped key")
+	}
+	if _, err := dropped.ValidateToken(legacy); err == nil {
+		t.Error("ValidateToken accepted a kid-less token signed with a dropped key")
+	}
+
+	// A kid naming the wrong key fails even if the secret is active
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{UserID: userID, TokenType: AccessToken})
+	forged.Header["kid"] = "2024-09"
+	forgedToken, _ := forged.SignedString([]byte("old_secret"))
+	if _, err := rotated.ValidateToken(forgedToken); err == nil {
+		t.Error("ValidateToken accepted a token whose kid names a different key")
+	}
+}
+
+func TestJWTManager_Leeway(t *testing.T) {
+	userID := uuid.New()
+	// Signed by a server whose clock runs a little fast or slow
+	skewed := func(issuedAt, expiresAt time.Time) string {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+			UserID:    userID,
+			TokenType: AccessToken,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+				IssuedAt:  jwt.NewNumericDate(issuedAt),
+				NotBefore: jwt.NewNumericDate(issuedAt),
+			},
+		}).SignedString([]byte("test_secret_key"))
+		return token
+	}
+	now := time.Now()
+	fromTheFuture := skewed(now.Add(10*time.Second), now.Add(15*time.Minute))
+	justExpired := skewed(now.Add(-15*time.Minute), now.Add(-10*time.Second))
+	longExpired := skewed(now.Add(-15*time.Minute), now.Add(-time.Minute))
+
+	strict := NewJWTManager("test_secret_key", 15*time.Minute, time.Hour)
+	lenient := NewJWTManager("test_secret_key", 15*time.Minute, time.Hour).WithLeeway(30 * time.Second)
+
+	if _, err := strict.ValidateToken(fromTheFuture); ValidationFailureReason(err) != "not_yet_valid" {
+		t.Errorf("strict ValidateToken(future) error = %v, want not yet valid", err)
+	}
+	if _, err := strict.ValidateToken(justExpired); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("strict ValidateToken(just expired) error = %v, want ErrExpiredToken", err)
+	}
+	for name, token := range map[string]string{"future": fromTheFuture, "just expired": justExpired} {
+		if _, err := lenient.ValidateToken(token); err != nil {
+			t.Errorf("lenient ValidateToken(%s) error = %v", name, err)
+		}
+	}
+	if _, err := lenient.ValidateToken(longExpired); ValidationFailureReason(err) != "expired" {
+		t.Errorf("lenient ValidateToken(long expired) error = %v, want expired", err)
+	}
+}
+
+func TestJWTManager_IssuerAndAudience(t *testing.T) {
+	userID := uuid.New()
+	jwtManager := NewJWTManager("test_secret_key", 15*time.Minute, time.Hour).
+		WithIssuer("https://api.example.com").
+		WithAudience("nfl-analytics")
+
+	token, err := jwtManager.GenerateAccessToken(userID, "test@example.com")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+	claims, err := jwtManager.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.Issuer != "https://api.example.com" || len(claims.Audience) != 1 || claims.Audience[0] != "nfl-analytics" {
+		t.Errorf("claims iss = %q aud = %v", claims.Issuer, claims.Audience)
+	}
+
+	unset, _ := NewJWTManager("test_secret_key", 15*time.Minute, time.Hour).GenerateAccessToken(userID, "test@example.com")
+	otherIssuer, _ := NewJWTManager("test_secret_key", 15*time.Minute, time.Hour).
+		WithIssuer("https://staging.example.com").
+		WithAudience("nfl-analytics").
+		GenerateAccessToken(userID, "test@example.com")
+	otherAudience, _ := NewJWTManager("test_secret_key", 15*time.Minute, time.Hour).
+		WithIssuer("https://api.example.com").
+		WithAudience("admin-console").
+		GenerateAccessToken(userID, "test@example.com")
+
+	tests := []struct {
+		name   string
+		token  string
+		reason string
+	}{
+		{"no issuer or audience", unset, "missing_claim"},
+		{"other issuer", otherIssuer, "issuer"},
+		{"other audience", otherAudience, "audience"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwtManager.ValidateToken(tt.token)
+			if got := ValidationFailureReason(err); err == nil || got != tt.reason {
+				t.Errorf("ValidateToken() error = %v (reason %q), want reason %q", err, got, tt.reason)
+			}
+		})
+	}
+}
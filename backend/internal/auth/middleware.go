@@ -1,10 +1,15 @@
 package auth
 
 import (
+	"crypto/subtle"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
@@ -13,13 +18,47 @@ const (
 	BearerPrefix        = "Bearer "
 	UserIDKey           = "user_id"
 	UserEmailKey        = "user_email"
+	AdminKeyHeader      = "X-Admin-Key"
+	ShareTokenHeader    = "X-Share-Token"
+	ShareTokenQuery     = "share_token"
+	ResumeTokenHeader   = "X-Resume-Token"
+	ResumeTokenQuery    = "resume_token"
+	TokenScopeKey       = "token_scope"
+	SessionIDKey        = "session_id"
+	TokenIDKey          = "token_id"
+	TokenExpiresAtKey   = "token_expires_at"
+	OperatorKey         = "operator"
 )
 
-// AuthMiddleware creates a JWT authentication middleware
-func AuthMiddleware(jwtManager *JWTManager) gin.HandlerFunc {
+// Token scopes
+const (
+	// ScopeDraftRead is read-only access to a draft session's board and
+	// recommendations
+	ScopeDraftRead = "draft:read"
+	// ScopeTwoFactor is a login that has passed its password and still
+	// needs a second factor; the resource is the user's ID
+	ScopeTwoFactor = "login:second_factor"
+	// ScopeTwoFactorRememberMe is ScopeTwoFactor for a login that asked to
+	// be remembered
+	ScopeTwoFactorRememberMe = "login:second_factor:remember"
+	// ScopeLoginVerification is a login from a new device waiting on a code
+	// emailed to the user; the resource is the verification's ID
+	ScopeLoginVerification = "login:new_device"
+	// ScopeDraftResume lets a user back into one draft session while their
+	// login is renewed; the resource is the draft session's ID
+	ScopeDraftResume = "draft:resume"
+	// ScopeVoiceDraft lets a linked voice assistant run the user's draft
+	// commands; the resource is the device's ID
+	ScopeVoiceDraft = "voice:draft"
+)
+
+// AuthMiddleware creates a JWT authentication middleware. Tokens on denylist
+// are rejected; denylist may be nil. Validations are cached when jwtManager
+// has a validation cache, and failures are logged as metrics by reason.
+func AuthMiddleware(jwtManager *JWTManager, denylist *Denylist) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get authorization header
-		authHeader := c.GetHeader(AuthorizationHeader)
+		authHeader := requestAuthorization(c)
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "authorization header is required",
@@ -47,10 +86,11 @@ func AuthMiddleware(jwtManager *JWTManager) gin.HandlerFunc {
 			return
 		}
 
-		// Validate token
-		claims, err := jwtManager.ValidateToken(token)
+		// Validate token, reusing recent validations of it
+		claims, err := jwtManager.validateCached(token)
 		if err != nil {
-			if err == ErrExpiredToken {
+			log.Printf("metrics jwt_validation_failure reason=%s", ValidationFailureReason(err))
+			if errors.Is(err, ErrExpiredToken) {
 				c.JSON(http.StatusUnauthorized, gin.H{
 					"error": "token has expired",
 				})
@@ -65,6 +105,7 @@ func AuthMiddleware(jwtManager *JWTManager) gin.HandlerFunc {
 
 		// Check if it's an access token
 		if claims.TokenType != AccessToken {
+			log.Printf("metrics jwt_validation_failure reason=token_type")
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "invalid token type",
 			})
@@ -72,14 +113,66 @@ func AuthMiddleware(jwtManager *JWTManager) gin.HandlerFunc {
 			return
 		}
 
+		// Check the token has not been revoked by a logout. The denylist
+		// fails open so a Redis outage does not sign everyone out.
+		revoked, err := denylist.IsRevoked(c.Request.Context(), claims)
+		if err != nil {
+			log.Printf("Skipping token denylist: %v", err)
+		}
+		if revoked {
+			log.Printf("metrics jwt_validation_failure reason=revoked")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "token has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
 		// Set user information in context
 		c.Set(UserIDKey, claims.UserID)
 		c.Set(UserEmailKey, claims.Email)
+		if sessionID, err := uuid.Parse(claims.SessionID); err == nil {
+			c.Set(SessionIDKey, sessionID)
+		}
+		c.Set(TokenIDKey, claims.ID)
+		if claims.ExpiresAt != nil {
+			c.Set(TokenExpiresAtKey, claims.ExpiresAt.Time)
+		}
 
 		c.Next()
 	}
 }
 
+// requestAuthorization returns the Authorization header or, for browsers in
+// cookie mode, the access token cookie as a bearer token
+func requestAuthorization(c *gin.Context) string {
+	if header := c.GetHeader(AuthorizationHeader); header != "" {
+		return header
+	}
+	if token, err := c.Cookie(AccessTokenCookie); err == nil && token != "" {
+		return BearerPrefix + token
+	}
+	return ""
+}
+
+// GetSessionID extracts the session the access token belongs to. Tokens
+// issued before sessions were tracked have none.
+func GetSessionID(c *gin.Context) (uuid.UUID, bool) {
+	sessionID, exists := c.Get(SessionIDKey)
+	if !exists {
+		return uuid.UUID{}, false
+	}
+	id, ok := sessionID.(uuid.UUID)
+	return id, ok
+}
+
+// GetTokenID extracts the JWT ID of the access token and when it expires
+func GetTokenID(c *gin.Context) (string, time.Time, bool) {
+	id := c.GetString(TokenIDKey)
+	expiresAt := c.GetTime(TokenExpiresAtKey)
+	return id, expiresAt, id != ""
+}
+
 // GetUserID extracts the user ID from the context
 func GetUserID(c *gin.Context) (uuid.UUID, bool) {
 	userID, exists := c.Get(UserIDKey)
@@ -107,7 +200,7 @@ func GetUserEmail(c *gin.Context) (string, bool) {
 func OptionalAuthMiddleware(jwtManager *JWTManager) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get authorization header
-		authHeader := c.GetHeader(AuthorizationHeader)
+		authHeader := requestAuthorization(c)
 		if authHeader == "" {
 			c.Next()
 			return
@@ -145,4 +238,179 @@ func OptionalAuthMiddleware(jwtManager *JWTManager) gin.HandlerFunc {
 
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// ScopedTokenMiddleware authorizes routes with a scoped token instead of a
+// login. The token must carry scope and name the resource in the route's
+// resourceParam. It is read from the X-Share-Token header or, for links and
+// event streams, the share_token query parameter. Handlers see the user who
+// issued the token, so they must only read.
+func ScopedTokenMiddleware(jwtManager *JWTManager, scope, resourceParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(ShareTokenHeader)
+		if token == "" {
+			token = c.Query(ShareTokenQuery)
+		}
+		if token == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "share token is required",
+			})
+			c.Abort()
+			return
+		}
+
+		claims, err := jwtManager.ValidateToken(token)
+		if err != nil {
+			if errors.Is(err, ErrExpiredToken) || errors.Is(err, jwt.ErrTokenExpired) {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "share token has expired",
+				})
+			} else {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "invalid share token",
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		if claims.TokenType != ScopedToken || claims.Scope != scope || claims.Resource != c.Param(resourceParam) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "share token does not grant access to this resource",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set(UserIDKey, claims.UserID)
+		c.Set(TokenScopeKey, claims.Scope)
+
+		c.Next()
+	}
+}
+
+// ResumableAuthMiddleware authenticates like AuthMiddleware but, when the
+// request's access token is missing, expired or invalid, accepts a token
+// with scope on the resource in the route's resourceParam instead, so a
+// user whose refresh failed keeps working while they sign back in. The
+// token is read from the X-Resume-Token header or, for event streams, the
+// resume_token query parameter on GET requests. It must belong to a session
+// that has not been signed out.
+func ResumableAuthMiddleware(jwtManager *JWTManager, denylist *Denylist, scope, resourceParam string) gin.HandlerFunc {
+	access := AuthMiddleware(jwtManager, denylist)
+	return func(c *gin.Context) {
+		token := c.GetHeader(ResumeTokenHeader)
+		if token == "" && c.Request.Method == http.MethodGet {
+			token = c.Query(ResumeTokenQuery)
+		}
+		if token == "" || hasValidAccessToken(c, jwtManager) {
+			access(c)
+			return
+		}
+
+		claims, err := jwtManager.ValidateToken(token)
+		if err != nil {
+			log.Printf("metrics jwt_validation_failure reason=%s", ValidationFailureReason(err))
+			if errors.Is(err, ErrExpiredToken) {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "resume token has expired",
+				})
+			} else {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "invalid resume token",
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		if claims.TokenType != ScopedToken || claims.Scope != scope || claims.Resource != c.Param(resourceParam) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "resume token does not grant access to this resource",
+			})
+			c.Abort()
+			return
+		}
+
+		revoked, err := denylist.IsRevoked(c.Request.Context(), claims)
+		if err != nil {
+			log.Printf("Skipping token denylist: %v", err)
+		}
+		if revoked {
+			log.Printf("metrics jwt_validation_failure reason=revoked")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "resume token has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
+		log.Printf("metrics resume_token_used scope=%s", claims.Scope)
+		c.Set(UserIDKey, claims.UserID)
+		c.Set(UserEmailKey, claims.Email)
+		if sessionID, err := uuid.Parse(claims.SessionID); err == nil {
+			c.Set(SessionIDKey, sessionID)
+		}
+		c.Set(TokenScopeKey, claims.Scope)
+
+		c.Next()
+	}
+}
+
+// hasValidAccessToken reports whether the request carries an unexpired
+// access token. Revocation is left to AuthMiddleware.
+func hasValidAccessToken(c *gin.Context, jwtManager *JWTManager) bool {
+	authHeader := requestAuthorization(c)
+	if !strings.HasPrefix(authHeader, BearerPrefix) {
+		return false
+	}
+	claims, err := jwtManager.validateCached(strings.TrimPrefix(authHeader, BearerPrefix))
+	return err == nil && claims.TokenType == AccessToken
+}
+
+// AdminKeyMiddleware authorizes operator routes with a shared API key sent in
+// the X-Admin-Key header. The routes are hidden when no key is configured.
+func AdminKeyMiddleware(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiKey == "" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "not found",
+			})
+			c.Abort()
+			return
+		}
+
+		key := c.GetHeader(AdminKeyHeader)
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "invalid admin key",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set(OperatorKey, true)
+		c.Next()
+	}
+}
+
+// IsOperator reports whether the request was made with the admin key
+func IsOperator(c *gin.Context) bool {
+	return c.GetBool(OperatorKey)
+}
+
+// OperatorMiddleware authenticates operator routes with the admin key, as
+// AdminKeyMiddleware does, or, for requests without one, with a user's
+// access token, as AuthMiddleware does. Which users may use the routes is
+// left to the authorization policy that must follow it.
+func OperatorMiddleware(apiKey string, jwtManager *JWTManager, denylist *Denylist) gin.HandlerFunc {
+	keyAuth := AdminKeyMiddleware(apiKey)
+	userAuth := AuthMiddleware(jwtManager, denylist)
+	return func(c *gin.Context) {
+		if c.GetHeader(AdminKeyHeader) == "" && requestAuthorization(c) != "" {
+			userAuth(c)
+			return
+		}
+		keyAuth(c)
+	}
+}
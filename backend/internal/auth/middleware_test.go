@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestScopedTokenMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtManager := NewJWTManager("test_secret_key", 15*time.Minute, 7*24*time.Hour)
+	owner := uuid.New()
+
+	r := gin.New()
+	r.GET("/shared/:id", ScopedTokenMiddleware(jwtManager, ScopeDraftRead, "id"), func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		c.String(http.StatusOK, userID.String())
+	})
+
+	shareToken, expiresAt, err := jwtManager.GenerateScopedToken(owner, ScopeDraftRead, "session-1", 2*time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken() error = %v", err)
+	}
+	if d := time.Until(expiresAt); d < 119*time.Minute || d > 2*time.Hour {
+		t.Errorf("GenerateScopedToken() expires in %v, want 2h", d)
+	}
+	otherScope, _, _ := jwtManager.GenerateScopedToken(owner, "league:read", "session-1", time.Hour)
+	expired, _, _ := jwtManager.GenerateScopedToken(owner, ScopeDraftRead, "session-1", -time.Minute)
+	accessToken, _ := jwtManager.GenerateAccessToken(owner, "owner@example.com")
+
+	tests := []struct {
+		name       string
+		path       string
+		header     string
+		wantStatus int
+	}{
+		{"header token", "/shared/session-1", shareToken, http.StatusOK},
+		{"query token", "/shared/session-1?share_token=" + shareToken, "", http.StatusOK},
+		{"missing token", "/shared/session-1", "", http.StatusUnauthorized},
+		{"other session", "/shared/session-2", shareToken, http.StatusForbidden},
+		{"other scope", "/shared/session-1", otherScope, http.StatusForbidden},
+		{"access token", "/shared/session-1", accessToken, http.StatusForbidden},
+		{"expired token", "/shared/session-1", expired, http.StatusUnauthorized},
+		{"garbage", "/shared/session-1", "not-a-token", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(ShareTokenHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != owner.String() {
+				t.Errorf("handler saw user %s, want owner %s", w.Body.String(), owner)
+			}
+		})
+	}
+}
+
+func TestResumableAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtManager := NewJWTManager("test_secret_key", 15*time.Minute, 7*24*time.Hour)
+	owner, loginSession := uuid.New(), uuid.New()
+
+	r := gin.New()
+	room := r.Group("/draft/:id", ResumableAuthMiddleware(jwtManager, nil, ScopeDraftResume, "id"))
+	handler := func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		sessionID, _ := GetSessionID(c)
+		c.String(http.StatusOK, userID.String()+" "+sessionID.String())
+	}
+	room.GET("", handler)
+	room.POST("/pick", handler)
+
+	resumeToken, _, err := jwtManager.GenerateSessionScopedToken(owner, "owner@example.com", loginSession.String(), ScopeDraftResume, "session-1", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateSessionScopedToken() error = %v", err)
+	}
+	shareToken, _, _ := jwtManager.GenerateScopedToken(owner, ScopeDraftRead, "session-1", time.Hour)
+	expiredResume, _, _ := jwtManager.GenerateSessionScopedToken(owner, "owner@example.com", loginSession.String(), ScopeDraftResume, "session-1", -time.Minute)
+	accessToken, _, _ := jwtManager.GenerateSessionTokenPair(owner, "owner@example.com", loginSession, false)
+	expiredAccess, _ := NewJWTManager("test_secret_key", -time.Minute, time.Hour).GenerateAccessToken(owner, "owner@example.com")
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		access     string
+		resume     string
+		wantStatus int
+	}{
+		{"access token", http.MethodPost, "/draft/session-1/pick", accessToken, "", http.StatusOK},
+		{"resume token", http.MethodPost, "/draft/session-1/pick", "", resumeToken, http.StatusOK},
+		{"resume token after access expired", http.MethodPost, "/draft/session-1/pick", expiredAccess, resumeToken, http.StatusOK},
+		{"resume token in query for a stream", http.MethodGet, "/draft/session-1?resume_token=" + resumeToken, "", "", http.StatusOK},
+		{"resume token in query for a write", http.MethodPost, "/draft/session-1/pick?resume_token=" + resumeToken, "", "", http.StatusUnauthorized},
+		{"expired access token alone", http.MethodPost, "/draft/session-1/pick", expiredAccess, "", http.StatusUnauthorized},
+		{"other draft", http.MethodPost, "/draft/session-2/pick", "", resumeToken, http.StatusForbidden},
+		{"share token", http.MethodGet, "/draft/session-1", "", shareToken, http.StatusForbidden},
+		{"access token as resume token", http.MethodGet, "/draft/session-1", "", accessToken, http.StatusForbidden},
+		{"expired resume token", http.MethodPost, "/draft/session-1/pick", expiredAccess, expiredResume, http.StatusUnauthorized},
+		{"no tokens", http.MethodGet, "/draft/session-1", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.access != "" {
+				req.Header.Set(AuthorizationHeader, BearerPrefix+tt.access)
+			}
+			if tt.resume != "" {
+				req.Header.Set(ResumeTokenHeader, tt.resume)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if want := owner.String() + " " + loginSession.String(); tt.wantStatus == http.StatusOK && w.Body.String() != want {
+				t.Errorf("handler saw %q, want %q", w.Body.String(), want)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RejectsScopedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtManager := NewJWTManager("test_secret_key", 15*time.Minute, 7*24*time.Hour)
+
+	r := gin.New()
+	r.GET("/me", AuthMiddleware(jwtManager, nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	token, _, _ := jwtManager.GenerateScopedToken(uuid.New(), ScopeDraftRead, "session-1", time.Hour)
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set(AuthorizationHeader, BearerPrefix+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthMiddleware_SetsSessionID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtManager := NewJWTManager("test_secret_key", 15*time.Minute, 7*24*time.Hour)
+	userID, sessionID := uuid.New(), uuid.New()
+
+	r := gin.New()
+	r.GET("/me", AuthMiddleware(jwtManager, nil), func(c *gin.Context) {
+		id, ok := GetSessionID(c)
+		if !ok {
+			c.String(http.StatusOK, "none")
+			return
+		}
+		c.String(http.StatusOK, id.String())
+	})
+
+	sessionToken, refreshToken, err := jwtManager.GenerateSessionTokenPair(userID, "fan@example.com", sessionID, false)
+	if err != nil {
+		t.Fatalf("GenerateSessionTokenPair() error = %v", err)
+	}
+	if claims, err := jwtManager.ValidateToken(refreshToken); err != nil || claims.SessionID != sessionID.String() {
+		t.Errorf("refresh token session = %v, %v; want %s", claims, err, sessionID)
+	}
+	plainToken, _ := jwtManager.GenerateAccessToken(userID, "fan@example.com")
+
+	for token, want := range map[string]string{sessionToken: sessionID.String(), plainToken: "none"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set(AuthorizationHeader, BearerPrefix+token)
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || w.Body.String() != want {
+			t.Errorf("GET /me = %d %q, want %q", w.Code, w.Body.String(), want)
+		}
+	}
+}
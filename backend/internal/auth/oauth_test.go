@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newTestGoogleProvider(t *testing.T, tokenStatus int) *googleProvider {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if err := r.ParseForm(); err != nil || r.Form.Get("code") != "good-code" || r.Form.Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(tokenStatus)
+			w.Write([]byte(`{"access_token":"at-123","token_type":"Bearer"}`))
+		case "/userinfo":
+			if r.Header.Get("Authorization") != "Bearer at-123" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"sub":"1089","email":"Fan@Example.com","email_verified":true,"given_name":"Pat","family_name":"Fan"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	g := NewGoogleProvider("client", "secret", "http://localhost/cb").(*googleProvider)
+	g.tokenURL = srv.URL + "/token"
+	g.userInfoURL = srv.URL + "/userinfo"
+	return g
+}
+
+func TestGoogleProvider_Exchange(t *testing.T) {
+	g := newTestGoogleProvider(t, http.StatusOK)
+
+	identity, err := g.Exchange(context.Background(), "good-code")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	want := OAuthIdentity{Provider: "google", Subject: "1089", Email: "fan@example.com", EmailVerified: true, FirstName: "Pat", LastName: "Fan"}
+	if *identity != want {
+		t.Errorf("Exchange() = %+v, want %+v", *identity, want)
+	}
+
+	if _, err := g.Exchange(context.Background(), "bad-code"); !errors.Is(err, ErrOAuthExchange) {
+		t.Errorf("Exchange(bad code) error = %v, want ErrOAuthExchange", err)
+	}
+	if _, err := g.Exchange(context.Background(), ""); !errors.Is(err, ErrOAuthExchange) {
+		t.Errorf("Exchange(empty code) error = %v, want ErrOAuthExchange", err)
+	}
+}
+
+func TestGoogleProvider_ExchangeOutage(t *testing.T) {
+	g := newTestGoogleProvider(t, http.StatusBadGateway)
+
+	if _, err := g.Exchange(context.Background(), "good-code"); !errors.Is(err, ErrOAuthUnavailable) {
+		t.Errorf("Exchange() error = %v, want ErrOAuthUnavailable", err)
+	}
+}
+
+func TestGoogleProvider_AuthCodeURL(t *testing.T) {
+	g := NewGoogleProvider("client", "secret", "http://localhost/cb")
+
+	u, err := url.Parse(g.AuthCodeURL("xyz"))
+	if err != nil {
+		t.Fatalf("AuthCodeURL() is not a URL: %v", err)
+	}
+	q := u.Query()
+	if q.Get("state") != "xyz" || q.Get("client_id") != "client" || q.Get("redirect_uri") != "http://localhost/cb" {
+		t.Errorf("AuthCodeURL() query = %v", q)
+	}
+	if q.Get("client_secret") != "" {
+		t.Error("AuthCodeURL() leaks the client secret")
+	}
+}
@@ -1,15 +1,61 @@
 package auth
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// PasswordManager handles password hashing and verification
+// Password hashing algorithms
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// argon2idPrefix starts hashes in the PHC string format,
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+const argon2idPrefix = "$argon2id$"
+
+// errInvalidHash is returned for stored hashes that cannot be parsed
+var errInvalidHash = errors.New("invalid password hash")
+
+// Argon2Params are the Argon2id cost parameters
+type Argon2Params struct {
+	// Memory is in KiB
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params are OWASP's recommended minimum: 19 MiB, two passes
+// and one lane
+var DefaultArgon2Params = Argon2Params{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// PasswordManager handles password hashing and verification. It hashes with
+// bcrypt unless Argon2id is turned on, and verifies hashes of either kind so
+// existing users can still sign in; NeedsRehash tells when a stored hash
+// should be replaced.
 type PasswordManager struct {
-	cost int
+	cost   int
+	argon2 *Argon2Params
+	// breaches, when set, rejects passwords known from data breaches
+	breaches BreachChecker
 }
 
 // NewPasswordManager creates a new password manager
@@ -22,11 +68,43 @@ func NewPasswordManager(cost int) *PasswordManager {
 	}
 }
 
+// WithArgon2id hashes new passwords with Argon2id using params. Unlike
+// bcrypt it reads the whole password rather than its first 72 bytes.
+func (pm *PasswordManager) WithArgon2id(params Argon2Params) *PasswordManager {
+	if params.SaltLength == 0 {
+		params.SaltLength = DefaultArgon2Params.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = DefaultArgon2Params.KeyLength
+	}
+	pm.argon2 = &params
+	return pm
+}
+
+// WithBreachCheck rejects new passwords that checker finds in data breaches.
+// A check that fails, as when the API cannot be reached, lets the password
+// through.
+func (pm *PasswordManager) WithBreachCheck(checker BreachChecker) *PasswordManager {
+	pm.breaches = checker
+	return pm
+}
+
+// Algorithm is the algorithm new passwords are hashed with
+func (pm *PasswordManager) Algorithm() string {
+	if pm.argon2 != nil {
+		return AlgorithmArgon2id
+	}
+	return AlgorithmBcrypt
+}
+
 // HashPassword hashes a plain text password
 func (pm *PasswordManager) HashPassword(password string) (string, error) {
 	if len(password) < 8 {
 		return "", fmt.Errorf("password must be at least 8 characters long")
 	}
+	if pm.argon2 != nil {
+		return hashArgon2id(password, *pm.argon2)
+	}
 	
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), pm.cost)
 	if err != nil {
@@ -38,6 +116,10 @@ func (pm *PasswordManager) HashPassword(password string) (string, error) {
 
 // VerifyPassword compares a plain text password with a hashed password
 func (pm *PasswordManager) VerifyPassword(hashedPassword, password string) error {
+	if strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		return verifyArgon2id(hashedPassword, password)
+	}
+
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	if err != nil {
 		if err == bcrypt.ErrMismatchedHashAndPassword {
@@ -48,6 +130,87 @@ func (pm *PasswordManager) VerifyPassword(hashedPassword, password string) error
 	return nil
 }
 
+// NeedsRehash reports whether a hash that just verified was made with
+// another algorithm or other parameters than new hashes are, so it should
+// be replaced with a hash of the password the user signed in with
+func (pm *PasswordManager) NeedsRehash(hashedPassword string) bool {
+	if pm.argon2 == nil {
+		cost, err := bcrypt.Cost([]byte(hashedPassword))
+		return err != nil || cost != pm.cost
+	}
+
+	params, _, _, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return true
+	}
+	return params.Memory != pm.argon2.Memory ||
+		params.Iterations != pm.argon2.Iterations ||
+		params.Parallelism != pm.argon2.Parallelism ||
+		params.KeyLength != pm.argon2.KeyLength
+}
+
+// hashArgon2id hashes password with a random salt, encoded with its
+// parameters
+func hashArgon2id(password string, params Argon2Params) (string, error) {
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyArgon2id checks password against an Argon2id hash with the
+// parameters stored in it
+func verifyArgon2id(hashedPassword, password string) error {
+	params, salt, key, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return fmt.Errorf("failed to verify password: %w", err)
+	}
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, candidate) != 1 {
+		return fmt.Errorf("invalid password")
+	}
+	return nil
+}
+
+// decodeArgon2id parses an Argon2id hash into its parameters, salt and key
+func decodeArgon2id(hashedPassword string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return params, nil, nil, errInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errInvalidHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, errInvalidHash
+	}
+	if params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return params, nil, nil, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return params, nil, nil, errInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errInvalidHash
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
+
 // Common weak passwords list
 var commonWeakPasswords = []string{
 	"password", "password123", "123456", "12345678", "123456789",
@@ -208,6 +371,19 @@ func (pm *PasswordManager) ValidatePasswordStrength(password string) error {
 	if hasRepeatedChars(password, 3) {
 		return fmt.Errorf("password should not contain repeated characters (e.g., 'aaa', '111')")
 	}
+
+	// Check known data breaches last, so passwords failing the rules above
+	// cost no request
+	if pm.breaches != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), breachCheckTimeout)
+		defer cancel()
+		breached, err := pm.breaches.Breached(ctx, password)
+		if err != nil {
+			log.Printf("Skipping breached password check: %v", err)
+		} else if breached {
+			return ErrPasswordBreached
+		}
+	}
 	
 	return nil
 }
\ No newline at end of file
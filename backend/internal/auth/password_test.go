@@ -270,4 +270,72 @@ func TestNewPasswordManager_CostValidation(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestPasswordManager_Argon2id(t *testing.T) {
+	params := Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1}
+	pm := NewPasswordManager(4).WithArgon2id(params)
+
+	// Argon2id reads past bcrypt's 72 bytes
+	long := strings.Repeat("a", 100) + "A1!"
+	hash, err := pm.HashPassword(long)
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("HashPassword() = %q, want an encoded argon2id hash", hash)
+	}
+	if err := pm.VerifyPassword(hash, long); err != nil {
+		t.Errorf("VerifyPassword() error = %v", err)
+	}
+	if err := pm.VerifyPassword(hash, strings.Repeat("a", 100)+"A2!"); err == nil {
+		t.Error("VerifyPassword() accepted a password differing after 72 bytes")
+	}
+	if pm.NeedsRehash(hash) {
+		t.Error("NeedsRehash() = true for a hash with the current parameters")
+	}
+
+	for _, invalid := range []string{"$argon2id$v=19$m=64,t=1,p=1$salt", "$argon2id$v=16$m=64,t=1,p=1$c2FsdA$a2V5", "$argon2id$v=19$m=0,t=1,p=1$c2FsdA$a2V5"} {
+		if err := pm.VerifyPassword(invalid, long); err == nil {
+			t.Errorf("VerifyPassword(%q) accepted an invalid hash", invalid)
+		}
+	}
+}
+
+func TestPasswordManager_NeedsRehash(t *testing.T) {
+	bcryptManager := NewPasswordManager(4)
+	bcryptHash, err := bcryptManager.HashPassword("SecurePass123!")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	argonManager := NewPasswordManager(4).WithArgon2id(Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1})
+	argonHash, err := argonManager.HashPassword("SecurePass123!")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	// Old bcrypt hashes still verify, and are due to move to Argon2id
+	if err := argonManager.VerifyPassword(bcryptHash, "SecurePass123!"); err != nil {
+		t.Errorf("VerifyPassword() of bcrypt hash error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		manager *PasswordManager
+		hash    string
+		want    bool
+	}{
+		{"bcrypt to argon2id", argonManager, bcryptHash, true},
+		{"argon2id current", argonManager, argonHash, false},
+		{"argon2id stronger params", NewPasswordManager(4).WithArgon2id(Argon2Params{Memory: 128, Iterations: 1, Parallelism: 1}), argonHash, true},
+		{"bcrypt current", bcryptManager, bcryptHash, false},
+		{"bcrypt higher cost", NewPasswordManager(5), bcryptHash, true},
+		{"argon2id back to bcrypt", bcryptManager, argonHash, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.manager.NeedsRehash(tt.hash); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DefaultEncryptionKeyID names the encryption key when no ID is configured
+const DefaultEncryptionKeyID = "v1"
+
+// keyIDSeparator ends the key ID sealed text starts with. It is not in the
+// base64 alphabet, so text sealed before key IDs has none.
+const keyIDSeparator = ":"
+
+// ErrUnknownEncryptionKey is returned for text sealed with a key the box
+// does not have
+var ErrUnknownEncryptionKey = errors.New("sealed with an unknown encryption key")
+
+// SecretBox encrypts secrets we must be able to read back, such as platform
+// cookies and two-factor keys, with AES-256-GCM. Sealed text is prefixed
+// with the ID of the key that sealed it, so the key can be rotated: the box
+// seals with its current key and opens text sealed with any key it holds.
+type SecretBox struct {
+	keyID string
+	keys  map[string]cipher.AEAD
+	// legacyOrder is the order keys are tried for text without a key ID,
+	// current first
+	legacyOrder []string
+}
+
+// NewSecretBox creates a box from a 32 byte key, under DefaultEncryptionKeyID
+func NewSecretBox(key string) (*SecretBox, error) {
+	return NewVersionedSecretBox(DefaultEncryptionKeyID, key, nil)
+}
+
+// NewVersionedSecretBox creates a box sealing with key under keyID that
+// also opens text sealed with the previous keys, by key ID. Every key is 32
+// bytes.
+func NewVersionedSecretBox(keyID, key string, previous map[string]string) (*SecretBox, error) {
+	b := &SecretBox{
+		keyID: keyID,
+		keys:  make(map[string]cipher.AEAD, len(previous)+1),
+	}
+	if err := b.add(keyID, key); err != nil {
+		return nil, err
+	}
+
+	previousIDs := make([]string, 0, len(previous))
+	for id := range previous {
+		previousIDs = append(previousIDs, id)
+	}
+	sort.Strings(previousIDs)
+	for _, id := range previousIDs {
+		if id == keyID {
+			return nil, fmt.Errorf("previous encryption key reuses the current key ID %q", keyID)
+		}
+		if err := b.add(id, previous[id]); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// add adds a key that opens text sealed under id
+func (b *SecretBox) add(id, key string) error {
+	if id == "" || strings.ContainsAny(id, keyIDSeparator+", ") {
+		return fmt.Errorf("invalid encryption key ID %q", id)
+	}
+	if len(key) != 32 {
+		return fmt.Errorf("encryption key %q must be 32 bytes", id)
+	}
+
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	b.keys[id] = gcm
+	b.legacyOrder = append(b.legacyOrder, id)
+	return nil
+}
+
+// KeyID returns the ID of the key new text is sealed with
+func (b *SecretBox) KeyID() string {
+	return b.keyID
+}
+
+// Seal encrypts plaintext with a random nonce under the current key,
+// returning the key ID and base64 text
+func (b *SecretBox) Seal(plaintext []byte) (string, error) {
+	gcm := b.keys[b.keyID]
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+	return b.keyID + keyIDSeparator + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Open decrypts text from Seal. Text sealed before key IDs is tried with
+// every key.
+func (b *SecretBox) Open(ciphertext string) ([]byte, error) {
+	keyID, encoded, versioned := strings.Cut(ciphertext, keyIDSeparator)
+	if !versioned {
+		return b.openLegacy(ciphertext)
+	}
+
+	gcm, ok := b.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownEncryptionKey, keyID)
+	}
+	return open(gcm, encoded)
+}
+
+// openLegacy decrypts text sealed before key IDs with whichever key sealed
+// it; GCM refuses the others
+func (b *SecretBox) openLegacy(encoded string) ([]byte, error) {
+	var lastErr error
+	for _, id := range b.legacyOrder {
+		plaintext, err := open(b.keys[id], encoded)
+		if err == nil {
+			return plaintext, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// NeedsReseal reports whether text was sealed with a key other than the
+// current one, or before key IDs
+func (b *SecretBox) NeedsReseal(ciphertext string) bool {
+	keyID, _, versioned := strings.Cut(ciphertext, keyIDSeparator)
+	return !versioned || keyID != b.keyID
+}
+
+// Reseal opens text sealed with any key the box holds and seals it again
+// with the current one
+func (b *SecretBox) Reseal(ciphertext string) (string, error) {
+	plaintext, err := b.Open(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return b.Seal(plaintext)
+}
+
+// open decrypts base64 text sealed by gcm
+func open(gcm cipher.AEAD, encoded string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	oldEncryptionKey = "0123456789abcdef0123456789abcdef"
+	newEncryptionKey = "fedcba9876543210fedcba9876543210"
+)
+
+// sealUnversioned seals text the way boxes did before key IDs
+func sealUnversioned(t *testing.T, key string, plaintext []byte) string {
+	block, err := aes.NewCipher([]byte(key))
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, plaintext, nil))
+}
+
+func TestSecretBox_SealsWithKeyID(t *testing.T) {
+	box, err := NewSecretBox(oldEncryptionKey)
+	require.NoError(t, err)
+
+	sealed, err := box.Seal([]byte("espn_s2"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, DefaultEncryptionKeyID+":"))
+	assert.False(t, box.NeedsReseal(sealed))
+
+	opened, err := box.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "espn_s2", string(opened))
+}
+
+func TestSecretBox_Rotation(t *testing.T) {
+	oldBox, err := NewVersionedSecretBox("2024", oldEncryptionKey, nil)
+	require.NoError(t, err)
+	oldSealed, err := oldBox.Seal([]byte("cookie"))
+	require.NoError(t, err)
+	legacy := sealUnversioned(t, oldEncryptionKey, []byte("totp"))
+
+	box, err := NewVersionedSecretBox("2025", newEncryptionKey, map[string]string{"2024": oldEncryptionKey})
+	require.NoError(t, err)
+
+	// Text sealed with the retired key, with or without its ID, still opens
+	opened, err := box.Open(oldSealed)
+	require.NoError(t, err)
+	assert.Equal(t, "cookie", string(opened))
+	opened, err = box.Open(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, "totp", string(opened))
+
+	assert.True(t, box.NeedsReseal(oldSealed))
+	assert.True(t, box.NeedsReseal(legacy))
+	resealed, err := box.Reseal(oldSealed)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resealed, "2025:"))
+	assert.False(t, box.NeedsReseal(resealed))
+
+	// Once the retired key is dropped, only resealed text opens
+	newOnly, err := NewVersionedSecretBox("2025", newEncryptionKey, nil)
+	require.NoError(t, err)
+	_, err = newOnly.Open(oldSealed)
+	assert.ErrorIs(t, err, ErrUnknownEncryptionKey)
+	_, err = newOnly.Open(legacy)
+	assert.Error(t, err)
+	opened, err = newOnly.Open(resealed)
+	require.NoError(t, err)
+	assert.Equal(t, "cookie", string(opened))
+}
+
+func TestNewVersionedSecretBox_Invalid(t *testing.T) {
+	_, err := NewVersionedSecretBox("v1", "short", nil)
+	assert.Error(t, err)
+	_, err = NewVersionedSecretBox("v:1", oldEncryptionKey, nil)
+	assert.Error(t, err)
+	_, err = NewVersionedSecretBox("v2", newEncryptionKey, map[string]string{"v2": oldEncryptionKey})
+	assert.Error(t, err)
+	_, err = NewVersionedSecretBox("v2", newEncryptionKey, map[string]string{"v1": "short"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// TokenCache is a small LRU of recently validated tokens and their claims.
+// Draft rooms poll the same endpoints with the same token many times a
+// second, and verifying its signature each time is most of the cost of
+// authenticating them. Tokens are keyed by their SHA-256, so the cache never
+// holds one that could be replayed, and entries are dropped once the token
+// expires. Revocation is not cached: callers still check the denylist.
+type TokenCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+// tokenCacheEntry is one validated token
+type tokenCacheEntry struct {
+	key    [sha256.Size]byte
+	claims Claims
+}
+
+// NewTokenCache creates a cache of up to size tokens. A size of zero or less
+// returns nil, which caches nothing.
+func NewTokenCache(size int) *TokenCache {
+	if size <= 0 {
+		return nil
+	}
+	return &TokenCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[[sha256.Size]byte]*list.Element, size),
+	}
+}
+
+// Get returns the claims of token if it was validated recently and has not
+// expired since
+func (c *TokenCache) Get(token string) (*Claims, bool) {
+	if c == nil {
+		return nil, false
+	}
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*tokenCacheEntry)
+	if entry.claims.ExpiresAt != nil && !time.Now().Before(entry.claims.ExpiresAt.Time) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	claims := entry.claims
+	return &claims, true
+}
+
+// Add records that token validated with claims, evicting the least recently
+// used token when the cache is full
+func (c *TokenCache) Add(token string, claims *Claims) {
+	if c == nil {
+		return
+	}
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*tokenCacheEntry).claims = *claims
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&tokenCacheEntry{key: key, claims: *claims})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*tokenCacheEntry).key)
+	}
+}
+
+// Len returns the number of cached tokens
+func (c *TokenCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestTokenCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewTokenCache(2)
+	claims := func() *Claims {
+		return &Claims{UserID: uuid.New(), RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}}
+	}
+	a, b, c := claims(), claims(), claims()
+
+	cache.Add("a", a)
+	cache.Add("b", b)
+	if got, ok := cache.Get("a"); !ok || got.UserID != a.UserID {
+		t.Fatalf("Get(a) = %v, %v", got, ok)
+	}
+	// b is now the least recently used
+	cache.Add("c", c)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) hit after it should have been evicted")
+	}
+	for token, want := range map[string]*Claims{"a": a, "c": c} {
+		if got, ok := cache.Get(token); !ok || got.UserID != want.UserID {
+			t.Errorf("Get(%s) = %v, %v", token, got, ok)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+}
+
+func TestTokenCache_DropsExpiredTokens(t *testing.T) {
+	cache := NewTokenCache(10)
+	cache.Add("expired", &Claims{RegisteredClaims: jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Second)),
+	}})
+
+	if _, ok := cache.Get("expired"); ok {
+		t.Error("Get returned an expired token")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", cache.Len())
+	}
+}
+
+func TestTokenCache_Disabled(t *testing.T) {
+	cache := NewTokenCache(0)
+	cache.Add("token", &Claims{})
+	if _, ok := cache.Get("token"); ok {
+		t.Error("disabled cache returned a token")
+	}
+}
+
+func TestJWTManager_ValidateCached(t *testing.T) {
+	jwtManager := NewJWTManager("test_secret_key", 15*time.Minute, time.Hour).WithValidationCache(8)
+	token, err := jwtManager.GenerateAccessToken(uuid.New(), "fan@example.com")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+
+	first, err := jwtManager.validateCached(token)
+	if err != nil {
+		t.Fatalf("validateCached() error = %v", err)
+	}
+	if jwtManager.cache.Len() != 1 {
+		t.Fatalf("cache holds %d tokens, want 1", jwtManager.cache.Len())
+	}
+	second, err := jwtManager.validateCached(token)
+	if err != nil || second.ID != first.ID {
+		t.Errorf("cached validateCached() = %v, %v; want token %s", second, err, first.ID)
+	}
+
+	if _, err := jwtManager.validateCached(token + "x"); err == nil {
+		t.Error("validateCached accepted a tampered token")
+	}
+	if jwtManager.cache.Len() != 1 {
+		t.Errorf("cache holds %d tokens after a failed validation, want 1", jwtManager.cache.Len())
+	}
+}
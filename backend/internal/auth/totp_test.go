@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 key from RFC 6238's test vectors
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode_RFC6238(t *testing.T) {
+	// The RFC lists 8 digit codes; ours are their last 6
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode(t=%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := TOTPStep(now)
+
+	current, _ := TOTPCode(rfc6238Secret, step)
+	if got, ok := ValidateTOTP(rfc6238Secret, current, now); !ok || got != step {
+		t.Errorf("ValidateTOTP(current) = %d, %v; want %d, true", got, ok, step)
+	}
+
+	previous, _ := TOTPCode(rfc6238Secret, step-1)
+	if got, ok := ValidateTOTP(rfc6238Secret, previous, now); !ok || got != step-1 {
+		t.Errorf("ValidateTOTP(previous step) = %d, %v; want %d, true", got, ok, step-1)
+	}
+
+	stale, _ := TOTPCode(rfc6238Secret, step-3)
+	if _, ok := ValidateTOTP(rfc6238Secret, stale, now); ok {
+		t.Error("ValidateTOTP accepted a code three steps old")
+	}
+
+	for _, bad := range []string{"", "12345", "abcdef", "1234567"} {
+		if _, ok := ValidateTOTP(rfc6238Secret, bad, now); ok {
+			t.Errorf("ValidateTOTP(%q) accepted", bad)
+		}
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("secret length = %d, want 32", len(secret))
+	}
+	if _, err := TOTPCode(secret, 1); err != nil {
+		t.Errorf("generated secret does not decode: %v", err)
+	}
+
+	uri := TOTPProvisioningURI(secret, "NFL Analytics", "fan@example.com")
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "otpauth" || u.Host != "totp" {
+		t.Fatalf("TOTPProvisioningURI() = %q", uri)
+	}
+	if u.Query().Get("secret") != secret || u.Query().Get("issuer") != "NFL Analytics" {
+		t.Errorf("TOTPProvisioningURI() query = %v", u.Query())
+	}
+	if !strings.HasPrefix(uri, "otpauth://totp/NFL%20Analytics:fan@example.com?") {
+		t.Errorf("TOTPProvisioningURI() label = %q", uri)
+	}
+}
+
+func TestBackupCodes(t *testing.T) {
+	codes, err := GenerateBackupCodes(10)
+	if err != nil {
+		t.Fatalf("GenerateBackupCodes() error = %v", err)
+	}
+	seen := map[string]bool{}
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("backup code %q is not xxxxx-xxxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("duplicate backup code %q", code)
+		}
+		seen[code] = true
+	}
+
+	if HashBackupCode("abcde-fghjk") != HashBackupCode(" ABCDE FGHJK ") {
+		t.Error("HashBackupCode should ignore case, spaces and dashes")
+	}
+	if HashBackupCode("abcde-fghjk") == HashBackupCode("abcde-fghjm") {
+		t.Error("HashBackupCode collided")
+	}
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache stores JSON values in Redis. A Cache without a client is a no-op, so
+// callers work unchanged when Redis is unavailable.
+type Cache struct {
+	client *redis.Client
+	scaler TTLScaler
+}
+
+// TTLScaler stretches cache TTLs at runtime, such as during draft-day surge
+// mode when fresher data is worth less than a lighter load
+type TTLScaler interface {
+	CacheTTLMultiplier(ctx context.Context) float64
+}
+
+// New creates a cache backed by client, which may be nil
+func New(client *redis.Client) *Cache {
+	return &Cache{client: client}
+}
+
+// WithTTLScaler multiplies every TTL set through the cache by the scaler's
+// current multiplier
+func (c *Cache) WithTTLScaler(s TTLScaler) *Cache {
+	c.scaler = s
+	return c
+}
+
+// Enabled reports whether the cache has a Redis client
+func (c *Cache) Enabled() bool {
+	return c != nil && c.client != nil
+}
+
+// GetJSON loads key into dest and reports whether it was found. Redis errors
+// are treated as misses.
+func (c *Cache) GetJSON(ctx context.Context, key string, dest interface{}) bool {
+	if !c.Enabled() {
+		return false
+	}
+
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(data, dest) == nil
+}
+
+// SetJSON stores value under key for ttl
+func (c *Cache) SetJSON(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache value: %w", err)
+	}
+
+	if c.scaler != nil {
+		ttl = time.Duration(float64(ttl) * c.scaler.CacheTTLMultiplier(ctx))
+	}
+
+	if err := c.client.Set(ctx, key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set cache key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// DeletePrefix removes every key starting with prefix and returns how many
+// were removed
+func (c *Cache) DeletePrefix(ctx context.Context, prefix string) (int, error) {
+	if !c.Enabled() {
+		return 0, nil
+	}
+
+	deleted := 0
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(ctx, cursor, prefix+"*", 500).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("failed to scan cache keys %s*: %w", prefix, err)
+		}
+		if len(keys) > 0 {
+			n, err := c.client.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("failed to delete cache keys %s*: %w", prefix, err)
+			}
+			deleted += int(n)
+		}
+		cursor = next
+		if cursor == 0 {
+			return deleted, nil
+		}
+	}
+}
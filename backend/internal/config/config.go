@@ -4,15 +4,41 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	App      AppConfig
+	Server        ServerConfig
+	Database      DatabaseConfig
+	Redis         RedisConfig
+	JWT           JWTConfig
+	Password      PasswordConfig
+	Encryption    EncryptionConfig
+	Secrets       SecretsConfig
+	App           AppConfig
+	Worker        WorkerConfig
+	Cache         CacheConfig
+	Draft         DraftConfig
+	Projections   ProjectionsConfig
+	BotProtection BotProtectionConfig
+	Maintenance   MaintenanceConfig
+	Surge         SurgeConfig
+	Admin         AdminConfig
+	RedisAudit    RedisAuditConfig
+	Analytics     AnalyticsConfig
+	EventBus      EventBusConfig
+	Stream        StreamConfig
+	Quota         QuotaConfig
+	OAuth         OAuthConfig
+	TwoFactor     TwoFactorConfig
+	LoginAlerts   LoginAlertConfig
+	Mail          MailConfig
+	Assistant     AssistantConfig
+	Voice         VoiceConfig
+	Archive       ArchiveConfig
+	RateLimit     RateLimitConfig
+	AuthCookies   AuthCookieConfig
 }
 
 type ServerConfig struct {
@@ -20,6 +46,9 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	// TrustedProxies are the IPs or CIDRs whose X-Forwarded-For is believed
+	// when resolving client IPs; none are trusted when empty
+	TrustedProxies []string
 }
 
 type DatabaseConfig struct {
@@ -42,17 +71,392 @@ type RedisConfig struct {
 	DB       int
 }
 
+// maxRememberMeExpiry is the longest a remember-me session may stay signed
+// in without the user entering their password again
+const maxRememberMeExpiry = 90 * 24 * time.Hour
+
+// maxDraftResumeTokenTTL bounds DRAFT_RESUME_TOKEN_TTL; resume tokens are
+// meant to bridge a failed refresh, not replace logins
+const maxDraftResumeTokenTTL = 12 * time.Hour
+
+// maxJWTLeeway bounds JWT_LEEWAY; more than clock skew would let expired
+// tokens in
+const maxJWTLeeway = 5 * time.Minute
+
 type JWTConfig struct {
-	Secret              string
-	AccessTokenExpiry   time.Duration
-	RefreshTokenExpiry  time.Duration
+	// Algorithm signs tokens: HS256 with Secret, or RS256 or EdDSA with
+	// PrivateKey
+	Algorithm string
+	Secret    string
+	// PrivateKey is the PEM-encoded RSA or Ed25519 signing key
+	PrivateKey string
+	// KeyID names the signing key in the kid header of the tokens it signs
+	KeyID string
+	// PreviousKeys are retired secrets by kid, still accepted until the
+	// tokens signed with them expire
+	PreviousKeys map[string]string
+	// PreviousPublicKeys are the PEM-encoded public keys of retired private
+	// keys, by kid
+	PreviousPublicKeys map[string]string
+	AccessTokenExpiry  time.Duration
+	RefreshTokenExpiry time.Duration
+	// RememberMeRefreshExpiry is the refresh lifetime of logins with
+	// remember me, between RefreshTokenExpiry and maxRememberMeExpiry
+	RememberMeRefreshExpiry time.Duration
+	// Leeway tolerates clock skew between servers when checking token
+	// times, up to maxJWTLeeway
+	Leeway time.Duration
+	// Issuer and Audience are set on tokens and, when not empty, required
+	// of the tokens validated
+	Issuer   string
+	Audience string
+	// ValidationCacheSize is how many recently validated tokens are kept;
+	// zero disables the cache
+	ValidationCacheSize int
+}
+
+type PasswordConfig struct {
+	// Algorithm hashes new passwords: bcrypt or argon2id. Hashes made with
+	// the other, or with other parameters, are replaced at the next login.
+	Algorithm  string
+	BcryptCost int
+	// Argon2id parameters; memory is in KiB
+	Argon2Memory      int
+	Argon2Iterations  int
+	Argon2Parallelism int
+	// BreachCheck rejects new passwords found in Have I Been Pwned's
+	// breach corpus, looked up at BreachCheckURL
+	BreachCheck    bool
+	BreachCheckURL string
+}
+
+type EncryptionConfig struct {
+	// Key encrypts league credentials and two-factor secrets: 32 bytes,
+	// DevEncryptionKey outside production when unset. With a secrets
+	// provider other than env it is a reference to the key instead.
+	Key string
+	// KeyID is stored with every value Key encrypts
+	KeyID string
+	// PreviousKeys are retired keys by ID, still used to decrypt values
+	// until they are re-encrypted with Key. They are references like Key.
+	PreviousKeys map[string]string
+}
+
+// SecretsConfig chooses where the encryption keys come from
+type SecretsConfig struct {
+	// Provider is env, where ENCRYPTION_KEY is the key itself, or file, kms
+	// or vault, where it is a file path, a KMS ciphertext blob or a Vault
+	// path#field
+	Provider string
+	// AWS KMS
+	AWSRegion          string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+	KMSEndpoint        string
+	// HashiCorp Vault
+	VaultAddr      string
+	VaultToken     string
+	VaultTokenFile string
+	VaultNamespace string
 }
 
 type AppConfig struct {
+	// Environment is Profile's name, kept for comparisons with "production"
 	Environment string
+	Profile     Profile
 	LogLevel    string
 }
 
+type WorkerConfig struct {
+	LeagueSyncEnabled  bool
+	LeagueSyncInterval time.Duration
+	TransactionLimit   int
+	// LeagueSyncConcurrency is how many leagues are synced at once
+	LeagueSyncConcurrency int
+	// LeagueSyncCooldown is how long a user waits between sync requests
+	LeagueSyncCooldown time.Duration
+	// ESPNLeagueHourlyBudget caps ESPN requests per league per hour before
+	// the league's syncs are deferred; 0 disables the cap
+	ESPNLeagueHourlyBudget int
+	PlayerNewsEnabled  bool
+	PlayerNewsInterval time.Duration
+	PlayerNewsLimit    int
+	// Weekly league analytics precompute, run once stats are final
+	AnalyticsEnabled   bool
+	AnalyticsDay       time.Weekday
+	AnalyticsHour      int
+	AnalyticsLocation  *time.Location
+	PlayoffSimulations int
+	// Nightly player team, status and position refresh
+	PlayerMetadataEnabled  bool
+	PlayerMetadataHour     int
+	PlayerMetadataLocation *time.Location
+	// NFL team names and logos, kept in memory for decorating responses
+	TeamMetadataInterval time.Duration
+	// Daily warning and anonymization of inactive accounts, at
+	// InactivityHour UTC
+	InactivityEnabled       bool
+	InactivityHour          int
+	InactiveAfter           time.Duration
+	InactivityWarningPeriod time.Duration
+	InactivityBatchSize     int
+	// Daily pruning of data past its retention, at RetentionHour UTC. A zero
+	// retention keeps that data forever.
+	RetentionEnabled              bool
+	RetentionHour                 int
+	RetentionBatchSize            int
+	RetentionRawProjectionSeasons int
+	RetentionSyncDiffs            time.Duration
+	RetentionAuditLog             time.Duration
+	// A worker that misses its next tick by HeartbeatGrace is reported
+	// stale; heartbeats are logged as metrics every HeartbeatReportInterval
+	HeartbeatGrace          time.Duration
+	HeartbeatReportInterval time.Duration
+}
+type CacheConfig struct {
+	// WarmOnStartup preloads hot data before the server accepts traffic
+	WarmOnStartup  bool
+	WarmTimeout    time.Duration
+	ProjectionsTTL time.Duration
+	// ESPN response cache used by user-facing requests
+	ESPNEnabled       bool
+	ESPNLeagueTTL     time.Duration
+	ESPNRostersTTL    time.Duration
+	ESPNFreeAgentsTTL time.Duration
+}
+
+type DraftConfig struct {
+	// Recommendation engine version served to users
+	EngineVersion string
+	// Candidate engine version run in shadow for ShadowPercent of requests
+	ShadowEngineVersion string
+	ShadowPercent       float64
+	// RateLimit is the per-user draft route limit per minute
+	RateLimit int
+	// ResumeTokenTTL is how long a draft resume token works; 0 turns them
+	// off
+	ResumeTokenTTL time.Duration
+}
+
+type BotProtectionConfig struct {
+	// CAPTCHA verification on register is off unless a secret is set
+	CaptchaProvider string
+	CaptchaSecret   string
+	// Reject signups from throwaway email providers
+	BlockDisposableEmails bool
+	DisposableDomains     []string
+}
+
+type MaintenanceConfig struct {
+	// Enabled starts the API in maintenance mode; the switch stored in
+	// Redis overrides it once toggled through the admin API
+	Enabled bool
+	Message string
+	// DraftGracePeriod keeps draft routes open after maintenance starts
+	DraftGracePeriod time.Duration
+	// RefreshInterval is how often each instance rereads the Redis switch
+	RefreshInterval time.Duration
+}
+
+type SurgeConfig struct {
+	// AutoActiveDrafts turns surge mode on once this many drafts are in
+	// progress; 0 leaves it to the admin override
+	AutoActiveDrafts      int
+	RefreshInterval       time.Duration
+	CacheTTLMultiplier    float64
+	JobIntervalMultiplier int
+	DraftRateMultiplier   float64
+	// Share of requests whose latency is logged, normally and in a surge
+	MetricsSampleRate      float64
+	SurgeMetricsSampleRate float64
+}
+
+type OAuthConfig struct {
+	// Google sign-in is offered when a client ID is set
+	GoogleClientID     string
+	GoogleClientSecret string
+	// GoogleRedirectURL is our callback, as registered with Google
+	GoogleRedirectURL string
+	// SuccessRedirectURL is the frontend page that receives tokens after
+	// sign-in; empty answers the callback with JSON
+	SuccessRedirectURL string
+}
+
+type TwoFactorConfig struct {
+	// Issuer names us in users' authenticator apps
+	Issuer string
+}
+
+// LoginAlertConfig covers sign-ins from devices and networks a user has not
+// used before
+type LoginAlertConfig struct {
+	// Enabled records them in the security log and emails the user
+	Enabled bool
+	// RequireVerification also holds them until the user enters a code
+	// emailed to them. It needs mail to be configured.
+	RequireVerification bool
+}
+
+// MailConfig is the SMTP relay transactional email goes through. With no
+// host, email is written to the log outside production and not sent in
+// production.
+type MailConfig struct {
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+}
+
+type RateLimitConfig struct {
+	// Requests each client may make to the auth endpoints per window
+	AuthLimit  int
+	AuthWindow time.Duration
+	// Requests each user, or IP for public routes, may make to the rest of
+	// the API per window
+	APILimit  int
+	APIWindow time.Duration
+}
+
+type AuthCookieConfig struct {
+	// Enabled lets browser clients ask for tokens as httpOnly cookies
+	Enabled bool
+	Domain  string
+	Secure  bool
+	// SameSite is lax, strict or none; none needs Secure
+	SameSite string
+}
+
+type AssistantConfig struct {
+	// Provider is anthropic or openai; empty turns the assistant off
+	Provider string
+	APIKey   string
+	Model    string
+	// BaseURL overrides the provider's API host, such as for an OpenAI
+	// compatible server
+	BaseURL string
+	Timeout time.Duration
+	// RateLimit is the questions each user may ask per hour
+	RateLimit int
+}
+
+type VoiceConfig struct {
+	// Enabled turns on device linking and the assistant webhooks
+	Enabled bool
+	// DeviceTTL is how long a linked device's token works
+	DeviceTTL time.Duration
+	// AlexaSkillID is the only skill Alexa requests are accepted for;
+	// required in production
+	AlexaSkillID string
+	// AlexaVerifySignatures checks Alexa's signature on every request; only
+	// turn it off to try the skill locally, never in production
+	AlexaVerifySignatures bool
+	// GoogleWebhookSecret, when set, must be sent by Dialogflow in the
+	// X-Voice-Webhook-Secret header; required in production
+	GoogleWebhookSecret string
+	// RedirectURIs are the account linking redirects of the Alexa skill and
+	// Google action
+	RedirectURIs []string
+}
+
+type ArchiveConfig struct {
+	// Enabled runs the daily archival job at Hour UTC. Archived seasons can
+	// be rehydrated whether or not it is enabled.
+	Enabled bool
+	Hour    int
+	// HotSeasons stay in Postgres, counting the current one
+	HotSeasons int
+	// BatchSize bounds the league seasons archived per run
+	BatchSize int
+	// RehydratedFor is how long a rehydrated season stays before it is
+	// archived again
+	RehydratedFor time.Duration
+	// Store is "file", under Dir, or "s3"
+	Store string
+	Dir   string
+	// S3 bucket; an empty endpoint is AWS
+	S3Endpoint        string
+	S3Bucket          string
+	S3Region          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+}
+
+type QuotaConfig struct {
+	// Plan names the plan every user is on until accounts carry their own
+	Plan string
+	// Soft per-user limits reported in quota headers; 0 is unlimited
+	MaxLeagues       int
+	MaxDraftSessions int
+	MaxWatchlist     int
+}
+
+type AdminConfig struct {
+	// APIKey authorizes /api/admin routes; they are disabled when empty
+	APIKey string
+}
+
+type RedisAuditConfig struct {
+	// Periodic scan of Redis memory per key namespace that also sets
+	// expiries on app keys missing one
+	Enabled  bool
+	Interval time.Duration
+	// Memory budgets in megabytes; a namespace over budget is flagged
+	DraftStateBudgetMB int
+	CacheBudgetMB      int
+	RateLimitBudgetMB  int
+}
+
+type AnalyticsConfig struct {
+	// Sink receives product events: none, log, segment or kafka
+	Sink            string
+	SegmentEndpoint string
+	SegmentWriteKey string
+	// Kafka events go through a REST proxy
+	KafkaRESTURL string
+	KafkaTopic   string
+	// DefaultConsent applies to users who have not chosen
+	DefaultConsent bool
+	QueueSize      int
+	BatchSize      int
+	FlushInterval  time.Duration
+}
+
+type EventBusConfig struct {
+	// Transport forwards bus events to an external stream: none, kafka or nats
+	Transport string
+	// Topics limits which topics are forwarded; empty forwards all of them
+	Topics    []string
+	QueueSize int
+	// Kafka events go through a REST proxy, one Kafka topic per bus topic
+	KafkaRESTURL     string
+	KafkaTopicPrefix string
+	NATSURL           string
+	NATSSubjectPrefix string
+}
+
+type StreamConfig struct {
+	// Recent events kept per draft or league stream for clients resuming
+	BufferSize int
+	// PollTimeout is how long a long-poll request waits for events
+	PollTimeout time.Duration
+	// HeartbeatInterval is how often an idle SSE stream sends a comment
+	HeartbeatInterval time.Duration
+}
+
+type ProjectionsConfig struct {
+	PipelineStages []string
+	SourceWeights  map[string]float64
+	RestModifier   bool
+	// AlertThreshold is the PPR change that notifies rostering and watching users
+	AlertThreshold float64
+	// The Odds API key and the bookmakers whose player props become
+	// projection sources
+	OddsAPIKey     string
+	OddsBookmakers []string
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	cfg := &Config{}
@@ -62,6 +466,7 @@ func Load() (*Config, error) {
 	cfg.Server.ReadTimeout = getDurationEnv("SERVER_READ_TIMEOUT", 15*time.Second)
 	cfg.Server.WriteTimeout = getDurationEnv("SERVER_WRITE_TIMEOUT", 15*time.Second)
 	cfg.Server.IdleTimeout = getDurationEnv("SERVER_IDLE_TIMEOUT", 60*time.Second)
+	cfg.Server.TrustedProxies = getListEnv("TRUSTED_PROXIES", nil)
 
 	// Database configuration
 	cfg.Database.Host = getEnv("POSTGRES_HOST", "localhost")
@@ -82,20 +487,375 @@ func Load() (*Config, error) {
 	cfg.Redis.DB = getIntEnv("REDIS_DB", 0)
 
 	// JWT configuration
+	algorithm, err := parseJWTAlgorithm(getEnv("JWT_ALGORITHM", "HS256"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.JWT.Algorithm = algorithm
 	cfg.JWT.Secret = getEnv("JWT_SECRET", "")
-	if cfg.JWT.Secret == "" {
+	if cfg.JWT.Algorithm == "HS256" && cfg.JWT.Secret == "" {
 		return nil, fmt.Errorf("JWT_SECRET is required")
 	}
+	if cfg.JWT.Algorithm != "HS256" {
+		cfg.JWT.PrivateKey, err = getPEMEnv("JWT_PRIVATE_KEY")
+		if err != nil {
+			return nil, err
+		}
+		if cfg.JWT.PrivateKey == "" {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY or JWT_PRIVATE_KEY_FILE is required with JWT_ALGORITHM=%s", cfg.JWT.Algorithm)
+		}
+	}
+	cfg.JWT.KeyID = getEnv("JWT_KEY_ID", "primary")
+	cfg.JWT.PreviousKeys = getSecretsEnv("JWT_PREVIOUS_KEYS")
+	if _, ok := cfg.JWT.PreviousKeys[cfg.JWT.KeyID]; ok {
+		return nil, fmt.Errorf("JWT_PREVIOUS_KEYS reuses the current JWT_KEY_ID %q", cfg.JWT.KeyID)
+	}
+	cfg.JWT.PreviousPublicKeys = make(map[string]string)
+	for kid, path := range getSecretsEnv("JWT_PREVIOUS_PUBLIC_KEY_FILES") {
+		if kid == cfg.JWT.KeyID {
+			return nil, fmt.Errorf("JWT_PREVIOUS_PUBLIC_KEY_FILES reuses the current JWT_KEY_ID %q", kid)
+		}
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read previous JWT public key %q: %w", kid, err)
+		}
+		cfg.JWT.PreviousPublicKeys[kid] = string(pem)
+	}
 	cfg.JWT.AccessTokenExpiry = getDurationEnv("JWT_ACCESS_TOKEN_EXPIRY", 15*time.Minute)
 	cfg.JWT.RefreshTokenExpiry = getDurationEnv("JWT_REFRESH_TOKEN_EXPIRY", 7*24*time.Hour)
+	cfg.JWT.RememberMeRefreshExpiry = getDurationEnv("JWT_REMEMBER_ME_REFRESH_EXPIRY", 30*24*time.Hour)
+	if cfg.JWT.RememberMeRefreshExpiry < cfg.JWT.RefreshTokenExpiry || cfg.JWT.RememberMeRefreshExpiry > maxRememberMeExpiry {
+		return nil, fmt.Errorf("JWT_REMEMBER_ME_REFRESH_EXPIRY must be between JWT_REFRESH_TOKEN_EXPIRY and %s", maxRememberMeExpiry)
+	}
+	cfg.JWT.Leeway = getDurationEnv("JWT_LEEWAY", 30*time.Second)
+	if cfg.JWT.Leeway < 0 || cfg.JWT.Leeway > maxJWTLeeway {
+		return nil, fmt.Errorf("JWT_LEEWAY must be between 0 and %s", maxJWTLeeway)
+	}
+	cfg.JWT.Issuer = getEnv("JWT_ISSUER", "")
+	cfg.JWT.Audience = getEnv("JWT_AUDIENCE", "")
+	cfg.JWT.ValidationCacheSize = getIntEnv("JWT_VALIDATION_CACHE_SIZE", 1024)
+	if cfg.JWT.ValidationCacheSize < 0 {
+		return nil, fmt.Errorf("JWT_VALIDATION_CACHE_SIZE must not be negative")
+	}
+
+	// Password hashing
+	cfg.Password.Algorithm = strings.ToLower(getEnv("PASSWORD_HASH_ALGORITHM", "bcrypt"))
+	cfg.Password.BcryptCost = getIntEnv("BCRYPT_COST", 10)
+	cfg.Password.Argon2Memory = getIntEnv("ARGON2_MEMORY_KB", 19*1024)
+	cfg.Password.Argon2Iterations = getIntEnv("ARGON2_ITERATIONS", 2)
+	cfg.Password.Argon2Parallelism = getIntEnv("ARGON2_PARALLELISM", 1)
+	cfg.Password.BreachCheck = getBoolEnv("PASSWORD_BREACH_CHECK", true)
+	cfg.Password.BreachCheckURL = getEnv("PASSWORD_BREACH_CHECK_URL", "https://api.pwnedpasswords.com")
+	switch cfg.Password.Algorithm {
+	case "bcrypt":
+	case "argon2id":
+		if cfg.Password.Argon2Memory < 8*cfg.Password.Argon2Parallelism || cfg.Password.Argon2Iterations < 1 ||
+			cfg.Password.Argon2Parallelism < 1 || cfg.Password.Argon2Parallelism > 255 {
+			return nil, fmt.Errorf("invalid Argon2 parameters")
+		}
+	default:
+		return nil, fmt.Errorf("invalid PASSWORD_HASH_ALGORITHM %q", cfg.Password.Algorithm)
+	}
 
 	// App configuration
-	cfg.App.Environment = getEnv("ENV", "development")
+	profile, err := ParseProfile(getEnv("ENV", "development"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.App.Profile = profile
+	cfg.App.Environment = string(profile)
 	cfg.App.LogLevel = getEnv("LOG_LEVEL", "info")
 
+	// Encryption keys are kept in the environment or, in production, read
+	// at startup from a file, AWS KMS or Vault
+	cfg.Secrets.Provider = strings.ToLower(getEnv("SECRETS_PROVIDER", "env"))
+	cfg.Secrets.AWSRegion = getEnv("AWS_REGION", "")
+	cfg.Secrets.AWSAccessKeyID = getEnv("AWS_ACCESS_KEY_ID", "")
+	cfg.Secrets.AWSSecretAccessKey = getEnv("AWS_SECRET_ACCESS_KEY", "")
+	cfg.Secrets.AWSSessionToken = getEnv("AWS_SESSION_TOKEN", "")
+	cfg.Secrets.KMSEndpoint = getEnv("KMS_ENDPOINT", "")
+	cfg.Secrets.VaultAddr = getEnv("VAULT_ADDR", "")
+	cfg.Secrets.VaultToken = getEnv("VAULT_TOKEN", "")
+	cfg.Secrets.VaultTokenFile = getEnv("VAULT_TOKEN_FILE", "")
+	cfg.Secrets.VaultNamespace = getEnv("VAULT_NAMESPACE", "")
+	switch cfg.Secrets.Provider {
+	case "env", "file":
+	case "kms":
+		if cfg.Secrets.AWSRegion == "" || cfg.Secrets.AWSAccessKeyID == "" || cfg.Secrets.AWSSecretAccessKey == "" {
+			return nil, fmt.Errorf("SECRETS_PROVIDER=kms requires AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+	case "vault":
+		if cfg.Secrets.VaultAddr == "" || (cfg.Secrets.VaultToken == "" && cfg.Secrets.VaultTokenFile == "") {
+			return nil, fmt.Errorf("SECRETS_PROVIDER=vault requires VAULT_ADDR and VAULT_TOKEN or VAULT_TOKEN_FILE")
+		}
+	default:
+		return nil, fmt.Errorf("invalid SECRETS_PROVIDER %q: use env, file, kms or vault", cfg.Secrets.Provider)
+	}
+
+	// Encryption key, which production must set to a secret of its own
+	cfg.Encryption.Key = getEnv("ENCRYPTION_KEY", "")
+	if cfg.Encryption.Key == "" && !profile.IsProduction() && cfg.Secrets.Provider == "env" {
+		cfg.Encryption.Key = DevEncryptionKey
+	}
+	if err := profile.checkSecrets(cfg); err != nil {
+		return nil, err
+	}
+	// Keys from other providers are checked by ResolveEncryptionKeys
+	if cfg.Encryption.Key == "" {
+		return nil, fmt.Errorf("ENCRYPTION_KEY is required with SECRETS_PROVIDER=%s", cfg.Secrets.Provider)
+	}
+	if cfg.Secrets.Provider == "env" && len(cfg.Encryption.Key) != 32 {
+		return nil, fmt.Errorf("ENCRYPTION_KEY must be exactly 32 bytes, got %d", len(cfg.Encryption.Key))
+	}
+	cfg.Encryption.KeyID = getEnv("ENCRYPTION_KEY_ID", "v1")
+	if strings.ContainsAny(cfg.Encryption.KeyID, ":, ") {
+		return nil, fmt.Errorf("ENCRYPTION_KEY_ID must not contain ':', ',' or spaces")
+	}
+	cfg.Encryption.PreviousKeys = getSecretsEnv("ENCRYPTION_PREVIOUS_KEYS")
+	if _, ok := cfg.Encryption.PreviousKeys[cfg.Encryption.KeyID]; ok {
+		return nil, fmt.Errorf("ENCRYPTION_PREVIOUS_KEYS reuses the current ENCRYPTION_KEY_ID %q", cfg.Encryption.KeyID)
+	}
+	for id, key := range cfg.Encryption.PreviousKeys {
+		if cfg.Secrets.Provider == "env" && len(key) != 32 {
+			return nil, fmt.Errorf("ENCRYPTION_PREVIOUS_KEYS key %q must be exactly 32 bytes, got %d", id, len(key))
+		}
+	}
+
+	// Background worker configuration
+	cfg.Worker.LeagueSyncEnabled = getBoolEnv("ENABLE_LEAGUE_SYNC", true)
+	cfg.Worker.LeagueSyncInterval = getDurationEnv("LEAGUE_SYNC_INTERVAL", 30*time.Minute)
+	cfg.Worker.TransactionLimit = getIntEnv("LEAGUE_SYNC_TRANSACTION_LIMIT", 50)
+	cfg.Worker.LeagueSyncConcurrency = getIntEnv("LEAGUE_SYNC_CONCURRENCY", 3)
+	cfg.Worker.LeagueSyncCooldown = getDurationEnv("LEAGUE_SYNC_COOLDOWN", 5*time.Minute)
+	cfg.Worker.ESPNLeagueHourlyBudget = getIntEnv("ESPN_LEAGUE_HOURLY_BUDGET", 300)
+	cfg.Worker.PlayerNewsEnabled = getBoolEnv("ENABLE_PLAYER_NEWS_SYNC", true)
+	cfg.Worker.PlayerNewsInterval = getDurationEnv("PLAYER_NEWS_SYNC_INTERVAL", time.Hour)
+	cfg.Worker.PlayerNewsLimit = getIntEnv("PLAYER_NEWS_LIMIT", 5)
+	cfg.Worker.AnalyticsEnabled = getBoolEnv("ENABLE_ANALYTICS_PRECOMPUTE", true)
+	cfg.Worker.AnalyticsDay = getWeekdayEnv("ANALYTICS_PRECOMPUTE_DAY", time.Tuesday)
+	cfg.Worker.AnalyticsHour = getIntEnv("ANALYTICS_PRECOMPUTE_HOUR", 6)
+	cfg.Worker.AnalyticsLocation = getLocationEnv("ANALYTICS_PRECOMPUTE_TIMEZONE", "America/New_York")
+	cfg.Worker.PlayoffSimulations = getIntEnv("PLAYOFF_ODDS_SIMULATIONS", 10000)
+	cfg.Worker.PlayerMetadataEnabled = getBoolEnv("ENABLE_PLAYER_METADATA_REFRESH", true)
+	cfg.Worker.PlayerMetadataHour = getIntEnv("PLAYER_METADATA_REFRESH_HOUR", 4)
+	cfg.Worker.PlayerMetadataLocation = getLocationEnv("PLAYER_METADATA_REFRESH_TIMEZONE", "America/New_York")
+	cfg.Worker.TeamMetadataInterval = getDurationEnv("TEAM_METADATA_REFRESH_INTERVAL", 7*24*time.Hour)
+	cfg.Worker.InactivityEnabled = getBoolEnv("ENABLE_INACTIVE_ACCOUNT_ANONYMIZATION", false)
+	cfg.Worker.InactivityHour = getIntEnv("INACTIVE_ACCOUNT_JOB_HOUR", 3)
+	cfg.Worker.InactiveAfter = getDurationEnv("INACTIVE_ACCOUNT_AFTER", 2*365*24*time.Hour)
+	cfg.Worker.InactivityWarningPeriod = getDurationEnv("INACTIVE_ACCOUNT_WARNING_PERIOD", 30*24*time.Hour)
+	cfg.Worker.InactivityBatchSize = getIntEnv("INACTIVE_ACCOUNT_BATCH_SIZE", 500)
+	cfg.Worker.RetentionEnabled = getBoolEnv("ENABLE_DATA_RETENTION", false)
+	cfg.Worker.RetentionHour = getIntEnv("RETENTION_JOB_HOUR", 5)
+	cfg.Worker.RetentionBatchSize = getIntEnv("RETENTION_BATCH_SIZE", 5000)
+	cfg.Worker.RetentionRawProjectionSeasons = getIntEnv("RETENTION_RAW_PROJECTIONS_SEASONS", 2)
+	cfg.Worker.RetentionSyncDiffs = getDurationEnv("RETENTION_SYNC_DIFFS", 90*24*time.Hour)
+	cfg.Worker.RetentionAuditLog = getDurationEnv("RETENTION_AUDIT_LOG", 365*24*time.Hour)
+	if cfg.Worker.RetentionRawProjectionSeasons < 0 || cfg.Worker.RetentionSyncDiffs < 0 || cfg.Worker.RetentionAuditLog < 0 {
+		return nil, fmt.Errorf("data retention periods cannot be negative")
+	}
+	cfg.Worker.HeartbeatGrace = getDurationEnv("WORKER_HEARTBEAT_GRACE", 15*time.Minute)
+	cfg.Worker.HeartbeatReportInterval = getDurationEnv("WORKER_HEARTBEAT_REPORT_INTERVAL", time.Minute)
+	if cfg.Worker.HeartbeatGrace < 0 || cfg.Worker.HeartbeatReportInterval <= 0 {
+		return nil, fmt.Errorf("WORKER_HEARTBEAT_GRACE cannot be negative and WORKER_HEARTBEAT_REPORT_INTERVAL must be positive")
+	}
+
+	// Cache configuration
+	cfg.Cache.WarmOnStartup = getBoolEnv("CACHE_WARM_ON_STARTUP", false)
+	cfg.Cache.WarmTimeout = getDurationEnv("CACHE_WARM_TIMEOUT", 2*time.Minute)
+	cfg.Cache.ProjectionsTTL = getDurationEnv("PROJECTIONS_CACHE_TTL", 10*time.Minute)
+	cfg.Cache.ESPNEnabled = getBoolEnv("ESPN_CACHE_ENABLED", true)
+	cfg.Cache.ESPNLeagueTTL = getDurationEnv("ESPN_CACHE_LEAGUE_TTL", time.Hour)
+	cfg.Cache.ESPNRostersTTL = getDurationEnv("ESPN_CACHE_ROSTERS_TTL", 5*time.Minute)
+	cfg.Cache.ESPNFreeAgentsTTL = getDurationEnv("ESPN_CACHE_FREE_AGENTS_TTL", 5*time.Minute)
+
+	// Draft recommendation engine flags
+	cfg.Draft.EngineVersion = getEnv("RECOMMENDATION_ENGINE_VERSION", "v1")
+	cfg.Draft.ShadowEngineVersion = getEnv("RECOMMENDATION_SHADOW_VERSION", "")
+	cfg.Draft.ShadowPercent = getFloatEnv("RECOMMENDATION_SHADOW_PERCENT", 0)
+	cfg.Draft.RateLimit = getIntEnv("DRAFT_RATE_LIMIT", 120)
+	cfg.Draft.ResumeTokenTTL = getDurationEnv("DRAFT_RESUME_TOKEN_TTL", time.Hour)
+	if cfg.Draft.ResumeTokenTTL != 0 && (cfg.Draft.ResumeTokenTTL < cfg.JWT.AccessTokenExpiry || cfg.Draft.ResumeTokenTTL > maxDraftResumeTokenTTL) {
+		return nil, fmt.Errorf("DRAFT_RESUME_TOKEN_TTL must be between JWT_ACCESS_TOKEN_EXPIRY and %s, or 0 to disable", maxDraftResumeTokenTTL)
+	}
+
+	// Rate limits shared across instances through Redis
+	cfg.RateLimit.AuthLimit = getIntEnv("RATE_LIMIT_AUTH", 20)
+	cfg.RateLimit.AuthWindow = getDurationEnv("RATE_LIMIT_AUTH_WINDOW", time.Minute)
+	cfg.RateLimit.APILimit = getIntEnv("RATE_LIMIT_API", 300)
+	cfg.RateLimit.APIWindow = getDurationEnv("RATE_LIMIT_API_WINDOW", time.Minute)
+
+	// Cookie delivery of tokens for browser clients
+	cfg.AuthCookies.Enabled = getBoolEnv("AUTH_COOKIES_ENABLED", false)
+	cfg.AuthCookies.Domain = getEnv("AUTH_COOKIE_DOMAIN", "")
+	cfg.AuthCookies.Secure = getBoolEnv("AUTH_COOKIE_SECURE", cfg.App.Environment == "production")
+	cfg.AuthCookies.SameSite = strings.ToLower(getEnv("AUTH_COOKIE_SAMESITE", "lax"))
+	switch cfg.AuthCookies.SameSite {
+	case "lax", "strict":
+	case "none":
+		if !cfg.AuthCookies.Secure {
+			return nil, fmt.Errorf("AUTH_COOKIE_SAMESITE=none requires AUTH_COOKIE_SECURE")
+		}
+	default:
+		return nil, fmt.Errorf("invalid AUTH_COOKIE_SAMESITE %q", cfg.AuthCookies.SameSite)
+	}
+
+	// Bot protection on auth endpoints, on by default in production
+	cfg.BotProtection.CaptchaProvider = getEnv("CAPTCHA_PROVIDER", "turnstile")
+	cfg.BotProtection.CaptchaSecret = getEnv("CAPTCHA_SECRET_KEY", "")
+	cfg.BotProtection.BlockDisposableEmails = getBoolEnv("BLOCK_DISPOSABLE_EMAILS", cfg.App.Environment == "production")
+	cfg.BotProtection.DisposableDomains = getListEnv("DISPOSABLE_EMAIL_DOMAINS", nil)
+
+	// Maintenance mode configuration
+	cfg.Maintenance.Enabled = getBoolEnv("MAINTENANCE_MODE", false)
+	cfg.Maintenance.Message = getEnv("MAINTENANCE_MESSAGE", "We're performing scheduled maintenance and will be back shortly.")
+	cfg.Maintenance.DraftGracePeriod = getDurationEnv("MAINTENANCE_DRAFT_GRACE_PERIOD", 15*time.Minute)
+	cfg.Maintenance.RefreshInterval = getDurationEnv("MAINTENANCE_REFRESH_INTERVAL", 5*time.Second)
+
+	// Draft-day surge mode configuration
+	cfg.Surge.AutoActiveDrafts = getIntEnv("SURGE_AUTO_ACTIVE_DRAFTS", 50)
+	cfg.Surge.RefreshInterval = getDurationEnv("SURGE_REFRESH_INTERVAL", 30*time.Second)
+	cfg.Surge.CacheTTLMultiplier = getFloatEnv("SURGE_CACHE_TTL_MULTIPLIER", 3)
+	cfg.Surge.JobIntervalMultiplier = getIntEnv("SURGE_JOB_INTERVAL_MULTIPLIER", 4)
+	cfg.Surge.DraftRateMultiplier = getFloatEnv("SURGE_DRAFT_RATE_MULTIPLIER", 3)
+	cfg.Surge.MetricsSampleRate = getFloatEnv("METRICS_SAMPLE_RATE", 0.01)
+	cfg.Surge.SurgeMetricsSampleRate = getFloatEnv("SURGE_METRICS_SAMPLE_RATE", 0.2)
+
+	// Social sign-in
+	cfg.OAuth.GoogleClientID = getEnv("GOOGLE_CLIENT_ID", "")
+	cfg.OAuth.GoogleClientSecret = getEnv("GOOGLE_CLIENT_SECRET", "")
+	cfg.OAuth.GoogleRedirectURL = getEnv("GOOGLE_REDIRECT_URL", "http://localhost:8080/api/auth/google/callback")
+	cfg.OAuth.SuccessRedirectURL = getEnv("OAUTH_SUCCESS_REDIRECT_URL", "")
+
+	// Two-factor authentication
+	cfg.TwoFactor.Issuer = getEnv("TOTP_ISSUER", "NFL Analytics")
+
+	// Transactional email
+	cfg.Mail.SMTPHost = getEnv("SMTP_HOST", "")
+	cfg.Mail.SMTPPort = getIntEnv("SMTP_PORT", 587)
+	cfg.Mail.SMTPUsername = getEnv("SMTP_USERNAME", "")
+	cfg.Mail.SMTPPassword = getEnv("SMTP_PASSWORD", "")
+	cfg.Mail.From = getEnv("MAIL_FROM", "NFL Analytics <no-reply@localhost>")
+
+	// Sign-ins from new devices and networks
+	cfg.LoginAlerts.Enabled = getBoolEnv("LOGIN_ALERTS_ENABLED", true)
+	cfg.LoginAlerts.RequireVerification = getBoolEnv("LOGIN_NEW_DEVICE_VERIFICATION", false)
+	if cfg.LoginAlerts.RequireVerification && (!cfg.LoginAlerts.Enabled || (cfg.Mail.SMTPHost == "" && profile.IsProduction())) {
+		return nil, fmt.Errorf("LOGIN_NEW_DEVICE_VERIFICATION requires LOGIN_ALERTS_ENABLED and, in production, SMTP_HOST")
+	}
+
+	// Natural language assistant
+	cfg.Assistant.Provider = getEnv("ASSISTANT_LLM_PROVIDER", "")
+	cfg.Assistant.APIKey = getEnv("ASSISTANT_LLM_API_KEY", "")
+	cfg.Assistant.Model = getEnv("ASSISTANT_LLM_MODEL", "")
+	cfg.Assistant.BaseURL = getEnv("ASSISTANT_LLM_BASE_URL", "")
+	cfg.Assistant.Timeout = getDurationEnv("ASSISTANT_LLM_TIMEOUT", 30*time.Second)
+	cfg.Assistant.RateLimit = getIntEnv("ASSISTANT_RATE_LIMIT", 20)
+
+	// Voice assistants
+	cfg.Voice.Enabled = getBoolEnv("VOICE_ENABLED", false)
+	cfg.Voice.DeviceTTL = getDurationEnv("VOICE_DEVICE_TTL", 180*24*time.Hour)
+	cfg.Voice.AlexaSkillID = getEnv("VOICE_ALEXA_SKILL_ID", "")
+	cfg.Voice.AlexaVerifySignatures = getBoolEnv("VOICE_ALEXA_VERIFY_SIGNATURES", true)
+	cfg.Voice.GoogleWebhookSecret = getEnv("VOICE_GOOGLE_WEBHOOK_SECRET", "")
+	cfg.Voice.RedirectURIs = getListEnv("VOICE_REDIRECT_URIS", nil)
+	if cfg.Voice.Enabled && profile.IsProduction() {
+		switch {
+		case cfg.Voice.AlexaSkillID == "":
+			return nil, fmt.Errorf("VOICE_ENABLED requires VOICE_ALEXA_SKILL_ID in production")
+		case cfg.Voice.GoogleWebhookSecret == "":
+			return nil, fmt.Errorf("VOICE_ENABLED requires VOICE_GOOGLE_WEBHOOK_SECRET in production")
+		case !cfg.Voice.AlexaVerifySignatures:
+			return nil, fmt.Errorf("VOICE_ALEXA_VERIFY_SIGNATURES cannot be turned off in production")
+		}
+	}
+
+	// Cold storage of completed seasons
+	cfg.Archive.Enabled = getBoolEnv("ENABLE_SEASON_ARCHIVAL", false)
+	cfg.Archive.Hour = getIntEnv("ARCHIVE_JOB_HOUR", 6)
+	cfg.Archive.HotSeasons = getIntEnv("ARCHIVE_HOT_SEASONS", 2)
+	cfg.Archive.BatchSize = getIntEnv("ARCHIVE_BATCH_SIZE", 100)
+	cfg.Archive.RehydratedFor = getDurationEnv("ARCHIVE_REHYDRATED_FOR", 7*24*time.Hour)
+	cfg.Archive.Store = getEnv("ARCHIVE_STORE", "file")
+	cfg.Archive.Dir = getEnv("ARCHIVE_DIR", "./data/archive")
+	cfg.Archive.S3Endpoint = getEnv("ARCHIVE_S3_ENDPOINT", "")
+	cfg.Archive.S3Bucket = getEnv("ARCHIVE_S3_BUCKET", "")
+	cfg.Archive.S3Region = getEnv("ARCHIVE_S3_REGION", "us-east-1")
+	cfg.Archive.S3AccessKeyID = getEnv("ARCHIVE_S3_ACCESS_KEY_ID", "")
+	cfg.Archive.S3SecretAccessKey = getEnv("ARCHIVE_S3_SECRET_ACCESS_KEY", "")
+	switch cfg.Archive.Store {
+	case "file":
+	case "s3":
+		if cfg.Archive.S3Bucket == "" {
+			return nil, fmt.Errorf("ARCHIVE_STORE=s3 requires ARCHIVE_S3_BUCKET")
+		}
+	default:
+		return nil, fmt.Errorf("invalid ARCHIVE_STORE %q", cfg.Archive.Store)
+	}
+	if cfg.Archive.HotSeasons < 1 {
+		return nil, fmt.Errorf("ARCHIVE_HOT_SEASONS must be at least 1")
+	}
+
+	// Per-user quotas, reported to clients but not enforced
+	cfg.Quota.Plan = getEnv("QUOTA_PLAN", "free")
+	cfg.Quota.MaxLeagues = getIntEnv("QUOTA_MAX_LEAGUES", 10)
+	cfg.Quota.MaxDraftSessions = getIntEnv("QUOTA_MAX_DRAFT_SESSIONS", 25)
+	cfg.Quota.MaxWatchlist = getIntEnv("QUOTA_MAX_WATCHLIST", 50)
+
+	// Admin API configuration
+	cfg.Admin.APIKey = getEnv("ADMIN_API_KEY", "")
+
+	// Redis audit configuration
+	cfg.RedisAudit.Enabled = getBoolEnv("ENABLE_REDIS_AUDIT", true)
+	cfg.RedisAudit.Interval = getDurationEnv("REDIS_AUDIT_INTERVAL", time.Hour)
+	cfg.RedisAudit.DraftStateBudgetMB = getIntEnv("REDIS_BUDGET_DRAFT_STATE_MB", 64)
+	cfg.RedisAudit.CacheBudgetMB = getIntEnv("REDIS_BUDGET_CACHE_MB", 512)
+	cfg.RedisAudit.RateLimitBudgetMB = getIntEnv("REDIS_BUDGET_RATE_LIMIT_MB", 32)
+
+	// Product analytics configuration
+	cfg.Analytics.Sink = getEnv("ANALYTICS_SINK", "none")
+	cfg.Analytics.SegmentEndpoint = getEnv("ANALYTICS_SEGMENT_ENDPOINT", "https://api.segment.io")
+	cfg.Analytics.SegmentWriteKey = getEnv("ANALYTICS_SEGMENT_WRITE_KEY", "")
+	cfg.Analytics.KafkaRESTURL = getEnv("ANALYTICS_KAFKA_REST_URL", "")
+	cfg.Analytics.KafkaTopic = getEnv("ANALYTICS_KAFKA_TOPIC", "product-events")
+	cfg.Analytics.DefaultConsent = getBoolEnv("ANALYTICS_DEFAULT_CONSENT", false)
+	cfg.Analytics.QueueSize = getIntEnv("ANALYTICS_QUEUE_SIZE", 10000)
+	cfg.Analytics.BatchSize = getIntEnv("ANALYTICS_BATCH_SIZE", 100)
+	cfg.Analytics.FlushInterval = getDurationEnv("ANALYTICS_FLUSH_INTERVAL", 10*time.Second)
+
+	// Event bus configuration
+	cfg.EventBus.Transport = getEnv("EVENT_BUS_TRANSPORT", "none")
+	cfg.EventBus.Topics = getListEnv("EVENT_BUS_TOPICS", nil)
+	cfg.EventBus.QueueSize = getIntEnv("EVENT_BUS_QUEUE_SIZE", 10000)
+	cfg.EventBus.KafkaRESTURL = getEnv("EVENT_BUS_KAFKA_REST_URL", "")
+	cfg.EventBus.KafkaTopicPrefix = getEnv("EVENT_BUS_KAFKA_TOPIC_PREFIX", "nfl-analytics")
+	cfg.EventBus.NATSURL = getEnv("EVENT_BUS_NATS_URL", "nats://localhost:4222")
+	cfg.EventBus.NATSSubjectPrefix = getEnv("EVENT_BUS_NATS_SUBJECT_PREFIX", "nfl-analytics")
+
+	// Draft and live scoring stream configuration
+	cfg.Stream.BufferSize = getIntEnv("STREAM_BUFFER_SIZE", 100)
+	cfg.Stream.PollTimeout = getDurationEnv("STREAM_POLL_TIMEOUT", 25*time.Second)
+	cfg.Stream.HeartbeatInterval = getDurationEnv("STREAM_HEARTBEAT_INTERVAL", 15*time.Second)
+
+	// Projection pipeline configuration
+	cfg.Projections = LoadProjections()
+
 	return cfg, nil
 }
 
+// LoadProjections loads the projection pipeline configuration. It is separate
+// from Load so batch commands can run the pipeline without API secrets.
+func LoadProjections() ProjectionsConfig {
+	return ProjectionsConfig{
+		PipelineStages: getListEnv("PROJECTION_PIPELINE_STAGES", nil),
+		SourceWeights:  getWeightsEnv("PROJECTION_SOURCE_WEIGHTS"),
+		RestModifier:   getBoolEnv("ENABLE_REST_MODIFIER", false),
+		AlertThreshold: getFloatEnv("PROJECTION_ALERT_THRESHOLD", 1.5),
+		OddsAPIKey:     getEnv("ODDS_API_KEY", ""),
+		OddsBookmakers: getListEnv("ODDS_API_BOOKMAKERS", []string{"pinnacle", "betonlineag"}),
+	}
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -112,6 +872,24 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -119,4 +897,102 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 		}
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getWeekdayEnv parses a day name such as "tuesday"
+func getWeekdayEnv(key string, defaultValue time.Weekday) time.Weekday {
+	value := strings.TrimSpace(os.Getenv(key))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(value, day.String()) {
+			return day
+		}
+	}
+	return defaultValue
+}
+
+// getLocationEnv loads an IANA time zone, falling back to UTC if neither the
+// configured nor the default zone is available
+func getLocationEnv(key, defaultValue string) *time.Location {
+	if loc, err := time.LoadLocation(getEnv(key, defaultValue)); err == nil {
+		return loc
+	}
+	if loc, err := time.LoadLocation(defaultValue); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getWeightsEnv parses "name:weight" pairs, e.g. "fantasypros:1.0,espn:0.8".
+// Malformed pairs are skipped.
+func getWeightsEnv(key string) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, pair := range getListEnv(key, nil) {
+		name, weight, ok := strings.Cut(pair, ":")
+		if !ok {
+			continue
+		}
+		if w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64); err == nil {
+			weights[strings.TrimSpace(name)] = w
+		}
+	}
+	return weights
+}
+
+// getSecretsEnv parses "name:secret" pairs, e.g. "2024-09:s3cret,2024-06:old".
+// Only the first colon separates, so secrets may contain colons but not
+// commas. Pairs without a secret are skipped.
+func getSecretsEnv(key string) map[string]string {
+	secrets := make(map[string]string)
+	for _, pair := range getListEnv(key, nil) {
+		name, secret, ok := strings.Cut(pair, ":")
+		if name = strings.TrimSpace(name); !ok || name == "" || secret == "" {
+			continue
+		}
+		secrets[name] = secret
+	}
+	return secrets
+}
+
+// getPEMEnv reads a PEM block from key, where newlines may be written as
+// \n, or from the file named by key_FILE
+func getPEMEnv(key string) (string, error) {
+	if value := getEnv(key, ""); value != "" {
+		return strings.ReplaceAll(value, `\n`, "\n"), nil
+	}
+	path := getEnv(key+"_FILE", "")
+	if path == "" {
+		return "", nil
+	}
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s_FILE: %w", key, err)
+	}
+	return string(pem), nil
+}
+
+// parseJWTAlgorithm reads JWT_ALGORITHM: HS256, RS256 or EdDSA
+func parseJWTAlgorithm(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "hs256":
+		return "HS256", nil
+	case "rs256":
+		return "RS256", nil
+	case "eddsa", "ed25519":
+		return "EdDSA", nil
+	}
+	return "", fmt.Errorf("invalid JWT_ALGORITHM %q: use HS256, RS256 or EdDSA", value)
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Profile is the environment a deployment runs in, set by ENV. Production
+// refuses to start with development secrets; only development runs gin in
+// debug mode.
+type Profile string
+
+// Environment profiles
+const (
+	ProfileDevelopment Profile = "development"
+	ProfileStaging     Profile = "staging"
+	ProfileProduction  Profile = "production"
+)
+
+// DevEncryptionKey encrypts credentials in development when ENCRYPTION_KEY
+// is not set. Production refuses to start with it.
+const DevEncryptionKey = "dev-key-change-in-production-32b"
+
+// minProductionSecretLength is the shortest JWT secret production accepts
+const minProductionSecretLength = 32
+
+// placeholderSecrets are the sample values shipped in .env.example and
+// docker-compose.yml, which production refuses like the development key
+var placeholderSecrets = map[string]bool{
+	DevEncryptionKey:                                true,
+	"change-this-32-byte-key-for-prod!":             true,
+	"your_jwt_secret_change_me_to_something_secure": true,
+	"your_jwt_secret_change_me":                     true,
+}
+
+// ParseProfile reads ENV, accepting dev and prod as short names
+func ParseProfile(env string) (Profile, error) {
+	switch strings.ToLower(strings.TrimSpace(env)) {
+	case "", "dev", "development", "local":
+		return ProfileDevelopment, nil
+	case "staging", "stage":
+		return ProfileStaging, nil
+	case "prod", "production":
+		return ProfileProduction, nil
+	}
+	return "", fmt.Errorf("invalid ENV %q: use development, staging or production", env)
+}
+
+// IsProduction reports whether p is production
+func (p Profile) IsProduction() bool {
+	return p == ProfileProduction
+}
+
+// AllowsDebug reports whether gin's debug mode is on, which is only in
+// development
+func (p Profile) AllowsDebug() bool {
+	return p == ProfileDevelopment
+}
+
+// checkSecrets refuses production configurations that would run with
+// development or sample secrets
+func (p Profile) checkSecrets(cfg *Config) error {
+	if !p.IsProduction() {
+		return nil
+	}
+	if cfg.Encryption.Key == "" {
+		return fmt.Errorf("ENCRYPTION_KEY is required in production")
+	}
+	// Other providers' keys are references, checked once they are resolved
+	if cfg.Secrets.Provider == "env" {
+		if err := p.checkEncryptionKey(cfg.Encryption.Key); err != nil {
+			return err
+		}
+	}
+	// Asymmetric signing keys are checked when they are parsed
+	if cfg.JWT.Algorithm != "HS256" {
+		return nil
+	}
+	if placeholderSecrets[cfg.JWT.Secret] {
+		return fmt.Errorf("JWT_SECRET is a sample secret; set a secret one in production")
+	}
+	if len(cfg.JWT.Secret) < minProductionSecretLength {
+		return fmt.Errorf("JWT_SECRET must be at least %d characters in production", minProductionSecretLength)
+	}
+	return nil
+}
+
+// checkEncryptionKey refuses a development or sample encryption key in
+// production
+func (p Profile) checkEncryptionKey(key string) error {
+	if p.IsProduction() && placeholderSecrets[key] {
+		return fmt.Errorf("ENCRYPTION_KEY is a development or sample key; set a secret one in production")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nfl-analytics/backend/internal/secrets"
+)
+
+// provider creates the secrets provider SECRETS_PROVIDER names
+func (c SecretsConfig) provider() (secrets.Provider, error) {
+	return secrets.New(secrets.Config{
+		Provider: c.Provider,
+		KMS: secrets.KMSConfig{
+			Region:          c.AWSRegion,
+			Endpoint:        c.KMSEndpoint,
+			AccessKeyID:     c.AWSAccessKeyID,
+			SecretAccessKey: c.AWSSecretAccessKey,
+			SessionToken:    c.AWSSessionToken,
+		},
+		Vault: secrets.VaultConfig{
+			Addr:      c.VaultAddr,
+			Token:     c.VaultToken,
+			TokenFile: c.VaultTokenFile,
+			Namespace: c.VaultNamespace,
+		},
+	})
+}
+
+// ResolveEncryptionKeys resolves ENCRYPTION_KEY and ENCRYPTION_PREVIOUS_KEYS
+// through the secrets provider, returning the current key and the retired
+// keys by ID. Production refuses a current key that is a development or
+// sample key, wherever it was kept.
+func ResolveEncryptionKeys(ctx context.Context, cfg *Config) (string, map[string]string, error) {
+	provider, err := cfg.Secrets.provider()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to initialize secrets provider: %w", err)
+	}
+	key, previous, err := secrets.ResolveKeys(ctx, provider, cfg.Encryption.Key, cfg.Encryption.PreviousKeys)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := cfg.App.Profile.checkEncryptionKey(key); err != nil {
+		return "", nil, err
+	}
+	return key, previous, nil
+}
@@ -0,0 +1,772 @@
+package draft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nfl-analytics/backend/internal/integrations/llm"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// Draft command actions
+const (
+	CommandPick       = "pick"
+	CommandQueue      = "queue"
+	CommandQueueTop   = "queue_top"
+	CommandUnqueue    = "unqueue"
+	CommandClearQueue = "clear_queue"
+	CommandUndo       = "undo"
+	CommandRedo       = "redo"
+	CommandPause      = "pause"
+	CommandResume     = "resume"
+	// CommandBestAvailable only reads the recommendations for the pick on
+	// the clock
+	CommandBestAvailable = "best_available"
+)
+
+// Command statuses: done, or waiting for the user to confirm which player
+// they meant
+const (
+	CommandDone    = "done"
+	CommandConfirm = "confirm"
+)
+
+// How a command was understood
+const (
+	ParsedByGrammar = "grammar"
+	ParsedByLLM     = "llm"
+)
+
+// Orders for queue_top
+const (
+	OrderByADP        = "adp"
+	OrderByProjection = "projection"
+)
+
+const (
+	// maxQueueTop caps how many players one queue_top command adds
+	maxQueueTop = 10
+	// maxQueue caps the user's queue
+	maxQueue = 30
+	// maxCandidates caps the players offered to confirm an ambiguous name
+	maxCandidates = 5
+	// commandMaxTokens caps the model's reply when parsing a command
+	commandMaxTokens = 120
+	// bestAvailableCount is how many recommendations best_available returns
+	bestAvailableCount = 3
+	// bestAvailablePool is how many recommendations are searched for a
+	// position's best available
+	bestAvailablePool = 50
+)
+
+var (
+	// ErrUnknownCommand is returned when neither the grammar nor the model
+	// understands a command
+	ErrUnknownCommand = errors.New("command not understood")
+	// ErrCommandParserFailed is returned when the model could not be asked
+	ErrCommandParserFailed = errors.New("command parser failed")
+	// ErrPlayerNotFound is returned when no available player matches the
+	// name in a command
+	ErrPlayerNotFound = errors.New("no available player matches")
+	// ErrNoPlayerLookup is returned for commands that name players when the
+	// service has no player repository
+	ErrNoPlayerLookup = errors.New("player lookup is not configured")
+	// ErrQueueFull is returned when a command would queue more than
+	// maxQueue players
+	ErrQueueFull = errors.New("queue is full")
+)
+
+// Command is a draft command parsed from free text
+type Command struct {
+	Action string `json:"action"`
+	// Player is the player's name as typed, for pick, queue and unqueue
+	Player string `json:"player,omitempty"`
+	// Position, Count and OrderBy choose the players for queue_top.
+	// Position also narrows best_available.
+	Position string `json:"position,omitempty"`
+	Count    int    `json:"count,omitempty"`
+	OrderBy  string `json:"order_by,omitempty"`
+}
+
+// CommandParser interprets commands the grammar does not recognise
+type CommandParser interface {
+	ParseCommand(ctx context.Context, text string) (*Command, error)
+}
+
+// CommandResult is what a command did, or the players to choose from when
+// it named more than one
+type CommandResult struct {
+	Command  Command `json:"command"`
+	ParsedBy string  `json:"parsed_by"`
+	Status   string  `json:"status"`
+	// Candidates are the players an unconfirmed command could mean. Send the
+	// same text with the chosen player's ID as player_id to go ahead.
+	Candidates []Player `json:"candidates,omitempty"`
+	// Pick is the pick recorded by a pick or redo command
+	Pick *models.DraftPick `json:"pick,omitempty"`
+	// Recommendations answer a best_available command, best first
+	Recommendations []models.DraftRecommendation `json:"recommendations,omitempty"`
+	// Queue is the user's queue after the command
+	Queue []Player `json:"queue"`
+}
+
+// WithPlayers sets where commands look up players by name and ADP. Without
+// it, commands that name players are rejected with ErrNoPlayerLookup.
+func (s *Service) WithPlayers(players PlayerRepository, adp ADPRepository) *Service {
+	s.players = players
+	s.adp = adp
+	return s
+}
+
+// WithCommandParser sets the parser for commands the grammar does not
+// recognise. Without one, they are rejected with ErrUnknownCommand.
+func (s *Service) WithCommandParser(p CommandParser) *Service {
+	s.commandParser = p
+	return s
+}
+
+// RunCommand carries out a free-text command such as "take Bijan" or "queue
+// the next three WRs by ADP" with the existing pick, undo, pause and queue
+// operations. A command naming several players, or a pick the model parsed,
+// is not carried out until the user confirms the player.
+func (s *Service) RunCommand(ctx context.Context, sessionID, userID string, req *CommandRequest) (*CommandResult, error) {
+	session, err := s.AuthorizeSession(ctx, sessionID, userID, policy.ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, parsedBy, err := s.parseCommand(ctx, req.Text)
+	if err != nil {
+		return nil, err
+	}
+	result := &CommandResult{Command: *cmd, ParsedBy: parsedBy, Status: CommandDone}
+
+	switch cmd.Action {
+	case CommandUndo:
+		err = s.UndoPick(ctx, sessionID, userID)
+	case CommandRedo:
+		result.Pick, err = s.RedoPick(ctx, sessionID, userID)
+	case CommandPause:
+		err = s.PauseSession(ctx, sessionID, userID)
+	case CommandResume:
+		err = s.ResumeSession(ctx, sessionID, userID)
+	case CommandBestAvailable:
+		result.Recommendations, err = s.bestAvailable(ctx, sessionID, userID, cmd.Position)
+	default:
+		err = s.runQueueCommand(ctx, session, userID, req, result)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.getState(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state: %w", err)
+	}
+	result.Queue, err = s.queuedPlayers(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// bestAvailable returns the top recommendations for the pick on the clock,
+// only at position if one is given
+func (s *Service) bestAvailable(ctx context.Context, sessionID, userID, position string) ([]models.DraftRecommendation, error) {
+	count := bestAvailableCount
+	if position != "" {
+		count = bestAvailablePool
+	}
+	recommendations, err := s.Recommendations(ctx, sessionID, userID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	best := make([]models.DraftRecommendation, 0, bestAvailableCount)
+	for _, rec := range recommendations {
+		if position != "" && rec.Position != position {
+			continue
+		}
+		best = append(best, rec)
+		if len(best) == bestAvailableCount {
+			break
+		}
+	}
+	return best, nil
+}
+
+// parseCommand parses text with the grammar, falling back to the command
+// parser
+func (s *Service) parseCommand(ctx context.Context, text string) (*Command, string, error) {
+	if cmd := parseGrammar(text); cmd != nil {
+		return cmd, ParsedByGrammar, nil
+	}
+	if s.commandParser == nil {
+		return nil, "", ErrUnknownCommand
+	}
+
+	cmd, err := s.commandParser.ParseCommand(ctx, text)
+	if errors.Is(err, ErrUnknownCommand) {
+		return nil, "", err
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrCommandParserFailed, err)
+	}
+	return cmd, ParsedByLLM, nil
+}
+
+// runQueueCommand carries out the commands that pick or queue players
+func (s *Service) runQueueCommand(ctx context.Context, session *models.DraftSession, userID string, req *CommandRequest, result *CommandResult) error {
+	state, err := s.getState(ctx, session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get state: %w", err)
+	}
+
+	cmd := result.Command
+	if cmd.Action == CommandClearQueue {
+		state.Queue = nil
+		return s.saveState(ctx, session.ID, state)
+	}
+	if s.players == nil {
+		return ErrNoPlayerLookup
+	}
+
+	available, err := s.players.GetAvailablePlayers(ctx, state.AvailablePlayers)
+	if err != nil {
+		return fmt.Errorf("failed to get available players: %w", err)
+	}
+
+	if cmd.Action == CommandQueueTop {
+		top, err := s.topPlayers(ctx, session, available, state.Queue, cmd)
+		if err != nil {
+			return err
+		}
+		if len(state.Queue)+len(top) > maxQueue {
+			return ErrQueueFull
+		}
+		for _, p := range top {
+			state.Queue = append(state.Queue, p.ID)
+		}
+		return s.saveState(ctx, session.ID, state)
+	}
+
+	pool := available
+	if cmd.Action == CommandUnqueue {
+		pool = queuedOnly(available, state.Queue)
+	}
+	player, candidates := resolvePlayer(cmd.Player, pool, req.PlayerID)
+	if player == nil && len(candidates) == 0 {
+		return ErrPlayerNotFound
+	}
+	if player == nil || (cmd.Action == CommandPick && result.ParsedBy == ParsedByLLM && req.PlayerID == "") {
+		// Picks cannot be taken back quietly, so the model's reading of
+		// one is confirmed first
+		if player != nil {
+			candidates = []Player{*player}
+		}
+		result.Status = CommandConfirm
+		result.Candidates = candidates
+		return nil
+	}
+
+	switch cmd.Action {
+	case CommandPick:
+		result.Pick, err = s.RecordPick(ctx, session.ID, userID, &RecordPickRequest{
+			PlayerID:    player.ID,
+			PlayerName:  player.Name,
+			Position:    player.Position,
+			CurrentPick: req.CurrentPick,
+		})
+		return err
+	case CommandQueue:
+		if s.isPlayerAvailable(state.Queue, player.ID) {
+			return nil
+		}
+		if len(state.Queue) >= maxQueue {
+			return ErrQueueFull
+		}
+		state.Queue = append(state.Queue, player.ID)
+	case CommandUnqueue:
+		state.Queue = s.removePlayer(state.Queue, player.ID)
+	}
+	return s.saveState(ctx, session.ID, state)
+}
+
+// topPlayers returns the best available players not yet queued at the
+// command's position, by ADP or projection
+func (s *Service) topPlayers(ctx context.Context, session *models.DraftSession, available []Player, queue []string, cmd Command) ([]Player, error) {
+	var pool []Player
+	for _, p := range available {
+		if (cmd.Position == "" || p.Position == cmd.Position) && !s.isPlayerAvailable(queue, p.ID) {
+			pool = append(pool, p)
+		}
+	}
+	if len(pool) == 0 {
+		return nil, ErrPlayerNotFound
+	}
+
+	if cmd.OrderBy == OrderByProjection {
+		ids := make([]string, len(pool))
+		for i, p := range pool {
+			ids[i] = p.ID
+		}
+		projected, err := s.players.GetPlayerProjections(ctx, ids, session.Settings.ScoringType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get projections: %w", err)
+		}
+		for i := range pool {
+			pool[i].Projection = projected[pool[i].ID]
+		}
+		sort.SliceStable(pool, func(i, j int) bool { return pool[i].Projection > pool[j].Projection })
+	} else {
+		if s.adp != nil {
+			source := session.Settings.RankingsSource
+			if source == "" {
+				source = projections.RankingsSourceESPN
+			}
+			adpData, err := s.adp.GetADP(ctx, source, session.Settings.ScoringType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ADP data: %w", err)
+			}
+			for i, p := range pool {
+				adp, ok := adpData[p.ID]
+				if !ok {
+					adp, ok = adpData[projections.PlayerKey(p.Name, p.Position)]
+				}
+				if ok {
+					pool[i].ADP = adp
+				}
+			}
+		}
+		sortByADP(pool)
+	}
+
+	if len(pool) > cmd.Count {
+		pool = pool[:cmd.Count]
+	}
+	return pool, nil
+}
+
+// queuedPlayers looks up the players in the user's queue, in queue order.
+// Without a player repository only their IDs are known.
+func (s *Service) queuedPlayers(ctx context.Context, state *models.DraftState) ([]Player, error) {
+	queue := make([]Player, 0, len(state.Queue))
+	if len(state.Queue) == 0 {
+		return queue, nil
+	}
+	if s.players == nil {
+		for _, id := range state.Queue {
+			queue = append(queue, Player{ID: id})
+		}
+		return queue, nil
+	}
+
+	players, err := s.players.GetAvailablePlayers(ctx, state.Queue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queued players: %w", err)
+	}
+	byID := make(map[string]Player, len(players))
+	for _, p := range players {
+		byID[p.ID] = p
+	}
+	for _, id := range state.Queue {
+		if p, ok := byID[id]; ok {
+			queue = append(queue, p)
+		}
+	}
+	return queue, nil
+}
+
+// queuedOnly returns the players in queue
+func queuedOnly(players []Player, queue []string) []Player {
+	queued := make(map[string]bool, len(queue))
+	for _, id := range queue {
+		queued[id] = true
+	}
+	var result []Player
+	for _, p := range players {
+		if queued[p.ID] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// resolvePlayer finds the player a name means. A full name match, or a
+// single partial match, is taken as meant; otherwise the matches are
+// returned as candidates. confirmedID picks one of the candidates.
+func resolvePlayer(name string, pool []Player, confirmedID string) (*Player, []Player) {
+	matches := matchPlayerName(name, pool)
+	if confirmedID != "" {
+		for i := range matches {
+			if matches[i].ID == confirmedID {
+				return &matches[i], nil
+			}
+		}
+	}
+	if len(matches) == 1 && confirmedID == "" {
+		return &matches[0], nil
+	}
+
+	sortByADP(matches)
+	if len(matches) > maxCandidates {
+		matches = matches[:maxCandidates]
+	}
+	return nil, matches
+}
+
+// matchPlayerName returns the players whose full name is name or, failing
+// that, whose name has every word of it, each word matching a whole word
+// or the start of one
+func matchPlayerName(name string, pool []Player) []Player {
+	query := commandTokens(name)
+	if len(query) == 0 {
+		return nil
+	}
+	full := strings.Join(query, " ")
+
+	var exact, partial []Player
+	for _, p := range pool {
+		tokens := commandTokens(p.Name)
+		if strings.Join(tokens, " ") == full {
+			exact = append(exact, p)
+			continue
+		}
+		if containsTokens(tokens, query) {
+			partial = append(partial, p)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return partial
+}
+
+// containsTokens reports whether each query token is one of tokens or, from
+// three letters, the start of one
+func containsTokens(tokens, query []string) bool {
+	for _, q := range query {
+		found := false
+		for _, t := range tokens {
+			if t == q || (len(q) >= 3 && strings.HasPrefix(t, q)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sortByADP orders players by ADP, players without one last
+func sortByADP(players []Player) {
+	sort.SliceStable(players, func(i, j int) bool {
+		a, b := players[i].ADP, players[j].ADP
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+}
+
+// commandTokens lowercases text and splits it into words, dropping
+// punctuation, apostrophes and name suffixes
+func commandTokens(text string) []string {
+	text = strings.ToLower(strings.ReplaceAll(text, "'", ""))
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '/')
+	})
+	tokens := words[:0]
+	for _, w := range words {
+		switch w {
+		case "jr", "sr", "ii", "iii", "iv":
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+var (
+	pickVerbs    = map[string]bool{"take": true, "draft": true, "pick": true, "select": true, "grab": true}
+	queueVerbs   = map[string]bool{"queue": true, "enqueue": true}
+	unqueueVerbs = map[string]bool{"unqueue": true, "dequeue": true, "remove": true}
+	// fillerWords are dropped from the start and end of commands
+	fillerWords = map[string]bool{"please": true, "now": true, "lets": true, "ok": true}
+)
+
+// commandPositions map words to the position they name
+var commandPositions = map[string]string{
+	"qb": "QB", "qbs": "QB", "quarterback": "QB", "quarterbacks": "QB",
+	"rb": "RB", "rbs": "RB", "back": "RB", "backs": "RB",
+	"wr": "WR", "wrs": "WR", "receiver": "WR", "receivers": "WR",
+	"te": "TE", "tes": "TE", "end": "TE", "ends": "TE",
+	"k": "K", "ks": "K", "kicker": "K", "kickers": "K",
+	"dst": "DST", "dsts": "DST", "d/st": "DST", "defense": "DST", "defenses": "DST",
+}
+
+// numberWords are the counts commands spell out
+var numberWords = map[string]int{
+	"a": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+// parseGrammar parses the commands the draft room understands without the
+// model, or returns nil:
+//
+//	take|draft|pick|select|grab <player>
+//	queue [up] <player>, add <player> to [the|my] queue
+//	queue [up] [the] next|top|best [<n>] <position>s [by adp|projection]
+//	unqueue|dequeue|remove <player> [from [the|my] queue]
+//	clear|empty [the|my] queue
+//	undo, redo, pause, resume
+//	... best available [<position>] ..., who should i take|draft|pick
+func parseGrammar(text string) *Command {
+	tokens := commandTokens(text)
+	for len(tokens) > 0 && fillerWords[tokens[0]] {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 0 && fillerWords[tokens[len(tokens)-1]] {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	if cmd := parseBestAvailable(tokens); cmd != nil {
+		return cmd
+	}
+
+	verb, rest := tokens[0], tokens[1:]
+	switch {
+	case verb == "undo" && len(rest) <= 3:
+		return &Command{Action: CommandUndo}
+	case verb == "redo" && len(rest) <= 3:
+		return &Command{Action: CommandRedo}
+	case verb == "pause" && len(rest) <= 2:
+		return &Command{Action: CommandPause}
+	case (verb == "resume" || verb == "unpause") && len(rest) <= 2:
+		return &Command{Action: CommandResume}
+	case (verb == "clear" || verb == "empty") && len(rest) > 0 && rest[len(rest)-1] == "queue" && len(rest) <= 2:
+		return &Command{Action: CommandClearQueue}
+	case pickVerbs[verb]:
+		return playerCommand(CommandPick, rest)
+	case queueVerbs[verb]:
+		if len(rest) > 0 && rest[0] == "up" {
+			rest = rest[1:]
+		}
+		if cmd := parseQueueTop(rest); cmd != nil {
+			return cmd
+		}
+		return playerCommand(CommandQueue, rest)
+	case verb == "add":
+		if name, ok := trimQueueSuffix(rest, "to"); ok {
+			return playerCommand(CommandQueue, name)
+		}
+	case unqueueVerbs[verb]:
+		if name, ok := trimQueueSuffix(rest, "from"); ok {
+			rest = name
+		}
+		return playerCommand(CommandUnqueue, rest)
+	}
+	return nil
+}
+
+// playerCommand is action on the player named by tokens
+func playerCommand(action string, tokens []string) *Command {
+	if len(tokens) > 0 && tokens[0] == "the" {
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	return &Command{Action: action, Player: strings.Join(tokens, " ")}
+}
+
+// parseBestAvailable parses questions such as "who's the best available
+// RB?" or "who should I take?", or returns nil. Commands that queue or pick
+// the best available are left to the other rules.
+func parseBestAvailable(tokens []string) *Command {
+	if queueVerbs[tokens[0]] || pickVerbs[tokens[0]] {
+		return nil
+	}
+	if len(tokens) >= 4 && tokens[0] == "who" && tokens[1] == "should" && tokens[2] == "i" && pickVerbs[tokens[3]] {
+		return &Command{Action: CommandBestAvailable}
+	}
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i] != "best" || tokens[i+1] != "available" {
+			continue
+		}
+		cmd := &Command{Action: CommandBestAvailable}
+		rest := tokens[i+2:]
+		if len(rest) > 1 && (rest[0] == "running" || rest[0] == "wide" || rest[0] == "tight") {
+			rest = rest[1:]
+		}
+		if len(rest) > 0 {
+			cmd.Position = commandPositions[rest[0]]
+		}
+		return cmd
+	}
+	return nil
+}
+
+// trimQueueSuffix removes a trailing "<preposition> [the|my] queue"
+func trimQueueSuffix(tokens []string, preposition string) ([]string, bool) {
+	n := len(tokens)
+	if n < 2 || tokens[n-1] != "queue" {
+		return tokens, false
+	}
+	n--
+	if tokens[n-1] == "the" || tokens[n-1] == "my" {
+		n--
+	}
+	if n < 1 || tokens[n-1] != preposition {
+		return tokens, false
+	}
+	return tokens[:n-1], true
+}
+
+// parseQueueTop parses "[the] next|top|best [<n>] <position>s [by
+// adp|projection]", or returns nil. One of next, top, best or a count is
+// required so a name such as "Chase" is never read as a position.
+func parseQueueTop(tokens []string) *Command {
+	cmd := &Command{Action: CommandQueueTop, Count: 1, OrderBy: OrderByADP}
+	i := 0
+	if i < len(tokens) && tokens[i] == "the" {
+		i++
+	}
+	ranked := false
+	if i < len(tokens) && (tokens[i] == "next" || tokens[i] == "top" || tokens[i] == "best") {
+		ranked = true
+		i++
+	}
+	if i < len(tokens) {
+		if n, ok := numberWords[tokens[i]]; ok {
+			cmd.Count, ranked = n, true
+			i++
+		} else if n, err := strconv.Atoi(tokens[i]); err == nil && n > 0 {
+			cmd.Count, ranked = n, true
+			i++
+		}
+	}
+	if !ranked || i >= len(tokens) {
+		return nil
+	}
+
+	// "running backs", "wide receivers", "tight ends"
+	if i+1 < len(tokens) && (tokens[i] == "running" || tokens[i] == "wide" || tokens[i] == "tight") {
+		i++
+	}
+	position, ok := commandPositions[tokens[i]]
+	if !ok {
+		return nil
+	}
+	cmd.Position = position
+	i++
+
+	if i < len(tokens) {
+		if tokens[i] != "by" || i+2 != len(tokens) {
+			return nil
+		}
+		switch tokens[i+1] {
+		case "adp":
+		case "projection", "projections", "projected", "points":
+			cmd.OrderBy = OrderByProjection
+		default:
+			return nil
+		}
+	}
+	cmd.Count = min(cmd.Count, maxQueueTop)
+	return cmd
+}
+
+// validate checks a command from the model before it is carried out
+func (c *Command) validate() error {
+	switch c.Action {
+	case CommandPick, CommandQueue, CommandUnqueue:
+		if strings.TrimSpace(c.Player) == "" {
+			return ErrUnknownCommand
+		}
+	case CommandQueueTop, CommandBestAvailable:
+		c.Position = strings.ToUpper(c.Position)
+		if c.Position == "D/ST" {
+			c.Position = "DST"
+		}
+		if c.Position != "" && commandPositions[strings.ToLower(c.Position)] == "" {
+			return ErrUnknownCommand
+		}
+		if c.Action == CommandBestAvailable {
+			c.Count, c.OrderBy = 0, ""
+			break
+		}
+		if c.OrderBy != OrderByProjection {
+			c.OrderBy = OrderByADP
+		}
+		c.Count = max(1, min(c.Count, maxQueueTop))
+	case CommandClearQueue, CommandUndo, CommandRedo, CommandPause, CommandResume:
+	default:
+		return ErrUnknownCommand
+	}
+	return nil
+}
+
+// commandSystemPrompt asks the model for a command as JSON
+const commandSystemPrompt = `You read commands typed into a fantasy football draft room and reply with only a JSON object, no other text:
+{"action": "...", "player": "...", "position": "...", "count": 0, "order_by": "..."}
+action is one of:
+- "pick": draft the named player now
+- "queue": add the named player to the user's queue
+- "queue_top": queue the best available players at a position; set position (QB, RB, WR, TE, K or DST), count and order_by ("adp" or "projection")
+- "unqueue": remove the named player from the queue
+- "best_available": the user asks who to take or who is the best player left; set position if they name one
+- "clear_queue", "undo", "redo", "pause", "resume"
+- "unknown": the text is not one of these commands
+player is the player's name as the user wrote it. Leave out fields the action does not use.`
+
+// LLMCommandParser reads commands the grammar does not recognise with a
+// language model
+type LLMCommandParser struct {
+	client llm.Client
+}
+
+// NewLLMCommandParser creates a command parser that asks client
+func NewLLMCommandParser(client llm.Client) *LLMCommandParser {
+	return &LLMCommandParser{client: client}
+}
+
+// ParseCommand asks the model what text means. Returns ErrUnknownCommand
+// when it is not a draft command.
+func (p *LLMCommandParser) ParseCommand(ctx context.Context, text string) (*Command, error) {
+	resp, err := p.client.Complete(ctx, llm.Request{
+		System:    commandSystemPrompt,
+		Prompt:    text,
+		MaxTokens: commandMaxTokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse command: %w", err)
+	}
+
+	// Models sometimes wrap the object in prose or a code fence
+	reply := resp.Text
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return nil, ErrUnknownCommand
+	}
+	var cmd Command
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &cmd); err != nil {
+		return nil, ErrUnknownCommand
+	}
+	if err := cmd.validate(); err != nil {
+		return nil, err
+	}
+	return &cmd, nil
+}
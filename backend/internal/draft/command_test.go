@@ -0,0 +1,125 @@
+package draft
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/integrations/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGrammar(t *testing.T) {
+	tests := []struct {
+		text string
+		want *Command
+	}{
+		{"take Bijan", &Command{Action: CommandPick, Player: "bijan"}},
+		{"Draft Ja'Marr Chase please", &Command{Action: CommandPick, Player: "jamarr chase"}},
+		{"grab the Ravens D/ST", &Command{Action: CommandPick, Player: "ravens d/st"}},
+		{"queue up Puka Nacua", &Command{Action: CommandQueue, Player: "puka nacua"}},
+		{"add Breece Hall to my queue", &Command{Action: CommandQueue, Player: "breece hall"}},
+		{"queue the next three WRs by ADP", &Command{Action: CommandQueueTop, Position: "WR", Count: 3, OrderBy: OrderByADP}},
+		{"queue top 2 running backs by projection", &Command{Action: CommandQueueTop, Position: "RB", Count: 2, OrderBy: OrderByProjection}},
+		{"queue the best TE", &Command{Action: CommandQueueTop, Position: "TE", Count: 1, OrderBy: OrderByADP}},
+		{"queue next 40 QBs", &Command{Action: CommandQueueTop, Position: "QB", Count: maxQueueTop, OrderBy: OrderByADP}},
+		{"remove Kyren Williams from the queue", &Command{Action: CommandUnqueue, Player: "kyren williams"}},
+		{"clear my queue", &Command{Action: CommandClearQueue}},
+		{"undo that", &Command{Action: CommandUndo}},
+		{"Redo", &Command{Action: CommandRedo}},
+		{"pause the draft", &Command{Action: CommandPause}},
+		{"unpause", &Command{Action: CommandResume}},
+		{"who's the best available RB?", &Command{Action: CommandBestAvailable, Position: "RB"}},
+		{"best available wide receiver", &Command{Action: CommandBestAvailable, Position: "WR"}},
+		{"who is the best available player", &Command{Action: CommandBestAvailable}},
+		{"who should I take here?", &Command{Action: CommandBestAvailable}},
+		{"queue the best available", &Command{Action: CommandQueue, Player: "best available"}},
+		// Not commands the grammar knows
+		{"who won the game?", nil},
+		{"take", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseGrammar(tt.text))
+		})
+	}
+}
+
+func TestParseGrammar_QueueNameNotPosition(t *testing.T) {
+	// Without next, top, best or a count the rest is a name
+	cmd := parseGrammar("queue Chase Brown")
+	require.NotNil(t, cmd)
+	assert.Equal(t, CommandQueue, cmd.Action)
+	assert.Equal(t, "chase brown", cmd.Player)
+}
+
+func TestResolvePlayer(t *testing.T) {
+	pool := []Player{
+		{ID: "1", Name: "Bijan Robinson", Position: "RB", ADP: 2},
+		{ID: "2", Name: "Brian Robinson Jr.", Position: "RB", ADP: 90},
+		{ID: "3", Name: "DJ Moore", Position: "WR", ADP: 40},
+		{ID: "4", Name: "Rondale Moore", Position: "WR"},
+		{ID: "5", Name: "Elijah Moore", Position: "WR", ADP: 180},
+	}
+
+	player, candidates := resolvePlayer("bijan", pool, "")
+	require.NotNil(t, player)
+	assert.Equal(t, "1", player.ID)
+	assert.Empty(t, candidates)
+
+	// A full name wins over names that merely contain it
+	player, _ = resolvePlayer("brian robinson", pool, "")
+	require.NotNil(t, player)
+	assert.Equal(t, "2", player.ID)
+
+	// Ambiguous names are offered best ADP first, unranked players last
+	player, candidates = resolvePlayer("moore", pool, "")
+	assert.Nil(t, player)
+	require.Len(t, candidates, 3)
+	assert.Equal(t, []string{"3", "5", "4"}, []string{candidates[0].ID, candidates[1].ID, candidates[2].ID})
+
+	// Confirming one of the candidates resolves it
+	player, _ = resolvePlayer("moore", pool, "5")
+	require.NotNil(t, player)
+	assert.Equal(t, "Elijah Moore", player.Name)
+
+	// A confirmed ID the name does not match is not taken
+	player, candidates = resolvePlayer("moore", pool, "1")
+	assert.Nil(t, player)
+	assert.Len(t, candidates, 3)
+
+	player, candidates = resolvePlayer("justin jefferson", pool, "")
+	assert.Nil(t, player)
+	assert.Empty(t, candidates)
+}
+
+type stubLLM struct {
+	text string
+}
+
+func (s *stubLLM) Complete(ctx context.Context, req llm.Request) (*llm.Response, error) {
+	return &llm.Response{Text: s.text, Model: "stub"}, nil
+}
+
+func TestLLMCommandParser(t *testing.T) {
+	client := &stubLLM{}
+	parser := NewLLMCommandParser(client)
+
+	client.text = "```json\n{\"action\": \"queue_top\", \"position\": \"wr\", \"count\": 25}\n```"
+	cmd, err := parser.ParseCommand(context.Background(), "line up some receivers")
+	require.NoError(t, err)
+	assert.Equal(t, &Command{Action: CommandQueueTop, Position: "WR", Count: maxQueueTop, OrderBy: OrderByADP}, cmd)
+
+	client.text = `{"action": "pick", "player": "CMC"}`
+	cmd, err = parser.ParseCommand(context.Background(), "gimme CMC")
+	require.NoError(t, err)
+	assert.Equal(t, "CMC", cmd.Player)
+
+	for _, reply := range []string{`{"action": "unknown"}`, `{"action": "pick"}`, "I can't help with that"} {
+		client.text = reply
+		_, err = parser.ParseCommand(context.Background(), "what's the weather")
+		assert.ErrorIs(t, err, ErrUnknownCommand, reply)
+	}
+}
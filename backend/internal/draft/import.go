@@ -0,0 +1,276 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// Import errors
+var (
+	ErrNoDraftResults   = errors.New("the league has no draft results yet")
+	ErrUnknownUserTeam  = errors.New("could not tell which team is yours - set user_position")
+	ErrUnsupportedDraft = errors.New("draft cannot be imported")
+)
+
+// ImportSessionRequest represents a request to import a league's ESPN draft
+// as a completed draft session
+type ImportSessionRequest struct {
+	LeagueID string `json:"league_id" binding:"required,uuid"`
+	// Name defaults to the ESPN league's name followed by "draft"
+	Name string `json:"name"`
+	// UserPosition is the user's draft slot. When unset it is taken from
+	// the team the user's ESPN account owns.
+	UserPosition int `json:"user_position" binding:"omitempty,min=1"`
+}
+
+// ESPNDraft identifies the ESPN draft to import: the league's ESPN ID, a
+// client able to read it, and the user's SWID for finding their team
+type ESPNDraft struct {
+	Client   espn.Client
+	LeagueID string
+	SWID     string
+}
+
+// ImportSession pulls an ESPN league's draft results and saves them as a
+// completed session, so drafts run on ESPN can be analyzed like drafts run
+// here. Pick positions come from the league's current rosters and are blank
+// for players no longer rostered.
+func (s *Service) ImportSession(ctx context.Context, userID string, req *ImportSessionRequest, src ESPNDraft) (*models.DraftSession, error) {
+	info, err := src.Client.GetLeagueInfo(ctx, src.LeagueID)
+	if err != nil {
+		return nil, err
+	}
+	results, err := src.Client.GetDraftResults(ctx, src.LeagueID)
+	if err != nil {
+		return nil, err
+	}
+	rosters, err := src.Client.GetRosters(ctx, src.LeagueID)
+	if err != nil {
+		log.Printf("Failed to get rosters for ESPN league %s, importing picks without positions: %v", src.LeagueID, err)
+	}
+
+	session, picks, err := importedSession(userID, req, src.SWID, info, results, rosters, src.Client.DetectScoringFormat(info.Settings))
+	if err != nil {
+		return nil, err
+	}
+
+	// The session is created empty and filled with its picks the same way a
+	// turn records several at once
+	completedAt, currentPick := session.CompletedAt, session.CurrentPick
+	session.Status, session.CompletedAt, session.CurrentPick = "active", nil, 0
+	if err := s.repo.CreateSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	session.Status, session.CompletedAt, session.CurrentPick = "completed", completedAt, currentPick
+	if err := s.repo.RecordPicks(ctx, session, 0, picks); err != nil {
+		if delErr := s.repo.DeleteSession(ctx, session.ID); delErr != nil {
+			log.Printf("Failed to clean up imported draft session %s: %v", session.ID, delErr)
+		}
+		return nil, fmt.Errorf("failed to record imported picks: %w", err)
+	}
+
+	state := &models.DraftState{
+		SessionID:        session.ID,
+		Picks:            make([]models.DraftPick, 0, len(picks)),
+		AvailablePlayers: []string{},
+		TeamRosters:      make(map[int][]string),
+		UndoStack:        []models.DraftEvent{},
+		RedoStack:        []models.DraftEvent{},
+		LastAction:       time.Now(),
+	}
+	for i := 1; i <= session.TeamCount; i++ {
+		state.TeamRosters[i] = []string{}
+	}
+	for _, pick := range picks {
+		state.Picks = append(state.Picks, *pick)
+		state.TeamRosters[pick.TeamNumber] = append(state.TeamRosters[pick.TeamNumber], pick.PlayerID)
+	}
+	if err := s.saveState(ctx, session.ID, state); err != nil {
+		// The picks are saved; the state only caches them
+		log.Printf("Failed to save state for imported draft session %s: %v", session.ID, err)
+	} else {
+		session.State = state
+	}
+
+	return session, nil
+}
+
+// importedSession builds a completed session and its picks from an ESPN
+// league and draft results. Teams are numbered by draft slot, from the
+// league's pick order or, failing that, the order they picked in round one.
+func importedSession(userID string, req *ImportSessionRequest, swid string, info *espn.LeagueInfo, results []espn.DraftPick, rosters []espn.Roster, scoringType string) (*models.DraftSession, []*models.DraftPick, error) {
+	if len(results) == 0 {
+		return nil, nil, ErrNoDraftResults
+	}
+
+	results = append([]espn.DraftPick(nil), results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].OverallPick != results[j].OverallPick {
+			return results[i].OverallPick < results[j].OverallPick
+		}
+		if results[i].Round != results[j].Round {
+			return results[i].Round < results[j].Round
+		}
+		return results[i].Pick < results[j].Pick
+	})
+
+	slots := draftSlots(info, results)
+	teamCount := max(len(info.Teams), len(slots))
+	if teamCount < 4 || teamCount > 20 {
+		return nil, nil, fmt.Errorf("%w: %d teams, sessions support 4 to 20", ErrUnsupportedDraft, teamCount)
+	}
+
+	roundCount := 0
+	for _, r := range results {
+		roundCount = max(roundCount, r.Round)
+	}
+	if roundCount < 1 || roundCount > 30 {
+		return nil, nil, fmt.Errorf("%w: %d rounds, sessions support 1 to 30", ErrUnsupportedDraft, roundCount)
+	}
+
+	userPosition := req.UserPosition
+	if userPosition == 0 {
+		userPosition = slots[userTeamID(info, swid)]
+	}
+	if userPosition == 0 {
+		return nil, nil, ErrUnknownUserTeam
+	}
+	if userPosition > teamCount {
+		return nil, nil, fmt.Errorf("%w: user position cannot be greater than team count", ErrUnsupportedDraft)
+	}
+
+	draftType := "snake"
+	if strings.EqualFold(info.Settings.DraftSettings.Type, "AUCTION") {
+		draftType = "auction"
+	}
+	name := req.Name
+	if name == "" {
+		name = info.Name + " draft"
+	}
+
+	roster := info.Settings.RosterSettings
+	now := time.Now()
+	session := &models.DraftSession{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		LeagueID:     req.LeagueID,
+		Name:         name,
+		DraftType:    draftType,
+		TeamCount:    teamCount,
+		RoundCount:   roundCount,
+		UserPosition: userPosition,
+		CurrentPick:  len(results),
+		Status:       "completed",
+		Settings: models.DraftSettings{
+			ScoringType: scoringType,
+			RosterSlots: models.RosterSlots{
+				QB:    roster.QB,
+				RB:    roster.RB,
+				WR:    roster.WR,
+				TE:    roster.TE,
+				FLEX:  roster.FLEX,
+				DST:   roster.DST,
+				K:     roster.K,
+				BENCH: roster.BENCH,
+			},
+			TimerSeconds: info.Settings.DraftSettings.TimePerPick,
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	positions := make(map[string]string)
+	for _, r := range rosters {
+		for _, p := range r.Players {
+			positions[p.PlayerID] = p.Position
+		}
+	}
+
+	var startedAt, completedAt time.Time
+	picks := make([]*models.DraftPick, 0, len(results))
+	for i, r := range results {
+		pickedAt := r.Timestamp
+		if pickedAt.IsZero() {
+			pickedAt = now
+		} else {
+			if startedAt.IsZero() || pickedAt.Before(startedAt) {
+				startedAt = pickedAt
+			}
+			if pickedAt.After(completedAt) {
+				completedAt = pickedAt
+			}
+		}
+		roundPick := r.Pick
+		if roundPick == 0 {
+			roundPick = i%teamCount + 1
+		}
+		if r.Keeper {
+			session.Settings.KeeperPlayers = append(session.Settings.KeeperPlayers, r.PlayerID)
+		}
+
+		picks = append(picks, &models.DraftPick{
+			ID:         uuid.New().String(),
+			SessionID:  session.ID,
+			PickNumber: i + 1,
+			Round:      r.Round,
+			RoundPick:  roundPick,
+			TeamNumber: slots[r.TeamID],
+			PlayerID:   r.PlayerID,
+			PlayerName: r.PlayerName,
+			Position:   positions[r.PlayerID],
+			IsKeeper:   r.Keeper,
+			PickedAt:   pickedAt,
+		})
+	}
+
+	if completedAt.IsZero() {
+		completedAt = now
+	}
+	session.CompletedAt = &completedAt
+	if !startedAt.IsZero() {
+		session.StartedAt = &startedAt
+	}
+
+	return session, picks, nil
+}
+
+// draftSlots maps ESPN team IDs to 1-based draft slots. Teams missing from
+// the league's pick order take the next slots in the order they first
+// picked.
+func draftSlots(info *espn.LeagueInfo, results []espn.DraftPick) map[int]int {
+	slots := make(map[int]int)
+	add := func(teamID int) {
+		if _, ok := slots[teamID]; !ok {
+			slots[teamID] = len(slots) + 1
+		}
+	}
+	for _, teamID := range info.Settings.DraftSettings.PickOrder {
+		add(teamID)
+	}
+	for _, r := range results {
+		add(r.TeamID)
+	}
+	return slots
+}
+
+// userTeamID finds the ESPN team the SWID owns, or 0
+func userTeamID(info *espn.LeagueInfo, swid string) int {
+	if swid == "" {
+		return 0
+	}
+	for _, team := range info.Teams {
+		if strings.EqualFold(strings.Trim(team.Owner.ID, "{}"), strings.Trim(swid, "{}")) {
+			return team.ID
+		}
+	}
+	return 0
+}
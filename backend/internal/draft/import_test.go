@@ -0,0 +1,78 @@
+package draft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportedSession(t *testing.T) {
+	info := &espn.LeagueInfo{
+		Name: "Dynasty Bros",
+		Teams: []espn.Team{
+			{ID: 1}, {ID: 2}, {ID: 3, Owner: espn.TeamOwner{ID: "{ABC-123}"}}, {ID: 4},
+		},
+	}
+	info.Settings.DraftSettings.Type = "SNAKE"
+	info.Settings.RosterSettings.RB = 2
+
+	start := time.Date(2025, 8, 30, 19, 0, 0, 0, time.UTC)
+	results := []espn.DraftPick{
+		// Out of order, as ESPN does not promise an order
+		{Round: 2, Pick: 1, OverallPick: 5, TeamID: 2, PlayerID: "5", PlayerName: "Fifth", Timestamp: start.Add(5 * time.Minute)},
+		{Round: 1, Pick: 1, OverallPick: 1, TeamID: 4, PlayerID: "1", PlayerName: "First", Timestamp: start, Keeper: true},
+		{Round: 1, Pick: 2, OverallPick: 2, TeamID: 3, PlayerID: "2", PlayerName: "Second", Timestamp: start.Add(time.Minute)},
+		{Round: 1, Pick: 3, OverallPick: 3, TeamID: 1, PlayerID: "3", PlayerName: "Third", Timestamp: start.Add(2 * time.Minute)},
+		{Round: 1, Pick: 4, OverallPick: 4, TeamID: 2, PlayerID: "4", PlayerName: "Fourth", Timestamp: start.Add(3 * time.Minute)},
+	}
+	rosters := []espn.Roster{{TeamID: 3, Players: []espn.RosterPlayer{{PlayerID: "2", Position: "WR"}}}}
+
+	session, picks, err := importedSession("user-1", &ImportSessionRequest{LeagueID: "league-1"}, "abc-123", info, results, rosters, "PPR")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Dynasty Bros draft", session.Name)
+	assert.Equal(t, "snake", session.DraftType)
+	assert.Equal(t, "completed", session.Status)
+	assert.Equal(t, 4, session.TeamCount)
+	assert.Equal(t, 2, session.RoundCount)
+	assert.Equal(t, 5, session.CurrentPick)
+	assert.Equal(t, "PPR", session.Settings.ScoringType)
+	assert.Equal(t, 2, session.Settings.RosterSlots.RB)
+	assert.Equal(t, []string{"1"}, session.Settings.KeeperPlayers)
+	assert.Equal(t, start, *session.StartedAt)
+	assert.Equal(t, start.Add(5*time.Minute), *session.CompletedAt)
+
+	// Team 3 owns the SWID and picked second in round one
+	assert.Equal(t, 2, session.UserPosition)
+
+	require.Len(t, picks, 5)
+	assert.Equal(t, "First", picks[0].PlayerName)
+	assert.Equal(t, 1, picks[0].TeamNumber)
+	assert.True(t, picks[0].IsKeeper)
+	assert.Equal(t, "WR", picks[1].Position)
+	assert.Equal(t, "", picks[2].Position)
+	assert.Equal(t, 5, picks[4].PickNumber)
+	assert.Equal(t, 4, picks[4].TeamNumber)
+	assert.Equal(t, session.ID, picks[4].SessionID)
+}
+
+func TestImportedSessionErrors(t *testing.T) {
+	info := &espn.LeagueInfo{Teams: []espn.Team{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}}
+	results := []espn.DraftPick{{Round: 1, Pick: 1, OverallPick: 1, TeamID: 1, PlayerID: "1"}}
+
+	_, _, err := importedSession("user-1", &ImportSessionRequest{}, "", info, nil, nil, "PPR")
+	assert.ErrorIs(t, err, ErrNoDraftResults)
+
+	// Without a SWID the user's team has to be given
+	_, _, err = importedSession("user-1", &ImportSessionRequest{}, "", info, results, nil, "PPR")
+	assert.ErrorIs(t, err, ErrUnknownUserTeam)
+
+	_, _, err = importedSession("user-1", &ImportSessionRequest{UserPosition: 5}, "", info, results, nil, "PPR")
+	assert.ErrorIs(t, err, ErrUnsupportedDraft)
+
+	_, _, err = importedSession("user-1", &ImportSessionRequest{UserPosition: 1}, "", &espn.LeagueInfo{}, results, nil, "PPR")
+	assert.ErrorIs(t, err, ErrUnsupportedDraft)
+}
@@ -0,0 +1,190 @@
+package draft
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// slowPicksReported is how many of the user's slowest picks the decision
+// speed report lists
+const slowPicksReported = 5
+
+// PickSpeed is the timing of one of the user's picks
+type PickSpeed struct {
+	PickNumber       int    `json:"pick_number"`
+	Round            int    `json:"round"`
+	PlayerName       string `json:"player_name"`
+	DecisionMS       *int   `json:"decision_ms,omitempty"`
+	ProcessingMS     int    `json:"processing_ms"`
+	RecommendationMS int    `json:"recommendation_ms"`
+	// OverTimer means the decision took longer than the pick timer allows
+	OverTimer bool `json:"over_timer"`
+}
+
+// RoundSpeed is the user's average decision time in one round
+type RoundSpeed struct {
+	Round         int `json:"round"`
+	Picks         int `json:"picks"`
+	AvgDecisionMS int `json:"avg_decision_ms"`
+}
+
+// DecisionSpeedReport breaks down how long the user took over their picks
+// and how much of that the server's processing and recommendations cost
+type DecisionSpeedReport struct {
+	SessionID    string `json:"session_id"`
+	TimerSeconds int    `json:"timer_seconds"`
+	UserPicks    int    `json:"user_picks"`
+	// TimedPicks are the user's picks with a client-reported decision time
+	TimedPicks          int          `json:"timed_picks"`
+	AvgDecisionMS       int          `json:"avg_decision_ms"`
+	MedianDecisionMS    int          `json:"median_decision_ms"`
+	P90DecisionMS       int          `json:"p90_decision_ms"`
+	AvgProcessingMS     int          `json:"avg_processing_ms"`
+	AvgRecommendationMS int          `json:"avg_recommendation_ms"`
+	MaxRecommendationMS int          `json:"max_recommendation_ms"`
+	OverTimerPicks      int          `json:"over_timer_picks"`
+	ByRound             []RoundSpeed `json:"by_round"`
+	SlowestPicks        []PickSpeed  `json:"slowest_picks"`
+	Picks               []PickSpeed  `json:"picks"`
+}
+
+// DecisionSpeed reports how quickly the user made their picks in a draft
+func (s *Service) DecisionSpeed(ctx context.Context, sessionID, userID string) (*DecisionSpeedReport, error) {
+	session, err := s.GetSession(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	picks, err := s.repo.GetPicks(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return decisionSpeed(session, picks), nil
+}
+
+// decisionSpeed builds the report from the user's picks. Keepers are not
+// decisions made during the draft and are left out.
+func decisionSpeed(session *models.DraftSession, picks []*models.DraftPick) *DecisionSpeedReport {
+	report := &DecisionSpeedReport{
+		SessionID:    session.ID,
+		TimerSeconds: session.Settings.TimerSeconds,
+		ByRound:      []RoundSpeed{},
+		SlowestPicks: []PickSpeed{},
+		Picks:        []PickSpeed{},
+	}
+	timerMS := session.Settings.TimerSeconds * 1000
+
+	var decisions []int
+	var processingTotal, recommendationTotal, recommendationCount int
+	rounds := make(map[int]*RoundSpeed)
+	roundTotals := make(map[int]int)
+	for _, pick := range picks {
+		if pick.TeamNumber != session.UserPosition || pick.IsKeeper {
+			continue
+		}
+
+		speed := PickSpeed{
+			PickNumber:       pick.PickNumber,
+			Round:            pick.Round,
+			PlayerName:       pick.PlayerName,
+			DecisionMS:       pick.DecisionMS,
+			ProcessingMS:     pick.ProcessingMS,
+			RecommendationMS: pick.RecommendationMS,
+		}
+		report.UserPicks++
+		processingTotal += pick.ProcessingMS
+		if pick.RecommendationMS > 0 {
+			recommendationTotal += pick.RecommendationMS
+			recommendationCount++
+			if pick.RecommendationMS > report.MaxRecommendationMS {
+				report.MaxRecommendationMS = pick.RecommendationMS
+			}
+		}
+
+		if pick.DecisionMS != nil {
+			decision := *pick.DecisionMS
+			decisions = append(decisions, decision)
+			speed.OverTimer = timerMS > 0 && decision > timerMS
+			if speed.OverTimer {
+				report.OverTimerPicks++
+			}
+
+			round, ok := rounds[pick.Round]
+			if !ok {
+				round = &RoundSpeed{Round: pick.Round}
+				rounds[pick.Round] = round
+			}
+			round.Picks++
+			roundTotals[pick.Round] += decision
+		}
+
+		report.Picks = append(report.Picks, speed)
+	}
+
+	if report.UserPicks > 0 {
+		report.AvgProcessingMS = processingTotal / report.UserPicks
+	}
+	if recommendationCount > 0 {
+		report.AvgRecommendationMS = recommendationTotal / recommendationCount
+	}
+
+	report.TimedPicks = len(decisions)
+	if len(decisions) > 0 {
+		total := 0
+		for _, d := range decisions {
+			total += d
+		}
+		report.AvgDecisionMS = total / len(decisions)
+
+		sort.Ints(decisions)
+		report.MedianDecisionMS = percentile(decisions, 0.5)
+		report.P90DecisionMS = percentile(decisions, 0.9)
+	}
+
+	for number, round := range rounds {
+		round.AvgDecisionMS = roundTotals[number] / round.Picks
+		report.ByRound = append(report.ByRound, *round)
+	}
+	sort.Slice(report.ByRound, func(i, j int) bool { return report.ByRound[i].Round < report.ByRound[j].Round })
+
+	for _, speed := range report.Picks {
+		if speed.DecisionMS != nil {
+			report.SlowestPicks = append(report.SlowestPicks, speed)
+		}
+	}
+	sort.SliceStable(report.SlowestPicks, func(i, j int) bool {
+		return *report.SlowestPicks[i].DecisionMS > *report.SlowestPicks[j].DecisionMS
+	})
+	if len(report.SlowestPicks) > slowPicksReported {
+		report.SlowestPicks = report.SlowestPicks[:slowPicksReported]
+	}
+
+	return report
+}
+
+// percentile reads the nearest-rank percentile from sorted values
+func percentile(sorted []int, p float64) int {
+	rank := int(float64(len(sorted))*p+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// logPickLatency logs a pick's timings for the metrics pipeline
+func logPickLatency(pick *models.DraftPick, total time.Duration) {
+	decision := -1
+	if pick.DecisionMS != nil {
+		decision = *pick.DecisionMS
+	}
+	log.Printf("metrics draft_pick_latency session=%s pick=%d processing_ms=%d total_ms=%d decision_ms=%d recommendation_ms=%d",
+		pick.SessionID, pick.PickNumber, pick.ProcessingMS, total.Milliseconds(), decision, pick.RecommendationMS)
+}
@@ -0,0 +1,47 @@
+package draft
+
+import (
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecisionSpeed(t *testing.T) {
+	ms := func(v int) *int { return &v }
+	session := &models.DraftSession{
+		ID:           "session-1",
+		UserPosition: 2,
+		Settings:     models.DraftSettings{TimerSeconds: 60},
+	}
+	picks := []*models.DraftPick{
+		{PickNumber: 1, Round: 1, TeamNumber: 1, DecisionMS: ms(5000)},
+		{PickNumber: 2, Round: 1, TeamNumber: 2, PlayerName: "A", DecisionMS: ms(20000), ProcessingMS: 30, RecommendationMS: 400},
+		{PickNumber: 3, Round: 2, TeamNumber: 2, PlayerName: "B", DecisionMS: ms(75000), ProcessingMS: 50, RecommendationMS: 2600},
+		{PickNumber: 4, Round: 2, TeamNumber: 2, PlayerName: "C", DecisionMS: ms(10000), ProcessingMS: 40},
+		{PickNumber: 5, Round: 3, TeamNumber: 2, PlayerName: "D", ProcessingMS: 40},
+		{PickNumber: 6, Round: 3, TeamNumber: 2, PlayerName: "Keeper", IsKeeper: true},
+	}
+
+	report := decisionSpeed(session, picks)
+	assert.Equal(t, 4, report.UserPicks)
+	assert.Equal(t, 3, report.TimedPicks)
+	assert.Equal(t, 35000, report.AvgDecisionMS)
+	assert.Equal(t, 20000, report.MedianDecisionMS)
+	assert.Equal(t, 75000, report.P90DecisionMS)
+	assert.Equal(t, 40, report.AvgProcessingMS)
+	assert.Equal(t, 1500, report.AvgRecommendationMS)
+	assert.Equal(t, 2600, report.MaxRecommendationMS)
+	assert.Equal(t, 1, report.OverTimerPicks)
+
+	assert.Equal(t, []RoundSpeed{
+		{Round: 1, Picks: 1, AvgDecisionMS: 20000},
+		{Round: 2, Picks: 2, AvgDecisionMS: 42500},
+	}, report.ByRound)
+
+	require.Len(t, report.SlowestPicks, 3)
+	assert.Equal(t, "B", report.SlowestPicks[0].PlayerName)
+	assert.True(t, report.SlowestPicks[0].OverTimer)
+	assert.Len(t, report.Picks, 4)
+}
@@ -5,14 +5,20 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/projections"
 )
 
 // RecommendationEngine provides draft pick recommendations
 type RecommendationEngine struct {
 	playerRepo PlayerRepository
 	adpRepo    ADPRepository
+	injuryRepo InjuryRepository
+	byeRepo    ByeWeekRepository
+	availRepo  AvailabilityRepository
 }
 
 // PlayerRepository interface for accessing player data
@@ -21,9 +27,26 @@ type PlayerRepository interface {
 	GetPlayerProjections(ctx context.Context, playerIDs []string, scoringType string) (map[string]float64, error)
 }
 
-// ADPRepository interface for accessing ADP data
+// ADPRepository interface for accessing ADP data. Players are keyed by ID
+// and by projections.PlayerKey, for sources that do not share our IDs.
 type ADPRepository interface {
-	GetADP(ctx context.Context, scoringType string) (map[string]float64, error)
+	GetADP(ctx context.Context, source, scoringType string) (map[string]float64, error)
+}
+
+// InjuryRepository interface for accessing current injury designations
+type InjuryRepository interface {
+	GetInjuryStatuses(ctx context.Context, playerIDs []string) (map[string]string, error)
+}
+
+// ByeWeekRepository interface for accessing each NFL team's bye week
+type ByeWeekRepository interface {
+	GetByeWeeks(ctx context.Context, season int) (map[string]int, error)
+}
+
+// AvailabilityRepository interface for accessing suspensions, holdouts and
+// PUP stints
+type AvailabilityRepository interface {
+	GetAvailability(ctx context.Context, season int) ([]projections.Availability, error)
 }
 
 // Player represents a player with their stats and projections
@@ -34,16 +57,35 @@ type Player struct {
 	Team       string  `json:"team"`
 	Projection float64 `json:"projection"`
 	ADP        float64 `json:"adp"`
+	// InjuryStatus is the current designation, empty when healthy
+	InjuryStatus string `json:"injury_status,omitempty"`
+	ByeWeek      int    `json:"bye_week,omitempty"`
 }
 
-// NewRecommendationEngine creates a new recommendation engine
-func NewRecommendationEngine(playerRepo PlayerRepository, adpRepo ADPRepository) *RecommendationEngine {
+// NewRecommendationEngine creates a new recommendation engine. injuryRepo may
+// be nil, in which case injuries are not considered.
+func NewRecommendationEngine(playerRepo PlayerRepository, adpRepo ADPRepository, injuryRepo InjuryRepository) *RecommendationEngine {
 	return &RecommendationEngine{
 		playerRepo: playerRepo,
 		adpRepo:    adpRepo,
+		injuryRepo: injuryRepo,
 	}
 }
 
+// WithByeWeeks discounts players whose bye falls in the same week as players
+// the user already drafted at that position
+func (e *RecommendationEngine) WithByeWeeks(r ByeWeekRepository) *RecommendationEngine {
+	e.byeRepo = r
+	return e
+}
+
+// WithAvailability takes the games players will miss to suspensions and
+// PUP stints off their projections and flags holdouts
+func (e *RecommendationEngine) WithAvailability(r AvailabilityRepository) *RecommendationEngine {
+	e.availRepo = r
+	return e
+}
+
 // GetRecommendations generates draft recommendations for the current pick
 func (e *RecommendationEngine) GetRecommendations(
 	ctx context.Context,
@@ -59,32 +101,70 @@ func (e *RecommendationEngine) GetRecommendations(
 	}
 
 	// Get projections for the scoring type
-	projections, err := e.playerRepo.GetPlayerProjections(ctx, state.AvailablePlayers, session.Settings.ScoringType)
+	projected, err := e.playerRepo.GetPlayerProjections(ctx, state.AvailablePlayers, session.Settings.ScoringType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get projections: %w", err)
 	}
 
-	// Get ADP data
-	adpData, err := e.adpRepo.GetADP(ctx, session.Settings.ScoringType)
+	// Get ADP data from the session's rankings source
+	source := session.Settings.RankingsSource
+	if source == "" {
+		source = projections.RankingsSourceESPN
+	}
+	adpData, err := e.adpRepo.GetADP(ctx, source, session.Settings.ScoringType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ADP data: %w", err)
 	}
 
+	// Get injury designations
+	injuries, err := e.getInjuries(ctx, state.AvailablePlayers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get injury statuses: %w", err)
+	}
+
+	// Get bye weeks and the byes already on the user's roster
+	byeWeeks, rosterByes, err := e.getByeWeeks(ctx, session, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bye weeks: %w", err)
+	}
+
+	// Get suspensions, holdouts and PUP stints
+	availability, err := e.getAvailability(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player availability: %w", err)
+	}
+
 	// Calculate current roster needs
 	rosterNeeds := e.calculateRosterNeeds(session, state)
 
+	// Derive DST/K tiers from the projection pool where possible
+	thresholds := projectionThresholds(players, projected, session.TeamCount)
+
 	// Score each player
 	recommendations := make([]models.DraftRecommendation, 0, len(players))
 	for _, player := range players {
 		// Get player's projected points
-		projectedPoints := projections[player.ID]
+		projectedPoints := projected[player.ID]
 		
 		// Get player's ADP
 		adp, hasADP := adpData[player.ID]
+		if !hasADP {
+			adp, hasADP = adpData[projections.PlayerKey(player.Name, player.Position)]
+		}
 		if !hasADP {
 			adp = 200.0 // Default ADP for unlisted players
 		}
 		player.ADP = adp
+		player.InjuryStatus = injuries[player.ID]
+		player.ByeWeek = byeWeeks[player.Team]
+
+		// Games a player sits out score nothing
+		stint, unavailable := availability.Lookup(player.ID, player.Name)
+		gamesMissed := 0
+		if unavailable {
+			gamesMissed = min(stint.MissedWeeks(1, player.ByeWeek), projections.RegularSeasonGames)
+			projectedPoints *= float64(projections.RegularSeasonGames-gamesMissed) / float64(projections.RegularSeasonGames)
+		}
 
 		// Calculate value over ADP
 		currentPick := float64(session.CurrentPick)
@@ -103,7 +183,9 @@ func (e *RecommendationEngine) GetRecommendations(
 			positionalNeed,
 			player.Position,
 			currentPick,
+			thresholds,
 		)
+		score = applyInjuryPenalty(score, player.InjuryStatus)
 
 		// Generate reasoning
 		reasoning := e.generateReasoning(
@@ -113,6 +195,21 @@ func (e *RecommendationEngine) GetRecommendations(
 			projectedPoints,
 		)
 
+		if unavailable {
+			// Holdouts and indefinite suspensions could cost any number of
+			// games, so they carry a flat penalty instead
+			if !stint.Known() {
+				score *= unknownAvailabilityPenalty
+			}
+			reasoning += "; " + stint.Describe()
+		}
+
+		// Stacking byes at one position leaves a hole that week
+		if shared := rosterByes[player.Position][player.ByeWeek]; player.ByeWeek > 0 && shared > 0 {
+			score *= byeConflictPenalty
+			reasoning += fmt.Sprintf("; Shares week %d bye with %d of your %ss", player.ByeWeek, shared, player.Position)
+		}
+
 		recommendations = append(recommendations, models.DraftRecommendation{
 			PlayerID:       player.ID,
 			PlayerName:     player.Name,
@@ -121,6 +218,10 @@ func (e *RecommendationEngine) GetRecommendations(
 			Score:          score,
 			ValueOverADP:   valueOverADP,
 			PositionalNeed: positionalNeed,
+			InjuryStatus:   player.InjuryStatus,
+			ByeWeek:        player.ByeWeek,
+			Availability:   stint.Status,
+			GamesMissed:    gamesMissed,
 			Reasoning:      reasoning,
 		})
 	}
@@ -138,6 +239,116 @@ func (e *RecommendationEngine) GetRecommendations(
 	return recommendations, nil
 }
 
+// getInjuries returns designations keyed by player ID, or none if the engine
+// has no injury source
+func (e *RecommendationEngine) getInjuries(ctx context.Context, playerIDs []string) (map[string]string, error) {
+	if e.injuryRepo == nil {
+		return map[string]string{}, nil
+	}
+	return e.injuryRepo.GetInjuryStatuses(ctx, playerIDs)
+}
+
+// unknownAvailabilityPenalty scales the score of a player missing an unknown
+// number of games
+const unknownAvailabilityPenalty = 0.85
+
+// getAvailability indexes the draft season's suspensions, holdouts and PUP
+// stints, or none if the engine has no availability source
+func (e *RecommendationEngine) getAvailability(ctx context.Context, session *models.DraftSession) (*projections.AvailabilityIndex, error) {
+	if e.availRepo == nil {
+		return projections.NewAvailabilityIndex(nil), nil
+	}
+	stints, err := e.availRepo.GetAvailability(ctx, draftSeason(session))
+	if err != nil {
+		return nil, err
+	}
+	return projections.NewAvailabilityIndex(stints), nil
+}
+
+// byeConflictPenalty scales the score of a player sharing a bye with
+// players the user already has at the position
+const byeConflictPenalty = 0.95
+
+// getByeWeeks returns each NFL team's bye and, for the user's drafted
+// players, how many at each position have each bye week. Both are empty if
+// the engine has no bye week source.
+func (e *RecommendationEngine) getByeWeeks(
+	ctx context.Context,
+	session *models.DraftSession,
+	state *models.DraftState,
+) (map[string]int, map[string]map[int]int, error) {
+	rosterByes := make(map[string]map[int]int)
+	if e.byeRepo == nil {
+		return map[string]int{}, rosterByes, nil
+	}
+
+	byeWeeks, err := e.byeRepo.GetByeWeeks(ctx, draftSeason(session))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var drafted []string
+	for _, pick := range state.Picks {
+		if pick.TeamNumber == session.UserPosition {
+			drafted = append(drafted, pick.PlayerID)
+		}
+	}
+	if len(drafted) == 0 {
+		return byeWeeks, rosterByes, nil
+	}
+
+	// The player lookup is by ID, so it also finds drafted players
+	players, err := e.playerRepo.GetAvailablePlayers(ctx, drafted)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range players {
+		bye := byeWeeks[p.Team]
+		if bye == 0 {
+			continue
+		}
+		if rosterByes[p.Position] == nil {
+			rosterByes[p.Position] = make(map[int]int)
+		}
+		rosterByes[p.Position][bye]++
+	}
+
+	return byeWeeks, rosterByes, nil
+}
+
+// draftSeason is the NFL season a draft is for: the year it was created,
+// or the year before for drafts held in January and February
+func draftSeason(session *models.DraftSession) int {
+	created := session.CreatedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+	if created.Month() < time.March {
+		return created.Year() - 1
+	}
+	return created.Year()
+}
+
+// injuryScorePenalties scale a recommendation score by designation. Drafts
+// value the whole season, so a single-week designation costs far less here
+// than in weekly projections.
+var injuryScorePenalties = map[string]float64{
+	"QUESTIONABLE":   0.97,
+	"DOUBTFUL":       0.92,
+	"OUT":            0.85,
+	"SUSPENSION":     0.8,
+	"IR":             0.6,
+	"INJURY_RESERVE": 0.6,
+}
+
+// applyInjuryPenalty discounts the score of an injured player
+func applyInjuryPenalty(score float64, injuryStatus string) float64 {
+	if penalty, ok := injuryScorePenalties[strings.ToUpper(injuryStatus)]; ok {
+		return score * penalty
+	}
+	return score
+}
+
 // calculateRosterNeeds determines which positions need to be filled
 func (e *RecommendationEngine) calculateRosterNeeds(
 	session *models.DraftSession,
@@ -224,6 +435,7 @@ func (e *RecommendationEngine) calculateScore(
 	positionalNeed float64,
 	position string,
 	currentPick float64,
+	thresholds map[string][]float64,
 ) float64 {
 	
 	// Weight factors based on draft stage
@@ -254,7 +466,7 @@ func (e *RecommendationEngine) calculateScore(
 	valueScore := math.Max(0, math.Min(100, 50+valueOverADP*2))
 	
 	// Calculate projection score (position-relative)
-	projectionScore := e.getProjectionScore(projectedPoints, position)
+	projectionScore := e.getProjectionScore(projectedPoints, thresholds[position])
 	
 	// Calculate weighted score
 	score := valueScore*valueWeight + 
@@ -267,20 +479,9 @@ func (e *RecommendationEngine) calculateScore(
 	return math.Max(0, math.Min(100, score))
 }
 
-// getProjectionScore normalizes projection points by position
-func (e *RecommendationEngine) getProjectionScore(points float64, position string) float64 {
-	// Position-specific point thresholds for elite/good/average
-	thresholds := map[string][]float64{
-		"QB":  {300, 250, 200},  // Elite, Good, Average
-		"RB":  {200, 150, 100},
-		"WR":  {180, 140, 90},
-		"TE":  {140, 100, 60},
-		"DST": {120, 100, 80},
-		"K":   {130, 110, 90},
-	}
-	
-	posThresholds, exists := thresholds[position]
-	if !exists {
+// getProjectionScore normalizes projection points against elite/good/average thresholds
+func (e *RecommendationEngine) getProjectionScore(points float64, posThresholds []float64) float64 {
+	if len(posThresholds) != 3 {
 		return 50.0 // Default middle score
 	}
 	
@@ -301,6 +502,60 @@ func (e *RecommendationEngine) getProjectionScore(points float64, position strin
 	return math.Max(0, points/posThresholds[2]*40)
 }
 
+// defaultProjectionThresholds are the elite/good/average season point cutoffs by position
+var defaultProjectionThresholds = map[string][]float64{
+	"QB":  {300, 250, 200},
+	"RB":  {200, 150, 100},
+	"WR":  {180, 140, 90},
+	"TE":  {140, 100, 60},
+	"DST": {120, 100, 80},
+	"K":   {130, 110, 90},
+}
+
+// poolDerivedPositions have projections ingested for every starter, so their
+// tiers come from the projection pool rather than fixed cutoffs
+var poolDerivedPositions = []string{"DST", "K"}
+
+// projectionThresholds returns per-position tier thresholds. For DST and K the
+// elite, good and average cutoffs are the projections of the players ranked at
+// one third, two thirds and all of the league's starters. Positions with fewer
+// available players than teams keep the default thresholds.
+func projectionThresholds(players []Player, projections map[string]float64, teamCount int) map[string][]float64 {
+	thresholds := make(map[string][]float64, len(defaultProjectionThresholds))
+	for pos, values := range defaultProjectionThresholds {
+		thresholds[pos] = values
+	}
+
+	if teamCount < 3 {
+		return thresholds
+	}
+
+	for _, pos := range poolDerivedPositions {
+		var pool []float64
+		for _, player := range players {
+			if player.Position == pos && projections[player.ID] > 0 {
+				pool = append(pool, projections[player.ID])
+			}
+		}
+		if len(pool) < teamCount {
+			continue
+		}
+
+		sort.Sort(sort.Reverse(sort.Float64Slice(pool)))
+		elite := pool[teamCount/3-1]
+		good := pool[2*teamCount/3-1]
+		average := pool[teamCount-1]
+
+		// A flat pool can't separate tiers; keep the defaults
+		if elite <= good || good <= average {
+			continue
+		}
+		thresholds[pos] = []float64{elite, good, average}
+	}
+
+	return thresholds
+}
+
 // applyPositionAdjustments applies draft strategy adjustments
 func (e *RecommendationEngine) applyPositionAdjustments(score float64, position string, currentPick float64) float64 {
 	// Don't draft K/DST too early
@@ -384,6 +639,11 @@ func (e *RecommendationEngine) generateReasoning(
 		}
 	}
 	
+	// Injury flag
+	if player.InjuryStatus != "" {
+		reasons = append(reasons, fmt.Sprintf("Injury: %s", strings.ReplaceAll(player.InjuryStatus, "_", " ")))
+	}
+	
 	if len(reasons) == 0 {
 		reasons = append(reasons, "Solid pick at current position")
 	}
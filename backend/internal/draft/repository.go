@@ -16,10 +16,14 @@ type Repository interface {
 	UpdateSession(ctx context.Context, session *models.DraftSession) error
 	DeleteSession(ctx context.Context, sessionID string) error
 	GetUserSessions(ctx context.Context, userID string) ([]*models.DraftSession, error)
+	GetActiveSessions(ctx context.Context) ([]*models.DraftSession, error)
 	
 	CreatePick(ctx context.Context, pick *models.DraftPick) error
+	RecordPicks(ctx context.Context, session *models.DraftSession, previousPick int, picks []*models.DraftPick) error
 	GetPicks(ctx context.Context, sessionID string) ([]*models.DraftPick, error)
 	DeletePick(ctx context.Context, pickID string) error
+
+	SaveShadowComparison(ctx context.Context, comparison *ShadowComparison) error
 }
 
 // PostgresRepository implements Repository for PostgreSQL
@@ -190,7 +194,26 @@ func (r *PostgresRepository) GetUserSessions(ctx context.Context, userID string)
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	return r.querySessions(ctx, query, userID)
+}
+
+// GetActiveSessions gets every draft session that is still in progress
+func (r *PostgresRepository) GetActiveSessions(ctx context.Context) ([]*models.DraftSession, error) {
+	query := `
+		SELECT id, user_id, league_id, name, draft_type, team_count,
+			   round_count, user_position, current_pick, status, settings,
+			   started_at, completed_at, created_at, updated_at
+		FROM draft_sessions
+		WHERE status = 'active'
+		ORDER BY updated_at DESC
+	`
+
+	return r.querySessions(ctx, query)
+}
+
+// querySessions runs a draft session query and scans the results
+func (r *PostgresRepository) querySessions(ctx context.Context, query string, args ...interface{}) ([]*models.DraftSession, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sessions: %w", err)
 	}
@@ -240,8 +263,8 @@ func (r *PostgresRepository) CreatePick(ctx context.Context, pick *models.DraftP
 		INSERT INTO draft_picks (
 			id, session_id, pick_number, round, round_pick,
 			team_number, player_id, player_name, position, 
-			is_keeper, picked_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			is_keeper, picked_at, processing_ms, decision_ms, recommendation_ms
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, 0), $13, NULLIF($14, 0))
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -256,6 +279,9 @@ func (r *PostgresRepository) CreatePick(ctx context.Context, pick *models.DraftP
 		pick.Position,
 		pick.IsKeeper,
 		pick.PickedAt,
+		pick.ProcessingMS,
+		pick.DecisionMS,
+		pick.RecommendationMS,
 	)
 
 	if err != nil {
@@ -265,12 +291,75 @@ func (r *PostgresRepository) CreatePick(ctx context.Context, pick *models.DraftP
 	return nil
 }
 
+// RecordPicks saves several picks and the session's new position in one
+// transaction. It fails with ErrStaleTurn if the session is no longer at
+// previousPick, so two clients reporting the same picks cannot both succeed.
+func (r *PostgresRepository) RecordPicks(ctx context.Context, session *models.DraftSession, previousPick int, picks []*models.DraftPick) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE draft_sessions
+		SET current_pick = $2, status = $3, completed_at = $4, updated_at = $5
+		WHERE id = $1 AND current_pick = $6
+	`, session.ID, session.CurrentPick, session.Status, session.CompletedAt, session.UpdatedAt, previousPick)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrStaleTurn
+	}
+
+	query := `
+		INSERT INTO draft_picks (
+			id, session_id, pick_number, round, round_pick,
+			team_number, player_id, player_name, position,
+			is_keeper, picked_at, processing_ms, decision_ms, recommendation_ms
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, 0), $13, NULLIF($14, 0))
+	`
+	for _, pick := range picks {
+		_, err := tx.ExecContext(ctx, query,
+			pick.ID,
+			pick.SessionID,
+			pick.PickNumber,
+			pick.Round,
+			pick.RoundPick,
+			pick.TeamNumber,
+			pick.PlayerID,
+			pick.PlayerName,
+			pick.Position,
+			pick.IsKeeper,
+			pick.PickedAt,
+			pick.ProcessingMS,
+			pick.DecisionMS,
+			pick.RecommendationMS,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create pick %d: %w", pick.PickNumber, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit picks: %w", err)
+	}
+
+	return nil
+}
+
 // GetPicks retrieves all picks for a draft session
 func (r *PostgresRepository) GetPicks(ctx context.Context, sessionID string) ([]*models.DraftPick, error) {
 	query := `
 		SELECT id, session_id, pick_number, round, round_pick,
 			   team_number, player_id, player_name, position,
-			   is_keeper, picked_at
+			   is_keeper, picked_at, COALESCE(processing_ms, 0), decision_ms,
+			   COALESCE(recommendation_ms, 0)
 		FROM draft_picks
 		WHERE session_id = $1
 		ORDER BY pick_number ASC
@@ -286,6 +375,7 @@ func (r *PostgresRepository) GetPicks(ctx context.Context, sessionID string) ([]
 
 	for rows.Next() {
 		pick := &models.DraftPick{}
+		var decisionMS sql.NullInt64
 
 		err := rows.Scan(
 			&pick.ID,
@@ -299,11 +389,18 @@ func (r *PostgresRepository) GetPicks(ctx context.Context, sessionID string) ([]
 			&pick.Position,
 			&pick.IsKeeper,
 			&pick.PickedAt,
+			&pick.ProcessingMS,
+			&decisionMS,
+			&pick.RecommendationMS,
 		)
 
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan pick: %w", err)
 		}
+		if decisionMS.Valid {
+			ms := int(decisionMS.Int64)
+			pick.DecisionMS = &ms
+		}
 
 		picks = append(picks, pick)
 	}
@@ -330,4 +427,50 @@ func (r *PostgresRepository) DeletePick(ctx context.Context, pickID string) erro
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// SaveShadowComparison logs the active and candidate engine outputs for a
+// shadowed recommendation request
+func (r *PostgresRepository) SaveShadowComparison(ctx context.Context, comparison *ShadowComparison) error {
+	activeJSON, err := json.Marshal(comparison.Active)
+	if err != nil {
+		return fmt.Errorf("failed to marshal active recommendations: %w", err)
+	}
+
+	// NULL when the candidate failed
+	var candidateJSON interface{}
+	if comparison.Candidate != nil {
+		data, err := json.Marshal(comparison.Candidate)
+		if err != nil {
+			return fmt.Errorf("failed to marshal candidate recommendations: %w", err)
+		}
+		candidateJSON = data
+	}
+
+	query := `
+		INSERT INTO draft_recommendation_shadow_log (
+			session_id, pick_number, active_version, candidate_version,
+			active_output, candidate_output, candidate_error, top_pick_match,
+			overlap, active_latency_ms, candidate_latency_ms
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		comparison.SessionID,
+		comparison.PickNumber,
+		comparison.ActiveVersion,
+		comparison.CandidateVersion,
+		activeJSON,
+		candidateJSON,
+		sql.NullString{String: comparison.CandidateError, Valid: comparison.CandidateError != ""},
+		comparison.TopPickMatch,
+		comparison.Overlap,
+		comparison.ActiveLatency.Milliseconds(),
+		comparison.CandidateLatency.Milliseconds(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save shadow comparison: %w", err)
+	}
+
+	return nil
+}
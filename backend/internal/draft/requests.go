@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/projections"
 )
 
 // CreateSessionRequest represents a request to create a draft session
@@ -43,7 +44,18 @@ func (r *CreateSessionRequest) Validate() error {
 		return fmt.Errorf("invalid scoring type: %s", r.Settings.ScoringType)
 	}
 
-	// Validate timer
+	// Validate rankings source
+	if source := r.Settings.RankingsSource; source != "" {
+		valid := false
+		for _, s := range projections.RankingsSources {
+			valid = valid || source == s
+		}
+		if !valid {
+			return fmt.Errorf("invalid rankings source: %s", source)
+		}
+	}
+
+		// Validate timer
 	if r.Settings.TimerSeconds < 0 || r.Settings.TimerSeconds > 600 {
 		return fmt.Errorf("timer must be between 0 and 600 seconds")
 	}
@@ -56,6 +68,39 @@ type RecordPickRequest struct {
 	PlayerID   string `json:"player_id" binding:"required"`
 	PlayerName string `json:"player_name" binding:"required"`
 	Position   string `json:"position" binding:"required,oneof=QB RB WR TE DST K"`
+	// RecommendationRank is the player's position in the recommendations
+	// shown when the pick was made, if they were recommended
+	RecommendationRank *int `json:"recommendation_rank,omitempty"`
+	// DecisionMS is how long the user took to pick after going on the
+	// clock, as measured by the client
+	DecisionMS *int `json:"decision_ms,omitempty" binding:"omitempty,min=0"`
+	// CurrentPick is the session's current pick as the client last saw it.
+	// When set, the pick is rejected if the draft has moved on since, rather
+	// than recorded against a stale board. Picks reported in a turn use the
+	// turn's previous_pick instead.
+	CurrentPick *int `json:"current_pick,omitempty" binding:"omitempty,min=0"`
+}
+
+// TurnRequest reports the picks made since the client's last update and asks
+// for everything needed to make the next one
+type TurnRequest struct {
+	// PreviousPick is the session's current pick as the client last saw it;
+	// the picks are rejected if the draft has moved on since
+	PreviousPick int                 `json:"previous_pick" binding:"min=0"`
+	Picks        []RecordPickRequest `json:"picks" binding:"max=30,dive"`
+	// RecommendationCount defaults to 10
+	RecommendationCount int `json:"recommendation_count" binding:"min=0,max=50"`
+}
+
+// CommandRequest is a free-text draft command such as "take Bijan"
+type CommandRequest struct {
+	Text string `json:"text" binding:"required,max=200"`
+	// PlayerID confirms which of the candidates returned for an ambiguous
+	// command the user meant
+	PlayerID string `json:"player_id,omitempty"`
+	// CurrentPick rejects a pick if the draft has moved on, as for
+	// RecordPickRequest
+	CurrentPick *int `json:"current_pick,omitempty" binding:"omitempty,min=0"`
 }
 
 // UpdateSessionRequest represents a request to update a draft session
@@ -3,18 +3,34 @@ package draft
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
 	"github.com/redis/go-redis/v9"
 )
 
+// Draft state is kept in Redis under StateKeyPrefix plus the session ID for
+// StateTTL after its last change
+const (
+	StateKeyPrefix = "draft:state:"
+	StateTTL       = 24 * time.Hour
+)
+
 // Service handles draft business logic
 type Service struct {
-	repo  Repository
-	redis *redis.Client
+	repo        Repository
+	redis       *redis.Client
+	recommender Recommender
+	authz       *policy.Authorizer
+
+	// Free-text commands
+	players       PlayerRepository
+	adp           ADPRepository
+	commandParser CommandParser
 }
 
 // NewService creates a new draft service
@@ -84,16 +100,27 @@ func (s *Service) CreateSession(ctx context.Context, userID string, req *CreateS
 	return session, nil
 }
 
-// GetSession retrieves a draft session
+// WithAuthorizer sets who may read and change sessions besides their owners
+func (s *Service) WithAuthorizer(a *policy.Authorizer) *Service {
+	s.authz = a
+	return s
+}
+
+// GetSession retrieves a draft session the user may read
 func (s *Service) GetSession(ctx context.Context, sessionID, userID string) (*models.DraftSession, error) {
+	return s.AuthorizeSession(ctx, sessionID, userID, policy.ActionRead)
+}
+
+// AuthorizeSession retrieves a draft session the user may take action on,
+// returning an error wrapping policy.ErrForbidden if they may not
+func (s *Service) AuthorizeSession(ctx context.Context, sessionID, userID string, action policy.Action) (*models.DraftSession, error) {
 	session, err := s.repo.GetSession(ctx, sessionID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify ownership
-	if session.UserID != userID {
-		return nil, fmt.Errorf("unauthorized")
+	if err := s.authz.Authorize(ctx, policy.User(userID), action, policy.Draft(session)); err != nil {
+		return nil, err
 	}
 
 	// Load state from Redis
@@ -107,8 +134,10 @@ func (s *Service) GetSession(ctx context.Context, sessionID, userID string) (*mo
 
 // RecordPick records a draft pick
 func (s *Service) RecordPick(ctx context.Context, sessionID, userID string, req *RecordPickRequest) (*models.DraftPick, error) {
+	start := time.Now()
+
 	// Get session
-	session, err := s.GetSession(ctx, sessionID, userID)
+	session, err := s.AuthorizeSession(ctx, sessionID, userID, policy.ActionWrite)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +151,11 @@ func (s *Service) RecordPick(ctx context.Context, sessionID, userID string, req
 		return nil, fmt.Errorf("draft is complete")
 	}
 
+	// Reject picks made against a board the client has not caught up with
+	if req.CurrentPick != nil && *req.CurrentPick != session.CurrentPick {
+		return nil, ErrStaleTurn
+	}
+
 	// Get current state
 	state, err := s.getState(ctx, sessionID)
 	if err != nil {
@@ -134,6 +168,7 @@ func (s *Service) RecordPick(ctx context.Context, sessionID, userID string, req
 	}
 
 	// Create pick
+	previousPick := session.CurrentPick
 	session.CurrentPick++
 	pick := &models.DraftPick{
 		ID:         uuid.New().String(),
@@ -147,12 +182,12 @@ func (s *Service) RecordPick(ctx context.Context, sessionID, userID string, req
 		Position:   req.Position,
 		IsKeeper:   false,
 		PickedAt:   time.Now(),
+		DecisionMS: req.DecisionMS,
 	}
-
-	// Save pick to database
-	if err := s.repo.CreatePick(ctx, pick); err != nil {
-		return nil, fmt.Errorf("failed to save pick: %w", err)
+	if pick.TeamNumber == session.UserPosition {
+		pick.RecommendationMS = state.RecommendationMS
 	}
+	pick.ProcessingMS = int(time.Since(start).Milliseconds())
 
 	// Update session
 	session.UpdatedAt = time.Now()
@@ -161,14 +196,30 @@ func (s *Service) RecordPick(ctx context.Context, sessionID, userID string, req
 		session.CompletedAt = &[]time.Time{time.Now()}[0]
 	}
 
-	if err := s.repo.UpdateSession(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to update session: %w", err)
+	if req.CurrentPick != nil {
+		// Save the pick only if no other pick got in first
+		if err := s.repo.RecordPicks(ctx, session, previousPick, []*models.DraftPick{pick}); err != nil {
+			if errors.Is(err, ErrStaleTurn) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to save pick: %w", err)
+		}
+	} else {
+		// Save pick to database
+		if err := s.repo.CreatePick(ctx, pick); err != nil {
+			return nil, fmt.Errorf("failed to save pick: %w", err)
+		}
+
+		if err := s.repo.UpdateSession(ctx, session); err != nil {
+			return nil, fmt.Errorf("failed to update session: %w", err)
+		}
 	}
 
 	// Update state
 	state.Picks = append(state.Picks, *pick)
 	state.TeamRosters[pick.TeamNumber] = append(state.TeamRosters[pick.TeamNumber], pick.PlayerID)
 	state.AvailablePlayers = s.removePlayer(state.AvailablePlayers, pick.PlayerID)
+	state.Queue = s.removePlayer(state.Queue, pick.PlayerID)
 	state.LastAction = time.Now()
 
 	// Add to undo stack
@@ -186,13 +237,15 @@ func (s *Service) RecordPick(ctx context.Context, sessionID, userID string, req
 		return nil, fmt.Errorf("failed to save state: %w", err)
 	}
 
+	logPickLatency(pick, time.Since(start))
+
 	return pick, nil
 }
 
 // UndoPick undoes the last pick
 func (s *Service) UndoPick(ctx context.Context, sessionID, userID string) error {
 	// Get session
-	session, err := s.GetSession(ctx, sessionID, userID)
+	session, err := s.AuthorizeSession(ctx, sessionID, userID, policy.ActionWrite)
 	if err != nil {
 		return err
 	}
@@ -262,7 +315,7 @@ func (s *Service) UndoPick(ctx context.Context, sessionID, userID string) error
 // RedoPick redoes a previously undone pick
 func (s *Service) RedoPick(ctx context.Context, sessionID, userID string) (*models.DraftPick, error) {
 	// Get session
-	session, err := s.GetSession(ctx, sessionID, userID)
+	session, err := s.AuthorizeSession(ctx, sessionID, userID, policy.ActionWrite)
 	if err != nil {
 		return nil, err
 	}
@@ -304,6 +357,7 @@ func (s *Service) RedoPick(ctx context.Context, sessionID, userID string) (*mode
 	state.Picks = append(state.Picks, *pick)
 	state.TeamRosters[pick.TeamNumber] = append(state.TeamRosters[pick.TeamNumber], pick.PlayerID)
 	state.AvailablePlayers = s.removePlayer(state.AvailablePlayers, pick.PlayerID)
+	state.Queue = s.removePlayer(state.Queue, pick.PlayerID)
 
 	// Update session
 	session.CurrentPick++
@@ -323,7 +377,7 @@ func (s *Service) RedoPick(ctx context.Context, sessionID, userID string) (*mode
 
 // PauseSession pauses a draft session
 func (s *Service) PauseSession(ctx context.Context, sessionID, userID string) error {
-	session, err := s.GetSession(ctx, sessionID, userID)
+	session, err := s.AuthorizeSession(ctx, sessionID, userID, policy.ActionWrite)
 	if err != nil {
 		return err
 	}
@@ -340,7 +394,7 @@ func (s *Service) PauseSession(ctx context.Context, sessionID, userID string) er
 
 // ResumeSession resumes a paused draft session
 func (s *Service) ResumeSession(ctx context.Context, sessionID, userID string) error {
-	session, err := s.GetSession(ctx, sessionID, userID)
+	session, err := s.AuthorizeSession(ctx, sessionID, userID, policy.ActionWrite)
 	if err != nil {
 		return err
 	}
@@ -360,6 +414,65 @@ func (s *Service) GetUserSessions(ctx context.Context, userID string) ([]*models
 	return s.repo.GetUserSessions(ctx, userID)
 }
 
+// GetActiveSessions gets every draft session that is still in progress
+func (s *Service) GetActiveSessions(ctx context.Context) ([]*models.DraftSession, error) {
+	return s.repo.GetActiveSessions(ctx)
+}
+
+// WarmActiveSessions rebuilds the cached state of in-progress drafts whose
+// state is missing from Redis, such as after a deploy with a fresh cache.
+// Undo history cannot be recovered. Returns the number of states rebuilt.
+func (s *Service) WarmActiveSessions(ctx context.Context) (int, error) {
+	if s.redis == nil {
+		return 0, nil
+	}
+
+	sessions, err := s.repo.GetActiveSessions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rebuilt := 0
+	for _, session := range sessions {
+		exists, err := s.redis.Exists(ctx, StateKeyPrefix+session.ID).Result()
+		if err != nil {
+			return rebuilt, fmt.Errorf("failed to check draft state: %w", err)
+		}
+		if exists > 0 {
+			continue
+		}
+
+		picks, err := s.repo.GetPicks(ctx, session.ID)
+		if err != nil {
+			return rebuilt, err
+		}
+
+		state := &models.DraftState{
+			SessionID:        session.ID,
+			Picks:            []models.DraftPick{},
+			AvailablePlayers: []string{},
+			TeamRosters:      make(map[int][]string),
+			UndoStack:        []models.DraftEvent{},
+			RedoStack:        []models.DraftEvent{},
+			LastAction:       session.UpdatedAt,
+		}
+		for i := 1; i <= session.TeamCount; i++ {
+			state.TeamRosters[i] = []string{}
+		}
+		for _, pick := range picks {
+			state.Picks = append(state.Picks, *pick)
+			state.TeamRosters[pick.TeamNumber] = append(state.TeamRosters[pick.TeamNumber], pick.PlayerID)
+		}
+
+		if err := s.saveState(ctx, session.ID, state); err != nil {
+			return rebuilt, fmt.Errorf("failed to save state: %w", err)
+		}
+		rebuilt++
+	}
+
+	return rebuilt, nil
+}
+
 // Helper functions
 
 func (s *Service) saveState(ctx context.Context, sessionID string, state *models.DraftState) error {
@@ -368,12 +481,12 @@ func (s *Service) saveState(ctx context.Context, sessionID string, state *models
 		return err
 	}
 
-	key := fmt.Sprintf("draft:state:%s", sessionID)
-	return s.redis.Set(ctx, key, data, 24*time.Hour).Err()
+	key := StateKeyPrefix + sessionID
+	return s.redis.Set(ctx, key, data, StateTTL).Err()
 }
 
 func (s *Service) getState(ctx context.Context, sessionID string) (*models.DraftState, error) {
-	key := fmt.Sprintf("draft:state:%s", sessionID)
+	key := StateKeyPrefix + sessionID
 	data, err := s.redis.Get(ctx, key).Result()
 	if err != nil {
 		return nil, err
@@ -397,7 +510,7 @@ func (s *Service) isPlayerAvailable(availablePlayers []string, playerID string)
 }
 
 func (s *Service) removePlayer(availablePlayers []string, playerID string) []string {
-	result := make([]string, 0, len(availablePlayers)-1)
+	result := make([]string, 0, len(availablePlayers))
 	for _, id := range availablePlayers {
 		if id != playerID {
 			result = append(result, id)
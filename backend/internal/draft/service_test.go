@@ -49,11 +49,24 @@ func (m *MockRepository) GetUserSessions(ctx context.Context, userID string) ([]
 	return args.Get(0).([]*models.DraftSession), args.Error(1)
 }
 
+func (m *MockRepository) GetActiveSessions(ctx context.Context) ([]*models.DraftSession, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.DraftSession), args.Error(1)
+}
+
 func (m *MockRepository) CreatePick(ctx context.Context, pick *models.DraftPick) error {
 	args := m.Called(ctx, pick)
 	return args.Error(0)
 }
 
+func (m *MockRepository) RecordPicks(ctx context.Context, session *models.DraftSession, previousPick int, picks []*models.DraftPick) error {
+	args := m.Called(ctx, session, previousPick, picks)
+	return args.Error(0)
+}
+
 func (m *MockRepository) GetPicks(ctx context.Context, sessionID string) ([]*models.DraftPick, error) {
 	args := m.Called(ctx, sessionID)
 	if args.Get(0) == nil {
@@ -67,6 +80,11 @@ func (m *MockRepository) DeletePick(ctx context.Context, pickID string) error {
 	return args.Error(0)
 }
 
+func (m *MockRepository) SaveShadowComparison(ctx context.Context, comparison *ShadowComparison) error {
+	args := m.Called(ctx, comparison)
+	return args.Error(0)
+}
+
 // Helper function to create a test Redis client
 func createTestRedis() *redis.Client {
 	// Use mini redis for testing or mock
@@ -0,0 +1,163 @@
+package draft
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// EngineVersionV1 is the current recommendation engine
+const EngineVersionV1 = "v1"
+
+// shadowTimeout bounds how long a shadow computation may run after the
+// request it mirrors has been served
+const shadowTimeout = 10 * time.Second
+
+// Recommender produces draft recommendations for the current pick
+type Recommender interface {
+	GetRecommendations(ctx context.Context, session *models.DraftSession, state *models.DraftState, count int) ([]models.DraftRecommendation, error)
+}
+
+// ShadowConfig selects the served engine version and an optional candidate
+// version computed alongside it for a percentage of requests
+type ShadowConfig struct {
+	ActiveVersion    string
+	CandidateVersion string
+	// Percent of requests, 0-100, that also run the candidate
+	Percent float64
+}
+
+// ShadowComparison records both engines' output for one request
+type ShadowComparison struct {
+	SessionID        string                       `json:"session_id"`
+	PickNumber       int                          `json:"pick_number"`
+	ActiveVersion    string                       `json:"active_version"`
+	CandidateVersion string                       `json:"candidate_version"`
+	Active           []models.DraftRecommendation `json:"active"`
+	Candidate        []models.DraftRecommendation `json:"candidate"`
+	CandidateError   string                       `json:"candidate_error,omitempty"`
+	TopPickMatch     bool                         `json:"top_pick_match"`
+	Overlap          float64                      `json:"overlap"`
+	ActiveLatency    time.Duration                `json:"active_latency"`
+	CandidateLatency time.Duration                `json:"candidate_latency"`
+}
+
+// ShadowLogger stores shadow comparisons
+type ShadowLogger interface {
+	SaveShadowComparison(ctx context.Context, comparison *ShadowComparison) error
+}
+
+// ShadowRecommender serves the active engine version and, for a sample of
+// requests, runs the candidate version in the background and logs both
+// outputs. The candidate never affects the response.
+type ShadowRecommender struct {
+	active    Recommender
+	candidate Recommender
+	config    ShadowConfig
+	logger    ShadowLogger
+	sample    func() float64
+	wg        sync.WaitGroup
+}
+
+// NewShadowRecommender picks the configured versions from engines. Shadowing
+// is off when no candidate version is configured.
+func NewShadowRecommender(engines map[string]Recommender, config ShadowConfig, logger ShadowLogger) (*ShadowRecommender, error) {
+	active, ok := engines[config.ActiveVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown recommendation engine version %q", config.ActiveVersion)
+	}
+
+	r := &ShadowRecommender{
+		active: active,
+		config: config,
+		logger: logger,
+		sample: rand.Float64,
+	}
+
+	if config.CandidateVersion != "" && config.Percent > 0 {
+		candidate, ok := engines[config.CandidateVersion]
+		if !ok {
+			return nil, fmt.Errorf("unknown shadow engine version %q", config.CandidateVersion)
+		}
+		r.candidate = candidate
+	}
+
+	return r, nil
+}
+
+// GetRecommendations returns the active engine's recommendations
+func (r *ShadowRecommender) GetRecommendations(ctx context.Context, session *models.DraftSession, state *models.DraftState, count int) ([]models.DraftRecommendation, error) {
+	started := time.Now()
+	recommendations, err := r.active.GetRecommendations(ctx, session, state, count)
+	if err != nil {
+		return nil, err
+	}
+	activeLatency := time.Since(started)
+
+	if r.candidate != nil && r.sample()*100 < r.config.Percent {
+		comparison := &ShadowComparison{
+			SessionID:        session.ID,
+			PickNumber:       session.CurrentPick,
+			ActiveVersion:    r.config.ActiveVersion,
+			CandidateVersion: r.config.CandidateVersion,
+			Active:           recommendations,
+			ActiveLatency:    activeLatency,
+		}
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.runShadow(context.WithoutCancel(ctx), session, state, count, comparison)
+		}()
+	}
+
+	return recommendations, nil
+}
+
+// runShadow computes the candidate's recommendations and logs the comparison
+func (r *ShadowRecommender) runShadow(ctx context.Context, session *models.DraftSession, state *models.DraftState, count int, comparison *ShadowComparison) {
+	ctx, cancel := context.WithTimeout(ctx, shadowTimeout)
+	defer cancel()
+
+	started := time.Now()
+	candidate, err := r.candidate.GetRecommendations(ctx, session, state, count)
+	comparison.CandidateLatency = time.Since(started)
+	if err != nil {
+		comparison.CandidateError = err.Error()
+	} else {
+		comparison.Candidate = candidate
+		comparison.TopPickMatch, comparison.Overlap = compareRecommendations(comparison.Active, candidate)
+	}
+
+	if err := r.logger.SaveShadowComparison(ctx, comparison); err != nil {
+		log.Printf("Failed to log shadow recommendations for session %s: %v", session.ID, err)
+	}
+}
+
+// compareRecommendations reports whether both lists lead with the same
+// player and what share of the active list the candidate also returned
+func compareRecommendations(active, candidate []models.DraftRecommendation) (bool, float64) {
+	if len(active) == 0 {
+		return len(candidate) == 0, 1
+	}
+
+	topPickMatch := len(candidate) > 0 && active[0].PlayerID == candidate[0].PlayerID
+
+	returned := make(map[string]bool, len(candidate))
+	for _, rec := range candidate {
+		returned[rec.PlayerID] = true
+	}
+	shared := 0
+	for _, rec := range active {
+		if returned[rec.PlayerID] {
+			shared++
+		}
+	}
+
+	return topPickMatch, float64(shared) / float64(len(active))
+}
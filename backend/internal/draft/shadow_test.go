@@ -0,0 +1,103 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedRecommender struct {
+	ids []string
+	err error
+}
+
+func (f *fixedRecommender) GetRecommendations(ctx context.Context, session *models.DraftSession, state *models.DraftState, count int) ([]models.DraftRecommendation, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	recs := make([]models.DraftRecommendation, 0, len(f.ids))
+	for _, id := range f.ids {
+		recs = append(recs, models.DraftRecommendation{PlayerID: id})
+	}
+	return recs, nil
+}
+
+type recordingShadowLogger struct {
+	mu          sync.Mutex
+	comparisons []*ShadowComparison
+}
+
+func (l *recordingShadowLogger) SaveShadowComparison(ctx context.Context, comparison *ShadowComparison) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.comparisons = append(l.comparisons, comparison)
+	return nil
+}
+
+func TestShadowRecommender(t *testing.T) {
+	engines := map[string]Recommender{
+		EngineVersionV1: &fixedRecommender{ids: []string{"a", "b", "c", "d"}},
+		"v2":            &fixedRecommender{ids: []string{"b", "a", "c", "e"}},
+		"broken":        &fixedRecommender{err: errors.New("boom")},
+	}
+	session := &models.DraftSession{ID: "session-1", CurrentPick: 14}
+
+	t.Run("logs both outputs for sampled requests", func(t *testing.T) {
+		logger := &recordingShadowLogger{}
+		r, err := NewShadowRecommender(engines, ShadowConfig{ActiveVersion: EngineVersionV1, CandidateVersion: "v2", Percent: 25}, logger)
+		require.NoError(t, err)
+
+		samples := []float64{0.1, 0.9}
+		r.sample = func() float64 {
+			s := samples[0]
+			samples = samples[1:]
+			return s
+		}
+
+		for i := 0; i < 2; i++ {
+			recs, err := r.GetRecommendations(context.Background(), session, &models.DraftState{}, 4)
+			require.NoError(t, err)
+			// The active engine is always served
+			assert.Equal(t, "a", recs[0].PlayerID)
+		}
+		r.wg.Wait()
+
+		// Only the request sampled under 25% was shadowed
+		require.Len(t, logger.comparisons, 1)
+		c := logger.comparisons[0]
+		assert.Equal(t, "session-1", c.SessionID)
+		assert.Equal(t, 14, c.PickNumber)
+		assert.Equal(t, "v2", c.CandidateVersion)
+		assert.Len(t, c.Candidate, 4)
+		assert.False(t, c.TopPickMatch)
+		assert.Equal(t, 0.75, c.Overlap)
+	})
+
+	t.Run("candidate errors are logged, not served", func(t *testing.T) {
+		logger := &recordingShadowLogger{}
+		r, err := NewShadowRecommender(engines, ShadowConfig{ActiveVersion: EngineVersionV1, CandidateVersion: "broken", Percent: 100}, logger)
+		require.NoError(t, err)
+
+		recs, err := r.GetRecommendations(context.Background(), session, &models.DraftState{}, 4)
+		require.NoError(t, err)
+		assert.Len(t, recs, 4)
+		r.wg.Wait()
+
+		require.Len(t, logger.comparisons, 1)
+		assert.Equal(t, "boom", logger.comparisons[0].CandidateError)
+		assert.Nil(t, logger.comparisons[0].Candidate)
+	})
+
+	t.Run("unknown versions are rejected", func(t *testing.T) {
+		_, err := NewShadowRecommender(engines, ShadowConfig{ActiveVersion: "v9"}, nil)
+		assert.Error(t, err)
+
+		_, err = NewShadowRecommender(engines, ShadowConfig{ActiveVersion: EngineVersionV1, CandidateVersion: "v9", Percent: 10}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,270 @@
+package draft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+)
+
+// defaultTurnRecommendations is how many recommendations a turn returns when
+// the client does not ask for a number
+const defaultTurnRecommendations = 10
+
+// ErrStaleTurn is returned when a turn reports picks from an earlier point
+// in the draft than the session is at, usually because another tab or a
+// retried request already recorded them
+var ErrStaleTurn = errors.New("draft has moved on since the previous pick")
+
+// TurnQueue describes who is on the clock and how far away the user's next
+// pick is
+type TurnQueue struct {
+	CurrentPick    int  `json:"current_pick"` // Overall number of the pick on the clock
+	Round          int  `json:"round"`
+	OnTheClock     int  `json:"on_the_clock"` // Team number making the pick
+	UserTeam       int  `json:"user_team"`
+	UserOnTheClock bool `json:"user_on_the_clock"`
+	// NextUserPick is the user's next overall pick, 0 once they have none left
+	NextUserPick   int `json:"next_user_pick"`
+	PicksUntilUser int `json:"picks_until_user"`
+	RemainingPicks int `json:"remaining_picks"`
+}
+
+// TurnTimer is the pick clock for the pick on the clock. The clock is not
+// running while the draft is paused or when it has no timer.
+type TurnTimer struct {
+	TimerSeconds     int        `json:"timer_seconds"`
+	Running          bool       `json:"running"`
+	PickStartedAt    time.Time  `json:"pick_started_at"`
+	Deadline         *time.Time `json:"deadline,omitempty"`
+	RemainingSeconds int        `json:"remaining_seconds"`
+}
+
+// TurnResult is everything the draft assistant needs to make the next pick
+type TurnResult struct {
+	Session         *models.DraftSession         `json:"session"`
+	Recorded        []*models.DraftPick          `json:"recorded"`
+	Recommendations []models.DraftRecommendation `json:"recommendations"`
+	Queue           TurnQueue                    `json:"queue"`
+	Timer           TurnTimer                    `json:"timer"`
+}
+
+// WithRecommender sets the engine that supplies recommendations for turns.
+// Without one, turns return no recommendations.
+func (s *Service) WithRecommender(r Recommender) *Service {
+	s.recommender = r
+	return s
+}
+
+// TakeTurn records the picks made since previousPick in one transaction and
+// returns the updated draft, replacing separate calls to record each pick,
+// reload the session and fetch recommendations. The picks are rejected with
+// ErrStaleTurn if the session is no longer at previousPick.
+func (s *Service) TakeTurn(ctx context.Context, sessionID, userID string, req *TurnRequest) (*TurnResult, error) {
+	start := time.Now()
+
+	session, err := s.AuthorizeSession(ctx, sessionID, userID, policy.ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.CurrentPick != req.PreviousPick {
+		return nil, ErrStaleTurn
+	}
+
+	var recorded []*models.DraftPick
+	if len(req.Picks) > 0 {
+		if session.Status != "active" {
+			return nil, fmt.Errorf("draft is not active")
+		}
+
+		state, err := s.getState(ctx, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state: %w", err)
+		}
+
+		recorded, err = s.buildPicks(session, state, req.Picks)
+		if err != nil {
+			return nil, err
+		}
+		for _, pick := range recorded {
+			pick.ProcessingMS = int(time.Since(start).Milliseconds())
+		}
+
+		session.UpdatedAt = time.Now()
+		if session.IsComplete() {
+			session.Status = "completed"
+			session.CompletedAt = &[]time.Time{time.Now()}[0]
+		}
+
+		if err := s.repo.RecordPicks(ctx, session, req.PreviousPick, recorded); err != nil {
+			if errors.Is(err, ErrStaleTurn) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to save picks: %w", err)
+		}
+
+		// Each pick is undone on its own, as if recorded separately
+		for _, pick := range recorded {
+			state.Picks = append(state.Picks, *pick)
+			state.TeamRosters[pick.TeamNumber] = append(state.TeamRosters[pick.TeamNumber], pick.PlayerID)
+			state.AvailablePlayers = s.removePlayer(state.AvailablePlayers, pick.PlayerID)
+			state.Queue = s.removePlayer(state.Queue, pick.PlayerID)
+			state.UndoStack = append(state.UndoStack, models.DraftEvent{
+				Type:      "pick",
+				Data:      pick,
+				Timestamp: pick.PickedAt,
+				UserID:    userID,
+			})
+		}
+		state.RedoStack = []models.DraftEvent{}
+		state.LastAction = time.Now()
+		session.State = state
+	}
+
+	result := &TurnResult{
+		Session:         session,
+		Recorded:        recorded,
+		Recommendations: []models.DraftRecommendation{},
+		Queue:           turnQueue(session),
+		Timer:           turnTimer(session, time.Now()),
+	}
+	if result.Recorded == nil {
+		result.Recorded = []*models.DraftPick{}
+	}
+
+	stateChanged := len(recorded) > 0
+	if s.recommender != nil && session.State != nil && session.Status == "active" {
+		count := req.RecommendationCount
+		if count == 0 {
+			count = defaultTurnRecommendations
+		}
+		recommendStart := time.Now()
+		recommendations, err := s.recommender.GetRecommendations(ctx, session, session.State, count)
+		if err != nil {
+			// The picks are saved; the client can still fetch recommendations
+			log.Printf("Failed to get recommendations for draft %s: %v", sessionID, err)
+		} else {
+			result.Recommendations = recommendations
+			// Carried onto the user's next pick for the decision speed report
+			session.State.RecommendationMS = int(time.Since(recommendStart).Milliseconds())
+			stateChanged = true
+		}
+	}
+
+	if stateChanged {
+		if err := s.saveState(ctx, sessionID, session.State); err != nil {
+			return nil, fmt.Errorf("failed to save state: %w", err)
+		}
+	}
+
+	for _, pick := range recorded {
+		logPickLatency(pick, time.Since(start))
+	}
+
+	return result, nil
+}
+
+// Recommendations returns recommendations for the pick on the clock without
+// recording anything, for read-only viewers of the draft
+func (s *Service) Recommendations(ctx context.Context, sessionID, userID string, count int) ([]models.DraftRecommendation, error) {
+	session, err := s.GetSession(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.recommender == nil || session.State == nil || session.Status != "active" {
+		return []models.DraftRecommendation{}, nil
+	}
+	if count <= 0 {
+		count = defaultTurnRecommendations
+	}
+	return s.recommender.GetRecommendations(ctx, session, session.State, count)
+}
+
+// buildPicks validates reported picks against the draft state and numbers
+// them in order from the session's current pick, advancing the session
+func (s *Service) buildPicks(session *models.DraftSession, state *models.DraftState, reqs []RecordPickRequest) ([]*models.DraftPick, error) {
+	available := state.AvailablePlayers
+	picks := make([]*models.DraftPick, 0, len(reqs))
+	for _, req := range reqs {
+		if session.IsComplete() {
+			return nil, fmt.Errorf("draft is complete")
+		}
+		if !s.isPlayerAvailable(available, req.PlayerID) {
+			return nil, fmt.Errorf("player is not available: %s", req.PlayerID)
+		}
+		available = s.removePlayer(available, req.PlayerID)
+
+		session.CurrentPick++
+		picks = append(picks, &models.DraftPick{
+			ID:         uuid.New().String(),
+			SessionID:  session.ID,
+			PickNumber: session.CurrentPick,
+			Round:      session.GetCurrentRound(),
+			RoundPick:  ((session.CurrentPick - 1) % session.TeamCount) + 1,
+			TeamNumber: session.GetCurrentTeam(),
+			PlayerID:   req.PlayerID,
+			PlayerName: req.PlayerName,
+			Position:   req.Position,
+			IsKeeper:   false,
+			PickedAt:   time.Now(),
+			DecisionMS: req.DecisionMS,
+		})
+		if session.GetCurrentTeam() == session.UserPosition {
+			picks[len(picks)-1].RecommendationMS = state.RecommendationMS
+		}
+	}
+	return picks, nil
+}
+
+// turnQueue works out the pick on the clock, which follows the session's
+// last recorded pick, and the user's next turn
+func turnQueue(session *models.DraftSession) TurnQueue {
+	next := session.CurrentPick + 1
+	total := session.GetTotalPicks()
+	queue := TurnQueue{
+		CurrentPick: next,
+		UserTeam:    session.UserPosition,
+	}
+	if next > total {
+		return queue
+	}
+
+	queue.Round = ((next - 1) / session.TeamCount) + 1
+	queue.OnTheClock = session.TeamForPick(next)
+	queue.UserOnTheClock = queue.OnTheClock == session.UserPosition
+	queue.RemainingPicks = total - next + 1
+	for pick := next; pick <= total; pick++ {
+		if session.TeamForPick(pick) == session.UserPosition {
+			queue.NextUserPick = pick
+			queue.PicksUntilUser = pick - next
+			break
+		}
+	}
+	return queue
+}
+
+// turnTimer reports the pick clock, which starts when the previous pick was
+// recorded
+func turnTimer(session *models.DraftSession, now time.Time) TurnTimer {
+	timer := TurnTimer{TimerSeconds: session.Settings.TimerSeconds}
+	if session.State != nil {
+		timer.PickStartedAt = session.State.LastAction
+	}
+	if timer.TimerSeconds <= 0 || timer.PickStartedAt.IsZero() || session.Status != "active" {
+		return timer
+	}
+
+	deadline := timer.PickStartedAt.Add(time.Duration(timer.TimerSeconds) * time.Second)
+	timer.Running = true
+	timer.Deadline = &deadline
+	if remaining := deadline.Sub(now); remaining > 0 {
+		timer.RemainingSeconds = int(remaining.Round(time.Second) / time.Second)
+	}
+	return timer
+}
@@ -0,0 +1,69 @@
+package draft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTurnQueue(t *testing.T) {
+	session := &models.DraftSession{DraftType: "snake", TeamCount: 10, RoundCount: 3, UserPosition: 3}
+
+	// Picks 1-8 made: pick 9 is team 9, the user's next is pick 18 (team 3 in round 2)
+	session.CurrentPick = 8
+	queue := turnQueue(session)
+	assert.Equal(t, 9, queue.CurrentPick)
+	assert.Equal(t, 1, queue.Round)
+	assert.Equal(t, 9, queue.OnTheClock)
+	assert.False(t, queue.UserOnTheClock)
+	assert.Equal(t, 18, queue.NextUserPick)
+	assert.Equal(t, 9, queue.PicksUntilUser)
+	assert.Equal(t, 22, queue.RemainingPicks)
+
+	session.CurrentPick = 17
+	queue = turnQueue(session)
+	assert.Equal(t, 3, queue.OnTheClock)
+	assert.True(t, queue.UserOnTheClock)
+	assert.Equal(t, 0, queue.PicksUntilUser)
+
+	// The user's last pick is 23; after it there is nothing left for them
+	session.CurrentPick = 23
+	queue = turnQueue(session)
+	assert.Equal(t, 0, queue.NextUserPick)
+	assert.Equal(t, 7, queue.RemainingPicks)
+
+	session.CurrentPick = 30
+	queue = turnQueue(session)
+	assert.Equal(t, 0, queue.OnTheClock)
+	assert.Equal(t, 0, queue.RemainingPicks)
+}
+
+func TestTurnTimer(t *testing.T) {
+	started := time.Date(2025, 8, 30, 19, 0, 0, 0, time.UTC)
+	session := &models.DraftSession{
+		Status:   "active",
+		Settings: models.DraftSettings{TimerSeconds: 90},
+		State:    &models.DraftState{LastAction: started},
+	}
+
+	timer := turnTimer(session, started.Add(30*time.Second))
+	assert.True(t, timer.Running)
+	require.NotNil(t, timer.Deadline)
+	assert.Equal(t, started.Add(90*time.Second), *timer.Deadline)
+	assert.Equal(t, 60, timer.RemainingSeconds)
+
+	timer = turnTimer(session, started.Add(2*time.Minute))
+	assert.Equal(t, 0, timer.RemainingSeconds)
+
+	session.Status = "paused"
+	timer = turnTimer(session, started.Add(30*time.Second))
+	assert.False(t, timer.Running)
+	assert.Nil(t, timer.Deadline)
+
+	session.Status = "active"
+	session.Settings.TimerSeconds = 0
+	assert.False(t, turnTimer(session, started).Running)
+}
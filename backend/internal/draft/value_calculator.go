@@ -96,6 +96,13 @@ func (v *ValueCalculator) CalculatePositionalScarcity(
 		"K":   110,
 	}
 	
+	// DST/K elite cutoffs come from the projection pool when it is deep enough
+	for _, pos := range poolDerivedPositions {
+		if threshold, ok := v.poolEliteThreshold(projections, pos); ok {
+			eliteThresholds[pos] = threshold
+		}
+	}
+	
 	availableElite := make(map[string]int)
 	totalAvailable := make(map[string]int)
 	
@@ -122,6 +129,29 @@ func (v *ValueCalculator) CalculatePositionalScarcity(
 	return scarcity
 }
 
+// poolEliteThreshold returns the projection of the last elite starter at a
+// position, counting the whole pool so the cutoff stays stable as players are drafted
+func (v *ValueCalculator) poolEliteThreshold(projections map[string]PlayerProjection, position string) (float64, bool) {
+	baseline := v.getPositionBaselines("")[position]
+	eliteRank := baseline / 3
+	if eliteRank == 0 {
+		return 0, false
+	}
+
+	var pool []float64
+	for _, proj := range projections {
+		if proj.Position == position && proj.ProjectedPoints > 0 {
+			pool = append(pool, proj.ProjectedPoints)
+		}
+	}
+	if len(pool) < baseline {
+		return 0, false
+	}
+
+	sort.Sort(sort.Reverse(sort.Float64Slice(pool)))
+	return pool[eliteRank-1], true
+}
+
 // CalculateTierBreaks identifies tier breaks in player rankings
 func (v *ValueCalculator) CalculateTierBreaks(
 	projections []PlayerProjection,
@@ -0,0 +1,148 @@
+package espnbudget
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyPrefix namespaces the hourly counters in Redis
+const KeyPrefix = "espnbudget:"
+
+// keyTTL keeps an hour's counter around long enough to report on the
+// previous hour
+const keyTTL = 2 * time.Hour
+
+// hourFormat buckets counters by UTC hour
+const hourFormat = "2006010215"
+
+// LeagueUsage is the number of ESPN requests made for a league this hour
+type LeagueUsage struct {
+	LeagueID string `json:"league_id"`
+	Calls    int64  `json:"calls"`
+	// OverBudget means the league's syncs are being deferred
+	OverBudget bool `json:"over_budget"`
+}
+
+// Report is the current hour's ESPN usage per league, busiest first
+type Report struct {
+	Hour    time.Time     `json:"hour"`
+	Budget  int64         `json:"budget"`
+	Leagues []LeagueUsage `json:"leagues"`
+}
+
+// Budget counts ESPN API requests per league per hour in Redis, shared by
+// every instance, so one league that keeps failing or has a huge history
+// cannot use up the global ESPN rate limit
+type Budget struct {
+	redis *redis.Client
+	limit int64
+}
+
+// NewBudget creates a budget of limit requests per league per hour. A limit
+// of 0 only counts requests and never defers a league.
+func NewBudget(redisClient *redis.Client, limit int) *Budget {
+	return &Budget{redis: redisClient, limit: int64(limit)}
+}
+
+// Record counts one request for an ESPN league. It fits the ESPN client's
+// request observer; failures are logged rather than failing the request.
+func (b *Budget) Record(ctx context.Context, leagueID string) {
+	key := hourKey(leagueID, time.Now())
+	pipe := b.redis.Pipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, keyTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to record ESPN request for league %s: %v", leagueID, err)
+		return
+	}
+	if b.limit > 0 && incr.Val() == b.limit+1 {
+		log.Printf("metrics espn_budget_exceeded league=%s budget=%d", leagueID, b.limit)
+	}
+}
+
+// Usage returns the requests made for an ESPN league this hour
+func (b *Budget) Usage(ctx context.Context, leagueID string) (int64, error) {
+	calls, err := b.redis.Get(ctx, hourKey(leagueID, time.Now())).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ESPN usage for league %s: %w", leagueID, err)
+	}
+	return calls, nil
+}
+
+// Allow reports whether an ESPN league is still within this hour's budget,
+// along with the requests it has made
+func (b *Budget) Allow(ctx context.Context, leagueID string) (bool, int64, error) {
+	calls, err := b.Usage(ctx, leagueID)
+	if err != nil {
+		return true, 0, err
+	}
+	return b.limit <= 0 || calls < b.limit, calls, nil
+}
+
+// Limit is the hourly request budget per league, 0 if unlimited
+func (b *Budget) Limit() int64 {
+	return b.limit
+}
+
+// Report lists every league with requests this hour
+func (b *Budget) Report(ctx context.Context) (*Report, error) {
+	now := time.Now().UTC()
+	suffix := ":" + now.Format(hourFormat)
+
+	var keys []string
+	iter := b.redis.Scan(ctx, 0, KeyPrefix+"*"+suffix, 500).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan ESPN usage: %w", err)
+	}
+
+	report := &Report{Hour: now.Truncate(time.Hour), Budget: b.limit, Leagues: []LeagueUsage{}}
+	if len(keys) == 0 {
+		return report, nil
+	}
+
+	values, err := b.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ESPN usage: %w", err)
+	}
+	for i, key := range keys {
+		s, ok := values[i].(string)
+		if !ok {
+			continue // expired since the scan
+		}
+		calls, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			continue
+		}
+		report.Leagues = append(report.Leagues, LeagueUsage{
+			LeagueID:   strings.TrimSuffix(strings.TrimPrefix(key, KeyPrefix), suffix),
+			Calls:      calls,
+			OverBudget: b.limit > 0 && calls >= b.limit,
+		})
+	}
+
+	sort.Slice(report.Leagues, func(i, j int) bool {
+		if report.Leagues[i].Calls != report.Leagues[j].Calls {
+			return report.Leagues[i].Calls > report.Leagues[j].Calls
+		}
+		return report.Leagues[i].LeagueID < report.Leagues[j].LeagueID
+	})
+	return report, nil
+}
+
+// hourKey is the counter for a league in the hour containing t
+func hourKey(leagueID string, t time.Time) string {
+	return KeyPrefix + leagueID + ":" + t.UTC().Format(hourFormat)
+}
@@ -0,0 +1,194 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Topics carried on the bus
+const (
+	// TopicLiveScoring carries matchup scores each time a league syncs
+	TopicLiveScoring = "live_scoring"
+	// TopicLeagueSync carries the outcome of each league sync
+	TopicLeagueSync = "league_sync"
+	// TopicDraft carries draft session changes, keyed by session ID
+	TopicDraft = "draft"
+	// TopicTransactions carries league transactions first seen by a sync
+	TopicTransactions = "transactions"
+	// TopicRosters carries changes to rostered players seen by a sync
+	TopicRosters = "rosters"
+)
+
+// maxBatch caps the messages handed to a transport at once
+const maxBatch = 200
+
+// Message is one event on the bus
+type Message struct {
+	Topic string `json:"topic"`
+	// Key orders related messages, such as every message for one league
+	Key         string          `json:"key,omitempty"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	PublishedAt time.Time       `json:"published_at"`
+}
+
+// Handler receives messages from a subscription. Handlers run on the
+// publisher's goroutine and must not block.
+type Handler func(ctx context.Context, msg Message)
+
+// Publisher publishes events to the bus
+type Publisher interface {
+	Publish(ctx context.Context, topic, key, eventType string, payload interface{}) error
+}
+
+// Transport forwards messages to an external streaming system so other
+// pipelines can consume them
+type Transport interface {
+	Name() string
+	Send(ctx context.Context, msgs []Message) error
+	Close() error
+}
+
+// Bus delivers events to in-process subscribers and, for exported topics,
+// to external transports such as Kafka or NATS. Forwarding happens on a
+// background goroutine so a slow transport never holds up a publisher. A
+// nil Bus discards every event.
+type Bus struct {
+	transports []Transport
+	// exported limits which topics reach transports; nil exports every topic
+	exported map[string]bool
+	queue    chan Message
+	dropped  atomic.Int64
+
+	mu       sync.RWMutex
+	handlers map[string]map[int]Handler
+	nextID   int
+}
+
+// New creates a bus forwarding the exported topics to transports. Call Run
+// to start forwarding.
+func New(transports []Transport, exportedTopics []string, queueSize int) *Bus {
+	if queueSize <= 0 {
+		queueSize = 10000
+	}
+	var exported map[string]bool
+	if len(exportedTopics) > 0 {
+		exported = make(map[string]bool, len(exportedTopics))
+		for _, topic := range exportedTopics {
+			exported[topic] = true
+		}
+	}
+	return &Bus{
+		transports: transports,
+		exported:   exported,
+		queue:      make(chan Message, queueSize),
+		handlers:   make(map[string]map[int]Handler),
+	}
+}
+
+// Publish sends an event to the topic's subscribers and queues it for the
+// transports
+func (b *Bus) Publish(ctx context.Context, topic, key, eventType string, payload interface{}) error {
+	if b == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+	msg := Message{
+		Topic:       topic,
+		Key:         key,
+		Type:        eventType,
+		Payload:     data,
+		PublishedAt: time.Now().UTC(),
+	}
+
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers[topic]))
+	for _, h := range b.handlers[topic] {
+		handlers = append(handlers, h)
+	}
+	b.mu.RUnlock()
+	for _, h := range handlers {
+		h(ctx, msg)
+	}
+
+	if len(b.transports) == 0 || (b.exported != nil && !b.exported[topic]) {
+		return nil
+	}
+	select {
+	case b.queue <- msg:
+	default:
+		if b.dropped.Add(1)%1000 == 1 {
+			log.Printf("Event bus queue full, dropped %d events so far", b.dropped.Load())
+		}
+	}
+	return nil
+}
+
+// Subscribe registers h for a topic and returns a function that removes it
+func (b *Bus) Subscribe(topic string, h Handler) func() {
+	if b == nil {
+		return func() {}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.handlers[topic] == nil {
+		b.handlers[topic] = make(map[int]Handler)
+	}
+	id := b.nextID
+	b.nextID++
+	b.handlers[topic][id] = h
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers[topic], id)
+	}
+}
+
+// Run forwards queued messages to the transports until the context is
+// cancelled, then closes them. Messages waiting in the queue are sent
+// together, up to maxBatch at a time. A failed send is logged and not
+// retried.
+func (b *Bus) Run(ctx context.Context) {
+	defer func() {
+		for _, t := range b.transports {
+			if err := t.Close(); err != nil {
+				log.Printf("Failed to close %s transport: %v", t.Name(), err)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-b.queue:
+			batch := []Message{msg}
+		drain:
+			for len(batch) < maxBatch {
+				select {
+				case msg := <-b.queue:
+					batch = append(batch, msg)
+				default:
+					break drain
+				}
+			}
+
+			for _, t := range b.transports {
+				if err := t.Send(ctx, batch); err != nil {
+					log.Printf("Failed to forward %d events to %s: %v", len(batch), t.Name(), err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingTransport struct {
+	mu   sync.Mutex
+	msgs []Message
+}
+
+func (t *recordingTransport) Name() string { return "recording" }
+func (t *recordingTransport) Close() error { return nil }
+
+func (t *recordingTransport) Send(ctx context.Context, msgs []Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.msgs = append(t.msgs, msgs...)
+	return nil
+}
+
+func (t *recordingTransport) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.msgs)
+}
+
+func TestBus(t *testing.T) {
+	transport := &recordingTransport{}
+	bus := New([]Transport{transport}, []string{TopicLiveScoring}, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go bus.Run(ctx)
+
+	var received []Message
+	unsubscribe := bus.Subscribe(TopicLeagueSync, func(ctx context.Context, msg Message) {
+		received = append(received, msg)
+	})
+
+	require.NoError(t, bus.Publish(ctx, TopicLeagueSync, "league-1", "league.synced", map[string]int{"week": 3}))
+	require.NoError(t, bus.Publish(ctx, TopicLiveScoring, "league-1", "matchups.updated", []int{1}))
+
+	// Subscribers get their topic synchronously
+	require.Len(t, received, 1)
+	assert.Equal(t, "league.synced", received[0].Type)
+	assert.JSONEq(t, `{"week": 3}`, string(received[0].Payload))
+
+	// Only exported topics reach the transport
+	require.Eventually(t, func() bool { return transport.count() == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, TopicLiveScoring, transport.msgs[0].Topic)
+
+	unsubscribe()
+	require.NoError(t, bus.Publish(ctx, TopicLeagueSync, "league-1", "league.synced", nil))
+	assert.Len(t, received, 1)
+
+	// A nil bus discards events
+	var none *Bus
+	assert.NoError(t, none.Publish(ctx, TopicLeagueSync, "", "league.synced", nil))
+}
+
+func TestNATSTransport(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	published := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "CONNECT"):
+				assert.Contains(t, line, `"auth_token":"secret"`)
+			case strings.HasPrefix(line, "PING"):
+				conn.Write([]byte("PONG\r\n"))
+			case strings.HasPrefix(line, "PUB"):
+				payload, _ := r.ReadString('\n')
+				published <- line + payload
+			}
+		}
+	}()
+
+	transport, err := NewNATSTransport("nats://secret@"+listener.Addr().String(), "nfl")
+	require.NoError(t, err)
+	defer transport.Close()
+
+	msg := Message{Topic: TopicLiveScoring, Key: "league-1", Type: "matchups.updated", Payload: json.RawMessage(`{}`)}
+	require.NoError(t, transport.Send(context.Background(), []Message{msg}))
+
+	select {
+	case got := <-published:
+		header, body, _ := strings.Cut(got, "\r\n")
+		assert.True(t, strings.HasPrefix(header, "PUB nfl.live_scoring "))
+		var decoded Message
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(body)), &decoded))
+		assert.Equal(t, "matchups.updated", decoded.Type)
+	case <-time.After(time.Second):
+		t.Fatal("nothing published")
+	}
+}
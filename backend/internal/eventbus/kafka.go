@@ -0,0 +1,85 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// KafkaTransport produces messages to Kafka through a Confluent-compatible
+// REST proxy. Each bus topic maps to the Kafka topic prefix.topic, and the
+// message key keeps a league's events on one partition in order.
+type KafkaTransport struct {
+	endpoint   string
+	prefix     string
+	httpClient *http.Client
+}
+
+// NewKafkaTransport creates a transport producing through the REST proxy at
+// endpoint
+func NewKafkaTransport(endpoint, topicPrefix string) *KafkaTransport {
+	return &KafkaTransport{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		prefix:     topicPrefix,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name identifies the transport in logs
+func (t *KafkaTransport) Name() string { return "kafka" }
+
+// Send produces the messages, one request per Kafka topic
+func (t *KafkaTransport) Send(ctx context.Context, msgs []Message) error {
+	type record struct {
+		Key   string  `json:"key,omitempty"`
+		Value Message `json:"value"`
+	}
+	byTopic := make(map[string][]record)
+	var order []string
+	for _, msg := range msgs {
+		topic := qualify(t.prefix, msg.Topic, ".")
+		if _, ok := byTopic[topic]; !ok {
+			order = append(order, topic)
+		}
+		byTopic[topic] = append(byTopic[topic], record{Key: msg.Key, Value: msg})
+	}
+
+	for _, topic := range order {
+		data, err := json.Marshal(map[string]interface{}{"records": byTopic[topic]})
+		if err != nil {
+			return fmt.Errorf("failed to marshal records: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint+"/topics/"+topic, bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+
+		resp, err := t.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to produce to %s: %w", topic, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("kafka proxy returned status %d for %s", resp.StatusCode, topic)
+		}
+	}
+
+	return nil
+}
+
+// Close has nothing to release
+func (t *KafkaTransport) Close() error { return nil }
+
+// qualify joins a prefix and a topic with sep, or returns the topic alone
+func qualify(prefix, topic, sep string) string {
+	if prefix == "" {
+		return topic
+	}
+	return prefix + sep + topic
+}
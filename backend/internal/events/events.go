@@ -0,0 +1,207 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Product event names
+const (
+	SessionCreated         = "session_created"
+	PickRecorded           = "pick_recorded"
+	RecommendationViewed   = "recommendation_viewed"
+	RecommendationAccepted = "recommendation_accepted"
+	LeagueConnected        = "league_connected"
+)
+
+// ClientEvents are the events clients may report themselves; the rest are
+// only emitted by the server
+var ClientEvents = map[string]bool{
+	RecommendationViewed:   true,
+	RecommendationAccepted: true,
+}
+
+// consentCacheTTL is how long a user's consent answer is reused before it
+// is looked up again
+const consentCacheTTL = 5 * time.Minute
+
+// Event is one product analytics event
+type Event struct {
+	MessageID  string                 `json:"messageId"`
+	Name       string                 `json:"event"`
+	UserID     string                 `json:"userId"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+}
+
+// Sink delivers a batch of events to an analytics backend
+type Sink interface {
+	Send(ctx context.Context, events []Event) error
+}
+
+// ConsentStore looks up whether a user agreed to product analytics. A nil
+// answer means the user has not chosen yet.
+type ConsentStore interface {
+	GetAnalyticsConsent(ctx context.Context, userID uuid.UUID) (*bool, error)
+}
+
+// Options tunes how events are queued and delivered
+type Options struct {
+	// DefaultConsent applies to users who have not chosen
+	DefaultConsent bool
+	QueueSize      int
+	BatchSize      int
+	FlushInterval  time.Duration
+}
+
+type consentEntry struct {
+	consented bool
+	expires   time.Time
+}
+
+// Tracker queues product events and delivers them to a sink in batches
+// from a background goroutine, so tracking never slows a request down.
+// Events from users without analytics consent are dropped before they
+// leave the process. A nil Tracker discards every event.
+type Tracker struct {
+	sink    Sink
+	consent ConsentStore
+	opts    Options
+	queue   chan Event
+	dropped atomic.Int64
+
+	mu       sync.Mutex
+	consents map[uuid.UUID]consentEntry
+}
+
+// NewTracker creates a tracker; call Run to start delivery
+func NewTracker(sink Sink, consent ConsentStore, opts Options) *Tracker {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 10000
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 10 * time.Second
+	}
+	return &Tracker{
+		sink:     sink,
+		consent:  consent,
+		opts:     opts,
+		queue:    make(chan Event, opts.QueueSize),
+		consents: make(map[uuid.UUID]consentEntry),
+	}
+}
+
+// Track queues an event for userID. It never blocks: when the queue is full
+// the event is dropped and counted.
+func (t *Tracker) Track(userID uuid.UUID, name string, properties map[string]interface{}) {
+	if t == nil {
+		return
+	}
+
+	event := Event{
+		MessageID:  uuid.NewString(),
+		Name:       name,
+		UserID:     userID.String(),
+		Properties: properties,
+		Timestamp:  time.Now().UTC(),
+	}
+
+	select {
+	case t.queue <- event:
+	default:
+		if t.dropped.Add(1)%1000 == 1 {
+			log.Printf("Analytics queue full, dropped %d events so far", t.dropped.Load())
+		}
+	}
+}
+
+// ForgetConsent drops the cached consent answer for a user, so a change
+// takes effect on their next event
+func (t *Tracker) ForgetConsent(userID uuid.UUID) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	delete(t.consents, userID)
+	t.mu.Unlock()
+}
+
+// Run delivers queued events until the context is cancelled, then flushes
+// what it has
+func (t *Tracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, t.opts.BatchSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		if err := t.sink.Send(ctx, batch); err != nil {
+			log.Printf("Failed to send %d analytics events: %v", len(batch), err)
+		}
+		batch = make([]Event, 0, t.opts.BatchSize)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			flush(shutdownCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			flush(ctx)
+		case event := <-t.queue:
+			if !t.hasConsent(ctx, event.UserID) {
+				continue
+			}
+			batch = append(batch, event)
+			if len(batch) >= t.opts.BatchSize {
+				flush(ctx)
+			}
+		}
+	}
+}
+
+// hasConsent reports whether a user's events may be sent. Lookup failures
+// are treated as no consent.
+func (t *Tracker) hasConsent(ctx context.Context, userID string) bool {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return false
+	}
+
+	t.mu.Lock()
+	entry, ok := t.consents[id]
+	t.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.consented
+	}
+
+	consented := t.opts.DefaultConsent
+	if t.consent != nil {
+		answer, err := t.consent.GetAnalyticsConsent(ctx, id)
+		if err != nil {
+			log.Printf("Failed to look up analytics consent for user %s: %v", id, err)
+			return false
+		}
+		if answer != nil {
+			consented = *answer
+		}
+	}
+
+	t.mu.Lock()
+	t.consents[id] = consentEntry{consented: consented, expires: time.Now().Add(consentCacheTTL)}
+	t.mu.Unlock()
+
+	return consented
+}
@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *recordingSink) Send(ctx context.Context, events []Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func (s *recordingSink) names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	for _, e := range s.events {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+type consentMap map[uuid.UUID]bool
+
+func (m consentMap) GetAnalyticsConsent(ctx context.Context, userID uuid.UUID) (*bool, error) {
+	consented, ok := m[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &consented, nil
+}
+
+func TestTrackerConsent(t *testing.T) {
+	optedIn, optedOut, undecided := uuid.New(), uuid.New(), uuid.New()
+	sink := &recordingSink{}
+	tracker := NewTracker(sink, consentMap{optedIn: true, optedOut: false}, Options{
+		BatchSize:     2,
+		FlushInterval: 10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		tracker.Run(ctx)
+		close(done)
+	}()
+
+	tracker.Track(optedIn, SessionCreated, map[string]interface{}{"draft_type": "snake"})
+	tracker.Track(optedOut, SessionCreated, nil)
+	tracker.Track(undecided, LeagueConnected, nil)
+	tracker.Track(optedIn, PickRecorded, nil)
+
+	require.Eventually(t, func() bool { return len(sink.names()) == 2 }, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+
+	// Only the consenting user's events leave the process
+	assert.Equal(t, []string{SessionCreated, PickRecorded}, sink.names())
+	assert.Equal(t, optedIn.String(), sink.events[0].UserID)
+	assert.NotEmpty(t, sink.events[0].MessageID)
+
+	// A nil tracker discards events
+	var none *Tracker
+	none.Track(optedIn, PickRecorded, nil)
+}
+
+func TestSegmentSink(t *testing.T) {
+	var body struct {
+		Batch []map[string]interface{} `json:"batch"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/batch", r.URL.Path)
+		key, _, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "write-key", key)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+
+	sink := NewSegmentSink(server.URL+"/", "write-key")
+	err := sink.Send(context.Background(), []Event{{
+		MessageID:  "m1",
+		Name:       RecommendationAccepted,
+		UserID:     "u1",
+		Properties: map[string]interface{}{"recommendation_rank": 2},
+		Timestamp:  time.Now(),
+	}})
+	require.NoError(t, err)
+
+	require.Len(t, body.Batch, 1)
+	assert.Equal(t, "track", body.Batch[0]["type"])
+	assert.Equal(t, RecommendationAccepted, body.Batch[0]["event"])
+	assert.Equal(t, "u1", body.Batch[0]["userId"])
+	assert.Equal(t, "m1", body.Batch[0]["messageId"])
+}
@@ -0,0 +1,123 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SegmentSink sends events to a Segment-compatible HTTP tracking API
+type SegmentSink struct {
+	endpoint   string
+	writeKey   string
+	httpClient *http.Client
+}
+
+// NewSegmentSink creates a sink posting to endpoint's /v1/batch with the
+// source write key
+func NewSegmentSink(endpoint, writeKey string) *SegmentSink {
+	return &SegmentSink{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		writeKey:   writeKey,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send posts the events as one batch of track calls
+func (s *SegmentSink) Send(ctx context.Context, events []Event) error {
+	type track struct {
+		Type string `json:"type"`
+		Event
+	}
+	batch := make([]track, len(events))
+	for i, e := range events {
+		batch[i] = track{Type: "track", Event: e}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"batch": batch})
+	if err != nil {
+		return fmt.Errorf("failed to marshal events: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/v1/batch", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(s.writeKey, "")
+
+	return send(s.httpClient, req)
+}
+
+// KafkaSink produces events to a Kafka topic through a Confluent-compatible
+// REST proxy, keyed by user so each user's events stay in order
+type KafkaSink struct {
+	endpoint   string
+	topic      string
+	httpClient *http.Client
+}
+
+// NewKafkaSink creates a sink producing to topic through the REST proxy at
+// endpoint
+func NewKafkaSink(endpoint, topic string) *KafkaSink {
+	return &KafkaSink{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		topic:      topic,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send produces the events as one batch of records
+func (s *KafkaSink) Send(ctx context.Context, events []Event) error {
+	type record struct {
+		Key   string `json:"key"`
+		Value Event  `json:"value"`
+	}
+	records := make([]record, len(events))
+	for i, e := range events {
+		records[i] = record{Key: e.UserID, Value: e}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return fmt.Errorf("failed to marshal events: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/topics/"+s.topic, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+
+	return send(s.httpClient, req)
+}
+
+// LogSink writes events to the log, for development
+type LogSink struct{}
+
+// Send logs each event
+func (LogSink) Send(ctx context.Context, events []Event) error {
+	for _, e := range events {
+		log.Printf("Analytics event %s user=%s properties=%v", e.Name, e.UserID, e.Properties)
+	}
+	return nil
+}
+
+// send performs req and treats any non-2xx status as an error
+func send(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("analytics sink returned status %d", resp.StatusCode)
+	}
+	return nil
+}
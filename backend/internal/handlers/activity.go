@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/activity"
+)
+
+// maxActivityLimit bounds ?limit= on a user's activity
+const maxActivityLimit = 100
+
+// ActivityHandler shows users their own account activity
+type ActivityHandler struct {
+	repo activity.Repository
+}
+
+// NewActivityHandler creates a new activity handler
+func NewActivityHandler(repo activity.Repository) *ActivityHandler {
+	return &ActivityHandler{repo: repo}
+}
+
+// GetActivity handles GET /api/users/activity. Filters: action (comma
+// separated), since and until (RFC 3339 or YYYY-MM-DD), q to search the
+// device, IP address and details, and limit. Pass next_before from a
+// response as before to page back.
+func (h *ActivityHandler) GetActivity(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > maxActivityLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+
+	filter := activity.Filter{
+		Query: strings.TrimSpace(c.Query("q")),
+		Limit: limit,
+	}
+	if raw := c.Query("action"); raw != "" {
+		for _, action := range strings.Split(raw, ",") {
+			if action = strings.TrimSpace(action); action != "" {
+				filter.Actions = append(filter.Actions, action)
+			}
+		}
+	}
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"since", &filter.Since},
+		{"until", &filter.Until},
+		{"before", &filter.Before},
+	} {
+		raw := c.Query(p.name)
+		if raw == "" {
+			continue
+		}
+		t, err := parseActivityTime(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": p.name + " must be an RFC 3339 time or YYYY-MM-DD date"})
+			return
+		}
+		*p.dst = t
+	}
+
+	entries, err := h.repo.List(c.Request.Context(), userID, filter)
+	if err != nil {
+		log.Printf("Failed to list activity for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch activity"})
+		return
+	}
+	if entries == nil {
+		entries = []activity.Entry{}
+	}
+
+	response := gin.H{
+		"activity": entries,
+		"count":    len(entries),
+	}
+	if len(entries) == limit {
+		response["next_before"] = entries[len(entries)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// parseActivityTime reads an RFC 3339 time or a date, taken as midnight UTC
+func parseActivityTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
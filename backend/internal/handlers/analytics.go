@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/analytics"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// AnalyticsHandler serves precomputed league analytics
+type AnalyticsHandler struct {
+	leagueRepo    repositories.LeagueRepository
+	analyticsRepo analytics.Repository
+	authz         *policy.Authorizer
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(leagueRepo repositories.LeagueRepository, analyticsRepo analytics.Repository) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		leagueRepo:    leagueRepo,
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+// WithAuthorizer sets who besides a league's owner may read its analytics
+func (h *AnalyticsHandler) WithAuthorizer(a *policy.Authorizer) *AnalyticsHandler {
+	h.authz = a
+	return h
+}
+
+// GetLeagueAnalytics handles GET /api/leagues/:id/analytics
+func (h *AnalyticsHandler) GetLeagueAnalytics(c *gin.Context) {
+	league, ok := h.league(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	result, err := h.analyticsRepo.GetLeagueAnalytics(ctx, league.ID.String())
+	if err != nil {
+		log.Printf("Failed to get analytics for league %s: %v", league.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch league analytics"})
+		return
+	}
+	if result == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League analytics have not been computed yet"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetRecap handles GET /api/leagues/:id/recap. Pass week for an earlier week
+// of the season, and format=text for the recap as a plain text script.
+func (h *AnalyticsHandler) GetRecap(c *gin.Context) {
+	week := 0
+	if raw := c.Query("week"); raw != "" {
+		var err error
+		week, err = strconv.Atoi(raw)
+		if err != nil || week < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid week"})
+			return
+		}
+	}
+
+	league, ok := h.league(c)
+	if !ok {
+		return
+	}
+
+	recap, err := h.analyticsRepo.GetRecap(c.Request.Context(), league.ID.String(), week)
+	if err != nil {
+		log.Printf("Failed to get recap for league %s: %v", league.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch league recap"})
+		return
+	}
+	if recap == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League recap has not been built yet"})
+		return
+	}
+
+	if c.Query("format") == "text" {
+		c.String(http.StatusOK, recap.Script())
+		return
+	}
+	c.JSON(http.StatusOK, recap)
+}
+
+// league returns the league in the path if the current user may read it,
+// responding with an error otherwise
+func (h *AnalyticsHandler) league(c *gin.Context) (*models.League, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return nil, false
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid league ID"})
+		return nil, false
+	}
+
+	ctx := c.Request.Context()
+	league, err := h.leagueRepo.GetByID(ctx, leagueID.String())
+	if err == nil {
+		err = h.authz.Authorize(ctx, policy.User(userID.String()), policy.ActionRead, policy.League(league))
+	}
+	if errors.Is(err, repositories.ErrLeagueNotFound) || errors.Is(err, policy.ErrForbidden) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found"})
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("Failed to get league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch league"})
+		return nil, false
+	}
+	return league, true
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/archive"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// ArchiveHandler serves the archived seasons of a user's leagues, and runs
+// the archival job for admins
+type ArchiveHandler struct {
+	archiver      *archive.Archiver
+	leagueService services.LeagueService
+}
+
+// NewArchiveHandler creates a new archive handler
+func NewArchiveHandler(archiver *archive.Archiver, leagueService services.LeagueService) *ArchiveHandler {
+	return &ArchiveHandler{archiver: archiver, leagueService: leagueService}
+}
+
+// ListArchives handles GET /api/leagues/:id/archives
+func (h *ArchiveHandler) ListArchives(c *gin.Context) {
+	leagueID, ok := h.ownedLeague(c)
+	if !ok {
+		return
+	}
+
+	archives, err := h.archiver.List(c.Request.Context(), leagueID)
+	if err != nil {
+		log.Printf("Failed to list archives for league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list archives"})
+		return
+	}
+	if archives == nil {
+		archives = []archive.Archive{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"archives": archives})
+}
+
+// GetArchivedSeason handles GET /api/leagues/:id/archives/:season, the
+// weekly team summaries kept for an archived season
+func (h *ArchiveHandler) GetArchivedSeason(c *gin.Context) {
+	leagueID, ok := h.ownedLeague(c)
+	if !ok {
+		return
+	}
+	season, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+
+	summaries, err := h.archiver.Summaries(c.Request.Context(), leagueID, season)
+	if err != nil {
+		log.Printf("Failed to get summaries for league %s season %d: %v", leagueID, season, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get archived season"})
+		return
+	}
+	if summaries == nil {
+		summaries = []archive.WeeklySummary{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season": season,
+		"weeks":  summaries,
+	})
+}
+
+// Rehydrate handles POST /api/leagues/:id/archives/:season/rehydrate. The
+// season's rosters and box scores are loaded back from object storage, so
+// roster history, rule simulations and other deep history work for it again
+// until the archival job next archives it.
+func (h *ArchiveHandler) Rehydrate(c *gin.Context) {
+	leagueID, ok := h.ownedLeague(c)
+	if !ok {
+		return
+	}
+	season, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+
+	restored, err := h.archiver.Rehydrate(c.Request.Context(), leagueID, season)
+	if errors.Is(err, archive.ErrNotArchived) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to rehydrate league %s season %d: %v", leagueID, season, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to rehydrate season"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"archives": restored})
+}
+
+// Run handles POST /api/admin/archives/run, running the archival job now
+func (h *ArchiveHandler) Run(c *gin.Context) {
+	report, err := h.archiver.Run(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to run season archival: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run season archival"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ownedLeague parses the league ID and checks it belongs to the user
+func (h *ArchiveHandler) ownedLeague(c *gin.Context) (string, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return "", false
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return "", false
+	}
+
+	if _, err := h.leagueService.GetLeague(c.Request.Context(), userID, leagueID); err != nil {
+		if errors.Is(err, repositories.ErrLeagueNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+			return "", false
+		}
+		log.Printf("Failed to get league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get league"})
+		return "", false
+	}
+	return leagueID.String(), true
+}
+
+func seasonParam(c *gin.Context) (int, bool) {
+	season, err := strconv.Atoi(c.Param("season"))
+	if err != nil || season < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid season"})
+		return 0, false
+	}
+	return season, true
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/assistant"
+	"github.com/nfl-analytics/backend/internal/integrations/llm"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// AskRequest is a question for the assistant
+type AskRequest struct {
+	Question string `json:"question" binding:"required,max=500"`
+	// LeagueID defaults to the user's selected league
+	LeagueID string `json:"league_id,omitempty"`
+	// TeamID is the user's team in the league, to include their roster
+	TeamID int `json:"team_id,omitempty" binding:"min=0"`
+	// Season and Week default to the league's latest synced week
+	Season int `json:"season,omitempty" binding:"min=0"`
+	Week   int `json:"week,omitempty" binding:"min=0,max=22"`
+}
+
+// AssistantHandler answers natural language roster questions
+type AssistantHandler struct {
+	assistant *assistant.Assistant
+}
+
+// NewAssistantHandler creates a new assistant handler. a may be nil when no
+// LLM provider is configured.
+func NewAssistantHandler(a *assistant.Assistant) *AssistantHandler {
+	return &AssistantHandler{assistant: a}
+}
+
+// Ask handles POST /api/assistant/ask
+func (h *AssistantHandler) Ask(c *gin.Context) {
+	if h.assistant == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "The assistant is not enabled"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req AskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	q := assistant.Question{Text: req.Question, TeamID: req.TeamID, Season: req.Season, Week: req.Week}
+	if req.LeagueID != "" {
+		leagueID, err := uuid.Parse(req.LeagueID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid league ID"})
+			return
+		}
+		q.LeagueID = leagueID
+	}
+
+	answer, err := h.assistant.Ask(c.Request.Context(), userID, q)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, answer)
+	case errors.Is(err, repositories.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found"})
+	case errors.Is(err, assistant.ErrWeekRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Select a league or pass season and week"})
+	case errors.Is(err, assistant.ErrNoFacts):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "We have no projections or roster data for that question"})
+	case errors.Is(err, llm.ErrRateLimited):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "The assistant is busy, try again shortly"})
+	default:
+		log.Printf("Failed to answer question for user %s: %v", userID, err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to answer the question"})
+	}
+}
@@ -1,19 +1,29 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
 	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/loginguard"
 	"github.com/nfl-analytics/backend/internal/models"
 	"github.com/nfl-analytics/backend/internal/services"
 )
 
 // AuthHandler handles authentication-related HTTP requests
 type AuthHandler struct {
-	authService services.AuthService
+	authService   services.AuthService
+	botProtection *auth.BotProtection
+	activity      *activity.Recorder
+	// cookies is set when clients may ask for tokens as cookies
+	cookies  *auth.CookieConfig
+	denylist *auth.Denylist
 }
 
 // NewAuthHandler creates a new auth handler
@@ -23,6 +33,90 @@ func NewAuthHandler(authService services.AuthService) *AuthHandler {
 	}
 }
 
+// WithBotProtection screens registrations with CAPTCHA and throwaway email
+// checks
+func (h *AuthHandler) WithBotProtection(bp *auth.BotProtection) *AuthHandler {
+	h.botProtection = bp
+	return h
+}
+
+// WithActivity records sign-ups, logins and logouts in the user's activity
+func (h *AuthHandler) WithActivity(r *activity.Recorder) *AuthHandler {
+	h.activity = r
+	return h
+}
+
+// WithCookies lets browser clients ask for tokens as httpOnly cookies with
+// the X-Token-Delivery: cookie header
+func (h *AuthHandler) WithCookies(cfg auth.CookieConfig) *AuthHandler {
+	h.cookies = &cfg
+	return h
+}
+
+// WithDenylist revokes access tokens on logout and session revocation,
+// instead of leaving them valid until they expire
+func (h *AuthHandler) WithDenylist(d *auth.Denylist) *AuthHandler {
+	h.denylist = d
+	return h
+}
+
+// respond sends an auth response. In cookie mode the tokens are set as
+// cookies and replaced in the body by the CSRF token.
+func (h *AuthHandler) respond(c *gin.Context, status int, response *models.AuthResponse, cookies bool) {
+	if h.cookies == nil || !cookies || response.AccessToken == "" {
+		c.JSON(status, response)
+		return
+	}
+
+	body, err := setTokenCookies(c, *h.cookies, response)
+	if err != nil {
+		log.Printf("Failed to set auth cookies: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "login failed"})
+		return
+	}
+	c.JSON(status, body)
+}
+
+// setTokenCookies sets response's tokens as cookies and returns the response
+// to send in its place, with the CSRF token instead of the tokens
+func setTokenCookies(c *gin.Context, cfg auth.CookieConfig, response *models.AuthResponse) (*models.AuthResponse, error) {
+	// Remember-me sessions outlive the standard refresh cookie
+	if response.RefreshExpiresAt != nil {
+		cfg.RefreshTTL = time.Until(*response.RefreshExpiresAt)
+	}
+	csrfToken, err := auth.SetAuthCookies(c, cfg, response.AccessToken, response.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	body := *response
+	body.AccessToken, body.RefreshToken = "", ""
+	body.CSRFToken = csrfToken
+	return &body, nil
+}
+
+// checkBot writes an error response if bot protection rejects the request.
+// The CAPTCHA token may come from the request body or the X-Captcha-Token
+// header.
+func (h *AuthHandler) checkBot(c *gin.Context, email, captchaToken string) bool {
+	if captchaToken == "" {
+		captchaToken = c.GetHeader("X-Captcha-Token")
+	}
+
+	err := h.botProtection.Check(c.Request.Context(), email, captchaToken, c.ClientIP())
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, auth.ErrDisposableEmail):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "please use a permanent email address"})
+	case errors.Is(err, auth.ErrCaptchaRequired), errors.Is(err, auth.ErrCaptchaFailed):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "captcha verification failed"})
+	default:
+		log.Printf("Captcha verification error: %v", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "captcha verification unavailable, try again shortly"})
+	}
+	return false
+}
+
 // Register handles user registration
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req models.RegisterRequest
@@ -37,7 +131,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Register(c.Request.Context(), &req)
+	if !h.checkBot(c, req.Email, req.CaptchaToken) {
+		return
+	}
+
+	response, err := h.authService.Register(auth.WithClient(c.Request.Context(), c), &req)
 	if err != nil {
 		switch err {
 		case services.ErrEmailExists:
@@ -54,7 +152,8 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, response)
+	h.activity.Record(c, response.User.ID, activity.ActionAccountCreated, "", "", map[string]interface{}{"method": "password"})
+	h.respond(c, http.StatusCreated, response, auth.WantsCookies(c))
 }
 
 // Login handles user login
@@ -71,7 +170,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Login(c.Request.Context(), &req)
+	response, err := h.authService.Login(auth.WithClient(c.Request.Context(), c), &req)
 	if err != nil {
 		switch err {
 		case services.ErrInvalidCredentials:
@@ -81,16 +180,87 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		}
 		return
 	}
+	// Logins waiting on a code are recorded once verified
+	if !response.TwoFactorRequired && !response.VerificationRequired {
+		h.activity.Record(c, response.User.ID, activity.ActionLogin, "", "", map[string]interface{}{"method": "password"})
+	}
+
+	h.respond(c, http.StatusOK, response, auth.WantsCookies(c))
+}
+
+// VerifyTwoFactor finishes a login with a code from the user's authenticator
+// app or a backup code
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req models.TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "two_factor_token and code are required"})
+		return
+	}
+
+	response, err := h.authService.VerifyTwoFactor(auth.WithClient(c.Request.Context(), c), req.TwoFactorToken, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidToken), errors.Is(err, services.ErrTwoFactorNotEnabled),
+			errors.Is(err, services.ErrInvalidCredentials):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired two-factor token, log in again"})
+		case errors.Is(err, services.ErrInvalidTwoFactor):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid code"})
+		case errors.Is(err, services.ErrTwoFactorLocked):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		default:
+			log.Printf("Two-factor verification failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "login failed"})
+		}
+		return
+	}
+
+	h.activity.Record(c, response.User.ID, activity.ActionLogin, "", "", map[string]interface{}{"method": "password", "two_factor": true})
+	h.respond(c, http.StatusOK, response, auth.WantsCookies(c))
+}
+
+// VerifyLogin finishes a login from a new device with the code emailed to
+// the user
+func (h *AuthHandler) VerifyLogin(c *gin.Context) {
+	var req models.LoginVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "verification_token and code are required"})
+		return
+	}
+
+	response, err := h.authService.VerifyLogin(auth.WithClient(c.Request.Context(), c), req.VerificationToken, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidToken), errors.Is(err, services.ErrInvalidCredentials):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired verification token, log in again"})
+		case errors.Is(err, loginguard.ErrInvalidCode):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid code"})
+		default:
+			log.Printf("Login verification failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "login failed"})
+		}
+		return
+	}
 
-	c.JSON(http.StatusOK, response)
+	h.activity.Record(c, response.User.ID, activity.ActionLogin, "", "", map[string]interface{}{"method": "password", "email_verified": true})
+	h.respond(c, http.StatusOK, response, auth.WantsCookies(c))
 }
 
 // RefreshToken handles token refresh
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req models.RefreshTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+	}
+
+	// Browsers in cookie mode send the refresh token as a cookie
+	fromCookie := false
+	if req.RefreshToken == "" && h.cookies != nil {
+		if token, err := c.Cookie(auth.RefreshTokenCookie); err == nil && token != "" {
+			req.RefreshToken, fromCookie = token, true
+		}
 	}
 
 	// Validate refresh token
@@ -99,7 +269,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
+	response, err := h.authService.RefreshToken(auth.WithClient(c.Request.Context(), c), req.RefreshToken)
 	if err != nil {
 		switch err {
 		case services.ErrInvalidToken:
@@ -110,7 +280,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response, fromCookie || auth.WantsCookies(c))
 }
 
 // Logout handles user logout
@@ -133,5 +303,78 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
+	// Logout signs out every device, so their access tokens go too
+	if tokenID, expiresAt, ok := auth.GetTokenID(c); ok {
+		if err := h.denylist.RevokeToken(c.Request.Context(), tokenID, expiresAt); err != nil {
+			log.Printf("Failed to revoke access token for user %s: %v", uid, err)
+		}
+	}
+	if err := h.denylist.RevokeUser(c.Request.Context(), uid); err != nil {
+		log.Printf("Failed to revoke access tokens for user %s: %v", uid, err)
+	}
+
+	if h.cookies != nil {
+		auth.ClearAuthCookies(c, *h.cookies)
+	}
+	h.activity.Record(c, uid, activity.ActionLogout, "", "", nil)
 	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
-}
\ No newline at end of file
+}
+
+// sessionResponse is a session with the device its user agent describes
+type sessionResponse struct {
+	models.Session
+	Device activity.Device `json:"device"`
+}
+
+// ListSessions handles GET /api/auth/sessions, listing the devices the user
+// is signed in on
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	currentID, _ := auth.GetSessionID(c)
+
+	sessions, err := h.authService.ListSessions(c.Request.Context(), userID, currentID)
+	if err != nil {
+		log.Printf("Failed to list sessions for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list sessions"})
+		return
+	}
+
+	response := make([]sessionResponse, len(sessions))
+	for i, s := range sessions {
+		response[i] = sessionResponse{Session: s, Device: activity.DescribeDevice(s.UserAgent)}
+	}
+	c.JSON(http.StatusOK, gin.H{"sessions": response})
+}
+
+// RevokeSession handles DELETE /api/auth/sessions/:id, signing one device
+// out
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+		return
+	}
+
+	err = h.authService.RevokeSession(c.Request.Context(), userID, sessionID)
+	switch {
+	case errors.Is(err, services.ErrSessionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+	case err != nil:
+		log.Printf("Failed to revoke session %s for user %s: %v", sessionID, userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke session"})
+	default:
+		if err := h.denylist.RevokeSession(c.Request.Context(), sessionID); err != nil {
+			log.Printf("Failed to revoke access tokens for session %s: %v", sessionID, err)
+		}
+		h.activity.Record(c, userID, activity.ActionSessionRevoked, "session", sessionID.String(), nil)
+		c.JSON(http.StatusOK, gin.H{"message": "session revoked"})
+	}
+}
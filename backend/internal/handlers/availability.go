@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// AvailabilityHandler serves and curates suspensions, holdouts and PUP
+// stints. No feed reports holdouts or suspension lengths, so they are
+// entered by admins.
+type AvailabilityHandler struct {
+	repo projections.AvailabilityRepository
+}
+
+// NewAvailabilityHandler creates a new availability handler
+func NewAvailabilityHandler(repo projections.AvailabilityRepository) *AvailabilityHandler {
+	return &AvailabilityHandler{repo: repo}
+}
+
+// AvailabilityRequest records a player's suspension, holdout or PUP stint.
+// A zero weeks means the length is unknown, except on PUP, where it
+// defaults to the four games the list requires.
+type AvailabilityRequest struct {
+	Season     int    `json:"season"`
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name" binding:"required"`
+	Position   string `json:"position"`
+	Team       string `json:"team"`
+	Status     string `json:"status" binding:"required"`
+	StartWeek  int    `json:"start_week" binding:"min=0,max=18"`
+	Weeks      int    `json:"weeks" binding:"min=0,max=18"`
+	Notes      string `json:"notes"`
+}
+
+// List handles GET /api/players/availability, listing a season's stints
+// (?season=, the current season by default) with the games each player
+// still misses from ?week= (default 1)
+func (h *AvailabilityHandler) List(c *gin.Context) {
+	season, week, ok := seasonWeekParams(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.repo.GetAvailability(c.Request.Context(), season)
+	if err != nil {
+		log.Printf("Failed to get %d player availability: %v", season, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get player availability"})
+		return
+	}
+	if list == nil {
+		list = []projections.Availability{}
+	}
+	for i := range list {
+		list[i].WeeksRemaining = list[i].MissedWeeks(week, 0)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"season": season, "week": week, "players": list})
+}
+
+// Upsert handles PUT /api/admin/players/availability
+func (h *AvailabilityHandler) Upsert(c *gin.Context) {
+	var req AvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := strings.ToUpper(req.Status)
+	if !slices.Contains(projections.AvailabilityStatuses, status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of " + strings.Join(projections.AvailabilityStatuses, ", ")})
+		return
+	}
+
+	a := &projections.Availability{
+		Season:     req.Season,
+		PlayerID:   req.PlayerID,
+		PlayerName: strings.TrimSpace(req.PlayerName),
+		Position:   strings.ToUpper(req.Position),
+		Team:       strings.ToUpper(req.Team),
+		Status:     status,
+		StartWeek:  req.StartWeek,
+		Weeks:      req.Weeks,
+		Notes:      req.Notes,
+		Source:     "manual",
+	}
+	if a.Season == 0 {
+		a.Season = projections.CurrentSeason(time.Now())
+	}
+	if a.StartWeek == 0 {
+		a.StartWeek = 1
+	}
+	if a.Status == projections.AvailabilityPUP && a.Weeks == 0 {
+		a.Weeks = projections.PUPWeeks
+	}
+
+	if err := h.repo.UpsertAvailability(c.Request.Context(), a); err != nil {
+		log.Printf("Failed to save availability for %s: %v", a.PlayerName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save player availability"})
+		return
+	}
+
+	c.JSON(http.StatusOK, a)
+}
+
+// Delete handles DELETE /api/admin/players/availability, clearing a
+// player's stint (?player=&status=&season=) once it is over or overturned
+func (h *AvailabilityHandler) Delete(c *gin.Context) {
+	season, _, ok := seasonWeekParams(c)
+	if !ok {
+		return
+	}
+	player := c.Query("player")
+	status := strings.ToUpper(c.Query("status"))
+	if player == "" || !slices.Contains(projections.AvailabilityStatuses, status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "player and status are required"})
+		return
+	}
+
+	if err := h.repo.DeleteAvailability(c.Request.Context(), season, player, status); err != nil {
+		log.Printf("Failed to delete availability for %s: %v", player, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete player availability"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Player availability cleared"})
+}
+
+// seasonWeekParams reads ?season= (the current season by default) and
+// ?week= (default 1), responding and returning false when either is invalid
+func seasonWeekParams(c *gin.Context) (int, int, bool) {
+	season := projections.CurrentSeason(time.Now())
+	if s := c.Query("season"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil || parsed < 1999 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season parameter"})
+			return 0, 0, false
+		}
+		season = parsed
+	}
+
+	week, err := strconv.Atoi(c.DefaultQuery("week", "1"))
+	if err != nil || week < 1 || week > 18 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "week must be between 1 and 18"})
+		return 0, 0, false
+	}
+	return season, week, true
+}
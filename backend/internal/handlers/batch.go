@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBatchIDs caps how many IDs one batch lookup can ask for
+const maxBatchIDs = 100
+
+// BatchRequest asks for several players at once
+type BatchRequest struct {
+	IDs    []string `json:"ids" binding:"required"`
+	Season int      `json:"season"`
+	Week   int      `json:"week"`
+}
+
+// bindBatch reads a batch request, dropping blank and repeated IDs, and
+// responds with an error if there are none or too many
+func bindBatch(c *gin.Context) (*BatchRequest, bool) {
+	var req BatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	seen := make(map[string]bool, len(req.IDs))
+	ids := make([]string, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids must name at least one player"})
+		return nil, false
+	}
+	if len(ids) > maxBatchIDs {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at most " + strconv.Itoa(maxBatchIDs) + " ids can be looked up at once"})
+		return nil, false
+	}
+	if req.Season < 0 || req.Week < 0 || req.Week > 22 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season or week"})
+		return nil, false
+	}
+
+	req.IDs = ids
+	return &req, true
+}
+
+// missingIDs lists the requested IDs with no result, in request order
+func missingIDs[T any](ids []string, found map[string]T) []string {
+	missing := []string{}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
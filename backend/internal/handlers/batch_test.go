@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/nflverse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRosters serves roster lookups from a fixed list
+type stubRosters struct {
+	nflverse.Repository
+	entries []nflverse.RosterEntry
+}
+
+func (s stubRosters) GetRosterEntries(ctx context.Context, season int, ids []string) ([]nflverse.RosterEntry, error) {
+	return s.entries, nil
+}
+
+func TestGetPlayersBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewPlayersHandler(nil, nil).WithRosters(stubRosters{entries: []nflverse.RosterEntry{
+		{PlayerID: "00-0036355", ESPNID: "4362628", PlayerName: "Ja'Marr Chase", Position: "WR", Team: "CIN"},
+		{PlayerID: "00-0033873", ESPNID: "3139477", PlayerName: "Patrick Mahomes", Position: "QB", Team: "KC"},
+	}})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/players/batch", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		h.GetPlayersBatch(c)
+		return w
+	}
+
+	w := post(`{"ids": ["4362628", "00-0033873", "4362628", " ", "999"], "season": 2025}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Players map[string]nflverse.RosterEntry `json:"players"`
+		Missing []string                        `json:"missing"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(t, body.Players, 2)
+	assert.Equal(t, "Ja'Marr Chase", body.Players["4362628"].PlayerName)
+	assert.Equal(t, "Patrick Mahomes", body.Players["00-0033873"].PlayerName)
+	assert.Equal(t, []string{"999"}, body.Missing)
+
+	assert.Equal(t, http.StatusBadRequest, post(`{"ids": []}`).Code)
+	ids := make([]string, maxBatchIDs+1)
+	for i := range ids {
+		ids[i] = strings.Repeat("1", i+1)
+	}
+	tooMany, _ := json.Marshal(map[string][]string{"ids": ids})
+	assert.Equal(t, http.StatusBadRequest, post(string(tooMany)).Code)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/events"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// CreateCustomLeague handles POST /api/leagues/custom, setting up a league
+// from a platform we have no client for
+func (h *LeagueHandler) CreateCustomLeague(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req services.CustomLeagueConfig
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	league, err := h.leagueService.CreateCustomLeague(c.Request.Context(), userID, &req)
+	if errors.Is(err, services.ErrInvalidLeagueConfig) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to create custom league for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create league"})
+		return
+	}
+
+	h.tracker.Track(userID, events.LeagueConnected, map[string]interface{}{
+		"platform":       services.PlatformCustom,
+		"host_platform":  req.HostPlatform,
+		"scoring_format": league.ScoringType,
+		"team_count":     len(req.Teams),
+	})
+	h.activity.Record(c, userID, activity.ActionLeagueConnected, "league", league.ID.String(), map[string]interface{}{
+		"platform":    services.PlatformCustom,
+		"league_name": league.Name,
+	})
+
+	c.JSON(http.StatusCreated, customLeagueResponse(league))
+}
+
+// UpdateCustomLeague handles PUT /api/leagues/custom/:id, replacing a
+// custom league's scoring, roster and teams
+func (h *LeagueHandler) UpdateCustomLeague(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+
+	var req services.CustomLeagueConfig
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	league, err := h.leagueService.UpdateCustomLeague(c.Request.Context(), userID, leagueID, &req)
+	switch {
+	case errors.Is(err, repositories.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+		return
+	case errors.Is(err, services.ErrInvalidLeagueConfig):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		log.Printf("Failed to update custom league %s for user %s: %v", leagueID, userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update league"})
+		return
+	}
+
+	c.JSON(http.StatusOK, customLeagueResponse(league))
+}
+
+// customLeagueResponse returns a custom league with the draft settings its
+// configuration implies, ready to start a draft session with
+func customLeagueResponse(league *models.League) gin.H {
+	var settings models.LeagueSettings
+	if err := json.Unmarshal(league.Settings, &settings); err != nil {
+		log.Printf("Failed to read settings for league %s: %v", league.ID, err)
+		return gin.H{"league": league}
+	}
+
+	roster := settings.Roster
+	return gin.H{
+		"league": league,
+		"draft": gin.H{
+			"league_id":   league.ID,
+			"team_count":  settings.TeamCount,
+			"round_count": settings.RosterSize,
+			"settings": models.DraftSettings{
+				ScoringType: settings.ScoringType,
+				RosterSlots: models.RosterSlots{
+					QB:    roster.QB,
+					RB:    roster.RB,
+					WR:    roster.WR,
+					TE:    roster.TE,
+					FLEX:  roster.FLEX,
+					DST:   roster.DST,
+					K:     roster.K,
+					BENCH: roster.BE,
+				},
+			},
+		},
+	}
+}
@@ -1,16 +1,42 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/auth"
 	"github.com/nfl-analytics/backend/internal/draft"
+	"github.com/nfl-analytics/backend/internal/eventbus"
+	"github.com/nfl-analytics/backend/internal/events"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/quota"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
 )
 
 // DraftHandler handles draft-related HTTP requests
 type DraftHandler struct {
 	draftService *draft.Service
+	tracker      *events.Tracker
+	publisher    eventbus.Publisher
+
+	// ESPN draft imports
+	credService   *services.CredentialsService
+	leagueService services.LeagueService
+	espnClient    espn.Client
+
+	// Read-only share tokens and resume tokens
+	jwtManager *auth.JWTManager
+	activity   *activity.Recorder
+	resumeTTL  time.Duration
 }
 
 // NewDraftHandler creates a new draft handler
@@ -20,7 +46,44 @@ func NewDraftHandler(draftService *draft.Service) *DraftHandler {
 	}
 }
 
-// CreateSession handles POST /api/draft/sessions
+// WithActivity records shared drafts in the user's activity
+func (h *DraftHandler) WithActivity(r *activity.Recorder) *DraftHandler {
+	h.activity = r
+	return h
+}
+
+// WithTracker emits product events for created sessions and recorded picks
+func (h *DraftHandler) WithTracker(t *events.Tracker) *DraftHandler {
+	h.tracker = t
+	return h
+}
+
+// WithPublisher publishes draft changes to p, feeding the draft event stream
+func (h *DraftHandler) WithPublisher(p eventbus.Publisher) *DraftHandler {
+	h.publisher = p
+	return h
+}
+
+// WithESPN enables importing drafts from users' ESPN leagues
+func (h *DraftHandler) WithESPN(credService *services.CredentialsService, leagueService services.LeagueService, espnClient espn.Client) *DraftHandler {
+	h.credService = credService
+	h.leagueService = leagueService
+	h.espnClient = espnClient
+	return h
+}
+
+// publish sends a draft change on the bus; failures are logged only
+func (h *DraftHandler) publish(ctx context.Context, sessionID, eventType string, payload interface{}) {
+	if h.publisher == nil {
+		return
+	}
+	if err := h.publisher.Publish(ctx, eventbus.TopicDraft, sessionID, eventType, payload); err != nil {
+		log.Printf("Failed to publish %s for draft %s: %v", eventType, sessionID, err)
+	}
+}
+
+// CreateSession handles POST /api/draft/sessions. The new session comes
+// with a resume token for it, when they are on.
 func (h *DraftHandler) CreateSession(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userIDValue, exists := c.Get("user_id")
@@ -49,10 +112,86 @@ func (h *DraftHandler) CreateSession(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, session)
+	h.tracker.Track(userUUID, events.SessionCreated, map[string]interface{}{
+		"session_id":   session.ID,
+		"draft_type":   session.DraftType,
+		"team_count":   session.TeamCount,
+		"round_count":  session.RoundCount,
+		"scoring_type": session.Settings.ScoringType,
+	})
+
+	c.JSON(http.StatusCreated, h.withResumeToken(c, userUUID, session))
+}
+
+// ImportSession handles POST /api/draft/sessions/import, saving a connected
+// ESPN league's finished draft as a completed session for post-draft
+// analysis
+func (h *DraftHandler) ImportSession(c *gin.Context) {
+	if h.espnClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "draft import is not available"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req draft.ImportSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	league, err := h.leagueService.GetLeague(c.Request.Context(), userID, uuid.MustParse(req.LeagueID))
+	switch {
+	case errors.Is(err, repositories.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+		return
+	case err != nil:
+		log.Printf("Failed to get league %s: %v", req.LeagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get league"})
+		return
+	case league.Platform != services.PlatformESPN:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only ESPN drafts can be imported"})
+		return
+	}
+
+	client, swid, ok := espnClientForUser(c, h.credService, h.espnClient, userID)
+	if !ok {
+		return
+	}
+
+	session, err := h.draftService.ImportSession(c.Request.Context(), userID.String(), &req, draft.ESPNDraft{
+		Client:   client,
+		LeagueID: league.ExternalID,
+		SWID:     swid,
+	})
+	switch {
+	case errors.Is(err, draft.ErrNoDraftResults):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, draft.ErrUnknownUserTeam), errors.Is(err, draft.ErrUnsupportedDraft):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		if !respondESPNError(c, err) {
+			log.Printf("Failed to import draft for league %s: %v", req.LeagueID, err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to import the draft from ESPN"})
+		}
+	default:
+		h.tracker.Track(userID, events.SessionCreated, map[string]interface{}{
+			"session_id":   session.ID,
+			"draft_type":   session.DraftType,
+			"team_count":   session.TeamCount,
+			"round_count":  session.RoundCount,
+			"scoring_type": session.Settings.ScoringType,
+			"imported":     true,
+		})
+		c.JSON(http.StatusCreated, session)
+	}
 }
 
-// GetSession handles GET /api/draft/sessions/:id
+// GetSession handles GET /api/draft/sessions/:id. ?fields= trims the
+// response, as for a client that only renders the state.
 func (h *DraftHandler) GetSession(c *gin.Context) {
 	// Get user ID from context
 	userIDValue, exists := c.Get("user_id")
@@ -70,15 +209,43 @@ func (h *DraftHandler) GetSession(c *gin.Context) {
 
 	session, err := h.draftService.GetSession(c.Request.Context(), sessionID, userID)
 	if err != nil {
-		if err.Error() == "unauthorized" {
+		if errors.Is(err, policy.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Draft session not found"})
+		return
+	}
+
+	respondWithFields(c, http.StatusOK, session)
+}
+
+// GetDecisionSpeed handles GET /api/draft/sessions/:id/decision-speed
+func (h *DraftHandler) GetDecisionSpeed(c *gin.Context) {
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	userUUID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user ID format"})
+		return
+	}
+	sessionID := c.Param("id")
+
+	report, err := h.draftService.DecisionSpeed(c.Request.Context(), sessionID, userUUID.String())
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
 			return
 		}
+		log.Printf("Failed to build decision speed report for draft %s: %v", sessionID, err)
 		c.JSON(http.StatusNotFound, gin.H{"error": "Draft session not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, session)
+	c.JSON(http.StatusOK, report)
 }
 
 // GetUserSessions handles GET /api/draft/sessions
@@ -102,6 +269,7 @@ func (h *DraftHandler) GetUserSessions(c *gin.Context) {
 		return
 	}
 
+	quota.SetHeaders(c, quota.ResourceDraftSessions, len(sessions))
 	c.JSON(http.StatusOK, gin.H{
 		"sessions": sessions,
 		"count":    len(sessions),
@@ -132,10 +300,20 @@ func (h *DraftHandler) RecordPick(c *gin.Context) {
 
 	pick, err := h.draftService.RecordPick(c.Request.Context(), sessionID, userID, &req)
 	if err != nil {
-		if err.Error() == "unauthorized" {
+		if errors.Is(err, policy.ErrForbidden) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
 			return
 		}
+		if errors.Is(err, draft.ErrStaleTurn) {
+			// Send the authoritative session so the client can reconcile
+			session, getErr := h.draftService.GetSession(c.Request.Context(), sessionID, userID)
+			if getErr != nil {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "session": session})
+			return
+		}
 		if err.Error() == "draft is not active" || err.Error() == "draft is complete" {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -148,9 +326,92 @@ func (h *DraftHandler) RecordPick(c *gin.Context) {
 		return
 	}
 
+	pickProperties := map[string]interface{}{
+		"session_id":  sessionID,
+		"pick_number": pick.PickNumber,
+		"round":       pick.Round,
+		"player_id":   pick.PlayerID,
+		"position":    pick.Position,
+	}
+	h.tracker.Track(userUUID, events.PickRecorded, pickProperties)
+	if req.RecommendationRank != nil {
+		accepted := map[string]interface{}{"recommendation_rank": *req.RecommendationRank}
+		for k, v := range pickProperties {
+			accepted[k] = v
+		}
+		h.tracker.Track(userUUID, events.RecommendationAccepted, accepted)
+	}
+	h.publish(c.Request.Context(), sessionID, "pick.recorded", pick)
+
 	c.JSON(http.StatusCreated, pick)
 }
 
+// TakeTurn handles POST /api/draft/sessions/:id/turn. It records the picks
+// made since the client's last update and returns the draft state,
+// recommendations, pick queue and timer in one response, trimmed by
+// ?fields=.
+func (h *DraftHandler) TakeTurn(c *gin.Context) {
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+	userUUID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user ID format"})
+		return
+	}
+	userID := userUUID.String()
+	sessionID := c.Param("id")
+
+	var req draft.TurnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.draftService.TakeTurn(c.Request.Context(), sessionID, userID, &req)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
+			return
+		}
+		if errors.Is(err, draft.ErrStaleTurn) {
+			// Send the current session so the client can resync and retry
+			session, getErr := h.draftService.GetSession(c.Request.Context(), sessionID, userID)
+			if getErr != nil {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "session": session})
+			return
+		}
+		if err.Error() == "draft is not active" || err.Error() == "draft is complete" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.HasPrefix(err.Error(), "player is not available") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, pick := range result.Recorded {
+		h.tracker.Track(userUUID, events.PickRecorded, map[string]interface{}{
+			"session_id":  sessionID,
+			"pick_number": pick.PickNumber,
+			"round":       pick.Round,
+			"player_id":   pick.PlayerID,
+			"position":    pick.Position,
+		})
+		h.publish(c.Request.Context(), sessionID, "pick.recorded", pick)
+	}
+
+	respondWithFields(c, http.StatusOK, result)
+}
+
 // UndoPick handles POST /api/draft/sessions/:id/undo
 func (h *DraftHandler) UndoPick(c *gin.Context) {
 	userID := c.GetString("user_id")
@@ -158,7 +419,7 @@ func (h *DraftHandler) UndoPick(c *gin.Context) {
 
 	err := h.draftService.UndoPick(c.Request.Context(), sessionID, userID)
 	if err != nil {
-		if err.Error() == "unauthorized" {
+		if errors.Is(err, policy.ErrForbidden) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
 			return
 		}
@@ -174,6 +435,8 @@ func (h *DraftHandler) UndoPick(c *gin.Context) {
 		return
 	}
 
+	h.publish(c.Request.Context(), sessionID, "pick.undone", gin.H{"session_id": sessionID})
+
 	c.JSON(http.StatusOK, gin.H{"message": "Pick undone successfully"})
 }
 
@@ -184,7 +447,7 @@ func (h *DraftHandler) RedoPick(c *gin.Context) {
 
 	pick, err := h.draftService.RedoPick(c.Request.Context(), sessionID, userID)
 	if err != nil {
-		if err.Error() == "unauthorized" {
+		if errors.Is(err, policy.ErrForbidden) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
 			return
 		}
@@ -200,6 +463,8 @@ func (h *DraftHandler) RedoPick(c *gin.Context) {
 		return
 	}
 
+	h.publish(c.Request.Context(), sessionID, "pick.redone", pick)
+
 	c.JSON(http.StatusOK, pick)
 }
 
@@ -210,7 +475,7 @@ func (h *DraftHandler) PauseSession(c *gin.Context) {
 
 	err := h.draftService.PauseSession(c.Request.Context(), sessionID, userID)
 	if err != nil {
-		if err.Error() == "unauthorized" {
+		if errors.Is(err, policy.ErrForbidden) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
 			return
 		}
@@ -222,6 +487,8 @@ func (h *DraftHandler) PauseSession(c *gin.Context) {
 		return
 	}
 
+	h.publish(c.Request.Context(), sessionID, "session.paused", gin.H{"session_id": sessionID})
+
 	c.JSON(http.StatusOK, gin.H{"message": "Draft session paused"})
 }
 
@@ -232,7 +499,7 @@ func (h *DraftHandler) ResumeSession(c *gin.Context) {
 
 	err := h.draftService.ResumeSession(c.Request.Context(), sessionID, userID)
 	if err != nil {
-		if err.Error() == "unauthorized" {
+		if errors.Is(err, policy.ErrForbidden) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
 			return
 		}
@@ -244,6 +511,8 @@ func (h *DraftHandler) ResumeSession(c *gin.Context) {
 		return
 	}
 
+	h.publish(c.Request.Context(), sessionID, "session.resumed", gin.H{"session_id": sessionID})
+
 	c.JSON(http.StatusOK, gin.H{"message": "Draft session resumed"})
 }
 
@@ -253,11 +522,15 @@ func (h *DraftHandler) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		// Sessions
 		draft.POST("/sessions", h.CreateSession)
+		draft.POST("/sessions/import", h.ImportSession)
 		draft.GET("/sessions", h.GetUserSessions)
 		draft.GET("/sessions/:id", h.GetSession)
+		draft.GET("/sessions/:id/decision-speed", h.GetDecisionSpeed)
 		
 		// Draft actions
 		draft.POST("/sessions/:id/pick", h.RecordPick)
+		draft.POST("/sessions/:id/turn", h.TakeTurn)
+		draft.POST("/sessions/:id/command", h.RunCommand)
 		draft.POST("/sessions/:id/undo", h.UndoPick)
 		draft.POST("/sessions/:id/redo", h.RedoPick)
 		draft.POST("/sessions/:id/pause", h.PauseSession)
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/draft"
+	"github.com/nfl-analytics/backend/internal/events"
+	"github.com/nfl-analytics/backend/internal/policy"
+)
+
+// RunCommand handles POST /api/draft/sessions/:id/command. It carries out a
+// free-text command such as "take Bijan" or "queue the next three WRs by
+// ADP". When the command could mean more than one player it returns the
+// candidates with status confirm, and the client resends the text with the
+// chosen player_id.
+func (h *DraftHandler) RunCommand(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	sessionID := c.Param("id")
+
+	var req draft.CommandRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.draftService.RunCommand(c.Request.Context(), sessionID, userID.String(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, policy.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
+		case errors.Is(err, draft.ErrUnknownCommand):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Command not understood"})
+		case errors.Is(err, draft.ErrPlayerNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "No available player matches"})
+		case errors.Is(err, draft.ErrQueueFull):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, draft.ErrNoPlayerLookup):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Player commands are not enabled"})
+		case errors.Is(err, draft.ErrCommandParserFailed):
+			log.Printf("Failed to parse command for draft %s: %v", sessionID, err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to understand the command"})
+		case errors.Is(err, draft.ErrStaleTurn), err.Error() == "player is not available":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case err.Error() == "draft is not active", err.Error() == "draft is complete",
+			strings.HasPrefix(err.Error(), "nothing to"), strings.HasPrefix(err.Error(), "can only"):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if result.Status == draft.CommandDone {
+		h.publishCommand(c, userID, sessionID, result, "command")
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// publishCommand sends the draft changes a command made, as the endpoint
+// for each operation would. source says where the command came from.
+func (h *DraftHandler) publishCommand(c *gin.Context, userID uuid.UUID, sessionID string, result *draft.CommandResult, source string) {
+	ctx := c.Request.Context()
+	switch result.Command.Action {
+	case draft.CommandPick:
+		pick := result.Pick
+		h.tracker.Track(userID, events.PickRecorded, map[string]interface{}{
+			"session_id":  sessionID,
+			"pick_number": pick.PickNumber,
+			"round":       pick.Round,
+			"player_id":   pick.PlayerID,
+			"position":    pick.Position,
+			"source":      source,
+		})
+		h.publish(ctx, sessionID, "pick.recorded", pick)
+	case draft.CommandRedo:
+		h.publish(ctx, sessionID, "pick.redone", result.Pick)
+	case draft.CommandUndo:
+		h.publish(ctx, sessionID, "pick.undone", gin.H{"session_id": sessionID})
+	case draft.CommandPause:
+		h.publish(ctx, sessionID, "session.paused", gin.H{"session_id": sessionID})
+	case draft.CommandResume:
+		h.publish(ctx, sessionID, "session.resumed", gin.H{"session_id": sessionID})
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+)
+
+// draftSessionResponse is a new draft session with a resume token for it
+type draftSessionResponse struct {
+	*models.DraftSession
+	ResumeToken          string     `json:"resume_token,omitempty"`
+	ResumeTokenExpiresAt *time.Time `json:"resume_token_expires_at,omitempty"`
+}
+
+// WithResumeTokens issues resume tokens lasting ttl with new drafts, which
+// let the user back into the draft room if their login lapses mid-draft
+func (h *DraftHandler) WithResumeTokens(jwtManager *auth.JWTManager, ttl time.Duration) *DraftHandler {
+	h.jwtManager = jwtManager
+	h.resumeTTL = ttl
+	return h
+}
+
+// issueResumeToken mints a resume token for the draft session, tied to the
+// request's signed-in session. It returns an empty token when resume tokens
+// are off.
+func (h *DraftHandler) issueResumeToken(c *gin.Context, userID uuid.UUID, sessionID string) (string, time.Time, error) {
+	if h.jwtManager == nil || h.resumeTTL <= 0 {
+		return "", time.Time{}, nil
+	}
+	email, _ := auth.GetUserEmail(c)
+	var loginSession string
+	if id, ok := auth.GetSessionID(c); ok {
+		loginSession = id.String()
+	}
+	return h.jwtManager.GenerateSessionScopedToken(userID, email, loginSession, auth.ScopeDraftResume, sessionID, h.resumeTTL)
+}
+
+// withResumeToken adds a resume token to a new session's response. Drafts
+// are still created when one cannot be minted; the client can ask again.
+func (h *DraftHandler) withResumeToken(c *gin.Context, userID uuid.UUID, session *models.DraftSession) draftSessionResponse {
+	resp := draftSessionResponse{DraftSession: session}
+	token, expiresAt, err := h.issueResumeToken(c, userID, session.ID)
+	if err != nil {
+		log.Printf("Failed to create resume token for draft %s: %v", session.ID, err)
+		return resp
+	}
+	if token != "" {
+		resp.ResumeToken = token
+		resp.ResumeTokenExpiresAt = &expiresAt
+	}
+	return resp
+}
+
+// RenewResumeToken handles POST /api/draft/sessions/:id/resume-token. Clients
+// call it while signed in, such as after each refresh, to keep a resume
+// token for a draft in progress that outlives their access token. A resume
+// token cannot renew itself: the route needs a login.
+func (h *DraftHandler) RenewResumeToken(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	if h.jwtManager == nil || h.resumeTTL <= 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "draft resume tokens are not enabled"})
+		return
+	}
+	sessionID := c.Param("id")
+
+	session, err := h.draftService.AuthorizeSession(c.Request.Context(), sessionID, userID.String(), policy.ActionWrite)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Draft session not found"})
+		return
+	}
+	if session.Status == "completed" {
+		c.JSON(http.StatusConflict, gin.H{"error": "draft is already complete"})
+		return
+	}
+
+	token, expiresAt, err := h.issueResumeToken(c, userID, sessionID)
+	if err != nil {
+		log.Printf("Failed to create resume token for draft %s: %v", sessionID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create resume token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"resume_token":            token,
+		"resume_token_expires_at": expiresAt,
+	})
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/policy"
+)
+
+// draftShareTTL is how long a shared draft link works
+const draftShareTTL = 2 * time.Hour
+
+// maxSharedRecommendations bounds ?count= on shared recommendations
+const maxSharedRecommendations = 50
+
+// WithShareTokens lets users share a read-only view of their drafts
+func (h *DraftHandler) WithShareTokens(jwtManager *auth.JWTManager) *DraftHandler {
+	h.jwtManager = jwtManager
+	return h
+}
+
+// ShareSession handles POST /api/draft/sessions/:id/share. It issues a token
+// giving a co-manager read-only access to the draft board and live
+// recommendations for two hours, without sharing the account or the draft
+// room.
+func (h *DraftHandler) ShareSession(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	if h.jwtManager == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "draft sharing is not enabled"})
+		return
+	}
+	sessionID := c.Param("id")
+
+	if _, err := h.draftService.AuthorizeSession(c.Request.Context(), sessionID, userID.String(), policy.ActionManage); err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Draft session not found"})
+		return
+	}
+
+	token, expiresAt, err := h.jwtManager.GenerateScopedToken(userID, auth.ScopeDraftRead, sessionID, draftShareTTL)
+	if err != nil {
+		log.Printf("Failed to create share token for draft %s: %v", sessionID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to share draft"})
+		return
+	}
+
+	h.activity.Record(c, userID, activity.ActionDraftShared, "draft_session", sessionID, map[string]interface{}{
+		"scope":      auth.ScopeDraftRead,
+		"expires_at": expiresAt,
+	})
+
+	base := "/api/shared/draft/sessions/" + sessionID
+	c.JSON(http.StatusCreated, gin.H{
+		"token":      token,
+		"scope":      auth.ScopeDraftRead,
+		"expires_at": expiresAt,
+		"links": gin.H{
+			"board":           base,
+			"recommendations": base + "/recommendations",
+			"events":          base + "/events",
+		},
+	})
+}
+
+// GetSharedBoard handles GET /api/shared/draft/sessions/:id, the draft board
+// for someone holding a share token. It takes ?fields= like GetSession.
+func (h *DraftHandler) GetSharedBoard(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.draftService.GetSession(c.Request.Context(), c.Param("id"), userID.String())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Draft session not found"})
+		return
+	}
+
+	respondWithFields(c, http.StatusOK, session)
+}
+
+// GetSharedRecommendations handles GET
+// /api/shared/draft/sessions/:id/recommendations, the owner's live
+// recommendations for someone holding a share token. ?count= defaults to 10.
+func (h *DraftHandler) GetSharedRecommendations(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	count := 0
+	if raw := c.Query("count"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxSharedRecommendations {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "count must be between 1 and 50"})
+			return
+		}
+		count = n
+	}
+
+	sessionID := c.Param("id")
+	recommendations, err := h.draftService.Recommendations(c.Request.Context(), sessionID, userID.String(), count)
+	if err != nil {
+		log.Printf("Failed to get shared recommendations for draft %s: %v", sessionID, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Draft session not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":      sessionID,
+		"recommendations": recommendations,
+	})
+}
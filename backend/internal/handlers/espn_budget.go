@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/espnbudget"
+)
+
+// ESPNBudgetHandler serves per-league ESPN request usage
+type ESPNBudgetHandler struct {
+	budget *espnbudget.Budget
+}
+
+// NewESPNBudgetHandler creates a new ESPN budget handler
+func NewESPNBudgetHandler(budget *espnbudget.Budget) *ESPNBudgetHandler {
+	return &ESPNBudgetHandler{budget: budget}
+}
+
+// GetUsage handles GET /api/admin/espn-budget, listing this hour's ESPN
+// requests per league, busiest first
+func (h *ESPNBudgetHandler) GetUsage(c *gin.Context) {
+	report, err := h.budget.Report(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to get ESPN budget report: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get ESPN budget report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/events"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// EventsHandler accepts client-side product events and manages each user's
+// analytics consent
+type EventsHandler struct {
+	tracker        *events.Tracker
+	consentRepo    repositories.ConsentRepository
+	defaultConsent bool
+}
+
+// NewEventsHandler creates a new events handler. defaultConsent is reported
+// for users who have not chosen.
+func NewEventsHandler(tracker *events.Tracker, consentRepo repositories.ConsentRepository, defaultConsent bool) *EventsHandler {
+	return &EventsHandler{
+		tracker:        tracker,
+		consentRepo:    consentRepo,
+		defaultConsent: defaultConsent,
+	}
+}
+
+// TrackEventRequest is a product event reported by a client
+type TrackEventRequest struct {
+	Event      string                 `json:"event" binding:"required"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// AnalyticsConsentRequest records a user's analytics choice
+type AnalyticsConsentRequest struct {
+	Consented *bool `json:"consented" binding:"required"`
+}
+
+// TrackEvent handles POST /api/events. Only events the server cannot see,
+// such as recommendations shown in the draft room, are accepted.
+func (h *EventsHandler) TrackEvent(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req TrackEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if !events.ClientEvents[req.Event] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported event"})
+		return
+	}
+
+	h.tracker.Track(userID, req.Event, req.Properties)
+	c.Status(http.StatusAccepted)
+}
+
+// GetAnalyticsConsent handles GET /api/users/analytics-consent
+func (h *EventsHandler) GetAnalyticsConsent(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	consented, err := h.consentRepo.GetAnalyticsConsent(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to get analytics consent for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get analytics consent"})
+		return
+	}
+
+	if consented == nil {
+		c.JSON(http.StatusOK, gin.H{"consented": h.defaultConsent, "chosen": false})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"consented": *consented, "chosen": true})
+}
+
+// SetAnalyticsConsent handles PUT /api/users/analytics-consent
+func (h *EventsHandler) SetAnalyticsConsent(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req AnalyticsConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "consented is required"})
+		return
+	}
+
+	if err := h.consentRepo.SetAnalyticsConsent(c.Request.Context(), userID, *req.Consented); err != nil {
+		log.Printf("Failed to set analytics consent for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save analytics consent"})
+		return
+	}
+	h.tracker.ForgetConsent(userID)
+
+	c.JSON(http.StatusOK, gin.H{"consented": *req.Consented, "chosen": true})
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxSelectedFields bounds how many fields a ?fields= list may name
+const maxSelectedFields = 50
+
+// fieldSet is a tree of selected JSON fields. A field with no children is
+// selected whole.
+type fieldSet map[string]fieldSet
+
+// parseFields reads a comma separated ?fields= list. Nested fields are
+// named with dots, as in state.current_pick; a field inside a list applies
+// to every element. It returns nil when no fields were asked for.
+func parseFields(raw string) (fieldSet, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, true
+	}
+
+	paths := strings.Split(raw, ",")
+	if len(paths) > maxSelectedFields {
+		return nil, false
+	}
+
+	fields := fieldSet{}
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		names := strings.Split(path, ".")
+		node := fields
+		for i, name := range names {
+			if name == "" {
+				return nil, false
+			}
+			child, ok := node[name]
+			if i == len(names)-1 {
+				// A field named whole wins over its subfields
+				node[name] = fieldSet{}
+				break
+			}
+			if ok && len(child) == 0 {
+				// Already selected whole
+				break
+			}
+			if !ok {
+				child = fieldSet{}
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	if len(fields) == 0 {
+		return nil, true
+	}
+	return fields, true
+}
+
+// selectFields keeps only the selected fields of a decoded JSON value.
+// Values that are not objects are kept as they are.
+func selectFields(value interface{}, fields fieldSet) interface{} {
+	if len(fields) == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		selected := make(map[string]interface{}, len(fields))
+		for name, children := range fields {
+			if field, ok := v[name]; ok {
+				selected[name] = selectFields(field, children)
+			}
+		}
+		return selected
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = selectFields(elem, fields)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// respondWithFields writes obj as JSON, trimmed to the fields named in
+// ?fields= when the client asks for a subset. Unknown fields are left out
+// rather than rejected, since optional fields are omitted when empty.
+func respondWithFields(c *gin.Context, status int, obj interface{}) {
+	fields, ok := parseFields(c.Query("fields"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid fields parameter"})
+		return
+	}
+	if fields == nil {
+		c.JSON(status, obj)
+		return
+	}
+
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		log.Printf("Failed to encode response for field selection: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encode response"})
+		return
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		log.Printf("Failed to decode response for field selection: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encode response"})
+		return
+	}
+
+	c.JSON(status, selectFields(decoded, fields))
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespondWithFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type pick struct {
+		Player string `json:"player_name"`
+		Round  int    `json:"round"`
+	}
+	session := map[string]interface{}{
+		"id":     "s1",
+		"status": "active",
+		"state":  map[string]interface{}{"current_pick": 14, "round": 2},
+		"picks":  []pick{{"A", 1}, {"B", 1}},
+	}
+
+	respond := func(query string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/?fields="+query, nil)
+		respondWithFields(c, http.StatusOK, session)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w.Code, body
+	}
+
+	code, body := respond("")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, body, 4)
+
+	code, body = respond("id,state.current_pick,picks.player_name,missing")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]interface{}{
+		"id":    "s1",
+		"state": map[string]interface{}{"current_pick": 14.0},
+		"picks": []interface{}{
+			map[string]interface{}{"player_name": "A"},
+			map[string]interface{}{"player_name": "B"},
+		},
+	}, body)
+
+	// A whole field wins over its subfields
+	_, body = respond("state.round,state")
+	assert.Len(t, body["state"], 2)
+
+	code, _ = respond("state..round")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
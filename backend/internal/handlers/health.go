@@ -7,11 +7,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/nfl-analytics/backend/internal/database"
+	"github.com/nfl-analytics/backend/internal/worker"
 )
 
 type HealthHandler struct {
 	db    *database.PostgresDB
 	redis interface{} // TODO: Add Redis client type
+	// heartbeats is set when background workers run in this process
+	heartbeats *worker.Heartbeats
 }
 
 // NewHealthHandler creates a new health handler
@@ -22,6 +25,12 @@ func NewHealthHandler(db *database.PostgresDB, redis interface{}) *HealthHandler
 	}
 }
 
+// WithHeartbeats adds the background workers' heartbeats to /readyz
+func (h *HealthHandler) WithHeartbeats(hb *worker.Heartbeats) *HealthHandler {
+	h.heartbeats = hb
+	return h
+}
+
 // Health returns the health status of the application
 func (h *HealthHandler) Health(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -69,4 +78,52 @@ func (h *HealthHandler) Health(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, response)
-}
\ No newline at end of file
+}
+
+// Ready reports whether the instance can serve traffic, with the detail of
+// each background worker: its last tick, when the next is due, jobs in
+// flight and queue depth. Only the database decides readiness; a stale
+// worker marks the response degraded for alerting, since taking the
+// instance out of rotation would not restart it.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	status := http.StatusOK
+	response := gin.H{
+		"status":    "ready",
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+
+	checks := gin.H{}
+	if h.db != nil {
+		if err := h.db.Health(ctx); err != nil {
+			checks["postgres"] = gin.H{"status": "unhealthy", "error": err.Error()}
+			response["status"] = "not ready"
+			status = http.StatusServiceUnavailable
+		} else {
+			checks["postgres"] = gin.H{"status": "healthy"}
+		}
+	} else {
+		checks["postgres"] = gin.H{"status": "not configured"}
+	}
+	response["checks"] = checks
+
+	workers := h.heartbeats.Status()
+	stale := 0
+	for _, w := range workers {
+		if w.Stale {
+			stale++
+		}
+	}
+	if workers == nil {
+		workers = []worker.WorkerStatus{}
+	}
+	response["workers"] = workers
+	response["stale_workers"] = stale
+	if stale > 0 && status == http.StatusOK {
+		response["status"] = "degraded"
+	}
+
+	c.JSON(status, response)
+}
@@ -5,9 +5,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nfl-analytics/backend/internal/database"
+	"github.com/nfl-analytics/backend/internal/worker"
 )
 
 func TestHealthHandler_Health(t *testing.T) {
@@ -107,4 +109,46 @@ func TestHealthHandler_ResponseFormat(t *testing.T) {
 	} else if timestamp == "" {
 		t.Error("timestamp is empty")
 	}
-}
\ No newline at end of file
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	heartbeats := worker.NewHeartbeats(0)
+	heartbeats.Tick("league_sync", time.Hour)
+	heartbeats.Tick("player_news", -time.Minute)
+
+	handler := NewHealthHandler(nil, nil).WithHeartbeats(heartbeats)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/readyz", nil)
+
+	handler.Ready(c)
+
+	// A stale worker is reported but does not fail readiness
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		Status       string                `json:"status"`
+		StaleWorkers int                   `json:"stale_workers"`
+		Workers      []worker.WorkerStatus `json:"workers"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if response.Status != "degraded" {
+		t.Errorf("expected status degraded, got %s", response.Status)
+	}
+	if response.StaleWorkers != 1 || len(response.Workers) != 2 {
+		t.Fatalf("expected 1 stale of 2 workers, got %d of %d", response.StaleWorkers, len(response.Workers))
+	}
+	if response.Workers[0].Name != "league_sync" || response.Workers[0].Stale {
+		t.Errorf("expected league_sync to be ticking, got %+v", response.Workers[0])
+	}
+	if response.Workers[1].Name != "player_news" || !response.Workers[1].Stale {
+		t.Errorf("expected player_news to be stale, got %+v", response.Workers[1])
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/inactivity"
+)
+
+// InactivityHandler reports on and runs the inactive account job for admins
+type InactivityHandler struct {
+	job   *inactivity.Job
+	store inactivity.Store
+}
+
+// NewInactivityHandler creates a new inactive account handler
+func NewInactivityHandler(job *inactivity.Job, store inactivity.Store) *InactivityHandler {
+	return &InactivityHandler{job: job, store: store}
+}
+
+// GetRuns returns recent runs, newest first; ?limit= defaults to 30
+func (h *InactivityHandler) GetRuns(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "30"))
+	if err != nil || limit < 1 || limit > 365 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 365"})
+		return
+	}
+
+	runs, err := h.store.ListRuns(c.Request.Context(), limit)
+	if err != nil {
+		log.Printf("Failed to list inactive account runs: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list runs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+// Run runs the job now and returns its report
+func (h *InactivityHandler) Run(c *gin.Context) {
+	run, err := h.job.Run(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to run inactive account job: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run inactive account job"})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/platform"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// LeagueDataHandler serves a connected league's live data from any platform
+type LeagueDataHandler struct {
+	service services.LeagueDataService
+}
+
+// NewLeagueDataHandler creates a new league data handler
+func NewLeagueDataHandler(service services.LeagueDataService) *LeagueDataHandler {
+	return &LeagueDataHandler{service: service}
+}
+
+// GetTeams handles GET /api/leagues/:id/teams. ?fields= trims the response.
+func (h *LeagueDataHandler) GetTeams(c *gin.Context) {
+	userID, leagueID, ok := leagueParams(c)
+	if !ok {
+		return
+	}
+
+	league, err := h.service.GetLeague(c.Request.Context(), userID, leagueID)
+	if err != nil {
+		respondPlatformError(c, leagueID, "league", err)
+		return
+	}
+	respondWithFields(c, http.StatusOK, league)
+}
+
+// GetRosters handles GET /api/leagues/:id/rosters
+func (h *LeagueDataHandler) GetRosters(c *gin.Context) {
+	userID, leagueID, ok := leagueParams(c)
+	if !ok {
+		return
+	}
+
+	rosters, err := h.service.GetRosters(c.Request.Context(), userID, leagueID)
+	if err != nil {
+		respondPlatformError(c, leagueID, "rosters", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rosters": rosters})
+}
+
+// GetPlayers handles GET /api/leagues/:id/players, the league's available
+// players (?limit=, default 50)
+func (h *LeagueDataHandler) GetPlayers(c *gin.Context) {
+	userID, leagueID, ok := leagueParams(c)
+	if !ok {
+		return
+	}
+	limit, ok := limitParam(c, 50, 200)
+	if !ok {
+		return
+	}
+
+	players, err := h.service.GetPlayers(c.Request.Context(), userID, leagueID, limit)
+	if err != nil {
+		respondPlatformError(c, leagueID, "players", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"players": players})
+}
+
+// GetTransactions handles GET /api/leagues/:id/transactions (?limit=,
+// default 25)
+func (h *LeagueDataHandler) GetTransactions(c *gin.Context) {
+	userID, leagueID, ok := leagueParams(c)
+	if !ok {
+		return
+	}
+	limit, ok := limitParam(c, 25, 100)
+	if !ok {
+		return
+	}
+
+	transactions, err := h.service.GetTransactions(c.Request.Context(), userID, leagueID, limit)
+	if err != nil {
+		respondPlatformError(c, leagueID, "transactions", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"transactions": transactions})
+}
+
+// GetDraft handles GET /api/leagues/:id/draft
+func (h *LeagueDataHandler) GetDraft(c *gin.Context) {
+	userID, leagueID, ok := leagueParams(c)
+	if !ok {
+		return
+	}
+
+	picks, err := h.service.GetDraft(c.Request.Context(), userID, leagueID)
+	if err != nil {
+		respondPlatformError(c, leagueID, "draft", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"picks": picks})
+}
+
+// leagueParams reads the current user and the :id league, responding and
+// returning false when either is missing or invalid
+func leagueParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, leagueID, true
+}
+
+// limitParam reads ?limit=, responding and returning false when it is out
+// of range
+func limitParam(c *gin.Context, def, maxLimit int) (int, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(def)))
+	if err != nil || limit < 1 || limit > maxLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxLimit)})
+		return 0, false
+	}
+	return limit, true
+}
+
+// respondPlatformError maps league and platform errors to a response
+func respondPlatformError(c *gin.Context, leagueID uuid.UUID, what string, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrLeagueNotFound), errors.Is(err, platform.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+	case errors.Is(err, platform.ErrUnsupportedPlatform):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, platform.ErrCredentialsRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Connect your account on this league's platform to view it"})
+	case errors.Is(err, services.ErrCredentialsInvalid):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your espn_s2 cookie has expired - sign in to ESPN again and update your cookies"})
+	default:
+		if !respondESPNError(c, err) {
+			log.Printf("Failed to get %s for league %s: %v", what, leagueID, err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to fetch " + what + " from the league's platform"})
+		}
+	}
+}
@@ -1,25 +1,87 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/analytics"
+	"github.com/nfl-analytics/backend/internal/audit"
+	"github.com/nfl-analytics/backend/internal/events"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/quota"
+	"github.com/nfl-analytics/backend/internal/repositories"
 	"github.com/nfl-analytics/backend/internal/services"
+	"github.com/nfl-analytics/backend/internal/worker"
 )
 
 // LeagueHandler handles league-related HTTP requests
 type LeagueHandler struct {
-	credService *services.CredentialsService
+	credService   *services.CredentialsService
+	leagueService services.LeagueService
+	espnClient    espn.Client
+	tracker       *events.Tracker
+	simulator     *services.RuleSimulator
+	lineups       *services.LineupAdvisor
+	activity      *activity.Recorder
+	audit         *audit.Logger
+	rosterHistory *services.RosterHistorian
+	syncQueue     *worker.SyncQueue
 }
 
 // NewLeagueHandler creates a new league handler
-func NewLeagueHandler(credService *services.CredentialsService) *LeagueHandler {
+func NewLeagueHandler(credService *services.CredentialsService, leagueService services.LeagueService, espnClient espn.Client) *LeagueHandler {
 	return &LeagueHandler{
-		credService: credService,
+		credService:   credService,
+		leagueService: leagueService,
+		espnClient:    espnClient,
 	}
 }
 
+// WithTracker emits a product event when a league is connected
+func (h *LeagueHandler) WithTracker(t *events.Tracker) *LeagueHandler {
+	h.tracker = t
+	return h
+}
+
+// WithActivity records credential changes and league connections in the
+// user's activity
+func (h *LeagueHandler) WithActivity(r *activity.Recorder) *LeagueHandler {
+	h.activity = r
+	return h
+}
+
+// WithAudit records connected and disconnected platform credentials in the
+// security audit log
+func (h *LeagueHandler) WithAudit(l *audit.Logger) *LeagueHandler {
+	h.audit = l
+	return h
+}
+
+// WithRuleSimulator enables simulating rule changes over imported history
+func (h *LeagueHandler) WithRuleSimulator(sim *services.RuleSimulator) *LeagueHandler {
+	h.simulator = sim
+	return h
+}
+
+// WithLineupAdvisor enables lineup checks against synced rosters
+func (h *LeagueHandler) WithLineupAdvisor(a *services.LineupAdvisor) *LeagueHandler {
+	h.lineups = a
+	return h
+}
+
+// WithRosterHistorian enables roster histories rebuilt from league syncs
+func (h *LeagueHandler) WithRosterHistorian(r *services.RosterHistorian) *LeagueHandler {
+	h.rosterHistory = r
+	return h
+}
+
 // ConnectESPNRequest represents the request to connect an ESPN league
 type ConnectESPNRequest struct {
 	LeagueID string `json:"league_id" binding:"required"`
@@ -55,6 +117,12 @@ func (h *LeagueHandler) ConnectESPN(c *gin.Context) {
 		return
 	}
 
+	// Validate credentials against ESPN before storing anything
+	info, ok := h.validateESPNLeague(c, req.LeagueID, swid, req.EspnS2)
+	if !ok {
+		return
+	}
+
 	// Store encrypted credentials
 	err := h.credService.StoreESPNCredentials(
 		c.Request.Context(),
@@ -67,15 +135,58 @@ func (h *LeagueHandler) ConnectESPN(c *gin.Context) {
 		return
 	}
 
-	// TODO: Validate credentials by making a test request to ESPN
-	// TODO: Fetch and store league details
+	// Import the league's settings, scoring and teams
+	league, err := h.leagueService.ImportESPNLeague(c.Request.Context(), userID.(uuid.UUID), info)
+	if err != nil {
+		log.Printf("Failed to import ESPN league %s: %v", req.LeagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to import league"})
+		return
+	}
+
+	h.tracker.Track(userID.(uuid.UUID), events.LeagueConnected, map[string]interface{}{
+		"platform":       "espn",
+		"scoring_format": league.ScoringType,
+		"team_count":     len(info.Teams),
+	})
+	h.activity.Record(c, userID.(uuid.UUID), activity.ActionCredentialsAdded, "credentials", "", map[string]interface{}{"platform": "espn"})
+	h.audit.RecordRequest(c, userID.(uuid.UUID), audit.EventCredentialsConnected, map[string]interface{}{"platform": "espn"})
+	h.activity.Record(c, userID.(uuid.UUID), activity.ActionLeagueConnected, "league", league.ID.String(), map[string]interface{}{
+		"platform":    "espn",
+		"league_name": info.Name,
+	})
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "ESPN league connected successfully",
 		"league_id": req.LeagueID,
+		"league_name": info.Name,
+		"scoring_format": league.ScoringType,
+		"league": league,
 	})
 }
 
+// validateESPNLeague fetches the league with the supplied cookies and writes an
+// error response if ESPN rejects them
+func (h *LeagueHandler) validateESPNLeague(c *gin.Context, leagueID, swid, espnS2 string) (*espn.LeagueInfo, bool) {
+	info, err := h.espnClient.WithAuthentication(swid, espnS2).GetLeagueInfo(c.Request.Context(), leagueID)
+	if err != nil {
+		switch {
+		case errors.Is(err, espn.ErrLeagueNotVisible), errors.Is(err, espn.ErrRegionBlocked):
+			respondESPNError(c, err)
+		case errors.Is(err, espn.ErrUnauthorized):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ESPN rejected the credentials - check your SWID and espn_s2 cookies"})
+		case errors.Is(err, espn.ErrLeagueNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "ESPN league not found"})
+		case errors.Is(err, espn.ErrCircuitOpen):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "ESPN is unavailable, try again shortly"})
+		default:
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to verify league with ESPN"})
+		}
+		return nil, false
+	}
+
+	return info, true
+}
+
 // GetESPNStatus checks if user has ESPN credentials stored
 func (h *LeagueHandler) GetESPNStatus(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -99,11 +210,25 @@ func (h *LeagueHandler) GetESPNStatus(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	health, err := h.credService.GetESPNCredentialHealth(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		log.Printf("Failed to get ESPN credential health for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check ESPN credentials"})
+		return
+	}
+
+	response := gin.H{
 		"connected": true,
 		"expires_at": expiresAt,
 		"is_expiring": isExpiring,
-	})
+		"valid": health.Valid,
+	}
+	if !health.Valid {
+		response["invalid_at"] = health.InvalidAt
+		response["invalid_reason"] = health.Reason
+		response["message"] = "ESPN rejected your cookies - sign in to ESPN again and update them"
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // DisconnectESPN removes ESPN credentials
@@ -122,6 +247,8 @@ func (h *LeagueHandler) DisconnectESPN(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to disconnect ESPN"})
 		return
 	}
+	h.activity.Record(c, userID.(uuid.UUID), activity.ActionCredentialsRemoved, "credentials", "", map[string]interface{}{"platform": "espn"})
+	h.audit.RecordRequest(c, userID.(uuid.UUID), audit.EventCredentialsDisconnected, map[string]interface{}{"platform": "espn"})
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "ESPN account disconnected successfully",
@@ -153,6 +280,11 @@ func (h *LeagueHandler) UpdateESPNCredentials(c *gin.Context) {
 		return
 	}
 
+	// Validate new credentials against ESPN before replacing the stored ones
+	if _, ok := h.validateESPNLeague(c, req.LeagueID, swid, req.EspnS2); !ok {
+		return
+	}
+
 	// Update credentials
 	err := h.credService.UpdateESPNCredentials(
 		c.Request.Context(),
@@ -164,8 +296,638 @@ func (h *LeagueHandler) UpdateESPNCredentials(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update credentials"})
 		return
 	}
+	h.activity.Record(c, userID.(uuid.UUID), activity.ActionCredentialsUpdated, "credentials", "", map[string]interface{}{"platform": "espn"})
+	h.audit.RecordRequest(c, userID.(uuid.UUID), audit.EventCredentialsConnected, map[string]interface{}{"platform": "espn", "updated": true})
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "ESPN credentials updated successfully",
 	})
-}
\ No newline at end of file
+}
+// GetWaiverClaims returns waiver claims in an ESPN league, using the user's
+// stored cookies when they have connected an account. Only pending claims are
+// returned unless status=all.
+func (h *LeagueHandler) GetWaiverClaims(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID := c.Param("leagueId")
+	ctx := c.Request.Context()
+
+	client, ok := h.userESPNClient(c, userID)
+	if !ok {
+		return
+	}
+
+	claims, err := client.GetWaiverClaims(ctx, leagueID)
+	if err != nil {
+		if !respondESPNError(c, err) {
+			log.Printf("Failed to get waiver claims for league %s: %v", leagueID, err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to fetch waiver claims from ESPN"})
+		}
+		return
+	}
+
+	status := strings.ToUpper(c.DefaultQuery("status", "pending"))
+	filtered := make([]espn.WaiverClaim, 0, len(claims))
+	for _, claim := range claims {
+		if status == "ALL" || strings.EqualFold(claim.Status, status) {
+			filtered = append(filtered, claim)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"league_id": leagueID,
+		"claims":    filtered,
+		"count":     len(filtered),
+	})
+}
+
+// GetAuctionValues handles GET /api/leagues/espn/:leagueId/auction-values
+func (h *LeagueHandler) GetAuctionValues(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID := c.Param("leagueId")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "200"))
+	if err != nil || limit < 1 || limit > 300 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 300"})
+		return
+	}
+
+	client, ok := h.userESPNClient(c, userID)
+	if !ok {
+		return
+	}
+	season, ok := seasonQuery(c, client)
+	if !ok {
+		return
+	}
+
+	values, err := client.GetAuctionValues(c.Request.Context(), leagueID, season, limit)
+	if err != nil {
+		if !respondESPNError(c, err) {
+			log.Printf("Failed to get auction values for league %s: %v", leagueID, err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to fetch auction values from ESPN"})
+		}
+		return
+	}
+	if values == nil {
+		values = []espn.AuctionValue{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"league_id": leagueID,
+		"season":    season,
+		"values":    values,
+		"count":     len(values),
+	})
+}
+
+// GetKeeperCosts handles GET /api/leagues/espn/:leagueId/keepers
+func (h *LeagueHandler) GetKeeperCosts(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID := c.Param("leagueId")
+
+	client, ok := h.userESPNClient(c, userID)
+	if !ok {
+		return
+	}
+	season, ok := seasonQuery(c, client)
+	if !ok {
+		return
+	}
+
+	costs, err := client.GetKeeperCosts(c.Request.Context(), leagueID, season)
+	if err != nil {
+		if !respondESPNError(c, err) {
+			log.Printf("Failed to get keeper costs for league %s: %v", leagueID, err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to fetch keeper costs from ESPN"})
+		}
+		return
+	}
+
+	// Optionally narrow to one fantasy team
+	if t := c.Query("team_id"); t != "" {
+		teamID, err := strconv.Atoi(t)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid team_id"})
+			return
+		}
+		filtered := costs[:0]
+		for _, cost := range costs {
+			if cost.TeamID == teamID {
+				filtered = append(filtered, cost)
+			}
+		}
+		costs = filtered
+	}
+	if costs == nil {
+		costs = []espn.KeeperCost{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"league_id": leagueID,
+		"season":    season,
+		"keepers":   costs,
+		"count":     len(costs),
+	})
+}
+
+// ListLeagues handles GET /api/leagues
+func (h *LeagueHandler) ListLeagues(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagues, err := h.leagueService.ListLeagues(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to list leagues for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch leagues"})
+		return
+	}
+
+	quota.SetHeaders(c, quota.ResourceLeagues, len(leagues))
+	c.JSON(http.StatusOK, gin.H{
+		"leagues": leagues,
+		"count":   len(leagues),
+	})
+}
+
+// GetSelectedLeague handles GET /api/leagues/selected. ?fields= trims the
+// response.
+func (h *LeagueHandler) GetSelectedLeague(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	league, err := h.leagueService.GetSelectedLeague(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to get selected league for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch selected league"})
+		return
+	}
+	if league == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no leagues connected"})
+		return
+	}
+
+	respondWithFields(c, http.StatusOK, league)
+}
+
+// SelectLeague handles PUT /api/leagues/:id/select
+func (h *LeagueHandler) SelectLeague(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+
+	league, err := h.leagueService.SelectLeague(c.Request.Context(), userID, leagueID)
+	if errors.Is(err, repositories.ErrLeagueNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to select league %s for user %s: %v", leagueID, userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to select league"})
+		return
+	}
+
+	c.JSON(http.StatusOK, league)
+}
+
+// DisconnectLeague handles DELETE /api/leagues/:id
+func (h *LeagueHandler) DisconnectLeague(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+
+	err = h.leagueService.DisconnectLeague(c.Request.Context(), userID, leagueID)
+	if errors.Is(err, repositories.ErrLeagueNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to disconnect league %s for user %s: %v", leagueID, userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to disconnect league"})
+		return
+	}
+	h.activity.Record(c, userID, activity.ActionLeagueDisconnected, "league", leagueID.String(), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "League disconnected successfully",
+	})
+}
+
+// ImportLeagueHistoryRequest optionally limits a history import to seasons
+type ImportLeagueHistoryRequest struct {
+	Seasons []int `json:"seasons"`
+}
+
+// ImportLeagueHistory handles POST /api/leagues/:id/history
+func (h *LeagueHandler) ImportLeagueHistory(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+
+	var req ImportLeagueHistoryRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	client, ok := h.userESPNClient(c, userID)
+	if !ok {
+		return
+	}
+
+	imported, err := h.leagueService.ImportHistory(c.Request.Context(), client, userID, leagueID, req.Seasons)
+	switch {
+	case errors.Is(err, repositories.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+	case errors.Is(err, services.ErrHistoryUnsupported):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		if !respondESPNError(c, err) {
+			log.Printf("Failed to import history for league %s: %v", leagueID, err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to import league history", "imported": imported})
+		}
+	default:
+		c.JSON(http.StatusOK, gin.H{
+			"league_id": leagueID,
+			"imported":  imported,
+			"count":     len(imported),
+		})
+	}
+}
+
+// GetLeagueHistory handles GET /api/leagues/:id/history
+func (h *LeagueHandler) GetLeagueHistory(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+
+	season := 0
+	if s := c.Query("season"); s != "" {
+		if season, err = strconv.Atoi(s); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid season"})
+			return
+		}
+	}
+
+	seasons, err := h.leagueService.GetHistory(c.Request.Context(), userID, leagueID, season)
+	if errors.Is(err, repositories.ErrLeagueNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get history for league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get league history"})
+		return
+	}
+	if seasons == nil {
+		seasons = []models.LeagueSeasonHistory{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"league_id": leagueID,
+		"seasons":   seasons,
+		"count":     len(seasons),
+	})
+}
+
+// GetLiveScoreboard handles GET /api/leagues/:id/live. Scores are cached for
+// seconds while NFL games are in progress and minutes between game windows.
+func (h *LeagueHandler) GetLiveScoreboard(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+
+	week := 0
+	if w := c.Query("week"); w != "" {
+		if week, err = strconv.Atoi(w); err != nil || week < 1 || week > 18 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "week must be between 1 and 18"})
+			return
+		}
+	}
+
+	client, ok := h.userESPNClient(c, userID)
+	if !ok {
+		return
+	}
+
+	scoring, err := h.leagueService.GetLiveScoring(c.Request.Context(), client, userID, leagueID, week)
+	switch {
+	case errors.Is(err, repositories.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+	case errors.Is(err, services.ErrLiveUnsupported):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		if !respondESPNError(c, err) {
+			log.Printf("Failed to get live scoring for league %s: %v", leagueID, err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to fetch live scores from ESPN"})
+		}
+	default:
+		c.JSON(http.StatusOK, scoring)
+	}
+}
+
+// SimulateRulesRequest is a proposed rule change to replay over a past
+// season; a zero season uses the most recent imported one
+type SimulateRulesRequest struct {
+	analytics.RuleChange
+	Season  int `json:"season"`
+	Players int `json:"players" binding:"min=0,max=200"`
+}
+
+// SimulateRuleChange handles POST /api/leagues/:id/simulate-rules
+func (h *LeagueHandler) SimulateRuleChange(c *gin.Context) {
+	if h.simulator == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "rule simulation is not available"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+
+	var req SimulateRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	if req.Scoring == nil && len(req.ReceptionBonus) == 0 && !req.Superflex {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "propose at least one of scoring, reception_bonus or superflex"})
+		return
+	}
+
+	impact, err := h.simulator.Simulate(c.Request.Context(), userID, leagueID, req.Season, req.RuleChange, req.Players)
+	switch {
+	case errors.Is(err, repositories.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+	case errors.Is(err, services.ErrNoSeasonHistory), errors.Is(err, services.ErrNoBoxScores):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case err != nil:
+		log.Printf("Failed to simulate rule change for league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to simulate rule change"})
+	default:
+		c.JSON(http.StatusOK, impact)
+	}
+}
+
+// GetLineup handles GET /api/leagues/:id/teams/:teamId/lineup
+func (h *LeagueHandler) GetLineup(c *gin.Context) {
+	if h.lineups == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "lineup checks are not available"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+	teamID, err := strconv.Atoi(c.Param("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+		return
+	}
+
+	report, err := h.lineups.Analyze(c.Request.Context(), userID, leagueID, teamID)
+	switch {
+	case errors.Is(err, repositories.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+	case errors.Is(err, services.ErrNoSyncedRoster):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		log.Printf("Failed to analyze lineup for league %s team %d: %v", leagueID, teamID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to analyze lineup"})
+	default:
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// GetRosterHistory handles GET /api/leagues/:id/teams/:teamId/roster/history.
+// from and to are weeks, defaulting to the whole season so far; season
+// defaults to the league's current one.
+func (h *LeagueHandler) GetRosterHistory(c *gin.Context) {
+	if h.rosterHistory == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "roster history is not available"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+	teamID, err := strconv.Atoi(c.Param("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+		return
+	}
+
+	var season, from, to int
+	for _, param := range []struct {
+		name string
+		dest *int
+	}{{"season", &season}, {"from", &from}, {"to", &to}} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		if *param.dest, err = strconv.Atoi(raw); err != nil || *param.dest < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param.name})
+			return
+		}
+	}
+
+	history, err := h.rosterHistory.History(c.Request.Context(), userID, leagueID, teamID, season, from, to)
+	switch {
+	case errors.Is(err, repositories.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+	case errors.Is(err, services.ErrNoSyncedRoster):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidWeekRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		log.Printf("Failed to build roster history for league %s team %d: %v", leagueID, teamID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build roster history"})
+	default:
+		respondWithFields(c, http.StatusOK, history)
+	}
+}
+
+// GetAcquisitions handles GET /api/leagues/:id/teams/:teamId/acquisitions,
+// tagging each player on the team's current roster with how they were
+// acquired
+func (h *LeagueHandler) GetAcquisitions(c *gin.Context) {
+	if h.rosterHistory == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "roster history is not available"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+	teamID, err := strconv.Atoi(c.Param("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+		return
+	}
+
+	acquisitions, err := h.rosterHistory.Acquisitions(c.Request.Context(), userID, leagueID, teamID)
+	switch {
+	case errors.Is(err, repositories.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+	case errors.Is(err, services.ErrNoSyncedRoster):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		log.Printf("Failed to attribute acquisitions for league %s team %d: %v", leagueID, teamID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to attribute acquisitions"})
+	default:
+		respondWithFields(c, http.StatusOK, acquisitions)
+	}
+}
+
+// userESPNClient returns the ESPN client with the user's cookies, or the
+// anonymous client for users who have not connected ESPN, which still works
+// for public leagues. It responds and returns false on failure.
+func (h *LeagueHandler) userESPNClient(c *gin.Context, userID uuid.UUID) (espn.Client, bool) {
+	client, _, ok := espnClientForUser(c, h.credService, h.espnClient, userID)
+	return client, ok
+}
+
+// espnClientForUser authenticates client with the user's cookies, also
+// returning their SWID, which is empty for users who have not connected
+// ESPN. It responds and returns false on failure.
+func espnClientForUser(c *gin.Context, credService *services.CredentialsService, client espn.Client, userID uuid.UUID) (espn.Client, string, bool) {
+	swid, espnS2, err := credService.GetESPNCredentials(c.Request.Context(), userID)
+	switch {
+	case err == nil:
+		return client.WithAuthentication(swid, espnS2), swid, true
+	case errors.Is(err, repositories.ErrLeagueAuthNotFound):
+		return client, "", true
+	case errors.Is(err, services.ErrCredentialsInvalid):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your espn_s2 cookie has expired - sign in to ESPN again and update your cookies"})
+		return nil, "", false
+	default:
+		log.Printf("Failed to load ESPN credentials for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load ESPN credentials"})
+		return nil, "", false
+	}
+}
+
+// respondESPNError maps ESPN client errors with a clear user-facing cause to
+// a response, returning false for other errors
+func respondESPNError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, espn.ErrCookiesExpired):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your espn_s2 cookie has expired - sign in to ESPN again and update your cookies"})
+	case errors.Is(err, espn.ErrLeagueNotVisible):
+		c.JSON(http.StatusForbidden, gin.H{"error": "This league isn't accessible with this ESPN account - connect the account that belongs to the league"})
+	case errors.Is(err, espn.ErrUnauthorized):
+		c.JSON(http.StatusForbidden, gin.H{"error": "This league is private - connect your ESPN account to view it"})
+	case errors.Is(err, espn.ErrRegionBlocked):
+		c.JSON(http.StatusBadGateway, gin.H{"error": "ESPN is refusing requests from our servers' region - try again later"})
+	case errors.Is(err, espn.ErrLeagueNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "ESPN league not found"})
+	case errors.Is(err, espn.ErrCircuitOpen):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "ESPN is unavailable, try again shortly"})
+	default:
+		return false
+	}
+	return true
+}
+
+// seasonQuery reads the season query parameter, defaulting to ESPN's current
+// season
+func seasonQuery(c *gin.Context, client espn.Client) (int, bool) {
+	if s := c.Query("season"); s != "" {
+		season, err := strconv.Atoi(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season"})
+			return 0, false
+		}
+		return season, true
+	}
+
+	status, err := client.GetSeasonStatus(c.Request.Context())
+	if err != nil {
+		if !respondESPNError(c, err) {
+			log.Printf("Failed to get ESPN season status: %v", err)
+			c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch current season"})
+		}
+		return 0, false
+	}
+	return status.Season, true
+}
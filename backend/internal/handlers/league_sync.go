@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+	"github.com/nfl-analytics/backend/internal/worker"
+)
+
+// WithSyncQueue lets users ask for a league sync ahead of the scheduled
+// refresh
+func (h *LeagueHandler) WithSyncQueue(q *worker.SyncQueue) *LeagueHandler {
+	h.syncQueue = q
+	return h
+}
+
+// RequestSync handles POST /api/leagues/:id/sync, queueing the league's sync
+// ahead of scheduled work. Each user may ask once per cooldown.
+func (h *LeagueHandler) RequestSync(c *gin.Context) {
+	if h.syncQueue == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "league sync is not enabled"})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid league ID"})
+		return
+	}
+
+	league, err := h.leagueService.GetLeague(c.Request.Context(), userID, leagueID)
+	if errors.Is(err, repositories.ErrLeagueNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "league not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get league"})
+		return
+	}
+	if league.Platform != services.PlatformESPN {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only ESPN leagues are synced"})
+		return
+	}
+	// Rejected cookies would only be rejected again
+	if _, ok := h.userESPNClient(c, userID); !ok {
+		return
+	}
+
+	request, err := h.syncQueue.RequestSync(userID, league)
+	if errors.Is(err, worker.ErrSyncCooldown) {
+		retryAfter := int(math.Ceil(request.RetryAfter.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": fmt.Sprintf("a sync was requested recently, retry in %d seconds", retryAfter),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, request)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/draft"
+	"github.com/nfl-analytics/backend/internal/maintenance"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// MaintenanceHandler reports and toggles maintenance mode
+type MaintenanceHandler struct {
+	maintenance         *maintenance.Switch
+	draftService        *draft.Service
+	notificationService services.NotificationService
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(sw *maintenance.Switch, draftService *draft.Service, notificationService services.NotificationService) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenance:         sw,
+		draftService:        draftService,
+		notificationService: notificationService,
+	}
+}
+
+// SetMaintenanceRequest turns maintenance mode on or off
+type SetMaintenanceRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required"`
+	Message string `json:"message"`
+}
+
+// GetStatus returns the current maintenance state so clients can show a
+// banner before requests start failing
+func (h *MaintenanceHandler) GetStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.maintenance.State(c.Request.Context()))
+}
+
+// SetMaintenance turns maintenance mode on or off. Turning it on warns
+// every user with a draft in progress that the draft will be interrupted
+// once the grace period ends.
+func (h *MaintenanceHandler) SetMaintenance(c *gin.Context) {
+	var req SetMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if !*req.Enabled {
+		state, err := h.maintenance.Disable(ctx)
+		if err != nil {
+			log.Printf("Failed to disable maintenance mode: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable maintenance mode"})
+			return
+		}
+		log.Printf("Maintenance mode disabled")
+		c.JSON(http.StatusOK, state)
+		return
+	}
+
+	wasEnabled := h.maintenance.State(ctx).Enabled
+	state, err := h.maintenance.Enable(ctx, req.Message)
+	if err != nil {
+		log.Printf("Failed to enable maintenance mode: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enable maintenance mode"})
+		return
+	}
+	log.Printf("Maintenance mode enabled; drafts close at %s", state.DraftGraceUntil.Format("15:04:05 MST"))
+
+	warned := 0
+	if !wasEnabled {
+		// A failed warning should not undo the switch
+		if warned, err = h.warnActiveDrafts(ctx, state); err != nil {
+			log.Printf("Failed to warn active drafts of maintenance: %v", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"state":         state,
+		"drafts_warned": warned,
+	})
+}
+
+// warnActiveDrafts notifies the owner of every draft in progress
+func (h *MaintenanceHandler) warnActiveDrafts(ctx context.Context, state maintenance.State) (int, error) {
+	sessions, err := h.draftService.GetActiveSessions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(sessions))
+	for _, s := range sessions {
+		userID, err := uuid.Parse(s.UserID)
+		if err != nil {
+			log.Printf("Skipping maintenance warning for draft %s: invalid user ID %q", s.ID, s.UserID)
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return h.notificationService.NotifyMaintenance(ctx, userIDs, state.Message, state.DraftGraceUntil)
+}
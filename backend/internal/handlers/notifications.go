@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/quota"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+const maxNotificationsLimit = 100
+
+// NotificationsHandler handles notification and watchlist requests
+type NotificationsHandler struct {
+	notificationService services.NotificationService
+}
+
+// NewNotificationsHandler creates a new notifications handler
+func NewNotificationsHandler(notificationService services.NotificationService) *NotificationsHandler {
+	return &NotificationsHandler{
+		notificationService: notificationService,
+	}
+}
+
+// WatchlistRequest is the body for adding a player to the watchlist
+type WatchlistRequest struct {
+	PlayerName string `json:"player_name" binding:"required"`
+}
+
+// GetNotifications handles GET /api/notifications
+func (h *NotificationsHandler) GetNotifications(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > maxNotificationsLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+	unreadOnly := c.Query("unread") == "true"
+
+	notifications, err := h.notificationService.GetNotifications(c.Request.Context(), userID, unreadOnly, limit)
+	if err != nil {
+		log.Printf("Failed to get notifications for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notifications"})
+		return
+	}
+	if notifications == nil {
+		notifications = []models.Notification{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": notifications,
+		"count":         len(notifications),
+	})
+}
+
+// MarkNotificationRead handles POST /api/notifications/:id/read
+func (h *NotificationsHandler) MarkNotificationRead(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	err = h.notificationService.MarkRead(c.Request.Context(), userID, id)
+	if errors.Is(err, repositories.ErrNotificationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to mark notification %d read: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+// GetWatchlist handles GET /api/watchlist
+func (h *NotificationsHandler) GetWatchlist(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.notificationService.GetWatchlist(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to get watchlist for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch watchlist"})
+		return
+	}
+	if entries == nil {
+		entries = []models.WatchlistEntry{}
+	}
+
+	quota.SetHeaders(c, quota.ResourceWatchlist, len(entries))
+	c.JSON(http.StatusOK, gin.H{
+		"players": entries,
+		"count":   len(entries),
+	})
+}
+
+// AddToWatchlist handles POST /api/watchlist
+func (h *NotificationsHandler) AddToWatchlist(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.notificationService.AddToWatchlist(c.Request.Context(), userID, req.PlayerName); err != nil {
+		log.Printf("Failed to add %s to watchlist: %v", req.PlayerName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update watchlist"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Player added to watchlist"})
+}
+
+// RemoveFromWatchlist handles DELETE /api/watchlist/:player
+func (h *NotificationsHandler) RemoveFromWatchlist(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	player := c.Param("player")
+	if err := h.notificationService.RemoveFromWatchlist(c.Request.Context(), userID, player); err != nil {
+		log.Printf("Failed to remove %s from watchlist: %v", player, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update watchlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Player removed from watchlist"})
+}
+
+// currentUserID reads the authenticated user's ID set by the auth middleware,
+// writing an error response if it is missing
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return uuid.Nil, false
+	}
+
+	userID, ok := value.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user ID format"})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/audit"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// oauthStateCookie holds the state sent to the provider until its callback
+const oauthStateCookie = "oauth_state"
+
+// oauthDeliveryCookie remembers that sign-in was started in cookie mode
+const oauthDeliveryCookie = "oauth_token_delivery"
+
+// oauthStateMaxAge is how long a user has to finish signing in, in seconds
+const oauthStateMaxAge = 10 * 60
+
+// OAuthHandler handles social sign-in
+type OAuthHandler struct {
+	oauthService services.OAuthService
+	provider     auth.OAuthProvider
+	// redirectURL is the frontend page that receives tokens after sign-in
+	redirectURL string
+	activity    *activity.Recorder
+	audit       *audit.Logger
+	// cookies is set when sign-ins may deliver tokens as cookies
+	cookies *auth.CookieConfig
+}
+
+// NewOAuthHandler creates a handler for one sign-in provider
+func NewOAuthHandler(oauthService services.OAuthService, provider auth.OAuthProvider) *OAuthHandler {
+	return &OAuthHandler{
+		oauthService: oauthService,
+		provider:     provider,
+	}
+}
+
+// WithRedirect sends the browser to a frontend page after sign-in, with the
+// tokens or an error in the URL fragment, instead of answering the callback
+// with JSON
+func (h *OAuthHandler) WithRedirect(redirectURL string) *OAuthHandler {
+	h.redirectURL = redirectURL
+	return h
+}
+
+// WithActivity records social sign-ins in the user's activity
+func (h *OAuthHandler) WithActivity(r *activity.Recorder) *OAuthHandler {
+	h.activity = r
+	return h
+}
+
+// WithAudit records social sign-ins in the security audit log
+func (h *OAuthHandler) WithAudit(l *audit.Logger) *OAuthHandler {
+	h.audit = l
+	return h
+}
+
+// WithCookies lets the frontend start sign-in with ?token_delivery=cookie to
+// get the tokens as httpOnly cookies instead of in the redirect
+func (h *OAuthHandler) WithCookies(cfg auth.CookieConfig) *OAuthHandler {
+	h.cookies = &cfg
+	return h
+}
+
+// Start handles GET /api/auth/:provider/login, sending the browser to the
+// provider's sign-in page
+func (h *OAuthHandler) Start(c *gin.Context) {
+	state, err := auth.NewOAuthState()
+	if err != nil {
+		log.Printf("Failed to start %s sign-in: %v", h.provider.Name(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "sign-in failed"})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, oauthStateMaxAge, h.cookiePath(), "", isSecureRequest(c), true)
+	if h.cookies != nil && c.Query("token_delivery") == "cookie" {
+		c.SetCookie(oauthDeliveryCookie, "cookie", oauthStateMaxAge, h.cookiePath(), "", isSecureRequest(c), true)
+	}
+	c.Redirect(http.StatusFound, h.provider.AuthCodeURL(state))
+}
+
+// Callback handles GET /api/auth/:provider/callback, where the provider
+// returns the browser after sign-in
+func (h *OAuthHandler) Callback(c *gin.Context) {
+	state, err := c.Cookie(oauthStateCookie)
+	if err != nil || state == "" || c.Query("state") != state {
+		h.fail(c, http.StatusBadRequest, "invalid sign-in state")
+		return
+	}
+	// The state is good for one callback
+	c.SetCookie(oauthStateCookie, "", -1, h.cookiePath(), "", isSecureRequest(c), true)
+	delivery, _ := c.Cookie(oauthDeliveryCookie)
+	if delivery != "" {
+		c.SetCookie(oauthDeliveryCookie, "", -1, h.cookiePath(), "", isSecureRequest(c), true)
+	}
+
+	if denied := c.Query("error"); denied != "" {
+		h.fail(c, http.StatusUnauthorized, "sign-in was cancelled")
+		return
+	}
+
+	identity, err := h.provider.Exchange(c.Request.Context(), c.Query("code"))
+	if err != nil {
+		log.Printf("%s sign-in exchange failed: %v", h.provider.Name(), err)
+		if errors.Is(err, auth.ErrOAuthUnavailable) {
+			h.fail(c, http.StatusServiceUnavailable, "sign-in provider unavailable, try again shortly")
+		} else {
+			h.fail(c, http.StatusUnauthorized, "sign-in failed")
+		}
+		return
+	}
+
+	response, err := h.oauthService.SignIn(auth.WithClient(c.Request.Context(), c), identity)
+	switch {
+	case errors.Is(err, services.ErrEmailNotVerified):
+		h.fail(c, http.StatusForbidden, "verify your email with "+h.provider.Name()+" before signing in")
+		return
+	case errors.Is(err, services.ErrInvalidCredentials):
+		h.fail(c, http.StatusUnauthorized, "account is disabled")
+		return
+	case err != nil:
+		log.Printf("%s sign-in failed: %v", h.provider.Name(), err)
+		h.fail(c, http.StatusInternalServerError, "sign-in failed")
+		return
+	}
+
+	if !response.TwoFactorRequired {
+		h.activity.Record(c, response.User.ID, activity.ActionLogin, "", "", map[string]interface{}{"method": h.provider.Name()})
+		h.audit.RecordRequest(c, response.User.ID, audit.EventLoginSucceeded, map[string]interface{}{"method": h.provider.Name()})
+	}
+	h.succeed(c, response, delivery == "cookie")
+}
+
+// succeed hands the token pair, or the token to verify a two-factor code
+// with, to the frontend. In cookie mode the tokens are set as cookies and
+// only the CSRF token is handed over.
+func (h *OAuthHandler) succeed(c *gin.Context, response *models.AuthResponse, cookies bool) {
+	if cookies && h.cookies != nil && !response.TwoFactorRequired {
+		body, err := setTokenCookies(c, *h.cookies, response)
+		if err != nil {
+			log.Printf("Failed to set auth cookies: %v", err)
+			h.fail(c, http.StatusInternalServerError, "sign-in failed")
+			return
+		}
+		response = body
+	}
+
+	if h.redirectURL == "" {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	fragment := url.Values{
+		"access_token":  {response.AccessToken},
+		"refresh_token": {response.RefreshToken},
+	}
+	if response.CSRFToken != "" {
+		fragment = url.Values{"csrf_token": {response.CSRFToken}}
+	}
+	if response.TwoFactorRequired {
+		fragment = url.Values{"two_factor_token": {response.TwoFactorToken}}
+	}
+	c.Redirect(http.StatusFound, h.redirectURL+"#"+fragment.Encode())
+}
+
+// fail reports a sign-in error to the frontend
+func (h *OAuthHandler) fail(c *gin.Context, status int, message string) {
+	if h.redirectURL == "" {
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+	c.Redirect(http.StatusFound, h.redirectURL+"#"+url.Values{"error": {message}}.Encode())
+}
+
+// cookiePath scopes the state cookie to the provider's routes
+func (h *OAuthHandler) cookiePath() string {
+	return "/api/auth/" + h.provider.Name()
+}
+
+// isSecureRequest reports whether the client reached us over HTTPS, directly
+// or through a proxy
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/analytics"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/nfl-analytics/backend/internal/stream"
+	"gopkg.in/yaml.v3"
+)
+
+// TestOpenAPISchemasMatchStructs keeps docs/openapi.yaml, and the TypeScript
+// client generated from it, in step with the structs handlers serialize
+func TestOpenAPISchemasMatchStructs(t *testing.T) {
+	data, err := os.ReadFile("../../../docs/openapi.yaml")
+	if err != nil {
+		t.Fatalf("failed to read spec: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]interface{} `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("failed to parse spec: %v", err)
+	}
+
+	structs := map[string]interface{}{
+		"RegisterRequest":        models.RegisterRequest{},
+		"LoginRequest":           models.LoginRequest{},
+		"RefreshTokenRequest":    models.RefreshTokenRequest{},
+		"User":                   models.UserResponse{},
+		"AuthResponse":           models.AuthResponse{},
+		"TwoFactorVerifyRequest": models.TwoFactorVerifyRequest{},
+		"LoginVerifyRequest":     models.LoginVerifyRequest{},
+		"ProjectionResponse":     ProjectionResponse{},
+		"Adjustment":             projections.Adjustment{},
+		"GameWeather":            projections.GameWeather{},
+		"League":                 models.League{},
+		"ConnectESPNRequest":     ConnectESPNRequest{},
+		"LeagueAnalytics":        analytics.LeagueAnalytics{},
+		"TeamStanding":           analytics.TeamStanding{},
+		"PowerRanking":           analytics.PowerRanking{},
+		"GameRecord":             analytics.GameRecord{},
+		"LeagueRecords":          analytics.LeagueRecords{},
+		"Award":                  analytics.Award{},
+		"PlayoffOdds":            analytics.PlayoffOdds{},
+		"RosterConstruction":     analytics.RosterConstruction{},
+		"SourceBreakdown":        analytics.SourceBreakdown{},
+		"WeeklyRecap":            analytics.WeeklyRecap{},
+		"RecapGame":              analytics.RecapGame{},
+		"Upset":                  analytics.Upset{},
+		"StandingsMove":          analytics.StandingsMove{},
+		"RecapSection":           analytics.RecapSection{},
+		"DraftRecommendation":    models.DraftRecommendation{},
+		"Notification":           models.Notification{},
+		"TransportNegotiation":   TransportNegotiation{},
+		"StreamEvent":            stream.Event{},
+		"StreamEvents":           StreamEvents{},
+	}
+
+	for name, v := range structs {
+		schema, ok := spec.Components.Schemas[name]
+		if !ok {
+			t.Errorf("schema %s missing from spec", name)
+			continue
+		}
+
+		var specFields []string
+		for field := range schema.Properties {
+			specFields = append(specFields, field)
+		}
+		sort.Strings(specFields)
+
+		if goFields := jsonFields(reflect.TypeOf(v)); !reflect.DeepEqual(goFields, specFields) {
+			t.Errorf("schema %s fields %v do not match %T fields %v", name, specFields, v, goFields)
+		}
+	}
+}
+
+// jsonFields lists the JSON names encoding/json writes for a struct
+func jsonFields(typ reflect.Type) []string {
+	var fields []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
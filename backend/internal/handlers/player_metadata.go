@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/nflverse"
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// PlayerMetadataHandler runs player metadata refreshes and serves their
+// reports to the data quality dashboard
+type PlayerMetadataHandler struct {
+	refresher *nflverse.Refresher
+	repo      nflverse.Repository
+}
+
+// NewPlayerMetadataHandler creates a new player metadata handler
+func NewPlayerMetadataHandler(refresher *nflverse.Refresher, repo nflverse.Repository) *PlayerMetadataHandler {
+	return &PlayerMetadataHandler{refresher: refresher, repo: repo}
+}
+
+// Refresh handles POST /api/admin/players/metadata/refresh, refreshing a
+// season's player metadata now (?season=, the current season by default)
+// and returning the change report
+func (h *PlayerMetadataHandler) Refresh(c *gin.Context) {
+	season := projections.CurrentSeason(time.Now())
+	if s := c.Query("season"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil || parsed < 1999 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season parameter"})
+			return
+		}
+		season = parsed
+	}
+
+	report, err := h.refresher.Refresh(c.Request.Context(), season)
+	if err != nil {
+		log.Printf("Failed to refresh player metadata for %d: %v", season, err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to refresh player metadata", "report": report})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetReports handles GET /api/admin/data-quality/player-metadata, listing
+// the latest refreshes (?limit=, default 10) with the changes each found
+func (h *PlayerMetadataHandler) GetReports(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+
+	reports, err := h.repo.GetMetadataReports(c.Request.Context(), limit)
+	if err != nil {
+		log.Printf("Failed to get player metadata reports: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get player metadata reports"})
+		return
+	}
+	if reports == nil {
+		reports = []nflverse.MetadataReport{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reports": reports})
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/nflverse"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+const (
+	maxNewsLimit     = 50
+	maxTrendingLimit = 100
+)
+
+// PlayersHandler handles player information requests
+type PlayersHandler struct {
+	newsService services.PlayerNewsService
+	espnClient  espn.Client
+	teams       services.TeamMetadataService
+	rosters     nflverse.Repository
+}
+
+// NewPlayersHandler creates a new players handler
+func NewPlayersHandler(newsService services.PlayerNewsService, espnClient espn.Client) *PlayersHandler {
+	return &PlayersHandler{
+		newsService: newsService,
+		espnClient:  espnClient,
+	}
+}
+
+// WithTeams decorates player responses with their NFL teams' names and
+// logos and serves the team directory
+func (h *PlayersHandler) WithTeams(teams services.TeamMetadataService) *PlayersHandler {
+	h.teams = teams
+	return h
+}
+
+// WithRosters enables batch player lookups from the nflverse rosters
+func (h *PlayersHandler) WithRosters(rosters nflverse.Repository) *PlayersHandler {
+	h.rosters = rosters
+	return h
+}
+
+// GetPlayersBatch handles POST /api/players/batch, looking up to
+// maxBatchIDs players by nflverse or ESPN ID in one request. Results are
+// keyed by the requested ID; IDs with no player are listed as missing.
+func (h *PlayersHandler) GetPlayersBatch(c *gin.Context) {
+	if h.rosters == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Player lookups are not available"})
+		return
+	}
+	req, ok := bindBatch(c)
+	if !ok {
+		return
+	}
+	season := req.Season
+	if season == 0 {
+		season = projections.CurrentSeason(time.Now())
+	}
+
+	entries, err := h.rosters.GetRosterEntries(c.Request.Context(), season, req.IDs)
+	if err != nil {
+		log.Printf("Failed to look up %d players for %d: %v", len(req.IDs), season, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up players"})
+		return
+	}
+
+	requested := make(map[string]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		requested[id] = true
+	}
+	players := make(map[string]nflverse.RosterEntry, len(entries))
+	var abbrs []string
+	for _, e := range entries {
+		for _, id := range []string{e.PlayerID, e.ESPNID} {
+			if id != "" && requested[id] {
+				players[id] = e
+			}
+		}
+		abbrs = append(abbrs, e.Team)
+	}
+
+	response := gin.H{
+		"season":  season,
+		"players": players,
+		"missing": missingIDs(req.IDs, players),
+	}
+	if h.teams != nil {
+		response["teams"] = h.teams.Decorate(abbrs...)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetPlayerNews returns recent news and the current injury designation for
+// an ESPN player ID
+func (h *PlayersHandler) GetPlayerNews(c *gin.Context) {
+	playerID := c.Param("id")
+	if _, err := strconv.Atoi(playerID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > maxNewsLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 50"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	news, err := h.newsService.GetPlayerNews(ctx, playerID, limit)
+	if errors.Is(err, espn.ErrLeagueNotFound) {
+		// ESPN answers 404 for unknown players
+		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+		return
+	}
+	if errors.Is(err, espn.ErrCircuitOpen) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "ESPN is unavailable, try again shortly"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get news for player %s: %v", playerID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player news"})
+		return
+	}
+	if news == nil {
+		news = []models.PlayerNews{}
+	}
+
+	injuryStatus, err := h.newsService.GetInjuryStatus(ctx, playerID)
+	if err != nil {
+		// News is still useful without the designation
+		log.Printf("Failed to get injury status for player %s: %v", playerID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player_id":     playerID,
+		"injury_status": injuryStatus,
+		"news":          news,
+		"count":         len(news),
+	})
+}
+
+// GetTrendingPlayers returns the players most added or dropped across ESPN
+// leagues over the last week, for spotting waiver targets
+func (h *PlayersHandler) GetTrendingPlayers(c *gin.Context) {
+	direction := c.DefaultQuery("direction", espn.TrendingAdds)
+	if direction != espn.TrendingAdds && direction != espn.TrendingDrops {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "direction must be adds or drops"})
+		return
+	}
+
+	position := strings.ToUpper(c.Query("position"))
+	if position == "D/ST" {
+		position = "DST"
+	}
+	switch position {
+	case "", "QB", "RB", "WR", "TE", "DST", "K":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid position"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "25"))
+	if err != nil || limit < 1 || limit > maxTrendingLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	season, ok := seasonQuery(c, h.espnClient)
+	if !ok {
+		return
+	}
+
+	players, err := h.espnClient.GetTrendingPlayers(ctx, season, direction, position, limit)
+	if errors.Is(err, espn.ErrCircuitOpen) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "ESPN is unavailable, try again shortly"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get trending players: %v", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch trending players"})
+		return
+	}
+	if players == nil {
+		players = []espn.TrendingPlayer{}
+	}
+
+	response := gin.H{
+		"season":    season,
+		"direction": direction,
+		"players":   players,
+		"count":     len(players),
+	}
+	if h.teams != nil {
+		abbrs := make([]string, 0, len(players))
+		for _, p := range players {
+			abbrs = append(abbrs, p.Team)
+		}
+		response["teams"] = h.teams.Decorate(abbrs...)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetNFLTeams handles GET /api/nfl/teams, every NFL team's names, colors
+// and logos
+func (h *PlayersHandler) GetNFLTeams(c *gin.Context) {
+	teams, ok := h.loadedTeams(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"teams": teams, "count": len(teams)})
+}
+
+// GetNFLTeam handles GET /api/nfl/teams/:abbr
+func (h *PlayersHandler) GetNFLTeam(c *gin.Context) {
+	if _, ok := h.loadedTeams(c); !ok {
+		return
+	}
+	team, ok := h.teams.Team(c.Param("abbr"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "NFL team not found"})
+		return
+	}
+	c.JSON(http.StatusOK, team)
+}
+
+// loadedTeams returns the team directory, loading it if the worker has not
+// yet. It responds and returns false when no teams are available.
+func (h *PlayersHandler) loadedTeams(c *gin.Context) ([]espn.NFLTeam, bool) {
+	if h.teams == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "NFL team metadata is not available"})
+		return nil, false
+	}
+	teams := h.teams.Teams()
+	if len(teams) > 0 {
+		return teams, true
+	}
+
+	if _, err := h.teams.Load(c.Request.Context()); err != nil {
+		log.Printf("Failed to load NFL team metadata: %v", err)
+	}
+	teams = h.teams.Teams()
+	if len(teams) == 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "NFL team metadata is not available yet, try again shortly"})
+		return nil, false
+	}
+	return teams, true
+}
@@ -1,13 +1,21 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"log"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/cache"
+	"github.com/nfl-analytics/backend/internal/projections"
 )
 
 type ProjectionResponse struct {
@@ -31,15 +39,109 @@ type ProjectionResponse struct {
 	ProjectionStdDev    *float64 `json:"projection_std_dev"`
 	ConfidenceRating    string   `json:"confidence_rating"`
 	HasProps            bool     `json:"has_props"`
+	// Consensus values from the pipeline's scoring stage, before modifiers
+	BaseConsensusPPR      float64                  `json:"base_consensus_ppr"`
+	BaseConsensusStandard float64                  `json:"base_consensus_standard"`
+	Adjustments           []projections.Adjustment `json:"adjustments"`
+	// Weather is the forecast for the player's game, when one is loaded
+	Weather *projections.GameWeather `json:"weather,omitempty"`
 }
 
+// maxProjectionsLimit caps how many projections one request can list
+const maxProjectionsLimit = 500
+
 type ProjectionsHandler struct {
-	db *sql.DB
+	db             *sql.DB
+	projectionRepo projections.Repository
+	cache          *cache.Cache
+	cacheTTL       time.Duration
+	ecrRepo        projections.ECRRepository
+	scheduleRepo   projections.ScheduleRepository
+	availRepo      projections.AvailabilityRepository
 }
 
-func NewProjectionsHandler(db *sql.DB) *ProjectionsHandler {
+func NewProjectionsHandler(db *sql.DB, projectionRepo projections.Repository) *ProjectionsHandler {
 	return &ProjectionsHandler{
-		db: db,
+		db:             db,
+		projectionRepo: projectionRepo,
+	}
+}
+
+// WithCache serves projection lists from c, refreshing them after ttl
+func (h *ProjectionsHandler) WithCache(c *cache.Cache, ttl time.Duration) *ProjectionsHandler {
+	h.cache = c
+	h.cacheTTL = ttl
+	return h
+}
+
+// WithECR enables player history and the rank accuracy comparison against
+// stored expert consensus ranks
+func (h *ProjectionsHandler) WithECR(repo projections.ECRRepository) *ProjectionsHandler {
+	h.ecrRepo = repo
+	return h
+}
+
+// WithRestOfSeason enables rest-of-season projections, which need byes from
+// the schedule and the games players miss to suspensions and PUP stints
+func (h *ProjectionsHandler) WithRestOfSeason(schedule projections.ScheduleRepository, availability projections.AvailabilityRepository) *ProjectionsHandler {
+	h.scheduleRepo = schedule
+	h.availRepo = availability
+	return h
+}
+
+// attachStageOutputs fills in base values and adjustments from the persisted
+// pipeline stages. Projections the pipeline did not compute are served as-is.
+func (h *ProjectionsHandler) attachStageOutputs(ctx context.Context, season, week int, results []ProjectionResponse) {
+	names := make([]string, len(results))
+	for i := range results {
+		results[i].BaseConsensusPPR = results[i].ConsensusPPR
+		results[i].BaseConsensusStandard = results[i].ConsensusStandard
+		results[i].Adjustments = []projections.Adjustment{}
+		names[i] = results[i].PlayerName
+	}
+
+	outputs, err := h.projectionRepo.GetStageOutputs(ctx, season, week, names)
+	if err != nil {
+		// Serve projections without the breakdown rather than failing the request
+		log.Printf("Failed to load projection stage outputs for %d week %d: %v", season, week, err)
+		return
+	}
+
+	for i := range results {
+		for _, o := range outputs[results[i].PlayerName] {
+			if o.Stage == projections.StageScoring {
+				results[i].BaseConsensusPPR = o.PointsPPR
+				results[i].BaseConsensusStandard = o.PointsStandard
+			}
+			if o.Adjustment != nil {
+				results[i].Adjustments = append(results[i].Adjustments, *o.Adjustment)
+			}
+		}
+	}
+}
+
+// attachWeather adds the forecast for each player's game. Projections are
+// served without weather if the forecasts cannot be loaded.
+func (h *ProjectionsHandler) attachWeather(ctx context.Context, season, week int, results []ProjectionResponse) {
+	if week == 0 || len(results) == 0 {
+		return
+	}
+
+	games, err := h.projectionRepo.GetGameWeather(ctx, season, week)
+	if err != nil {
+		log.Printf("Failed to load game weather for %d week %d: %v", season, week, err)
+		return
+	}
+
+	byTeam := make(map[string]*projections.GameWeather, len(games)*2)
+	for i := range games {
+		byTeam[strings.ToUpper(games[i].HomeTeam)] = &games[i]
+		byTeam[strings.ToUpper(games[i].AwayTeam)] = &games[i]
+	}
+	for i := range results {
+		if results[i].Team != nil {
+			results[i].Weather = byTeam[strings.ToUpper(*results[i].Team)]
+		}
 	}
 }
 
@@ -59,7 +161,7 @@ func (h *ProjectionsHandler) GetProjections(c *gin.Context) {
 	weekStr := c.DefaultQuery("week", "1")
 	seasonStr := c.DefaultQuery("season", "2025")
 	position := c.Query("position")
-	limit := c.DefaultQuery("limit", "50")
+	limitStr := c.DefaultQuery("limit", "50")
 
 	week, err := strconv.Atoi(weekStr)
 	if err != nil {
@@ -73,6 +175,52 @@ func (h *ProjectionsHandler) GetProjections(c *gin.Context) {
 		return
 	}
 
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 || limit > maxProjectionsLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+		return
+	}
+
+	results, err := h.listProjections(c.Request.Context(), season, week, position, limit)
+	if err != nil {
+		log.Printf("Failed to fetch projections for %d week %d: %v", season, week, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projections"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"projections": results,
+		"week":        week,
+		"season":      season,
+		"count":       len(results),
+	})
+}
+
+// listProjections returns the top consensus projections for a week, from the
+// cache when it is warm
+func (h *ProjectionsHandler) listProjections(ctx context.Context, season, week int, position string, limit int) ([]ProjectionResponse, error) {
+	key := projectionsCacheKey(season, week, position, limit)
+
+	var results []ProjectionResponse
+	if h.cache.GetJSON(ctx, key, &results) {
+		return results, nil
+	}
+
+	results, err := h.queryProjections(ctx, season, week, position, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.cache.SetJSON(ctx, key, results, h.cacheTTL); err != nil {
+		log.Printf("Failed to cache projections: %v", err)
+	}
+
+	return results, nil
+}
+
+// queryProjections loads the top consensus projections for a week from the
+// warehouse
+func (h *ProjectionsHandler) queryProjections(ctx context.Context, season, week int, position string, limit int) ([]ProjectionResponse, error) {
 	query := `
 		SELECT 
 			player_name,
@@ -100,22 +248,21 @@ func (h *ProjectionsHandler) GetProjections(c *gin.Context) {
 	`
 
 	args := []interface{}{week, season}
-	
+
 	if position != "" {
 		query += " AND position = $3"
 		args = append(args, position)
 	}
-	
-	query += " ORDER BY consensus_points_ppr DESC LIMIT " + limit
 
-	rows, err := h.db.Query(query, args...)
+	query += fmt.Sprintf(" ORDER BY consensus_points_ppr DESC LIMIT %d", limit)
+
+	rows, err := h.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projections"})
-		return
+		return nil, fmt.Errorf("failed to query projections: %w", err)
 	}
 	defer rows.Close()
 
-	var projections []ProjectionResponse
+	var results []ProjectionResponse
 	for rows.Next() {
 		var p ProjectionResponse
 		err := rows.Scan(
@@ -155,18 +302,186 @@ func (h *ProjectionsHandler) GetProjections(c *gin.Context) {
 		p.Receptions = sanitizeFloat64(p.Receptions)
 		p.ProjectionStdDev = sanitizeFloat64(p.ProjectionStdDev)
 		
-		projections = append(projections, p)
+		results = append(results, p)
+	}
+
+	h.attachStageOutputs(ctx, season, week, results)
+	h.attachWeather(ctx, season, week, results)
+
+	return results, nil
+}
+
+// WarmCache loads the projection lists the app requests most for a week into
+// the cache. Returns the number of lists cached.
+func (h *ProjectionsHandler) WarmCache(ctx context.Context, season, week int) (int, error) {
+	if !h.cache.Enabled() {
+		return 0, nil
+	}
+
+	type view struct {
+		position string
+		limit    int
+	}
+	views := []view{{"", 50}, {"", 100}}
+	for _, pos := range []string{"QB", "RB", "WR", "TE", "K", "DST"} {
+		views = append(views, view{pos, 50})
+	}
+
+	for _, v := range views {
+		results, err := h.queryProjections(ctx, season, week, v.position, v.limit)
+		if err != nil {
+			return 0, err
+		}
+		if err := h.cache.SetJSON(ctx, projectionsCacheKey(season, week, v.position, v.limit), results, h.cacheTTL); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(views), nil
+}
+
+// InvalidateCache drops every cached projection list of a season, such as
+// after players change teams. Returns the number of lists dropped.
+func (h *ProjectionsHandler) InvalidateCache(ctx context.Context, season int) (int, error) {
+	return h.cache.DeletePrefix(ctx, fmt.Sprintf("projections:%d:", season))
+}
+
+func projectionsCacheKey(season, week int, position string, limit int) string {
+	return fmt.Sprintf("projections:%d:%d:%s:%d", season, week, position, limit)
+}
+
+// GetProjectionsBatch handles POST /api/projections/batch, up to
+// maxBatchIDs players' projections for a week in one request. Players are
+// matched by the ID stored with their projection or by ESPN ID, and results
+// are keyed by the requested ID.
+func (h *ProjectionsHandler) GetProjectionsBatch(c *gin.Context) {
+	req, ok := bindBatch(c)
+	if !ok {
+		return
+	}
+	season, week := req.Season, req.Week
+	if season == 0 {
+		season = projections.CurrentSeason(time.Now())
+	}
+	if week == 0 {
+		week = 1
+	}
+
+	query := `
+		SELECT
+			COALESCE(c.player_id, ''),
+			COALESCE(r.espn_id, ''),
+			c.player_name,
+			c.position,
+			c.team,
+			c.consensus_points_ppr,
+			c.consensus_points_standard,
+			c.floor_points_ppr,
+			c.ceiling_points_ppr,
+			c.betonline_proj,
+			c.pinnacle_proj,
+			c.proj_passing_yards,
+			c.proj_passing_tds,
+			c.proj_rushing_yards,
+			c.proj_rushing_tds,
+			c.proj_receiving_yards,
+			c.proj_receiving_tds,
+			c.proj_receptions,
+			c.num_sources,
+			c.projection_std_dev,
+			c.confidence_rating,
+			c.has_props
+		FROM gold.consensus_projections c
+		LEFT JOIN silver.nfl_rosters r ON r.player_id = c.player_id AND r.season = c.season
+		WHERE c.week = $1 AND c.season = $2 AND (c.player_id = ANY($3) OR r.espn_id = ANY($3))
+	`
+	ctx := c.Request.Context()
+	rows, err := h.db.QueryContext(ctx, query, week, season, pq.Array(req.IDs))
+	if err != nil {
+		log.Printf("Failed to look up %d projections for %d week %d: %v", len(req.IDs), season, week, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projections"})
+		return
+	}
+	defer rows.Close()
+
+	var results []ProjectionResponse
+	var keys [][2]string
+	for rows.Next() {
+		var p ProjectionResponse
+		var playerID, espnID string
+		if err := rows.Scan(
+			&playerID,
+			&espnID,
+			&p.PlayerName,
+			&p.Position,
+			&p.Team,
+			&p.ConsensusPPR,
+			&p.ConsensusStandard,
+			&p.FloorPPR,
+			&p.CeilingPPR,
+			&p.BetonlineProj,
+			&p.PinnacleProj,
+			&p.PassingYards,
+			&p.PassingTDs,
+			&p.RushingYards,
+			&p.RushingTDs,
+			&p.ReceivingYards,
+			&p.ReceivingTDs,
+			&p.Receptions,
+			&p.NumSources,
+			&p.ProjectionStdDev,
+			&p.ConfidenceRating,
+			&p.HasProps,
+		); err != nil {
+			log.Printf("Failed to scan projection: %v", err)
+			continue
+		}
+		p.BetonlineProj = sanitizeFloat64(p.BetonlineProj)
+		p.PinnacleProj = sanitizeFloat64(p.PinnacleProj)
+		p.PassingYards = sanitizeFloat64(p.PassingYards)
+		p.PassingTDs = sanitizeFloat64(p.PassingTDs)
+		p.RushingYards = sanitizeFloat64(p.RushingYards)
+		p.RushingTDs = sanitizeFloat64(p.RushingTDs)
+		p.ReceivingYards = sanitizeFloat64(p.ReceivingYards)
+		p.ReceivingTDs = sanitizeFloat64(p.ReceivingTDs)
+		p.Receptions = sanitizeFloat64(p.Receptions)
+		p.ProjectionStdDev = sanitizeFloat64(p.ProjectionStdDev)
+
+		results = append(results, p)
+		keys = append(keys, [2]string{playerID, espnID})
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to read projections for %d week %d: %v", season, week, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projections"})
+		return
+	}
+
+	h.attachStageOutputs(ctx, season, week, results)
+	h.attachWeather(ctx, season, week, results)
+
+	requested := make(map[string]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		requested[id] = true
+	}
+	byID := make(map[string]ProjectionResponse, len(results))
+	for i, p := range results {
+		for _, id := range keys[i] {
+			if id != "" && requested[id] {
+				byID[id] = p
+			}
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"projections": projections,
-		"week":        week,
 		"season":      season,
-		"count":       len(projections),
+		"week":        week,
+		"projections": byID,
+		"missing":     missingIDs(req.IDs, byID),
 	})
 }
 
-// GetPlayerProjection returns projection for a specific player
+// GetPlayerProjection returns projection for a specific player. ?fields=
+// trims the response.
 func (h *ProjectionsHandler) GetPlayerProjection(c *gin.Context) {
 	playerName := c.Param("player")
 	weekStr := c.DefaultQuery("week", "1")
@@ -255,5 +570,461 @@ func (h *ProjectionsHandler) GetPlayerProjection(c *gin.Context) {
 	p.Receptions = sanitizeFloat64(p.Receptions)
 	p.ProjectionStdDev = sanitizeFloat64(p.ProjectionStdDev)
 
-	c.JSON(http.StatusOK, p)
-}
\ No newline at end of file
+	results := []ProjectionResponse{p}
+	h.attachStageOutputs(c.Request.Context(), season, week, results)
+	h.attachWeather(c.Request.Context(), season, week, results)
+
+	respondWithFields(c, http.StatusOK, results[0])
+}
+// GetBacktests returns recent accuracy backtests for a projection modifier
+func (h *ProjectionsHandler) GetBacktests(c *gin.Context) {
+	modifier := c.Query("modifier")
+	if modifier == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "modifier parameter is required"})
+		return
+	}
+
+	results, err := h.projectionRepo.GetBacktestResults(c.Request.Context(), modifier, 20)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch backtests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"modifier":  modifier,
+		"backtests": results,
+		"count":     len(results),
+	})
+}
+
+// GetProjectionDiff returns players whose projection for a week moved by at
+// least threshold PPR since a point in time. since is an RFC 3339 timestamp
+// or a duration such as "24h" counted back from now.
+func (h *ProjectionsHandler) GetProjectionDiff(c *gin.Context) {
+	week, err := strconv.Atoi(c.DefaultQuery("week", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid week parameter"})
+		return
+	}
+
+	season, err := strconv.Atoi(c.DefaultQuery("season", "2025"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season parameter"})
+		return
+	}
+
+	since, err := parseSince(c.DefaultQuery("since", "24h"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 timestamp or a duration like 24h"})
+		return
+	}
+
+	threshold := projections.DefaultDiffThreshold
+	if t := c.Query("threshold"); t != "" {
+		threshold, err = strconv.ParseFloat(t, 64)
+		if err != nil || threshold < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid threshold parameter"})
+			return
+		}
+	}
+
+	changes, err := projections.DiffSince(c.Request.Context(), h.projectionRepo, season, week, since, threshold)
+	if err != nil {
+		log.Printf("Failed to diff projections since %s: %v", since, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare projections"})
+		return
+	}
+	if changes == nil {
+		changes = []projections.ProjectionChange{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"since":     since,
+		"week":      week,
+		"season":    season,
+		"threshold": threshold,
+		"changes":   changes,
+		"count":     len(changes),
+	})
+}
+
+// GetRestOfSeason handles GET /api/projections/rest-of-season, projecting
+// points from a week (?week=, default 1) through the end of the regular
+// season from that week's consensus projections
+func (h *ProjectionsHandler) GetRestOfSeason(c *gin.Context) {
+	if h.scheduleRepo == nil || h.availRepo == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Rest-of-season projections are not available"})
+		return
+	}
+
+	week, err := strconv.Atoi(c.DefaultQuery("week", "1"))
+	if err != nil || week < 1 || week > 18 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "week must be between 1 and 18"})
+		return
+	}
+	season, err := strconv.Atoi(c.DefaultQuery("season", "2025"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season parameter"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > maxProjectionsLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxProjectionsLimit)})
+		return
+	}
+	position := strings.ToUpper(c.Query("position"))
+
+	all, err := projections.RestOfSeasonProjections(c.Request.Context(), h.projectionRepo, h.scheduleRepo, h.availRepo, season, week)
+	if err != nil {
+		log.Printf("Failed to project rest of season from %d week %d: %v", season, week, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to project rest of season"})
+		return
+	}
+
+	results := make([]projections.RestOfSeason, 0, min(limit, len(all)))
+	for _, r := range all {
+		if position != "" && r.Position != position {
+			continue
+		}
+		results = append(results, r)
+		if len(results) == limit {
+			break
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":      season,
+		"from_week":   week,
+		"projections": results,
+		"count":       len(results),
+	})
+}
+
+// CustomScoreRequest is a scoring rule set to try against a week's
+// projections
+type CustomScoreRequest struct {
+	Season   int                            `json:"season"`
+	Week     int                            `json:"week" binding:"required,min=1,max=22"`
+	Position string                         `json:"position"`
+	Limit    int                            `json:"limit" binding:"min=0"`
+	Rules    projections.CustomScoringRules `json:"rules"`
+}
+
+// CustomScore rescores a week's projections with a scoring rule set sent by
+// the client, so proposed league rule changes can be compared before
+// adopting them
+func (h *ProjectionsHandler) CustomScore(c *gin.Context) {
+	var req CustomScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Season == 0 {
+		req.Season = 2025
+	}
+	if req.Limit == 0 {
+		req.Limit = 200
+	}
+	if req.Limit > maxProjectionsLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be at most %d", maxProjectionsLimit)})
+		return
+	}
+
+	results, err := h.listProjections(c.Request.Context(), req.Season, req.Week, strings.ToUpper(req.Position), req.Limit)
+	if err != nil {
+		log.Printf("Failed to load projections for custom scoring: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projections"})
+		return
+	}
+
+	players := make([]projections.ProjectedPlayer, len(results))
+	for i, p := range results {
+		players[i] = projections.ProjectedPlayer{
+			PlayerName:     p.PlayerName,
+			Position:       derefString(p.Position),
+			Team:           derefString(p.Team),
+			Stats:          p.statLine(),
+			PointsPPR:      p.ConsensusPPR,
+			PointsStandard: p.ConsensusStandard,
+		}
+	}
+
+	scores := projections.RescoreProjections(players, req.Rules)
+	c.JSON(http.StatusOK, gin.H{
+		"season":  req.Season,
+		"week":    req.Week,
+		"rules":   req.Rules,
+		"players": scores,
+		"count":   len(scores),
+	})
+}
+
+// statLine returns the projected stats, or nil when the player is projected
+// only in points
+func (p ProjectionResponse) statLine() *projections.StatLine {
+	stats := []*float64{p.PassingYards, p.PassingTDs, p.RushingYards, p.RushingTDs, p.ReceivingYards, p.ReceivingTDs, p.Receptions}
+	projected := false
+	for _, s := range stats {
+		if s != nil {
+			projected = true
+		}
+	}
+	if !projected {
+		return nil
+	}
+
+	return &projections.StatLine{
+		PassingYards:   derefFloat(p.PassingYards),
+		PassingTDs:     derefFloat(p.PassingTDs),
+		RushingYards:   derefFloat(p.RushingYards),
+		RushingTDs:     derefFloat(p.RushingTDs),
+		ReceivingYards: derefFloat(p.ReceivingYards),
+		ReceivingTDs:   derefFloat(p.ReceivingTDs),
+		Receptions:     derefFloat(p.Receptions),
+	}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefFloat(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
+
+// defaultAccuracyDepth is how many of the experts' top players at each
+// position the accuracy comparison scores
+const defaultAccuracyDepth = 24
+
+// GetPlayerHistory returns a player's weekly projection beside the expert
+// consensus rank and actual points for a season
+func (h *ProjectionsHandler) GetPlayerHistory(c *gin.Context) {
+	if h.ecrRepo == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Player history is not available"})
+		return
+	}
+
+	season, err := strconv.Atoi(c.DefaultQuery("season", "2025"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season parameter"})
+		return
+	}
+
+	player := c.Param("player")
+	history, err := h.ecrRepo.GetPlayerHistory(c.Request.Context(), season, player)
+	if err != nil {
+		log.Printf("Failed to load history for %s: %v", player, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player history"})
+		return
+	}
+	if len(history) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No history for this player and season"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player_name": player,
+		"season":      season,
+		"weeks":       history,
+	})
+}
+
+// GetRankAccuracy compares how well our consensus and the expert consensus
+// ranked each position against actual finish over a range of weeks
+func (h *ProjectionsHandler) GetRankAccuracy(c *gin.Context) {
+	if h.ecrRepo == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Rank accuracy is not available"})
+		return
+	}
+
+	season, err := strconv.Atoi(c.DefaultQuery("season", "2025"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season parameter"})
+		return
+	}
+	fromWeek, err := strconv.Atoi(c.DefaultQuery("from_week", "1"))
+	if err != nil || fromWeek < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from_week parameter"})
+		return
+	}
+	toWeek, err := strconv.Atoi(c.DefaultQuery("to_week", "18"))
+	if err != nil || toWeek < fromWeek || toWeek > 22 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to_week parameter"})
+		return
+	}
+	depth, err := strconv.Atoi(c.DefaultQuery("depth", strconv.Itoa(defaultAccuracyDepth)))
+	if err != nil || depth < 2 || depth > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "depth must be between 2 and 100"})
+		return
+	}
+
+	positions := projections.ECRPositions
+	if p := c.Query("position"); p != "" {
+		positions = []string{strings.ToUpper(p)}
+	}
+
+	results, err := projections.SeasonAccuracy(c.Request.Context(), h.ecrRepo, season, fromWeek, toWeek, positions, depth)
+	if err != nil {
+		log.Printf("Failed to compare rank accuracy for %d: %v", season, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compare rank accuracy"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":    season,
+		"from_week": fromWeek,
+		"to_week":   toWeek,
+		"depth":     depth,
+		"positions": results,
+	})
+}
+
+// parseSince accepts an absolute timestamp or a duration before now
+func parseSince(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid since value %q", value)
+	}
+	return time.Now().Add(-d), nil
+}
+
+// ExplainPlayerProjection breaks a player's weekly projection down by
+// pipeline stage. The player may be given by ID or name.
+func (h *ProjectionsHandler) ExplainPlayerProjection(c *gin.Context) {
+	player := c.Param("player")
+	weekStr := c.DefaultQuery("week", "1")
+	seasonStr := c.DefaultQuery("season", "2025")
+
+	week, err := strconv.Atoi(weekStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid week parameter"})
+		return
+	}
+
+	season, err := strconv.Atoi(seasonStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season parameter"})
+		return
+	}
+
+	query := `
+		SELECT player_name, position, team
+		FROM gold.consensus_projections
+		WHERE week = $2 AND season = $3
+			AND (player_id = $1 OR player_name ILIKE '%' || $1 || '%')
+		ORDER BY player_id = $1 DESC NULLS LAST, consensus_points_ppr DESC
+		LIMIT 1
+	`
+
+	var playerName string
+	var position, team *string
+	err = h.db.QueryRowContext(c.Request.Context(), query, player, week, season).Scan(&playerName, &position, &team)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch player projection"})
+		return
+	}
+
+	outputs, err := h.projectionRepo.GetStageOutputs(c.Request.Context(), season, week, []string{playerName})
+	if err != nil {
+		log.Printf("Failed to load stage outputs for %s: %v", playerName, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projection breakdown"})
+		return
+	}
+	if len(outputs[playerName]) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pipeline breakdown for this player and week"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"player_name": playerName,
+		"position":    position,
+		"team":        team,
+		"week":        week,
+		"season":      season,
+		"explanation": projections.Explain(outputs[playerName]),
+	})
+}
+
+// gameContextCSVHeader names the columns of a game-context CSV download
+var gameContextCSVHeader = []string{
+	"season", "week", "team", "opponent", "home", "kickoff_at", "rest_days", "opponent_rest_days",
+	"short_week", "thursday_game", "travel_miles", "timezone_shift",
+}
+
+// GetGameContext handles GET /api/projections/game-context, the game-context
+// feature store in bulk: every team's rest, travel and time zone shift for a
+// season (?season=), or one week (?week=) or team (?team=) of it. Pass
+// format=csv to download a CSV instead of JSON.
+func (h *ProjectionsHandler) GetGameContext(c *gin.Context) {
+	season, err := strconv.Atoi(c.DefaultQuery("season", "2025"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season parameter"})
+		return
+	}
+	fromWeek, toWeek := 1, 18
+	if raw := c.Query("week"); raw != "" {
+		week, err := strconv.Atoi(raw)
+		if err != nil || week < 1 || week > 18 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "week must be between 1 and 18"})
+			return
+		}
+		fromWeek, toWeek = week, week
+	}
+	team := strings.ToUpper(c.Query("team"))
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+		return
+	}
+
+	all, err := h.projectionRepo.GetGameContext(c.Request.Context(), season, fromWeek, toWeek)
+	if err != nil {
+		log.Printf("Failed to get game context for %d: %v", season, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch game context"})
+		return
+	}
+	features := make([]projections.GameContext, 0, len(all))
+	for _, f := range all {
+		if team == "" || f.Team == team {
+			features = append(features, f)
+		}
+	}
+
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="game_context_%d.csv"`, season))
+		w := csv.NewWriter(c.Writer)
+		w.Write(gameContextCSVHeader)
+		for _, f := range features {
+			w.Write([]string{
+				strconv.Itoa(f.Season), strconv.Itoa(f.Week), f.Team, f.Opponent, strconv.FormatBool(f.Home),
+				f.KickoffAt.UTC().Format(time.RFC3339), strconv.Itoa(f.RestDays), strconv.Itoa(f.OpponentRestDays),
+				strconv.FormatBool(f.ShortWeek), strconv.FormatBool(f.ThursdayGame),
+				strconv.FormatFloat(f.TravelMiles, 'f', -1, 64), strconv.Itoa(f.TimezoneShift),
+			})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			log.Printf("Failed to write game context CSV: %v", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":   season,
+		"features": features,
+		"count":    len(features),
+	})
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/redisaudit"
+)
+
+// RedisAuditHandler serves Redis memory and key expiry reports
+type RedisAuditHandler struct {
+	auditor *redisaudit.Auditor
+}
+
+// NewRedisAuditHandler creates a new Redis audit handler
+func NewRedisAuditHandler(auditor *redisaudit.Auditor) *RedisAuditHandler {
+	return &RedisAuditHandler{auditor: auditor}
+}
+
+// GetReport returns the latest audit, running one if none has run yet
+func (h *RedisAuditHandler) GetReport(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	report, err := h.auditor.Latest(ctx)
+	if err == nil && report == nil {
+		report, err = h.auditor.Run(ctx)
+	}
+	if err != nil {
+		log.Printf("Failed to get Redis audit report: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get Redis audit report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// RunAudit audits Redis now and returns the new report
+func (h *RedisAuditHandler) RunAudit(c *gin.Context) {
+	report, err := h.auditor.Run(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to run Redis audit: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run Redis audit"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/analytics"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// RookiePickHandler serves dynasty leagues' future rookie draft picks and
+// records who owns them
+type RookiePickHandler struct {
+	leagueRepo    repositories.LeagueRepository
+	analyticsRepo analytics.Repository
+	picks         analytics.PickRepository
+	ledgerSync    services.PickLedgerService
+	authz         *policy.Authorizer
+}
+
+// NewRookiePickHandler creates a new rookie pick handler
+func NewRookiePickHandler(leagueRepo repositories.LeagueRepository, analyticsRepo analytics.Repository, picks analytics.PickRepository) *RookiePickHandler {
+	return &RookiePickHandler{
+		leagueRepo:    leagueRepo,
+		analyticsRepo: analyticsRepo,
+		picks:         picks,
+	}
+}
+
+// WithAuthorizer sets who besides a league's owner may read and record its
+// rookie picks
+func (h *RookiePickHandler) WithAuthorizer(a *policy.Authorizer) *RookiePickHandler {
+	h.authz = a
+	return h
+}
+
+// WithLedgerSync enables syncing the pick ledger from the league's trades
+func (h *RookiePickHandler) WithLedgerSync(s services.PickLedgerService) *RookiePickHandler {
+	h.ledgerSync = s
+	return h
+}
+
+// RookiePickRequest records the owner of a team's pick. Setting the owner
+// back to the original team undoes a trade.
+type RookiePickRequest struct {
+	Season         int    `json:"season" binding:"required"`
+	Round          int    `json:"round" binding:"required,min=1,max=10"`
+	OriginalTeamID int    `json:"original_team_id" binding:"required"`
+	OwnerTeamID    int    `json:"owner_team_id" binding:"required"`
+	Notes          string `json:"notes"`
+}
+
+// GetPicks handles GET /api/leagues/:id/picks, every team's picks in the
+// next drafts (?years=, default 3) of ?rounds= (default 4) with their
+// owners, projected slots and values, and the value chart for the next draft
+func (h *RookiePickHandler) GetPicks(c *gin.Context) {
+	league, ok := h.league(c, policy.ActionRead)
+	if !ok {
+		return
+	}
+
+	years, err := strconv.Atoi(c.DefaultQuery("years", "3"))
+	if err != nil || years < 1 || years > 5 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "years must be between 1 and 5"})
+		return
+	}
+	rounds, err := strconv.Atoi(c.DefaultQuery("rounds", strconv.Itoa(analytics.DefaultRookieRounds)))
+	if err != nil || rounds < 1 || rounds > 10 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rounds must be between 1 and 10"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	traded, err := h.picks.GetRookiePicks(ctx, league.ID.String())
+	if err != nil {
+		log.Printf("Failed to get rookie picks for league %s: %v", league.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch rookie picks"})
+		return
+	}
+
+	// Only this season's standings say anything about next year's order
+	var standings []analytics.TeamStanding
+	computed, err := h.analyticsRepo.GetLeagueAnalytics(ctx, league.ID.String())
+	if err != nil {
+		log.Printf("Failed to get analytics for league %s, projecting picks mid-round: %v", league.ID, err)
+	} else if computed != nil && computed.Season == league.Season {
+		standings = computed.Standings
+	}
+
+	teams := leagueTeams(league)
+	firstSeason := league.Season + 1
+	board := analytics.RookiePickBoard(league.ID.String(), teams, standings, traded, firstSeason, years, rounds)
+
+	teamCount := len(teams)
+	if teamCount == 0 {
+		teamCount = len(standings)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"first_season": firstSeason,
+		"picks":        board,
+		"value_chart":  analytics.RookiePickValueChart(teamCount, rounds),
+	})
+}
+
+// RecordPick handles PUT /api/leagues/:id/picks, a trade entered by hand
+// by the league's owner. It goes in the ledger like a synced trade.
+func (h *RookiePickHandler) RecordPick(c *gin.Context) {
+	league, ok := h.league(c, policy.ActionWrite)
+	if !ok {
+		return
+	}
+
+	var req RookiePickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Season <= league.Season {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "only picks in future drafts can be recorded"})
+		return
+	}
+	if teams := leagueTeams(league); len(teams) > 0 {
+		known := make(map[int]bool, len(teams))
+		for _, t := range teams {
+			known[t.ID] = true
+		}
+		if !known[req.OriginalTeamID] || !known[req.OwnerTeamID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "original_team_id and owner_team_id must be teams in the league"})
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	traded, err := h.picks.GetRookiePicks(ctx, league.ID.String())
+	if err != nil {
+		log.Printf("Failed to get rookie picks for league %s: %v", league.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch rookie picks"})
+		return
+	}
+
+	trade := &analytics.PickTrade{
+		LeagueID:       league.ID.String(),
+		Season:         req.Season,
+		Round:          req.Round,
+		OriginalTeamID: req.OriginalTeamID,
+		FromTeamID:     analytics.PickOwner(traded, req.Season, req.Round, req.OriginalTeamID),
+		ToTeamID:       req.OwnerTeamID,
+		Source:         analytics.PickTradeCommissioner,
+		RecordedBy:     league.UserID.String(),
+		Notes:          req.Notes,
+	}
+	if trade.FromTeamID == trade.ToTeamID {
+		c.JSON(http.StatusConflict, gin.H{"error": "team already owns this pick"})
+		return
+	}
+	if _, err := h.picks.RecordPickTrade(ctx, trade); err != nil {
+		log.Printf("Failed to save rookie pick for league %s: %v", league.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save rookie pick"})
+		return
+	}
+
+	c.JSON(http.StatusOK, trade)
+}
+
+// GetLedger handles GET /api/leagues/:id/picks/ledger, every pick trade in
+// the league (?season= for one draft), oldest first
+func (h *RookiePickHandler) GetLedger(c *gin.Context) {
+	league, ok := h.league(c, policy.ActionRead)
+	if !ok {
+		return
+	}
+
+	season := 0
+	if s := c.Query("season"); s != "" {
+		var err error
+		if season, err = strconv.Atoi(s); err != nil || season < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season"})
+			return
+		}
+	}
+
+	trades, err := h.picks.GetPickTrades(c.Request.Context(), league.ID.String(), season)
+	if err != nil {
+		log.Printf("Failed to get pick trades for league %s: %v", league.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch pick trades"})
+		return
+	}
+	if trades == nil {
+		trades = []analytics.PickTrade{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"trades": trades})
+}
+
+// SyncLedger handles POST /api/leagues/:id/picks/sync, adding the picks
+// moved by the league's trades on its platform to the ledger
+func (h *RookiePickHandler) SyncLedger(c *gin.Context) {
+	if h.ledgerSync == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Pick syncing is not available"})
+		return
+	}
+	league, ok := h.league(c, policy.ActionWrite)
+	if !ok {
+		return
+	}
+
+	added, err := h.ledgerSync.SyncTrades(c.Request.Context(), league.UserID, league.ID)
+	if err != nil {
+		respondPlatformError(c, league.ID, "transactions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"added": added})
+}
+
+// league loads the league in the path, responding and returning false if
+// the user may not take action on it
+func (h *RookiePickHandler) league(c *gin.Context, action policy.Action) (*models.League, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return nil, false
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid league ID"})
+		return nil, false
+	}
+
+	ctx := c.Request.Context()
+	league, err := h.leagueRepo.GetByID(ctx, leagueID.String())
+	if err == nil {
+		err = h.authz.Authorize(ctx, policy.User(userID.String()), action, policy.League(league))
+	}
+	if errors.Is(err, repositories.ErrLeagueNotFound) || errors.Is(err, policy.ErrForbidden) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found"})
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("Failed to get league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch league"})
+		return nil, false
+	}
+
+	return league, true
+}
+
+// leagueTeams reads the teams saved with a league at its last sync
+func leagueTeams(league *models.League) []espn.Team {
+	var teams []espn.Team
+	if len(league.TeamsData) > 0 {
+		if err := json.Unmarshal(league.TeamsData, &teams); err != nil {
+			log.Printf("Failed to read teams for league %s: %v", league.ID, err)
+		}
+	}
+	return teams
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/worker"
+)
+
+// ByeWeekPlayer is a rostered player out for a bye
+type ByeWeekPlayer struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Position   string `json:"position"`
+	Team       string `json:"team"`
+	Starter    bool   `json:"starter"`
+}
+
+// ByeWeekPlan lists the rostered players on bye in one week
+type ByeWeekPlan struct {
+	Week        int             `json:"week"`
+	Players     []ByeWeekPlayer `json:"players"`
+	StartersOut int             `json:"starters_out"`
+}
+
+// ScheduledGameResponse is a game with its forecast, when one is loaded
+type ScheduledGameResponse struct {
+	projections.ScheduledGame
+	Weather *projections.GameWeather `json:"weather,omitempty"`
+}
+
+// benchLineupSlots are roster slots that do not start
+var benchLineupSlots = map[string]bool{"BE": true, "IR": true}
+
+// ScheduleHandler serves the NFL schedule and bye-week planning
+type ScheduleHandler struct {
+	scheduleRepo projections.ScheduleRepository
+	leagueRepo   repositories.LeagueRepository
+	syncRepo     repositories.LeagueSyncRepository
+	authz        *policy.Authorizer
+}
+
+// NewScheduleHandler creates a new schedule handler
+func NewScheduleHandler(scheduleRepo projections.ScheduleRepository, leagueRepo repositories.LeagueRepository, syncRepo repositories.LeagueSyncRepository) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleRepo: scheduleRepo,
+		leagueRepo:   leagueRepo,
+		syncRepo:     syncRepo,
+	}
+}
+
+// WithAuthorizer sets who besides a league's owner may read its bye plan
+func (h *ScheduleHandler) WithAuthorizer(a *policy.Authorizer) *ScheduleHandler {
+	h.authz = a
+	return h
+}
+
+// GetSchedule handles GET /api/schedule
+func (h *ScheduleHandler) GetSchedule(c *gin.Context) {
+	season, ok := scheduleSeason(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var games []projections.ScheduledGame
+	var err error
+	if team := c.Query("team"); team != "" {
+		games, err = h.scheduleRepo.GetTeamSchedule(ctx, season, team)
+	} else {
+		fromWeek, toWeek := 1, 18
+		if weekStr := c.Query("week"); weekStr != "" {
+			week, convErr := strconv.Atoi(weekStr)
+			if convErr != nil || week < 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid week parameter"})
+				return
+			}
+			fromWeek, toWeek = week, week
+		}
+		games, err = h.scheduleRepo.GetSchedule(ctx, season, fromWeek, toWeek)
+	}
+	if err != nil {
+		log.Printf("Failed to get %d schedule: %v", season, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch schedule"})
+		return
+	}
+
+	response := make([]ScheduledGameResponse, len(games))
+	firstWeek, lastWeek := 0, 0
+	for i, g := range games {
+		response[i] = ScheduledGameResponse{ScheduledGame: g}
+		if firstWeek == 0 || g.Week < firstWeek {
+			firstWeek = g.Week
+		}
+		if g.Week > lastWeek {
+			lastWeek = g.Week
+		}
+	}
+	if len(games) > 0 {
+		h.attachWeather(ctx, season, firstWeek, lastWeek, response)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season": season,
+		"games":  response,
+		"count":  len(response),
+	})
+}
+
+// attachWeather adds forecasts to games. Games are served without weather if
+// the forecasts cannot be loaded.
+func (h *ScheduleHandler) attachWeather(ctx context.Context, season, fromWeek, toWeek int, games []ScheduledGameResponse) {
+	forecasts, err := h.scheduleRepo.GetWeatherForWeeks(ctx, season, fromWeek, toWeek)
+	if err != nil {
+		log.Printf("Failed to get %d weather for weeks %d-%d: %v", season, fromWeek, toWeek, err)
+		return
+	}
+
+	byGame := make(map[string]*projections.GameWeather, len(forecasts))
+	for i := range forecasts {
+		f := &forecasts[i]
+		byGame[fmt.Sprintf("%d:%s", f.Week, strings.ToUpper(f.HomeTeam))] = f
+	}
+	for i := range games {
+		games[i].Weather = byGame[fmt.Sprintf("%d:%s", games[i].Week, strings.ToUpper(games[i].HomeTeam))]
+	}
+}
+
+// GetByeWeeks handles GET /api/schedule/byes
+func (h *ScheduleHandler) GetByeWeeks(c *gin.Context) {
+	season, ok := scheduleSeason(c)
+	if !ok {
+		return
+	}
+
+	byes, err := h.scheduleRepo.GetByeWeeks(c.Request.Context(), season)
+	if err != nil {
+		log.Printf("Failed to get %d bye weeks: %v", season, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch bye weeks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":    season,
+		"bye_weeks": byes,
+	})
+}
+
+// GetLeagueByePlan handles GET /api/leagues/:id/byes. It groups the user's
+// synced roster by bye week so weeks with several starters out stand out.
+func (h *ScheduleHandler) GetLeagueByePlan(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid league ID"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	league, err := h.leagueRepo.GetByID(ctx, leagueID.String())
+	if err == nil {
+		err = h.authz.Authorize(ctx, policy.User(userID.String()), policy.ActionRead, policy.League(league))
+	}
+	if errors.Is(err, repositories.ErrLeagueNotFound) || errors.Is(err, policy.ErrForbidden) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch league"})
+		return
+	}
+
+	var roster espn.Roster
+	found, err := h.syncRepo.GetLatestSnapshot(ctx, league.ID.String(), worker.SnapshotUserRoster, &roster)
+	if err != nil {
+		log.Printf("Failed to get roster for league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch roster"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League roster has not been synced yet"})
+		return
+	}
+
+	season := league.Season
+	if season == 0 {
+		season = projections.CurrentSeason(time.Now())
+	}
+	byes, err := h.scheduleRepo.GetByeWeeks(ctx, season)
+	if err != nil {
+		log.Printf("Failed to get %d bye weeks: %v", season, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch bye weeks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"league_id": league.ID,
+		"season":    season,
+		"weeks":     planByeWeeks(roster.Players, byes),
+	})
+}
+
+// planByeWeeks groups players by their team's bye week, earliest first.
+// Players whose team has no known bye are left out.
+func planByeWeeks(players []espn.RosterPlayer, byes map[string]int) []ByeWeekPlan {
+	byWeek := make(map[int]*ByeWeekPlan)
+	for _, p := range players {
+		week := byes[strings.ToUpper(p.Team)]
+		if week == 0 {
+			continue
+		}
+		plan, ok := byWeek[week]
+		if !ok {
+			plan = &ByeWeekPlan{Week: week}
+			byWeek[week] = plan
+		}
+		starter := !benchLineupSlots[p.LineupSlot]
+		plan.Players = append(plan.Players, ByeWeekPlayer{
+			PlayerID:   p.PlayerID,
+			PlayerName: p.PlayerName,
+			Position:   p.Position,
+			Team:       p.Team,
+			Starter:    starter,
+		})
+		if starter {
+			plan.StartersOut++
+		}
+	}
+
+	plans := make([]ByeWeekPlan, 0, len(byWeek))
+	for _, plan := range byWeek {
+		plans = append(plans, *plan)
+	}
+	sort.Slice(plans, func(i, j int) bool { return plans[i].Week < plans[j].Week })
+	return plans
+}
+
+// scheduleSeason reads ?season=, defaulting to the current NFL season
+func scheduleSeason(c *gin.Context) (int, bool) {
+	seasonStr := c.Query("season")
+	if seasonStr == "" {
+		return projections.CurrentSeason(time.Now()), true
+	}
+	season, err := strconv.Atoi(seasonStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season parameter"})
+		return 0, false
+	}
+	return season, true
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockLeagueRepository serves a fixed set of leagues
+type MockLeagueRepository struct {
+	repositories.LeagueRepository
+	leagues map[string]*models.League
+}
+
+func (m *MockLeagueRepository) GetByID(ctx context.Context, id string) (*models.League, error) {
+	league, ok := m.leagues[id]
+	if !ok {
+		return nil, repositories.ErrLeagueNotFound
+	}
+	return league, nil
+}
+
+// MockLeagueSyncRepository serves the same roster snapshot for every league
+type MockLeagueSyncRepository struct {
+	repositories.LeagueSyncRepository
+	roster espn.Roster
+}
+
+func (m *MockLeagueSyncRepository) GetLatestSnapshot(ctx context.Context, leagueID, dataType string, dest interface{}) (bool, error) {
+	*dest.(*espn.Roster) = m.roster
+	return true, nil
+}
+
+// MockScheduleRepository serves fixed bye weeks
+type MockScheduleRepository struct {
+	projections.ScheduleRepository
+	byes map[string]int
+}
+
+func (m *MockScheduleRepository) GetByeWeeks(ctx context.Context, season int) (map[string]int, error) {
+	return m.byes, nil
+}
+
+// MockLeagueRoles keeps league roles by user and league
+type MockLeagueRoles struct {
+	policy.Repository
+	roles map[string]policy.LeagueRole
+}
+
+func (m *MockLeagueRoles) GetLeagueRole(ctx context.Context, userID, leagueID string) (policy.LeagueRole, error) {
+	return m.roles[userID+"/"+leagueID], nil
+}
+
+func (m *MockLeagueRoles) GetOrgRole(ctx context.Context, userID string) (policy.OrgRole, error) {
+	return policy.OrgRoleNone, nil
+}
+
+func TestScheduleHandler_GetLeagueByePlan_Authorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	league := &models.League{ID: uuid.New(), UserID: uuid.New(), Season: 2025}
+	member, stranger := uuid.New(), uuid.New()
+
+	handler := NewScheduleHandler(
+		&MockScheduleRepository{byes: map[string]int{"KC": 6}},
+		&MockLeagueRepository{leagues: map[string]*models.League{league.ID.String(): league}},
+		&MockLeagueSyncRepository{roster: espn.Roster{Players: []espn.RosterPlayer{
+			{PlayerID: "1", PlayerName: "Patrick Mahomes", Team: "KC", LineupSlot: "QB"},
+		}}},
+	).WithAuthorizer(policy.NewAuthorizer(&MockLeagueRoles{roles: map[string]policy.LeagueRole{
+		member.String() + "/" + league.ID.String(): policy.LeagueRoleMember,
+	}}))
+
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		wantStatus int
+	}{
+		{name: "owner", userID: league.UserID, wantStatus: http.StatusOK},
+		{name: "member", userID: member, wantStatus: http.StatusOK},
+		{name: "stranger", userID: stranger, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/leagues/:id/byes", func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				handler.GetLeagueByePlan(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/leagues/"+league.ID.String()+"/byes", nil)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if tt.wantStatus == http.StatusOK {
+				assert.Len(t, body["weeks"], 1)
+			} else {
+				assert.Equal(t, "League not found", body["error"])
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/audit"
+)
+
+// SecurityEventsHandler shows users the security audit log of their account
+type SecurityEventsHandler struct {
+	repo audit.Repository
+}
+
+// NewSecurityEventsHandler creates a new security events handler
+func NewSecurityEventsHandler(repo audit.Repository) *SecurityEventsHandler {
+	return &SecurityEventsHandler{repo: repo}
+}
+
+// GetSecurityEvents handles GET /api/users/security-events. Filters: event
+// (comma separated) and limit. Pass next_before from a response as before
+// to page back.
+func (h *SecurityEventsHandler) GetSecurityEvents(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > maxActivityLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+
+	filter := audit.Filter{Limit: limit}
+	if raw := c.Query("event"); raw != "" {
+		for _, event := range strings.Split(raw, ",") {
+			if event = strings.TrimSpace(event); event != "" {
+				filter.Events = append(filter.Events, event)
+			}
+		}
+	}
+	if raw := c.Query("before"); raw != "" {
+		before, err := parseActivityTime(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "before must be an RFC 3339 time or YYYY-MM-DD date"})
+			return
+		}
+		filter.Before = before
+	}
+
+	events, err := h.repo.List(c.Request.Context(), userID, filter)
+	if err != nil {
+		log.Printf("Failed to list security events for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch security events"})
+		return
+	}
+	if events == nil {
+		events = []audit.Event{}
+	}
+
+	response := gin.H{
+		"events": events,
+		"count":  len(events),
+	}
+	if len(events) == limit {
+		response["next_before"] = events[len(events)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+	c.JSON(http.StatusOK, response)
+}
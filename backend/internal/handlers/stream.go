@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/draft"
+	"github.com/nfl-analytics/backend/internal/eventbus"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/stream"
+)
+
+// Stream transports, in order of preference
+const (
+	TransportSSE      = "sse"
+	TransportLongPoll = "long-poll"
+)
+
+// streamTransports are the transports the server offers, best first
+var streamTransports = []string{TransportSSE, TransportLongPoll}
+
+// TransportNegotiation is the server's answer to a client listing the
+// stream transports it supports
+type TransportNegotiation struct {
+	// Transport is the one the client should use, empty if none match
+	Transport string   `json:"transport"`
+	Available []string `json:"available"`
+	// PollTimeoutSeconds is how long a long-poll request is held open
+	PollTimeoutSeconds int `json:"poll_timeout_seconds"`
+	// HeartbeatSeconds is how often an idle SSE stream sends a comment
+	HeartbeatSeconds int `json:"heartbeat_seconds"`
+}
+
+// StreamEvents is a long-poll response
+type StreamEvents struct {
+	Events []stream.Event `json:"events"`
+	// Cursor is passed back on the next poll
+	Cursor uint64 `json:"cursor"`
+	// Resync means events were missed and the client should reload state
+	Resync bool `json:"resync"`
+}
+
+// StreamHandler serves draft and live scoring streams over SSE, with long
+// polling for networks that hold or buffer streaming responses. Both read
+// from the same hub.
+type StreamHandler struct {
+	hub          *stream.Hub
+	draftService *draft.Service
+	leagueRepo   repositories.LeagueRepository
+	authz        *policy.Authorizer
+	pollTimeout  time.Duration
+	heartbeat    time.Duration
+}
+
+// NewStreamHandler creates a new stream handler
+func NewStreamHandler(hub *stream.Hub, draftService *draft.Service, leagueRepo repositories.LeagueRepository, pollTimeout, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{
+		hub:          hub,
+		draftService: draftService,
+		leagueRepo:   leagueRepo,
+		pollTimeout:  pollTimeout,
+		heartbeat:    heartbeat,
+	}
+}
+
+// WithAuthorizer sets who besides a league's owner may follow its live
+// scoring
+func (h *StreamHandler) WithAuthorizer(a *policy.Authorizer) *StreamHandler {
+	h.authz = a
+	return h
+}
+
+// NegotiateTransport handles GET /api/stream/transports. The client lists
+// the transports it can use in ?supported=, best first, and gets back the
+// first one the server offers; without the parameter the server's
+// preferred transport is returned.
+func (h *StreamHandler) NegotiateTransport(c *gin.Context) {
+	result := TransportNegotiation{
+		Available:          streamTransports,
+		PollTimeoutSeconds: int(h.pollTimeout.Seconds()),
+		HeartbeatSeconds:   int(h.heartbeat.Seconds()),
+	}
+
+	supported := c.Query("supported")
+	if supported == "" {
+		result.Transport = streamTransports[0]
+	}
+	for _, t := range strings.Split(supported, ",") {
+		if offered(strings.TrimSpace(t)) {
+			result.Transport = strings.TrimSpace(t)
+			break
+		}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DraftEvents handles GET /api/draft/sessions/:id/events
+func (h *StreamHandler) DraftEvents(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sessionID := c.Param("id")
+	if _, err := h.draftService.GetSession(c.Request.Context(), sessionID, userID.String()); err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized access to draft session"})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "Draft session not found"})
+		return
+	}
+
+	h.serve(c, stream.Channel(eventbus.TopicDraft, sessionID))
+}
+
+// LiveScoringEvents handles GET /api/leagues/:id/live/events
+func (h *StreamHandler) LiveScoringEvents(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	leagueID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid league ID"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	league, err := h.leagueRepo.GetByID(ctx, leagueID.String())
+	if err == nil {
+		err = h.authz.Authorize(ctx, policy.User(userID.String()), policy.ActionRead, policy.League(league))
+	}
+	if errors.Is(err, repositories.ErrLeagueNotFound) || errors.Is(err, policy.ErrForbidden) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "League not found"})
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to get league %s: %v", leagueID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch league"})
+		return
+	}
+
+	h.serve(c, stream.Channel(eventbus.TopicLiveScoring, league.ID.String()))
+}
+
+// serve picks the transport from ?transport=, or SSE when the client
+// accepts text/event-stream, and long polling otherwise
+func (h *StreamHandler) serve(c *gin.Context, channel string) {
+	transport := c.Query("transport")
+	if transport == "" {
+		transport = TransportLongPoll
+		if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+			transport = TransportSSE
+		}
+	}
+
+	cursor, ok := h.cursor(c, channel)
+	if !ok {
+		return
+	}
+
+	switch transport {
+	case TransportSSE:
+		h.serveSSE(c, channel, cursor)
+	case TransportLongPoll:
+		h.serveLongPoll(c, channel, cursor)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "transport must be one of: " + strings.Join(streamTransports, ", ")})
+	}
+}
+
+// cursor reads where the client left off from ?cursor= or the SSE
+// Last-Event-ID header. Without either the client only gets new events.
+func (h *StreamHandler) cursor(c *gin.Context, channel string) (uint64, bool) {
+	value := c.Query("cursor")
+	if value == "" {
+		value = c.GetHeader("Last-Event-ID")
+	}
+	if value == "" {
+		return h.hub.Cursor(channel), true
+	}
+
+	cursor, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cursor must be a non-negative integer"})
+		return 0, false
+	}
+	return cursor, true
+}
+
+// serveLongPoll holds the request until there are events or the poll
+// timeout passes, in which case it returns an empty list
+func (h *StreamHandler) serveLongPoll(c *gin.Context, channel string, cursor uint64) {
+	timeout := h.pollTimeout
+	if value := c.Query("timeout"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "timeout must be a number of seconds"})
+			return
+		}
+		if requested := time.Duration(seconds) * time.Second; requested < timeout {
+			timeout = requested
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+
+	events, resync, err := h.hub.Wait(ctx, channel, cursor)
+	if err != nil && c.Request.Context().Err() != nil {
+		return // client went away
+	}
+
+	result := StreamEvents{Events: events, Cursor: cursor, Resync: resync}
+	if result.Events == nil {
+		result.Events = []stream.Event{}
+	}
+	if len(events) > 0 {
+		result.Cursor = events[len(events)-1].ID
+	} else if resync {
+		result.Cursor = h.hub.Cursor(channel)
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, result)
+}
+
+// serveSSE streams events until the client disconnects, sending a comment
+// every heartbeat so proxies keep the connection open
+func (h *StreamHandler) serveSSE(c *gin.Context, channel string, cursor uint64) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	fmt.Fprintf(w, "retry: 3000\n\n")
+	w.Flush()
+
+	ctx := c.Request.Context()
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, h.heartbeat)
+		events, resync, err := h.hub.Wait(waitCtx, channel, cursor)
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(w, ": ping\n\n")
+			w.Flush()
+			continue
+		}
+
+		if resync {
+			fmt.Fprintf(w, "event: resync\ndata: {}\n\n")
+		}
+		for _, e := range events {
+			data, err := json.Marshal(e)
+			if err != nil {
+				log.Printf("Failed to encode stream event %d on %s: %v", e.ID, channel, err)
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+			cursor = e.ID
+		}
+		if resync && len(events) == 0 {
+			cursor = h.hub.Cursor(channel)
+		}
+		w.Flush()
+	}
+}
+
+func offered(transport string) bool {
+	for _, t := range streamTransports {
+		if t == transport {
+			return true
+		}
+	}
+	return false
+}
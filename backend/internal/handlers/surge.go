@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/surge"
+)
+
+// SurgeHandler reports and overrides draft-day surge mode
+type SurgeHandler struct {
+	surge *surge.Mode
+}
+
+// NewSurgeHandler creates a new surge handler
+func NewSurgeHandler(mode *surge.Mode) *SurgeHandler {
+	return &SurgeHandler{surge: mode}
+}
+
+// SetSurgeRequest forces surge mode on or off, or returns it to automatic
+type SetSurgeRequest struct {
+	Override string `json:"override" binding:"required,oneof=auto on off"`
+}
+
+// GetStatus returns whether surge mode is on and why
+func (h *SurgeHandler) GetStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.surge.Status(c.Request.Context()))
+}
+
+// SetOverride forces surge mode on or off, or returns it to automatic
+func (h *SurgeHandler) SetOverride(c *gin.Context) {
+	var req SetSurgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "override must be auto, on or off"})
+		return
+	}
+
+	status, err := h.surge.SetOverride(c.Request.Context(), req.Override)
+	if err != nil {
+		log.Printf("Failed to set surge override: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set surge mode"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// TwoFactorHandler handles a user's two-factor settings
+type TwoFactorHandler struct {
+	twoFactorService services.TwoFactorService
+	activity         *activity.Recorder
+}
+
+// NewTwoFactorHandler creates a new two-factor handler
+func NewTwoFactorHandler(twoFactorService services.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+	}
+}
+
+// WithActivity records two-factor changes in the user's activity
+func (h *TwoFactorHandler) WithActivity(r *activity.Recorder) *TwoFactorHandler {
+	h.activity = r
+	return h
+}
+
+// GetStatus handles GET /api/auth/2fa
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.twoFactorService.Status(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to get two-factor status: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get two-factor status"})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// Enroll handles POST /api/auth/2fa/enroll, returning a secret and the
+// otpauth:// URI to show as a QR code. Two-factor is not on until the user
+// confirms a code.
+func (h *TwoFactorHandler) Enroll(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.twoFactorService.Enroll(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// Confirm handles POST /api/auth/2fa/confirm, turning two-factor on and
+// returning backup codes. The codes are not shown again.
+func (h *TwoFactorHandler) Confirm(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code is required"})
+		return
+	}
+
+	codes, err := h.twoFactorService.Confirm(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	h.activity.Record(c, userID, activity.ActionTwoFactorEnabled, "", "", nil)
+	c.JSON(http.StatusOK, gin.H{"backup_codes": codes})
+}
+
+// Disable handles POST /api/auth/2fa/disable
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code is required"})
+		return
+	}
+
+	if err := h.twoFactorService.Disable(c.Request.Context(), userID, req.Code); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	h.activity.Record(c, userID, activity.ActionTwoFactorDisabled, "", "", nil)
+	c.JSON(http.StatusOK, gin.H{"message": "two-factor authentication disabled"})
+}
+
+// RegenerateBackupCodes handles POST /api/auth/2fa/backup-codes, replacing
+// the user's backup codes
+func (h *TwoFactorHandler) RegenerateBackupCodes(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var req models.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code is required"})
+		return
+	}
+
+	codes, err := h.twoFactorService.RegenerateBackupCodes(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	h.activity.Record(c, userID, activity.ActionBackupCodesReset, "", "", nil)
+	c.JSON(http.StatusOK, gin.H{"backup_codes": codes})
+}
+
+// respondError maps two-factor errors to responses
+func (h *TwoFactorHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTwoFactorEnabled), errors.Is(err, services.ErrTwoFactorNotEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidTwoFactor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid code"})
+	case errors.Is(err, services.ErrTwoFactorLocked):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	default:
+		log.Printf("Two-factor request failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "two-factor request failed"})
+	}
+}
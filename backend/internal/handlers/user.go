@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/audit"
 	"github.com/nfl-analytics/backend/internal/auth"
 	"github.com/nfl-analytics/backend/internal/models"
 	"github.com/nfl-analytics/backend/internal/services"
@@ -13,6 +16,9 @@ import (
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
 	userService services.UserService
+	activity    *activity.Recorder
+	denylist    *auth.Denylist
+	audit       *audit.Logger
 }
 
 // NewUserHandler creates a new user handler
@@ -22,6 +28,25 @@ func NewUserHandler(userService services.UserService) *UserHandler {
 	}
 }
 
+// WithActivity records password changes in the user's activity
+func (h *UserHandler) WithActivity(r *activity.Recorder) *UserHandler {
+	h.activity = r
+	return h
+}
+
+// WithDenylist revokes a deleted account's access tokens
+func (h *UserHandler) WithDenylist(d *auth.Denylist) *UserHandler {
+	h.denylist = d
+	return h
+}
+
+// WithAudit records password changes and account deletion in the security
+// audit log
+func (h *UserHandler) WithAudit(l *audit.Logger) *UserHandler {
+	h.audit = l
+	return h
+}
+
 // GetProfile retrieves the current user's profile
 func (h *UserHandler) GetProfile(c *gin.Context) {
 	userID, exists := c.Get(auth.UserIDKey)
@@ -106,6 +131,11 @@ func (h *UserHandler) DeleteAccount(c *gin.Context) {
 		return
 	}
 
+	if err := h.denylist.RevokeUser(c.Request.Context(), uid); err != nil {
+		log.Printf("Failed to revoke access tokens for user %s: %v", uid, err)
+	}
+	h.audit.RecordRequest(c, uid, audit.EventAccountDeleted, nil)
+
 	c.JSON(http.StatusOK, gin.H{"message": "account deleted successfully"})
 }
 
@@ -144,11 +174,15 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "incorrect password"})
 		case services.ErrWeakPassword:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "password does not meet requirements"})
+		case auth.ErrPasswordBreached:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to change password"})
 		}
 		return
 	}
 
+	h.activity.Record(c, uid, activity.ActionPasswordChanged, "", "", nil)
+	h.audit.RecordRequest(c, uid, audit.EventPasswordChanged, nil)
 	c.JSON(http.StatusOK, gin.H{"message": "password changed successfully"})
 }
\ No newline at end of file
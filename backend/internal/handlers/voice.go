@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/draft"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/voice"
+)
+
+const (
+	// maxVoiceRequestBytes bounds webhook request bodies
+	maxVoiceRequestBytes = 64 << 10
+	// maxVoiceCommand is the longest utterance run as a draft command, as
+	// for typed commands
+	maxVoiceCommand = 200
+	// VoiceWebhookSecretHeader carries the shared secret Dialogflow is
+	// configured to send
+	VoiceWebhookSecretHeader = "X-Voice-Webhook-Secret"
+)
+
+// VoiceHandler links voice assistants to users' accounts and answers their
+// webhooks with draft commands
+type VoiceHandler struct {
+	drafts     *DraftHandler
+	devices    voice.Repository
+	users      repositories.UserRepository
+	jwtManager *auth.JWTManager
+	// deviceTTL is how long a linked device's token works
+	deviceTTL time.Duration
+	activity  *activity.Recorder
+
+	alexa *voice.AlexaVerifier
+	// googleSecret, when set, must be sent by Dialogflow in the
+	// X-Voice-Webhook-Secret header
+	googleSecret string
+	// redirectURIs are the account linking redirects the authorize
+	// endpoint will send tokens to
+	redirectURIs []string
+}
+
+// NewVoiceHandler creates a voice handler that runs commands and publishes
+// their changes through drafts. Devices only work for users who are active.
+func NewVoiceHandler(drafts *DraftHandler, devices voice.Repository, users repositories.UserRepository, jwtManager *auth.JWTManager, deviceTTL time.Duration) *VoiceHandler {
+	return &VoiceHandler{
+		drafts:     drafts,
+		devices:    devices,
+		users:      users,
+		jwtManager: jwtManager,
+		deviceTTL:  deviceTTL,
+	}
+}
+
+// WithAlexa answers Alexa skill requests checked by verifier
+func (h *VoiceHandler) WithAlexa(verifier *voice.AlexaVerifier) *VoiceHandler {
+	h.alexa = verifier
+	return h
+}
+
+// WithGoogleSecret requires Dialogflow requests to carry secret
+func (h *VoiceHandler) WithGoogleSecret(secret string) *VoiceHandler {
+	h.googleSecret = secret
+	return h
+}
+
+// WithRedirectURIs allows account linking to send tokens to uris
+func (h *VoiceHandler) WithRedirectURIs(uris []string) *VoiceHandler {
+	h.redirectURIs = uris
+	return h
+}
+
+// WithActivity records device linking in users' activity
+func (h *VoiceHandler) WithActivity(recorder *activity.Recorder) *VoiceHandler {
+	h.activity = recorder
+	return h
+}
+
+// LinkDeviceRequest names an assistant being linked
+type LinkDeviceRequest struct {
+	Platform string `json:"platform" binding:"required,oneof=alexa google"`
+	Name     string `json:"name" binding:"max=100"`
+}
+
+// LinkDevice handles POST /api/voice/devices. It links an assistant and
+// returns its device token, which is only shown once. Assistants that link
+// accounts through OAuth get one from Authorize instead.
+func (h *VoiceHandler) LinkDevice(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req LinkDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	device, token, err := h.linkDevice(c, userID, req.Platform, req.Name)
+	if err != nil {
+		log.Printf("Failed to link voice device for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link device"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"device": device,
+		"token":  token,
+	})
+}
+
+// AuthorizeRequest is an account linking request from an assistant, as the
+// query parameters it sent to the consent page
+type AuthorizeRequest struct {
+	ResponseType string `json:"response_type" binding:"required"`
+	RedirectURI  string `json:"redirect_uri" binding:"required"`
+	State        string `json:"state"`
+}
+
+// Authorize handles POST /api/voice/authorize, account linking with the
+// OAuth implicit grant. The assistant sends the user to the app's consent
+// page, which posts its query parameters here once the signed-in user
+// agrees, then sends them on to the returned redirect_url carrying a new
+// device token. Posting from the page, not linking on the assistant's GET,
+// keeps other sites from linking an account without the user's consent.
+func (h *VoiceHandler) Authorize(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req AuthorizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ResponseType != "token" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "response_type must be token"})
+		return
+	}
+	if !h.allowedRedirect(req.RedirectURI) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "redirect_uri is not allowed"})
+		return
+	}
+
+	device, token, err := h.linkDevice(c, userID, redirectPlatform(req.RedirectURI), "")
+	if err != nil {
+		log.Printf("Failed to link voice device for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link device"})
+		return
+	}
+
+	fragment := url.Values{}
+	fragment.Set("access_token", token)
+	fragment.Set("token_type", "Bearer")
+	fragment.Set("state", req.State)
+	c.JSON(http.StatusOK, gin.H{
+		"device":       device,
+		"redirect_url": req.RedirectURI + "#" + fragment.Encode(),
+	})
+}
+
+// ListDevices handles GET /api/voice/devices
+func (h *VoiceHandler) ListDevices(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	devices, err := h.devices.List(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to list voice devices for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list devices"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"devices": devices})
+}
+
+// UnlinkDevice handles DELETE /api/voice/devices/:id. The device's token
+// stops working at once.
+func (h *VoiceHandler) UnlinkDevice(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid device ID"})
+		return
+	}
+
+	if err := h.devices.Revoke(c.Request.Context(), userID, deviceID); err != nil {
+		if errors.Is(err, voice.ErrDeviceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Device not found"})
+			return
+		}
+		log.Printf("Failed to unlink voice device %s: %v", deviceID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlink device"})
+		return
+	}
+
+	h.activity.Record(c, userID, activity.ActionVoiceDeviceUnlinked, "voice_device", deviceID.String(), nil)
+	c.Status(http.StatusNoContent)
+}
+
+// AlexaWebhook handles POST /api/voice/alexa, the endpoint of the Alexa
+// skill
+func (h *VoiceHandler) AlexaWebhook(c *gin.Context) {
+	if h.alexa == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Alexa is not enabled"})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxVoiceRequestBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request"})
+		return
+	}
+	var req voice.AlexaRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Alexa request"})
+		return
+	}
+	if err := h.alexa.Verify(c.Request, body, &req); err != nil {
+		log.Printf("Rejected Alexa request %s: %v", req.Request.RequestID, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Alexa request"})
+		return
+	}
+
+	reply, linkAccount := h.respond(c, req.AccessToken(), req.Utterance())
+	c.JSON(http.StatusOK, voice.NewAlexaResponse(reply, linkAccount))
+}
+
+// GoogleWebhook handles POST /api/voice/google, the Dialogflow fulfillment
+// webhook of the Google Assistant action
+func (h *VoiceHandler) GoogleWebhook(c *gin.Context) {
+	if h.googleSecret != "" &&
+		subtle.ConstantTimeCompare([]byte(c.GetHeader(VoiceWebhookSecretHeader)), []byte(h.googleSecret)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid webhook secret"})
+		return
+	}
+
+	var req voice.DialogflowRequest
+	if err := json.NewDecoder(io.LimitReader(c.Request.Body, maxVoiceRequestBytes)).Decode(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Dialogflow request"})
+		return
+	}
+
+	token := req.AccessToken()
+	if token == "" {
+		token = strings.TrimPrefix(c.GetHeader(auth.AuthorizationHeader), auth.BearerPrefix)
+	}
+
+	reply, linkAccount := h.respond(c, token, req.Utterance())
+	c.JSON(http.StatusOK, voice.NewDialogflowResponse(reply, linkAccount))
+}
+
+// respond runs what the user said as a command on their active draft. It
+// asks the user to link their account when the device token is missing or
+// no longer works.
+func (h *VoiceHandler) respond(c *gin.Context, token string, utterance voice.Utterance) (voice.Reply, bool) {
+	if utterance.End {
+		return voice.Reply{Speech: voice.GoodbyeSpeech, EndSession: true}, false
+	}
+
+	ctx := c.Request.Context()
+	userID, err := h.authenticate(ctx, token)
+	if err != nil {
+		return voice.Reply{Speech: voice.NotLinked, EndSession: true}, true
+	}
+	if utterance.Launch {
+		return voice.Reply{Speech: voice.WelcomeSpeech}, false
+	}
+	if utterance.Text == "" || utf8.RuneCountInString(utterance.Text) > maxVoiceCommand {
+		return voice.ErrorReply(draft.ErrUnknownCommand), false
+	}
+
+	draftService := h.drafts.draftService
+	sessionID, err := voice.ActiveSession(ctx, draftService, userID)
+	if err != nil {
+		if !errors.Is(err, voice.ErrNoActiveDraft) {
+			log.Printf("Failed to find active draft for user %s: %v", userID, err)
+		}
+		return voice.ErrorReply(err), false
+	}
+
+	result, err := draftService.RunCommand(ctx, sessionID, userID.String(), &draft.CommandRequest{Text: utterance.Text})
+	if err != nil {
+		if errors.Is(err, draft.ErrCommandParserFailed) {
+			log.Printf("Failed to parse voice command for draft %s: %v", sessionID, err)
+		}
+		return voice.ErrorReply(err), false
+	}
+
+	if result.Status == draft.CommandDone {
+		h.drafts.publishCommand(c, userID, sessionID, result, "voice")
+	}
+	return voice.CommandReply(result), false
+}
+
+// authenticate returns the user a device token was issued to, as long as
+// the device is still linked and the account is active. Deactivated and
+// anonymized accounts are treated as unlinked.
+func (h *VoiceHandler) authenticate(ctx context.Context, token string) (uuid.UUID, error) {
+	if token == "" {
+		return uuid.UUID{}, voice.ErrDeviceNotFound
+	}
+	claims, err := h.jwtManager.ValidateToken(token)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if claims.TokenType != auth.ScopedToken || claims.Scope != auth.ScopeVoiceDraft {
+		return uuid.UUID{}, voice.ErrDeviceNotFound
+	}
+	deviceID, err := uuid.Parse(claims.Resource)
+	if err != nil {
+		return uuid.UUID{}, voice.ErrDeviceNotFound
+	}
+
+	if _, err := h.devices.Get(ctx, claims.UserID, deviceID); err != nil {
+		if !errors.Is(err, voice.ErrDeviceNotFound) {
+			log.Printf("Failed to get voice device %s: %v", deviceID, err)
+		}
+		return uuid.UUID{}, err
+	}
+	user, err := h.users.GetByID(ctx, claims.UserID)
+	if errors.Is(err, repositories.ErrUserNotFound) {
+		return uuid.UUID{}, voice.ErrDeviceNotFound
+	}
+	if err != nil {
+		log.Printf("Failed to get user %s for voice device %s: %v", claims.UserID, deviceID, err)
+		return uuid.UUID{}, err
+	}
+	if !user.IsActive {
+		return uuid.UUID{}, voice.ErrDeviceNotFound
+	}
+	if err := h.devices.Touch(ctx, deviceID); err != nil {
+		log.Printf("Failed to update voice device %s: %v", deviceID, err)
+	}
+	return claims.UserID, nil
+}
+
+// linkDevice links a device for the user and issues its token
+func (h *VoiceHandler) linkDevice(c *gin.Context, userID uuid.UUID, platform, name string) (*voice.Device, string, error) {
+	if name == "" {
+		name = defaultDeviceNames[platform]
+	}
+	device := &voice.Device{ID: uuid.New(), UserID: userID, Platform: platform, Name: name}
+
+	token, expiresAt, err := h.jwtManager.GenerateScopedToken(userID, auth.ScopeVoiceDraft, device.ID.String(), h.deviceTTL)
+	if err != nil {
+		return nil, "", err
+	}
+	device.ExpiresAt = expiresAt
+	if err := h.devices.Create(c.Request.Context(), device); err != nil {
+		return nil, "", err
+	}
+
+	h.activity.Record(c, userID, activity.ActionVoiceDeviceLinked, "voice_device", device.ID.String(), map[string]interface{}{
+		"platform": platform,
+	})
+	return device, token, nil
+}
+
+// defaultDeviceNames name devices linked without one
+var defaultDeviceNames = map[string]string{
+	voice.PlatformAlexa:  "Alexa",
+	voice.PlatformGoogle: "Google Assistant",
+}
+
+// allowedRedirect reports whether uri is one of the configured account
+// linking redirects
+func (h *VoiceHandler) allowedRedirect(uri string) bool {
+	if uri == "" {
+		return false
+	}
+	for _, allowed := range h.redirectURIs {
+		if uri == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// redirectPlatform tells which assistant an account linking redirect
+// belongs to
+func redirectPlatform(uri string) string {
+	u, err := url.Parse(uri)
+	if err == nil && strings.Contains(u.Hostname(), "google") {
+		return voice.PlatformGoogle
+	}
+	return voice.PlatformAlexa
+}
@@ -0,0 +1,153 @@
+// Package inactivity warns users whose accounts have gone unused and, if
+// they still do not come back, anonymizes them: personal data and platform
+// credentials are removed while the leagues, rosters and drafts they took
+// part in stay intact for everyone else's history.
+package inactivity
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxRunErrors caps the errors kept in a run report
+const maxRunErrors = 20
+
+// Policy is when accounts are warned and anonymized
+type Policy struct {
+	// InactiveAfter is how long since a user last logged in (or signed up)
+	// before they are warned
+	InactiveAfter time.Duration
+	// WarningPeriod is how long a warned user has to log in before their
+	// account is anonymized
+	WarningPeriod time.Duration
+	// BatchSize caps the accounts warned and anonymized per run
+	BatchSize int
+}
+
+// Account is a user found inactive
+type Account struct {
+	UserID       uuid.UUID
+	LastActiveAt time.Time
+	WarnedAt     *time.Time
+}
+
+// Run is the report of one run, kept for admins
+type Run struct {
+	ID                uuid.UUID `json:"id"`
+	StartedAt         time.Time `json:"started_at"`
+	FinishedAt        time.Time `json:"finished_at"`
+	InactiveAfterDays int       `json:"inactive_after_days"`
+	WarningDays       int       `json:"warning_days"`
+	// Reactivated counts warned users who logged in again
+	Reactivated int      `json:"reactivated"`
+	Warned      int      `json:"warned"`
+	Anonymized  int      `json:"anonymized"`
+	Failed      int      `json:"failed"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// Store finds inactive accounts and anonymizes them
+type Store interface {
+	// ClearReactivated cancels the warning for users who logged in after
+	// being warned, returning how many there were
+	ClearReactivated(ctx context.Context) (int, error)
+	// FindUnwarned returns accounts last active before cutoff that have not
+	// been warned
+	FindUnwarned(ctx context.Context, cutoff time.Time, limit int) ([]Account, error)
+	// FindDue returns accounts warned before warnedBefore
+	FindDue(ctx context.Context, warnedBefore time.Time, limit int) ([]Account, error)
+	MarkWarned(ctx context.Context, userID uuid.UUID, at time.Time) error
+	// Anonymize replaces the user's personal data, signs them out and
+	// deletes their platform credentials, keeping their league history
+	Anonymize(ctx context.Context, userID uuid.UUID) error
+	SaveRun(ctx context.Context, run *Run) error
+	// ListRuns returns recent runs, newest first
+	ListRuns(ctx context.Context, limit int) ([]Run, error)
+}
+
+// Notifier tells a user their account will be anonymized
+type Notifier interface {
+	NotifyInactivity(ctx context.Context, userID uuid.UUID, anonymizeAt time.Time) error
+}
+
+// Job applies a Policy
+type Job struct {
+	store    Store
+	notifier Notifier
+	policy   Policy
+	now      func() time.Time
+}
+
+// NewJob creates a job for the given policy
+func NewJob(store Store, notifier Notifier, policy Policy) *Job {
+	return &Job{store: store, notifier: notifier, policy: policy, now: time.Now}
+}
+
+// Run warns newly inactive users and anonymizes those whose warning period
+// has passed, then saves and returns a report. Users who logged in since
+// their warning are left alone. A failure on one account is reported and
+// the run goes on.
+func (j *Job) Run(ctx context.Context) (*Run, error) {
+	now := j.now()
+	run := &Run{
+		ID:                uuid.New(),
+		StartedAt:         now,
+		InactiveAfterDays: int(j.policy.InactiveAfter / (24 * time.Hour)),
+		WarningDays:       int(j.policy.WarningPeriod / (24 * time.Hour)),
+	}
+
+	reactivated, err := j.store.ClearReactivated(ctx)
+	if err != nil {
+		return nil, err
+	}
+	run.Reactivated = reactivated
+
+	// Anonymize first, so a user is never warned and anonymized in one run
+	due, err := j.store.FindDue(ctx, now.Add(-j.policy.WarningPeriod), j.policy.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range due {
+		if err := j.store.Anonymize(ctx, account.UserID); err != nil {
+			run.fail(fmt.Errorf("anonymize %s: %w", account.UserID, err))
+			continue
+		}
+		run.Anonymized++
+	}
+
+	unwarned, err := j.store.FindUnwarned(ctx, now.Add(-j.policy.InactiveAfter), j.policy.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	anonymizeAt := now.Add(j.policy.WarningPeriod)
+	for _, account := range unwarned {
+		if err := j.notifier.NotifyInactivity(ctx, account.UserID, anonymizeAt); err != nil {
+			run.fail(fmt.Errorf("warn %s: %w", account.UserID, err))
+			continue
+		}
+		// The warning period starts once the user has been told
+		if err := j.store.MarkWarned(ctx, account.UserID, now); err != nil {
+			run.fail(fmt.Errorf("mark %s warned: %w", account.UserID, err))
+			continue
+		}
+		run.Warned++
+	}
+
+	run.FinishedAt = j.now()
+	if err := j.store.SaveRun(ctx, run); err != nil {
+		log.Printf("Failed to save inactive account run: %v", err)
+	}
+	return run, nil
+}
+
+// fail counts a failed account and keeps its error for the report
+func (r *Run) fail(err error) {
+	r.Failed++
+	if len(r.Errors) < maxRunErrors {
+		r.Errors = append(r.Errors, err.Error())
+	}
+}
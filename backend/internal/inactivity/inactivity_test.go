@@ -0,0 +1,117 @@
+package inactivity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore keeps accounts in memory
+type fakeStore struct {
+	unwarned    []Account
+	due         []Account
+	reactivated int
+	warned      map[uuid.UUID]time.Time
+	anonymized  []uuid.UUID
+	failOn      uuid.UUID
+	runs        []Run
+
+	unwarnedCutoff time.Time
+	dueBefore      time.Time
+}
+
+func (s *fakeStore) ClearReactivated(ctx context.Context) (int, error) {
+	return s.reactivated, nil
+}
+
+func (s *fakeStore) FindUnwarned(ctx context.Context, cutoff time.Time, limit int) ([]Account, error) {
+	s.unwarnedCutoff = cutoff
+	return s.unwarned, nil
+}
+
+func (s *fakeStore) FindDue(ctx context.Context, warnedBefore time.Time, limit int) ([]Account, error) {
+	s.dueBefore = warnedBefore
+	return s.due, nil
+}
+
+func (s *fakeStore) MarkWarned(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	s.warned[userID] = at
+	return nil
+}
+
+func (s *fakeStore) Anonymize(ctx context.Context, userID uuid.UUID) error {
+	if userID == s.failOn {
+		return errors.New("database unavailable")
+	}
+	s.anonymized = append(s.anonymized, userID)
+	return nil
+}
+
+func (s *fakeStore) SaveRun(ctx context.Context, run *Run) error {
+	s.runs = append(s.runs, *run)
+	return nil
+}
+
+func (s *fakeStore) ListRuns(ctx context.Context, limit int) ([]Run, error) {
+	return s.runs, nil
+}
+
+// fakeNotifier records warnings, failing for one user
+type fakeNotifier struct {
+	notified map[uuid.UUID]time.Time
+	failOn   uuid.UUID
+}
+
+func (n *fakeNotifier) NotifyInactivity(ctx context.Context, userID uuid.UUID, anonymizeAt time.Time) error {
+	if userID == n.failOn {
+		return errors.New("notification failed")
+	}
+	n.notified[userID] = anonymizeAt
+	return nil
+}
+
+func TestJobRun(t *testing.T) {
+	now := time.Date(2025, 3, 1, 4, 0, 0, 0, time.UTC)
+	warnOK, warnFails := uuid.New(), uuid.New()
+	dueOK, dueFails := uuid.New(), uuid.New()
+
+	store := &fakeStore{
+		unwarned:    []Account{{UserID: warnOK}, {UserID: warnFails}},
+		due:         []Account{{UserID: dueOK}, {UserID: dueFails}},
+		reactivated: 3,
+		warned:      map[uuid.UUID]time.Time{},
+		failOn:      dueFails,
+	}
+	notifier := &fakeNotifier{notified: map[uuid.UUID]time.Time{}, failOn: warnFails}
+	policy := Policy{InactiveAfter: 730 * 24 * time.Hour, WarningPeriod: 30 * 24 * time.Hour, BatchSize: 100}
+
+	job := NewJob(store, notifier, policy)
+	job.now = func() time.Time { return now }
+
+	run, err := job.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, now.Add(-policy.InactiveAfter), store.unwarnedCutoff)
+	assert.Equal(t, now.Add(-policy.WarningPeriod), store.dueBefore)
+
+	// Only users who were actually told start their warning period
+	assert.Equal(t, map[uuid.UUID]time.Time{warnOK: now}, store.warned)
+	assert.Equal(t, now.Add(policy.WarningPeriod), notifier.notified[warnOK])
+	assert.Equal(t, []uuid.UUID{dueOK}, store.anonymized)
+
+	assert.Equal(t, 730, run.InactiveAfterDays)
+	assert.Equal(t, 30, run.WarningDays)
+	assert.Equal(t, 3, run.Reactivated)
+	assert.Equal(t, 1, run.Warned)
+	assert.Equal(t, 1, run.Anonymized)
+	assert.Equal(t, 2, run.Failed)
+	assert.Len(t, run.Errors, 2)
+
+	require.Len(t, store.runs, 1)
+	assert.Equal(t, run.ID, store.runs[0].ID)
+}
@@ -0,0 +1,200 @@
+package inactivity
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// personalTables hold rows that only matter to the user and are deleted when
+// they are anonymized. League, roster and draft rows are kept.
+var personalTables = []string{
+	"league_auth",
+	"refresh_tokens",
+	"user_identities",
+	"user_backup_codes",
+	"user_two_factor",
+	"user_watchlist",
+	"notifications",
+	"user_analytics_consent",
+	"user_known_devices",
+	"user_known_networks",
+	"login_verifications",
+	"voice_devices",
+}
+
+// PostgresStore implements Store on the users table
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new inactive account store
+func NewPostgresStore(db *sql.DB) Store {
+	return &PostgresStore{db: db}
+}
+
+// ClearReactivated cancels warnings for users who have logged in since
+func (s *PostgresStore) ClearReactivated(ctx context.Context) (int, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE users SET inactivity_warned_at = NULL
+		WHERE inactivity_warned_at IS NOT NULL AND last_login_at > inactivity_warned_at`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear inactivity warnings: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
+
+// FindUnwarned returns the longest inactive accounts not yet warned
+func (s *PostgresStore) FindUnwarned(ctx context.Context, cutoff time.Time, limit int) ([]Account, error) {
+	return s.find(ctx, `
+		SELECT id, COALESCE(last_login_at, created_at), inactivity_warned_at
+		FROM users
+		WHERE anonymized_at IS NULL AND inactivity_warned_at IS NULL
+		  AND COALESCE(last_login_at, created_at) < $1
+		ORDER BY COALESCE(last_login_at, created_at)
+		LIMIT $2`, cutoff, limit)
+}
+
+// FindDue returns accounts whose warning period has passed
+func (s *PostgresStore) FindDue(ctx context.Context, warnedBefore time.Time, limit int) ([]Account, error) {
+	return s.find(ctx, `
+		SELECT id, COALESCE(last_login_at, created_at), inactivity_warned_at
+		FROM users
+		WHERE anonymized_at IS NULL AND inactivity_warned_at < $1
+		ORDER BY inactivity_warned_at
+		LIMIT $2`, warnedBefore, limit)
+}
+
+func (s *PostgresStore) find(ctx context.Context, query string, at time.Time, limit int) ([]Account, error) {
+	rows, err := s.db.QueryContext(ctx, query, at, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find inactive accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []Account
+	for rows.Next() {
+		var a Account
+		if err := rows.Scan(&a.UserID, &a.LastActiveAt, &a.WarnedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan inactive account: %w", err)
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, rows.Err()
+}
+
+// MarkWarned starts the user's warning period
+func (s *PostgresStore) MarkWarned(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	if _, err := s.db.ExecContext(ctx,
+		`UPDATE users SET inactivity_warned_at = $2 WHERE id = $1`, userID, at,
+	); err != nil {
+		return fmt.Errorf("failed to mark user warned: %w", err)
+	}
+	return nil
+}
+
+// Anonymize removes the user's personal data in one transaction. The user
+// row stays, under a placeholder name and email, so their leagues and
+// drafts still have an owner.
+func (s *PostgresStore) Anonymize(ctx context.Context, userID uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	placeholder := fmt.Sprintf("anonymized-%s@anonymized.invalid", userID)
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users SET
+			email = $2, username = $2, first_name = 'Deleted', last_name = 'User', full_name = NULL,
+			password_hash = '', is_active = false, is_verified = false, email_verified = false,
+			email_verified_at = NULL, preferences = '{}', inactivity_warned_at = NULL,
+			anonymized_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND anonymized_at IS NULL`,
+		userID, placeholder,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		// Already anonymized
+		return nil
+	}
+
+	for _, table := range personalTables {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", table, err)
+		}
+	}
+	for _, table := range []string{"audit_logs", "audit_events", "api_logs"} {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE `+table+` SET ip_address = NULL, user_agent = NULL WHERE user_id = $1`, userID,
+		); err != nil {
+			return fmt.Errorf("failed to scrub %s: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit anonymization: %w", err)
+	}
+	return nil
+}
+
+// SaveRun stores a run report
+func (s *PostgresStore) SaveRun(ctx context.Context, run *Run) error {
+	var errs []byte
+	if len(run.Errors) > 0 {
+		var err error
+		if errs, err = json.Marshal(run.Errors); err != nil {
+			return fmt.Errorf("failed to marshal run errors: %w", err)
+		}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO inactivity_runs (id, started_at, finished_at, inactive_after_days, warning_days,
+			reactivated, warned, anonymized, failed, errors)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		run.ID, run.StartedAt, run.FinishedAt, run.InactiveAfterDays, run.WarningDays,
+		run.Reactivated, run.Warned, run.Anonymized, run.Failed, errs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save inactivity run: %w", err)
+	}
+	return nil
+}
+
+// ListRuns returns the most recent runs
+func (s *PostgresStore) ListRuns(ctx context.Context, limit int) ([]Run, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, started_at, finished_at, inactive_after_days, warning_days,
+			reactivated, warned, anonymized, failed, errors
+		FROM inactivity_runs
+		ORDER BY started_at DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inactivity runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []Run{}
+	for rows.Next() {
+		var run Run
+		var errs []byte
+		if err := rows.Scan(&run.ID, &run.StartedAt, &run.FinishedAt, &run.InactiveAfterDays, &run.WarningDays,
+			&run.Reactivated, &run.Warned, &run.Anonymized, &run.Failed, &errs); err != nil {
+			return nil, fmt.Errorf("failed to scan inactivity run: %w", err)
+		}
+		if len(errs) > 0 {
+			if err := json.Unmarshal(errs, &run.Errors); err != nil {
+				return nil, fmt.Errorf("failed to decode run errors: %w", err)
+			}
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
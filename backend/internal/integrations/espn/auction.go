@@ -0,0 +1,156 @@
+package espn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// maxAuctionValues caps an auction value request
+const maxAuctionValues = 300
+
+// AuctionValue is what a player is worth in a league's auction draft
+type AuctionValue struct {
+	PlayerID   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	Position   string `json:"position"`
+	Team       string `json:"team"`
+	// AuctionValue is ESPN's suggested price for the league's budget and
+	// scoring settings
+	AuctionValue int `json:"auctionValue"`
+	// AverageAuctionValue is the average winning bid across ESPN leagues
+	AverageAuctionValue       float64 `json:"averageAuctionValue"`
+	AverageAuctionValueChange float64 `json:"averageAuctionValueChange"`
+	AverageDraftPosition      float64 `json:"averageDraftPosition"`
+	// OnTeamID is the fantasy team rostering the player, 0 if available
+	OnTeamID int `json:"onTeamId,omitempty"`
+}
+
+// KeeperCost is what it costs a team to keep a rostered player
+type KeeperCost struct {
+	TeamID     int    `json:"teamId"`
+	PlayerID   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	Position   string `json:"position"`
+	Team       string `json:"team"`
+	// KeeperValue is the cost to keep the player this season: an auction
+	// price, or a draft round in snake leagues
+	KeeperValue int `json:"keeperValue"`
+	// KeeperValueFuture is the cost to keep the player next season
+	KeeperValueFuture int `json:"keeperValueFuture"`
+	// AuctionValue is ESPN's suggested auction price for comparison
+	AuctionValue int `json:"auctionValue"`
+}
+
+// espnPoolPlayer is the player part of a player pool entry
+type espnPoolPlayer struct {
+	ID                int    `json:"id"`
+	FullName          string `json:"fullName"`
+	DefaultPositionID int    `json:"defaultPositionId"`
+	ProTeamID         int    `json:"proTeamId"`
+	Ownership         struct {
+		AuctionValueAverage       float64 `json:"auctionValueAverage"`
+		AuctionValueAverageChange float64 `json:"auctionValueAverageChange"`
+		AverageDraftPosition      float64 `json:"averageDraftPosition"`
+	} `json:"ownership"`
+}
+
+// GetAuctionValues fetches auction prices for a league's player pool, most
+// valuable first. ESPN prices players for the league's own budget and
+// scoring, so values differ between leagues.
+func (c *ESPNClient) GetAuctionValues(ctx context.Context, leagueID string, season, limit int) ([]AuctionValue, error) {
+	if limit <= 0 || limit > maxAuctionValues {
+		limit = maxAuctionValues
+	}
+
+	data, err := json.Marshal(playerFilter{
+		Players: playerFilterOptions{
+			FilterSlotIDs:  filterValue{Value: []int{0, 2, 4, 6, 16, 17}},
+			Limit:          limit,
+			SortDraftRanks: &draftRankOrder{SortPriority: 1, SortAsc: true, Value: "STANDARD"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build player filter: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/seasons/%d/segments/0/leagues/%s?view=kona_player_info&scoringPeriodId=0",
+		c.baseURL, season, leagueID)
+
+	var response struct {
+		Players []struct {
+			OnTeamID          int            `json:"onTeamId"`
+			DraftAuctionValue int            `json:"draftAuctionValue"`
+			Player            espnPoolPlayer `json:"player"`
+		} `json:"players"`
+	}
+
+	headers := map[string]string{"X-Fantasy-Filter": string(data)}
+	if err := c.makeRequestWithHeaders(ctx, "GET", endpoint, nil, headers, &response); err != nil {
+		return nil, fmt.Errorf("failed to get auction values: %w", err)
+	}
+
+	values := make([]AuctionValue, 0, len(response.Players))
+	for _, entry := range response.Players {
+		p := entry.Player
+		values = append(values, AuctionValue{
+			PlayerID:                  strconv.Itoa(p.ID),
+			PlayerName:                p.FullName,
+			Position:                  PositionName(p.DefaultPositionID),
+			Team:                      TeamAbbreviation(p.ProTeamID),
+			AuctionValue:              entry.DraftAuctionValue,
+			AverageAuctionValue:       p.Ownership.AuctionValueAverage,
+			AverageAuctionValueChange: p.Ownership.AuctionValueAverageChange,
+			AverageDraftPosition:      p.Ownership.AverageDraftPosition,
+			OnTeamID:                  entry.OnTeamID,
+		})
+	}
+
+	return values, nil
+}
+
+// GetKeeperCosts fetches the cost of keeping each rostered player in a
+// keeper league, grouped by team
+func (c *ESPNClient) GetKeeperCosts(ctx context.Context, leagueID string, season int) ([]KeeperCost, error) {
+	endpoint := fmt.Sprintf("%s/seasons/%d/segments/0/leagues/%s?view=mRoster", c.baseURL, season, leagueID)
+
+	var response struct {
+		Teams []struct {
+			ID     int `json:"id"`
+			Roster struct {
+				Entries []struct {
+					PlayerPoolEntry struct {
+						KeeperValue       int            `json:"keeperValue"`
+						KeeperValueFuture int            `json:"keeperValueFuture"`
+						DraftAuctionValue int            `json:"draftAuctionValue"`
+						Player            espnPoolPlayer `json:"player"`
+					} `json:"playerPoolEntry"`
+				} `json:"entries"`
+			} `json:"roster"`
+		} `json:"teams"`
+	}
+
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get keeper costs: %w", err)
+	}
+
+	var costs []KeeperCost
+	for _, team := range response.Teams {
+		for _, entry := range team.Roster.Entries {
+			pool := entry.PlayerPoolEntry
+			costs = append(costs, KeeperCost{
+				TeamID:            team.ID,
+				PlayerID:          strconv.Itoa(pool.Player.ID),
+				PlayerName:        pool.Player.FullName,
+				Position:          PositionName(pool.Player.DefaultPositionID),
+				Team:              TeamAbbreviation(pool.Player.ProTeamID),
+				KeeperValue:       pool.KeeperValue,
+				KeeperValueFuture: pool.KeeperValueFuture,
+				AuctionValue:      pool.DraftAuctionValue,
+			})
+		}
+	}
+
+	return costs, nil
+}
@@ -0,0 +1,139 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// actualStatSource is the statSourceId ESPN uses for actual stats
+const actualStatSource = 0
+
+// lineupSlots maps lineupSlotId to slot names
+var lineupSlots = map[int]string{
+	0:  "QB",
+	2:  "RB",
+	4:  "WR",
+	6:  "TE",
+	16: "DST",
+	17: "K",
+	20: "BE",
+	21: "IR",
+	23: "FLEX",
+}
+
+// benchSlots are lineup slots whose points do not count
+var benchSlots = map[int]bool{20: true, 21: true}
+
+// boxScoreSide is a team's half of a matchup in the mBoxscore view
+type boxScoreSide struct {
+	TeamID                        int     `json:"teamId"`
+	TotalPoints                   float64 `json:"totalPoints"`
+	TotalProjectedPointsLive      float64 `json:"totalProjectedPointsLive"`
+	RosterForCurrentScoringPeriod struct {
+		Entries []struct {
+			PlayerID        int `json:"playerId"`
+			LineupSlotID    int `json:"lineupSlotId"`
+			PlayerPoolEntry struct {
+				Player struct {
+					ID                int    `json:"id"`
+					FullName          string `json:"fullName"`
+					DefaultPositionID int    `json:"defaultPositionId"`
+					ProTeamID         int    `json:"proTeamId"`
+					Stats             []struct {
+						ScoringPeriodID int                `json:"scoringPeriodId"`
+						StatSourceID    int                `json:"statSourceId"`
+						Stats           map[string]float64 `json:"stats"`
+						AppliedTotal    float64            `json:"appliedTotal"`
+					} `json:"stats"`
+				} `json:"player"`
+			} `json:"playerPoolEntry"`
+		} `json:"entries"`
+	} `json:"rosterForCurrentScoringPeriod"`
+}
+
+// GetBoxScores fetches every team's box score for a week: each rostered
+// player's fantasy points, projection and raw stats. Teams on a bye are
+// returned without an opponent.
+func (c *ESPNClient) GetBoxScores(ctx context.Context, leagueID string, week int) ([]BoxScore, error) {
+	endpoint := fmt.Sprintf("%s/seasons/2023/segments/0/leagues/%s?view=mBoxscore&view=mMatchupScore&scoringPeriodId=%d",
+		c.baseURL, leagueID, week)
+
+	var response struct {
+		Schedule []struct {
+			ID              int           `json:"id"`
+			MatchupPeriodID int           `json:"matchupPeriodId"`
+			Home            *boxScoreSide `json:"home"`
+			Away            *boxScoreSide `json:"away"`
+		} `json:"schedule"`
+	}
+
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get box scores: %w", err)
+	}
+
+	var boxScores []BoxScore
+	for _, m := range response.Schedule {
+		matchupID := strconv.Itoa(m.ID)
+		for _, pair := range [][2]*boxScoreSide{{m.Home, m.Away}, {m.Away, m.Home}} {
+			side, opponent := pair[0], pair[1]
+			// Only matchups covering the requested week carry rosters
+			if side == nil || len(side.RosterForCurrentScoringPeriod.Entries) == 0 {
+				continue
+			}
+
+			box := BoxScore{
+				MatchupID:       matchupID,
+				Week:            week,
+				TeamID:          side.TeamID,
+				Points:          side.TotalPoints,
+				ProjectedPoints: side.TotalProjectedPointsLive,
+				Players:         boxScorePlayers(side, week),
+			}
+			if opponent != nil {
+				box.OpponentID = opponent.TeamID
+			}
+			boxScores = append(boxScores, box)
+		}
+	}
+
+	return boxScores, nil
+}
+
+// boxScorePlayers pulls each roster entry's actual and projected stats for week
+func boxScorePlayers(side *boxScoreSide, week int) []BoxScorePlayer {
+	entries := side.RosterForCurrentScoringPeriod.Entries
+	players := make([]BoxScorePlayer, 0, len(entries))
+
+	for _, e := range entries {
+		p := e.PlayerPoolEntry.Player
+		player := BoxScorePlayer{
+			PlayerID:   strconv.Itoa(e.PlayerID),
+			PlayerName: p.FullName,
+			Position:   PositionName(p.DefaultPositionID),
+			Team:       TeamAbbreviation(p.ProTeamID),
+			LineupSlot: lineupSlots[e.LineupSlotID],
+			Starter:    !benchSlots[e.LineupSlotID],
+			Stats:      map[string]float64{},
+		}
+
+		for _, s := range p.Stats {
+			if s.ScoringPeriodID != week {
+				continue
+			}
+			switch s.StatSourceID {
+			case actualStatSource:
+				player.Points = s.AppliedTotal
+				if s.Stats != nil {
+					player.Stats = s.Stats
+				}
+			case projectionStatSource:
+				player.ProjectedPoints = s.AppliedTotal
+			}
+		}
+
+		players = append(players, player)
+	}
+
+	return players
+}
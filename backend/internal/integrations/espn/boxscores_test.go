@@ -0,0 +1,80 @@
+package espn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBoxScores(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/seasons/2023/segments/0/leagues/123456", r.URL.Path)
+		assert.Equal(t, []string{"mBoxscore", "mMatchupScore"}, r.URL.Query()["view"])
+		assert.Equal(t, "7", r.URL.Query().Get("scoringPeriodId"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"schedule": [
+			{"id": 31, "matchupPeriodId": 6,
+			 "home": {"teamId": 1, "totalPoints": 98.2, "rosterForCurrentScoringPeriod": {"entries": []}},
+			 "away": {"teamId": 2, "totalPoints": 101.5, "rosterForCurrentScoringPeriod": {"entries": []}}},
+			{"id": 37, "matchupPeriodId": 7,
+			 "home": {"teamId": 1, "totalPoints": 112.4, "totalProjectedPointsLive": 108.9, "rosterForCurrentScoringPeriod": {"entries": [
+				{"playerId": 3918298, "lineupSlotId": 0, "playerPoolEntry": {"player": {"id": 3918298, "fullName": "Josh Allen", "defaultPositionId": 1, "proTeamId": 2, "stats": [
+					{"scoringPeriodId": 6, "statSourceId": 0, "stats": {"3": 180}, "appliedTotal": 12.2},
+					{"scoringPeriodId": 7, "statSourceId": 0, "stats": {"3": 310, "4": 3}, "appliedTotal": 26.4},
+					{"scoringPeriodId": 7, "statSourceId": 1, "stats": {"3": 265}, "appliedTotal": 22.1}
+				]}}},
+				{"playerId": 2977187, "lineupSlotId": 20, "playerPoolEntry": {"player": {"id": 2977187, "fullName": "Harrison Butker", "defaultPositionId": 5, "proTeamId": 12, "stats": [
+					{"scoringPeriodId": 7, "statSourceId": 0, "stats": {}, "appliedTotal": 9}
+				]}}}
+			 ]}},
+			 "away": {"teamId": 3, "totalPoints": 87.0, "rosterForCurrentScoringPeriod": {"entries": [
+				{"playerId": 4262921, "lineupSlotId": 23, "playerPoolEntry": {"player": {"id": 4262921, "fullName": "Justin Jefferson", "defaultPositionId": 3, "proTeamId": 16, "stats": []}}}
+			 ]}}},
+			{"id": 38, "matchupPeriodId": 7,
+			 "home": {"teamId": 4, "totalPoints": 90.1, "rosterForCurrentScoringPeriod": {"entries": [
+				{"playerId": 15847, "lineupSlotId": 6, "playerPoolEntry": {"player": {"id": 15847, "fullName": "Travis Kelce", "defaultPositionId": 4, "proTeamId": 12, "stats": []}}}
+			 ]}}}
+		]}`))
+	}))
+	defer server.Close()
+
+	boxScores, err := newTestClient(server.URL).GetBoxScores(context.Background(), "123456", 7)
+	require.NoError(t, err)
+
+	// Earlier matchups carry no rosters for the week
+	require.Len(t, boxScores, 3)
+
+	home := boxScores[0]
+	assert.Equal(t, "37", home.MatchupID)
+	assert.Equal(t, 7, home.Week)
+	assert.Equal(t, 1, home.TeamID)
+	assert.Equal(t, 3, home.OpponentID)
+	assert.Equal(t, 112.4, home.Points)
+	assert.Equal(t, 108.9, home.ProjectedPoints)
+	require.Len(t, home.Players, 2)
+
+	allen := home.Players[0]
+	assert.Equal(t, "3918298", allen.PlayerID)
+	assert.Equal(t, "QB", allen.Position)
+	assert.Equal(t, "BUF", allen.Team)
+	assert.True(t, allen.Starter)
+	assert.Equal(t, 26.4, allen.Points)
+	assert.Equal(t, 22.1, allen.ProjectedPoints)
+	assert.Equal(t, 310.0, allen.Stats[StatPassingYards])
+
+	butker := home.Players[1]
+	assert.Equal(t, "K", butker.Position)
+	assert.Equal(t, "BE", butker.LineupSlot)
+	assert.False(t, butker.Starter)
+
+	assert.Equal(t, 3, boxScores[1].TeamID)
+	assert.Equal(t, "FLEX", boxScores[1].Players[0].LineupSlot)
+
+	// Teams on a bye have no opponent
+	assert.Equal(t, 4, boxScores[2].TeamID)
+	assert.Zero(t, boxScores[2].OpponentID)
+}
@@ -0,0 +1,121 @@
+package espn
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// breakerThreshold is how many consecutive failed requests open the circuit
+	breakerThreshold = 5
+	// breakerCooldown is how long the circuit stays open before a probe
+	breakerCooldown = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without contacting ESPN while the API is
+// considered down
+var ErrCircuitOpen = errors.New("ESPN API unavailable - circuit breaker open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker stops calling ESPN after repeated failures so an outage fails
+// fast instead of tying up handlers in retries. After the cooldown one probe
+// request is let through; its result closes or reopens the circuit.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a request may proceed. A nil breaker allows
+// everything.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return nil
+	case breakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// success records a request that reached a healthy ESPN
+func (b *circuitBreaker) success() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// failure records a request that failed because ESPN was unreachable or erroring
+func (b *circuitBreaker) failure() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+	b.probing = false
+}
+
+// cancel releases a half-open probe whose caller gave up, so the next request
+// can probe instead
+func (b *circuitBreaker) cancel() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
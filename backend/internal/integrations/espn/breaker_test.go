@@ -0,0 +1,91 @@
+package espn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(3, 30*time.Second)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, b.allow())
+		b.failure()
+	}
+	assert.NoError(t, b.allow(), "circuit should stay closed below the threshold")
+	b.failure()
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen)
+
+	// One probe after the cooldown; others keep failing fast
+	now = now.Add(31 * time.Second)
+	assert.NoError(t, b.allow())
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen)
+
+	// A failed probe reopens immediately
+	b.failure()
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen)
+
+	// A cancelled probe lets the next request probe
+	now = now.Add(31 * time.Second)
+	assert.NoError(t, b.allow())
+	b.cancel()
+	assert.NoError(t, b.allow())
+
+	// A successful probe closes the circuit
+	b.success()
+	assert.NoError(t, b.allow())
+	assert.NoError(t, b.allow())
+}
+
+func TestCircuitBreaker_Nil(t *testing.T) {
+	var b *circuitBreaker
+	assert.NoError(t, b.allow())
+	b.failure()
+	b.success()
+	b.cancel()
+}
+
+func TestMakeRequest_CircuitBreaker(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Query().Get("missing") != "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &ESPNClient{
+		httpClient:  http.DefaultClient,
+		baseURL:     server.URL,
+		rateLimiter: newRateLimiter(time.Millisecond, rateLimitBurst),
+		breaker:     newCircuitBreaker(1, time.Minute),
+	}
+	ctx := context.Background()
+
+	// A missing league is a healthy response and does not trip the breaker
+	err := client.makeRequest(ctx, "GET", server.URL+"?missing=1", nil, &struct{}{})
+	assert.ErrorIs(t, err, ErrLeagueNotFound)
+	assert.NoError(t, client.breaker.allow())
+	client.breaker.success()
+
+	err = client.makeRequest(ctx, "GET", server.URL, nil, &struct{}{})
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrCircuitOpen))
+
+	sent := atomic.LoadInt32(&requests)
+	err = client.makeRequest(ctx, "GET", server.URL, nil, &struct{}{})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, sent, atomic.LoadInt32(&requests), "open circuit should not contact ESPN")
+}
@@ -0,0 +1,379 @@
+package espn
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/cache"
+)
+
+// CacheTTLs sets how long each kind of ESPN response is cached. A zero TTL
+// always calls ESPN.
+type CacheTTLs struct {
+	LeagueInfo   time.Duration
+	Rosters      time.Duration
+	FreeAgents   time.Duration
+	Matchups     time.Duration
+	Transactions time.Duration
+	WaiverClaims time.Duration
+	BoxScores    time.Duration
+	// LiveScoring is used while an NFL game is in progress and LiveIdle
+	// between game windows
+	LiveScoring   time.Duration
+	LiveIdle      time.Duration
+	DraftResults  time.Duration
+	History       time.Duration
+	AuctionValues time.Duration
+	KeeperCosts   time.Duration
+	SeasonStatus  time.Duration
+	InjuryReport  time.Duration
+	PlayerNews    time.Duration
+	Projections   time.Duration
+	Trending      time.Duration
+	DraftRanks    time.Duration
+	NFLTeams      time.Duration
+}
+
+// DefaultCacheTTLs returns TTLs suited to how often each view changes.
+// Scores and transactions move during games, so they are kept short.
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		LeagueInfo:    time.Hour,
+		Rosters:       5 * time.Minute,
+		FreeAgents:    5 * time.Minute,
+		Matchups:      time.Minute,
+		Transactions:  2 * time.Minute,
+		WaiverClaims:  2 * time.Minute,
+		BoxScores:     time.Minute,
+		LiveScoring:   20 * time.Second,
+		LiveIdle:      5 * time.Minute,
+		DraftResults:  24 * time.Hour,
+		History:       24 * time.Hour,
+		AuctionValues: time.Hour,
+		KeeperCosts:   time.Hour,
+		SeasonStatus:  time.Hour,
+		InjuryReport:  15 * time.Minute,
+		PlayerNews:    10 * time.Minute,
+		Projections:   time.Hour,
+		Trending:      30 * time.Minute,
+		DraftRanks:    6 * time.Hour,
+		NFLTeams:      7 * 24 * time.Hour,
+	}
+}
+
+// CachedClient caches ESPN responses in Redis in front of another Client.
+// Keys are scoped to the cookies in use so a private league's data is only
+// served to callers holding the same credentials. Errors are never cached.
+type CachedClient struct {
+	client Client
+	cache  *cache.Cache
+	ttls   CacheTTLs
+	scope  string
+}
+
+// NewCachedClient wraps client with a response cache
+func NewCachedClient(client Client, c *cache.Cache, ttls CacheTTLs) Client {
+	return &CachedClient{
+		client: client,
+		cache:  c,
+		ttls:   ttls,
+		scope:  "public",
+	}
+}
+
+// GetLeagueInfo returns cached league information
+func (c *CachedClient) GetLeagueInfo(ctx context.Context, leagueID string) (*LeagueInfo, error) {
+	var info *LeagueInfo
+	err := c.fetch(ctx, c.key("league", leagueID), c.ttls.LeagueInfo, &info, func() (err error) {
+		info, err = c.client.GetLeagueInfo(ctx, leagueID)
+		return err
+	})
+	return info, err
+}
+
+// GetRosters returns cached rosters
+func (c *CachedClient) GetRosters(ctx context.Context, leagueID string) ([]Roster, error) {
+	var rosters []Roster
+	err := c.fetch(ctx, c.key("rosters", leagueID), c.ttls.Rosters, &rosters, func() (err error) {
+		rosters, err = c.client.GetRosters(ctx, leagueID)
+		return err
+	})
+	return rosters, err
+}
+
+// GetAvailablePlayers returns cached free agents
+func (c *CachedClient) GetAvailablePlayers(ctx context.Context, leagueID string, maxPlayers int) ([]Player, error) {
+	var players []Player
+	err := c.fetch(ctx, c.key("free_agents", leagueID, maxPlayers), c.ttls.FreeAgents, &players, func() (err error) {
+		players, err = c.client.GetAvailablePlayers(ctx, leagueID, maxPlayers)
+		return err
+	})
+	return players, err
+}
+
+// GetMatchups returns cached matchups for a week
+func (c *CachedClient) GetMatchups(ctx context.Context, leagueID string, week int) ([]Matchup, error) {
+	var matchups []Matchup
+	err := c.fetch(ctx, c.key("matchups", leagueID, week), c.ttls.Matchups, &matchups, func() (err error) {
+		matchups, err = c.client.GetMatchups(ctx, leagueID, week)
+		return err
+	})
+	return matchups, err
+}
+
+// GetTransactions returns cached recent transactions
+func (c *CachedClient) GetTransactions(ctx context.Context, leagueID string, limit int) ([]Transaction, error) {
+	var transactions []Transaction
+	err := c.fetch(ctx, c.key("transactions", leagueID, limit), c.ttls.Transactions, &transactions, func() (err error) {
+		transactions, err = c.client.GetTransactions(ctx, leagueID, limit)
+		return err
+	})
+	return transactions, err
+}
+
+// GetWaiverClaims returns cached waiver claims
+func (c *CachedClient) GetWaiverClaims(ctx context.Context, leagueID string) ([]WaiverClaim, error) {
+	var claims []WaiverClaim
+	err := c.fetch(ctx, c.key("waivers", leagueID), c.ttls.WaiverClaims, &claims, func() (err error) {
+		claims, err = c.client.GetWaiverClaims(ctx, leagueID)
+		return err
+	})
+	return claims, err
+}
+
+// GetBoxScores returns cached box scores for a week
+func (c *CachedClient) GetBoxScores(ctx context.Context, leagueID string, week int) ([]BoxScore, error) {
+	var boxScores []BoxScore
+	err := c.fetch(ctx, c.key("boxscores", leagueID, week), c.ttls.BoxScores, &boxScores, func() (err error) {
+		boxScores, err = c.client.GetBoxScores(ctx, leagueID, week)
+		return err
+	})
+	return boxScores, err
+}
+
+// GetScoreboard returns the cached NFL scoreboard for a week, kept briefly
+// while games are being played
+func (c *CachedClient) GetScoreboard(ctx context.Context, week int) ([]NFLGame, error) {
+	var games []NFLGame
+	err := c.fetchWithTTL(ctx, c.key("scoreboard", week), &games, func() (time.Duration, error) {
+		var err error
+		games, err = c.client.GetScoreboard(ctx, week)
+		return c.liveTTL(games), err
+	})
+	return games, err
+}
+
+// GetNFLTeams returns the cached NFL team list, which changes about once a
+// season
+func (c *CachedClient) GetNFLTeams(ctx context.Context) ([]NFLTeam, error) {
+	var teams []NFLTeam
+	err := c.fetch(ctx, c.key("nfl_teams"), c.ttls.NFLTeams, &teams, func() (err error) {
+		teams, err = c.client.GetNFLTeams(ctx)
+		return err
+	})
+	return teams, err
+}
+
+// GetLiveScoring returns cached live scoring for a week, kept briefly while
+// games are being played
+func (c *CachedClient) GetLiveScoring(ctx context.Context, leagueID string, week int) (*LiveScoring, error) {
+	var scoring *LiveScoring
+	err := c.fetchWithTTL(ctx, c.key("live", leagueID, week), &scoring, func() (time.Duration, error) {
+		var err error
+		scoring, err = c.client.GetLiveScoring(ctx, leagueID, week)
+		if err != nil {
+			return 0, err
+		}
+		if scoring.Live {
+			return c.ttls.LiveScoring, nil
+		}
+		return c.ttls.LiveIdle, nil
+	})
+	return scoring, err
+}
+
+// liveTTL picks the live TTL while any of games is in progress
+func (c *CachedClient) liveTTL(games []NFLGame) time.Duration {
+	for _, g := range games {
+		if g.State == GameStateIn {
+			return c.ttls.LiveScoring
+		}
+	}
+	return c.ttls.LiveIdle
+}
+
+// GetDraftResults returns cached draft picks
+func (c *CachedClient) GetDraftResults(ctx context.Context, leagueID string) ([]DraftPick, error) {
+	var picks []DraftPick
+	err := c.fetch(ctx, c.key("draft", leagueID), c.ttls.DraftResults, &picks, func() (err error) {
+		picks, err = c.client.GetDraftResults(ctx, leagueID)
+		return err
+	})
+	return picks, err
+}
+
+// GetSeasonHistory returns a cached past season
+func (c *CachedClient) GetSeasonHistory(ctx context.Context, leagueID string, season int) (*SeasonHistory, error) {
+	var history *SeasonHistory
+	err := c.fetch(ctx, c.key("history", leagueID, season), c.ttls.History, &history, func() (err error) {
+		history, err = c.client.GetSeasonHistory(ctx, leagueID, season)
+		return err
+	})
+	return history, err
+}
+
+// GetAuctionValues returns cached auction values
+func (c *CachedClient) GetAuctionValues(ctx context.Context, leagueID string, season, limit int) ([]AuctionValue, error) {
+	var values []AuctionValue
+	err := c.fetch(ctx, c.key("auction", leagueID, season, limit), c.ttls.AuctionValues, &values, func() (err error) {
+		values, err = c.client.GetAuctionValues(ctx, leagueID, season, limit)
+		return err
+	})
+	return values, err
+}
+
+// GetKeeperCosts returns cached keeper costs
+func (c *CachedClient) GetKeeperCosts(ctx context.Context, leagueID string, season int) ([]KeeperCost, error) {
+	var costs []KeeperCost
+	err := c.fetch(ctx, c.key("keepers", leagueID, season), c.ttls.KeeperCosts, &costs, func() (err error) {
+		costs, err = c.client.GetKeeperCosts(ctx, leagueID, season)
+		return err
+	})
+	return costs, err
+}
+
+// DetectScoringFormat passes through to the wrapped client
+func (c *CachedClient) DetectScoringFormat(settings LeagueSettings) string {
+	return c.client.DetectScoringFormat(settings)
+}
+
+// GetSeasonStatus returns the cached season status
+func (c *CachedClient) GetSeasonStatus(ctx context.Context) (*SeasonStatus, error) {
+	var status *SeasonStatus
+	err := c.fetch(ctx, c.key("season_status"), c.ttls.SeasonStatus, &status, func() (err error) {
+		status, err = c.client.GetSeasonStatus(ctx)
+		return err
+	})
+	return status, err
+}
+
+// GetInjuryReport returns the cached injury report
+func (c *CachedClient) GetInjuryReport(ctx context.Context, season int) ([]InjuryReport, error) {
+	var report []InjuryReport
+	err := c.fetch(ctx, c.key("injuries", season), c.ttls.InjuryReport, &report, func() (err error) {
+		report, err = c.client.GetInjuryReport(ctx, season)
+		return err
+	})
+	return report, err
+}
+
+// GetPlayerNews returns cached news for a player
+func (c *CachedClient) GetPlayerNews(ctx context.Context, playerID string, limit int) ([]PlayerNews, error) {
+	var news []PlayerNews
+	err := c.fetch(ctx, c.key("news", playerID, limit), c.ttls.PlayerNews, &news, func() (err error) {
+		news, err = c.client.GetPlayerNews(ctx, playerID, limit)
+		return err
+	})
+	return news, err
+}
+
+// GetPlayerProjections returns cached projections
+func (c *CachedClient) GetPlayerProjections(ctx context.Context, season, week int) ([]Player, error) {
+	var players []Player
+	err := c.fetch(ctx, c.key("projections", season, week), c.ttls.Projections, &players, func() (err error) {
+		players, err = c.client.GetPlayerProjections(ctx, season, week)
+		return err
+	})
+	return players, err
+}
+
+// GetTrendingPlayers returns cached trending players
+func (c *CachedClient) GetTrendingPlayers(ctx context.Context, season int, direction, position string, limit int) ([]TrendingPlayer, error) {
+	var players []TrendingPlayer
+	err := c.fetch(ctx, c.key("trending", season, direction, position, limit), c.ttls.Trending, &players, func() (err error) {
+		players, err = c.client.GetTrendingPlayers(ctx, season, direction, position, limit)
+		return err
+	})
+	return players, err
+}
+
+// GetDraftRankings returns cached draft rankings
+func (c *CachedClient) GetDraftRankings(ctx context.Context, season int, rankType string, limit int) ([]DraftRank, error) {
+	var ranks []DraftRank
+	err := c.fetch(ctx, c.key("draftranks", season, rankType, limit), c.ttls.DraftRanks, &ranks, func() (err error) {
+		ranks, err = c.client.GetDraftRankings(ctx, season, rankType, limit)
+		return err
+	})
+	return ranks, err
+}
+
+// WithAuthentication returns a cached client for the given cookies, with its
+// own key scope
+func (c *CachedClient) WithAuthentication(swid, espnS2 string) Client {
+	sum := sha256.Sum256([]byte(swid + "|" + espnS2))
+	return &CachedClient{
+		client: c.client.WithAuthentication(swid, espnS2),
+		cache:  c.cache,
+		ttls:   c.ttls,
+		scope:  hex.EncodeToString(sum[:8]),
+	}
+}
+
+// key builds a cache key from the view name and its arguments
+func (c *CachedClient) key(view string, args ...interface{}) string {
+	key := fmt.Sprintf("espn:%s:%s", c.scope, view)
+	for _, arg := range args {
+		key += fmt.Sprintf(":%v", arg)
+	}
+	return key
+}
+
+// fetch serves dest from the cache, or calls load and caches the result
+func (c *CachedClient) fetch(ctx context.Context, key string, ttl time.Duration, dest interface{}, load func() error) error {
+	if ttl <= 0 || !c.cache.Enabled() {
+		return load()
+	}
+
+	if c.cache.GetJSON(ctx, key, dest) {
+		return nil
+	}
+
+	if err := load(); err != nil {
+		return err
+	}
+
+	if err := c.cache.SetJSON(ctx, key, dest, ttl); err != nil {
+		log.Printf("Failed to cache ESPN response %s: %v", key, err)
+	}
+
+	return nil
+}
+
+// fetchWithTTL is fetch for responses whose TTL depends on what was loaded
+func (c *CachedClient) fetchWithTTL(ctx context.Context, key string, dest interface{}, load func() (time.Duration, error)) error {
+	if !c.cache.Enabled() {
+		_, err := load()
+		return err
+	}
+
+	if c.cache.GetJSON(ctx, key, dest) {
+		return nil
+	}
+
+	ttl, err := load()
+	if err != nil {
+		return err
+	}
+
+	if ttl > 0 {
+		if err := c.cache.SetJSON(ctx, key, dest, ttl); err != nil {
+			log.Printf("Failed to cache ESPN response %s: %v", key, err)
+		}
+	}
+
+	return nil
+}
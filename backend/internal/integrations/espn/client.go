@@ -3,39 +3,83 @@ package espn
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
 const (
 	baseURL = "https://fantasy.espn.com/apis/v3/games/ffl"
+	newsURL = "https://site.api.espn.com/apis/fantasy/v2/games/ffl/news/players"
+	// scoreboardURL is ESPN's NFL scoreboard, which has every game's status
+	scoreboardURL = "https://site.api.espn.com/apis/site/v2/sports/football/nfl/scoreboard"
+	// teamsURL lists every NFL team with its names, colors and logos
+	teamsURL = "https://site.api.espn.com/apis/site/v2/sports/football/nfl/teams"
 	userAgent = "Mozilla/5.0 (compatible; NFLAnalytics/1.0)"
-	maxRetries = 3
-	retryDelay = time.Second
+	// freeAgentPageSize is how many players ESPN returns per free agent page
+	freeAgentPageSize = 50
+	// maxFreeAgentPages stops paging if ESPN keeps returning full pages
+	maxFreeAgentPages = 40
+)
+
+var (
+	// ErrLeagueNotFound is returned when ESPN has no league with the requested ID
+	ErrLeagueNotFound = errors.New("league not found")
+	// ErrUnauthorized is returned when a private league rejects the supplied cookies
+	ErrUnauthorized = errors.New("unauthorized - private league requires authentication")
+	// ErrCookiesExpired is returned when ESPN rejects the SWID and espn_s2
+	// cookies themselves, usually because espn_s2 expired. It wraps
+	// ErrUnauthorized.
+	ErrCookiesExpired = fmt.Errorf("%w: espn_s2 cookie invalid or expired", ErrUnauthorized)
+	// ErrLeagueNotVisible is returned when the cookies are accepted but the
+	// account is not a member of the private league. It wraps ErrUnauthorized.
+	ErrLeagueNotVisible = fmt.Errorf("%w: league not visible to this ESPN account", ErrUnauthorized)
+	// ErrRegionBlocked is returned when ESPN's edge refuses the request
+	// outright, as it does for some countries and cloud networks
+	ErrRegionBlocked = errors.New("forbidden - ESPN is blocking requests from this region or network")
+
+	errParseResponse = errors.New("failed to parse response")
 )
 
 // ESPNClient handles communication with ESPN Fantasy API
 type ESPNClient struct {
 	httpClient *http.Client
 	baseURL    string
+	newsURL    string
+	scoreboardURL string
+	teamsURL   string
 	rateLimiter *rateLimiter
+	breaker    *circuitBreaker
 	mu         sync.RWMutex
 	swid       string // ESPN SWID cookie for authentication
 	espnS2     string // ESPN S2 cookie for authentication
 	cookies    []*http.Cookie
+	// observer is told about every request made for a league
+	observer RequestObserver
 }
 
-// rateLimiter prevents hitting API rate limits
-type rateLimiter struct {
-	mu           sync.Mutex
-	lastRequest  time.Time
-	minInterval  time.Duration
-	requestCount int
-	resetTime    time.Time
+// RequestObserver is called once per HTTP request sent for a league,
+// retries included, with the ESPN league ID
+type RequestObserver func(ctx context.Context, leagueID string)
+
+// leagueIDPattern finds the league ID in a league endpoint URL
+var leagueIDPattern = regexp.MustCompile(`/leagues/(\d+)`)
+
+// observe reports a request to the observer if it is for a league
+func (c *ESPNClient) observe(ctx context.Context, endpoint string) {
+	if c.observer == nil {
+		return
+	}
+	if m := leagueIDPattern.FindStringSubmatch(endpoint); m != nil {
+		c.observer(ctx, m[1])
+	}
 }
 
 // NewESPNClient creates a new ESPN API client
@@ -45,13 +89,20 @@ func NewESPNClient() *ESPNClient {
 			Timeout: 30 * time.Second,
 		},
 		baseURL: baseURL,
-		rateLimiter: &rateLimiter{
-			minInterval: 100 * time.Millisecond, // 10 requests per second max
-			resetTime:   time.Now().Add(time.Minute),
-		},
+		newsURL: newsURL,
+		scoreboardURL: scoreboardURL,
+		teamsURL: teamsURL,
+		rateLimiter: newRateLimiter(rateLimitInterval, rateLimitBurst),
+		breaker: newCircuitBreaker(breakerThreshold, breakerCooldown),
 	}
 }
 
+// SetRequestObserver has every league request reported to fn, for per-league
+// request accounting. Set it before the client is shared.
+func (c *ESPNClient) SetRequestObserver(fn RequestObserver) {
+	c.observer = fn
+}
+
 // SetAuthentication sets ESPN authentication cookies for private leagues
 func (c *ESPNClient) SetAuthentication(swid, espnS2 string) {
 	c.mu.Lock()
@@ -76,6 +127,25 @@ func (c *ESPNClient) SetAuthentication(swid, espnS2 string) {
 	}
 }
 
+// WithAuthentication returns a client that sends the given cookies on every
+// request. The copy shares the HTTP client, rate limiter and circuit breaker
+// with c, so it is safe to create one per request without affecting other
+// callers.
+func (c *ESPNClient) WithAuthentication(swid, espnS2 string) Client {
+	authed := &ESPNClient{
+		httpClient:  c.httpClient,
+		baseURL:     c.baseURL,
+		newsURL:     c.newsURL,
+		scoreboardURL: c.scoreboardURL,
+		teamsURL:    c.teamsURL,
+		rateLimiter: c.rateLimiter,
+		breaker:     c.breaker,
+		observer:    c.observer,
+	}
+	authed.SetAuthentication(swid, espnS2)
+	return authed
+}
+
 // SetCookies sets authentication cookies for private leagues (deprecated - use SetAuthentication)
 func (c *ESPNClient) SetCookies(cookies []*http.Cookie) {
 	c.mu.Lock()
@@ -121,27 +191,40 @@ func (c *ESPNClient) GetRosters(ctx context.Context, leagueID string) ([]Roster,
 	return rosters, nil
 }
 
-// GetAvailablePlayers fetches free agents and waiver wire players
-func (c *ESPNClient) GetAvailablePlayers(ctx context.Context, leagueID string) ([]Player, error) {
-	endpoint := fmt.Sprintf("%s/seasons/2023/segments/0/leagues/%s/players?view=kona_player_info", c.baseURL, leagueID)
-	
-	params := url.Values{}
-	params.Add("scoringPeriodId", "0")
-	params.Add("filterStatus", `{"value":"FREEAGENT"}`)
-	params.Add("filterSlotIds", `{"value":[0,2,4,6,23,16,17]}`)
-	params.Add("limit", "200")
-	
-	fullURL := fmt.Sprintf("%s&%s", endpoint, params.Encode())
-	
-	var response struct {
-		Players []Player `json:"players"`
-	}
-	
-	if err := c.makeRequest(ctx, "GET", fullURL, nil, &response); err != nil {
-		return nil, fmt.Errorf("failed to get available players: %w", err)
+// GetAvailablePlayers fetches free agents and waiver wire players, most owned
+// first. ESPN serves the list in pages, so this keeps requesting pages until
+// ESPN runs out of players or maxPlayers is reached; maxPlayers <= 0 fetches
+// them all.
+func (c *ESPNClient) GetAvailablePlayers(ctx context.Context, leagueID string, maxPlayers int) ([]Player, error) {
+	endpoint := fmt.Sprintf("%s/seasons/2023/segments/0/leagues/%s/players?view=kona_player_info&scoringPeriodId=0", c.baseURL, leagueID)
+
+	var players []Player
+	for page := 0; page < maxFreeAgentPages; page++ {
+		limit := freeAgentPageSize
+		if maxPlayers > 0 && maxPlayers-len(players) < limit {
+			limit = maxPlayers - len(players)
+		}
+
+		filter, err := freeAgentFilter(len(players), limit)
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Players []Player `json:"players"`
+		}
+		headers := map[string]string{"X-Fantasy-Filter": filter}
+		if err := c.makeRequestWithHeaders(ctx, "GET", endpoint, nil, headers, &response); err != nil {
+			return nil, fmt.Errorf("failed to get available players: %w", err)
+		}
+
+		players = append(players, response.Players...)
+		if len(response.Players) < limit || (maxPlayers > 0 && len(players) >= maxPlayers) {
+			break
+		}
 	}
-	
-	return response.Players, nil
+
+	return players, nil
 }
 
 // GetMatchups fetches matchups for a specific week
@@ -181,6 +264,69 @@ func (c *ESPNClient) GetTransactions(ctx context.Context, leagueID string, limit
 	return transactions, nil
 }
 
+// GetWaiverClaims fetches the league's waiver claims. ESPN only exposes
+// pending claims to members, so private leagues need authentication.
+func (c *ESPNClient) GetWaiverClaims(ctx context.Context, leagueID string) ([]WaiverClaim, error) {
+	endpoint := fmt.Sprintf("%s/seasons/2023/segments/0/leagues/%s?view=mPendingTransactions&view=mTeam", c.baseURL, leagueID)
+
+	var response struct {
+		Teams []struct {
+			ID         int `json:"id"`
+			WaiverRank int `json:"waiverRank"`
+		} `json:"teams"`
+		Transactions []struct {
+			ID          string `json:"id"`
+			TeamID      int    `json:"teamId"`
+			Type        string `json:"type"`
+			Status      string `json:"status"`
+			BidAmount   int    `json:"bidAmount"`
+			ProcessDate int64  `json:"processDate"` // epoch milliseconds
+			Items       []struct {
+				PlayerID int    `json:"playerId"`
+				Type     string `json:"type"` // ADD, DROP
+			} `json:"items"`
+		} `json:"transactions"`
+	}
+
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get waiver claims: %w", err)
+	}
+
+	waiverRank := make(map[int]int, len(response.Teams))
+	for _, team := range response.Teams {
+		waiverRank[team.ID] = team.WaiverRank
+	}
+
+	var claims []WaiverClaim
+	for _, t := range response.Transactions {
+		if t.Type != "WAIVER" {
+			continue
+		}
+
+		claim := WaiverClaim{
+			ID:        t.ID,
+			TeamID:    t.TeamID,
+			BidAmount: t.BidAmount,
+			Priority:  waiverRank[t.TeamID],
+			Status:    t.Status,
+		}
+		if t.ProcessDate > 0 {
+			claim.ProcessDate = time.UnixMilli(t.ProcessDate)
+		}
+		for _, item := range t.Items {
+			switch item.Type {
+			case "ADD":
+				claim.PlayerAdd = strconv.Itoa(item.PlayerID)
+			case "DROP":
+				claim.PlayerDrop = strconv.Itoa(item.PlayerID)
+			}
+		}
+		claims = append(claims, claim)
+	}
+
+	return claims, nil
+}
+
 // GetDraftResults fetches draft results
 func (c *ESPNClient) GetDraftResults(ctx context.Context, leagueID string) ([]DraftPick, error) {
 	endpoint := fmt.Sprintf("%s/seasons/2023/segments/0/leagues/%s/draft", c.baseURL, leagueID)
@@ -210,73 +356,125 @@ func (c *ESPNClient) DetectScoringFormat(settings LeagueSettings) string {
 
 // makeRequest handles HTTP requests with rate limiting and retries
 func (c *ESPNClient) makeRequest(ctx context.Context, method, url string, body io.Reader, result interface{}) error {
-	// Apply rate limiting
-	if err := c.rateLimiter.wait(); err != nil {
+	return c.makeRequestWithHeaders(ctx, method, url, body, nil, result)
+}
+
+// makeRequestWithHeaders makes a request with extra headers, such as the
+// X-Fantasy-Filter ESPN uses to filter and page player lists. Requests fail
+// fast with ErrCircuitOpen while ESPN is down.
+func (c *ESPNClient) makeRequestWithHeaders(ctx context.Context, method, url string, body io.Reader, headers map[string]string, result interface{}) error {
+	if err := c.breaker.allow(); err != nil {
 		return err
 	}
-	
+
+	err := c.doRequest(ctx, method, url, body, headers, result)
+	switch {
+	case err == nil, errors.Is(err, ErrLeagueNotFound), errors.Is(err, ErrUnauthorized), errors.Is(err, ErrRegionBlocked), errors.Is(err, errParseResponse):
+		// ESPN answered, even if not with what we wanted
+		c.breaker.success()
+	case ctx.Err() != nil:
+		c.breaker.cancel()
+	default:
+		c.breaker.failure()
+	}
+
+	return err
+}
+
+// doRequest sends a request, retrying transient failures with exponential
+// backoff. A Retry-After from ESPN is honored, and the request gives up once
+// the waits would exceed the retry budget. Every attempt takes a rate limit
+// token.
+func (c *ESPNClient) doRequest(ctx context.Context, method, url string, body io.Reader, headers map[string]string, result interface{}) error {
 	var lastErr error
+	var retryAfter, waited time.Duration
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		if attempt > 0 {
+			delay := retryDelay(attempt, retryAfter)
+			if waited+delay > retryBudget {
+				return fmt.Errorf("retry budget exhausted after %d attempts (next retry in %s): %w", attempt, delay.Round(time.Second), lastErr)
+			}
+			waited += delay
+
+			timer := time.NewTimer(delay)
 			select {
 			case <-ctx.Done():
+				timer.Stop()
 				return ctx.Err()
-			case <-time.After(retryDelay * time.Duration(attempt)):
+			case <-timer.C:
 			}
 		}
-		
-		req, err := http.NewRequestWithContext(ctx, method, url, body)
-		if err != nil {
+
+		if err := c.rateLimiter.wait(ctx); err != nil {
 			return err
 		}
-		
-		req.Header.Set("User-Agent", userAgent)
-		req.Header.Set("Accept", "application/json")
-		
-		// Add cookies for private leagues
-		c.mu.RLock()
-		for _, cookie := range c.cookies {
-			req.AddCookie(cookie)
-		}
-		c.mu.RUnlock()
-		
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			lastErr = err
-			continue
+
+		var retry bool
+		retryAfter, retry, lastErr = c.attempt(ctx, method, url, body, headers, result)
+		if lastErr == nil || !retry {
+			return lastErr
 		}
-		defer resp.Body.Close()
-		
-		// Handle HTTP errors
-		if resp.StatusCode != http.StatusOK {
-			lastErr = c.handleHTTPError(resp)
-			if resp.StatusCode == http.StatusTooManyRequests {
-				// Back off on rate limiting
-				c.rateLimiter.backoff()
-			}
-			continue
+	}
+
+	return fmt.Errorf("request failed after %d attempts: %w", maxRetries, lastErr)
+}
+
+// attempt sends the request once. retry reports whether the failure is worth
+// retrying, and retryAfter is how long ESPN asked us to wait first.
+func (c *ESPNClient) attempt(ctx context.Context, method, url string, body io.Reader, headers map[string]string, result interface{}) (retryAfter time.Duration, retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return 0, false, err
+	}
+	c.observe(ctx, url)
+
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	// Add cookies for private leagues
+	c.mu.RLock()
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+	c.mu.RUnlock()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := c.handleHTTPError(resp)
+		// Retrying won't fix a missing league, rejected credentials or a block
+		if errors.Is(err, ErrLeagueNotFound) || errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrRegionBlocked) {
+			return 0, false, err
 		}
-		
-		// Parse response
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-			return fmt.Errorf("failed to parse response: %w", err)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			// Slow every request down, not just this one
+			c.rateLimiter.backoff()
 		}
-		
-		return nil
+		return parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()), true, err
 	}
-	
-	return fmt.Errorf("request failed after %d attempts: %w", maxRetries, lastErr)
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return 0, false, fmt.Errorf("%w: %v", errParseResponse, err)
+	}
+	return 0, false, nil
 }
 
 // handleHTTPError processes HTTP error responses
 func (c *ESPNClient) handleHTTPError(resp *http.Response) error {
 	switch resp.StatusCode {
 	case http.StatusNotFound:
-		return fmt.Errorf("league not found")
-	case http.StatusUnauthorized:
-		return fmt.Errorf("unauthorized - private league requires authentication")
+		return ErrLeagueNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return authError(resp)
 	case http.StatusTooManyRequests:
-		return fmt.Errorf("rate limited - too many requests")
+		return ErrRateLimited
 	case http.StatusServiceUnavailable:
 		return fmt.Errorf("service unavailable - ESPN API is down")
 	default:
@@ -285,48 +483,92 @@ func (c *ESPNClient) handleHTTPError(resp *http.Response) error {
 	}
 }
 
-// wait implements rate limiting
-func (r *rateLimiter) wait() error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	
-	now := time.Now()
-	
-	// Reset counter if window expired
-	if now.After(r.resetTime) {
-		r.requestCount = 0
-		r.resetTime = now.Add(time.Minute)
+// espnErrorBody is the JSON ESPN sends with auth failures, e.g.
+// {"details":[{"message":"You are not authorized to view this League.","type":"AUTH_LEAGUE_NOT_VISIBLE"}]}
+type espnErrorBody struct {
+	Details []struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"details"`
+}
+
+// authError tells apart the reasons ESPN refuses a request. A 403 without
+// ESPN's JSON error body comes from the CDN in front of the API rather than
+// the API itself, which only happens for blocked regions and networks. ESPN
+// answers a private league with AUTH_LEAGUE_NOT_VISIBLE whether or not
+// cookies were sent, so the cookies on the request decide between a league
+// needing authentication and an account that is not a member; other
+// rejections of sent cookies mean the cookies are no longer valid.
+func authError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	var body espnErrorBody
+	isJSON := json.Unmarshal(data, &body) == nil
+	if resp.StatusCode == http.StatusForbidden && !isJSON {
+		return ErrRegionBlocked
 	}
-	
-	// Check if we've hit the limit
-	if r.requestCount >= 100 { // 100 requests per minute
-		sleepTime := r.resetTime.Sub(now)
-		if sleepTime > 0 {
-			time.Sleep(sleepTime)
-		}
-		r.requestCount = 0
-		r.resetTime = time.Now().Add(time.Minute)
+
+	sentCookies := resp.Request != nil && len(resp.Request.Cookies()) > 0
+	if !sentCookies {
+		return ErrUnauthorized
 	}
-	
-	// Enforce minimum interval between requests
-	timeSinceLastRequest := now.Sub(r.lastRequest)
-	if timeSinceLastRequest < r.minInterval {
-		time.Sleep(r.minInterval - timeSinceLastRequest)
+	for _, d := range body.Details {
+		if strings.Contains(d.Type, "NOT_VISIBLE") {
+			return ErrLeagueNotVisible
+		}
 	}
-	
-	r.lastRequest = time.Now()
-	r.requestCount++
-	
-	return nil
+	return ErrCookiesExpired
 }
 
-// backoff increases the rate limit interval
-func (r *rateLimiter) backoff() {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	
-	r.minInterval = r.minInterval * 2
-	if r.minInterval > 5*time.Second {
-		r.minInterval = 5 * time.Second
+// playerFilter is the X-Fantasy-Filter header ESPN uses to filter, sort and
+// page player lists
+type playerFilter struct {
+	Players playerFilterOptions `json:"players"`
+}
+
+type playerFilterOptions struct {
+	FilterStatus    *filterValue    `json:"filterStatus,omitempty"`
+	FilterSlotIDs   filterValue     `json:"filterSlotIds"`
+	Limit           int             `json:"limit"`
+	Offset          int             `json:"offset"`
+	SortPercOwned   *filterOrder    `json:"sortPercOwned,omitempty"`
+	SortPercChanged *filterOrder    `json:"sortPercChanged,omitempty"`
+	SortDraftRanks  *draftRankOrder `json:"sortDraftRanks,omitempty"`
+}
+
+type filterValue struct {
+	Value interface{} `json:"value"`
+}
+
+type filterOrder struct {
+	SortPriority int  `json:"sortPriority"`
+	SortAsc      bool `json:"sortAsc"`
+}
+
+// draftRankOrder sorts by ESPN's draft ranks for a rank type such as STANDARD or PPR
+type draftRankOrder struct {
+	SortPriority int    `json:"sortPriority"`
+	SortAsc      bool   `json:"sortAsc"`
+	Value        string `json:"value"`
+}
+
+// freeAgentFilter builds the filter for one page of unrostered players at
+// the QB, RB, WR, TE, FLEX, DST and K slots
+func freeAgentFilter(offset, limit int) (string, error) {
+	filter := playerFilter{
+		Players: playerFilterOptions{
+			FilterStatus:  &filterValue{Value: []string{"FREEAGENT", "WAIVERS"}},
+			FilterSlotIDs: filterValue{Value: []int{0, 2, 4, 6, 23, 16, 17}},
+			Limit:         limit,
+			Offset:        offset,
+			SortPercOwned: &filterOrder{SortPriority: 1, SortAsc: false},
+		},
 	}
-}
\ No newline at end of file
+
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return "", fmt.Errorf("failed to build player filter: %w", err)
+	}
+
+	return string(data), nil
+}
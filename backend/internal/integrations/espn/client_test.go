@@ -3,12 +3,14 @@ package espn
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewESPNClient(t *testing.T) {
@@ -71,10 +73,7 @@ func TestGetLeagueInfo(t *testing.T) {
 	client := &ESPNClient{
 		httpClient: http.DefaultClient,
 		baseURL:    server.URL,
-		rateLimiter: &rateLimiter{
-			minInterval: 100 * time.Millisecond,
-			resetTime:   time.Now().Add(time.Minute),
-		},
+		rateLimiter: newRateLimiter(100 * time.Millisecond, rateLimitBurst),
 	}
 
 	ctx := context.Background()
@@ -133,10 +132,7 @@ func TestGetRosters(t *testing.T) {
 	client := &ESPNClient{
 		httpClient: http.DefaultClient,
 		baseURL:    server.URL,
-		rateLimiter: &rateLimiter{
-			minInterval: 100 * time.Millisecond,
-			resetTime:   time.Now().Add(time.Minute),
-		},
+		rateLimiter: newRateLimiter(100 * time.Millisecond, rateLimitBurst),
 	}
 
 	ctx := context.Background()
@@ -149,50 +145,84 @@ func TestGetRosters(t *testing.T) {
 }
 
 func TestGetAvailablePlayers(t *testing.T) {
+	pool := make([]Player, 120)
+	for i := range pool {
+		pool[i] = Player{ID: fmt.Sprintf("player%03d", i), Name: fmt.Sprintf("Available Player %d", i), Position: "RB", PercentOwned: 45.5}
+	}
+
+	var requests int
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		response := []Player{
-			{
-				ID:         "player001",
-				Name:       "Available Player 1",
-				Position:   "RB",
-				Team:       "DAL",
-				Status:     "ACTIVE",
-				PercentOwned: 45.5,
-				AverageDraftPosition: 65.2,
-			},
-			{
-				ID:         "player002",
-				Name:       "Available Player 2",
-				Position:   "WR",
-				Team:       "MIN",
-				Status:     "ACTIVE",
-				PercentOwned: 32.1,
-				AverageDraftPosition: 88.7,
-			},
+		requests++
+		var filter playerFilter
+		if err := json.Unmarshal([]byte(r.Header.Get("X-Fantasy-Filter")), &filter); err != nil {
+			t.Fatalf("invalid X-Fantasy-Filter header: %v", err)
 		}
-		
+		assert.Equal(t, []interface{}{"FREEAGENT", "WAIVERS"}, filter.Players.FilterStatus.Value)
+
+		start := filter.Players.Offset
+		end := start + filter.Players.Limit
+		if start > len(pool) {
+			start = len(pool)
+		}
+		if end > len(pool) {
+			end = len(pool)
+		}
+
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(response)
+		json.NewEncoder(w).Encode(map[string][]Player{"players": pool[start:end]})
 	}))
 	defer server.Close()
 
 	client := &ESPNClient{
 		httpClient: http.DefaultClient,
 		baseURL:    server.URL,
-		rateLimiter: &rateLimiter{
-			minInterval: 100 * time.Millisecond,
-			resetTime:   time.Now().Add(time.Minute),
-		},
+		rateLimiter: newRateLimiter(time.Millisecond, rateLimitBurst),
 	}
 
 	ctx := context.Background()
-	players, err := client.GetAvailablePlayers(ctx, "123456")
-	
+
+	players, err := client.GetAvailablePlayers(ctx, "123456", 0)
 	assert.NoError(t, err)
-	assert.Len(t, players, 2)
-	assert.Equal(t, "Available Player 1", players[0].Name)
-	assert.Equal(t, "RB", players[0].Position)
+	assert.Len(t, players, 120)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, "Available Player 0", players[0].Name)
+	assert.Equal(t, "player119", players[119].ID)
 	assert.Equal(t, 45.5, players[0].PercentOwned)
+
+	requests = 0
+	players, err = client.GetAvailablePlayers(ctx, "123456", 60)
+	assert.NoError(t, err)
+	assert.Len(t, players, 60)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, "player059", players[59].ID)
+}
+
+func TestPlayerDecodesESPNIDs(t *testing.T) {
+	var players []Player
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"id": 4262921, "onTeamId": 0, "player": {"id": 4262921, "fullName": "Justin Jefferson", "defaultPositionId": 3, "proTeamId": 16, "jersey": "18",
+			"stats": [{"seasonId": 2024, "statSourceId": 0}]}},
+		{"id": 2577327, "fullName": "Free Agent Kicker", "defaultPositionId": 5, "proTeamId": 0},
+		{"id": "player100", "fullName": "Cached RB", "defaultPositionId": "RB", "proTeamId": "DAL", "percentOwned": 45.2}
+	]`), &players))
+	require.Len(t, players, 3)
+
+	assert.Equal(t, "4262921", players[0].ID)
+	assert.Equal(t, "Justin Jefferson", players[0].Name)
+	assert.Equal(t, "WR", players[0].Position)
+	assert.Equal(t, "MIN", players[0].Team)
+	assert.Equal(t, 18, players[0].Jersey)
+
+	assert.Equal(t, "K", players[1].Position)
+	assert.Equal(t, "FA", players[1].Team)
+
+	// Players read back from the cache keep their names
+	assert.Equal(t, "RB", players[2].Position)
+	assert.Equal(t, "DAL", players[2].Team)
+	assert.Equal(t, 45.2, players[2].PercentOwned)
+
+	assert.Equal(t, "DST", PositionName(16))
+	assert.Empty(t, TeamAbbreviation(99))
 }
 
 func TestDetectScoringFormat(t *testing.T) {
@@ -289,10 +319,8 @@ func TestHandleHTTPError(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := &ESPNClient{
-				httpClient: http.DefaultClient,
-				baseURL:    server.URL,
-			}
+			client := NewESPNClient()
+			client.baseURL = server.URL
 
 			ctx := context.Background()
 			_, err := client.GetLeagueInfo(ctx, "123456")
@@ -307,6 +335,109 @@ func TestHandleHTTPError(t *testing.T) {
 	}
 }
 
+func TestAuthErrors(t *testing.T) {
+	notVisible := `{"details":[{"message":"You are not authorized to view this League.","type":"AUTH_LEAGUE_NOT_VISIBLE"}]}`
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		cookies    bool
+		want       error
+	}{
+		{"private league without cookies", 401, notVisible, false, ErrUnauthorized},
+		{"account not in league", 401, notVisible, true, ErrLeagueNotVisible},
+		{"expired cookies", 401, `{"details":[{"type":"AUTH_INVALID_TOKEN"}]}`, true, ErrCookiesExpired},
+		{"blocked region", 403, "<html><body>Access Denied</body></html>", true, ErrRegionBlocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			base := NewESPNClient()
+			base.baseURL = server.URL
+			var client Client = base
+			if tt.cookies {
+				client = base.WithAuthentication("swid", "s2")
+			}
+
+			_, err := client.GetLeagueInfo(context.Background(), "123456")
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, 1, requests, "auth failures are not retried")
+		})
+	}
+
+	// Both cookie errors are still unauthorized errors
+	assert.ErrorIs(t, ErrCookiesExpired, ErrUnauthorized)
+	assert.ErrorIs(t, ErrLeagueNotVisible, ErrUnauthorized)
+}
+
+func TestWithAuthentication(t *testing.T) {
+	var gotSWID, gotS2 string
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if cookie, err := r.Cookie("SWID"); err == nil {
+			gotSWID = cookie.Value
+		}
+		if cookie, err := r.Cookie("espn_s2"); err == nil {
+			gotS2 = cookie.Value
+		}
+		if gotS2 != "valid-s2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(LeagueInfo{ID: "123456"})
+	}))
+	defer server.Close()
+
+	base := NewESPNClient()
+	base.baseURL = server.URL
+	ctx := context.Background()
+
+	// Rejected cookies surface ErrUnauthorized without retrying
+	_, err := base.WithAuthentication("swid", "bad-s2").GetLeagueInfo(ctx, "123456")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, 1, requests)
+
+	info, err := base.WithAuthentication("swid", "valid-s2").GetLeagueInfo(ctx, "123456")
+	require.NoError(t, err)
+	assert.Equal(t, "123456", info.ID)
+	assert.Equal(t, "{swid}", gotSWID)
+
+	// The base client is left unauthenticated
+	assert.Empty(t, base.cookies)
+}
+
+func TestRequestObserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(LeagueInfo{ID: "123456"})
+	}))
+	defer server.Close()
+
+	var observed []string
+	base := NewESPNClient()
+	base.baseURL = server.URL
+	base.SetRequestObserver(func(ctx context.Context, leagueID string) {
+		observed = append(observed, leagueID)
+	})
+
+	_, err := base.GetLeagueInfo(context.Background(), "123456")
+	require.NoError(t, err)
+
+	// Clients with user cookies report to the same observer
+	_, err = base.WithAuthentication("swid", "s2").GetLeagueInfo(context.Background(), "654321")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"123456", "654321"}, observed)
+}
+
 func TestRateLimiting(t *testing.T) {
 	requestCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -322,10 +453,7 @@ func TestRateLimiting(t *testing.T) {
 	client := &ESPNClient{
 		httpClient: http.DefaultClient,
 		baseURL:    server.URL,
-		rateLimiter: &rateLimiter{
-			minInterval: 100 * time.Millisecond,
-			resetTime:   time.Now().Add(time.Minute),
-		},
+		rateLimiter: newRateLimiter(100 * time.Millisecond, rateLimitBurst),
 	}
 
 	ctx := context.Background()
@@ -351,10 +479,7 @@ func TestConcurrentRequests(t *testing.T) {
 	client := &ESPNClient{
 		httpClient: http.DefaultClient,
 		baseURL:    server.URL,
-		rateLimiter: &rateLimiter{
-			minInterval: 100 * time.Millisecond,
-			resetTime:   time.Now().Add(time.Minute),
-		},
+		rateLimiter: newRateLimiter(100 * time.Millisecond, rateLimitBurst),
 	}
 
 	ctx := context.Background()
@@ -373,4 +498,150 @@ func TestConcurrentRequests(t *testing.T) {
 	for i := 0; i < 5; i++ {
 		<-done
 	}
-}
\ No newline at end of file
+}
+func TestGetWaiverClaims(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/seasons/2023/segments/0/leagues/123456", r.URL.Path)
+		assert.Equal(t, []string{"mPendingTransactions", "mTeam"}, r.URL.Query()["view"])
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"teams": [{"id": 1, "waiverRank": 4}, {"id": 2, "waiverRank": 1}],
+			"transactions": [
+				{"id": "a1", "teamId": 2, "type": "WAIVER", "status": "PENDING", "bidAmount": 17,
+				 "processDate": 1699434000000,
+				 "items": [{"playerId": 4430807, "type": "ADD"}, {"playerId": 3054850, "type": "DROP"}]},
+				{"id": "b2", "teamId": 1, "type": "FREEAGENT", "status": "EXECUTED",
+				 "items": [{"playerId": 15847, "type": "ADD"}]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	claims, err := newTestClient(server.URL).GetWaiverClaims(context.Background(), "123456")
+	require.NoError(t, err)
+
+	// Free agent pickups are not waiver claims
+	require.Len(t, claims, 1)
+	assert.Equal(t, "a1", claims[0].ID)
+	assert.Equal(t, "4430807", claims[0].PlayerAdd)
+	assert.Equal(t, "3054850", claims[0].PlayerDrop)
+	assert.Equal(t, 17, claims[0].BidAmount)
+	assert.Equal(t, 1, claims[0].Priority)
+	assert.Equal(t, "PENDING", claims[0].Status)
+	assert.Equal(t, int64(1699434000000), claims[0].ProcessDate.UnixMilli())
+}
+
+func TestGetTrendingPlayers(t *testing.T) {
+	var filter playerFilter
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/seasons/2024/")
+		require.NoError(t, json.Unmarshal([]byte(r.Header.Get("X-Fantasy-Filter")), &filter))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"players": [{"player": {
+			"id": 4430807, "fullName": "Bijan Robinson", "defaultPositionId": 2, "proTeamId": 1,
+			"ownership": {"percentOwned": 62.5, "percentChange": 18.25, "percentStarted": 40.1, "percentStartedChange": 12.5}
+		}}]}`))
+	}))
+	defer server.Close()
+
+	client := &ESPNClient{
+		httpClient: http.DefaultClient,
+		baseURL:    server.URL,
+		rateLimiter: newRateLimiter(time.Millisecond, rateLimitBurst),
+	}
+
+	ctx := context.Background()
+
+	players, err := client.GetTrendingPlayers(ctx, 2024, TrendingAdds, "RB", 10)
+	require.NoError(t, err)
+	require.Len(t, players, 1)
+	assert.Equal(t, TrendingPlayer{
+		PlayerID:             "4430807",
+		PlayerName:           "Bijan Robinson",
+		Position:             "RB",
+		Team:                 "ATL",
+		PercentOwned:         62.5,
+		PercentOwnedChange:   18.25,
+		PercentStarted:       40.1,
+		PercentStartedChange: 12.5,
+	}, players[0])
+
+	// Adds sort by the biggest gain, filtered to the requested position
+	assert.Equal(t, 10, filter.Players.Limit)
+	assert.Equal(t, []interface{}{float64(2)}, filter.Players.FilterSlotIDs.Value)
+	require.NotNil(t, filter.Players.SortPercChanged)
+	assert.False(t, filter.Players.SortPercChanged.SortAsc)
+	assert.Nil(t, filter.Players.FilterStatus)
+
+	_, err = client.GetTrendingPlayers(ctx, 2024, TrendingDrops, "", 10)
+	require.NoError(t, err)
+	assert.True(t, filter.Players.SortPercChanged.SortAsc)
+
+	_, err = client.GetTrendingPlayers(ctx, 2024, "sideways", "", 10)
+	assert.Error(t, err)
+}
+
+func TestGetAuctionValuesAndKeeperCosts(t *testing.T) {
+	var filter playerFilter
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/seasons/2024/segments/0/leagues/123456", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Query().Get("view") {
+		case "kona_player_info":
+			require.NoError(t, json.Unmarshal([]byte(r.Header.Get("X-Fantasy-Filter")), &filter))
+			w.Write([]byte(`{"players": [{"onTeamId": 0, "draftAuctionValue": 58, "player": {
+				"id": 4262921, "fullName": "Justin Jefferson", "defaultPositionId": 3, "proTeamId": 16,
+				"ownership": {"auctionValueAverage": 54.2, "auctionValueAverageChange": -1.5, "averageDraftPosition": 3.4}
+			}}]}`))
+		case "mRoster":
+			w.Write([]byte(`{"teams": [{"id": 4, "roster": {"entries": [{"playerPoolEntry": {
+				"keeperValue": 12, "keeperValueFuture": 17, "draftAuctionValue": 40,
+				"player": {"id": 4361307, "fullName": "Puka Nacua", "defaultPositionId": 3, "proTeamId": 14}
+			}}]}}]}`))
+		default:
+			t.Errorf("unexpected view %q", r.URL.Query().Get("view"))
+		}
+	}))
+	defer server.Close()
+
+	client := &ESPNClient{
+		httpClient: http.DefaultClient,
+		baseURL:    server.URL,
+		rateLimiter: newRateLimiter(time.Millisecond, rateLimitBurst),
+	}
+
+	ctx := context.Background()
+
+	values, err := client.GetAuctionValues(ctx, "123456", 2024, 50)
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	assert.Equal(t, AuctionValue{
+		PlayerID:                  "4262921",
+		PlayerName:                "Justin Jefferson",
+		Position:                  "WR",
+		Team:                      "MIN",
+		AuctionValue:              58,
+		AverageAuctionValue:       54.2,
+		AverageAuctionValueChange: -1.5,
+		AverageDraftPosition:      3.4,
+	}, values[0])
+	assert.Equal(t, 50, filter.Players.Limit)
+	require.NotNil(t, filter.Players.SortDraftRanks)
+	assert.True(t, filter.Players.SortDraftRanks.SortAsc)
+
+	costs, err := client.GetKeeperCosts(ctx, "123456", 2024)
+	require.NoError(t, err)
+	require.Len(t, costs, 1)
+	assert.Equal(t, KeeperCost{
+		TeamID:            4,
+		PlayerID:          "4361307",
+		PlayerName:        "Puka Nacua",
+		Position:          "WR",
+		Team:              "LAR",
+		KeeperValue:       12,
+		KeeperValueFuture: 17,
+		AuctionValue:      40,
+	}, costs[0])
+}
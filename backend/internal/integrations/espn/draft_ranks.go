@@ -0,0 +1,88 @@
+package espn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ESPN draft rank types. ESPN publishes no half point PPR ranks.
+const (
+	RankTypeStandard = "STANDARD"
+	RankTypePPR      = "PPR"
+)
+
+// maxDraftRanks caps a draft rankings request
+const maxDraftRanks = 500
+
+// DraftRank is a player's place in ESPN's preseason rankings with their
+// average draft position across ESPN drafts
+type DraftRank struct {
+	PlayerID             string  `json:"playerId"`
+	PlayerName           string  `json:"playerName"`
+	Position             string  `json:"position"`
+	Team                 string  `json:"team"`
+	Rank                 int     `json:"rank"`
+	AverageDraftPosition float64 `json:"averageDraftPosition"`
+}
+
+// GetDraftRankings fetches ESPN's default draft rankings for rankType,
+// RankTypeStandard or RankTypePPR, best first
+func (c *ESPNClient) GetDraftRankings(ctx context.Context, season int, rankType string, limit int) ([]DraftRank, error) {
+	if rankType != RankTypeStandard && rankType != RankTypePPR {
+		return nil, fmt.Errorf("invalid draft rank type %q", rankType)
+	}
+	if limit <= 0 || limit > maxDraftRanks {
+		limit = maxDraftRanks
+	}
+
+	data, err := json.Marshal(playerFilter{
+		Players: playerFilterOptions{
+			FilterSlotIDs:  filterValue{Value: []int{0, 2, 4, 6, 16, 17}},
+			Limit:          limit,
+			SortDraftRanks: &draftRankOrder{SortPriority: 1, SortAsc: true, Value: rankType},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build player filter: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/seasons/%d/segments/0/leaguedefaults/3?view=kona_player_info&scoringPeriodId=0",
+		c.baseURL, season)
+
+	var response struct {
+		Players []struct {
+			Player struct {
+				espnPoolPlayer
+				DraftRanksByRankType map[string]struct {
+					Rank int `json:"rank"`
+				} `json:"draftRanksByRankType"`
+			} `json:"player"`
+		} `json:"players"`
+	}
+
+	headers := map[string]string{"X-Fantasy-Filter": string(data)}
+	if err := c.makeRequestWithHeaders(ctx, "GET", endpoint, nil, headers, &response); err != nil {
+		return nil, fmt.Errorf("failed to get draft rankings: %w", err)
+	}
+
+	ranks := make([]DraftRank, 0, len(response.Players))
+	for _, entry := range response.Players {
+		p := entry.Player
+		rank := p.DraftRanksByRankType[rankType].Rank
+		if rank == 0 {
+			continue
+		}
+		ranks = append(ranks, DraftRank{
+			PlayerID:             strconv.Itoa(p.ID),
+			PlayerName:           p.FullName,
+			Position:             PositionName(p.DefaultPositionID),
+			Team:                 TeamAbbreviation(p.ProTeamID),
+			Rank:                 rank,
+			AverageDraftPosition: p.Ownership.AverageDraftPosition,
+		})
+	}
+
+	return ranks, nil
+}
@@ -0,0 +1,194 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// firstCurrentSeason is the first season ESPN serves from the seasons
+// endpoint; earlier seasons are only available from leagueHistory
+const firstCurrentSeason = 2018
+
+// SeasonHistory is a league's final results for one season
+type SeasonHistory struct {
+	LeagueID   string         `json:"leagueId"`
+	Season     int            `json:"season"`
+	Standings  []TeamStanding `json:"standings"`
+	Matchups   []Matchup      `json:"matchups"`
+	DraftPicks []DraftPick    `json:"draftPicks"`
+}
+
+// TeamStanding is a team's record and finish in a season
+type TeamStanding struct {
+	TeamID        int     `json:"teamId"`
+	TeamName      string  `json:"teamName"`
+	OwnerName     string  `json:"ownerName,omitempty"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Ties          int     `json:"ties"`
+	PointsFor     float64 `json:"pointsFor"`
+	PointsAgainst float64 `json:"pointsAgainst"`
+	PlayoffSeed   int     `json:"playoffSeed"`
+	FinalRank     int     `json:"finalRank"`
+}
+
+// historySide is a team's half of a matchup in the mMatchup view
+type historySide struct {
+	TeamID      int     `json:"teamId"`
+	TotalPoints float64 `json:"totalPoints"`
+}
+
+// historyResponse is the part of the league document the history views fill
+type historyResponse struct {
+	SeasonID int `json:"seasonId"`
+	Members  []struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+	} `json:"members"`
+	Teams []struct {
+		ID           int    `json:"id"`
+		Location     string `json:"location"`
+		Nickname     string `json:"nickname"`
+		Name         string `json:"name"`
+		PrimaryOwner string `json:"primaryOwner"`
+		PlayoffSeed  int    `json:"playoffSeed"`
+		FinalRank    int    `json:"rankCalculatedFinal"`
+		Record       struct {
+			Overall struct {
+				Wins          int     `json:"wins"`
+				Losses        int     `json:"losses"`
+				Ties          int     `json:"ties"`
+				PointsFor     float64 `json:"pointsFor"`
+				PointsAgainst float64 `json:"pointsAgainst"`
+			} `json:"overall"`
+		} `json:"record"`
+	} `json:"teams"`
+	Schedule []struct {
+		ID              int          `json:"id"`
+		MatchupPeriodID int          `json:"matchupPeriodId"`
+		Home            *historySide `json:"home"`
+		Away            *historySide `json:"away"`
+		Winner          string       `json:"winner"`
+		PlayoffTierType string       `json:"playoffTierType"`
+	} `json:"schedule"`
+	DraftDetail struct {
+		Picks []struct {
+			OverallPickNumber int  `json:"overallPickNumber"`
+			RoundID           int  `json:"roundId"`
+			RoundPickNumber   int  `json:"roundPickNumber"`
+			TeamID            int  `json:"teamId"`
+			PlayerID          int  `json:"playerId"`
+			BidAmount         int  `json:"bidAmount"`
+			Keeper            bool `json:"keeper"`
+		} `json:"picks"`
+	} `json:"draftDetail"`
+}
+
+// GetSeasonHistory fetches a past season's final standings, matchups and
+// draft. Seasons before 2018 come from ESPN's league history archive.
+func (c *ESPNClient) GetSeasonHistory(ctx context.Context, leagueID string, season int) (*SeasonHistory, error) {
+	const views = "view=mTeam&view=mMatchup&view=mDraftDetail"
+
+	var response historyResponse
+	if season >= firstCurrentSeason {
+		endpoint := fmt.Sprintf("%s/seasons/%d/segments/0/leagues/%s?%s", c.baseURL, season, leagueID, views)
+		if err := c.makeRequest(ctx, "GET", endpoint, nil, &response); err != nil {
+			return nil, fmt.Errorf("failed to get %d season history: %w", season, err)
+		}
+	} else {
+		// The archive answers with a list holding the one requested season
+		endpoint := fmt.Sprintf("%s/leagueHistory/%s?seasonId=%d&%s", c.baseURL, leagueID, season, views)
+		var archived []historyResponse
+		if err := c.makeRequest(ctx, "GET", endpoint, nil, &archived); err != nil {
+			return nil, fmt.Errorf("failed to get %d season history: %w", season, err)
+		}
+		if len(archived) == 0 {
+			return nil, fmt.Errorf("failed to get %d season history: %w", season, ErrLeagueNotFound)
+		}
+		response = archived[0]
+	}
+
+	return parseSeasonHistory(leagueID, season, &response), nil
+}
+
+// parseSeasonHistory converts ESPN's league document to a SeasonHistory
+func parseSeasonHistory(leagueID string, season int, response *historyResponse) *SeasonHistory {
+	history := &SeasonHistory{
+		LeagueID:   leagueID,
+		Season:     season,
+		Standings:  []TeamStanding{},
+		Matchups:   []Matchup{},
+		DraftPicks: []DraftPick{},
+	}
+
+	owners := make(map[string]string, len(response.Members))
+	for _, m := range response.Members {
+		owners[m.ID] = m.DisplayName
+	}
+
+	for _, t := range response.Teams {
+		name := t.Name
+		if name == "" {
+			name = strings.TrimSpace(t.Location + " " + t.Nickname)
+		}
+		overall := t.Record.Overall
+		history.Standings = append(history.Standings, TeamStanding{
+			TeamID:        t.ID,
+			TeamName:      name,
+			OwnerName:     owners[t.PrimaryOwner],
+			Wins:          overall.Wins,
+			Losses:        overall.Losses,
+			Ties:          overall.Ties,
+			PointsFor:     overall.PointsFor,
+			PointsAgainst: overall.PointsAgainst,
+			PlayoffSeed:   t.PlayoffSeed,
+			FinalRank:     t.FinalRank,
+		})
+	}
+	sort.Slice(history.Standings, func(i, j int) bool {
+		a, b := history.Standings[i], history.Standings[j]
+		if a.FinalRank != b.FinalRank {
+			// Unranked teams sort last
+			return a.FinalRank != 0 && (b.FinalRank == 0 || a.FinalRank < b.FinalRank)
+		}
+		return a.PlayoffSeed < b.PlayoffSeed
+	})
+
+	for _, m := range response.Schedule {
+		// Byes have no away team
+		if m.Home == nil || m.Away == nil {
+			continue
+		}
+		history.Matchups = append(history.Matchups, Matchup{
+			ID:         strconv.Itoa(m.ID),
+			Week:       m.MatchupPeriodID,
+			HomeTeamID: m.Home.TeamID,
+			AwayTeamID: m.Away.TeamID,
+			HomeScore:  m.Home.TotalPoints,
+			AwayScore:  m.Away.TotalPoints,
+			Winner:     m.Winner,
+			IsComplete: m.Winner != "" && m.Winner != "UNDECIDED",
+			IsPlayoffs: m.PlayoffTierType != "" && m.PlayoffTierType != "NONE",
+		})
+	}
+
+	for _, p := range response.DraftDetail.Picks {
+		history.DraftPicks = append(history.DraftPicks, DraftPick{
+			Round:       p.RoundID,
+			Pick:        p.RoundPickNumber,
+			OverallPick: p.OverallPickNumber,
+			TeamID:      p.TeamID,
+			PlayerID:    strconv.Itoa(p.PlayerID),
+			BidAmount:   p.BidAmount,
+			Keeper:      p.Keeper,
+		})
+	}
+	sort.Slice(history.DraftPicks, func(i, j int) bool {
+		return history.DraftPicks[i].OverallPick < history.DraftPicks[j].OverallPick
+	})
+
+	return history
+}
@@ -0,0 +1,83 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const historyDocument = `{"seasonId": %d,
+	"members": [{"id": "{OWNER-1}", "displayName": "jdoe"}, {"id": "{OWNER-2}", "displayName": "asmith"}],
+	"teams": [
+		{"id": 2, "location": "Beta", "nickname": "Bots", "primaryOwner": "{OWNER-2}", "playoffSeed": 1, "rankCalculatedFinal": 2,
+		 "record": {"overall": {"wins": 10, "losses": 4, "ties": 0, "pointsFor": 1650.4, "pointsAgainst": 1400.2}}},
+		{"id": 1, "name": "Alpha Dogs", "primaryOwner": "{OWNER-1}", "playoffSeed": 2, "rankCalculatedFinal": 1,
+		 "record": {"overall": {"wins": 9, "losses": 5, "ties": 0, "pointsFor": 1701.8, "pointsAgainst": 1502.0}}}
+	],
+	"schedule": [
+		{"id": 1, "matchupPeriodId": 1, "home": {"teamId": 1, "totalPoints": 110.5}, "away": {"teamId": 2, "totalPoints": 98.1}, "winner": "HOME", "playoffTierType": "NONE"},
+		{"id": 2, "matchupPeriodId": 15, "home": {"teamId": 3, "totalPoints": 0}, "winner": "UNDECIDED"},
+		{"id": 3, "matchupPeriodId": 16, "home": {"teamId": 2, "totalPoints": 120.0}, "away": {"teamId": 1, "totalPoints": 125.2}, "winner": "AWAY", "playoffTierType": "WINNERS_BRACKET"}
+	],
+	"draftDetail": {"picks": [
+		{"overallPickNumber": 2, "roundId": 1, "roundPickNumber": 2, "teamId": 2, "playerId": 3054211},
+		{"overallPickNumber": 1, "roundId": 1, "roundPickNumber": 1, "teamId": 1, "playerId": 3916387, "keeper": true}
+	]}
+}`
+
+func TestGetSeasonHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/seasons/2022/segments/0/leagues/123456":
+			assert.Equal(t, []string{"mTeam", "mMatchup", "mDraftDetail"}, r.URL.Query()["view"])
+			w.Write([]byte(fmt.Sprintf(historyDocument, 2022)))
+		case "/leagueHistory/123456":
+			// Seasons before 2018 come from the archive, wrapped in a list
+			assert.Equal(t, "2016", r.URL.Query().Get("seasonId"))
+			w.Write([]byte("[" + fmt.Sprintf(historyDocument, 2016) + "]"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &ESPNClient{
+		httpClient:  http.DefaultClient,
+		baseURL:     server.URL,
+		rateLimiter: newRateLimiter(time.Millisecond, rateLimitBurst),
+	}
+
+	for _, season := range []int{2022, 2016} {
+		history, err := client.GetSeasonHistory(context.Background(), "123456", season)
+		require.NoError(t, err)
+		assert.Equal(t, season, history.Season)
+
+		// Standings are ordered by final rank
+		require.Len(t, history.Standings, 2)
+		assert.Equal(t, TeamStanding{
+			TeamID: 1, TeamName: "Alpha Dogs", OwnerName: "jdoe", Wins: 9, Losses: 5,
+			PointsFor: 1701.8, PointsAgainst: 1502.0, PlayoffSeed: 2, FinalRank: 1,
+		}, history.Standings[0])
+		assert.Equal(t, "Beta Bots", history.Standings[1].TeamName)
+
+		// The bye week is dropped
+		require.Len(t, history.Matchups, 2)
+		assert.False(t, history.Matchups[0].IsPlayoffs)
+		assert.True(t, history.Matchups[1].IsPlayoffs)
+		assert.True(t, history.Matchups[1].IsComplete)
+		assert.Equal(t, 125.2, history.Matchups[1].AwayScore)
+
+		require.Len(t, history.DraftPicks, 2)
+		assert.Equal(t, "3916387", history.DraftPicks[0].PlayerID)
+		assert.True(t, history.DraftPicks[0].Keeper)
+		assert.Equal(t, 2, history.DraftPicks[1].OverallPick)
+	}
+}
@@ -0,0 +1,146 @@
+package espn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// positions maps defaultPositionId to position abbreviations
+var positions = map[int]string{
+	1:  "QB",
+	2:  "RB",
+	3:  "WR",
+	4:  "TE",
+	5:  "K",
+	7:  "P",
+	9:  "DT",
+	10: "DE",
+	11: "LB",
+	12: "CB",
+	13: "S",
+	14: "HC",
+	16: "DST",
+}
+
+// proTeams maps proTeamId to team abbreviations. 0 is a player without a
+// team.
+var proTeams = map[int]string{
+	0: "FA",
+	1: "ATL", 2: "BUF", 3: "CHI", 4: "CIN", 5: "CLE", 6: "DAL", 7: "DEN", 8: "DET",
+	9: "GB", 10: "TEN", 11: "IND", 12: "KC", 13: "LV", 14: "LAR", 15: "MIA", 16: "MIN",
+	17: "NE", 18: "NO", 19: "NYG", 20: "NYJ", 21: "PHI", 22: "ARI", 23: "PIT", 24: "LAC",
+	25: "SF", 26: "SEA", 27: "TB", 28: "WSH", 29: "CAR", 30: "JAX", 33: "BAL", 34: "HOU",
+}
+
+// PositionName converts an ESPN defaultPositionId to a position
+// abbreviation, or "" if the ID is unknown
+func PositionName(positionID int) string {
+	return positions[positionID]
+}
+
+// TeamAbbreviation converts an ESPN proTeamId to a team abbreviation, or ""
+// if the ID is unknown
+func TeamAbbreviation(proTeamID int) string {
+	return proTeams[proTeamID]
+}
+
+// UnmarshalJSON decodes a player from ESPN, whose IDs are numbers, or from
+// the cache, which stores them already converted. ESPN's player list wraps
+// each player in an entry with a "player" object, which is unwrapped.
+func (p *Player) UnmarshalJSON(data []byte) error {
+	var entry struct {
+		Player json.RawMessage `json:"player"`
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return err
+	}
+	if len(entry.Player) > 0 && entry.Player[0] == '{' {
+		data = entry.Player
+	}
+
+	type plain Player
+	var aux struct {
+		plain
+		ID          json.RawMessage `json:"id"`
+		Position    json.RawMessage `json:"defaultPositionId"`
+		Team        json.RawMessage `json:"proTeamId"`
+		Jersey      json.RawMessage `json:"jersey"`
+		Stats       json.RawMessage `json:"stats"`
+		Projections json.RawMessage `json:"projections"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*p = Player(aux.plain)
+
+	var err error
+	if p.ID, err = idString(aux.ID); err != nil {
+		return fmt.Errorf("invalid player id: %w", err)
+	}
+	if p.Position, err = idName(aux.Position, positions); err != nil {
+		return fmt.Errorf("invalid defaultPositionId: %w", err)
+	}
+	if p.Team, err = idName(aux.Team, proTeams); err != nil {
+		return fmt.Errorf("invalid proTeamId: %w", err)
+	}
+	if jersey, err := idString(aux.Jersey); err == nil && jersey != "" {
+		p.Jersey, _ = strconv.Atoi(jersey)
+	}
+
+	// ESPN sends stats as a list of stat lines, which the player does not
+	// carry; only the cached single stat line is decoded
+	if isObject(aux.Stats) {
+		if err := json.Unmarshal(aux.Stats, &p.Stats); err != nil {
+			return fmt.Errorf("invalid stats: %w", err)
+		}
+	}
+	if isObject(aux.Projections) {
+		if err := json.Unmarshal(aux.Projections, &p.Projections); err != nil {
+			return fmt.Errorf("invalid projections: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// idString reads an ID sent as either a number or a string
+func idString(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return "", nil
+	}
+	if raw[0] == '"' {
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// idName converts a numeric ID to its name. Strings are names already, as
+// stored in the cache, and are kept.
+func idName(raw json.RawMessage, names map[int]string) (string, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return "", nil
+	}
+	if raw[0] == '"' {
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	}
+	var id int
+	if err := json.Unmarshal(raw, &id); err != nil {
+		return "", err
+	}
+	return names[id], nil
+}
+
+func isObject(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && raw[0] == '{'
+}
@@ -0,0 +1,37 @@
+package espn
+
+import "context"
+
+// Client defines the ESPN Fantasy API operations used by services.
+// ESPNClient talks to ESPN; MockESPNClient serves canned data for tests.
+type Client interface {
+	GetLeagueInfo(ctx context.Context, leagueID string) (*LeagueInfo, error)
+	GetRosters(ctx context.Context, leagueID string) ([]Roster, error)
+	GetAvailablePlayers(ctx context.Context, leagueID string, maxPlayers int) ([]Player, error)
+	GetMatchups(ctx context.Context, leagueID string, week int) ([]Matchup, error)
+	GetTransactions(ctx context.Context, leagueID string, limit int) ([]Transaction, error)
+	GetWaiverClaims(ctx context.Context, leagueID string) ([]WaiverClaim, error)
+	GetBoxScores(ctx context.Context, leagueID string, week int) ([]BoxScore, error)
+	GetScoreboard(ctx context.Context, week int) ([]NFLGame, error)
+	GetNFLTeams(ctx context.Context) ([]NFLTeam, error)
+	GetLiveScoring(ctx context.Context, leagueID string, week int) (*LiveScoring, error)
+	GetDraftResults(ctx context.Context, leagueID string) ([]DraftPick, error)
+	GetSeasonHistory(ctx context.Context, leagueID string, season int) (*SeasonHistory, error)
+	GetAuctionValues(ctx context.Context, leagueID string, season, limit int) ([]AuctionValue, error)
+	GetKeeperCosts(ctx context.Context, leagueID string, season int) ([]KeeperCost, error)
+	DetectScoringFormat(settings LeagueSettings) string
+	GetSeasonStatus(ctx context.Context) (*SeasonStatus, error)
+	GetInjuryReport(ctx context.Context, season int) ([]InjuryReport, error)
+	GetPlayerNews(ctx context.Context, playerID string, limit int) ([]PlayerNews, error)
+	GetPlayerProjections(ctx context.Context, season, week int) ([]Player, error)
+	GetTrendingPlayers(ctx context.Context, season int, direction, position string, limit int) ([]TrendingPlayer, error)
+	GetDraftRankings(ctx context.Context, season int, rankType string, limit int) ([]DraftRank, error)
+	// WithAuthentication returns a client that sends the given cookies
+	WithAuthentication(swid, espnS2 string) Client
+}
+
+var (
+	_ Client = (*ESPNClient)(nil)
+	_ Client = (*MockESPNClient)(nil)
+	_ Client = (*CachedClient)(nil)
+)
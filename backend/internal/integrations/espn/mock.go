@@ -14,8 +14,24 @@ type MockESPNClient struct {
 	AvailablePlayers  []Player
 	Matchups          []Matchup
 	Transactions      []Transaction
+	WaiverClaims      []WaiverClaim
+	BoxScores         []BoxScore
+	Scoreboard        []NFLGame
+	NFLTeams          []NFLTeam
 	DraftPicks        []DraftPick
+	SeasonStatus      *SeasonStatus
+	InjuryReport      []InjuryReport
+	News              map[string][]PlayerNews
+	Projections       []Player
+	Trending          []TrendingPlayer
+	DraftRanks        []DraftRank
+	History           map[int]*SeasonHistory
+	AuctionValues     []AuctionValue
+	KeeperCosts       []KeeperCost
 	Error             error
+	// Cookies passed to the most recent WithAuthentication call
+	SWID              string
+	EspnS2            string
 }
 
 // NewMockESPNClient creates a mock client with sample data
@@ -142,7 +158,7 @@ func (m *MockESPNClient) GetLeagueInfo(ctx context.Context, leagueID string) (*L
 		return nil, m.Error
 	}
 	if m.LeagueInfo == nil {
-		return nil, fmt.Errorf("league not found")
+		return nil, ErrLeagueNotFound
 	}
 	return m.LeagueInfo, nil
 }
@@ -155,11 +171,14 @@ func (m *MockESPNClient) GetRosters(ctx context.Context, leagueID string) ([]Ros
 	return m.Rosters, nil
 }
 
-// GetAvailablePlayers returns mock available players
-func (m *MockESPNClient) GetAvailablePlayers(ctx context.Context, leagueID string) ([]Player, error) {
+// GetAvailablePlayers returns up to maxPlayers mock available players
+func (m *MockESPNClient) GetAvailablePlayers(ctx context.Context, leagueID string, maxPlayers int) ([]Player, error) {
 	if m.Error != nil {
 		return nil, m.Error
 	}
+	if maxPlayers > 0 && len(m.AvailablePlayers) > maxPlayers {
+		return m.AvailablePlayers[:maxPlayers], nil
+	}
 	return m.AvailablePlayers, nil
 }
 
@@ -219,6 +238,55 @@ func (m *MockESPNClient) GetTransactions(ctx context.Context, leagueID string, l
 	return m.Transactions, nil
 }
 
+// GetWaiverClaims returns the mock waiver claims
+func (m *MockESPNClient) GetWaiverClaims(ctx context.Context, leagueID string) ([]WaiverClaim, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.WaiverClaims, nil
+}
+
+// GetBoxScores returns the mock box scores for the week
+func (m *MockESPNClient) GetBoxScores(ctx context.Context, leagueID string, week int) ([]BoxScore, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	var boxScores []BoxScore
+	for _, b := range m.BoxScores {
+		if b.Week == week {
+			boxScores = append(boxScores, b)
+		}
+	}
+	return boxScores, nil
+}
+
+// GetScoreboard returns the mock NFL games for a week
+func (m *MockESPNClient) GetScoreboard(ctx context.Context, week int) ([]NFLGame, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	var games []NFLGame
+	for _, g := range m.Scoreboard {
+		if g.Week == week {
+			games = append(games, g)
+		}
+	}
+	return games, nil
+}
+
+// GetLiveScoring returns live scoring built from the mock box scores and games
+func (m *MockESPNClient) GetLiveScoring(ctx context.Context, leagueID string, week int) (*LiveScoring, error) {
+	boxScores, err := m.GetBoxScores(ctx, leagueID, week)
+	if err != nil {
+		return nil, err
+	}
+	games, err := m.GetScoreboard(ctx, week)
+	if err != nil {
+		return nil, err
+	}
+	return liveScoring(week, boxScores, games, time.Now()), nil
+}
+
 // GetDraftResults returns mock draft picks
 func (m *MockESPNClient) GetDraftResults(ctx context.Context, leagueID string) ([]DraftPick, error) {
 	if m.Error != nil {
@@ -249,6 +317,37 @@ func (m *MockESPNClient) GetDraftResults(ctx context.Context, leagueID string) (
 	return m.DraftPicks, nil
 }
 
+// GetSeasonHistory returns the mock history for a season
+func (m *MockESPNClient) GetSeasonHistory(ctx context.Context, leagueID string, season int) (*SeasonHistory, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	history, ok := m.History[season]
+	if !ok {
+		return nil, ErrLeagueNotFound
+	}
+	return history, nil
+}
+
+// GetAuctionValues returns up to limit mock auction values
+func (m *MockESPNClient) GetAuctionValues(ctx context.Context, leagueID string, season, limit int) ([]AuctionValue, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if limit > 0 && limit < len(m.AuctionValues) {
+		return m.AuctionValues[:limit], nil
+	}
+	return m.AuctionValues, nil
+}
+
+// GetKeeperCosts returns the mock keeper costs
+func (m *MockESPNClient) GetKeeperCosts(ctx context.Context, leagueID string, season int) ([]KeeperCost, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.KeeperCosts, nil
+}
+
 // DetectScoringFormat returns the mock scoring format
 func (m *MockESPNClient) DetectScoringFormat(settings LeagueSettings) string {
 	if settings.ScoringSettings.ReceptionPoints == 1.0 {
@@ -259,6 +358,90 @@ func (m *MockESPNClient) DetectScoringFormat(settings LeagueSettings) string {
 	return "STANDARD"
 }
 
+// GetSeasonStatus returns the mock season status, defaulting to week 1 of 2023
+func (m *MockESPNClient) GetSeasonStatus(ctx context.Context) (*SeasonStatus, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if m.SeasonStatus == nil {
+		return &SeasonStatus{Season: 2023, Week: 1}, nil
+	}
+	return m.SeasonStatus, nil
+}
+
+// GetInjuryReport returns the mock injury report
+func (m *MockESPNClient) GetInjuryReport(ctx context.Context, season int) ([]InjuryReport, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.InjuryReport, nil
+}
+
+// GetPlayerNews returns mock news for a player
+func (m *MockESPNClient) GetPlayerNews(ctx context.Context, playerID string, limit int) ([]PlayerNews, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	news := m.News[playerID]
+	if limit > 0 && len(news) > limit {
+		return news[:limit], nil
+	}
+	return news, nil
+}
+
+// GetPlayerProjections returns the mock projections
+func (m *MockESPNClient) GetPlayerProjections(ctx context.Context, season, week int) ([]Player, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.Projections, nil
+}
+
+// GetTrendingPlayers returns up to limit mock trending players at position
+func (m *MockESPNClient) GetTrendingPlayers(ctx context.Context, season int, direction, position string, limit int) ([]TrendingPlayer, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	var players []TrendingPlayer
+	for _, p := range m.Trending {
+		if position != "" && p.Position != position {
+			continue
+		}
+		players = append(players, p)
+		if limit > 0 && len(players) == limit {
+			break
+		}
+	}
+	return players, nil
+}
+
+// GetNFLTeams returns the mock NFL teams
+func (m *MockESPNClient) GetNFLTeams(ctx context.Context) ([]NFLTeam, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.NFLTeams, nil
+}
+
+// GetDraftRankings returns up to limit mock draft ranks
+func (m *MockESPNClient) GetDraftRankings(ctx context.Context, season int, rankType string, limit int) ([]DraftRank, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if limit > 0 && limit < len(m.DraftRanks) {
+		return m.DraftRanks[:limit], nil
+	}
+	return m.DraftRanks, nil
+}
+
+// WithAuthentication records the cookies and returns the same mock so tests
+// can inspect what was sent
+func (m *MockESPNClient) WithAuthentication(swid, espnS2 string) Client {
+	m.SWID = swid
+	m.EspnS2 = espnS2
+	return m
+}
+
 // SetCookies is a no-op for the mock client
 func (m *MockESPNClient) SetCookies(cookies []*http.Cookie) {
 	// No-op for mock
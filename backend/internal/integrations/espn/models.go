@@ -66,6 +66,7 @@ type DraftSettings struct {
 	PickOrder    []int     `json:"pickOrder"`
 	TimePerPick  int       `json:"timePerPick"`
 	AuctionBudget int      `json:"auctionBudget,omitempty"`
+	KeeperCount  int       `json:"keeperCount,omitempty"`
 }
 
 // TradeSettings defines trade rules
@@ -110,6 +111,7 @@ type LeagueStatus struct {
 	FinalMatchupPeriod  int    `json:"finalMatchupPeriod"`
 	TransactionCounter  int    `json:"transactionCounter"`
 	WaiverStatus        string `json:"waiverStatus"`
+	PreviousSeasons     []int  `json:"previousSeasons,omitempty"`
 }
 
 // Roster represents a team's roster
@@ -130,9 +132,36 @@ type RosterPlayer struct {
 	Points          float64 `json:"appliedStatTotal,omitempty"`
 	ProjectedPoints float64 `json:"projectedPoints,omitempty"`
 	AcquisitionDate time.Time `json:"acquisitionDate,omitempty"`
+	// EligibleSlots are the lineupSlotIds ESPN lets the player start in
+	EligibleSlots []int `json:"eligibleSlots,omitempty"`
 }
 
-// Player represents available player information
+// eligiblePositionSlots are the lineup slots that name a single position, in
+// lineup order
+var eligiblePositionSlots = []int{0, 2, 4, 6, 16, 17}
+
+// EligiblePositions lists the positions the player can start at, falling
+// back to their listed position when ESPN sent no eligible slots
+func (p RosterPlayer) EligiblePositions() []string {
+	slots := make(map[int]bool, len(p.EligibleSlots))
+	for _, slot := range p.EligibleSlots {
+		slots[slot] = true
+	}
+
+	var positions []string
+	for _, slot := range eligiblePositionSlots {
+		if slots[slot] {
+			positions = append(positions, lineupSlots[slot])
+		}
+	}
+	if len(positions) == 0 && p.Position != "" {
+		positions = []string{p.Position}
+	}
+	return positions
+}
+
+// Player represents available player information. ESPN's numeric position
+// and team IDs are decoded to abbreviations, see UnmarshalJSON.
 type Player struct {
 	ID                   string  `json:"id"`
 	Name                 string  `json:"fullName"`
@@ -209,6 +238,53 @@ type WaiverClaim struct {
 	ProcessDate time.Time `json:"processDate"`
 }
 
+// BoxScore is one team's side of a week's matchup, player by player
+type BoxScore struct {
+	MatchupID       string           `json:"matchupId"`
+	Week            int              `json:"week"`
+	TeamID          int              `json:"teamId"`
+	OpponentID      int              `json:"opponentId"`
+	Points          float64          `json:"points"`
+	ProjectedPoints float64          `json:"projectedPoints"`
+	Players         []BoxScorePlayer `json:"players"`
+}
+
+// BoxScorePlayer is a rostered player's fantasy output for a week
+type BoxScorePlayer struct {
+	PlayerID        string             `json:"playerId"`
+	PlayerName      string             `json:"playerName"`
+	Position        string             `json:"position"`
+	Team            string             `json:"team"`
+	LineupSlot      string             `json:"lineupSlot"`
+	Starter         bool               `json:"starter"`
+	Points          float64            `json:"points"`
+	ProjectedPoints float64            `json:"projectedPoints"`
+	Stats           map[string]float64 `json:"stats"` // Raw stats keyed by ESPN stat ID
+}
+
+// SeasonStatus is ESPN's current fantasy season and scoring period
+type SeasonStatus struct {
+	Season int `json:"season"`
+	Week   int `json:"week"`
+}
+
+// InjuryReport is a player's current injury designation
+type InjuryReport struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Status     string `json:"status"` // QUESTIONABLE, DOUBTFUL, OUT, INJURY_RESERVE, SUSPENSION
+}
+
+// PlayerNews is a news blurb about a player
+type PlayerNews struct {
+	ID        string    `json:"id"`
+	PlayerID  string    `json:"player_id"`
+	Headline  string    `json:"headline"`
+	Story     string    `json:"story"`
+	Source    string    `json:"source"`
+	Published time.Time `json:"published"`
+}
+
 // ParsePlayerPosition standardizes position strings
 func ParsePlayerPosition(pos string) string {
 	switch pos {
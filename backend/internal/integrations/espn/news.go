@@ -0,0 +1,103 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// healthyStatuses are the injury statuses ESPN uses for players without a designation
+var healthyStatuses = map[string]bool{
+	"":       true,
+	"ACTIVE": true,
+	"NORMAL": true,
+}
+
+// GetSeasonStatus fetches the current fantasy season and scoring period
+func (c *ESPNClient) GetSeasonStatus(ctx context.Context) (*SeasonStatus, error) {
+	var response struct {
+		CurrentSeasonID      int `json:"currentSeasonId"`
+		CurrentScoringPeriod struct {
+			ID int `json:"id"`
+		} `json:"currentScoringPeriod"`
+	}
+
+	if err := c.makeRequest(ctx, "GET", c.baseURL, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get season status: %w", err)
+	}
+
+	return &SeasonStatus{
+		Season: response.CurrentSeasonID,
+		Week:   response.CurrentScoringPeriod.ID,
+	}, nil
+}
+
+// GetInjuryReport fetches every player with an injury designation
+func (c *ESPNClient) GetInjuryReport(ctx context.Context, season int) ([]InjuryReport, error) {
+	endpoint := fmt.Sprintf("%s/seasons/%d/players?view=players_wl&scoringPeriodId=0", c.baseURL, season)
+
+	var players []struct {
+		ID           int    `json:"id"`
+		FullName     string `json:"fullName"`
+		InjuryStatus string `json:"injuryStatus"`
+	}
+
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &players); err != nil {
+		return nil, fmt.Errorf("failed to get injury report: %w", err)
+	}
+
+	var report []InjuryReport
+	for _, p := range players {
+		status := strings.ToUpper(p.InjuryStatus)
+		if healthyStatuses[status] {
+			continue
+		}
+		report = append(report, InjuryReport{
+			PlayerID:   strconv.Itoa(p.ID),
+			PlayerName: p.FullName,
+			Status:     status,
+		})
+	}
+
+	return report, nil
+}
+
+// GetPlayerNews fetches the most recent news blurbs for a player
+func (c *ESPNClient) GetPlayerNews(ctx context.Context, playerID string, limit int) ([]PlayerNews, error) {
+	params := url.Values{}
+	params.Add("playerId", playerID)
+	params.Add("limit", strconv.Itoa(limit))
+
+	fullURL := fmt.Sprintf("%s?%s", c.newsURL, params.Encode())
+
+	var response struct {
+		Feed []struct {
+			ID        int64     `json:"id"`
+			Headline  string    `json:"headline"`
+			Story     string    `json:"story"`
+			Type      string    `json:"type"`
+			Published time.Time `json:"published"`
+		} `json:"feed"`
+	}
+
+	if err := c.makeRequest(ctx, "GET", fullURL, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get player news: %w", err)
+	}
+
+	news := make([]PlayerNews, 0, len(response.Feed))
+	for _, item := range response.Feed {
+		news = append(news, PlayerNews{
+			ID:        strconv.FormatInt(item.ID, 10),
+			PlayerID:  playerID,
+			Headline:  item.Headline,
+			Story:     item.Story,
+			Source:    item.Type,
+			Published: item.Published,
+		})
+	}
+
+	return news, nil
+}
@@ -0,0 +1,76 @@
+package espn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(url string) *ESPNClient {
+	return &ESPNClient{
+		httpClient:    http.DefaultClient,
+		baseURL:       url,
+		newsURL:       url + "/news",
+		scoreboardURL: url + "/scoreboard",
+		teamsURL:      url + "/teams",
+		rateLimiter:   newRateLimiter(time.Millisecond, rateLimitBurst),
+	}
+}
+
+func TestGetInjuryReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/seasons/2024/players", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"id": 3139477, "fullName": "Patrick Mahomes", "injuryStatus": "ACTIVE"},
+			{"id": 4047365, "fullName": "Josh Jacobs", "injuryStatus": "Questionable"},
+			{"id": 3116406, "fullName": "Tyreek Hill", "injuryStatus": "INJURY_RESERVE"},
+			{"id": 15847, "fullName": "Travis Kelce"}
+		]`))
+	}))
+	defer server.Close()
+
+	report, err := newTestClient(server.URL).GetInjuryReport(context.Background(), 2024)
+	require.NoError(t, err)
+	require.Len(t, report, 2)
+	assert.Equal(t, InjuryReport{PlayerID: "4047365", PlayerName: "Josh Jacobs", Status: "QUESTIONABLE"}, report[0])
+	assert.Equal(t, "INJURY_RESERVE", report[1].Status)
+}
+
+func TestGetPlayerNews(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/news", r.URL.Path)
+		assert.Equal(t, "4047365", r.URL.Query().Get("playerId"))
+		assert.Equal(t, "3", r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"feed": [
+			{"id": 41234567, "headline": "Jacobs limited in practice", "story": "Hip.", "type": "Rotowire", "published": "2024-10-02T18:30:00Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	news, err := newTestClient(server.URL).GetPlayerNews(context.Background(), "4047365", 3)
+	require.NoError(t, err)
+	require.Len(t, news, 1)
+	assert.Equal(t, "41234567", news[0].ID)
+	assert.Equal(t, "4047365", news[0].PlayerID)
+	assert.Equal(t, "Rotowire", news[0].Source)
+	assert.Equal(t, time.Date(2024, 10, 2, 18, 30, 0, 0, time.UTC), news[0].Published.UTC())
+}
+
+func TestGetSeasonStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"currentSeasonId": 2024, "currentScoringPeriod": {"id": 5}}`))
+	}))
+	defer server.Close()
+
+	status, err := newTestClient(server.URL).GetSeasonStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &SeasonStatus{Season: 2024, Week: 5}, status)
+}
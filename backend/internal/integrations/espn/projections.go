@@ -0,0 +1,115 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ESPN stat IDs used in player stat and projection maps
+const (
+	StatPassingYards     = "3"
+	StatPassingTDs       = "4"
+	StatInterceptions    = "20"
+	StatRushingYards     = "24"
+	StatRushingTDs       = "25"
+	StatReceivingYards   = "42"
+	StatReceivingTDs     = "43"
+	StatReceptions       = "53"
+	StatFumblesLost      = "72"
+	StatFieldGoalsMade   = "83"
+	StatFieldGoalsMissed = "85"
+	StatExtraPointsMade  = "86"
+)
+
+const (
+	// projectionStatSource is the statSourceId ESPN uses for projections (0 is actuals)
+	projectionStatSource = 1
+	// maxProjectedPlayers caps the projection request; ESPN sorts by ownership
+	maxProjectedPlayers = 1000
+)
+
+// projectionPositions maps defaultPositionId to the offensive positions we
+// store stat-line projections for
+var projectionPositions = map[int]string{
+	1: "QB",
+	2: "RB",
+	3: "WR",
+	4: "TE",
+}
+
+// GetPlayerProjections fetches ESPN's projections for QBs, RBs, WRs and TEs.
+// Week 0 returns season-long projections. Each player's Projections carries
+// the raw stat map keyed by ESPN stat ID.
+func (c *ESPNClient) GetPlayerProjections(ctx context.Context, season, week int) ([]Player, error) {
+	endpoint := fmt.Sprintf("%s/seasons/%d/segments/0/leaguedefaults/3?view=kona_player_info&scoringPeriodId=%d",
+		c.baseURL, season, week)
+
+	filter := fmt.Sprintf(`{"players":{"filterSlotIds":{"value":[0,2,4,6]},"filterStatsForSourceIds":{"value":[%d]},"limit":%d,"sortPercOwned":{"sortPriority":1,"sortAsc":false}}}`,
+		projectionStatSource, maxProjectedPlayers)
+
+	var response struct {
+		Players []struct {
+			Player struct {
+				ID                int    `json:"id"`
+				FullName          string `json:"fullName"`
+				FirstName         string `json:"firstName"`
+				LastName          string `json:"lastName"`
+				DefaultPositionID int    `json:"defaultPositionId"`
+				ProTeamID         int    `json:"proTeamId"`
+				InjuryStatus      string `json:"injuryStatus"`
+				Stats             []struct {
+					SeasonID        int                `json:"seasonId"`
+					ScoringPeriodID int                `json:"scoringPeriodId"`
+					StatSourceID    int                `json:"statSourceId"`
+					StatSplitTypeID int                `json:"statSplitTypeId"`
+					Stats           map[string]float64 `json:"stats"`
+					AppliedTotal    float64            `json:"appliedTotal"`
+					ExternalID      string             `json:"externalId"`
+				} `json:"stats"`
+			} `json:"player"`
+		} `json:"players"`
+	}
+
+	headers := map[string]string{"X-Fantasy-Filter": filter}
+	if err := c.makeRequestWithHeaders(ctx, "GET", endpoint, nil, headers, &response); err != nil {
+		return nil, fmt.Errorf("failed to get player projections: %w", err)
+	}
+
+	var players []Player
+	for _, entry := range response.Players {
+		p := entry.Player
+		position, ok := projectionPositions[p.DefaultPositionID]
+		if !ok {
+			continue
+		}
+
+		for _, s := range p.Stats {
+			// Players carry projections for several periods; keep the requested one
+			if s.StatSourceID != projectionStatSource || s.SeasonID != season || s.ScoringPeriodID != week {
+				continue
+			}
+
+			players = append(players, Player{
+				ID:        strconv.Itoa(p.ID),
+				Name:      p.FullName,
+				FirstName: p.FirstName,
+				LastName:  p.LastName,
+				Position:  position,
+				Team:      TeamAbbreviation(p.ProTeamID),
+				Status:    p.InjuryStatus,
+				Projections: PlayerStats{
+					Season:       s.SeasonID,
+					Week:         s.ScoringPeriodID,
+					StatSource:   strconv.Itoa(s.StatSourceID),
+					Stats:        s.Stats,
+					AppliedTotal: s.AppliedTotal,
+					ExternalID:   s.ExternalID,
+				},
+			})
+			break
+		}
+	}
+
+	return players, nil
+}
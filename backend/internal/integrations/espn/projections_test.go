@@ -0,0 +1,44 @@
+package espn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPlayerProjections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/seasons/2024/segments/0/leaguedefaults/3", r.URL.Path)
+		assert.Equal(t, "5", r.URL.Query().Get("scoringPeriodId"))
+		assert.Contains(t, r.Header.Get("X-Fantasy-Filter"), `"filterStatsForSourceIds":{"value":[1]}`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"players": [
+			{"player": {"id": 3918298, "fullName": "Josh Allen", "defaultPositionId": 1, "proTeamId": 2, "stats": [
+				{"seasonId": 2024, "scoringPeriodId": 0, "statSourceId": 1, "statSplitTypeId": 0, "stats": {"3": 4100}},
+				{"seasonId": 2024, "scoringPeriodId": 5, "statSourceId": 0, "statSplitTypeId": 1, "stats": {"3": 310}},
+				{"seasonId": 2024, "scoringPeriodId": 5, "statSourceId": 1, "statSplitTypeId": 1, "stats": {"3": 255.5, "4": 1.9, "24": 31}, "appliedTotal": 22.1}
+			]}},
+			{"player": {"id": 2977187, "fullName": "Harrison Butker", "defaultPositionId": 5, "proTeamId": 12, "stats": [
+				{"seasonId": 2024, "scoringPeriodId": 5, "statSourceId": 1, "statSplitTypeId": 1, "stats": {"80": 1.5}}
+			]}},
+			{"player": {"id": 4241478, "fullName": "Bench Guy", "defaultPositionId": 3, "proTeamId": 16, "stats": []}}
+		]}`))
+	}))
+	defer server.Close()
+
+	players, err := newTestClient(server.URL).GetPlayerProjections(context.Background(), 2024, 5)
+	require.NoError(t, err)
+
+	// Kickers and players without a projection for the week are skipped
+	require.Len(t, players, 1)
+	assert.Equal(t, "3918298", players[0].ID)
+	assert.Equal(t, "QB", players[0].Position)
+	assert.Equal(t, "BUF", players[0].Team)
+	assert.Equal(t, 5, players[0].Projections.Week)
+	assert.Equal(t, 255.5, players[0].Projections.Stats[StatPassingYards])
+	assert.Equal(t, 22.1, players[0].Projections.AppliedTotal)
+}
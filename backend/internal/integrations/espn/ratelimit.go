@@ -0,0 +1,100 @@
+package espn
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitInterval adds one request token, 100 requests per minute
+	rateLimitInterval = 600 * time.Millisecond
+	// rateLimitBurst is how many requests can go out back to back
+	rateLimitBurst = 10
+	// maxRateLimitInterval caps how far backoff slows the refill
+	maxRateLimitInterval = 5 * time.Second
+	// rateLimitJitter is the largest share of the refill interval added to a
+	// wait, so callers queued together do not all fire at once
+	rateLimitJitter = 0.2
+)
+
+// rateLimiter is a token bucket shared by every request the client makes.
+// Waiting callers hold no lock and give up as soon as their context ends.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // time to add one token
+	burst    float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+// newRateLimiter creates a full bucket refilled one token per interval
+func newRateLimiter(interval time.Duration, burst int) *rateLimiter {
+	return &rateLimiter{
+		interval: interval,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		now:      time.Now,
+	}
+}
+
+// wait takes a token, waiting for one if the bucket is empty. The token is
+// reserved before waiting, so queued callers are served in order; if ctx ends
+// first the reservation is returned and ctx's error is returned.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.refill()
+	r.tokens--
+	var delay time.Duration
+	if r.tokens < 0 {
+		delay = time.Duration(-r.tokens * float64(r.interval))
+		delay += time.Duration(rand.Float64() * rateLimitJitter * float64(r.interval))
+	}
+	r.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		r.mu.Lock()
+		r.tokens++
+		r.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// refill adds the tokens earned since the last refill. Callers hold mu.
+func (r *rateLimiter) refill() {
+	now := r.now()
+	if !r.last.IsZero() && r.interval > 0 {
+		r.tokens += float64(now.Sub(r.last)) / float64(r.interval)
+		if r.tokens > r.burst {
+			r.tokens = r.burst
+		}
+	}
+	r.last = now
+}
+
+// backoff halves the refill rate after ESPN rate limits a request
+func (r *rateLimiter) backoff() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.refill()
+	r.interval *= 2
+	if r.interval > maxRateLimitInterval {
+		r.interval = maxRateLimitInterval
+	}
+}
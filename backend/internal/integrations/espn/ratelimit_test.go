@@ -0,0 +1,60 @@
+package espn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterAllowsBurst(t *testing.T) {
+	limiter := newRateLimiter(time.Hour, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.wait(context.Background()))
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestRateLimiterWaitReturnsOnCancel(t *testing.T) {
+	limiter := newRateLimiter(time.Hour, 1)
+	require.NoError(t, limiter.wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := limiter.wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The cancelled caller's reservation is handed back
+	assert.InDelta(t, 0, limiter.tokens, 0.01)
+}
+
+func TestRateLimiterRefills(t *testing.T) {
+	now := time.Date(2025, 9, 7, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(time.Second, 2)
+	limiter.now = func() time.Time { return now }
+
+	require.NoError(t, limiter.wait(context.Background()))
+	require.NoError(t, limiter.wait(context.Background()))
+	assert.InDelta(t, 0, limiter.tokens, 0.01)
+
+	now = now.Add(10 * time.Second)
+	limiter.mu.Lock()
+	limiter.refill()
+	limiter.mu.Unlock()
+	assert.InDelta(t, 2, limiter.tokens, 0.01, "refill is capped at the burst")
+}
+
+func TestRateLimiterBackoff(t *testing.T) {
+	limiter := newRateLimiter(time.Second, 1)
+	for i := 0; i < 5; i++ {
+		limiter.backoff()
+	}
+	assert.Equal(t, maxRateLimitInterval, limiter.interval)
+}
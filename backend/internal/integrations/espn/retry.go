@@ -0,0 +1,70 @@
+package espn
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRetries is how many times a request is sent before giving up
+	maxRetries = 3
+	// retryBaseDelay is the backoff before the first retry; it doubles for
+	// each retry after
+	retryBaseDelay = 500 * time.Millisecond
+	// maxRetryDelay caps a single backoff
+	maxRetryDelay = 10 * time.Second
+	// retryBudget caps the total time one request spends waiting between
+	// attempts, so a Retry-After longer than a handler can wait fails fast
+	retryBudget = 30 * time.Second
+)
+
+// ErrRateLimited is returned when ESPN keeps answering 429 Too Many Requests
+// or asks us to wait longer than the retry budget allows
+var ErrRateLimited = errors.New("rate limited - too many requests")
+
+// retryBackoff is the wait before the given retry, doubling from
+// retryBaseDelay with half of it randomized so clients rate limited together
+// do not retry together
+func retryBackoff(retry int) time.Duration {
+	delay := retryBaseDelay << (retry - 1)
+	if delay > maxRetryDelay || delay <= 0 {
+		delay = maxRetryDelay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryDelay is how long to wait before a retry: the backoff, or longer if
+// ESPN's Retry-After asked for it
+func retryDelay(retry int, retryAfter time.Duration) time.Duration {
+	delay := retryBackoff(retry)
+	if retryAfter > delay {
+		return retryAfter
+	}
+	return delay
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date. It returns 0 when the header is missing, invalid or in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
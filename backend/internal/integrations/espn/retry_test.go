@@ -0,0 +1,90 @@
+package espn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("-5", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+	assert.Zero(t, parseRetryAfter("", now))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	for retry := 1; retry <= 3; retry++ {
+		full := retryBaseDelay << (retry - 1)
+		for i := 0; i < 20; i++ {
+			delay := retryBackoff(retry)
+			assert.GreaterOrEqual(t, delay, full/2)
+			assert.LessOrEqual(t, delay, full)
+		}
+	}
+	assert.LessOrEqual(t, retryBackoff(20), maxRetryDelay)
+
+	// Retry-After wins when it asks for longer than the backoff
+	assert.Equal(t, 20*time.Second, retryDelay(1, 20*time.Second))
+}
+
+func TestRetryAfterBeyondBudgetFailsFast(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &ESPNClient{
+		httpClient:  http.DefaultClient,
+		baseURL:     server.URL,
+		rateLimiter: newRateLimiter(time.Millisecond, rateLimitBurst),
+	}
+
+	start := time.Now()
+	err := client.makeRequest(context.Background(), "GET", server.URL, nil, &struct{}{})
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Contains(t, err.Error(), "retry budget exhausted")
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	client := &ESPNClient{
+		httpClient:  http.DefaultClient,
+		baseURL:     server.URL,
+		rateLimiter: newRateLimiter(time.Millisecond, rateLimitBurst),
+	}
+
+	var result struct {
+		ID int `json:"id"`
+	}
+	start := time.Now()
+	require.NoError(t, client.makeRequest(context.Background(), "GET", server.URL, nil, &result))
+	assert.Equal(t, 1, result.ID)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,184 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// NFL game states on the scoreboard
+const (
+	GameStatePre  = "pre"
+	GameStateIn   = "in"
+	GameStatePost = "post"
+)
+
+// NFLGame is an NFL game's status on the scoreboard
+type NFLGame struct {
+	ID        string    `json:"id"`
+	Week      int       `json:"week"`
+	HomeTeam  string    `json:"homeTeam"`
+	AwayTeam  string    `json:"awayTeam"`
+	HomeScore int       `json:"homeScore"`
+	AwayScore int       `json:"awayScore"`
+	Kickoff   time.Time `json:"kickoff"`
+	State     string    `json:"state"`  // GameStatePre, GameStateIn or GameStatePost
+	Detail    string    `json:"detail"` // e.g. "Final" or "3rd Quarter 4:12"
+}
+
+// LiveScoring is a league's matchups for a week while games are played
+type LiveScoring struct {
+	Week int `json:"week"`
+	// Live is true while any NFL game of the week is in progress
+	Live      bool          `json:"live"`
+	Matchups  []LiveMatchup `json:"matchups"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+// LiveMatchup is one fantasy matchup's current score. Away is nil for a team
+// on a bye.
+type LiveMatchup struct {
+	MatchupID string    `json:"matchupId"`
+	Home      LiveTeam  `json:"home"`
+	Away      *LiveTeam `json:"away"`
+}
+
+// LiveTeam is a team's score so far and how many of its starters have games
+// left. Starters without a game this week count as finished.
+type LiveTeam struct {
+	TeamID          int     `json:"teamId"`
+	Points          float64 `json:"points"`
+	ProjectedPoints float64 `json:"projectedPoints"`
+	YetToPlay       int     `json:"yetToPlay"`
+	InPlay          int     `json:"inPlay"`
+	Finished        int     `json:"finished"`
+}
+
+// GetScoreboard fetches the current season's NFL games for a regular season
+// week with their status
+func (c *ESPNClient) GetScoreboard(ctx context.Context, week int) ([]NFLGame, error) {
+	endpoint := fmt.Sprintf("%s?seasontype=2&week=%d", c.scoreboardURL, week)
+
+	var response scoreboardResponse
+	if err := c.makeRequest(ctx, "GET", endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get scoreboard: %w", err)
+	}
+
+	return response.games(), nil
+}
+
+// GetLiveScoring fetches a league's matchup scores for a week and counts each
+// team's starters still to play from the NFL scoreboard
+func (c *ESPNClient) GetLiveScoring(ctx context.Context, leagueID string, week int) (*LiveScoring, error) {
+	boxScores, err := c.GetBoxScores(ctx, leagueID, week)
+	if err != nil {
+		return nil, err
+	}
+	games, err := c.GetScoreboard(ctx, week)
+	if err != nil {
+		return nil, err
+	}
+	return liveScoring(week, boxScores, games, time.Now()), nil
+}
+
+// liveScoring pairs each matchup's box scores and counts starters by the
+// state of their NFL team's game
+func liveScoring(week int, boxScores []BoxScore, games []NFLGame, now time.Time) *LiveScoring {
+	states := make(map[string]string, len(games)*2)
+	live := false
+	for _, g := range games {
+		states[g.HomeTeam] = g.State
+		states[g.AwayTeam] = g.State
+		live = live || g.State == GameStateIn
+	}
+
+	scoring := &LiveScoring{Week: week, Live: live, Matchups: []LiveMatchup{}, UpdatedAt: now}
+	index := make(map[string]int)
+	for _, b := range boxScores {
+		team := LiveTeam{TeamID: b.TeamID, Points: b.Points, ProjectedPoints: b.ProjectedPoints}
+		for _, p := range b.Players {
+			if !p.Starter {
+				continue
+			}
+			switch states[p.Team] {
+			case GameStatePre:
+				team.YetToPlay++
+			case GameStateIn:
+				team.InPlay++
+			default:
+				team.Finished++
+			}
+		}
+
+		// Box scores list each matchup once per side
+		i, ok := index[b.MatchupID]
+		if !ok {
+			index[b.MatchupID] = len(scoring.Matchups)
+			scoring.Matchups = append(scoring.Matchups, LiveMatchup{MatchupID: b.MatchupID, Home: team})
+			continue
+		}
+		scoring.Matchups[i].Away = &team
+	}
+	return scoring
+}
+
+// scoreboardResponse is the part of ESPN's scoreboard we read
+type scoreboardResponse struct {
+	Events []struct {
+		ID   string `json:"id"`
+		Date string `json:"date"`
+		Week struct {
+			Number int `json:"number"`
+		} `json:"week"`
+		Competitions []struct {
+			Competitors []struct {
+				HomeAway string `json:"homeAway"`
+				Score    string `json:"score"`
+				Team     struct {
+					Abbreviation string `json:"abbreviation"`
+				} `json:"team"`
+			} `json:"competitors"`
+			Status struct {
+				Type struct {
+					State  string `json:"state"`
+					Detail string `json:"detail"`
+				} `json:"type"`
+			} `json:"status"`
+		} `json:"competitions"`
+	} `json:"events"`
+}
+
+// games converts the scoreboard's events to games
+func (r scoreboardResponse) games() []NFLGame {
+	games := make([]NFLGame, 0, len(r.Events))
+	for _, e := range r.Events {
+		if len(e.Competitions) == 0 {
+			continue
+		}
+		competition := e.Competitions[0]
+		game := NFLGame{
+			ID:     e.ID,
+			Week:   e.Week.Number,
+			State:  competition.Status.Type.State,
+			Detail: competition.Status.Type.Detail,
+		}
+		// ESPN leaves out the seconds, e.g. 2024-09-06T00:20Z
+		for _, layout := range []string{"2006-01-02T15:04Z07:00", time.RFC3339} {
+			if t, err := time.Parse(layout, e.Date); err == nil {
+				game.Kickoff = t
+				break
+			}
+		}
+		for _, team := range competition.Competitors {
+			score, _ := strconv.Atoi(team.Score)
+			if team.HomeAway == "home" {
+				game.HomeTeam, game.HomeScore = team.Team.Abbreviation, score
+			} else {
+				game.AwayTeam, game.AwayScore = team.Team.Abbreviation, score
+			}
+		}
+		games = append(games, game)
+	}
+	return games
+}
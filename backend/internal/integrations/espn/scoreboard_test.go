@@ -0,0 +1,119 @@
+package espn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetScoreboard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/scoreboard", r.URL.Path)
+		assert.Equal(t, "3", r.URL.Query().Get("week"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"events": [
+			{"id": "401671", "date": "2024-09-20T00:15Z", "week": {"number": 3},
+			 "competitions": [{"competitors": [
+				{"homeAway": "home", "score": "21", "team": {"abbreviation": "NYJ"}},
+				{"homeAway": "away", "score": "10", "team": {"abbreviation": "NE"}}],
+				"status": {"type": {"state": "post", "detail": "Final"}}}]},
+			{"id": "401672", "date": "2024-09-22T17:00Z", "week": {"number": 3},
+			 "competitions": [{"competitors": [
+				{"homeAway": "home", "score": "0", "team": {"abbreviation": "CLE"}},
+				{"homeAway": "away", "score": "0", "team": {"abbreviation": "NYG"}}],
+				"status": {"type": {"state": "pre", "detail": "Sun, September 22nd at 1:00 PM EDT"}}}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	games, err := newTestClient(server.URL).GetScoreboard(context.Background(), 3)
+	require.NoError(t, err)
+	require.Len(t, games, 2)
+	assert.Equal(t, NFLGame{
+		ID:        "401671",
+		Week:      3,
+		HomeTeam:  "NYJ",
+		AwayTeam:  "NE",
+		HomeScore: 21,
+		AwayScore: 10,
+		Kickoff:   time.Date(2024, 9, 20, 0, 15, 0, 0, time.UTC),
+		State:     GameStatePost,
+		Detail:    "Final",
+	}, games[0])
+	assert.Equal(t, GameStatePre, games[1].State)
+}
+
+func TestGetNFLTeams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/teams", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"sports": [{"leagues": [{"teams": [
+			{"team": {"id": "12", "abbreviation": "KC", "displayName": "Kansas City Chiefs",
+			 "location": "Kansas City", "name": "Chiefs", "color": "e31837", "alternateColor": "ffb612",
+			 "logos": [
+				{"href": "https://a.espncdn.com/i/teamlogos/nfl/500/kc.png", "rel": ["full", "default"]},
+				{"href": "https://a.espncdn.com/i/teamlogos/nfl/500-dark/kc.png", "rel": ["full", "dark"]}]}},
+			{"team": {"id": "22", "abbreviation": "ARI", "displayName": "Arizona Cardinals",
+			 "location": "Arizona", "name": "Cardinals"}}
+		]}]}]}`))
+	}))
+	defer server.Close()
+
+	teams, err := newTestClient(server.URL).GetNFLTeams(context.Background())
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	assert.Equal(t, "ARI", teams[0].Abbreviation)
+	assert.Equal(t, NFLTeam{
+		ID:             12,
+		Abbreviation:   "KC",
+		Name:           "Kansas City Chiefs",
+		Location:       "Kansas City",
+		Nickname:       "Chiefs",
+		Color:          "e31837",
+		AlternateColor: "ffb612",
+		LogoURL:        "https://a.espncdn.com/i/teamlogos/nfl/500/kc.png",
+		DarkLogoURL:    "https://a.espncdn.com/i/teamlogos/nfl/500-dark/kc.png",
+	}, teams[1])
+}
+
+func TestLiveScoring(t *testing.T) {
+	games := []NFLGame{
+		{HomeTeam: "KC", AwayTeam: "BAL", State: GameStatePost},
+		{HomeTeam: "BUF", AwayTeam: "MIA", State: GameStateIn},
+		{HomeTeam: "SF", AwayTeam: "DAL", State: GameStatePre},
+	}
+	boxScores := []BoxScore{
+		{MatchupID: "m1", TeamID: 1, Points: 42.5, ProjectedPoints: 110, Players: []BoxScorePlayer{
+			{Team: "KC", Starter: true},
+			{Team: "BUF", Starter: true},
+			{Team: "SF", Starter: true},
+			{Team: "DAL", Starter: false},
+			{Team: "CHI", Starter: true}, // on a bye
+		}},
+		{MatchupID: "m1", TeamID: 2, Points: 30, ProjectedPoints: 105, Players: []BoxScorePlayer{
+			{Team: "DAL", Starter: true},
+			{Team: "DAL", Starter: true},
+		}},
+		{MatchupID: "m2", TeamID: 3, Points: 0},
+	}
+	now := time.Date(2024, 9, 22, 18, 0, 0, 0, time.UTC)
+
+	scoring := liveScoring(3, boxScores, games, now)
+	assert.True(t, scoring.Live)
+	assert.Equal(t, now, scoring.UpdatedAt)
+	require.Len(t, scoring.Matchups, 2)
+
+	m1 := scoring.Matchups[0]
+	assert.Equal(t, LiveTeam{TeamID: 1, Points: 42.5, ProjectedPoints: 110, YetToPlay: 1, InPlay: 1, Finished: 2}, m1.Home)
+	require.NotNil(t, m1.Away)
+	assert.Equal(t, LiveTeam{TeamID: 2, Points: 30, ProjectedPoints: 105, YetToPlay: 2}, *m1.Away)
+	assert.Nil(t, scoring.Matchups[1].Away)
+
+	games[1].State = GameStatePost
+	assert.False(t, liveScoring(3, boxScores, games, now).Live)
+}
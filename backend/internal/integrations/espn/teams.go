@@ -0,0 +1,96 @@
+package espn
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// NFLTeam is an NFL team's names, colors and logo
+type NFLTeam struct {
+	ID             int    `json:"id"`
+	Abbreviation   string `json:"abbreviation"`
+	Name           string `json:"name"`     // e.g. "Kansas City Chiefs"
+	Location       string `json:"location"` // e.g. "Kansas City"
+	Nickname       string `json:"nickname"` // e.g. "Chiefs"
+	Color          string `json:"color,omitempty"`
+	AlternateColor string `json:"alternateColor,omitempty"`
+	LogoURL        string `json:"logoUrl,omitempty"`
+	// DarkLogoURL is the logo variant for dark backgrounds, when ESPN has one
+	DarkLogoURL string `json:"darkLogoUrl,omitempty"`
+}
+
+// teamsResponse is ESPN's NFL team list
+type teamsResponse struct {
+	Sports []struct {
+		Leagues []struct {
+			Teams []struct {
+				Team struct {
+					ID             string `json:"id"`
+					Abbreviation   string `json:"abbreviation"`
+					DisplayName    string `json:"displayName"`
+					Location       string `json:"location"`
+					Name           string `json:"name"`
+					Color          string `json:"color"`
+					AlternateColor string `json:"alternateColor"`
+					Logos          []struct {
+						Href string   `json:"href"`
+						Rel  []string `json:"rel"`
+					} `json:"logos"`
+				} `json:"team"`
+			} `json:"teams"`
+		} `json:"leagues"`
+	} `json:"sports"`
+}
+
+// GetNFLTeams fetches every NFL team's names, colors and logos, ordered by
+// abbreviation
+func (c *ESPNClient) GetNFLTeams(ctx context.Context) ([]NFLTeam, error) {
+	var response teamsResponse
+	if err := c.makeRequest(ctx, "GET", c.teamsURL, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get NFL teams: %w", err)
+	}
+
+	return response.teams(), nil
+}
+
+// teams flattens the response into NFLTeams
+func (r teamsResponse) teams() []NFLTeam {
+	var teams []NFLTeam
+	for _, sport := range r.Sports {
+		for _, league := range sport.Leagues {
+			for _, entry := range league.Teams {
+				t := entry.Team
+				id, _ := strconv.Atoi(t.ID)
+				team := NFLTeam{
+					ID:             id,
+					Abbreviation:   t.Abbreviation,
+					Name:           t.DisplayName,
+					Location:       t.Location,
+					Nickname:       t.Name,
+					Color:          t.Color,
+					AlternateColor: t.AlternateColor,
+				}
+				for _, logo := range t.Logos {
+					dark := false
+					for _, rel := range logo.Rel {
+						dark = dark || rel == "dark"
+					}
+					switch {
+					case dark && team.DarkLogoURL == "":
+						team.DarkLogoURL = logo.Href
+					case !dark && team.LogoURL == "":
+						team.LogoURL = logo.Href
+					}
+				}
+				teams = append(teams, team)
+			}
+		}
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Abbreviation < teams[j].Abbreviation
+	})
+	return teams
+}
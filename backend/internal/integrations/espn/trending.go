@@ -0,0 +1,118 @@
+package espn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Trending directions
+const (
+	TrendingAdds  = "adds"
+	TrendingDrops = "drops"
+)
+
+// maxTrendingPlayers caps a trending request
+const maxTrendingPlayers = 100
+
+// positionSlots maps positions to the lineup slot IDs ESPN filters players by
+var positionSlots = map[string]int{
+	"QB":  0,
+	"RB":  2,
+	"WR":  4,
+	"TE":  6,
+	"DST": 16,
+	"K":   17,
+}
+
+// TrendingPlayer is a player whose ownership across ESPN leagues moved over
+// the last week
+type TrendingPlayer struct {
+	PlayerID             string  `json:"playerId"`
+	PlayerName           string  `json:"playerName"`
+	Position             string  `json:"position"`
+	Team                 string  `json:"team"`
+	InjuryStatus         string  `json:"injuryStatus,omitempty"`
+	PercentOwned         float64 `json:"percentOwned"`
+	PercentOwnedChange   float64 `json:"percentOwnedChange"`
+	PercentStarted       float64 `json:"percentStarted"`
+	PercentStartedChange float64 `json:"percentStartedChange"`
+}
+
+// GetTrendingPlayers fetches the players most added (TrendingAdds) or most
+// dropped (TrendingDrops) across ESPN leagues, ranked by the change in
+// percent owned. position optionally limits the list to QB, RB, WR, TE, DST
+// or K.
+func (c *ESPNClient) GetTrendingPlayers(ctx context.Context, season int, direction, position string, limit int) ([]TrendingPlayer, error) {
+	if direction != TrendingAdds && direction != TrendingDrops {
+		return nil, fmt.Errorf("invalid trending direction %q", direction)
+	}
+	if limit <= 0 || limit > maxTrendingPlayers {
+		limit = maxTrendingPlayers
+	}
+
+	slots := []int{0, 2, 4, 6, 16, 17}
+	if position != "" {
+		slot, ok := positionSlots[position]
+		if !ok {
+			return nil, fmt.Errorf("invalid position %q", position)
+		}
+		slots = []int{slot}
+	}
+
+	data, err := json.Marshal(playerFilter{
+		Players: playerFilterOptions{
+			FilterSlotIDs:   filterValue{Value: slots},
+			Limit:           limit,
+			SortPercChanged: &filterOrder{SortPriority: 1, SortAsc: direction == TrendingDrops},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build player filter: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/seasons/%d/segments/0/leaguedefaults/3?view=kona_player_info&scoringPeriodId=0",
+		c.baseURL, season)
+
+	var response struct {
+		Players []struct {
+			Player struct {
+				ID                int    `json:"id"`
+				FullName          string `json:"fullName"`
+				DefaultPositionID int    `json:"defaultPositionId"`
+				ProTeamID         int    `json:"proTeamId"`
+				InjuryStatus      string `json:"injuryStatus"`
+				Ownership         struct {
+					PercentOwned         float64 `json:"percentOwned"`
+					PercentChange        float64 `json:"percentChange"`
+					PercentStarted       float64 `json:"percentStarted"`
+					PercentStartedChange float64 `json:"percentStartedChange"`
+				} `json:"ownership"`
+			} `json:"player"`
+		} `json:"players"`
+	}
+
+	headers := map[string]string{"X-Fantasy-Filter": string(data)}
+	if err := c.makeRequestWithHeaders(ctx, "GET", endpoint, nil, headers, &response); err != nil {
+		return nil, fmt.Errorf("failed to get trending players: %w", err)
+	}
+
+	players := make([]TrendingPlayer, 0, len(response.Players))
+	for _, entry := range response.Players {
+		p := entry.Player
+		players = append(players, TrendingPlayer{
+			PlayerID:             strconv.Itoa(p.ID),
+			PlayerName:           p.FullName,
+			Position:             PositionName(p.DefaultPositionID),
+			Team:                 TeamAbbreviation(p.ProTeamID),
+			InjuryStatus:         p.InjuryStatus,
+			PercentOwned:         p.Ownership.PercentOwned,
+			PercentOwnedChange:   p.Ownership.PercentChange,
+			PercentStarted:       p.Ownership.PercentStarted,
+			PercentStartedChange: p.Ownership.PercentStartedChange,
+		})
+	}
+
+	return players, nil
+}
@@ -0,0 +1,341 @@
+package fantasypros
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// baseURL is FantasyPros' NFL site
+const baseURL = "https://www.fantasypros.com/nfl"
+
+// Scoring formats, named as draft settings name them
+const (
+	FormatStandard = "STANDARD"
+	FormatHalfPPR  = "HALF_PPR"
+	FormatPPR      = "PPR"
+)
+
+// Formats lists every scoring format FantasyPros ranks
+var Formats = []string{FormatStandard, FormatHalfPPR, FormatPPR}
+
+// pages are each format's draft cheat sheet and ADP pages
+var pages = map[string]struct{ rankings, adp string }{
+	FormatStandard: {"rankings/consensus-cheatsheets.php", "adp/overall.php"},
+	FormatHalfPPR:  {"rankings/half-point-ppr-cheatsheets.php", "adp/half-point-ppr-overall.php"},
+	FormatPPR:      {"rankings/ppr-cheatsheets.php", "adp/ppr-overall.php"},
+}
+
+// ErrLayoutChanged is returned when a page no longer has the data where the
+// scraper looks for it
+var ErrLayoutChanged = errors.New("FantasyPros page layout changed")
+
+// Ranking is a player's place in FantasyPros' expert consensus draft
+// rankings (ECR)
+type Ranking struct {
+	PlayerID     string  `json:"player_id"` // FantasyPros player ID
+	PlayerName   string  `json:"player_name"`
+	Team         string  `json:"team"`
+	Position     string  `json:"position"`
+	Rank         int     `json:"rank"`
+	PositionRank int     `json:"position_rank"`
+	BestRank     int     `json:"best_rank"`
+	WorstRank    int     `json:"worst_rank"`
+	AvgRank      float64 `json:"avg_rank"`
+	StdDev       float64 `json:"std_dev"`
+	Tier         int     `json:"tier"`
+}
+
+// ADP is a player's average draft position across the sites FantasyPros
+// tracks
+type ADP struct {
+	PlayerName   string  `json:"player_name"`
+	Team         string  `json:"team"`
+	Position     string  `json:"position"`
+	Rank         int     `json:"rank"`
+	PositionRank int     `json:"position_rank"`
+	ADP          float64 `json:"adp"`
+}
+
+// Client fetches FantasyPros draft rankings and ADP
+type Client interface {
+	GetRankings(ctx context.Context, format string) ([]Ranking, error)
+	GetADP(ctx context.Context, format string) ([]ADP, error)
+}
+
+// FantasyProsClient scrapes FantasyPros' public draft pages
+type FantasyProsClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewFantasyProsClient creates a FantasyPros scraper
+func NewFantasyProsClient() *FantasyProsClient {
+	return &FantasyProsClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    baseURL,
+	}
+}
+
+// GetRankings fetches the current expert consensus draft rankings for a
+// scoring format, best first
+func (c *FantasyProsClient) GetRankings(ctx context.Context, format string) ([]Ranking, error) {
+	page, ok := pages[format]
+	if !ok {
+		return nil, fmt.Errorf("invalid scoring format %q", format)
+	}
+
+	body, err := c.get(ctx, page.rankings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s rankings: %w", format, err)
+	}
+	rankings, err := parseRankings(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s rankings: %w", format, err)
+	}
+	return rankings, nil
+}
+
+// GetADP fetches the current average draft positions for a scoring format,
+// earliest first
+func (c *FantasyProsClient) GetADP(ctx context.Context, format string) ([]ADP, error) {
+	page, ok := pages[format]
+	if !ok {
+		return nil, fmt.Errorf("invalid scoring format %q", format)
+	}
+
+	body, err := c.get(ctx, page.adp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s ADP: %w", format, err)
+	}
+	adp, err := parseADP(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s ADP: %w", format, err)
+	}
+	return adp, nil
+}
+
+// get downloads a page
+func (c *FantasyProsClient) get(ctx context.Context, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/"+path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; nfl-analytics/1.0)")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("FantasyPros returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	return string(body), nil
+}
+
+// ecrDataPattern finds the rankings the cheat sheet page embeds as a script
+// variable
+var ecrDataPattern = regexp.MustCompile(`(?s)var ecrData = (\{.*?\});\s*\n`)
+
+// ecrData is the part of the embedded rankings we read. Some numbers are
+// sent as strings.
+type ecrData struct {
+	Players []struct {
+		PlayerID   json.Number `json:"player_id"`
+		PlayerName string      `json:"player_name"`
+		Team       string      `json:"player_team_id"`
+		Position   string      `json:"player_position_id"`
+		RankECR    flexFloat   `json:"rank_ecr"`
+		RankMin    flexFloat   `json:"rank_min"`
+		RankMax    flexFloat   `json:"rank_max"`
+		RankAve    flexFloat   `json:"rank_ave"`
+		RankStd    flexFloat   `json:"rank_std"`
+		PosRank    string      `json:"pos_rank"`
+		Tier       flexFloat   `json:"tier"`
+	} `json:"players"`
+}
+
+// parseRankings reads the rankings embedded in a cheat sheet page
+func parseRankings(page string) ([]Ranking, error) {
+	match := ecrDataPattern.FindStringSubmatch(page)
+	if match == nil {
+		return nil, ErrLayoutChanged
+	}
+
+	var data ecrData
+	if err := json.Unmarshal([]byte(match[1]), &data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLayoutChanged, err)
+	}
+
+	rankings := make([]Ranking, 0, len(data.Players))
+	for _, p := range data.Players {
+		position, positionRank := splitPositionRank(p.PosRank)
+		if position == "" {
+			position = normalizePosition(p.Position)
+		}
+		rankings = append(rankings, Ranking{
+			PlayerID:     p.PlayerID.String(),
+			PlayerName:   p.PlayerName,
+			Team:         normalizeTeam(p.Team),
+			Position:     position,
+			Rank:         int(p.RankECR),
+			PositionRank: positionRank,
+			BestRank:     int(p.RankMin),
+			WorstRank:    int(p.RankMax),
+			AvgRank:      float64(p.RankAve),
+			StdDev:       float64(p.RankStd),
+			Tier:         int(p.Tier),
+		})
+	}
+	return rankings, nil
+}
+
+var (
+	tablePattern      = regexp.MustCompile(`(?s)<table[^>]*id="data"[^>]*>(.*?)</table>`)
+	rowPattern        = regexp.MustCompile(`(?s)<tr[^>]*>(.*?)</tr>`)
+	headerPattern     = regexp.MustCompile(`(?s)<th[^>]*>(.*?)</th>`)
+	cellPattern       = regexp.MustCompile(`(?s)<td[^>]*>(.*?)</td>`)
+	playerNamePattern = regexp.MustCompile(`(?s)class="player-name"[^>]*>(.*?)</a>`)
+	smallPattern      = regexp.MustCompile(`(?s)<small[^>]*>(.*?)</small>`)
+	tagPattern        = regexp.MustCompile(`<[^>]*>`)
+)
+
+// parseADP reads the ADP table. Its columns are the rank, the player with
+// their team and bye, the position rank, one column per draft site and the
+// average, which is the last column.
+func parseADP(page string) ([]ADP, error) {
+	table := tablePattern.FindStringSubmatch(page)
+	if table == nil {
+		return nil, ErrLayoutChanged
+	}
+
+	var adp []ADP
+	playerColumn, positionColumn, adpColumn := -1, -1, -1
+	for _, row := range rowPattern.FindAllStringSubmatch(table[1], -1) {
+		if headers := headerPattern.FindAllStringSubmatch(row[1], -1); len(headers) > 0 {
+			for i, h := range headers {
+				switch name := strings.ToUpper(text(h[1])); {
+				case strings.HasPrefix(name, "PLAYER"):
+					playerColumn = i
+				case name == "POS":
+					positionColumn = i
+				case name == "AVG":
+					adpColumn = i
+				}
+			}
+			continue
+		}
+		if playerColumn < 0 || positionColumn < 0 || adpColumn < 0 {
+			return nil, ErrLayoutChanged
+		}
+
+		cells := cellPattern.FindAllStringSubmatch(row[1], -1)
+		if len(cells) <= adpColumn || len(cells) <= playerColumn || len(cells) <= positionColumn {
+			continue
+		}
+		rank, err := strconv.Atoi(text(cells[0][1]))
+		if err != nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(text(cells[adpColumn][1]), 64)
+		if err != nil {
+			continue
+		}
+
+		player := cells[playerColumn][1]
+		name := text(player)
+		if m := playerNamePattern.FindStringSubmatch(player); m != nil {
+			name = text(m[1])
+		}
+		var team string
+		if m := smallPattern.FindStringSubmatch(player); m != nil {
+			team = normalizeTeam(text(m[1]))
+		}
+		position, positionRank := splitPositionRank(text(cells[positionColumn][1]))
+
+		adp = append(adp, ADP{
+			PlayerName:   name,
+			Team:         team,
+			Position:     position,
+			Rank:         rank,
+			PositionRank: positionRank,
+			ADP:          value,
+		})
+	}
+	if playerColumn < 0 {
+		return nil, ErrLayoutChanged
+	}
+	return adp, nil
+}
+
+// text strips tags and entities from an HTML fragment
+func text(fragment string) string {
+	return strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(fragment, "")))
+}
+
+// splitPositionRank splits a position rank such as "WR12" into the position
+// and rank
+func splitPositionRank(pos string) (string, int) {
+	pos = strings.ToUpper(strings.TrimSpace(pos))
+	i := strings.IndexFunc(pos, func(r rune) bool { return r >= '0' && r <= '9' })
+	if i < 0 {
+		return normalizePosition(pos), 0
+	}
+	rank, _ := strconv.Atoi(pos[i:])
+	return normalizePosition(pos[:i]), rank
+}
+
+func normalizePosition(pos string) string {
+	if pos == "DEF" || pos == "D/ST" {
+		return "DST"
+	}
+	return pos
+}
+
+// normalizeTeam converts FantasyPros team abbreviations to the ones used
+// elsewhere in the app
+func normalizeTeam(team string) string {
+	team = strings.ToUpper(strings.TrimSpace(team))
+	switch team {
+	case "JAC":
+		return "JAX"
+	case "WAS":
+		return "WSH"
+	case "FA":
+		return ""
+	}
+	return team
+}
+
+// flexFloat decodes a JSON number that may be sent as a string
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*f = flexFloat(v)
+	return nil
+}
@@ -0,0 +1,75 @@
+package fantasypros
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cheatSheetPage = `<html><head><script>
+var sport = "nfl";
+var ecrData = {"sport":"NFL","type":"draft","scoring":"PPR","players":[
+	{"player_id":16393,"player_name":"Christian McCaffrey","player_team_id":"SF","player_position_id":"RB","rank_ecr":1,"rank_min":"1","rank_max":"4","rank_ave":"1.3","rank_std":"0.6","pos_rank":"RB1","tier":1},
+	{"player_id":17240,"player_name":"Jacksonville Defense","player_team_id":"JAC","player_position_id":"DST","rank_ecr":160,"rank_min":"120","rank_max":"210","rank_ave":"158.2","rank_std":"18.1","pos_rank":"DST5","tier":"14"}
+]};
+var adpData = [];
+</script></head></html>`
+
+const adpPage = `<html><body>
+<table id="data" class="table">
+<thead><tr><th>Rank</th><th>Player Team (Bye)</th><th>POS</th><th>ESPN</th><th>Sleeper</th><th>AVG</th></tr></thead>
+<tbody>
+<tr class="player-row"><td>1</td><td class="player-label"><a href="/nfl/players/christian-mccaffrey.php" class="player-name">Christian McCaffrey</a> <small>SF</small> <small>(9)</small></td><td>RB1</td><td>1.0</td><td>1.0</td><td>1.2</td></tr>
+<tr class="player-row"><td>2</td><td class="player-label"><a href="/nfl/players/ja-marr-chase.php" class="player-name">Ja&#39;Marr Chase</a> <small>CIN</small> <small>(12)</small></td><td>WR1</td><td>2.0</td><td>3.0</td><td>2.6</td></tr>
+</tbody>
+</table></body></html>`
+
+func TestGetRankingsAndADP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rankings/ppr-cheatsheets.php":
+			w.Write([]byte(cheatSheetPage))
+		case "/adp/ppr-overall.php":
+			w.Write([]byte(adpPage))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewFantasyProsClient()
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	rankings, err := client.GetRankings(ctx, FormatPPR)
+	require.NoError(t, err)
+	require.Len(t, rankings, 2)
+	assert.Equal(t, Ranking{
+		PlayerID: "16393", PlayerName: "Christian McCaffrey", Team: "SF", Position: "RB",
+		Rank: 1, PositionRank: 1, BestRank: 1, WorstRank: 4, AvgRank: 1.3, StdDev: 0.6, Tier: 1,
+	}, rankings[0])
+	assert.Equal(t, "JAX", rankings[1].Team)
+	assert.Equal(t, "DST", rankings[1].Position)
+	assert.Equal(t, 14, rankings[1].Tier)
+
+	adp, err := client.GetADP(ctx, FormatPPR)
+	require.NoError(t, err)
+	require.Len(t, adp, 2)
+	assert.Equal(t, ADP{PlayerName: "Christian McCaffrey", Team: "SF", Position: "RB", Rank: 1, PositionRank: 1, ADP: 1.2}, adp[0])
+	assert.Equal(t, "Ja'Marr Chase", adp[1].PlayerName)
+	assert.Equal(t, 2.6, adp[1].ADP)
+
+	_, err = client.GetRankings(ctx, "TWO_QB")
+	assert.Error(t, err)
+
+	// A page without the data fails loudly rather than returning nothing
+	_, err = client.GetADP(ctx, FormatStandard)
+	assert.Error(t, err)
+	_, err = parseRankings("<html></html>")
+	assert.True(t, errors.Is(err, ErrLayoutChanged))
+}
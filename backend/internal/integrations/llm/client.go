@@ -0,0 +1,212 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Supported providers
+const (
+	ProviderAnthropic = "anthropic"
+	// ProviderOpenAI also covers any server with an OpenAI compatible chat
+	// completions API, given its base URL
+	ProviderOpenAI = "openai"
+)
+
+const (
+	anthropicBaseURL = "https://api.anthropic.com"
+	anthropicVersion = "2023-06-01"
+	openAIBaseURL    = "https://api.openai.com"
+	// defaultMaxTokens caps answers when a request does not
+	defaultMaxTokens = 1024
+)
+
+var (
+	// ErrUnauthorized is returned when the provider rejects the API key
+	ErrUnauthorized = errors.New("LLM provider rejected the API key")
+	// ErrRateLimited is returned when the provider is throttling us
+	ErrRateLimited = errors.New("LLM provider rate limit exceeded")
+)
+
+// Request is a single-turn completion
+type Request struct {
+	System    string
+	Prompt    string
+	MaxTokens int
+}
+
+// Response is the model's reply
+type Response struct {
+	Text  string
+	Model string
+}
+
+// Client completes prompts with a language model
+type Client interface {
+	Complete(ctx context.Context, req Request) (*Response, error)
+}
+
+// NewClient creates a client for provider. baseURL overrides the provider's
+// API host and may be empty.
+func NewClient(provider, apiKey, model, baseURL string, timeout time.Duration) (Client, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("an API key is required for %s", provider)
+	}
+	if model == "" {
+		return nil, fmt.Errorf("a model is required for %s", provider)
+	}
+
+	httpClient := &http.Client{Timeout: timeout}
+	switch strings.ToLower(provider) {
+	case ProviderAnthropic:
+		if baseURL == "" {
+			baseURL = anthropicBaseURL
+		}
+		return &AnthropicClient{httpClient: httpClient, baseURL: strings.TrimSuffix(baseURL, "/"), apiKey: apiKey, model: model}, nil
+	case ProviderOpenAI:
+		if baseURL == "" {
+			baseURL = openAIBaseURL
+		}
+		return &OpenAIClient{httpClient: httpClient, baseURL: strings.TrimSuffix(baseURL, "/"), apiKey: apiKey, model: model}, nil
+	default:
+		return nil, fmt.Errorf("unknown LLM provider %q", provider)
+	}
+}
+
+// AnthropicClient completes prompts with the Anthropic Messages API
+type AnthropicClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+}
+
+// Complete sends the prompt as a single user message
+func (c *AnthropicClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	body := map[string]interface{}{
+		"model":      c.model,
+		"max_tokens": maxTokens(req),
+		"messages":   []map[string]string{{"role": "user", "content": req.Prompt}},
+	}
+	if req.System != "" {
+		body["system"] = req.System
+	}
+	headers := map[string]string{
+		"x-api-key":         c.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+
+	var resp struct {
+		Model   string `json:"model"`
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := post(ctx, c.httpClient, c.baseURL+"/v1/messages", headers, body, &resp); err != nil {
+		return nil, err
+	}
+
+	var text strings.Builder
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	return &Response{Text: text.String(), Model: resp.Model}, nil
+}
+
+// OpenAIClient completes prompts with an OpenAI compatible chat completions
+// API
+type OpenAIClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+}
+
+// Complete sends the system prompt and a single user message
+func (c *OpenAIClient) Complete(ctx context.Context, req Request) (*Response, error) {
+	var messages []map[string]string
+	if req.System != "" {
+		messages = append(messages, map[string]string{"role": "system", "content": req.System})
+	}
+	messages = append(messages, map[string]string{"role": "user", "content": req.Prompt})
+
+	body := map[string]interface{}{
+		"model":      c.model,
+		"max_tokens": maxTokens(req),
+		"messages":   messages,
+	}
+	headers := map[string]string{"Authorization": "Bearer " + c.apiKey}
+
+	var resp struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := post(ctx, c.httpClient, c.baseURL+"/v1/chat/completions", headers, body, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("LLM response had no choices")
+	}
+	return &Response{Text: resp.Choices[0].Message.Content, Model: resp.Model}, nil
+}
+
+func maxTokens(req Request) int {
+	if req.MaxTokens > 0 {
+		return req.MaxTokens
+	}
+	return defaultMaxTokens
+}
+
+// post sends a JSON request and decodes the JSON response
+func post(ctx context.Context, httpClient *http.Client, url string, headers map[string]string, body, result interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("LLM API error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
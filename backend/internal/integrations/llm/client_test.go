@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnthropicClient_Complete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "test-model", body["model"])
+		assert.Equal(t, "Be brief", body["system"])
+		assert.Equal(t, float64(defaultMaxTokens), body["max_tokens"])
+
+		w.Write([]byte(`{"model":"test-model","content":[{"type":"text","text":"Start "},{"type":"text","text":"him [F1]"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ProviderAnthropic, "test-key", "test-model", server.URL, time.Second)
+	require.NoError(t, err)
+
+	resp, err := client.Complete(context.Background(), Request{System: "Be brief", Prompt: "Who?"})
+	require.NoError(t, err)
+	assert.Equal(t, "Start him [F1]", resp.Text)
+	assert.Equal(t, "test-model", resp.Model)
+}
+
+func TestOpenAIClient_Complete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		var body struct {
+			Messages []map[string]string `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Messages, 2)
+		assert.Equal(t, "system", body.Messages[0]["role"])
+		assert.Equal(t, "Who?", body.Messages[1]["content"])
+
+		w.Write([]byte(`{"model":"test-model","choices":[{"message":{"role":"assistant","content":"Sit him [F2]"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ProviderOpenAI, "test-key", "test-model", server.URL+"/", time.Second)
+	require.NoError(t, err)
+
+	resp, err := client.Complete(context.Background(), Request{System: "Be brief", Prompt: "Who?"})
+	require.NoError(t, err)
+	assert.Equal(t, "Sit him [F2]", resp.Text)
+}
+
+func TestComplete_Errors(t *testing.T) {
+	status := http.StatusUnauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ProviderOpenAI, "test-key", "test-model", server.URL, time.Second)
+	require.NoError(t, err)
+
+	_, err = client.Complete(context.Background(), Request{Prompt: "Who?"})
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	status = http.StatusTooManyRequests
+	_, err = client.Complete(context.Background(), Request{Prompt: "Who?"})
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
+
+func TestNewClient_Validation(t *testing.T) {
+	_, err := NewClient("other", "key", "model", "", time.Second)
+	assert.Error(t, err)
+	_, err = NewClient(ProviderAnthropic, "", "model", "", time.Second)
+	assert.Error(t, err)
+	_, err = NewClient(ProviderAnthropic, "key", "", "", time.Second)
+	assert.Error(t, err)
+}
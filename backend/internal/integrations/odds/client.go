@@ -0,0 +1,212 @@
+package odds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// baseURL is The Odds API
+	baseURL = "https://api.the-odds-api.com/v4"
+	// sportKey is the NFL on The Odds API
+	sportKey = "americanfootball_nfl"
+)
+
+// Player prop markets on The Odds API
+const (
+	MarketPassYards     = "player_pass_yds"
+	MarketPassTDs       = "player_pass_tds"
+	MarketInterceptions = "player_pass_interceptions"
+	MarketRushYards     = "player_rush_yds"
+	MarketReceptions    = "player_receptions"
+	MarketReceiveYards  = "player_reception_yds"
+	MarketAnytimeTD     = "player_anytime_td"
+)
+
+// DefaultMarkets are the props that map to projected stats. Every market is
+// billed against the API quota per event, so keep the list short.
+var DefaultMarkets = []string{
+	MarketPassYards,
+	MarketPassTDs,
+	MarketInterceptions,
+	MarketRushYards,
+	MarketReceptions,
+	MarketReceiveYards,
+	MarketAnytimeTD,
+}
+
+var (
+	// ErrUnauthorized is returned when the API key is missing or invalid
+	ErrUnauthorized = errors.New("odds API rejected the API key")
+	// ErrQuotaExceeded is returned once the plan's monthly requests are used up
+	ErrQuotaExceeded = errors.New("odds API request quota exceeded")
+)
+
+// Event is an upcoming game with odds
+type Event struct {
+	ID           string    `json:"id"`
+	CommenceTime time.Time `json:"commence_time"`
+	HomeTeam     string    `json:"home_team"`
+	AwayTeam     string    `json:"away_team"`
+}
+
+// PlayerProp is one bookmaker's line on one player stat. Anytime touchdown
+// props have no line and no under price.
+type PlayerProp struct {
+	EventID    string    `json:"event_id"`
+	GameTime   time.Time `json:"game_time"`
+	Bookmaker  string    `json:"bookmaker"`
+	Market     string    `json:"market"`
+	PlayerName string    `json:"player_name"`
+	Line       float64   `json:"line"`
+	OverPrice  int       `json:"over_price"` // American odds
+	UnderPrice int       `json:"under_price,omitempty"`
+}
+
+// Client fetches NFL player props
+type Client interface {
+	GetEvents(ctx context.Context) ([]Event, error)
+	GetPlayerProps(ctx context.Context, event Event, markets, bookmakers []string) ([]PlayerProp, error)
+}
+
+// OddsAPIClient reads player props from The Odds API
+type OddsAPIClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+}
+
+// NewOddsAPIClient creates a client for The Odds API
+func NewOddsAPIClient(apiKey string) *OddsAPIClient {
+	return &OddsAPIClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+	}
+}
+
+// GetEvents lists upcoming NFL games. Listing events does not count against
+// the quota.
+func (c *OddsAPIClient) GetEvents(ctx context.Context) ([]Event, error) {
+	var events []Event
+	if err := c.get(ctx, "/sports/"+sportKey+"/events", nil, &events); err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	return events, nil
+}
+
+// GetPlayerProps fetches a game's player props from the given bookmakers
+func (c *OddsAPIClient) GetPlayerProps(ctx context.Context, event Event, markets, bookmakers []string) ([]PlayerProp, error) {
+	params := url.Values{}
+	params.Set("markets", strings.Join(markets, ","))
+	params.Set("oddsFormat", "american")
+	if len(bookmakers) > 0 {
+		params.Set("bookmakers", strings.Join(bookmakers, ","))
+	} else {
+		params.Set("regions", "us")
+	}
+
+	var resp eventOdds
+	if err := c.get(ctx, "/sports/"+sportKey+"/events/"+event.ID+"/odds", params, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get props for %s at %s: %w", event.AwayTeam, event.HomeTeam, err)
+	}
+	return resp.props(event), nil
+}
+
+// get calls an endpoint and decodes its JSON response
+func (c *OddsAPIClient) get(ctx context.Context, path string, params url.Values, result interface{}) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("apiKey", c.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if remaining := resp.Header.Get("x-requests-remaining"); remaining != "" {
+		log.Printf("metrics odds_api_quota remaining=%s used=%s", remaining, resp.Header.Get("x-requests-used"))
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrQuotaExceeded
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("odds API error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// eventOdds is The Odds API's event odds response
+type eventOdds struct {
+	Bookmakers []struct {
+		Key     string `json:"key"`
+		Markets []struct {
+			Key      string `json:"key"`
+			Outcomes []struct {
+				Name        string  `json:"name"`        // Over, Under or Yes
+				Description string  `json:"description"` // the player
+				Price       int     `json:"price"`
+				Point       float64 `json:"point"`
+			} `json:"outcomes"`
+		} `json:"markets"`
+	} `json:"bookmakers"`
+}
+
+// props pairs each player's over and under outcomes into one prop per
+// bookmaker, market and line
+func (o eventOdds) props(event Event) []PlayerProp {
+	var props []PlayerProp
+	for _, book := range o.Bookmakers {
+		for _, market := range book.Markets {
+			index := make(map[string]int)
+			for _, outcome := range market.Outcomes {
+				key := fmt.Sprintf("%s|%g", outcome.Description, outcome.Point)
+				i, ok := index[key]
+				if !ok {
+					i = len(props)
+					index[key] = i
+					props = append(props, PlayerProp{
+						EventID:    event.ID,
+						GameTime:   event.CommenceTime,
+						Bookmaker:  book.Key,
+						Market:     market.Key,
+						PlayerName: outcome.Description,
+						Line:       outcome.Point,
+					})
+				}
+				switch outcome.Name {
+				case "Over", "Yes":
+					props[i].OverPrice = outcome.Price
+				case "Under", "No":
+					props[i].UnderPrice = outcome.Price
+				}
+			}
+		}
+	}
+	return props
+}
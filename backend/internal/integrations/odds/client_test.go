@@ -0,0 +1,74 @@
+package odds
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPlayerProps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-key", r.URL.Query().Get("apiKey"))
+		switch r.URL.Path {
+		case "/sports/americanfootball_nfl/events":
+			w.Write([]byte(`[{"id":"ev1","commence_time":"2024-09-06T00:20:00Z","home_team":"Kansas City Chiefs","away_team":"Baltimore Ravens"}]`))
+		case "/sports/americanfootball_nfl/events/ev1/odds":
+			assert.Equal(t, "pinnacle", r.URL.Query().Get("bookmakers"))
+			assert.Equal(t, "player_pass_yds,player_anytime_td", r.URL.Query().Get("markets"))
+			w.Header().Set("x-requests-remaining", "480")
+			w.Write([]byte(`{"id":"ev1","bookmakers":[{"key":"pinnacle","markets":[
+				{"key":"player_pass_yds","outcomes":[
+					{"name":"Over","description":"Patrick Mahomes","price":-120,"point":265.5},
+					{"name":"Under","description":"Patrick Mahomes","price":100,"point":265.5}]},
+				{"key":"player_anytime_td","outcomes":[
+					{"name":"Yes","description":"Travis Kelce","price":130}]}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewOddsAPIClient("test-key")
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	events, err := client.GetEvents(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, time.Date(2024, 9, 6, 0, 20, 0, 0, time.UTC), events[0].CommenceTime)
+
+	props, err := client.GetPlayerProps(ctx, events[0], []string{MarketPassYards, MarketAnytimeTD}, []string{"pinnacle"})
+	require.NoError(t, err)
+	require.Len(t, props, 2)
+
+	assert.Equal(t, PlayerProp{
+		EventID: "ev1", GameTime: events[0].CommenceTime, Bookmaker: "pinnacle", Market: MarketPassYards,
+		PlayerName: "Patrick Mahomes", Line: 265.5, OverPrice: -120, UnderPrice: 100,
+	}, props[0])
+	assert.Equal(t, "Travis Kelce", props[1].PlayerName)
+	assert.Equal(t, 130, props[1].OverPrice)
+	assert.Zero(t, props[1].UnderPrice)
+}
+
+func TestGetPlayerProps_Errors(t *testing.T) {
+	status := http.StatusUnauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := NewOddsAPIClient("bad-key")
+	client.baseURL = server.URL
+
+	_, err := client.GetEvents(context.Background())
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	status = http.StatusTooManyRequests
+	_, err = client.GetPlayerProps(context.Background(), Event{ID: "ev1"}, DefaultMarkets, nil)
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+}
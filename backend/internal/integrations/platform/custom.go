@@ -0,0 +1,106 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// CustomClient reads a league set up by hand from its stored configuration.
+// There is no platform behind it, so it has teams but no player pool,
+// transactions or draft.
+type CustomClient struct {
+	league *models.League
+}
+
+// NewCustomClient creates a client for a custom league
+func NewCustomClient(league *models.League) *CustomClient {
+	return &CustomClient{league: league}
+}
+
+// Platform returns custom
+func (c *CustomClient) Platform() string {
+	return PlatformCustom
+}
+
+// GetLeague returns the league's name, season, scoring format and teams
+func (c *CustomClient) GetLeague(ctx context.Context, leagueID string) (*League, error) {
+	teams, err := c.teams()
+	if err != nil {
+		return nil, err
+	}
+
+	league := &League{
+		Platform: PlatformCustom,
+		ID:       leagueID,
+		Name:     c.league.Name,
+		Season:   c.league.Season,
+		Teams:    make([]Team, 0, len(teams)),
+	}
+	var settings models.LeagueSettings
+	if err := json.Unmarshal(c.league.Settings, &settings); err == nil {
+		league.ScoringType = settings.ScoringType
+		if scoring, err := projections.ParseScoringRules(settings.ScoringRules); err == nil && league.ScoringType == "" {
+			league.ScoringType = ScoringType(scoring.ReceptionPoints)
+		}
+	}
+	for _, t := range teams {
+		league.Teams = append(league.Teams, Team{
+			ID:        strconv.Itoa(t.ID),
+			Name:      strings.TrimSpace(t.FullName + " " + t.Nickname),
+			OwnerName: t.Owner.Name,
+			Wins:      t.Record.Wins,
+			Losses:    t.Record.Losses,
+			Ties:      t.Record.Ties,
+			PointsFor: t.Points,
+		})
+	}
+	return league, nil
+}
+
+// GetRosters returns an empty roster for each team
+func (c *CustomClient) GetRosters(ctx context.Context, leagueID string) ([]Roster, error) {
+	teams, err := c.teams()
+	if err != nil {
+		return nil, err
+	}
+
+	rosters := make([]Roster, 0, len(teams))
+	for _, t := range teams {
+		rosters = append(rosters, Roster{TeamID: strconv.Itoa(t.ID), Players: []RosterPlayer{}})
+	}
+	return rosters, nil
+}
+
+// GetPlayers returns no players
+func (c *CustomClient) GetPlayers(ctx context.Context, leagueID string, limit int) ([]Player, error) {
+	return []Player{}, nil
+}
+
+// GetTransactions returns no transactions
+func (c *CustomClient) GetTransactions(ctx context.Context, leagueID string, limit int) ([]Transaction, error) {
+	return []Transaction{}, nil
+}
+
+// GetDraft returns no picks
+func (c *CustomClient) GetDraft(ctx context.Context, leagueID string) ([]DraftPick, error) {
+	return []DraftPick{}, nil
+}
+
+// teams reads the teams stored with the league
+func (c *CustomClient) teams() ([]espn.Team, error) {
+	var teams []espn.Team
+	if len(c.league.TeamsData) == 0 {
+		return teams, nil
+	}
+	if err := json.Unmarshal(c.league.TeamsData, &teams); err != nil {
+		return nil, fmt.Errorf("failed to parse league teams: %w", err)
+	}
+	return teams, nil
+}
@@ -0,0 +1,165 @@
+package platform
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+)
+
+// ESPNPlatform reads ESPN leagues through an ESPN client. ESPN errors are
+// returned as they are, so callers can still tell expired cookies and
+// private leagues apart.
+type ESPNPlatform struct {
+	client espn.Client
+}
+
+// NewESPNPlatform wraps an ESPN client, which should already carry the
+// league owner's cookies for private leagues
+func NewESPNPlatform(client espn.Client) *ESPNPlatform {
+	return &ESPNPlatform{client: client}
+}
+
+// Platform returns espn
+func (p *ESPNPlatform) Platform() string {
+	return PlatformESPN
+}
+
+// GetLeague fetches a league's settings and teams
+func (p *ESPNPlatform) GetLeague(ctx context.Context, leagueID string) (*League, error) {
+	info, err := p.client.GetLeagueInfo(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	league := &League{
+		Platform:    PlatformESPN,
+		ID:          info.ID,
+		Name:        info.Name,
+		Season:      info.Season,
+		ScoringType: p.client.DetectScoringFormat(info.Settings),
+		CurrentWeek: info.Status.CurrentWeek,
+		Teams:       make([]Team, 0, len(info.Teams)),
+	}
+	if league.ID == "" {
+		league.ID = leagueID
+	}
+	for _, t := range info.Teams {
+		name := strings.TrimSpace(t.FullName + " " + t.Nickname)
+		if name == "" {
+			name = t.Name
+		}
+		league.Teams = append(league.Teams, Team{
+			ID:        strconv.Itoa(t.ID),
+			Name:      name,
+			OwnerID:   t.Owner.ID,
+			OwnerName: t.Owner.Name,
+			Wins:      t.Record.Wins,
+			Losses:    t.Record.Losses,
+			Ties:      t.Record.Ties,
+			PointsFor: t.Points,
+		})
+	}
+	return league, nil
+}
+
+// GetRosters fetches every team's roster
+func (p *ESPNPlatform) GetRosters(ctx context.Context, leagueID string) ([]Roster, error) {
+	rosters, err := p.client.GetRosters(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Roster, 0, len(rosters))
+	for _, r := range rosters {
+		roster := Roster{TeamID: strconv.Itoa(r.TeamID), Players: make([]RosterPlayer, 0, len(r.Players))}
+		for _, pl := range r.Players {
+			roster.Players = append(roster.Players, RosterPlayer{
+				PlayerID:   pl.PlayerID,
+				Name:       pl.PlayerName,
+				Position:   pl.Position,
+				Team:       pl.Team,
+				Status:     pl.Status,
+				LineupSlot: pl.LineupSlot,
+				Starter:    pl.LineupSlot != "" && pl.LineupSlot != "BE" && pl.LineupSlot != "IR",
+			})
+		}
+		result = append(result, roster)
+	}
+	return result, nil
+}
+
+// GetPlayers fetches the league's free agents
+func (p *ESPNPlatform) GetPlayers(ctx context.Context, leagueID string, limit int) ([]Player, error) {
+	players, err := p.client.GetAvailablePlayers(ctx, leagueID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Player, 0, len(players))
+	for _, pl := range players {
+		result = append(result, Player{
+			ID:           pl.ID,
+			Name:         pl.Name,
+			Position:     pl.Position,
+			Team:         pl.Team,
+			Status:       pl.Status,
+			PercentOwned: pl.PercentOwned,
+		})
+	}
+	return result, nil
+}
+
+// GetTransactions fetches the league's latest transactions. ESPN does not
+// say which way each player moved.
+func (p *ESPNPlatform) GetTransactions(ctx context.Context, leagueID string, limit int) ([]Transaction, error) {
+	transactions, err := p.client.GetTransactions(ctx, leagueID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Transaction, 0, len(transactions))
+	for _, t := range transactions {
+		tx := Transaction{
+			ID:          t.ID,
+			Type:        t.Type,
+			Status:      t.Status,
+			TeamIDs:     []string{strconv.Itoa(t.ProposingTeamID)},
+			Players:     make([]TransactionPlayer, 0, len(t.Players)),
+			Bid:         t.BidAmount,
+			ProcessedAt: t.ProcessDate,
+		}
+		if t.AcceptingTeamID != 0 {
+			tx.TeamIDs = append(tx.TeamIDs, strconv.Itoa(t.AcceptingTeamID))
+		}
+		for _, id := range t.Players {
+			tx.Players = append(tx.Players, TransactionPlayer{PlayerID: id})
+		}
+		result = append(result, tx)
+	}
+	return result, nil
+}
+
+// GetDraft fetches the league's draft picks
+func (p *ESPNPlatform) GetDraft(ctx context.Context, leagueID string) ([]DraftPick, error) {
+	picks, err := p.client.GetDraftResults(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]DraftPick, 0, len(picks))
+	for _, pk := range picks {
+		result = append(result, DraftPick{
+			Round:      pk.Round,
+			Pick:       pk.Pick,
+			Overall:    pk.OverallPick,
+			TeamID:     strconv.Itoa(pk.TeamID),
+			PlayerID:   pk.PlayerID,
+			PlayerName: pk.PlayerName,
+			Keeper:     pk.Keeper,
+			Bid:        pk.BidAmount,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// ESPNCredentials loads a user's ESPN cookies
+type ESPNCredentials interface {
+	GetESPNCredentials(ctx context.Context, userID uuid.UUID) (swid, espnS2 string, err error)
+}
+
+// YahooTokenSource loads a user's Yahoo OAuth access token, refreshing it
+// when it has expired
+type YahooTokenSource interface {
+	YahooAccessToken(ctx context.Context, userID uuid.UUID) (string, error)
+}
+
+// Factory builds the client for a league's platform, authenticated as the
+// league's owner
+type Factory struct {
+	espnClient espn.Client
+	espnCreds  ESPNCredentials
+	sleeper    *SleeperClient
+	yahoo      YahooTokenSource
+}
+
+// NewFactory creates a client factory. ESPN leagues are read with the
+// owner's cookies from creds, or anonymously when they have none.
+func NewFactory(espnClient espn.Client, creds ESPNCredentials) *Factory {
+	return &Factory{
+		espnClient: espnClient,
+		espnCreds:  creds,
+		sleeper:    NewSleeperClient(),
+	}
+}
+
+// WithYahoo enables Yahoo leagues, read with tokens from source. Without it
+// Yahoo leagues fail with ErrCredentialsRequired.
+func (f *Factory) WithYahoo(source YahooTokenSource) *Factory {
+	f.yahoo = source
+	return f
+}
+
+// ForLeague returns the client for the league's platform. Leagues stored
+// before the platform column was filled are ESPN leagues.
+func (f *Factory) ForLeague(ctx context.Context, league *models.League) (PlatformClient, error) {
+	switch strings.ToLower(league.Platform) {
+	case PlatformESPN, "":
+		return f.espn(ctx, league.UserID)
+	case PlatformSleeper:
+		return f.sleeper, nil
+	case PlatformCustom:
+		return NewCustomClient(league), nil
+	case PlatformYahoo:
+		if f.yahoo == nil {
+			return nil, ErrCredentialsRequired
+		}
+		token, err := f.yahoo.YahooAccessToken(ctx, league.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return NewYahooClient(token), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPlatform, league.Platform)
+	}
+}
+
+// espn returns the ESPN client with the user's cookies, or the anonymous
+// client, which still reads public leagues
+func (f *Factory) espn(ctx context.Context, userID uuid.UUID) (PlatformClient, error) {
+	if f.espnCreds == nil {
+		return NewESPNPlatform(f.espnClient), nil
+	}
+
+	swid, espnS2, err := f.espnCreds.GetESPNCredentials(ctx, userID)
+	switch {
+	case err == nil:
+		return NewESPNPlatform(f.espnClient.WithAuthentication(swid, espnS2)), nil
+	case errors.Is(err, repositories.ErrLeagueAuthNotFound):
+		return NewESPNPlatform(f.espnClient), nil
+	default:
+		return nil, err
+	}
+}
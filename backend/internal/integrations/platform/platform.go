@@ -0,0 +1,167 @@
+// Package platform puts the fantasy providers leagues can be connected from
+// behind one client, so services can read leagues, rosters, players,
+// transactions and drafts without knowing which provider hosts the league.
+package platform
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Platforms, as stored on leagues
+const (
+	PlatformESPN    = "espn"
+	PlatformYahoo   = "yahoo"
+	PlatformSleeper = "sleeper"
+	// PlatformCustom leagues are set up by hand, for platforms with no
+	// client
+	PlatformCustom = "custom"
+)
+
+// Transaction types, as ESPN names them
+const (
+	TransactionTrade  = "TRADE"
+	TransactionAdd    = "ADD"
+	TransactionDrop   = "DROP"
+	TransactionWaiver = "WAIVER"
+)
+
+var (
+	// ErrUnsupportedPlatform is returned for leagues on a platform with no
+	// client
+	ErrUnsupportedPlatform = errors.New("unsupported fantasy platform")
+	// ErrCredentialsRequired is returned when a platform needs the user to
+	// connect their account before their leagues can be read
+	ErrCredentialsRequired = errors.New("platform account is not connected")
+	// ErrLeagueNotFound is returned when the platform has no such league
+	ErrLeagueNotFound = errors.New("league not found on platform")
+)
+
+// League is a league's name, season and teams
+type League struct {
+	Platform    string `json:"platform"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Season      int    `json:"season"`
+	ScoringType string `json:"scoring_type,omitempty"` // STANDARD, HALF_PPR or PPR
+	CurrentWeek int    `json:"current_week,omitempty"`
+	Teams       []Team `json:"teams"`
+}
+
+// Team is a fantasy team and its record. IDs are strings because platforms
+// key teams differently.
+type Team struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	OwnerID   string  `json:"owner_id,omitempty"`
+	OwnerName string  `json:"owner_name,omitempty"`
+	Wins      int     `json:"wins"`
+	Losses    int     `json:"losses"`
+	Ties      int     `json:"ties"`
+	PointsFor float64 `json:"points_for"`
+}
+
+// Roster is the players on a team
+type Roster struct {
+	TeamID  string         `json:"team_id"`
+	Players []RosterPlayer `json:"players"`
+}
+
+// RosterPlayer is a player on a roster and where they are slotted
+type RosterPlayer struct {
+	PlayerID   string `json:"player_id"`
+	Name       string `json:"name"`
+	Position   string `json:"position"`
+	Team       string `json:"team,omitempty"`
+	Status     string `json:"status,omitempty"`
+	LineupSlot string `json:"lineup_slot,omitempty"`
+	Starter    bool   `json:"starter"`
+}
+
+// Player is a player in the league's player pool
+type Player struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Position     string  `json:"position"`
+	Team         string  `json:"team,omitempty"`
+	Status       string  `json:"status,omitempty"`
+	PercentOwned float64 `json:"percent_owned,omitempty"`
+}
+
+// Transaction is a trade, add, drop or waiver claim
+type Transaction struct {
+	ID      string              `json:"id"`
+	Type    string              `json:"type"`
+	Status  string              `json:"status"`
+	TeamIDs []string            `json:"team_ids"`
+	Players []TransactionPlayer `json:"players"`
+	// Picks are the future draft picks a trade moved, on platforms that
+	// report them
+	Picks       []TransactionPick `json:"picks,omitempty"`
+	Bid         int               `json:"bid,omitempty"`
+	ProcessedAt time.Time         `json:"processed_at"`
+}
+
+// TransactionPlayer is a player moved by a transaction. Action and TeamID
+// are empty when the platform does not say which way the player moved.
+type TransactionPlayer struct {
+	PlayerID string `json:"player_id"`
+	Action   string `json:"action,omitempty"` // ADD or DROP
+	TeamID   string `json:"team_id,omitempty"`
+}
+
+// TransactionPick is a future draft pick moved by a trade. OriginalTeamID is
+// the team the pick first belonged to.
+type TransactionPick struct {
+	Season         int    `json:"season"`
+	Round          int    `json:"round"`
+	OriginalTeamID string `json:"original_team_id"`
+	FromTeamID     string `json:"from_team_id"`
+	ToTeamID       string `json:"to_team_id"`
+}
+
+// DraftPick is a pick in the league's draft
+type DraftPick struct {
+	Round      int    `json:"round"`
+	Pick       int    `json:"pick"`
+	Overall    int    `json:"overall"`
+	TeamID     string `json:"team_id"`
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name,omitempty"`
+	Position   string `json:"position,omitempty"`
+	Keeper     bool   `json:"keeper"`
+	Bid        int    `json:"bid,omitempty"` // For auction drafts
+}
+
+// PlatformClient reads a league from the platform that hosts it. League IDs
+// are the platform's own.
+type PlatformClient interface {
+	Platform() string
+	GetLeague(ctx context.Context, leagueID string) (*League, error)
+	GetRosters(ctx context.Context, leagueID string) ([]Roster, error)
+	// GetPlayers returns the league's available players, at most limit
+	GetPlayers(ctx context.Context, leagueID string, limit int) ([]Player, error)
+	// GetTransactions returns the league's latest transactions, at most limit
+	GetTransactions(ctx context.Context, leagueID string, limit int) ([]Transaction, error)
+	GetDraft(ctx context.Context, leagueID string) ([]DraftPick, error)
+}
+
+var (
+	_ PlatformClient = (*ESPNPlatform)(nil)
+	_ PlatformClient = (*SleeperClient)(nil)
+	_ PlatformClient = (*YahooClient)(nil)
+	_ PlatformClient = (*CustomClient)(nil)
+)
+
+// ScoringType names a scoring format by its points per reception
+func ScoringType(reception float64) string {
+	switch {
+	case reception >= 1:
+		return "PPR"
+	case reception >= 0.5:
+		return "HALF_PPR"
+	default:
+		return "STANDARD"
+	}
+}
@@ -0,0 +1,193 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sleeperResponses = map[string]string{
+	"/league/42":       `{"league_id":"42","name":"Dynasty Degens","season":"2024","draft_id":"d1","scoring_settings":{"rec":0.5}}`,
+	"/league/42/users": `[{"user_id":"u1","display_name":"alice","metadata":{"team_name":"Alice's Aces"}},{"user_id":"u2","display_name":"bob","metadata":{}}]`,
+	"/league/42/rosters": `[
+		{"roster_id":1,"owner_id":"u1","players":["4046","6794"],"starters":["4046"],"reserve":["6794"],"settings":{"wins":3,"losses":1,"ties":0,"fpts":412,"fpts_decimal":56}},
+		{"roster_id":2,"owner_id":"u2","players":["4034"],"starters":[],"settings":{"wins":1,"losses":3}}
+	]`,
+	"/state/nfl": `{"week":2,"season":"2024"}`,
+	"/players/nfl": `{
+		"4046":{"full_name":"Patrick Mahomes","position":"QB","team":"KC","active":true,"search_rank":20},
+		"6794":{"full_name":"Justin Jefferson","position":"WR","team":"MIN","active":true,"injury_status":"Out","search_rank":3},
+		"4034":{"full_name":"Christian McCaffrey","position":"RB","team":"SF","active":true,"search_rank":1},
+		"5850":{"full_name":"Josh Jacobs","position":"RB","team":"GB","active":true,"search_rank":30},
+		"4881":{"full_name":"Lamar Jackson","position":"QB","team":"BAL","active":true,"search_rank":10},
+		"1234":{"full_name":"Retired Guy","position":"WR","team":null,"active":false,"search_rank":999}
+	}`,
+	"/league/42/transactions/2": `[{"transaction_id":"t2","type":"free_agent","status":"complete","roster_ids":[1],"adds":{"5850":1},"drops":{"6794":1},"status_updated":1726000000000}]`,
+	"/league/42/transactions/1": `[
+		{"transaction_id":"t1","type":"waiver","status":"complete","roster_ids":[2],"adds":{"4881":2},"status_updated":1725400000000,"settings":{"waiver_bid":17}},
+		{"transaction_id":"t0","type":"trade","status":"complete","roster_ids":[1,2],"adds":{"4034":2},"drops":{"4034":1},"status_updated":1725300000000,
+		 "draft_picks":[{"season":"2025","round":2,"roster_id":1,"previous_owner_id":1,"owner_id":2}]}
+	]`,
+	"/league/42/drafts": `[{"draft_id":"d1","settings":{"teams":2}}]`,
+	"/draft/d1/picks": `[
+		{"round":1,"pick_no":1,"roster_id":2,"player_id":"4034","metadata":{"first_name":"Christian","last_name":"McCaffrey","position":"RB"}},
+		{"round":2,"pick_no":4,"roster_id":2,"player_id":"4046","is_keeper":true,"metadata":{"first_name":"Patrick","last_name":"Mahomes","position":"QB","amount":"45"}}
+	]`,
+	"/league/404": `null`,
+}
+
+func newSleeperServer(t *testing.T) *SleeperClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := sleeperResponses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewSleeperClient()
+	client.baseURL = server.URL
+	return client
+}
+
+func TestSleeperClient(t *testing.T) {
+	client := newSleeperServer(t)
+	ctx := context.Background()
+
+	league, err := client.GetLeague(ctx, "42")
+	require.NoError(t, err)
+	assert.Equal(t, "Dynasty Degens", league.Name)
+	assert.Equal(t, 2024, league.Season)
+	assert.Equal(t, "HALF_PPR", league.ScoringType)
+	assert.Equal(t, 2, league.CurrentWeek)
+	require.Len(t, league.Teams, 2)
+	assert.Equal(t, Team{ID: "1", Name: "Alice's Aces", OwnerID: "u1", OwnerName: "alice", Wins: 3, Losses: 1, PointsFor: 412.56}, league.Teams[0])
+	assert.Equal(t, "bob", league.Teams[1].Name)
+
+	rosters, err := client.GetRosters(ctx, "42")
+	require.NoError(t, err)
+	require.Len(t, rosters, 2)
+	assert.Equal(t, []RosterPlayer{
+		{PlayerID: "4046", Name: "Patrick Mahomes", Position: "QB", Team: "KC", LineupSlot: "QB", Starter: true},
+		{PlayerID: "6794", Name: "Justin Jefferson", Position: "WR", Team: "MIN", Status: "Out", LineupSlot: "IR"},
+	}, rosters[0].Players)
+
+	// Rostered and inactive players are not available
+	players, err := client.GetPlayers(ctx, "42", 1)
+	require.NoError(t, err)
+	require.Len(t, players, 1)
+	assert.Equal(t, "Lamar Jackson", players[0].Name)
+
+	transactions, err := client.GetTransactions(ctx, "42", 10)
+	require.NoError(t, err)
+	require.Len(t, transactions, 3)
+	assert.Equal(t, TransactionAdd, transactions[0].Type)
+	assert.Equal(t, []TransactionPlayer{
+		{PlayerID: "5850", Action: TransactionAdd, TeamID: "1"},
+		{PlayerID: "6794", Action: TransactionDrop, TeamID: "1"},
+	}, transactions[0].Players)
+	assert.Equal(t, TransactionWaiver, transactions[1].Type)
+	assert.Equal(t, 17, transactions[1].Bid)
+	assert.Equal(t, TransactionTrade, transactions[2].Type)
+	assert.Equal(t, []TransactionPick{{Season: 2025, Round: 2, OriginalTeamID: "1", FromTeamID: "1", ToTeamID: "2"}}, transactions[2].Picks)
+
+	picks, err := client.GetDraft(ctx, "42")
+	require.NoError(t, err)
+	require.Len(t, picks, 2)
+	assert.Equal(t, DraftPick{Round: 2, Pick: 2, Overall: 4, TeamID: "2", PlayerID: "4046", PlayerName: "Patrick Mahomes", Position: "QB", Keeper: true, Bid: 45}, picks[1])
+
+	_, err = client.GetLeague(ctx, "404")
+	assert.True(t, errors.Is(err, ErrLeagueNotFound))
+}
+
+func TestYahooMerge(t *testing.T) {
+	raw := json.RawMessage(`[{"team_key":"423.l.1.t.4"},{"team_id":"4"},[],{"name":"Gridiron"},
+		{"managers":[{"manager":{"guid":"G1","nickname":"Sam"}}]},{"team_standings":{"outcome_totals":{"wins":"5","losses":3,"ties":0},"points_for":"801.2"}}]`)
+	team := yahooMerge(raw)
+	assert.Equal(t, "4", yahooString(team["team_id"]))
+	assert.Equal(t, "Gridiron", yahooString(team["name"]))
+	assert.Equal(t, 801.2, yahooFloat(yahooMerge(team["team_standings"])["points_for"]))
+	assert.Equal(t, 3, yahooInt(yahooMerge(yahooMerge(team["team_standings"])["outcome_totals"])["losses"]))
+	assert.Equal(t, "4", yahooTeamID(yahooString(team["team_key"])))
+
+	list := yahooList(json.RawMessage(`{"0":{"manager":{"guid":"A"}},"1":{"manager":{"guid":"B"}},"count":2}`), "manager")
+	require.Len(t, list, 2)
+	assert.Equal(t, "B", yahooString(yahooMerge(list[1])["guid"]))
+}
+
+type stubCredentials struct{ swid, espnS2 string }
+
+func (s stubCredentials) GetESPNCredentials(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	return s.swid, s.espnS2, nil
+}
+
+func TestFactoryForLeague(t *testing.T) {
+	factory := NewFactory(espn.NewMockESPNClient(), stubCredentials{swid: "{S}", espnS2: "s2"})
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		platform string
+		want     string
+		err      error
+	}{
+		{platform: "espn", want: PlatformESPN},
+		{platform: "", want: PlatformESPN},
+		{platform: "sleeper", want: PlatformSleeper},
+		{platform: "custom", want: PlatformCustom},
+		{platform: "yahoo", err: ErrCredentialsRequired},
+		{platform: "fleaflicker", err: ErrUnsupportedPlatform},
+	} {
+		client, err := factory.ForLeague(ctx, &models.League{Platform: tc.platform})
+		if tc.err != nil {
+			assert.True(t, errors.Is(err, tc.err), tc.platform)
+			continue
+		}
+		require.NoError(t, err, tc.platform)
+		assert.Equal(t, tc.want, client.Platform())
+	}
+}
+
+func TestCustomClient(t *testing.T) {
+	teams, err := json.Marshal([]espn.Team{
+		{ID: 1, Nickname: "Gridiron Gang", Owner: espn.TeamOwner{Name: "Sam"}},
+		{ID: 2, Nickname: "Bench Mob"},
+	})
+	require.NoError(t, err)
+	league := &models.League{
+		ID:        uuid.New(),
+		Platform:  PlatformCustom,
+		Name:      "Office League",
+		Season:    2025,
+		Settings:  json.RawMessage(`{"scoring_rules":{"receptionPoints":0.5}}`),
+		TeamsData: teams,
+	}
+	client := NewCustomClient(league)
+	ctx := context.Background()
+
+	info, err := client.GetLeague(ctx, league.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Office League", info.Name)
+	assert.Equal(t, "HALF_PPR", info.ScoringType)
+	require.Len(t, info.Teams, 2)
+	assert.Equal(t, Team{ID: "1", Name: "Gridiron Gang", OwnerName: "Sam"}, info.Teams[0])
+
+	rosters, err := client.GetRosters(ctx, league.ID.String())
+	require.NoError(t, err)
+	require.Len(t, rosters, 2)
+	assert.Empty(t, rosters[1].Players)
+
+	transactions, err := client.GetTransactions(ctx, league.ID.String(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, transactions)
+}
@@ -0,0 +1,485 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sleeperBaseURL is Sleeper's public read-only API, which needs no
+// authentication
+const sleeperBaseURL = "https://api.sleeper.app/v1"
+
+// sleeperPlayersTTL is how long the Sleeper player directory is kept.
+// Sleeper asks callers to fetch it at most once a day.
+const sleeperPlayersTTL = 24 * time.Hour
+
+// SleeperClient reads Sleeper leagues
+type SleeperClient struct {
+	httpClient *http.Client
+	baseURL    string
+
+	mu        sync.Mutex
+	players   map[string]sleeperPlayer
+	playersAt time.Time
+}
+
+// NewSleeperClient creates a Sleeper client
+func NewSleeperClient() *SleeperClient {
+	return &SleeperClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    sleeperBaseURL,
+	}
+}
+
+type sleeperLeague struct {
+	LeagueID        string             `json:"league_id"`
+	Name            string             `json:"name"`
+	Season          string             `json:"season"`
+	DraftID         string             `json:"draft_id"`
+	ScoringSettings map[string]float64 `json:"scoring_settings"`
+}
+
+type sleeperUser struct {
+	UserID      string `json:"user_id"`
+	DisplayName string `json:"display_name"`
+	Metadata    struct {
+		TeamName string `json:"team_name"`
+	} `json:"metadata"`
+}
+
+type sleeperRoster struct {
+	RosterID int      `json:"roster_id"`
+	OwnerID  string   `json:"owner_id"`
+	Players  []string `json:"players"`
+	Starters []string `json:"starters"`
+	Reserve  []string `json:"reserve"`
+	Settings struct {
+		Wins        int `json:"wins"`
+		Losses      int `json:"losses"`
+		Ties        int `json:"ties"`
+		FPts        int `json:"fpts"`
+		FPtsDecimal int `json:"fpts_decimal"`
+	} `json:"settings"`
+}
+
+type sleeperTransaction struct {
+	TransactionID string         `json:"transaction_id"`
+	Type          string         `json:"type"` // trade, free_agent or waiver
+	Status        string         `json:"status"`
+	RosterIDs     []int          `json:"roster_ids"`
+	Adds          map[string]int `json:"adds"`
+	Drops         map[string]int `json:"drops"`
+	DraftPicks    []struct {
+		Season          string `json:"season"`
+		Round           int    `json:"round"`
+		RosterID        int    `json:"roster_id"` // The team the pick originally belonged to
+		PreviousOwnerID int    `json:"previous_owner_id"`
+		OwnerID         int    `json:"owner_id"`
+	} `json:"draft_picks"`
+	StatusUpdated int64 `json:"status_updated"` // Milliseconds
+	Settings      struct {
+		WaiverBid int `json:"waiver_bid"`
+	} `json:"settings"`
+}
+
+type sleeperPick struct {
+	Round    int    `json:"round"`
+	PickNo   int    `json:"pick_no"`
+	RosterID int    `json:"roster_id"`
+	PlayerID string `json:"player_id"`
+	IsKeeper bool   `json:"is_keeper"`
+	Metadata struct {
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+		Position  string `json:"position"`
+		Amount    string `json:"amount"`
+	} `json:"metadata"`
+}
+
+type sleeperDraft struct {
+	DraftID  string `json:"draft_id"`
+	Settings struct {
+		Teams int `json:"teams"`
+	} `json:"settings"`
+}
+
+type sleeperPlayer struct {
+	PlayerID     string `json:"player_id"`
+	FullName     string `json:"full_name"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Position     string `json:"position"`
+	Team         string `json:"team"`
+	Active       bool   `json:"active"`
+	InjuryStatus string `json:"injury_status"`
+	SearchRank   int    `json:"search_rank"`
+}
+
+func (p sleeperPlayer) name() string {
+	if p.FullName != "" {
+		return p.FullName
+	}
+	return strings.TrimSpace(p.FirstName + " " + p.LastName)
+}
+
+// Platform returns sleeper
+func (c *SleeperClient) Platform() string {
+	return PlatformSleeper
+}
+
+// GetLeague fetches a league and its teams, which are Sleeper rosters named
+// by their owners
+func (c *SleeperClient) GetLeague(ctx context.Context, leagueID string) (*League, error) {
+	var league sleeperLeague
+	if err := c.get(ctx, "/league/"+leagueID, &league); err != nil {
+		return nil, err
+	}
+	var users []sleeperUser
+	if err := c.get(ctx, "/league/"+leagueID+"/users", &users); err != nil {
+		return nil, err
+	}
+	var rosters []sleeperRoster
+	if err := c.get(ctx, "/league/"+leagueID+"/rosters", &rosters); err != nil {
+		return nil, err
+	}
+	var state struct {
+		Week int `json:"week"`
+	}
+	if err := c.get(ctx, "/state/nfl", &state); err != nil {
+		return nil, err
+	}
+
+	season, _ := strconv.Atoi(league.Season)
+	result := &League{
+		Platform:    PlatformSleeper,
+		ID:          league.LeagueID,
+		Name:        league.Name,
+		Season:      season,
+		ScoringType: ScoringType(league.ScoringSettings["rec"]),
+		CurrentWeek: state.Week,
+		Teams:       sleeperTeams(rosters, users),
+	}
+	return result, nil
+}
+
+// GetRosters fetches every team's roster, naming players from Sleeper's
+// player directory
+func (c *SleeperClient) GetRosters(ctx context.Context, leagueID string) ([]Roster, error) {
+	var rosters []sleeperRoster
+	if err := c.get(ctx, "/league/"+leagueID+"/rosters", &rosters); err != nil {
+		return nil, err
+	}
+	players, err := c.playerDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Roster, 0, len(rosters))
+	for _, r := range rosters {
+		starters := make(map[string]bool, len(r.Starters))
+		for _, id := range r.Starters {
+			starters[id] = true
+		}
+		reserve := make(map[string]bool, len(r.Reserve))
+		for _, id := range r.Reserve {
+			reserve[id] = true
+		}
+
+		roster := Roster{TeamID: strconv.Itoa(r.RosterID), Players: make([]RosterPlayer, 0, len(r.Players))}
+		for _, id := range r.Players {
+			p := players[id]
+			slot := "BE"
+			switch {
+			case starters[id]:
+				slot = p.Position
+			case reserve[id]:
+				slot = "IR"
+			}
+			roster.Players = append(roster.Players, RosterPlayer{
+				PlayerID:   id,
+				Name:       p.name(),
+				Position:   p.Position,
+				Team:       p.Team,
+				Status:     p.InjuryStatus,
+				LineupSlot: slot,
+				Starter:    starters[id],
+			})
+		}
+		result = append(result, roster)
+	}
+	return result, nil
+}
+
+// GetPlayers returns the active players no team in the league has rostered,
+// in Sleeper's search rank order. Sleeper has no free agent endpoint, so
+// this is the player directory less the league's rosters.
+func (c *SleeperClient) GetPlayers(ctx context.Context, leagueID string, limit int) ([]Player, error) {
+	var rosters []sleeperRoster
+	if err := c.get(ctx, "/league/"+leagueID+"/rosters", &rosters); err != nil {
+		return nil, err
+	}
+	players, err := c.playerDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rostered := make(map[string]bool)
+	for _, r := range rosters {
+		for _, id := range r.Players {
+			rostered[id] = true
+		}
+	}
+
+	available := make([]sleeperPlayer, 0, len(players))
+	for id, p := range players {
+		if !p.Active || rostered[id] || p.Team == "" || p.SearchRank <= 0 {
+			continue
+		}
+		p.PlayerID = id
+		available = append(available, p)
+	}
+	sort.Slice(available, func(i, j int) bool {
+		return available[i].SearchRank < available[j].SearchRank
+	})
+	if limit > 0 && len(available) > limit {
+		available = available[:limit]
+	}
+
+	result := make([]Player, 0, len(available))
+	for _, p := range available {
+		result = append(result, Player{
+			ID:       p.PlayerID,
+			Name:     p.name(),
+			Position: p.Position,
+			Team:     p.Team,
+			Status:   p.InjuryStatus,
+		})
+	}
+	return result, nil
+}
+
+// GetTransactions fetches the latest transactions, walking back from the
+// current week because Sleeper lists transactions one week at a time
+func (c *SleeperClient) GetTransactions(ctx context.Context, leagueID string, limit int) ([]Transaction, error) {
+	var state struct {
+		Week int `json:"week"`
+	}
+	if err := c.get(ctx, "/state/nfl", &state); err != nil {
+		return nil, err
+	}
+
+	var result []Transaction
+	for week := max(state.Week, 1); week >= 1; week-- {
+		var transactions []sleeperTransaction
+		if err := c.get(ctx, fmt.Sprintf("/league/%s/transactions/%d", leagueID, week), &transactions); err != nil {
+			return nil, err
+		}
+		for _, t := range transactions {
+			result = append(result, sleeperTransactionToTransaction(t))
+		}
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ProcessedAt.After(result[j].ProcessedAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// GetDraft fetches the picks of the league's draft
+func (c *SleeperClient) GetDraft(ctx context.Context, leagueID string) ([]DraftPick, error) {
+	var drafts []sleeperDraft
+	if err := c.get(ctx, "/league/"+leagueID+"/drafts", &drafts); err != nil {
+		return nil, err
+	}
+	if len(drafts) == 0 {
+		return []DraftPick{}, nil
+	}
+
+	var picks []sleeperPick
+	if err := c.get(ctx, "/draft/"+drafts[0].DraftID+"/picks", &picks); err != nil {
+		return nil, err
+	}
+
+	teams := drafts[0].Settings.Teams
+	result := make([]DraftPick, 0, len(picks))
+	for _, p := range picks {
+		pick := DraftPick{
+			Round:      p.Round,
+			Pick:       p.PickNo,
+			Overall:    p.PickNo,
+			TeamID:     strconv.Itoa(p.RosterID),
+			PlayerID:   p.PlayerID,
+			PlayerName: strings.TrimSpace(p.Metadata.FirstName + " " + p.Metadata.LastName),
+			Position:   p.Metadata.Position,
+			Keeper:     p.IsKeeper,
+		}
+		// pick_no is the overall pick; the pick within the round follows
+		// from the number of teams
+		if teams > 0 {
+			pick.Pick = (p.PickNo-1)%teams + 1
+		}
+		pick.Bid, _ = strconv.Atoi(p.Metadata.Amount)
+		result = append(result, pick)
+	}
+	return result, nil
+}
+
+// playerDirectory returns Sleeper's NFL players keyed by player ID, fetching
+// them at most once a day
+func (c *SleeperClient) playerDirectory(ctx context.Context) (map[string]sleeperPlayer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.players != nil && time.Since(c.playersAt) < sleeperPlayersTTL {
+		return c.players, nil
+	}
+
+	var players map[string]sleeperPlayer
+	if err := c.get(ctx, "/players/nfl", &players); err != nil {
+		if c.players != nil {
+			// A stale directory still names nearly every player
+			return c.players, nil
+		}
+		return nil, err
+	}
+	c.players = players
+	c.playersAt = time.Now()
+	return players, nil
+}
+
+// get fetches a Sleeper API path into result. Sleeper answers unknown
+// leagues with a null body rather than a 404.
+func (c *SleeperClient) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sleeper request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrLeagueNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("sleeper returned status %d for %s", resp.StatusCode, path)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read sleeper response: %w", err)
+	}
+	if strings.TrimSpace(string(body)) == "null" {
+		return ErrLeagueNotFound
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to decode sleeper response: %w", err)
+	}
+	return nil
+}
+
+// sleeperTeams names each roster after its owner's team name, or their
+// display name when they have not named the team
+func sleeperTeams(rosters []sleeperRoster, users []sleeperUser) []Team {
+	byID := make(map[string]sleeperUser, len(users))
+	for _, u := range users {
+		byID[u.UserID] = u
+	}
+
+	teams := make([]Team, 0, len(rosters))
+	for _, r := range rosters {
+		owner := byID[r.OwnerID]
+		name := owner.Metadata.TeamName
+		if name == "" {
+			name = owner.DisplayName
+		}
+		if name == "" {
+			name = fmt.Sprintf("Team %d", r.RosterID)
+		}
+		teams = append(teams, Team{
+			ID:        strconv.Itoa(r.RosterID),
+			Name:      name,
+			OwnerID:   r.OwnerID,
+			OwnerName: owner.DisplayName,
+			Wins:      r.Settings.Wins,
+			Losses:    r.Settings.Losses,
+			Ties:      r.Settings.Ties,
+			PointsFor: float64(r.Settings.FPts) + float64(r.Settings.FPtsDecimal)/100,
+		})
+	}
+	return teams
+}
+
+// sleeperTransactionToTransaction converts a Sleeper transaction, naming its
+// type as ESPN does
+func sleeperTransactionToTransaction(t sleeperTransaction) Transaction {
+	tx := Transaction{
+		ID:          t.TransactionID,
+		Status:      strings.ToUpper(t.Status),
+		TeamIDs:     make([]string, 0, len(t.RosterIDs)),
+		Bid:         t.Settings.WaiverBid,
+		ProcessedAt: time.UnixMilli(t.StatusUpdated).UTC(),
+	}
+	switch t.Type {
+	case "trade":
+		tx.Type = TransactionTrade
+	case "waiver":
+		tx.Type = TransactionWaiver
+	default:
+		tx.Type = TransactionAdd
+		if len(t.Adds) == 0 {
+			tx.Type = TransactionDrop
+		}
+	}
+	for _, id := range t.RosterIDs {
+		tx.TeamIDs = append(tx.TeamIDs, strconv.Itoa(id))
+	}
+
+	for _, id := range sortedKeys(t.Adds) {
+		tx.Players = append(tx.Players, TransactionPlayer{PlayerID: id, Action: TransactionAdd, TeamID: strconv.Itoa(t.Adds[id])})
+	}
+	for _, id := range sortedKeys(t.Drops) {
+		tx.Players = append(tx.Players, TransactionPlayer{PlayerID: id, Action: TransactionDrop, TeamID: strconv.Itoa(t.Drops[id])})
+	}
+	for _, p := range t.DraftPicks {
+		season, err := strconv.Atoi(p.Season)
+		if err != nil {
+			continue
+		}
+		tx.Picks = append(tx.Picks, TransactionPick{
+			Season:         season,
+			Round:          p.Round,
+			OriginalTeamID: strconv.Itoa(p.RosterID),
+			FromTeamID:     strconv.Itoa(p.PreviousOwnerID),
+			ToTeamID:       strconv.Itoa(p.OwnerID),
+		})
+	}
+	return tx
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,403 @@
+package platform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// yahooBaseURL is Yahoo's Fantasy Sports API
+const yahooBaseURL = "https://fantasysports.yahooapis.com/fantasy/v2"
+
+// yahooPageSize is the most players Yahoo returns per request
+const yahooPageSize = 25
+
+// yahooReceptionStatID is Yahoo's stat ID for receptions
+const yahooReceptionStatID = "11"
+
+// YahooClient reads Yahoo leagues with a user's OAuth access token
+type YahooClient struct {
+	httpClient  *http.Client
+	baseURL     string
+	accessToken string
+}
+
+// NewYahooClient creates a Yahoo client that authenticates as the user the
+// access token was issued to
+func NewYahooClient(accessToken string) *YahooClient {
+	return &YahooClient{
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		baseURL:     yahooBaseURL,
+		accessToken: accessToken,
+	}
+}
+
+// Platform returns yahoo
+func (c *YahooClient) Platform() string {
+	return PlatformYahoo
+}
+
+// GetLeague fetches a league's standings and scoring settings
+func (c *YahooClient) GetLeague(ctx context.Context, leagueID string) (*League, error) {
+	key := yahooLeagueKey(leagueID)
+
+	standings, err := c.league(ctx, "/league/"+key+"/standings")
+	if err != nil {
+		return nil, err
+	}
+	settings, err := c.league(ctx, "/league/"+key+"/settings")
+	if err != nil {
+		return nil, err
+	}
+
+	league := &League{
+		Platform:    PlatformYahoo,
+		ID:          key,
+		Name:        yahooString(standings["name"]),
+		Season:      yahooInt(standings["season"]),
+		ScoringType: ScoringType(yahooReceptionPoints(settings)),
+		CurrentWeek: yahooInt(standings["current_week"]),
+	}
+
+	teams := yahooList(yahooMerge(standings["standings"])["teams"], "team")
+	league.Teams = make([]Team, 0, len(teams))
+	for _, raw := range teams {
+		t := yahooMerge(raw)
+		team := Team{
+			ID:   yahooString(t["team_id"]),
+			Name: yahooString(t["name"]),
+		}
+		for _, m := range yahooList(t["managers"], "manager") {
+			manager := yahooMerge(m)
+			team.OwnerID = yahooString(manager["guid"])
+			team.OwnerName = yahooString(manager["nickname"])
+			break
+		}
+		record := yahooMerge(t["team_standings"])
+		totals := yahooMerge(record["outcome_totals"])
+		team.Wins = yahooInt(totals["wins"])
+		team.Losses = yahooInt(totals["losses"])
+		team.Ties = yahooInt(totals["ties"])
+		team.PointsFor = yahooFloat(record["points_for"])
+		league.Teams = append(league.Teams, team)
+	}
+	return league, nil
+}
+
+// GetRosters fetches every team's roster for the current week
+func (c *YahooClient) GetRosters(ctx context.Context, leagueID string) ([]Roster, error) {
+	league, err := c.league(ctx, "/league/"+yahooLeagueKey(leagueID)+"/teams/roster")
+	if err != nil {
+		return nil, err
+	}
+
+	teams := yahooList(league["teams"], "team")
+	rosters := make([]Roster, 0, len(teams))
+	for _, raw := range teams {
+		t := yahooMerge(raw)
+		roster := Roster{TeamID: yahooString(t["team_id"]), Players: []RosterPlayer{}}
+
+		players := yahooList(yahooMerge(yahooMerge(t["roster"])["0"])["players"], "player")
+		for _, rp := range players {
+			p := yahooMerge(rp)
+			slot := yahooString(yahooMerge(p["selected_position"])["position"])
+			roster.Players = append(roster.Players, RosterPlayer{
+				PlayerID:   yahooString(p["player_id"]),
+				Name:       yahooString(yahooMerge(p["name"])["full"]),
+				Position:   yahooString(p["display_position"]),
+				Team:       strings.ToUpper(yahooString(p["editorial_team_abbr"])),
+				Status:     yahooString(p["status"]),
+				LineupSlot: slot,
+				Starter:    slot != "" && slot != "BN" && slot != "IR",
+			})
+		}
+		rosters = append(rosters, roster)
+	}
+	return rosters, nil
+}
+
+// GetPlayers fetches the league's available players by Yahoo's actual rank,
+// a page at a time
+func (c *YahooClient) GetPlayers(ctx context.Context, leagueID string, limit int) ([]Player, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	key := yahooLeagueKey(leagueID)
+
+	players := []Player{}
+	for start := 0; start < limit; start += yahooPageSize {
+		count := min(yahooPageSize, limit-start)
+		league, err := c.league(ctx, fmt.Sprintf("/league/%s/players;status=A;sort=AR;start=%d;count=%d", key, start, count))
+		if err != nil {
+			return nil, err
+		}
+
+		page := yahooList(league["players"], "player")
+		for _, raw := range page {
+			p := yahooMerge(raw)
+			players = append(players, Player{
+				ID:       yahooString(p["player_id"]),
+				Name:     yahooString(yahooMerge(p["name"])["full"]),
+				Position: yahooString(p["display_position"]),
+				Team:     strings.ToUpper(yahooString(p["editorial_team_abbr"])),
+				Status:   yahooString(p["status"]),
+			})
+		}
+		if len(page) < count {
+			break
+		}
+	}
+	return players, nil
+}
+
+// GetTransactions fetches the league's latest transactions
+func (c *YahooClient) GetTransactions(ctx context.Context, leagueID string, limit int) ([]Transaction, error) {
+	path := "/league/" + yahooLeagueKey(leagueID) + "/transactions"
+	if limit > 0 {
+		path += ";count=" + strconv.Itoa(limit)
+	}
+	league, err := c.league(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := yahooList(league["transactions"], "transaction")
+	transactions := make([]Transaction, 0, len(raw))
+	for _, r := range raw {
+		t := yahooMerge(r)
+		tx := Transaction{
+			ID:          yahooString(t["transaction_id"]),
+			Type:        yahooTransactionType(yahooString(t["type"])),
+			Status:      strings.ToUpper(yahooString(t["status"])),
+			TeamIDs:     []string{},
+			Players:     []TransactionPlayer{},
+			Bid:         yahooInt(t["faab_bid"]),
+			ProcessedAt: time.Unix(int64(yahooInt(t["timestamp"])), 0).UTC(),
+		}
+
+		teams := make(map[string]bool)
+		for _, rp := range yahooList(t["players"], "player") {
+			p := yahooMerge(rp)
+			data := yahooMerge(p["transaction_data"])
+			player := TransactionPlayer{PlayerID: yahooString(p["player_id"])}
+			switch yahooString(data["type"]) {
+			case "add", "trade":
+				player.Action = TransactionAdd
+				player.TeamID = yahooTeamID(yahooString(data["destination_team_key"]))
+			case "drop":
+				player.Action = TransactionDrop
+				player.TeamID = yahooTeamID(yahooString(data["source_team_key"]))
+			}
+			if player.TeamID != "" && !teams[player.TeamID] {
+				teams[player.TeamID] = true
+				tx.TeamIDs = append(tx.TeamIDs, player.TeamID)
+			}
+			tx.Players = append(tx.Players, player)
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, nil
+}
+
+// GetDraft fetches the league's draft results
+func (c *YahooClient) GetDraft(ctx context.Context, leagueID string) ([]DraftPick, error) {
+	league, err := c.league(ctx, "/league/"+yahooLeagueKey(leagueID)+"/draftresults")
+	if err != nil {
+		return nil, err
+	}
+
+	results := yahooList(league["draft_results"], "draft_result")
+	teams := yahooInt(league["num_teams"])
+	picks := make([]DraftPick, 0, len(results))
+	for _, raw := range results {
+		r := yahooMerge(raw)
+		playerKey := yahooString(r["player_key"])
+		if playerKey == "" {
+			// Picks not made yet
+			continue
+		}
+		pick := DraftPick{
+			Round:    yahooInt(r["round"]),
+			Pick:     yahooInt(r["pick"]),
+			Overall:  yahooInt(r["pick"]),
+			TeamID:   yahooTeamID(yahooString(r["team_key"])),
+			PlayerID: playerKey[strings.LastIndex(playerKey, ".")+1:],
+			Bid:      yahooInt(r["cost"]),
+		}
+		if teams > 0 {
+			pick.Pick = (pick.Overall-1)%teams + 1
+		}
+		picks = append(picks, pick)
+	}
+	return picks, nil
+}
+
+// league fetches a league resource and merges its parts into one object
+func (c *YahooClient) league(ctx context.Context, path string) (map[string]json.RawMessage, error) {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+sep+"format=json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("yahoo request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("%w: yahoo rejected the access token", ErrCredentialsRequired)
+	case http.StatusBadRequest, http.StatusNotFound:
+		return nil, ErrLeagueNotFound
+	default:
+		return nil, fmt.Errorf("yahoo returned status %d for %s", resp.StatusCode, path)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read yahoo response: %w", err)
+	}
+	var content struct {
+		FantasyContent struct {
+			League json.RawMessage `json:"league"`
+		} `json:"fantasy_content"`
+	}
+	if err := json.Unmarshal(body, &content); err != nil {
+		return nil, fmt.Errorf("failed to decode yahoo response: %w", err)
+	}
+	if len(content.FantasyContent.League) == 0 {
+		return nil, ErrLeagueNotFound
+	}
+	return yahooMerge(content.FantasyContent.League), nil
+}
+
+// yahooLeagueKey turns a bare league ID into a league key for the current
+// NFL season; full keys such as 423.l.12345 are used as they are
+func yahooLeagueKey(leagueID string) string {
+	if strings.Contains(leagueID, ".l.") {
+		return leagueID
+	}
+	return "nfl.l." + leagueID
+}
+
+// yahooTeamID takes the team ID from a team key such as 423.l.12345.t.4
+func yahooTeamID(teamKey string) string {
+	if i := strings.LastIndex(teamKey, ".t."); i >= 0 {
+		return teamKey[i+3:]
+	}
+	return teamKey
+}
+
+func yahooTransactionType(t string) string {
+	switch t {
+	case "trade":
+		return TransactionTrade
+	case "drop":
+		return TransactionDrop
+	case "waiver":
+		return TransactionWaiver
+	default:
+		// add, add/drop and commissioner moves
+		return TransactionAdd
+	}
+}
+
+// yahooReceptionPoints finds the points per reception in a league's
+// settings
+func yahooReceptionPoints(league map[string]json.RawMessage) float64 {
+	settings := yahooMerge(league["settings"])
+	modifiers := yahooMerge(settings["stat_modifiers"])
+	var stats []struct {
+		Stat struct {
+			StatID json.RawMessage `json:"stat_id"`
+			Value  json.RawMessage `json:"value"`
+		} `json:"stat"`
+	}
+	if err := json.Unmarshal(modifiers["stats"], &stats); err != nil {
+		return 0
+	}
+	for _, s := range stats {
+		if yahooString(s.Stat.StatID) == yahooReceptionStatID {
+			return yahooFloat(s.Stat.Value)
+		}
+	}
+	return 0
+}
+
+// yahooMerge flattens Yahoo's JSON, which splits one resource's fields
+// across nested arrays of single-field objects, into one object. Later
+// fields win.
+func yahooMerge(raw json.RawMessage) map[string]json.RawMessage {
+	merged := make(map[string]json.RawMessage)
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return merged
+	}
+
+	switch raw[0] {
+	case '[':
+		var parts []json.RawMessage
+		if err := json.Unmarshal(raw, &parts); err != nil {
+			return merged
+		}
+		for _, part := range parts {
+			for k, v := range yahooMerge(part) {
+				merged[k] = v
+			}
+		}
+	case '{':
+		_ = json.Unmarshal(raw, &merged)
+	}
+	return merged
+}
+
+// yahooList reads a Yahoo collection, an object of numbered entries plus a
+// count, returning the field named key of each entry in order
+func yahooList(raw json.RawMessage, key string) []json.RawMessage {
+	collection := yahooMerge(raw)
+	var items []json.RawMessage
+	for i := 0; ; i++ {
+		entry, ok := collection[strconv.Itoa(i)]
+		if !ok {
+			return items
+		}
+		items = append(items, yahooMerge(entry)[key])
+	}
+}
+
+// yahooString reads a value Yahoo may send as a string or a number
+func yahooString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	text := strings.TrimSpace(string(raw))
+	if text == "null" {
+		return ""
+	}
+	return text
+}
+
+func yahooInt(raw json.RawMessage) int {
+	n, _ := strconv.Atoi(yahooString(raw))
+	return n
+}
+
+func yahooFloat(raw json.RawMessage) float64 {
+	f, _ := strconv.ParseFloat(yahooString(raw), 64)
+	return f
+}
@@ -0,0 +1,124 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// baseURL is the Open-Meteo forecast API, which needs no API key
+const baseURL = "https://api.open-meteo.com/v1"
+
+// MaxForecastDays is how far ahead Open-Meteo forecasts
+const MaxForecastDays = 16
+
+// hourFormat is how Open-Meteo writes hourly timestamps in UTC
+const hourFormat = "2006-01-02T15:04"
+
+// Conditions is the forecast for one place and hour
+type Conditions struct {
+	Time                time.Time `json:"time"`
+	TemperatureF        float64   `json:"temperature_f"`
+	WindMPH             float64   `json:"wind_mph"`
+	PrecipitationChance float64   `json:"precipitation_chance"` // 0-100
+}
+
+// Client fetches hourly forecasts
+type Client interface {
+	// GetForecast returns the forecast for the hour containing at
+	GetForecast(ctx context.Context, latitude, longitude float64, at time.Time) (*Conditions, error)
+}
+
+// OpenMeteoClient reads forecasts from Open-Meteo
+type OpenMeteoClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewOpenMeteoClient creates a client for Open-Meteo
+func NewOpenMeteoClient() *OpenMeteoClient {
+	return &OpenMeteoClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    baseURL,
+	}
+}
+
+// GetForecast returns the forecast for the hour containing at. Times more
+// than MaxForecastDays ahead, or in the past, have no forecast.
+func (c *OpenMeteoClient) GetForecast(ctx context.Context, latitude, longitude float64, at time.Time) (*Conditions, error) {
+	at = at.UTC().Truncate(time.Hour)
+	day := at.Format("2006-01-02")
+
+	params := url.Values{}
+	params.Set("latitude", fmt.Sprintf("%.4f", latitude))
+	params.Set("longitude", fmt.Sprintf("%.4f", longitude))
+	params.Set("hourly", "temperature_2m,wind_speed_10m,precipitation_probability")
+	params.Set("temperature_unit", "fahrenheit")
+	params.Set("wind_speed_unit", "mph")
+	params.Set("timezone", "UTC")
+	params.Set("start_date", day)
+	params.Set("end_date", day)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/forecast?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("weather API error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var forecast hourlyForecast
+	if err := json.NewDecoder(resp.Body).Decode(&forecast); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return forecast.at(at)
+}
+
+// hourlyForecast is Open-Meteo's hourly forecast response
+type hourlyForecast struct {
+	Hourly struct {
+		Time                     []string   `json:"time"`
+		Temperature2m            []*float64 `json:"temperature_2m"`
+		WindSpeed10m             []*float64 `json:"wind_speed_10m"`
+		PrecipitationProbability []*float64 `json:"precipitation_probability"`
+	} `json:"hourly"`
+}
+
+// at picks the hour out of a day's forecast. Missing values, which Open-Meteo
+// sends as null, count as zero.
+func (f hourlyForecast) at(hour time.Time) (*Conditions, error) {
+	want := hour.Format(hourFormat)
+	for i, t := range f.Hourly.Time {
+		if t != want {
+			continue
+		}
+		return &Conditions{
+			Time:                hour,
+			TemperatureF:        valueAt(f.Hourly.Temperature2m, i),
+			WindMPH:             valueAt(f.Hourly.WindSpeed10m, i),
+			PrecipitationChance: valueAt(f.Hourly.PrecipitationProbability, i),
+		}, nil
+	}
+	return nil, fmt.Errorf("no forecast for %s", want)
+}
+
+func valueAt(values []*float64, i int) float64 {
+	if i >= len(values) || values[i] == nil {
+		return 0
+	}
+	return *values[i]
+}
@@ -0,0 +1,40 @@
+package weather
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetForecast(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/forecast", r.URL.Path)
+		assert.Equal(t, "42.7738", r.URL.Query().Get("latitude"))
+		assert.Equal(t, "2024-12-01", r.URL.Query().Get("start_date"))
+		assert.Equal(t, "mph", r.URL.Query().Get("wind_speed_unit"))
+		w.Write([]byte(`{"hourly":{
+			"time":["2024-12-01T17:00","2024-12-01T18:00"],
+			"temperature_2m":[28.4,27.1],
+			"wind_speed_10m":[18.2,21.5],
+			"precipitation_probability":[70,null]}}`))
+	}))
+	defer server.Close()
+
+	client := NewOpenMeteoClient()
+	client.baseURL = server.URL
+
+	conditions, err := client.GetForecast(context.Background(), 42.7738, -78.787, time.Date(2024, 12, 1, 18, 25, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 12, 1, 18, 0, 0, 0, time.UTC), conditions.Time)
+	assert.Equal(t, 27.1, conditions.TemperatureF)
+	assert.Equal(t, 21.5, conditions.WindMPH)
+	assert.Zero(t, conditions.PrecipitationChance)
+
+	_, err = client.GetForecast(context.Background(), 42.7738, -78.787, time.Date(2024, 12, 1, 21, 0, 0, 0, time.UTC))
+	assert.Error(t, err)
+}
@@ -0,0 +1,301 @@
+// Package loginguard notices sign-ins from devices and networks a user has
+// not signed in from before. Each one is recorded in the security audit log
+// and the user is emailed about it; optionally the sign-in must first be
+// confirmed with a code sent to the user's email.
+package loginguard
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/audit"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/mail"
+)
+
+// Verification settings
+const (
+	// VerificationTTL is how long an emailed code can be used
+	VerificationTTL = 15 * time.Minute
+	// maxVerificationAttempts is how many wrong codes end a verification
+	maxVerificationAttempts = 5
+	// alertTimeout bounds sending a new sign-in alert
+	alertTimeout = 30 * time.Second
+)
+
+var (
+	// ErrVerificationNotFound is returned for verifications that do not
+	// exist, have expired or ran out of attempts
+	ErrVerificationNotFound = errors.New("login verification not found or expired")
+	// ErrInvalidCode is returned for a wrong verification code
+	ErrInvalidCode = errors.New("invalid verification code")
+)
+
+// Known is what a user has signed in from before
+type Known struct {
+	// Any is whether they have any known device, which they do not until
+	// their first sign-in is remembered
+	Any     bool
+	Device  bool
+	Network bool
+}
+
+// Verification is a sign-in waiting on an emailed code
+type Verification struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	CodeHash   string
+	RememberMe bool
+	Attempts   int
+	ExpiresAt  time.Time
+}
+
+// Store keeps users' known devices and networks and pending verifications
+type Store interface {
+	Known(ctx context.Context, userID uuid.UUID, fingerprint, network string) (Known, error)
+	// Remember records a sign-in from the device and network, from ip
+	Remember(ctx context.Context, userID uuid.UUID, fingerprint string, device activity.Device, network, ip string) error
+	CreateVerification(ctx context.Context, v *Verification) error
+	// AttemptVerification counts an attempt at userID's verification and
+	// returns it, in one step so concurrent attempts cannot exceed
+	// maxAttempts. It returns ErrVerificationNotFound if there is no
+	// unexpired verification with attempts left.
+	AttemptVerification(ctx context.Context, id, userID uuid.UUID, maxAttempts int) (*Verification, error)
+	// ConsumeVerification deletes a verification, returning
+	// ErrVerificationNotFound if it was already gone
+	ConsumeVerification(ctx context.Context, id uuid.UUID) error
+}
+
+// Assessment is how a sign-in's device and network compare to the user's
+// earlier ones
+type Assessment struct {
+	Device      activity.Device
+	Fingerprint string
+	Network     string
+	IPAddress   string
+	// Checked is false when the known devices could not be read, in which
+	// case the sign-in is not flagged
+	Checked    bool
+	FirstLogin bool
+	NewDevice  bool
+	NewNetwork bool
+}
+
+// Suspicious reports whether the sign-in came from a new device or network.
+// A user's first sign-in is not: there is nothing to compare it with.
+func (a Assessment) Suspicious() bool {
+	return a.Checked && !a.FirstLogin && (a.NewDevice || a.NewNetwork)
+}
+
+// Guard assesses password and social sign-ins. A nil Guard flags nothing.
+type Guard struct {
+	store  Store
+	sender mail.Sender
+	events *audit.Logger
+	// verify requires suspicious sign-ins to be confirmed by email
+	verify bool
+	now    func() time.Time
+}
+
+// NewGuard creates a guard that emails users through sender, which may be
+// nil to only record sign-ins in events
+func NewGuard(store Store, sender mail.Sender, events *audit.Logger) *Guard {
+	return &Guard{store: store, sender: sender, events: events, now: time.Now}
+}
+
+// WithVerification requires sign-ins from a new device or network to be
+// confirmed with a code emailed to the user. It needs a sender.
+func (g *Guard) WithVerification(required bool) *Guard {
+	g.verify = required
+	return g
+}
+
+// Assess compares the client in ctx, set by auth.WithClient, with the
+// devices and networks userID has signed in from
+func (g *Guard) Assess(ctx context.Context, userID uuid.UUID) Assessment {
+	if g == nil {
+		return Assessment{}
+	}
+	client := auth.ClientFromContext(ctx)
+	device := activity.DescribeDevice(client.UserAgent)
+	a := Assessment{
+		Device:      device,
+		Fingerprint: Fingerprint(device),
+		Network:     Network(client.IPAddress),
+		IPAddress:   client.IPAddress,
+	}
+
+	known, err := g.store.Known(ctx, userID, a.Fingerprint, a.Network)
+	if err != nil {
+		log.Printf("Skipping new device check for user %s: %v", userID, err)
+		return a
+	}
+	a.Checked = true
+	a.FirstLogin = !known.Any
+	a.NewDevice = !known.Device
+	// Requests without an address cannot be placed
+	a.NewNetwork = a.Network != "" && !known.Network
+	return a
+}
+
+// RequiresVerification reports whether the sign-in must be confirmed by
+// email before it is let in
+func (g *Guard) RequiresVerification(a Assessment) bool {
+	return g != nil && g.verify && g.sender != nil && a.Suspicious()
+}
+
+// Challenge emails the user a code to confirm the sign-in with and returns
+// the verification's ID
+func (g *Guard) Challenge(ctx context.Context, userID uuid.UUID, email string, a Assessment, rememberMe bool) (uuid.UUID, error) {
+	code, err := newCode()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	v := &Verification{
+		ID:         uuid.New(),
+		UserID:     userID,
+		RememberMe: rememberMe,
+		ExpiresAt:  g.now().Add(VerificationTTL),
+	}
+	v.CodeHash = hashCode(v.ID, code)
+	if err := g.store.CreateVerification(ctx, v); err != nil {
+		return uuid.Nil, err
+	}
+
+	msg := mail.Message{
+		To:      email,
+		Subject: "Your sign-in code",
+		Body: fmt.Sprintf("Someone signed in to your NFL Analytics account from %s.\n\n"+
+			"If it was you, enter this code to finish signing in: %s\n\n"+
+			"It expires in %d minutes. If it was not you, change your password; "+
+			"without the code they cannot get in.\n", describe(a), code, int(VerificationTTL.Minutes())),
+	}
+	if err := g.sender.Send(ctx, msg); err != nil {
+		return uuid.Nil, err
+	}
+	return v.ID, nil
+}
+
+// Verify checks the code for userID's verification, consuming it. After
+// maxVerificationAttempts wrong codes the verification is dropped and the
+// user must sign in again.
+func (g *Guard) Verify(ctx context.Context, userID, id uuid.UUID, code string) (*Verification, error) {
+	v, err := g.store.AttemptVerification(ctx, id, userID, maxVerificationAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	code = strings.TrimSpace(code)
+	if subtle.ConstantTimeCompare([]byte(hashCode(v.ID, code)), []byte(v.CodeHash)) != 1 {
+		return nil, ErrInvalidCode
+	}
+	// Only the request that deletes the verification signs in, so a code
+	// sent twice at once is not let in twice
+	if err := g.store.ConsumeVerification(ctx, id); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Succeeded remembers the device and network of a sign-in that was let in.
+// A suspicious one is recorded in the audit log and, unless the user just
+// confirmed it by email, they are alerted. Failures are logged; they never
+// fail the sign-in.
+func (g *Guard) Succeeded(ctx context.Context, userID uuid.UUID, email string, a Assessment, verified bool) {
+	if g == nil || !a.Checked {
+		return
+	}
+	if err := g.store.Remember(ctx, userID, a.Fingerprint, a.Device, a.Network, a.IPAddress); err != nil {
+		log.Printf("Failed to remember device for user %s: %v", userID, err)
+	}
+	if !a.Suspicious() {
+		return
+	}
+
+	g.events.Record(ctx, &userID, audit.EventNewDeviceLogin, map[string]interface{}{
+		"new_device":  a.NewDevice,
+		"new_network": a.NewNetwork,
+		"browser":     a.Device.Browser,
+		"os":          a.Device.OS,
+		"network":     a.Network,
+		"verified":    verified,
+	})
+	log.Printf("metrics new_device_login new_device=%t new_network=%t verified=%t", a.NewDevice, a.NewNetwork, verified)
+	if verified || g.sender == nil {
+		return
+	}
+
+	msg := mail.Message{
+		To:      email,
+		Subject: "New sign-in to your account",
+		Body: fmt.Sprintf("Your NFL Analytics account was signed in to from %s at %s.\n\n"+
+			"If this was you, there is nothing to do. If not, change your password and "+
+			"sign out the session from your security settings.\n", describe(a), g.now().UTC().Format("Jan 2, 2006 15:04 MST")),
+	}
+	// The sign-in has already happened, so the alert goes out even if the
+	// client has gone
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), alertTimeout)
+		defer cancel()
+		if err := g.sender.Send(ctx, msg); err != nil {
+			log.Printf("Failed to send new sign-in alert to user %s: %v", userID, err)
+		}
+	}()
+}
+
+// Fingerprint identifies a kind of device by its browser and operating
+// system. Versions are left out, so updates do not make a device new.
+func Fingerprint(device activity.Device) string {
+	return fmt.Sprintf("%s|%s|%t", device.Browser, device.OS, device.Mobile)
+}
+
+// Network is the network an address is in, standing in for where the user
+// is: the /24 of an IPv4 address or the /48 of an IPv6 one. Invalid or
+// empty addresses have none.
+func Network(ip string) string {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return ""
+	}
+	if v4 := addr.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: addr.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+}
+
+// describe names a sign-in's device and address for an email
+func describe(a Assessment) string {
+	where := fmt.Sprintf("%s on %s", a.Device.Browser, a.Device.OS)
+	if a.IPAddress != "" {
+		where += fmt.Sprintf(" (IP address %s)", a.IPAddress)
+	}
+	return where
+}
+
+// newCode generates a six-digit verification code
+func newCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashCode hashes a code with its verification's ID, so stored hashes
+// cannot be looked up in a table of every code
+func hashCode(id uuid.UUID, code string) string {
+	sum := sha256.Sum256([]byte(id.String() + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,283 @@
+package loginguard
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
+	"github.com/nfl-analytics/backend/internal/audit"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/mail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chromeOnWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Safari/537.36"
+	safariOnIPhone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1"
+)
+
+type memoryStore struct {
+	mu            sync.Mutex
+	devices       map[uuid.UUID]map[string]bool
+	networks      map[uuid.UUID]map[string]bool
+	verifications map[uuid.UUID]*Verification
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		devices:       map[uuid.UUID]map[string]bool{},
+		networks:      map[uuid.UUID]map[string]bool{},
+		verifications: map[uuid.UUID]*Verification{},
+	}
+}
+
+func (s *memoryStore) Known(ctx context.Context, userID uuid.UUID, fingerprint, network string) (Known, error) {
+	return Known{Any: len(s.devices[userID]) > 0, Device: s.devices[userID][fingerprint], Network: s.networks[userID][network]}, nil
+}
+
+func (s *memoryStore) Remember(ctx context.Context, userID uuid.UUID, fingerprint string, device activity.Device, network, ip string) error {
+	if s.devices[userID] == nil {
+		s.devices[userID], s.networks[userID] = map[string]bool{}, map[string]bool{}
+	}
+	s.devices[userID][fingerprint] = true
+	if network != "" {
+		s.networks[userID][network] = true
+	}
+	return nil
+}
+
+func (s *memoryStore) CreateVerification(ctx context.Context, v *Verification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *v
+	s.verifications[v.ID] = &copied
+	return nil
+}
+
+func (s *memoryStore) AttemptVerification(ctx context.Context, id, userID uuid.UUID, maxAttempts int) (*Verification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.verifications[id]
+	if !ok || v.UserID != userID || v.Attempts >= maxAttempts || !time.Now().Before(v.ExpiresAt) {
+		return nil, ErrVerificationNotFound
+	}
+	v.Attempts++
+	copied := *v
+	return &copied, nil
+}
+
+func (s *memoryStore) ConsumeVerification(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.verifications[id]; !ok {
+		return ErrVerificationNotFound
+	}
+	delete(s.verifications, id)
+	return nil
+}
+
+type outbox struct {
+	mu   sync.Mutex
+	sent []mail.Message
+}
+
+func (o *outbox) Send(ctx context.Context, msg mail.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+func (o *outbox) messages() []mail.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]mail.Message(nil), o.sent...)
+}
+
+type eventLog struct {
+	events []audit.Event
+}
+
+func (l *eventLog) Record(ctx context.Context, event *audit.Event) error {
+	l.events = append(l.events, *event)
+	return nil
+}
+
+func (l *eventLog) List(ctx context.Context, userID uuid.UUID, filter audit.Filter) ([]audit.Event, error) {
+	return l.events, nil
+}
+
+// clientContext is a request context from the user agent and address
+func clientContext(userAgent, ip string) context.Context {
+	req := httptest.NewRequest("POST", "/api/auth/login", nil)
+	req.Header.Set("User-Agent", userAgent)
+	req.RemoteAddr = net.JoinHostPort(ip, "4000")
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	return auth.WithClient(context.Background(), c)
+}
+
+func TestGuard_FlagsNewDevicesAndNetworks(t *testing.T) {
+	store, sent, events := newMemoryStore(), &outbox{}, &eventLog{}
+	guard := NewGuard(store, sent, audit.NewLogger(events))
+	userID := uuid.New()
+	home := clientContext(chromeOnWindows, "203.0.113.10")
+
+	// The first sign-in has nothing to compare with
+	first := guard.Assess(home, userID)
+	assert.True(t, first.FirstLogin)
+	assert.False(t, first.Suspicious())
+	guard.Succeeded(home, userID, "fan@example.com", first, false)
+
+	// Another address on the same network and an updated browser are known
+	again := guard.Assess(clientContext(chromeOnWindows, "203.0.113.77"), userID)
+	assert.False(t, again.Suspicious())
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		newDevice  bool
+		newNetwork bool
+	}{
+		{"new network", clientContext(chromeOnWindows, "198.51.100.4"), false, true},
+		{"new device", clientContext(safariOnIPhone, "203.0.113.10"), true, false},
+		{"new device and network", clientContext(safariOnIPhone, "2001:db8:1234::1"), true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := guard.Assess(tt.ctx, userID)
+			assert.True(t, a.Suspicious())
+			assert.Equal(t, tt.newDevice, a.NewDevice)
+			assert.Equal(t, tt.newNetwork, a.NewNetwork)
+		})
+	}
+
+	away := clientContext(safariOnIPhone, "198.51.100.4")
+	assessment := guard.Assess(away, userID)
+	guard.Succeeded(away, userID, "fan@example.com", assessment, false)
+
+	require.Len(t, events.events, 1)
+	assert.Equal(t, audit.EventNewDeviceLogin, events.events[0].Event)
+	assert.Equal(t, "198.51.100.0/24", events.events[0].Details["network"])
+	assert.Eventually(t, func() bool { return len(sent.messages()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "fan@example.com", sent.messages()[0].To)
+	assert.Contains(t, sent.messages()[0].Body, "Safari on iOS (IP address 198.51.100.4)")
+
+	// Once let in, the device and network are known
+	assert.False(t, guard.Assess(away, userID).Suspicious())
+}
+
+func TestGuard_Verification(t *testing.T) {
+	store, sent := newMemoryStore(), &outbox{}
+	guard := NewGuard(store, sent, nil).WithVerification(true)
+	userID := uuid.New()
+	require.NoError(t, store.Remember(context.Background(), userID, Fingerprint(activity.DescribeDevice(chromeOnWindows)), activity.Device{}, "203.0.113.0/24", ""))
+
+	ctx := clientContext(safariOnIPhone, "198.51.100.4")
+	assessment := guard.Assess(ctx, userID)
+	require.True(t, guard.RequiresVerification(assessment))
+	assert.False(t, guard.RequiresVerification(guard.Assess(clientContext(chromeOnWindows, "203.0.113.10"), userID)))
+
+	id, err := guard.Challenge(ctx, userID, "fan@example.com", assessment, true)
+	require.NoError(t, err)
+	require.Len(t, sent.messages(), 1)
+	code := regexp.MustCompile(`\b\d{6}\b`).FindString(sent.messages()[0].Body)
+	require.NotEmpty(t, code)
+
+	_, err = guard.Verify(ctx, uuid.New(), id, code)
+	assert.ErrorIs(t, err, ErrVerificationNotFound, "another user's verification")
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	_, err = guard.Verify(ctx, userID, id, wrong)
+	assert.ErrorIs(t, err, ErrInvalidCode)
+
+	v, err := guard.Verify(ctx, userID, id, code)
+	require.NoError(t, err)
+	assert.True(t, v.RememberMe)
+	_, err = guard.Verify(ctx, userID, id, code)
+	assert.ErrorIs(t, err, ErrVerificationNotFound, "codes work once")
+}
+
+func TestGuard_VerificationLocksAfterWrongCodes(t *testing.T) {
+	store, sent := newMemoryStore(), &outbox{}
+	guard := NewGuard(store, sent, nil).WithVerification(true)
+	userID := uuid.New()
+	ctx := clientContext(safariOnIPhone, "198.51.100.4")
+
+	id, err := guard.Challenge(ctx, userID, "fan@example.com", guard.Assess(ctx, userID), false)
+	require.NoError(t, err)
+	code := regexp.MustCompile(`\b\d{6}\b`).FindString(sent.messages()[0].Body)
+	for i := 0; i < maxVerificationAttempts; i++ {
+		_, err = guard.Verify(ctx, userID, id, "not-a-code")
+		assert.ErrorIs(t, err, ErrInvalidCode)
+	}
+	_, err = guard.Verify(ctx, userID, id, code)
+	assert.ErrorIs(t, err, ErrVerificationNotFound)
+}
+
+func TestGuard_ConcurrentVerifications(t *testing.T) {
+	store, sent := newMemoryStore(), &outbox{}
+	guard := NewGuard(store, sent, nil).WithVerification(true)
+	userID := uuid.New()
+	ctx := clientContext(safariOnIPhone, "198.51.100.4")
+
+	// verifyAtOnce sends code for a new verification from 20 requests at
+	// once and counts their outcomes
+	verifyAtOnce := func(code func(string) string) map[error]int {
+		id, err := guard.Challenge(ctx, userID, "fan@example.com", guard.Assess(ctx, userID), false)
+		require.NoError(t, err)
+		messages := sent.messages()
+		sentCode := regexp.MustCompile(`\b\d{6}\b`).FindString(messages[len(messages)-1].Body)
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		outcomes := map[error]int{}
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := guard.Verify(ctx, userID, id, code(sentCode))
+				mu.Lock()
+				defer mu.Unlock()
+				outcomes[err]++
+			}()
+		}
+		wg.Wait()
+		return outcomes
+	}
+
+	outcomes := verifyAtOnce(func(sentCode string) string { return sentCode })
+	assert.Equal(t, 1, outcomes[nil], "a code signs in once")
+	assert.Equal(t, 19, outcomes[ErrVerificationNotFound])
+
+	outcomes = verifyAtOnce(func(string) string { return "not-a-code" })
+	assert.Equal(t, maxVerificationAttempts, outcomes[ErrInvalidCode], "wrong codes past the limit are not checked")
+	assert.Equal(t, 20-maxVerificationAttempts, outcomes[ErrVerificationNotFound])
+}
+
+func TestNetwork(t *testing.T) {
+	assert.Equal(t, "203.0.113.0/24", Network("203.0.113.200"))
+	assert.Equal(t, "2001:db8:1234::/48", Network("2001:db8:1234:5678::1"))
+	assert.Equal(t, "203.0.113.0/24", Network("::ffff:203.0.113.9"))
+	assert.Equal(t, "", Network(""))
+	assert.Equal(t, "", Network("not an ip"))
+}
+
+func TestNilGuard(t *testing.T) {
+	var guard *Guard
+	a := guard.Assess(context.Background(), uuid.New())
+	assert.False(t, a.Suspicious())
+	assert.False(t, guard.RequiresVerification(a))
+	guard.Succeeded(context.Background(), uuid.New(), "fan@example.com", a, false)
+}
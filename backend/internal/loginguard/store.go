@@ -0,0 +1,122 @@
+package loginguard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/activity"
+)
+
+// PostgresStore keeps known devices and networks in the user_known_devices
+// and user_known_networks tables and verifications in login_verifications
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new login guard store
+func NewPostgresStore(db *sql.DB) Store {
+	return &PostgresStore{db: db}
+}
+
+// Known reports what the user has signed in from before
+func (s *PostgresStore) Known(ctx context.Context, userID uuid.UUID, fingerprint, network string) (Known, error) {
+	var known Known
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			EXISTS (SELECT 1 FROM user_known_devices WHERE user_id = $1),
+			EXISTS (SELECT 1 FROM user_known_devices WHERE user_id = $1 AND fingerprint = $2),
+			EXISTS (SELECT 1 FROM user_known_networks WHERE user_id = $1 AND network::text = $3)`,
+		userID, fingerprint, network,
+	).Scan(&known.Any, &known.Device, &known.Network)
+	if err != nil {
+		return Known{}, fmt.Errorf("failed to get known devices: %w", err)
+	}
+	return known, nil
+}
+
+// Remember records a sign-in from the device and network
+func (s *PostgresStore) Remember(ctx context.Context, userID uuid.UUID, fingerprint string, device activity.Device, network, ip string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO user_known_devices (user_id, fingerprint, browser, os, mobile)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, fingerprint) DO UPDATE SET last_seen_at = NOW()`,
+		userID, fingerprint, device.Browser, device.OS, device.Mobile,
+	); err != nil {
+		return fmt.Errorf("failed to remember device: %w", err)
+	}
+	if network != "" {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO user_known_networks (user_id, network, last_ip_address)
+			VALUES ($1, $2::cidr, NULLIF($3, '')::inet)
+			ON CONFLICT (user_id, network) DO UPDATE SET last_ip_address = EXCLUDED.last_ip_address, last_seen_at = NOW()`,
+			userID, network, ip,
+		); err != nil {
+			return fmt.Errorf("failed to remember network: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CreateVerification stores a pending verification, clearing out expired
+// ones
+func (s *PostgresStore) CreateVerification(ctx context.Context, v *Verification) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM login_verifications WHERE expires_at < NOW()`); err != nil {
+		return fmt.Errorf("failed to delete expired login verifications: %w", err)
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO login_verifications (id, user_id, code_hash, remember_me, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		v.ID, v.UserID, v.CodeHash, v.RememberMe, v.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create login verification: %w", err)
+	}
+	return nil
+}
+
+// AttemptVerification counts an attempt at a pending verification
+func (s *PostgresStore) AttemptVerification(ctx context.Context, id, userID uuid.UUID, maxAttempts int) (*Verification, error) {
+	v := &Verification{}
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE login_verifications SET attempts = attempts + 1
+		WHERE id = $1 AND user_id = $2 AND attempts < $3 AND expires_at > NOW()
+		RETURNING id, user_id, code_hash, remember_me, attempts, expires_at`,
+		id, userID, maxAttempts,
+	).Scan(&v.ID, &v.UserID, &v.CodeHash, &v.RememberMe, &v.Attempts, &v.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVerificationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to attempt login verification: %w", err)
+	}
+	return v, nil
+}
+
+// ConsumeVerification removes a verification once it is used
+func (s *PostgresStore) ConsumeVerification(ctx context.Context, id uuid.UUID) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM login_verifications WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete login verification: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrVerificationNotFound
+	}
+	return nil
+}
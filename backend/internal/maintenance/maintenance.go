@@ -0,0 +1,197 @@
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// stateKey is the Redis key shared by every API instance
+const stateKey = "maintenance:state"
+
+// State is the current maintenance mode setting
+type State struct {
+	Enabled   bool      `json:"enabled"`
+	Message   string    `json:"message,omitempty"`
+	StartedAt time.Time `json:"started_at,omitempty"`
+	// DraftGraceUntil is when in-progress drafts stop being served
+	DraftGraceUntil time.Time `json:"draft_grace_until,omitempty"`
+}
+
+// InDraftGrace reports whether drafts are still served at now
+func (s State) InDraftGrace(now time.Time) bool {
+	return s.Enabled && now.Before(s.DraftGraceUntil)
+}
+
+// Switch is the global maintenance flag. The state lives in Redis so every
+// instance sees a toggle; without Redis it is kept in memory and comes from
+// config. Each instance caches the Redis state for the refresh interval.
+type Switch struct {
+	redis          *redis.Client
+	defaultMessage string
+	grace          time.Duration
+	refresh        time.Duration
+
+	mu       sync.RWMutex
+	state    State
+	loadedAt time.Time
+}
+
+// NewSwitch creates a maintenance switch. enabled and message are used until
+// the switch is toggled through Enable or Disable.
+func NewSwitch(redisClient *redis.Client, enabled bool, message string, grace, refresh time.Duration) *Switch {
+	s := &Switch{
+		redis:          redisClient,
+		defaultMessage: message,
+		grace:          grace,
+		refresh:        refresh,
+	}
+	if enabled {
+		s.state = s.newState(message, time.Now())
+	}
+	return s
+}
+
+// State returns the current maintenance state. Redis errors keep the last
+// known state so an outage does not flip the switch.
+func (s *Switch) State(ctx context.Context) State {
+	if s == nil {
+		return State{}
+	}
+
+	s.mu.RLock()
+	state, fresh := s.state, time.Since(s.loadedAt) < s.refresh
+	s.mu.RUnlock()
+
+	if s.redis == nil || fresh {
+		return state
+	}
+
+	data, err := s.redis.Get(ctx, stateKey).Bytes()
+	switch {
+	case err == redis.Nil:
+		// Never toggled; keep the state from config
+	case err != nil:
+		log.Printf("Failed to read maintenance state: %v", err)
+	default:
+		var stored State
+		if err := json.Unmarshal(data, &stored); err != nil {
+			log.Printf("Failed to parse maintenance state: %v", err)
+		} else {
+			state = stored
+		}
+	}
+
+	s.mu.Lock()
+	s.state, s.loadedAt = state, time.Now()
+	s.mu.Unlock()
+
+	return state
+}
+
+// Enable turns maintenance mode on. An empty message uses the configured
+// default. Enabling while already on keeps the original start and grace
+// deadline so drafts are not given more time.
+func (s *Switch) Enable(ctx context.Context, message string) (State, error) {
+	if message == "" {
+		message = s.defaultMessage
+	}
+
+	current := s.State(ctx)
+	state := s.newState(message, time.Now())
+	if current.Enabled {
+		state.StartedAt = current.StartedAt
+		state.DraftGraceUntil = current.DraftGraceUntil
+	}
+
+	return state, s.save(ctx, state)
+}
+
+// Disable turns maintenance mode off
+func (s *Switch) Disable(ctx context.Context) (State, error) {
+	state := State{}
+	return state, s.save(ctx, state)
+}
+
+// Paused reports whether background jobs should skip their runs
+func (s *Switch) Paused(ctx context.Context) bool {
+	return s.State(ctx).Enabled
+}
+
+func (s *Switch) newState(message string, now time.Time) State {
+	return State{
+		Enabled:         true,
+		Message:         message,
+		StartedAt:       now,
+		DraftGraceUntil: now.Add(s.grace),
+	}
+}
+
+func (s *Switch) save(ctx context.Context, state State) error {
+	if s.redis != nil {
+		data, err := json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("failed to marshal maintenance state: %w", err)
+		}
+		if err := s.redis.Set(ctx, stateKey, data, 0).Err(); err != nil {
+			return fmt.Errorf("failed to save maintenance state: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.state, s.loadedAt = state, time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Middleware returns 503 with a maintenance payload while maintenance mode
+// is on. Requests under an exempt path prefix, such as health checks and
+// admin routes, always pass. Requests under a draft prefix pass until the
+// draft grace period ends, with headers warning that maintenance has begun.
+func Middleware(s *Switch, exempt, drafts []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if hasPrefix(path, exempt) {
+			c.Next()
+			return
+		}
+
+		state := s.State(c.Request.Context())
+		if !state.Enabled {
+			c.Next()
+			return
+		}
+
+		if hasPrefix(path, drafts) && state.InDraftGrace(time.Now()) {
+			c.Header("X-Maintenance-Warning", state.Message)
+			c.Header("X-Maintenance-Deadline", state.DraftGraceUntil.UTC().Format(time.RFC3339))
+			c.Next()
+			return
+		}
+
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":      "maintenance",
+			"message":    state.Message,
+			"started_at": state.StartedAt,
+		})
+		c.Abort()
+	}
+}
+
+func hasPrefix(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if path == p || strings.HasPrefix(path, strings.TrimSuffix(p, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package maintenance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwitchEnableDisable(t *testing.T) {
+	ctx := context.Background()
+	sw := NewSwitch(nil, false, "Back soon", 10*time.Minute, time.Second)
+	assert.False(t, sw.Paused(ctx))
+
+	state, err := sw.Enable(ctx, "")
+	require.NoError(t, err)
+	assert.True(t, state.Enabled)
+	assert.Equal(t, "Back soon", state.Message)
+	assert.WithinDuration(t, state.StartedAt.Add(10*time.Minute), state.DraftGraceUntil, 0)
+	assert.True(t, sw.Paused(ctx))
+
+	// Re-enabling updates the message without extending the grace period
+	again, err := sw.Enable(ctx, "Migrating the database")
+	require.NoError(t, err)
+	assert.Equal(t, "Migrating the database", again.Message)
+	assert.Equal(t, state.DraftGraceUntil, again.DraftGraceUntil)
+
+	_, err = sw.Disable(ctx)
+	require.NoError(t, err)
+	assert.False(t, sw.Paused(ctx))
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	sw := NewSwitch(nil, true, "Back soon", time.Hour, time.Second)
+
+	r := gin.New()
+	r.Use(Middleware(sw, []string{"/health", "/api/admin"}, []string{"/api/draft"}))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/health", ok)
+	r.GET("/api/admin/maintenance", ok)
+	r.GET("/api/draft/sessions", ok)
+	r.GET("/api/leagues", ok)
+	r.GET("/api/drafts-report", ok)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, get("/health").Code)
+	assert.Equal(t, http.StatusOK, get("/api/admin/maintenance").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, get("/api/leagues").Code)
+	assert.Contains(t, get("/api/leagues").Body.String(), "Back soon")
+	assert.Equal(t, http.StatusServiceUnavailable, get("/api/drafts-report").Code)
+
+	// Drafts keep working during the grace period, with a warning
+	w := get("/api/draft/sessions")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Back soon", w.Header().Get("X-Maintenance-Warning"))
+	assert.NotEmpty(t, w.Header().Get("X-Maintenance-Deadline"))
+
+	// Once the grace period ends drafts are closed too
+	expired := NewSwitch(nil, true, "Back soon", 0, time.Second)
+	r2 := gin.New()
+	r2.Use(Middleware(expired, nil, []string{"/api/draft"}))
+	r2.GET("/api/draft/sessions", ok)
+	w = httptest.NewRecorder()
+	r2.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/draft/sessions", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	_, err := sw.Disable(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, get("/api/leagues").Code)
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SampleRater returns the share of requests, 0-1, whose metrics are recorded
+type SampleRater interface {
+	MetricsSampleRate(ctx context.Context) float64
+}
+
+// SampledMetrics logs route, status and latency for a sample of requests so
+// load can be watched without logging every request
+func SampledMetrics(rater SampleRater) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rand.Float64() >= rater.MetricsSampleRate(c.Request.Context()) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		log.Printf("metrics method=%s route=%s status=%d latency_ms=%d",
+			c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start).Milliseconds())
+	}
+}
@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimitKeyPrefix namespaces rate limit counters in Redis
+const RateLimitKeyPrefix = "ratelimit:"
+
+// LimitScaler raises a rate limit at runtime, such as for draft routes
+// during draft-day surge mode
+type LimitScaler interface {
+	RateLimitMultiplier(ctx context.Context) float64
+}
+
+// rateDecision is whether a request is within its limit
+type rateDecision struct {
+	allowed   bool
+	remaining int
+	// reset is when the current window ends
+	reset time.Time
+	// retryAfter is how long a limited client should wait
+	retryAfter time.Duration
+}
+
+// rateWindow counts a client's requests in the current window
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// rateLimiter is an in-memory fixed-window limiter keyed by client
+type rateLimiter struct {
+	mu      sync.Mutex
+	window  time.Duration
+	windows map[string]*rateWindow
+}
+
+func newRateLimiter(window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		window:  window,
+		windows: make(map[string]*rateWindow),
+	}
+}
+
+// allow records a request for key if it is within limit
+func (l *rateLimiter) allow(key string, limit int, now time.Time) rateDecision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		// Drop expired windows so idle clients do not accumulate
+		if len(l.windows) > 10000 {
+			for k, old := range l.windows {
+				if now.Sub(old.start) >= l.window {
+					delete(l.windows, k)
+				}
+			}
+		}
+		w = &rateWindow{start: now}
+		l.windows[key] = w
+	}
+
+	reset := w.start.Add(l.window)
+	if w.count >= limit {
+		return rateDecision{reset: reset, retryAfter: reset.Sub(now)}
+	}
+	w.count++
+	return rateDecision{allowed: true, remaining: limit - w.count, reset: reset}
+}
+
+// rateLimitScript counts a request against a sliding window: the current
+// fixed window's count plus the previous window's, weighted by how much of
+// it the sliding window still covers. Returns whether the request was
+// allowed and both counts.
+var rateLimitScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+if math.floor(previous * tonumber(ARGV[2])) + current >= tonumber(ARGV[1]) then
+	return {0, current, previous}
+end
+current = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return {1, current, previous}
+`)
+
+// redisRateLimiter is a sliding window limiter shared by every instance
+// through Redis
+type redisRateLimiter struct {
+	redis  *redis.Client
+	group  string
+	window time.Duration
+}
+
+// allow records a request for key if it is within limit
+func (l *redisRateLimiter) allow(ctx context.Context, key string, limit int, now time.Time) (rateDecision, error) {
+	index := now.UnixNano() / int64(l.window)
+	start := time.Unix(0, index*int64(l.window))
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(l.window)
+
+	prefix := RateLimitKeyPrefix + l.group + ":" + key + ":"
+	keys := []string{prefix + strconv.FormatInt(index, 10), prefix + strconv.FormatInt(index-1, 10)}
+	// Counters outlive their window so the next one can weigh them
+	ttl := (2 * l.window).Milliseconds()
+
+	result, err := rateLimitScript.Run(ctx, l.redis, keys, limit, weight, ttl).Int64Slice()
+	if err != nil {
+		return rateDecision{}, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	allowed, current, previous := result[0] == 1, result[1], result[2]
+
+	decision := rateDecision{allowed: allowed, reset: start.Add(l.window)}
+	used := int(math.Floor(float64(previous)*weight)) + int(current)
+	if allowed {
+		decision.remaining = max(limit-used, 0)
+	} else {
+		decision.retryAfter = slidingRetryAfter(limit, int(current), int(previous), elapsed, l.window)
+	}
+	return decision, nil
+}
+
+// slidingRetryAfter is how long until enough of the previous window's
+// requests slide out for one more request. Past the current window, its
+// requests become the previous window's and slide out in turn.
+func slidingRetryAfter(limit, current, previous int, elapsed, window time.Duration) time.Duration {
+	if current >= limit {
+		return window - elapsed + time.Duration(float64(window)*(1-float64(limit)/float64(current)))
+	}
+	// Allowed once previous * (1 - (elapsed + wait) / window) < limit - current
+	wait := time.Duration(float64(window)*(1-float64(limit-current)/float64(previous))) - elapsed
+	if wait < time.Second {
+		return time.Second
+	}
+	return wait
+}
+
+// RateLimit limits each user, or each IP for anonymous requests, to limit
+// requests per window on this instance. scaler may be nil.
+func RateLimit(limit int, window time.Duration, scaler LimitScaler) gin.HandlerFunc {
+	l := newRateLimiter(window)
+
+	return func(c *gin.Context) {
+		effective := scaledLimit(c, limit, scaler)
+		limitRequest(c, effective, l.allow(rateLimitKey(c), effective, time.Now()))
+	}
+}
+
+// RedisRateLimit limits each user, or each IP for anonymous requests, to
+// limit requests per sliding window across every instance, counting them in
+// Redis under group. Without Redis, or while it is failing, each instance
+// limits on its own. scaler may be nil.
+func RedisRateLimit(redisClient *redis.Client, group string, limit int, window time.Duration, scaler LimitScaler) gin.HandlerFunc {
+	if redisClient == nil {
+		return RateLimit(limit, window, scaler)
+	}
+	l := &redisRateLimiter{redis: redisClient, group: group, window: window}
+	fallback := newRateLimiter(window)
+
+	return func(c *gin.Context) {
+		effective := scaledLimit(c, limit, scaler)
+		key := rateLimitKey(c)
+		now := time.Now()
+
+		decision, err := l.allow(c.Request.Context(), key, effective, now)
+		if err != nil {
+			log.Printf("Rate limiting %s on this instance only: %v", group, err)
+			decision = fallback.allow(key, effective, now)
+		}
+		limitRequest(c, effective, decision)
+	}
+}
+
+// rateLimitKey identifies the client a request counts against
+func rateLimitKey(c *gin.Context) string {
+	if userID, ok := auth.GetUserID(c); ok {
+		return "user:" + userID.String()
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func scaledLimit(c *gin.Context, limit int, scaler LimitScaler) int {
+	if scaler == nil {
+		return limit
+	}
+	return int(math.Ceil(float64(limit) * scaler.RateLimitMultiplier(c.Request.Context())))
+}
+
+// limitRequest sets the rate limit headers and rejects a request over its
+// limit with 429
+func limitRequest(c *gin.Context, limit int, decision rateDecision) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.remaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(decision.reset.Unix(), 10))
+	if !decision.allowed {
+		retryAfter := int(math.Ceil(decision.retryAfter.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": fmt.Sprintf("rate limit exceeded, retry in %d seconds", retryAfter),
+		})
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func rateLimitedRouter(limiter gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(limiter)
+	r.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func get(r *gin.Engine) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_Headers(t *testing.T) {
+	r := rateLimitedRouter(RateLimit(2, time.Minute, nil))
+
+	w := get(r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Remaining"))
+	assert.NotEmpty(t, w.Header().Get("X-RateLimit-Reset"))
+
+	w = get(r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+
+	w = get(r)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+}
+
+func TestRedisRateLimit_FallsBackWhenRedisFails(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		DialTimeout: 50 * time.Millisecond,
+		MaxRetries:  -1,
+	})
+	defer client.Close()
+	r := rateLimitedRouter(RedisRateLimit(client, "test", 1, time.Minute, nil))
+
+	assert.Equal(t, http.StatusOK, get(r).Code)
+	assert.Equal(t, http.StatusTooManyRequests, get(r).Code)
+}
+
+func TestRateLimit_IgnoresForwardedForFromUntrustedClients(t *testing.T) {
+	r := rateLimitedRouter(RateLimit(1, time.Minute, nil))
+	assert.NoError(t, r.SetTrustedProxies(nil))
+
+	forwardedFor := func(ip string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "203.0.113.7:1234"
+		req.Header.Set("X-Forwarded-For", ip)
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, forwardedFor("198.51.100.1"))
+	assert.Equal(t, http.StatusTooManyRequests, forwardedFor("198.51.100.2"))
+}
+
+func TestSlidingRetryAfter(t *testing.T) {
+	window := time.Minute
+
+	// Ten requests last window, fifteen seconds in: three more this window
+	// leave room once the old ones weigh under seven, past 18 seconds
+	assert.Equal(t, 3*time.Second, slidingRetryAfter(10, 3, 10, 15*time.Second, window))
+	// This window alone is full: wait it out, then for half of it to slide by
+	assert.Equal(t, 75*time.Second, slidingRetryAfter(10, 20, 0, 15*time.Second, window))
+	// Never less than a second
+	assert.Equal(t, time.Second, slidingRetryAfter(10, 9, 2, 59*time.Second, window))
+}
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // RegisterRequest represents a user registration request
 type RegisterRequest struct {
@@ -8,17 +12,24 @@ type RegisterRequest struct {
 	Password  string `json:"password" binding:"required,min=12"`
 	FirstName string `json:"first_name" binding:"required"`
 	LastName  string `json:"last_name" binding:"required"`
+	// Token from the CAPTCHA widget, required when bot protection is enabled
+	CaptchaToken string `json:"captcha_token,omitempty"`
 }
 
 // LoginRequest represents a user login request
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
+	// RememberMe keeps the session signed in for the longer remember-me
+	// refresh lifetime instead of the standard one
+	RememberMe bool `json:"remember_me"`
 }
 
 // RefreshTokenRequest represents a token refresh request
 type RefreshTokenRequest struct {
-	RefreshToken string `json:"refresh_token" binding:"required"`
+	// RefreshToken may be left out in cookie mode, where the refresh token
+	// cookie is used
+	RefreshToken string `json:"refresh_token"`
 }
 
 // UserResponse represents user data in auth responses
@@ -30,9 +41,55 @@ type UserResponse struct {
 	IsActive  bool      `json:"is_active"`
 }
 
-// AuthResponse represents an authentication response
+// AuthResponse represents an authentication response. Users with two-factor
+// authentication get only TwoFactorRequired and TwoFactorToken at first, and
+// the rest once they verify a code.
 type AuthResponse struct {
-	User         *UserResponse `json:"user"`
-	AccessToken  string        `json:"access_token"`
-	RefreshToken string        `json:"refresh_token"`
-}
\ No newline at end of file
+	User              *UserResponse `json:"user,omitempty"`
+	AccessToken       string        `json:"access_token,omitempty"`
+	RefreshToken      string        `json:"refresh_token,omitempty"`
+	TwoFactorRequired bool          `json:"two_factor_required,omitempty"`
+	TwoFactorToken    string        `json:"two_factor_token,omitempty"`
+	// VerificationRequired is set for sign-ins from a new device or network
+	// that must be confirmed with a code emailed to the user, sent with
+	// VerificationToken
+	VerificationRequired bool   `json:"verification_required,omitempty"`
+	VerificationToken    string `json:"verification_token,omitempty"`
+	// RefreshExpiresAt is when the refresh token, and so the session,
+	// expires unless it is used
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty"`
+	// CSRFToken is set in cookie mode, where the tokens are cookies instead
+	CSRFToken string `json:"csrf_token,omitempty"`
+}
+
+// TwoFactorVerifyRequest completes a login that needs a second factor
+type TwoFactorVerifyRequest struct {
+	TwoFactorToken string `json:"two_factor_token" binding:"required"`
+	// Code is a code from the authenticator app or a backup code
+	Code string `json:"code" binding:"required"`
+}
+
+// LoginVerifyRequest completes a sign-in from a new device with the code
+// emailed to the user
+type LoginVerifyRequest struct {
+	VerificationToken string `json:"verification_token" binding:"required"`
+	Code              string `json:"code" binding:"required"`
+}
+
+// TwoFactorCodeRequest confirms a two-factor settings change with a code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// Session is a device the user is signed in on. It lasts until its refresh
+// token expires or it is revoked.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	SignedInAt time.Time `json:"signed_in_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Current is the session making the request
+	Current bool `json:"current"`
+}
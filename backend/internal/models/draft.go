@@ -31,6 +31,9 @@ type DraftSettings struct {
 	TimerSeconds     int              `json:"timer_seconds"`     // Seconds per pick (0 = no timer)
 	AutoDraftEnabled bool             `json:"auto_draft_enabled"`
 	KeeperPlayers    []string         `json:"keeper_players,omitempty"` // Player IDs for keepers
+	// RankingsSource is where recommendations take ADP from: "espn" (the
+	// default) or "fantasypros"
+	RankingsSource string `json:"rankings_source,omitempty"`
 }
 
 // RosterSlots defines roster requirements
@@ -58,6 +61,13 @@ type DraftPick struct {
 	Position    string    `json:"position" db:"position"`
 	IsKeeper    bool      `json:"is_keeper" db:"is_keeper"`
 	PickedAt    time.Time `json:"picked_at" db:"picked_at"`
+	// Latency telemetry, in milliseconds. ProcessingMS is the server's time to
+	// record the pick, DecisionMS the client-reported time from going on the
+	// clock to picking, and RecommendationMS how long the recommendations
+	// shown before the pick took to compute. Zero or nil when unknown.
+	ProcessingMS     int  `json:"processing_ms,omitempty" db:"processing_ms"`
+	DecisionMS       *int `json:"decision_ms,omitempty" db:"decision_ms"`
+	RecommendationMS int  `json:"recommendation_ms,omitempty" db:"recommendation_ms"`
 }
 
 // DraftState represents the current state of a draft (stored in Redis)
@@ -69,6 +79,11 @@ type DraftState struct {
 	UndoStack       []DraftEvent       `json:"undo_stack"`
 	RedoStack       []DraftEvent       `json:"redo_stack"`
 	LastAction      time.Time          `json:"last_action"`
+	// RecommendationMS is how long the latest recommendations took, carried
+	// onto the user's next pick
+	RecommendationMS int `json:"recommendation_ms,omitempty"`
+	// Queue is the players the user plans to take next, in order
+	Queue []string `json:"queue,omitempty"`
 }
 
 // DraftEvent represents an event in the draft (for undo/redo)
@@ -88,6 +103,12 @@ type DraftRecommendation struct {
 	Score         float64 `json:"score"`         // Recommendation score (0-100)
 	ValueOverADP  float64 `json:"value_over_adp"` // How much value vs ADP
 	PositionalNeed float64 `json:"positional_need"` // How much this position is needed
+	InjuryStatus  string  `json:"injury_status,omitempty"` // Current designation, empty when healthy
+	ByeWeek       int     `json:"bye_week,omitempty"`      // Team's bye week, 0 when unknown
+	// Availability is SUSPENDED, HOLDOUT or PUP for a healthy player who
+	// will miss games, empty otherwise
+	Availability string `json:"availability,omitempty"`
+	GamesMissed  int    `json:"games_missed,omitempty"` // Known games missed to Availability
 	Reasoning     string  `json:"reasoning"`      // Human-readable explanation
 }
 
@@ -104,14 +125,17 @@ func (ds *DraftSession) GetCurrentTeam() int {
 	if ds.CurrentPick == 0 {
 		return 1
 	}
-	
-	round := ds.GetCurrentRound()
+	return ds.TeamForPick(ds.CurrentPick)
+}
+
+// TeamForPick calculates which team makes an overall pick
+func (ds *DraftSession) TeamForPick(pickNumber int) int {
+	round := ((pickNumber - 1) / ds.TeamCount) + 1
 	if ds.DraftType == "snake" && round%2 == 0 {
 		// Even rounds go in reverse order for snake drafts
-		return ds.TeamCount - ((ds.CurrentPick - 1) % ds.TeamCount)
+		return ds.TeamCount - ((pickNumber - 1) % ds.TeamCount)
 	}
-	
-	return ((ds.CurrentPick - 1) % ds.TeamCount) + 1
+	return ((pickNumber - 1) % ds.TeamCount) + 1
 }
 
 // IsUserPick checks if it's currently the user's turn to pick
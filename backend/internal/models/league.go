@@ -26,6 +26,7 @@ type League struct {
 	EncryptedSWID   sql.NullString  `json:"-" db:"encrypted_swid"`
 	EncryptedESPN   sql.NullString  `json:"-" db:"encrypted_espn_s2"`
 	LastSyncAt      sql.NullTime    `json:"last_sync_at" db:"last_sync_at"`
+	IsSelected      bool            `json:"is_selected" db:"-"` // The user's selected league for analytics
 	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at" db:"updated_at"`
 }
@@ -38,17 +39,25 @@ type LeagueAuth struct {
 	EncryptedCredentials []byte    `json:"-" db:"encrypted_credentials"`
 	CreatedAt            time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at" db:"updated_at"`
+	// InvalidAt is when the platform rejected the credentials
+	InvalidAt     sql.NullTime   `json:"invalid_at" db:"invalid_at"`
+	InvalidReason sql.NullString `json:"invalid_reason" db:"invalid_reason"`
 }
 
 // LeagueSettings represents league configuration
 type LeagueSettings struct {
-	LeagueID         uuid.UUID `json:"league_id"`
-	Name             string    `json:"name"`
-	Season           int       `json:"season"`
-	ScoringType      string    `json:"scoring_type"`
-	RosterSize       int       `json:"roster_size"`
-	PlayoffTeams     int       `json:"playoff_teams"`
-	PlayoffWeekStart int       `json:"playoff_week_start"`
+	LeagueID         uuid.UUID          `json:"league_id"`
+	Name             string             `json:"name"`
+	Season           int                `json:"season"`
+	ScoringType      string             `json:"scoring_type"`
+	RosterSize       int                `json:"roster_size"`
+	PlayoffTeams     int                `json:"playoff_teams"`
+	PlayoffWeekStart int                `json:"playoff_week_start"`
+	TeamCount        int                `json:"team_count"`
+	Roster           RosterRequirements `json:"roster"`
+	ScoringRules     json.RawMessage    `json:"scoring_rules,omitempty"` // Platform point values
+	// HostPlatform is where a custom league is played, such as CBS
+	HostPlatform string `json:"host_platform,omitempty"`
 }
 
 // RosterRequirements defines roster position requirements
@@ -62,4 +71,83 @@ type RosterRequirements struct {
 	K    int `json:"k"`
 	BE   int `json:"bench"`
 	IR   int `json:"ir,omitempty"`
-}
\ No newline at end of file
+}
+// LeagueSyncStatus tracks the background sync health of a league
+type LeagueSyncStatus struct {
+	LeagueID            uuid.UUID      `json:"league_id" db:"league_id"`
+	LastAttemptAt       sql.NullTime   `json:"last_attempt_at" db:"last_attempt_at"`
+	LastSuccessAt       sql.NullTime   `json:"last_success_at" db:"last_success_at"`
+	LastError           sql.NullString `json:"last_error" db:"last_error"`
+	ConsecutiveFailures int            `json:"consecutive_failures" db:"consecutive_failures"`
+	UpdatedAt           time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// PlayerBoxScore is a rostered player's fantasy output for a league week
+type PlayerBoxScore struct {
+	LeagueID        uuid.UUID          `json:"league_id" db:"league_id"`
+	Season          int                `json:"season" db:"season"`
+	Week            int                `json:"week" db:"week"`
+	TeamID          int                `json:"team_id" db:"team_id"`
+	OpponentID      int                `json:"opponent_id" db:"opponent_id"`
+	PlayerID        string             `json:"player_id" db:"player_id"`
+	PlayerName      string             `json:"player_name" db:"player_name"`
+	Position        string             `json:"position" db:"position"`
+	NFLTeam         string             `json:"nfl_team" db:"nfl_team"`
+	LineupSlot      string             `json:"lineup_slot" db:"lineup_slot"`
+	Starter         bool               `json:"starter" db:"starter"`
+	Points          float64            `json:"points" db:"points"`
+	ProjectedPoints float64            `json:"projected_points" db:"projected_points"`
+	Stats           map[string]float64 `json:"stats" db:"stats"` // Raw stats keyed by platform stat ID
+	UpdatedAt       time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// LeagueSeasonHistory is a league's final results for a past season
+type LeagueSeasonHistory struct {
+	LeagueID   uuid.UUID       `json:"league_id" db:"league_id"`
+	Season     int             `json:"season" db:"season"`
+	Standings  json.RawMessage `json:"standings" db:"standings"`
+	Matchups   json.RawMessage `json:"matchups" db:"matchups"`
+	DraftPicks json.RawMessage `json:"draft_picks" db:"draft_picks"`
+	ImportedAt time.Time       `json:"imported_at" db:"imported_at"`
+}
+
+// LeagueRosterEntry is a player on a team's roster in a league week
+type LeagueRosterEntry struct {
+	LeagueID     uuid.UUID `json:"league_id" db:"league_id"`
+	Season       int       `json:"season" db:"season"`
+	Week         int       `json:"week" db:"week"`
+	TeamID       int       `json:"team_id" db:"team_id"`
+	PlayerID     string    `json:"player_id" db:"player_id"`
+	PlayerName   string    `json:"player_name" db:"player_name"`
+	Position     string    `json:"position" db:"position"`
+	NFLTeam      string    `json:"nfl_team" db:"nfl_team"`
+	InjuryStatus string    `json:"injury_status,omitempty" db:"injury_status"`
+	// EligiblePositions are the positions the player can start at
+	EligiblePositions []string   `json:"eligible_positions" db:"eligible_positions"`
+	LineupSlot        string     `json:"lineup_slot" db:"lineup_slot"`
+	AcquiredAt        *time.Time `json:"acquired_at,omitempty" db:"acquired_at"`
+	SyncedAt          time.Time  `json:"synced_at" db:"synced_at"`
+}
+
+// EventEligibilityChanged is the event published for an EligibilityChange
+const EventEligibilityChanged = "eligibility_changed"
+
+// EligibilityChange is a rostered player gaining or losing positions they can
+// start at, seen by comparing a sync with the one before it
+type EligibilityChange struct {
+	LeagueID   uuid.UUID `json:"league_id" db:"league_id"`
+	LeagueName string    `json:"league_name,omitempty" db:"-"`
+	// OwnerID is the user who connected the league
+	OwnerID uuid.UUID `json:"owner_id" db:"-"`
+	Season  int       `json:"season" db:"season"`
+	Week    int       `json:"week" db:"week"`
+	TeamID  int       `json:"team_id" db:"team_id"`
+	// OwnerRostered is true when the player is on the owner's team
+	OwnerRostered bool      `json:"owner_rostered" db:"-"`
+	PlayerID      string    `json:"player_id" db:"player_id"`
+	PlayerName    string    `json:"player_name" db:"player_name"`
+	Added         []string  `json:"added" db:"added"`
+	Removed       []string  `json:"removed" db:"removed"`
+	Positions     []string  `json:"positions" db:"positions"`
+	DetectedAt    time.Time `json:"detected_at" db:"detected_at"`
+}
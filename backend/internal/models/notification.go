@@ -0,0 +1,38 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification types
+const (
+	NotificationProjectionChange = "projection_change"
+	NotificationMaintenance      = "maintenance_warning"
+	NotificationPlayerDropped    = "player_dropped"
+	NotificationTradeProcessed   = "trade_processed"
+	NotificationEligibility      = "eligibility_change"
+	NotificationInactivity       = "account_inactivity"
+)
+
+// Notification is an in-app message for a user
+type Notification struct {
+	ID        int64           `json:"id" db:"id"`
+	UserID    uuid.UUID       `json:"user_id" db:"user_id"`
+	Type      string          `json:"type" db:"type"`
+	Title     string          `json:"title" db:"title"`
+	Body      string          `json:"body" db:"body"`
+	Data      json.RawMessage `json:"data,omitempty" db:"data"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	ReadAt    sql.NullTime    `json:"read_at" db:"read_at"`
+}
+
+// WatchlistEntry is a player a user follows for alerts
+type WatchlistEntry struct {
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	PlayerName string    `json:"player_name" db:"player_name"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
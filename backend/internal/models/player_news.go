@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// PlayerNews is a stored news blurb about a player
+type PlayerNews struct {
+	ID          int       `json:"id" db:"id"`
+	Source      string    `json:"source" db:"source"`
+	ExternalID  string    `json:"external_id" db:"external_id"`
+	PlayerID    string    `json:"player_id" db:"player_id"`
+	Headline    string    `json:"headline" db:"headline"`
+	Story       string    `json:"story" db:"story"`
+	NewsSource  string    `json:"news_source" db:"news_source"`
+	PublishedAt time.Time `json:"published_at" db:"published_at"`
+	FetchedAt   time.Time `json:"fetched_at" db:"fetched_at"`
+}
+
+// InjuryStatus is a player's injury designation for a week
+type InjuryStatus struct {
+	PlayerID   string    `json:"player_id" db:"player_id"`
+	PlayerName string    `json:"player_name" db:"player_name"`
+	Season     int       `json:"season" db:"season"`
+	Week       int       `json:"week" db:"week"`
+	Status     string    `json:"status" db:"status"`
+	Source     string    `json:"source" db:"source"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Transaction change kinds
+const (
+	TransactionPlayerDropped  = "player_dropped"
+	TransactionTradeProcessed = "trade_processed"
+)
+
+// TransactionChange is a league transaction that a sync saw for the first
+// time, or saw complete since the previous sync
+type TransactionChange struct {
+	Kind       string    `json:"kind"`
+	LeagueID   uuid.UUID `json:"league_id"`
+	LeagueName string    `json:"league_name,omitempty"`
+	// OwnerID is the user who connected the league
+	OwnerID       uuid.UUID `json:"owner_id"`
+	TransactionID string    `json:"transaction_id"`
+	TeamIDs       []int     `json:"team_ids"`
+	Players       []string  `json:"players"`
+	ProcessDate   time.Time `json:"process_date"`
+}
@@ -0,0 +1,347 @@
+package nflverse
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// ReleaseURL is where nflverse publishes its data files, one release per
+// dataset
+const ReleaseURL = "https://github.com/nflverse/nflverse-data/releases/download"
+
+// Datasets that can be ingested
+const (
+	DatasetPlayerStats = "player_stats"
+	DatasetSnapCounts  = "snap_counts"
+	DatasetRosters     = "rosters"
+)
+
+// Datasets lists every dataset in load order
+var Datasets = []string{DatasetPlayerStats, DatasetSnapCounts, DatasetRosters}
+
+// FileName is a dataset's file for one season, relative to ReleaseURL or a
+// local directory holding downloaded files
+func FileName(dataset string, season int) (string, error) {
+	switch dataset {
+	case DatasetPlayerStats:
+		return fmt.Sprintf("stats_player/stats_player_week_%d.csv", season), nil
+	case DatasetSnapCounts:
+		return fmt.Sprintf("snap_counts/snap_counts_%d.csv", season), nil
+	case DatasetRosters:
+		return fmt.Sprintf("rosters/roster_%d.csv", season), nil
+	default:
+		return "", fmt.Errorf("unknown nflverse dataset %q", dataset)
+	}
+}
+
+// Open opens a dataset's file for one season. source is either a base URL
+// such as ReleaseURL or a local directory laid out the same way. Only CSV
+// files are read; nflverse publishes every dataset as CSV as well as parquet.
+func Open(ctx context.Context, client *http.Client, source, dataset string, season int) (io.ReadCloser, error) {
+	name, err := FileName(dataset, season)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		f, err := os.Open(filepath.Join(source, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		return f, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(source, "/")+"/"+name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: status %d", name, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// PlayerWeekStats is one player's box score line for a week
+type PlayerWeekStats struct {
+	PlayerID         string  `json:"player_id"` // nflverse GSIS ID
+	PlayerName       string  `json:"player_name"`
+	Position         string  `json:"position"`
+	Team             string  `json:"team"`
+	Opponent         string  `json:"opponent"`
+	Season           int     `json:"season"`
+	Week             int     `json:"week"`
+	SeasonType       string  `json:"season_type"` // REG or POST
+	Completions      float64 `json:"completions"`
+	Attempts         float64 `json:"attempts"`
+	PassingYards     float64 `json:"passing_yards"`
+	PassingTDs       float64 `json:"passing_tds"`
+	Interceptions    float64 `json:"interceptions"`
+	Carries          float64 `json:"carries"`
+	RushingYards     float64 `json:"rushing_yards"`
+	RushingTDs       float64 `json:"rushing_tds"`
+	Targets          float64 `json:"targets"`
+	Receptions       float64 `json:"receptions"`
+	ReceivingYards   float64 `json:"receiving_yards"`
+	ReceivingTDs     float64 `json:"receiving_tds"`
+	FantasyPoints    float64 `json:"fantasy_points"`
+	FantasyPointsPPR float64 `json:"fantasy_points_ppr"`
+}
+
+// SnapCount is one player's snaps in a game
+type SnapCount struct {
+	PlayerID          string  `json:"player_id"` // Pro Football Reference ID
+	PlayerName        string  `json:"player_name"`
+	Position          string  `json:"position"`
+	Team              string  `json:"team"`
+	Opponent          string  `json:"opponent"`
+	Season            int     `json:"season"`
+	Week              int     `json:"week"`
+	GameType          string  `json:"game_type"`
+	OffenseSnaps      int     `json:"offense_snaps"`
+	OffensePct        float64 `json:"offense_pct"`
+	DefenseSnaps      int     `json:"defense_snaps"`
+	DefensePct        float64 `json:"defense_pct"`
+	SpecialTeamsSnaps int     `json:"special_teams_snaps"`
+	SpecialTeamsPct   float64 `json:"special_teams_pct"`
+}
+
+// RosterEntry is a player on a team's season roster
+type RosterEntry struct {
+	PlayerID     string `json:"player_id"` // nflverse GSIS ID
+	ESPNID       string `json:"espn_id,omitempty"`
+	PlayerName   string `json:"player_name"`
+	Position     string `json:"position"`
+	Team         string `json:"team"`
+	Season       int    `json:"season"`
+	JerseyNumber int    `json:"jersey_number,omitempty"`
+	Status       string `json:"status"`
+	YearsExp     int    `json:"years_exp"`
+}
+
+// ReadPlayerStats parses nflverse weekly player stats. Older files name some
+// columns differently, so both names are accepted.
+func ReadPlayerStats(r io.Reader) ([]PlayerWeekStats, error) {
+	var stats []PlayerWeekStats
+	err := eachRow(r, func(line int, row csvRow) error {
+		s := PlayerWeekStats{
+			PlayerID:   row.str("player_id"),
+			PlayerName: row.first("player_display_name", "player_name"),
+			Position:   row.str("position"),
+			Team:       projections.NFLVerseTeam(row.first("team", "recent_team")),
+			Opponent:   projections.NFLVerseTeam(row.first("opponent_team", "opponent")),
+			SeasonType: row.str("season_type"),
+		}
+		if s.PlayerID == "" {
+			return fmt.Errorf("line %d: missing player_id", line)
+		}
+
+		if err := row.ints(map[string]*int{"season": &s.Season, "week": &s.Week}); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := row.floats(map[string]*float64{
+			"completions":        &s.Completions,
+			"attempts":           &s.Attempts,
+			"passing_yards":      &s.PassingYards,
+			"passing_tds":        &s.PassingTDs,
+			"carries":            &s.Carries,
+			"rushing_yards":      &s.RushingYards,
+			"rushing_tds":        &s.RushingTDs,
+			"targets":            &s.Targets,
+			"receptions":         &s.Receptions,
+			"receiving_yards":    &s.ReceivingYards,
+			"receiving_tds":      &s.ReceivingTDs,
+			"fantasy_points":     &s.FantasyPoints,
+			"fantasy_points_ppr": &s.FantasyPointsPPR,
+		}); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		interceptions := row.first("passing_interceptions", "interceptions")
+		if interceptions != "" {
+			v, err := strconv.ParseFloat(interceptions, 64)
+			if err != nil {
+				return fmt.Errorf("line %d: invalid interceptions %q", line, interceptions)
+			}
+			s.Interceptions = v
+		}
+
+		stats = append(stats, s)
+		return nil
+	})
+	return stats, err
+}
+
+// ReadSnapCounts parses nflverse snap counts
+func ReadSnapCounts(r io.Reader) ([]SnapCount, error) {
+	var snaps []SnapCount
+	err := eachRow(r, func(line int, row csvRow) error {
+		s := SnapCount{
+			PlayerID:   row.str("pfr_player_id"),
+			PlayerName: row.str("player"),
+			Position:   row.str("position"),
+			Team:       projections.NFLVerseTeam(row.str("team")),
+			Opponent:   projections.NFLVerseTeam(row.str("opponent")),
+			GameType:   row.str("game_type"),
+		}
+		if s.PlayerID == "" {
+			return fmt.Errorf("line %d: missing pfr_player_id", line)
+		}
+
+		var offense, defense, specialTeams float64
+		if err := row.ints(map[string]*int{"season": &s.Season, "week": &s.Week}); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := row.floats(map[string]*float64{
+			"offense_snaps": &offense,
+			"offense_pct":   &s.OffensePct,
+			"defense_snaps": &defense,
+			"defense_pct":   &s.DefensePct,
+			"st_snaps":      &specialTeams,
+			"st_pct":        &s.SpecialTeamsPct,
+		}); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		s.OffenseSnaps, s.DefenseSnaps, s.SpecialTeamsSnaps = int(offense), int(defense), int(specialTeams)
+
+		snaps = append(snaps, s)
+		return nil
+	})
+	return snaps, err
+}
+
+// ReadRosters parses nflverse season rosters. Players without a GSIS ID,
+// mostly practice squad and futures signings, are skipped.
+func ReadRosters(r io.Reader) ([]RosterEntry, error) {
+	var roster []RosterEntry
+	err := eachRow(r, func(line int, row csvRow) error {
+		e := RosterEntry{
+			PlayerID:   row.str("gsis_id"),
+			ESPNID:     row.str("espn_id"),
+			PlayerName: row.str("full_name"),
+			Position:   row.str("position"),
+			Team:       projections.NFLVerseTeam(row.str("team")),
+			Status:     row.str("status"),
+		}
+		if e.PlayerID == "" {
+			return nil
+		}
+
+		if err := row.ints(map[string]*int{
+			"season":        &e.Season,
+			"jersey_number": &e.JerseyNumber,
+			"years_exp":     &e.YearsExp,
+		}); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		roster = append(roster, e)
+		return nil
+	})
+	return roster, err
+}
+
+type csvRow map[string]string
+
+func (r csvRow) str(column string) string {
+	value := strings.TrimSpace(r[column])
+	if value == "NA" {
+		return ""
+	}
+	return value
+}
+
+// first returns the first of the columns with a value
+func (r csvRow) first(columns ...string) string {
+	for _, column := range columns {
+		if value := r.str(column); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// floats parses the named columns into their targets; missing or empty
+// cells are zero
+func (r csvRow) floats(targets map[string]*float64) error {
+	for column, target := range targets {
+		value := r.str(column)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", column, value)
+		}
+		*target = parsed
+	}
+	return nil
+}
+
+// ints parses the named columns into their targets; missing or empty cells
+// are zero. Whole numbers written as floats, e.g. "12.0", are accepted.
+func (r csvRow) ints(targets map[string]*int) error {
+	for column, target := range targets {
+		value := r.str(column)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", column, value)
+		}
+		*target = int(parsed)
+	}
+	return nil
+}
+
+// eachRow streams a CSV file, calling fn with each row keyed by its
+// lower-cased header. Seasons of snap counts run to tens of thousands of
+// rows, so rows are not all read up front.
+func eachRow(r io.Reader, fn func(line int, row csvRow) error) error {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	header = append([]string(nil), header...)
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	row := make(csvRow, len(header))
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read CSV line %d: %w", line, err)
+		}
+
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			} else {
+				row[column] = ""
+			}
+		}
+		if err := fn(line, row); err != nil {
+			return err
+		}
+	}
+}
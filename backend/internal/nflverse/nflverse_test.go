@@ -0,0 +1,129 @@
+package nflverse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPlayerStats(t *testing.T) {
+	csv := "player_id,player_name,player_display_name,position,team,season,week,season_type,opponent_team,completions,attempts,passing_yards,passing_tds,passing_interceptions,carries,rushing_yards,rushing_tds,receptions,targets,receiving_yards,receiving_tds,fantasy_points,fantasy_points_ppr\n" +
+		"00-0033873,P.Mahomes,Patrick Mahomes,QB,KC,2024,1,REG,BAL,20,28,291,1,1,2,3,0,0,0,0,0,14.94,14.94\n" +
+		"00-0036223,J.Taylor,Jonathan Taylor,RB,IND,2024,1,REG,HOU,NA,NA,NA,NA,NA,16,48,1,2,3,12,0,12.0,14.0\n"
+
+	stats, err := ReadPlayerStats(strings.NewReader(csv))
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, "Patrick Mahomes", stats[0].PlayerName)
+	assert.Equal(t, "BAL", stats[0].Opponent)
+	assert.Equal(t, 291.0, stats[0].PassingYards)
+	assert.Equal(t, 1.0, stats[0].Interceptions)
+
+	// NA cells are zero
+	assert.Zero(t, stats[1].PassingYards)
+	assert.Equal(t, 48.0, stats[1].RushingYards)
+	assert.Equal(t, 14.0, stats[1].FantasyPointsPPR)
+}
+
+func TestReadPlayerStats_OlderColumns(t *testing.T) {
+	csv := "player_id,player_name,position,recent_team,season,week,season_type,interceptions\n" +
+		"00-0023459,A.Rodgers,QB,LA,2021,3,REG,2\n"
+
+	stats, err := ReadPlayerStats(strings.NewReader(csv))
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "A.Rodgers", stats[0].PlayerName)
+	assert.Equal(t, "LAR", stats[0].Team, "nflverse abbreviations are mapped to ESPN's")
+	assert.Equal(t, 2.0, stats[0].Interceptions)
+}
+
+func TestReadSnapCountsAndRosters(t *testing.T) {
+	snaps, err := ReadSnapCounts(strings.NewReader(
+		"game_id,pfr_game_id,season,game_type,week,player,pfr_player_id,position,team,opponent,offense_snaps,offense_pct,defense_snaps,defense_pct,st_snaps,st_pct\n" +
+			"2024_01_BAL_KC,202409050kan,2024,REG,1,Travis Kelce,KelcTr00,TE,KC,BAL,58.0,0.87,0.0,0.0,4.0,0.15\n"))
+	require.NoError(t, err)
+	require.Len(t, snaps, 1)
+	assert.Equal(t, 58, snaps[0].OffenseSnaps)
+	assert.Equal(t, 0.87, snaps[0].OffensePct)
+	assert.Equal(t, 4, snaps[0].SpecialTeamsSnaps)
+
+	roster, err := ReadRosters(strings.NewReader(
+		"season,team,position,jersey_number,status,full_name,gsis_id,espn_id,years_exp\n" +
+			"2024,WAS,QB,5,ACT,Jayden Daniels,00-0039910,4426348,0\n" +
+			"2024,WAS,WR,,RES,Futures Signing,,,0\n"))
+	require.NoError(t, err)
+	require.Len(t, roster, 1, "players without a GSIS ID are skipped")
+	assert.Equal(t, "WSH", roster[0].Team)
+	assert.Equal(t, "4426348", roster[0].ESPNID)
+	assert.Equal(t, 5, roster[0].JerseyNumber)
+}
+
+func TestDiffRosters(t *testing.T) {
+	now := time.Date(2024, 11, 6, 4, 0, 0, 0, time.UTC)
+	stored := []RosterEntry{
+		{PlayerID: "00-0036900", PlayerName: "Amari Cooper", Team: "CLE", Position: "WR", Status: "ACT", Season: 2024},
+		{PlayerID: "00-0033873", PlayerName: "Patrick Mahomes", Team: "KC", Position: "QB", Status: "ACT", Season: 2024},
+		{PlayerID: "00-0030000", PlayerName: "Released Player", Team: "NYG", Position: "RB", Status: "ACT", Season: 2024},
+	}
+	fresh := []RosterEntry{
+		{PlayerID: "00-0036900", ESPNID: "2976499", PlayerName: "Amari Cooper", Team: "BUF", Position: "WR", Status: "ACT", Season: 2024},
+		{PlayerID: "00-0033873", PlayerName: "Patrick Mahomes", Team: "KC", Position: "QB", Status: "ACT", Season: 2024},
+		{PlayerID: "00-0039000", PlayerName: "New Signing", Team: "NYG", Position: "RB", Status: "ACT", Season: 2024},
+	}
+
+	changes, added := DiffRosters(stored, fresh, now)
+	assert.Equal(t, 1, added)
+	require.Len(t, changes, 1, "unchanged and missing players are not reported")
+	assert.Equal(t, MetadataChange{
+		PlayerID: "00-0036900", ESPNID: "2976499", PlayerName: "Amari Cooper", Season: 2024,
+		Field: FieldTeam, OldValue: "CLE", NewValue: "BUF", DetectedAt: now,
+	}, changes[0])
+
+	fresh[1].Status, fresh[1].Position = "RES", "TE"
+	changes, _ = DiffRosters(stored, fresh, now)
+	require.Len(t, changes, 3)
+	assert.Equal(t, FieldStatus, changes[1].Field)
+	assert.Equal(t, FieldPosition, changes[2].Field)
+}
+
+func TestOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rosters/roster_2024.csv" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("season\n2024\n"))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	f, err := Open(ctx, server.Client(), server.URL, DatasetRosters, 2024)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(f)
+	f.Close()
+	assert.Equal(t, "season\n2024\n", string(body))
+
+	_, err = Open(ctx, server.Client(), server.URL, DatasetRosters, 2023)
+	assert.ErrorContains(t, err, "status 404")
+
+	// A directory of downloaded files works the same way
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "snap_counts"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "snap_counts", "snap_counts_2024.csv"), []byte("season\n"), 0o644))
+	f, err = Open(ctx, nil, dir, DatasetSnapCounts, 2024)
+	require.NoError(t, err)
+	f.Close()
+
+	_, err = Open(ctx, nil, dir, "play_by_play", 2024)
+	assert.ErrorContains(t, err, "unknown nflverse dataset")
+}
@@ -0,0 +1,198 @@
+package nflverse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Player details compared by a metadata refresh
+const (
+	FieldTeam     = "team"
+	FieldStatus   = "status"
+	FieldPosition = "position"
+)
+
+// Refresh statuses, as stored in data_sync_logs
+const (
+	RefreshCompleted = "COMPLETED"
+	RefreshFailed    = "FAILED"
+)
+
+// MetadataChange is a change to one of a player's details found by a
+// metadata refresh
+type MetadataChange struct {
+	PlayerID   string    `json:"player_id"`
+	ESPNID     string    `json:"espn_id,omitempty"`
+	PlayerName string    `json:"player_name"`
+	Season     int       `json:"season"`
+	Field      string    `json:"field"`
+	OldValue   string    `json:"old_value"`
+	NewValue   string    `json:"new_value"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// MetadataReport summarizes one metadata refresh for the data quality
+// dashboard
+type MetadataReport struct {
+	ID             string `json:"id,omitempty"`
+	Source         string `json:"source"`
+	Season         int    `json:"season"`
+	Status         string `json:"status"`
+	PlayersChecked int    `json:"players_checked"`
+	PlayersAdded   int    `json:"players_added"`
+	PlayersChanged int    `json:"players_changed"`
+	// CachesInvalidated counts the cache entries dropped because of the
+	// changes
+	CachesInvalidated int              `json:"caches_invalidated"`
+	Changes           []MetadataChange `json:"changes"`
+	Error             string           `json:"error,omitempty"`
+	StartedAt         time.Time        `json:"started_at"`
+	CompletedAt       time.Time        `json:"completed_at"`
+}
+
+// Invalidator drops cached data built from a season's player metadata and
+// returns how many entries it removed
+type Invalidator func(ctx context.Context, season int) (int, error)
+
+// Refresher reloads season rosters from nflverse so player teams, statuses
+// and positions follow trades, cuts and signings between full ingests
+type Refresher struct {
+	repo         Repository
+	client       *http.Client
+	source       string
+	invalidators []Invalidator
+}
+
+// NewRefresher creates a refresher that reads rosters from source, a base
+// URL such as ReleaseURL or a directory of downloaded files
+func NewRefresher(repo Repository, client *http.Client, source string) *Refresher {
+	return &Refresher{
+		repo:   repo,
+		client: client,
+		source: source,
+	}
+}
+
+// WithInvalidator runs fn after a refresh that changed any player
+func (r *Refresher) WithInvalidator(fn Invalidator) *Refresher {
+	r.invalidators = append(r.invalidators, fn)
+	return r
+}
+
+// Refresh reloads a season's rosters, stores them and records what changed.
+// The report is saved even when the refresh fails, so failures show up on
+// the dashboard too.
+func (r *Refresher) Refresh(ctx context.Context, season int) (*MetadataReport, error) {
+	report := &MetadataReport{
+		Source:    "nflverse",
+		Season:    season,
+		Status:    RefreshCompleted,
+		Changes:   []MetadataChange{},
+		StartedAt: time.Now(),
+	}
+
+	err := r.refresh(ctx, report)
+	report.CompletedAt = time.Now()
+	if err != nil {
+		report.Status = RefreshFailed
+		report.Error = err.Error()
+	}
+
+	if saveErr := r.repo.SaveMetadataReport(ctx, report); saveErr != nil {
+		log.Printf("Failed to save player metadata report: %v", saveErr)
+	}
+	log.Printf("metrics player_metadata_refresh season=%d status=%s checked=%d added=%d changed=%d invalidated=%d duration_ms=%d",
+		season, report.Status, report.PlayersChecked, report.PlayersAdded, report.PlayersChanged,
+		report.CachesInvalidated, report.CompletedAt.Sub(report.StartedAt).Milliseconds())
+
+	return report, err
+}
+
+func (r *Refresher) refresh(ctx context.Context, report *MetadataReport) error {
+	f, err := Open(ctx, r.client, r.source, DatasetRosters, report.Season)
+	if err != nil {
+		return err
+	}
+	fresh, err := ReadRosters(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	stored, err := r.repo.GetRosters(ctx, report.Season)
+	if err != nil {
+		return err
+	}
+
+	report.PlayersChecked = len(fresh)
+	report.Changes, report.PlayersAdded = DiffRosters(stored, fresh, time.Now())
+	changed := make(map[string]bool)
+	for _, c := range report.Changes {
+		changed[c.PlayerID] = true
+	}
+	report.PlayersChanged = len(changed)
+
+	if err := r.repo.UpsertRosters(ctx, fresh); err != nil {
+		return err
+	}
+
+	if report.PlayersChanged == 0 && report.PlayersAdded == 0 {
+		return nil
+	}
+	for _, invalidate := range r.invalidators {
+		n, err := invalidate(ctx, report.Season)
+		if err != nil {
+			return fmt.Errorf("failed to invalidate caches: %w", err)
+		}
+		report.CachesInvalidated += n
+	}
+
+	return nil
+}
+
+// DiffRosters compares freshly loaded rosters with the stored ones and
+// returns each team, status and position change, plus how many players are
+// new. Players missing from the fresh rosters are left alone; nflverse keeps
+// released players on the season roster with a new status.
+func DiffRosters(stored, fresh []RosterEntry, now time.Time) ([]MetadataChange, int) {
+	previous := make(map[string]RosterEntry, len(stored))
+	for _, e := range stored {
+		previous[e.PlayerID] = e
+	}
+
+	changes := []MetadataChange{}
+	added := 0
+	for _, e := range fresh {
+		old, ok := previous[e.PlayerID]
+		if !ok {
+			added++
+			continue
+		}
+		for _, f := range []struct {
+			field    string
+			old, new string
+		}{
+			{FieldTeam, old.Team, e.Team},
+			{FieldStatus, old.Status, e.Status},
+			{FieldPosition, old.Position, e.Position},
+		} {
+			if f.old == f.new {
+				continue
+			}
+			changes = append(changes, MetadataChange{
+				PlayerID:   e.PlayerID,
+				ESPNID:     e.ESPNID,
+				PlayerName: e.PlayerName,
+				Season:     e.Season,
+				Field:      f.field,
+				OldValue:   f.old,
+				NewValue:   f.new,
+				DetectedAt: now,
+			})
+		}
+	}
+	return changes, added
+}
@@ -0,0 +1,359 @@
+package nflverse
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Repository stores nflverse data in the silver layer
+type Repository interface {
+	UpsertPlayerStats(ctx context.Context, stats []PlayerWeekStats) error
+	UpsertSnapCounts(ctx context.Context, snaps []SnapCount) error
+	UpsertRosters(ctx context.Context, roster []RosterEntry) error
+	GetRosters(ctx context.Context, season int) ([]RosterEntry, error)
+	// GetRosterEntries looks up players in a season's rosters by nflverse
+	// or ESPN ID
+	GetRosterEntries(ctx context.Context, season int, ids []string) ([]RosterEntry, error)
+	SaveMetadataReport(ctx context.Context, report *MetadataReport) error
+	GetMetadataReports(ctx context.Context, limit int) ([]MetadataReport, error)
+}
+
+// PostgresRepository implements Repository against PostgreSQL
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new nflverse repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// UpsertPlayerStats stores weekly player stats, replacing earlier loads of
+// the same player and week
+func (r *PostgresRepository) UpsertPlayerStats(ctx context.Context, stats []PlayerWeekStats) error {
+	query := `
+		INSERT INTO silver.player_weekly_stats (
+			player_id, player_name, position, team, opponent, season, week, season_type,
+			completions, attempts, passing_yards, passing_tds, interceptions,
+			carries, rushing_yards, rushing_tds,
+			targets, receptions, receiving_yards, receiving_tds,
+			fantasy_points, fantasy_points_ppr, loaded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, NOW())
+		ON CONFLICT (player_id, season, week, season_type) DO UPDATE SET
+			player_name = EXCLUDED.player_name,
+			position = EXCLUDED.position,
+			team = EXCLUDED.team,
+			opponent = EXCLUDED.opponent,
+			completions = EXCLUDED.completions,
+			attempts = EXCLUDED.attempts,
+			passing_yards = EXCLUDED.passing_yards,
+			passing_tds = EXCLUDED.passing_tds,
+			interceptions = EXCLUDED.interceptions,
+			carries = EXCLUDED.carries,
+			rushing_yards = EXCLUDED.rushing_yards,
+			rushing_tds = EXCLUDED.rushing_tds,
+			targets = EXCLUDED.targets,
+			receptions = EXCLUDED.receptions,
+			receiving_yards = EXCLUDED.receiving_yards,
+			receiving_tds = EXCLUDED.receiving_tds,
+			fantasy_points = EXCLUDED.fantasy_points,
+			fantasy_points_ppr = EXCLUDED.fantasy_points_ppr,
+			loaded_at = NOW()
+	`
+
+	return r.upsert(ctx, "player stats", query, len(stats), func(stmt *sql.Stmt, i int) error {
+		s := stats[i]
+		_, err := stmt.ExecContext(ctx,
+			s.PlayerID, s.PlayerName, s.Position, s.Team, s.Opponent, s.Season, s.Week, s.SeasonType,
+			s.Completions, s.Attempts, s.PassingYards, s.PassingTDs, s.Interceptions,
+			s.Carries, s.RushingYards, s.RushingTDs,
+			s.Targets, s.Receptions, s.ReceivingYards, s.ReceivingTDs,
+			s.FantasyPoints, s.FantasyPointsPPR,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert stats for %s week %d: %w", s.PlayerName, s.Week, err)
+		}
+		return nil
+	})
+}
+
+// UpsertSnapCounts stores per-game snap counts
+func (r *PostgresRepository) UpsertSnapCounts(ctx context.Context, snaps []SnapCount) error {
+	query := `
+		INSERT INTO silver.player_snap_counts (
+			player_id, player_name, position, team, opponent, season, week, game_type,
+			offense_snaps, offense_pct, defense_snaps, defense_pct,
+			special_teams_snaps, special_teams_pct, loaded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW())
+		ON CONFLICT (player_id, season, week, game_type) DO UPDATE SET
+			player_name = EXCLUDED.player_name,
+			position = EXCLUDED.position,
+			team = EXCLUDED.team,
+			opponent = EXCLUDED.opponent,
+			offense_snaps = EXCLUDED.offense_snaps,
+			offense_pct = EXCLUDED.offense_pct,
+			defense_snaps = EXCLUDED.defense_snaps,
+			defense_pct = EXCLUDED.defense_pct,
+			special_teams_snaps = EXCLUDED.special_teams_snaps,
+			special_teams_pct = EXCLUDED.special_teams_pct,
+			loaded_at = NOW()
+	`
+
+	return r.upsert(ctx, "snap counts", query, len(snaps), func(stmt *sql.Stmt, i int) error {
+		s := snaps[i]
+		_, err := stmt.ExecContext(ctx,
+			s.PlayerID, s.PlayerName, s.Position, s.Team, s.Opponent, s.Season, s.Week, s.GameType,
+			s.OffenseSnaps, s.OffensePct, s.DefenseSnaps, s.DefensePct,
+			s.SpecialTeamsSnaps, s.SpecialTeamsPct,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert snaps for %s week %d: %w", s.PlayerName, s.Week, err)
+		}
+		return nil
+	})
+}
+
+// UpsertRosters stores season rosters
+func (r *PostgresRepository) UpsertRosters(ctx context.Context, roster []RosterEntry) error {
+	query := `
+		INSERT INTO silver.nfl_rosters (
+			player_id, espn_id, player_name, position, team, season,
+			jersey_number, status, years_exp, loaded_at
+		) VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, NULLIF($7, 0), $8, $9, NOW())
+		ON CONFLICT (player_id, season) DO UPDATE SET
+			espn_id = EXCLUDED.espn_id,
+			player_name = EXCLUDED.player_name,
+			position = EXCLUDED.position,
+			team = EXCLUDED.team,
+			jersey_number = EXCLUDED.jersey_number,
+			status = EXCLUDED.status,
+			years_exp = EXCLUDED.years_exp,
+			loaded_at = NOW()
+	`
+
+	return r.upsert(ctx, "rosters", query, len(roster), func(stmt *sql.Stmt, i int) error {
+		e := roster[i]
+		_, err := stmt.ExecContext(ctx,
+			e.PlayerID, e.ESPNID, e.PlayerName, e.Position, e.Team, e.Season,
+			e.JerseyNumber, e.Status, e.YearsExp,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert roster entry for %s: %w", e.PlayerName, err)
+		}
+		return nil
+	})
+}
+
+// GetRosters retrieves a season's stored rosters
+func (r *PostgresRepository) GetRosters(ctx context.Context, season int) ([]RosterEntry, error) {
+	query := `
+		SELECT player_id, COALESCE(espn_id, ''), player_name, COALESCE(position, ''), COALESCE(team, ''),
+			season, COALESCE(jersey_number, 0), COALESCE(status, ''), COALESCE(years_exp, 0)
+		FROM silver.nfl_rosters
+		WHERE season = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rosters: %w", err)
+	}
+	defer rows.Close()
+
+	var roster []RosterEntry
+	for rows.Next() {
+		var e RosterEntry
+		if err := rows.Scan(
+			&e.PlayerID, &e.ESPNID, &e.PlayerName, &e.Position, &e.Team,
+			&e.Season, &e.JerseyNumber, &e.Status, &e.YearsExp,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan roster entry: %w", err)
+		}
+		roster = append(roster, e)
+	}
+
+	return roster, rows.Err()
+}
+
+// GetRosterEntries returns the roster entries whose nflverse or ESPN ID is
+// one of ids
+func (r *PostgresRepository) GetRosterEntries(ctx context.Context, season int, ids []string) ([]RosterEntry, error) {
+	query := `
+		SELECT player_id, COALESCE(espn_id, ''), player_name, COALESCE(position, ''), COALESCE(team, ''),
+			season, COALESCE(jersey_number, 0), COALESCE(status, ''), COALESCE(years_exp, 0)
+		FROM silver.nfl_rosters
+		WHERE season = $1 AND (player_id = ANY($2) OR espn_id = ANY($2))
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query roster entries: %w", err)
+	}
+	defer rows.Close()
+
+	var roster []RosterEntry
+	for rows.Next() {
+		var e RosterEntry
+		if err := rows.Scan(
+			&e.PlayerID, &e.ESPNID, &e.PlayerName, &e.Position, &e.Team,
+			&e.Season, &e.JerseyNumber, &e.Status, &e.YearsExp,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan roster entry: %w", err)
+		}
+		roster = append(roster, e)
+	}
+
+	return roster, rows.Err()
+}
+
+// SaveMetadataReport logs a metadata refresh in data_sync_logs with the
+// changes it found, and sets the report's ID
+func (r *PostgresRepository) SaveMetadataReport(ctx context.Context, report *MetadataReport) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var errorDetails []byte
+	if report.Error != "" {
+		if errorDetails, err = json.Marshal(map[string]string{"error": report.Error}); err != nil {
+			return fmt.Errorf("failed to marshal error details: %w", err)
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO data_sync_logs (
+			sync_type, source, target, season, records_processed, records_created, records_updated,
+			status, error_details, started_at, completed_at
+		) VALUES ('player_metadata', $1, 'silver.nfl_rosters', $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, report.Source, report.Season, report.PlayersChecked, report.PlayersAdded, report.PlayersChanged,
+		report.Status, errorDetails, report.StartedAt, report.CompletedAt,
+	).Scan(&report.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save metadata report: %w", err)
+	}
+
+	query := `
+		INSERT INTO player_metadata_changes (
+			sync_log_id, player_id, espn_id, player_name, season, field, old_value, new_value, detected_at
+		) VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9)
+	`
+	for _, c := range report.Changes {
+		if _, err := tx.ExecContext(ctx, query,
+			report.ID, c.PlayerID, c.ESPNID, c.PlayerName, c.Season, c.Field, c.OldValue, c.NewValue, c.DetectedAt,
+		); err != nil {
+			return fmt.Errorf("failed to save metadata change for %s: %w", c.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit metadata report: %w", err)
+	}
+
+	return nil
+}
+
+// GetMetadataReports retrieves the most recent metadata refreshes with their
+// changes, newest first
+func (r *PostgresRepository) GetMetadataReports(ctx context.Context, limit int) ([]MetadataReport, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, source, COALESCE(season, 0), status, records_processed, records_created, records_updated,
+			COALESCE(error_details->>'error', ''), started_at, completed_at
+		FROM data_sync_logs
+		WHERE sync_type = 'player_metadata'
+		ORDER BY started_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query metadata reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []MetadataReport
+	index := make(map[string]int)
+	var ids []string
+	for rows.Next() {
+		var report MetadataReport
+		var completedAt sql.NullTime
+		if err := rows.Scan(
+			&report.ID, &report.Source, &report.Season, &report.Status, &report.PlayersChecked, &report.PlayersAdded,
+			&report.PlayersChanged, &report.Error, &report.StartedAt, &completedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan metadata report: %w", err)
+		}
+		report.CompletedAt = completedAt.Time
+		report.Changes = []MetadataChange{}
+		index[report.ID] = len(reports)
+		ids = append(ids, report.ID)
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return reports, nil
+	}
+
+	changeRows, err := r.db.QueryContext(ctx, `
+		SELECT sync_log_id, player_id, COALESCE(espn_id, ''), player_name, season, field,
+			COALESCE(old_value, ''), COALESCE(new_value, ''), detected_at
+		FROM player_metadata_changes
+		WHERE sync_log_id = ANY($1::uuid[])
+		ORDER BY id
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query metadata changes: %w", err)
+	}
+	defer changeRows.Close()
+
+	for changeRows.Next() {
+		var syncLogID string
+		var c MetadataChange
+		if err := changeRows.Scan(
+			&syncLogID, &c.PlayerID, &c.ESPNID, &c.PlayerName, &c.Season, &c.Field,
+			&c.OldValue, &c.NewValue, &c.DetectedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan metadata change: %w", err)
+		}
+		report := &reports[index[syncLogID]]
+		report.Changes = append(report.Changes, c)
+	}
+
+	return reports, changeRows.Err()
+}
+
+// upsert runs a prepared statement for each of n rows in one transaction
+func (r *PostgresRepository) upsert(ctx context.Context, what, query string, n int, exec func(stmt *sql.Stmt, i int) error) error {
+	if n == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare %s upsert: %w", what, err)
+	}
+	defer stmt.Close()
+
+	for i := 0; i < n; i++ {
+		if err := exec(stmt, i); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit %s: %w", what, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+// Package parquet writes and reads flat tables as Apache Parquet files, so
+// archived data can be queried by DuckDB, Spark or pandas as well as loaded
+// back into Postgres. It covers what archiving needs and no more: a single
+// level of nullable columns, PLAIN encoding, and gzip compression.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Type is a column's type, as stored and as returned by Read
+type Type int
+
+const (
+	// Boolean values are bool
+	Boolean Type = iota
+	// Int32 values are int32
+	Int32
+	// Int64 values are int64
+	Int64
+	// Double values are float64
+	Double
+	// String values are UTF-8 strings
+	String
+	// JSON values are strings holding a JSON document
+	JSON
+	// Timestamp values are time.Time, stored as UTC microseconds
+	Timestamp
+)
+
+// Column names and types a column. Every column is nullable; a nil value is
+// null.
+type Column struct {
+	Name string
+	Type Type
+}
+
+// magic starts and ends every Parquet file
+const magic = "PAR1"
+
+// Physical types, encodings, codecs and converted types from the Parquet
+// format
+const (
+	physicalBoolean   = 0
+	physicalInt32     = 1
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecUncompressed = 0
+	codecGzip         = 2
+
+	convertedUTF8            = 0
+	convertedTimestampMicros = 10
+	convertedJSON            = 19
+
+	repetitionOptional = 1
+	pageTypeData       = 0
+)
+
+// createdBy names the writer in file metadata
+const createdBy = "nfl-analytics parquet"
+
+var (
+	// ErrInvalidFile is returned for data that is not a Parquet file this
+	// package can read
+	ErrInvalidFile = errors.New("invalid parquet file")
+	// ErrUnsupported is returned for valid Parquet files using features
+	// this package does not implement
+	ErrUnsupported = errors.New("unsupported parquet feature")
+)
+
+func (t Type) physical() int32 {
+	switch t {
+	case Boolean:
+		return physicalBoolean
+	case Int32:
+		return physicalInt32
+	case Int64, Timestamp:
+		return physicalInt64
+	case Double:
+		return physicalDouble
+	}
+	return physicalByteArray
+}
+
+// converted is the annotation telling readers how to interpret the physical
+// type, or -1 for none
+func (t Type) converted() int32 {
+	switch t {
+	case String:
+		return convertedUTF8
+	case JSON:
+		return convertedJSON
+	case Timestamp:
+		return convertedTimestampMicros
+	}
+	return -1
+}
+
+// Write writes rows as a Parquet file of one row group. Each row holds one
+// value per column, in column order.
+func Write(w io.Writer, columns []Column, rows [][]interface{}) error {
+	if len(columns) == 0 {
+		return errors.New("parquet file needs at least one column")
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return fmt.Errorf("row %d has %d values for %d columns", i, len(row), len(columns))
+		}
+	}
+
+	var file bytes.Buffer
+	file.WriteString(magic)
+
+	chunks := make([]columnChunk, len(columns))
+	var totalSize int64
+	for i, col := range columns {
+		page, uncompressed, err := encodePage(col, i, rows)
+		if err != nil {
+			return err
+		}
+
+		header := newThriftWriter()
+		header.i32(1, pageTypeData)
+		header.i32(2, int32(uncompressed))
+		header.i32(3, int32(len(page)))
+		header.beginStruct(5)
+		header.i32(1, int32(len(rows)))
+		header.i32(2, encodingPlain)
+		header.i32(3, encodingRLE)
+		header.i32(4, encodingRLE)
+		header.endStruct()
+		header.endStruct()
+
+		chunks[i] = columnChunk{
+			offset:           int64(file.Len()),
+			compressedSize:   int64(len(header.buf) + len(page)),
+			uncompressedSize: int64(len(header.buf) + uncompressed),
+		}
+		totalSize += chunks[i].uncompressedSize
+		file.Write(header.buf)
+		file.Write(page)
+	}
+
+	meta := fileMetadata(columns, chunks, int64(len(rows)), totalSize)
+	file.Write(meta)
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(meta)))
+	file.Write(length[:])
+	file.WriteString(magic)
+
+	_, err := w.Write(file.Bytes())
+	return err
+}
+
+type columnChunk struct {
+	offset           int64
+	compressedSize   int64
+	uncompressedSize int64
+}
+
+// encodePage encodes a column's definition levels and values as one
+// gzip-compressed data page, returning it and its uncompressed size
+func encodePage(col Column, index int, rows [][]interface{}) ([]byte, int, error) {
+	levels := make([]bool, len(rows))
+	var values bytes.Buffer
+	var bits []bool
+
+	for i, row := range rows {
+		v := row[index]
+		if v == nil {
+			continue
+		}
+		levels[i] = true
+
+		switch col.Type {
+		case Boolean:
+			b, ok := v.(bool)
+			if !ok {
+				return nil, 0, typeError(col, v)
+			}
+			bits = append(bits, b)
+		case Int32:
+			n, ok := toInt64(v)
+			if !ok || n < math.MinInt32 || n > math.MaxInt32 {
+				return nil, 0, typeError(col, v)
+			}
+			binary.Write(&values, binary.LittleEndian, int32(n))
+		case Int64:
+			n, ok := toInt64(v)
+			if !ok {
+				return nil, 0, typeError(col, v)
+			}
+			binary.Write(&values, binary.LittleEndian, n)
+		case Double:
+			f, ok := v.(float64)
+			if !ok {
+				return nil, 0, typeError(col, v)
+			}
+			binary.Write(&values, binary.LittleEndian, math.Float64bits(f))
+		case String, JSON:
+			s, ok := v.(string)
+			if !ok {
+				return nil, 0, typeError(col, v)
+			}
+			binary.Write(&values, binary.LittleEndian, uint32(len(s)))
+			values.WriteString(s)
+		case Timestamp:
+			t, ok := v.(time.Time)
+			if !ok {
+				return nil, 0, typeError(col, v)
+			}
+			binary.Write(&values, binary.LittleEndian, t.UnixMicro())
+		default:
+			return nil, 0, fmt.Errorf("column %s has unknown type %d", col.Name, col.Type)
+		}
+	}
+	if col.Type == Boolean {
+		values.Write(packBits(bits))
+	}
+
+	encodedLevels := encodeLevels(levels)
+	var page bytes.Buffer
+	binary.Write(&page, binary.LittleEndian, uint32(len(encodedLevels)))
+	page.Write(encodedLevels)
+	page.Write(values.Bytes())
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(page.Bytes()); err != nil {
+		return nil, 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, 0, err
+	}
+	return compressed.Bytes(), page.Len(), nil
+}
+
+func typeError(col Column, v interface{}) error {
+	return fmt.Errorf("column %s cannot hold %T value %v", col.Name, v, v)
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	}
+	return 0, false
+}
+
+// encodeLevels encodes definition levels, present or null, with the RLE
+// hybrid encoding as runs of equal levels
+func encodeLevels(levels []bool) []byte {
+	var buf []byte
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		buf = binary.AppendUvarint(buf, uint64(j-i)<<1)
+		if levels[i] {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+		i = j
+	}
+	return buf
+}
+
+// packBits packs booleans one bit each, least significant bit first
+func packBits(bits []bool) []byte {
+	buf := make([]byte, (len(bits)+7)/8)
+	for i, b := range bits {
+		if b {
+			buf[i/8] |= 1 << (i % 8)
+		}
+	}
+	return buf
+}
+
+// fileMetadata encodes the footer describing the schema and the row group
+func fileMetadata(columns []Column, chunks []columnChunk, numRows, totalSize int64) []byte {
+	w := newThriftWriter()
+	w.i32(1, 1)
+
+	w.listHeader(2, thriftStruct, len(columns)+1)
+	w.beginElement()
+	w.binary(4, "schema")
+	w.i32(5, int32(len(columns)))
+	w.endStruct()
+	for _, col := range columns {
+		w.beginElement()
+		w.i32(1, col.Type.physical())
+		w.i32(3, repetitionOptional)
+		w.binary(4, col.Name)
+		if c := col.Type.converted(); c >= 0 {
+			w.i32(6, c)
+		}
+		w.endStruct()
+	}
+
+	w.i64(3, numRows)
+
+	w.listHeader(4, thriftStruct, 1)
+	w.beginElement()
+	w.listHeader(1, thriftStruct, len(columns))
+	for i, col := range columns {
+		chunk := chunks[i]
+		w.beginElement()
+		w.i64(2, chunk.offset)
+		w.beginStruct(3)
+		w.i32(1, col.Type.physical())
+		w.listI32(2, []int32{encodingPlain, encodingRLE})
+		w.listBinary(3, []string{col.Name})
+		w.i32(4, codecGzip)
+		w.i64(5, numRows)
+		w.i64(6, chunk.uncompressedSize)
+		w.i64(7, chunk.compressedSize)
+		w.i64(9, chunk.offset)
+		w.endStruct()
+		w.endStruct()
+	}
+	w.i64(2, totalSize)
+	w.i64(3, numRows)
+	w.endStruct()
+
+	w.binary(6, createdBy)
+	w.endStruct()
+	return w.buf
+}
@@ -0,0 +1,90 @@
+package parquet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	columns := []Column{
+		{Name: "league_id", Type: String},
+		{Name: "week", Type: Int32},
+		{Name: "row_id", Type: Int64},
+		{Name: "starter", Type: Boolean},
+		{Name: "points", Type: Double},
+		{Name: "stats", Type: JSON},
+		{Name: "synced_at", Type: Timestamp},
+	}
+	synced := time.Date(2024, 12, 30, 18, 4, 5, 123456000, time.UTC)
+
+	var rows [][]interface{}
+	for i := 0; i < 40; i++ {
+		row := []interface{}{"league-1", int32(i % 18), int64(i) << 33, i%3 == 0, float64(i) + 0.25, `{"53":1}`, synced.Add(time.Duration(i) * time.Hour)}
+		if i%7 == 0 {
+			// Nulls in every column, in runs and alone
+			row = []interface{}{nil, nil, nil, nil, nil, nil, nil}
+		}
+		if i == 12 || i == 13 {
+			row[4] = nil
+		}
+		rows = append(rows, row)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, columns, rows))
+	assert.Equal(t, magic, buf.String()[:4])
+
+	gotColumns, gotRows, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, columns, gotColumns)
+	assert.Equal(t, rows, gotRows)
+}
+
+func TestWrite_EmptyAndInvalid(t *testing.T) {
+	columns := []Column{{Name: "name", Type: String}}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, columns, nil))
+	_, rows, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	assert.Error(t, Write(&buf, columns, [][]interface{}{{42}}))
+	assert.Error(t, Write(&buf, columns, [][]interface{}{{"a", "b"}}))
+	assert.Error(t, Write(&buf, []Column{{Name: "n", Type: Int32}}, [][]interface{}{{int64(1) << 40}}))
+}
+
+func TestRead_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, []Column{{Name: "week", Type: Int32}}, [][]interface{}{{int32(1)}, {int32(2)}}))
+	data := buf.Bytes()
+
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("PAR1PAR1"),
+		data[:len(data)-1],
+		append([]byte("XXXX"), data[4:]...),
+	} {
+		_, _, err := Read(bad)
+		assert.ErrorIs(t, err, ErrInvalidFile)
+	}
+
+	// Truncated footers and pages fail rather than panic
+	for i := 0; i < len(data)-8; i++ {
+		corrupt := append([]byte(nil), data...)
+		corrupt[i] ^= 0xff
+		assert.NotPanics(t, func() { Read(corrupt) })
+	}
+}
+
+func TestDecodeLevels_BitPacked(t *testing.T) {
+	// One bit-packed group of eight: present, null, present, ... then an
+	// RLE run of three nulls
+	levels, err := decodeLevels([]byte{0x03, 0x55, 0x06, 0x00}, 11)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true, false, true, false, true, false, false, false, false}, levels)
+}
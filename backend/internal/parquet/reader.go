@@ -0,0 +1,326 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// maxPageSize bounds a decompressed page, so a corrupt or hostile file
+// cannot exhaust memory
+const maxPageSize = 256 << 20
+
+// Read reads a flat Parquet file of the types Write produces, returning its
+// columns and rows. Required columns, several row groups and several pages
+// per column are read too; nested schemas, dictionary encoding and codecs
+// other than gzip are not.
+func Read(data []byte) ([]Column, [][]interface{}, error) {
+	if len(data) < 12 || string(data[:4]) != magic || string(data[len(data)-4:]) != magic {
+		return nil, nil, ErrInvalidFile
+	}
+	metaLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	if metaLen <= 0 || metaLen > len(data)-12 {
+		return nil, nil, ErrInvalidFile
+	}
+	metaReader := &thriftReader{buf: data[len(data)-8-metaLen : len(data)-8]}
+	meta, err := metaReader.readStruct(0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	schema := meta.list(2)
+	if len(schema) < 2 {
+		return nil, nil, ErrInvalidFile
+	}
+	columns := make([]Column, 0, len(schema)-1)
+	optional := make([]bool, 0, len(schema)-1)
+	for _, e := range schema[1:] {
+		element, _ := e.(thriftStructValue)
+		if element == nil || element.has(5) {
+			return nil, nil, fmt.Errorf("%w: nested columns", ErrUnsupported)
+		}
+		typ, err := columnType(element)
+		if err != nil {
+			return nil, nil, err
+		}
+		columns = append(columns, Column{Name: element.string(4), Type: typ})
+		optional = append(optional, element.int(3) == repetitionOptional)
+	}
+
+	// Rows are bounded by the file's size, so a corrupt row count cannot
+	// allocate without end
+	numRows := meta.int(3)
+	if numRows < 0 || numRows > int64(len(data))*8 {
+		return nil, nil, ErrInvalidFile
+	}
+	rows := make([][]interface{}, 0, numRows)
+
+	for _, g := range meta.list(4) {
+		group, _ := g.(thriftStructValue)
+		groupRows := int(group.int(3))
+		chunks := group.list(1)
+		if group == nil || len(chunks) != len(columns) || groupRows < 0 || len(rows)+groupRows > int(numRows) {
+			return nil, nil, ErrInvalidFile
+		}
+
+		start := len(rows)
+		for i := 0; i < groupRows; i++ {
+			rows = append(rows, make([]interface{}, len(columns)))
+		}
+		for i, c := range chunks {
+			chunk, _ := c.(thriftStructValue)
+			colMeta := chunk.structField(3)
+			if colMeta == nil {
+				return nil, nil, ErrInvalidFile
+			}
+			values, err := readChunk(data, colMeta, columns[i], optional[i], groupRows)
+			if err != nil {
+				return nil, nil, fmt.Errorf("column %s: %w", columns[i].Name, err)
+			}
+			for r, v := range values {
+				rows[start+r][i] = v
+			}
+		}
+	}
+	if len(rows) != int(numRows) {
+		return nil, nil, ErrInvalidFile
+	}
+	return columns, rows, nil
+}
+
+// columnType maps a schema element to the column type Write would have
+// used for it
+func columnType(element thriftStructValue) (Type, error) {
+	converted := int64(-1)
+	if element.has(6) {
+		converted = element.int(6)
+	}
+	switch element.int(1) {
+	case physicalBoolean:
+		return Boolean, nil
+	case physicalInt32:
+		return Int32, nil
+	case physicalInt64:
+		if converted == convertedTimestampMicros {
+			return Timestamp, nil
+		}
+		return Int64, nil
+	case physicalDouble:
+		return Double, nil
+	case physicalByteArray:
+		if converted == convertedJSON {
+			return JSON, nil
+		}
+		return String, nil
+	}
+	return 0, fmt.Errorf("%w: physical type %d", ErrUnsupported, element.int(1))
+}
+
+// readChunk reads a column chunk's pages until it has numValues values
+func readChunk(data []byte, meta thriftStructValue, col Column, optional bool, numValues int) ([]interface{}, error) {
+	codec := meta.int(4)
+	if codec != codecUncompressed && codec != codecGzip {
+		return nil, fmt.Errorf("%w: codec %d", ErrUnsupported, codec)
+	}
+	offset := meta.int(9)
+	if offset < 4 || offset >= int64(len(data)) {
+		return nil, ErrInvalidFile
+	}
+
+	values := make([]interface{}, 0, numValues)
+	pos := int(offset)
+	for len(values) < numValues {
+		if pos >= len(data) {
+			return nil, ErrInvalidFile
+		}
+		r := &thriftReader{buf: data[pos:]}
+		header, err := r.readStruct(0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		pos += r.pos
+
+		size := int(header.int(3))
+		if size < 0 || size > len(data)-pos {
+			return nil, ErrInvalidFile
+		}
+		body := data[pos : pos+size]
+		pos += size
+
+		if header.int(1) != pageTypeData {
+			return nil, fmt.Errorf("%w: page type %d", ErrUnsupported, header.int(1))
+		}
+		dataHeader := header.structField(5)
+		if dataHeader == nil || dataHeader.int(2) != encodingPlain {
+			return nil, fmt.Errorf("%w: encoding", ErrUnsupported)
+		}
+		pageValues := int(dataHeader.int(1))
+		if pageValues < 0 || pageValues > numValues-len(values) {
+			return nil, ErrInvalidFile
+		}
+
+		if codec == codecGzip {
+			if body, err = gunzip(body); err != nil {
+				return nil, err
+			}
+		}
+		decoded, err := decodePage(body, col, optional, pageValues)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, decoded...)
+	}
+	return values, nil
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	defer zr.Close()
+	out, err := io.ReadAll(io.LimitReader(zr, maxPageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	if len(out) > maxPageSize {
+		return nil, ErrInvalidFile
+	}
+	return out, nil
+}
+
+// decodePage decodes a data page's definition levels and PLAIN values
+func decodePage(page []byte, col Column, optional bool, n int) ([]interface{}, error) {
+	present := make([]bool, n)
+	count := n
+	if optional {
+		if len(page) < 4 {
+			return nil, ErrInvalidFile
+		}
+		levelsLen := int(binary.LittleEndian.Uint32(page))
+		if levelsLen < 0 || levelsLen > len(page)-4 {
+			return nil, ErrInvalidFile
+		}
+		levels, err := decodeLevels(page[4:4+levelsLen], n)
+		if err != nil {
+			return nil, err
+		}
+		page = page[4+levelsLen:]
+		count = 0
+		for i, l := range levels {
+			present[i] = l
+			if l {
+				count++
+			}
+		}
+	} else {
+		for i := range present {
+			present[i] = true
+		}
+	}
+
+	values := make([]interface{}, 0, count)
+	pos := 0
+	need := func(size int) error {
+		if size < 0 || pos+size > len(page) {
+			return ErrInvalidFile
+		}
+		return nil
+	}
+	for i := 0; i < count; i++ {
+		switch col.Type {
+		case Boolean:
+			if i/8 >= len(page) {
+				return nil, ErrInvalidFile
+			}
+			values = append(values, page[i/8]&(1<<(i%8)) != 0)
+		case Int32:
+			if err := need(4); err != nil {
+				return nil, err
+			}
+			values = append(values, int32(binary.LittleEndian.Uint32(page[pos:])))
+			pos += 4
+		case Int64, Timestamp:
+			if err := need(8); err != nil {
+				return nil, err
+			}
+			v := int64(binary.LittleEndian.Uint64(page[pos:]))
+			pos += 8
+			if col.Type == Timestamp {
+				values = append(values, time.UnixMicro(v).UTC())
+			} else {
+				values = append(values, v)
+			}
+		case Double:
+			if err := need(8); err != nil {
+				return nil, err
+			}
+			values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(page[pos:])))
+			pos += 8
+		case String, JSON:
+			if err := need(4); err != nil {
+				return nil, err
+			}
+			size := int(binary.LittleEndian.Uint32(page[pos:]))
+			pos += 4
+			if err := need(size); err != nil {
+				return nil, err
+			}
+			values = append(values, string(page[pos:pos+size]))
+			pos += size
+		}
+	}
+
+	out := make([]interface{}, n)
+	next := 0
+	for i, p := range present {
+		if p {
+			out[i] = values[next]
+			next++
+		}
+	}
+	return out, nil
+}
+
+// decodeLevels decodes n definition levels of bit width 1 in the RLE
+// hybrid encoding, both RLE and bit-packed runs
+func decodeLevels(buf []byte, n int) ([]bool, error) {
+	levels := make([]bool, 0, n)
+	pos := 0
+	for len(levels) < n {
+		header, size := binary.Uvarint(buf[pos:])
+		if size <= 0 {
+			return nil, ErrInvalidFile
+		}
+		pos += size
+
+		if header&1 == 0 {
+			run := int(header >> 1)
+			if pos >= len(buf) || run > n-len(levels) {
+				return nil, ErrInvalidFile
+			}
+			value := buf[pos] != 0
+			pos++
+			for i := 0; i < run; i++ {
+				levels = append(levels, value)
+			}
+			continue
+		}
+
+		// Bit-packed runs are groups of eight values, one byte per group at
+		// bit width 1; padding past n is ignored
+		groups := int(header >> 1)
+		if groups > len(buf)-pos {
+			return nil, ErrInvalidFile
+		}
+		for i := 0; i < groups*8 && len(levels) < n; i++ {
+			levels = append(levels, buf[pos+i/8]&(1<<(i%8)) != 0)
+		}
+		pos += groups
+	}
+	return levels, nil
+}
@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nfl-analytics/backend/internal/auth"
+)
+
+// SubjectFromContext returns who is making a request: an operator if it
+// carried the admin key, otherwise the signed-in user
+func SubjectFromContext(c *gin.Context) Subject {
+	if auth.IsOperator(c) {
+		return Operator()
+	}
+	if userID, ok := auth.GetUserID(c); ok {
+		return User(userID.String())
+	}
+	return Subject{}
+}
+
+// RequireAdmin authorizes operator routes after auth.OperatorMiddleware.
+// GET and HEAD requests read; every other method writes.
+func RequireAdmin(authz *Authorizer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		action := ActionWrite
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			action = ActionRead
+		}
+
+		err := authz.Authorize(c.Request.Context(), SubjectFromContext(c), action, Admin())
+		if errors.Is(err, ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			c.Abort()
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to authorize operator request: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authorize request"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,328 @@
+// Package policy decides whether a subject may take an action on a resource.
+// Services ask an Authorizer instead of comparing owner IDs, so who may read
+// or change a league, a draft or the operator routes is set in one place and
+// tested without handlers.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrForbidden is returned when a subject may not take an action
+var ErrForbidden = errors.New("forbidden")
+
+// Action is what a subject wants to do with a resource
+type Action string
+
+// Actions
+const (
+	// ActionRead views a resource
+	ActionRead Action = "read"
+	// ActionWrite changes a resource's data, such as syncing a league or
+	// recording a draft pick
+	ActionWrite Action = "write"
+	// ActionManage changes who has a resource or whether it exists, such as
+	// disconnecting a league or sharing a draft
+	ActionManage Action = "manage"
+)
+
+// Resource kinds
+const (
+	KindLeague = "league"
+	KindDraft  = "draft"
+	// KindAdmin is the operator routes
+	KindAdmin = "admin"
+)
+
+// LeagueRole is a user's role in a league someone else connected, ordered
+// so a higher role has every right of a lower one
+type LeagueRole int
+
+// League roles
+const (
+	LeagueRoleNone LeagueRole = iota
+	// LeagueRoleMember sees the league and its drafts
+	LeagueRoleMember
+	// LeagueRoleCommissioner also syncs and edits the league
+	LeagueRoleCommissioner
+)
+
+// OrgRole is a user's role across the whole service, ordered like LeagueRole
+type OrgRole int
+
+// Org roles
+const (
+	OrgRoleNone OrgRole = iota
+	// OrgRoleSupport sees users' leagues and drafts and the operator reports
+	OrgRoleSupport
+	// OrgRoleAdmin also changes them and runs operator jobs
+	OrgRoleAdmin
+)
+
+// ParseLeagueRole reads a league role as stored
+func ParseLeagueRole(s string) (LeagueRole, error) {
+	switch s {
+	case "member":
+		return LeagueRoleMember, nil
+	case "commissioner":
+		return LeagueRoleCommissioner, nil
+	}
+	return LeagueRoleNone, fmt.Errorf("unknown league role %q", s)
+}
+
+// String returns the role as stored
+func (r LeagueRole) String() string {
+	switch r {
+	case LeagueRoleMember:
+		return "member"
+	case LeagueRoleCommissioner:
+		return "commissioner"
+	}
+	return "none"
+}
+
+// ParseOrgRole reads an org role as stored
+func ParseOrgRole(s string) (OrgRole, error) {
+	switch s {
+	case "support":
+		return OrgRoleSupport, nil
+	case "admin":
+		return OrgRoleAdmin, nil
+	}
+	return OrgRoleNone, fmt.Errorf("unknown org role %q", s)
+}
+
+// String returns the role as stored
+func (r OrgRole) String() string {
+	switch r {
+	case OrgRoleSupport:
+		return "support"
+	case OrgRoleAdmin:
+		return "admin"
+	}
+	return "none"
+}
+
+// Subject is who is acting: a signed-in user, or an operator holding the
+// admin API key
+type Subject struct {
+	UserID   string
+	Operator bool
+}
+
+// User is the subject for a signed-in user
+func User(userID string) Subject {
+	return Subject{UserID: userID}
+}
+
+// Operator is the subject for a request made with the admin API key
+func Operator() Subject {
+	return Subject{Operator: true}
+}
+
+// Resource is what is acted on
+type Resource struct {
+	Kind    string
+	ID      string
+	OwnerID string
+	// LeagueID is the league the resource belongs to, whose roles apply to
+	// it; a league is its own
+	LeagueID string
+}
+
+// Request is one authorization question, as rules see it. Roles are looked
+// up on first use, so rules that never need them cost no queries.
+type Request struct {
+	Subject  Subject
+	Action   Action
+	Resource Resource
+
+	roles      Repository
+	leagueRole *LeagueRole
+	orgRole    *OrgRole
+}
+
+// LeagueRole returns the subject's role in the resource's league
+func (r *Request) LeagueRole(ctx context.Context) (LeagueRole, error) {
+	if r.leagueRole == nil {
+		role := LeagueRoleNone
+		if r.roles != nil && r.Subject.UserID != "" && r.Resource.LeagueID != "" {
+			var err error
+			if role, err = r.roles.GetLeagueRole(ctx, r.Subject.UserID, r.Resource.LeagueID); err != nil {
+				return LeagueRoleNone, err
+			}
+		}
+		r.leagueRole = &role
+	}
+	return *r.leagueRole, nil
+}
+
+// OrgRole returns the subject's org role
+func (r *Request) OrgRole(ctx context.Context) (OrgRole, error) {
+	if r.orgRole == nil {
+		role := OrgRoleNone
+		if r.roles != nil && r.Subject.UserID != "" {
+			var err error
+			if role, err = r.roles.GetOrgRole(ctx, r.Subject.UserID); err != nil {
+				return OrgRoleNone, err
+			}
+		}
+		r.orgRole = &role
+	}
+	return *r.orgRole, nil
+}
+
+// Rule allows or refuses a request. An error is a failed role lookup, not a
+// refusal.
+type Rule func(ctx context.Context, req *Request) (bool, error)
+
+// Owner allows the user who owns the resource
+func Owner() Rule {
+	return func(ctx context.Context, req *Request) (bool, error) {
+		return req.Subject.UserID != "" && req.Subject.UserID == req.Resource.OwnerID, nil
+	}
+}
+
+// IsOperator allows requests made with the admin API key
+func IsOperator() Rule {
+	return func(ctx context.Context, req *Request) (bool, error) {
+		return req.Subject.Operator, nil
+	}
+}
+
+// HasLeagueRole allows users with at least min in the resource's league
+func HasLeagueRole(min LeagueRole) Rule {
+	return func(ctx context.Context, req *Request) (bool, error) {
+		role, err := req.LeagueRole(ctx)
+		return role >= min, err
+	}
+}
+
+// HasOrgRole allows users with at least min across the service
+func HasOrgRole(min OrgRole) Rule {
+	return func(ctx context.Context, req *Request) (bool, error) {
+		role, err := req.OrgRole(ctx)
+		return role >= min, err
+	}
+}
+
+// AnyOf allows a request any of rules allows, checking them in order
+func AnyOf(rules ...Rule) Rule {
+	return func(ctx context.Context, req *Request) (bool, error) {
+		for _, rule := range rules {
+			allowed, err := rule(ctx, req)
+			if err != nil {
+				return false, err
+			}
+			if allowed {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// AllOf allows a request every one of rules allows
+func AllOf(rules ...Rule) Rule {
+	return func(ctx context.Context, req *Request) (bool, error) {
+		for _, rule := range rules {
+			allowed, err := rule(ctx, req)
+			if err != nil || !allowed {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+}
+
+// Deny refuses every request
+func Deny() Rule {
+	return func(ctx context.Context, req *Request) (bool, error) {
+		return false, nil
+	}
+}
+
+// DefaultRules is who may do what out of the box:
+//   - leagues: members read, commissioners write, only the owner manages;
+//     support staff read and admins do anything
+//   - drafts: the league's members and support staff read, only the owner
+//     changes them
+//   - operator routes: the admin key or support staff read, the admin key or
+//     admins write and manage
+func DefaultRules() map[string]map[Action]Rule {
+	return map[string]map[Action]Rule{
+		KindLeague: {
+			ActionRead:   AnyOf(Owner(), HasLeagueRole(LeagueRoleMember), HasOrgRole(OrgRoleSupport)),
+			ActionWrite:  AnyOf(Owner(), HasLeagueRole(LeagueRoleCommissioner), HasOrgRole(OrgRoleAdmin)),
+			ActionManage: AnyOf(Owner(), HasOrgRole(OrgRoleAdmin)),
+		},
+		KindDraft: {
+			ActionRead:   AnyOf(Owner(), HasLeagueRole(LeagueRoleMember), HasOrgRole(OrgRoleSupport)),
+			ActionWrite:  Owner(),
+			ActionManage: Owner(),
+		},
+		KindAdmin: {
+			ActionRead:   AnyOf(IsOperator(), HasOrgRole(OrgRoleSupport)),
+			ActionWrite:  AnyOf(IsOperator(), HasOrgRole(OrgRoleAdmin)),
+			ActionManage: AnyOf(IsOperator(), HasOrgRole(OrgRoleAdmin)),
+		},
+	}
+}
+
+// Authorizer answers authorization requests with a rule per resource kind
+// and action. Pairs without a rule are refused. A nil Authorizer applies
+// DefaultRules with no roles, which leaves resources to their owners.
+type Authorizer struct {
+	rules map[string]map[Action]Rule
+	roles Repository
+}
+
+// defaultAuthorizer is what a nil Authorizer uses
+var defaultAuthorizer = NewAuthorizer(nil)
+
+// NewAuthorizer creates an authorizer applying DefaultRules, reading roles
+// from roles, which may be nil to grant none
+func NewAuthorizer(roles Repository) *Authorizer {
+	return &Authorizer{
+		rules: DefaultRules(),
+		roles: roles,
+	}
+}
+
+// WithRule replaces the rule for an action on a kind of resource
+func (a *Authorizer) WithRule(kind string, action Action, rule Rule) *Authorizer {
+	if a.rules[kind] == nil {
+		a.rules[kind] = make(map[Action]Rule)
+	}
+	a.rules[kind][action] = rule
+	return a
+}
+
+// Authorize returns nil if subject may take action on resource, an error
+// wrapping ErrForbidden if not, or the error looking up the subject's roles
+func (a *Authorizer) Authorize(ctx context.Context, subject Subject, action Action, resource Resource) error {
+	if a == nil {
+		a = defaultAuthorizer
+	}
+
+	rule := a.rules[resource.Kind][action]
+	if rule == nil {
+		return fmt.Errorf("%w: no rule to %s %s", ErrForbidden, action, resource.Kind)
+	}
+
+	allowed, err := rule(ctx, &Request{
+		Subject:  subject,
+		Action:   action,
+		Resource: resource,
+		roles:    a.roles,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to authorize %s %s: %w", action, resource.Kind, err)
+	}
+	if !allowed {
+		return fmt.Errorf("%w: may not %s %s %s", ErrForbidden, action, resource.Kind, resource.ID)
+	}
+	return nil
+}
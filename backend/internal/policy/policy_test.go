@@ -0,0 +1,179 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRoles struct {
+	league  map[string]LeagueRole
+	org     map[string]OrgRole
+	err     error
+	lookups int
+}
+
+func (f *fakeRoles) GetLeagueRole(ctx context.Context, userID, leagueID string) (LeagueRole, error) {
+	f.lookups++
+	return f.league[userID+"/"+leagueID], f.err
+}
+
+func (f *fakeRoles) SetLeagueRole(ctx context.Context, userID, leagueID string, role LeagueRole) error {
+	f.league[userID+"/"+leagueID] = role
+	return nil
+}
+
+func (f *fakeRoles) GetOrgRole(ctx context.Context, userID string) (OrgRole, error) {
+	f.lookups++
+	return f.org[userID], f.err
+}
+
+func (f *fakeRoles) SetOrgRole(ctx context.Context, userID string, role OrgRole) error {
+	f.org[userID] = role
+	return nil
+}
+
+func TestAuthorize_League(t *testing.T) {
+	league := &models.League{ID: uuid.New(), UserID: uuid.New()}
+	resource := League(league)
+	owner := league.UserID.String()
+	member, commissioner, support, admin, stranger := "member", "commissioner", "support", "admin", "stranger"
+
+	roles := &fakeRoles{
+		league: map[string]LeagueRole{
+			member + "/" + resource.LeagueID:       LeagueRoleMember,
+			commissioner + "/" + resource.LeagueID: LeagueRoleCommissioner,
+		},
+		org: map[string]OrgRole{support: OrgRoleSupport, admin: OrgRoleAdmin},
+	}
+	authz := NewAuthorizer(roles)
+
+	tests := []struct {
+		user    string
+		action  Action
+		allowed bool
+	}{
+		{owner, ActionRead, true},
+		{owner, ActionWrite, true},
+		{owner, ActionManage, true},
+		{member, ActionRead, true},
+		{member, ActionWrite, false},
+		{commissioner, ActionWrite, true},
+		{commissioner, ActionManage, false},
+		{support, ActionRead, true},
+		{support, ActionWrite, false},
+		{admin, ActionManage, true},
+		{stranger, ActionRead, false},
+		{"", ActionRead, false},
+	}
+	for _, tt := range tests {
+		err := authz.Authorize(context.Background(), User(tt.user), tt.action, resource)
+		if tt.allowed {
+			assert.NoError(t, err, "%s %s", tt.user, tt.action)
+		} else {
+			assert.ErrorIs(t, err, ErrForbidden, "%s %s", tt.user, tt.action)
+		}
+	}
+}
+
+func TestAuthorize_Draft(t *testing.T) {
+	leagueID := uuid.New().String()
+	session := &models.DraftSession{ID: "session-1", UserID: "owner", LeagueID: leagueID}
+	roles := &fakeRoles{
+		league: map[string]LeagueRole{"commissioner/" + leagueID: LeagueRoleCommissioner},
+		org:    map[string]OrgRole{"admin": OrgRoleAdmin},
+	}
+	authz := NewAuthorizer(roles)
+	ctx := context.Background()
+
+	assert.NoError(t, authz.Authorize(ctx, User("owner"), ActionWrite, Draft(session)))
+	assert.NoError(t, authz.Authorize(ctx, User("commissioner"), ActionRead, Draft(session)))
+	// Only the owner makes picks, whatever their role
+	assert.ErrorIs(t, authz.Authorize(ctx, User("commissioner"), ActionWrite, Draft(session)), ErrForbidden)
+	assert.ErrorIs(t, authz.Authorize(ctx, User("admin"), ActionManage, Draft(session)), ErrForbidden)
+
+	// Sessions outside a league have no league roles to look up
+	roles.lookups = 0
+	mock := &models.DraftSession{ID: "session-2", UserID: "owner"}
+	assert.ErrorIs(t, authz.Authorize(ctx, User("commissioner"), ActionRead, Draft(mock)), ErrForbidden)
+	assert.Equal(t, 1, roles.lookups, "only the org role is looked up")
+}
+
+func TestAuthorize_Admin(t *testing.T) {
+	authz := NewAuthorizer(&fakeRoles{org: map[string]OrgRole{"support": OrgRoleSupport, "admin": OrgRoleAdmin}})
+	ctx := context.Background()
+
+	assert.NoError(t, authz.Authorize(ctx, Operator(), ActionWrite, Admin()))
+	assert.NoError(t, authz.Authorize(ctx, User("support"), ActionRead, Admin()))
+	assert.ErrorIs(t, authz.Authorize(ctx, User("support"), ActionWrite, Admin()), ErrForbidden)
+	assert.NoError(t, authz.Authorize(ctx, User("admin"), ActionWrite, Admin()))
+	assert.ErrorIs(t, authz.Authorize(ctx, User("someone"), ActionRead, Admin()), ErrForbidden)
+	// The admin key is no one's login, so it does not reach users' leagues
+	assert.ErrorIs(t, authz.Authorize(ctx, Operator(), ActionRead, Resource{Kind: KindLeague, OwnerID: "owner"}), ErrForbidden)
+}
+
+func TestAuthorize_NilAuthorizerLeavesResourcesToOwners(t *testing.T) {
+	var authz *Authorizer
+	resource := Resource{Kind: KindLeague, ID: "l1", OwnerID: "owner", LeagueID: "l1"}
+
+	assert.NoError(t, authz.Authorize(context.Background(), User("owner"), ActionManage, resource))
+	assert.ErrorIs(t, authz.Authorize(context.Background(), User("other"), ActionRead, resource), ErrForbidden)
+}
+
+func TestAuthorize_OwnersNeedNoLookups(t *testing.T) {
+	roles := &fakeRoles{err: errors.New("database down")}
+	authz := NewAuthorizer(roles)
+	resource := Resource{Kind: KindLeague, ID: "l1", OwnerID: "owner", LeagueID: "l1"}
+
+	assert.NoError(t, authz.Authorize(context.Background(), User("owner"), ActionRead, resource))
+	assert.Zero(t, roles.lookups)
+
+	// A failed lookup is an error, not a refusal
+	err := authz.Authorize(context.Background(), User("other"), ActionRead, resource)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrForbidden)
+}
+
+func TestAuthorize_Rules(t *testing.T) {
+	authz := NewAuthorizer(nil).
+		WithRule(KindLeague, ActionRead, AllOf(Owner(), Deny())).
+		WithRule("report", ActionRead, AnyOf(Deny(), IsOperator()))
+	ctx := context.Background()
+
+	assert.ErrorIs(t, authz.Authorize(ctx, User("owner"), ActionRead, Resource{Kind: KindLeague, OwnerID: "owner"}), ErrForbidden)
+	assert.NoError(t, authz.Authorize(ctx, Operator(), ActionRead, Resource{Kind: "report"}))
+	// Actions without a rule are refused
+	assert.ErrorIs(t, authz.Authorize(ctx, Operator(), ActionWrite, Resource{Kind: "report"}), ErrForbidden)
+}
+
+func TestRequireAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	supportID := uuid.New()
+	authz := NewAuthorizer(&fakeRoles{org: map[string]OrgRole{supportID.String(): OrgRoleSupport}})
+
+	serve := func(method string, setup func(c *gin.Context)) int {
+		r := gin.New()
+		r.Use(func(c *gin.Context) { setup(c); c.Next() }, RequireAdmin(authz))
+		r.Handle(method, "/admin", func(c *gin.Context) { c.Status(http.StatusOK) })
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, "/admin", nil))
+		return w.Code
+	}
+	operator := func(c *gin.Context) { c.Set(auth.OperatorKey, true) }
+	support := func(c *gin.Context) { c.Set(auth.UserIDKey, supportID) }
+	user := func(c *gin.Context) { c.Set(auth.UserIDKey, uuid.New()) }
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodPut, operator))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, support))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPut, support))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, user))
+}
@@ -0,0 +1,107 @@
+package policy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Repository stores users' league and org roles
+type Repository interface {
+	GetLeagueRole(ctx context.Context, userID, leagueID string) (LeagueRole, error)
+	SetLeagueRole(ctx context.Context, userID, leagueID string, role LeagueRole) error
+	GetOrgRole(ctx context.Context, userID string) (OrgRole, error)
+	SetOrgRole(ctx context.Context, userID string, role OrgRole) error
+}
+
+// PostgresRepository implements Repository for PostgreSQL
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL role repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// GetLeagueRole returns a user's role in a league, LeagueRoleNone if they
+// have none
+func (r *PostgresRepository) GetLeagueRole(ctx context.Context, userID, leagueID string) (LeagueRole, error) {
+	var role string
+	err := r.db.QueryRowContext(ctx,
+		`SELECT role FROM league_user_roles WHERE user_id = $1 AND league_id = $2`,
+		userID, leagueID,
+	).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return LeagueRoleNone, nil
+	}
+	if err != nil {
+		return LeagueRoleNone, fmt.Errorf("failed to get league role: %w", err)
+	}
+	return ParseLeagueRole(role)
+}
+
+// SetLeagueRole grants a user a role in a league, or removes theirs for
+// LeagueRoleNone
+func (r *PostgresRepository) SetLeagueRole(ctx context.Context, userID, leagueID string, role LeagueRole) error {
+	if role == LeagueRoleNone {
+		if _, err := r.db.ExecContext(ctx,
+			`DELETE FROM league_user_roles WHERE user_id = $1 AND league_id = $2`,
+			userID, leagueID,
+		); err != nil {
+			return fmt.Errorf("failed to remove league role: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO league_user_roles (league_id, user_id, role, granted_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (league_id, user_id) DO UPDATE SET
+			role = EXCLUDED.role,
+			granted_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, leagueID, userID, role.String()); err != nil {
+		return fmt.Errorf("failed to set league role: %w", err)
+	}
+	return nil
+}
+
+// GetOrgRole returns a user's org role, OrgRoleNone if they have none
+func (r *PostgresRepository) GetOrgRole(ctx context.Context, userID string) (OrgRole, error) {
+	var role string
+	err := r.db.QueryRowContext(ctx,
+		`SELECT role FROM user_roles WHERE user_id = $1`,
+		userID,
+	).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return OrgRoleNone, nil
+	}
+	if err != nil {
+		return OrgRoleNone, fmt.Errorf("failed to get org role: %w", err)
+	}
+	return ParseOrgRole(role)
+}
+
+// SetOrgRole grants a user an org role, or removes theirs for OrgRoleNone
+func (r *PostgresRepository) SetOrgRole(ctx context.Context, userID string, role OrgRole) error {
+	if role == OrgRoleNone {
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to remove org role: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO user_roles (user_id, role, granted_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			role = EXCLUDED.role,
+			granted_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, userID, role.String()); err != nil {
+		return fmt.Errorf("failed to set org role: %w", err)
+	}
+	return nil
+}
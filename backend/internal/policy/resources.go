@@ -0,0 +1,31 @@
+package policy
+
+import (
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// League is a connected league as a resource
+func League(league *models.League) Resource {
+	return Resource{
+		Kind:     KindLeague,
+		ID:       league.ID.String(),
+		OwnerID:  league.UserID.String(),
+		LeagueID: league.ID.String(),
+	}
+}
+
+// Draft is a draft session as a resource, belonging to its league if it
+// has one
+func Draft(session *models.DraftSession) Resource {
+	return Resource{
+		Kind:     KindDraft,
+		ID:       session.ID,
+		OwnerID:  session.UserID,
+		LeagueID: session.LeagueID,
+	}
+}
+
+// Admin is the operator routes as a resource
+func Admin() Resource {
+	return Resource{Kind: KindAdmin}
+}
@@ -0,0 +1,308 @@
+package projections
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Availability statuses. These are reasons a healthy player misses games,
+// tracked apart from injury designations.
+const (
+	AvailabilitySuspended = "SUSPENDED"
+	AvailabilityHoldout   = "HOLDOUT"
+	AvailabilityPUP       = "PUP"
+)
+
+// AvailabilityStatuses lists every availability status
+var AvailabilityStatuses = []string{AvailabilitySuspended, AvailabilityHoldout, AvailabilityPUP}
+
+// PUPWeeks is how many games a player placed on the reserve/PUP list must
+// sit before they can be activated
+const PUPWeeks = 4
+
+// RegularSeasonGames is how many games each team plays in a regular season
+const RegularSeasonGames = maxRegularSeasonWeek - 1
+
+// Availability is a suspension, holdout or PUP stint. Weeks is how many
+// games the player misses from StartWeek on, 0 while the length is unknown
+// as with holdouts and indefinite suspensions.
+type Availability struct {
+	Season     int       `json:"season"`
+	PlayerID   string    `json:"player_id,omitempty"` // ESPN player ID when known
+	PlayerName string    `json:"player_name"`
+	Position   string    `json:"position,omitempty"`
+	Team       string    `json:"team,omitempty"`
+	Status     string    `json:"status"`
+	StartWeek  int       `json:"start_week"`
+	Weeks      int       `json:"weeks,omitempty"`
+	Notes      string    `json:"notes,omitempty"`
+	Source     string    `json:"source"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	// WeeksRemaining is how many more games the player misses from the week
+	// asked about, filled in by callers that know the week
+	WeeksRemaining int `json:"weeks_remaining,omitempty"`
+}
+
+// Known reports whether the number of games missed is known
+func (a Availability) Known() bool {
+	return a.Weeks > 0
+}
+
+// Misses reports whether the player sits out week. Stints of unknown length
+// miss no particular week.
+func (a Availability) Misses(week int) bool {
+	return a.Known() && week >= a.StartWeek && week < a.StartWeek+a.Weeks
+}
+
+// MissedWeeks counts the weeks from fromWeek on that the player sits out.
+// Bye weeks inside the stint do not count, since the player would not have
+// played anyway.
+func (a Availability) MissedWeeks(fromWeek, byeWeek int) int {
+	if !a.Known() {
+		return 0
+	}
+	missed := 0
+	for week := max(fromWeek, a.StartWeek); week < a.StartWeek+a.Weeks && week <= maxRegularSeasonWeek; week++ {
+		if week != byeWeek {
+			missed++
+		}
+	}
+	return missed
+}
+
+// Describe is a short human readable account of the stint
+func (a Availability) Describe() string {
+	switch {
+	case a.Status == AvailabilityHoldout && !a.Known():
+		return "Holding out"
+	case a.Status == AvailabilityPUP && a.Known():
+		return fmt.Sprintf("On PUP through week %d", a.StartWeek+a.Weeks-1)
+	case a.Status == AvailabilityPUP:
+		return "On PUP"
+	case !a.Known():
+		return "Suspended indefinitely"
+	case a.Status == AvailabilityHoldout:
+		return fmt.Sprintf("Expected to miss %d games holding out", a.Weeks)
+	default:
+		return fmt.Sprintf("Suspended %d games", a.Weeks)
+	}
+}
+
+// AvailabilityRepository stores suspensions, holdouts and PUP stints
+type AvailabilityRepository interface {
+	UpsertAvailability(ctx context.Context, a *Availability) error
+	DeleteAvailability(ctx context.Context, season int, playerName, status string) error
+	GetAvailability(ctx context.Context, season int) ([]Availability, error)
+}
+
+// NewPostgresAvailabilityRepository creates an availability repository
+// backed by the silver player availability table
+func NewPostgresAvailabilityRepository(db *sql.DB) AvailabilityRepository {
+	return &PostgresRepository{db: db}
+}
+
+// AvailabilityIndex looks up players' availability by ESPN ID, falling back
+// to name for sources that do not carry IDs
+type AvailabilityIndex struct {
+	byID   map[string]Availability
+	byName map[string]Availability
+}
+
+// NewAvailabilityIndex indexes a season's availability. A player with more
+// than one stint is indexed by the one that ends latest.
+func NewAvailabilityIndex(list []Availability) *AvailabilityIndex {
+	idx := &AvailabilityIndex{
+		byID:   make(map[string]Availability, len(list)),
+		byName: make(map[string]Availability, len(list)),
+	}
+	for _, a := range list {
+		name := strings.ToLower(a.PlayerName)
+		if existing, ok := idx.byName[name]; ok && !endsLater(a, existing) {
+			continue
+		}
+		idx.byName[name] = a
+		if a.PlayerID != "" {
+			idx.byID[a.PlayerID] = a
+		}
+	}
+	return idx
+}
+
+// Lookup finds a player's availability
+func (idx *AvailabilityIndex) Lookup(playerID, playerName string) (Availability, bool) {
+	if idx == nil {
+		return Availability{}, false
+	}
+	if a, ok := idx.byID[playerID]; ok && playerID != "" {
+		return a, true
+	}
+	a, ok := idx.byName[strings.ToLower(playerName)]
+	return a, ok
+}
+
+// endsLater reports whether a keeps the player out longer than b, counting
+// stints of unknown length as the longest
+func endsLater(a, b Availability) bool {
+	if !a.Known() || !b.Known() {
+		return !a.Known() && b.Known()
+	}
+	return a.StartWeek+a.Weeks > b.StartWeek+b.Weeks
+}
+
+// RestOfSeason is a player's projected points from a week to the end of the
+// regular season
+type RestOfSeason struct {
+	PlayerName      string  `json:"player_name"`
+	Position        string  `json:"position"`
+	Team            string  `json:"team"`
+	Season          int     `json:"season"`
+	FromWeek        int     `json:"from_week"`
+	ByeWeek         int     `json:"bye_week,omitempty"`
+	PerGamePPR      float64 `json:"per_game_ppr"`
+	PerGameStandard float64 `json:"per_game_standard"`
+	// Games is how many games the player is projected to play, after byes
+	// and the games GamesMissed lost to suspensions and PUP
+	Games          int           `json:"games"`
+	GamesMissed    int           `json:"games_missed,omitempty"`
+	PointsPPR      float64       `json:"points_ppr"`
+	PointsStandard float64       `json:"points_standard"`
+	Availability   *Availability `json:"availability,omitempty"`
+}
+
+// ProjectRestOfSeason projects each player's points from fromWeek through
+// the last regular season week, taking a weekly consensus projection as the
+// per-game rate. Bye weeks and weeks a player is suspended or on PUP score
+// zero. Results are ordered by PPR points, highest first.
+func ProjectRestOfSeason(weekly []ConsensusProjection, byes map[string]int, availability *AvailabilityIndex, season, fromWeek int) []RestOfSeason {
+	results := make([]RestOfSeason, 0, len(weekly))
+	for _, p := range weekly {
+		bye := byes[p.Team]
+		games := 0
+		for week := fromWeek; week <= maxRegularSeasonWeek; week++ {
+			if week != bye {
+				games++
+			}
+		}
+
+		ros := RestOfSeason{
+			PlayerName:      p.PlayerName,
+			Position:        p.Position,
+			Team:            p.Team,
+			Season:          season,
+			FromWeek:        fromWeek,
+			ByeWeek:         bye,
+			PerGamePPR:      p.PointsPPR,
+			PerGameStandard: p.PointsStandard,
+		}
+		if a, ok := availability.Lookup("", p.PlayerName); ok {
+			ros.Availability = &a
+			ros.Availability.WeeksRemaining = a.MissedWeeks(fromWeek, bye)
+			ros.GamesMissed = ros.Availability.WeeksRemaining
+		}
+
+		ros.Games = max(games-ros.GamesMissed, 0)
+		ros.PointsPPR = round2(p.PointsPPR * float64(ros.Games))
+		ros.PointsStandard = round2(p.PointsStandard * float64(ros.Games))
+		results = append(results, ros)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].PointsPPR > results[j].PointsPPR
+	})
+	return results
+}
+
+// RestOfSeasonProjections loads the week's consensus projections, byes and
+// availability and projects the rest of the season from week
+func RestOfSeasonProjections(ctx context.Context, repo Repository, schedule ScheduleRepository, availability AvailabilityRepository, season, week int) ([]RestOfSeason, error) {
+	weekly, err := repo.GetConsensusProjections(ctx, season, week)
+	if err != nil {
+		return nil, err
+	}
+	byes, err := schedule.GetByeWeeks(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	stints, err := availability.GetAvailability(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	return ProjectRestOfSeason(weekly, byes, NewAvailabilityIndex(stints), season, week), nil
+}
+
+// UpsertAvailability stores a stint, replacing the player's stint of the
+// same status for the season
+func (r *PostgresRepository) UpsertAvailability(ctx context.Context, a *Availability) error {
+	query := `
+		INSERT INTO silver.player_availability (
+			season, player_id, player_name, position, team, status, start_week, weeks, notes, source, updated_at
+		) VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, NULLIF($8, 0), NULLIF($9, ''), $10, CURRENT_TIMESTAMP)
+		ON CONFLICT (season, player_name, status) DO UPDATE SET
+			player_id = EXCLUDED.player_id,
+			position = EXCLUDED.position,
+			team = EXCLUDED.team,
+			start_week = EXCLUDED.start_week,
+			weeks = EXCLUDED.weeks,
+			notes = EXCLUDED.notes,
+			source = EXCLUDED.source,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		a.Season, a.PlayerID, a.PlayerName, a.Position, a.Team, a.Status, a.StartWeek, a.Weeks, a.Notes, a.Source,
+	).Scan(&a.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert availability for %s: %w", a.PlayerName, err)
+	}
+	return nil
+}
+
+// DeleteAvailability removes a player's stint, as when a suspension is
+// overturned or a holdout reports
+func (r *PostgresRepository) DeleteAvailability(ctx context.Context, season int, playerName, status string) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM silver.player_availability WHERE season = $1 AND LOWER(player_name) = LOWER($2) AND status = $3`,
+		season, playerName, status,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete availability for %s: %w", playerName, err)
+	}
+	return nil
+}
+
+// GetAvailability retrieves a season's stints, earliest starting first
+func (r *PostgresRepository) GetAvailability(ctx context.Context, season int) ([]Availability, error) {
+	query := `
+		SELECT season, COALESCE(player_id, ''), player_name, COALESCE(position, ''), COALESCE(team, ''),
+			status, start_week, COALESCE(weeks, 0), COALESCE(notes, ''), source, updated_at
+		FROM silver.player_availability
+		WHERE season = $1
+		ORDER BY start_week, player_name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query availability: %w", err)
+	}
+	defer rows.Close()
+
+	var list []Availability
+	for rows.Next() {
+		var a Availability
+		if err := rows.Scan(
+			&a.Season, &a.PlayerID, &a.PlayerName, &a.Position, &a.Team,
+			&a.Status, &a.StartWeek, &a.Weeks, &a.Notes, &a.Source, &a.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan availability: %w", err)
+		}
+		list = append(list, a)
+	}
+
+	return list, rows.Err()
+}
@@ -0,0 +1,74 @@
+package projections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRestOfSeason(t *testing.T) {
+	weekly := []ConsensusProjection{
+		{PlayerName: "Healthy Back", Position: "RB", Team: "KC", PointsPPR: 15, PointsStandard: 12},
+		{PlayerName: "Suspended Receiver", Position: "WR", Team: "BUF", PointsPPR: 20, PointsStandard: 15},
+		{PlayerName: "Holdout Back", Position: "RB", Team: "SF", PointsPPR: 18, PointsStandard: 16},
+	}
+	byes := map[string]int{"KC": 10, "BUF": 6}
+	availability := NewAvailabilityIndex([]Availability{
+		// Weeks 4-8, with the bye in week 6 falling inside the suspension
+		{PlayerName: "Suspended Receiver", Status: AvailabilitySuspended, StartWeek: 4, Weeks: 5},
+		{PlayerName: "Holdout Back", Status: AvailabilityHoldout, StartWeek: 1},
+	})
+
+	results := ProjectRestOfSeason(weekly, byes, availability, 2025, 5)
+	require.Len(t, results, 3)
+	byName := make(map[string]RestOfSeason, len(results))
+	for _, r := range results {
+		byName[r.PlayerName] = r
+	}
+
+	// Weeks 5-18 less the bye
+	healthy := byName["Healthy Back"]
+	assert.Equal(t, 13, healthy.Games)
+	assert.Equal(t, 195.0, healthy.PointsPPR)
+	assert.Nil(t, healthy.Availability)
+
+	// Weeks 5, 7 and 8 are lost to the suspension; 6 was a bye anyway
+	suspended := byName["Suspended Receiver"]
+	assert.Equal(t, 3, suspended.GamesMissed)
+	assert.Equal(t, 10, suspended.Games)
+	assert.Equal(t, 200.0, suspended.PointsPPR)
+	assert.Equal(t, 150.0, suspended.PointsStandard)
+	require.NotNil(t, suspended.Availability)
+	assert.Equal(t, 3, suspended.Availability.WeeksRemaining)
+
+	// A holdout of unknown length is flagged but keeps every game
+	holdout := byName["Holdout Back"]
+	assert.Equal(t, 0, holdout.GamesMissed)
+	assert.Equal(t, 14, holdout.Games)
+	require.NotNil(t, holdout.Availability)
+	assert.Equal(t, "Holding out", holdout.Availability.Describe())
+
+	assert.Equal(t, "Holdout Back", results[0].PlayerName)
+}
+
+func TestAvailabilityIndex(t *testing.T) {
+	idx := NewAvailabilityIndex([]Availability{
+		{PlayerID: "101", PlayerName: "Two Stints", Status: AvailabilityPUP, StartWeek: 1, Weeks: 4},
+		{PlayerID: "101", PlayerName: "Two Stints", Status: AvailabilitySuspended, StartWeek: 5, Weeks: 2},
+	})
+
+	// The stint that keeps the player out longest wins
+	a, ok := idx.Lookup("101", "")
+	require.True(t, ok)
+	assert.Equal(t, AvailabilitySuspended, a.Status)
+	assert.True(t, a.Misses(6))
+	assert.False(t, a.Misses(7))
+
+	a, ok = idx.Lookup("", "two stints")
+	require.True(t, ok)
+	assert.Equal(t, "Suspended 2 games", a.Describe())
+
+	_, ok = idx.Lookup("999", "Nobody")
+	assert.False(t, ok)
+}
@@ -0,0 +1,108 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Backtester measures how much a modifier improves projection accuracy
+// against actual weekly results
+type Backtester struct {
+	repo Repository
+}
+
+// NewBacktester creates a new modifier backtester
+func NewBacktester(repo Repository) *Backtester {
+	return &Backtester{repo: repo}
+}
+
+// Run applies a modifier to each week's unmodified projections and compares
+// PPR error with and without it. Only projections the modifier changed count
+// toward the result, so a rarely triggered modifier isn't diluted by
+// untouched players. The result is persisted.
+func (b *Backtester) Run(ctx context.Context, modifier string, season, fromWeek, toWeek int) (*BacktestResult, error) {
+	if fromWeek < 1 || toWeek < fromWeek {
+		return nil, fmt.Errorf("invalid week range %d-%d", fromWeek, toWeek)
+	}
+
+	var baseErr, adjustedErr float64
+	var count int
+
+	for week := fromWeek; week <= toWeek; week++ {
+		m, err := BuildModifier(ctx, b.repo, modifier, season, week)
+		if err != nil {
+			return nil, err
+		}
+
+		consensus, err := b.baseProjections(ctx, season, week)
+		if err != nil {
+			return nil, err
+		}
+
+		actuals, err := b.repo.GetWeeklyActuals(ctx, season, week)
+		if err != nil {
+			return nil, err
+		}
+
+		base, adjusted, n := scoreModifier(m, consensus, actuals)
+		baseErr += base
+		adjustedErr += adjusted
+		count += n
+	}
+
+	result := &BacktestResult{
+		Modifier:      modifier,
+		Season:        season,
+		WeekStart:     fromWeek,
+		WeekEnd:       toWeek,
+		AdjustedCount: count,
+		RunAt:         time.Now(),
+	}
+	if count > 0 {
+		result.BaseMAE = math.Round(baseErr/float64(count)*1000) / 1000
+		result.AdjustedMAE = math.Round(adjustedErr/float64(count)*1000) / 1000
+	}
+
+	if err := b.repo.SaveBacktestResult(ctx, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// baseProjections returns a week's projections before any modifier ran. Weeks
+// computed by the pipeline use the scoring stage output, since the stored
+// consensus already includes modifiers.
+func (b *Backtester) baseProjections(ctx context.Context, season, week int) ([]ConsensusProjection, error) {
+	base, err := b.repo.GetStageProjections(ctx, season, week, StageScoring)
+	if err != nil {
+		return nil, err
+	}
+	if len(base) > 0 {
+		return base, nil
+	}
+	return b.repo.GetConsensusProjections(ctx, season, week)
+}
+
+// scoreModifier returns the summed absolute PPR error before and after the
+// modifier for every adjusted projection with a known actual
+func scoreModifier(m Modifier, consensus []ConsensusProjection, actuals map[string]WeeklyActual) (baseErr, adjustedErr float64, count int) {
+	for i := range consensus {
+		actual, ok := actuals[consensus[i].PlayerName]
+		if !ok {
+			continue
+		}
+
+		p := consensus[i].Adjustable()
+		if m.Adjust(&p) == nil {
+			continue
+		}
+
+		baseErr += math.Abs(consensus[i].PointsPPR - actual.PointsPPR)
+		adjustedErr += math.Abs(p.PointsPPR - actual.PointsPPR)
+		count++
+	}
+	return baseErr, adjustedErr, count
+}
@@ -0,0 +1,94 @@
+package projections
+
+import (
+	"sort"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+)
+
+// CustomScoringRules is a scoring rule set to try against the projections,
+// in the same shape as a connected league's rules plus per-position
+// reception bonuses
+type CustomScoringRules struct {
+	espn.ScoringSettings
+	// ReceptionBonus adds points per reception by position on top of
+	// receptionPoints, e.g. {"TE": 0.5} for a tight end premium
+	ReceptionBonus map[string]float64 `json:"receptionBonus,omitempty"`
+}
+
+// Score returns a stat line's points under the rules
+func (r CustomScoringRules) Score(position string, s StatLine) float64 {
+	return r.ScoreStats(position, s.ESPNStats())
+}
+
+// ScoreStats returns points for a raw ESPN stat map under the rules
+func (r CustomScoringRules) ScoreStats(position string, stats map[string]float64) float64 {
+	return round2(ScoreStats(stats, r.ScoringSettings) + stats[espn.StatReceptions]*r.ReceptionBonus[position])
+}
+
+// ProjectedPlayer is a consensus projection to rescore
+type ProjectedPlayer struct {
+	PlayerName     string
+	Position       string
+	Team           string
+	Stats          *StatLine // nil for DST and K
+	PointsPPR      float64
+	PointsStandard float64
+}
+
+// CustomScore is a player's projection under a custom rule set beside the
+// same projection in PPR
+type CustomScore struct {
+	PlayerName   string    `json:"player_name"`
+	Position     string    `json:"position"`
+	Team         string    `json:"team"`
+	Points       float64   `json:"points"`
+	PointsPPR    float64   `json:"points_ppr"`
+	Difference   float64   `json:"difference"` // Points minus PPR points
+	Rank         int       `json:"rank"`
+	PPRRank      int       `json:"ppr_rank"`
+	PositionRank int       `json:"position_rank"`
+	Stats        *StatLine `json:"stats,omitempty"`
+	// StatBased is false for players projected only in points, such as DST
+	// and K, who keep their standard points since the rules cannot be
+	// applied to them
+	StatBased bool `json:"stat_based"`
+}
+
+// RescoreProjections scores each player's projected stat line with the rules
+// and ranks the players by the new points. Only the offensive stats the
+// consensus projects are scored; interception, fumble and kicking rules have
+// no projected stats to apply to.
+func RescoreProjections(players []ProjectedPlayer, rules CustomScoringRules) []CustomScore {
+	scores := make([]CustomScore, len(players))
+	for i, p := range players {
+		score := CustomScore{
+			PlayerName: p.PlayerName,
+			Position:   p.Position,
+			Team:       p.Team,
+			PointsPPR:  p.PointsPPR,
+			Points:     p.PointsStandard,
+			Stats:      p.Stats,
+		}
+		if p.Stats != nil {
+			score.Points = rules.Score(p.Position, *p.Stats)
+			score.StatBased = true
+		}
+		score.Difference = round2(score.Points - score.PointsPPR)
+		scores[i] = score
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].PointsPPR > scores[j].PointsPPR })
+	for i := range scores {
+		scores[i].PPRRank = i + 1
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].Points > scores[j].Points })
+	positionRanks := make(map[string]int)
+	for i := range scores {
+		scores[i].Rank = i + 1
+		positionRanks[scores[i].Position]++
+		scores[i].PositionRank = positionRanks[scores[i].Position]
+	}
+	return scores
+}
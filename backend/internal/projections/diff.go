@@ -0,0 +1,155 @@
+package projections
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Reasons a projection changed between two pipeline runs
+const (
+	ReasonSourceUpdate = "source_update"
+	ReasonInjury       = "injury_designation"
+	ReasonGameContext  = "game_context"
+)
+
+// DefaultDiffThreshold is the smallest PPR change reported as a diff
+const DefaultDiffThreshold = 1.0
+
+// ProjectionSnapshot is a player's final projection from one pipeline run
+type ProjectionSnapshot struct {
+	PlayerName     string             `json:"player_name"`
+	Position       string             `json:"position"`
+	Team           string             `json:"team"`
+	Season         int                `json:"season"`
+	Week           int                `json:"week"`
+	PointsPPR      float64            `json:"points_ppr"`
+	PointsStandard float64            `json:"points_standard"`
+	NumSources     int                `json:"num_sources"`
+	SourcePoints   map[string]float64 `json:"source_points"`
+	Adjustments    []Adjustment       `json:"adjustments"`
+	RecordedAt     time.Time          `json:"recorded_at"`
+}
+
+// ProjectionChange describes how a player's projection moved between runs
+type ProjectionChange struct {
+	PlayerName  string    `json:"player_name"`
+	Position    string    `json:"position"`
+	Team        string    `json:"team"`
+	Season      int       `json:"season"`
+	Week        int       `json:"week"`
+	PreviousPPR float64   `json:"previous_ppr"`
+	CurrentPPR  float64   `json:"current_ppr"`
+	DeltaPPR    float64   `json:"delta_ppr"`
+	Reasons     []string  `json:"reasons"`
+	PreviousAt  time.Time `json:"previous_at"`
+	CurrentAt   time.Time `json:"current_at"`
+}
+
+// DiffProjections compares two sets of snapshots and returns players whose
+// PPR projection moved by at least threshold, largest moves first. Players
+// missing from either side are skipped.
+func DiffProjections(before, after []ProjectionSnapshot, threshold float64) []ProjectionChange {
+	previous := make(map[string]ProjectionSnapshot, len(before))
+	for _, s := range before {
+		previous[s.PlayerName] = s
+	}
+
+	var changes []ProjectionChange
+	for _, cur := range after {
+		prev, ok := previous[cur.PlayerName]
+		if !ok {
+			continue
+		}
+
+		delta := round2(cur.PointsPPR - prev.PointsPPR)
+		if math.Abs(delta) < threshold {
+			continue
+		}
+
+		changes = append(changes, ProjectionChange{
+			PlayerName:  cur.PlayerName,
+			Position:    cur.Position,
+			Team:        cur.Team,
+			Season:      cur.Season,
+			Week:        cur.Week,
+			PreviousPPR: prev.PointsPPR,
+			CurrentPPR:  cur.PointsPPR,
+			DeltaPPR:    delta,
+			Reasons:     changeReasons(prev, cur),
+			PreviousAt:  prev.RecordedAt,
+			CurrentAt:   cur.RecordedAt,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return math.Abs(changes[i].DeltaPPR) > math.Abs(changes[j].DeltaPPR)
+	})
+
+	return changes
+}
+
+// DiffSince compares each player's latest projection for a week with the
+// projection they had at since
+func DiffSince(ctx context.Context, repo Repository, season, week int, since time.Time, threshold float64) ([]ProjectionChange, error) {
+	before, err := repo.GetProjectionSnapshots(ctx, season, week, since)
+	if err != nil {
+		return nil, err
+	}
+	after, err := repo.GetProjectionSnapshots(ctx, season, week, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return DiffProjections(before, after, threshold), nil
+}
+
+// changeReasons attributes a change to the inputs that differ between runs
+func changeReasons(prev, cur ProjectionSnapshot) []string {
+	var reasons []string
+
+	if sourcesChanged(prev.SourcePoints, cur.SourcePoints) {
+		reasons = append(reasons, ReasonSourceUpdate)
+	}
+
+	prevInjury, prevContext := splitAdjustments(prev.Adjustments)
+	curInjury, curContext := splitAdjustments(cur.Adjustments)
+	if prevInjury != curInjury {
+		reasons = append(reasons, ReasonInjury)
+	}
+	if prevContext != curContext {
+		reasons = append(reasons, ReasonGameContext)
+	}
+
+	return reasons
+}
+
+// sourcesChanged reports whether a source was added, dropped or revised
+func sourcesChanged(prev, cur map[string]float64) bool {
+	if len(prev) != len(cur) {
+		return true
+	}
+	for source, points := range cur {
+		old, ok := prev[source]
+		if !ok || math.Abs(old-points) >= 0.01 {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAdjustments summarizes injury and game context adjustments separately
+// so each can be compared between runs
+func splitAdjustments(adjustments []Adjustment) (injury, gameContext string) {
+	var parts []string
+	for _, a := range adjustments {
+		if a.Modifier == ModifierInjury {
+			injury = a.Reason
+			continue
+		}
+		parts = append(parts, a.Modifier+":"+a.Reason)
+	}
+	sort.Strings(parts)
+	return injury, strings.Join(parts, "|")
+}
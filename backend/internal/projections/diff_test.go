@@ -0,0 +1,41 @@
+package projections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffProjections(t *testing.T) {
+	before := []ProjectionSnapshot{
+		{PlayerName: "Josh Allen", PointsPPR: 21, SourcePoints: map[string]float64{"fantasypros": 21}},
+		{PlayerName: "Travis Kelce", PointsPPR: 13, SourcePoints: map[string]float64{"fantasypros": 13}},
+		{PlayerName: "Tyreek Hill", PointsPPR: 15, SourcePoints: map[string]float64{"fantasypros": 15}},
+		{PlayerName: "Derrick Henry", PointsPPR: 16, SourcePoints: map[string]float64{"fantasypros": 16}},
+	}
+	after := []ProjectionSnapshot{
+		// New ESPN source and wind both move Allen
+		{PlayerName: "Josh Allen", PointsPPR: 18.5, SourcePoints: map[string]float64{"fantasypros": 21, "espn": 22},
+			Adjustments: []Adjustment{{Modifier: ModifierWeather, Reason: "22 mph wind"}}},
+		{PlayerName: "Travis Kelce", PointsPPR: 11.05, SourcePoints: map[string]float64{"fantasypros": 13},
+			Adjustments: []Adjustment{{Modifier: ModifierInjury, Reason: "listed as QUESTIONABLE"}}},
+		// Below the threshold
+		{PlayerName: "Tyreek Hill", PointsPPR: 15.5, SourcePoints: map[string]float64{"fantasypros": 15.5}},
+		// No earlier projection to compare against
+		{PlayerName: "Puka Nacua", PointsPPR: 17},
+	}
+
+	changes := DiffProjections(before, after, 1.0)
+	require.Len(t, changes, 2)
+
+	// Largest move first
+	assert.Equal(t, "Josh Allen", changes[0].PlayerName)
+	assert.Equal(t, -2.5, changes[0].DeltaPPR)
+	assert.Equal(t, []string{ReasonSourceUpdate, ReasonGameContext}, changes[0].Reasons)
+
+	assert.Equal(t, "Travis Kelce", changes[1].PlayerName)
+	assert.Equal(t, 13.0, changes[1].PreviousPPR)
+	assert.Equal(t, -1.95, changes[1].DeltaPPR)
+	assert.Equal(t, []string{ReasonInjury}, changes[1].Reasons)
+}
@@ -0,0 +1,262 @@
+package projections
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/integrations/fantasypros"
+)
+
+// Draft rankings sources
+const (
+	RankingsSourceESPN        = "espn"
+	RankingsSourceFantasyPros = "fantasypros"
+)
+
+// RankingsSources lists the sources draft recommendations can take ADP from
+var RankingsSources = []string{RankingsSourceESPN, RankingsSourceFantasyPros}
+
+// DraftRanking is a player's preseason rank and average draft position from
+// one source for one scoring format
+type DraftRanking struct {
+	Source       string    `json:"source"`
+	ScoringType  string    `json:"scoring_type"`
+	Season       int       `json:"season"`
+	PlayerID     string    `json:"player_id,omitempty"` // The source's player ID
+	ESPNID       string    `json:"espn_id,omitempty"`
+	PlayerName   string    `json:"player_name"`
+	Position     string    `json:"position"`
+	Team         string    `json:"team"`
+	Rank         int       `json:"rank,omitempty"`
+	PositionRank int       `json:"position_rank,omitempty"`
+	Tier         int       `json:"tier,omitempty"`
+	ADP          float64   `json:"adp,omitempty"`
+	SnapshotAt   time.Time `json:"snapshot_at"`
+}
+
+// DraftRankingRepository stores draft rankings snapshots. GetADP serves the
+// latest snapshot to the draft recommendation engine.
+type DraftRankingRepository interface {
+	UpsertDraftRankings(ctx context.Context, rankings []DraftRanking) error
+	GetDraftRankings(ctx context.Context, source, scoringType string, season int) ([]DraftRanking, error)
+	GetADP(ctx context.Context, source, scoringType string) (map[string]float64, error)
+}
+
+// NewPostgresDraftRankingRepository creates a draft rankings repository
+// backed by the gold draft rankings table
+func NewPostgresDraftRankingRepository(db *sql.DB) DraftRankingRepository {
+	return &PostgresRepository{db: db}
+}
+
+// PlayerKey identifies a player by name and position, for matching players
+// between sources that do not share IDs
+func PlayerKey(name, position string) string {
+	return strings.ToLower(strings.TrimSpace(name)) + "|" + strings.ToUpper(position)
+}
+
+// ESPNDraftRankings fetches ESPN's ranks and ADP for a scoring format. ESPN
+// has no half point PPR ranks, so HALF_PPR uses its PPR ranks; ADP is the
+// same for every format.
+func ESPNDraftRankings(ctx context.Context, client espn.Client, season int, scoringType string, snapshotAt time.Time) ([]DraftRanking, error) {
+	rankType := espn.RankTypePPR
+	if scoringType == fantasypros.FormatStandard {
+		rankType = espn.RankTypeStandard
+	}
+
+	ranks, err := client.GetDraftRankings(ctx, season, rankType, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	rankings := make([]DraftRanking, 0, len(ranks))
+	for _, r := range ranks {
+		rankings = append(rankings, DraftRanking{
+			Source:      RankingsSourceESPN,
+			ScoringType: scoringType,
+			Season:      season,
+			PlayerID:    r.PlayerID,
+			ESPNID:      r.PlayerID,
+			PlayerName:  r.PlayerName,
+			Position:    r.Position,
+			Team:        r.Team,
+			Rank:        r.Rank,
+			ADP:         r.AverageDraftPosition,
+			SnapshotAt:  snapshotAt,
+		})
+	}
+	return rankings, nil
+}
+
+// FantasyProsDraftRankings fetches FantasyPros' expert consensus ranks and
+// ADP for a scoring format and joins them by player. Players with only one
+// of the two are kept.
+func FantasyProsDraftRankings(ctx context.Context, client fantasypros.Client, season int, scoringType string, snapshotAt time.Time) ([]DraftRanking, error) {
+	ecr, err := client.GetRankings(ctx, scoringType)
+	if err != nil {
+		return nil, err
+	}
+	adp, err := client.GetADP(ctx, scoringType)
+	if err != nil {
+		return nil, err
+	}
+
+	rankings := make([]DraftRanking, 0, len(ecr))
+	index := make(map[string]int, len(ecr))
+	for _, r := range ecr {
+		index[PlayerKey(r.PlayerName, r.Position)] = len(rankings)
+		rankings = append(rankings, DraftRanking{
+			Source:       RankingsSourceFantasyPros,
+			ScoringType:  scoringType,
+			Season:       season,
+			PlayerID:     r.PlayerID,
+			PlayerName:   r.PlayerName,
+			Position:     r.Position,
+			Team:         r.Team,
+			Rank:         r.Rank,
+			PositionRank: r.PositionRank,
+			Tier:         r.Tier,
+			SnapshotAt:   snapshotAt,
+		})
+	}
+	for _, a := range adp {
+		if i, ok := index[PlayerKey(a.PlayerName, a.Position)]; ok {
+			rankings[i].ADP = a.ADP
+			continue
+		}
+		rankings = append(rankings, DraftRanking{
+			Source:       RankingsSourceFantasyPros,
+			ScoringType:  scoringType,
+			Season:       season,
+			PlayerName:   a.PlayerName,
+			Position:     a.Position,
+			Team:         a.Team,
+			PositionRank: a.PositionRank,
+			ADP:          a.ADP,
+			SnapshotAt:   snapshotAt,
+		})
+	}
+	return rankings, nil
+}
+
+// UpsertDraftRankings stores a draft rankings snapshot. Loading the same
+// snapshot again replaces it; later snapshots are kept alongside.
+func (r *PostgresRepository) UpsertDraftRankings(ctx context.Context, rankings []DraftRanking) error {
+	if len(rankings) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO gold.draft_rankings (
+			source, scoring_type, season, player_id, espn_id, player_name, position, team,
+			rank, position_rank, tier, adp, snapshot_at
+		) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, NULLIF($8, ''),
+			NULLIF($9, 0), NULLIF($10, 0), NULLIF($11, 0), NULLIF($12, 0), $13)
+		ON CONFLICT (source, scoring_type, season, player_name, position, snapshot_at) DO UPDATE SET
+			player_id = EXCLUDED.player_id,
+			espn_id = EXCLUDED.espn_id,
+			team = EXCLUDED.team,
+			rank = EXCLUDED.rank,
+			position_rank = EXCLUDED.position_rank,
+			tier = EXCLUDED.tier,
+			adp = EXCLUDED.adp
+	`
+
+	for _, e := range rankings {
+		_, err := tx.ExecContext(ctx, query,
+			e.Source, e.ScoringType, e.Season, e.PlayerID, e.ESPNID, e.PlayerName, e.Position, e.Team,
+			e.Rank, e.PositionRank, e.Tier, e.ADP, e.SnapshotAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert draft ranking for %s: %w", e.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit draft rankings: %w", err)
+	}
+
+	return nil
+}
+
+// GetDraftRankings retrieves a source's latest snapshot for a scoring format
+// and season, best ranked first
+func (r *PostgresRepository) GetDraftRankings(ctx context.Context, source, scoringType string, season int) ([]DraftRanking, error) {
+	query := `
+		SELECT source, scoring_type, season, COALESCE(player_id, ''), COALESCE(espn_id, ''),
+			player_name, position, COALESCE(team, ''), COALESCE(rank, 0), COALESCE(position_rank, 0),
+			COALESCE(tier, 0), COALESCE(adp, 0), snapshot_at
+		FROM gold.draft_rankings
+		WHERE source = $1 AND scoring_type = $2 AND season = $3
+			AND snapshot_at = (
+				SELECT MAX(snapshot_at) FROM gold.draft_rankings
+				WHERE source = $1 AND scoring_type = $2 AND season = $3
+			)
+		ORDER BY rank IS NULL, rank, adp
+	`
+
+	return r.queryDraftRankings(ctx, query, source, scoringType, season)
+}
+
+// GetADP returns each player's ADP from a source's latest snapshot for a
+// scoring format, keyed by ESPN ID where the source has it and by
+// PlayerKey for every player
+func (r *PostgresRepository) GetADP(ctx context.Context, source, scoringType string) (map[string]float64, error) {
+	query := `
+		SELECT source, scoring_type, season, COALESCE(player_id, ''), COALESCE(espn_id, ''),
+			player_name, position, COALESCE(team, ''), COALESCE(rank, 0), COALESCE(position_rank, 0),
+			COALESCE(tier, 0), COALESCE(adp, 0), snapshot_at
+		FROM gold.draft_rankings
+		WHERE source = $1 AND scoring_type = $2 AND adp IS NOT NULL
+			AND snapshot_at = (
+				SELECT MAX(snapshot_at) FROM gold.draft_rankings
+				WHERE source = $1 AND scoring_type = $2
+			)
+	`
+
+	rankings, err := r.queryDraftRankings(ctx, query, source, scoringType)
+	if err != nil {
+		return nil, err
+	}
+
+	adp := make(map[string]float64, len(rankings)*2)
+	for _, e := range rankings {
+		if e.ESPNID != "" {
+			adp[e.ESPNID] = e.ADP
+		}
+		adp[PlayerKey(e.PlayerName, e.Position)] = e.ADP
+	}
+	return adp, nil
+}
+
+func (r *PostgresRepository) queryDraftRankings(ctx context.Context, query string, args ...interface{}) ([]DraftRanking, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query draft rankings: %w", err)
+	}
+	defer rows.Close()
+
+	rankings := []DraftRanking{}
+	for rows.Next() {
+		var e DraftRanking
+		if err := rows.Scan(
+			&e.Source, &e.ScoringType, &e.Season, &e.PlayerID, &e.ESPNID,
+			&e.PlayerName, &e.Position, &e.Team, &e.Rank, &e.PositionRank,
+			&e.Tier, &e.ADP, &e.SnapshotAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan draft ranking: %w", err)
+		}
+		rankings = append(rankings, e)
+	}
+
+	return rankings, rows.Err()
+}
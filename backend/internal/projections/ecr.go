@@ -0,0 +1,447 @@
+package projections
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExpertRanking is one player's expert consensus rank (ECR) in a snapshot of
+// an industry ranking, such as FantasyPros' weekly consensus
+type ExpertRanking struct {
+	PlayerName   string    `json:"player_name"`
+	Position     string    `json:"position"`
+	Team         string    `json:"team"`
+	Season       int       `json:"season"`
+	Week         int       `json:"week"`
+	Source       string    `json:"source"`
+	Rank         int       `json:"rank"`          // Overall rank
+	PositionRank int       `json:"position_rank"` // Rank within the position
+	BestRank     int       `json:"best_rank,omitempty"`
+	WorstRank    int       `json:"worst_rank,omitempty"`
+	AvgRank      float64   `json:"avg_rank,omitempty"`
+	StdDev       float64   `json:"std_dev,omitempty"`
+	SnapshotAt   time.Time `json:"snapshot_at"`
+}
+
+// PlayerWeekHistory sets a player's projection for a week beside the
+// experts' rank and what the player scored
+type PlayerWeekHistory struct {
+	Week int `json:"week"`
+	// Our last consensus projection for the week
+	ConsensusPPR *float64 `json:"consensus_ppr"`
+	// Position rank of that projection among the week's projections
+	ConsensusPositionRank *int `json:"consensus_position_rank"`
+	// The last expert consensus snapshot for the week
+	ECRRank         *int       `json:"ecr_rank"`
+	ECRPositionRank *int       `json:"ecr_position_rank"`
+	ECRSnapshotAt   *time.Time `json:"ecr_snapshot_at,omitempty"`
+	ActualPPR       *float64   `json:"actual_ppr"`
+	// Position rank of the player's actual points
+	ActualPositionRank *int `json:"actual_position_rank"`
+}
+
+// RankedPlayer is a player's position rank by our consensus, by the experts
+// and by actual points in one week
+type RankedPlayer struct {
+	PlayerName    string
+	Position      string
+	ConsensusRank int
+	ECRRank       int
+	ActualRank    int
+}
+
+// RankAccuracy compares how well our consensus and the expert consensus
+// ranked one position in one week. A lower mean absolute rank error and a
+// higher Spearman correlation with actual finish are better.
+type RankAccuracy struct {
+	Week                int     `json:"week"`
+	Position            string  `json:"position"`
+	Players             int     `json:"players"`
+	ConsensusRankError  float64 `json:"consensus_rank_error"`
+	ECRRankError        float64 `json:"ecr_rank_error"`
+	ConsensusSpearman   float64 `json:"consensus_spearman"`
+	ECRSpearman         float64 `json:"ecr_spearman"`
+	ConsensusBeatExpert bool    `json:"consensus_beat_expert"`
+}
+
+// ECRRepository stores expert consensus rank snapshots and reads them back
+// beside our projections
+type ECRRepository interface {
+	UpsertExpertRankings(ctx context.Context, rankings []ExpertRanking) error
+	GetPlayerHistory(ctx context.Context, season int, playerName string) ([]PlayerWeekHistory, error)
+	GetRankedPlayers(ctx context.Context, season, week int, position string, limit int) ([]RankedPlayer, error)
+}
+
+// NewPostgresECRRepository creates an ECR repository backed by the gold
+// expert rankings table
+func NewPostgresECRRepository(db *sql.DB) ECRRepository {
+	return &PostgresRepository{db: db}
+}
+
+// ReadECRCSV reads an expert consensus ranking export such as FantasyPros'.
+// Expected columns: rk, player name, team, pos (e.g. "WR3"), and optionally
+// best, worst, avg. and std.dev. Every row shares one snapshot time.
+func ReadECRCSV(path, source string, season, week int, snapshotAt time.Time) ([]ExpertRanking, error) {
+	rows, err := readCSVFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rankings := make([]ExpertRanking, 0, len(rows))
+	for i, row := range rows {
+		name := row.str("player name")
+		if name == "" {
+			name = row.str("player")
+		}
+		if name == "" {
+			return nil, fmt.Errorf("row %d: missing player name", i+2)
+		}
+
+		rank, err := strconv.Atoi(row.str("rk"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid rk %q", i+2, row.str("rk"))
+		}
+		position, positionRank := splitPositionRank(row.str("pos"))
+		if position == "" {
+			return nil, fmt.Errorf("row %d: missing pos", i+2)
+		}
+
+		r := ExpertRanking{
+			PlayerName:   name,
+			Position:     position,
+			Team:         strings.ToUpper(row.str("team")),
+			Season:       season,
+			Week:         week,
+			Source:       source,
+			Rank:         rank,
+			PositionRank: positionRank,
+			SnapshotAt:   snapshotAt,
+		}
+		var best, worst float64
+		if err := row.floats(map[string]*float64{
+			"best":    &best,
+			"worst":   &worst,
+			"avg.":    &r.AvgRank,
+			"std.dev": &r.StdDev,
+		}); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		r.BestRank, r.WorstRank = int(best), int(worst)
+
+		rankings = append(rankings, r)
+	}
+
+	return rankings, nil
+}
+
+// splitPositionRank splits a position rank such as "WR12" into the position
+// and rank. DST is written "DST" or "DEF".
+func splitPositionRank(pos string) (string, int) {
+	pos = strings.ToUpper(strings.TrimSpace(pos))
+	i := strings.IndexFunc(pos, func(r rune) bool { return r >= '0' && r <= '9' })
+	if i < 0 {
+		return normalizeECRPosition(pos), 0
+	}
+	rank, _ := strconv.Atoi(pos[i:])
+	return normalizeECRPosition(pos[:i]), rank
+}
+
+func normalizeECRPosition(pos string) string {
+	if pos == "DEF" || pos == "D/ST" {
+		return "DST"
+	}
+	return pos
+}
+
+// CompareRankAccuracy scores our consensus and the expert consensus against
+// the actual finish of the same players
+func CompareRankAccuracy(week int, position string, players []RankedPlayer) RankAccuracy {
+	accuracy := RankAccuracy{Week: week, Position: position, Players: len(players)}
+	if len(players) < 2 {
+		return accuracy
+	}
+
+	// Re-rank within the compared players so all three rankings share 1..n
+	consensus := denseRanks(players, func(p RankedPlayer) int { return p.ConsensusRank })
+	ecr := denseRanks(players, func(p RankedPlayer) int { return p.ECRRank })
+	actual := denseRanks(players, func(p RankedPlayer) int { return p.ActualRank })
+
+	var consensusError, ecrError, consensusD2, ecrD2 float64
+	for i := range players {
+		dc := float64(consensus[i] - actual[i])
+		de := float64(ecr[i] - actual[i])
+		consensusError += math.Abs(dc)
+		ecrError += math.Abs(de)
+		consensusD2 += dc * dc
+		ecrD2 += de * de
+	}
+
+	n := float64(len(players))
+	accuracy.ConsensusRankError = round2(consensusError / n)
+	accuracy.ECRRankError = round2(ecrError / n)
+	accuracy.ConsensusSpearman = round4(1 - 6*consensusD2/(n*(n*n-1)))
+	accuracy.ECRSpearman = round4(1 - 6*ecrD2/(n*(n*n-1)))
+	accuracy.ConsensusBeatExpert = accuracy.ConsensusRankError < accuracy.ECRRankError
+	return accuracy
+}
+
+// denseRanks ranks players 1..n by the given rank, breaking ties by order
+func denseRanks(players []RankedPlayer, rank func(RankedPlayer) int) []int {
+	order := make([]int, len(players))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return rank(players[order[a]]) < rank(players[order[b]]) })
+
+	ranks := make([]int, len(players))
+	for r, i := range order {
+		ranks[i] = r + 1
+	}
+	return ranks
+}
+
+// UpsertExpertRankings stores an ECR snapshot. Loading the same snapshot
+// again replaces it; a later snapshot for the week is kept alongside.
+func (r *PostgresRepository) UpsertExpertRankings(ctx context.Context, rankings []ExpertRanking) error {
+	if len(rankings) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO gold.expert_rankings (
+			player_name, position, team, season, week, source,
+			rank, position_rank, best_rank, worst_rank, avg_rank, std_dev, snapshot_at
+		) VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, NULLIF($9, 0), NULLIF($10, 0), NULLIF($11, 0), NULLIF($12, 0), $13)
+		ON CONFLICT (player_name, season, week, source, snapshot_at) DO UPDATE SET
+			position = EXCLUDED.position,
+			team = EXCLUDED.team,
+			rank = EXCLUDED.rank,
+			position_rank = EXCLUDED.position_rank,
+			best_rank = EXCLUDED.best_rank,
+			worst_rank = EXCLUDED.worst_rank,
+			avg_rank = EXCLUDED.avg_rank,
+			std_dev = EXCLUDED.std_dev
+	`
+
+	for _, e := range rankings {
+		_, err := tx.ExecContext(ctx, query,
+			e.PlayerName, e.Position, e.Team, e.Season, e.Week, e.Source,
+			e.Rank, e.PositionRank, e.BestRank, e.WorstRank, e.AvgRank, e.StdDev, e.SnapshotAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert expert ranking for %s: %w", e.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit expert rankings: %w", err)
+	}
+
+	return nil
+}
+
+// GetPlayerHistory retrieves a player's weekly projection, expert rank and
+// actual points over a season. Each week uses the last projection and the
+// last ECR snapshot recorded for it.
+func (r *PostgresRepository) GetPlayerHistory(ctx context.Context, season int, playerName string) ([]PlayerWeekHistory, error) {
+	query := `
+		WITH projections AS (
+			SELECT DISTINCT ON (week, player_name) week, player_name, position, points_ppr
+			FROM gold.projection_history
+			WHERE season = $1
+			ORDER BY week, player_name, recorded_at DESC
+		), projection_ranks AS (
+			SELECT week, player_name, points_ppr,
+				RANK() OVER (PARTITION BY week, position ORDER BY points_ppr DESC) AS position_rank
+			FROM projections
+		), ecr AS (
+			SELECT DISTINCT ON (week) week, rank, position_rank, snapshot_at
+			FROM gold.expert_rankings
+			WHERE season = $1 AND LOWER(player_name) = LOWER($2)
+			ORDER BY week, snapshot_at DESC
+		), actual_ranks AS (
+			SELECT week, player_name, fantasy_points_ppr,
+				RANK() OVER (PARTITION BY week, position ORDER BY fantasy_points_ppr DESC) AS position_rank
+			FROM gold.player_weekly_actuals
+			WHERE season = $1
+		), weeks AS (
+			SELECT week FROM projection_ranks WHERE LOWER(player_name) = LOWER($2)
+			UNION SELECT week FROM ecr
+			UNION SELECT week FROM actual_ranks WHERE LOWER(player_name) = LOWER($2)
+		)
+		SELECT w.week, p.points_ppr, p.position_rank,
+			e.rank, e.position_rank, e.snapshot_at,
+			a.fantasy_points_ppr, a.position_rank
+		FROM weeks w
+		LEFT JOIN projection_ranks p ON p.week = w.week AND LOWER(p.player_name) = LOWER($2)
+		LEFT JOIN ecr e ON e.week = w.week
+		LEFT JOIN actual_ranks a ON a.week = w.week AND LOWER(a.player_name) = LOWER($2)
+		ORDER BY w.week
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, playerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []PlayerWeekHistory{}
+	for rows.Next() {
+		var h PlayerWeekHistory
+		var consensus, actual sql.NullFloat64
+		var consensusRank, ecrRank, ecrPositionRank, actualRank sql.NullInt64
+		var snapshotAt sql.NullTime
+		if err := rows.Scan(
+			&h.Week,
+			&consensus,
+			&consensusRank,
+			&ecrRank,
+			&ecrPositionRank,
+			&snapshotAt,
+			&actual,
+			&actualRank,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan player history: %w", err)
+		}
+		h.ConsensusPPR = nullFloat(consensus)
+		h.ConsensusPositionRank = nullInt(consensusRank)
+		h.ECRRank = nullInt(ecrRank)
+		h.ECRPositionRank = nullInt(ecrPositionRank)
+		if snapshotAt.Valid {
+			h.ECRSnapshotAt = &snapshotAt.Time
+		}
+		h.ActualPPR = nullFloat(actual)
+		h.ActualPositionRank = nullInt(actualRank)
+		history = append(history, h)
+	}
+
+	return history, rows.Err()
+}
+
+// GetRankedPlayers retrieves the players at a position that have our last
+// projection, an expert rank and actual points for a week, limited to the
+// experts' top players
+func (r *PostgresRepository) GetRankedPlayers(ctx context.Context, season, week int, position string, limit int) ([]RankedPlayer, error) {
+	query := `
+		WITH projections AS (
+			SELECT DISTINCT ON (player_name) player_name, points_ppr
+			FROM gold.projection_history
+			WHERE season = $1 AND week = $2 AND position = $3
+			ORDER BY player_name, recorded_at DESC
+		), ecr AS (
+			SELECT DISTINCT ON (player_name) player_name, position_rank
+			FROM gold.expert_rankings
+			WHERE season = $1 AND week = $2 AND position = $3
+			ORDER BY player_name, snapshot_at DESC
+		)
+		SELECT e.player_name,
+			RANK() OVER (ORDER BY p.points_ppr DESC),
+			e.position_rank,
+			RANK() OVER (ORDER BY a.fantasy_points_ppr DESC)
+		FROM ecr e
+		JOIN projections p ON LOWER(p.player_name) = LOWER(e.player_name)
+		JOIN gold.player_weekly_actuals a
+			ON LOWER(a.player_name) = LOWER(e.player_name) AND a.season = $1 AND a.week = $2
+		ORDER BY e.position_rank
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, week, position, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ranked players: %w", err)
+	}
+	defer rows.Close()
+
+	var players []RankedPlayer
+	for rows.Next() {
+		p := RankedPlayer{Position: position}
+		if err := rows.Scan(&p.PlayerName, &p.ConsensusRank, &p.ECRRank, &p.ActualRank); err != nil {
+			return nil, fmt.Errorf("failed to scan ranked player: %w", err)
+		}
+		players = append(players, p)
+	}
+
+	return players, rows.Err()
+}
+
+func nullFloat(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
+
+func nullInt(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := int(v.Int64)
+	return &i
+}
+
+// ECRPositions are the positions the accuracy comparison covers by default
+var ECRPositions = []string{"QB", "RB", "WR", "TE"}
+
+// SeasonRankAccuracy averages weekly rank accuracy for one position
+type SeasonRankAccuracy struct {
+	Position           string         `json:"position"`
+	Weeks              int            `json:"weeks"`
+	ConsensusRankError float64        `json:"consensus_rank_error"`
+	ECRRankError       float64        `json:"ecr_rank_error"`
+	ConsensusSpearman  float64        `json:"consensus_spearman"`
+	ECRSpearman        float64        `json:"ecr_spearman"`
+	WeeksConsensusWon  int            `json:"weeks_consensus_won"`
+	ByWeek             []RankAccuracy `json:"by_week"`
+}
+
+// SeasonAccuracy compares our consensus with the expert consensus for each
+// position over a range of weeks, scoring the experts' top players at each
+// position. Weeks without ranks, projections or actuals are skipped.
+func SeasonAccuracy(ctx context.Context, repo ECRRepository, season, fromWeek, toWeek int, positions []string, depth int) ([]SeasonRankAccuracy, error) {
+	results := make([]SeasonRankAccuracy, 0, len(positions))
+	for _, position := range positions {
+		summary := SeasonRankAccuracy{Position: position, ByWeek: []RankAccuracy{}}
+		for week := fromWeek; week <= toWeek; week++ {
+			players, err := repo.GetRankedPlayers(ctx, season, week, position, depth)
+			if err != nil {
+				return nil, err
+			}
+			if len(players) < 2 {
+				continue
+			}
+
+			accuracy := CompareRankAccuracy(week, position, players)
+			summary.ByWeek = append(summary.ByWeek, accuracy)
+			summary.ConsensusRankError += accuracy.ConsensusRankError
+			summary.ECRRankError += accuracy.ECRRankError
+			summary.ConsensusSpearman += accuracy.ConsensusSpearman
+			summary.ECRSpearman += accuracy.ECRSpearman
+			if accuracy.ConsensusBeatExpert {
+				summary.WeeksConsensusWon++
+			}
+		}
+
+		summary.Weeks = len(summary.ByWeek)
+		if summary.Weeks > 0 {
+			n := float64(summary.Weeks)
+			summary.ConsensusRankError = round2(summary.ConsensusRankError / n)
+			summary.ECRRankError = round2(summary.ECRRankError / n)
+			summary.ConsensusSpearman = round4(summary.ConsensusSpearman / n)
+			summary.ECRSpearman = round4(summary.ECRSpearman / n)
+		}
+		results = append(results, summary)
+	}
+	return results, nil
+}
@@ -0,0 +1,110 @@
+package projections
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/fantasypros"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadECRCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ecr.csv")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"RK,TIERS,PLAYER NAME,TEAM,POS,BEST,WORST,AVG.,STD.DEV\n"+
+			"1,1,Ja'Marr Chase,cin,WR1,1,3,1.4,0.6\n"+
+			"12,2,San Francisco 49ers,SF,DEF1,,,,\n"), 0o644))
+
+	snapshot := time.Date(2025, 9, 18, 12, 0, 0, 0, time.UTC)
+	rankings, err := ReadECRCSV(path, "fantasypros", 2025, 3, snapshot)
+	require.NoError(t, err)
+	require.Len(t, rankings, 2)
+
+	assert.Equal(t, ExpertRanking{
+		PlayerName: "Ja'Marr Chase", Position: "WR", Team: "CIN", Season: 2025, Week: 3,
+		Source: "fantasypros", Rank: 1, PositionRank: 1, BestRank: 1, WorstRank: 3,
+		AvgRank: 1.4, StdDev: 0.6, SnapshotAt: snapshot,
+	}, rankings[0])
+	assert.Equal(t, "DST", rankings[1].Position)
+	assert.Equal(t, 1, rankings[1].PositionRank)
+	assert.Zero(t, rankings[1].BestRank)
+}
+
+func TestReadECRCSVRejectsBadRank(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ecr.csv")
+	require.NoError(t, os.WriteFile(path, []byte("RK,PLAYER NAME,TEAM,POS\nfirst,Josh Allen,BUF,QB1\n"), 0o644))
+
+	_, err := ReadECRCSV(path, "fantasypros", 2025, 3, time.Now())
+	assert.ErrorContains(t, err, "row 2")
+}
+
+func TestCompareRankAccuracy(t *testing.T) {
+	// Our consensus matches the actual finish; the experts swapped the top two
+	players := []RankedPlayer{
+		{PlayerName: "A", ConsensusRank: 1, ECRRank: 2, ActualRank: 1},
+		{PlayerName: "B", ConsensusRank: 2, ECRRank: 1, ActualRank: 2},
+		{PlayerName: "C", ConsensusRank: 3, ECRRank: 3, ActualRank: 3},
+	}
+
+	accuracy := CompareRankAccuracy(4, "RB", players)
+	assert.Equal(t, 3, accuracy.Players)
+	assert.Equal(t, 0.0, accuracy.ConsensusRankError)
+	assert.Equal(t, 0.67, accuracy.ECRRankError)
+	assert.Equal(t, 1.0, accuracy.ConsensusSpearman)
+	assert.Equal(t, 0.5, accuracy.ECRSpearman)
+	assert.True(t, accuracy.ConsensusBeatExpert)
+}
+
+func TestCompareRankAccuracyReRanksSubset(t *testing.T) {
+	// Ranks come from the whole position; only the compared players count
+	players := []RankedPlayer{
+		{PlayerName: "A", ConsensusRank: 5, ECRRank: 2, ActualRank: 10},
+		{PlayerName: "B", ConsensusRank: 9, ECRRank: 7, ActualRank: 30},
+	}
+
+	accuracy := CompareRankAccuracy(4, "WR", players)
+	assert.Equal(t, 0.0, accuracy.ConsensusRankError)
+	assert.Equal(t, 0.0, accuracy.ECRRankError)
+	assert.False(t, accuracy.ConsensusBeatExpert)
+}
+
+type fakeFantasyPros struct {
+	rankings []fantasypros.Ranking
+	adp      []fantasypros.ADP
+}
+
+func (f fakeFantasyPros) GetRankings(ctx context.Context, format string) ([]fantasypros.Ranking, error) {
+	return f.rankings, nil
+}
+
+func (f fakeFantasyPros) GetADP(ctx context.Context, format string) ([]fantasypros.ADP, error) {
+	return f.adp, nil
+}
+
+func TestFantasyProsDraftRankings(t *testing.T) {
+	snapshot := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+	client := fakeFantasyPros{
+		rankings: []fantasypros.Ranking{
+			{PlayerID: "16393", PlayerName: "Christian McCaffrey", Team: "SF", Position: "RB", Rank: 1, PositionRank: 1, Tier: 1},
+		},
+		adp: []fantasypros.ADP{
+			{PlayerName: "christian mccaffrey", Team: "SF", Position: "RB", Rank: 1, PositionRank: 1, ADP: 1.2},
+			{PlayerName: "Rookie Sleeper", Team: "NYJ", Position: "WR", Rank: 210, PositionRank: 80, ADP: 205.5},
+		},
+	}
+
+	rankings, err := FantasyProsDraftRankings(context.Background(), client, 2025, fantasypros.FormatPPR, snapshot)
+	require.NoError(t, err)
+	require.Len(t, rankings, 2)
+	assert.Equal(t, DraftRanking{
+		Source: RankingsSourceFantasyPros, ScoringType: "PPR", Season: 2025, PlayerID: "16393",
+		PlayerName: "Christian McCaffrey", Position: "RB", Team: "SF", Rank: 1, PositionRank: 1, Tier: 1,
+		ADP: 1.2, SnapshotAt: snapshot,
+	}, rankings[0], "ADP is joined to the expert rank by name and position")
+	assert.Equal(t, "Rookie Sleeper", rankings[1].PlayerName, "players with only an ADP are kept")
+	assert.Zero(t, rankings[1].Rank)
+}
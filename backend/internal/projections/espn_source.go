@@ -0,0 +1,57 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+)
+
+// SourceESPN is the source name stored with ESPN projections
+const SourceESPN = "espn"
+
+// PlayerSource provides weekly stat-line projections for offensive players
+type PlayerSource interface {
+	Name() string
+	FetchPlayers(ctx context.Context, season, week int) ([]PlayerProjection, error)
+}
+
+// ESPNSource reads ESPN's own player projections through the ESPN client
+type ESPNSource struct {
+	client espn.Client
+}
+
+// NewESPNSource creates a projection source backed by the ESPN API
+func NewESPNSource(client espn.Client) *ESPNSource {
+	return &ESPNSource{client: client}
+}
+
+// Name returns the source identifier stored with each projection
+func (s *ESPNSource) Name() string {
+	return SourceESPN
+}
+
+// FetchPlayers converts ESPN's projected stat maps into stat lines.
+// Week 0 fetches season-long projections.
+func (s *ESPNSource) FetchPlayers(ctx context.Context, season, week int) ([]PlayerProjection, error) {
+	players, err := s.client.GetPlayerProjections(ctx, season, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ESPN projections: %w", err)
+	}
+
+	projections := make([]PlayerProjection, 0, len(players))
+	for _, p := range players {
+		projections = append(projections, PlayerProjection{
+			PlayerID:   p.ID,
+			PlayerName: p.Name,
+			Position:   p.Position,
+			Team:       p.Team,
+			Week:       week,
+			Season:     season,
+			Source:     SourceESPN,
+			Stats:      StatLineFromESPN(p.Projections.Stats),
+		})
+	}
+
+	return projections, nil
+}
@@ -0,0 +1,78 @@
+package projections
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StageContribution is one pipeline stage's effect on a projection
+type StageContribution struct {
+	StageOutput
+	// DeltaPPR is the change from the previous stage's PPR points
+	DeltaPPR float64 `json:"delta_ppr"`
+}
+
+// Explanation breaks a weekly projection down by pipeline stage
+type Explanation struct {
+	FinalPPR      float64             `json:"final_ppr"`
+	FinalStandard float64             `json:"final_standard"`
+	Stages        []StageContribution `json:"stages"`
+	Summary       string              `json:"summary"`
+}
+
+// Explain builds an explanation from a player's stage outputs, which must be
+// in stage order
+func Explain(outputs []StageOutput) *Explanation {
+	e := &Explanation{Stages: make([]StageContribution, 0, len(outputs))}
+
+	var previous float64
+	var parts []string
+	for i, o := range outputs {
+		delta := 0.0
+		if i > 0 {
+			delta = round2(o.PointsPPR - previous)
+		}
+		previous = o.PointsPPR
+		e.Stages = append(e.Stages, StageContribution{StageOutput: o, DeltaPPR: delta})
+
+		if part := describeStage(o, delta); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	if len(outputs) > 0 {
+		last := outputs[len(outputs)-1]
+		e.FinalPPR = last.PointsPPR
+		e.FinalStandard = last.PointsStandard
+	}
+	e.Summary = strings.Join(parts, "; ")
+
+	return e
+}
+
+// describeStage returns a short sentence for stages that shaped the number
+func describeStage(o StageOutput, delta float64) string {
+	switch o.Stage {
+	case StageSourceBlend:
+		return fmt.Sprintf("blended %d sources to %.2f", countSources(o.Details["sources"]), o.PointsPPR)
+	case StageScoring:
+		return fmt.Sprintf("scored at %.2f PPR", o.PointsPPR)
+	}
+
+	if o.Adjustment == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %+.2f (%s)", o.Stage, delta, o.Adjustment.Reason)
+}
+
+// countSources counts blended sources in stage details, which hold a typed map
+// in-process and a generic one once decoded from storage
+func countSources(v interface{}) int {
+	switch sources := v.(type) {
+	case map[string]float64:
+		return len(sources)
+	case map[string]interface{}:
+		return len(sources)
+	}
+	return 0
+}
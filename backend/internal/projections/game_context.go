@@ -0,0 +1,215 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	// Time zone features must not depend on the host's zoneinfo
+	_ "time/tzdata"
+)
+
+// GameContext is one team's features for one game, computed from the
+// schedule: rest, travel and time zone change. The projection pipeline's
+// rest modifier reads them, and the modeling team downloads them in bulk.
+type GameContext struct {
+	Season           int       `json:"season"`
+	Week             int       `json:"week"`
+	Team             string    `json:"team"`
+	Opponent         string    `json:"opponent"`
+	Home             bool      `json:"home"`
+	KickoffAt        time.Time `json:"kickoff_at"`
+	RestDays         int       `json:"rest_days"`
+	OpponentRestDays int       `json:"opponent_rest_days"`
+	ShortWeek        bool      `json:"short_week"`
+	// ThursdayGame is a Thursday kickoff in US Eastern time
+	ThursdayGame bool `json:"thursday_game"`
+	// TravelMiles is the great-circle distance from the team's stadium to
+	// the game's, zero at home
+	TravelMiles float64 `json:"travel_miles"`
+	// TimezoneShift is the venue's UTC offset minus the team's home offset
+	// at kickoff, in hours: positive when the team travels east
+	TimezoneShift int `json:"timezone_shift"`
+}
+
+// earthRadiusMiles is the Earth's mean radius
+const earthRadiusMiles = 3958.8
+
+// ComputeGameContext computes every team's features for each game in games.
+// A team's first game in games has unknown rest (0), so include the weeks
+// before the first one wanted. Games are played at the home team's stadium;
+// international games are not known to the schedule and count as home.
+func ComputeGameContext(games []ScheduledGame) []GameContext {
+	sorted := make([]ScheduledGame, len(games))
+	copy(sorted, games)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].KickoffAt.Before(sorted[j].KickoffAt)
+	})
+
+	zones := make(map[string]*time.Location)
+	zone := func(name string) *time.Location {
+		if loc, ok := zones[name]; ok {
+			return loc
+		}
+		// Unknown zones stay nil and count as no shift
+		loc, _ := time.LoadLocation(name)
+		zones[name] = loc
+		return loc
+	}
+	eastern := zone("America/New_York")
+
+	lastKickoff := make(map[string]time.Time)
+	contexts := make([]GameContext, 0, len(sorted)*2)
+
+	for _, g := range sorted {
+		home := strings.ToUpper(g.HomeTeam)
+		away := strings.ToUpper(g.AwayTeam)
+		homeRest := daysBetween(lastKickoff[home], g.KickoffAt)
+		awayRest := daysBetween(lastKickoff[away], g.KickoffAt)
+		thursday := eastern != nil && g.KickoffAt.In(eastern).Weekday() == time.Thursday
+		venue, venueKnown := HomeStadium(home)
+
+		for _, side := range []struct {
+			team, opponent string
+			rest, oppRest  int
+		}{
+			{home, away, homeRest, awayRest},
+			{away, home, awayRest, homeRest},
+		} {
+			c := GameContext{
+				Season:           g.Season,
+				Week:             g.Week,
+				Team:             side.team,
+				Opponent:         side.opponent,
+				Home:             side.team == home,
+				KickoffAt:        g.KickoffAt,
+				RestDays:         side.rest,
+				OpponentRestDays: side.oppRest,
+				ShortWeek:        side.rest > 0 && side.rest <= shortWeekDays,
+				ThursdayGame:     thursday,
+			}
+			if stadium, ok := HomeStadium(side.team); ok && venueKnown && !c.Home {
+				c.TravelMiles = math.Round(haversineMiles(stadium, venue))
+				c.TimezoneShift = offsetHours(zone(venue.TimeZone), g.KickoffAt) - offsetHours(zone(stadium.TimeZone), g.KickoffAt)
+			}
+			contexts = append(contexts, c)
+		}
+
+		lastKickoff[home] = g.KickoffAt
+		lastKickoff[away] = g.KickoffAt
+	}
+
+	return contexts
+}
+
+// haversineMiles is the great-circle distance between two stadiums
+func haversineMiles(a, b Stadium) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMiles * math.Asin(math.Sqrt(h))
+}
+
+// offsetHours is loc's UTC offset at t, zero when the zone is unknown
+func offsetHours(loc *time.Location, t time.Time) int {
+	if loc == nil {
+		return 0
+	}
+	_, offset := t.In(loc).Zone()
+	return offset / 3600
+}
+
+// RefreshGameContext recomputes a season's game-context features from its
+// stored schedule, returning how many team games were stored
+func RefreshGameContext(ctx context.Context, repo Repository, season int) (int, error) {
+	games, err := repo.GetSchedule(ctx, season, 1, maxRegularSeasonWeek)
+	if err != nil {
+		return 0, err
+	}
+	contexts := ComputeGameContext(games)
+	if err := repo.UpsertGameContext(ctx, contexts); err != nil {
+		return 0, err
+	}
+	return len(contexts), nil
+}
+
+// UpsertGameContext stores game-context features in the silver layer
+func (r *PostgresRepository) UpsertGameContext(ctx context.Context, contexts []GameContext) error {
+	if len(contexts) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO silver.team_game_context (
+			season, week, team, opponent, is_home, kickoff_at, rest_days, opponent_rest_days,
+			short_week, thursday_game, travel_miles, timezone_shift, computed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW())
+		ON CONFLICT (season, week, team) DO UPDATE SET
+			opponent = EXCLUDED.opponent,
+			is_home = EXCLUDED.is_home,
+			kickoff_at = EXCLUDED.kickoff_at,
+			rest_days = EXCLUDED.rest_days,
+			opponent_rest_days = EXCLUDED.opponent_rest_days,
+			short_week = EXCLUDED.short_week,
+			thursday_game = EXCLUDED.thursday_game,
+			travel_miles = EXCLUDED.travel_miles,
+			timezone_shift = EXCLUDED.timezone_shift,
+			computed_at = NOW()
+	`
+
+	for _, c := range contexts {
+		if _, err := tx.ExecContext(ctx, query,
+			c.Season, c.Week, c.Team, c.Opponent, c.Home, c.KickoffAt, c.RestDays, c.OpponentRestDays,
+			c.ShortWeek, c.ThursdayGame, c.TravelMiles, c.TimezoneShift,
+		); err != nil {
+			return fmt.Errorf("failed to upsert game context for %s: %w", c.Team, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit game context: %w", err)
+	}
+
+	return nil
+}
+
+// GetGameContext retrieves the features for games between two weeks,
+// inclusive, in kickoff order
+func (r *PostgresRepository) GetGameContext(ctx context.Context, season, fromWeek, toWeek int) ([]GameContext, error) {
+	query := `
+		SELECT season, week, team, opponent, is_home, kickoff_at, rest_days, opponent_rest_days,
+			short_week, thursday_game, travel_miles, timezone_shift
+		FROM silver.team_game_context
+		WHERE season = $1 AND week BETWEEN $2 AND $3
+		ORDER BY kickoff_at, team
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, fromWeek, toWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query game context: %w", err)
+	}
+	defer rows.Close()
+
+	var contexts []GameContext
+	for rows.Next() {
+		var c GameContext
+		if err := rows.Scan(&c.Season, &c.Week, &c.Team, &c.Opponent, &c.Home, &c.KickoffAt, &c.RestDays,
+			&c.OpponentRestDays, &c.ShortWeek, &c.ThursdayGame, &c.TravelMiles, &c.TimezoneShift); err != nil {
+			return nil, fmt.Errorf("failed to scan game context: %w", err)
+		}
+		contexts = append(contexts, c)
+	}
+
+	return contexts, rows.Err()
+}
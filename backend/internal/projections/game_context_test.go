@@ -0,0 +1,62 @@
+package projections
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeGameContext(t *testing.T) {
+	games := []ScheduledGame{
+		// Thursday night opener, 8:20pm Eastern
+		{Season: 2024, Week: 1, HomeTeam: "KC", AwayTeam: "BAL", KickoffAt: kickoff(6, 0)},
+		{Season: 2024, Week: 1, HomeTeam: "NYG", AwayTeam: "SEA", KickoffAt: kickoff(8, 17)},
+		{Season: 2024, Week: 2, HomeTeam: "SEA", AwayTeam: "KC", KickoffAt: kickoff(15, 20)},
+	}
+
+	byGame := make(map[string]GameContext)
+	for _, c := range ComputeGameContext(games) {
+		byGame[fmt.Sprintf("%s@%d", c.Team, c.Week)] = c
+	}
+	require.Len(t, byGame, 6)
+
+	kc := byGame["KC@1"]
+	assert.True(t, kc.Home)
+	assert.True(t, kc.ThursdayGame)
+	assert.Zero(t, kc.TravelMiles)
+	assert.Zero(t, kc.RestDays, "first game has unknown rest")
+
+	bal := byGame["BAL@1"]
+	assert.False(t, bal.Home)
+	assert.Equal(t, "KC", bal.Opponent)
+	assert.Equal(t, -1, bal.TimezoneShift, "Baltimore to Kansas City is one zone west")
+	assert.InDelta(t, 966, bal.TravelMiles, 25)
+
+	sea := byGame["SEA@1"]
+	assert.False(t, sea.ThursdayGame)
+	assert.Equal(t, 3, sea.TimezoneShift)
+	assert.InDelta(t, 2415, sea.TravelMiles, 50)
+
+	kcAway := byGame["KC@2"]
+	assert.Equal(t, 10, kcAway.RestDays)
+	assert.Equal(t, 7, kcAway.OpponentRestDays)
+	assert.False(t, kcAway.ShortWeek)
+	assert.Equal(t, -2, kcAway.TimezoneShift)
+}
+
+func TestRestModifier_CrossCountry(t *testing.T) {
+	modifier := NewRestModifier(map[string]TeamRest{
+		"SEA": {Team: "SEA", Opponent: "NYG", RestDays: 7, OpponentRestDays: 7, TravelMiles: 2415, TimezoneShift: 3},
+		"NYG": {Team: "NYG", Opponent: "SEA", RestDays: 7, OpponentRestDays: 7},
+	})
+
+	p := &Adjustable{PlayerName: "Geno Smith", Team: "SEA", PointsPPR: 20, PointsStandard: 20}
+	adj := modifier.Adjust(p)
+	require.NotNil(t, adj)
+	assert.Equal(t, crossCountryMultiplier, adj.Multiplier)
+	assert.Contains(t, adj.Reason, "3 time zones")
+
+	assert.Nil(t, modifier.Adjust(&Adjustable{PlayerName: "Malik Nabers", Team: "NYG", PointsPPR: 15}))
+}
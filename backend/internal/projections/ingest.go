@@ -0,0 +1,96 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+)
+
+// IngestResult summarizes a single ingestion run
+type IngestResult struct {
+	Season      int `json:"season"`
+	Week        int `json:"week"`
+	DSTCount    int `json:"dst_count"`
+	KickerCount int `json:"kicker_count"`
+	PlayerCount int `json:"player_count"`
+}
+
+// Ingester loads projections from sources into the warehouse
+type Ingester struct {
+	repo          Repository
+	sources       []Source
+	playerSources []PlayerSource
+}
+
+// NewIngester creates a new projection ingester
+func NewIngester(repo Repository, sources ...Source) *Ingester {
+	return &Ingester{
+		repo:    repo,
+		sources: sources,
+	}
+}
+
+// WithPlayerSources adds sources of offensive player projections
+func (i *Ingester) WithPlayerSources(sources ...PlayerSource) *Ingester {
+	i.playerSources = append(i.playerSources, sources...)
+	return i
+}
+
+// Ingest fetches, scores and stores projections from every source, then
+// rebuilds the DST/K consensus rows for the week. Offensive player rows reach
+// the gold layer through the pipeline.
+func (i *Ingester) Ingest(ctx context.Context, season, week int) (*IngestResult, error) {
+	result := &IngestResult{Season: season, Week: week}
+
+	for _, source := range i.sources {
+		dst, err := source.FetchDST(ctx, season, week)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch DST projections from %s: %w", source.Name(), err)
+		}
+		for idx := range dst {
+			dst[idx].FantasyPoints = ScoreDST(&dst[idx])
+		}
+		if err := i.repo.UpsertDSTProjections(ctx, dst); err != nil {
+			return nil, err
+		}
+		result.DSTCount += len(dst)
+
+		kickers, err := source.FetchKickers(ctx, season, week)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch kicker projections from %s: %w", source.Name(), err)
+		}
+		for idx := range kickers {
+			kickers[idx].FantasyPoints = ScoreKicker(&kickers[idx])
+		}
+		if err := i.repo.UpsertKickerProjections(ctx, kickers); err != nil {
+			return nil, err
+		}
+		result.KickerCount += len(kickers)
+	}
+
+	for _, source := range i.playerSources {
+		players, err := source.FetchPlayers(ctx, season, week)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch player projections from %s: %w", source.Name(), err)
+		}
+		for idx := range players {
+			scorePlayerProjection(&players[idx])
+		}
+		if err := i.repo.UpsertPlayerProjections(ctx, players); err != nil {
+			return nil, err
+		}
+		result.PlayerCount += len(players)
+	}
+
+	if err := i.repo.RefreshConsensus(ctx, season, week); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// scorePlayerProjection fills in a projection's points under each reception format
+func scorePlayerProjection(p *PlayerProjection) {
+	p.PointsPPR = round2(ScoreStatLine(p.Stats, 1))
+	p.PointsStandard = round2(ScoreStatLine(p.Stats, 0))
+	p.PointsHalfPPR = round2(ScoreStatLine(p.Stats, 0.5))
+}
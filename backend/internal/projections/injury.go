@@ -0,0 +1,75 @@
+package projections
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InjuryStatus is a player's injury designation for a week
+type InjuryStatus struct {
+	PlayerName string    `json:"player_name"`
+	Team       string    `json:"team"`
+	Season     int       `json:"season"`
+	Week       int       `json:"week"`
+	Status     string    `json:"status"`
+	Source     string    `json:"source"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// injuryDiscounts are the share of a projection kept for each designation
+var injuryDiscounts = map[string]float64{
+	"QUESTIONABLE":   0.85,
+	"DOUBTFUL":       0.25,
+	"OUT":            0,
+	"IR":             0,
+	"INJURY_RESERVE": 0,
+	"SUSPENSION":     0,
+}
+
+// InjuryDiscount returns the projection multiplier for an injury designation
+// and whether the designation discounts the projection at all
+func InjuryDiscount(status string) (float64, bool) {
+	m, ok := injuryDiscounts[strings.ToUpper(status)]
+	return m, ok
+}
+
+// InjuryModifier discounts projections for players with an injury designation
+type InjuryModifier struct {
+	byPlayer map[string]InjuryStatus
+}
+
+// NewInjuryModifier creates an injury modifier for one week of designations
+func NewInjuryModifier(statuses []InjuryStatus) *InjuryModifier {
+	byPlayer := make(map[string]InjuryStatus, len(statuses))
+	for _, s := range statuses {
+		byPlayer[strings.ToLower(s.PlayerName)] = s
+	}
+	return &InjuryModifier{byPlayer: byPlayer}
+}
+
+// Name returns the modifier identifier
+func (m *InjuryModifier) Name() string {
+	return ModifierInjury
+}
+
+// Adjust discounts the projection by the player's designation. Unlike game
+// context modifiers the multiplier is not clamped, so Out players drop to zero.
+func (m *InjuryModifier) Adjust(p *Adjustable) *Adjustment {
+	status, ok := m.byPlayer[strings.ToLower(p.PlayerName)]
+	if !ok {
+		return nil
+	}
+
+	multiplier, ok := InjuryDiscount(status.Status)
+	if !ok {
+		return nil
+	}
+
+	return &Adjustment{
+		Modifier:   m.Name(),
+		Multiplier: round2(multiplier),
+		PointsPPR:  p.scale(multiplier),
+		Reason:     fmt.Sprintf("listed as %s", strings.ToUpper(status.Status)),
+	}
+}
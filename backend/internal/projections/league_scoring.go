@@ -0,0 +1,75 @@
+package projections
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+)
+
+// ScoreStats returns fantasy points for a raw ESPN stat map, keyed by stat
+// ID, using a league's exact scoring settings. It works for actual box score
+// stats and projected stats alike.
+func ScoreStats(stats map[string]float64, settings espn.ScoringSettings) float64 {
+	points := stats[espn.StatPassingYards] * settings.PassingYards
+	points += stats[espn.StatPassingTDs] * settings.PassingTouchdowns
+	points += stats[espn.StatInterceptions] * settings.Interceptions
+	points += stats[espn.StatRushingYards] * settings.RushingYards
+	points += stats[espn.StatRushingTDs] * settings.RushingTouchdowns
+	points += stats[espn.StatReceptions] * settings.ReceptionPoints
+	points += stats[espn.StatReceivingYards] * settings.ReceivingYards
+	points += stats[espn.StatReceivingTDs] * settings.ReceivingTouchdowns
+	points += stats[espn.StatFumblesLost] * settings.Fumbles
+	points += stats[espn.StatFieldGoalsMade] * settings.FieldGoalMade
+	points += stats[espn.StatFieldGoalsMissed] * settings.FieldGoalMissed
+	points += stats[espn.StatExtraPointsMade] * settings.ExtraPointMade
+	return round2(points)
+}
+
+// ScoreStatLineForLeague returns fantasy points for a projected stat line
+// using a league's scoring settings rather than the generic PPR and
+// Standard buckets
+func ScoreStatLineForLeague(s StatLine, settings espn.ScoringSettings) float64 {
+	return ScoreStats(s.ESPNStats(), settings)
+}
+
+// StatLineFromESPN builds a stat line from an ESPN stat map
+func StatLineFromESPN(stats map[string]float64) StatLine {
+	return StatLine{
+		PassingYards:   stats[espn.StatPassingYards],
+		PassingTDs:     stats[espn.StatPassingTDs],
+		PassingInts:    stats[espn.StatInterceptions],
+		RushingYards:   stats[espn.StatRushingYards],
+		RushingTDs:     stats[espn.StatRushingTDs],
+		ReceivingYards: stats[espn.StatReceivingYards],
+		ReceivingTDs:   stats[espn.StatReceivingTDs],
+		Receptions:     stats[espn.StatReceptions],
+	}
+}
+
+// ESPNStats returns the stat line as an ESPN stat map so it can be scored
+// with ScoreStats
+func (s StatLine) ESPNStats() map[string]float64 {
+	return map[string]float64{
+		espn.StatPassingYards:   s.PassingYards,
+		espn.StatPassingTDs:     s.PassingTDs,
+		espn.StatInterceptions:  s.PassingInts,
+		espn.StatRushingYards:   s.RushingYards,
+		espn.StatRushingTDs:     s.RushingTDs,
+		espn.StatReceivingYards: s.ReceivingYards,
+		espn.StatReceivingTDs:   s.ReceivingTDs,
+		espn.StatReceptions:     s.Receptions,
+	}
+}
+
+// ParseScoringRules decodes the scoring rules stored with a connected league
+func ParseScoringRules(raw json.RawMessage) (espn.ScoringSettings, error) {
+	var settings espn.ScoringSettings
+	if len(raw) == 0 {
+		return settings, fmt.Errorf("league has no scoring rules")
+	}
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return settings, fmt.Errorf("failed to parse scoring rules: %w", err)
+	}
+	return settings, nil
+}
@@ -0,0 +1,117 @@
+package projections
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// PointsAllowed is the average PPR points a defense has allowed per game to
+// one position
+type PointsAllowed struct {
+	Defense   string  `json:"defense"`
+	Position  string  `json:"position"`
+	AvgPoints float64 `json:"avg_points"`
+	Games     int     `json:"games"`
+}
+
+const (
+	// matchupPriorGames shrinks early-season ratings toward league average;
+	// a defense needs this many games to carry half its raw rating
+	matchupPriorGames = 4.0
+	// matchupMinEffect ignores ratings too close to average to matter
+	matchupMinEffect = 0.02
+
+	minMatchupMultiplier = 0.85
+	maxMatchupMultiplier = 1.15
+)
+
+// matchupPositions are the positions rated against opposing defenses
+var matchupPositions = map[string]bool{
+	"QB": true,
+	"RB": true,
+	"WR": true,
+	"TE": true,
+	"K":  true,
+}
+
+// MatchupModifier adjusts projections by how many fantasy points the
+// opposing defense has allowed to the player's position
+type MatchupModifier struct {
+	opponents map[string]string
+	ratings   map[string]map[string]float64
+}
+
+// NewMatchupModifier creates a matchup modifier from one week of games and
+// season-to-date points allowed
+func NewMatchupModifier(games []ScheduledGame, allowed []PointsAllowed) *MatchupModifier {
+	opponents := make(map[string]string, len(games)*2)
+	for _, g := range games {
+		home := strings.ToUpper(g.HomeTeam)
+		away := strings.ToUpper(g.AwayTeam)
+		opponents[home] = away
+		opponents[away] = home
+	}
+
+	// League average per position across defenses
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, a := range allowed {
+		sums[a.Position] += a.AvgPoints
+		counts[a.Position]++
+	}
+
+	ratings := make(map[string]map[string]float64)
+	for _, a := range allowed {
+		if !matchupPositions[a.Position] || a.Games == 0 {
+			continue
+		}
+		leagueAvg := sums[a.Position] / float64(counts[a.Position])
+		if leagueAvg <= 0 {
+			continue
+		}
+
+		raw := a.AvgPoints / leagueAvg
+		weight := float64(a.Games) / (float64(a.Games) + matchupPriorGames)
+		rating := 1 + (raw-1)*weight
+		rating = math.Max(minMatchupMultiplier, math.Min(maxMatchupMultiplier, rating))
+
+		defense := strings.ToUpper(a.Defense)
+		if ratings[defense] == nil {
+			ratings[defense] = make(map[string]float64)
+		}
+		ratings[defense][a.Position] = rating
+	}
+
+	return &MatchupModifier{opponents: opponents, ratings: ratings}
+}
+
+// Name returns the modifier identifier
+func (m *MatchupModifier) Name() string {
+	return ModifierMatchup
+}
+
+// Adjust scales the projection by the opposing defense's rating
+func (m *MatchupModifier) Adjust(p *Adjustable) *Adjustment {
+	opponent, ok := m.opponents[strings.ToUpper(p.Team)]
+	if !ok {
+		return nil
+	}
+
+	rating, ok := m.ratings[opponent][p.Position]
+	if !ok || math.Abs(rating-1) < matchupMinEffect {
+		return nil
+	}
+
+	reason := fmt.Sprintf("%s allows %.0f%% more %s points than average", opponent, (rating-1)*100, p.Position)
+	if rating < 1 {
+		reason = fmt.Sprintf("%s allows %.0f%% fewer %s points than average", opponent, (1-rating)*100, p.Position)
+	}
+
+	return &Adjustment{
+		Modifier:   m.Name(),
+		Multiplier: round2(rating),
+		PointsPPR:  p.scale(rating),
+		Reason:     reason,
+	}
+}
@@ -0,0 +1,123 @@
+package projections
+
+import "time"
+
+// DSTProjection represents a single source's weekly projection for a team defense
+type DSTProjection struct {
+	Team             string  `json:"team"`
+	Opponent         string  `json:"opponent,omitempty"`
+	Week             int     `json:"week"`
+	Season           int     `json:"season"`
+	Source           string  `json:"source"`
+	PointsAllowed    float64 `json:"points_allowed"`
+	YardsAllowed     float64 `json:"yards_allowed"`
+	Sacks            float64 `json:"sacks"`
+	Interceptions    float64 `json:"interceptions"`
+	FumbleRecoveries float64 `json:"fumble_recoveries"`
+	Safeties         float64 `json:"safeties"`
+	DefensiveTDs     float64 `json:"defensive_tds"`
+	ReturnTDs        float64 `json:"return_tds"`
+	FantasyPoints    float64 `json:"fantasy_points"`
+}
+
+// PlayerName returns the name used for the defense in consensus tables
+func (p *DSTProjection) PlayerName() string {
+	return p.Team + " D/ST"
+}
+
+// KickerProjection represents a single source's weekly projection for a kicker
+type KickerProjection struct {
+	PlayerID      string  `json:"player_id,omitempty"`
+	PlayerName    string  `json:"player_name"`
+	Team          string  `json:"team"`
+	Opponent      string  `json:"opponent,omitempty"`
+	Week          int     `json:"week"`
+	Season        int     `json:"season"`
+	Source        string  `json:"source"`
+	FGAttempts    float64 `json:"fg_attempts"`
+	FGMade0To39   float64 `json:"fg_made_0_39"`
+	FGMade40To49  float64 `json:"fg_made_40_49"`
+	FGMade50Plus  float64 `json:"fg_made_50_plus"`
+	FGMissed      float64 `json:"fg_missed"`
+	XPAttempts    float64 `json:"xp_attempts"`
+	XPMade        float64 `json:"xp_made"`
+	FantasyPoints float64 `json:"fantasy_points"`
+}
+
+// FGMade returns the total projected field goals made
+func (p *KickerProjection) FGMade() float64 {
+	return p.FGMade0To39 + p.FGMade40To49 + p.FGMade50Plus
+}
+
+// PlayerProjection represents a single source's stat-line projection for
+// a QB, RB, WR or TE
+type PlayerProjection struct {
+	PlayerID       string   `json:"player_id,omitempty"`
+	PlayerName     string   `json:"player_name"`
+	Position       string   `json:"position"`
+	Team           string   `json:"team"`
+	Week           int      `json:"week"`
+	Season         int      `json:"season"`
+	Source         string   `json:"source"`
+	Stats          StatLine `json:"stats"`
+	PointsPPR      float64  `json:"points_ppr"`
+	PointsStandard float64  `json:"points_standard"`
+	PointsHalfPPR  float64  `json:"points_half_ppr"`
+	// HasProps marks projections derived from sportsbook prop lines
+	HasProps bool `json:"has_props,omitempty"`
+}
+
+// ConsensusProjection is a player's consensus weekly projection
+type ConsensusProjection struct {
+	PlayerName     string  `json:"player_name"`
+	Position       string  `json:"position"`
+	Team           string  `json:"team"`
+	Season         int     `json:"season"`
+	Week           int     `json:"week"`
+	PointsPPR      float64 `json:"points_ppr"`
+	PointsStandard float64 `json:"points_standard"`
+	FloorPPR       float64 `json:"floor_ppr"`
+	CeilingPPR     float64 `json:"ceiling_ppr"`
+}
+
+// Adjustable returns the modifiable view of the projection
+func (p *ConsensusProjection) Adjustable() Adjustable {
+	return Adjustable{
+		PlayerName:     p.PlayerName,
+		Position:       p.Position,
+		Team:           p.Team,
+		PointsPPR:      p.PointsPPR,
+		PointsStandard: p.PointsStandard,
+		FloorPPR:       p.FloorPPR,
+		CeilingPPR:     p.CeilingPPR,
+	}
+}
+
+// WeeklyActual is a player's actual fantasy output for a week
+type WeeklyActual struct {
+	PlayerName     string  `json:"player_name"`
+	Position       string  `json:"position"`
+	Team           string  `json:"team"`
+	Season         int     `json:"season"`
+	Week           int     `json:"week"`
+	PointsPPR      float64 `json:"points_ppr"`
+	PointsStandard float64 `json:"points_standard"`
+}
+
+// BacktestResult summarizes a modifier's accuracy over a range of weeks
+type BacktestResult struct {
+	ID            int       `json:"id"`
+	Modifier      string    `json:"modifier"`
+	Season        int       `json:"season"`
+	WeekStart     int       `json:"week_start"`
+	WeekEnd       int       `json:"week_end"`
+	AdjustedCount int       `json:"adjusted_count"`
+	BaseMAE       float64   `json:"base_mae"`
+	AdjustedMAE   float64   `json:"adjusted_mae"`
+	RunAt         time.Time `json:"run_at"`
+}
+
+// Improved reports whether the modifier reduced projection error
+func (b *BacktestResult) Improved() bool {
+	return b.AdjustedCount > 0 && b.AdjustedMAE < b.BaseMAE
+}
@@ -0,0 +1,127 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// Adjustable is the part of a weekly projection that modifiers may change
+type Adjustable struct {
+	PlayerName     string
+	Position       string
+	Team           string
+	PointsPPR      float64
+	PointsStandard float64
+	FloorPPR       float64
+	CeilingPPR     float64
+}
+
+// Adjustment records how a modifier changed a projection
+type Adjustment struct {
+	Modifier   string  `json:"modifier"`
+	Multiplier float64 `json:"multiplier"`
+	PointsPPR  float64 `json:"points_ppr"`
+	Reason     string  `json:"reason"`
+}
+
+// Modifier adjusts consensus projections for game context
+type Modifier interface {
+	Name() string
+	// Adjust modifies p in place and returns the adjustment, or nil if p is unaffected
+	Adjust(p *Adjustable) *Adjustment
+}
+
+// Modifier names
+const (
+	ModifierMatchup = "matchup"
+	ModifierWeather = "weather"
+	ModifierRest    = "rest"
+	ModifierInjury  = "injury"
+)
+
+// BuildModifier loads the game context for a week and builds a single named
+// modifier. Used by pipeline stages and backtests.
+func BuildModifier(ctx context.Context, repo Repository, name string, season, week int) (Modifier, error) {
+	switch name {
+	case ModifierMatchup:
+		games, err := repo.GetSchedule(ctx, season, week, week)
+		if err != nil {
+			return nil, err
+		}
+		allowed, err := repo.GetPointsAllowed(ctx, season, week)
+		if err != nil {
+			return nil, err
+		}
+		return NewMatchupModifier(games, allowed), nil
+	case ModifierWeather:
+		games, err := repo.GetGameWeather(ctx, season, week)
+		if err != nil {
+			return nil, err
+		}
+		return NewWeatherModifier(games), nil
+	case ModifierRest:
+		rest, err := loadTeamRest(ctx, repo, season, week)
+		if err != nil {
+			return nil, err
+		}
+		return NewRestModifier(rest), nil
+	case ModifierInjury:
+		statuses, err := repo.GetInjuryStatuses(ctx, season, week)
+		if err != nil {
+			return nil, err
+		}
+		return NewInjuryModifier(statuses), nil
+	default:
+		return nil, fmt.Errorf("unknown modifier %q", name)
+	}
+}
+
+// loadTeamRest reads a week's rest and travel from the game-context feature
+// store, computing it from the schedule for weeks not yet stored
+func loadTeamRest(ctx context.Context, repo Repository, season, week int) (map[string]TeamRest, error) {
+	contexts, err := repo.GetGameContext(ctx, season, week, week)
+	if err != nil {
+		return nil, err
+	}
+	if len(contexts) > 0 {
+		return TeamRestFromContext(contexts, week), nil
+	}
+
+	// Two prior weeks find each team's last game even when coming off a bye
+	fromWeek := week - 2
+	if fromWeek < 1 {
+		fromWeek = 1
+	}
+	games, err := repo.GetSchedule(ctx, season, fromWeek, week)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeTeamRest(games, week), nil
+}
+
+// ApplyModifiers runs each modifier in order and returns the adjustments made
+func ApplyModifiers(p *Adjustable, modifiers ...Modifier) []Adjustment {
+	var adjustments []Adjustment
+	for _, m := range modifiers {
+		if adj := m.Adjust(p); adj != nil {
+			adjustments = append(adjustments, *adj)
+		}
+	}
+	return adjustments
+}
+
+// scale applies a multiplier to every point value and returns the PPR delta
+func (p *Adjustable) scale(multiplier float64) float64 {
+	before := p.PointsPPR
+	p.PointsPPR = round2(p.PointsPPR * multiplier)
+	p.PointsStandard = round2(p.PointsStandard * multiplier)
+	p.FloorPPR = round2(p.FloorPPR * multiplier)
+	p.CeilingPPR = round2(p.CeilingPPR * multiplier)
+	return round2(p.PointsPPR - before)
+}
+
+// clampMultiplier keeps stacked penalties within a sane range
+func clampMultiplier(m float64) float64 {
+	return math.Max(0.5, math.Min(1.5, m))
+}
@@ -0,0 +1,279 @@
+package projections
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/odds"
+)
+
+// oddsBookmakerSources maps The Odds API bookmaker keys to the source names
+// the consensus tracks separately; other books keep their own key
+var oddsBookmakerSources = map[string]string{
+	"betonlineag": "betonline",
+}
+
+// propStatSpread is the standard deviation of each yardage stat as a share of
+// its line, used to turn an over/under price into a mean
+const propStatSpread = 0.35
+
+// anytimeTDOverround is the typical bookmaker margin on one-sided anytime
+// touchdown prices, removed before converting them to a probability
+const anytimeTDOverround = 1.08
+
+// kickoffWindowSlack widens a week's kickoff window so odds listed with a
+// slightly different start time still match
+const kickoffWindowSlack = 12 * time.Hour
+
+// PropStore keeps the raw prop lines behind prop-derived projections
+type PropStore interface {
+	UpsertRawProps(ctx context.Context, season, week int, props []odds.PlayerProp) error
+}
+
+// NewPostgresPropStore creates a prop store backed by the bronze raw
+// projections table
+func NewPostgresPropStore(db *sql.DB) PropStore {
+	return &PostgresRepository{db: db}
+}
+
+// OddsSource turns sportsbook player props into projected stat lines, one
+// source per bookmaker. Props only cover the stats books offer lines on, so
+// a prop projection is a partial stat line.
+type OddsSource struct {
+	client     odds.Client
+	schedule   ScheduleRepository
+	store      PropStore
+	bookmakers []string
+	markets    []string
+}
+
+// NewOddsSource creates a projection source backed by player props. Games
+// are matched to the week through the stored schedule; store may be nil.
+func NewOddsSource(client odds.Client, schedule ScheduleRepository, store PropStore, bookmakers []string) *OddsSource {
+	return &OddsSource{
+		client:     client,
+		schedule:   schedule,
+		store:      store,
+		bookmakers: bookmakers,
+		markets:    odds.DefaultMarkets,
+	}
+}
+
+// Name returns the source identifier used in errors. Each projection carries
+// its bookmaker as its source.
+func (s *OddsSource) Name() string {
+	return "odds"
+}
+
+// FetchPlayers fetches props for the week's games. Books only post props for
+// upcoming games, so run it in the days before the week kicks off.
+func (s *OddsSource) FetchPlayers(ctx context.Context, season, week int) ([]PlayerProjection, error) {
+	if week == 0 {
+		return nil, fmt.Errorf("player props are only offered for weekly projections")
+	}
+
+	games, err := s.schedule.GetSchedule(ctx, season, week, week)
+	if err != nil {
+		return nil, err
+	}
+	if len(games) == 0 {
+		return nil, fmt.Errorf("no schedule stored for %d week %d", season, week)
+	}
+	from, to := games[0].KickoffAt, games[0].KickoffAt
+	for _, g := range games {
+		if g.KickoffAt.Before(from) {
+			from = g.KickoffAt
+		}
+		if g.KickoffAt.After(to) {
+			to = g.KickoffAt
+		}
+	}
+	from, to = from.Add(-kickoffWindowSlack), to.Add(kickoffWindowSlack)
+
+	events, err := s.client.GetEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var props []odds.PlayerProp
+	for _, event := range events {
+		if event.CommenceTime.Before(from) || event.CommenceTime.After(to) {
+			continue
+		}
+		eventProps, err := s.client.GetPlayerProps(ctx, event, s.markets, s.bookmakers)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, eventProps...)
+	}
+
+	if s.store != nil {
+		if err := s.store.UpsertRawProps(ctx, season, week, props); err != nil {
+			return nil, err
+		}
+	}
+
+	return PropProjections(props, season, week), nil
+}
+
+// PropProjections converts props into one projection per player and
+// bookmaker. Over/under lines are treated as the median of a normal
+// distribution whose mean the prices shift; touchdown and interception
+// lines, mostly 0.5 or 1.5, are treated as Poisson counts.
+func PropProjections(props []odds.PlayerProp, season, week int) []PlayerProjection {
+	type key struct{ book, player string }
+	byPlayer := make(map[key]map[string]odds.PlayerProp)
+	for _, p := range props {
+		if p.PlayerName == "" || p.OverPrice == 0 {
+			continue
+		}
+		k := key{p.Bookmaker, p.PlayerName}
+		if byPlayer[k] == nil {
+			byPlayer[k] = make(map[string]odds.PlayerProp)
+		}
+		byPlayer[k][p.Market] = p
+	}
+
+	projections := make([]PlayerProjection, 0, len(byPlayer))
+	for k, markets := range byPlayer {
+		var stats StatLine
+		for market, p := range markets {
+			switch market {
+			case odds.MarketPassYards:
+				stats.PassingYards = round2(yardageMean(p))
+			case odds.MarketRushYards:
+				stats.RushingYards = round2(yardageMean(p))
+			case odds.MarketReceiveYards:
+				stats.ReceivingYards = round2(yardageMean(p))
+			case odds.MarketReceptions:
+				stats.Receptions = round2(yardageMean(p))
+			case odds.MarketPassTDs:
+				stats.PassingTDs = round2(countMean(p))
+			case odds.MarketInterceptions:
+				stats.PassingInts = round2(countMean(p))
+			}
+		}
+
+		position := propPosition(markets)
+		if td, ok := markets[odds.MarketAnytimeTD]; ok {
+			// Anytime touchdowns are scored rushing or receiving, split by
+			// where the player's yards come from
+			tds := -math.Log(1 - clampProbability(impliedProbability(td.OverPrice)/anytimeTDOverround))
+			rushShare := 1.0
+			if total := stats.RushingYards + stats.ReceivingYards; total > 0 {
+				rushShare = stats.RushingYards / total
+			} else if position == "" {
+				rushShare = 0
+			}
+			stats.RushingTDs = round2(tds * rushShare)
+			stats.ReceivingTDs = round2(tds * (1 - rushShare))
+		}
+
+		p := PlayerProjection{
+			PlayerName: k.player,
+			Position:   position,
+			Week:       week,
+			Season:     season,
+			Source:     oddsSourceName(k.book),
+			Stats:      stats,
+			HasProps:   true,
+		}
+		scorePlayerProjection(&p)
+		projections = append(projections, p)
+	}
+
+	sort.Slice(projections, func(i, j int) bool {
+		if projections[i].PlayerName != projections[j].PlayerName {
+			return projections[i].PlayerName < projections[j].PlayerName
+		}
+		return projections[i].Source < projections[j].Source
+	})
+	return projections
+}
+
+// propPosition guesses a position from the markets offered on a player:
+// passing props mean a quarterback. Other positions are left to the sources
+// that know them.
+func propPosition(markets map[string]odds.PlayerProp) string {
+	if _, ok := markets[odds.MarketPassYards]; ok {
+		return "QB"
+	}
+	if _, ok := markets[odds.MarketPassTDs]; ok {
+		return "QB"
+	}
+	return ""
+}
+
+// yardageMean is the mean of a normally distributed stat given its line and
+// the vig-free probability of the over
+func yardageMean(p odds.PlayerProp) float64 {
+	over := overProbability(p)
+	sigma := propStatSpread * p.Line
+	return math.Max(0, p.Line+sigma*math.Sqrt2*math.Erfinv(2*over-1))
+}
+
+// countMean is the Poisson mean at which the chance of beating the line
+// matches the vig-free over probability, found by bisection
+func countMean(p odds.PlayerProp) float64 {
+	over := overProbability(p)
+	need := int(math.Floor(p.Line)) + 1 // the over needs this many or more
+
+	low, high := 0.0, 10.0
+	for i := 0; i < 50; i++ {
+		mid := (low + high) / 2
+		if poissonAtLeast(mid, need) < over {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// poissonAtLeast is P(X >= k) for X ~ Poisson(lambda)
+func poissonAtLeast(lambda float64, k int) float64 {
+	below, term := 0.0, math.Exp(-lambda)
+	for i := 0; i < k; i++ {
+		below += term
+		term *= lambda / float64(i+1)
+	}
+	return 1 - below
+}
+
+// overProbability is the chance of the over with the bookmaker's margin
+// removed. One-sided props count as even money.
+func overProbability(p odds.PlayerProp) float64 {
+	if p.UnderPrice == 0 {
+		return 0.5
+	}
+	over, under := impliedProbability(p.OverPrice), impliedProbability(p.UnderPrice)
+	return clampProbability(over / (over + under))
+}
+
+// impliedProbability converts American odds to a probability
+func impliedProbability(price int) float64 {
+	if price < 0 {
+		return float64(-price) / float64(-price+100)
+	}
+	return 100 / float64(price+100)
+}
+
+func round4(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+func clampProbability(p float64) float64 {
+	return math.Min(0.99, math.Max(0.01, p))
+}
+
+func oddsSourceName(bookmaker string) string {
+	if name, ok := oddsBookmakerSources[bookmaker]; ok {
+		return name
+	}
+	return strings.ToLower(bookmaker)
+}
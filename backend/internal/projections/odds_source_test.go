@@ -0,0 +1,50 @@
+package projections
+
+import (
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/integrations/odds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropProjections(t *testing.T) {
+	props := []odds.PlayerProp{
+		{Bookmaker: "pinnacle", Market: odds.MarketPassYards, PlayerName: "Patrick Mahomes", Line: 265.5, OverPrice: -110, UnderPrice: -110},
+		{Bookmaker: "pinnacle", Market: odds.MarketPassTDs, PlayerName: "Patrick Mahomes", Line: 1.5, OverPrice: -150, UnderPrice: 125},
+		{Bookmaker: "betonlineag", Market: odds.MarketPassYards, PlayerName: "Patrick Mahomes", Line: 270.5, OverPrice: -140, UnderPrice: 110},
+		{Bookmaker: "pinnacle", Market: odds.MarketReceiveYards, PlayerName: "Travis Kelce", Line: 55.5, OverPrice: -110, UnderPrice: -110},
+		{Bookmaker: "pinnacle", Market: odds.MarketAnytimeTD, PlayerName: "Travis Kelce", OverPrice: 130},
+		// An under without an over is not usable
+		{Bookmaker: "pinnacle", Market: odds.MarketRushYards, PlayerName: "Nobody", Line: 20.5, UnderPrice: -110},
+	}
+
+	projections := PropProjections(props, 2024, 1)
+	require.Len(t, projections, 3)
+
+	// Sorted by player, then source; BetOnline is stored under its consensus name
+	mahomesBetOnline, mahomesPinnacle, kelce := projections[0], projections[1], projections[2]
+	assert.Equal(t, "betonline", mahomesBetOnline.Source)
+	assert.Equal(t, "pinnacle", mahomesPinnacle.Source)
+	assert.Equal(t, "QB", mahomesPinnacle.Position)
+	assert.True(t, mahomesPinnacle.HasProps)
+
+	// An even-money line projects the line itself; a favored over projects more
+	assert.Equal(t, 265.5, mahomesPinnacle.Stats.PassingYards)
+	assert.Greater(t, mahomesBetOnline.Stats.PassingYards, 270.5)
+	assert.Greater(t, mahomesPinnacle.Stats.PassingTDs, 1.5)
+	assert.Less(t, mahomesPinnacle.Stats.PassingTDs, 2.5)
+
+	// A pass catcher's anytime touchdown counts as receiving
+	assert.Equal(t, "Travis Kelce", kelce.PlayerName)
+	assert.Empty(t, kelce.Position)
+	assert.Zero(t, kelce.Stats.RushingTDs)
+	assert.InDelta(t, 0.52, kelce.Stats.ReceivingTDs, 0.01)
+	assert.Equal(t, round2(ScoreStatLine(kelce.Stats, 1)), kelce.PointsPPR)
+}
+
+func TestImpliedProbability(t *testing.T) {
+	assert.InDelta(t, 0.5238, impliedProbability(-110), 0.0001)
+	assert.InDelta(t, 0.4348, impliedProbability(130), 0.0001)
+	assert.InDelta(t, 0.5, overProbability(odds.PlayerProp{OverPrice: -110, UnderPrice: -110}), 1e-9)
+}
@@ -0,0 +1,471 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Pipeline stage names. Modifier stages share their modifier's name.
+const (
+	StageSourceBlend = "source_blend"
+	StageScoring     = "scoring"
+)
+
+// DefaultPipelineStages is the stage order used when a deployment does not
+// configure one
+var DefaultPipelineStages = []string{
+	StageSourceBlend,
+	StageScoring,
+	ModifierMatchup,
+	ModifierWeather,
+	ModifierInjury,
+}
+
+// StatLine is a weekly offensive stat projection
+type StatLine struct {
+	PassingYards   float64 `json:"passing_yards"`
+	PassingTDs     float64 `json:"passing_tds"`
+	PassingInts    float64 `json:"passing_ints"`
+	RushingYards   float64 `json:"rushing_yards"`
+	RushingTDs     float64 `json:"rushing_tds"`
+	ReceivingYards float64 `json:"receiving_yards"`
+	ReceivingTDs   float64 `json:"receiving_tds"`
+	Receptions     float64 `json:"receptions"`
+}
+
+// SourceProjection is a single source's projection for a player
+type SourceProjection struct {
+	Source string
+	// Stats is nil for DST and K, which sources project in points
+	Stats          *StatLine
+	PointsPPR      float64
+	PointsStandard float64
+	HasProps       bool
+}
+
+// PlayerState carries one player's projection through the pipeline
+type PlayerState struct {
+	PlayerID   string
+	PlayerName string
+	Position   string
+	Team       string
+	Season     int
+	Week       int
+	Sources    []SourceProjection
+
+	// Set by source blending
+	Stats           *StatLine
+	BlendedPPR      float64
+	BlendedStandard float64
+	StdDev          float64
+
+	// Projection is the current point projection, updated by each stage
+	Projection  Adjustable
+	Adjustments []Adjustment
+}
+
+// SourcePoints returns each source's PPR projection keyed by source name
+func (p *PlayerState) SourcePoints() map[string]float64 {
+	points := make(map[string]float64, len(p.Sources))
+	for _, s := range p.Sources {
+		points[s.Source] = s.PointsPPR
+	}
+	return points
+}
+
+// HasProps reports whether any source was derived from prop lines
+func (p *PlayerState) HasProps() bool {
+	for _, s := range p.Sources {
+		if s.HasProps {
+			return true
+		}
+	}
+	return false
+}
+
+// StageOutput records a player's projection after a pipeline stage
+type StageOutput struct {
+	Stage          string                 `json:"stage"`
+	Order          int                    `json:"order"`
+	PointsPPR      float64                `json:"points_ppr"`
+	PointsStandard float64                `json:"points_standard"`
+	Details        map[string]interface{} `json:"details,omitempty"`
+	Adjustment     *Adjustment            `json:"adjustment,omitempty"`
+}
+
+// Stage is one step of the projection pipeline
+type Stage interface {
+	Name() string
+	// Prepare loads any week-level data the stage needs before players run
+	Prepare(ctx context.Context, season, week int) error
+	// Process updates the player in place and returns the stage's details
+	Process(p *PlayerState) (map[string]interface{}, *Adjustment)
+}
+
+// PipelineConfig selects and orders the stages a deployment runs
+type PipelineConfig struct {
+	Stages        []string
+	SourceWeights map[string]float64
+	RestEnabled   bool
+}
+
+// PipelineResult summarizes a pipeline run
+type PipelineResult struct {
+	Season  int      `json:"season"`
+	Week    int      `json:"week"`
+	Players int      `json:"players"`
+	Stages  []string `json:"stages"`
+}
+
+// Pipeline computes consensus projections from source projections through an
+// ordered list of stages, persisting each stage's output
+type Pipeline struct {
+	repo   Repository
+	stages []Stage
+}
+
+// NewPipeline creates a pipeline from explicit stages
+func NewPipeline(repo Repository, stages ...Stage) *Pipeline {
+	return &Pipeline{repo: repo, stages: stages}
+}
+
+// BuildPipeline creates a pipeline from configured stage names. Source
+// blending and scoring must come first since later stages adjust points.
+// The rest modifier runs after weather when enabled and not listed.
+func BuildPipeline(repo Repository, cfg PipelineConfig) (*Pipeline, error) {
+	names := cfg.Stages
+	if len(names) == 0 {
+		names = DefaultPipelineStages
+	}
+	if cfg.RestEnabled && !containsStage(names, ModifierRest) {
+		names = insertAfter(names, ModifierWeather, ModifierRest)
+	}
+
+	if len(names) < 2 || names[0] != StageSourceBlend || names[1] != StageScoring {
+		return nil, fmt.Errorf("pipeline must start with %s and %s", StageSourceBlend, StageScoring)
+	}
+
+	stages := make([]Stage, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate pipeline stage %q", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case StageSourceBlend:
+			stages = append(stages, NewSourceBlendStage(cfg.SourceWeights))
+		case StageScoring:
+			stages = append(stages, NewScoringStage())
+		case ModifierMatchup, ModifierWeather, ModifierRest, ModifierInjury:
+			stages = append(stages, NewModifierStage(repo, name))
+		default:
+			return nil, fmt.Errorf("unknown pipeline stage %q", name)
+		}
+	}
+
+	return NewPipeline(repo, stages...), nil
+}
+
+// StageNames returns the configured stage order
+func (p *Pipeline) StageNames() []string {
+	names := make([]string, len(p.stages))
+	for i, s := range p.stages {
+		names[i] = s.Name()
+	}
+	return names
+}
+
+// Run computes and stores projections for every player with a source
+// projection in the given week
+func (p *Pipeline) Run(ctx context.Context, season, week int) (*PipelineResult, error) {
+	players, err := p.repo.GetSourceProjections(ctx, season, week)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range p.stages {
+		if err := s.Prepare(ctx, season, week); err != nil {
+			return nil, fmt.Errorf("failed to prepare %s stage: %w", s.Name(), err)
+		}
+	}
+
+	outputs := make(map[string][]StageOutput, len(players))
+	for _, player := range players {
+		outputs[player.PlayerName] = p.Process(player)
+	}
+
+	if err := p.repo.SavePipelineRun(ctx, season, week, players, outputs); err != nil {
+		return nil, err
+	}
+
+	return &PipelineResult{
+		Season:  season,
+		Week:    week,
+		Players: len(players),
+		Stages:  p.StageNames(),
+	}, nil
+}
+
+// Process runs a single player through every stage. Stages must already be
+// prepared.
+func (p *Pipeline) Process(player *PlayerState) []StageOutput {
+	outputs := make([]StageOutput, 0, len(p.stages))
+	for i, s := range p.stages {
+		details, adj := s.Process(player)
+		if adj != nil {
+			player.Adjustments = append(player.Adjustments, *adj)
+		}
+		outputs = append(outputs, StageOutput{
+			Stage:          s.Name(),
+			Order:          i + 1,
+			PointsPPR:      player.Projection.PointsPPR,
+			PointsStandard: player.Projection.PointsStandard,
+			Details:        details,
+			Adjustment:     adj,
+		})
+	}
+	return outputs
+}
+
+// SourceBlendStage averages source projections using per-source weights
+type SourceBlendStage struct {
+	weights map[string]float64
+}
+
+// NewSourceBlendStage creates a blend stage. Sources without a weight count as 1.
+func NewSourceBlendStage(weights map[string]float64) *SourceBlendStage {
+	return &SourceBlendStage{weights: weights}
+}
+
+// Name returns the stage identifier
+func (s *SourceBlendStage) Name() string {
+	return StageSourceBlend
+}
+
+// Prepare is a no-op; blending only uses the player's own sources
+func (s *SourceBlendStage) Prepare(ctx context.Context, season, week int) error {
+	return nil
+}
+
+func (s *SourceBlendStage) weight(source string) float64 {
+	if w, ok := s.weights[source]; ok {
+		return w
+	}
+	return 1
+}
+
+// Process blends stat lines and point projections across sources
+func (s *SourceBlendStage) Process(p *PlayerState) (map[string]interface{}, *Adjustment) {
+	weights := make(map[string]float64, len(p.Sources))
+	var total, statTotal, ppr, standard float64
+	var stats StatLine
+
+	for _, src := range p.Sources {
+		w := s.weight(src.Source)
+		if w <= 0 {
+			continue
+		}
+		weights[src.Source] = w
+		total += w
+		ppr += w * src.PointsPPR
+		standard += w * src.PointsStandard
+
+		if src.Stats != nil {
+			statTotal += w
+			stats.add(*src.Stats, w)
+		}
+	}
+
+	if total > 0 {
+		p.BlendedPPR = round2(ppr / total)
+		p.BlendedStandard = round2(standard / total)
+	}
+	if statTotal > 0 {
+		stats.scale(1 / statTotal)
+		p.Stats = &stats
+	}
+	p.StdDev = round2(sourceStdDev(p.Sources))
+
+	p.Projection = Adjustable{
+		PlayerName:     p.PlayerName,
+		Position:       p.Position,
+		Team:           p.Team,
+		PointsPPR:      p.BlendedPPR,
+		PointsStandard: p.BlendedStandard,
+	}
+
+	return map[string]interface{}{
+		"sources": p.SourcePoints(),
+		"weights": weights,
+	}, nil
+}
+
+// ScoringStage converts blended stat lines into fantasy points and sets the
+// floor and ceiling
+type ScoringStage struct{}
+
+// NewScoringStage creates a scoring conversion stage
+func NewScoringStage() *ScoringStage {
+	return &ScoringStage{}
+}
+
+// Name returns the stage identifier
+func (s *ScoringStage) Name() string {
+	return StageScoring
+}
+
+// Prepare is a no-op; scoring rules are fixed
+func (s *ScoringStage) Prepare(ctx context.Context, season, week int) error {
+	return nil
+}
+
+// floorCeilingSpread is the minimum floor/ceiling distance as a share of the
+// projection, used when sources agree too closely to give a real range
+const floorCeilingSpread = 0.25
+
+// Process scores the blended stats. DST and K, which have no stat line, keep
+// their blended points.
+func (s *ScoringStage) Process(p *PlayerState) (map[string]interface{}, *Adjustment) {
+	details := map[string]interface{}{"scored_from": "source_points"}
+
+	if p.Stats != nil {
+		p.Projection.PointsPPR = round2(ScoreStatLine(*p.Stats, 1))
+		p.Projection.PointsStandard = round2(ScoreStatLine(*p.Stats, 0))
+		details["scored_from"] = "stats"
+		details["points_half_ppr"] = round2(ScoreStatLine(*p.Stats, 0.5))
+		details["stats"] = *p.Stats
+	}
+
+	spread := math.Max(p.StdDev, p.Projection.PointsPPR*floorCeilingSpread)
+	p.Projection.FloorPPR = round2(math.Max(0, p.Projection.PointsPPR-spread))
+	p.Projection.CeilingPPR = round2(p.Projection.PointsPPR + spread)
+
+	return details, nil
+}
+
+// ScoreStatLine returns fantasy points for a stat line using standard
+// scoring plus pointsPerReception
+func ScoreStatLine(s StatLine, pointsPerReception float64) float64 {
+	return s.PassingYards/25 + s.PassingTDs*4 - s.PassingInts*2 +
+		s.RushingYards/10 + s.RushingTDs*6 +
+		s.ReceivingYards/10 + s.ReceivingTDs*6 +
+		s.Receptions*pointsPerReception
+}
+
+// ModifierStage runs a Modifier as a pipeline stage, rebuilding it for each week
+type ModifierStage struct {
+	repo     Repository
+	name     string
+	modifier Modifier
+}
+
+// NewModifierStage creates a stage for a named modifier
+func NewModifierStage(repo Repository, name string) *ModifierStage {
+	return &ModifierStage{repo: repo, name: name}
+}
+
+// Name returns the stage identifier
+func (s *ModifierStage) Name() string {
+	return s.name
+}
+
+// Prepare loads the modifier's game context for the week
+func (s *ModifierStage) Prepare(ctx context.Context, season, week int) error {
+	m, err := BuildModifier(ctx, s.repo, s.name, season, week)
+	if err != nil {
+		return err
+	}
+	s.modifier = m
+	return nil
+}
+
+// Process applies the modifier to the current projection
+func (s *ModifierStage) Process(p *PlayerState) (map[string]interface{}, *Adjustment) {
+	if s.modifier == nil {
+		return nil, nil
+	}
+	return nil, s.modifier.Adjust(&p.Projection)
+}
+
+func (s *StatLine) add(o StatLine, w float64) {
+	s.PassingYards += o.PassingYards * w
+	s.PassingTDs += o.PassingTDs * w
+	s.PassingInts += o.PassingInts * w
+	s.RushingYards += o.RushingYards * w
+	s.RushingTDs += o.RushingTDs * w
+	s.ReceivingYards += o.ReceivingYards * w
+	s.ReceivingTDs += o.ReceivingTDs * w
+	s.Receptions += o.Receptions * w
+}
+
+func (s *StatLine) scale(f float64) {
+	s.PassingYards = round2(s.PassingYards * f)
+	s.PassingTDs = round2(s.PassingTDs * f)
+	s.PassingInts = round2(s.PassingInts * f)
+	s.RushingYards = round2(s.RushingYards * f)
+	s.RushingTDs = round2(s.RushingTDs * f)
+	s.ReceivingYards = round2(s.ReceivingYards * f)
+	s.ReceivingTDs = round2(s.ReceivingTDs * f)
+	s.Receptions = round2(s.Receptions * f)
+}
+
+// sourceStdDev is the sample standard deviation of source PPR projections
+func sourceStdDev(sources []SourceProjection) float64 {
+	if len(sources) < 2 {
+		return 0
+	}
+	var sum float64
+	for _, s := range sources {
+		sum += s.PointsPPR
+	}
+	mean := sum / float64(len(sources))
+
+	var sq float64
+	for _, s := range sources {
+		sq += (s.PointsPPR - mean) * (s.PointsPPR - mean)
+	}
+	return math.Sqrt(sq / float64(len(sources)-1))
+}
+
+// ConfidenceRating grades a consensus by how many sources contributed
+func ConfidenceRating(numSources int) string {
+	switch {
+	case numSources >= 3:
+		return "HIGH"
+	case numSources == 2:
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
+
+func containsStage(names []string, name string) bool {
+	for _, n := range names {
+		if strings.TrimSpace(n) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// insertAfter inserts name after anchor, or appends it if anchor is absent
+func insertAfter(names []string, anchor, name string) []string {
+	out := make([]string, 0, len(names)+1)
+	inserted := false
+	for _, n := range names {
+		out = append(out, n)
+		if strings.TrimSpace(n) == anchor {
+			out = append(out, name)
+			inserted = true
+		}
+	}
+	if !inserted {
+		out = append(out, name)
+	}
+	return out
+}
@@ -0,0 +1,219 @@
+package projections
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pipelineRepo serves fixed week data to the pipeline and captures the run
+type pipelineRepo struct {
+	Repository
+	players []*PlayerState
+	weather []GameWeather
+	games   []ScheduledGame
+	allowed []PointsAllowed
+	injured []InjuryStatus
+
+	saved     []*PlayerState
+	outputs   map[string][]StageOutput
+	projected []PlayerProjection
+}
+
+func (r *pipelineRepo) GetSourceProjections(ctx context.Context, season, week int) ([]*PlayerState, error) {
+	return r.players, nil
+}
+
+func (r *pipelineRepo) GetGameWeather(ctx context.Context, season, week int) ([]GameWeather, error) {
+	return r.weather, nil
+}
+
+func (r *pipelineRepo) GetSchedule(ctx context.Context, season, fromWeek, toWeek int) ([]ScheduledGame, error) {
+	return r.games, nil
+}
+
+func (r *pipelineRepo) GetGameContext(ctx context.Context, season, fromWeek, toWeek int) ([]GameContext, error) {
+	return nil, nil
+}
+
+func (r *pipelineRepo) GetPointsAllowed(ctx context.Context, season, beforeWeek int) ([]PointsAllowed, error) {
+	return r.allowed, nil
+}
+
+func (r *pipelineRepo) GetInjuryStatuses(ctx context.Context, season, week int) ([]InjuryStatus, error) {
+	return r.injured, nil
+}
+
+func (r *pipelineRepo) UpsertPlayerProjections(ctx context.Context, projections []PlayerProjection) error {
+	r.projected = append(r.projected, projections...)
+	return nil
+}
+
+func (r *pipelineRepo) RefreshConsensus(ctx context.Context, season, week int) error {
+	return nil
+}
+
+func (r *pipelineRepo) SavePipelineRun(ctx context.Context, season, week int, players []*PlayerState, outputs map[string][]StageOutput) error {
+	r.saved = players
+	r.outputs = outputs
+	return nil
+}
+
+func TestBuildPipeline(t *testing.T) {
+	repo := &pipelineRepo{}
+
+	p, err := BuildPipeline(repo, PipelineConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPipelineStages, p.StageNames())
+
+	p, err = BuildPipeline(repo, PipelineConfig{RestEnabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"source_blend", "scoring", "matchup", "weather", "rest", "injury"}, p.StageNames())
+
+	_, err = BuildPipeline(repo, PipelineConfig{Stages: []string{"scoring", "source_blend"}})
+	assert.Error(t, err)
+
+	_, err = BuildPipeline(repo, PipelineConfig{Stages: []string{"source_blend", "scoring", "vibes"}})
+	assert.Error(t, err)
+}
+
+func TestPipelineRun(t *testing.T) {
+	allen := &PlayerState{
+		PlayerName: "Josh Allen",
+		Position:   "QB",
+		Team:       "BUF",
+		Sources: []SourceProjection{
+			{Source: "fantasypros", Stats: &StatLine{PassingYards: 250, PassingTDs: 2, RushingYards: 30}, PointsPPR: 21},
+			{Source: "espn", Stats: &StatLine{PassingYards: 300, PassingTDs: 2, RushingYards: 30}, PointsPPR: 23},
+		},
+	}
+	kelce := &PlayerState{
+		PlayerName: "Travis Kelce",
+		Position:   "TE",
+		Team:       "KC",
+		Sources: []SourceProjection{
+			{Source: "fantasypros", Stats: &StatLine{ReceivingYards: 70, Receptions: 6}, PointsPPR: 13},
+		},
+	}
+	dst := &PlayerState{
+		PlayerName: "BUF D/ST",
+		Position:   "DST",
+		Team:       "BUF",
+		Sources: []SourceProjection{
+			{Source: "fantasypros", PointsPPR: 8, PointsStandard: 8},
+		},
+	}
+
+	repo := &pipelineRepo{
+		players: []*PlayerState{allen, kelce, dst},
+		weather: []GameWeather{{HomeTeam: "BUF", AwayTeam: "MIA", WindMPH: 22}},
+		injured: []InjuryStatus{{PlayerName: "Travis Kelce", Status: "Questionable"}},
+	}
+
+	p, err := BuildPipeline(repo, PipelineConfig{SourceWeights: map[string]float64{"espn": 0}})
+	require.NoError(t, err)
+
+	result, err := p.Run(context.Background(), 2024, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Players)
+	require.Len(t, repo.saved, 3)
+
+	// ESPN is weighted out, so only the fantasypros stat line is scored:
+	// 250/25 + 2*4 + 30/10 = 21, then severe wind cuts a QB by 12%
+	outputs := repo.outputs["Josh Allen"]
+	require.Len(t, outputs, 5)
+	assert.Equal(t, StageScoring, outputs[1].Stage)
+	assert.InDelta(t, 21.0, outputs[1].PointsPPR, 0.001)
+	assert.Equal(t, ModifierWeather, outputs[3].Stage)
+	require.NotNil(t, outputs[3].Adjustment)
+	assert.InDelta(t, 18.48, allen.Projection.PointsPPR, 0.001)
+
+	// 70/10 + 6 receptions = 13 PPR, discounted for Questionable
+	assert.InDelta(t, 13.0, repo.outputs["Travis Kelce"][1].PointsPPR, 0.001)
+	assert.InDelta(t, 11.05, kelce.Projection.PointsPPR, 0.001)
+	require.Len(t, kelce.Adjustments, 1)
+	assert.Equal(t, ModifierInjury, kelce.Adjustments[0].Modifier)
+
+	// DST keeps its source points and is not affected by wind
+	assert.InDelta(t, 8.0, dst.Projection.PointsPPR, 0.001)
+	assert.Empty(t, dst.Adjustments)
+}
+
+func TestMatchupModifier(t *testing.T) {
+	games := []ScheduledGame{{HomeTeam: "KC", AwayTeam: "DEN"}}
+	allowed := []PointsAllowed{
+		{Defense: "DEN", Position: "WR", AvgPoints: 50, Games: 4},
+		{Defense: "KC", Position: "WR", AvgPoints: 30, Games: 4},
+	}
+	modifier := NewMatchupModifier(games, allowed)
+
+	// League average is 40; DEN allows 1.25x, shrunk halfway to 1.125
+	p := &Adjustable{Position: "WR", Team: "KC", PointsPPR: 20}
+	adj := modifier.Adjust(p)
+	require.NotNil(t, adj)
+	assert.InDelta(t, 22.5, p.PointsPPR, 0.01)
+	assert.Contains(t, adj.Reason, "DEN allows")
+
+	p = &Adjustable{Position: "WR", Team: "DEN", PointsPPR: 20}
+	require.NotNil(t, modifier.Adjust(p))
+	assert.InDelta(t, 17.5, p.PointsPPR, 0.01)
+
+	// No rating for the position
+	p = &Adjustable{Position: "RB", Team: "KC", PointsPPR: 15}
+	assert.Nil(t, modifier.Adjust(p))
+}
+
+func TestExplain(t *testing.T) {
+	outputs := []StageOutput{
+		{Stage: StageSourceBlend, Order: 1, PointsPPR: 12.5, Details: map[string]interface{}{
+			"sources": map[string]interface{}{"fantasypros": 12.0, "espn": 13.0},
+		}},
+		{Stage: StageScoring, Order: 2, PointsPPR: 13, PointsStandard: 7},
+		{Stage: ModifierWeather, Order: 3, PointsPPR: 13, PointsStandard: 7},
+		{Stage: ModifierInjury, Order: 4, PointsPPR: 11.05, PointsStandard: 5.95, Adjustment: &Adjustment{
+			Modifier: ModifierInjury, Multiplier: 0.85, PointsPPR: -1.95, Reason: "listed as QUESTIONABLE",
+		}},
+	}
+
+	e := Explain(outputs)
+
+	assert.Equal(t, 11.05, e.FinalPPR)
+	assert.Equal(t, 5.95, e.FinalStandard)
+	require.Len(t, e.Stages, 4)
+	assert.Equal(t, 0.5, e.Stages[1].DeltaPPR)
+	assert.Equal(t, 0.0, e.Stages[2].DeltaPPR)
+	assert.Equal(t, -1.95, e.Stages[3].DeltaPPR)
+	assert.Equal(t, "blended 2 sources to 12.50; scored at 13.00 PPR; injury -1.95 (listed as QUESTIONABLE)", e.Summary)
+}
+
+func TestIngestESPNProjections(t *testing.T) {
+	client := espn.NewMockESPNClient()
+	client.Projections = []espn.Player{
+		{ID: "3918298", Name: "Josh Allen", Position: "QB", Team: "BUF", Projections: espn.PlayerStats{
+			Stats: map[string]float64{espn.StatPassingYards: 250, espn.StatPassingTDs: 2, espn.StatInterceptions: 1, espn.StatRushingYards: 30},
+		}},
+		{ID: "15847", Name: "Travis Kelce", Position: "TE", Team: "KC", Projections: espn.PlayerStats{
+			Stats: map[string]float64{espn.StatReceivingYards: 70, espn.StatReceptions: 6},
+		}},
+	}
+
+	repo := &pipelineRepo{}
+	result, err := NewIngester(repo).WithPlayerSources(NewESPNSource(client)).Ingest(context.Background(), 2024, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.PlayerCount)
+
+	require.Len(t, repo.projected, 2)
+	allen := repo.projected[0]
+	assert.Equal(t, SourceESPN, allen.Source)
+	assert.Equal(t, 5, allen.Week)
+	// 250/25 + 2*4 - 2 + 30/10
+	assert.InDelta(t, 19.0, allen.PointsPPR, 0.001)
+
+	kelce := repo.projected[1]
+	assert.InDelta(t, 13.0, kelce.PointsPPR, 0.001)
+	assert.InDelta(t, 10.0, kelce.PointsHalfPPR, 0.001)
+	assert.InDelta(t, 7.0, kelce.PointsStandard, 0.001)
+}
@@ -0,0 +1,1204 @@
+package projections
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/integrations/odds"
+)
+
+// Repository defines the interface for projection storage
+type Repository interface {
+	UpsertDSTProjections(ctx context.Context, projections []DSTProjection) error
+	UpsertKickerProjections(ctx context.Context, projections []KickerProjection) error
+	UpsertPlayerProjections(ctx context.Context, projections []PlayerProjection) error
+	RefreshConsensus(ctx context.Context, season, week int) error
+	UpsertGameWeather(ctx context.Context, games []GameWeather) error
+	GetGameWeather(ctx context.Context, season, week int) ([]GameWeather, error)
+	UpsertSchedule(ctx context.Context, games []ScheduledGame) error
+	GetSchedule(ctx context.Context, season, fromWeek, toWeek int) ([]ScheduledGame, error)
+	UpsertGameContext(ctx context.Context, contexts []GameContext) error
+	GetGameContext(ctx context.Context, season, fromWeek, toWeek int) ([]GameContext, error)
+	GetConsensusProjections(ctx context.Context, season, week int) ([]ConsensusProjection, error)
+	UpsertWeeklyActuals(ctx context.Context, actuals []WeeklyActual) error
+	GetWeeklyActuals(ctx context.Context, season, week int) (map[string]WeeklyActual, error)
+	SaveBacktestResult(ctx context.Context, result *BacktestResult) error
+	GetBacktestResults(ctx context.Context, modifier string, limit int) ([]BacktestResult, error)
+	GetSourceProjections(ctx context.Context, season, week int) ([]*PlayerState, error)
+	SavePipelineRun(ctx context.Context, season, week int, players []*PlayerState, outputs map[string][]StageOutput) error
+	GetStageOutputs(ctx context.Context, season, week int, playerNames []string) (map[string][]StageOutput, error)
+	GetStageProjections(ctx context.Context, season, week int, stage string) ([]ConsensusProjection, error)
+	GetPointsAllowed(ctx context.Context, season, beforeWeek int) ([]PointsAllowed, error)
+	GetInjuryStatuses(ctx context.Context, season, week int) ([]InjuryStatus, error)
+	GetProjectionSnapshots(ctx context.Context, season, week int, asOf time.Time) ([]ProjectionSnapshot, error)
+}
+
+// PostgresRepository implements Repository for PostgreSQL
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new PostgreSQL projections repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// UpsertDSTProjections stores team defense projections in the silver layer
+func (r *PostgresRepository) UpsertDSTProjections(ctx context.Context, projections []DSTProjection) error {
+	if len(projections) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO silver.dst_projections (
+			team, opponent, week, season, source,
+			proj_points_allowed, proj_yards_allowed, proj_sacks, proj_interceptions,
+			proj_fumble_recoveries, proj_safeties, proj_defensive_tds, proj_return_tds,
+			fantasy_points, processed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW())
+		ON CONFLICT (team, season, week, source) DO UPDATE SET
+			opponent = EXCLUDED.opponent,
+			proj_points_allowed = EXCLUDED.proj_points_allowed,
+			proj_yards_allowed = EXCLUDED.proj_yards_allowed,
+			proj_sacks = EXCLUDED.proj_sacks,
+			proj_interceptions = EXCLUDED.proj_interceptions,
+			proj_fumble_recoveries = EXCLUDED.proj_fumble_recoveries,
+			proj_safeties = EXCLUDED.proj_safeties,
+			proj_defensive_tds = EXCLUDED.proj_defensive_tds,
+			proj_return_tds = EXCLUDED.proj_return_tds,
+			fantasy_points = EXCLUDED.fantasy_points,
+			processed_at = NOW()
+	`
+
+	for _, p := range projections {
+		_, err := tx.ExecContext(ctx, query,
+			p.Team,
+			p.Opponent,
+			p.Week,
+			p.Season,
+			p.Source,
+			p.PointsAllowed,
+			p.YardsAllowed,
+			p.Sacks,
+			p.Interceptions,
+			p.FumbleRecoveries,
+			p.Safeties,
+			p.DefensiveTDs,
+			p.ReturnTDs,
+			p.FantasyPoints,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert DST projection for %s: %w", p.Team, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit DST projections: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertKickerProjections stores kicker projections in the silver layer
+func (r *PostgresRepository) UpsertKickerProjections(ctx context.Context, projections []KickerProjection) error {
+	if len(projections) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO silver.kicker_projections (
+			player_id, player_name, team, opponent, week, season, source,
+			proj_fg_attempts, proj_fg_made_0_39, proj_fg_made_40_49, proj_fg_made_50_plus,
+			proj_fg_missed, proj_xp_attempts, proj_xp_made, fantasy_points, processed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NOW())
+		ON CONFLICT (player_name, season, week, source) DO UPDATE SET
+			player_id = EXCLUDED.player_id,
+			team = EXCLUDED.team,
+			opponent = EXCLUDED.opponent,
+			proj_fg_attempts = EXCLUDED.proj_fg_attempts,
+			proj_fg_made_0_39 = EXCLUDED.proj_fg_made_0_39,
+			proj_fg_made_40_49 = EXCLUDED.proj_fg_made_40_49,
+			proj_fg_made_50_plus = EXCLUDED.proj_fg_made_50_plus,
+			proj_fg_missed = EXCLUDED.proj_fg_missed,
+			proj_xp_attempts = EXCLUDED.proj_xp_attempts,
+			proj_xp_made = EXCLUDED.proj_xp_made,
+			fantasy_points = EXCLUDED.fantasy_points,
+			processed_at = NOW()
+	`
+
+	for _, p := range projections {
+		_, err := tx.ExecContext(ctx, query,
+			sql.NullString{String: p.PlayerID, Valid: p.PlayerID != ""},
+			p.PlayerName,
+			p.Team,
+			p.Opponent,
+			p.Week,
+			p.Season,
+			p.Source,
+			p.FGAttempts,
+			p.FGMade0To39,
+			p.FGMade40To49,
+			p.FGMade50Plus,
+			p.FGMissed,
+			p.XPAttempts,
+			p.XPMade,
+			p.FantasyPoints,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert kicker projection for %s: %w", p.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit kicker projections: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertPlayerProjections stores offensive player projections in the silver layer
+func (r *PostgresRepository) UpsertPlayerProjections(ctx context.Context, projections []PlayerProjection) error {
+	if len(projections) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO silver.player_projections (
+			player_id, player_name, position, team, week, season, source,
+			passing_yards, passing_tds, passing_ints, rushing_yards, rushing_tds,
+			receiving_yards, receiving_tds, receptions,
+			fantasy_points_ppr, fantasy_points_standard, fantasy_points_half_ppr,
+			has_props, processed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NOW())
+		ON CONFLICT (player_name, season, week, source) DO UPDATE SET
+			player_id = EXCLUDED.player_id,
+			position = EXCLUDED.position,
+			team = EXCLUDED.team,
+			passing_yards = EXCLUDED.passing_yards,
+			passing_tds = EXCLUDED.passing_tds,
+			passing_ints = EXCLUDED.passing_ints,
+			rushing_yards = EXCLUDED.rushing_yards,
+			rushing_tds = EXCLUDED.rushing_tds,
+			receiving_yards = EXCLUDED.receiving_yards,
+			receiving_tds = EXCLUDED.receiving_tds,
+			receptions = EXCLUDED.receptions,
+			fantasy_points_ppr = EXCLUDED.fantasy_points_ppr,
+			fantasy_points_standard = EXCLUDED.fantasy_points_standard,
+			fantasy_points_half_ppr = EXCLUDED.fantasy_points_half_ppr,
+			has_props = EXCLUDED.has_props,
+			processed_at = NOW()
+	`
+
+	for _, p := range projections {
+		_, err := tx.ExecContext(ctx, query,
+			p.PlayerID,
+			p.PlayerName,
+			p.Position,
+			p.Team,
+			p.Week,
+			p.Season,
+			p.Source,
+			p.Stats.PassingYards,
+			p.Stats.PassingTDs,
+			p.Stats.PassingInts,
+			p.Stats.RushingYards,
+			p.Stats.RushingTDs,
+			p.Stats.ReceivingYards,
+			p.Stats.ReceivingTDs,
+			p.Stats.Receptions,
+			p.PointsPPR,
+			p.PointsStandard,
+			p.PointsHalfPPR,
+			p.HasProps,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert projection for %s: %w", p.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit player projections: %w", err)
+	}
+
+	return nil
+}
+
+// RefreshConsensus rebuilds DST and K rows in gold.consensus_projections for a week
+// by averaging every source in the silver layer
+func (r *PostgresRepository) RefreshConsensus(ctx context.Context, season, week int) error {
+	query := `
+		INSERT INTO gold.consensus_projections (
+			player_id, player_name, position, team, week, season,
+			consensus_points_ppr, consensus_points_standard,
+			floor_points_ppr, ceiling_points_ppr,
+			fantasypros_proj, espn_proj,
+			num_sources, projection_std_dev, confidence_rating, has_props, calculated_at
+		)
+		SELECT
+			MAX(player_id), player_name, position, MAX(team), week, season,
+			AVG(fantasy_points), AVG(fantasy_points),
+			MIN(fantasy_points), MAX(fantasy_points),
+			AVG(fantasy_points) FILTER (WHERE source = 'fantasypros'),
+			AVG(fantasy_points) FILTER (WHERE source = 'espn'),
+			COUNT(*),
+			COALESCE(STDDEV_SAMP(fantasy_points), 0),
+			CASE
+				WHEN COUNT(*) >= 3 THEN 'HIGH'
+				WHEN COUNT(*) = 2 THEN 'MEDIUM'
+				ELSE 'LOW'
+			END,
+			FALSE,
+			NOW()
+		FROM (
+			SELECT NULL::VARCHAR AS player_id, team || ' D/ST' AS player_name, 'DST' AS position,
+				team, week, season, source, fantasy_points
+			FROM silver.dst_projections
+			WHERE season = $1 AND week = $2
+			UNION ALL
+			SELECT player_id, player_name, 'K',
+				team, week, season, source, fantasy_points
+			FROM silver.kicker_projections
+			WHERE season = $1 AND week = $2
+		) sources
+		GROUP BY player_name, position, week, season
+		ON CONFLICT (player_name, season, week) DO UPDATE SET
+			player_id = EXCLUDED.player_id,
+			position = EXCLUDED.position,
+			team = EXCLUDED.team,
+			consensus_points_ppr = EXCLUDED.consensus_points_ppr,
+			consensus_points_standard = EXCLUDED.consensus_points_standard,
+			floor_points_ppr = EXCLUDED.floor_points_ppr,
+			ceiling_points_ppr = EXCLUDED.ceiling_points_ppr,
+			fantasypros_proj = EXCLUDED.fantasypros_proj,
+			espn_proj = EXCLUDED.espn_proj,
+			num_sources = EXCLUDED.num_sources,
+			projection_std_dev = EXCLUDED.projection_std_dev,
+			confidence_rating = EXCLUDED.confidence_rating,
+			calculated_at = NOW()
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, season, week); err != nil {
+		return fmt.Errorf("failed to refresh DST/K consensus: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertGameWeather stores game forecasts in the silver layer
+func (r *PostgresRepository) UpsertGameWeather(ctx context.Context, games []GameWeather) error {
+	if len(games) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO silver.game_weather (
+			season, week, home_team, away_team, temperature_f, wind_mph,
+			precipitation_chance, is_dome, source, stadium, kickoff_at, fetched_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, NOW())
+		ON CONFLICT (season, week, home_team) DO UPDATE SET
+			away_team = EXCLUDED.away_team,
+			temperature_f = EXCLUDED.temperature_f,
+			wind_mph = EXCLUDED.wind_mph,
+			precipitation_chance = EXCLUDED.precipitation_chance,
+			is_dome = EXCLUDED.is_dome,
+			source = EXCLUDED.source,
+			stadium = EXCLUDED.stadium,
+			kickoff_at = EXCLUDED.kickoff_at,
+			fetched_at = NOW()
+	`
+
+	for _, g := range games {
+		_, err := tx.ExecContext(ctx, query,
+			g.Season,
+			g.Week,
+			g.HomeTeam,
+			g.AwayTeam,
+			g.TemperatureF,
+			g.WindMPH,
+			g.PrecipitationChance,
+			g.IsDome,
+			g.Source,
+			g.Stadium,
+			g.KickoffAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert weather for %s: %w", g.HomeTeam, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit game weather: %w", err)
+	}
+
+	return nil
+}
+
+// GetGameWeather retrieves the forecasts for every game in a week
+func (r *PostgresRepository) GetGameWeather(ctx context.Context, season, week int) ([]GameWeather, error) {
+	return r.GetWeatherForWeeks(ctx, season, week, week)
+}
+
+// GetWeatherForWeeks retrieves the forecasts for every game in a range of
+// weeks
+func (r *PostgresRepository) GetWeatherForWeeks(ctx context.Context, season, fromWeek, toWeek int) ([]GameWeather, error) {
+	query := `
+		SELECT season, week, home_team, away_team,
+			COALESCE(temperature_f, 0), COALESCE(wind_mph, 0),
+			COALESCE(precipitation_chance, 0), COALESCE(is_dome, FALSE), source,
+			COALESCE(stadium, ''), kickoff_at
+		FROM silver.game_weather
+		WHERE season = $1 AND week BETWEEN $2 AND $3
+		ORDER BY week, home_team
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, fromWeek, toWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query game weather: %w", err)
+	}
+	defer rows.Close()
+
+	var games []GameWeather
+	for rows.Next() {
+		var g GameWeather
+		var kickoff sql.NullTime
+		if err := rows.Scan(
+			&g.Season,
+			&g.Week,
+			&g.HomeTeam,
+			&g.AwayTeam,
+			&g.TemperatureF,
+			&g.WindMPH,
+			&g.PrecipitationChance,
+			&g.IsDome,
+			&g.Source,
+			&g.Stadium,
+			&kickoff,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan game weather: %w", err)
+		}
+		if kickoff.Valid {
+			g.KickoffAt = &kickoff.Time
+		}
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// UpsertSchedule stores NFL schedule entries in the silver layer
+func (r *PostgresRepository) UpsertSchedule(ctx context.Context, games []ScheduledGame) error {
+	if len(games) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO silver.nfl_schedule (season, week, home_team, away_team, kickoff_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (season, week, home_team) DO UPDATE SET
+			away_team = EXCLUDED.away_team,
+			kickoff_at = EXCLUDED.kickoff_at
+	`
+
+	for _, g := range games {
+		if _, err := tx.ExecContext(ctx, query, g.Season, g.Week, g.HomeTeam, g.AwayTeam, g.KickoffAt); err != nil {
+			return fmt.Errorf("failed to upsert schedule for %s: %w", g.HomeTeam, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetSchedule retrieves the games between two weeks, inclusive
+func (r *PostgresRepository) GetSchedule(ctx context.Context, season, fromWeek, toWeek int) ([]ScheduledGame, error) {
+	query := `
+		SELECT season, week, home_team, away_team, kickoff_at
+		FROM silver.nfl_schedule
+		WHERE season = $1 AND week BETWEEN $2 AND $3
+		ORDER BY kickoff_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, fromWeek, toWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schedule: %w", err)
+	}
+	defer rows.Close()
+
+	var games []ScheduledGame
+	for rows.Next() {
+		var g ScheduledGame
+		if err := rows.Scan(&g.Season, &g.Week, &g.HomeTeam, &g.AwayTeam, &g.KickoffAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schedule: %w", err)
+		}
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// GetConsensusProjections retrieves every consensus projection for a week
+func (r *PostgresRepository) GetConsensusProjections(ctx context.Context, season, week int) ([]ConsensusProjection, error) {
+	query := `
+		SELECT player_name, position, COALESCE(team, ''), season, week,
+			COALESCE(consensus_points_ppr, 0), COALESCE(consensus_points_standard, 0),
+			COALESCE(floor_points_ppr, 0), COALESCE(ceiling_points_ppr, 0)
+		FROM gold.consensus_projections
+		WHERE season = $1 AND week = $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query consensus projections: %w", err)
+	}
+	defer rows.Close()
+
+	var projections []ConsensusProjection
+	for rows.Next() {
+		var p ConsensusProjection
+		if err := rows.Scan(
+			&p.PlayerName,
+			&p.Position,
+			&p.Team,
+			&p.Season,
+			&p.Week,
+			&p.PointsPPR,
+			&p.PointsStandard,
+			&p.FloorPPR,
+			&p.CeilingPPR,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan consensus projection: %w", err)
+		}
+		projections = append(projections, p)
+	}
+
+	return projections, rows.Err()
+}
+
+// UpsertWeeklyActuals stores actual fantasy points in the gold layer
+func (r *PostgresRepository) UpsertWeeklyActuals(ctx context.Context, actuals []WeeklyActual) error {
+	if len(actuals) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO gold.player_weekly_actuals (
+			player_name, position, team, season, week,
+			fantasy_points_ppr, fantasy_points_standard, loaded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (player_name, season, week) DO UPDATE SET
+			position = EXCLUDED.position,
+			team = EXCLUDED.team,
+			fantasy_points_ppr = EXCLUDED.fantasy_points_ppr,
+			fantasy_points_standard = EXCLUDED.fantasy_points_standard,
+			loaded_at = NOW()
+	`
+
+	for _, a := range actuals {
+		_, err := tx.ExecContext(ctx, query,
+			a.PlayerName,
+			a.Position,
+			a.Team,
+			a.Season,
+			a.Week,
+			a.PointsPPR,
+			a.PointsStandard,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert actuals for %s: %w", a.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit weekly actuals: %w", err)
+	}
+
+	return nil
+}
+
+// GetWeeklyActuals retrieves actual fantasy points for a week keyed by player name
+func (r *PostgresRepository) GetWeeklyActuals(ctx context.Context, season, week int) (map[string]WeeklyActual, error) {
+	query := `
+		SELECT player_name, COALESCE(position, ''), COALESCE(team, ''), season, week,
+			COALESCE(fantasy_points_ppr, 0), COALESCE(fantasy_points_standard, 0)
+		FROM gold.player_weekly_actuals
+		WHERE season = $1 AND week = $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weekly actuals: %w", err)
+	}
+	defer rows.Close()
+
+	actuals := make(map[string]WeeklyActual)
+	for rows.Next() {
+		var a WeeklyActual
+		if err := rows.Scan(&a.PlayerName, &a.Position, &a.Team, &a.Season, &a.Week, &a.PointsPPR, &a.PointsStandard); err != nil {
+			return nil, fmt.Errorf("failed to scan weekly actual: %w", err)
+		}
+		actuals[a.PlayerName] = a
+	}
+
+	return actuals, rows.Err()
+}
+
+// SaveBacktestResult records a modifier backtest run
+func (r *PostgresRepository) SaveBacktestResult(ctx context.Context, result *BacktestResult) error {
+	query := `
+		INSERT INTO gold.modifier_backtests (
+			modifier, season, week_start, week_end, adjusted_count, base_mae, adjusted_mae, run_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		result.Modifier,
+		result.Season,
+		result.WeekStart,
+		result.WeekEnd,
+		result.AdjustedCount,
+		result.BaseMAE,
+		result.AdjustedMAE,
+		result.RunAt,
+	).Scan(&result.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save backtest result: %w", err)
+	}
+
+	return nil
+}
+
+// GetBacktestResults retrieves the most recent backtest runs for a modifier
+func (r *PostgresRepository) GetBacktestResults(ctx context.Context, modifier string, limit int) ([]BacktestResult, error) {
+	query := `
+		SELECT id, modifier, season, week_start, week_end, adjusted_count,
+			COALESCE(base_mae, 0), COALESCE(adjusted_mae, 0), run_at
+		FROM gold.modifier_backtests
+		WHERE modifier = $1
+		ORDER BY run_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, modifier, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query backtest results: %w", err)
+	}
+	defer rows.Close()
+
+	var results []BacktestResult
+	for rows.Next() {
+		var b BacktestResult
+		if err := rows.Scan(
+			&b.ID,
+			&b.Modifier,
+			&b.Season,
+			&b.WeekStart,
+			&b.WeekEnd,
+			&b.AdjustedCount,
+			&b.BaseMAE,
+			&b.AdjustedMAE,
+			&b.RunAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan backtest result: %w", err)
+		}
+		results = append(results, b)
+	}
+
+	return results, rows.Err()
+}
+
+// UpsertRawProps stores sportsbook prop lines in the bronze layer, one row
+// per bookmaker, player and market
+func (r *PostgresRepository) UpsertRawProps(ctx context.Context, season, week int, props []odds.PlayerProp) error {
+	if len(props) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO bronze.raw_projections (
+			source, season, week, player_name, prop_type, prop_line,
+			over_price, under_price, implied_over, implied_under,
+			game_id, game_date, timestamp, ingested_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, 0), $9, $10, $11, $12, NOW(), NOW())
+		ON CONFLICT (source, season, week, player_name, prop_type) DO UPDATE SET
+			prop_line = EXCLUDED.prop_line,
+			over_price = EXCLUDED.over_price,
+			under_price = EXCLUDED.under_price,
+			implied_over = EXCLUDED.implied_over,
+			implied_under = EXCLUDED.implied_under,
+			game_id = EXCLUDED.game_id,
+			game_date = EXCLUDED.game_date,
+			timestamp = NOW(),
+			ingested_at = NOW()
+	`
+
+	for _, p := range props {
+		if p.OverPrice == 0 {
+			continue
+		}
+		var impliedUnder interface{}
+		if p.UnderPrice != 0 {
+			impliedUnder = round4(impliedProbability(p.UnderPrice))
+		}
+		_, err := tx.ExecContext(ctx, query,
+			oddsSourceName(p.Bookmaker),
+			season,
+			week,
+			p.PlayerName,
+			p.Market,
+			p.Line,
+			p.OverPrice,
+			p.UnderPrice,
+			round4(impliedProbability(p.OverPrice)),
+			impliedUnder,
+			p.EventID,
+			p.GameTime,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert %s prop for %s: %w", p.Market, p.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit props: %w", err)
+	}
+
+	return nil
+}
+
+// GetSourceProjections loads every source projection for a week, grouped by
+// player. Offensive players carry stat lines; DST and K carry points only.
+func (r *PostgresRepository) GetSourceProjections(ctx context.Context, season, week int) ([]*PlayerState, error) {
+	query := `
+		SELECT COALESCE(player_id, ''), player_name, COALESCE(position, ''), COALESCE(team, ''), source,
+			TRUE AS has_stats,
+			COALESCE(passing_yards, 0), COALESCE(passing_tds, 0), COALESCE(passing_ints, 0),
+			COALESCE(rushing_yards, 0), COALESCE(rushing_tds, 0),
+			COALESCE(receiving_yards, 0), COALESCE(receiving_tds, 0), COALESCE(receptions, 0),
+			COALESCE(fantasy_points_ppr, 0), COALESCE(fantasy_points_standard, 0),
+			COALESCE(has_props, FALSE)
+		FROM silver.player_projections
+		WHERE season = $1 AND week = $2
+		UNION ALL
+		SELECT '', team || ' D/ST', 'DST', team, source,
+			FALSE, 0, 0, 0, 0, 0, 0, 0, 0,
+			COALESCE(fantasy_points, 0), COALESCE(fantasy_points, 0), FALSE
+		FROM silver.dst_projections
+		WHERE season = $1 AND week = $2
+		UNION ALL
+		SELECT COALESCE(player_id, ''), player_name, 'K', COALESCE(team, ''), source,
+			FALSE, 0, 0, 0, 0, 0, 0, 0, 0,
+			COALESCE(fantasy_points, 0), COALESCE(fantasy_points, 0), FALSE
+		FROM silver.kicker_projections
+		WHERE season = $1 AND week = $2
+		ORDER BY 2, 5
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query source projections: %w", err)
+	}
+	defer rows.Close()
+
+	var players []*PlayerState
+	byName := make(map[string]*PlayerState)
+	for rows.Next() {
+		var (
+			playerID, name, position, team string
+			hasStats                       bool
+			stats                          StatLine
+			src                            SourceProjection
+		)
+		if err := rows.Scan(
+			&playerID,
+			&name,
+			&position,
+			&team,
+			&src.Source,
+			&hasStats,
+			&stats.PassingYards,
+			&stats.PassingTDs,
+			&stats.PassingInts,
+			&stats.RushingYards,
+			&stats.RushingTDs,
+			&stats.ReceivingYards,
+			&stats.ReceivingTDs,
+			&stats.Receptions,
+			&src.PointsPPR,
+			&src.PointsStandard,
+			&src.HasProps,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan source projection: %w", err)
+		}
+		if hasStats {
+			src.Stats = &stats
+		}
+
+		player, ok := byName[name]
+		if !ok {
+			player = &PlayerState{
+				PlayerID:   playerID,
+				PlayerName: name,
+				Position:   position,
+				Team:       team,
+				Season:     season,
+				Week:       week,
+			}
+			byName[name] = player
+			players = append(players, player)
+		}
+		// Prop-derived rows carry no ID, position or team
+		if player.PlayerID == "" {
+			player.PlayerID = playerID
+		}
+		if player.Position == "" {
+			player.Position = position
+		}
+		if player.Team == "" {
+			player.Team = team
+		}
+		player.Sources = append(player.Sources, src)
+	}
+
+	return players, rows.Err()
+}
+
+// SavePipelineRun stores final projections in gold.consensus_projections,
+// appends them to the projection history and replaces the week's stage outputs
+func (r *PostgresRepository) SavePipelineRun(ctx context.Context, season, week int, players []*PlayerState, outputs map[string][]StageOutput) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	consensusQuery := `
+		INSERT INTO gold.consensus_projections (
+			player_id, player_name, position, team, week, season,
+			consensus_points_ppr, consensus_points_standard,
+			floor_points_ppr, ceiling_points_ppr,
+			betonline_proj, pinnacle_proj, fantasypros_proj, espn_proj,
+			proj_passing_yards, proj_passing_tds, proj_rushing_yards, proj_rushing_tds,
+			proj_receiving_yards, proj_receiving_tds, proj_receptions,
+			num_sources, projection_std_dev, confidence_rating, has_props, calculated_at
+		) VALUES (
+			NULLIF($1, ''), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
+			$15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, NOW()
+		)
+		ON CONFLICT (player_name, season, week) DO UPDATE SET
+			player_id = EXCLUDED.player_id,
+			position = EXCLUDED.position,
+			team = EXCLUDED.team,
+			consensus_points_ppr = EXCLUDED.consensus_points_ppr,
+			consensus_points_standard = EXCLUDED.consensus_points_standard,
+			floor_points_ppr = EXCLUDED.floor_points_ppr,
+			ceiling_points_ppr = EXCLUDED.ceiling_points_ppr,
+			betonline_proj = EXCLUDED.betonline_proj,
+			pinnacle_proj = EXCLUDED.pinnacle_proj,
+			fantasypros_proj = EXCLUDED.fantasypros_proj,
+			espn_proj = EXCLUDED.espn_proj,
+			proj_passing_yards = EXCLUDED.proj_passing_yards,
+			proj_passing_tds = EXCLUDED.proj_passing_tds,
+			proj_rushing_yards = EXCLUDED.proj_rushing_yards,
+			proj_rushing_tds = EXCLUDED.proj_rushing_tds,
+			proj_receiving_yards = EXCLUDED.proj_receiving_yards,
+			proj_receiving_tds = EXCLUDED.proj_receiving_tds,
+			proj_receptions = EXCLUDED.proj_receptions,
+			num_sources = EXCLUDED.num_sources,
+			projection_std_dev = EXCLUDED.projection_std_dev,
+			confidence_rating = EXCLUDED.confidence_rating,
+			has_props = EXCLUDED.has_props,
+			calculated_at = NOW()
+	`
+
+	for _, p := range players {
+		sources := p.SourcePoints()
+		var stats [7]interface{}
+		if p.Stats != nil {
+			stats = [7]interface{}{
+				p.Stats.PassingYards, p.Stats.PassingTDs, p.Stats.RushingYards, p.Stats.RushingTDs,
+				p.Stats.ReceivingYards, p.Stats.ReceivingTDs, p.Stats.Receptions,
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, consensusQuery,
+			p.PlayerID,
+			p.PlayerName,
+			p.Position,
+			p.Team,
+			week,
+			season,
+			p.Projection.PointsPPR,
+			p.Projection.PointsStandard,
+			p.Projection.FloorPPR,
+			p.Projection.CeilingPPR,
+			sourcePoints(sources, "betonline"),
+			sourcePoints(sources, "pinnacle"),
+			sourcePoints(sources, "fantasypros"),
+			sourcePoints(sources, "espn"),
+			stats[0], stats[1], stats[2], stats[3], stats[4], stats[5], stats[6],
+			len(p.Sources),
+			p.StdDev,
+			ConfidenceRating(len(p.Sources)),
+			p.HasProps(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert consensus projection for %s: %w", p.PlayerName, err)
+		}
+	}
+
+	historyQuery := `
+		INSERT INTO gold.projection_history (
+			player_name, position, team, week, season,
+			points_ppr, points_standard, num_sources, source_points, adjustments, recorded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+	`
+
+	for _, p := range players {
+		sources, err := json.Marshal(p.SourcePoints())
+		if err != nil {
+			return fmt.Errorf("failed to encode source points for %s: %w", p.PlayerName, err)
+		}
+		adjustments, err := json.Marshal(p.Adjustments)
+		if err != nil {
+			return fmt.Errorf("failed to encode adjustments for %s: %w", p.PlayerName, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, historyQuery,
+			p.PlayerName,
+			p.Position,
+			p.Team,
+			week,
+			season,
+			p.Projection.PointsPPR,
+			p.Projection.PointsStandard,
+			len(p.Sources),
+			sources,
+			adjustments,
+		); err != nil {
+			return fmt.Errorf("failed to record projection history for %s: %w", p.PlayerName, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM gold.projection_stage_outputs WHERE season = $1 AND week = $2`,
+		season, week,
+	); err != nil {
+		return fmt.Errorf("failed to clear stage outputs: %w", err)
+	}
+
+	stageQuery := `
+		INSERT INTO gold.projection_stage_outputs (
+			player_name, season, week, stage, stage_order,
+			points_ppr, points_standard, details, adjustment, computed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+	`
+
+	for playerName, stages := range outputs {
+		for _, o := range stages {
+			details, err := marshalNullable(o.Details)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s details for %s: %w", o.Stage, playerName, err)
+			}
+			adjustment, err := marshalNullable(o.Adjustment)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s adjustment for %s: %w", o.Stage, playerName, err)
+			}
+
+			if _, err := tx.ExecContext(ctx, stageQuery,
+				playerName,
+				season,
+				week,
+				o.Stage,
+				o.Order,
+				o.PointsPPR,
+				o.PointsStandard,
+				details,
+				adjustment,
+			); err != nil {
+				return fmt.Errorf("failed to insert %s stage output for %s: %w", o.Stage, playerName, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit pipeline run: %w", err)
+	}
+
+	return nil
+}
+
+// GetStageOutputs retrieves the pipeline stage outputs for the given players,
+// in stage order, keyed by player name
+func (r *PostgresRepository) GetStageOutputs(ctx context.Context, season, week int, playerNames []string) (map[string][]StageOutput, error) {
+	outputs := make(map[string][]StageOutput)
+	if len(playerNames) == 0 {
+		return outputs, nil
+	}
+
+	query := `
+		SELECT player_name, stage, stage_order,
+			COALESCE(points_ppr, 0), COALESCE(points_standard, 0), details, adjustment
+		FROM gold.projection_stage_outputs
+		WHERE season = $1 AND week = $2 AND player_name = ANY($3)
+		ORDER BY player_name, stage_order
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, week, pq.Array(playerNames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stage outputs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			playerName          string
+			o                   StageOutput
+			details, adjustment []byte
+		)
+		if err := rows.Scan(&playerName, &o.Stage, &o.Order, &o.PointsPPR, &o.PointsStandard, &details, &adjustment); err != nil {
+			return nil, fmt.Errorf("failed to scan stage output: %w", err)
+		}
+		if len(details) > 0 {
+			if err := json.Unmarshal(details, &o.Details); err != nil {
+				return nil, fmt.Errorf("failed to decode stage details: %w", err)
+			}
+		}
+		if len(adjustment) > 0 {
+			o.Adjustment = &Adjustment{}
+			if err := json.Unmarshal(adjustment, o.Adjustment); err != nil {
+				return nil, fmt.Errorf("failed to decode stage adjustment: %w", err)
+			}
+		}
+		outputs[playerName] = append(outputs[playerName], o)
+	}
+
+	return outputs, rows.Err()
+}
+
+// GetStageProjections retrieves every player's projection as it stood after
+// one pipeline stage
+func (r *PostgresRepository) GetStageProjections(ctx context.Context, season, week int, stage string) ([]ConsensusProjection, error) {
+	query := `
+		SELECT s.player_name, c.position, COALESCE(c.team, ''), s.season, s.week,
+			COALESCE(s.points_ppr, 0), COALESCE(s.points_standard, 0)
+		FROM gold.projection_stage_outputs s
+		JOIN gold.consensus_projections c
+			ON c.player_name = s.player_name AND c.season = s.season AND c.week = s.week
+		WHERE s.season = $1 AND s.week = $2 AND s.stage = $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, week, stage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s stage projections: %w", stage, err)
+	}
+	defer rows.Close()
+
+	var projections []ConsensusProjection
+	for rows.Next() {
+		var p ConsensusProjection
+		if err := rows.Scan(&p.PlayerName, &p.Position, &p.Team, &p.Season, &p.Week, &p.PointsPPR, &p.PointsStandard); err != nil {
+			return nil, fmt.Errorf("failed to scan stage projection: %w", err)
+		}
+		projections = append(projections, p)
+	}
+
+	return projections, rows.Err()
+}
+
+// GetPointsAllowed retrieves each defense's average PPR points allowed per
+// game by position, over the season's weeks before beforeWeek
+func (r *PostgresRepository) GetPointsAllowed(ctx context.Context, season, beforeWeek int) ([]PointsAllowed, error) {
+	query := `
+		WITH games AS (
+			SELECT season, week, home_team AS team, away_team AS opponent
+			FROM silver.nfl_schedule
+			WHERE season = $1 AND week < $2
+			UNION ALL
+			SELECT season, week, away_team, home_team
+			FROM silver.nfl_schedule
+			WHERE season = $1 AND week < $2
+		), allowed AS (
+			SELECT g.opponent AS defense, a.position, g.week, SUM(a.fantasy_points_ppr) AS points
+			FROM gold.player_weekly_actuals a
+			JOIN games g ON g.team = a.team AND g.season = a.season AND g.week = a.week
+			WHERE a.position IS NOT NULL
+			GROUP BY g.opponent, a.position, g.week
+		)
+		SELECT defense, position, AVG(points), COUNT(*)
+		FROM allowed
+		GROUP BY defense, position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, beforeWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query points allowed: %w", err)
+	}
+	defer rows.Close()
+
+	var allowed []PointsAllowed
+	for rows.Next() {
+		var a PointsAllowed
+		if err := rows.Scan(&a.Defense, &a.Position, &a.AvgPoints, &a.Games); err != nil {
+			return nil, fmt.Errorf("failed to scan points allowed: %w", err)
+		}
+		allowed = append(allowed, a)
+	}
+
+	return allowed, rows.Err()
+}
+
+// GetInjuryStatuses retrieves the injury designations for a week
+func (r *PostgresRepository) GetInjuryStatuses(ctx context.Context, season, week int) ([]InjuryStatus, error) {
+	query := `
+		SELECT player_name, COALESCE(team, ''), season, week, status, source, updated_at
+		FROM silver.player_injury_status
+		WHERE season = $1 AND week = $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query injury statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []InjuryStatus
+	for rows.Next() {
+		var s InjuryStatus
+		if err := rows.Scan(&s.PlayerName, &s.Team, &s.Season, &s.Week, &s.Status, &s.Source, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan injury status: %w", err)
+		}
+		statuses = append(statuses, s)
+	}
+
+	return statuses, rows.Err()
+}
+
+// GetProjectionSnapshots retrieves each player's most recent projection
+// recorded at or before asOf
+func (r *PostgresRepository) GetProjectionSnapshots(ctx context.Context, season, week int, asOf time.Time) ([]ProjectionSnapshot, error) {
+	query := `
+		SELECT DISTINCT ON (player_name)
+			player_name, COALESCE(position, ''), COALESCE(team, ''),
+			COALESCE(points_ppr, 0), COALESCE(points_standard, 0), COALESCE(num_sources, 0),
+			source_points, adjustments, recorded_at
+		FROM gold.projection_history
+		WHERE season = $1 AND week = $2 AND recorded_at <= $3
+		ORDER BY player_name, recorded_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, week, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projection history: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []ProjectionSnapshot
+	for rows.Next() {
+		s := ProjectionSnapshot{Season: season, Week: week}
+		var sources, adjustments []byte
+		if err := rows.Scan(
+			&s.PlayerName,
+			&s.Position,
+			&s.Team,
+			&s.PointsPPR,
+			&s.PointsStandard,
+			&s.NumSources,
+			&sources,
+			&adjustments,
+			&s.RecordedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan projection history: %w", err)
+		}
+		if len(sources) > 0 {
+			if err := json.Unmarshal(sources, &s.SourcePoints); err != nil {
+				return nil, fmt.Errorf("failed to decode source points for %s: %w", s.PlayerName, err)
+			}
+		}
+		if len(adjustments) > 0 {
+			if err := json.Unmarshal(adjustments, &s.Adjustments); err != nil {
+				return nil, fmt.Errorf("failed to decode adjustments for %s: %w", s.PlayerName, err)
+			}
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	return snapshots, rows.Err()
+}
+
+// sourcePoints returns a source's projection, or nil if it has none
+func sourcePoints(sources map[string]float64, source string) interface{} {
+	if points, ok := sources[source]; ok {
+		return points
+	}
+	return nil
+}
+
+// marshalNullable encodes v as JSON, or returns nil for empty values so the
+// column stays NULL
+func marshalNullable(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 {
+			return nil, nil
+		}
+	case *Adjustment:
+		if t == nil {
+			return nil, nil
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
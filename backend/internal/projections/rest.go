@@ -0,0 +1,152 @@
+package projections
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ScheduledGame is a single game on the NFL schedule
+type ScheduledGame struct {
+	Season    int       `json:"season"`
+	Week      int       `json:"week"`
+	HomeTeam  string    `json:"home_team"`
+	AwayTeam  string    `json:"away_team"`
+	KickoffAt time.Time `json:"kickoff_at"`
+}
+
+// TeamRest is a team's rest and travel going into a game
+type TeamRest struct {
+	Team             string  `json:"team"`
+	Opponent         string  `json:"opponent"`
+	RestDays         int     `json:"rest_days"`
+	OpponentRestDays int     `json:"opponent_rest_days"`
+	TravelMiles      float64 `json:"travel_miles"`
+	TimezoneShift    int     `json:"timezone_shift"`
+}
+
+// ShortWeek reports whether the team plays on four or fewer days of rest
+func (r TeamRest) ShortWeek() bool {
+	return r.RestDays > 0 && r.RestDays <= shortWeekDays
+}
+
+// Differential is the team's rest advantage over its opponent in days
+func (r TeamRest) Differential() int {
+	if r.RestDays == 0 || r.OpponentRestDays == 0 {
+		return 0
+	}
+	return r.RestDays - r.OpponentRestDays
+}
+
+const (
+	// shortWeekDays covers Thursday games after a Sunday game
+	shortWeekDays = 4
+	// restEdgeDays is the differential treated as a meaningful advantage
+	restEdgeDays = 3
+
+	// crossCountryZones is the time zone shift treated as a coast-to-coast
+	// trip
+	crossCountryZones = 3
+
+	shortWeekMultiplier     = 0.97
+	restAdvantageMultiplier = 1.02
+	restDeficitMultiplier   = 0.98
+	crossCountryMultiplier  = 0.98
+)
+
+// ComputeTeamRest calculates rest for every team playing in the given week.
+// games must include the previous week's games; a team's first game of the
+// season has unknown rest (0).
+func ComputeTeamRest(games []ScheduledGame, week int) map[string]TeamRest {
+	return TeamRestFromContext(ComputeGameContext(games), week)
+}
+
+// TeamRestFromContext takes each team's rest and travel in the given week
+// from its game-context features
+func TeamRestFromContext(contexts []GameContext, week int) map[string]TeamRest {
+	rest := make(map[string]TeamRest)
+	for _, c := range contexts {
+		if c.Week != week {
+			continue
+		}
+		rest[c.Team] = TeamRest{
+			Team:             c.Team,
+			Opponent:         c.Opponent,
+			RestDays:         c.RestDays,
+			OpponentRestDays: c.OpponentRestDays,
+			TravelMiles:      c.TravelMiles,
+			TimezoneShift:    c.TimezoneShift,
+		}
+	}
+	return rest
+}
+
+func daysBetween(from, to time.Time) int {
+	if from.IsZero() {
+		return 0
+	}
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+// RestModifier adjusts projections for short weeks, rest differentials and
+// coast-to-coast trips
+type RestModifier struct {
+	byTeam map[string]TeamRest
+}
+
+// NewRestModifier creates a rest modifier for one week of games
+func NewRestModifier(rest map[string]TeamRest) *RestModifier {
+	return &RestModifier{byTeam: rest}
+}
+
+// Name returns the modifier identifier
+func (m *RestModifier) Name() string {
+	return ModifierRest
+}
+
+// Adjust applies short-week, rest-differential and travel multipliers
+func (m *RestModifier) Adjust(p *Adjustable) *Adjustment {
+	rest, ok := m.byTeam[strings.ToUpper(p.Team)]
+	if !ok {
+		return nil
+	}
+
+	multiplier := 1.0
+	var reasons []string
+
+	if rest.ShortWeek() {
+		multiplier *= shortWeekMultiplier
+		reasons = append(reasons, fmt.Sprintf("short week (%d days rest)", rest.RestDays))
+	}
+
+	switch diff := rest.Differential(); {
+	case diff >= restEdgeDays:
+		multiplier *= restAdvantageMultiplier
+		reasons = append(reasons, fmt.Sprintf("%d more days rest than %s", diff, rest.Opponent))
+	case diff <= -restEdgeDays:
+		multiplier *= restDeficitMultiplier
+		reasons = append(reasons, fmt.Sprintf("%d fewer days rest than %s", -diff, rest.Opponent))
+	}
+
+	zones := rest.TimezoneShift
+	if zones < 0 {
+		zones = -zones
+	}
+	if zones >= crossCountryZones {
+		multiplier *= crossCountryMultiplier
+		reasons = append(reasons, fmt.Sprintf("traveled %.0f miles across %d time zones", rest.TravelMiles, zones))
+	}
+
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	multiplier = clampMultiplier(multiplier)
+	return &Adjustment{
+		Modifier:   m.Name(),
+		Multiplier: round2(multiplier),
+		PointsPPR:  p.scale(multiplier),
+		Reason:     strings.Join(reasons, ", "),
+	}
+}
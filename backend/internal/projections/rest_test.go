@@ -0,0 +1,91 @@
+package projections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kickoff returns a September 2024 kickoff in UTC; Sunday the 8th is week 1
+func kickoff(day int, hour int) time.Time {
+	return time.Date(2024, time.September, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestComputeTeamRest(t *testing.T) {
+	games := []ScheduledGame{
+		// Week 1
+		{Week: 1, HomeTeam: "KC", AwayTeam: "BAL", KickoffAt: kickoff(6, 0)},
+		{Week: 1, HomeTeam: "BUF", AwayTeam: "ARI", KickoffAt: kickoff(8, 17)},
+		{Week: 1, HomeTeam: "MIA", AwayTeam: "JAX", KickoffAt: kickoff(8, 17)},
+		// Week 2: Thursday game after a Sunday game
+		{Week: 2, HomeTeam: "MIA", AwayTeam: "BUF", KickoffAt: kickoff(13, 0)},
+		// Week 2: Sunday game, KC coming off a Thursday opener
+		{Week: 2, HomeTeam: "KC", AwayTeam: "JAX", KickoffAt: kickoff(15, 17)},
+	}
+
+	rest := ComputeTeamRest(games, 2)
+
+	assert.Equal(t, 4, rest["MIA"].RestDays)
+	assert.True(t, rest["MIA"].ShortWeek())
+	assert.Equal(t, "BUF", rest["MIA"].Opponent)
+
+	assert.Equal(t, 10, rest["KC"].RestDays)
+	assert.Equal(t, 7, rest["JAX"].RestDays)
+	assert.Equal(t, 3, rest["KC"].Differential())
+	assert.Equal(t, -3, rest["JAX"].Differential())
+
+	// Teams not playing in the week are absent
+	_, ok := rest["ARI"]
+	assert.False(t, ok)
+}
+
+func TestRestModifier(t *testing.T) {
+	modifier := NewRestModifier(map[string]TeamRest{
+		"MIA": {Team: "MIA", Opponent: "BUF", RestDays: 4, OpponentRestDays: 4},
+		"KC":  {Team: "KC", Opponent: "JAX", RestDays: 11, OpponentRestDays: 7},
+		"JAX": {Team: "JAX", Opponent: "KC", RestDays: 7, OpponentRestDays: 11},
+		"SF":  {Team: "SF", Opponent: "LAR", RestDays: 7, OpponentRestDays: 7},
+	})
+
+	p := &Adjustable{Position: "WR", Team: "MIA", PointsPPR: 20}
+	adj := modifier.Adjust(p)
+	require.NotNil(t, adj)
+	assert.InDelta(t, 19.4, p.PointsPPR, 0.001)
+	assert.Contains(t, adj.Reason, "short week")
+
+	p = &Adjustable{Position: "RB", Team: "KC", PointsPPR: 10}
+	require.NotNil(t, modifier.Adjust(p))
+	assert.InDelta(t, 10.2, p.PointsPPR, 0.001)
+
+	p = &Adjustable{Position: "RB", Team: "JAX", PointsPPR: 10}
+	require.NotNil(t, modifier.Adjust(p))
+	assert.InDelta(t, 9.8, p.PointsPPR, 0.001)
+
+	p = &Adjustable{Position: "QB", Team: "SF", PointsPPR: 18}
+	assert.Nil(t, modifier.Adjust(p))
+}
+
+func TestScoreModifier(t *testing.T) {
+	modifier := NewRestModifier(map[string]TeamRest{
+		"MIA": {Team: "MIA", Opponent: "BUF", RestDays: 4, OpponentRestDays: 4},
+	})
+
+	consensus := []ConsensusProjection{
+		{PlayerName: "Tyreek Hill", Position: "WR", Team: "MIA", PointsPPR: 20},
+		{PlayerName: "Josh Allen", Position: "QB", Team: "BUF", PointsPPR: 24},
+		{PlayerName: "No Actual", Position: "RB", Team: "MIA", PointsPPR: 12},
+	}
+	actuals := map[string]WeeklyActual{
+		"Tyreek Hill": {PlayerName: "Tyreek Hill", PointsPPR: 15},
+		"Josh Allen":  {PlayerName: "Josh Allen", PointsPPR: 30},
+	}
+
+	baseErr, adjustedErr, count := scoreModifier(modifier, consensus, actuals)
+
+	// Only the adjusted player with an actual counts
+	assert.Equal(t, 1, count)
+	assert.InDelta(t, 5.0, baseErr, 0.001)
+	assert.InDelta(t, 4.4, adjustedErr, 0.001)
+}
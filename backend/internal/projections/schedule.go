@@ -0,0 +1,187 @@
+package projections
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NFLVerseScheduleURL is nflverse's game list, every season in one CSV
+const NFLVerseScheduleURL = "https://github.com/nflverse/nfldata/raw/master/data/games.csv"
+
+// nflverseTeams maps nflverse abbreviations to the ESPN ones used by
+// rosters and player data
+var nflverseTeams = map[string]string{
+	"LA":  "LAR",
+	"WAS": "WSH",
+}
+
+// ScheduleRepository reads the NFL schedule for draft recommendations,
+// lineup decisions and bye-week planning
+type ScheduleRepository interface {
+	GetSchedule(ctx context.Context, season, fromWeek, toWeek int) ([]ScheduledGame, error)
+	GetTeamSchedule(ctx context.Context, season int, team string) ([]ScheduledGame, error)
+	GetByeWeeks(ctx context.Context, season int) (map[string]int, error)
+	GetWeatherForWeeks(ctx context.Context, season, fromWeek, toWeek int) ([]GameWeather, error)
+}
+
+// NewPostgresScheduleRepository creates a schedule repository backed by the
+// silver schedule table
+func NewPostgresScheduleRepository(db *sql.DB) ScheduleRepository {
+	return &PostgresRepository{db: db}
+}
+
+// CurrentSeason is the NFL season under way at now. Seasons end in
+// February, so January and February belong to the previous year's season.
+func CurrentSeason(now time.Time) int {
+	if now.Month() < time.March {
+		return now.Year() - 1
+	}
+	return now.Year()
+}
+
+// FetchNFLVerseSchedule downloads nflverse's game list and returns the
+// regular season games of one season. Kickoff times are published in US
+// Eastern time.
+func FetchNFLVerseSchedule(ctx context.Context, client *http.Client, url string, season int) ([]ScheduledGame, error) {
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Eastern time zone: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download schedule: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download schedule: status %d", resp.StatusCode)
+	}
+
+	rows, err := readCSV(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var games []ScheduledGame
+	for i, row := range rows {
+		if row.str("season") != strconv.Itoa(season) || row.str("game_type") != "REG" {
+			continue
+		}
+
+		week, err := strconv.Atoi(row.str("week"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid week %q", i+2, row.str("week"))
+		}
+		kickoff, err := time.ParseInLocation("2006-01-02 15:04", row.str("gameday")+" "+row.str("gametime"), eastern)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid kickoff %q %q", i+2, row.str("gameday"), row.str("gametime"))
+		}
+
+		games = append(games, ScheduledGame{
+			Season:    season,
+			Week:      week,
+			HomeTeam:  NFLVerseTeam(row.str("home_team")),
+			AwayTeam:  NFLVerseTeam(row.str("away_team")),
+			KickoffAt: kickoff.UTC(),
+		})
+	}
+
+	return games, nil
+}
+
+// NFLVerseTeam converts an nflverse team abbreviation to ESPN's
+func NFLVerseTeam(abbreviation string) string {
+	abbreviation = strings.ToUpper(abbreviation)
+	if team, ok := nflverseTeams[abbreviation]; ok {
+		return team
+	}
+	return abbreviation
+}
+
+// ByeWeeks finds each team's bye from a regular season schedule: the week,
+// within the weeks that have games, in which the team does not play. Teams
+// with no week off, or more than one, are left out.
+func ByeWeeks(games []ScheduledGame) map[string]int {
+	weeks := make(map[int]bool)
+	played := make(map[string]map[int]bool)
+	for _, g := range games {
+		weeks[g.Week] = true
+		for _, team := range []string{g.HomeTeam, g.AwayTeam} {
+			if played[team] == nil {
+				played[team] = make(map[int]bool)
+			}
+			played[team][g.Week] = true
+		}
+	}
+
+	allWeeks := make([]int, 0, len(weeks))
+	for week := range weeks {
+		allWeeks = append(allWeeks, week)
+	}
+	sort.Ints(allWeeks)
+
+	byes := make(map[string]int, len(played))
+	for team, teamWeeks := range played {
+		var off []int
+		for _, week := range allWeeks {
+			if !teamWeeks[week] {
+				off = append(off, week)
+			}
+		}
+		if len(off) == 1 {
+			byes[team] = off[0]
+		}
+	}
+
+	return byes
+}
+
+// GetTeamSchedule retrieves one team's games for a season in kickoff order
+func (r *PostgresRepository) GetTeamSchedule(ctx context.Context, season int, team string) ([]ScheduledGame, error) {
+	query := `
+		SELECT season, week, home_team, away_team, kickoff_at
+		FROM silver.nfl_schedule
+		WHERE season = $1 AND (home_team = $2 OR away_team = $2)
+		ORDER BY kickoff_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, season, strings.ToUpper(team))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team schedule: %w", err)
+	}
+	defer rows.Close()
+
+	var games []ScheduledGame
+	for rows.Next() {
+		var g ScheduledGame
+		if err := rows.Scan(&g.Season, &g.Week, &g.HomeTeam, &g.AwayTeam, &g.KickoffAt); err != nil {
+			return nil, fmt.Errorf("failed to scan team schedule: %w", err)
+		}
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// GetByeWeeks returns each team's bye week for a season, derived from the
+// stored schedule
+func (r *PostgresRepository) GetByeWeeks(ctx context.Context, season int) (map[string]int, error) {
+	games, err := r.GetSchedule(ctx, season, 1, maxRegularSeasonWeek)
+	if err != nil {
+		return nil, err
+	}
+	return ByeWeeks(games), nil
+}
+
+// maxRegularSeasonWeek is the last regular season week since 2021
+const maxRegularSeasonWeek = 18
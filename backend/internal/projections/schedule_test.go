@@ -0,0 +1,59 @@
+package projections
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchNFLVerseSchedule(t *testing.T) {
+	csv := "game_id,season,game_type,week,gameday,weekday,gametime,away_team,home_team\n" +
+		"2023_22_SF_KC,2023,SB,22,2024-02-11,Sunday,18:30,SF,KC\n" +
+		"2024_01_BAL_KC,2024,REG,1,2024-09-05,Thursday,20:20,BAL,KC\n" +
+		"2024_01_WAS_TB,2024,REG,1,2024-09-08,Sunday,13:00,WAS,TB\n" +
+		"2024_01_LA_DET,2024,REG,1,2024-09-08,Sunday,20:20,LA,DET\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(csv))
+	}))
+	defer server.Close()
+
+	games, err := FetchNFLVerseSchedule(context.Background(), server.Client(), server.URL, 2024)
+	require.NoError(t, err)
+	require.Len(t, games, 3)
+
+	assert.Equal(t, "KC", games[0].HomeTeam)
+	assert.Equal(t, "BAL", games[0].AwayTeam)
+	assert.Equal(t, time.Date(2024, 9, 6, 0, 20, 0, 0, time.UTC), games[0].KickoffAt)
+
+	// nflverse abbreviations are mapped to ESPN's
+	assert.Equal(t, "WSH", games[1].AwayTeam)
+	assert.Equal(t, "LAR", games[2].AwayTeam)
+}
+
+func TestByeWeeks(t *testing.T) {
+	games := []ScheduledGame{
+		{Week: 1, HomeTeam: "KC", AwayTeam: "BAL"},
+		{Week: 1, HomeTeam: "BUF", AwayTeam: "MIA"},
+		{Week: 2, HomeTeam: "KC", AwayTeam: "BUF"},
+		{Week: 2, HomeTeam: "BAL", AwayTeam: "MIA"},
+		{Week: 3, HomeTeam: "BUF", AwayTeam: "BAL"},
+		{Week: 3, HomeTeam: "MIA", AwayTeam: "KC"},
+		{Week: 4, HomeTeam: "KC", AwayTeam: "BAL"},
+	}
+
+	byes := ByeWeeks(games)
+	assert.Equal(t, map[string]int{"BUF": 4, "MIA": 4}, byes, "teams off more than one week are left out")
+
+	games = append(games, ScheduledGame{Week: 4, HomeTeam: "BUF", AwayTeam: "MIA"}, ScheduledGame{Week: 5, HomeTeam: "BUF", AwayTeam: "KC"}, ScheduledGame{Week: 5, HomeTeam: "MIA", AwayTeam: "BAL"})
+	assert.Equal(t, map[string]int{}, ByeWeeks(games), "no team has a week off")
+}
+
+func TestCurrentSeason(t *testing.T) {
+	assert.Equal(t, 2024, CurrentSeason(time.Date(2025, 2, 9, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 2025, CurrentSeason(time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,89 @@
+package projections
+
+import "math"
+
+// pointsAllowedTier maps an upper bound of points allowed to DST fantasy points
+type pointsAllowedTier struct {
+	maxPoints int
+	score     float64
+}
+
+// Standard ESPN points-allowed scoring tiers
+var pointsAllowedTiers = []pointsAllowedTier{
+	{0, 5},
+	{6, 4},
+	{13, 3},
+	{17, 1},
+	{27, 0},
+	{34, -1},
+	{45, -3},
+}
+
+const (
+	// pointsAllowedFloorScore applies when a defense allows more than the last tier
+	pointsAllowedFloorScore = -5
+
+	// pointsAllowedStdDev is the spread of NFL team scores around their projection
+	pointsAllowedStdDev = 10.0
+
+	// maxModeledPoints bounds the points-allowed distribution
+	maxModeledPoints = 70
+)
+
+// PointsAllowedScore returns the DST fantasy points for an exact points-allowed total
+func PointsAllowedScore(points int) float64 {
+	for _, tier := range pointsAllowedTiers {
+		if points <= tier.maxPoints {
+			return tier.score
+		}
+	}
+	return pointsAllowedFloorScore
+}
+
+// ExpectedPointsAllowedScore models points allowed as a normal distribution around
+// the projected mean and returns the expected points-allowed fantasy score.
+// Scoring the mean directly overstates shutout tiers and understates blowouts.
+func ExpectedPointsAllowedScore(mean float64) float64 {
+	if mean < 0 {
+		mean = 0
+	}
+
+	var expected, totalWeight float64
+	for points := 0; points <= maxModeledPoints; points++ {
+		z := (float64(points) - mean) / pointsAllowedStdDev
+		weight := math.Exp(-0.5 * z * z)
+		expected += weight * PointsAllowedScore(points)
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		return PointsAllowedScore(int(math.Round(mean)))
+	}
+	return expected / totalWeight
+}
+
+// ScoreDST calculates projected fantasy points for a team defense
+func ScoreDST(p *DSTProjection) float64 {
+	points := ExpectedPointsAllowedScore(p.PointsAllowed)
+	points += p.Sacks * 1
+	points += p.Interceptions * 2
+	points += p.FumbleRecoveries * 2
+	points += p.Safeties * 2
+	points += (p.DefensiveTDs + p.ReturnTDs) * 6
+	return round2(points)
+}
+
+// ScoreKicker calculates projected fantasy points for a kicker
+func ScoreKicker(p *KickerProjection) float64 {
+	points := p.FGMade0To39 * 3
+	points += p.FGMade40To49 * 4
+	points += p.FGMade50Plus * 5
+	points -= p.FGMissed * 1
+	points += p.XPMade * 1
+	points -= math.Max(0, p.XPAttempts-p.XPMade) * 1
+	return round2(points)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,186 @@
+package projections
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointsAllowedScore(t *testing.T) {
+	tests := []struct {
+		points   int
+		expected float64
+	}{
+		{0, 5},
+		{3, 4},
+		{10, 3},
+		{14, 1},
+		{21, 0},
+		{30, -1},
+		{40, -3},
+		{50, -5},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, PointsAllowedScore(tt.points), "points allowed %d", tt.points)
+	}
+}
+
+func TestExpectedPointsAllowedScore(t *testing.T) {
+	// Better defenses should always project higher
+	assert.Greater(t, ExpectedPointsAllowedScore(14), ExpectedPointsAllowedScore(21))
+	assert.Greater(t, ExpectedPointsAllowedScore(21), ExpectedPointsAllowedScore(28))
+
+	// Variance keeps a strong defense from scoring as a guaranteed shutout
+	assert.Less(t, ExpectedPointsAllowedScore(0), 5.0)
+	assert.Greater(t, ExpectedPointsAllowedScore(60), -5.0)
+}
+
+func TestScoreKicker(t *testing.T) {
+	p := &KickerProjection{
+		FGMade0To39:  1,
+		FGMade40To49: 1,
+		FGMade50Plus: 0.5,
+		FGMissed:     0.5,
+		XPAttempts:   3,
+		XPMade:       2.8,
+	}
+
+	// 3 + 4 + 2.5 - 0.5 + 2.8 - 0.2
+	assert.InDelta(t, 11.6, ScoreKicker(p), 0.001)
+	assert.InDelta(t, 2.5, p.FGMade(), 0.001)
+}
+
+func TestCSVSource(t *testing.T) {
+	dir := t.TempDir()
+	dstPath := filepath.Join(dir, "dst.csv")
+	kickerPath := filepath.Join(dir, "k.csv")
+
+	require.NoError(t, os.WriteFile(dstPath, []byte(
+		"Team,Opponent,Points_Allowed,Sacks,Interceptions\n"+
+			"sf,ari,17.5,3.2,1.1\n"), 0o644))
+	require.NoError(t, os.WriteFile(kickerPath, []byte(
+		"player_name,team,fg_made_0_39,fg_made_40_49,fg_made_50_plus,xp_attempts,xp_made\n"+
+			"Justin Tucker,BAL,1.2,0.6,0.3,2.5,2.4\n"), 0o644))
+
+	source := NewCSVSource("fantasypros", dstPath, kickerPath)
+
+	dst, err := source.FetchDST(context.Background(), 2024, 3)
+	require.NoError(t, err)
+	require.Len(t, dst, 1)
+	assert.Equal(t, "SF", dst[0].Team)
+	assert.Equal(t, "SF D/ST", dst[0].PlayerName())
+	assert.Equal(t, 17.5, dst[0].PointsAllowed)
+	assert.Equal(t, 0.0, dst[0].FumbleRecoveries)
+	assert.Equal(t, "fantasypros", dst[0].Source)
+
+	kickers, err := source.FetchKickers(context.Background(), 2024, 3)
+	require.NoError(t, err)
+	require.Len(t, kickers, 1)
+	assert.Equal(t, "Justin Tucker", kickers[0].PlayerName)
+	assert.Equal(t, 2024, kickers[0].Season)
+	assert.Equal(t, 3, kickers[0].Week)
+}
+
+func TestCSVSource_InvalidValue(t *testing.T) {
+	dir := t.TempDir()
+	dstPath := filepath.Join(dir, "dst.csv")
+	require.NoError(t, os.WriteFile(dstPath, []byte("team,sacks\nSF,lots\n"), 0o644))
+
+	_, err := NewCSVSource("test", dstPath, "").FetchDST(context.Background(), 2024, 1)
+	assert.Error(t, err)
+}
+
+func TestScoreStats(t *testing.T) {
+	settings := espn.ScoringSettings{
+		PassingYards:        0.04,
+		PassingTouchdowns:   6,
+		Interceptions:       -2,
+		RushingYards:        0.1,
+		RushingTouchdowns:   6,
+		ReceptionPoints:     0.5,
+		ReceivingYards:      0.1,
+		ReceivingTouchdowns: 6,
+		Fumbles:             -2,
+	}
+
+	stats := map[string]float64{
+		espn.StatPassingYards:  300,
+		espn.StatPassingTDs:    2,
+		espn.StatInterceptions: 1,
+		espn.StatRushingYards:  25,
+		espn.StatFumblesLost:   1,
+		"999":                  40, // Unscored stats are ignored
+	}
+
+	// 12 passing yards + 12 passing TDs - 2 INT + 2.5 rushing yards - 2 fumble
+	assert.Equal(t, 22.5, ScoreStats(stats, settings))
+}
+
+func TestScoreStatLineForLeague(t *testing.T) {
+	line := StatLine{
+		PassingYards: 250, PassingTDs: 2, PassingInts: 1,
+		RushingYards: 30, RushingTDs: 1,
+		ReceivingYards: 45, ReceivingTDs: 1, Receptions: 6,
+	}
+
+	ppr := espn.NewMockESPNClient().LeagueInfo.Settings.ScoringSettings
+	assert.Equal(t, round2(ScoreStatLine(line, 1)), ScoreStatLineForLeague(line, ppr))
+
+	// A six-point passing TD league scores the same line higher
+	ppr.PassingTouchdowns = 6
+	assert.Equal(t, round2(ScoreStatLine(line, 1))+4, ScoreStatLineForLeague(line, ppr))
+
+	assert.Equal(t, line, StatLineFromESPN(line.ESPNStats()))
+}
+
+func TestParseScoringRules(t *testing.T) {
+	settings, err := ParseScoringRules([]byte(`{"receptionPoints": 0.5, "passingTouchdowns": 6}`))
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, settings.ReceptionPoints)
+	assert.Equal(t, 6.0, settings.PassingTouchdowns)
+
+	_, err = ParseScoringRules(nil)
+	assert.Error(t, err)
+}
+
+func TestRescoreProjections(t *testing.T) {
+	rules := CustomScoringRules{ScoringSettings: espn.NewMockESPNClient().LeagueInfo.Settings.ScoringSettings}
+	rules.ReceptionBonus = map[string]float64{"TE": 1}
+
+	players := []ProjectedPlayer{
+		{PlayerName: "WR One", Position: "WR", Stats: &StatLine{ReceivingYards: 80, Receptions: 6}, PointsPPR: 14, PointsStandard: 8},
+		{PlayerName: "TE One", Position: "TE", Stats: &StatLine{ReceivingYards: 70, Receptions: 7}, PointsPPR: 13, PointsStandard: 7},
+		{PlayerName: "Bills D/ST", Position: "DST", PointsPPR: 9, PointsStandard: 9},
+	}
+
+	scores := RescoreProjections(players, rules)
+	require.Len(t, scores, 3)
+
+	// The tight end premium moves the TE past the WR
+	assert.Equal(t, "TE One", scores[0].PlayerName)
+	assert.Equal(t, 21.0, scores[0].Points)
+	assert.Equal(t, 8.0, scores[0].Difference)
+	assert.Equal(t, 2, scores[0].PPRRank)
+	assert.Equal(t, 1, scores[0].PositionRank)
+	assert.Equal(t, 14.0, scores[1].Points)
+
+	// Players projected only in points keep their standard points
+	assert.False(t, scores[2].StatBased)
+	assert.Equal(t, 9.0, scores[2].Points)
+	assert.Equal(t, 3, scores[2].Rank)
+}
+
+func TestCustomScoringRulesDecode(t *testing.T) {
+	var rules CustomScoringRules
+	require.NoError(t, json.Unmarshal([]byte(`{"passingTouchdowns":6,"receptionPoints":0.5,"receptionBonus":{"TE":0.5}}`), &rules))
+	assert.Equal(t, 6.0, rules.PassingTouchdowns)
+	assert.Equal(t, 0.5, rules.ReceptionPoints)
+	assert.Equal(t, 0.5, rules.ReceptionBonus["TE"])
+}
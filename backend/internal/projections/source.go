@@ -0,0 +1,308 @@
+package projections
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Source provides weekly DST and kicker projections from a single provider
+type Source interface {
+	Name() string
+	FetchDST(ctx context.Context, season, week int) ([]DSTProjection, error)
+	FetchKickers(ctx context.Context, season, week int) ([]KickerProjection, error)
+}
+
+// CSVSource reads projections exported by a provider as CSV files.
+// Either path may be empty to skip that position.
+type CSVSource struct {
+	name       string
+	dstPath    string
+	kickerPath string
+}
+
+// NewCSVSource creates a new CSV projection source
+func NewCSVSource(name, dstPath, kickerPath string) *CSVSource {
+	return &CSVSource{
+		name:       name,
+		dstPath:    dstPath,
+		kickerPath: kickerPath,
+	}
+}
+
+// Name returns the source identifier stored with each projection
+func (s *CSVSource) Name() string {
+	return s.name
+}
+
+// FetchDST reads team defense projections.
+// Expected columns: team, opponent, points_allowed, yards_allowed, sacks,
+// interceptions, fumble_recoveries, safeties, defensive_tds, return_tds
+func (s *CSVSource) FetchDST(ctx context.Context, season, week int) ([]DSTProjection, error) {
+	if s.dstPath == "" {
+		return nil, nil
+	}
+
+	rows, err := readCSVFile(s.dstPath)
+	if err != nil {
+		return nil, err
+	}
+
+	projections := make([]DSTProjection, 0, len(rows))
+	for i, row := range rows {
+		team := strings.ToUpper(row.str("team"))
+		if team == "" {
+			return nil, fmt.Errorf("row %d: missing team", i+2)
+		}
+
+		p := DSTProjection{
+			Team:     team,
+			Opponent: strings.ToUpper(row.str("opponent")),
+			Week:     week,
+			Season:   season,
+			Source:   s.name,
+		}
+		if err := row.floats(map[string]*float64{
+			"points_allowed":    &p.PointsAllowed,
+			"yards_allowed":     &p.YardsAllowed,
+			"sacks":             &p.Sacks,
+			"interceptions":     &p.Interceptions,
+			"fumble_recoveries": &p.FumbleRecoveries,
+			"safeties":          &p.Safeties,
+			"defensive_tds":     &p.DefensiveTDs,
+			"return_tds":        &p.ReturnTDs,
+		}); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		projections = append(projections, p)
+	}
+
+	return projections, nil
+}
+
+// FetchKickers reads kicker projections.
+// Expected columns: player_name, team, opponent, fg_attempts, fg_made_0_39,
+// fg_made_40_49, fg_made_50_plus, fg_missed, xp_attempts, xp_made
+func (s *CSVSource) FetchKickers(ctx context.Context, season, week int) ([]KickerProjection, error) {
+	if s.kickerPath == "" {
+		return nil, nil
+	}
+
+	rows, err := readCSVFile(s.kickerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	projections := make([]KickerProjection, 0, len(rows))
+	for i, row := range rows {
+		name := row.str("player_name")
+		if name == "" {
+			return nil, fmt.Errorf("row %d: missing player_name", i+2)
+		}
+
+		p := KickerProjection{
+			PlayerID:   row.str("player_id"),
+			PlayerName: name,
+			Team:       strings.ToUpper(row.str("team")),
+			Opponent:   strings.ToUpper(row.str("opponent")),
+			Week:       week,
+			Season:     season,
+			Source:     s.name,
+		}
+		if err := row.floats(map[string]*float64{
+			"fg_attempts":     &p.FGAttempts,
+			"fg_made_0_39":    &p.FGMade0To39,
+			"fg_made_40_49":   &p.FGMade40To49,
+			"fg_made_50_plus": &p.FGMade50Plus,
+			"fg_missed":       &p.FGMissed,
+			"xp_attempts":     &p.XPAttempts,
+			"xp_made":         &p.XPMade,
+		}); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		projections = append(projections, p)
+	}
+
+	return projections, nil
+}
+
+// ReadWeatherCSV reads game forecasts.
+// Expected columns: home_team, away_team, temperature_f, wind_mph,
+// precipitation_chance, is_dome
+func ReadWeatherCSV(path, source string, season, week int) ([]GameWeather, error) {
+	rows, err := readCSVFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]GameWeather, 0, len(rows))
+	for i, row := range rows {
+		home := strings.ToUpper(row.str("home_team"))
+		away := strings.ToUpper(row.str("away_team"))
+		if home == "" || away == "" {
+			return nil, fmt.Errorf("row %d: missing home_team or away_team", i+2)
+		}
+
+		g := GameWeather{
+			Season:   season,
+			Week:     week,
+			HomeTeam: home,
+			AwayTeam: away,
+			Source:   source,
+		}
+		if err := row.floats(map[string]*float64{
+			"temperature_f":        &g.TemperatureF,
+			"wind_mph":             &g.WindMPH,
+			"precipitation_chance": &g.PrecipitationChance,
+		}); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		if dome := row.str("is_dome"); dome != "" {
+			g.IsDome, err = strconv.ParseBool(dome)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid is_dome %q", i+2, dome)
+			}
+		}
+		games = append(games, g)
+	}
+
+	return games, nil
+}
+
+// ReadScheduleCSV reads a season schedule.
+// Expected columns: week, home_team, away_team, kickoff_at (RFC 3339)
+func ReadScheduleCSV(path string, season int) ([]ScheduledGame, error) {
+	rows, err := readCSVFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]ScheduledGame, 0, len(rows))
+	for i, row := range rows {
+		week, err := strconv.Atoi(row.str("week"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid week %q", i+2, row.str("week"))
+		}
+		kickoff, err := time.Parse(time.RFC3339, row.str("kickoff_at"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid kickoff_at %q", i+2, row.str("kickoff_at"))
+		}
+
+		games = append(games, ScheduledGame{
+			Season:    season,
+			Week:      week,
+			HomeTeam:  strings.ToUpper(row.str("home_team")),
+			AwayTeam:  strings.ToUpper(row.str("away_team")),
+			KickoffAt: kickoff,
+		})
+	}
+
+	return games, nil
+}
+
+// ReadActualsCSV reads actual weekly fantasy points.
+// Expected columns: player_name, position, team, fantasy_points_ppr,
+// fantasy_points_standard
+func ReadActualsCSV(path string, season, week int) ([]WeeklyActual, error) {
+	rows, err := readCSVFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	actuals := make([]WeeklyActual, 0, len(rows))
+	for i, row := range rows {
+		name := row.str("player_name")
+		if name == "" {
+			return nil, fmt.Errorf("row %d: missing player_name", i+2)
+		}
+
+		a := WeeklyActual{
+			PlayerName: name,
+			Position:   strings.ToUpper(row.str("position")),
+			Team:       strings.ToUpper(row.str("team")),
+			Season:     season,
+			Week:       week,
+		}
+		if err := row.floats(map[string]*float64{
+			"fantasy_points_ppr":      &a.PointsPPR,
+			"fantasy_points_standard": &a.PointsStandard,
+		}); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		actuals = append(actuals, a)
+	}
+
+	return actuals, nil
+}
+
+// csvRow maps lowercase header names to cell values
+type csvRow map[string]string
+
+func (r csvRow) str(column string) string {
+	return strings.TrimSpace(r[column])
+}
+
+// floats parses the named columns into their targets; missing or empty cells are zero
+func (r csvRow) floats(targets map[string]*float64) error {
+	for column, target := range targets {
+		value := r.str(column)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", column, value, err)
+		}
+		*target = parsed
+	}
+	return nil
+}
+
+func readCSVFile(path string) ([]csvRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return readCSV(f)
+}
+
+func readCSV(r io.Reader) ([]csvRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	var rows []csvRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV row: %w", err)
+		}
+
+		row := make(csvRow, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
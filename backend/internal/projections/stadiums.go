@@ -0,0 +1,118 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/weather"
+)
+
+// Stadium is where a team plays its home games
+type Stadium struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+	// Indoor covers domes and retractable roofs, which close in bad weather
+	Indoor bool
+	// TimeZone is the stadium's IANA time zone
+	TimeZone string
+}
+
+// stadiums maps each team's ESPN abbreviation to its home stadium
+var stadiums = map[string]Stadium{
+	"ARI": {"State Farm Stadium", 33.5276, -112.2626, true, "America/Phoenix"},
+	"ATL": {"Mercedes-Benz Stadium", 33.7554, -84.4008, true, "America/New_York"},
+	"BAL": {"M&T Bank Stadium", 39.2780, -76.6227, false, "America/New_York"},
+	"BUF": {"Highmark Stadium", 42.7738, -78.7870, false, "America/New_York"},
+	"CAR": {"Bank of America Stadium", 35.2258, -80.8528, false, "America/New_York"},
+	"CHI": {"Soldier Field", 41.8623, -87.6167, false, "America/Chicago"},
+	"CIN": {"Paycor Stadium", 39.0955, -84.5161, false, "America/New_York"},
+	"CLE": {"Huntington Bank Field", 41.5061, -81.6995, false, "America/New_York"},
+	"DAL": {"AT&T Stadium", 32.7473, -97.0945, true, "America/Chicago"},
+	"DEN": {"Empower Field at Mile High", 39.7439, -105.0201, false, "America/Denver"},
+	"DET": {"Ford Field", 42.3400, -83.0456, true, "America/Detroit"},
+	"GB":  {"Lambeau Field", 44.5013, -88.0622, false, "America/Chicago"},
+	"HOU": {"NRG Stadium", 29.6847, -95.4107, true, "America/Chicago"},
+	"IND": {"Lucas Oil Stadium", 39.7601, -86.1639, true, "America/Indiana/Indianapolis"},
+	"JAX": {"EverBank Stadium", 30.3239, -81.6373, false, "America/New_York"},
+	"KC":  {"GEHA Field at Arrowhead Stadium", 39.0489, -94.4839, false, "America/Chicago"},
+	"LAC": {"SoFi Stadium", 33.9535, -118.3392, true, "America/Los_Angeles"},
+	"LAR": {"SoFi Stadium", 33.9535, -118.3392, true, "America/Los_Angeles"},
+	"LV":  {"Allegiant Stadium", 36.0909, -115.1833, true, "America/Los_Angeles"},
+	"MIA": {"Hard Rock Stadium", 25.9580, -80.2389, false, "America/New_York"},
+	"MIN": {"U.S. Bank Stadium", 44.9737, -93.2577, true, "America/Chicago"},
+	"NE":  {"Gillette Stadium", 42.0909, -71.2643, false, "America/New_York"},
+	"NO":  {"Caesars Superdome", 29.9511, -90.0812, true, "America/Chicago"},
+	"NYG": {"MetLife Stadium", 40.8135, -74.0745, false, "America/New_York"},
+	"NYJ": {"MetLife Stadium", 40.8135, -74.0745, false, "America/New_York"},
+	"PHI": {"Lincoln Financial Field", 39.9008, -75.1675, false, "America/New_York"},
+	"PIT": {"Acrisure Stadium", 40.4468, -80.0158, false, "America/New_York"},
+	"SEA": {"Lumen Field", 47.5952, -122.3316, false, "America/Los_Angeles"},
+	"SF":  {"Levi's Stadium", 37.4030, -121.9700, false, "America/Los_Angeles"},
+	"TB":  {"Raymond James Stadium", 27.9759, -82.5033, false, "America/New_York"},
+	"TEN": {"Nissan Stadium", 36.1665, -86.7713, false, "America/Chicago"},
+	"WSH": {"Northwest Stadium", 38.9077, -76.8645, false, "America/New_York"},
+}
+
+// indoorTemperatureF is reported for games under a roof
+const indoorTemperatureF = 72.0
+
+// WeatherSourceOpenMeteo names forecasts fetched from Open-Meteo
+const WeatherSourceOpenMeteo = "open-meteo"
+
+// HomeStadium returns a team's home stadium
+func HomeStadium(team string) (Stadium, bool) {
+	s, ok := stadiums[strings.ToUpper(team)]
+	return s, ok
+}
+
+// ForecastGameWeather fetches the kickoff forecast at each game's stadium.
+// Indoor games are not fetched, and games already played or too far ahead
+// to forecast are left out. International games are forecast at the home
+// team's stadium, so load those from a CSV instead.
+func ForecastGameWeather(ctx context.Context, client weather.Client, games []ScheduledGame, now time.Time) ([]GameWeather, error) {
+	horizon := now.AddDate(0, 0, weather.MaxForecastDays)
+
+	var forecasts []GameWeather
+	for _, g := range games {
+		stadium, ok := HomeStadium(g.HomeTeam)
+		if !ok {
+			log.Printf("No stadium known for %s, skipping weather for %s at %s", g.HomeTeam, g.AwayTeam, g.HomeTeam)
+			continue
+		}
+		if g.KickoffAt.IsZero() || g.KickoffAt.Before(now) || g.KickoffAt.After(horizon) {
+			continue
+		}
+
+		kickoff := g.KickoffAt
+		forecast := GameWeather{
+			Season:    g.Season,
+			Week:      g.Week,
+			HomeTeam:  strings.ToUpper(g.HomeTeam),
+			AwayTeam:  strings.ToUpper(g.AwayTeam),
+			Stadium:   stadium.Name,
+			KickoffAt: &kickoff,
+			IsDome:    stadium.Indoor,
+			Source:    WeatherSourceOpenMeteo,
+		}
+		if stadium.Indoor {
+			forecast.TemperatureF = indoorTemperatureF
+			forecasts = append(forecasts, forecast)
+			continue
+		}
+
+		conditions, err := client.GetForecast(ctx, stadium.Latitude, stadium.Longitude, g.KickoffAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get forecast for %s at %s: %w", g.AwayTeam, g.HomeTeam, err)
+		}
+		forecast.TemperatureF = round2(conditions.TemperatureF)
+		forecast.WindMPH = round2(conditions.WindMPH)
+		forecast.PrecipitationChance = round2(conditions.PrecipitationChance)
+		forecasts = append(forecasts, forecast)
+	}
+
+	return forecasts, nil
+}
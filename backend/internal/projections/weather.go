@@ -0,0 +1,116 @@
+package projections
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GameWeather is the forecast for a single game
+type GameWeather struct {
+	Season              int     `json:"season"`
+	Week                int     `json:"week"`
+	HomeTeam            string  `json:"home_team"`
+	AwayTeam            string  `json:"away_team"`
+	TemperatureF        float64 `json:"temperature_f"`
+	WindMPH             float64 `json:"wind_mph"`
+	PrecipitationChance float64 `json:"precipitation_chance"`
+	IsDome              bool    `json:"is_dome"`
+	Source              string  `json:"source"`
+	// Stadium and KickoffAt are set for forecasts fetched for the game
+	Stadium   string     `json:"stadium,omitempty"`
+	KickoffAt *time.Time `json:"kickoff_at,omitempty"`
+}
+
+const (
+	// highWindMPH is where deep passing and long field goals start to suffer
+	highWindMPH = 15.0
+	// severeWindMPH is where teams abandon the deep ball
+	severeWindMPH = 20.0
+	// wetGameChance is the precipitation probability treated as a wet game
+	wetGameChance = 60.0
+	// extremeColdF is where ball handling and kicking distance suffer
+	extremeColdF = 20.0
+)
+
+// weatherPenalties are the multipliers applied per position for each condition
+var weatherPenalties = map[string]struct {
+	highWind   float64
+	severeWind float64
+	wet        float64
+	cold       float64
+}{
+	"QB": {0.94, 0.88, 0.95, 0.97},
+	"WR": {0.95, 0.90, 0.95, 0.97},
+	"TE": {0.97, 0.94, 0.97, 0.98},
+	"K":  {0.92, 0.85, 0.95, 0.93},
+}
+
+// WeatherModifier reduces passing and kicking projections for high wind,
+// precipitation and extreme cold games
+type WeatherModifier struct {
+	byTeam map[string]GameWeather
+}
+
+// NewWeatherModifier creates a weather modifier for one week of games
+func NewWeatherModifier(games []GameWeather) *WeatherModifier {
+	byTeam := make(map[string]GameWeather, len(games)*2)
+	for _, g := range games {
+		byTeam[strings.ToUpper(g.HomeTeam)] = g
+		byTeam[strings.ToUpper(g.AwayTeam)] = g
+	}
+	return &WeatherModifier{byTeam: byTeam}
+}
+
+// Name returns the modifier identifier
+func (m *WeatherModifier) Name() string {
+	return ModifierWeather
+}
+
+// Adjust applies wind, precipitation and cold penalties to passing and kicking positions
+func (m *WeatherModifier) Adjust(p *Adjustable) *Adjustment {
+	penalties, affected := weatherPenalties[p.Position]
+	if !affected {
+		return nil
+	}
+
+	game, ok := m.byTeam[strings.ToUpper(p.Team)]
+	if !ok || game.IsDome {
+		return nil
+	}
+
+	multiplier := 1.0
+	var reasons []string
+
+	switch {
+	case game.WindMPH >= severeWindMPH:
+		multiplier *= penalties.severeWind
+		reasons = append(reasons, fmt.Sprintf("severe wind (%.0f mph)", game.WindMPH))
+	case game.WindMPH >= highWindMPH:
+		multiplier *= penalties.highWind
+		reasons = append(reasons, fmt.Sprintf("high wind (%.0f mph)", game.WindMPH))
+	}
+
+	if game.PrecipitationChance >= wetGameChance {
+		multiplier *= penalties.wet
+		reasons = append(reasons, fmt.Sprintf("%.0f%% chance of precipitation", game.PrecipitationChance))
+	}
+
+	// Forecasts loaded without a temperature read as 0, which is not trusted
+	if game.TemperatureF != 0 && game.TemperatureF <= extremeColdF {
+		multiplier *= penalties.cold
+		reasons = append(reasons, fmt.Sprintf("extreme cold (%.0f°F)", game.TemperatureF))
+	}
+
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	multiplier = clampMultiplier(multiplier)
+	return &Adjustment{
+		Modifier:   m.Name(),
+		Multiplier: round2(multiplier),
+		PointsPPR:  p.scale(multiplier),
+		Reason:     strings.Join(reasons, ", "),
+	}
+}
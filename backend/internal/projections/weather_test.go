@@ -0,0 +1,100 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/integrations/weather"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeatherModifier(t *testing.T) {
+	modifier := NewWeatherModifier([]GameWeather{
+		{HomeTeam: "BUF", AwayTeam: "MIA", WindMPH: 22, PrecipitationChance: 80},
+		{HomeTeam: "CHI", AwayTeam: "GB", WindMPH: 16},
+		{HomeTeam: "DET", AwayTeam: "MIN", WindMPH: 30, IsDome: true},
+		{HomeTeam: "KC", AwayTeam: "DEN", TemperatureF: 8, WindMPH: 6},
+	})
+
+	t.Run("severe wind and rain", func(t *testing.T) {
+		p := &Adjustable{Position: "QB", Team: "MIA", PointsPPR: 20, PointsStandard: 20, FloorPPR: 12, CeilingPPR: 28}
+		adj := modifier.Adjust(p)
+		require.NotNil(t, adj)
+
+		// 0.88 wind * 0.95 rain
+		assert.InDelta(t, 16.72, p.PointsPPR, 0.001)
+		assert.InDelta(t, -3.28, adj.PointsPPR, 0.001)
+		assert.Equal(t, "weather", adj.Modifier)
+		assert.Contains(t, adj.Reason, "severe wind")
+		assert.Contains(t, adj.Reason, "precipitation")
+	})
+
+	t.Run("high wind kicker", func(t *testing.T) {
+		p := &Adjustable{Position: "K", Team: "GB", PointsPPR: 10, PointsStandard: 10}
+		adj := modifier.Adjust(p)
+		require.NotNil(t, adj)
+		assert.InDelta(t, 9.2, p.PointsPPR, 0.001)
+	})
+
+	t.Run("extreme cold", func(t *testing.T) {
+		p := &Adjustable{Position: "K", Team: "DEN", PointsPPR: 10, PointsStandard: 10}
+		adj := modifier.Adjust(p)
+		require.NotNil(t, adj)
+		assert.InDelta(t, 9.3, p.PointsPPR, 0.001)
+		assert.Contains(t, adj.Reason, "extreme cold")
+	})
+
+	t.Run("running backs unaffected", func(t *testing.T) {
+		p := &Adjustable{Position: "RB", Team: "BUF", PointsPPR: 15}
+		assert.Nil(t, modifier.Adjust(p))
+		assert.Equal(t, 15.0, p.PointsPPR)
+	})
+
+	t.Run("dome ignores wind", func(t *testing.T) {
+		p := &Adjustable{Position: "QB", Team: "DET", PointsPPR: 20}
+		assert.Nil(t, modifier.Adjust(p))
+	})
+
+	t.Run("no game data", func(t *testing.T) {
+		p := &Adjustable{Position: "WR", Team: "SEA", PointsPPR: 14}
+		assert.Empty(t, ApplyModifiers(p, modifier))
+		assert.Equal(t, 14.0, p.PointsPPR)
+	})
+}
+
+type fakeWeatherClient struct {
+	calls int
+}
+
+func (f *fakeWeatherClient) GetForecast(ctx context.Context, latitude, longitude float64, at time.Time) (*weather.Conditions, error) {
+	f.calls++
+	return &weather.Conditions{Time: at, TemperatureF: 18.44, WindMPH: 21.06, PrecipitationChance: 40}, nil
+}
+
+func TestForecastGameWeather(t *testing.T) {
+	now := time.Date(2024, 11, 27, 12, 0, 0, 0, time.UTC)
+	kickoff := time.Date(2024, 12, 1, 18, 0, 0, 0, time.UTC)
+	games := []ScheduledGame{
+		{Season: 2024, Week: 13, HomeTeam: "BUF", AwayTeam: "SF", KickoffAt: kickoff},
+		{Season: 2024, Week: 13, HomeTeam: "DET", AwayTeam: "CHI", KickoffAt: kickoff},
+		{Season: 2024, Week: 13, HomeTeam: "KC", AwayTeam: "LV", KickoffAt: now.Add(-time.Hour)},
+		{Season: 2024, Week: 13, HomeTeam: "XYZ", AwayTeam: "NYG", KickoffAt: kickoff},
+	}
+
+	client := &fakeWeatherClient{}
+	forecasts, err := ForecastGameWeather(context.Background(), client, games, now)
+	require.NoError(t, err)
+	require.Len(t, forecasts, 2)
+	assert.Equal(t, 1, client.calls, "indoor games are not fetched")
+
+	assert.Equal(t, "Highmark Stadium", forecasts[0].Stadium)
+	assert.Equal(t, kickoff, *forecasts[0].KickoffAt)
+	assert.Equal(t, 18.44, forecasts[0].TemperatureF)
+	assert.Equal(t, 21.06, forecasts[0].WindMPH)
+	assert.Equal(t, WeatherSourceOpenMeteo, forecasts[0].Source)
+
+	assert.True(t, forecasts[1].IsDome)
+	assert.Equal(t, indoorTemperatureF, forecasts[1].TemperatureF)
+}
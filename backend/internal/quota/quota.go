@@ -0,0 +1,103 @@
+// Package quota holds the per-user limits of each plan and reports usage
+// against them in response headers, so clients can show a limit before a
+// user runs into it.
+package quota
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Resources with per-user limits
+const (
+	ResourceLeagues       = "leagues"
+	ResourceDraftSessions = "draft_sessions"
+	ResourceWatchlist     = "watchlist"
+)
+
+// Response headers
+const (
+	HeaderResourceCount = "X-Resource-Count"
+	HeaderQuotaResource = "X-Quota-Resource"
+	HeaderQuotaLimit    = "X-Quota-Limit"
+	HeaderQuotaRemain   = "X-Quota-Remaining"
+	HeaderQuotaPlan     = "X-Quota-Plan"
+)
+
+// ExposedHeaders lists the headers browsers need CORS to expose
+var ExposedHeaders = []string{HeaderResourceCount, HeaderQuotaResource, HeaderQuotaLimit, HeaderQuotaRemain, HeaderQuotaPlan}
+
+// planKey is where Middleware stores the user's plan on the request
+const planKey = "quota_plan"
+
+// Plan is a named set of per-user limits. A missing or zero limit means
+// the resource is unlimited.
+type Plan struct {
+	Name   string         `json:"name"`
+	Limits map[string]int `json:"limits"`
+}
+
+// Limit returns the plan's limit on a resource, 0 when unlimited
+func (p Plan) Limit(resource string) int {
+	return p.Limits[resource]
+}
+
+// Planner decides which plan a user is on
+type Planner interface {
+	PlanFor(ctx context.Context, userID uuid.UUID) Plan
+}
+
+// StaticPlanner puts every user on the same plan
+type StaticPlanner struct {
+	plan Plan
+}
+
+// NewStaticPlanner creates a planner that gives every user plan
+func NewStaticPlanner(plan Plan) *StaticPlanner {
+	return &StaticPlanner{plan: plan}
+}
+
+// PlanFor returns the plan
+func (p *StaticPlanner) PlanFor(ctx context.Context, userID uuid.UUID) Plan {
+	return p.plan
+}
+
+// Middleware looks up the authenticated user's plan for SetHeaders. It runs
+// after the auth middleware; requests without a user get no plan.
+func Middleware(planner Planner) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userID, ok := c.Get("user_id"); ok {
+			if id, ok := userID.(uuid.UUID); ok {
+				c.Set(planKey, planner.PlanFor(c.Request.Context(), id))
+			}
+		}
+		c.Next()
+	}
+}
+
+// SetHeaders reports how many of a resource the user has. When their plan
+// limits the resource it also reports the limit and what is left of it.
+// Limits are soft: nothing here stops a user going over.
+func SetHeaders(c *gin.Context, resource string, count int) {
+	c.Header(HeaderResourceCount, strconv.Itoa(count))
+
+	value, ok := c.Get(planKey)
+	if !ok {
+		return
+	}
+	plan, ok := value.(Plan)
+	if !ok {
+		return
+	}
+	c.Header(HeaderQuotaPlan, plan.Name)
+	limit := plan.Limit(resource)
+	if limit <= 0 {
+		return
+	}
+	c.Header(HeaderQuotaResource, resource)
+	c.Header(HeaderQuotaLimit, strconv.Itoa(limit))
+	c.Header(HeaderQuotaRemain, strconv.Itoa(max(limit-count, 0)))
+}
@@ -0,0 +1,51 @@
+package quota
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	plan := Plan{Name: "free", Limits: map[string]int{ResourceLeagues: 3}}
+
+	serve := func(userID interface{}, resource string, count int) http.Header {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			if userID != nil {
+				c.Set("user_id", userID)
+			}
+		}, Middleware(NewStaticPlanner(plan)))
+		r.GET("/", func(c *gin.Context) {
+			SetHeaders(c, resource, count)
+			c.Status(http.StatusOK)
+		})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w.Header()
+	}
+
+	h := serve(uuid.New(), ResourceLeagues, 2)
+	assert.Equal(t, "2", h.Get(HeaderResourceCount))
+	assert.Equal(t, "leagues", h.Get(HeaderQuotaResource))
+	assert.Equal(t, "3", h.Get(HeaderQuotaLimit))
+	assert.Equal(t, "1", h.Get(HeaderQuotaRemain))
+	assert.Equal(t, "free", h.Get(HeaderQuotaPlan))
+
+	// Over a soft limit nothing is left, but nothing is blocked either
+	h = serve(uuid.New(), ResourceLeagues, 5)
+	assert.Equal(t, "0", h.Get(HeaderQuotaRemain))
+
+	// Unlimited resources and anonymous requests only get the count
+	h = serve(uuid.New(), ResourceWatchlist, 7)
+	assert.Equal(t, "7", h.Get(HeaderResourceCount))
+	assert.Empty(t, h.Get(HeaderQuotaLimit))
+	h = serve(nil, ResourceLeagues, 1)
+	assert.Equal(t, "1", h.Get(HeaderResourceCount))
+	assert.Empty(t, h.Get(HeaderQuotaPlan))
+}
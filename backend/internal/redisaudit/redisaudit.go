@@ -0,0 +1,256 @@
+package redisaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// reportKey holds the latest report so every API instance serves the same one
+const reportKey = "redisaudit:report"
+
+// scanCount is the number of keys asked for per SCAN call
+const scanCount = 500
+
+// maxSampleKeys caps the unrecognised keys listed in a report
+const maxSampleKeys = 10
+
+// OtherNamespace names the keys that match no registered prefix
+const OtherNamespace = "other"
+
+// Namespace is a group of app keys sharing a prefix
+type Namespace struct {
+	Name   string
+	Prefix string
+	// TTL is set on keys found without an expiry; 0 marks keys that are
+	// meant to persist
+	TTL time.Duration
+	// Budget is the memory in bytes the namespace may use before it is
+	// flagged; 0 is unlimited
+	Budget int64
+}
+
+// NamespaceUsage is the memory and expiry state of one namespace
+type NamespaceUsage struct {
+	Name            string `json:"name"`
+	Prefix          string `json:"prefix,omitempty"`
+	Keys            int64  `json:"keys"`
+	Bytes           int64  `json:"bytes"`
+	Budget          int64  `json:"budget,omitempty"`
+	OverBudget      bool   `json:"over_budget"`
+	WithoutExpiry   int64  `json:"without_expiry"`
+	ExpiriesSet     int64  `json:"expiries_set"`
+	LargestKey      string `json:"largest_key,omitempty"`
+	LargestKeyBytes int64  `json:"largest_key_bytes,omitempty"`
+	// SampleKeys lists keys outside every registered namespace, the first
+	// place to look for a leaked key pattern
+	SampleKeys []string `json:"sample_keys,omitempty"`
+}
+
+// Report is the result of one audit
+type Report struct {
+	StartedAt   time.Time        `json:"started_at"`
+	DurationMS  int64            `json:"duration_ms"`
+	UsedMemory  int64            `json:"used_memory"`
+	MaxMemory   int64            `json:"max_memory"`
+	TotalKeys   int64            `json:"total_keys"`
+	TotalBytes  int64            `json:"total_bytes"`
+	ExpiriesSet int64            `json:"expiries_set"`
+	Namespaces  []NamespaceUsage `json:"namespaces"`
+}
+
+// Auditor scans the keyspace, totals memory per namespace and sets an
+// expiry on app keys that are missing one, so a leaked key pattern cannot
+// quietly fill Redis during the season
+type Auditor struct {
+	redis      *redis.Client
+	namespaces []Namespace
+}
+
+// NewAuditor creates an auditor for the given namespaces
+func NewAuditor(redisClient *redis.Client, namespaces []Namespace) *Auditor {
+	return &Auditor{redis: redisClient, namespaces: namespaces}
+}
+
+// Run audits the keyspace and stores the report as the latest one
+func (a *Auditor) Run(ctx context.Context) (*Report, error) {
+	started := time.Now()
+	t := newTally(a.namespaces)
+
+	var cursor uint64
+	for {
+		keys, next, err := a.redis.Scan(ctx, cursor, "*", scanCount).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		if err := a.auditKeys(ctx, t, keys); err != nil {
+			return nil, err
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	report := t.report()
+	report.StartedAt = started
+	report.DurationMS = time.Since(started).Milliseconds()
+
+	if info, err := a.redis.InfoMap(ctx, "memory").Result(); err == nil {
+		memory := info["Memory"]
+		report.UsedMemory, _ = strconv.ParseInt(memory["used_memory"], 10, 64)
+		report.MaxMemory, _ = strconv.ParseInt(memory["maxmemory"], 10, 64)
+	} else {
+		log.Printf("Failed to read Redis memory info: %v", err)
+	}
+
+	for _, ns := range report.Namespaces {
+		if ns.OverBudget {
+			log.Printf("Redis namespace %s is over budget: %d of %d bytes in %d keys", ns.Name, ns.Bytes, ns.Budget, ns.Keys)
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit report: %w", err)
+	}
+	if err := a.redis.Set(ctx, reportKey, data, 0).Err(); err != nil {
+		return nil, fmt.Errorf("failed to save audit report: %w", err)
+	}
+
+	return report, nil
+}
+
+// Latest returns the most recent report, or nil if no audit has run
+func (a *Auditor) Latest(ctx context.Context) (*Report, error) {
+	data, err := a.redis.Get(ctx, reportKey).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit report: %w", err)
+	}
+
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit report: %w", err)
+	}
+	return &report, nil
+}
+
+// auditKeys reads the size and expiry of one page of keys in a single round
+// trip and sets any missing expiries in another
+func (a *Auditor) auditKeys(ctx context.Context, t *tally, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := a.redis.Pipeline()
+	sizes := make([]*redis.IntCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		sizes[i] = pipe.MemoryUsage(ctx, key)
+		ttls[i] = pipe.TTL(ctx, key)
+	}
+	// Keys that expire mid-scan fail individually and are skipped below
+	if _, err := pipe.Exec(ctx); err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	expire := a.redis.Pipeline()
+	for i, key := range keys {
+		bytes, err := sizes[i].Result()
+		if err != nil {
+			continue
+		}
+		ttl, err := ttls[i].Result()
+		if err != nil {
+			continue
+		}
+		if set := t.add(key, bytes, ttl); set > 0 {
+			expire.Expire(ctx, key, set)
+		}
+	}
+	if expire.Len() > 0 {
+		if _, err := expire.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to set key expiries: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// tally accumulates usage per namespace. The last entry is OtherNamespace.
+type tally struct {
+	namespaces []Namespace
+	usage      []NamespaceUsage
+}
+
+func newTally(namespaces []Namespace) *tally {
+	t := &tally{namespaces: namespaces, usage: make([]NamespaceUsage, len(namespaces)+1)}
+	for i, ns := range namespaces {
+		t.usage[i] = NamespaceUsage{Name: ns.Name, Prefix: ns.Prefix, Budget: ns.Budget}
+	}
+	t.usage[len(namespaces)] = NamespaceUsage{Name: OtherNamespace}
+	return t
+}
+
+// match returns the index of the namespace with the longest prefix of key,
+// or the index of OtherNamespace
+func (t *tally) match(key string) int {
+	match, longest := len(t.namespaces), -1
+	for i, ns := range t.namespaces {
+		if strings.HasPrefix(key, ns.Prefix) && len(ns.Prefix) > longest {
+			match, longest = i, len(ns.Prefix)
+		}
+	}
+	return match
+}
+
+// add records a key and returns the expiry to set on it, or 0. A ttl of -1
+// is Redis reporting that the key never expires.
+func (t *tally) add(key string, bytes int64, ttl time.Duration) time.Duration {
+	i := t.match(key)
+	u := &t.usage[i]
+	u.Keys++
+	u.Bytes += bytes
+	if bytes > u.LargestKeyBytes {
+		u.LargestKey, u.LargestKeyBytes = key, bytes
+	}
+	if i == len(t.namespaces) && len(u.SampleKeys) < maxSampleKeys {
+		u.SampleKeys = append(u.SampleKeys, key)
+	}
+
+	if ttl != -1 {
+		return 0
+	}
+	if i < len(t.namespaces) && t.namespaces[i].TTL > 0 {
+		u.ExpiriesSet++
+		return t.namespaces[i].TTL
+	}
+	u.WithoutExpiry++
+	return 0
+}
+
+// report totals the namespaces, largest first
+func (t *tally) report() *Report {
+	report := &Report{Namespaces: make([]NamespaceUsage, 0, len(t.usage))}
+	for _, u := range t.usage {
+		u.OverBudget = u.Budget > 0 && u.Bytes > u.Budget
+		report.TotalKeys += u.Keys
+		report.TotalBytes += u.Bytes
+		report.ExpiriesSet += u.ExpiriesSet
+		report.Namespaces = append(report.Namespaces, u)
+	}
+	sort.SliceStable(report.Namespaces, func(i, j int) bool {
+		return report.Namespaces[i].Bytes > report.Namespaces[j].Bytes
+	})
+	return report
+}
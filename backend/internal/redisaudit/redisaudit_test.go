@@ -0,0 +1,50 @@
+package redisaudit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTally(t *testing.T) {
+	tl := newTally([]Namespace{
+		{Name: "draft state", Prefix: "draft:state:", TTL: 24 * time.Hour, Budget: 100},
+		{Name: "drafts", Prefix: "draft:"},
+		{Name: "surge", Prefix: "surge:"},
+	})
+
+	// Keys missing an expiry get their namespace's TTL
+	assert.Equal(t, 24*time.Hour, tl.add("draft:state:a", 80, -1))
+	assert.Equal(t, time.Duration(0), tl.add("draft:state:b", 40, time.Hour))
+
+	// The longest prefix wins, and persistent namespaces are left alone
+	assert.Equal(t, time.Duration(0), tl.add("draft:lock:a", 10, -1))
+	assert.Equal(t, time.Duration(0), tl.add("surge:override", 5, -1))
+
+	// Unknown keys are reported but never expired
+	assert.Equal(t, time.Duration(0), tl.add("leaked:1", 500, -1))
+
+	report := tl.report()
+	assert.Equal(t, int64(5), report.TotalKeys)
+	assert.Equal(t, int64(635), report.TotalBytes)
+	assert.Equal(t, int64(1), report.ExpiriesSet)
+
+	require.Len(t, report.Namespaces, 4)
+	other := report.Namespaces[0]
+	assert.Equal(t, OtherNamespace, other.Name)
+	assert.Equal(t, []string{"leaked:1"}, other.SampleKeys)
+	assert.Equal(t, int64(1), other.WithoutExpiry)
+
+	state := report.Namespaces[1]
+	assert.Equal(t, "draft state", state.Name)
+	assert.Equal(t, int64(2), state.Keys)
+	assert.True(t, state.OverBudget)
+	assert.Equal(t, "draft:state:a", state.LargestKey)
+	assert.Equal(t, int64(0), state.WithoutExpiry)
+
+	assert.Equal(t, "drafts", report.Namespaces[2].Name)
+	assert.Equal(t, int64(1), report.Namespaces[2].WithoutExpiry)
+	assert.False(t, report.Namespaces[2].OverBudget)
+}
@@ -3,27 +3,43 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/nfl-analytics/backend/internal/database"
 )
 
-// RefreshToken represents a refresh token in the database
+// RefreshToken represents a refresh token in the database. Its ID is the
+// session it belongs to, kept when the token is rotated.
 type RefreshToken struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Token     string
-	ExpiresAt time.Time
-	CreatedAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Token      string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	UserAgent  string
+	IPAddress  string
+	LastUsedAt time.Time
+	// RememberMe sessions refresh with the remember-me lifetime
+	RememberMe bool
 }
 
 // AuthRepository interface defines authentication data operations
 type AuthRepository interface {
-	StoreRefreshToken(ctx context.Context, userID uuid.UUID, token string, expiresAt time.Time) error
+	StoreRefreshToken(ctx context.Context, token *RefreshToken) error
 	GetRefreshToken(ctx context.Context, token string) (*RefreshToken, error)
+	// RotateRefreshToken replaces a session's token with next's, returning
+	// ErrTokenNotFound if oldToken was already used or revoked
+	RotateRefreshToken(ctx context.Context, oldToken string, next *RefreshToken) error
 	DeleteRefreshToken(ctx context.Context, token string) error
 	DeleteUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
+	// ListSessions returns a user's unexpired sessions, most recently used
+	// first
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]RefreshToken, error)
+	// DeleteSession revokes one of a user's sessions, returning
+	// ErrTokenNotFound if the user has no such session
+	DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error
 	UpdateLastLogin(ctx context.Context, userID uuid.UUID) error
 }
 
@@ -39,23 +55,29 @@ func NewPostgresAuthRepository(db *database.PostgresDB) AuthRepository {
 	}
 }
 
-// StoreRefreshToken stores a new refresh token
-func (r *PostgresAuthRepository) StoreRefreshToken(ctx context.Context, userID uuid.UUID, token string, expiresAt time.Time) error {
+// StoreRefreshToken stores a new refresh token, starting a session
+func (r *PostgresAuthRepository) StoreRefreshToken(ctx context.Context, token *RefreshToken) error {
 	query := `
-		INSERT INTO refresh_tokens (id, user_id, token, expires_at, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO refresh_tokens (id, user_id, token, expires_at, created_at, user_agent, ip_address, last_used_at, remember_me)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, '')::inet, $5, $8)
 	`
-	
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
 	_, err := r.db.DB.ExecContext(
 		ctx,
 		query,
-		uuid.New(),
-		userID,
-		token,
-		expiresAt,
+		token.ID,
+		token.UserID,
+		token.Token,
+		token.ExpiresAt,
 		time.Now(),
+		token.UserAgent,
+		token.IPAddress,
+		token.RememberMe,
 	)
-	
+
 	return err
 }
 
@@ -64,7 +86,7 @@ func (r *PostgresAuthRepository) GetRefreshToken(ctx context.Context, token stri
 	var rt RefreshToken
 	
 	query := `
-		SELECT id, user_id, token, expires_at, created_at
+		SELECT id, user_id, token, expires_at, created_at, remember_me
 		FROM refresh_tokens
 		WHERE token = $1 AND expires_at > $2
 	`
@@ -75,6 +97,7 @@ func (r *PostgresAuthRepository) GetRefreshToken(ctx context.Context, token stri
 		&rt.Token,
 		&rt.ExpiresAt,
 		&rt.CreatedAt,
+		&rt.RememberMe,
 	)
 	
 	if err != nil {
@@ -87,6 +110,69 @@ func (r *PostgresAuthRepository) GetRefreshToken(ctx context.Context, token stri
 	return &rt, nil
 }
 
+// RotateRefreshToken swaps the session's token, refreshing its expiry and
+// the device it was last used from
+func (r *PostgresAuthRepository) RotateRefreshToken(ctx context.Context, oldToken string, next *RefreshToken) error {
+	query := `
+		UPDATE refresh_tokens
+		SET token = $3, expires_at = $4, last_used_at = $5,
+			user_agent = COALESCE(NULLIF($6, ''), user_agent),
+			ip_address = COALESCE(NULLIF($7, '')::inet, ip_address)
+		WHERE id = $1 AND token = $2
+	`
+
+	result, err := r.db.DB.ExecContext(ctx, query,
+		next.ID, oldToken, next.Token, next.ExpiresAt, time.Now(), next.UserAgent, next.IPAddress,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+// ListSessions returns the user's sessions that can still refresh
+func (r *PostgresAuthRepository) ListSessions(ctx context.Context, userID uuid.UUID) ([]RefreshToken, error) {
+	query := `
+		SELECT id, user_id, expires_at, created_at, COALESCE(user_agent, ''),
+			COALESCE(HOST(ip_address), ''), COALESCE(last_used_at, created_at)
+		FROM refresh_tokens
+		WHERE user_id = $1 AND expires_at > $2
+		ORDER BY COALESCE(last_used_at, created_at) DESC
+	`
+
+	rows, err := r.db.DB.QueryContext(ctx, query, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []RefreshToken{}
+	for rows.Next() {
+		var rt RefreshToken
+		if err := rows.Scan(&rt.ID, &rt.UserID, &rt.ExpiresAt, &rt.CreatedAt, &rt.UserAgent, &rt.IPAddress, &rt.LastUsedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, rt)
+	}
+	return sessions, rows.Err()
+}
+
+// DeleteSession deletes one of the user's sessions
+func (r *PostgresAuthRepository) DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	result, err := r.db.DB.ExecContext(ctx,
+		`DELETE FROM refresh_tokens WHERE id = $1 AND user_id = $2`, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
 // DeleteRefreshToken deletes a specific refresh token
 func (r *PostgresAuthRepository) DeleteRefreshToken(ctx context.Context, token string) error {
 	query := `DELETE FROM refresh_tokens WHERE token = $1`
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ConsentRepository defines the interface for user analytics consent
+type ConsentRepository interface {
+	GetAnalyticsConsent(ctx context.Context, userID uuid.UUID) (*bool, error)
+	SetAnalyticsConsent(ctx context.Context, userID uuid.UUID, consented bool) error
+}
+
+// PostgresConsentRepository implements ConsentRepository for PostgreSQL
+type PostgresConsentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresConsentRepository creates a new PostgreSQL consent repository
+func NewPostgresConsentRepository(db *sql.DB) ConsentRepository {
+	return &PostgresConsentRepository{db: db}
+}
+
+// GetAnalyticsConsent returns the user's choice, or nil if they have not made one
+func (r *PostgresConsentRepository) GetAnalyticsConsent(ctx context.Context, userID uuid.UUID) (*bool, error) {
+	query := `SELECT consented FROM user_analytics_consent WHERE user_id = $1`
+
+	var consented bool
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&consented)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get analytics consent: %w", err)
+	}
+
+	return &consented, nil
+}
+
+// SetAnalyticsConsent records the user's choice
+func (r *PostgresConsentRepository) SetAnalyticsConsent(ctx context.Context, userID uuid.UUID, consented bool) error {
+	query := `
+		INSERT INTO user_analytics_consent (user_id, consented, updated_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id) DO UPDATE SET
+			consented = EXCLUDED.consented,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, consented); err != nil {
+		return fmt.Errorf("failed to set analytics consent: %w", err)
+	}
+
+	return nil
+}
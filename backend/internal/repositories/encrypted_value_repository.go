@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of values sealed with the encryption key
+const (
+	EncryptedLeagueCredentials = "league_credentials"
+	EncryptedTwoFactorSecrets  = "two_factor_secrets"
+)
+
+// EncryptedKinds are every kind of encrypted value, in the order they are
+// re-encrypted
+var EncryptedKinds = []string{EncryptedLeagueCredentials, EncryptedTwoFactorSecrets}
+
+// EncryptedValue is one stored encrypted value
+type EncryptedValue struct {
+	ID         uuid.UUID
+	Ciphertext string
+}
+
+// EncryptedValueRepository reads and replaces encrypted values of every
+// kind, for moving them to a new encryption key
+type EncryptedValueRepository interface {
+	// List returns up to limit values of a kind with IDs after afterID, by ID
+	List(ctx context.Context, kind string, afterID uuid.UUID, limit int) ([]EncryptedValue, error)
+	// Replace swaps a value's ciphertext if it is still old, reporting
+	// whether it was, so a value the user changed meanwhile is kept
+	Replace(ctx context.Context, kind string, id uuid.UUID, old, new string) (bool, error)
+}
+
+// encryptedColumn is where a kind of value is stored
+type encryptedColumn struct {
+	table  string
+	id     string
+	column string
+	// binary columns are BYTEA
+	binary bool
+}
+
+var encryptedColumns = map[string]encryptedColumn{
+	EncryptedLeagueCredentials: {table: "league_auth", id: "id", column: "encrypted_credentials", binary: true},
+	EncryptedTwoFactorSecrets:  {table: "user_two_factor", id: "user_id", column: "secret_encrypted"},
+}
+
+// PostgresEncryptedValueRepository implements EncryptedValueRepository for PostgreSQL
+type PostgresEncryptedValueRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresEncryptedValueRepository creates a new PostgreSQL encrypted value repository
+func NewPostgresEncryptedValueRepository(db *sql.DB) EncryptedValueRepository {
+	return &PostgresEncryptedValueRepository{db: db}
+}
+
+// List returns up to limit values of a kind with IDs after afterID
+func (r *PostgresEncryptedValueRepository) List(ctx context.Context, kind string, afterID uuid.UUID, limit int) ([]EncryptedValue, error) {
+	col, ok := encryptedColumns[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown encrypted value kind %q", kind)
+	}
+
+	query := fmt.Sprintf(`SELECT %s, %s FROM %s WHERE %s > $1 ORDER BY %s LIMIT $2`,
+		col.id, col.column, col.table, col.id, col.id)
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", kind, err)
+	}
+	defer rows.Close()
+
+	var values []EncryptedValue
+	for rows.Next() {
+		var v EncryptedValue
+		var ciphertext []byte
+		if err := rows.Scan(&v.ID, &ciphertext); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", kind, err)
+		}
+		v.Ciphertext = string(ciphertext)
+		values = append(values, v)
+	}
+
+	return values, rows.Err()
+}
+
+// Replace swaps a value's ciphertext if it is still old
+func (r *PostgresEncryptedValueRepository) Replace(ctx context.Context, kind string, id uuid.UUID, old, new string) (bool, error) {
+	col, ok := encryptedColumns[kind]
+	if !ok {
+		return false, fmt.Errorf("unknown encrypted value kind %q", kind)
+	}
+
+	var oldArg, newArg interface{} = old, new
+	if col.binary {
+		oldArg, newArg = []byte(old), []byte(new)
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET %s = $3 WHERE %s = $1 AND %s = $2`,
+		col.table, col.column, col.id, col.column)
+	result, err := r.db.ExecContext(ctx, query, id, oldArg, newArg)
+	if err != nil {
+		return false, fmt.Errorf("failed to replace %s: %w", kind, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to replace %s: %w", kind, err)
+	}
+	return n > 0, nil
+}
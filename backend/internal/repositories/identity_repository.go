@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/database"
+)
+
+// ErrIdentityNotFound is returned when no user is linked to a provider account
+var ErrIdentityNotFound = errors.New("identity not found")
+
+// IdentityRepository links social sign-in accounts to users
+type IdentityRepository interface {
+	// GetUserID returns the user linked to a provider account and marks the
+	// link as used
+	GetUserID(ctx context.Context, provider, subject string) (uuid.UUID, error)
+	// Link ties a provider account to a user. Linking an account that is
+	// already linked leaves it with its user.
+	Link(ctx context.Context, userID uuid.UUID, provider, subject, email string) error
+}
+
+// PostgresIdentityRepository implements IdentityRepository using PostgreSQL
+type PostgresIdentityRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresIdentityRepository creates a new PostgreSQL identity repository
+func NewPostgresIdentityRepository(db *database.PostgresDB) IdentityRepository {
+	return &PostgresIdentityRepository{
+		db: db,
+	}
+}
+
+// GetUserID returns the user linked to a provider account
+func (r *PostgresIdentityRepository) GetUserID(ctx context.Context, provider, subject string) (uuid.UUID, error) {
+	query := `
+		UPDATE user_identities SET last_used_at = CURRENT_TIMESTAMP
+		WHERE provider = $1 AND subject = $2
+		RETURNING user_id
+	`
+
+	var userID uuid.UUID
+	err := r.db.DB.QueryRowContext(ctx, query, provider, subject).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, ErrIdentityNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+	return userID, nil
+}
+
+// Link ties a provider account to a user
+func (r *PostgresIdentityRepository) Link(ctx context.Context, userID uuid.UUID, provider, subject, email string) error {
+	query := `
+		INSERT INTO user_identities (provider, subject, user_id, email)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, subject) DO NOTHING
+	`
+
+	if _, err := r.db.DB.ExecContext(ctx, query, provider, subject, userID, email); err != nil {
+		return fmt.Errorf("failed to link identity: %w", err)
+	}
+	return nil
+}
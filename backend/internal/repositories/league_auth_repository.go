@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,6 +24,7 @@ type LeagueAuthRepository interface {
 	Update(ctx context.Context, auth *models.LeagueAuth) error
 	Delete(ctx context.Context, userID uuid.UUID, platform string) error
 	GetAllByUser(ctx context.Context, userID uuid.UUID) ([]*models.LeagueAuth, error)
+	MarkInvalid(ctx context.Context, userID uuid.UUID, platform, reason string, at time.Time) error
 }
 
 // postgresLeagueAuthRepository implements LeagueAuthRepository using PostgreSQL
@@ -42,7 +44,9 @@ func (r *postgresLeagueAuthRepository) Store(ctx context.Context, auth *models.L
 		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (user_id, platform) DO UPDATE SET
 		encrypted_credentials = EXCLUDED.encrypted_credentials,
-		updated_at = EXCLUDED.updated_at`
+		updated_at = EXCLUDED.updated_at,
+		invalid_at = NULL,
+		invalid_reason = NULL`
 	
 	_, err := r.db.ExecContext(ctx, query,
 		auth.ID,
@@ -63,7 +67,7 @@ func (r *postgresLeagueAuthRepository) Store(ctx context.Context, auth *models.L
 // GetByUserAndPlatform retrieves league auth by user ID and platform
 func (r *postgresLeagueAuthRepository) GetByUserAndPlatform(ctx context.Context, userID uuid.UUID, platform string) (*models.LeagueAuth, error) {
 	query := `
-		SELECT id, user_id, platform, encrypted_credentials, created_at, updated_at
+		SELECT id, user_id, platform, encrypted_credentials, created_at, updated_at, invalid_at, invalid_reason
 		FROM league_auth
 		WHERE user_id = $1 AND platform = $2`
 	
@@ -75,6 +79,8 @@ func (r *postgresLeagueAuthRepository) GetByUserAndPlatform(ctx context.Context,
 		&auth.EncryptedCredentials,
 		&auth.CreatedAt,
 		&auth.UpdatedAt,
+		&auth.InvalidAt,
+		&auth.InvalidReason,
 	)
 	
 	if err != nil {
@@ -91,7 +97,7 @@ func (r *postgresLeagueAuthRepository) GetByUserAndPlatform(ctx context.Context,
 func (r *postgresLeagueAuthRepository) Update(ctx context.Context, auth *models.LeagueAuth) error {
 	query := `
 		UPDATE league_auth
-		SET encrypted_credentials = $1, updated_at = $2
+		SET encrypted_credentials = $1, updated_at = $2, invalid_at = NULL, invalid_reason = NULL
 		WHERE user_id = $3 AND platform = $4`
 	
 	result, err := r.db.ExecContext(ctx, query,
@@ -141,7 +147,7 @@ func (r *postgresLeagueAuthRepository) Delete(ctx context.Context, userID uuid.U
 // GetAllByUser retrieves all league auth records for a user
 func (r *postgresLeagueAuthRepository) GetAllByUser(ctx context.Context, userID uuid.UUID) ([]*models.LeagueAuth, error) {
 	query := `
-		SELECT id, user_id, platform, encrypted_credentials, created_at, updated_at
+		SELECT id, user_id, platform, encrypted_credentials, created_at, updated_at, invalid_at, invalid_reason
 		FROM league_auth
 		WHERE user_id = $1
 		ORDER BY platform`
@@ -162,6 +168,8 @@ func (r *postgresLeagueAuthRepository) GetAllByUser(ctx context.Context, userID
 			&auth.EncryptedCredentials,
 			&auth.CreatedAt,
 			&auth.UpdatedAt,
+			&auth.InvalidAt,
+			&auth.InvalidReason,
 		)
 		if err != nil {
 			return nil, err
@@ -174,4 +182,18 @@ func (r *postgresLeagueAuthRepository) GetAllByUser(ctx context.Context, userID
 	}
 	
 	return auths, nil
-}
\ No newline at end of file
+}
+
+// MarkInvalid records that the platform rejected a user's stored credentials
+func (r *postgresLeagueAuthRepository) MarkInvalid(ctx context.Context, userID uuid.UUID, platform, reason string, at time.Time) error {
+	query := `
+		UPDATE league_auth
+		SET invalid_at = $1, invalid_reason = $2
+		WHERE user_id = $3 AND platform = $4 AND invalid_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, at, reason, userID, platform); err != nil {
+		return fmt.Errorf("failed to mark credentials invalid: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// LeagueHistoryRepository defines the interface for past season data access
+type LeagueHistoryRepository interface {
+	SaveSeason(ctx context.Context, history *models.LeagueSeasonHistory) error
+	GetSeasons(ctx context.Context, leagueID string) ([]models.LeagueSeasonHistory, error)
+	GetSeason(ctx context.Context, leagueID string, season int) (*models.LeagueSeasonHistory, error)
+}
+
+// PostgresLeagueHistoryRepository implements LeagueHistoryRepository for PostgreSQL
+type PostgresLeagueHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLeagueHistoryRepository creates a new PostgreSQL league history repository
+func NewPostgresLeagueHistoryRepository(db *sql.DB) LeagueHistoryRepository {
+	return &PostgresLeagueHistoryRepository{db: db}
+}
+
+// SaveSeason stores a past season, replacing an earlier import
+func (r *PostgresLeagueHistoryRepository) SaveSeason(ctx context.Context, history *models.LeagueSeasonHistory) error {
+	query := `
+		INSERT INTO league_season_history (league_id, season, standings, matchups, draft_picks, imported_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (league_id, season) DO UPDATE SET
+			standings = EXCLUDED.standings,
+			matchups = EXCLUDED.matchups,
+			draft_picks = EXCLUDED.draft_picks,
+			imported_at = EXCLUDED.imported_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		history.LeagueID,
+		history.Season,
+		[]byte(history.Standings),
+		[]byte(history.Matchups),
+		[]byte(history.DraftPicks),
+		history.ImportedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save %d season history: %w", history.Season, err)
+	}
+
+	return nil
+}
+
+// GetSeasons retrieves every imported season of a league, most recent first
+func (r *PostgresLeagueHistoryRepository) GetSeasons(ctx context.Context, leagueID string) ([]models.LeagueSeasonHistory, error) {
+	query := `
+		SELECT league_id, season, standings, matchups, draft_picks, imported_at
+		FROM league_season_history
+		WHERE league_id = $1
+		ORDER BY season DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query season history: %w", err)
+	}
+	defer rows.Close()
+
+	var seasons []models.LeagueSeasonHistory
+	for rows.Next() {
+		var h models.LeagueSeasonHistory
+		if err := rows.Scan(&h.LeagueID, &h.Season, &h.Standings, &h.Matchups, &h.DraftPicks, &h.ImportedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan season history: %w", err)
+		}
+		seasons = append(seasons, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read season history: %w", err)
+	}
+
+	return seasons, nil
+}
+
+// GetSeason retrieves one imported season, or nil if it was never imported
+func (r *PostgresLeagueHistoryRepository) GetSeason(ctx context.Context, leagueID string, season int) (*models.LeagueSeasonHistory, error) {
+	query := `
+		SELECT league_id, season, standings, matchups, draft_picks, imported_at
+		FROM league_season_history
+		WHERE league_id = $1 AND season = $2
+	`
+
+	var h models.LeagueSeasonHistory
+	err := r.db.QueryRowContext(ctx, query, leagueID, season).Scan(
+		&h.LeagueID, &h.Season, &h.Standings, &h.Matchups, &h.DraftPicks, &h.ImportedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get season history: %w", err)
+	}
+
+	return &h, nil
+}
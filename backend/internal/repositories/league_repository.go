@@ -4,12 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/nfl-analytics/backend/internal/models"
 )
 
+// ErrLeagueNotFound is returned when a league does not exist
+var ErrLeagueNotFound = errors.New("league not found")
+
 // LeagueRepository defines the interface for league data access
 type LeagueRepository interface {
 	Create(ctx context.Context, league *models.League) error
@@ -19,6 +23,10 @@ type LeagueRepository interface {
 	Update(ctx context.Context, league *models.League) error
 	Delete(ctx context.Context, id string) error
 	GetActiveLeagues(ctx context.Context) ([]*models.League, error)
+	UpdateLastSync(ctx context.Context, id string, syncedAt time.Time) error
+	SetSelectedLeague(ctx context.Context, userID, leagueID string) error
+	GetSelectedLeagueID(ctx context.Context, userID string) (string, error)
+	ClearSelectedLeague(ctx context.Context, userID string) error
 }
 
 // PostgresLeagueRepository implements LeagueRepository for PostgreSQL
@@ -95,7 +103,7 @@ func (r *PostgresLeagueRepository) GetByID(ctx context.Context, id string) (*mod
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("league not found")
+		return nil, ErrLeagueNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get league: %w", err)
@@ -235,7 +243,7 @@ func (r *PostgresLeagueRepository) Update(ctx context.Context, league *models.Le
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("league not found")
+		return ErrLeagueNotFound
 	}
 
 	return nil
@@ -256,7 +264,28 @@ func (r *PostgresLeagueRepository) Delete(ctx context.Context, id string) error
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("league not found")
+		return ErrLeagueNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastSync records when a league was last synced from its platform
+func (r *PostgresLeagueRepository) UpdateLastSync(ctx context.Context, id string, syncedAt time.Time) error {
+	query := `UPDATE leagues SET last_sync_at = $2, updated_at = $3 WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, syncedAt, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to update last sync: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrLeagueNotFound
 	}
 
 	return nil
@@ -311,4 +340,48 @@ func (r *PostgresLeagueRepository) GetActiveLeagues(ctx context.Context) ([]*mod
 	}
 
 	return leagues, nil
-}
\ No newline at end of file
+}
+// SetSelectedLeague records the league a user has selected for analytics
+func (r *PostgresLeagueRepository) SetSelectedLeague(ctx context.Context, userID, leagueID string) error {
+	query := `
+		INSERT INTO user_selected_leagues (user_id, league_id, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+			league_id = EXCLUDED.league_id,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, leagueID, time.Now()); err != nil {
+		return fmt.Errorf("failed to set selected league: %w", err)
+	}
+
+	return nil
+}
+
+// GetSelectedLeagueID returns the ID of the user's selected league, or an
+// empty string if they have not selected one
+func (r *PostgresLeagueRepository) GetSelectedLeagueID(ctx context.Context, userID string) (string, error) {
+	query := `SELECT league_id FROM user_selected_leagues WHERE user_id = $1`
+
+	var leagueID string
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&leagueID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get selected league: %w", err)
+	}
+
+	return leagueID, nil
+}
+
+// ClearSelectedLeague removes the user's league selection
+func (r *PostgresLeagueRepository) ClearSelectedLeague(ctx context.Context, userID string) error {
+	query := `DELETE FROM user_selected_leagues WHERE user_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to clear selected league: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// LeagueRosterRepository defines the interface for synced roster data access
+type LeagueRosterRepository interface {
+	SaveRosters(ctx context.Context, leagueID uuid.UUID, season, week int, entries []models.LeagueRosterEntry) error
+	GetRosters(ctx context.Context, leagueID string, season, week int) ([]models.LeagueRosterEntry, error)
+	GetLatestWeek(ctx context.Context, leagueID string, season int) (int, error)
+	GetTeamHistory(ctx context.Context, leagueID string, season, teamID int) ([]models.LeagueRosterEntry, error)
+	GetEligibility(ctx context.Context, leagueID string) (map[string][]string, error)
+	SaveEligibilityChanges(ctx context.Context, changes []models.EligibilityChange) error
+	GetEligibilityChanges(ctx context.Context, leagueID string, season int) ([]models.EligibilityChange, error)
+}
+
+// PostgresLeagueRosterRepository implements LeagueRosterRepository for PostgreSQL
+type PostgresLeagueRosterRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLeagueRosterRepository creates a new PostgreSQL league roster repository
+func NewPostgresLeagueRosterRepository(db *sql.DB) LeagueRosterRepository {
+	return &PostgresLeagueRosterRepository{db: db}
+}
+
+// SaveRosters replaces a league week's rosters and updates the details of
+// every player on them. Players dropped since the last sync of the week are
+// removed from it.
+func (r *PostgresLeagueRosterRepository) SaveRosters(ctx context.Context, leagueID uuid.UUID, season, week int, entries []models.LeagueRosterEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM league_rosters WHERE league_id = $1 AND season = $2 AND week = $3`,
+		leagueID.String(), season, week,
+	); err != nil {
+		return fmt.Errorf("failed to clear week %d rosters: %w", week, err)
+	}
+
+	playerQuery := `
+		INSERT INTO league_players (
+			league_id, player_id, player_name, position, nfl_team, injury_status, eligible_positions, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (league_id, player_id) DO UPDATE SET
+			player_name = EXCLUDED.player_name,
+			position = EXCLUDED.position,
+			nfl_team = EXCLUDED.nfl_team,
+			injury_status = EXCLUDED.injury_status,
+			eligible_positions = EXCLUDED.eligible_positions,
+			updated_at = NOW()
+	`
+	rosterQuery := `
+		INSERT INTO league_rosters (league_id, season, week, team_id, player_id, lineup_slot, acquired_at, synced_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (league_id, season, week, team_id, player_id) DO UPDATE SET
+			lineup_slot = EXCLUDED.lineup_slot,
+			acquired_at = EXCLUDED.acquired_at,
+			synced_at = EXCLUDED.synced_at
+	`
+
+	syncedAt := time.Now()
+	for _, e := range entries {
+		injuryStatus := sql.NullString{String: e.InjuryStatus, Valid: e.InjuryStatus != ""}
+		if _, err := tx.ExecContext(ctx, playerQuery,
+			leagueID.String(), e.PlayerID, e.PlayerName, e.Position, e.NFLTeam, injuryStatus, pq.Array(e.EligiblePositions),
+		); err != nil {
+			return fmt.Errorf("failed to save player %s: %w", e.PlayerName, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, rosterQuery,
+			leagueID.String(), season, week, e.TeamID, e.PlayerID, e.LineupSlot, e.AcquiredAt, syncedAt,
+		); err != nil {
+			return fmt.Errorf("failed to save roster entry for %s: %w", e.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rosters: %w", err)
+	}
+
+	return nil
+}
+
+// GetRosters retrieves every team's roster for a league week
+func (r *PostgresLeagueRosterRepository) GetRosters(ctx context.Context, leagueID string, season, week int) ([]models.LeagueRosterEntry, error) {
+	query := rosterSelect + `
+		WHERE r.league_id = $1 AND r.season = $2 AND r.week = $3
+		ORDER BY r.team_id, r.lineup_slot, p.player_name
+	`
+
+	return r.queryEntries(ctx, query, leagueID, season, week)
+}
+
+// GetLatestWeek returns the most recent synced week of a season, or 0 if the
+// season has no rosters
+func (r *PostgresLeagueRosterRepository) GetLatestWeek(ctx context.Context, leagueID string, season int) (int, error) {
+	query := `
+		SELECT COALESCE(MAX(week), 0)
+		FROM league_rosters
+		WHERE league_id = $1 AND season = $2
+	`
+
+	var week int
+	if err := r.db.QueryRowContext(ctx, query, leagueID, season).Scan(&week); err != nil {
+		return 0, fmt.Errorf("failed to get latest roster week: %w", err)
+	}
+
+	return week, nil
+}
+
+// GetTeamHistory retrieves a team's roster for every synced week of a
+// season, in week order
+func (r *PostgresLeagueRosterRepository) GetTeamHistory(ctx context.Context, leagueID string, season, teamID int) ([]models.LeagueRosterEntry, error) {
+	query := rosterSelect + `
+		WHERE r.league_id = $1 AND r.season = $2 AND r.team_id = $3
+		ORDER BY r.week, r.lineup_slot, p.player_name
+	`
+
+	return r.queryEntries(ctx, query, leagueID, season, teamID)
+}
+
+// GetEligibility returns the positions each player seen in a league could
+// start at as of the last sync, keyed by player ID
+func (r *PostgresLeagueRosterRepository) GetEligibility(ctx context.Context, leagueID string) (map[string][]string, error) {
+	query := `
+		SELECT player_id, eligible_positions
+		FROM league_players
+		WHERE league_id = $1 AND eligible_positions IS NOT NULL
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query eligibility: %w", err)
+	}
+	defer rows.Close()
+
+	eligibility := make(map[string][]string)
+	for rows.Next() {
+		var playerID string
+		var positions []string
+		if err := rows.Scan(&playerID, pq.Array(&positions)); err != nil {
+			return nil, fmt.Errorf("failed to scan eligibility: %w", err)
+		}
+		eligibility[playerID] = positions
+	}
+
+	return eligibility, rows.Err()
+}
+
+// SaveEligibilityChanges records players' eligibility changes
+func (r *PostgresLeagueRosterRepository) SaveEligibilityChanges(ctx context.Context, changes []models.EligibilityChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO player_eligibility_changes (
+			league_id, season, week, team_id, player_id, player_name, added, removed, positions, detected_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	for _, c := range changes {
+		_, err := tx.ExecContext(ctx, query,
+			c.LeagueID.String(), c.Season, c.Week, c.TeamID, c.PlayerID, c.PlayerName,
+			pq.Array(c.Added), pq.Array(c.Removed), pq.Array(c.Positions), c.DetectedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save eligibility change for %s: %w", c.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit eligibility changes: %w", err)
+	}
+
+	return nil
+}
+
+// GetEligibilityChanges retrieves a league's eligibility changes in a
+// season, newest first
+func (r *PostgresLeagueRosterRepository) GetEligibilityChanges(ctx context.Context, leagueID string, season int) ([]models.EligibilityChange, error) {
+	query := `
+		SELECT league_id, season, week, team_id, player_id, player_name, added, removed, positions, detected_at
+		FROM player_eligibility_changes
+		WHERE league_id = $1 AND season = $2
+		ORDER BY detected_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query eligibility changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []models.EligibilityChange
+	for rows.Next() {
+		var c models.EligibilityChange
+		var playerName sql.NullString
+		if err := rows.Scan(
+			&c.LeagueID, &c.Season, &c.Week, &c.TeamID, &c.PlayerID, &playerName,
+			pq.Array(&c.Added), pq.Array(&c.Removed), pq.Array(&c.Positions), &c.DetectedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan eligibility change: %w", err)
+		}
+		c.PlayerName = playerName.String
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
+
+// rosterSelect joins roster rows to their players' details
+const rosterSelect = `
+	SELECT r.league_id, r.season, r.week, r.team_id, r.player_id, p.player_name, p.position,
+		p.nfl_team, p.injury_status, p.eligible_positions, r.lineup_slot, r.acquired_at, r.synced_at
+	FROM league_rosters r
+	LEFT JOIN league_players p ON p.league_id = r.league_id AND p.player_id = r.player_id
+`
+
+// queryEntries runs a rosterSelect query
+func (r *PostgresLeagueRosterRepository) queryEntries(ctx context.Context, query string, args ...interface{}) ([]models.LeagueRosterEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rosters: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.LeagueRosterEntry
+	for rows.Next() {
+		var e models.LeagueRosterEntry
+		var playerName, position, nflTeam, injuryStatus, lineupSlot sql.NullString
+		var acquiredAt sql.NullTime
+
+		if err := rows.Scan(
+			&e.LeagueID, &e.Season, &e.Week, &e.TeamID, &e.PlayerID, &playerName, &position,
+			&nflTeam, &injuryStatus, pq.Array(&e.EligiblePositions), &lineupSlot, &acquiredAt, &e.SyncedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan roster entry: %w", err)
+		}
+
+		e.PlayerName = playerName.String
+		e.Position = position.String
+		e.NFLTeam = nflTeam.String
+		e.InjuryStatus = injuryStatus.String
+		e.LineupSlot = lineupSlot.String
+		if acquiredAt.Valid {
+			e.AcquiredAt = &acquiredAt.Time
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
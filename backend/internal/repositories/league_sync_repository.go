@@ -0,0 +1,326 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// LeagueSyncRepository defines the interface for league sync data access
+type LeagueSyncRepository interface {
+	SaveSnapshot(ctx context.Context, leagueID, dataType string, week int, payload interface{}) error
+	GetLatestSnapshot(ctx context.Context, leagueID, dataType string, dest interface{}) (bool, error)
+	ListSnapshots(ctx context.Context, leagueID, dataType string, since time.Time) ([]json.RawMessage, error)
+	RecordSuccess(ctx context.Context, leagueID string, syncedAt time.Time) error
+	RecordFailure(ctx context.Context, leagueID string, attemptedAt time.Time, syncErr error) error
+	GetStatus(ctx context.Context, leagueID string) (*models.LeagueSyncStatus, error)
+	GetRosteredBy(ctx context.Context, playerNames []string) (map[string][]uuid.UUID, error)
+	SaveBoxScores(ctx context.Context, scores []models.PlayerBoxScore) error
+	GetBoxScores(ctx context.Context, leagueID string, season, week int) ([]models.PlayerBoxScore, error)
+}
+
+// PostgresLeagueSyncRepository implements LeagueSyncRepository for PostgreSQL
+type PostgresLeagueSyncRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLeagueSyncRepository creates a new PostgreSQL league sync repository
+func NewPostgresLeagueSyncRepository(db *sql.DB) LeagueSyncRepository {
+	return &PostgresLeagueSyncRepository{db: db}
+}
+
+// SaveSnapshot replaces the stored snapshot for a league, data type and week
+func (r *PostgresLeagueSyncRepository) SaveSnapshot(ctx context.Context, leagueID, dataType string, week int, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s snapshot: %w", dataType, err)
+	}
+
+	query := `
+		INSERT INTO league_sync_snapshots (league_id, data_type, week, payload, synced_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (league_id, data_type, week) DO UPDATE SET
+			payload = EXCLUDED.payload,
+			synced_at = EXCLUDED.synced_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, leagueID, dataType, week, payloadJSON, time.Now()); err != nil {
+		return fmt.Errorf("failed to save %s snapshot: %w", dataType, err)
+	}
+
+	return nil
+}
+
+// GetLatestSnapshot decodes the league's most recent snapshot of a data type
+// into dest. It returns false if the league has none.
+func (r *PostgresLeagueSyncRepository) GetLatestSnapshot(ctx context.Context, leagueID, dataType string, dest interface{}) (bool, error) {
+	query := `
+		SELECT payload
+		FROM league_sync_snapshots
+		WHERE league_id = $1 AND data_type = $2
+		ORDER BY synced_at DESC
+		LIMIT 1
+	`
+
+	var payload []byte
+	err := r.db.QueryRowContext(ctx, query, leagueID, dataType).Scan(&payload)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s snapshot: %w", dataType, err)
+	}
+
+	if err := json.Unmarshal(payload, dest); err != nil {
+		return false, fmt.Errorf("failed to unmarshal %s snapshot: %w", dataType, err)
+	}
+
+	return true, nil
+}
+
+// ListSnapshots returns the payloads of a league's snapshots of a data type
+// synced since the given time, one per week, oldest first
+func (r *PostgresLeagueSyncRepository) ListSnapshots(ctx context.Context, leagueID, dataType string, since time.Time) ([]json.RawMessage, error) {
+	query := `
+		SELECT payload
+		FROM league_sync_snapshots
+		WHERE league_id = $1 AND data_type = $2 AND synced_at >= $3
+		ORDER BY synced_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID, dataType, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s snapshots: %w", dataType, err)
+	}
+	defer rows.Close()
+
+	var payloads []json.RawMessage
+	for rows.Next() {
+		var payload []byte
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("failed to scan %s snapshot: %w", dataType, err)
+		}
+		payloads = append(payloads, payload)
+	}
+
+	return payloads, rows.Err()
+}
+
+// RecordSuccess marks a sync as successful and clears any previous error
+func (r *PostgresLeagueSyncRepository) RecordSuccess(ctx context.Context, leagueID string, syncedAt time.Time) error {
+	query := `
+		INSERT INTO league_sync_status (
+			league_id, last_attempt_at, last_success_at, last_error, consecutive_failures, updated_at
+		) VALUES ($1, $2, $2, NULL, 0, NOW())
+		ON CONFLICT (league_id) DO UPDATE SET
+			last_attempt_at = EXCLUDED.last_attempt_at,
+			last_success_at = EXCLUDED.last_success_at,
+			last_error = NULL,
+			consecutive_failures = 0,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, leagueID, syncedAt); err != nil {
+		return fmt.Errorf("failed to record sync success: %w", err)
+	}
+
+	return nil
+}
+
+// RecordFailure stores the sync error and increments the failure count
+func (r *PostgresLeagueSyncRepository) RecordFailure(ctx context.Context, leagueID string, attemptedAt time.Time, syncErr error) error {
+	query := `
+		INSERT INTO league_sync_status (
+			league_id, last_attempt_at, last_error, consecutive_failures, updated_at
+		) VALUES ($1, $2, $3, 1, NOW())
+		ON CONFLICT (league_id) DO UPDATE SET
+			last_attempt_at = EXCLUDED.last_attempt_at,
+			last_error = EXCLUDED.last_error,
+			consecutive_failures = league_sync_status.consecutive_failures + 1,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, leagueID, attemptedAt, syncErr.Error()); err != nil {
+		return fmt.Errorf("failed to record sync failure: %w", err)
+	}
+
+	return nil
+}
+
+// GetStatus retrieves the sync status for a league
+func (r *PostgresLeagueSyncRepository) GetStatus(ctx context.Context, leagueID string) (*models.LeagueSyncStatus, error) {
+	query := `
+		SELECT league_id, last_attempt_at, last_success_at, last_error, consecutive_failures, updated_at
+		FROM league_sync_status
+		WHERE league_id = $1
+	`
+
+	status := &models.LeagueSyncStatus{}
+	err := r.db.QueryRowContext(ctx, query, leagueID).Scan(
+		&status.LeagueID,
+		&status.LastAttemptAt,
+		&status.LastSuccessAt,
+		&status.LastError,
+		&status.ConsecutiveFailures,
+		&status.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // Never synced
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync status: %w", err)
+	}
+
+	return status, nil
+}
+
+// GetRosteredBy finds the users who have each player on their own roster in
+// an active league, keyed by player name. Only each league's latest synced
+// week is considered.
+func (r *PostgresLeagueSyncRepository) GetRosteredBy(ctx context.Context, playerNames []string) (map[string][]uuid.UUID, error) {
+	owners := make(map[string][]uuid.UUID)
+	if len(playerNames) == 0 {
+		return owners, nil
+	}
+
+	query := `
+		SELECT DISTINCT player->>'playerName', l.user_id
+		FROM league_sync_snapshots s
+		JOIN leagues l ON l.id::text = s.league_id AND l.is_active = true
+		CROSS JOIN LATERAL jsonb_array_elements(s.payload->'players') AS player
+		WHERE s.data_type = 'user_roster'
+			AND s.week = (
+				SELECT MAX(week) FROM league_sync_snapshots latest
+				WHERE latest.league_id = s.league_id AND latest.data_type = s.data_type
+			)
+			AND player->>'playerName' = ANY($1)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(playerNames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rostered players: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerName string
+		var userID uuid.UUID
+		if err := rows.Scan(&playerName, &userID); err != nil {
+			return nil, fmt.Errorf("failed to scan rostered player: %w", err)
+		}
+		owners[playerName] = append(owners[playerName], userID)
+	}
+
+	return owners, rows.Err()
+}
+
+// SaveBoxScores upserts players' weekly box scores, so stat corrections
+// overwrite earlier pulls
+func (r *PostgresLeagueSyncRepository) SaveBoxScores(ctx context.Context, scores []models.PlayerBoxScore) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO league_box_scores (
+			league_id, season, week, team_id, opponent_id, player_id, player_name,
+			position, nfl_team, lineup_slot, starter, points, projected_points, stats, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW())
+		ON CONFLICT (league_id, season, week, team_id, player_id) DO UPDATE SET
+			opponent_id = EXCLUDED.opponent_id,
+			player_name = EXCLUDED.player_name,
+			position = EXCLUDED.position,
+			nfl_team = EXCLUDED.nfl_team,
+			lineup_slot = EXCLUDED.lineup_slot,
+			starter = EXCLUDED.starter,
+			points = EXCLUDED.points,
+			projected_points = EXCLUDED.projected_points,
+			stats = EXCLUDED.stats,
+			updated_at = NOW()
+	`
+
+	for _, s := range scores {
+		statsJSON, err := json.Marshal(s.Stats)
+		if err != nil {
+			return fmt.Errorf("failed to marshal stats: %w", err)
+		}
+		opponentID := sql.NullInt64{Int64: int64(s.OpponentID), Valid: s.OpponentID != 0}
+
+		_, err = tx.ExecContext(ctx, query,
+			s.LeagueID.String(), s.Season, s.Week, s.TeamID, opponentID, s.PlayerID, s.PlayerName,
+			s.Position, s.NFLTeam, s.LineupSlot, s.Starter, s.Points, s.ProjectedPoints, statsJSON,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save box score for %s: %w", s.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit box scores: %w", err)
+	}
+
+	return nil
+}
+
+// GetBoxScores retrieves every player's box score for a league week
+func (r *PostgresLeagueSyncRepository) GetBoxScores(ctx context.Context, leagueID string, season, week int) ([]models.PlayerBoxScore, error) {
+	query := `
+		SELECT league_id, season, week, team_id, opponent_id, player_id, player_name,
+			position, nfl_team, lineup_slot, starter, points, projected_points, stats, updated_at
+		FROM league_box_scores
+		WHERE league_id = $1 AND season = $2 AND week = $3
+		ORDER BY team_id, starter DESC, points DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID, season, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query box scores: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []models.PlayerBoxScore
+	for rows.Next() {
+		var s models.PlayerBoxScore
+		var opponentID sql.NullInt64
+		var playerName, position, nflTeam, lineupSlot sql.NullString
+		var points, projectedPoints sql.NullFloat64
+		var statsJSON []byte
+
+		if err := rows.Scan(
+			&s.LeagueID, &s.Season, &s.Week, &s.TeamID, &opponentID, &s.PlayerID, &playerName,
+			&position, &nflTeam, &lineupSlot, &s.Starter, &points, &projectedPoints, &statsJSON, &s.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan box score: %w", err)
+		}
+
+		s.OpponentID = int(opponentID.Int64)
+		s.PlayerName = playerName.String
+		s.Position = position.String
+		s.NFLTeam = nflTeam.String
+		s.LineupSlot = lineupSlot.String
+		s.Points = points.Float64
+		s.ProjectedPoints = projectedPoints.Float64
+		if len(statsJSON) > 0 {
+			if err := json.Unmarshal(statsJSON, &s.Stats); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal stats: %w", err)
+			}
+		}
+
+		scores = append(scores, s)
+	}
+
+	return scores, rows.Err()
+}
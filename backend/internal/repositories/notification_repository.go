@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// ErrNotificationNotFound is returned when a notification does not exist for the user
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	Create(ctx context.Context, notifications []models.Notification) error
+	GetByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, id int64) error
+}
+
+// PostgresNotificationRepository implements NotificationRepository for PostgreSQL
+type PostgresNotificationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresNotificationRepository creates a new PostgreSQL notification repository
+func NewPostgresNotificationRepository(db *sql.DB) NotificationRepository {
+	return &PostgresNotificationRepository{db: db}
+}
+
+// Create stores a batch of notifications
+func (r *PostgresNotificationRepository) Create(ctx context.Context, notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO notifications (user_id, type, title, body, data, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`
+
+	for _, n := range notifications {
+		var data interface{}
+		if len(n.Data) > 0 {
+			data = []byte(n.Data)
+		}
+		if _, err := tx.ExecContext(ctx, query, n.UserID, n.Type, n.Title, n.Body, data); err != nil {
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit notifications: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser retrieves a user's notifications, newest first
+func (r *PostgresNotificationRepository) GetByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error) {
+	query := `
+		SELECT id, user_id, type, title, COALESCE(body, ''), data, created_at, read_at
+		FROM notifications
+		WHERE user_id = $1 AND ($2 = false OR read_at IS NULL)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, unreadOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		var n models.Notification
+		var data []byte
+		if err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.Type,
+			&n.Title,
+			&n.Body,
+			&data,
+			&n.CreatedAt,
+			&n.ReadAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		n.Data = data
+		notifications = append(notifications, n)
+	}
+
+	return notifications, rows.Err()
+}
+
+// MarkRead marks one of the user's notifications as read
+func (r *PostgresNotificationRepository) MarkRead(ctx context.Context, userID uuid.UUID, id int64) error {
+	query := `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// PlayerNewsRepository defines the interface for player news and injury data access
+type PlayerNewsRepository interface {
+	UpsertNews(ctx context.Context, news []models.PlayerNews) error
+	GetNewsByPlayer(ctx context.Context, playerID string, limit int) ([]models.PlayerNews, error)
+	ReplaceInjuryStatuses(ctx context.Context, season, week int, source string, statuses []models.InjuryStatus) error
+	GetInjuryStatuses(ctx context.Context, playerIDs []string) (map[string]string, error)
+}
+
+// PostgresPlayerNewsRepository implements PlayerNewsRepository for PostgreSQL
+type PostgresPlayerNewsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPlayerNewsRepository creates a new PostgreSQL player news repository
+func NewPostgresPlayerNewsRepository(db *sql.DB) PlayerNewsRepository {
+	return &PostgresPlayerNewsRepository{db: db}
+}
+
+// UpsertNews stores news blurbs, updating any already fetched
+func (r *PostgresPlayerNewsRepository) UpsertNews(ctx context.Context, news []models.PlayerNews) error {
+	if len(news) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO silver.player_news (
+			source, external_id, player_id, headline, story, news_source, published_at, fetched_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (source, external_id) DO UPDATE SET
+			headline = EXCLUDED.headline,
+			story = EXCLUDED.story,
+			news_source = EXCLUDED.news_source,
+			published_at = EXCLUDED.published_at,
+			fetched_at = NOW()
+	`
+
+	for _, n := range news {
+		_, err := tx.ExecContext(ctx, query,
+			n.Source,
+			n.ExternalID,
+			n.PlayerID,
+			n.Headline,
+			n.Story,
+			n.NewsSource,
+			n.PublishedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert news %s: %w", n.ExternalID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit player news: %w", err)
+	}
+
+	return nil
+}
+
+// GetNewsByPlayer retrieves a player's most recent news, newest first
+func (r *PostgresPlayerNewsRepository) GetNewsByPlayer(ctx context.Context, playerID string, limit int) ([]models.PlayerNews, error) {
+	query := `
+		SELECT id, source, external_id, player_id, headline, COALESCE(story, ''),
+			COALESCE(news_source, ''), published_at, fetched_at
+		FROM silver.player_news
+		WHERE player_id = $1
+		ORDER BY published_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, playerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player news: %w", err)
+	}
+	defer rows.Close()
+
+	var news []models.PlayerNews
+	for rows.Next() {
+		var n models.PlayerNews
+		if err := rows.Scan(
+			&n.ID,
+			&n.Source,
+			&n.ExternalID,
+			&n.PlayerID,
+			&n.Headline,
+			&n.Story,
+			&n.NewsSource,
+			&n.PublishedAt,
+			&n.FetchedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan player news: %w", err)
+		}
+		news = append(news, n)
+	}
+
+	return news, rows.Err()
+}
+
+// ReplaceInjuryStatuses replaces a source's designations for a week, so
+// players who came off the report are cleared
+func (r *PostgresPlayerNewsRepository) ReplaceInjuryStatuses(ctx context.Context, season, week int, source string, statuses []models.InjuryStatus) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM silver.player_injury_status WHERE season = $1 AND week = $2 AND source = $3`,
+		season, week, source,
+	); err != nil {
+		return fmt.Errorf("failed to clear injury statuses: %w", err)
+	}
+
+	query := `
+		INSERT INTO silver.player_injury_status (
+			player_id, player_name, season, week, status, source, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (player_name, season, week) DO UPDATE SET
+			player_id = EXCLUDED.player_id,
+			status = EXCLUDED.status,
+			source = EXCLUDED.source,
+			updated_at = NOW()
+	`
+
+	for _, s := range statuses {
+		if _, err := tx.ExecContext(ctx, query, s.PlayerID, s.PlayerName, season, week, s.Status, source); err != nil {
+			return fmt.Errorf("failed to store injury status for %s: %w", s.PlayerName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit injury statuses: %w", err)
+	}
+
+	return nil
+}
+
+// GetInjuryStatuses retrieves the current designation for each injured
+// player, keyed by player ID. Players without a designation are omitted.
+func (r *PostgresPlayerNewsRepository) GetInjuryStatuses(ctx context.Context, playerIDs []string) (map[string]string, error) {
+	statuses := make(map[string]string)
+	if len(playerIDs) == 0 {
+		return statuses, nil
+	}
+
+	// Only the latest reported week is current
+	query := `
+		SELECT player_id, status
+		FROM silver.player_injury_status
+		WHERE player_id = ANY($1)
+			AND (season, week) = (
+				SELECT season, week FROM silver.player_injury_status
+				ORDER BY season DESC, week DESC
+				LIMIT 1
+			)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(playerIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query injury statuses: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerID, status string
+		if err := rows.Scan(&playerID, &status); err != nil {
+			return nil, fmt.Errorf("failed to scan injury status: %w", err)
+		}
+		statuses[playerID] = status
+	}
+
+	return statuses, rows.Err()
+}
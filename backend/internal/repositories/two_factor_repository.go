@@ -0,0 +1,249 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/database"
+)
+
+// ErrTwoFactorNotFound is returned for users who have not enrolled in
+// two-factor authentication
+var ErrTwoFactorNotFound = errors.New("two-factor not enrolled")
+
+// TwoFactor is a user's two-factor enrollment
+type TwoFactor struct {
+	UserID          uuid.UUID
+	SecretEncrypted string
+	// EnabledAt is nil until the user confirms a code from their app
+	EnabledAt      *time.Time
+	LastUsedStep   int64
+	FailedAttempts int
+	LockedUntil    *time.Time
+}
+
+// TwoFactorRepository stores two-factor secrets and backup codes
+type TwoFactorRepository interface {
+	Get(ctx context.Context, userID uuid.UUID) (*TwoFactor, error)
+	// SavePending starts or restarts an enrollment that is not enabled yet
+	SavePending(ctx context.Context, userID uuid.UUID, secretEncrypted string) error
+	// Enable turns two-factor on with a confirmed code's step and a fresh set
+	// of backup codes
+	Enable(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error
+	Disable(ctx context.Context, userID uuid.UUID) error
+	// UseStep accepts a code's step if it is later than any used before,
+	// clearing failed attempts
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	// UseBackupCode spends an unused backup code, clearing failed attempts
+	UseBackupCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	// RecordFailure counts a wrong code, locking the user out for lockFor
+	// once maxAttempts are reached
+	RecordFailure(ctx context.Context, userID uuid.UUID, maxAttempts int, lockFor time.Duration) error
+	ReplaceBackupCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	CountBackupCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// PostgresTwoFactorRepository implements TwoFactorRepository using PostgreSQL
+type PostgresTwoFactorRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresTwoFactorRepository creates a new PostgreSQL two-factor repository
+func NewPostgresTwoFactorRepository(db *database.PostgresDB) TwoFactorRepository {
+	return &PostgresTwoFactorRepository{
+		db: db,
+	}
+}
+
+// Get retrieves a user's enrollment
+func (r *PostgresTwoFactorRepository) Get(ctx context.Context, userID uuid.UUID) (*TwoFactor, error) {
+	query := `
+		SELECT user_id, secret_encrypted, enabled_at, last_used_step, failed_attempts, locked_until
+		FROM user_two_factor
+		WHERE user_id = $1
+	`
+
+	var tf TwoFactor
+	err := r.db.DB.QueryRowContext(ctx, query, userID).Scan(
+		&tf.UserID, &tf.SecretEncrypted, &tf.EnabledAt, &tf.LastUsedStep, &tf.FailedAttempts, &tf.LockedUntil,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTwoFactorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get two-factor: %w", err)
+	}
+	return &tf, nil
+}
+
+// SavePending stores a new secret unless two-factor is already on
+func (r *PostgresTwoFactorRepository) SavePending(ctx context.Context, userID uuid.UUID, secretEncrypted string) error {
+	query := `
+		INSERT INTO user_two_factor (user_id, secret_encrypted)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET
+			secret_encrypted = EXCLUDED.secret_encrypted,
+			last_used_step = 0,
+			failed_attempts = 0,
+			locked_until = NULL,
+			created_at = CURRENT_TIMESTAMP
+		WHERE user_two_factor.enabled_at IS NULL
+	`
+
+	if _, err := r.db.DB.ExecContext(ctx, query, userID, secretEncrypted); err != nil {
+		return fmt.Errorf("failed to save two-factor secret: %w", err)
+	}
+	return nil
+}
+
+// Enable turns two-factor on and replaces the backup codes in one transaction
+func (r *PostgresTwoFactorRepository) Enable(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error {
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE user_two_factor
+		SET enabled_at = CURRENT_TIMESTAMP, last_used_step = $2, failed_attempts = 0, locked_until = NULL
+		WHERE user_id = $1 AND enabled_at IS NULL`,
+		userID, step,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enable two-factor: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrTwoFactorNotFound
+	}
+
+	if err := replaceBackupCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit two-factor: %w", err)
+	}
+	return nil
+}
+
+// Disable removes the secret and backup codes
+func (r *PostgresTwoFactorRepository) Disable(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_backup_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete backup codes: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_two_factor WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete two-factor: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit two-factor removal: %w", err)
+	}
+	return nil
+}
+
+// UseStep records a code's step, refusing steps already used
+func (r *PostgresTwoFactorRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	result, err := r.db.DB.ExecContext(ctx, `
+		UPDATE user_two_factor
+		SET last_used_step = $2, failed_attempts = 0, locked_until = NULL
+		WHERE user_id = $1 AND last_used_step < $2`,
+		userID, step,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to use two-factor code: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// UseBackupCode marks a backup code used
+func (r *PostgresTwoFactorRepository) UseBackupCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	result, err := r.db.DB.ExecContext(ctx, `
+		UPDATE user_backup_codes SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`,
+		userID, codeHash,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to use backup code: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	_, err = r.db.DB.ExecContext(ctx,
+		`UPDATE user_two_factor SET failed_attempts = 0, locked_until = NULL WHERE user_id = $1`, userID)
+	if err != nil {
+		return true, fmt.Errorf("failed to reset two-factor attempts: %w", err)
+	}
+	return true, nil
+}
+
+// RecordFailure counts a failed code and locks the user out after too many
+func (r *PostgresTwoFactorRepository) RecordFailure(ctx context.Context, userID uuid.UUID, maxAttempts int, lockFor time.Duration) error {
+	_, err := r.db.DB.ExecContext(ctx, `
+		UPDATE user_two_factor
+		SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END
+		WHERE user_id = $1`,
+		userID, maxAttempts, time.Now().Add(lockFor),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record two-factor failure: %w", err)
+	}
+	return nil
+}
+
+// ReplaceBackupCodes discards the user's backup codes for new ones
+func (r *PostgresTwoFactorRepository) ReplaceBackupCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	tx, err := r.db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := replaceBackupCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit backup codes: %w", err)
+	}
+	return nil
+}
+
+// CountBackupCodes returns how many unused backup codes the user has
+func (r *PostgresTwoFactorRepository) CountBackupCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.DB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM user_backup_codes WHERE user_id = $1 AND used_at IS NULL`, userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count backup codes: %w", err)
+	}
+	return count, nil
+}
+
+func replaceBackupCodes(ctx context.Context, tx *sql.Tx, userID uuid.UUID, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_backup_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete backup codes: %w", err)
+	}
+	for _, hash := range codeHashes {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO user_backup_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash,
+		); err != nil {
+			return fmt.Errorf("failed to store backup code: %w", err)
+		}
+	}
+	return nil
+}
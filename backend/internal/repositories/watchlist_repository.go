@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// WatchlistRepository defines the interface for user watchlist data access
+type WatchlistRepository interface {
+	Add(ctx context.Context, userID uuid.UUID, playerName string) error
+	Remove(ctx context.Context, userID uuid.UUID, playerName string) error
+	GetByUser(ctx context.Context, userID uuid.UUID) ([]models.WatchlistEntry, error)
+	GetWatchers(ctx context.Context, playerNames []string) (map[string][]uuid.UUID, error)
+}
+
+// PostgresWatchlistRepository implements WatchlistRepository for PostgreSQL
+type PostgresWatchlistRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWatchlistRepository creates a new PostgreSQL watchlist repository
+func NewPostgresWatchlistRepository(db *sql.DB) WatchlistRepository {
+	return &PostgresWatchlistRepository{db: db}
+}
+
+// Add puts a player on a user's watchlist. Adding a player twice is a no-op.
+func (r *PostgresWatchlistRepository) Add(ctx context.Context, userID uuid.UUID, playerName string) error {
+	query := `
+		INSERT INTO user_watchlist (user_id, player_name, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id, player_name) DO NOTHING
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, playerName); err != nil {
+		return fmt.Errorf("failed to add to watchlist: %w", err)
+	}
+
+	return nil
+}
+
+// Remove takes a player off a user's watchlist
+func (r *PostgresWatchlistRepository) Remove(ctx context.Context, userID uuid.UUID, playerName string) error {
+	query := `DELETE FROM user_watchlist WHERE user_id = $1 AND player_name = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, playerName); err != nil {
+		return fmt.Errorf("failed to remove from watchlist: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser retrieves a user's watchlist, most recently added first
+func (r *PostgresWatchlistRepository) GetByUser(ctx context.Context, userID uuid.UUID) ([]models.WatchlistEntry, error) {
+	query := `
+		SELECT user_id, player_name, created_at
+		FROM user_watchlist
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watchlist: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.WatchlistEntry
+	for rows.Next() {
+		var e models.WatchlistEntry
+		if err := rows.Scan(&e.UserID, &e.PlayerName, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan watchlist entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetWatchers finds the users watching each player, keyed by player name
+func (r *PostgresWatchlistRepository) GetWatchers(ctx context.Context, playerNames []string) (map[string][]uuid.UUID, error) {
+	watchers := make(map[string][]uuid.UUID)
+	if len(playerNames) == 0 {
+		return watchers, nil
+	}
+
+	query := `SELECT player_name, user_id FROM user_watchlist WHERE player_name = ANY($1)`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(playerNames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watchers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerName string
+		var userID uuid.UUID
+		if err := rows.Scan(&playerName, &userID); err != nil {
+			return nil, fmt.Errorf("failed to scan watcher: %w", err)
+		}
+		watchers[playerName] = append(watchers[playerName], userID)
+	}
+
+	return watchers, rows.Err()
+}
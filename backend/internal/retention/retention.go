@@ -0,0 +1,174 @@
+// Package retention prunes rows past their retention period so Postgres does
+// not grow without bound season after season. Each policy keeps one kind of
+// data for a configured age, or for a number of seasons.
+package retention
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// Policy names, one per kind of data kept
+const (
+	// PolicyRawProjections is the raw per-source projection feed in the
+	// bronze layer, kept for whole seasons
+	PolicyRawProjections = "raw_projections"
+	// PolicySyncDiffs are the changes detected between syncs: position
+	// eligibility and player metadata
+	PolicySyncDiffs = "sync_diffs"
+	// PolicyAuditLog is users' account activity and security events
+	PolicyAuditLog = "audit_log"
+)
+
+// defaultBatchSize is how many rows one delete removes when no batch size is
+// configured
+const defaultBatchSize = 5000
+
+// Settings are how long each kind of data is kept. Zero keeps it forever.
+type Settings struct {
+	RawProjectionSeasons int
+	SyncDiffs            time.Duration
+	AuditLog             time.Duration
+}
+
+// Policy keeps a table's rows for MaxAge by a timestamp column, or for the
+// last Seasons seasons by an integer season column
+type Policy struct {
+	Name    string
+	Table   string
+	Column  string
+	MaxAge  time.Duration
+	Seasons int
+}
+
+// Policies are the policies settings turn on. Tables and columns are fixed
+// here, never taken from configuration.
+func Policies(s Settings) []Policy {
+	var policies []Policy
+	if s.RawProjectionSeasons > 0 {
+		policies = append(policies, Policy{Name: PolicyRawProjections, Table: "bronze.raw_projections", Column: "season", Seasons: s.RawProjectionSeasons})
+	}
+	if s.SyncDiffs > 0 {
+		policies = append(policies,
+			Policy{Name: PolicySyncDiffs, Table: "player_eligibility_changes", Column: "detected_at", MaxAge: s.SyncDiffs},
+			Policy{Name: PolicySyncDiffs, Table: "player_metadata_changes", Column: "detected_at", MaxAge: s.SyncDiffs},
+		)
+	}
+	if s.AuditLog > 0 {
+		policies = append(policies,
+			Policy{Name: PolicyAuditLog, Table: "audit_logs", Column: "created_at", MaxAge: s.AuditLog},
+			Policy{Name: PolicyAuditLog, Table: "audit_events", Column: "created_at", MaxAge: s.AuditLog},
+		)
+	}
+	return policies
+}
+
+// cutoff is the condition on Column selecting expired rows, and its
+// argument
+func (p Policy) cutoff(now time.Time) (string, interface{}) {
+	if p.Seasons > 0 {
+		// Keeping two seasons in 2025 keeps 2024 and 2025
+		return p.Column + " < $1", projections.CurrentSeason(now) - p.Seasons + 1
+	}
+	return p.Column + " < $1", now.Add(-p.MaxAge)
+}
+
+// Result is what one policy removed from one table
+type Result struct {
+	Policy     string      `json:"policy"`
+	Table      string      `json:"table"`
+	Before     interface{} `json:"before"`
+	Rows       int64       `json:"rows"`
+	DurationMS int64       `json:"duration_ms"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// Report is the result of one run
+type Report struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Rows       int64     `json:"rows"`
+	Results    []Result  `json:"results"`
+}
+
+// Pruner deletes expired rows in batches, so no delete holds locks on a busy
+// table for long
+type Pruner struct {
+	db        *sql.DB
+	policies  []Policy
+	batchSize int
+	now       func() time.Time
+}
+
+// NewPruner creates a pruner enforcing policies
+func NewPruner(db *sql.DB, policies []Policy, batchSize int) *Pruner {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &Pruner{db: db, policies: policies, batchSize: batchSize, now: time.Now}
+}
+
+// Run applies every policy. A table that fails is reported and the rest are
+// still pruned; the error is the first failure.
+func (p *Pruner) Run(ctx context.Context) (*Report, error) {
+	report := &Report{StartedAt: p.now(), Results: make([]Result, 0, len(p.policies))}
+	var firstErr error
+
+	for _, policy := range p.policies {
+		started := time.Now()
+		condition, before := policy.cutoff(report.StartedAt)
+		rows, err := p.prune(ctx, policy.Table, condition, before)
+
+		result := Result{
+			Policy:     policy.Name,
+			Table:      policy.Table,
+			Before:     before,
+			Rows:       rows,
+			DurationMS: time.Since(started).Milliseconds(),
+		}
+		if err != nil {
+			result.Error = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		report.Rows += rows
+		report.Results = append(report.Results, result)
+		log.Printf("metrics retention_pruned policy=%s table=%s rows=%d duration_ms=%d failed=%t",
+			policy.Name, policy.Table, rows, result.DurationMS, err != nil)
+	}
+
+	report.FinishedAt = p.now()
+	return report, firstErr
+}
+
+// prune deletes a table's rows matching condition a batch at a time,
+// returning how many it removed
+func (p *Pruner) prune(ctx context.Context, table, condition string, arg interface{}) (int64, error) {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE ctid IN (SELECT ctid FROM %s WHERE %s LIMIT %d)`,
+		table, table, condition, p.batchSize)
+
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		result, err := p.db.ExecContext(ctx, query, arg)
+		if err != nil {
+			return total, fmt.Errorf("failed to prune %s: %w", table, err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("failed to prune %s: %w", table, err)
+		}
+		total += n
+		if n < int64(p.batchSize) {
+			return total, nil
+		}
+	}
+}
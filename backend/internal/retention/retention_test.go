@@ -0,0 +1,42 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicies(t *testing.T) {
+	policies := Policies(Settings{RawProjectionSeasons: 2, SyncDiffs: 90 * 24 * time.Hour, AuditLog: 365 * 24 * time.Hour})
+
+	var tables []string
+	for _, p := range policies {
+		tables = append(tables, p.Table)
+	}
+	assert.Equal(t, []string{"bronze.raw_projections", "player_eligibility_changes", "player_metadata_changes", "audit_logs", "audit_events"}, tables)
+
+	// A zero retention keeps the data
+	policies = Policies(Settings{AuditLog: time.Hour})
+	assert.Len(t, policies, 2)
+	assert.Equal(t, PolicyAuditLog, policies[0].Name)
+	assert.Empty(t, Policies(Settings{}))
+}
+
+func TestPolicy_Cutoff(t *testing.T) {
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	seasons := Policy{Column: "season", Seasons: 2}
+	condition, arg := seasons.cutoff(now)
+	assert.Equal(t, "season < $1", condition)
+	assert.Equal(t, 2024, arg)
+
+	// Before the league year rolls over the current season is still last year's
+	_, arg = seasons.cutoff(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 2024, arg)
+
+	age := Policy{Column: "created_at", MaxAge: 90 * 24 * time.Hour}
+	condition, arg = age.cutoff(now)
+	assert.Equal(t, "created_at < $1", condition)
+	assert.Equal(t, time.Date(2025, 7, 3, 12, 0, 0, 0, time.UTC), arg)
+}
@@ -0,0 +1,119 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/sigv4"
+)
+
+// maxKMSResponse bounds responses read from KMS
+const maxKMSResponse = 1 << 20
+
+// KMSConfig locates AWS KMS and the credentials to call it with
+type KMSConfig struct {
+	Region string
+	// Endpoint overrides the regional endpoint, as for a VPC endpoint or
+	// LocalStack
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// KMSProvider decrypts secrets with AWS KMS. A reference is the base64
+// CiphertextBlob from `aws kms encrypt`, so the plaintext is only ever held
+// in memory and reading it needs kms:Decrypt on the key.
+type KMSProvider struct {
+	cfg    KMSConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewKMSProvider creates a provider calling KMS in the configured region
+func NewKMSProvider(cfg KMSConfig) (*KMSProvider, error) {
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("kms secrets provider requires a region")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("kms secrets provider requires AWS credentials")
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com", cfg.Region)
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	return &KMSProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+	}, nil
+}
+
+// kmsDecryptResponse is the part of a Decrypt response used
+type kmsDecryptResponse struct {
+	Plaintext string `json:"Plaintext"`
+}
+
+// kmsError is the body of a failed KMS call
+type kmsError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// Secret decrypts the ciphertext in ref
+func (p *KMSProvider) Secret(ctx context.Context, ref string) (string, error) {
+	if _, err := base64.StdEncoding.DecodeString(ref); err != nil || ref == "" {
+		return "", fmt.Errorf("kms secret reference must be a base64 ciphertext blob")
+	}
+	body, err := json.Marshal(map[string]string{"CiphertextBlob": ref})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode kms request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.Endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create kms request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService.Decrypt")
+	sigv4.Sign(req, body, sigv4.Credentials{
+		AccessKeyID:     p.cfg.AccessKeyID,
+		SecretAccessKey: p.cfg.SecretAccessKey,
+		SessionToken:    p.cfg.SessionToken,
+	}, p.cfg.Region, "kms", p.now())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("kms request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxKMSResponse))
+	if err != nil {
+		return "", fmt.Errorf("failed to read kms response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var kmsErr kmsError
+		_ = json.Unmarshal(data, &kmsErr)
+		return "", fmt.Errorf("kms decrypt failed: status %d: %s %s", resp.StatusCode, kmsErr.Type, kmsErr.Message)
+	}
+
+	var decrypted kmsDecryptResponse
+	if err := json.Unmarshal(data, &decrypted); err != nil {
+		return "", fmt.Errorf("failed to decode kms response: %w", err)
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(decrypted.Plaintext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode kms plaintext: %w", err)
+	}
+	if len(plaintext) == 0 {
+		return "", ErrEmptySecret
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,99 @@
+// Package secrets resolves secrets such as the encryption key from where a
+// deployment keeps them: the environment, a mounted file, AWS KMS or
+// HashiCorp Vault. Configuration holds a reference to each secret, and the
+// provider turns it into the value at startup, so production need not keep
+// keys in plain environment variables.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Providers
+const (
+	ProviderEnv   = "env"
+	ProviderFile  = "file"
+	ProviderKMS   = "kms"
+	ProviderVault = "vault"
+)
+
+// ErrEmptySecret is returned when a reference resolves to nothing
+var ErrEmptySecret = errors.New("secret is empty")
+
+// Provider resolves a reference to the secret it names
+type Provider interface {
+	Secret(ctx context.Context, ref string) (string, error)
+}
+
+// Config chooses a provider and holds the settings of the remote ones
+type Config struct {
+	// Provider is env, file, kms or vault; empty is env
+	Provider string
+	KMS      KMSConfig
+	Vault    VaultConfig
+}
+
+// New creates the configured provider
+func New(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "", ProviderEnv:
+		return EnvProvider{}, nil
+	case ProviderFile:
+		return FileProvider{}, nil
+	case ProviderKMS:
+		return NewKMSProvider(cfg.KMS)
+	case ProviderVault:
+		return NewVaultProvider(cfg.Vault)
+	default:
+		return nil, fmt.Errorf("unknown secrets provider %q", cfg.Provider)
+	}
+}
+
+// EnvProvider is for secrets set directly in the environment, so a
+// reference is the secret itself
+type EnvProvider struct{}
+
+// Secret returns ref
+func (EnvProvider) Secret(ctx context.Context, ref string) (string, error) {
+	if ref == "" {
+		return "", ErrEmptySecret
+	}
+	return ref, nil
+}
+
+// FileProvider reads secrets from files, such as Kubernetes or Docker
+// secrets mounts. A reference is the file's path; a trailing newline is
+// dropped.
+type FileProvider struct{}
+
+// Secret reads the file at ref
+func (FileProvider) Secret(ctx context.Context, ref string) (string, error) {
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	secret := strings.TrimRight(string(data), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("%s: %w", ref, ErrEmptySecret)
+	}
+	return secret, nil
+}
+
+// ResolveKeys resolves the current key and retired keys by ID
+func ResolveKeys(ctx context.Context, p Provider, keyRef string, previousRefs map[string]string) (string, map[string]string, error) {
+	key, err := p.Secret(ctx, keyRef)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve current key: %w", err)
+	}
+	previous := make(map[string]string, len(previousRefs))
+	for id, ref := range previousRefs {
+		if previous[id], err = p.Secret(ctx, ref); err != nil {
+			return "", nil, fmt.Errorf("failed to resolve previous key %q: %w", id, err)
+		}
+	}
+	return key, previous, nil
+}
@@ -0,0 +1,147 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "0123456789abcdef0123456789abcdef"
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "encryption_key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(testKey+"\n"), 0o600))
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyFile, nil, 0o600))
+
+	secret, err := FileProvider{}.Secret(context.Background(), keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, testKey, secret)
+
+	_, err = FileProvider{}.Secret(context.Background(), emptyFile)
+	assert.ErrorIs(t, err, ErrEmptySecret)
+	_, err = FileProvider{}.Secret(context.Background(), filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestResolveKeys(t *testing.T) {
+	key, previous, err := ResolveKeys(context.Background(), EnvProvider{}, testKey, map[string]string{"v1": "old"})
+	require.NoError(t, err)
+	assert.Equal(t, testKey, key)
+	assert.Equal(t, map[string]string{"v1": "old"}, previous)
+
+	_, _, err = ResolveKeys(context.Background(), EnvProvider{}, "", nil)
+	assert.ErrorIs(t, err, ErrEmptySecret)
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "agent-token" || r.Header.Get("X-Vault-Namespace") != "fantasy" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/nfl-analytics":
+			_, _ = w.Write([]byte(`{"data":{"data":{"encryption_key":"` + testKey + `"},"metadata":{"version":3}}}`))
+		case "/v1/kv/nfl-analytics":
+			_, _ = w.Write([]byte(`{"data":{"encryption_key":"` + testKey + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("agent-token\n"), 0o600))
+	provider, err := NewVaultProvider(VaultConfig{Addr: server.URL, TokenFile: tokenFile, Namespace: "fantasy"})
+	require.NoError(t, err)
+
+	for _, ref := range []string{"secret/data/nfl-analytics#encryption_key", "kv/nfl-analytics#encryption_key"} {
+		secret, err := provider.Secret(context.Background(), ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, testKey, secret, ref)
+	}
+
+	for _, ref := range []string{"secret/data/nfl-analytics", "secret/data/nfl-analytics#other", "secret/data/missing#encryption_key"} {
+		_, err := provider.Secret(context.Background(), ref)
+		assert.Error(t, err, ref)
+	}
+
+	denied, err := NewVaultProvider(VaultConfig{Addr: server.URL, Token: "stale"})
+	require.NoError(t, err)
+	_, err = denied.Secret(context.Background(), "secret/data/nfl-analytics#encryption_key")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+}
+
+func TestKMSProvider(t *testing.T) {
+	ciphertext := base64.StdEncoding.EncodeToString([]byte("sealed by kms"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/us-west-2/kms/aws4_request") ||
+			r.Header.Get("X-Amz-Security-Token") != "session" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("X-Amz-Target") != "TrentService.Decrypt" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]string
+		_ = json.Unmarshal(body, &req)
+		if req["CiphertextBlob"] != ciphertext {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"InvalidCiphertextException","message":""}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"KeyId":"arn:aws:kms:us-west-2:111122223333:key/abc","Plaintext":"` +
+			base64.StdEncoding.EncodeToString([]byte(testKey)) + `"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewKMSProvider(KMSConfig{
+		Region:          "us-west-2",
+		Endpoint:        server.URL,
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		SessionToken:    "session",
+	})
+	require.NoError(t, err)
+
+	secret, err := provider.Secret(context.Background(), ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, testKey, secret)
+
+	_, err = provider.Secret(context.Background(), base64.StdEncoding.EncodeToString([]byte("other")))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "InvalidCiphertextException")
+	_, err = provider.Secret(context.Background(), "not base64!")
+	assert.Error(t, err)
+}
+
+func TestNew_RequiresSettings(t *testing.T) {
+	_, err := New(Config{Provider: ProviderKMS})
+	assert.Error(t, err)
+	_, err = New(Config{Provider: ProviderVault})
+	assert.Error(t, err)
+	_, err = New(Config{Provider: "ssm"})
+	assert.Error(t, err)
+
+	provider, err := New(Config{})
+	require.NoError(t, err)
+	assert.IsType(t, EnvProvider{}, provider)
+}
@@ -0,0 +1,123 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// maxVaultResponse bounds responses read from Vault
+const maxVaultResponse = 1 << 20
+
+// VaultConfig locates a Vault server and the token to read it with
+type VaultConfig struct {
+	Addr string
+	// Token authenticates requests. TokenFile is read instead on every
+	// request when set, for tokens renewed by a Vault agent.
+	Token     string
+	TokenFile string
+	// Namespace is the Vault Enterprise namespace, if any
+	Namespace string
+}
+
+// VaultProvider reads secrets from HashiCorp Vault. A reference is the API
+// path of the secret and the field to read, as in
+// secret/data/nfl-analytics#encryption_key for a KV version 2 mount.
+type VaultProvider struct {
+	cfg    VaultConfig
+	client *http.Client
+}
+
+// NewVaultProvider creates a provider reading from the configured server
+func NewVaultProvider(cfg VaultConfig) (*VaultProvider, error) {
+	if cfg.Addr == "" {
+		return nil, fmt.Errorf("vault secrets provider requires an address")
+	}
+	if cfg.Token == "" && cfg.TokenFile == "" {
+		return nil, fmt.Errorf("vault secrets provider requires a token or token file")
+	}
+	cfg.Addr = strings.TrimSuffix(cfg.Addr, "/")
+	return &VaultProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// vaultResponse is a secret read from Vault. KV version 2 nests the fields
+// under data.data, version 1 puts them under data.
+type vaultResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []string               `json:"errors"`
+}
+
+// Secret reads the field named in ref
+func (p *VaultProvider) Secret(ctx context.Context, ref string) (string, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	path = strings.Trim(path, "/")
+	if !ok || path == "" || field == "" {
+		return "", fmt.Errorf("vault secret reference must be path#field, got %q", ref)
+	}
+	token, err := p.token()
+	if err != nil {
+		return "", err
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Addr+"/v1/"+strings.Join(segments, "/"), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	var body vaultResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxVaultResponse)).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read vault secret %s: status %d %s", path, resp.StatusCode, strings.Join(body.Errors, "; "))
+	}
+
+	fields := body.Data
+	if nested, ok := fields["data"].(map[string]interface{}); ok {
+		if _, kv2 := fields["metadata"]; kv2 {
+			fields = nested
+		}
+	}
+	value, ok := fields[field].(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no string field %q", path, field)
+	}
+	if value == "" {
+		return "", fmt.Errorf("vault secret %s field %q: %w", path, field, ErrEmptySecret)
+	}
+	return value, nil
+}
+
+// token returns the configured token, reading the token file if set
+func (p *VaultProvider) token() (string, error) {
+	if p.cfg.TokenFile == "" {
+		return p.cfg.Token, nil
+	}
+	data, err := os.ReadFile(p.cfg.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault token file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/audit"
 	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/loginguard"
 	"github.com/nfl-analytics/backend/internal/models"
 	"github.com/nfl-analytics/backend/internal/repositories"
 )
@@ -17,6 +20,7 @@ var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrEmailExists        = errors.New("email already registered")
 	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrSessionNotFound    = errors.New("session not found")
 )
 
 // AuthService handles authentication business logic
@@ -25,6 +29,18 @@ type AuthService interface {
 	Login(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*models.AuthResponse, error)
 	Logout(ctx context.Context, userID uuid.UUID) error
+	// VerifyTwoFactor finishes a login that returned a two-factor token
+	VerifyTwoFactor(ctx context.Context, twoFactorToken, code string) (*models.AuthResponse, error)
+	// VerifyLogin finishes a login from a new device that returned a
+	// verification token, with the code emailed to the user
+	VerifyLogin(ctx context.Context, verificationToken, code string) (*models.AuthResponse, error)
+	// ListSessions returns the devices the user is signed in on, flagging
+	// currentID
+	ListSessions(ctx context.Context, userID, currentID uuid.UUID) ([]models.Session, error)
+	// RevokeSession signs one device out. It can no longer refresh; its
+	// access token works until it expires unless the caller also denylists
+	// the session.
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
 }
 
 // authService implements AuthService
@@ -33,15 +49,28 @@ type authService struct {
 	userRepo        repositories.UserRepository
 	jwtManager      *auth.JWTManager
 	passwordManager *auth.PasswordManager
+	twoFactor       TwoFactorService
+	events          *audit.Logger
+	guard           *loginguard.Guard
 }
 
-// NewAuthService creates a new auth service
-func NewAuthService(authRepo repositories.AuthRepository, userRepo repositories.UserRepository, jwtManager *auth.JWTManager) AuthService {
+// NewAuthService creates a new auth service. Logins ask users with two-factor
+// on for a code; twoFactor may be nil to skip the check. passwords hashes
+// new passwords and may be nil for bcrypt at cost 10. Logins and refreshes
+// are recorded in events, which may be nil. guard flags logins from new
+// devices and may be nil to skip the check.
+func NewAuthService(authRepo repositories.AuthRepository, userRepo repositories.UserRepository, jwtManager *auth.JWTManager, twoFactor TwoFactorService, passwords *auth.PasswordManager, events *audit.Logger, guard *loginguard.Guard) AuthService {
+	if passwords == nil {
+		passwords = auth.NewPasswordManager(10)
+	}
 	return &authService{
 		authRepo:        authRepo,
 		userRepo:        userRepo,
 		jwtManager:      jwtManager,
-		passwordManager: auth.NewPasswordManager(10),
+		passwordManager: passwords,
+		twoFactor:       twoFactor,
+		events:          events,
+		guard:           guard,
 	}
 }
 
@@ -94,32 +123,7 @@ func (s *authService) Register(ctx context.Context, req *models.RegisterRequest)
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 	
-	// Generate tokens
-	accessToken, refreshToken, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email)
-	if err != nil {
-		return nil, err
-	}
-	
-	// Store refresh token
-	expiresAt := time.Now().Add(time.Duration(7*24) * time.Hour)
-	if err := s.authRepo.StoreRefreshToken(ctx, user.ID, refreshToken, expiresAt); err != nil {
-		return nil, err
-	}
-	
-	// Update last login
-	_ = s.authRepo.UpdateLastLogin(ctx, user.ID)
-	
-	return &models.AuthResponse{
-		User: &models.UserResponse{
-			ID:        user.ID,
-			Email:     user.Email,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			IsActive:  user.IsActive,
-		},
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	}, nil
+	return issueTokens(ctx, s.authRepo, s.jwtManager, user, false)
 }
 
 // Login authenticates a user
@@ -131,6 +135,7 @@ func (s *authService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		if err == repositories.ErrUserNotFound {
+			s.events.Record(ctx, nil, audit.EventLoginFailed, map[string]interface{}{"method": "password", "reason": audit.ReasonUnknownEmail})
 			return nil, ErrInvalidCredentials
 		}
 		return nil, err
@@ -138,29 +143,196 @@ func (s *authService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	
 	// Check if user is active
 	if !user.IsActive {
+		s.loginFailed(ctx, user.ID, audit.ReasonInactive)
 		return nil, ErrInvalidCredentials
 	}
 	
 	// Verify password
 	if err := s.passwordManager.VerifyPassword(user.PasswordHash, req.Password); err != nil {
+		s.loginFailed(ctx, user.ID, audit.ReasonWrongPassword)
 		return nil, ErrInvalidCredentials
 	}
-	
+	s.rehashPassword(ctx, user, req.Password)
+
+	// Users with two-factor on get a code prompt instead of tokens; the
+	// login is recorded once the code is checked. The code already proves
+	// a new device is theirs.
+	if challenge, err := twoFactorChallenge(ctx, s.twoFactor, s.jwtManager, user.ID, req.RememberMe); err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	// Logins from a new device or network may first need a code sent to
+	// the user's email
+	assessment := s.guard.Assess(ctx, user.ID)
+	if s.guard.RequiresVerification(assessment) {
+		return s.loginVerificationChallenge(ctx, user, assessment, req.RememberMe)
+	}
+
+	response, err := issueTokens(ctx, s.authRepo, s.jwtManager, user, req.RememberMe)
+	if err == nil {
+		s.events.Record(ctx, &user.ID, audit.EventLoginSucceeded, map[string]interface{}{"method": "password", "remember_me": req.RememberMe})
+		s.guard.Succeeded(ctx, user.ID, user.Email, assessment, false)
+	}
+	return response, err
+}
+
+// loginVerificationChallenge emails the user a code for a login from a new
+// device and returns the token to send it back with
+func (s *authService) loginVerificationChallenge(ctx context.Context, user *models.User, assessment loginguard.Assessment, rememberMe bool) (*models.AuthResponse, error) {
+	verificationID, err := s.guard.Challenge(ctx, user.ID, user.Email, assessment, rememberMe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send login verification: %w", err)
+	}
+	token, _, err := s.jwtManager.GenerateScopedToken(user.ID, auth.ScopeLoginVerification, verificationID.String(), loginguard.VerificationTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	return &models.AuthResponse{
+		VerificationRequired: true,
+		VerificationToken:    token,
+	}, nil
+}
+
+// VerifyLogin checks the emailed code for a login from a new device. The
+// session is remembered if the login asked to be.
+func (s *authService) VerifyLogin(ctx context.Context, verificationToken, code string) (*models.AuthResponse, error) {
+	claims, err := s.jwtManager.ValidateToken(verificationToken)
+	if err != nil || claims.TokenType != auth.ScopedToken || claims.Scope != auth.ScopeLoginVerification || s.guard == nil {
+		return nil, ErrInvalidToken
+	}
+	verificationID, err := uuid.Parse(claims.Resource)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	verification, err := s.guard.Verify(ctx, claims.UserID, verificationID, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, loginguard.ErrVerificationNotFound):
+			return nil, ErrInvalidToken
+		case errors.Is(err, loginguard.ErrInvalidCode):
+			s.loginFailed(ctx, claims.UserID, audit.ReasonInvalidLoginVerification)
+		}
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		s.loginFailed(ctx, user.ID, audit.ReasonInactive)
+		return nil, ErrInvalidCredentials
+	}
+
+	assessment := s.guard.Assess(ctx, user.ID)
+	response, err := issueTokens(ctx, s.authRepo, s.jwtManager, user, verification.RememberMe)
+	if err == nil {
+		s.events.Record(ctx, &user.ID, audit.EventLoginSucceeded, map[string]interface{}{"method": "password", "email_verified": true, "remember_me": verification.RememberMe})
+		s.guard.Succeeded(ctx, user.ID, user.Email, assessment, true)
+	}
+	return response, err
+}
+
+// loginFailed records a failed login to a known account
+func (s *authService) loginFailed(ctx context.Context, userID uuid.UUID, reason string) {
+	s.events.Record(ctx, &userID, audit.EventLoginFailed, map[string]interface{}{"method": "password", "reason": reason})
+}
+
+// rehashPassword replaces a user's password hash after they sign in when it
+// was made with an older algorithm or parameters, so bcrypt hashes move to
+// Argon2id as users come back. Failures are logged and the old hash kept.
+func (s *authService) rehashPassword(ctx context.Context, user *models.User, password string) {
+	if !s.passwordManager.NeedsRehash(user.PasswordHash) {
+		return
+	}
+	hashedPassword, err := s.passwordManager.HashPassword(password)
+	if err != nil {
+		log.Printf("Failed to rehash password for user %s: %v", user.ID, err)
+		return
+	}
+	user.PasswordHash = hashedPassword
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		log.Printf("Failed to save rehashed password for user %s: %v", user.ID, err)
+		return
+	}
+	log.Printf("metrics password_rehashed algorithm=%s", s.passwordManager.Algorithm())
+}
+
+// VerifyTwoFactor checks the code for a login that passed its password. The
+// session is remembered if the login asked to be.
+func (s *authService) VerifyTwoFactor(ctx context.Context, twoFactorToken, code string) (*models.AuthResponse, error) {
+	claims, err := s.jwtManager.ValidateToken(twoFactorToken)
+	if err != nil || claims.TokenType != auth.ScopedToken ||
+		(claims.Scope != auth.ScopeTwoFactor && claims.Scope != auth.ScopeTwoFactorRememberMe) ||
+		claims.Resource != claims.UserID.String() || s.twoFactor == nil {
+		return nil, ErrInvalidToken
+	}
+	rememberMe := claims.Scope == auth.ScopeTwoFactorRememberMe
+
+	if err := s.twoFactor.Verify(ctx, claims.UserID, code); err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidTwoFactor):
+			s.loginFailed(ctx, claims.UserID, audit.ReasonInvalidTwoFactor)
+		case errors.Is(err, ErrTwoFactorLocked):
+			s.loginFailed(ctx, claims.UserID, audit.ReasonTwoFactorLocked)
+		}
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		s.loginFailed(ctx, user.ID, audit.ReasonInactive)
+		return nil, ErrInvalidCredentials
+	}
+
+	assessment := s.guard.Assess(ctx, user.ID)
+	response, err := issueTokens(ctx, s.authRepo, s.jwtManager, user, rememberMe)
+	if err == nil {
+		s.events.Record(ctx, &user.ID, audit.EventLoginSucceeded, map[string]interface{}{"method": "password", "two_factor": true, "remember_me": rememberMe})
+		s.guard.Succeeded(ctx, user.ID, user.Email, assessment, false)
+	}
+	return response, err
+}
+
+// issueTokens starts a session for a signed-in user, on the client in ctx,
+// and returns its token pair. Remember-me sessions last the longer refresh
+// lifetime.
+func issueTokens(ctx context.Context, authRepo repositories.AuthRepository, jwtManager *auth.JWTManager, user *models.User, rememberMe bool) (*models.AuthResponse, error) {
 	// Generate tokens
-	accessToken, refreshToken, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email)
+	sessionID := uuid.New()
+	accessToken, refreshToken, err := jwtManager.GenerateSessionTokenPair(user.ID, user.Email, sessionID, rememberMe)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	// Store refresh token
-	expiresAt := time.Now().Add(time.Duration(7*24) * time.Hour)
-	if err := s.authRepo.StoreRefreshToken(ctx, user.ID, refreshToken, expiresAt); err != nil {
+	client := auth.ClientFromContext(ctx)
+	expiresAt := time.Now().Add(jwtManager.RefreshDuration(rememberMe))
+	if err := authRepo.StoreRefreshToken(ctx, &repositories.RefreshToken{
+		ID:         sessionID,
+		UserID:     user.ID,
+		Token:      refreshToken,
+		ExpiresAt:  expiresAt,
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		RememberMe: rememberMe,
+	}); err != nil {
 		return nil, err
 	}
-	
+
 	// Update last login
-	_ = s.authRepo.UpdateLastLogin(ctx, user.ID)
-	
+	_ = authRepo.UpdateLastLogin(ctx, user.ID)
+
 	return &models.AuthResponse{
 		User: &models.UserResponse{
 			ID:        user.ID,
@@ -169,8 +341,9 @@ func (s *authService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 			LastName:  user.LastName,
 			IsActive:  user.IsActive,
 		},
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: &expiresAt,
 	}, nil
 }
 
@@ -203,20 +376,32 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*m
 		return nil, ErrInvalidToken
 	}
 	
-	// Generate new tokens
-	newAccessToken, newRefreshToken, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email)
+	// Generate new tokens for the same session, which stays remembered
+	newAccessToken, newRefreshToken, err := s.jwtManager.GenerateSessionTokenPair(user.ID, user.Email, storedToken.ID, storedToken.RememberMe)
 	if err != nil {
 		return nil, err
 	}
-	
-	// Delete old refresh token
-	_ = s.authRepo.DeleteRefreshToken(ctx, refreshToken)
-	
-	// Store new refresh token
-	expiresAt := time.Now().Add(time.Duration(7*24) * time.Hour)
-	if err := s.authRepo.StoreRefreshToken(ctx, user.ID, newRefreshToken, expiresAt); err != nil {
+
+	// Replace the old refresh token; losing a race with another refresh of
+	// it leaves nothing to replace
+	client := auth.ClientFromContext(ctx)
+	expiresAt := time.Now().Add(s.jwtManager.RefreshDuration(storedToken.RememberMe))
+	err = s.authRepo.RotateRefreshToken(ctx, refreshToken, &repositories.RefreshToken{
+		ID:         storedToken.ID,
+		UserID:     user.ID,
+		Token:      newRefreshToken,
+		ExpiresAt:  expiresAt,
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		RememberMe: storedToken.RememberMe,
+	})
+	if err == repositories.ErrTokenNotFound {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
 		return nil, err
 	}
+	s.events.Record(ctx, &user.ID, audit.EventTokenRefreshed, map[string]interface{}{"session_id": storedToken.ID.String()})
 	
 	return &models.AuthResponse{
 		User: &models.UserResponse{
@@ -226,12 +411,45 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*m
 			LastName:  user.LastName,
 			IsActive:  user.IsActive,
 		},
-		AccessToken:  newAccessToken,
-		RefreshToken: newRefreshToken,
+		AccessToken:      newAccessToken,
+		RefreshToken:     newRefreshToken,
+		RefreshExpiresAt: &expiresAt,
 	}, nil
 }
 
 // Logout invalidates all refresh tokens for a user
 func (s *authService) Logout(ctx context.Context, userID uuid.UUID) error {
 	return s.authRepo.DeleteUserRefreshTokens(ctx, userID)
-}
\ No newline at end of file
+}
+
+// ListSessions returns the user's unexpired sessions, most recently used
+// first
+func (s *authService) ListSessions(ctx context.Context, userID, currentID uuid.UUID) ([]models.Session, error) {
+	tokens, err := s.authRepo.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.Session, len(tokens))
+	for i, t := range tokens {
+		sessions[i] = models.Session{
+			ID:         t.ID,
+			UserAgent:  t.UserAgent,
+			IPAddress:  t.IPAddress,
+			SignedInAt: t.CreatedAt,
+			LastUsedAt: t.LastUsedAt,
+			ExpiresAt:  t.ExpiresAt,
+			Current:    t.ID == currentID,
+		}
+	}
+	return sessions, nil
+}
+
+// RevokeSession deletes the session's refresh token
+func (s *authService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	err := s.authRepo.DeleteSession(ctx, userID, sessionID)
+	if errors.Is(err, repositories.ErrTokenNotFound) {
+		return ErrSessionNotFound
+	}
+	return err
+}
@@ -2,20 +2,29 @@ package services
 
 import (
 	"context"
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
-	"encoding/base64"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/auth"
 	"github.com/nfl-analytics/backend/internal/models"
 	"github.com/nfl-analytics/backend/internal/repositories"
 )
 
+// ErrCredentialsInvalid is returned for stored credentials ESPN has
+// rejected, until the user replaces them
+var ErrCredentialsInvalid = errors.New("stored ESPN cookies were rejected; update them to reconnect")
+
+// CredentialHealth is whether a user's stored ESPN cookies still work
+type CredentialHealth struct {
+	Valid     bool       `json:"valid"`
+	InvalidAt *time.Time `json:"invalid_at,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+}
+
 // LeagueCredentials represents encrypted authentication data for fantasy platforms
 type LeagueCredentials struct {
 	SWID   string `json:"swid,omitempty"`
@@ -25,21 +34,17 @@ type LeagueCredentials struct {
 
 // CredentialsService handles secure credential storage for fantasy leagues
 type CredentialsService struct {
-	authRepo      repositories.LeagueAuthRepository
-	encryptionKey []byte
+	authRepo repositories.LeagueAuthRepository
+	box      *auth.SecretBox
 }
 
-// NewCredentialsService creates a new credentials service with encryption
-func NewCredentialsService(authRepo repositories.LeagueAuthRepository, encryptionKey string) (*CredentialsService, error) {
-	// Ensure encryption key is 32 bytes for AES-256
-	if len(encryptionKey) != 32 {
-		return nil, fmt.Errorf("encryption key must be 32 bytes")
-	}
-
+// NewCredentialsService creates a new credentials service encrypting with
+// box's current key
+func NewCredentialsService(authRepo repositories.LeagueAuthRepository, box *auth.SecretBox) *CredentialsService {
 	return &CredentialsService{
-		authRepo:      authRepo,
-		encryptionKey: []byte(encryptionKey),
-	}, nil
+		authRepo: authRepo,
+		box:      box,
+	}
 }
 
 // StoreESPNCredentials encrypts and stores ESPN authentication cookies
@@ -79,12 +84,16 @@ func (s *CredentialsService) StoreESPNCredentials(ctx context.Context, userID uu
 	return s.authRepo.Store(ctx, auth)
 }
 
-// GetESPNCredentials retrieves and decrypts ESPN authentication cookies
+// GetESPNCredentials retrieves and decrypts ESPN authentication cookies.
+// Cookies ESPN has rejected return ErrCredentialsInvalid.
 func (s *CredentialsService) GetESPNCredentials(ctx context.Context, userID uuid.UUID) (swid, espnS2 string, err error) {
 	auth, err := s.authRepo.GetByUserAndPlatform(ctx, userID, "espn")
 	if err != nil {
 		return "", "", err
 	}
+	if auth.InvalidAt.Valid {
+		return "", "", ErrCredentialsInvalid
+	}
 
 	// Decrypt the data
 	decrypted, err := s.decrypt(string(auth.EncryptedCredentials))
@@ -130,9 +139,11 @@ func (s *CredentialsService) UpdateESPNCredentials(ctx context.Context, userID u
 		return fmt.Errorf("failed to encrypt credentials: %w", err)
 	}
 
-	// Update the record
+	// Update the record; new cookies have not been rejected
 	auth.EncryptedCredentials = []byte(encrypted)
 	auth.UpdatedAt = time.Now()
+	auth.InvalidAt = sql.NullTime{}
+	auth.InvalidReason = sql.NullString{}
 
 	return s.authRepo.Update(ctx, auth)
 }
@@ -142,6 +153,28 @@ func (s *CredentialsService) DeleteLeagueCredentials(ctx context.Context, userID
 	return s.authRepo.Delete(ctx, userID, "espn")
 }
 
+// MarkESPNCredentialsInvalid records that ESPN rejected a user's stored
+// cookies, so they are not used again until the user updates them
+func (s *CredentialsService) MarkESPNCredentialsInvalid(ctx context.Context, userID uuid.UUID, reason string) error {
+	return s.authRepo.MarkInvalid(ctx, userID, "espn", reason, time.Now())
+}
+
+// GetESPNCredentialHealth reports whether a user's stored ESPN cookies were
+// rejected
+func (s *CredentialsService) GetESPNCredentialHealth(ctx context.Context, userID uuid.UUID) (*CredentialHealth, error) {
+	auth, err := s.authRepo.GetByUserAndPlatform(ctx, userID, "espn")
+	if err != nil {
+		return nil, err
+	}
+
+	health := &CredentialHealth{Valid: !auth.InvalidAt.Valid}
+	if auth.InvalidAt.Valid {
+		health.InvalidAt = &auth.InvalidAt.Time
+		health.Reason = auth.InvalidReason.String
+	}
+	return health, nil
+}
+
 // CheckCredentialsExpiry checks if credentials are about to expire
 func (s *CredentialsService) CheckCredentialsExpiry(ctx context.Context, userID uuid.UUID) (bool, time.Time, error) {
 	auth, err := s.authRepo.GetByUserAndPlatform(ctx, userID, "espn")
@@ -161,53 +194,12 @@ func (s *CredentialsService) CheckCredentialsExpiry(ctx context.Context, userID
 
 // encrypt encrypts data using AES-GCM
 func (s *CredentialsService) encrypt(plaintext []byte) (string, error) {
-	block, err := aes.NewCipher(s.encryptionKey)
-	if err != nil {
-		return "", err
-	}
-
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", err
-	}
-
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", err
-	}
-
-	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+	return s.box.Seal(plaintext)
 }
 
 // decrypt decrypts data using AES-GCM
 func (s *CredentialsService) decrypt(ciphertext string) ([]byte, error) {
-	data, err := base64.StdEncoding.DecodeString(ciphertext)
-	if err != nil {
-		return nil, err
-	}
-
-	block, err := aes.NewCipher(s.encryptionKey)
-	if err != nil {
-		return nil, err
-	}
-
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(data) < gcm.NonceSize() {
-		return nil, fmt.Errorf("ciphertext too short")
-	}
-
-	nonce, ciphertextBytes := data[:gcm.NonceSize()], data[gcm.NonceSize():]
-	plaintext, err := gcm.Open(nil, nonce, ciphertextBytes, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return plaintext, nil
+	return s.box.Open(ciphertext)
 }
 
 // validateESPNCredentials checks if ESPN credentials are valid format
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+)
+
+// PlatformCustom is the platform name stored on leagues set up by hand
+const PlatformCustom = "custom"
+
+// maxCustomRosterSize bounds a custom league's roster
+const maxCustomRosterSize = 40
+
+// ErrInvalidLeagueConfig is returned for a custom league setup that cannot
+// be used
+var ErrInvalidLeagueConfig = errors.New("invalid league configuration")
+
+// CustomLeagueConfig is a league on a platform we have no client for, such
+// as CBS or NFL.com, described by its owner
+type CustomLeagueConfig struct {
+	Name   string `json:"name" binding:"required"`
+	Season int    `json:"season" binding:"required"`
+	// HostPlatform is where the league is really played, for display
+	HostPlatform string `json:"host_platform"`
+	// ScoringType picks the default scoring rules: STANDARD, HALF_PPR or
+	// PPR. Scoring, when sent, replaces them.
+	ScoringType      string                    `json:"scoring_type"`
+	Scoring          *espn.ScoringSettings     `json:"scoring"`
+	Roster           models.RosterRequirements `json:"roster" binding:"required"`
+	Teams            []CustomLeagueTeam        `json:"teams" binding:"required,min=2,max=20,dive"`
+	PlayoffTeams     int                       `json:"playoff_teams"`
+	PlayoffWeekStart int                       `json:"playoff_week_start"`
+}
+
+// CustomLeagueTeam is a team in a custom league. Teams are numbered from 1
+// in the order given, and keep their numbers when the league is edited.
+type CustomLeagueTeam struct {
+	Name      string `json:"name" binding:"required"`
+	OwnerName string `json:"owner_name"`
+}
+
+// DefaultScoringRules returns the usual scoring for a format: 1 point per 25
+// passing yards and per 10 rushing or receiving yards, 4 per passing and 6
+// per other touchdown, and 1, 0.5 or no points per reception
+func DefaultScoringRules(scoringType string) espn.ScoringSettings {
+	rules := espn.ScoringSettings{
+		PassingYards:        0.04,
+		PassingTouchdowns:   4,
+		Interceptions:       -2,
+		RushingYards:        0.1,
+		RushingTouchdowns:   6,
+		ReceivingYards:      0.1,
+		ReceivingTouchdowns: 6,
+		Fumbles:             -2,
+		FieldGoalMade:       3,
+		FieldGoalMissed:     -1,
+		ExtraPointMade:      1,
+	}
+	switch scoringType {
+	case "PPR":
+		rules.ReceptionPoints = 1
+	case "HALF_PPR":
+		rules.ReceptionPoints = 0.5
+	}
+	return rules
+}
+
+// CreateCustomLeague stores a league set up by hand. It becomes the user's
+// selected league if they have not picked one.
+func (s *leagueService) CreateCustomLeague(ctx context.Context, userID uuid.UUID, config *CustomLeagueConfig) (*models.League, error) {
+	now := time.Now()
+	league := &models.League{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Platform:  PlatformCustom,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	league.ExternalID = league.ID.String()
+	if err := applyCustomLeagueConfig(league, config); err != nil {
+		return nil, err
+	}
+	if err := s.leagueRepo.Create(ctx, league); err != nil {
+		return nil, err
+	}
+
+	selectedID, err := s.leagueRepo.GetSelectedLeagueID(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if selectedID == "" {
+		if err := s.leagueRepo.SetSelectedLeague(ctx, userID.String(), league.ID.String()); err != nil {
+			return nil, err
+		}
+		selectedID = league.ID.String()
+	}
+
+	populateLeagueFields(league)
+	league.IsSelected = selectedID == league.ID.String()
+	return league, nil
+}
+
+// UpdateCustomLeague replaces a custom league's configuration
+func (s *leagueService) UpdateCustomLeague(ctx context.Context, userID, leagueID uuid.UUID, config *CustomLeagueConfig) (*models.League, error) {
+	league, err := s.getUserLeague(ctx, userID, leagueID, policy.ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+	if league.Platform != PlatformCustom {
+		return nil, fmt.Errorf("%w: only custom leagues can be edited", ErrInvalidLeagueConfig)
+	}
+
+	if err := applyCustomLeagueConfig(league, config); err != nil {
+		return nil, err
+	}
+	league.UpdatedAt = time.Now()
+	if err := s.leagueRepo.Update(ctx, league); err != nil {
+		return nil, err
+	}
+
+	populateLeagueFields(league)
+	return league, nil
+}
+
+// applyCustomLeagueConfig validates a configuration and stores it on the
+// league in the same form as an imported league's settings, so analytics
+// and drafts read it the same way
+func applyCustomLeagueConfig(league *models.League, config *CustomLeagueConfig) error {
+	name := strings.TrimSpace(config.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidLeagueConfig)
+	}
+	if config.Season < 2000 || config.Season > 2100 {
+		return fmt.Errorf("%w: season %d is out of range", ErrInvalidLeagueConfig, config.Season)
+	}
+
+	scoringType := strings.ToUpper(config.ScoringType)
+	switch scoringType {
+	case "":
+		scoringType = "PPR"
+	case "STANDARD", "HALF_PPR", "PPR":
+	default:
+		return fmt.Errorf("%w: scoring_type must be STANDARD, HALF_PPR or PPR", ErrInvalidLeagueConfig)
+	}
+	rules := DefaultScoringRules(scoringType)
+	if config.Scoring != nil {
+		rules = *config.Scoring
+	}
+
+	roster := config.Roster
+	for _, n := range []int{roster.QB, roster.RB, roster.WR, roster.TE, roster.FLEX, roster.DST, roster.K, roster.BE, roster.IR} {
+		if n < 0 {
+			return fmt.Errorf("%w: roster slots cannot be negative", ErrInvalidLeagueConfig)
+		}
+	}
+	starters := roster.QB + roster.RB + roster.WR + roster.TE + roster.FLEX + roster.DST + roster.K
+	rosterSize := starters + roster.BE
+	if starters == 0 {
+		return fmt.Errorf("%w: roster needs at least one starting slot", ErrInvalidLeagueConfig)
+	}
+	if rosterSize > maxCustomRosterSize {
+		return fmt.Errorf("%w: roster cannot have more than %d players", ErrInvalidLeagueConfig, maxCustomRosterSize)
+	}
+
+	teamCount := len(config.Teams)
+	if teamCount < 2 || teamCount > 20 {
+		return fmt.Errorf("%w: leagues need 2 to 20 teams", ErrInvalidLeagueConfig)
+	}
+	if config.PlayoffTeams < 0 || config.PlayoffTeams > teamCount {
+		return fmt.Errorf("%w: playoff_teams cannot exceed the number of teams", ErrInvalidLeagueConfig)
+	}
+	teams := make([]espn.Team, 0, teamCount)
+	for i, t := range config.Teams {
+		teamName := strings.TrimSpace(t.Name)
+		if teamName == "" {
+			return fmt.Errorf("%w: team %d has no name", ErrInvalidLeagueConfig, i+1)
+		}
+		teams = append(teams, espn.Team{
+			ID:       i + 1,
+			Nickname: teamName,
+			Owner:    espn.TeamOwner{Name: strings.TrimSpace(t.OwnerName)},
+		})
+	}
+
+	scoringRules, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scoring rules: %w", err)
+	}
+	settings, err := json.Marshal(models.LeagueSettings{
+		LeagueID:         league.ID,
+		Name:             name,
+		Season:           config.Season,
+		ScoringType:      scoringType,
+		RosterSize:       rosterSize,
+		PlayoffTeams:     config.PlayoffTeams,
+		PlayoffWeekStart: config.PlayoffWeekStart,
+		TeamCount:        teamCount,
+		Roster:           roster,
+		ScoringRules:     scoringRules,
+		HostPlatform:     strings.TrimSpace(config.HostPlatform),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal league settings: %w", err)
+	}
+	teamsData, err := json.Marshal(teams)
+	if err != nil {
+		return fmt.Errorf("failed to marshal teams: %w", err)
+	}
+
+	league.Name = name
+	league.Season = config.Season
+	league.Settings = settings
+	league.TeamsData = teamsData
+	return nil
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// authorizedLeague loads a connected league the user may take action on.
+// Leagues they may not and disconnected leagues are treated as not found, so
+// other users' leagues are not revealed.
+func authorizedLeague(ctx context.Context, leagueRepo repositories.LeagueRepository, authz *policy.Authorizer, userID, leagueID uuid.UUID, action policy.Action) (*models.League, error) {
+	league, err := leagueRepo.GetByID(ctx, leagueID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !league.IsActive {
+		return nil, repositories.ErrLeagueNotFound
+	}
+
+	err = authz.Authorize(ctx, policy.User(userID.String()), action, policy.League(league))
+	if errors.Is(err, policy.ErrForbidden) {
+		return nil, repositories.ErrLeagueNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return league, nil
+}
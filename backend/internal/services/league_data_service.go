@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/platform"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// LeagueDataService reads a connected league's live data from whichever
+// platform hosts it
+type LeagueDataService interface {
+	GetLeague(ctx context.Context, userID, leagueID uuid.UUID) (*platform.League, error)
+	GetRosters(ctx context.Context, userID, leagueID uuid.UUID) ([]platform.Roster, error)
+	GetPlayers(ctx context.Context, userID, leagueID uuid.UUID, limit int) ([]platform.Player, error)
+	GetTransactions(ctx context.Context, userID, leagueID uuid.UUID, limit int) ([]platform.Transaction, error)
+	GetDraft(ctx context.Context, userID, leagueID uuid.UUID) ([]platform.DraftPick, error)
+}
+
+// leagueDataService implements LeagueDataService
+type leagueDataService struct {
+	leagueRepo repositories.LeagueRepository
+	platforms  *platform.Factory
+	authz      *policy.Authorizer
+}
+
+// NewLeagueDataService creates a new league data service. authz decides who
+// besides a league's owner may read it; nil leaves leagues to their owners.
+func NewLeagueDataService(leagueRepo repositories.LeagueRepository, platforms *platform.Factory, authz *policy.Authorizer) LeagueDataService {
+	return &leagueDataService{
+		leagueRepo: leagueRepo,
+		platforms:  platforms,
+		authz:      authz,
+	}
+}
+
+// GetLeague returns the league's teams and standings
+func (s *leagueDataService) GetLeague(ctx context.Context, userID, leagueID uuid.UUID) (*platform.League, error) {
+	league, client, err := s.leagueClient(ctx, userID, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetLeague(ctx, league.ExternalID)
+}
+
+// GetRosters returns every team's roster
+func (s *leagueDataService) GetRosters(ctx context.Context, userID, leagueID uuid.UUID) ([]platform.Roster, error) {
+	league, client, err := s.leagueClient(ctx, userID, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetRosters(ctx, league.ExternalID)
+}
+
+// GetPlayers returns the league's available players
+func (s *leagueDataService) GetPlayers(ctx context.Context, userID, leagueID uuid.UUID, limit int) ([]platform.Player, error) {
+	league, client, err := s.leagueClient(ctx, userID, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetPlayers(ctx, league.ExternalID, limit)
+}
+
+// GetTransactions returns the league's latest transactions
+func (s *leagueDataService) GetTransactions(ctx context.Context, userID, leagueID uuid.UUID, limit int) ([]platform.Transaction, error) {
+	league, client, err := s.leagueClient(ctx, userID, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetTransactions(ctx, league.ExternalID, limit)
+}
+
+// GetDraft returns the league's draft picks
+func (s *leagueDataService) GetDraft(ctx context.Context, userID, leagueID uuid.UUID) ([]platform.DraftPick, error) {
+	league, client, err := s.leagueClient(ctx, userID, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetDraft(ctx, league.ExternalID)
+}
+
+// leagueClient loads a connected league the user may read, treating others
+// as not found, and the client for its platform
+func (s *leagueDataService) leagueClient(ctx context.Context, userID, leagueID uuid.UUID) (*models.League, platform.PlatformClient, error) {
+	league, err := authorizedLeague(ctx, s.leagueRepo, s.authz, userID, leagueID, policy.ActionRead)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := s.platforms.ForLeague(ctx, league)
+	if err != nil {
+		return nil, nil, err
+	}
+	return league, client, nil
+}
@@ -0,0 +1,449 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// PlatformESPN is the platform name stored on ESPN leagues
+const PlatformESPN = "espn"
+
+// ErrHistoryUnsupported is returned when a league's platform has no history
+var ErrHistoryUnsupported = errors.New("season history is not available for this platform")
+
+// ErrLiveUnsupported is returned when a league's platform has no live scoring
+var ErrLiveUnsupported = errors.New("live scoring is not available for this platform")
+
+// LeagueService handles the leagues users have connected
+type LeagueService interface {
+	ImportESPNLeague(ctx context.Context, userID uuid.UUID, info *espn.LeagueInfo) (*models.League, error)
+	// CreateCustomLeague stores a league on a platform with no client, set
+	// up by hand
+	CreateCustomLeague(ctx context.Context, userID uuid.UUID, config *CustomLeagueConfig) (*models.League, error)
+	UpdateCustomLeague(ctx context.Context, userID, leagueID uuid.UUID, config *CustomLeagueConfig) (*models.League, error)
+	ListLeagues(ctx context.Context, userID uuid.UUID) ([]*models.League, error)
+	SelectLeague(ctx context.Context, userID, leagueID uuid.UUID) (*models.League, error)
+	GetSelectedLeague(ctx context.Context, userID uuid.UUID) (*models.League, error)
+	GetLeague(ctx context.Context, userID, leagueID uuid.UUID) (*models.League, error)
+	DisconnectLeague(ctx context.Context, userID, leagueID uuid.UUID) error
+	ImportHistory(ctx context.Context, client espn.Client, userID, leagueID uuid.UUID, seasons []int) ([]int, error)
+	GetHistory(ctx context.Context, userID, leagueID uuid.UUID, season int) ([]models.LeagueSeasonHistory, error)
+	GetLiveScoring(ctx context.Context, client espn.Client, userID, leagueID uuid.UUID, week int) (*espn.LiveScoring, error)
+}
+
+// leagueService implements LeagueService
+type leagueService struct {
+	leagueRepo  repositories.LeagueRepository
+	historyRepo repositories.LeagueHistoryRepository
+	espnClient  espn.Client
+	authz       *policy.Authorizer
+}
+
+// NewLeagueService creates a new league service. authz decides who besides
+// a league's owner may see and change it; nil leaves leagues to their owners.
+func NewLeagueService(
+	leagueRepo repositories.LeagueRepository,
+	historyRepo repositories.LeagueHistoryRepository,
+	espnClient espn.Client,
+	authz *policy.Authorizer,
+) LeagueService {
+	return &leagueService{
+		leagueRepo:  leagueRepo,
+		historyRepo: historyRepo,
+		espnClient:  espnClient,
+		authz:       authz,
+	}
+}
+
+// ImportESPNLeague stores an ESPN league's settings, scoring and teams for a
+// user. Reconnecting a league the user already has refreshes it in place. The
+// league becomes the user's selected league if they have not picked one.
+func (s *leagueService) ImportESPNLeague(ctx context.Context, userID uuid.UUID, info *espn.LeagueInfo) (*models.League, error) {
+	league, err := s.leagueRepo.GetByExternalID(ctx, info.ID, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	leagueID := uuid.New()
+	if league != nil {
+		leagueID = league.ID
+	}
+
+	scoringFormat := s.espnClient.DetectScoringFormat(info.Settings)
+	settings, err := espnLeagueSettings(leagueID, info, scoringFormat)
+	if err != nil {
+		return nil, err
+	}
+	teamsData, err := json.Marshal(info.Teams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal teams: %w", err)
+	}
+
+	now := time.Now()
+
+	if league != nil {
+		league.Name = info.Name
+		league.Season = info.Season
+		league.Settings = settings
+		league.TeamsData = teamsData
+		league.IsActive = true
+		league.LastSyncAt.Time, league.LastSyncAt.Valid = now, true
+		if err := s.leagueRepo.Update(ctx, league); err != nil {
+			return nil, err
+		}
+	} else {
+		league = &models.League{
+			ID:         leagueID,
+			UserID:     userID,
+			Platform:   PlatformESPN,
+			ExternalID: info.ID,
+			Name:       info.Name,
+			Season:     info.Season,
+			Settings:   settings,
+			TeamsData:  teamsData,
+			IsActive:   true,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		league.LastSyncAt.Time, league.LastSyncAt.Valid = now, true
+		if err := s.leagueRepo.Create(ctx, league); err != nil {
+			return nil, err
+		}
+	}
+
+	selectedID, err := s.leagueRepo.GetSelectedLeagueID(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if selectedID == "" {
+		if err := s.leagueRepo.SetSelectedLeague(ctx, userID.String(), league.ID.String()); err != nil {
+			return nil, err
+		}
+		selectedID = league.ID.String()
+	}
+
+	league.ESPNLeagueID = info.ID
+	league.LeagueName = info.Name
+	league.ScoringType = scoringFormat
+	league.IsSelected = selectedID == league.ID.String()
+
+	return league, nil
+}
+
+// ListLeagues returns the user's connected leagues, most recent first, with
+// the selected league flagged
+func (s *leagueService) ListLeagues(ctx context.Context, userID uuid.UUID) ([]*models.League, error) {
+	leagues, err := s.leagueRepo.GetByUserID(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	selectedID, err := s.leagueRepo.GetSelectedLeagueID(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	connected := make([]*models.League, 0, len(leagues))
+	for _, league := range leagues {
+		if !league.IsActive {
+			continue
+		}
+		populateLeagueFields(league)
+		league.IsSelected = league.ID.String() == selectedID
+		connected = append(connected, league)
+	}
+
+	return connected, nil
+}
+
+// SelectLeague makes one of the user's connected leagues the one analytics
+// default to
+func (s *leagueService) SelectLeague(ctx context.Context, userID, leagueID uuid.UUID) (*models.League, error) {
+	league, err := s.getUserLeague(ctx, userID, leagueID, policy.ActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.leagueRepo.SetSelectedLeague(ctx, userID.String(), league.ID.String()); err != nil {
+		return nil, err
+	}
+
+	populateLeagueFields(league)
+	league.IsSelected = true
+
+	return league, nil
+}
+
+// GetSelectedLeague returns the user's selected league. Users who have not
+// picked one get their most recently connected league; nil means the user has
+// no connected leagues.
+func (s *leagueService) GetSelectedLeague(ctx context.Context, userID uuid.UUID) (*models.League, error) {
+	leagues, err := s.ListLeagues(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(leagues) == 0 {
+		return nil, nil
+	}
+
+	for _, league := range leagues {
+		if league.IsSelected {
+			return league, nil
+		}
+	}
+
+	return leagues[0], nil
+}
+
+// DisconnectLeague stops syncing one of the user's leagues and keeps its
+// history. The user's platform credentials are left alone since they may have
+// other leagues on the platform; if the league was selected, the most recently
+// connected remaining league is selected instead.
+func (s *leagueService) DisconnectLeague(ctx context.Context, userID, leagueID uuid.UUID) error {
+	league, err := s.getUserLeague(ctx, userID, leagueID, policy.ActionManage)
+	if err != nil {
+		return err
+	}
+
+	league.IsActive = false
+	if err := s.leagueRepo.Update(ctx, league); err != nil {
+		return err
+	}
+
+	selectedID, err := s.leagueRepo.GetSelectedLeagueID(ctx, userID.String())
+	if err != nil {
+		return err
+	}
+	if selectedID != league.ID.String() {
+		return nil
+	}
+
+	leagues, err := s.ListLeagues(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(leagues) == 0 {
+		return s.leagueRepo.ClearSelectedLeague(ctx, userID.String())
+	}
+
+	return s.leagueRepo.SetSelectedLeague(ctx, userID.String(), leagues[0].ID.String())
+}
+
+// ImportHistory pulls a league's past seasons from ESPN using client, which
+// carries the user's cookies. With no seasons given it imports every
+// previous season ESPN lists for the league that has not been imported yet;
+// listed seasons are always refetched. Seasons ESPN no longer has are
+// skipped. Returns the seasons imported.
+func (s *leagueService) ImportHistory(ctx context.Context, client espn.Client, userID, leagueID uuid.UUID, seasons []int) ([]int, error) {
+	league, err := s.getUserLeague(ctx, userID, leagueID, policy.ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+	if league.Platform != PlatformESPN {
+		return nil, ErrHistoryUnsupported
+	}
+
+	if len(seasons) == 0 {
+		if seasons, err = s.missingSeasons(ctx, client, league); err != nil {
+			return nil, err
+		}
+	}
+
+	imported := []int{}
+	for _, season := range seasons {
+		history, err := client.GetSeasonHistory(ctx, league.ExternalID, season)
+		if errors.Is(err, espn.ErrLeagueNotFound) {
+			log.Printf("Skipping %d history for league %s: not found on ESPN", season, league.ID)
+			continue
+		}
+		if err != nil {
+			return imported, err
+		}
+
+		record, err := seasonHistoryRecord(league.ID, history)
+		if err != nil {
+			return imported, err
+		}
+		if err := s.historyRepo.SaveSeason(ctx, record); err != nil {
+			return imported, err
+		}
+		imported = append(imported, season)
+	}
+
+	return imported, nil
+}
+
+// GetLiveScoring returns a league's current matchup scores for a week, or the
+// current week when week is 0
+func (s *leagueService) GetLiveScoring(ctx context.Context, client espn.Client, userID, leagueID uuid.UUID, week int) (*espn.LiveScoring, error) {
+	league, err := s.getUserLeague(ctx, userID, leagueID, policy.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+	if league.Platform != PlatformESPN {
+		return nil, ErrLiveUnsupported
+	}
+
+	if week == 0 {
+		status, err := client.GetSeasonStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		week = status.Week
+	}
+
+	return client.GetLiveScoring(ctx, league.ExternalID, week)
+}
+
+// GetLeague returns one of the user's active leagues, or
+// repositories.ErrLeagueNotFound
+func (s *leagueService) GetLeague(ctx context.Context, userID, leagueID uuid.UUID) (*models.League, error) {
+	league, err := s.getUserLeague(ctx, userID, leagueID, policy.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+	populateLeagueFields(league)
+	return league, nil
+}
+
+// GetHistory returns a league's imported past seasons, most recent first.
+// A nonzero season returns only that season.
+func (s *leagueService) GetHistory(ctx context.Context, userID, leagueID uuid.UUID, season int) ([]models.LeagueSeasonHistory, error) {
+	league, err := s.getUserLeague(ctx, userID, leagueID, policy.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if season == 0 {
+		return s.historyRepo.GetSeasons(ctx, league.ID.String())
+	}
+
+	history, err := s.historyRepo.GetSeason(ctx, league.ID.String(), season)
+	if err != nil || history == nil {
+		return nil, err
+	}
+	return []models.LeagueSeasonHistory{*history}, nil
+}
+
+// missingSeasons lists the league's previous seasons on ESPN that have not
+// been imported
+func (s *leagueService) missingSeasons(ctx context.Context, client espn.Client, league *models.League) ([]int, error) {
+	info, err := client.GetLeagueInfo(ctx, league.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.historyRepo.GetSeasons(ctx, league.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	have := make(map[int]bool, len(stored))
+	for _, h := range stored {
+		have[h.Season] = true
+	}
+
+	var missing []int
+	for _, season := range info.Status.PreviousSeasons {
+		if !have[season] {
+			missing = append(missing, season)
+		}
+	}
+	return missing, nil
+}
+
+// seasonHistoryRecord converts an ESPN season to the stored form
+func seasonHistoryRecord(leagueID uuid.UUID, history *espn.SeasonHistory) (*models.LeagueSeasonHistory, error) {
+	standings, err := json.Marshal(history.Standings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal standings: %w", err)
+	}
+	matchups, err := json.Marshal(history.Matchups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal matchups: %w", err)
+	}
+	picks, err := json.Marshal(history.DraftPicks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal draft picks: %w", err)
+	}
+
+	return &models.LeagueSeasonHistory{
+		LeagueID:   leagueID,
+		Season:     history.Season,
+		Standings:  standings,
+		Matchups:   matchups,
+		DraftPicks: picks,
+		ImportedAt: time.Now(),
+	}, nil
+}
+
+// getUserLeague loads a connected league the user may take action on,
+// treating others as not found
+func (s *leagueService) getUserLeague(ctx context.Context, userID, leagueID uuid.UUID, action policy.Action) (*models.League, error) {
+	return authorizedLeague(ctx, s.leagueRepo, s.authz, userID, leagueID, action)
+}
+
+// populateLeagueFields fills the platform-specific fields that are not stored
+// as columns
+func populateLeagueFields(league *models.League) {
+	if league.Platform == PlatformESPN {
+		league.ESPNLeagueID = league.ExternalID
+	}
+	league.LeagueName = league.Name
+
+	var settings models.LeagueSettings
+	if err := json.Unmarshal(league.Settings, &settings); err == nil {
+		league.ScoringType = settings.ScoringType
+	}
+}
+
+// espnLeagueSettings converts ESPN's league settings to the stored form
+func espnLeagueSettings(leagueID uuid.UUID, info *espn.LeagueInfo, scoringFormat string) (json.RawMessage, error) {
+	scoringRules, err := json.Marshal(info.Settings.ScoringSettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scoring settings: %w", err)
+	}
+
+	roster := info.Settings.RosterSettings
+	rosterSize := roster.Total
+	if rosterSize == 0 {
+		rosterSize = roster.QB + roster.RB + roster.WR + roster.TE + roster.FLEX + roster.DST + roster.K + roster.BENCH
+	}
+
+	settings, err := json.Marshal(models.LeagueSettings{
+		LeagueID:         leagueID,
+		Name:             info.Name,
+		Season:           info.Season,
+		ScoringType:      scoringFormat,
+		RosterSize:       rosterSize,
+		PlayoffTeams:     info.Settings.PlayoffSettings.PlayoffTeams,
+		PlayoffWeekStart: info.Settings.PlayoffSettings.PlayoffStart,
+		TeamCount:        len(info.Teams),
+		Roster: models.RosterRequirements{
+			QB:   roster.QB,
+			RB:   roster.RB,
+			WR:   roster.WR,
+			TE:   roster.TE,
+			FLEX: roster.FLEX,
+			DST:  roster.DST,
+			K:    roster.K,
+			BE:   roster.BENCH,
+			IR:   roster.IR,
+		},
+		ScoringRules: scoringRules,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal league settings: %w", err)
+	}
+
+	return settings, nil
+}
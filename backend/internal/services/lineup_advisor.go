@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/analytics"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// ErrNoSyncedRoster is returned when a team has no roster stored by the sync
+// worker
+var ErrNoSyncedRoster = errors.New("no synced roster for this team yet")
+
+// LineupAdvisor checks teams' lineups against their synced rosters, using
+// each player's current position eligibility
+type LineupAdvisor struct {
+	leagueRepo repositories.LeagueRepository
+	rosterRepo repositories.LeagueRosterRepository
+	syncRepo   repositories.LeagueSyncRepository
+	authz      *policy.Authorizer
+}
+
+// NewLineupAdvisor creates a lineup advisor over synced rosters and box
+// score projections. authz decides who besides a league's owner may read
+// it; nil leaves leagues to their owners.
+func NewLineupAdvisor(
+	leagueRepo repositories.LeagueRepository,
+	rosterRepo repositories.LeagueRosterRepository,
+	syncRepo repositories.LeagueSyncRepository,
+	authz *policy.Authorizer,
+) *LineupAdvisor {
+	return &LineupAdvisor{
+		leagueRepo: leagueRepo,
+		rosterRepo: rosterRepo,
+		syncRepo:   syncRepo,
+		authz:      authz,
+	}
+}
+
+// Analyze validates a team's lineup for the latest synced week and finds
+// the lineup with the most projected points
+func (a *LineupAdvisor) Analyze(ctx context.Context, userID, leagueID uuid.UUID, teamID int) (*analytics.LineupReport, error) {
+	league, err := authorizedLeague(ctx, a.leagueRepo, a.authz, userID, leagueID, policy.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings models.LeagueSettings
+	if err := json.Unmarshal(league.Settings, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse league settings: %w", err)
+	}
+
+	week, err := a.rosterRepo.GetLatestWeek(ctx, league.ID.String(), league.Season)
+	if err != nil {
+		return nil, err
+	}
+	if week == 0 {
+		return nil, ErrNoSyncedRoster
+	}
+
+	entries, err := a.rosterRepo.GetRosters(ctx, league.ID.String(), league.Season, week)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := a.syncRepo.GetBoxScores(ctx, league.ID.String(), league.Season, week)
+	if err != nil {
+		return nil, err
+	}
+	projected := make(map[string]float64, len(scores))
+	for _, s := range scores {
+		if s.TeamID == teamID {
+			projected[s.PlayerID] = s.ProjectedPoints
+		}
+	}
+
+	var players []analytics.LineupPlayer
+	for _, e := range entries {
+		if e.TeamID != teamID {
+			continue
+		}
+		positions := e.EligiblePositions
+		if len(positions) == 0 && e.Position != "" {
+			positions = []string{e.Position}
+		}
+		players = append(players, analytics.LineupPlayer{
+			PlayerID:        e.PlayerID,
+			PlayerName:      e.PlayerName,
+			Positions:       positions,
+			ProjectedPoints: projected[e.PlayerID],
+			LineupSlot:      e.LineupSlot,
+		})
+	}
+	if len(players) == 0 {
+		return nil, ErrNoSyncedRoster
+	}
+
+	return analytics.AnalyzeLineup(settings.Roster, players), nil
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// NotificationService handles watchlists and in-app notifications
+type NotificationService interface {
+	NotifyProjectionChanges(ctx context.Context, changes []projections.ProjectionChange) (int, error)
+	NotifyMaintenance(ctx context.Context, userIDs []uuid.UUID, message string, deadline time.Time) (int, error)
+	NotifyTransactionChange(ctx context.Context, change models.TransactionChange) (int, error)
+	NotifyEligibilityChange(ctx context.Context, change models.EligibilityChange) (int, error)
+	NotifyInactivity(ctx context.Context, userID uuid.UUID, anonymizeAt time.Time) error
+	GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, id int64) error
+	GetWatchlist(ctx context.Context, userID uuid.UUID) ([]models.WatchlistEntry, error)
+	AddToWatchlist(ctx context.Context, userID uuid.UUID, playerName string) error
+	RemoveFromWatchlist(ctx context.Context, userID uuid.UUID, playerName string) error
+}
+
+// notificationService implements NotificationService
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+	watchlistRepo    repositories.WatchlistRepository
+	syncRepo         repositories.LeagueSyncRepository
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(
+	notificationRepo repositories.NotificationRepository,
+	watchlistRepo repositories.WatchlistRepository,
+	syncRepo repositories.LeagueSyncRepository,
+) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		watchlistRepo:    watchlistRepo,
+		syncRepo:         syncRepo,
+	}
+}
+
+// NotifyProjectionChanges alerts every user who rosters or watches a player
+// whose projection changed. A user following a player both ways is notified
+// once. Returns the number of notifications created.
+func (s *notificationService) NotifyProjectionChanges(ctx context.Context, changes []projections.ProjectionChange) (int, error) {
+	if len(changes) == 0 {
+		return 0, nil
+	}
+
+	names := make([]string, 0, len(changes))
+	for _, c := range changes {
+		names = append(names, c.PlayerName)
+	}
+
+	rostered, err := s.syncRepo.GetRosteredBy(ctx, names)
+	if err != nil {
+		return 0, err
+	}
+	watchers, err := s.watchlistRepo.GetWatchers(ctx, names)
+	if err != nil {
+		return 0, err
+	}
+
+	var notifications []models.Notification
+	for _, c := range changes {
+		data, err := json.Marshal(c)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode projection change: %w", err)
+		}
+		title, body := describeProjectionChange(c)
+
+		notified := make(map[uuid.UUID]bool)
+		for _, userID := range append(rostered[c.PlayerName], watchers[c.PlayerName]...) {
+			if notified[userID] {
+				continue
+			}
+			notified[userID] = true
+			notifications = append(notifications, models.Notification{
+				UserID: userID,
+				Type:   models.NotificationProjectionChange,
+				Title:  title,
+				Body:   body,
+				Data:   data,
+			})
+		}
+	}
+
+	if err := s.notificationRepo.Create(ctx, notifications); err != nil {
+		return 0, err
+	}
+
+	return len(notifications), nil
+}
+
+// NotifyInactivity warns a user that their unused account will be anonymized
+// at anonymizeAt unless they log in
+func (s *notificationService) NotifyInactivity(ctx context.Context, userID uuid.UUID, anonymizeAt time.Time) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"anonymize_at": anonymizeAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode inactivity warning: %w", err)
+	}
+
+	return s.notificationRepo.Create(ctx, []models.Notification{{
+		UserID: userID,
+		Type:   models.NotificationInactivity,
+		Title:  "Your account will be anonymized",
+		Body: fmt.Sprintf("You haven't logged in for a long time. Log in before %s to keep your account; "+
+			"otherwise your personal details and league credentials will be removed.", anonymizeAt.UTC().Format("January 2, 2006")),
+		Data: data,
+	}})
+}
+
+// NotifyMaintenance warns users that maintenance has started and their
+// draft will be interrupted at deadline. Returns the number of notifications
+// created.
+func (s *notificationService) NotifyMaintenance(ctx context.Context, userIDs []uuid.UUID, message string, deadline time.Time) (int, error) {
+	data, err := json.Marshal(map[string]interface{}{
+		"message":  message,
+		"deadline": deadline,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode maintenance warning: %w", err)
+	}
+
+	var notifications []models.Notification
+	notified := make(map[uuid.UUID]bool)
+	for _, userID := range userIDs {
+		if notified[userID] {
+			continue
+		}
+		notified[userID] = true
+		notifications = append(notifications, models.Notification{
+			UserID: userID,
+			Type:   models.NotificationMaintenance,
+			Title:  "Scheduled maintenance has started",
+			Body:   fmt.Sprintf("%s Your draft stays available until %s.", message, deadline.UTC().Format("15:04 MST")),
+			Data:   data,
+		})
+	}
+
+	if err := s.notificationRepo.Create(ctx, notifications); err != nil {
+		return 0, err
+	}
+
+	return len(notifications), nil
+}
+
+// NotifyTransactionChange alerts a league's owner to a processed trade, or
+// to a drop of a player on their watchlist. Returns the number of
+// notifications created.
+func (s *notificationService) NotifyTransactionChange(ctx context.Context, change models.TransactionChange) (int, error) {
+	players := strings.Join(change.Players, ", ")
+	league := change.LeagueName
+	if league == "" {
+		league = "your league"
+	}
+
+	var notification models.Notification
+	switch change.Kind {
+	case models.TransactionTradeProcessed:
+		notification = models.Notification{
+			Type:  models.NotificationTradeProcessed,
+			Title: "Trade processed in " + league,
+			Body:  "Players moved: " + players,
+		}
+	case models.TransactionPlayerDropped:
+		watchers, err := s.watchlistRepo.GetWatchers(ctx, change.Players)
+		if err != nil {
+			return 0, err
+		}
+		var watched []string
+		for _, player := range change.Players {
+			for _, userID := range watchers[player] {
+				if userID == change.OwnerID {
+					watched = append(watched, player)
+					break
+				}
+			}
+		}
+		if len(watched) == 0 {
+			return 0, nil
+		}
+		notification = models.Notification{
+			Type:  models.NotificationPlayerDropped,
+			Title: strings.Join(watched, ", ") + " dropped in " + league,
+			Body:  "Dropped players are back on waivers: " + strings.Join(watched, ", "),
+		}
+	default:
+		return 0, nil
+	}
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode transaction change: %w", err)
+	}
+	notification.UserID = change.OwnerID
+	notification.Data = data
+
+	if err := s.notificationRepo.Create(ctx, []models.Notification{notification}); err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+// NotifyEligibilityChange tells a league's owner that a player on their team
+// can now start at more positions. Lost eligibility is left to roster
+// validation. Returns the number of notifications created.
+func (s *notificationService) NotifyEligibilityChange(ctx context.Context, change models.EligibilityChange) (int, error) {
+	if !change.OwnerRostered || len(change.Added) == 0 {
+		return 0, nil
+	}
+
+	league := change.LeagueName
+	if league == "" {
+		league = "your league"
+	}
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode eligibility change: %w", err)
+	}
+	notification := models.Notification{
+		UserID: change.OwnerID,
+		Type:   models.NotificationEligibility,
+		Title:  fmt.Sprintf("%s is now %s eligible in %s", change.PlayerName, strings.Join(change.Added, "/"), league),
+		Body:   fmt.Sprintf("%s can now start at %s, giving you more lineup flexibility.", change.PlayerName, strings.Join(change.Positions, " or ")),
+		Data:   data,
+	}
+
+	if err := s.notificationRepo.Create(ctx, []models.Notification{notification}); err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+// GetNotifications retrieves a user's notifications, newest first
+func (s *notificationService) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error) {
+	return s.notificationRepo.GetByUser(ctx, userID, unreadOnly, limit)
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *notificationService) MarkRead(ctx context.Context, userID uuid.UUID, id int64) error {
+	return s.notificationRepo.MarkRead(ctx, userID, id)
+}
+
+// GetWatchlist retrieves the players a user follows
+func (s *notificationService) GetWatchlist(ctx context.Context, userID uuid.UUID) ([]models.WatchlistEntry, error) {
+	return s.watchlistRepo.GetByUser(ctx, userID)
+}
+
+// AddToWatchlist follows a player
+func (s *notificationService) AddToWatchlist(ctx context.Context, userID uuid.UUID, playerName string) error {
+	return s.watchlistRepo.Add(ctx, userID, strings.TrimSpace(playerName))
+}
+
+// RemoveFromWatchlist stops following a player
+func (s *notificationService) RemoveFromWatchlist(ctx context.Context, userID uuid.UUID, playerName string) error {
+	return s.watchlistRepo.Remove(ctx, userID, strings.TrimSpace(playerName))
+}
+
+// projectionChangeReasons are the user-facing labels for change reasons
+var projectionChangeReasons = map[string]string{
+	projections.ReasonSourceUpdate: "source update",
+	projections.ReasonInjury:       "injury designation",
+	projections.ReasonGameContext:  "game conditions",
+}
+
+// describeProjectionChange builds the notification title and body for a change
+func describeProjectionChange(c projections.ProjectionChange) (string, string) {
+	direction := "up"
+	if c.DeltaPPR < 0 {
+		direction = "down"
+	}
+	title := fmt.Sprintf("%s projection %s %.1f", c.PlayerName, direction, math.Abs(c.DeltaPPR))
+
+	body := fmt.Sprintf("Week %d projection moved from %.2f to %.2f PPR", c.Week, c.PreviousPPR, c.CurrentPPR)
+	if len(c.Reasons) > 0 {
+		labels := make([]string, 0, len(c.Reasons))
+		for _, r := range c.Reasons {
+			labels = append(labels, projectionChangeReasons[r])
+		}
+		body += " (" + strings.Join(labels, ", ") + ")"
+	}
+
+	return title, body
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/loginguard"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// ErrEmailNotVerified is returned when a provider account with an unverified
+// email is used to sign in, since it cannot be matched to an account or
+// trusted to create one
+var ErrEmailNotVerified = errors.New("email is not verified with the provider")
+
+// OAuthService signs users in with social sign-in providers
+type OAuthService interface {
+	// SignIn returns our token pair for a provider account, linking it to the
+	// account with the same verified email or creating one
+	SignIn(ctx context.Context, identity *auth.OAuthIdentity) (*models.AuthResponse, error)
+}
+
+// oauthService implements OAuthService
+type oauthService struct {
+	authRepo     repositories.AuthRepository
+	userRepo     repositories.UserRepository
+	identityRepo repositories.IdentityRepository
+	jwtManager   *auth.JWTManager
+	twoFactor    TwoFactorService
+	guard        *loginguard.Guard
+}
+
+// NewOAuthService creates a new OAuth service. Users with two-factor on must
+// still enter a code; twoFactor may be nil to skip the check. guard alerts
+// users to sign-ins from new devices or networks and may be nil.
+func NewOAuthService(authRepo repositories.AuthRepository, userRepo repositories.UserRepository, identityRepo repositories.IdentityRepository, jwtManager *auth.JWTManager, twoFactor TwoFactorService, guard *loginguard.Guard) OAuthService {
+	return &oauthService{
+		authRepo:     authRepo,
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		jwtManager:   jwtManager,
+		twoFactor:    twoFactor,
+		guard:        guard,
+	}
+}
+
+// SignIn finds or creates the user for a provider account and issues tokens
+func (s *oauthService) SignIn(ctx context.Context, identity *auth.OAuthIdentity) (*models.AuthResponse, error) {
+	user, err := s.findUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user is active
+	if !user.IsActive {
+		return nil, ErrInvalidCredentials
+	}
+
+	// The provider only stands in for the password
+	if challenge, err := twoFactorChallenge(ctx, s.twoFactor, s.jwtManager, user.ID, false); err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	// The provider has confirmed the account, so a new device is only
+	// alerted, never held for an emailed code
+	assessment := s.guard.Assess(ctx, user.ID)
+	response, err := issueTokens(ctx, s.authRepo, s.jwtManager, user, false)
+	if err == nil {
+		s.guard.Succeeded(ctx, user.ID, user.Email, assessment, false)
+	}
+	return response, err
+}
+
+// findUser returns the user already linked to the provider account. Failing
+// that, a verified email links the account to the user with that email, or
+// to a new user without a password.
+func (s *oauthService) findUser(ctx context.Context, identity *auth.OAuthIdentity) (*models.User, error) {
+	userID, err := s.identityRepo.GetUserID(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			// Linked to a deleted account
+			return nil, ErrInvalidCredentials
+		}
+		return user, err
+	}
+	if !errors.Is(err, repositories.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	if !identity.EmailVerified || identity.Email == "" {
+		return nil, ErrEmailNotVerified
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, identity.Email)
+	if errors.Is(err, repositories.ErrUserNotFound) {
+		now := time.Now()
+		user = &models.User{
+			ID:         uuid.New(),
+			Email:      identity.Email,
+			FirstName:  identity.FirstName,
+			LastName:   identity.LastName,
+			IsActive:   true,
+			IsVerified: true,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		err = s.userRepo.Create(ctx, user)
+		if errors.Is(err, repositories.ErrUserExists) {
+			// Created by a sign-in running at the same time
+			user, err = s.userRepo.GetByEmail(ctx, identity.Email)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user for %s sign-in: %w", identity.Provider, err)
+	}
+
+	if err := s.identityRepo.Link(ctx, user.ID, identity.Provider, identity.Subject, identity.Email); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
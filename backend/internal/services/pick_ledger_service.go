@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/analytics"
+)
+
+// pickLedgerTransactionLimit is how far back a sync looks for trades
+const pickLedgerTransactionLimit = 500
+
+// PickLedgerService keeps a league's ledger of traded rookie picks in step
+// with the trades on its platform
+type PickLedgerService interface {
+	// SyncTrades records the picks moved by the league's trades that are not
+	// in the ledger yet, returning how many were added
+	SyncTrades(ctx context.Context, userID, leagueID uuid.UUID) (int, error)
+}
+
+// pickLedgerService implements PickLedgerService
+type pickLedgerService struct {
+	leagueData LeagueDataService
+	picks      analytics.PickRepository
+}
+
+// NewPickLedgerService creates a new pick ledger service
+func NewPickLedgerService(leagueData LeagueDataService, picks analytics.PickRepository) PickLedgerService {
+	return &pickLedgerService{
+		leagueData: leagueData,
+		picks:      picks,
+	}
+}
+
+// SyncTrades records the league's new pick trades. Platforms that do not
+// report picks in their transactions add nothing.
+func (s *pickLedgerService) SyncTrades(ctx context.Context, userID, leagueID uuid.UUID) (int, error) {
+	transactions, err := s.leagueData.GetTransactions(ctx, userID, leagueID, pickLedgerTransactionLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for _, trade := range analytics.PickTradesFromTransactions(leagueID.String(), transactions) {
+		recorded, err := s.picks.RecordPickTrade(ctx, &trade)
+		if err != nil {
+			return added, err
+		}
+		if recorded {
+			added++
+		}
+	}
+	return added, nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// NewsSourceESPN identifies news and injury data pulled from ESPN
+const NewsSourceESPN = "espn"
+
+// PlayerNewsService handles player news and injury designations
+type PlayerNewsService interface {
+	SyncInjuries(ctx context.Context) (int, error)
+	RefreshNews(ctx context.Context, playerID string) ([]models.PlayerNews, error)
+	GetPlayerNews(ctx context.Context, playerID string, limit int) ([]models.PlayerNews, error)
+	GetInjuryStatus(ctx context.Context, playerID string) (string, error)
+}
+
+// playerNewsService implements PlayerNewsService
+type playerNewsService struct {
+	newsRepo   repositories.PlayerNewsRepository
+	espnClient espn.Client
+	newsLimit  int
+}
+
+// NewPlayerNewsService creates a new player news service. newsLimit caps how
+// many blurbs are fetched per player.
+func NewPlayerNewsService(newsRepo repositories.PlayerNewsRepository, espnClient espn.Client, newsLimit int) PlayerNewsService {
+	return &playerNewsService{
+		newsRepo:   newsRepo,
+		espnClient: espnClient,
+		newsLimit:  newsLimit,
+	}
+}
+
+// SyncInjuries replaces the current week's injury designations and refreshes
+// news for every injured player. A news failure for one player is logged and
+// does not stop the others. Returns the number of injured players.
+func (s *playerNewsService) SyncInjuries(ctx context.Context) (int, error) {
+	status, err := s.espnClient.GetSeasonStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	report, err := s.espnClient.GetInjuryReport(ctx, status.Season)
+	if err != nil {
+		return 0, err
+	}
+
+	statuses := make([]models.InjuryStatus, 0, len(report))
+	for _, r := range report {
+		statuses = append(statuses, models.InjuryStatus{
+			PlayerID:   r.PlayerID,
+			PlayerName: r.PlayerName,
+			Season:     status.Season,
+			Week:       status.Week,
+			Status:     r.Status,
+			Source:     NewsSourceESPN,
+		})
+	}
+	if err := s.newsRepo.ReplaceInjuryStatuses(ctx, status.Season, status.Week, NewsSourceESPN, statuses); err != nil {
+		return 0, err
+	}
+
+	for _, r := range report {
+		if ctx.Err() != nil {
+			return len(report), ctx.Err()
+		}
+		if _, err := s.RefreshNews(ctx, r.PlayerID); err != nil {
+			log.Printf("Failed to refresh news for player %s: %v", r.PlayerID, err)
+		}
+	}
+
+	return len(report), nil
+}
+
+// RefreshNews fetches and stores a player's latest news
+func (s *playerNewsService) RefreshNews(ctx context.Context, playerID string) ([]models.PlayerNews, error) {
+	items, err := s.espnClient.GetPlayerNews(ctx, playerID, s.newsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	news := make([]models.PlayerNews, 0, len(items))
+	for _, item := range items {
+		news = append(news, models.PlayerNews{
+			Source:      NewsSourceESPN,
+			ExternalID:  item.ID,
+			PlayerID:    playerID,
+			Headline:    item.Headline,
+			Story:       item.Story,
+			NewsSource:  item.Source,
+			PublishedAt: item.Published,
+		})
+	}
+
+	if err := s.newsRepo.UpsertNews(ctx, news); err != nil {
+		return nil, fmt.Errorf("failed to store news for player %s: %w", playerID, err)
+	}
+
+	return news, nil
+}
+
+// GetPlayerNews returns stored news for a player, fetching it from ESPN the
+// first time a player is requested
+func (s *playerNewsService) GetPlayerNews(ctx context.Context, playerID string, limit int) ([]models.PlayerNews, error) {
+	news, err := s.newsRepo.GetNewsByPlayer(ctx, playerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(news) > 0 {
+		return news, nil
+	}
+
+	news, err = s.RefreshNews(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(news) > limit {
+		news = news[:limit]
+	}
+	return news, nil
+}
+
+// GetInjuryStatus returns the player's current designation, or "" if healthy
+func (s *playerNewsService) GetInjuryStatus(ctx context.Context, playerID string) (string, error) {
+	statuses, err := s.newsRepo.GetInjuryStatuses(ctx, []string{playerID})
+	if err != nil {
+		return "", err
+	}
+	return statuses[playerID], nil
+}
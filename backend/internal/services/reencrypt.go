@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// reencryptBatchSize is how many values are read at a time
+const reencryptBatchSize = 500
+
+// ReencryptResult counts one kind of value moved to the current key
+type ReencryptResult struct {
+	Kind    string `json:"kind"`
+	Scanned int    `json:"scanned"`
+	// Current values were already sealed with the current key
+	Current     int `json:"current"`
+	Reencrypted int `json:"reencrypted"`
+	// Changed values were replaced by their user during the run and are
+	// left as they are
+	Changed int `json:"changed"`
+	// Failed values could not be opened with any configured key
+	Failed int `json:"failed"`
+}
+
+// Reencryptor moves stored credentials and two-factor secrets sealed with
+// retired encryption keys to the current one, so a retired key can be
+// dropped from ENCRYPTION_PREVIOUS_KEYS
+type Reencryptor struct {
+	repo repositories.EncryptedValueRepository
+	box  *auth.SecretBox
+}
+
+// NewReencryptor creates a reencryptor sealing with box's current key
+func NewReencryptor(repo repositories.EncryptedValueRepository, box *auth.SecretBox) *Reencryptor {
+	return &Reencryptor{repo: repo, box: box}
+}
+
+// Run re-encrypts every value not sealed with the current key. A dry run
+// only counts them. It is safe to run while the API serves traffic and to
+// run again after a failure.
+func (r *Reencryptor) Run(ctx context.Context, dryRun bool) ([]ReencryptResult, error) {
+	results := make([]ReencryptResult, 0, len(repositories.EncryptedKinds))
+	for _, kind := range repositories.EncryptedKinds {
+		result, err := r.runKind(ctx, kind, dryRun)
+		results = append(results, result)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// runKind re-encrypts one kind of value, a batch at a time
+func (r *Reencryptor) runKind(ctx context.Context, kind string, dryRun bool) (ReencryptResult, error) {
+	result := ReencryptResult{Kind: kind}
+	after := uuid.Nil
+	for {
+		values, err := r.repo.List(ctx, kind, after, reencryptBatchSize)
+		if err != nil {
+			return result, err
+		}
+
+		for _, v := range values {
+			result.Scanned++
+			if !r.box.NeedsReseal(v.Ciphertext) {
+				result.Current++
+				continue
+			}
+
+			resealed, err := r.box.Reseal(v.Ciphertext)
+			if err != nil {
+				log.Printf("Failed to re-encrypt %s %s: %v", kind, v.ID, err)
+				result.Failed++
+				continue
+			}
+			if dryRun {
+				result.Reencrypted++
+				continue
+			}
+
+			replaced, err := r.repo.Replace(ctx, kind, v.ID, v.Ciphertext, resealed)
+			if err != nil {
+				return result, err
+			}
+			if replaced {
+				result.Reencrypted++
+			} else {
+				result.Changed++
+			}
+		}
+
+		if len(values) < reencryptBatchSize {
+			return result, nil
+		}
+		after = values[len(values)-1].ID
+	}
+}
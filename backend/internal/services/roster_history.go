@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/analytics"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/integrations/platform"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// ErrInvalidWeekRange is returned when a roster history's from week is after
+// its to week
+var ErrInvalidWeekRange = errors.New("from week must not be after to week")
+
+// TeamAcquisitions is how a team's current roster was acquired
+type TeamAcquisitions struct {
+	TeamID       int                           `json:"team_id"`
+	Season       int                           `json:"season"`
+	Week         int                           `json:"week"`
+	Players      []analytics.PlayerAcquisition `json:"players"`
+	Construction analytics.RosterConstruction  `json:"construction"`
+}
+
+// RosterHistorian rebuilds how teams' rosters changed over a season from
+// the weekly rosters and transactions kept by league syncs
+type RosterHistorian struct {
+	leagueRepo repositories.LeagueRepository
+	rosterRepo repositories.LeagueRosterRepository
+	syncRepo   repositories.LeagueSyncRepository
+	platforms  *platform.Factory
+	authz      *policy.Authorizer
+}
+
+// NewRosterHistorian creates a roster historian over synced league data.
+// Draft rounds come from the league's platform through platforms, which may
+// be nil to leave them out. authz decides who besides a league's owner may
+// read it; nil leaves leagues to their owners.
+func NewRosterHistorian(
+	leagueRepo repositories.LeagueRepository,
+	rosterRepo repositories.LeagueRosterRepository,
+	syncRepo repositories.LeagueSyncRepository,
+	platforms *platform.Factory,
+	authz *policy.Authorizer,
+) *RosterHistorian {
+	return &RosterHistorian{
+		leagueRepo: leagueRepo,
+		rosterRepo: rosterRepo,
+		syncRepo:   syncRepo,
+		platforms:  platforms,
+		authz:      authz,
+	}
+}
+
+// History returns a team's roster week by week for weeks from..to of a
+// season, with how each player was acquired. A zero season is the league's
+// current one; a zero to is the latest synced week.
+func (h *RosterHistorian) History(ctx context.Context, userID, leagueID uuid.UUID, teamID, season, from, to int) (*analytics.RosterHistory, error) {
+	league, err := h.ownedLeague(ctx, userID, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	if season == 0 {
+		season = league.Season
+	}
+
+	history, _, err := h.history(ctx, league, teamID, season, from, to)
+	return history, err
+}
+
+// Acquisitions tags each player on a team's current roster with how they
+// were acquired
+func (h *RosterHistorian) Acquisitions(ctx context.Context, userID, leagueID uuid.UUID, teamID int) (*TeamAcquisitions, error) {
+	league, err := h.ownedLeague(ctx, userID, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	return h.acquisitions(ctx, league, teamID, h.draft(ctx, league))
+}
+
+// Construction summarizes how every team in a league built its current
+// roster, keyed by team ID. Teams without synced rosters are left out.
+func (h *RosterHistorian) Construction(ctx context.Context, league *models.League) (map[int]analytics.RosterConstruction, error) {
+	week, err := h.rosterRepo.GetLatestWeek(ctx, league.ID.String(), league.Season)
+	if err != nil || week == 0 {
+		return nil, err
+	}
+	entries, err := h.rosterRepo.GetRosters(ctx, league.ID.String(), league.Season, week)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := h.draft(ctx, league)
+	constructions := make(map[int]analytics.RosterConstruction)
+	for _, e := range entries {
+		if _, done := constructions[e.TeamID]; done {
+			continue
+		}
+		team, err := h.acquisitions(ctx, league, e.TeamID, draft)
+		if err != nil {
+			return nil, fmt.Errorf("team %d: %w", e.TeamID, err)
+		}
+		constructions[e.TeamID] = team.Construction
+	}
+	return constructions, nil
+}
+
+func (h *RosterHistorian) acquisitions(ctx context.Context, league *models.League, teamID int, draft []platform.DraftPick) (*TeamAcquisitions, error) {
+	history, transactions, err := h.history(ctx, league, teamID, league.Season, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Starters' points are the ones that counted for the team
+	points := make(map[string]float64)
+	for week := 1; week <= history.ToWeek; week++ {
+		scores, err := h.syncRepo.GetBoxScores(ctx, league.ID.String(), league.Season, week)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range scores {
+			if s.TeamID == teamID && s.Starter {
+				points[s.PlayerID] += s.Points
+			}
+		}
+	}
+
+	players := analytics.AttributeAcquisitions(history, draft, transactions, points)
+	return &TeamAcquisitions{
+		TeamID:       teamID,
+		Season:       league.Season,
+		Week:         history.ToWeek,
+		Players:      players,
+		Construction: analytics.SummarizeRosterConstruction(teamID, players),
+	}, nil
+}
+
+// history rebuilds a team's roster history, also returning the
+// transactions it was matched against
+func (h *RosterHistorian) history(ctx context.Context, league *models.League, teamID, season, from, to int) (*analytics.RosterHistory, []espn.Transaction, error) {
+	entries, err := h.rosterRepo.GetTeamHistory(ctx, league.ID.String(), season, teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil, ErrNoSyncedRoster
+	}
+	if to == 0 {
+		to = entries[len(entries)-1].Week
+	}
+	if from > to {
+		return nil, nil, ErrInvalidWeekRange
+	}
+
+	// Transaction snapshots are kept per week rather than per season; those
+	// from this season were saved by the same syncs as its rosters, just
+	// after them
+	transactions, err := h.transactionsSince(ctx, league.ID.String(), entries[0].SyncedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return analytics.BuildRosterHistory(teamID, season, entries, transactions, from, to), transactions, nil
+}
+
+// transactionsSince returns the league's transactions from snapshots synced
+// since the given time, each once
+func (h *RosterHistorian) transactionsSince(ctx context.Context, leagueID string, since time.Time) ([]espn.Transaction, error) {
+	payloads, err := h.syncRepo.ListSnapshots(ctx, leagueID, "transactions", since)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []espn.Transaction
+	seen := make(map[string]bool)
+	for _, payload := range payloads {
+		var batch []espn.Transaction
+		if err := json.Unmarshal(payload, &batch); err != nil {
+			return nil, fmt.Errorf("failed to decode transactions snapshot: %w", err)
+		}
+		for _, t := range batch {
+			// Trades only move players once executed
+			if seen[t.ID] || (strings.EqualFold(t.Type, "TRADE") && !strings.EqualFold(t.Status, "EXECUTED")) {
+				continue
+			}
+			seen[t.ID] = true
+			transactions = append(transactions, t)
+		}
+	}
+	return transactions, nil
+}
+
+// draft returns the league's draft picks. Without them drafted players
+// still count as drafted, just without their round, so failures are only
+// logged.
+func (h *RosterHistorian) draft(ctx context.Context, league *models.League) []platform.DraftPick {
+	if h.platforms == nil {
+		return nil
+	}
+	client, err := h.platforms.ForLeague(ctx, league)
+	if err != nil {
+		log.Printf("Failed to get platform client for league %s draft: %v", league.ID, err)
+		return nil
+	}
+	picks, err := client.GetDraft(ctx, league.ExternalID)
+	if err != nil {
+		log.Printf("Failed to get draft results for league %s: %v", league.ID, err)
+		return nil
+	}
+	return picks
+}
+
+// ownedLeague returns an active league the user may read
+func (h *RosterHistorian) ownedLeague(ctx context.Context, userID, leagueID uuid.UUID) (*models.League, error) {
+	return authorizedLeague(ctx, h.leagueRepo, h.authz, userID, leagueID, policy.ActionRead)
+}
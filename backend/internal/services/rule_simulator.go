@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/analytics"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/policy"
+	"github.com/nfl-analytics/backend/internal/projections"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+var (
+	// ErrNoSeasonHistory is returned when a league has no imported season to
+	// simulate
+	ErrNoSeasonHistory = errors.New("no imported season history; import the league's history first")
+	// ErrNoBoxScores is returned when none of a season's weeks have stored box
+	// scores, which are only kept for seasons the league was synced during
+	ErrNoBoxScores = errors.New("no box scores stored for this season")
+)
+
+// RuleSimulator replays a league's past season under proposed rules
+type RuleSimulator struct {
+	leagueRepo  repositories.LeagueRepository
+	historyRepo repositories.LeagueHistoryRepository
+	syncRepo    repositories.LeagueSyncRepository
+	authz       *policy.Authorizer
+}
+
+// NewRuleSimulator creates a rule simulator over imported history and stored
+// box scores. authz decides who besides a league's owner may read it; nil
+// leaves leagues to their owners.
+func NewRuleSimulator(
+	leagueRepo repositories.LeagueRepository,
+	historyRepo repositories.LeagueHistoryRepository,
+	syncRepo repositories.LeagueSyncRepository,
+	authz *policy.Authorizer,
+) *RuleSimulator {
+	return &RuleSimulator{
+		leagueRepo:  leagueRepo,
+		historyRepo: historyRepo,
+		syncRepo:    syncRepo,
+		authz:       authz,
+	}
+}
+
+// Simulate reports how a rule change would have shifted a season's standings
+// and player values. A zero season uses the most recent imported season.
+func (s *RuleSimulator) Simulate(ctx context.Context, userID, leagueID uuid.UUID, season int, change analytics.RuleChange, playerShifts int) (*analytics.RuleChangeImpact, error) {
+	league, err := authorizedLeague(ctx, s.leagueRepo, s.authz, userID, leagueID, policy.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings models.LeagueSettings
+	if err := json.Unmarshal(league.Settings, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse league settings: %w", err)
+	}
+	scoring, err := projections.ParseScoringRules(settings.ScoringRules)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.season(ctx, league.ID.String(), season)
+	if err != nil {
+		return nil, err
+	}
+
+	data := analytics.SeasonData{
+		Season:    history.Season,
+		Teams:     make(map[int]string),
+		BoxScores: make(map[int][]models.PlayerBoxScore),
+		Scoring:   scoring,
+		Roster:    settings.Roster,
+	}
+
+	var standings []espn.TeamStanding
+	if err := json.Unmarshal(history.Standings, &standings); err != nil {
+		return nil, fmt.Errorf("failed to parse standings: %w", err)
+	}
+	for _, t := range standings {
+		data.Teams[t.TeamID] = t.TeamName
+	}
+	if err := json.Unmarshal(history.Matchups, &data.Matchups); err != nil {
+		return nil, fmt.Errorf("failed to parse matchups: %w", err)
+	}
+
+	found := false
+	loaded := make(map[int]bool)
+	for _, m := range data.Matchups {
+		if m.IsPlayoffs || loaded[m.Week] {
+			continue
+		}
+		loaded[m.Week] = true
+
+		scores, err := s.syncRepo.GetBoxScores(ctx, league.ID.String(), history.Season, m.Week)
+		if err != nil {
+			return nil, err
+		}
+		data.BoxScores[m.Week] = scores
+		found = found || len(scores) > 0
+	}
+	if !found {
+		return nil, ErrNoBoxScores
+	}
+
+	return analytics.SimulateRuleChange(data, change, playerShifts), nil
+}
+
+// season loads the requested imported season, or the latest one
+func (s *RuleSimulator) season(ctx context.Context, leagueID string, season int) (*models.LeagueSeasonHistory, error) {
+	if season != 0 {
+		history, err := s.historyRepo.GetSeason(ctx, leagueID, season)
+		if err != nil {
+			return nil, err
+		}
+		if history == nil {
+			return nil, ErrNoSeasonHistory
+		}
+		return history, nil
+	}
+
+	// Seasons come back most recent first
+	seasons, err := s.historyRepo.GetSeasons(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(seasons) == 0 {
+		return nil, ErrNoSeasonHistory
+	}
+	return &seasons[0], nil
+}
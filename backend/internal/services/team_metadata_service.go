@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/cache"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+)
+
+const (
+	// teamMetadataCacheKey holds the NFL team list in Redis so restarts do
+	// not refetch it
+	teamMetadataCacheKey = "nfl:teams"
+	// teamMetadataCacheTTL outlasts the weekly refresh, so a missed refresh
+	// keeps serving the last list
+	teamMetadataCacheTTL = 8 * 24 * time.Hour
+)
+
+// TeamMetadataService keeps NFL team names, abbreviations and logos in
+// memory so responses can be decorated without calling ESPN
+type TeamMetadataService interface {
+	// Load fills the directory from the cache, fetching from ESPN if the
+	// cache is empty
+	Load(ctx context.Context) (int, error)
+	// Refresh fetches the team list from ESPN and caches it
+	Refresh(ctx context.Context) (int, error)
+	// Teams returns every known team, ordered by abbreviation
+	Teams() []espn.NFLTeam
+	// Team looks up a team by abbreviation
+	Team(abbreviation string) (espn.NFLTeam, bool)
+	// Decorate returns the known teams among abbreviations, keyed by
+	// abbreviation, for attaching to a response
+	Decorate(abbreviations ...string) map[string]espn.NFLTeam
+}
+
+// teamMetadataService implements TeamMetadataService
+type teamMetadataService struct {
+	espnClient espn.Client
+	cache      *cache.Cache
+
+	mu     sync.RWMutex
+	teams  []espn.NFLTeam
+	byAbbr map[string]espn.NFLTeam
+}
+
+// NewTeamMetadataService creates a team directory that loads from
+// espnClient, which should not itself cache, and stores the list in c
+func NewTeamMetadataService(espnClient espn.Client, c *cache.Cache) TeamMetadataService {
+	return &teamMetadataService{
+		espnClient: espnClient,
+		cache:      c,
+		byAbbr:     make(map[string]espn.NFLTeam),
+	}
+}
+
+// Load fills the directory from the cache or ESPN
+func (s *teamMetadataService) Load(ctx context.Context) (int, error) {
+	var teams []espn.NFLTeam
+	if s.cache.GetJSON(ctx, teamMetadataCacheKey, &teams) && len(teams) > 0 {
+		s.set(teams)
+		return len(teams), nil
+	}
+	return s.Refresh(ctx)
+}
+
+// Refresh fetches the team list from ESPN. A failed fetch keeps the
+// current list.
+func (s *teamMetadataService) Refresh(ctx context.Context) (int, error) {
+	teams, err := s.espnClient.GetNFLTeams(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.set(teams)
+
+	if err := s.cache.SetJSON(ctx, teamMetadataCacheKey, teams, teamMetadataCacheTTL); err != nil {
+		return len(teams), err
+	}
+	return len(teams), nil
+}
+
+// Teams returns every known team
+func (s *teamMetadataService) Teams() []espn.NFLTeam {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]espn.NFLTeam(nil), s.teams...)
+}
+
+// Team looks up a team by abbreviation, ignoring case
+func (s *teamMetadataService) Team(abbreviation string) (espn.NFLTeam, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	team, ok := s.byAbbr[strings.ToUpper(abbreviation)]
+	return team, ok
+}
+
+// Decorate returns the known teams among abbreviations
+func (s *teamMetadataService) Decorate(abbreviations ...string) map[string]espn.NFLTeam {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	teams := make(map[string]espn.NFLTeam)
+	for _, abbr := range abbreviations {
+		if team, ok := s.byAbbr[strings.ToUpper(abbr)]; ok {
+			teams[abbr] = team
+		}
+	}
+	return teams
+}
+
+// set replaces the directory's teams
+func (s *teamMetadataService) set(teams []espn.NFLTeam) {
+	byAbbr := make(map[string]espn.NFLTeam, len(teams))
+	for _, t := range teams {
+		byAbbr[strings.ToUpper(t.Abbreviation)] = t
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.teams = teams
+	s.byAbbr = byAbbr
+}
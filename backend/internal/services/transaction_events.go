@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/eventbus"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// transactionNotifyTimeout bounds creating the notifications for one event
+const transactionNotifyTimeout = 10 * time.Second
+
+// TransactionEventHandler turns transaction events from the bus into
+// notifications. Subscribe it to eventbus.TopicTransactions. Notifications
+// are created off the publisher's goroutine so a sync is never held up.
+func TransactionEventHandler(s NotificationService) eventbus.Handler {
+	return func(_ context.Context, msg eventbus.Message) {
+		var change models.TransactionChange
+		if err := json.Unmarshal(msg.Payload, &change); err != nil {
+			log.Printf("Failed to decode %s event: %v", msg.Type, err)
+			return
+		}
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), transactionNotifyTimeout)
+			defer cancel()
+			if _, err := s.NotifyTransactionChange(ctx, change); err != nil {
+				log.Printf("Failed to notify %s for league %s: %v", change.Kind, change.LeagueID, err)
+			}
+		}()
+	}
+}
+
+// EligibilityEventHandler turns eligibility changes from the bus into
+// notifications. Subscribe it to eventbus.TopicRosters.
+func EligibilityEventHandler(s NotificationService) eventbus.Handler {
+	return func(_ context.Context, msg eventbus.Message) {
+		if msg.Type != models.EventEligibilityChanged {
+			return
+		}
+		var change models.EligibilityChange
+		if err := json.Unmarshal(msg.Payload, &change); err != nil {
+			log.Printf("Failed to decode %s event: %v", msg.Type, err)
+			return
+		}
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), transactionNotifyTimeout)
+			defer cancel()
+			if _, err := s.NotifyEligibilityChange(ctx, change); err != nil {
+				log.Printf("Failed to notify eligibility change for %s in league %s: %v", change.PlayerName, change.LeagueID, err)
+			}
+		}()
+	}
+}
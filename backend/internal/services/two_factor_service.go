@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/repositories"
+)
+
+// Two-factor settings
+const (
+	backupCodeCount = 10
+	// twoFactorTokenTTL is how long a user has to enter a code after their
+	// password
+	twoFactorTokenTTL = 5 * time.Minute
+	// Wrong codes allowed before the user is locked out of two-factor
+	// verification for twoFactorLockout
+	maxTwoFactorAttempts = 5
+	twoFactorLockout     = 15 * time.Minute
+)
+
+var (
+	ErrTwoFactorEnabled    = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+	ErrInvalidTwoFactor    = errors.New("invalid two-factor code")
+	ErrTwoFactorLocked     = errors.New("too many wrong two-factor codes, try again later")
+)
+
+// TwoFactorStatus is whether a user has two-factor authentication on
+type TwoFactorStatus struct {
+	Enabled              bool       `json:"enabled"`
+	EnabledAt            *time.Time `json:"enabled_at,omitempty"`
+	BackupCodesRemaining int        `json:"backup_codes_remaining"`
+}
+
+// TwoFactorEnrollment is what a user needs to add us to an authenticator app
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI is the otpauth:// URI to show as a QR code
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TwoFactorService manages TOTP two-factor authentication
+type TwoFactorService interface {
+	Status(ctx context.Context, userID uuid.UUID) (*TwoFactorStatus, error)
+	// Enroll creates a new secret. Two-factor stays off until Confirm.
+	Enroll(ctx context.Context, userID uuid.UUID) (*TwoFactorEnrollment, error)
+	// Confirm turns two-factor on with a code from the app, returning backup
+	// codes that are not shown again
+	Confirm(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	Disable(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateBackupCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	// Verify checks an app or backup code for a user with two-factor on
+	Verify(ctx context.Context, userID uuid.UUID, code string) error
+	Enabled(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// twoFactorService implements TwoFactorService
+type twoFactorService struct {
+	repo     repositories.TwoFactorRepository
+	userRepo repositories.UserRepository
+	box      *auth.SecretBox
+	issuer   string
+}
+
+// NewTwoFactorService creates a new two-factor service. Secrets are sealed
+// with box; issuer names us in authenticator apps.
+func NewTwoFactorService(repo repositories.TwoFactorRepository, userRepo repositories.UserRepository, box *auth.SecretBox, issuer string) TwoFactorService {
+	return &twoFactorService{
+		repo:     repo,
+		userRepo: userRepo,
+		box:      box,
+		issuer:   issuer,
+	}
+}
+
+// Status reports whether two-factor is on and how many backup codes are left
+func (s *twoFactorService) Status(ctx context.Context, userID uuid.UUID) (*TwoFactorStatus, error) {
+	tf, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, repositories.ErrTwoFactorNotFound) || (err == nil && tf.EnabledAt == nil) {
+		return &TwoFactorStatus{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := s.repo.CountBackupCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{Enabled: true, EnabledAt: tf.EnabledAt, BackupCodesRemaining: remaining}, nil
+}
+
+// Enroll generates a secret for the user's authenticator app
+func (s *twoFactorService) Enroll(ctx context.Context, userID uuid.UUID) (*TwoFactorEnrollment, error) {
+	if enabled, err := s.Enabled(ctx, userID); err != nil {
+		return nil, err
+	} else if enabled {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.box.Seal([]byte(secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt two-factor secret: %w", err)
+	}
+	if err := s.repo.SavePending(ctx, userID, sealed); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(secret, s.issuer, user.Email),
+	}, nil
+}
+
+// Confirm enables two-factor once the user proves their app has the secret
+func (s *twoFactorService) Confirm(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	tf, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, repositories.ErrTwoFactorNotFound) {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tf.EnabledAt != nil {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	secret, err := s.box.Open(tf.SecretEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt two-factor secret: %w", err)
+	}
+	step, ok := auth.ValidateTOTP(string(secret), code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactor
+	}
+
+	codes, hashes, err := newBackupCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Enable(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable turns two-factor off after checking a current code
+func (s *twoFactorService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+	return s.repo.Disable(ctx, userID)
+}
+
+// RegenerateBackupCodes replaces the user's backup codes after checking a
+// current code
+func (s *twoFactorService) RegenerateBackupCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newBackupCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceBackupCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Verify accepts a six digit code from the app, each at most once, or an
+// unused backup code. Repeated wrong codes lock verification for a while.
+func (s *twoFactorService) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	tf, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, repositories.ErrTwoFactorNotFound) {
+		return ErrTwoFactorNotEnabled
+	}
+	if err != nil {
+		return err
+	}
+	if tf.EnabledAt == nil {
+		return ErrTwoFactorNotEnabled
+	}
+	if tf.LockedUntil != nil && time.Now().Before(*tf.LockedUntil) {
+		return ErrTwoFactorLocked
+	}
+
+	code = strings.TrimSpace(code)
+	var accepted bool
+	if isTOTPCode(code) {
+		secret, err := s.box.Open(tf.SecretEncrypted)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt two-factor secret: %w", err)
+		}
+		if step, ok := auth.ValidateTOTP(string(secret), code, time.Now()); ok {
+			accepted, err = s.repo.UseStep(ctx, userID, step)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		accepted, err = s.repo.UseBackupCode(ctx, userID, auth.HashBackupCode(code))
+		if err != nil {
+			return err
+		}
+	}
+
+	if !accepted {
+		if err := s.repo.RecordFailure(ctx, userID, maxTwoFactorAttempts, twoFactorLockout); err != nil {
+			return err
+		}
+		return ErrInvalidTwoFactor
+	}
+	return nil
+}
+
+// Enabled reports whether the user must enter a code to log in
+func (s *twoFactorService) Enabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	tf, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, repositories.ErrTwoFactorNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return tf.EnabledAt != nil, nil
+}
+
+// newBackupCodes returns a set of backup codes and their stored hashes
+func newBackupCodes() ([]string, []string, error) {
+	codes, err := auth.GenerateBackupCodes(backupCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = auth.HashBackupCode(code)
+	}
+	return codes, hashes, nil
+}
+
+// isTOTPCode reports whether a code looks like one from an authenticator app
+// rather than a backup code
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// twoFactorChallenge returns the response that asks for a second factor
+// when the user has two-factor on, or nil when tokens can be issued. The
+// challenge token carries whether the login asked to be remembered.
+func twoFactorChallenge(ctx context.Context, twoFactor TwoFactorService, jwtManager *auth.JWTManager, userID uuid.UUID, rememberMe bool) (*models.AuthResponse, error) {
+	if twoFactor == nil {
+		return nil, nil
+	}
+	enabled, err := twoFactor.Enabled(ctx, userID)
+	if err != nil || !enabled {
+		return nil, err
+	}
+
+	scope := auth.ScopeTwoFactor
+	if rememberMe {
+		scope = auth.ScopeTwoFactorRememberMe
+	}
+	token, _, err := jwtManager.GenerateScopedToken(userID, scope, userID.String(), twoFactorTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate two-factor token: %w", err)
+	}
+	return &models.AuthResponse{
+		TwoFactorRequired: true,
+		TwoFactorToken:    token,
+	}, nil
+}
@@ -32,11 +32,15 @@ type userService struct {
 	passwordManager *auth.PasswordManager
 }
 
-// NewUserService creates a new user service
-func NewUserService(userRepo repositories.UserRepository) UserService {
+// NewUserService creates a new user service. passwords hashes changed
+// passwords and may be nil for bcrypt at cost 10.
+func NewUserService(userRepo repositories.UserRepository, passwords *auth.PasswordManager) UserService {
+	if passwords == nil {
+		passwords = auth.NewPasswordManager(10)
+	}
 	return &userService{
 		userRepo:        userRepo,
-		passwordManager: auth.NewPasswordManager(10),
+		passwordManager: passwords,
 	}
 }
 
@@ -117,6 +121,9 @@ func (s *userService) ChangePassword(ctx context.Context, userID uuid.UUID, req
 	
 	// Validate new password strength
 	if err := s.passwordManager.ValidatePasswordStrength(req.NewPassword); err != nil {
+		if errors.Is(err, auth.ErrPasswordBreached) {
+			return err
+		}
 		return ErrWeakPassword
 	}
 	
@@ -75,7 +75,7 @@ func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 func TestUserService_GetProfile(t *testing.T) {
 	ctx := context.Background()
 	repo := NewMockUserRepository()
-	service := NewUserService(repo)
+	service := NewUserService(repo, nil)
 
 	// Create test user
 	userID := uuid.New()
@@ -127,7 +127,7 @@ func TestUserService_GetProfile(t *testing.T) {
 func TestUserService_UpdateProfile(t *testing.T) {
 	ctx := context.Background()
 	repo := NewMockUserRepository()
-	service := NewUserService(repo)
+	service := NewUserService(repo, nil)
 
 	// Create test user
 	userID := uuid.New()
@@ -198,7 +198,7 @@ func TestUserService_UpdateProfile(t *testing.T) {
 func TestUserService_DeleteAccount(t *testing.T) {
 	ctx := context.Background()
 	repo := NewMockUserRepository()
-	service := NewUserService(repo)
+	service := NewUserService(repo, nil)
 
 	// Create test users
 	activeUserID := uuid.New()
@@ -253,7 +253,7 @@ func TestUserService_DeleteAccount(t *testing.T) {
 func TestUserService_ChangePassword(t *testing.T) {
 	ctx := context.Background()
 	repo := NewMockUserRepository()
-	service := NewUserService(repo)
+	service := NewUserService(repo, nil)
 
 	// Create test user with a real hashed password for testing
 	userID := uuid.New()
@@ -325,7 +325,7 @@ func TestUserService_ChangePassword(t *testing.T) {
 func TestUserService_ErrorHandling(t *testing.T) {
 	ctx := context.Background()
 	repo := NewMockUserRepository()
-	service := NewUserService(repo)
+	service := NewUserService(repo, nil)
 
 	// Test database error handling
 	repo.returnError = errors.New("database error")
@@ -0,0 +1,171 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/eventbus"
+)
+
+// idleTTL is how long a channel with no events and no waiting clients is
+// kept before its buffer is dropped
+const idleTTL = time.Hour
+
+// pruneInterval is how often idle channels are looked for
+const pruneInterval = time.Minute
+
+// Event is one message on a stream. IDs increase by one per channel, so a
+// client resumes a stream by passing the last ID it saw.
+type Event struct {
+	ID          uint64          `json:"id"`
+	Topic       string          `json:"topic"`
+	Type        string          `json:"type"`
+	Data        json.RawMessage `json:"data"`
+	PublishedAt time.Time       `json:"published_at"`
+}
+
+type channel struct {
+	events []Event
+	lastID uint64
+	// notify is closed and replaced whenever an event arrives
+	notify     chan struct{}
+	waiters    int
+	lastActive time.Time
+}
+
+// Hub buffers recent bus events per stream channel so SSE and long-poll
+// clients read from the same source and can resume after a reconnect.
+// Channels are named by topic and bus message key, e.g. a draft session's
+// events are on Channel(eventbus.TopicDraft, sessionID).
+type Hub struct {
+	bufferSize int
+
+	mu        sync.Mutex
+	channels  map[string]*channel
+	lastPrune time.Time
+}
+
+// NewHub creates a hub keeping the last bufferSize events of each channel
+// and subscribes it to the given bus topics
+func NewHub(bus *eventbus.Bus, bufferSize int, topics ...string) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = 100
+	}
+	h := &Hub{
+		bufferSize: bufferSize,
+		channels:   make(map[string]*channel),
+		lastPrune:  time.Now(),
+	}
+	for _, topic := range topics {
+		bus.Subscribe(topic, h.handle)
+	}
+	return h
+}
+
+// Channel names the stream for a topic and key
+func Channel(topic, key string) string {
+	return topic + ":" + key
+}
+
+// handle appends a bus message to its channel and wakes waiting clients
+func (h *Hub) handle(_ context.Context, msg eventbus.Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	ch := h.channelLocked(Channel(msg.Topic, msg.Key))
+	ch.lastID++
+	ch.events = append(ch.events, Event{
+		ID:          ch.lastID,
+		Topic:       msg.Topic,
+		Type:        msg.Type,
+		Data:        msg.Payload,
+		PublishedAt: msg.PublishedAt,
+	})
+	if len(ch.events) > h.bufferSize {
+		ch.events = append([]Event(nil), ch.events[len(ch.events)-h.bufferSize:]...)
+	}
+	ch.lastActive = now
+	close(ch.notify)
+	ch.notify = make(chan struct{})
+
+	if now.Sub(h.lastPrune) > pruneInterval {
+		h.pruneLocked(now)
+	}
+}
+
+// Cursor returns the ID of the newest event on a channel, 0 if there is none
+func (h *Hub) Cursor(name string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if ch, ok := h.channels[name]; ok {
+		return ch.lastID
+	}
+	return 0
+}
+
+// Wait returns the channel's events after the cursor, blocking until there
+// is at least one or the context ends. resync is true when the cursor is
+// older than the buffer or unknown to this hub, for instance after a
+// restart; the client has missed events and should reload full state
+// before applying the ones returned.
+func (h *Hub) Wait(ctx context.Context, name string, after uint64) (events []Event, resync bool, err error) {
+	h.mu.Lock()
+	ch := h.channelLocked(name)
+	ch.waiters++
+	defer func() {
+		h.mu.Lock()
+		ch.waiters--
+		ch.lastActive = time.Now()
+		h.mu.Unlock()
+	}()
+
+	for {
+		if after > ch.lastID {
+			resync, after = true, 0
+		}
+		if len(ch.events) > 0 && after+1 < ch.events[0].ID {
+			resync = true
+		}
+		for _, e := range ch.events {
+			if e.ID > after {
+				events = append(events, e)
+			}
+		}
+		if len(events) > 0 || resync {
+			h.mu.Unlock()
+			return events, resync, nil
+		}
+
+		notify := ch.notify
+		h.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-notify:
+		}
+		h.mu.Lock()
+	}
+}
+
+// channelLocked returns a channel, creating it if needed. The caller holds mu.
+func (h *Hub) channelLocked(name string) *channel {
+	ch, ok := h.channels[name]
+	if !ok {
+		ch = &channel{notify: make(chan struct{}), lastActive: time.Now()}
+		h.channels[name] = ch
+	}
+	return ch
+}
+
+// pruneLocked drops channels idle for longer than idleTTL. The caller holds mu.
+func (h *Hub) pruneLocked(now time.Time) {
+	for name, ch := range h.channels {
+		if ch.waiters == 0 && now.Sub(ch.lastActive) > idleTTL {
+			delete(h.channels, name)
+		}
+	}
+	h.lastPrune = now
+}
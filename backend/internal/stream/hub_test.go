@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub(t *testing.T) {
+	bus := eventbus.New(nil, nil, 0)
+	hub := NewHub(bus, 3, eventbus.TopicDraft)
+	ctx := context.Background()
+	name := Channel(eventbus.TopicDraft, "session-1")
+
+	// A waiting client is woken by the next event
+	done := make(chan []Event)
+	go func() {
+		events, resync, err := hub.Wait(ctx, name, 0)
+		assert.NoError(t, err)
+		assert.False(t, resync)
+		done <- events
+	}()
+	require.Eventually(t, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return hub.channels[name] != nil && hub.channels[name].waiters == 1
+	}, time.Second, time.Millisecond)
+	require.NoError(t, bus.Publish(ctx, eventbus.TopicDraft, "session-1", "pick.recorded", map[string]int{"pick": 1}))
+
+	select {
+	case events := <-done:
+		require.Len(t, events, 1)
+		assert.Equal(t, uint64(1), events[0].ID)
+		assert.Equal(t, "pick.recorded", events[0].Type)
+		assert.JSONEq(t, `{"pick": 1}`, string(events[0].Data))
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not woken")
+	}
+
+	// Other sessions and topics do not reach the channel
+	require.NoError(t, bus.Publish(ctx, eventbus.TopicDraft, "session-2", "pick.recorded", nil))
+	require.NoError(t, bus.Publish(ctx, eventbus.TopicLiveScoring, "session-1", "matchups.updated", nil))
+	assert.Equal(t, uint64(1), hub.Cursor(name))
+
+	// Nothing new: the wait ends with the context
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, _, err := hub.Wait(waitCtx, name, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A cursor older than the buffer asks the client to resync
+	for i := 0; i < 4; i++ {
+		require.NoError(t, bus.Publish(ctx, eventbus.TopicDraft, "session-1", "pick.recorded", nil))
+	}
+	events, resync, err := hub.Wait(ctx, name, 1)
+	require.NoError(t, err)
+	assert.True(t, resync)
+	require.Len(t, events, 3)
+	assert.Equal(t, uint64(3), events[0].ID)
+
+	// So does a cursor from before a restart
+	_, resync, err = hub.Wait(ctx, name, 99)
+	require.NoError(t, err)
+	assert.True(t, resync)
+}
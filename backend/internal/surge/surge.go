@@ -0,0 +1,215 @@
+package surge
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// overrideKey is the Redis key holding a manual surge override shared by
+// every API instance
+const overrideKey = "surge:override"
+
+// Override values. Auto turns surge mode on while the number of drafts in
+// progress is at or above the automatic threshold.
+const (
+	OverrideAuto = "auto"
+	OverrideOn   = "on"
+	OverrideOff  = "off"
+)
+
+// ActiveDraftCounter counts the draft sessions currently in progress
+type ActiveDraftCounter func(ctx context.Context) (int, error)
+
+// Options tunes what surge mode changes while it is on
+type Options struct {
+	// AutoThreshold is the active draft count that turns surge mode on
+	// automatically; 0 leaves it to the manual override
+	AutoThreshold int
+	// RefreshInterval is how often the override and draft count are reread
+	RefreshInterval time.Duration
+	// CacheTTLMultiplier stretches response cache TTLs
+	CacheTTLMultiplier float64
+	// JobIntervalMultiplier stretches background job intervals
+	JobIntervalMultiplier int
+	// DraftRateMultiplier raises the draft route rate limit
+	DraftRateMultiplier float64
+	// MetricsSampleRate is the share of requests whose metrics are logged,
+	// normally and during a surge
+	MetricsSampleRate      float64
+	SurgeMetricsSampleRate float64
+}
+
+// Status is the current surge state
+type Status struct {
+	Active       bool      `json:"active"`
+	Override     string    `json:"override"`
+	ActiveDrafts int       `json:"active_drafts"`
+	Threshold    int       `json:"threshold"`
+	CheckedAt    time.Time `json:"checked_at"`
+}
+
+// Mode is the draft-day surge switch. It turns on manually through an
+// override or automatically once enough drafts are in progress, and tells
+// caches, workers, rate limits and metrics how to adjust while it is on.
+type Mode struct {
+	redis   *redis.Client
+	counter ActiveDraftCounter
+	opts    Options
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// NewMode creates a surge switch. counter may be nil to disable automatic
+// activation.
+func NewMode(redisClient *redis.Client, counter ActiveDraftCounter, opts Options) *Mode {
+	return &Mode{
+		redis:   redisClient,
+		counter: counter,
+		opts:    opts,
+		status:  Status{Override: OverrideAuto, Threshold: opts.AutoThreshold},
+	}
+}
+
+// Status returns the current surge state, refreshing it when stale. Errors
+// keep the last known state.
+func (m *Mode) Status(ctx context.Context) Status {
+	if m == nil {
+		return Status{Override: OverrideAuto}
+	}
+
+	m.mu.RLock()
+	status := m.status
+	m.mu.RUnlock()
+
+	if time.Since(status.CheckedAt) < m.opts.RefreshInterval {
+		return status
+	}
+
+	if m.redis != nil {
+		override, err := m.redis.Get(ctx, overrideKey).Result()
+		switch {
+		case err == redis.Nil:
+			status.Override = OverrideAuto
+		case err != nil:
+			log.Printf("Failed to read surge override: %v", err)
+		default:
+			status.Override = override
+		}
+	}
+
+	if m.counter != nil && m.opts.AutoThreshold > 0 {
+		count, err := m.counter(ctx)
+		if err != nil {
+			log.Printf("Failed to count active drafts for surge mode: %v", err)
+		} else {
+			status.ActiveDrafts = count
+		}
+	}
+
+	wasActive := status.Active
+	status.Active = m.evaluate(status)
+	status.CheckedAt = time.Now()
+	if status.Active != wasActive {
+		log.Printf("Surge mode %s (override %s, %d active drafts)", onOff(status.Active), status.Override, status.ActiveDrafts)
+	}
+
+	m.mu.Lock()
+	m.status = status
+	m.mu.Unlock()
+
+	return status
+}
+
+// SetOverride forces surge mode on or off, or returns it to automatic
+func (m *Mode) SetOverride(ctx context.Context, override string) (Status, error) {
+	switch override {
+	case OverrideAuto, OverrideOn, OverrideOff:
+	default:
+		return Status{}, fmt.Errorf("invalid surge override %q", override)
+	}
+
+	if m.redis != nil {
+		var err error
+		if override == OverrideAuto {
+			err = m.redis.Del(ctx, overrideKey).Err()
+		} else {
+			err = m.redis.Set(ctx, overrideKey, override, 0).Err()
+		}
+		if err != nil {
+			return Status{}, fmt.Errorf("failed to save surge override: %w", err)
+		}
+	}
+
+	m.mu.Lock()
+	m.status.Override = override
+	m.status.Active = m.evaluate(m.status)
+	status := m.status
+	m.mu.Unlock()
+
+	log.Printf("Surge mode override set to %s", override)
+	return status, nil
+}
+
+// Active reports whether surge mode is on
+func (m *Mode) Active(ctx context.Context) bool {
+	return m.Status(ctx).Active
+}
+
+// CacheTTLMultiplier stretches response cache TTLs during a surge
+func (m *Mode) CacheTTLMultiplier(ctx context.Context) float64 {
+	if m.Active(ctx) && m.opts.CacheTTLMultiplier > 1 {
+		return m.opts.CacheTTLMultiplier
+	}
+	return 1
+}
+
+// IntervalMultiplier stretches background job intervals during a surge
+func (m *Mode) IntervalMultiplier(ctx context.Context) int {
+	if m.Active(ctx) && m.opts.JobIntervalMultiplier > 1 {
+		return m.opts.JobIntervalMultiplier
+	}
+	return 1
+}
+
+// RateLimitMultiplier raises draft route rate limits during a surge
+func (m *Mode) RateLimitMultiplier(ctx context.Context) float64 {
+	if m.Active(ctx) && m.opts.DraftRateMultiplier > 1 {
+		return m.opts.DraftRateMultiplier
+	}
+	return 1
+}
+
+// MetricsSampleRate is the share of requests whose metrics are recorded
+func (m *Mode) MetricsSampleRate(ctx context.Context) float64 {
+	if m == nil {
+		return 0
+	}
+	if m.Active(ctx) {
+		return m.opts.SurgeMetricsSampleRate
+	}
+	return m.opts.MetricsSampleRate
+}
+
+// evaluate applies the override, falling back to the draft count threshold
+func (m *Mode) evaluate(status Status) bool {
+	switch status.Override {
+	case OverrideOn:
+		return true
+	case OverrideOff:
+		return false
+	}
+	return m.opts.AutoThreshold > 0 && status.ActiveDrafts >= m.opts.AutoThreshold
+}
+
+func onOff(active bool) string {
+	if active {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,65 @@
+package surge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOptions() Options {
+	return Options{
+		AutoThreshold:          10,
+		CacheTTLMultiplier:     3,
+		JobIntervalMultiplier:  4,
+		DraftRateMultiplier:    2,
+		MetricsSampleRate:      0.01,
+		SurgeMetricsSampleRate: 0.5,
+	}
+}
+
+func TestModeAutomatic(t *testing.T) {
+	ctx := context.Background()
+	drafts := 3
+	m := NewMode(nil, func(ctx context.Context) (int, error) { return drafts, nil }, testOptions())
+
+	status := m.Status(ctx)
+	assert.False(t, status.Active)
+	assert.Equal(t, 3, status.ActiveDrafts)
+	assert.Equal(t, 1.0, m.CacheTTLMultiplier(ctx))
+	assert.Equal(t, 1, m.IntervalMultiplier(ctx))
+	assert.Equal(t, 0.01, m.MetricsSampleRate(ctx))
+
+	drafts = 12
+	assert.True(t, m.Active(ctx))
+	assert.Equal(t, 3.0, m.CacheTTLMultiplier(ctx))
+	assert.Equal(t, 4, m.IntervalMultiplier(ctx))
+	assert.Equal(t, 2.0, m.RateLimitMultiplier(ctx))
+	assert.Equal(t, 0.5, m.MetricsSampleRate(ctx))
+}
+
+func TestModeOverride(t *testing.T) {
+	ctx := context.Background()
+	m := NewMode(nil, func(ctx context.Context) (int, error) { return 0, nil }, testOptions())
+
+	status, err := m.SetOverride(ctx, OverrideOn)
+	require.NoError(t, err)
+	assert.True(t, status.Active)
+	assert.True(t, m.Active(ctx))
+
+	_, err = m.SetOverride(ctx, OverrideAuto)
+	require.NoError(t, err)
+	assert.False(t, m.Active(ctx))
+
+	_, err = m.SetOverride(ctx, "sometimes")
+	assert.Error(t, err)
+}
+
+func TestNilMode(t *testing.T) {
+	var m *Mode
+	ctx := context.Background()
+	assert.False(t, m.Active(ctx))
+	assert.Equal(t, 1.0, m.CacheTTLMultiplier(ctx))
+	assert.Equal(t, 0.0, m.MetricsSampleRate(ctx))
+}
@@ -0,0 +1,309 @@
+package voice
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Alexa request headers carrying the request signature
+const (
+	AlexaCertChainHeader = "SignatureCertChainUrl"
+	AlexaSignatureHeader = "Signature-256"
+)
+
+const (
+	// alexaTimestampTolerance is how old or early a request may be, as
+	// Alexa requires of skills
+	alexaTimestampTolerance = 150 * time.Second
+	// alexaCertHost is the name Alexa's signing certificate must be issued to
+	alexaCertHost = "echo-api.amazon.com"
+	// maxAlexaCertChain bounds the size of a downloaded certificate chain
+	maxAlexaCertChain = 64 << 10
+)
+
+var (
+	// ErrAlexaSkillMismatch is returned for requests sent to another skill
+	ErrAlexaSkillMismatch = errors.New("request is for another skill")
+	// ErrAlexaTimestamp is returned for requests too far from now to trust
+	ErrAlexaTimestamp = errors.New("request timestamp is out of range")
+	// ErrAlexaSignature is returned for requests Alexa did not sign
+	ErrAlexaSignature = errors.New("invalid request signature")
+)
+
+// AlexaRequest is the part of an Alexa skill request the webhook reads
+type AlexaRequest struct {
+	Version string `json:"version"`
+	Session struct {
+		Application alexaApplication `json:"application"`
+		User        alexaUser        `json:"user"`
+	} `json:"session"`
+	Context struct {
+		System struct {
+			Application alexaApplication `json:"application"`
+			User        alexaUser        `json:"user"`
+		} `json:"System"`
+	} `json:"context"`
+	Request struct {
+		Type      string    `json:"type"`
+		RequestID string    `json:"requestId"`
+		Timestamp time.Time `json:"timestamp"`
+		Intent    struct {
+			Name  string               `json:"name"`
+			Slots map[string]alexaSlot `json:"slots"`
+		} `json:"intent"`
+	} `json:"request"`
+}
+
+type alexaApplication struct {
+	ApplicationID string `json:"applicationId"`
+}
+
+type alexaUser struct {
+	AccessToken string `json:"accessToken"`
+}
+
+type alexaSlot struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ApplicationID is the skill the request was sent to
+func (r *AlexaRequest) ApplicationID() string {
+	if id := r.Context.System.Application.ApplicationID; id != "" {
+		return id
+	}
+	return r.Session.Application.ApplicationID
+}
+
+// AccessToken is the device token from account linking, empty when the
+// user has not linked their account
+func (r *AlexaRequest) AccessToken() string {
+	if token := r.Context.System.User.AccessToken; token != "" {
+		return token
+	}
+	return r.Session.User.AccessToken
+}
+
+// Utterance is what the user said. The skill's interaction model sends
+// commands as the command slot of DraftCommandIntent, and best available
+// questions as BestAvailableIntent with an optional position slot.
+func (r *AlexaRequest) Utterance() Utterance {
+	switch r.Request.Type {
+	case "LaunchRequest":
+		return Utterance{Launch: true}
+	case "SessionEndedRequest":
+		return Utterance{End: true}
+	}
+
+	intent := r.Request.Intent
+	switch intent.Name {
+	case "AMAZON.HelpIntent":
+		return Utterance{Launch: true}
+	case "AMAZON.StopIntent", "AMAZON.CancelIntent", "AMAZON.NoIntent":
+		return Utterance{End: true}
+	case "BestAvailableIntent":
+		return Utterance{Text: strings.TrimSpace("best available " + intent.Slots["position"].Value)}
+	}
+	return Utterance{Text: intent.Slots["command"].Value}
+}
+
+// AlexaResponse is a skill response that speaks plain text
+type AlexaResponse struct {
+	Version  string `json:"version"`
+	Response struct {
+		OutputSpeech struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"outputSpeech"`
+		Card             *alexaCard `json:"card,omitempty"`
+		ShouldEndSession bool       `json:"shouldEndSession"`
+	} `json:"response"`
+}
+
+type alexaCard struct {
+	Type string `json:"type"`
+}
+
+// NewAlexaResponse speaks reply. With linkAccount, the Alexa app also shows
+// the card that starts account linking.
+func NewAlexaResponse(reply Reply, linkAccount bool) *AlexaResponse {
+	resp := &AlexaResponse{Version: "1.0"}
+	resp.Response.OutputSpeech.Type = "PlainText"
+	resp.Response.OutputSpeech.Text = reply.Speech
+	resp.Response.ShouldEndSession = reply.EndSession
+	if linkAccount {
+		resp.Response.Card = &alexaCard{Type: "LinkAccount"}
+	}
+	return resp
+}
+
+// AlexaVerifier checks that requests come from Alexa for our skill, as Alexa
+// requires of skills hosted outside AWS Lambda
+type AlexaVerifier struct {
+	// skillID, when set, is the only skill requests are accepted for
+	skillID string
+	// verifySignatures checks each request's signature against Alexa's
+	// certificate. Only turn it off to try the skill locally.
+	verifySignatures bool
+	client           *http.Client
+	now              func() time.Time
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewAlexaVerifier creates a verifier for skillID
+func NewAlexaVerifier(skillID string, verifySignatures bool) *AlexaVerifier {
+	return &AlexaVerifier{
+		skillID:          skillID,
+		verifySignatures: verifySignatures,
+		client:           &http.Client{Timeout: 5 * time.Second},
+		now:              time.Now,
+		certs:            make(map[string]*x509.Certificate),
+	}
+}
+
+// Verify checks a request's skill, timestamp and signature. body is the raw
+// request body the signature covers.
+func (v *AlexaVerifier) Verify(r *http.Request, body []byte, req *AlexaRequest) error {
+	if v.skillID != "" && req.ApplicationID() != v.skillID {
+		return ErrAlexaSkillMismatch
+	}
+
+	age := v.now().Sub(req.Request.Timestamp)
+	if age > alexaTimestampTolerance || age < -alexaTimestampTolerance {
+		return ErrAlexaTimestamp
+	}
+
+	if !v.verifySignatures {
+		return nil
+	}
+	cert, err := v.certificate(r.Context(), r.Header.Get(AlexaCertChainHeader))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAlexaSignature, err)
+	}
+	return verifySignature(cert, body, r.Header.Get(AlexaSignatureHeader))
+}
+
+// certificate returns the verified signing certificate at certURL, fetching
+// it the first time it is seen
+func (v *AlexaVerifier) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if err := checkCertURL(certURL); err != nil {
+		return nil, err
+	}
+
+	now := v.now()
+	v.mu.Lock()
+	cert, ok := v.certs[certURL]
+	v.mu.Unlock()
+	if ok && now.Before(cert.NotAfter) {
+		return cert, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signing certificate: %w", err)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signing certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch signing certificate: status %d", resp.StatusCode)
+	}
+	chain, err := io.ReadAll(io.LimitReader(resp.Body, maxAlexaCertChain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing certificate: %w", err)
+	}
+
+	cert, err = parseCertChain(chain, now, nil)
+	if err != nil {
+		return nil, err
+	}
+	v.mu.Lock()
+	v.certs[certURL] = cert
+	v.mu.Unlock()
+	return cert, nil
+}
+
+// checkCertURL checks the certificate chain is one of Alexa's:
+// https://s3.amazonaws.com[:443]/echo.api/...
+func checkCertURL(certURL string) error {
+	u, err := url.Parse(certURL)
+	if err != nil || certURL == "" {
+		return errors.New("invalid certificate url")
+	}
+	if !strings.EqualFold(u.Scheme, "https") || !strings.EqualFold(u.Hostname(), "s3.amazonaws.com") {
+		return errors.New("certificate url is not alexa's")
+	}
+	if port := u.Port(); port != "" && port != "443" {
+		return errors.New("certificate url is not alexa's")
+	}
+	if !strings.HasPrefix(path.Clean(u.Path), "/echo.api/") {
+		return errors.New("certificate url is not alexa's")
+	}
+	return nil
+}
+
+// parseCertChain parses a PEM chain, leaf first, and verifies the leaf was
+// issued to Alexa by a trusted root. roots nil is the system roots.
+func parseCertChain(chain []byte, now time.Time, roots *x509.CertPool) (*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, chain = pem.Decode(chain)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signing certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no signing certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	leaf := certs[0]
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       alexaCertHost,
+		Intermediates: intermediates,
+		Roots:         roots,
+		CurrentTime:   now,
+	}); err != nil {
+		return nil, fmt.Errorf("untrusted signing certificate: %w", err)
+	}
+	return leaf, nil
+}
+
+// verifySignature checks signature, base64 RSA SHA-256, is cert's over body
+func verifySignature(cert *x509.Certificate, body []byte, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(sig) == 0 {
+		return ErrAlexaSignature
+	}
+	if err := cert.CheckSignature(x509.SHA256WithRSA, body, sig); err != nil {
+		return ErrAlexaSignature
+	}
+	return nil
+}
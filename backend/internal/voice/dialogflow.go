@@ -0,0 +1,104 @@
+package voice
+
+import "strings"
+
+// DialogflowRequest is the part of a Dialogflow ES fulfillment request from
+// a Google Assistant action that the webhook reads
+type DialogflowRequest struct {
+	ResponseID  string `json:"responseId"`
+	Session     string `json:"session"`
+	QueryResult struct {
+		QueryText string `json:"queryText"`
+		Intent    struct {
+			DisplayName string `json:"displayName"`
+		} `json:"intent"`
+	} `json:"queryResult"`
+	OriginalDetectIntentRequest struct {
+		Source  string `json:"source"`
+		Payload struct {
+			User struct {
+				AccessToken string `json:"accessToken"`
+			} `json:"user"`
+		} `json:"payload"`
+	} `json:"originalDetectIntentRequest"`
+}
+
+// Dialogflow intents the webhook treats specially
+const (
+	DialogflowWelcomeIntent = "Default Welcome Intent"
+	DialogflowCancelIntent  = "Cancel"
+)
+
+// AccessToken is the device token from account linking, empty when the
+// user has not linked their account
+func (r *DialogflowRequest) AccessToken() string {
+	return r.OriginalDetectIntentRequest.Payload.User.AccessToken
+}
+
+// Utterance is what the user said. Every intent other than welcome and
+// cancel is sent on as the text of the query, so the agent needs no more
+// than a fallback intent with fulfillment turned on.
+func (r *DialogflowRequest) Utterance() Utterance {
+	switch r.QueryResult.Intent.DisplayName {
+	case DialogflowWelcomeIntent:
+		return Utterance{Launch: true}
+	case DialogflowCancelIntent:
+		return Utterance{End: true}
+	}
+	return Utterance{Text: strings.TrimSpace(r.QueryResult.QueryText)}
+}
+
+// DialogflowResponse is a fulfillment response that speaks plain text on
+// Google Assistant
+type DialogflowResponse struct {
+	FulfillmentText string `json:"fulfillmentText"`
+	Payload         struct {
+		Google googlePayload `json:"google"`
+	} `json:"payload"`
+}
+
+type googlePayload struct {
+	ExpectUserResponse bool                `json:"expectUserResponse"`
+	RichResponse       *googleRichResponse `json:"richResponse,omitempty"`
+	SystemIntent       *googleSystemIntent `json:"systemIntent,omitempty"`
+}
+
+type googleRichResponse struct {
+	Items []googleItem `json:"items"`
+}
+
+type googleItem struct {
+	SimpleResponse struct {
+		TextToSpeech string `json:"textToSpeech"`
+	} `json:"simpleResponse"`
+}
+
+type googleSystemIntent struct {
+	Intent string            `json:"intent"`
+	Data   map[string]string `json:"data"`
+}
+
+// NewDialogflowResponse speaks reply. With linkAccount, Google Assistant
+// asks the user to sign in, which starts account linking.
+func NewDialogflowResponse(reply Reply, linkAccount bool) *DialogflowResponse {
+	resp := &DialogflowResponse{FulfillmentText: reply.Speech}
+	google := &resp.Payload.Google
+	google.ExpectUserResponse = !reply.EndSession
+
+	if linkAccount {
+		google.ExpectUserResponse = true
+		google.SystemIntent = &googleSystemIntent{
+			Intent: "actions.intent.SIGN_IN",
+			Data: map[string]string{
+				"@type":      "type.googleapis.com/google.actions.v2.SignInValueSpec",
+				"optContext": reply.Speech,
+			},
+		}
+		return resp
+	}
+
+	var item googleItem
+	item.SimpleResponse.TextToSpeech = reply.Speech
+	google.RichResponse = &googleRichResponse{Items: []googleItem{item}}
+	return resp
+}
@@ -0,0 +1,104 @@
+package voice
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// PostgresRepository stores linked devices in the voice_devices table
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a new voice device repository
+func NewPostgresRepository(db *sql.DB) Repository {
+	return &PostgresRepository{db: db}
+}
+
+// Create links a device
+func (r *PostgresRepository) Create(ctx context.Context, device *Device) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO voice_devices (id, user_id, platform, name, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at`,
+		device.ID, device.UserID, device.Platform, device.Name, device.ExpiresAt,
+	).Scan(&device.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create voice device: %w", err)
+	}
+	return nil
+}
+
+// Get returns an active device of the user's
+func (r *PostgresRepository) Get(ctx context.Context, userID, deviceID uuid.UUID) (*Device, error) {
+	var device Device
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, user_id, platform, name, expires_at, created_at, last_used_at
+		FROM voice_devices
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW()`,
+		deviceID, userID,
+	).Scan(&device.ID, &device.UserID, &device.Platform, &device.Name, &device.ExpiresAt, &device.CreatedAt, &device.LastUsedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeviceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voice device: %w", err)
+	}
+	return &device, nil
+}
+
+// List returns the user's active devices, most recently linked first
+func (r *PostgresRepository) List(ctx context.Context, userID uuid.UUID) ([]Device, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, platform, name, expires_at, created_at, last_used_at
+		FROM voice_devices
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list voice devices: %w", err)
+	}
+	defer rows.Close()
+
+	devices := []Device{}
+	for rows.Next() {
+		var device Device
+		if err := rows.Scan(&device.ID, &device.UserID, &device.Platform, &device.Name, &device.ExpiresAt, &device.CreatedAt, &device.LastUsedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan voice device: %w", err)
+		}
+		devices = append(devices, device)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list voice devices: %w", err)
+	}
+	return devices, nil
+}
+
+// Touch records that the device was just used
+func (r *PostgresRepository) Touch(ctx context.Context, deviceID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE voice_devices SET last_used_at = NOW() WHERE id = $1`, deviceID); err != nil {
+		return fmt.Errorf("failed to update voice device: %w", err)
+	}
+	return nil
+}
+
+// Revoke unlinks one of the user's devices
+func (r *PostgresRepository) Revoke(ctx context.Context, userID, deviceID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE voice_devices SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`,
+		deviceID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke voice device: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// Package voice connects voice assistants to the draft room. Alexa skills and
+// Google Assistant (Dialogflow) agents send what the user said to a webhook,
+// which runs it as a draft command and answers with speech. Users link an
+// assistant to their account once; the assistant then sends a device token
+// with every request.
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/draft"
+)
+
+// Platforms a device can be linked from
+const (
+	PlatformAlexa  = "alexa"
+	PlatformGoogle = "google"
+)
+
+var (
+	// ErrDeviceNotFound is returned for a device that does not exist, is not
+	// the user's or has been unlinked
+	ErrDeviceNotFound = errors.New("voice device not found")
+	// ErrNoActiveDraft is returned when the user has no draft in progress to
+	// send commands to
+	ErrNoActiveDraft = errors.New("no active draft")
+)
+
+// Device is an assistant linked to a user's account
+type Device struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Platform   string     `json:"platform"`
+	Name       string     `json:"name"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// Repository stores linked devices
+type Repository interface {
+	Create(ctx context.Context, device *Device) error
+	// Get returns an active device of the user's, or ErrDeviceNotFound
+	Get(ctx context.Context, userID, deviceID uuid.UUID) (*Device, error)
+	List(ctx context.Context, userID uuid.UUID) ([]Device, error)
+	// Touch records that the device was just used
+	Touch(ctx context.Context, deviceID uuid.UUID) error
+	// Revoke unlinks a device; its token stops working
+	Revoke(ctx context.Context, userID, deviceID uuid.UUID) error
+}
+
+// Utterance is what the user said, as text for the draft command parser
+type Utterance struct {
+	Text string
+	// Launch is true when the user only opened the skill or action
+	Launch bool
+	// End is true when the assistant is closing the conversation
+	End bool
+}
+
+// Reply is what the assistant says back
+type Reply struct {
+	Speech string
+	// EndSession closes the conversation instead of listening for more
+	EndSession bool
+}
+
+// Canned replies
+const (
+	WelcomeSpeech  = "Your draft is ready. Say take and a player's name, or ask who's the best available running back."
+	GoodbyeSpeech  = "Good luck with your draft."
+	NotLinked      = "Link your account in the app first, then try again."
+	NoActiveDraft  = "You don't have a draft in progress. Start one in the app, then try again."
+	notUnderstood  = "Sorry, I didn't catch that. Say take and a player's name, or ask who's the best available running back."
+	somethingWrong = "Sorry, something went wrong with your draft. Please try again."
+)
+
+// ActiveSession returns the user's most recently created draft that is in
+// progress, or ErrNoActiveDraft
+func ActiveSession(ctx context.Context, drafts *draft.Service, userID uuid.UUID) (string, error) {
+	sessions, err := drafts.GetUserSessions(ctx, userID.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to get draft sessions: %w", err)
+	}
+	// Sessions come newest first
+	for _, session := range sessions {
+		if session.Status == "active" || session.Status == "paused" {
+			return session.ID, nil
+		}
+	}
+	return "", ErrNoActiveDraft
+}
+
+// CommandReply says what a draft command did
+func CommandReply(result *draft.CommandResult) Reply {
+	if result.Status == draft.CommandConfirm {
+		return Reply{Speech: confirmSpeech(result.Command.Action, result.Candidates)}
+	}
+
+	cmd := result.Command
+	var speech string
+	switch cmd.Action {
+	case draft.CommandPick, draft.CommandRedo:
+		if result.Pick != nil {
+			speech = fmt.Sprintf("Drafted %s, %s, with pick %d.", result.Pick.PlayerName, result.Pick.Position, result.Pick.PickNumber)
+		} else {
+			speech = "Done."
+		}
+	case draft.CommandQueue, draft.CommandQueueTop, draft.CommandUnqueue, draft.CommandClearQueue:
+		speech = queueSpeech(len(result.Queue))
+	case draft.CommandUndo:
+		speech = "Undid the last pick."
+	case draft.CommandPause:
+		speech = "Draft paused."
+	case draft.CommandResume:
+		speech = "Draft resumed."
+	case draft.CommandBestAvailable:
+		speech = bestAvailableSpeech(cmd.Position, result)
+	default:
+		speech = "Done."
+	}
+	return Reply{Speech: speech}
+}
+
+// ErrorReply says why a command could not be carried out
+func ErrorReply(err error) Reply {
+	switch {
+	case errors.Is(err, ErrNoActiveDraft):
+		return Reply{Speech: NoActiveDraft, EndSession: true}
+	case errors.Is(err, draft.ErrUnknownCommand), errors.Is(err, draft.ErrCommandParserFailed):
+		return Reply{Speech: notUnderstood}
+	case errors.Is(err, draft.ErrPlayerNotFound):
+		return Reply{Speech: "I couldn't find an available player by that name."}
+	case errors.Is(err, draft.ErrNoPlayerLookup):
+		return Reply{Speech: "Picking players by voice isn't available yet. Make this pick in the app."}
+	case errors.Is(err, draft.ErrQueueFull):
+		return Reply{Speech: "Your queue is full. Remove someone first."}
+	case errors.Is(err, draft.ErrStaleTurn):
+		return Reply{Speech: "The draft has moved on. Ask again for the current pick."}
+	}
+
+	switch msg := err.Error(); {
+	case msg == "player is not available":
+		return Reply{Speech: "That player has already been drafted."}
+	case msg == "draft is not active":
+		return Reply{Speech: "Your draft is paused. Say resume to continue."}
+	case msg == "draft is complete":
+		return Reply{Speech: "Your draft is complete.", EndSession: true}
+	case strings.HasPrefix(msg, "nothing to"):
+		return Reply{Speech: "There's " + msg + "."}
+	case strings.HasPrefix(msg, "can only"):
+		return Reply{Speech: "I " + msg + "."}
+	}
+	return Reply{Speech: somethingWrong}
+}
+
+// confirmVerbs are how the user says each command that names a player
+var confirmVerbs = map[string]string{
+	draft.CommandPick:    "take",
+	draft.CommandQueue:   "queue",
+	draft.CommandUnqueue: "remove",
+}
+
+// confirmSpeech asks which of the candidates for action the user meant.
+// Saying a candidate's full name picks them out without asking again.
+func confirmSpeech(action string, candidates []draft.Player) string {
+	verb := confirmVerbs[action]
+	if verb == "" {
+		verb = "take"
+	}
+	if len(candidates) == 1 {
+		return fmt.Sprintf("Did you mean %s? Say %s %s to confirm.", candidates[0].Name, verb, candidates[0].Name)
+	}
+	names := make([]string, 0, 3)
+	for _, p := range candidates {
+		names = append(names, fmt.Sprintf("%s, %s", p.Name, p.Position))
+		if len(names) == cap(names) {
+			break
+		}
+	}
+	return fmt.Sprintf("Did you mean %s? Say %s and the full name.", joinNames(names, "or"), verb)
+}
+
+// queueSpeech reports the queue's length after a queue command
+func queueSpeech(n int) string {
+	switch n {
+	case 0:
+		return "Your queue is empty."
+	case 1:
+		return "Done. You have 1 player queued."
+	}
+	return fmt.Sprintf("Done. You have %d players queued.", n)
+}
+
+// bestAvailableSpeech reads out the top recommendations
+func bestAvailableSpeech(position string, result *draft.CommandResult) string {
+	recs := result.Recommendations
+	if len(recs) == 0 {
+		if position != "" {
+			return fmt.Sprintf("I don't have a %s to recommend right now.", position)
+		}
+		return "I don't have recommendations for this pick right now."
+	}
+
+	best := recs[0]
+	speech := fmt.Sprintf("The best available is %s, %s, %s.", best.PlayerName, best.Position, best.Team)
+	if position != "" {
+		speech = fmt.Sprintf("The best available %s is %s, %s.", position, best.PlayerName, best.Team)
+	}
+	if len(recs) > 1 {
+		names := make([]string, 0, len(recs)-1)
+		for _, rec := range recs[1:] {
+			names = append(names, rec.PlayerName)
+		}
+		speech += fmt.Sprintf(" After that, %s.", joinNames(names, "and"))
+	}
+	return speech
+}
+
+// joinNames lists names as "a, b or c"
+func joinNames(names []string, conjunction string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " " + conjunction + " " + names[len(names)-1]
+}
@@ -0,0 +1,249 @@
+package voice
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/draft"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlexaRequest_Utterance(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want Utterance
+	}{
+		{"launch", `{"request": {"type": "LaunchRequest"}}`, Utterance{Launch: true}},
+		{"ended", `{"request": {"type": "SessionEndedRequest"}}`, Utterance{End: true}},
+		{"stop", `{"request": {"type": "IntentRequest", "intent": {"name": "AMAZON.StopIntent"}}}`, Utterance{End: true}},
+		{"command", `{"request": {"type": "IntentRequest", "intent": {"name": "DraftCommandIntent",
+			"slots": {"command": {"name": "command", "value": "take bijan robinson"}}}}}`, Utterance{Text: "take bijan robinson"}},
+		{"best available", `{"request": {"type": "IntentRequest", "intent": {"name": "BestAvailableIntent",
+			"slots": {"position": {"name": "position", "value": "running back"}}}}}`, Utterance{Text: "best available running back"}},
+		{"best available any position", `{"request": {"type": "IntentRequest", "intent": {"name": "BestAvailableIntent"}}}`,
+			Utterance{Text: "best available"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req AlexaRequest
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &req))
+			assert.Equal(t, tt.want, req.Utterance())
+		})
+	}
+}
+
+func TestAlexaRequest_AccessToken(t *testing.T) {
+	var req AlexaRequest
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"session": {"application": {"applicationId": "skill-1"}, "user": {"accessToken": "session-token"}},
+		"context": {"System": {"user": {"accessToken": "context-token"}}}
+	}`), &req))
+
+	assert.Equal(t, "context-token", req.AccessToken())
+	assert.Equal(t, "skill-1", req.ApplicationID())
+}
+
+func TestDialogflowRequest(t *testing.T) {
+	var req DialogflowRequest
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"queryResult": {"queryText": " who's the best available WR ", "intent": {"displayName": "Default Fallback Intent"}},
+		"originalDetectIntentRequest": {"source": "google", "payload": {"user": {"accessToken": "device-token"}}}
+	}`), &req))
+
+	assert.Equal(t, "device-token", req.AccessToken())
+	assert.Equal(t, Utterance{Text: "who's the best available WR"}, req.Utterance())
+
+	req.QueryResult.Intent.DisplayName = DialogflowWelcomeIntent
+	assert.True(t, req.Utterance().Launch)
+}
+
+func TestNewDialogflowResponse(t *testing.T) {
+	resp := NewDialogflowResponse(Reply{Speech: "Draft paused."}, false)
+	assert.True(t, resp.Payload.Google.ExpectUserResponse)
+	require.NotNil(t, resp.Payload.Google.RichResponse)
+	assert.Equal(t, "Draft paused.", resp.Payload.Google.RichResponse.Items[0].SimpleResponse.TextToSpeech)
+
+	resp = NewDialogflowResponse(Reply{Speech: NotLinked, EndSession: true}, true)
+	require.NotNil(t, resp.Payload.Google.SystemIntent)
+	assert.Equal(t, "actions.intent.SIGN_IN", resp.Payload.Google.SystemIntent.Intent)
+}
+
+func TestCommandReply(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *draft.CommandResult
+		want   string
+	}{
+		{
+			"pick",
+			&draft.CommandResult{Command: draft.Command{Action: draft.CommandPick}, Status: draft.CommandDone,
+				Pick: &models.DraftPick{PlayerName: "Bijan Robinson", Position: "RB", PickNumber: 4}},
+			"Drafted Bijan Robinson, RB, with pick 4.",
+		},
+		{
+			"confirm one",
+			&draft.CommandResult{Command: draft.Command{Action: draft.CommandQueue}, Status: draft.CommandConfirm,
+				Candidates: []draft.Player{{Name: "Puka Nacua", Position: "WR"}}},
+			"Did you mean Puka Nacua? Say queue Puka Nacua to confirm.",
+		},
+		{
+			"confirm several",
+			&draft.CommandResult{Command: draft.Command{Action: draft.CommandPick}, Status: draft.CommandConfirm,
+				Candidates: []draft.Player{{Name: "DJ Moore", Position: "WR"}, {Name: "Elijah Moore", Position: "WR"}}},
+			"Did you mean DJ Moore, WR or Elijah Moore, WR? Say take and the full name.",
+		},
+		{
+			"queue",
+			&draft.CommandResult{Command: draft.Command{Action: draft.CommandQueueTop}, Status: draft.CommandDone,
+				Queue: make([]draft.Player, 3)},
+			"Done. You have 3 players queued.",
+		},
+		{
+			"best available at a position",
+			&draft.CommandResult{Command: draft.Command{Action: draft.CommandBestAvailable, Position: "RB"}, Status: draft.CommandDone,
+				Recommendations: []models.DraftRecommendation{
+					{PlayerName: "Breece Hall", Position: "RB", Team: "NYJ"},
+					{PlayerName: "Kyren Williams", Position: "RB", Team: "LAR"},
+					{PlayerName: "Josh Jacobs", Position: "RB", Team: "GB"},
+				}},
+			"The best available RB is Breece Hall, NYJ. After that, Kyren Williams and Josh Jacobs.",
+		},
+		{
+			"best available without recommendations",
+			&draft.CommandResult{Command: draft.Command{Action: draft.CommandBestAvailable}, Status: draft.CommandDone},
+			"I don't have recommendations for this pick right now.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CommandReply(tt.result).Speech)
+		})
+	}
+}
+
+func TestErrorReply(t *testing.T) {
+	assert.True(t, ErrorReply(ErrNoActiveDraft).EndSession)
+	assert.Equal(t, notUnderstood, ErrorReply(draft.ErrUnknownCommand).Speech)
+	assert.Equal(t, "There's nothing to undo.", ErrorReply(errors.New("nothing to undo")).Speech)
+	assert.Equal(t, somethingWrong, ErrorReply(errors.New("connection refused")).Speech)
+}
+
+func TestCheckCertURL(t *testing.T) {
+	valid := []string{
+		"https://s3.amazonaws.com/echo.api/echo-api-cert.pem",
+		"HTTPS://s3.amazonaws.com/echo.api/echo-api-cert.pem",
+		"https://s3.amazonaws.com:443/echo.api/echo-api-cert.pem",
+		"https://s3.amazonaws.com/echo.api/../echo.api/echo-api-cert.pem",
+	}
+	invalid := []string{
+		"",
+		"http://s3.amazonaws.com/echo.api/echo-api-cert.pem",
+		"https://notamazon.com/echo.api/echo-api-cert.pem",
+		"https://s3.amazonaws.com/EcHo.aPi/echo-api-cert.pem",
+		"https://s3.amazonaws.com/invalid.path/echo-api-cert.pem",
+		"https://s3.amazonaws.com:563/echo.api/echo-api-cert.pem",
+		"https://s3.amazonaws.com/echo.api/../other/cert.pem",
+	}
+
+	for _, u := range valid {
+		assert.NoError(t, checkCertURL(u), u)
+	}
+	for _, u := range invalid {
+		assert.Error(t, checkCertURL(u), u)
+	}
+}
+
+func TestAlexaSignature(t *testing.T) {
+	now := time.Now()
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	issue := func(dnsName string) ([]byte, *rsa.PrivateKey) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: dnsName},
+			DNSNames:     []string{dnsName},
+			NotBefore:    now.Add(-time.Hour),
+			NotAfter:     now.Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), key
+	}
+
+	chain, key := issue(alexaCertHost)
+	cert, err := parseCertChain(chain, now, roots)
+	require.NoError(t, err)
+
+	body := []byte(`{"version": "1.0"}`)
+	hash := sha256.Sum256(body)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	require.NoError(t, err)
+	signature := base64.StdEncoding.EncodeToString(sig)
+
+	assert.NoError(t, verifySignature(cert, body, signature))
+	assert.ErrorIs(t, verifySignature(cert, []byte(`{"version": "2.0"}`), signature), ErrAlexaSignature)
+	assert.ErrorIs(t, verifySignature(cert, body, "not base64!"), ErrAlexaSignature)
+
+	// Certificates for other names, or past their validity, are not trusted
+	otherChain, _ := issue("example.com")
+	_, err = parseCertChain(otherChain, now, roots)
+	assert.Error(t, err)
+	_, err = parseCertChain(chain, now.Add(2*time.Hour), roots)
+	assert.Error(t, err)
+}
+
+func TestAlexaVerifier_SkillAndTimestamp(t *testing.T) {
+	now := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+	verifier := NewAlexaVerifier("skill-1", false)
+	verifier.now = func() time.Time { return now }
+
+	request := func(skillID string, timestamp time.Time) *AlexaRequest {
+		var req AlexaRequest
+		req.Context.System.Application.ApplicationID = skillID
+		req.Request.Timestamp = timestamp
+		return &req
+	}
+	r := httptest.NewRequest("POST", "/api/voice/alexa", strings.NewReader("{}"))
+
+	assert.NoError(t, verifier.Verify(r, nil, request("skill-1", now.Add(-time.Minute))))
+	assert.ErrorIs(t, verifier.Verify(r, nil, request("skill-2", now)), ErrAlexaSkillMismatch)
+	assert.ErrorIs(t, verifier.Verify(r, nil, request("skill-1", now.Add(-3*time.Minute))), ErrAlexaTimestamp)
+	assert.ErrorIs(t, verifier.Verify(r, nil, request("skill-1", now.Add(3*time.Minute))), ErrAlexaTimestamp)
+}
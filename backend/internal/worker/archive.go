@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/archive"
+)
+
+// ArchiveWorker archives completed seasons once a day
+type ArchiveWorker struct {
+	archiver   *archive.Archiver
+	hour       int
+	pauser     Pauser
+	heartbeats *Heartbeats
+}
+
+// NewArchiveWorker creates a new season archival worker that runs daily at
+// hour UTC
+func NewArchiveWorker(archiver *archive.Archiver, hour int) *ArchiveWorker {
+	return &ArchiveWorker{
+		archiver: archiver,
+		hour:     hour,
+	}
+}
+
+// WithPauser skips scheduled runs while p is paused
+func (w *ArchiveWorker) WithPauser(p Pauser) *ArchiveWorker {
+	w.pauser = p
+	return w
+}
+
+// WithHeartbeats reports the worker's ticks to h
+func (w *ArchiveWorker) WithHeartbeats(h *Heartbeats) *ArchiveWorker {
+	w.heartbeats = h
+	return w
+}
+
+// Run waits for each daily run until the context is cancelled
+func (w *ArchiveWorker) Run(ctx context.Context) {
+	for {
+		next := nextDailyRun(time.Now(), w.hour, time.UTC)
+		w.heartbeats.Tick(archiveJob, time.Until(next))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Seasons skipped today are archived tomorrow
+		if isPaused(ctx, w.pauser) {
+			log.Printf("Season archival skipped: background jobs are paused")
+			continue
+		}
+
+		w.heartbeats.Start(archiveJob)
+		report, err := w.archiver.Run(ctx)
+		w.heartbeats.Done(archiveJob)
+		if err != nil {
+			log.Printf("Season archival failed: %v", err)
+			continue
+		}
+		log.Printf("Season archival complete: %d league seasons, %d rows, %d bytes, %d failed",
+			report.Seasons, report.Rows, report.Bytes, report.Failed)
+	}
+}
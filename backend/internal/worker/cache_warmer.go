@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// WarmFunc loads one set of data into the cache and returns how many entries
+// it warmed
+type WarmFunc func(ctx context.Context) (int, error)
+
+type warmStep struct {
+	name string
+	fn   WarmFunc
+}
+
+// CacheWarmer fills caches before the server starts taking traffic, so a
+// deploy during games does not serve every first request from the database
+type CacheWarmer struct {
+	steps   []warmStep
+	timeout time.Duration
+}
+
+// NewCacheWarmer creates a cache warmer that gives up after timeout
+func NewCacheWarmer(timeout time.Duration) *CacheWarmer {
+	return &CacheWarmer{timeout: timeout}
+}
+
+// Add registers a named warming step
+func (w *CacheWarmer) Add(name string, fn WarmFunc) *CacheWarmer {
+	w.steps = append(w.steps, warmStep{name: name, fn: fn})
+	return w
+}
+
+// Run executes each step in order. A failed step is logged and does not stop
+// the others; steps still running at the timeout are abandoned.
+func (w *CacheWarmer) Run(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	started := time.Now()
+	for _, step := range w.steps {
+		stepStarted := time.Now()
+		count, err := step.fn(ctx)
+		if err != nil {
+			log.Printf("Cache warming %s failed: %v", step.name, err)
+			continue
+		}
+		log.Printf("Cache warming %s: %d entries in %s", step.name, count, time.Since(stepStarted).Round(time.Millisecond))
+	}
+
+	log.Printf("Cache warming complete in %s", time.Since(started).Round(time.Millisecond))
+}
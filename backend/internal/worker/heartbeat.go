@@ -0,0 +1,201 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Worker names in heartbeats, metrics and /readyz
+const (
+	leagueSyncJob      = "league_sync"
+	leagueAnalyticsJob = "league_analytics"
+	playerNewsJob      = "player_news"
+	playerMetadataJob  = "player_metadata"
+	teamMetadataJob    = "team_metadata"
+	redisAuditJob      = "redis_audit"
+	inactivityJob      = "inactivity"
+	retentionJob       = "retention"
+	archiveJob         = "archive"
+)
+
+// Heartbeats tracks when each scheduled worker last ticked and when it is
+// due to tick again. A worker that misses its next tick by more than the
+// grace period is stale: its goroutine has died or is stuck, and the jobs
+// it schedules are not running. All methods are safe on a nil Heartbeats.
+type Heartbeats struct {
+	mu      sync.Mutex
+	workers map[string]*heartbeat
+	grace   time.Duration
+	now     func() time.Time
+}
+
+// heartbeat is one worker's state
+type heartbeat struct {
+	lastTick time.Time
+	due      time.Time
+	inFlight int
+	depth    func() int
+	// alerted is set once a stall has been reported, so it is reported once
+	alerted bool
+}
+
+// WorkerStatus is one worker's heartbeat as of a moment
+type WorkerStatus struct {
+	Name     string    `json:"name"`
+	LastTick time.Time `json:"last_tick"`
+	// DueBy is when the next tick is due, grace period included
+	DueBy    time.Time `json:"due_by"`
+	InFlight int       `json:"in_flight"`
+	// QueueDepth is set for workers fed by a queue
+	QueueDepth *int `json:"queue_depth,omitempty"`
+	Stale      bool `json:"stale"`
+}
+
+// NewHeartbeats creates an empty registry allowing each worker grace past
+// its expected next tick, which covers the time a run takes
+func NewHeartbeats(grace time.Duration) *Heartbeats {
+	return &Heartbeats{
+		workers: make(map[string]*heartbeat),
+		grace:   grace,
+		now:     time.Now,
+	}
+}
+
+// Tick records that a worker's scheduler is alive and will tick again
+// within next
+func (h *Heartbeats) Tick(name string, next time.Duration) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hb := h.worker(name)
+	now := h.now()
+	hb.lastTick = now
+	hb.due = now.Add(next + h.grace)
+	if hb.alerted {
+		hb.alerted = false
+		log.Printf("Worker %s is ticking again", name)
+	}
+}
+
+// Start records that a worker began a job
+func (h *Heartbeats) Start(name string) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.worker(name).inFlight++
+}
+
+// Done records that a job begun with Start finished
+func (h *Heartbeats) Done(name string) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if hb := h.worker(name); hb.inFlight > 0 {
+		hb.inFlight--
+	}
+}
+
+// TrackQueue reports depth as the number of jobs waiting for a worker
+func (h *Heartbeats) TrackQueue(name string, depth func() int) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.worker(name).depth = depth
+}
+
+// Status returns every worker's heartbeat, by name
+func (h *Heartbeats) Status() []WorkerStatus {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	now := h.now()
+	statuses := make([]WorkerStatus, 0, len(h.workers))
+	depths := make(map[int]func() int)
+	for name, hb := range h.workers {
+		if hb.depth != nil {
+			depths[len(statuses)] = hb.depth
+		}
+		statuses = append(statuses, WorkerStatus{
+			Name:     name,
+			LastTick: hb.lastTick,
+			DueBy:    hb.due,
+			InFlight: hb.inFlight,
+			Stale:    !hb.due.IsZero() && now.After(hb.due),
+		})
+	}
+	h.mu.Unlock()
+
+	// Queues take their own locks, so they are read after releasing ours
+	for i, depth := range depths {
+		n := depth()
+		statuses[i].QueueDepth = &n
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// Run reports every worker's heartbeat as metrics each interval and alerts
+// on workers that have missed their tick, until the context is cancelled
+func (h *Heartbeats) Run(ctx context.Context, interval time.Duration) {
+	if h == nil {
+		return
+	}
+	for sleep(ctx, interval) {
+		h.report()
+	}
+}
+
+// report logs one round of heartbeat metrics and alerts
+func (h *Heartbeats) report() {
+	now := h.now()
+	for _, s := range h.Status() {
+		depth := 0
+		if s.QueueDepth != nil {
+			depth = *s.QueueDepth
+		}
+		log.Printf("metrics worker_heartbeat worker=%s age_s=%d in_flight=%d queue_depth=%d stale=%t",
+			s.Name, int(now.Sub(s.LastTick).Seconds()), s.InFlight, depth, s.Stale)
+
+		if s.Stale && h.markAlerted(s.Name) {
+			log.Printf("ALERT worker %s has not ticked since %s; it was due by %s",
+				s.Name, s.LastTick.Format(time.RFC3339), s.DueBy.Format(time.RFC3339))
+			log.Printf("metrics worker_stale worker=%s overdue_s=%d", s.Name, int(now.Sub(s.DueBy).Seconds()))
+		}
+	}
+}
+
+// markAlerted records that a worker's stall was reported, returning false
+// if it already was
+func (h *Heartbeats) markAlerted(name string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hb := h.worker(name)
+	if hb.alerted {
+		return false
+	}
+	hb.alerted = true
+	return true
+}
+
+// worker returns a worker's state, adding it on first use. Callers hold mu.
+func (h *Heartbeats) worker(name string) *heartbeat {
+	hb, ok := h.workers[name]
+	if !ok {
+		hb = &heartbeat{}
+		h.workers[name] = hb
+	}
+	return hb
+}
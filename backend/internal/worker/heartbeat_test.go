@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeartbeats_StaleAfterMissedTick(t *testing.T) {
+	now := time.Date(2025, 10, 5, 12, 0, 0, 0, time.UTC)
+	h := NewHeartbeats(5 * time.Minute)
+	h.now = func() time.Time { return now }
+
+	h.Tick(leagueSyncJob, 30*time.Minute)
+	h.Tick(retentionJob, 12*time.Hour)
+
+	now = now.Add(34 * time.Minute)
+	statuses := h.Status()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, leagueSyncJob, statuses[0].Name)
+	assert.False(t, statuses[0].Stale, "within interval plus grace")
+
+	now = now.Add(2 * time.Minute)
+	statuses = h.Status()
+	assert.True(t, statuses[0].Stale)
+	assert.Equal(t, retentionJob, statuses[1].Name)
+	assert.False(t, statuses[1].Stale)
+
+	// A stall is alerted once, and again only after the worker recovers
+	assert.True(t, h.markAlerted(leagueSyncJob))
+	assert.False(t, h.markAlerted(leagueSyncJob))
+	h.Tick(leagueSyncJob, 30*time.Minute)
+	assert.False(t, h.Status()[0].Stale)
+	assert.True(t, h.markAlerted(leagueSyncJob))
+}
+
+func TestHeartbeats_InFlightAndQueueDepth(t *testing.T) {
+	h := NewHeartbeats(time.Minute)
+	q := NewSyncQueue(time.Minute)
+	q.Push(&models.League{ID: uuid.New()}, PriorityScheduled)
+	q.Push(&models.League{ID: uuid.New()}, PriorityScheduled)
+
+	h.Tick(leagueSyncJob, time.Hour)
+	h.TrackQueue(leagueSyncJob, q.Len)
+	h.Start(leagueSyncJob)
+	h.Start(leagueSyncJob)
+	h.Done(leagueSyncJob)
+
+	statuses := h.Status()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, 1, statuses[0].InFlight)
+	require.NotNil(t, statuses[0].QueueDepth)
+	assert.Equal(t, 2, *statuses[0].QueueDepth)
+}
+
+func TestHeartbeats_Nil(t *testing.T) {
+	var h *Heartbeats
+	h.Tick(leagueSyncJob, time.Minute)
+	h.Start(leagueSyncJob)
+	h.Done(leagueSyncJob)
+	assert.Nil(t, h.Status())
+}
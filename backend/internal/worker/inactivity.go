@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/inactivity"
+)
+
+// InactivityWorker warns and anonymizes inactive accounts once a day
+type InactivityWorker struct {
+	job        *inactivity.Job
+	hour       int
+	pauser     Pauser
+	heartbeats *Heartbeats
+}
+
+// NewInactivityWorker creates a new inactive account worker that runs daily
+// at hour UTC
+func NewInactivityWorker(job *inactivity.Job, hour int) *InactivityWorker {
+	return &InactivityWorker{
+		job:  job,
+		hour: hour,
+	}
+}
+
+// WithPauser skips scheduled runs while p is paused
+func (w *InactivityWorker) WithPauser(p Pauser) *InactivityWorker {
+	w.pauser = p
+	return w
+}
+
+// WithHeartbeats reports the worker's ticks to h
+func (w *InactivityWorker) WithHeartbeats(h *Heartbeats) *InactivityWorker {
+	w.heartbeats = h
+	return w
+}
+
+// Run waits for each daily run until the context is cancelled
+func (w *InactivityWorker) Run(ctx context.Context) {
+	for {
+		next := nextDailyRun(time.Now(), w.hour, time.UTC)
+		w.heartbeats.Tick(inactivityJob, time.Until(next))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Accounts skipped today are picked up tomorrow
+		if isPaused(ctx, w.pauser) {
+			log.Printf("Inactive account job skipped: background jobs are paused")
+			continue
+		}
+
+		w.heartbeats.Start(inactivityJob)
+		run, err := w.job.Run(ctx)
+		w.heartbeats.Done(inactivityJob)
+		if err != nil {
+			log.Printf("Inactive account job failed: %v", err)
+			continue
+		}
+		log.Printf("Inactive account job complete: %d warned, %d anonymized, %d reactivated, %d failed",
+			run.Warned, run.Anonymized, run.Reactivated, run.Failed)
+	}
+}
@@ -0,0 +1,193 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/analytics"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// LeagueAnalyticsWorker precomputes standings, power rankings, records,
+// awards, playoff odds and the weekly recap for every active league once a
+// week, after Monday night stats are final, so reads hit stored results
+type LeagueAnalyticsWorker struct {
+	leagueRepo    repositories.LeagueRepository
+	analyticsRepo analytics.Repository
+	credService   *services.CredentialsService
+	espnClient    espn.Client
+	day           time.Weekday
+	hour          int
+	location      *time.Location
+	simulations   int
+	pauser        Pauser
+	rosters       *services.RosterHistorian
+	heartbeats    *Heartbeats
+}
+
+// NewLeagueAnalyticsWorker creates a new league analytics worker that runs
+// weekly on day at hour in location
+func NewLeagueAnalyticsWorker(
+	leagueRepo repositories.LeagueRepository,
+	analyticsRepo analytics.Repository,
+	credService *services.CredentialsService,
+	espnClient espn.Client,
+	day time.Weekday,
+	hour int,
+	location *time.Location,
+	simulations int,
+) *LeagueAnalyticsWorker {
+	return &LeagueAnalyticsWorker{
+		leagueRepo:    leagueRepo,
+		analyticsRepo: analyticsRepo,
+		credService:   credService,
+		espnClient:    espnClient,
+		day:           day,
+		hour:          hour,
+		location:      location,
+		simulations:   simulations,
+	}
+}
+
+// WithPauser defers scheduled runs while p is paused
+func (w *LeagueAnalyticsWorker) WithPauser(p Pauser) *LeagueAnalyticsWorker {
+	w.pauser = p
+	return w
+}
+
+// WithRosterHistorian adds each team's roster construction to its power
+// ranking
+func (w *LeagueAnalyticsWorker) WithRosterHistorian(h *services.RosterHistorian) *LeagueAnalyticsWorker {
+	w.rosters = h
+	return w
+}
+
+// WithHeartbeats reports the worker's ticks to h
+func (w *LeagueAnalyticsWorker) WithHeartbeats(h *Heartbeats) *LeagueAnalyticsWorker {
+	w.heartbeats = h
+	return w
+}
+
+// Run waits for each scheduled run and precomputes every league until the
+// context is cancelled
+func (w *LeagueAnalyticsWorker) Run(ctx context.Context) {
+	for {
+		next := nextRun(time.Now(), w.day, w.hour, w.location)
+		log.Printf("Next league analytics precompute at %s", next.Format(time.RFC1123))
+		w.heartbeats.Tick(leagueAnalyticsJob, time.Until(next))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// A paused run is deferred rather than skipped so the week is not lost
+		if !waitUntilResumed(ctx, w.pauser, "League analytics precompute") {
+			return
+		}
+
+		w.heartbeats.Start(leagueAnalyticsJob)
+		if err := w.PrecomputeAll(ctx); err != nil {
+			log.Printf("League analytics precompute failed: %v", err)
+		}
+		w.heartbeats.Done(leagueAnalyticsJob)
+	}
+}
+
+// PrecomputeAll computes and stores analytics for every active league. A
+// failure in one league is logged and does not stop the others.
+func (w *LeagueAnalyticsWorker) PrecomputeAll(ctx context.Context) error {
+	leagues, err := w.leagueRepo.GetActiveLeagues(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active leagues: %w", err)
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	var computed, failed int
+	for _, league := range leagues {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !strings.EqualFold(league.Platform, "espn") {
+			continue
+		}
+
+		if err := w.precomputeLeague(ctx, league, rng); err != nil {
+			failed++
+			log.Printf("Failed to precompute analytics for league %s: %v", league.ID, err)
+			continue
+		}
+		computed++
+	}
+
+	log.Printf("League analytics precompute complete: %d computed, %d failed", computed, failed)
+	return nil
+}
+
+// precomputeLeague computes analytics for a single league from its season
+// schedule
+func (w *LeagueAnalyticsWorker) precomputeLeague(ctx context.Context, league *models.League, rng *rand.Rand) error {
+	client, _, err := leagueClient(ctx, w.credService, w.espnClient, league)
+	if err != nil {
+		return err
+	}
+
+	info, err := client.GetLeagueInfo(ctx, league.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	// The matchup view returns the whole season schedule
+	schedule, err := client.GetMatchups(ctx, league.ExternalID, info.Status.CurrentWeek)
+	if err != nil {
+		return err
+	}
+
+	result := analytics.Compute(info, schedule, w.simulations, rng)
+	result.LeagueID = league.ID.String()
+
+	if w.rosters != nil {
+		// Rankings are still worth saving without it
+		constructions, err := w.rosters.Construction(ctx, league)
+		if err != nil {
+			log.Printf("Failed to compute roster construction for league %s: %v", league.ID, err)
+		}
+		for i, ranking := range result.PowerRankings {
+			if construction, ok := constructions[ranking.TeamID]; ok {
+				result.PowerRankings[i].RosterConstruction = &construction
+			}
+		}
+	}
+
+	if err := w.analyticsRepo.SaveLeagueAnalytics(ctx, result); err != nil {
+		return err
+	}
+
+	recap := analytics.BuildRecap(info, schedule, result)
+	if recap == nil {
+		return nil
+	}
+	return w.analyticsRepo.SaveRecap(ctx, recap)
+}
+
+// nextRun returns the first time after now that falls on day at hour in loc
+func nextRun(now time.Time, day time.Weekday, hour int, loc *time.Location) time.Time {
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, loc)
+	next = next.AddDate(0, 0, (int(day)-int(next.Weekday())+7)%7)
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
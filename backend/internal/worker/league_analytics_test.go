@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextRun(t *testing.T) {
+	loc := time.FixedZone("EST", -5*60*60)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "later the same week",
+			now:  time.Date(2023, 10, 16, 22, 0, 0, 0, loc), // Monday night
+			want: time.Date(2023, 10, 17, 6, 0, 0, 0, loc),
+		},
+		{
+			name: "earlier on the run day",
+			now:  time.Date(2023, 10, 17, 5, 59, 0, 0, loc),
+			want: time.Date(2023, 10, 17, 6, 0, 0, 0, loc),
+		},
+		{
+			name: "just after the run",
+			now:  time.Date(2023, 10, 17, 6, 0, 0, 0, loc),
+			want: time.Date(2023, 10, 24, 6, 0, 0, 0, loc),
+		},
+		{
+			name: "converts from UTC",
+			now:  time.Date(2023, 10, 17, 12, 0, 0, 0, time.UTC), // 07:00 EST Tuesday
+			want: time.Date(2023, 10, 24, 6, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextRun(tt.now, time.Tuesday, 6, loc)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestNextDailyRun(t *testing.T) {
+	loc := time.FixedZone("EST", -5*60*60)
+
+	got := nextDailyRun(time.Date(2023, 10, 17, 3, 30, 0, 0, loc), 4, loc)
+	assert.True(t, time.Date(2023, 10, 17, 4, 0, 0, 0, loc).Equal(got), "later the same night, got %s", got)
+
+	got = nextDailyRun(time.Date(2023, 10, 17, 4, 0, 0, 0, loc), 4, loc)
+	assert.True(t, time.Date(2023, 10, 18, 4, 0, 0, 0, loc).Equal(got), "just after the run, got %s", got)
+}
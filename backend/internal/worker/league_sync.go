@@ -0,0 +1,636 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/eventbus"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+	"golang.org/x/sync/errgroup"
+)
+
+// Snapshot data types stored by the sync worker
+const (
+	SnapshotRosters      = "rosters"
+	SnapshotMatchups     = "matchups"
+	SnapshotTransactions = "transactions"
+	// SnapshotUserRoster is the league owner's own roster, used for alerts
+	SnapshotUserRoster = "user_roster"
+)
+
+// leagueFetchConcurrency caps the ESPN requests one league sync has in
+// flight. Every request still waits on the client's shared rate limiter.
+const leagueFetchConcurrency = 4
+
+// LeagueSyncWorker periodically pulls platform data for active leagues
+type LeagueSyncWorker struct {
+	leagueRepo       repositories.LeagueRepository
+	syncRepo         repositories.LeagueSyncRepository
+	rosterRepo       repositories.LeagueRosterRepository
+	credService      *services.CredentialsService
+	espnClient       espn.Client
+	interval         time.Duration
+	transactionLimit int
+	throttler        Throttler
+	pauser           Pauser
+	publisher        eventbus.Publisher
+	budget           RequestBudget
+	concurrency      int
+	queue            *SyncQueue
+	heartbeats       *Heartbeats
+}
+
+// NewLeagueSyncWorker creates a new league sync worker
+func NewLeagueSyncWorker(
+	leagueRepo repositories.LeagueRepository,
+	syncRepo repositories.LeagueSyncRepository,
+	credService *services.CredentialsService,
+	espnClient espn.Client,
+	interval time.Duration,
+	transactionLimit int,
+) *LeagueSyncWorker {
+	return &LeagueSyncWorker{
+		leagueRepo:       leagueRepo,
+		syncRepo:         syncRepo,
+		credService:      credService,
+		espnClient:       espnClient,
+		interval:         interval,
+		transactionLimit: transactionLimit,
+		concurrency:      1,
+	}
+}
+
+// WithPauser skips syncs while p is paused
+func (w *LeagueSyncWorker) WithPauser(p Pauser) *LeagueSyncWorker {
+	w.pauser = p
+	return w
+}
+
+// WithRosterStore also stores synced rosters in r's tables, alongside the
+// snapshot
+func (w *LeagueSyncWorker) WithRosterStore(r repositories.LeagueRosterRepository) *LeagueSyncWorker {
+	w.rosterRepo = r
+	return w
+}
+
+// WithThrottler stretches the interval between syncs while t asks for it
+func (w *LeagueSyncWorker) WithThrottler(t Throttler) *LeagueSyncWorker {
+	w.throttler = t
+	return w
+}
+
+// WithPublisher publishes matchup scores, new transactions and sync results
+// to p
+func (w *LeagueSyncWorker) WithPublisher(p eventbus.Publisher) *LeagueSyncWorker {
+	w.publisher = p
+	return w
+}
+
+// WithBudget defers leagues that have used up their hourly ESPN request
+// budget to a later interval
+func (w *LeagueSyncWorker) WithBudget(b RequestBudget) *LeagueSyncWorker {
+	w.budget = b
+	return w
+}
+
+// WithConcurrency syncs up to n leagues at once. Their requests share the
+// ESPN client's rate limiter, so this shortens runs without exceeding it.
+func (w *LeagueSyncWorker) WithConcurrency(n int) *LeagueSyncWorker {
+	if n > 0 {
+		w.concurrency = n
+	}
+	return w
+}
+
+// WithQueue syncs leagues from q, where syncs users ask for are served
+// before the scheduled refresh of every league
+func (w *LeagueSyncWorker) WithQueue(q *SyncQueue) *LeagueSyncWorker {
+	w.queue = q
+	return w
+}
+
+// WithHeartbeats reports the scheduler's ticks, leagues being synced and
+// the queue's depth to h
+func (w *LeagueSyncWorker) WithHeartbeats(h *Heartbeats) *LeagueSyncWorker {
+	w.heartbeats = h
+	return w
+}
+
+// Run syncs all active leagues immediately and then on every interval until
+// the context is cancelled. With a queue, each interval queues the leagues
+// instead and the worker's concurrency sets how many are synced at once.
+func (w *LeagueSyncWorker) Run(ctx context.Context) {
+	w.heartbeats.Tick(leagueSyncJob, 0)
+	if w.queue != nil {
+		w.heartbeats.TrackQueue(leagueSyncJob, w.queue.Len)
+		for i := 0; i < w.concurrency; i++ {
+			go w.consume(ctx)
+		}
+	}
+
+	for {
+		if isPaused(ctx, w.pauser) {
+			log.Printf("League sync skipped: background jobs are paused")
+		} else if w.queue != nil {
+			if err := w.queueAll(ctx); err != nil {
+				log.Printf("League sync failed: %v", err)
+			}
+		} else if err := w.SyncAll(ctx); err != nil {
+			log.Printf("League sync failed: %v", err)
+		}
+
+		interval := nextInterval(ctx, w.throttler, w.interval)
+		w.heartbeats.Tick(leagueSyncJob, interval)
+		if !sleep(ctx, interval) {
+			return
+		}
+	}
+}
+
+// SyncAll syncs every active league. A failure in one league is recorded
+// against that league and does not stop the others.
+func (w *LeagueSyncWorker) SyncAll(ctx context.Context) error {
+	leagues, err := w.leagueRepo.GetActiveLeagues(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active leagues: %w", err)
+	}
+
+	var mu sync.Mutex
+	var synced, failed, deferred, skipped int
+	count := func(n *int) {
+		mu.Lock()
+		*n++
+		mu.Unlock()
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, w.concurrency)
+	for _, league := range leagues {
+		if !strings.EqualFold(league.Platform, "espn") {
+			continue
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+		wg.Add(1)
+		go func(league *models.League) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			switch w.syncOne(ctx, league, PriorityScheduled) {
+			case syncDeferred:
+				count(&deferred)
+			case syncSkipped:
+				count(&skipped)
+			case syncFailed:
+				count(&failed)
+			default:
+				count(&synced)
+			}
+		}(league)
+	}
+	wg.Wait()
+
+	log.Printf("League sync complete: %d synced, %d failed, %d deferred over ESPN budget, %d skipped with rejected cookies",
+		synced, failed, deferred, skipped)
+	return nil
+}
+
+// syncOutcome is how one league's sync went
+type syncOutcome string
+
+const (
+	syncSucceeded syncOutcome = "synced"
+	syncFailed    syncOutcome = "failed"
+	// syncDeferred leagues are over their ESPN budget
+	syncDeferred syncOutcome = "deferred"
+	// syncSkipped leagues have cookies ESPN already rejected
+	syncSkipped syncOutcome = "skipped"
+)
+
+// syncOne syncs a league and records a failure against it. Scheduled syncs
+// of leagues over their ESPN budget are deferred; syncs users asked for are
+// not.
+func (w *LeagueSyncWorker) syncOne(ctx context.Context, league *models.League, priority SyncPriority) syncOutcome {
+	w.heartbeats.Start(leagueSyncJob)
+	defer w.heartbeats.Done(leagueSyncJob)
+
+	if priority == PriorityScheduled && !w.withinBudget(ctx, league) {
+		return syncDeferred
+	}
+
+	attemptedAt := time.Now()
+	err := w.SyncLeague(ctx, league)
+	switch {
+	case errors.Is(err, services.ErrCredentialsInvalid):
+		// Already recorded when ESPN rejected the cookies; retrying would
+		// only be rejected again
+		return syncSkipped
+	case err != nil:
+		log.Printf("Failed to sync league %s: %v", league.ID, err)
+		if errors.Is(err, espn.ErrCookiesExpired) {
+			w.markCredentialsInvalid(ctx, league, err)
+		}
+		if recordErr := w.syncRepo.RecordFailure(ctx, league.ID.String(), attemptedAt, err); recordErr != nil {
+			log.Printf("Failed to record sync failure for league %s: %v", league.ID, recordErr)
+		}
+		return syncFailed
+	}
+	return syncSucceeded
+}
+
+// queueAll adds every active ESPN league to the queue at scheduled priority
+func (w *LeagueSyncWorker) queueAll(ctx context.Context) error {
+	leagues, err := w.leagueRepo.GetActiveLeagues(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active leagues: %w", err)
+	}
+
+	queued := 0
+	for _, league := range leagues {
+		if strings.EqualFold(league.Platform, "espn") {
+			w.queue.Push(league, PriorityScheduled)
+			queued++
+		}
+	}
+	log.Printf("League sync queued %d leagues, %d syncs waiting", queued, w.queue.Len())
+	return nil
+}
+
+// consume syncs leagues from the queue, highest priority first, until the
+// context is cancelled
+func (w *LeagueSyncWorker) consume(ctx context.Context) {
+	for {
+		league, priority, err := w.queue.Pop(ctx)
+		if err != nil {
+			return
+		}
+
+		if isPaused(ctx, w.pauser) {
+			log.Printf("League sync of %s skipped: background jobs are paused", league.ID)
+		} else {
+			started := time.Now()
+			outcome := w.syncOne(ctx, league, priority)
+			if priority == PriorityUser {
+				log.Printf("metrics league_sync_user league=%s outcome=%s duration_ms=%d", league.ID, outcome, time.Since(started).Milliseconds())
+			}
+		}
+		w.queue.Done(league.ID)
+	}
+}
+
+// markCredentialsInvalid stops the league owner's cookies being used until
+// they update them
+func (w *LeagueSyncWorker) markCredentialsInvalid(ctx context.Context, league *models.League, syncErr error) {
+	if err := w.credService.MarkESPNCredentialsInvalid(ctx, league.UserID, syncErr.Error()); err != nil {
+		log.Printf("Failed to mark ESPN credentials invalid for user %s: %v", league.UserID, err)
+		return
+	}
+	log.Printf("metrics espn_credentials_invalid user=%s league=%s", league.UserID, league.ID)
+}
+
+// withinBudget reports whether a league may be synced this run. Budget
+// lookup errors let the sync go ahead.
+func (w *LeagueSyncWorker) withinBudget(ctx context.Context, league *models.League) bool {
+	if w.budget == nil {
+		return true
+	}
+	allowed, calls, err := w.budget.Allow(ctx, league.ExternalID)
+	if err != nil {
+		log.Printf("Failed to check ESPN budget for league %s: %v", league.ID, err)
+		return true
+	}
+	if !allowed {
+		log.Printf("metrics espn_budget_deferred league=%s espn_league=%s calls=%d budget=%d", league.ID, league.ExternalID, calls, w.budget.Limit())
+	}
+	return allowed
+}
+
+// leagueData is everything one sync fetches for the current week
+type leagueData struct {
+	rosters      []espn.Roster
+	matchups     []espn.Matchup
+	boxScores    [][]espn.BoxScore // Previous week, then the current week
+	transactions []espn.Transaction
+}
+
+// fetchLeague makes a sync's requests that only depend on the week
+// concurrently, at most leagueFetchConcurrency at a time. The first failure
+// cancels the rest.
+func (w *LeagueSyncWorker) fetchLeague(ctx context.Context, client espn.Client, league *models.League, week int) (*leagueData, error) {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(leagueFetchConcurrency)
+
+	data := &leagueData{}
+	g.Go(func() (err error) {
+		data.rosters, err = client.GetRosters(ctx, league.ExternalID)
+		return err
+	})
+	g.Go(func() (err error) {
+		data.matchups, err = client.GetMatchups(ctx, league.ExternalID, week)
+		return err
+	})
+	g.Go(func() (err error) {
+		data.transactions, err = client.GetTransactions(ctx, league.ExternalID, w.transactionLimit)
+		return err
+	})
+
+	// Box scores for the week before too, so stat corrections made after a
+	// week ends are picked up
+	weeks := []int{week - 1, week}
+	data.boxScores = make([][]espn.BoxScore, len(weeks))
+	for i, boxWeek := range weeks {
+		if boxWeek < 1 {
+			continue
+		}
+		g.Go(func() (err error) {
+			data.boxScores[i], err = client.GetBoxScores(ctx, league.ExternalID, boxWeek)
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// SyncLeague pulls rosters, current matchups and recent transactions for a
+// single league and updates its last sync time. Requests after the league
+// info are made concurrently; results are stored in order.
+func (w *LeagueSyncWorker) SyncLeague(ctx context.Context, league *models.League) error {
+	client, swid, err := leagueClient(ctx, w.credService, w.espnClient, league)
+	if err != nil {
+		return err
+	}
+
+	leagueID := league.ID.String()
+
+	info, err := client.GetLeagueInfo(ctx, league.ExternalID)
+	if err != nil {
+		return err
+	}
+	week := info.Status.CurrentWeek
+
+	data, err := w.fetchLeague(ctx, client, league, week)
+	if err != nil {
+		return err
+	}
+
+	rosters := data.rosters
+	if err := w.syncRepo.SaveSnapshot(ctx, leagueID, SnapshotRosters, week, rosters); err != nil {
+		return err
+	}
+	owned := ownerRoster(info.Teams, rosters, swid)
+	if owned != nil {
+		if err := w.syncRepo.SaveSnapshot(ctx, leagueID, SnapshotUserRoster, week, owned); err != nil {
+			return err
+		}
+	}
+	if w.rosterRepo != nil {
+		if err := w.storeRosters(ctx, league, info.Season, week, rosters, owned); err != nil {
+			return err
+		}
+	}
+
+	matchups := data.matchups
+	if err := w.syncRepo.SaveSnapshot(ctx, leagueID, SnapshotMatchups, week, matchups); err != nil {
+		return err
+	}
+	w.publish(ctx, eventbus.TopicLiveScoring, leagueID, "matchups.updated", map[string]interface{}{
+		"league_id": leagueID,
+		"week":      week,
+		"matchups":  matchups,
+	})
+
+	for _, boxScores := range data.boxScores {
+		if err := w.syncRepo.SaveBoxScores(ctx, playerBoxScores(league, info.Season, boxScores)); err != nil {
+			return err
+		}
+	}
+
+	transactions := data.transactions
+	var previous []espn.Transaction
+	hasPrevious, err := w.syncRepo.GetLatestSnapshot(ctx, leagueID, SnapshotTransactions, &previous)
+	if err != nil {
+		return err
+	}
+	if err := w.syncRepo.SaveSnapshot(ctx, leagueID, SnapshotTransactions, week, transactions); err != nil {
+		return err
+	}
+	for _, change := range diffTransactions(league, previous, transactions, hasPrevious) {
+		w.publish(ctx, eventbus.TopicTransactions, leagueID, change.Kind, change)
+	}
+
+	syncedAt := time.Now()
+	if err := w.leagueRepo.UpdateLastSync(ctx, leagueID, syncedAt); err != nil {
+		return err
+	}
+	if err := w.syncRepo.RecordSuccess(ctx, leagueID, syncedAt); err != nil {
+		return err
+	}
+
+	w.publish(ctx, eventbus.TopicLeagueSync, leagueID, "league.synced", map[string]interface{}{
+		"league_id":    leagueID,
+		"week":         week,
+		"rosters":      len(rosters),
+		"transactions": len(transactions),
+		"synced_at":    syncedAt.UTC(),
+	})
+	return nil
+}
+
+// storeRosters saves the week's rosters and records players whose position
+// eligibility changed since the previous sync. Players seen for the first
+// time have nothing to compare with and are not reported.
+func (w *LeagueSyncWorker) storeRosters(ctx context.Context, league *models.League, season, week int, rosters []espn.Roster, owned *espn.Roster) error {
+	previous, err := w.rosterRepo.GetEligibility(ctx, league.ID.String())
+	if err != nil {
+		return err
+	}
+
+	entries := rosterEntries(rosters)
+	if err := w.rosterRepo.SaveRosters(ctx, league.ID, season, week, entries); err != nil {
+		return err
+	}
+
+	ownerTeam := 0
+	if owned != nil {
+		ownerTeam = owned.TeamID
+	}
+	changes := eligibilityChanges(league, season, week, ownerTeam, previous, entries)
+	if err := w.rosterRepo.SaveEligibilityChanges(ctx, changes); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		w.publish(ctx, eventbus.TopicRosters, league.ID.String(), models.EventEligibilityChanged, change)
+	}
+	return nil
+}
+
+// publish sends an event when a publisher is set. Failures are logged and
+// never fail the sync.
+func (w *LeagueSyncWorker) publish(ctx context.Context, topic, key, eventType string, payload interface{}) {
+	if w.publisher == nil {
+		return
+	}
+	if err := w.publisher.Publish(ctx, topic, key, eventType, payload); err != nil {
+		log.Printf("Failed to publish %s for league %s: %v", eventType, key, err)
+	}
+}
+
+// playerBoxScores flattens team box scores into per-player rows
+func playerBoxScores(league *models.League, season int, boxScores []espn.BoxScore) []models.PlayerBoxScore {
+	var scores []models.PlayerBoxScore
+	for _, b := range boxScores {
+		for _, p := range b.Players {
+			scores = append(scores, models.PlayerBoxScore{
+				LeagueID:        league.ID,
+				Season:          season,
+				Week:            b.Week,
+				TeamID:          b.TeamID,
+				OpponentID:      b.OpponentID,
+				PlayerID:        p.PlayerID,
+				PlayerName:      p.PlayerName,
+				Position:        p.Position,
+				NFLTeam:         p.Team,
+				LineupSlot:      p.LineupSlot,
+				Starter:         p.Starter,
+				Points:          p.Points,
+				ProjectedPoints: p.ProjectedPoints,
+				Stats:           p.Stats,
+			})
+		}
+	}
+	return scores
+}
+
+// rosterEntries flattens team rosters into per-player rows
+func rosterEntries(rosters []espn.Roster) []models.LeagueRosterEntry {
+	var entries []models.LeagueRosterEntry
+	for _, roster := range rosters {
+		for _, p := range roster.Players {
+			entry := models.LeagueRosterEntry{
+				TeamID:            roster.TeamID,
+				PlayerID:          p.PlayerID,
+				PlayerName:        p.PlayerName,
+				Position:          p.Position,
+				NFLTeam:           p.Team,
+				InjuryStatus:      p.Status,
+				EligiblePositions: p.EligiblePositions(),
+				LineupSlot:        p.LineupSlot,
+			}
+			if !p.AcquisitionDate.IsZero() {
+				acquiredAt := p.AcquisitionDate
+				entry.AcquiredAt = &acquiredAt
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// eligibilityChanges compares each rostered player's eligible positions with
+// those stored by the previous sync
+func eligibilityChanges(league *models.League, season, week, ownerTeam int, previous map[string][]string, entries []models.LeagueRosterEntry) []models.EligibilityChange {
+	var changes []models.EligibilityChange
+	now := time.Now()
+	for _, e := range entries {
+		before, ok := previous[e.PlayerID]
+		if !ok {
+			continue
+		}
+		added := missingFrom(before, e.EligiblePositions)
+		removed := missingFrom(e.EligiblePositions, before)
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+
+		changes = append(changes, models.EligibilityChange{
+			LeagueID:      league.ID,
+			LeagueName:    league.Name,
+			OwnerID:       league.UserID,
+			Season:        season,
+			Week:          week,
+			TeamID:        e.TeamID,
+			OwnerRostered: ownerTeam != 0 && e.TeamID == ownerTeam,
+			PlayerID:      e.PlayerID,
+			PlayerName:    e.PlayerName,
+			Added:         added,
+			Removed:       removed,
+			Positions:     e.EligiblePositions,
+			DetectedAt:    now,
+		})
+	}
+	return changes
+}
+
+// missingFrom returns the values in b that are not in a
+func missingFrom(a, b []string) []string {
+	have := make(map[string]bool, len(a))
+	for _, v := range a {
+		have[v] = true
+	}
+	missing := []string{}
+	for _, v := range b {
+		if !have[v] {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// leagueClient returns an ESPN client authenticated as the league owner,
+// along with the owner's SWID. Leagues whose owner has no stored cookies are
+// treated as public and return an empty SWID.
+func leagueClient(ctx context.Context, credService *services.CredentialsService, espnClient espn.Client, league *models.League) (espn.Client, string, error) {
+	swid, espnS2, err := credService.GetESPNCredentials(ctx, league.UserID)
+	if errors.Is(err, repositories.ErrLeagueAuthNotFound) {
+		return espnClient, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load ESPN credentials: %w", err)
+	}
+
+	return espnClient.WithAuthentication(swid, espnS2), swid, nil
+}
+
+// ownerRoster finds the roster of the team owned by swid, or nil if the
+// owner is unknown or has no team in the league
+func ownerRoster(teams []espn.Team, rosters []espn.Roster, swid string) *espn.Roster {
+	owner := normalizeSWID(swid)
+	if owner == "" {
+		return nil
+	}
+
+	for _, team := range teams {
+		if normalizeSWID(team.Owner.ID) != owner {
+			continue
+		}
+		for i := range rosters {
+			if rosters[i].TeamID == team.ID {
+				return &rosters[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// normalizeSWID strips the braces ESPN wraps SWIDs in, which are not always
+// present on stored cookies
+func normalizeSWID(swid string) string {
+	return strings.ToLower(strings.Trim(swid, "{} "))
+}
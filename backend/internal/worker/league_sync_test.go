@@ -0,0 +1,478 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/auth"
+	"github.com/nfl-analytics/backend/internal/eventbus"
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/nfl-analytics/backend/internal/repositories"
+	"github.com/nfl-analytics/backend/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockLeagueRepository for testing
+type MockLeagueRepository struct {
+	repositories.LeagueRepository
+	mu       sync.Mutex
+	leagues  []*models.League
+	lastSync map[string]time.Time
+}
+
+func (m *MockLeagueRepository) GetActiveLeagues(ctx context.Context) ([]*models.League, error) {
+	return m.leagues, nil
+}
+
+func (m *MockLeagueRepository) UpdateLastSync(ctx context.Context, id string, syncedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastSync[id] = syncedAt
+	return nil
+}
+
+// MockLeagueSyncRepository for testing
+type MockLeagueSyncRepository struct {
+	mu        sync.Mutex
+	snapshots map[string]interface{}
+	successes map[string]int
+	failures  map[string]error
+	boxScores []models.PlayerBoxScore
+}
+
+func NewMockLeagueSyncRepository() *MockLeagueSyncRepository {
+	return &MockLeagueSyncRepository{
+		snapshots: make(map[string]interface{}),
+		successes: make(map[string]int),
+		failures:  make(map[string]error),
+	}
+}
+
+func (m *MockLeagueSyncRepository) SaveSnapshot(ctx context.Context, leagueID, dataType string, week int, payload interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshots[leagueID+":"+dataType] = payload
+	return nil
+}
+
+func (m *MockLeagueSyncRepository) GetLatestSnapshot(ctx context.Context, leagueID, dataType string, dest interface{}) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	payload, ok := m.snapshots[leagueID+":"+dataType]
+	if !ok {
+		return false, nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, dest)
+}
+
+func (m *MockLeagueSyncRepository) ListSnapshots(ctx context.Context, leagueID, dataType string, since time.Time) ([]json.RawMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	payload, ok := m.snapshots[leagueID+":"+dataType]
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return []json.RawMessage{data}, nil
+}
+
+func (m *MockLeagueSyncRepository) RecordSuccess(ctx context.Context, leagueID string, syncedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.successes[leagueID]++
+	return nil
+}
+
+func (m *MockLeagueSyncRepository) RecordFailure(ctx context.Context, leagueID string, attemptedAt time.Time, syncErr error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures[leagueID] = syncErr
+	return nil
+}
+
+func (m *MockLeagueSyncRepository) GetRosteredBy(ctx context.Context, playerNames []string) (map[string][]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (m *MockLeagueSyncRepository) SaveBoxScores(ctx context.Context, scores []models.PlayerBoxScore) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.boxScores = append(m.boxScores, scores...)
+	return nil
+}
+
+func (m *MockLeagueSyncRepository) GetBoxScores(ctx context.Context, leagueID string, season, week int) ([]models.PlayerBoxScore, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.boxScores, nil
+}
+
+func (m *MockLeagueSyncRepository) GetStatus(ctx context.Context, leagueID string) (*models.LeagueSyncStatus, error) {
+	return nil, nil
+}
+
+// MockLeagueRosterRepository for testing
+type MockLeagueRosterRepository struct {
+	repositories.LeagueRosterRepository
+	saved       map[int][]models.LeagueRosterEntry
+	eligibility map[string][]string
+	changes     []models.EligibilityChange
+}
+
+func NewMockLeagueRosterRepository() *MockLeagueRosterRepository {
+	return &MockLeagueRosterRepository{
+		saved:       make(map[int][]models.LeagueRosterEntry),
+		eligibility: make(map[string][]string),
+	}
+}
+
+func (m *MockLeagueRosterRepository) SaveRosters(ctx context.Context, leagueID uuid.UUID, season, week int, entries []models.LeagueRosterEntry) error {
+	m.saved[week] = entries
+	for _, e := range entries {
+		m.eligibility[e.PlayerID] = e.EligiblePositions
+	}
+	return nil
+}
+
+func (m *MockLeagueRosterRepository) GetEligibility(ctx context.Context, leagueID string) (map[string][]string, error) {
+	eligibility := make(map[string][]string, len(m.eligibility))
+	for id, positions := range m.eligibility {
+		eligibility[id] = positions
+	}
+	return eligibility, nil
+}
+
+func (m *MockLeagueRosterRepository) SaveEligibilityChanges(ctx context.Context, changes []models.EligibilityChange) error {
+	m.changes = append(m.changes, changes...)
+	return nil
+}
+
+// MockLeagueAuthRepository for testing
+type MockLeagueAuthRepository struct {
+	auths map[uuid.UUID]*models.LeagueAuth
+}
+
+func (m *MockLeagueAuthRepository) Store(ctx context.Context, auth *models.LeagueAuth) error {
+	m.auths[auth.UserID] = auth
+	return nil
+}
+
+func (m *MockLeagueAuthRepository) GetByUserAndPlatform(ctx context.Context, userID uuid.UUID, platform string) (*models.LeagueAuth, error) {
+	auth, exists := m.auths[userID]
+	if !exists {
+		return nil, repositories.ErrLeagueAuthNotFound
+	}
+	return auth, nil
+}
+
+func (m *MockLeagueAuthRepository) Update(ctx context.Context, auth *models.LeagueAuth) error {
+	m.auths[auth.UserID] = auth
+	return nil
+}
+
+func (m *MockLeagueAuthRepository) Delete(ctx context.Context, userID uuid.UUID, platform string) error {
+	delete(m.auths, userID)
+	return nil
+}
+
+func (m *MockLeagueAuthRepository) GetAllByUser(ctx context.Context, userID uuid.UUID) ([]*models.LeagueAuth, error) {
+	return nil, nil
+}
+
+func (m *MockLeagueAuthRepository) MarkInvalid(ctx context.Context, userID uuid.UUID, platform, reason string, at time.Time) error {
+	if auth, ok := m.auths[userID]; ok && !auth.InvalidAt.Valid {
+		auth.InvalidAt = sql.NullTime{Time: at, Valid: true}
+		auth.InvalidReason = sql.NullString{String: reason, Valid: true}
+	}
+	return nil
+}
+
+func newTestWorker(t *testing.T, leagues []*models.League, client espn.Client) (*LeagueSyncWorker, *MockLeagueRepository, *MockLeagueSyncRepository, *services.CredentialsService) {
+	box, err := auth.NewSecretBox("test-key-exactly-32-bytes-long!!")
+	require.NoError(t, err)
+	credService := services.NewCredentialsService(
+		&MockLeagueAuthRepository{auths: make(map[uuid.UUID]*models.LeagueAuth)},
+		box,
+	)
+
+	leagueRepo := &MockLeagueRepository{leagues: leagues, lastSync: make(map[string]time.Time)}
+	syncRepo := NewMockLeagueSyncRepository()
+
+	return NewLeagueSyncWorker(leagueRepo, syncRepo, credService, client, time.Minute, 25), leagueRepo, syncRepo, credService
+}
+
+// testEspnS2 is long enough to pass credential validation
+const testEspnS2 = "AEBxvY3Kc6hPbEHKxvY3Kc6hPbEHKxvY3Kc6hPbEHKxvY3Kc6hPbEHKxvY3Kc6hPbEHK"
+
+func TestSyncAll(t *testing.T) {
+	league := &models.League{ID: uuid.New(), UserID: uuid.New(), Platform: "espn", ExternalID: "123456"}
+	yahoo := &models.League{ID: uuid.New(), UserID: uuid.New(), Platform: "yahoo", ExternalID: "999"}
+
+	client := espn.NewMockESPNClient()
+	w, leagueRepo, syncRepo, credService := newTestWorker(t, []*models.League{league, yahoo}, client)
+
+	swid := uuid.New().String()
+	require.NoError(t, credService.StoreESPNCredentials(context.Background(), league.UserID, swid, testEspnS2))
+	client.LeagueInfo.Teams[0].Owner.ID = "{" + strings.ToUpper(swid) + "}"
+	client.BoxScores = []espn.BoxScore{
+		{Week: 3, TeamID: 1, Players: []espn.BoxScorePlayer{{PlayerID: "1", PlayerName: "Old Week"}}},
+		{Week: 9, TeamID: 1, OpponentID: 2, Players: []espn.BoxScorePlayer{{PlayerID: "3918298", PlayerName: "Josh Allen", Points: 26.4}}},
+		{Week: 10, TeamID: 1, OpponentID: 2, Players: []espn.BoxScorePlayer{{PlayerID: "3918298", PlayerName: "Josh Allen", Points: 8.1}}},
+	}
+
+	err := w.SyncAll(context.Background())
+	require.NoError(t, err)
+
+	id := league.ID.String()
+	assert.Contains(t, syncRepo.snapshots, id+":"+SnapshotRosters)
+	assert.Contains(t, syncRepo.snapshots, id+":"+SnapshotMatchups)
+	assert.Contains(t, syncRepo.snapshots, id+":"+SnapshotTransactions)
+	require.Contains(t, syncRepo.snapshots, id+":"+SnapshotUserRoster)
+	assert.Equal(t, 1, syncRepo.snapshots[id+":"+SnapshotUserRoster].(*espn.Roster).TeamID)
+	assert.Equal(t, 1, syncRepo.successes[id])
+	assert.Contains(t, leagueRepo.lastSync, id)
+
+	// Box scores cover the current and previous week
+	require.Len(t, syncRepo.boxScores, 2)
+	assert.Equal(t, 9, syncRepo.boxScores[0].Week)
+	assert.Equal(t, 26.4, syncRepo.boxScores[0].Points)
+	assert.Equal(t, league.ID, syncRepo.boxScores[0].LeagueID)
+	assert.Equal(t, 10, syncRepo.boxScores[1].Week)
+
+	// The owner's cookies were used
+	assert.Equal(t, swid, client.SWID)
+	assert.Equal(t, testEspnS2, client.EspnS2)
+
+	// Non-ESPN leagues are skipped
+	assert.NotContains(t, leagueRepo.lastSync, yahoo.ID.String())
+}
+
+func TestSyncAll_RecordsPerLeagueErrors(t *testing.T) {
+	league := &models.League{ID: uuid.New(), UserID: uuid.New(), Platform: "espn", ExternalID: "123456"}
+
+	client := espn.NewMockESPNClient()
+	client.Error = espn.ErrUnauthorized
+	w, leagueRepo, syncRepo, _ := newTestWorker(t, []*models.League{league}, client)
+
+	err := w.SyncAll(context.Background())
+	require.NoError(t, err)
+
+	id := league.ID.String()
+	assert.True(t, errors.Is(syncRepo.failures[id], espn.ErrUnauthorized))
+	assert.Zero(t, syncRepo.successes[id])
+	assert.NotContains(t, leagueRepo.lastSync, id)
+}
+
+func TestSyncLeague_StoresRosters(t *testing.T) {
+	league := &models.League{ID: uuid.New(), UserID: uuid.New(), Platform: "espn", ExternalID: "123456"}
+
+	client := espn.NewMockESPNClient()
+	acquired := time.Date(2024, 9, 18, 12, 0, 0, 0, time.UTC)
+	client.Rosters = []espn.Roster{
+		{TeamID: 1, Players: []espn.RosterPlayer{
+			{PlayerID: "3918298", PlayerName: "Josh Allen", Position: "QB", Team: "BUF", LineupSlot: "QB"},
+			{PlayerID: "4362628", PlayerName: "Ja'Marr Chase", Position: "WR", Team: "CIN", Status: "QUESTIONABLE", LineupSlot: "BE", AcquisitionDate: acquired},
+		}},
+		{TeamID: 2, Players: []espn.RosterPlayer{
+			{PlayerID: "3116406", PlayerName: "Tyreek Hill", Position: "WR", Team: "MIA", LineupSlot: "WR"},
+		}},
+	}
+	w, _, _, _ := newTestWorker(t, []*models.League{league}, client)
+	rosterRepo := NewMockLeagueRosterRepository()
+	w.WithRosterStore(rosterRepo)
+
+	require.NoError(t, w.SyncLeague(context.Background(), league))
+
+	week := client.LeagueInfo.Status.CurrentWeek
+	require.Len(t, rosterRepo.saved[week], 3)
+	chase := rosterRepo.saved[week][1]
+	assert.Equal(t, 1, chase.TeamID)
+	assert.Equal(t, "QUESTIONABLE", chase.InjuryStatus)
+	require.NotNil(t, chase.AcquiredAt)
+	assert.Equal(t, acquired, *chase.AcquiredAt)
+	assert.Nil(t, rosterRepo.saved[week][0].AcquiredAt)
+	assert.Equal(t, 2, rosterRepo.saved[week][2].TeamID)
+}
+
+func TestSyncLeague_RecordsEligibilityChanges(t *testing.T) {
+	league := &models.League{ID: uuid.New(), UserID: uuid.New(), Name: "Dynasty", Platform: "espn", ExternalID: "123456"}
+
+	client := espn.NewMockESPNClient()
+	client.Rosters = []espn.Roster{
+		{TeamID: 1, Players: []espn.RosterPlayer{
+			{PlayerID: "4241478", PlayerName: "Hybrid Receiver", Position: "WR", EligibleSlots: []int{4, 5, 23, 20, 21}},
+		}},
+		{TeamID: 2, Players: []espn.RosterPlayer{
+			{PlayerID: "3116406", PlayerName: "Other Receiver", Position: "WR", EligibleSlots: []int{4, 23, 20, 21}},
+		}},
+	}
+	w, _, _, credService := newTestWorker(t, []*models.League{league}, client)
+	swid := uuid.New().String()
+	require.NoError(t, credService.StoreESPNCredentials(context.Background(), league.UserID, swid, testEspnS2))
+	client.LeagueInfo.Teams[0].Owner.ID = swid
+	rosterRepo := NewMockLeagueRosterRepository()
+	publisher := &recordingPublisher{}
+	w.WithRosterStore(rosterRepo).WithPublisher(publisher)
+
+	// Players seen for the first time are not changes
+	require.NoError(t, w.SyncLeague(context.Background(), league))
+	assert.Empty(t, rosterRepo.changes)
+	assert.Equal(t, []string{"WR"}, rosterRepo.eligibility["4241478"])
+
+	// ESPN adds TE eligibility
+	client.Rosters[0].Players[0].EligibleSlots = []int{4, 5, 6, 23, 20, 21}
+	client.Rosters[1].Players[0].EligibleSlots = []int{4, 6, 23, 20, 21}
+	require.NoError(t, w.SyncLeague(context.Background(), league))
+
+	require.Len(t, rosterRepo.changes, 2)
+	change := rosterRepo.changes[0]
+	assert.Equal(t, "Hybrid Receiver", change.PlayerName)
+	assert.Equal(t, []string{"TE"}, change.Added)
+	assert.Empty(t, change.Removed)
+	assert.Equal(t, []string{"WR", "TE"}, change.Positions)
+	assert.True(t, change.OwnerRostered)
+	assert.Equal(t, league.UserID, change.OwnerID)
+	assert.False(t, rosterRepo.changes[1].OwnerRostered)
+	assert.Contains(t, publisher.events, eventbus.TopicRosters+":"+models.EventEligibilityChanged)
+}
+
+func TestSyncAll_StopsUsingRejectedCookies(t *testing.T) {
+	league := &models.League{ID: uuid.New(), UserID: uuid.New(), Platform: "espn", ExternalID: "123456"}
+
+	client := espn.NewMockESPNClient()
+	client.Error = espn.ErrCookiesExpired
+	w, _, syncRepo, credService := newTestWorker(t, []*models.League{league}, client)
+	require.NoError(t, credService.StoreESPNCredentials(context.Background(), league.UserID, uuid.New().String(), testEspnS2))
+
+	require.NoError(t, w.SyncAll(context.Background()))
+	assert.True(t, errors.Is(syncRepo.failures[league.ID.String()], espn.ErrCookiesExpired))
+
+	health, err := credService.GetESPNCredentialHealth(context.Background(), league.UserID)
+	require.NoError(t, err)
+	assert.False(t, health.Valid)
+	require.NotNil(t, health.InvalidAt)
+
+	// Later syncs skip the league instead of calling ESPN with dead cookies
+	client.Error = nil
+	delete(syncRepo.failures, league.ID.String())
+	require.NoError(t, w.SyncAll(context.Background()))
+	assert.Empty(t, syncRepo.failures)
+	assert.Zero(t, syncRepo.successes[league.ID.String()])
+
+	// New cookies resume syncing
+	require.NoError(t, credService.UpdateESPNCredentials(context.Background(), league.UserID, uuid.New().String(), testEspnS2))
+	require.NoError(t, w.SyncAll(context.Background()))
+	assert.Equal(t, 1, syncRepo.successes[league.ID.String()])
+}
+
+type fixedBudget struct {
+	calls map[string]int64
+	limit int64
+}
+
+func (b *fixedBudget) Allow(ctx context.Context, espnLeagueID string) (bool, int64, error) {
+	calls := b.calls[espnLeagueID]
+	return calls < b.limit, calls, nil
+}
+
+func (b *fixedBudget) Limit() int64 { return b.limit }
+
+func TestSyncAll_DefersLeaguesOverBudget(t *testing.T) {
+	busy := &models.League{ID: uuid.New(), UserID: uuid.New(), Platform: "espn", ExternalID: "111"}
+	quiet := &models.League{ID: uuid.New(), UserID: uuid.New(), Platform: "espn", ExternalID: "222"}
+
+	client := espn.NewMockESPNClient()
+	w, leagueRepo, syncRepo, _ := newTestWorker(t, []*models.League{busy, quiet}, client)
+	w.WithBudget(&fixedBudget{calls: map[string]int64{"111": 300, "222": 12}, limit: 300})
+
+	require.NoError(t, w.SyncAll(context.Background()))
+
+	assert.NotContains(t, leagueRepo.lastSync, busy.ID.String())
+	assert.NotContains(t, syncRepo.failures, busy.ID.String())
+	assert.Contains(t, leagueRepo.lastSync, quiet.ID.String())
+}
+
+type recordingPublisher struct {
+	events []string
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, topic, key, eventType string, payload interface{}) error {
+	p.events = append(p.events, topic+":"+eventType)
+	return nil
+}
+
+func TestSyncLeague_PublishesTransactionChanges(t *testing.T) {
+	league := &models.League{ID: uuid.New(), UserID: uuid.New(), Platform: "espn", ExternalID: "123456"}
+
+	client := espn.NewMockESPNClient()
+	client.Transactions = []espn.Transaction{
+		{ID: "t1", Type: "DROP", Status: "EXECUTED", ProposingTeamID: 1, Players: []string{"Old Drop"}},
+		{ID: "t2", Type: "TRADE", Status: "PENDING", ProposingTeamID: 1, AcceptingTeamID: 2, Players: []string{"A", "B"}},
+	}
+	w, _, _, credService := newTestWorker(t, []*models.League{league}, client)
+	require.NoError(t, credService.StoreESPNCredentials(context.Background(), league.UserID, uuid.New().String(), testEspnS2))
+	publisher := &recordingPublisher{}
+	w.WithPublisher(publisher)
+
+	// The first sync only records what is already there
+	require.NoError(t, w.SyncLeague(context.Background(), league))
+	assert.NotContains(t, publisher.events, eventbus.TopicTransactions+":"+models.TransactionPlayerDropped)
+
+	client.Transactions = []espn.Transaction{
+		{ID: "t1", Type: "DROP", Status: "EXECUTED", ProposingTeamID: 1, Players: []string{"Old Drop"}},
+		{ID: "t2", Type: "TRADE", Status: "EXECUTED", ProposingTeamID: 1, AcceptingTeamID: 2, Players: []string{"A", "B"}},
+		{ID: "t3", Type: "DROP", Status: "EXECUTED", ProposingTeamID: 3, Players: []string{"New Drop"}},
+		{ID: "t4", Type: "ADD", Status: "EXECUTED", ProposingTeamID: 3, Players: []string{"New Add"}},
+	}
+	publisher.events = nil
+	require.NoError(t, w.SyncLeague(context.Background(), league))
+
+	var transactionEvents []string
+	for _, e := range publisher.events {
+		if strings.HasPrefix(e, eventbus.TopicTransactions+":") {
+			transactionEvents = append(transactionEvents, e)
+		}
+	}
+	assert.Equal(t, []string{
+		eventbus.TopicTransactions + ":" + models.TransactionTradeProcessed,
+		eventbus.TopicTransactions + ":" + models.TransactionPlayerDropped,
+	}, transactionEvents)
+}
+
+func TestSyncAll_SyncsLeaguesConcurrently(t *testing.T) {
+	var leagues []*models.League
+	for i := 0; i < 5; i++ {
+		leagues = append(leagues, &models.League{ID: uuid.New(), UserID: uuid.New(), Platform: "espn", ExternalID: "123456"})
+	}
+
+	client := espn.NewMockESPNClient()
+	client.Matchups = []espn.Matchup{{ID: "m1", Week: 10}}
+	client.Transactions = []espn.Transaction{}
+	w, leagueRepo, syncRepo, _ := newTestWorker(t, leagues, client)
+	w.WithConcurrency(3)
+
+	require.NoError(t, w.SyncAll(context.Background()))
+
+	for _, league := range leagues {
+		id := league.ID.String()
+		assert.Equal(t, 1, syncRepo.successes[id])
+		assert.Contains(t, leagueRepo.lastSync, id)
+		assert.Contains(t, syncRepo.snapshots, id+":"+SnapshotMatchups)
+	}
+}
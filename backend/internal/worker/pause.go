@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// pausePollInterval is how often a scheduled run deferred by a pause
+// rechecks whether it can go ahead
+const pausePollInterval = time.Minute
+
+// Pauser reports whether background jobs should hold off, such as while the
+// API is in maintenance mode
+type Pauser interface {
+	Paused(ctx context.Context) bool
+}
+
+// Throttler stretches background job intervals, such as during draft-day
+// surge mode so live drafts get the database and ESPN capacity
+type Throttler interface {
+	IntervalMultiplier(ctx context.Context) int
+}
+
+// RequestBudget reports whether an ESPN league has ESPN requests left this
+// hour, so one league cannot use up the shared rate limit
+type RequestBudget interface {
+	Allow(ctx context.Context, espnLeagueID string) (bool, int64, error)
+	Limit() int64
+}
+
+// nextInterval returns interval stretched by t's current multiplier
+func nextInterval(ctx context.Context, t Throttler, interval time.Duration) time.Duration {
+	if t == nil {
+		return interval
+	}
+	if m := t.IntervalMultiplier(ctx); m > 1 {
+		return interval * time.Duration(m)
+	}
+	return interval
+}
+
+// sleep waits for d and returns false if the context is cancelled first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// isPaused reports whether p is set and paused
+func isPaused(ctx context.Context, p Pauser) bool {
+	return p != nil && p.Paused(ctx)
+}
+
+// waitUntilResumed blocks while p is paused. It returns false if the context
+// is cancelled first.
+func waitUntilResumed(ctx context.Context, p Pauser, job string) bool {
+	if !isPaused(ctx, p) {
+		return true
+	}
+
+	log.Printf("%s deferred: background jobs are paused", job)
+	ticker := time.NewTicker(pausePollInterval)
+	defer ticker.Stop()
+
+	for isPaused(ctx, p) {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+	return true
+}
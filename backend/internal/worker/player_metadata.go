@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/nflverse"
+	"github.com/nfl-analytics/backend/internal/projections"
+)
+
+// PlayerMetadataWorker refreshes player teams, statuses and positions every
+// night so trades and cuts reach projections without waiting for a full
+// ingest
+type PlayerMetadataWorker struct {
+	refresher  *nflverse.Refresher
+	hour       int
+	location   *time.Location
+	pauser     Pauser
+	heartbeats *Heartbeats
+}
+
+// NewPlayerMetadataWorker creates a new player metadata worker that runs
+// daily at hour in location
+func NewPlayerMetadataWorker(refresher *nflverse.Refresher, hour int, location *time.Location) *PlayerMetadataWorker {
+	return &PlayerMetadataWorker{
+		refresher: refresher,
+		hour:      hour,
+		location:  location,
+	}
+}
+
+// WithPauser skips scheduled runs while p is paused
+func (w *PlayerMetadataWorker) WithPauser(p Pauser) *PlayerMetadataWorker {
+	w.pauser = p
+	return w
+}
+
+// WithHeartbeats reports the worker's ticks to h
+func (w *PlayerMetadataWorker) WithHeartbeats(h *Heartbeats) *PlayerMetadataWorker {
+	w.heartbeats = h
+	return w
+}
+
+// Run waits for each nightly run and refreshes the current season until the
+// context is cancelled
+func (w *PlayerMetadataWorker) Run(ctx context.Context) {
+	for {
+		next := nextDailyRun(time.Now(), w.hour, w.location)
+		log.Printf("Next player metadata refresh at %s", next.Format(time.RFC1123))
+		w.heartbeats.Tick(playerMetadataJob, time.Until(next))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// The next night's run catches up on anything a paused one missed
+		if isPaused(ctx, w.pauser) {
+			log.Printf("Player metadata refresh skipped: background jobs are paused")
+			continue
+		}
+
+		w.heartbeats.Start(playerMetadataJob)
+		if _, err := w.refresher.Refresh(ctx, projections.CurrentSeason(time.Now())); err != nil {
+			log.Printf("Player metadata refresh failed: %v", err)
+		}
+		w.heartbeats.Done(playerMetadataJob)
+	}
+}
+
+// nextDailyRun returns the first time after now that falls at hour in loc
+func nextDailyRun(now time.Time, hour int, loc *time.Location) time.Time {
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, loc)
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
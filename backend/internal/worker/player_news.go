@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// PlayerNewsWorker periodically pulls the injury report and news for
+// injured players
+type PlayerNewsWorker struct {
+	newsService services.PlayerNewsService
+	interval    time.Duration
+	throttler   Throttler
+	pauser      Pauser
+	heartbeats  *Heartbeats
+}
+
+// NewPlayerNewsWorker creates a new player news worker
+func NewPlayerNewsWorker(newsService services.PlayerNewsService, interval time.Duration) *PlayerNewsWorker {
+	return &PlayerNewsWorker{
+		newsService: newsService,
+		interval:    interval,
+	}
+}
+
+// WithPauser skips syncs while p is paused
+func (w *PlayerNewsWorker) WithPauser(p Pauser) *PlayerNewsWorker {
+	w.pauser = p
+	return w
+}
+
+// WithThrottler stretches the interval between syncs while t asks for it
+func (w *PlayerNewsWorker) WithThrottler(t Throttler) *PlayerNewsWorker {
+	w.throttler = t
+	return w
+}
+
+// WithHeartbeats reports the worker's ticks to h
+func (w *PlayerNewsWorker) WithHeartbeats(h *Heartbeats) *PlayerNewsWorker {
+	w.heartbeats = h
+	return w
+}
+
+// Run syncs immediately and then on every interval until the context is cancelled
+func (w *PlayerNewsWorker) Run(ctx context.Context) {
+	for {
+		w.heartbeats.Start(playerNewsJob)
+		if isPaused(ctx, w.pauser) {
+			log.Printf("Player news sync skipped: background jobs are paused")
+		} else if injured, err := w.newsService.SyncInjuries(ctx); err != nil {
+			log.Printf("Player news sync failed: %v", err)
+		} else {
+			log.Printf("Player news sync complete: %d injured players", injured)
+		}
+		w.heartbeats.Done(playerNewsJob)
+
+		interval := nextInterval(ctx, w.throttler, w.interval)
+		w.heartbeats.Tick(playerNewsJob, interval)
+		if !sleep(ctx, interval) {
+			return
+		}
+	}
+}
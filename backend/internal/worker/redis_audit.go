@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/redisaudit"
+)
+
+// RedisAuditWorker periodically audits Redis memory per key namespace and
+// sets expiries on app keys that are missing one
+type RedisAuditWorker struct {
+	auditor    *redisaudit.Auditor
+	interval   time.Duration
+	throttler  Throttler
+	heartbeats *Heartbeats
+}
+
+// NewRedisAuditWorker creates a new Redis audit worker
+func NewRedisAuditWorker(auditor *redisaudit.Auditor, interval time.Duration) *RedisAuditWorker {
+	return &RedisAuditWorker{
+		auditor:  auditor,
+		interval: interval,
+	}
+}
+
+// WithThrottler stretches the interval between audits while t asks for it
+func (w *RedisAuditWorker) WithThrottler(t Throttler) *RedisAuditWorker {
+	w.throttler = t
+	return w
+}
+
+// WithHeartbeats reports the worker's ticks to h
+func (w *RedisAuditWorker) WithHeartbeats(h *Heartbeats) *RedisAuditWorker {
+	w.heartbeats = h
+	return w
+}
+
+// Run audits immediately and then on every interval until the context is cancelled
+func (w *RedisAuditWorker) Run(ctx context.Context) {
+	for {
+		w.heartbeats.Start(redisAuditJob)
+		if report, err := w.auditor.Run(ctx); err != nil {
+			log.Printf("Redis audit failed: %v", err)
+		} else {
+			log.Printf("Redis audit complete: %d keys, %d bytes, %d expiries set in %dms",
+				report.TotalKeys, report.TotalBytes, report.ExpiriesSet, report.DurationMS)
+		}
+		w.heartbeats.Done(redisAuditJob)
+
+		interval := nextInterval(ctx, w.throttler, w.interval)
+		w.heartbeats.Tick(redisAuditJob, interval)
+		if !sleep(ctx, interval) {
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/retention"
+)
+
+// RetentionWorker prunes data past its retention once a day
+type RetentionWorker struct {
+	pruner     *retention.Pruner
+	hour       int
+	pauser     Pauser
+	heartbeats *Heartbeats
+}
+
+// NewRetentionWorker creates a new retention worker that runs daily at hour
+// UTC
+func NewRetentionWorker(pruner *retention.Pruner, hour int) *RetentionWorker {
+	return &RetentionWorker{
+		pruner: pruner,
+		hour:   hour,
+	}
+}
+
+// WithPauser skips scheduled runs while p is paused
+func (w *RetentionWorker) WithPauser(p Pauser) *RetentionWorker {
+	w.pauser = p
+	return w
+}
+
+// WithHeartbeats reports the worker's ticks to h
+func (w *RetentionWorker) WithHeartbeats(h *Heartbeats) *RetentionWorker {
+	w.heartbeats = h
+	return w
+}
+
+// Run waits for each daily run until the context is cancelled
+func (w *RetentionWorker) Run(ctx context.Context) {
+	for {
+		next := nextDailyRun(time.Now(), w.hour, time.UTC)
+		w.heartbeats.Tick(retentionJob, time.Until(next))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Rows skipped today are still expired tomorrow
+		if isPaused(ctx, w.pauser) {
+			log.Printf("Retention job skipped: background jobs are paused")
+			continue
+		}
+
+		w.heartbeats.Start(retentionJob)
+		report, err := w.pruner.Run(ctx)
+		w.heartbeats.Done(retentionJob)
+		if err != nil {
+			log.Printf("Retention job failed: %v", err)
+		}
+		log.Printf("Retention job complete: %d rows removed from %d tables in %s",
+			report.Rows, len(report.Results), report.FinishedAt.Sub(report.StartedAt))
+	}
+}
@@ -0,0 +1,221 @@
+package worker
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// SyncPriority orders league syncs waiting in a SyncQueue
+type SyncPriority int
+
+const (
+	// PriorityScheduled is the interval refresh of every active league
+	PriorityScheduled SyncPriority = iota
+	// PriorityUser is a sync a user asked for, served before scheduled work
+	PriorityUser
+)
+
+// ErrSyncCooldown is returned when a user asks for another sync too soon
+var ErrSyncCooldown = errors.New("sync requested too recently")
+
+// SyncRequest is the outcome of a user asking for a league sync
+type SyncRequest struct {
+	// Status is "queued", or "syncing" when the league is already being
+	// synced and the request was not needed
+	Status string `json:"status"`
+	// Position is how many syncs will run before this one, counting from 1
+	Position int `json:"position,omitempty"`
+	// RetryAfter is set with ErrSyncCooldown
+	RetryAfter time.Duration `json:"-"`
+}
+
+// syncJob is a league waiting to be synced
+type syncJob struct {
+	league   *models.League
+	priority SyncPriority
+	seq      uint64 // Orders jobs of the same priority first in, first out
+	index    int    // Position in the heap
+}
+
+// syncJobs is a heap of jobs, highest priority first
+type syncJobs []*syncJob
+
+func (h syncJobs) Len() int { return len(h) }
+func (h syncJobs) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+func (h syncJobs) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *syncJobs) Push(x interface{}) {
+	job := x.(*syncJob)
+	job.index = len(*h)
+	*h = append(*h, job)
+}
+func (h *syncJobs) Pop() interface{} {
+	old := *h
+	job := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return job
+}
+
+// SyncQueue holds league syncs waiting for the sync worker. A league is
+// queued at most once; asking for it again at a higher priority moves it
+// ahead. Users may ask for one sync per cooldown.
+type SyncQueue struct {
+	mu       sync.Mutex
+	jobs     syncJobs
+	queued   map[uuid.UUID]*syncJob
+	running  map[uuid.UUID]bool
+	lastAsk  map[uuid.UUID]time.Time
+	cooldown time.Duration
+	seq      uint64
+	ready    chan struct{}
+	now      func() time.Time
+}
+
+// NewSyncQueue creates an empty queue allowing each user one sync request
+// per cooldown
+func NewSyncQueue(cooldown time.Duration) *SyncQueue {
+	return &SyncQueue{
+		queued:   make(map[uuid.UUID]*syncJob),
+		running:  make(map[uuid.UUID]bool),
+		lastAsk:  make(map[uuid.UUID]time.Time),
+		cooldown: cooldown,
+		ready:    make(chan struct{}, 1),
+		now:      time.Now,
+	}
+}
+
+// Push queues a league, or raises the priority of its queued sync. Leagues
+// being synced are not queued again. Returns the job's position.
+func (q *SyncQueue) Push(league *models.League, priority SyncPriority) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.push(league, priority)
+}
+
+// RequestSync queues a user's sync ahead of scheduled work, unless the user
+// asked for one within the cooldown
+func (q *SyncQueue) RequestSync(userID uuid.UUID, league *models.League) (*SyncRequest, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	if last, ok := q.lastAsk[userID]; ok && now.Sub(last) < q.cooldown {
+		return &SyncRequest{RetryAfter: q.cooldown - now.Sub(last)}, ErrSyncCooldown
+	}
+	q.lastAsk[userID] = now
+	q.forgetAsks(now)
+
+	if q.running[league.ID] {
+		return &SyncRequest{Status: "syncing"}, nil
+	}
+	return &SyncRequest{Status: "queued", Position: q.push(league, PriorityUser)}, nil
+}
+
+// Pop waits for the next league to sync. Call Done with its ID once synced.
+func (q *SyncQueue) Pop(ctx context.Context) (*models.League, SyncPriority, error) {
+	for {
+		q.mu.Lock()
+		if len(q.jobs) > 0 {
+			job := heap.Pop(&q.jobs).(*syncJob)
+			delete(q.queued, job.league.ID)
+			q.running[job.league.ID] = true
+			if len(q.jobs) > 0 {
+				q.signal()
+			}
+			q.mu.Unlock()
+			return job.league, job.priority, nil
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		case <-q.ready:
+		}
+	}
+}
+
+// Done marks a league's sync finished so it can be queued again
+func (q *SyncQueue) Done(leagueID uuid.UUID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.running, leagueID)
+}
+
+// Len returns how many syncs are waiting
+func (q *SyncQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.jobs)
+}
+
+// push queues or promotes a league's sync. Callers hold mu.
+func (q *SyncQueue) push(league *models.League, priority SyncPriority) int {
+	if q.running[league.ID] && priority == PriorityScheduled {
+		return 0
+	}
+
+	job, ok := q.queued[league.ID]
+	switch {
+	case !ok:
+		q.seq++
+		job = &syncJob{league: league, priority: priority, seq: q.seq}
+		heap.Push(&q.jobs, job)
+		q.queued[league.ID] = job
+		q.signal()
+	case priority > job.priority:
+		// Moves behind other jobs already at the new priority
+		q.seq++
+		job.priority, job.seq = priority, q.seq
+		heap.Fix(&q.jobs, job.index)
+	}
+	return q.position(job)
+}
+
+// position counts the jobs that will run before job, plus one. Callers
+// hold mu.
+func (q *SyncQueue) position(job *syncJob) int {
+	position := 1
+	for _, other := range q.jobs {
+		if other != job && q.jobs.Less(other.index, job.index) {
+			position++
+		}
+	}
+	return position
+}
+
+// signal wakes a waiting Pop. Callers hold mu.
+func (q *SyncQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// forgetAsks drops users whose cooldown has passed so the map does not
+// grow with every user who ever synced. Callers hold mu.
+func (q *SyncQueue) forgetAsks(now time.Time) {
+	if len(q.lastAsk) < 10000 {
+		return
+	}
+	for userID, last := range q.lastAsk {
+		if now.Sub(last) >= q.cooldown {
+			delete(q.lastAsk, userID)
+		}
+	}
+}
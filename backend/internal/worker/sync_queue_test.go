@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nfl-analytics/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncQueue_UserSyncsGoFirst(t *testing.T) {
+	q := NewSyncQueue(time.Minute)
+	a := &models.League{ID: uuid.New()}
+	b := &models.League{ID: uuid.New()}
+	c := &models.League{ID: uuid.New()}
+
+	assert.Equal(t, 1, q.Push(a, PriorityScheduled))
+	assert.Equal(t, 2, q.Push(b, PriorityScheduled))
+	// Queued again at the same priority keeps its place
+	assert.Equal(t, 1, q.Push(a, PriorityScheduled))
+
+	request, err := q.RequestSync(uuid.New(), c)
+	require.NoError(t, err)
+	assert.Equal(t, &SyncRequest{Status: "queued", Position: 1}, request)
+
+	// A queued league is promoted rather than queued twice
+	request, err = q.RequestSync(uuid.New(), b)
+	require.NoError(t, err)
+	assert.Equal(t, 2, request.Position)
+	assert.Equal(t, 3, q.Len())
+
+	ctx := context.Background()
+	var order []uuid.UUID
+	for i := 0; i < 3; i++ {
+		league, _, err := q.Pop(ctx)
+		require.NoError(t, err)
+		order = append(order, league.ID)
+	}
+	assert.Equal(t, []uuid.UUID{c.ID, b.ID, a.ID}, order)
+}
+
+func TestSyncQueue_RunningLeagues(t *testing.T) {
+	q := NewSyncQueue(0)
+	league := &models.League{ID: uuid.New()}
+	q.Push(league, PriorityScheduled)
+
+	_, _, err := q.Pop(context.Background())
+	require.NoError(t, err)
+
+	// Not queued again while it syncs
+	assert.Zero(t, q.Push(league, PriorityScheduled))
+	request, err := q.RequestSync(uuid.New(), league)
+	require.NoError(t, err)
+	assert.Equal(t, "syncing", request.Status)
+	assert.Zero(t, q.Len())
+
+	q.Done(league.ID)
+	assert.Equal(t, 1, q.Push(league, PriorityScheduled))
+}
+
+func TestSyncQueue_Cooldown(t *testing.T) {
+	q := NewSyncQueue(5 * time.Minute)
+	now := time.Date(2024, 10, 6, 12, 0, 0, 0, time.UTC)
+	q.now = func() time.Time { return now }
+	userID := uuid.New()
+
+	_, err := q.RequestSync(userID, &models.League{ID: uuid.New()})
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	request, err := q.RequestSync(userID, &models.League{ID: uuid.New()})
+	assert.ErrorIs(t, err, ErrSyncCooldown)
+	assert.Equal(t, 3*time.Minute, request.RetryAfter)
+
+	// Other users are not held back
+	_, err = q.RequestSync(uuid.New(), &models.League{ID: uuid.New()})
+	assert.NoError(t, err)
+
+	now = now.Add(3 * time.Minute)
+	_, err = q.RequestSync(userID, &models.League{ID: uuid.New()})
+	assert.NoError(t, err)
+}
+
+func TestSyncQueue_PopWaits(t *testing.T) {
+	q := NewSyncQueue(0)
+	league := &models.League{ID: uuid.New()}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Push(league, PriorityUser)
+	}()
+	got, priority, err := q.Pop(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, league.ID, got.ID)
+	assert.Equal(t, PriorityUser, priority)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = q.Pop(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
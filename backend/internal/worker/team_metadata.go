@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nfl-analytics/backend/internal/services"
+)
+
+// TeamMetadataWorker keeps the NFL team directory loaded and refreshes it
+// from ESPN on an interval, weekly by default
+type TeamMetadataWorker struct {
+	teams      services.TeamMetadataService
+	interval   time.Duration
+	pauser     Pauser
+	heartbeats *Heartbeats
+}
+
+// NewTeamMetadataWorker creates a new team metadata worker
+func NewTeamMetadataWorker(teams services.TeamMetadataService, interval time.Duration) *TeamMetadataWorker {
+	return &TeamMetadataWorker{
+		teams:    teams,
+		interval: interval,
+	}
+}
+
+// WithPauser skips refreshes while p is paused
+func (w *TeamMetadataWorker) WithPauser(p Pauser) *TeamMetadataWorker {
+	w.pauser = p
+	return w
+}
+
+// WithHeartbeats reports the worker's ticks to h
+func (w *TeamMetadataWorker) WithHeartbeats(h *Heartbeats) *TeamMetadataWorker {
+	w.heartbeats = h
+	return w
+}
+
+// Run loads the directory immediately, from the cache when it can, and
+// refreshes it on every interval until the context is cancelled
+func (w *TeamMetadataWorker) Run(ctx context.Context) {
+	if count, err := w.teams.Load(ctx); err != nil {
+		log.Printf("NFL team metadata load failed: %v", err)
+	} else {
+		log.Printf("NFL team metadata loaded: %d teams", count)
+	}
+
+	w.heartbeats.Tick(teamMetadataJob, w.interval)
+	for sleep(ctx, w.interval) {
+		if isPaused(ctx, w.pauser) {
+			log.Printf("NFL team metadata refresh skipped: background jobs are paused")
+		} else if count, err := w.teams.Refresh(ctx); err != nil {
+			log.Printf("NFL team metadata refresh failed: %v", err)
+		} else {
+			log.Printf("NFL team metadata refreshed: %d teams", count)
+		}
+		w.heartbeats.Tick(teamMetadataJob, w.interval)
+	}
+}
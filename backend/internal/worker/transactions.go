@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"strings"
+
+	"github.com/nfl-analytics/backend/internal/integrations/espn"
+	"github.com/nfl-analytics/backend/internal/models"
+)
+
+// diffTransactions compares freshly fetched transactions with the ones
+// stored by the previous sync and returns the changes worth notifying
+// about: drops that were not there before, and trades that were not there
+// or have since moved to executed. A league without a previous snapshot
+// yields nothing, so connecting a league does not replay its history.
+func diffTransactions(league *models.League, previous, current []espn.Transaction, hasPrevious bool) []models.TransactionChange {
+	if !hasPrevious {
+		return nil
+	}
+
+	seen := make(map[string]espn.Transaction, len(previous))
+	for _, t := range previous {
+		seen[t.ID] = t
+	}
+
+	var changes []models.TransactionChange
+	for _, t := range current {
+		before, existed := seen[t.ID]
+
+		var kind string
+		switch strings.ToUpper(t.Type) {
+		case "DROP":
+			if !existed {
+				kind = models.TransactionPlayerDropped
+			}
+		case "TRADE":
+			if isExecuted(t.Status) && (!existed || !isExecuted(before.Status)) {
+				kind = models.TransactionTradeProcessed
+			}
+		}
+		if kind == "" {
+			continue
+		}
+
+		teamIDs := []int{t.ProposingTeamID}
+		if t.AcceptingTeamID != 0 {
+			teamIDs = append(teamIDs, t.AcceptingTeamID)
+		}
+		changes = append(changes, models.TransactionChange{
+			Kind:          kind,
+			LeagueID:      league.ID,
+			LeagueName:    league.Name,
+			OwnerID:       league.UserID,
+			TransactionID: t.ID,
+			TeamIDs:       teamIDs,
+			Players:       t.Players,
+			ProcessDate:   t.ProcessDate,
+		})
+	}
+
+	return changes
+}
+
+func isExecuted(status string) bool {
+	return strings.EqualFold(status, "EXECUTED")
+}
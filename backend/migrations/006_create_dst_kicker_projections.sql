@@ -0,0 +1,56 @@
+-- Create DST and kicker projection tables
+-- Migration: 006_create_dst_kicker_projections.sql
+
+-- Silver: Team defense projections per source
+CREATE TABLE IF NOT EXISTS silver.dst_projections (
+    id SERIAL PRIMARY KEY,
+    team VARCHAR(10) NOT NULL,
+    opponent VARCHAR(10),
+    week INTEGER NOT NULL,
+    season INTEGER NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    -- Points-allowed model
+    proj_points_allowed DECIMAL(5,2),
+    proj_yards_allowed DECIMAL(6,2),
+    -- Splash plays
+    proj_sacks DECIMAL(4,2),
+    proj_interceptions DECIMAL(4,2),
+    proj_fumble_recoveries DECIMAL(4,2),
+    proj_safeties DECIMAL(4,2),
+    proj_defensive_tds DECIMAL(4,2),
+    proj_return_tds DECIMAL(4,2),
+    -- Fantasy points
+    fantasy_points DECIMAL(5,2),
+    processed_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(team, season, week, source)
+);
+
+-- Silver: Kicker projections per source
+CREATE TABLE IF NOT EXISTS silver.kicker_projections (
+    id SERIAL PRIMARY KEY,
+    player_id VARCHAR(50),
+    player_name VARCHAR(255) NOT NULL,
+    team VARCHAR(10),
+    opponent VARCHAR(10),
+    week INTEGER NOT NULL,
+    season INTEGER NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    -- Field goal attempt projections by distance
+    proj_fg_attempts DECIMAL(4,2),
+    proj_fg_made_0_39 DECIMAL(4,2),
+    proj_fg_made_40_49 DECIMAL(4,2),
+    proj_fg_made_50_plus DECIMAL(4,2),
+    proj_fg_missed DECIMAL(4,2),
+    proj_xp_attempts DECIMAL(4,2),
+    proj_xp_made DECIMAL(4,2),
+    -- Fantasy points
+    fantasy_points DECIMAL(5,2),
+    processed_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(player_name, season, week, source)
+);
+
+CREATE INDEX idx_silver_dst_team_week ON silver.dst_projections(team, season, week);
+CREATE INDEX idx_silver_kicker_player_week ON silver.kicker_projections(player_name, season, week);
+
+COMMENT ON TABLE silver.dst_projections IS 'Team defense projections with points-allowed model inputs';
+COMMENT ON TABLE silver.kicker_projections IS 'Kicker projections with field goal attempts by distance';
@@ -0,0 +1,29 @@
+-- Create league sync tables
+-- Migration: 007_create_league_sync_tables.sql
+
+-- Latest synced platform data per league
+CREATE TABLE IF NOT EXISTS league_sync_snapshots (
+    id SERIAL PRIMARY KEY,
+    league_id VARCHAR(36) NOT NULL,
+    data_type VARCHAR(50) NOT NULL, -- 'rosters', 'matchups', 'transactions'
+    week INTEGER NOT NULL DEFAULT 0,
+    payload JSONB NOT NULL,
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(league_id, data_type, week)
+);
+
+-- Per-league sync health
+CREATE TABLE IF NOT EXISTS league_sync_status (
+    league_id VARCHAR(36) PRIMARY KEY,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_success_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_league_sync_snapshots_league ON league_sync_snapshots(league_id, data_type);
+CREATE INDEX idx_league_sync_status_failures ON league_sync_status(consecutive_failures);
+
+COMMENT ON TABLE league_sync_snapshots IS 'Rosters, matchups and transactions pulled by the league sync worker';
+COMMENT ON TABLE league_sync_status IS 'Last sync attempt and error per league';
@@ -0,0 +1,22 @@
+-- Create game weather table
+-- Migration: 008_create_game_weather.sql
+
+-- Silver: Forecast conditions per game, used by the weather projection modifier
+CREATE TABLE IF NOT EXISTS silver.game_weather (
+    id SERIAL PRIMARY KEY,
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    home_team VARCHAR(10) NOT NULL,
+    away_team VARCHAR(10) NOT NULL,
+    temperature_f DECIMAL(5,2),
+    wind_mph DECIMAL(5,2),
+    precipitation_chance DECIMAL(5,2), -- 0-100
+    is_dome BOOLEAN DEFAULT FALSE,
+    source VARCHAR(50) NOT NULL,
+    fetched_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(season, week, home_team)
+);
+
+CREATE INDEX idx_silver_weather_week ON silver.game_weather(season, week);
+
+COMMENT ON TABLE silver.game_weather IS 'Game-day weather forecasts for projection modifiers';
@@ -0,0 +1,48 @@
+-- Create schedule, weekly actuals and modifier backtest tables
+-- Migration: 009_create_schedule_and_backtests.sql
+
+-- Silver: NFL schedule with kickoff times, used for rest calculations
+CREATE TABLE IF NOT EXISTS silver.nfl_schedule (
+    id SERIAL PRIMARY KEY,
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    home_team VARCHAR(10) NOT NULL,
+    away_team VARCHAR(10) NOT NULL,
+    kickoff_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE(season, week, home_team)
+);
+
+-- Gold: Actual weekly fantasy points, the ground truth for backtests
+CREATE TABLE IF NOT EXISTS gold.player_weekly_actuals (
+    id SERIAL PRIMARY KEY,
+    player_name VARCHAR(255) NOT NULL,
+    position VARCHAR(10),
+    team VARCHAR(10),
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    fantasy_points_ppr DECIMAL(5,2),
+    fantasy_points_standard DECIMAL(5,2),
+    loaded_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(player_name, season, week)
+);
+
+-- Gold: Historical accuracy of each projection modifier
+CREATE TABLE IF NOT EXISTS gold.modifier_backtests (
+    id SERIAL PRIMARY KEY,
+    modifier VARCHAR(50) NOT NULL,
+    season INTEGER NOT NULL,
+    week_start INTEGER NOT NULL,
+    week_end INTEGER NOT NULL,
+    adjusted_count INTEGER NOT NULL, -- projections the modifier changed
+    base_mae DECIMAL(6,3),           -- mean absolute error without the modifier
+    adjusted_mae DECIMAL(6,3),       -- mean absolute error with the modifier
+    run_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_silver_schedule_week ON silver.nfl_schedule(season, week);
+CREATE INDEX idx_gold_actuals_week ON gold.player_weekly_actuals(season, week);
+CREATE INDEX idx_gold_backtests_modifier ON gold.modifier_backtests(modifier, run_at);
+
+COMMENT ON TABLE silver.nfl_schedule IS 'NFL schedule with kickoff times';
+COMMENT ON TABLE gold.player_weekly_actuals IS 'Actual weekly fantasy points by player';
+COMMENT ON TABLE gold.modifier_backtests IS 'Projection modifier accuracy against actual results';
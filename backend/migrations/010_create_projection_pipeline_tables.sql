@@ -0,0 +1,37 @@
+-- Create projection pipeline tables
+-- Migration: 010_create_projection_pipeline_tables.sql
+
+-- Silver: Injury designations used by the injury discount stage
+CREATE TABLE IF NOT EXISTS silver.player_injury_status (
+    id SERIAL PRIMARY KEY,
+    player_name VARCHAR(255) NOT NULL,
+    team VARCHAR(10),
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL, -- 'QUESTIONABLE', 'DOUBTFUL', 'OUT', 'IR', 'SUSPENSION'
+    source VARCHAR(50) NOT NULL,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(player_name, season, week)
+);
+
+-- Gold: Output of every pipeline stage for every player, for explainability
+CREATE TABLE IF NOT EXISTS gold.projection_stage_outputs (
+    id SERIAL PRIMARY KEY,
+    player_name VARCHAR(255) NOT NULL,
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    stage VARCHAR(50) NOT NULL,
+    stage_order INTEGER NOT NULL,
+    points_ppr DECIMAL(6,2),       -- PPR points after the stage
+    points_standard DECIMAL(6,2),  -- Standard points after the stage
+    details JSONB,                 -- Stage-specific inputs (sources, weights, scoring)
+    adjustment JSONB,              -- Modifier adjustment, if the stage changed the projection
+    computed_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(player_name, season, week, stage)
+);
+
+CREATE INDEX idx_silver_injury_week ON silver.player_injury_status(season, week);
+CREATE INDEX idx_gold_stage_outputs_player ON gold.projection_stage_outputs(player_name, season, week);
+
+COMMENT ON TABLE silver.player_injury_status IS 'Weekly player injury designations';
+COMMENT ON TABLE gold.projection_stage_outputs IS 'Per-stage projection pipeline outputs';
@@ -0,0 +1,24 @@
+-- Create player news tables
+-- Migration: 011_create_player_news_tables.sql
+
+-- Injury designations pulled from ESPN are keyed by ESPN player ID
+ALTER TABLE silver.player_injury_status ADD COLUMN IF NOT EXISTS player_id VARCHAR(50);
+
+-- Silver: Player news blurbs
+CREATE TABLE IF NOT EXISTS silver.player_news (
+    id SERIAL PRIMARY KEY,
+    source VARCHAR(50) NOT NULL,
+    external_id VARCHAR(50) NOT NULL,
+    player_id VARCHAR(50) NOT NULL,
+    headline TEXT NOT NULL,
+    story TEXT,
+    news_source VARCHAR(100), -- Publisher reported by the feed, e.g. 'Rotowire'
+    published_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(source, external_id)
+);
+
+CREATE INDEX idx_silver_injury_player ON silver.player_injury_status(player_id);
+CREATE INDEX idx_silver_news_player ON silver.player_news(player_id, published_at DESC);
+
+COMMENT ON TABLE silver.player_news IS 'Player news blurbs pulled by the player news worker';
@@ -0,0 +1,46 @@
+-- Create projection history and alert tables
+-- Migration: 012_create_projection_alert_tables.sql
+
+-- Gold: Every pipeline run's final projection, so changes can be diffed over time
+CREATE TABLE IF NOT EXISTS gold.projection_history (
+    id BIGSERIAL PRIMARY KEY,
+    player_name VARCHAR(255) NOT NULL,
+    position VARCHAR(10),
+    team VARCHAR(10),
+    week INTEGER NOT NULL,
+    season INTEGER NOT NULL,
+    points_ppr DECIMAL(5,2),
+    points_standard DECIMAL(5,2),
+    num_sources INTEGER,
+    source_points JSONB,  -- PPR points keyed by source
+    adjustments JSONB,    -- Modifier adjustments applied, in stage order
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Players a user wants projection alerts for, beyond their own roster
+CREATE TABLE IF NOT EXISTS user_watchlist (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    player_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, player_name)
+);
+
+-- In-app notifications
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL, -- 'projection_change'
+    title VARCHAR(255) NOT NULL,
+    body TEXT,
+    data JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_gold_proj_history_week ON gold.projection_history(season, week, player_name, recorded_at DESC);
+CREATE INDEX idx_user_watchlist_player ON user_watchlist(player_name);
+CREATE INDEX idx_notifications_user ON notifications(user_id, created_at DESC);
+
+COMMENT ON TABLE gold.projection_history IS 'Final projection from each pipeline run';
+COMMENT ON TABLE user_watchlist IS 'Players each user follows for projection alerts';
+COMMENT ON TABLE notifications IS 'In-app notifications such as projection change alerts';
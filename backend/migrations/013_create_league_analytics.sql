@@ -0,0 +1,18 @@
+-- Create league analytics table
+-- Migration: 013_create_league_analytics.sql
+
+-- Standings, power rankings, records, awards and playoff odds precomputed
+-- weekly for each league
+CREATE TABLE IF NOT EXISTS league_analytics (
+    id SERIAL PRIMARY KEY,
+    league_id VARCHAR(36) NOT NULL,
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    computed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(league_id, season, week)
+);
+
+CREATE INDEX idx_league_analytics_latest ON league_analytics(league_id, computed_at DESC);
+
+COMMENT ON TABLE league_analytics IS 'Weekly league analytics precomputed after Monday night games';
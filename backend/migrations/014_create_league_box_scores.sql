@@ -0,0 +1,29 @@
+-- Create league box scores table
+-- Migration: 014_create_league_box_scores.sql
+
+-- Per-player weekly fantasy output in each league, for projection accuracy
+-- tracking and matchup analytics
+CREATE TABLE IF NOT EXISTS league_box_scores (
+    id BIGSERIAL PRIMARY KEY,
+    league_id VARCHAR(36) NOT NULL,
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    team_id INTEGER NOT NULL,
+    opponent_id INTEGER,  -- NULL on a bye
+    player_id VARCHAR(50) NOT NULL,
+    player_name VARCHAR(255),
+    position VARCHAR(10),
+    nfl_team VARCHAR(10),
+    lineup_slot VARCHAR(10),
+    starter BOOLEAN NOT NULL DEFAULT false,
+    points DECIMAL(6,2),
+    projected_points DECIMAL(6,2),
+    stats JSONB,  -- Raw stats keyed by platform stat ID
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(league_id, season, week, team_id, player_id)
+);
+
+CREATE INDEX idx_league_box_scores_week ON league_box_scores(league_id, season, week);
+CREATE INDEX idx_league_box_scores_player ON league_box_scores(player_id, season, week);
+
+COMMENT ON TABLE league_box_scores IS 'Weekly player fantasy points and stats pulled by the league sync worker';
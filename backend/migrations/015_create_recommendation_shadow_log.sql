@@ -0,0 +1,24 @@
+-- Create recommendation shadow log
+-- Migration: 015_create_recommendation_shadow_log.sql
+
+-- Outputs of the active and shadow recommendation engines for the same
+-- request, for offline comparison of candidate engine versions
+CREATE TABLE IF NOT EXISTS draft_recommendation_shadow_log (
+    id BIGSERIAL PRIMARY KEY,
+    session_id VARCHAR(36) NOT NULL,
+    pick_number INTEGER NOT NULL,
+    active_version VARCHAR(50) NOT NULL,
+    candidate_version VARCHAR(50) NOT NULL,
+    active_output JSONB NOT NULL,
+    candidate_output JSONB,
+    candidate_error TEXT,
+    top_pick_match BOOLEAN NOT NULL DEFAULT false,
+    overlap DECIMAL(4,3),  -- Share of the active top N the candidate also returned
+    active_latency_ms INTEGER,
+    candidate_latency_ms INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_shadow_log_versions ON draft_recommendation_shadow_log(active_version, candidate_version, created_at);
+
+COMMENT ON TABLE draft_recommendation_shadow_log IS 'Active vs candidate recommendation engine outputs from shadow traffic';
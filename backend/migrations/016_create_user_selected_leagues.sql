@@ -0,0 +1,15 @@
+-- Create user selected leagues table
+-- Migration: 016_create_user_selected_leagues.sql
+
+-- Users can connect several leagues on the same platform. Each connected
+-- league is its own row in leagues; this records which one the user is
+-- currently looking at in analytics.
+CREATE TABLE IF NOT EXISTS user_selected_leagues (
+    user_id VARCHAR(36) PRIMARY KEY,
+    league_id VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_selected_leagues_league ON user_selected_leagues(league_id);
+
+COMMENT ON TABLE user_selected_leagues IS 'The league each user has selected for analytics';
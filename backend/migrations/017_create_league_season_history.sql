@@ -0,0 +1,16 @@
+-- Create league season history table
+-- Migration: 017_create_league_season_history.sql
+
+-- Final standings, matchups and draft of a league's past seasons, imported
+-- once since completed seasons do not change
+CREATE TABLE IF NOT EXISTS league_season_history (
+    league_id VARCHAR(36) NOT NULL,
+    season INTEGER NOT NULL,
+    standings JSONB NOT NULL,
+    matchups JSONB NOT NULL,
+    draft_picks JSONB NOT NULL,
+    imported_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (league_id, season)
+);
+
+COMMENT ON TABLE league_season_history IS 'Past season results imported from the league platform';
@@ -0,0 +1,12 @@
+-- Create user analytics consent table
+-- Migration: 018_create_user_analytics_consent.sql
+
+-- Whether each user agreed to product analytics. Users without a row have
+-- not chosen, and ANALYTICS_DEFAULT_CONSENT decides for them.
+CREATE TABLE IF NOT EXISTS user_analytics_consent (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    consented BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE user_analytics_consent IS 'Product analytics consent chosen by each user';
@@ -0,0 +1,77 @@
+-- Create tables for data loaded from nflverse by cmd/ingest
+-- Migration: 019_create_nflverse_tables.sql
+
+-- Silver: Weekly player box score stats
+CREATE TABLE IF NOT EXISTS silver.player_weekly_stats (
+    id SERIAL PRIMARY KEY,
+    player_id VARCHAR(20) NOT NULL, -- nflverse GSIS ID
+    player_name VARCHAR(255) NOT NULL,
+    position VARCHAR(10),
+    team VARCHAR(10),
+    opponent VARCHAR(10),
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    season_type VARCHAR(10) NOT NULL, -- 'REG' or 'POST'
+    completions DECIMAL(5,1),
+    attempts DECIMAL(5,1),
+    passing_yards DECIMAL(6,1),
+    passing_tds DECIMAL(4,1),
+    interceptions DECIMAL(4,1),
+    carries DECIMAL(5,1),
+    rushing_yards DECIMAL(6,1),
+    rushing_tds DECIMAL(4,1),
+    targets DECIMAL(5,1),
+    receptions DECIMAL(5,1),
+    receiving_yards DECIMAL(6,1),
+    receiving_tds DECIMAL(4,1),
+    fantasy_points DECIMAL(6,2),
+    fantasy_points_ppr DECIMAL(6,2),
+    loaded_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(player_id, season, week, season_type)
+);
+
+-- Silver: Snaps played per game
+CREATE TABLE IF NOT EXISTS silver.player_snap_counts (
+    id SERIAL PRIMARY KEY,
+    player_id VARCHAR(20) NOT NULL, -- Pro Football Reference ID
+    player_name VARCHAR(255) NOT NULL,
+    position VARCHAR(10),
+    team VARCHAR(10),
+    opponent VARCHAR(10),
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    game_type VARCHAR(10) NOT NULL,
+    offense_snaps INTEGER,
+    offense_pct DECIMAL(4,3),
+    defense_snaps INTEGER,
+    defense_pct DECIMAL(4,3),
+    special_teams_snaps INTEGER,
+    special_teams_pct DECIMAL(4,3),
+    loaded_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(player_id, season, week, game_type)
+);
+
+-- Silver: Season rosters, linking nflverse IDs to ESPN's
+CREATE TABLE IF NOT EXISTS silver.nfl_rosters (
+    id SERIAL PRIMARY KEY,
+    player_id VARCHAR(20) NOT NULL, -- nflverse GSIS ID
+    espn_id VARCHAR(20),
+    player_name VARCHAR(255) NOT NULL,
+    position VARCHAR(10),
+    team VARCHAR(10),
+    season INTEGER NOT NULL,
+    jersey_number INTEGER,
+    status VARCHAR(20),
+    years_exp INTEGER,
+    loaded_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(player_id, season)
+);
+
+CREATE INDEX idx_silver_weekly_stats_week ON silver.player_weekly_stats(season, week);
+CREATE INDEX idx_silver_weekly_stats_name ON silver.player_weekly_stats(player_name, season);
+CREATE INDEX idx_silver_snap_counts_week ON silver.player_snap_counts(season, week);
+CREATE INDEX idx_silver_rosters_espn ON silver.nfl_rosters(espn_id);
+
+COMMENT ON TABLE silver.player_weekly_stats IS 'Weekly player stats from nflverse';
+COMMENT ON TABLE silver.player_snap_counts IS 'Per-game snap counts from nflverse';
+COMMENT ON TABLE silver.nfl_rosters IS 'Season rosters from nflverse';
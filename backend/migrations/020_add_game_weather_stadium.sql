@@ -0,0 +1,10 @@
+-- Add stadium and kickoff to game weather
+-- Migration: 020_add_game_weather_stadium.sql
+
+-- Forecasts fetched from a weather provider are taken at the home stadium at
+-- kickoff; forecasts loaded from CSV leave both empty
+ALTER TABLE silver.game_weather
+    ADD COLUMN IF NOT EXISTS stadium VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS kickoff_at TIMESTAMP;
+
+COMMENT ON COLUMN silver.game_weather.kickoff_at IS 'Kickoff the forecast is for, UTC';
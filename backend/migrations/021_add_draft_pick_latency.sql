@@ -0,0 +1,12 @@
+-- Add pick latency telemetry to draft picks
+-- Migration: 021_add_draft_pick_latency.sql
+
+-- Milliseconds; NULL for picks recorded before telemetry or without it
+ALTER TABLE draft_picks
+    ADD COLUMN IF NOT EXISTS processing_ms INTEGER,
+    ADD COLUMN IF NOT EXISTS decision_ms INTEGER,
+    ADD COLUMN IF NOT EXISTS recommendation_ms INTEGER;
+
+COMMENT ON COLUMN draft_picks.processing_ms IS 'Server time to record the pick';
+COMMENT ON COLUMN draft_picks.decision_ms IS 'Client-reported time from going on the clock to picking';
+COMMENT ON COLUMN draft_picks.recommendation_ms IS 'Time to compute the recommendations shown before the pick';
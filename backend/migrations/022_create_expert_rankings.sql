@@ -0,0 +1,27 @@
+-- Create expert consensus rank snapshots
+-- Migration: 022_create_expert_rankings.sql
+
+-- Gold: Weekly expert consensus ranks (ECR), one row per player per snapshot,
+-- so the ranks experts published can be compared with our projections later
+CREATE TABLE IF NOT EXISTS gold.expert_rankings (
+    id BIGSERIAL PRIMARY KEY,
+    player_name VARCHAR(255) NOT NULL,
+    position VARCHAR(10) NOT NULL,
+    team VARCHAR(10),
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    source VARCHAR(50) NOT NULL, -- 'fantasypros'
+    rank INTEGER NOT NULL,
+    position_rank INTEGER,
+    best_rank INTEGER,
+    worst_rank INTEGER,
+    avg_rank DECIMAL(6,2),
+    std_dev DECIMAL(6,2),
+    snapshot_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (player_name, season, week, source, snapshot_at)
+);
+
+CREATE INDEX idx_gold_expert_rankings_week ON gold.expert_rankings(season, week, position, snapshot_at DESC);
+CREATE INDEX idx_gold_expert_rankings_player ON gold.expert_rankings(LOWER(player_name), season);
+
+COMMENT ON TABLE gold.expert_rankings IS 'Weekly expert consensus rank snapshots';
@@ -0,0 +1,37 @@
+-- Create league roster tables
+-- Migration: 023_create_league_rosters.sql
+
+-- Players seen on any roster in a league, with their latest platform details
+CREATE TABLE IF NOT EXISTS league_players (
+    league_id VARCHAR(36) NOT NULL,
+    player_id VARCHAR(50) NOT NULL,
+    player_name VARCHAR(255),
+    position VARCHAR(10),
+    nfl_team VARCHAR(10),
+    injury_status VARCHAR(30),
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (league_id, player_id)
+);
+
+-- Each team's roster per week. A week's rows are replaced on every sync, so
+-- earlier weeks keep the roster as it was when the week was last synced.
+CREATE TABLE IF NOT EXISTS league_rosters (
+    id BIGSERIAL PRIMARY KEY,
+    league_id VARCHAR(36) NOT NULL,
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    team_id INTEGER NOT NULL,
+    player_id VARCHAR(50) NOT NULL,
+    lineup_slot VARCHAR(10),
+    acquired_at TIMESTAMP WITH TIME ZONE,
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(league_id, season, week, team_id, player_id)
+);
+
+CREATE INDEX idx_league_rosters_week ON league_rosters(league_id, season, week);
+CREATE INDEX idx_league_rosters_team ON league_rosters(league_id, team_id, season, week);
+CREATE INDEX idx_league_rosters_player ON league_rosters(league_id, player_id);
+
+COMMENT ON TABLE league_players IS 'Rostered players per league with their latest team, position and injury status';
+COMMENT ON TABLE league_rosters IS 'Weekly team rosters pulled by the league sync worker';
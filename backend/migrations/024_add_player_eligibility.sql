@@ -0,0 +1,24 @@
+-- Track position eligibility of rostered players
+-- Migration: 024_add_player_eligibility.sql
+
+ALTER TABLE league_players ADD COLUMN IF NOT EXISTS eligible_positions TEXT[];
+
+-- Positions a rostered player gained or lost during the season, e.g. a
+-- receiver made TE eligible
+CREATE TABLE IF NOT EXISTS player_eligibility_changes (
+    id BIGSERIAL PRIMARY KEY,
+    league_id VARCHAR(36) NOT NULL,
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    team_id INTEGER NOT NULL,
+    player_id VARCHAR(50) NOT NULL,
+    player_name VARCHAR(255),
+    added TEXT[] NOT NULL DEFAULT '{}',
+    removed TEXT[] NOT NULL DEFAULT '{}',
+    positions TEXT[] NOT NULL DEFAULT '{}',
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_player_eligibility_changes_league ON player_eligibility_changes(league_id, season, detected_at DESC);
+
+COMMENT ON TABLE player_eligibility_changes IS 'In-season position eligibility changes seen by the league sync worker';
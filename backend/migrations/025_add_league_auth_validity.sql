@@ -0,0 +1,7 @@
+-- Track stored credentials the platform has rejected
+-- Migration: 025_add_league_auth_validity.sql
+
+-- Set when the platform rejects the stored cookies, cleared when the user
+-- replaces them. Syncs skip users whose credentials are invalid.
+ALTER TABLE league_auth ADD COLUMN IF NOT EXISTS invalid_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE league_auth ADD COLUMN IF NOT EXISTS invalid_reason TEXT;
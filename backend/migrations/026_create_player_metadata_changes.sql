@@ -0,0 +1,24 @@
+-- Record player metadata refreshes for the data quality dashboard
+-- Migration: 026_create_player_metadata_changes.sql
+
+ALTER TABLE data_sync_logs ADD COLUMN IF NOT EXISTS season INTEGER;
+
+-- Each refresh is logged in data_sync_logs with sync_type 'player_metadata';
+-- the team, status and position changes it found are kept here
+CREATE TABLE IF NOT EXISTS player_metadata_changes (
+    id BIGSERIAL PRIMARY KEY,
+    sync_log_id UUID NOT NULL REFERENCES data_sync_logs(id) ON DELETE CASCADE,
+    player_id VARCHAR(20) NOT NULL, -- nflverse GSIS ID
+    espn_id VARCHAR(20),
+    player_name VARCHAR(255) NOT NULL,
+    season INTEGER NOT NULL,
+    field VARCHAR(20) NOT NULL,
+    old_value VARCHAR(50),
+    new_value VARCHAR(50),
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_player_metadata_changes_sync_log ON player_metadata_changes(sync_log_id);
+CREATE INDEX idx_player_metadata_changes_player ON player_metadata_changes(player_id, detected_at DESC);
+
+COMMENT ON TABLE player_metadata_changes IS 'Player team, status and position changes found by metadata refreshes';
@@ -0,0 +1,28 @@
+-- Create preseason draft rankings and ADP snapshots
+-- Migration: 027_create_draft_rankings.sql
+
+-- Gold: Draft rankings and average draft position per source and scoring
+-- format, one row per player per snapshot. ESPN rows carry ESPN's ranks and
+-- ADP; FantasyPros rows carry the expert consensus rank and ADP across the
+-- draft sites it tracks.
+CREATE TABLE IF NOT EXISTS gold.draft_rankings (
+    id BIGSERIAL PRIMARY KEY,
+    source VARCHAR(50) NOT NULL, -- 'espn' or 'fantasypros'
+    scoring_type VARCHAR(20) NOT NULL, -- 'STANDARD', 'HALF_PPR' or 'PPR'
+    season INTEGER NOT NULL,
+    player_id VARCHAR(50), -- the source's player ID
+    espn_id VARCHAR(20),
+    player_name VARCHAR(255) NOT NULL,
+    position VARCHAR(10) NOT NULL,
+    team VARCHAR(10),
+    rank INTEGER,
+    position_rank INTEGER,
+    tier INTEGER,
+    adp DECIMAL(6,2),
+    snapshot_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (source, scoring_type, season, player_name, position, snapshot_at)
+);
+
+CREATE INDEX idx_gold_draft_rankings_latest ON gold.draft_rankings(source, scoring_type, season, snapshot_at DESC);
+
+COMMENT ON TABLE gold.draft_rankings IS 'Preseason draft rankings and ADP snapshots by source and scoring format';
@@ -0,0 +1,26 @@
+-- Track suspensions, holdouts and PUP stints apart from injuries
+-- Migration: 028_create_player_availability.sql
+
+-- Silver: Non-injury reasons a player will miss games. Suspensions and PUP
+-- stints cover a known run of weeks starting at start_week; holdouts and
+-- indefinite suspensions have no weeks until their length is known.
+CREATE TABLE IF NOT EXISTS silver.player_availability (
+    id BIGSERIAL PRIMARY KEY,
+    season INTEGER NOT NULL,
+    player_id VARCHAR(50), -- ESPN player ID when known
+    player_name VARCHAR(255) NOT NULL,
+    position VARCHAR(10),
+    team VARCHAR(10),
+    status VARCHAR(20) NOT NULL, -- 'SUSPENDED', 'HOLDOUT' or 'PUP'
+    start_week INTEGER NOT NULL DEFAULT 1,
+    weeks INTEGER, -- NULL while the length is unknown
+    notes TEXT,
+    source VARCHAR(50) NOT NULL DEFAULT 'manual',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (season, player_name, status)
+);
+
+CREATE INDEX idx_silver_player_availability_season ON silver.player_availability(season);
+CREATE INDEX idx_silver_player_availability_player ON silver.player_availability(player_id);
+
+COMMENT ON TABLE silver.player_availability IS 'Suspensions, holdouts and PUP stints, kept apart from injury designations';
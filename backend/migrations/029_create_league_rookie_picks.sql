@@ -0,0 +1,19 @@
+-- Track who owns each team's future rookie draft picks in dynasty leagues
+-- Migration: 029_create_league_rookie_picks.sql
+
+-- A row exists only for picks that have changed hands; every other pick is
+-- owned by the team it originally belongs to. Team IDs are the platform's.
+CREATE TABLE IF NOT EXISTS league_rookie_picks (
+    league_id UUID NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+    season INTEGER NOT NULL,
+    round INTEGER NOT NULL CHECK (round >= 1),
+    original_team_id INTEGER NOT NULL,
+    owner_team_id INTEGER NOT NULL,
+    notes TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (league_id, season, round, original_team_id)
+);
+
+CREATE INDEX idx_league_rookie_picks_owner ON league_rookie_picks(league_id, owner_team_id);
+
+COMMENT ON TABLE league_rookie_picks IS 'Future rookie draft picks that have been traded away from their original team';
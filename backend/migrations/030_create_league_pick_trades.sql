@@ -0,0 +1,27 @@
+-- Ledger of traded rookie draft picks
+-- Migration: 030_create_league_pick_trades.sql
+
+-- Every change of a pick's owner, synced from platform trades or entered by
+-- hand. league_rookie_picks holds the resulting current owner.
+CREATE TABLE IF NOT EXISTS league_pick_trades (
+    id BIGSERIAL PRIMARY KEY,
+    league_id UUID NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+    season INTEGER NOT NULL,
+    round INTEGER NOT NULL CHECK (round >= 1),
+    original_team_id INTEGER NOT NULL,
+    from_team_id INTEGER NOT NULL,
+    to_team_id INTEGER NOT NULL,
+    source VARCHAR(20) NOT NULL, -- 'transaction' or 'commissioner'
+    transaction_id VARCHAR(100), -- The platform's transaction, for synced trades
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    notes TEXT,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_league_pick_trades_league ON league_pick_trades(league_id, season, recorded_at);
+-- A synced trade is recorded once however often transactions are synced
+CREATE UNIQUE INDEX idx_league_pick_trades_transaction
+    ON league_pick_trades(league_id, transaction_id, season, round, original_team_id)
+    WHERE transaction_id IS NOT NULL;
+
+COMMENT ON TABLE league_pick_trades IS 'Each time a rookie draft pick changed hands, by trade sync or commissioner edit';
@@ -0,0 +1,18 @@
+-- Social sign-in identities linked to user accounts
+-- Migration: 031_create_user_identities.sql
+
+-- One row per provider account a user signs in with. Users created through
+-- social sign-in have no usable password.
+CREATE TABLE IF NOT EXISTS user_identities (
+    provider VARCHAR(20) NOT NULL, -- 'google'
+    subject VARCHAR(255) NOT NULL, -- The provider's stable user ID
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL, -- Email the provider reported when linked
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user ON user_identities(user_id);
+
+COMMENT ON TABLE user_identities IS 'Google and other social sign-in accounts linked to users';
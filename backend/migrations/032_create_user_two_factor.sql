@@ -0,0 +1,26 @@
+-- TOTP two-factor authentication
+-- Migration: 032_create_user_two_factor.sql
+
+-- A user's authenticator secret, encrypted with ENCRYPTION_KEY. The row
+-- exists from enrollment; two-factor is on once enabled_at is set.
+CREATE TABLE IF NOT EXISTS user_two_factor (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret_encrypted TEXT NOT NULL,
+    enabled_at TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- Codes from this step or earlier are refused
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Single-use recovery codes, stored as SHA-256 hashes
+CREATE TABLE IF NOT EXISTS user_backup_codes (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+COMMENT ON TABLE user_two_factor IS 'TOTP secrets and lockout state for two-factor sign-in';
+COMMENT ON TABLE user_backup_codes IS 'Hashed one-time recovery codes for two-factor sign-in';
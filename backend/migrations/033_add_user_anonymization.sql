@@ -0,0 +1,30 @@
+-- Anonymization of inactive accounts
+-- Migration: 033_add_user_anonymization.sql
+
+-- inactivity_warned_at is when the user was told their account would be
+-- anonymized; logging in again cancels it. anonymized_at marks accounts
+-- whose personal data has been removed.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS inactivity_warned_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_users_last_active
+    ON users (COALESCE(last_login_at, created_at))
+    WHERE anonymized_at IS NULL;
+
+-- One row per run of the inactive account job, for admins
+CREATE TABLE IF NOT EXISTS inactivity_runs (
+    id UUID PRIMARY KEY,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    inactive_after_days INTEGER NOT NULL,
+    warning_days INTEGER NOT NULL,
+    reactivated INTEGER NOT NULL DEFAULT 0,
+    warned INTEGER NOT NULL DEFAULT 0,
+    anonymized INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    errors JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_inactivity_runs_started_at ON inactivity_runs(started_at DESC);
+
+COMMENT ON TABLE inactivity_runs IS 'Reports from the job that warns and anonymizes inactive accounts';
@@ -0,0 +1,13 @@
+-- Signed-in sessions
+-- Migration: 034_add_refresh_token_sessions.sql
+
+-- Each refresh token row is a session: refreshing replaces its token in
+-- place, so the row ID stays the same for the life of the sign-in. The
+-- device it was signed in from is kept so users can tell sessions apart.
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS user_agent TEXT;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS ip_address INET;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE refresh_tokens SET last_used_at = created_at WHERE last_used_at IS NULL;
+
+COMMENT ON COLUMN refresh_tokens.last_used_at IS 'When the session last signed in or refreshed';
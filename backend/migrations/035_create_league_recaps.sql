@@ -0,0 +1,18 @@
+-- Create league recaps table
+-- Migration: 035_create_league_recaps.sql
+
+-- Weekly recap narratives built alongside league analytics, for creators
+-- and email digests to render
+CREATE TABLE IF NOT EXISTS league_recaps (
+    id SERIAL PRIMARY KEY,
+    league_id VARCHAR(36) NOT NULL,
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    computed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(league_id, season, week)
+);
+
+CREATE INDEX idx_league_recaps_latest ON league_recaps(league_id, computed_at DESC);
+
+COMMENT ON TABLE league_recaps IS 'Weekly league recaps precomputed after Monday night games';
@@ -0,0 +1,19 @@
+-- Create voice devices table
+-- Migration: 036_create_voice_devices.sql
+
+-- Voice assistants linked to a user's account. Each one holds a device
+-- token naming its row, which stops working once the row is revoked.
+CREATE TABLE IF NOT EXISTS voice_devices (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_voice_devices_user ON voice_devices(user_id) WHERE revoked_at IS NULL;
+
+COMMENT ON TABLE voice_devices IS 'Alexa and Google Assistant devices linked for hands-free drafting';
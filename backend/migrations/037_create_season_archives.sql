@@ -0,0 +1,34 @@
+-- Create season archive tables
+-- Migration: 037_create_season_archives.sql
+
+-- Detail tables of a league's completed season exported to object storage
+-- as Parquet. rehydrated_at is set while the detail has been loaded back.
+CREATE TABLE IF NOT EXISTS league_season_archives (
+    league_id VARCHAR(36) NOT NULL,
+    season INTEGER NOT NULL,
+    table_name VARCHAR(50) NOT NULL,
+    object_key TEXT NOT NULL,
+    row_count BIGINT NOT NULL,
+    byte_size BIGINT NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rehydrated_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (league_id, season, table_name)
+);
+
+-- Each team's week in an archived season, kept in place of its box scores
+CREATE TABLE IF NOT EXISTS league_weekly_summaries (
+    league_id VARCHAR(36) NOT NULL,
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    team_id INTEGER NOT NULL,
+    opponent_id INTEGER,  -- NULL on a bye
+    points DECIMAL(7,2),  -- Starters' points
+    projected_points DECIMAL(7,2),
+    players INTEGER NOT NULL,
+    PRIMARY KEY (league_id, season, week, team_id)
+);
+
+CREATE INDEX idx_league_season_archives_rehydrated ON league_season_archives(rehydrated_at) WHERE rehydrated_at IS NOT NULL;
+
+COMMENT ON TABLE league_season_archives IS 'Completed season detail moved to object storage, per league, season and table';
+COMMENT ON TABLE league_weekly_summaries IS 'Weekly team results kept after a season''s box scores are archived';
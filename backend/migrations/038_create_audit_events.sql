@@ -0,0 +1,21 @@
+-- Create security audit events table
+-- Migration: 038_create_audit_events.sql
+
+-- Authentication events, including failed logins. user_id has no foreign
+-- key so an account's events, and its deletion, outlive the account;
+-- failed logins to unknown emails have no user.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY,
+    user_id UUID,
+    event VARCHAR(50) NOT NULL,
+    details JSONB,
+    ip_address INET,
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_events_user ON audit_events(user_id, created_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX idx_audit_events_created_at ON audit_events(created_at);
+CREATE INDEX idx_audit_events_failed_ip ON audit_events(ip_address, created_at) WHERE event = 'login_failed';
+
+COMMENT ON TABLE audit_events IS 'Security log of logins, password changes, token refreshes, credential changes and account deletions';
@@ -0,0 +1,25 @@
+-- Create game-context feature store
+-- Migration: 039_create_team_game_context.sql
+
+-- Silver: each team's rest, travel and time zone change per game, computed
+-- from silver.nfl_schedule whenever the schedule is ingested
+CREATE TABLE IF NOT EXISTS silver.team_game_context (
+    season INTEGER NOT NULL,
+    week INTEGER NOT NULL,
+    team VARCHAR(10) NOT NULL,
+    opponent VARCHAR(10) NOT NULL,
+    is_home BOOLEAN NOT NULL,
+    kickoff_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    rest_days INTEGER NOT NULL DEFAULT 0,
+    opponent_rest_days INTEGER NOT NULL DEFAULT 0,
+    short_week BOOLEAN NOT NULL DEFAULT FALSE,
+    thursday_game BOOLEAN NOT NULL DEFAULT FALSE,
+    travel_miles DOUBLE PRECISION NOT NULL DEFAULT 0,
+    timezone_shift INTEGER NOT NULL DEFAULT 0,
+    computed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (season, week, team)
+);
+
+COMMENT ON TABLE silver.team_game_context IS 'Game-context features per team and game: days of rest, short weeks, Thursday games, travel distance and time zone shift';
+COMMENT ON COLUMN silver.team_game_context.rest_days IS 'Days since the team''s previous game; 0 for its first game of the season';
+COMMENT ON COLUMN silver.team_game_context.timezone_shift IS 'Venue UTC offset minus the team''s home offset at kickoff, in hours; positive is eastward';
@@ -0,0 +1,8 @@
+-- Remember-me sessions
+-- Migration: 040_add_refresh_token_remember_me.sql
+
+-- Sessions signed in with remember me get the longer refresh lifetime, kept
+-- each time the token is rotated
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS remember_me BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN refresh_tokens.remember_me IS 'Whether the session refreshes with the remember-me lifetime';
@@ -0,0 +1,27 @@
+-- League and org roles for authorization
+-- Migration: 041_create_user_roles.sql
+
+-- Users other than a league's owner who may see it (member) or also sync and
+-- edit it (commissioner)
+CREATE TABLE IF NOT EXISTS league_user_roles (
+    league_id UUID NOT NULL REFERENCES leagues(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    granted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (league_id, user_id),
+    CONSTRAINT valid_league_role CHECK (role IN ('member', 'commissioner'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_league_user_roles_user_id ON league_user_roles(user_id);
+
+-- Staff roles across the service: support sees users' leagues and drafts and
+-- the operator reports, admin also changes them and runs operator jobs
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    granted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT valid_org_role CHECK (role IN ('support', 'admin'))
+);
+
+COMMENT ON TABLE league_user_roles IS 'Roles users hold in leagues they do not own';
+COMMENT ON TABLE user_roles IS 'Staff roles users hold across the service';
@@ -0,0 +1,41 @@
+-- Devices and networks users sign in from, for new sign-in alerts
+-- Migration: 042_create_known_devices.sql
+
+-- Kinds of device by browser and operating system, without versions
+CREATE TABLE IF NOT EXISTS user_known_devices (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(255) NOT NULL,
+    browser VARCHAR(50) NOT NULL,
+    os VARCHAR(50) NOT NULL,
+    mobile BOOLEAN NOT NULL DEFAULT FALSE,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, fingerprint)
+);
+
+-- The /24 (IPv4) or /48 (IPv6) networks sign-ins came from
+CREATE TABLE IF NOT EXISTS user_known_networks (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    network CIDR NOT NULL,
+    last_ip_address INET,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, network)
+);
+
+-- Sign-ins from a new device or network waiting on an emailed code
+CREATE TABLE IF NOT EXISTS login_verifications (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    remember_me BOOLEAN NOT NULL DEFAULT FALSE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_verifications_expires_at ON login_verifications(expires_at);
+
+COMMENT ON TABLE user_known_devices IS 'Kinds of device users have signed in from';
+COMMENT ON TABLE user_known_networks IS 'Networks users have signed in from';
+COMMENT ON TABLE login_verifications IS 'Sign-ins from new devices waiting on an emailed code';
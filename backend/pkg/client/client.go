@@ -0,0 +1,328 @@
+// Package client is a Go SDK for the NFL Analytics API. It handles JSON
+// encoding, bearer tokens and refreshing expired access tokens, and returns
+// failures as *APIError with a stable ErrorCode.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	onRefresh    func(AuthResponse)
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTokens starts the client with tokens from a previous session
+func WithTokens(accessToken, refreshToken string) Option {
+	return func(c *Client) {
+		c.accessToken = accessToken
+		c.refreshToken = refreshToken
+	}
+}
+
+// WithRefreshCallback is called after tokens are refreshed so callers can
+// persist them
+func WithRefreshCallback(fn func(AuthResponse)) Option {
+	return func(c *Client) {
+		c.onRefresh = fn
+	}
+}
+
+// New creates a client for the API at baseURL, e.g. http://localhost:8080
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Tokens returns the current access and refresh tokens
+func (c *Client) Tokens() (accessToken, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accessToken, c.refreshToken
+}
+
+// Register creates an account and stores its tokens
+func (c *Client) Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error) {
+	var resp AuthResponse
+	if err := c.do(ctx, http.MethodPost, "/api/auth/register", nil, req, &resp, false); err != nil {
+		return nil, err
+	}
+	c.setTokens(resp)
+	return &resp, nil
+}
+
+// Login signs in and stores the tokens
+func (c *Client) Login(ctx context.Context, email, password string) (*AuthResponse, error) {
+	body := map[string]string{"email": email, "password": password}
+
+	var resp AuthResponse
+	if err := c.do(ctx, http.MethodPost, "/api/auth/login", nil, body, &resp, false); err != nil {
+		return nil, err
+	}
+	c.setTokens(resp)
+	return &resp, nil
+}
+
+// Refresh exchanges the refresh token for new tokens. Protected calls do
+// this automatically when the access token has expired.
+func (c *Client) Refresh(ctx context.Context) (*AuthResponse, error) {
+	_, refreshToken := c.Tokens()
+	if refreshToken == "" {
+		return nil, ErrNotAuthenticated
+	}
+
+	body := map[string]string{"refresh_token": refreshToken}
+
+	var resp AuthResponse
+	if err := c.do(ctx, http.MethodPost, "/api/auth/refresh", nil, body, &resp, false); err != nil {
+		return nil, err
+	}
+	c.setTokens(resp)
+
+	c.mu.Lock()
+	onRefresh := c.onRefresh
+	c.mu.Unlock()
+	if onRefresh != nil {
+		onRefresh(resp)
+	}
+
+	return &resp, nil
+}
+
+// Logout revokes the session and forgets the tokens
+func (c *Client) Logout(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodPost, "/api/auth/logout", nil, nil, nil, true); err != nil {
+		return err
+	}
+	c.setTokens(AuthResponse{})
+	return nil
+}
+
+// GetProjections lists consensus projections for a week
+func (c *Client) GetProjections(ctx context.Context, q ProjectionsQuery) (*ProjectionsResponse, error) {
+	query := url.Values{}
+	if q.Season > 0 {
+		query.Set("season", strconv.Itoa(q.Season))
+	}
+	if q.Week > 0 {
+		query.Set("week", strconv.Itoa(q.Week))
+	}
+	if q.Position != "" {
+		query.Set("position", q.Position)
+	}
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	var resp ProjectionsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/projections", query, nil, &resp, false); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetPlayerProjection returns one player's projection for a week
+func (c *Client) GetPlayerProjection(ctx context.Context, playerName string, season, week int) (*Projection, error) {
+	query := url.Values{}
+	if season > 0 {
+		query.Set("season", strconv.Itoa(season))
+	}
+	if week > 0 {
+		query.Set("week", strconv.Itoa(week))
+	}
+
+	var resp Projection
+	if err := c.do(ctx, http.MethodGet, "/api/projections/player/"+url.PathEscape(playerName), query, nil, &resp, false); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ConnectESPN connects an ESPN league
+func (c *Client) ConnectESPN(ctx context.Context, req ConnectESPNRequest) (*ConnectESPNResponse, error) {
+	var resp ConnectESPNResponse
+	if err := c.do(ctx, http.MethodPost, "/api/leagues/espn/connect", nil, req, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListLeagues returns the user's connected leagues
+func (c *Client) ListLeagues(ctx context.Context) ([]League, error) {
+	var resp LeaguesResponse
+	if err := c.do(ctx, http.MethodGet, "/api/leagues", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return resp.Leagues, nil
+}
+
+// GetSelectedLeague returns the league analytics default to
+func (c *Client) GetSelectedLeague(ctx context.Context) (*League, error) {
+	var resp League
+	if err := c.do(ctx, http.MethodGet, "/api/leagues/selected", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SelectLeague makes a connected league the selected one
+func (c *Client) SelectLeague(ctx context.Context, leagueID string) (*League, error) {
+	var resp League
+	if err := c.do(ctx, http.MethodPut, "/api/leagues/"+url.PathEscape(leagueID)+"/select", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetLeagueAnalytics returns a league's precomputed analytics
+func (c *Client) GetLeagueAnalytics(ctx context.Context, leagueID string) (*LeagueAnalytics, error) {
+	var resp LeagueAnalytics
+	if err := c.do(ctx, http.MethodGet, "/api/leagues/"+url.PathEscape(leagueID)+"/analytics", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetNotifications returns the user's notifications
+func (c *Client) GetNotifications(ctx context.Context, unreadOnly bool, limit int) ([]Notification, error) {
+	query := url.Values{}
+	if unreadOnly {
+		query.Set("unread", "true")
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var resp NotificationsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/notifications", query, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return resp.Notifications, nil
+}
+
+func (c *Client) setTokens(resp AuthResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = resp.AccessToken
+	c.refreshToken = resp.RefreshToken
+}
+
+// do sends a request and decodes the response into out. Authenticated
+// requests that get a 401 refresh the tokens once and retry.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}, authenticated bool) error {
+	err := c.send(ctx, method, path, query, body, out, authenticated)
+	if !authenticated || !IsCode(err, CodeUnauthorized) {
+		return err
+	}
+
+	if _, refreshToken := c.Tokens(); refreshToken == "" {
+		return err
+	}
+	if _, refreshErr := c.Refresh(ctx); refreshErr != nil {
+		return err
+	}
+
+	return c.send(ctx, method, path, query, body, out, authenticated)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body, out interface{}, authenticated bool) error {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authenticated {
+		accessToken, _ := c.Tokens()
+		if accessToken == "" {
+			return ErrNotAuthenticated
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return decodeError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// decodeError builds an APIError from the API's {"error": "..."} body
+func decodeError(resp *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Code:       codeForStatus(resp.StatusCode),
+		Message:    http.StatusText(resp.StatusCode),
+	}
+
+	var body struct {
+		Error string `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(data, &body) == nil && body.Error != "" {
+		apiErr.Message = body.Error
+	}
+
+	return apiErr
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RefreshesExpiredToken(t *testing.T) {
+	var refreshed AuthResponse
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/auth/refresh":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["refresh_token"] != "refresh-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(AuthResponse{AccessToken: "access-2", RefreshToken: "refresh-2"})
+		case "/api/leagues":
+			if r.Header.Get("Authorization") != "Bearer access-2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid token"})
+				return
+			}
+			json.NewEncoder(w).Encode(LeaguesResponse{Leagues: []League{{ID: "league-1", IsSelected: true}}, Count: 1})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL,
+		WithTokens("access-1", "refresh-1"),
+		WithRefreshCallback(func(resp AuthResponse) { refreshed = resp }),
+	)
+
+	leagues, err := c.ListLeagues(context.Background())
+	require.NoError(t, err)
+	require.Len(t, leagues, 1)
+	assert.Equal(t, "league-1", leagues[0].ID)
+
+	access, refresh := c.Tokens()
+	assert.Equal(t, "access-2", access)
+	assert.Equal(t, "refresh-2", refresh)
+	assert.Equal(t, "access-2", refreshed.AccessToken)
+}
+
+func TestClient_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/leagues/missing/analytics":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "League not found"})
+		case "/api/auth/login":
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid email or password"})
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := New(server.URL, WithTokens("access", ""))
+
+	_, err := c.GetLeagueAnalytics(ctx, "missing")
+	assert.True(t, IsCode(err, CodeNotFound))
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "League not found", apiErr.Message)
+
+	_, err = c.Login(ctx, "user@example.com", "wrong")
+	assert.True(t, IsCode(err, CodeUnauthorized))
+
+	_, err = c.GetProjections(ctx, ProjectionsQuery{Week: 1})
+	assert.True(t, IsCode(err, CodeUpstream))
+
+	_, err = New(server.URL).ListLeagues(ctx)
+	assert.ErrorIs(t, err, ErrNotAuthenticated)
+}
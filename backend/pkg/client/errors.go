@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrorCode classifies API errors so callers can branch without parsing
+// messages
+type ErrorCode string
+
+// Error codes returned in APIError.Code
+const (
+	CodeInvalidRequest ErrorCode = "invalid_request"
+	CodeUnauthorized   ErrorCode = "unauthorized"
+	CodeForbidden      ErrorCode = "forbidden"
+	CodeNotFound       ErrorCode = "not_found"
+	CodeConflict       ErrorCode = "conflict"
+	CodeRateLimited    ErrorCode = "rate_limited"
+	CodeUpstream       ErrorCode = "upstream_error"
+	CodeUnavailable    ErrorCode = "unavailable"
+	CodeInternal       ErrorCode = "internal"
+	CodeUnknown        ErrorCode = "unknown"
+)
+
+// ErrNotAuthenticated is returned when calling a protected endpoint before
+// logging in or setting tokens
+var ErrNotAuthenticated = errors.New("client is not authenticated")
+
+// APIError is a non-2xx response from the API
+type APIError struct {
+	StatusCode int
+	Code       ErrorCode
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d (%s): %s", e.StatusCode, e.Code, e.Message)
+}
+
+// IsCode reports whether err is an APIError with the given code
+func IsCode(err error, code ErrorCode) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// codeForStatus maps an HTTP status to an error code
+func codeForStatus(status int) ErrorCode {
+	switch {
+	case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+		return CodeInvalidRequest
+	case status == http.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == http.StatusForbidden:
+		return CodeForbidden
+	case status == http.StatusNotFound:
+		return CodeNotFound
+	case status == http.StatusConflict:
+		return CodeConflict
+	case status == http.StatusTooManyRequests:
+		return CodeRateLimited
+	case status == http.StatusBadGateway, status == http.StatusGatewayTimeout:
+		return CodeUpstream
+	case status == http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case status >= 500:
+		return CodeInternal
+	default:
+		return CodeUnknown
+	}
+}
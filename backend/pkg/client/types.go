@@ -0,0 +1,147 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RegisterRequest creates an account
+type RegisterRequest struct {
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	CaptchaToken string `json:"captcha_token,omitempty"`
+}
+
+// User is the account returned by auth endpoints
+type User struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	IsActive  bool   `json:"is_active"`
+}
+
+// AuthResponse is returned by register, login and refresh
+type AuthResponse struct {
+	User         *User  `json:"user"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ProjectionsQuery filters GET /api/projections. Zero values use the API
+// defaults.
+type ProjectionsQuery struct {
+	Season   int
+	Week     int
+	Position string
+	Limit    int
+}
+
+// Projection is a player's consensus projection for a week
+type Projection struct {
+	PlayerName            string       `json:"player_name"`
+	Position              *string      `json:"position"`
+	Team                  *string      `json:"team"`
+	ConsensusPPR          float64      `json:"consensus_ppr"`
+	ConsensusStandard     float64      `json:"consensus_standard"`
+	FloorPPR              float64      `json:"floor_ppr"`
+	CeilingPPR            float64      `json:"ceiling_ppr"`
+	PassingYards          *float64     `json:"passing_yards"`
+	PassingTDs            *float64     `json:"passing_tds"`
+	RushingYards          *float64     `json:"rushing_yards"`
+	RushingTDs            *float64     `json:"rushing_tds"`
+	ReceivingYards        *float64     `json:"receiving_yards"`
+	ReceivingTDs          *float64     `json:"receiving_tds"`
+	Receptions            *float64     `json:"receptions"`
+	NumSources            int          `json:"num_sources"`
+	ProjectionStdDev      *float64     `json:"projection_std_dev"`
+	ConfidenceRating      string       `json:"confidence_rating"`
+	HasProps              bool         `json:"has_props"`
+	BaseConsensusPPR      float64      `json:"base_consensus_ppr"`
+	BaseConsensusStandard float64      `json:"base_consensus_standard"`
+	Adjustments           []Adjustment `json:"adjustments"`
+}
+
+// Adjustment is one pipeline modifier applied to a projection
+type Adjustment struct {
+	Modifier   string  `json:"modifier"`
+	Multiplier float64 `json:"multiplier"`
+	PointsPPR  float64 `json:"points_ppr"`
+	Reason     string  `json:"reason"`
+}
+
+// ProjectionsResponse is the body of GET /api/projections
+type ProjectionsResponse struct {
+	Projections []Projection `json:"projections"`
+	Season      int          `json:"season"`
+	Week        int          `json:"week"`
+	Count       int          `json:"count"`
+}
+
+// League is a league the user has connected
+type League struct {
+	ID          string          `json:"id"`
+	Platform    string          `json:"platform"`
+	ExternalID  string          `json:"external_id"`
+	Name        string          `json:"name"`
+	Season      int             `json:"season"`
+	ScoringType string          `json:"scoring_type"`
+	Settings    json.RawMessage `json:"settings"`
+	IsActive    bool            `json:"is_active"`
+	IsSelected  bool            `json:"is_selected"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// LeaguesResponse is the body of GET /api/leagues
+type LeaguesResponse struct {
+	Leagues []League `json:"leagues"`
+	Count   int      `json:"count"`
+}
+
+// ConnectESPNRequest connects an ESPN league with the user's cookies
+type ConnectESPNRequest struct {
+	LeagueID string `json:"league_id"`
+	SWID     string `json:"swid"`
+	EspnS2   string `json:"espn_s2"`
+}
+
+// ConnectESPNResponse is the body of POST /api/leagues/espn/connect
+type ConnectESPNResponse struct {
+	Message       string `json:"message"`
+	LeagueID      string `json:"league_id"`
+	LeagueName    string `json:"league_name"`
+	ScoringFormat string `json:"scoring_format"`
+	League        League `json:"league"`
+}
+
+// LeagueAnalytics is the precomputed weekly analytics for a league. The
+// sections are left raw so new fields do not need an SDK release.
+type LeagueAnalytics struct {
+	LeagueID      string          `json:"league_id"`
+	Season        int             `json:"season"`
+	Week          int             `json:"week"`
+	Standings     json.RawMessage `json:"standings"`
+	PowerRankings json.RawMessage `json:"power_rankings"`
+	Records       json.RawMessage `json:"records"`
+	Awards        json.RawMessage `json:"awards"`
+	PlayoffOdds   json.RawMessage `json:"playoff_odds"`
+	ComputedAt    time.Time       `json:"computed_at"`
+}
+
+// Notification is an in-app alert
+type Notification struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// NotificationsResponse is the body of GET /api/notifications
+type NotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+	Count         int            `json:"count"`
+}
@@ -0,0 +1,48 @@
+import createClient, { type Middleware } from "openapi-fetch";
+import type { components, paths } from "./schema";
+
+export type { components, paths };
+
+type Schemas = components["schemas"];
+
+export type AuthResponse = Schemas["AuthResponse"];
+export type User = Schemas["User"];
+export type RegisterRequest = Schemas["RegisterRequest"];
+export type LoginRequest = Schemas["LoginRequest"];
+export type ProjectionResponse = Schemas["ProjectionResponse"];
+export type ProjectionsResponse = Schemas["ProjectionsResponse"];
+export type League = Schemas["League"];
+export type LeagueAnalytics = Schemas["LeagueAnalytics"];
+export type DraftRecommendation = Schemas["DraftRecommendation"];
+export type Notification = Schemas["Notification"];
+export type ApiError = Schemas["Error"];
+
+export interface ApiClientOptions {
+  /** API origin, e.g. http://localhost:8080 */
+  baseUrl: string;
+  /** Returns the current access token, if the user is logged in */
+  getAccessToken?: () => string | undefined;
+}
+
+/**
+ * Creates a typed client. Paths, parameters and bodies are checked against
+ * the OpenAPI spec, e.g. `client.GET("/api/leagues/{id}/analytics", { params: { path: { id } } })`.
+ */
+export function createApiClient({ baseUrl, getAccessToken }: ApiClientOptions) {
+  const client = createClient<paths>({ baseUrl });
+
+  if (getAccessToken) {
+    const auth: Middleware = {
+      onRequest({ request }) {
+        const token = getAccessToken();
+        if (token) {
+          request.headers.set("Authorization", `Bearer ${token}`);
+        }
+        return request;
+      },
+    };
+    client.use(auth);
+  }
+
+  return client;
+}